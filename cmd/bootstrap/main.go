@@ -17,6 +17,7 @@ import (
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/kademlia"
 	"storj.io/storj/pkg/process"
+	"storj.io/storj/pkg/relay"
 	"storj.io/storj/pkg/server"
 )
 
@@ -27,6 +28,7 @@ type Bootstrap struct {
 
 	Server   server.Config
 	Kademlia kademlia.BootstrapConfig
+	Relay    relay.Config
 }
 
 var (
@@ -77,7 +79,7 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 	if err := process.InitMetricsWithCertPath(ctx, nil, cfg.Identity.CertPath); err != nil {
 		zap.S().Errorf("Failed to initialize telemetry batcher: %+v", err)
 	}
-	return cfg.Server.Run(ctx, nil, cfg.Kademlia)
+	return cfg.Server.Run(ctx, nil, cfg.Kademlia, cfg.Relay)
 }
 
 func cmdSetup(cmd *cobra.Command, args []string) (err error) {
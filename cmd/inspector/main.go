@@ -12,13 +12,17 @@ import (
 	"io"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gogo/protobuf/jsonpb"
 	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/spf13/cobra"
 	"github.com/zeebo/errs"
 
 	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/process"
 	"storj.io/storj/pkg/provider"
@@ -91,6 +95,65 @@ var (
 		Short: "dump all nodes in the routing table",
 		RunE:  DumpNodes,
 	}
+	routingTableCmd = &cobra.Command{
+		Use:   "routing-table",
+		Short: "dump the k buckets with when their nodes last answered and their ping round trips",
+		RunE:  RoutingTable,
+	}
+	routingTableFlags struct {
+		json bool
+	}
+	routingHealthCmd = &cobra.Command{
+		Use:   "health",
+		Short: "show the statistics of the k buckets and the health score of the routing table",
+		RunE:  RoutingHealth,
+	}
+	nodeEventsCmd = &cobra.Command{
+		Use:   "node-events [node_id]",
+		Short: "list lifecycle events of all nodes or of a single node",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  NodeEvents,
+	}
+	nodeVettingCmd = &cobra.Command{
+		Use:   "node-vetting <node_id>",
+		Short: "show the vetting state of a node together with the statistics it's based on",
+		Args:  cobra.ExactArgs(1),
+		RunE:  NodeVetting,
+	}
+	setNodeTagsCmd = &cobra.Command{
+		Use:   "set-node-tags <node_id> [name=value,...]",
+		Short: "set the operator tags of a node, which replace the tags the node signed, no tags remove them",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE:  SetNodeTags,
+	}
+	drainCmd = &cobra.Command{
+		Use:   "drain [node_id...]",
+		Short: "drain nodes and the nodes of regions, their pieces are repaired to other nodes",
+		RunE:  Drain,
+	}
+	drainFlags struct {
+		regions string
+		reason  string
+	}
+	drainStatusCmd = &cobra.Command{
+		Use:   "drain-status",
+		Short: "show the progress of the draining nodes",
+		Args:  cobra.NoArgs,
+		RunE:  DrainStatus,
+	}
+	explainSelectionCmd = &cobra.Command{
+		Use:   "explain-selection [excluded_node_id...]",
+		Short: "show which storage node selection filter excludes each node",
+		RunE:  ExplainSelection,
+	}
+	explainSelectionFlags struct {
+		freeBandwidth int64
+		freeDisk      int64
+		limit         int32
+		tags          string
+		excludedTags  string
+		pageToken     string
+	}
 	getStatsCmd = &cobra.Command{
 		Use:   "getstats <node_id>",
 		Short: "Get node stats",
@@ -121,10 +184,11 @@ var (
 
 // Inspector gives access to kademlia and overlay cache
 type Inspector struct {
-	identity      *provider.FullIdentity
-	kadclient     pb.KadInspectorClient
-	overlayclient pb.OverlayInspectorClient
-	statdbclient  pb.StatDBInspectorClient
+	identity         *provider.FullIdentity
+	kadclient        pb.KadInspectorClient
+	overlayclient    pb.OverlayInspectorClient
+	statdbclient     pb.StatDBInspectorClient
+	rebalancerclient pb.RebalancerInspectorClient
 }
 
 // NewInspector creates a new gRPC inspector server for access to kad
@@ -147,10 +211,11 @@ func NewInspector(address, path string) (*Inspector, error) {
 	}
 
 	return &Inspector{
-		identity:      identity,
-		kadclient:     pb.NewKadInspectorClient(conn),
-		overlayclient: pb.NewOverlayInspectorClient(conn),
-		statdbclient:  pb.NewStatDBInspectorClient(conn),
+		identity:         identity,
+		kadclient:        pb.NewKadInspectorClient(conn),
+		overlayclient:    pb.NewOverlayInspectorClient(conn),
+		statdbclient:     pb.NewStatDBInspectorClient(conn),
+		rebalancerclient: pb.NewRebalancerInspectorClient(conn),
 	}, nil
 }
 
@@ -264,6 +329,248 @@ func DumpNodes(cmd *cobra.Command, args []string) (err error) {
 	return nil
 }
 
+// RoutingTable outputs the k buckets of the routing table with the
+// connectivity of their nodes
+func RoutingTable(cmd *cobra.Command, args []string) (err error) {
+	i, err := NewInspector(*Addr, *IdentityPath)
+	if err != nil {
+		return ErrInspectorDial.Wrap(err)
+	}
+
+	res, err := i.kadclient.DumpRoutingTable(context.Background(), &pb.DumpRoutingTableRequest{})
+	if err != nil {
+		return ErrRequest.Wrap(err)
+	}
+
+	if routingTableFlags.json {
+		fmt.Println(prettyPrint(res))
+		return nil
+	}
+
+	fmt.Printf("self: %s %s\n", res.GetSelf().Id, res.GetSelf().GetAddress().GetAddress())
+	for _, bucket := range res.Buckets {
+		updated, _ := ptypes.Timestamp(bucket.LastUpdated)
+		fmt.Printf("\nbucket %s, updated %s, %d nodes, %d replacements\n", bucket.Id, updated.Format(time.RFC3339), len(bucket.Nodes), len(bucket.Replacements))
+		printRoutingTableNodes("", bucket.Nodes)
+		printRoutingTableNodes("replacement ", bucket.Replacements)
+	}
+	return nil
+}
+
+// printRoutingTableNodes prints one line per node, prefixed with kind
+func printRoutingTableNodes(kind string, nodes []*pb.RoutingTableNode) {
+	for _, node := range nodes {
+		lastSeen := "never"
+		if node.LastSeen != nil {
+			seen, _ := ptypes.Timestamp(node.LastSeen)
+			lastSeen = seen.Format(time.RFC3339)
+		}
+		fmt.Printf("  %s%s\t%s\tlast seen %s\trtt %s (avg %s of %d pings)\n", kind,
+			node.Node.Id, node.Node.GetAddress().GetAddress(), lastSeen,
+			time.Duration(node.LastRttNs), time.Duration(node.AverageRttNs), node.Pings)
+	}
+}
+
+// RoutingHealth outputs the health score of the routing table and the
+// statistics of its k buckets
+func RoutingHealth(cmd *cobra.Command, args []string) (err error) {
+	i, err := NewInspector(*Addr, *IdentityPath)
+	if err != nil {
+		return ErrInspectorDial.Wrap(err)
+	}
+
+	res, err := i.kadclient.RoutingHealth(context.Background(), &pb.RoutingHealthRequest{})
+	if err != nil {
+		return ErrRequest.Wrap(err)
+	}
+
+	fmt.Printf("health score %.2f of %d buckets\n\n", res.Score, len(res.Buckets))
+	fmt.Println("bucket\tscore\tnodes\tfill\tfresh\tunseen\tavg last seen\tchurn\treplacements")
+	for _, bucket := range res.Buckets {
+		fmt.Printf("%s\t%.2f\t%d\t%.2f\t%d\t%d\t%s\t%d\t%d\n", bucket.Id,
+			bucket.Score, bucket.Nodes, bucket.Fill, bucket.Fresh, bucket.Unseen,
+			time.Duration(bucket.AverageLastSeenAgeNs).Round(time.Second), bucket.Churn, bucket.Replacements)
+	}
+	return nil
+}
+
+// NodeEvents outputs the recorded lifecycle events of nodes
+func NodeEvents(cmd *cobra.Command, args []string) (err error) {
+	i, err := NewInspector(*Addr, *IdentityPath)
+	if err != nil {
+		return ErrInspectorDial.Wrap(err)
+	}
+
+	req := &pb.NodeEventsRequest{}
+	if len(args) > 0 {
+		req.NodeId, err = storj.NodeIDFromString(args[0])
+		if err != nil {
+			return ErrArgs.Wrap(err)
+		}
+	}
+
+	for {
+		res, err := i.overlayclient.NodeEvents(context.Background(), req)
+		if err != nil {
+			return ErrRequest.Wrap(err)
+		}
+
+		for _, event := range res.Events {
+			fmt.Println(prettyPrint(event))
+		}
+
+		if !res.More {
+			return nil
+		}
+		req.PageToken = res.NextPageToken
+	}
+}
+
+// NodeVetting outputs the vetting state of a node
+func NodeVetting(cmd *cobra.Command, args []string) (err error) {
+	i, err := NewInspector(*Addr, *IdentityPath)
+	if err != nil {
+		return ErrInspectorDial.Wrap(err)
+	}
+
+	nodeID, err := storj.NodeIDFromString(args[0])
+	if err != nil {
+		return ErrArgs.Wrap(err)
+	}
+
+	res, err := i.overlayclient.NodeVetting(context.Background(), &pb.NodeVettingRequest{NodeId: nodeID})
+	if err != nil {
+		return ErrRequest.Wrap(err)
+	}
+
+	fmt.Println(prettyPrint(res.Vetting))
+	return nil
+}
+
+// SetNodeTags sets the operator tags of a node
+func SetNodeTags(cmd *cobra.Command, args []string) (err error) {
+	i, err := NewInspector(*Addr, *IdentityPath)
+	if err != nil {
+		return ErrInspectorDial.Wrap(err)
+	}
+
+	req := &pb.SetNodeTagsRequest{}
+	req.NodeId, err = storj.NodeIDFromString(args[0])
+	if err != nil {
+		return ErrArgs.Wrap(err)
+	}
+	if len(args) > 1 {
+		req.Tags, err = overlay.ParseTags(args[1])
+		if err != nil {
+			return ErrArgs.Wrap(err)
+		}
+	}
+
+	res, err := i.overlayclient.SetNodeTags(context.Background(), req)
+	if err != nil {
+		return ErrRequest.Wrap(err)
+	}
+
+	fmt.Println(prettyPrint(res))
+	return nil
+}
+
+// ExplainSelection outputs the selection filter result of each node in the overlay cache
+func ExplainSelection(cmd *cobra.Command, args []string) (err error) {
+	i, err := NewInspector(*Addr, *IdentityPath)
+	if err != nil {
+		return ErrInspectorDial.Wrap(err)
+	}
+
+	req := &pb.ExplainSelectionRequest{
+		Restrictions: &pb.NodeRestrictions{
+			FreeBandwidth: explainSelectionFlags.freeBandwidth,
+			FreeDisk:      explainSelectionFlags.freeDisk,
+		},
+		Limit:     explainSelectionFlags.limit,
+		PageToken: explainSelectionFlags.pageToken,
+	}
+	req.Tags, err = overlay.ParseTags(explainSelectionFlags.tags)
+	if err != nil {
+		return ErrArgs.Wrap(err)
+	}
+	req.ExcludedTags, err = overlay.ParseTags(explainSelectionFlags.excludedTags)
+	if err != nil {
+		return ErrArgs.Wrap(err)
+	}
+	for _, arg := range args {
+		id, err := storj.NodeIDFromString(arg)
+		if err != nil {
+			return ErrArgs.Wrap(err)
+		}
+		req.ExcludedNodes = append(req.ExcludedNodes, id)
+	}
+
+	res, err := i.overlayclient.ExplainSelection(context.Background(), req)
+	if err != nil {
+		return ErrRequest.Wrap(err)
+	}
+
+	for _, node := range res.Nodes {
+		fmt.Printf("%s\t%s\t%s\t%s\n", node.NodeId, node.Address, node.Result, node.Detail)
+	}
+	fmt.Printf("eligible nodes: %d\n", res.Eligible)
+	if res.More {
+		fmt.Printf("more nodes exist, explain them with --page-token %s\n", res.NextPageToken)
+	}
+	return nil
+}
+
+// Drain drains the nodes and the nodes of the regions
+func Drain(cmd *cobra.Command, args []string) (err error) {
+	i, err := NewInspector(*Addr, *IdentityPath)
+	if err != nil {
+		return ErrInspectorDial.Wrap(err)
+	}
+
+	req := &pb.DrainRequest{Reason: drainFlags.reason}
+	for _, arg := range args {
+		nodeID, err := storj.NodeIDFromString(arg)
+		if err != nil {
+			return ErrArgs.Wrap(err)
+		}
+		req.NodeIds = append(req.NodeIds, nodeID)
+	}
+	if drainFlags.regions != "" {
+		req.Regions = strings.Split(drainFlags.regions, ",")
+	}
+	if len(req.NodeIds) == 0 && len(req.Regions) == 0 {
+		return ErrArgs.New("no nodes or regions to drain")
+	}
+
+	res, err := i.rebalancerclient.Drain(context.Background(), req)
+	if err != nil {
+		return ErrRequest.Wrap(err)
+	}
+
+	fmt.Printf("Drained %d nodes\n", len(res.Drained))
+	for _, nodeID := range res.Drained {
+		fmt.Println(nodeID)
+	}
+	return nil
+}
+
+// DrainStatus outputs the progress of the draining nodes
+func DrainStatus(cmd *cobra.Command, args []string) (err error) {
+	i, err := NewInspector(*Addr, *IdentityPath)
+	if err != nil {
+		return ErrInspectorDial.Wrap(err)
+	}
+
+	res, err := i.rebalancerclient.DrainStatus(context.Background(), &pb.DrainStatusRequest{})
+	if err != nil {
+		return ErrRequest.Wrap(err)
+	}
+
+	fmt.Println(prettyPrint(res))
+	return nil
+}
+
 func prettyPrint(unformatted proto.Message) string {
 	m := jsonpb.Marshaler{Indent: "  ", EmitDefaults: true}
 	formatted, err := m.MarshalToString(unformatted)
@@ -477,6 +784,24 @@ func init() {
 	kadCmd.AddCommand(pingNodeCmd)
 	kadCmd.AddCommand(lookupNodeCmd)
 	kadCmd.AddCommand(dumpNodesCmd)
+	kadCmd.AddCommand(routingTableCmd)
+	kadCmd.AddCommand(routingHealthCmd)
+	kadCmd.AddCommand(nodeEventsCmd)
+	kadCmd.AddCommand(nodeVettingCmd)
+	kadCmd.AddCommand(setNodeTagsCmd)
+	kadCmd.AddCommand(explainSelectionCmd)
+	kadCmd.AddCommand(drainCmd)
+	kadCmd.AddCommand(drainStatusCmd)
+
+	routingTableCmd.Flags().BoolVar(&routingTableFlags.json, "json", false, "print the routing table as json")
+	drainCmd.Flags().StringVar(&drainFlags.regions, "regions", "", "comma separated regions whose nodes are drained, now and when they join")
+	drainCmd.Flags().StringVar(&drainFlags.reason, "reason", "", "the reason recorded with the node events")
+	explainSelectionCmd.Flags().Int64Var(&explainSelectionFlags.freeBandwidth, "free-bandwidth", 0, "required free bandwidth in bytes")
+	explainSelectionCmd.Flags().Int64Var(&explainSelectionFlags.freeDisk, "free-disk", 0, "required free disk space in bytes")
+	explainSelectionCmd.Flags().Int32Var(&explainSelectionFlags.limit, "limit", 0, "maximum number of nodes to explain, 0 uses the server default")
+	explainSelectionCmd.Flags().StringVar(&explainSelectionFlags.tags, "tags", "", "comma separated name=value tags the nodes must have, a name without a value matches any value")
+	explainSelectionCmd.Flags().StringVar(&explainSelectionFlags.excludedTags, "excluded-tags", "", "comma separated name=value tags the nodes must not have, a name without a value matches any value")
+	explainSelectionCmd.Flags().StringVar(&explainSelectionFlags.pageToken, "page-token", "", "continue explaining after the nodes of a previous run")
 
 	statsCmd.AddCommand(getStatsCmd)
 	statsCmd.AddCommand(getCSVStatsCmd)
@@ -29,6 +29,7 @@ var (
 	jsonPrint bool
 
 	prefix     string
+	namePrefix string
 	endBefore  string
 	startAfter string
 	recursive  bool
@@ -53,6 +54,7 @@ var (
 
 func main() {
 	cmdList.Flags().StringVarP(&prefix, "prefix", "x", "", "bucket prefix")
+	cmdList.Flags().StringVarP(&namePrefix, "namePrefix", "n", "", "only list paths starting with prefix/namePrefix")
 	cmdList.Flags().StringVarP(&endBefore, "endBefore", "e", "", "end before path")
 	cmdList.Flags().StringVarP(&startAfter, "startAfter", "s", "", "start after path")
 	cmdList.Flags().BoolVarP(&recursive, "recursive", "r", true, "recursively list")
@@ -77,7 +79,7 @@ func listPointers(cmd *cobra.Command, args []string) {
 		fmt.Println("Error", err)
 		os.Exit(1)
 	}
-	items, more, err := client.List(ctx, prefix, startAfter, endBefore, recursive, limit, metaFlags)
+	items, more, err := client.ListWithNamePrefix(ctx, prefix, namePrefix, startAfter, endBefore, recursive, limit, metaFlags)
 	if err != nil {
 		fmt.Println("Error", err)
 		os.Exit(1)
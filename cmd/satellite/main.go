@@ -18,6 +18,8 @@ import (
 	"go.uber.org/zap"
 
 	"storj.io/storj/internal/fpath"
+	"storj.io/storj/pkg/accounting/archive"
+	"storj.io/storj/pkg/accounting/retention"
 	"storj.io/storj/pkg/accounting/rollup"
 	"storj.io/storj/pkg/accounting/tally"
 	"storj.io/storj/pkg/audit"
@@ -29,6 +31,7 @@ import (
 	"storj.io/storj/pkg/discovery"
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/kademlia"
+	"storj.io/storj/pkg/lease"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/payments"
 	"storj.io/storj/pkg/pb"
@@ -55,10 +58,13 @@ type Satellite struct {
 	BwAgreement bwagreement.Config
 	Discovery   discovery.Config
 	Database    string `help:"satellite database connection string" default:"sqlite3://$CONFDIR/master.db"`
+	OverlayNext string `help:"database connection string the overlay cache is migrated to: nodes are written to it as well and read from both databases, logging where they diverge" default:""`
 	StatDB      statdb.Config
 	Tally       tally.Config
 	Rollup      rollup.Config
+	Retention   retention.Config
 	Payments    payments.Config
+	Leases      lease.Config
 }
 
 var (
@@ -87,9 +93,21 @@ var (
 		Short: "Repair Queue Diagnostic Tool support",
 		RunE:  cmdQDiag,
 	}
+	archiveImportCmd = &cobra.Command{
+		Use:   "archive-import [archive...]",
+		Short: "Import archived raw tallies and rollups from files or URLs for analysis",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  cmdArchiveImport,
+	}
+	verifyConfigCmd = &cobra.Command{
+		Use:   "verify-config",
+		Short: "Check the satellite configuration and report every problem found",
+		RunE:  cmdVerifyConfig,
+	}
 
-	runCfg   Satellite
-	setupCfg Satellite
+	runCfg    Satellite
+	setupCfg  Satellite
+	verifyCfg Satellite
 
 	diagCfg struct {
 		Database string `help:"satellite database connection string" default:"sqlite3://$CONFDIR/master.db"`
@@ -98,6 +116,9 @@ var (
 		Database   string `help:"satellite database connection string" default:"sqlite3://$CONFDIR/master.db"`
 		QListLimit int    `help:"maximum segments that can be requested" default:"1000"`
 	}
+	archiveImportCfg struct {
+		Database string `help:"database connection string the archives are imported into" default:"sqlite3://$CONFDIR/archive.db"`
+	}
 
 	defaultConfDir string
 	confDir        *string
@@ -117,10 +138,15 @@ func init() {
 	rootCmd.AddCommand(setupCmd)
 	rootCmd.AddCommand(diagCmd)
 	rootCmd.AddCommand(qdiagCmd)
+	rootCmd.AddCommand(archiveImportCmd)
+	rootCmd.AddCommand(verifyConfigCmd)
 	cfgstruct.Bind(runCmd.Flags(), &runCfg, cfgstruct.ConfDir(defaultConfDir))
 	cfgstruct.BindSetup(setupCmd.Flags(), &setupCfg, cfgstruct.ConfDir(defaultConfDir))
 	cfgstruct.Bind(diagCmd.Flags(), &diagCfg, cfgstruct.ConfDir(defaultConfDir))
 	cfgstruct.Bind(qdiagCmd.Flags(), &qdiagCfg, cfgstruct.ConfDir(defaultConfDir))
+	cfgstruct.Bind(archiveImportCmd.Flags(), &archiveImportCfg, cfgstruct.ConfDir(defaultConfDir))
+	cfgstruct.Bind(verifyConfigCmd.Flags(), &verifyCfg, cfgstruct.ConfDir(defaultConfDir))
+	verifyConfigJSON = verifyConfigCmd.Flags().Bool("json", false, "print the report as json")
 }
 
 func cmdRun(cmd *cobra.Command, args []string) (err error) {
@@ -131,6 +157,14 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 		return errs.New("Error starting master database on satellite: %+v", err)
 	}
 
+	if runCfg.OverlayNext != "" {
+		next, err := satellitedb.New(runCfg.OverlayNext)
+		if err != nil {
+			return errs.New("Error starting overlay migration database on satellite: %+v", err)
+		}
+		database = satellitedb.NewOverlayDualRead(zap.L().Named("overlay:dualread"), database, next)
+	}
+
 	err = database.CreateTables()
 	if err != nil {
 		return errs.New("Error creating tables for master database on satellite: %+v", err)
@@ -142,9 +176,22 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 	//nolint ignoring context rules to not create cyclic dependency, will be removed later
 	ctx = context.WithValue(ctx, "masterdb", database)
 
+	if runCfg.Leases.Holder != "" {
+		ctx = lease.WithCoordinator(ctx, lease.NewCoordinator(zap.L().Named("leases"), database.Leases(), runCfg.Leases))
+
+		// replicas don't see the pointers changed by each other
+		if runCfg.PointerDB.PointerCacheSize > 0 {
+			zap.S().Warn("Pointer cache disabled, it can't be kept consistent between replicas")
+			runCfg.PointerDB.PointerCacheSize = 0
+		}
+	}
+
 	return runCfg.Server.Run(
 		ctx,
-		grpcauth.NewAPIKeyInterceptor(),
+		server.CombineInterceptors(
+			grpcauth.NewAPIKeyInterceptor(),
+			overlay.NewRateLimiter(runCfg.Overlay.RateLimit).UnaryInterceptor(),
+		),
 		runCfg.Kademlia,
 		runCfg.Overlay,
 		runCfg.PointerDB,
@@ -156,6 +203,7 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 		runCfg.StatDB,
 		runCfg.Tally,
 		runCfg.Rollup,
+		runCfg.Retention,
 		runCfg.Payments,
 	)
 }
@@ -309,6 +357,33 @@ func cmdQDiag(cmd *cobra.Command, args []string) (err error) {
 	return w.Flush()
 }
 
+func cmdArchiveImport(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	database, err := satellitedb.New(archiveImportCfg.Database)
+	if err != nil {
+		return errs.New("error connecting to archive database: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, database.Close())
+	}()
+	if err := database.CreateTables(); err != nil {
+		return errs.New("error creating tables for archive database: %+v", err)
+	}
+
+	for _, location := range args {
+		imported, err := archive.Load(ctx, location)
+		if err != nil {
+			return err
+		}
+		if err := archive.Import(ctx, database.Accounting(), imported); err != nil {
+			return err
+		}
+		fmt.Printf("imported %d raw tallies and %d rollups from %s\n", len(imported.Raws), len(imported.Rollups), location)
+	}
+	return nil
+}
+
 func main() {
 	process.Exec(rootCmd)
 }
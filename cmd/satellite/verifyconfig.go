@@ -0,0 +1,92 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/configcheck"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/process"
+)
+
+var verifyConfigJSON *bool
+
+func cmdVerifyConfig(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	var report configcheck.Report
+	verifySatellite(ctx, &report, &verifyCfg)
+
+	if *verifyConfigJSON {
+		err = report.PrintJSON(os.Stdout)
+	} else {
+		err = report.Print(os.Stdout)
+	}
+	if err != nil {
+		return err
+	}
+	return report.Err()
+}
+
+// verifySatellite adds the checks of the satellite configuration to report
+func verifySatellite(ctx context.Context, report *configcheck.Report, config *Satellite) {
+	report.Check("identity", configcheck.Identity(config.Server.Identity))
+	report.Check("server.address", configcheck.Listenable(config.Server.Address))
+	report.Check("server.revocation-db-url", configcheck.Database(ctx, config.Server.RevocationDBURL))
+	if config.Server.UsePeerCAWhitelist && config.Server.PeerCAWhitelistPath != "" {
+		_, err := ioutil.ReadFile(config.Server.PeerCAWhitelistPath)
+		report.Check("server.peer-ca-whitelist-path", err)
+	}
+
+	report.Check("database", configcheck.Database(ctx, config.Database))
+	report.Check("pointer-db.database-url", configcheck.Database(ctx, config.PointerDB.DatabaseURL))
+	_, err := pointerdb.ParseUndeleteWindows(config.PointerDB.BucketUndeleteWindows)
+	report.Check("pointer-db.bucket-undelete-windows", err)
+	if config.PointerDB.MinSegmentSize > config.PointerDB.MaxSegmentSize {
+		report.Add("pointer-db.min-segment-size", configcheck.Failed,
+			fmt.Sprintf("%s exceeds pointer-db.max-segment-size %s", config.PointerDB.MinSegmentSize, config.PointerDB.MaxSegmentSize))
+	}
+
+	report.Check("kademlia.db-path", configcheck.Writable(config.Kademlia.DBPath))
+	for _, address := range strings.Split(config.Kademlia.BootstrapAddr, ",") {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+		// bootstrapping is retried, so an unreachable node isn't fatal
+		problem := ""
+		if err := configcheck.Reachable(ctx, address); err != nil {
+			problem = err.Error()
+		}
+		report.Warn("kademlia.bootstrap-addr "+address, problem)
+	}
+	if config.Kademlia.BucketSize < 1 || config.Kademlia.Alpha < 1 {
+		report.Add("kademlia.bucket-size", configcheck.Failed,
+			fmt.Sprintf("bucket size %d and alpha %d must be positive", config.Kademlia.BucketSize, config.Kademlia.Alpha))
+	}
+
+	if config.Overlay.Network.Interval <= 0 {
+		report.Add("overlay.network.interval", configcheck.Failed, "the interval must be positive")
+	}
+	if config.Overlay.Network.Address != "" {
+		report.Check("overlay.network.address", configcheck.Listenable(config.Overlay.Network.Address))
+	}
+
+	_, err = overlay.NewNodeLists(zap.NewNop(), config.Overlay.Node.BlacklistFile, config.Overlay.Node.WhitelistFile, config.Overlay.Node.TagsFile)
+	report.Check("overlay.node.*-file", err)
+
+	if threshold := config.Discovery.Partition.Threshold; threshold <= 0 || threshold > 1 {
+		report.Add("discovery.partition.threshold", configcheck.Failed,
+			fmt.Sprintf("%v isn't a fraction between 0 and 1", threshold))
+	}
+}
@@ -5,6 +5,7 @@ package main
 
 import (
 	"context"
+	"encoding/csv"
 	"fmt"
 	"io"
 	"os"
@@ -13,7 +14,10 @@ import (
 	"regexp"
 	"runtime"
 	"sort"
+	"strconv"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/gogo/protobuf/proto"
@@ -22,6 +26,7 @@ import (
 	"go.uber.org/zap"
 
 	"storj.io/storj/internal/fpath"
+	"storj.io/storj/internal/memory"
 	"storj.io/storj/pkg/cfgstruct"
 	"storj.io/storj/pkg/kademlia"
 	"storj.io/storj/pkg/pb"
@@ -32,6 +37,7 @@ import (
 	"storj.io/storj/pkg/server"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/transport"
+	"storj.io/storj/pkg/utils"
 )
 
 // StorageNode defines storage node configuration
@@ -76,8 +82,41 @@ var (
 		Short: "Display a dashbaord",
 		RunE:  dashCmd,
 	}
-	runCfg   StorageNode
-	setupCfg StorageNode
+	usageCmd = &cobra.Command{
+		Use:   "usage",
+		Short: "Print the hourly usage per satellite as CSV",
+		RunE:  cmdUsage,
+	}
+	retainLogCmd = &cobra.Command{
+		Use:   "retain-log",
+		Short: "Print the signed garbage collection summaries as CSV",
+		RunE:  cmdRetainLog,
+	}
+	auditReceiptsCmd = &cobra.Command{
+		Use:   "audit-receipts",
+		Short: "Print the audit receipts signed by satellites as CSV",
+		RunE:  cmdAuditReceipts,
+	}
+	setAllocatedCmd = &cobra.Command{
+		Use:   "set-allocated <size>",
+		Short: "Change the disk space allocated by the running node, e.g. 2TB",
+		Args:  cobra.ExactArgs(1),
+		RunE:  cmdSetAllocated,
+	}
+	exitCmd = &cobra.Command{
+		Use:   "exit <satellite_id> <satellite_address>",
+		Short: "Announce to a satellite that the node is leaving the network",
+		Args:  cobra.ExactArgs(2),
+		RunE:  cmdExit,
+	}
+	verifyConfigCmd = &cobra.Command{
+		Use:   "verify-config",
+		Short: "Check the storage node configuration and report every problem found",
+		RunE:  cmdVerifyConfig,
+	}
+	runCfg    StorageNode
+	setupCfg  StorageNode
+	verifyCfg StorageNode
 
 	dashboardCfg struct {
 		Address string `default:":28967" help:"address for dashboard service"`
@@ -86,6 +125,32 @@ var (
 	diagCfg struct {
 	}
 
+	usageCfg struct {
+		Address   string        `default:":28967" help:"address of the storage node"`
+		Satellite string        `default:"" help:"id of the satellite to print the usage of, all satellites when empty"`
+		Since     time.Duration `default:"24h" help:"how far back to print the usage"`
+	}
+
+	retainLogCfg struct {
+		Address   string `default:":28967" help:"address of the storage node"`
+		Satellite string `default:"" help:"id of the satellite to print the summaries of, all satellites when empty"`
+		Limit     int    `default:"100" help:"number of the newest summaries to print, 0 prints all"`
+	}
+
+	auditReceiptsCfg struct {
+		Address   string `default:":28967" help:"address of the storage node"`
+		Satellite string `default:"" help:"id of the satellite to print the receipts of, all satellites when empty"`
+		Limit     int    `default:"100" help:"number of the newest receipts to print, 0 prints all"`
+	}
+
+	setAllocatedCfg struct {
+		Address string `default:":28967" help:"address of the storage node"`
+	}
+
+	exitCfg struct {
+		Reason string `default:"" help:"reason for leaving the network, shared with the satellite"`
+	}
+
 	defaultConfDir  string
 	defaultDiagDir  string
 	defaultCredsDir string
@@ -120,11 +185,24 @@ func init() {
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(diagCmd)
 	rootCmd.AddCommand(dashboardCmd)
+	rootCmd.AddCommand(usageCmd)
+	rootCmd.AddCommand(retainLogCmd)
+	rootCmd.AddCommand(auditReceiptsCmd)
+	rootCmd.AddCommand(setAllocatedCmd)
+	rootCmd.AddCommand(exitCmd)
+	rootCmd.AddCommand(verifyConfigCmd)
 	cfgstruct.Bind(runCmd.Flags(), &runCfg, cfgstruct.ConfDir(defaultConfDir))
 	cfgstruct.BindSetup(setupCmd.Flags(), &setupCfg, cfgstruct.ConfDir(defaultConfDir))
 	cfgstruct.BindSetup(configCmd.Flags(), &setupCfg, cfgstruct.ConfDir(defaultConfDir))
 	cfgstruct.Bind(diagCmd.Flags(), &diagCfg, cfgstruct.ConfDir(defaultDiagDir))
 	cfgstruct.Bind(dashboardCmd.Flags(), &dashboardCfg, cfgstruct.ConfDir(defaultDiagDir))
+	cfgstruct.Bind(usageCmd.Flags(), &usageCfg, cfgstruct.ConfDir(defaultDiagDir))
+	cfgstruct.Bind(retainLogCmd.Flags(), &retainLogCfg, cfgstruct.ConfDir(defaultDiagDir))
+	cfgstruct.Bind(auditReceiptsCmd.Flags(), &auditReceiptsCfg, cfgstruct.ConfDir(defaultDiagDir))
+	cfgstruct.Bind(setAllocatedCmd.Flags(), &setAllocatedCfg, cfgstruct.ConfDir(defaultDiagDir))
+	cfgstruct.Bind(exitCmd.Flags(), &exitCfg, cfgstruct.ConfDir(defaultConfDir))
+	cfgstruct.Bind(verifyConfigCmd.Flags(), &verifyCfg, cfgstruct.ConfDir(defaultConfDir))
+	verifyConfigJSON = verifyConfigCmd.Flags().Bool("json", false, "print the report as json")
 }
 
 func cmdRun(cmd *cobra.Command, args []string) (err error) {
@@ -145,6 +223,9 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 	} else {
 		zap.S().Info("Operator wallet: ", operatorConfig.Wallet)
 	}
+	if features := operatorConfig.Features(); len(features) > 0 {
+		zap.S().Info("Operator wallet features: ", strings.Join(features, ", "))
+	}
 	ctx := process.Ctx(cmd)
 	if err := process.InitMetricsWithCertPath(ctx, nil, runCfg.Server.Identity.CertPath); err != nil {
 		zap.S().Error("Failed to initialize telemetry batcher:", err)
@@ -383,6 +464,13 @@ func dashCmd(cmd *cobra.Command, args []string) (err error) {
 
 		fmt.Fprintf(color.Output, "Node Connections: %+v\n", whiteInt(data.GetNodeConnections()))
 
+		if data.GetWallet() != "" {
+			fmt.Fprintf(color.Output, "Wallet: %s\n", color.WhiteString(data.GetWallet()))
+		}
+		if features := data.GetWalletFeatures(); len(features) > 0 {
+			fmt.Fprintf(color.Output, "Wallet Features: %s\n", color.WhiteString(strings.Join(features, ", ")))
+		}
+
 		color.Green("\nIO\t\t\tAvailable\t\t\tUsed\n--\t\t\t---------\t\t\t----")
 		stats := data.GetStats()
 		if stats != nil {
@@ -392,8 +480,247 @@ func dashCmd(cmd *cobra.Command, args []string) (err error) {
 			color.Yellow("Loading...")
 		}
 
+		if scrub := data.GetScrub(); scrub != nil {
+			color.Green("\nScrubber\n--------")
+			fmt.Fprintf(color.Output, "Current Pass		%+v of %+v pieces, %+v bytes\n", whiteInt(scrub.GetPiecesChecked()), whiteInt(scrub.GetPiecesTotal()), whiteInt(scrub.GetBytesChecked()))
+			lastPass := "never"
+			if scrub.GetLastPassUnixSec() > 0 {
+				lastPass = time.Unix(scrub.GetLastPassUnixSec(), 0).Format(time.RFC3339)
+			}
+			fmt.Fprintf(color.Output, "Completed Passes	%+v, last %s\n", whiteInt(scrub.GetPasses()), color.WhiteString(lastPass))
+			corrupted := color.WhiteString("%d", scrub.GetCorrupted())
+			if scrub.GetCorrupted() > 0 {
+				corrupted = color.RedString("%d", scrub.GetCorrupted())
+			}
+			fmt.Fprintf(color.Output, "Corrupted		%s since start, %+v quarantined, %+v not reported yet\n", corrupted, whiteInt(scrub.GetQuarantined()), whiteInt(scrub.GetUnreported()))
+		}
+	}
+
+	return nil
+}
+
+func cmdUsage(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	ident, err := runCfg.Server.Identity.Load()
+	if err != nil {
+		return err
+	}
+
+	var satelliteID storj.NodeID
+	if usageCfg.Satellite != "" {
+		satelliteID, err = storj.NodeIDFromString(usageCfg.Satellite)
+		if err != nil {
+			return err
+		}
+	}
+
+	lc, err := psclient.NewLiteClient(ctx, transport.NewClient(ident), &pb.Node{
+		Address: &pb.NodeAddress{Address: usageCfg.Address},
+		Type:    pb.NodeType_STORAGE,
+	})
+	if err != nil {
+		return err
+	}
+
+	usage, err := lc.Usage(ctx, &pb.UsageRequest{
+		SatelliteId: satelliteID,
+		FromUnixSec: time.Now().Add(-usageCfg.Since).Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(os.Stdout)
+	_ = w.Write([]string{"satellite", "hour", "ingress", "ingress_repair", "egress", "egress_audit", "egress_repair", "disk_used"})
+	for _, satellite := range usage.GetSatellites() {
+		for _, point := range satellite.GetPoints() {
+			_ = w.Write([]string{
+				satellite.SatelliteId.String(),
+				time.Unix(point.HourUnixSec, 0).UTC().Format(time.RFC3339),
+				strconv.FormatInt(point.Ingress, 10),
+				strconv.FormatInt(point.IngressRepair, 10),
+				strconv.FormatInt(point.Egress, 10),
+				strconv.FormatInt(point.EgressAudit, 10),
+				strconv.FormatInt(point.EgressRepair, 10),
+				strconv.FormatInt(point.DiskUsed, 10),
+			})
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func cmdRetainLog(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	ident, err := runCfg.Server.Identity.Load()
+	if err != nil {
+		return err
+	}
+
+	var satelliteID storj.NodeID
+	if retainLogCfg.Satellite != "" {
+		satelliteID, err = storj.NodeIDFromString(retainLogCfg.Satellite)
+		if err != nil {
+			return err
+		}
+	}
+
+	lc, err := psclient.NewLiteClient(ctx, transport.NewClient(ident), &pb.Node{
+		Address: &pb.NodeAddress{Address: retainLogCfg.Address},
+		Type:    pb.NodeType_STORAGE,
+	})
+	if err != nil {
+		return err
+	}
+
+	log, err := lc.RetainLog(ctx, &pb.RetainLogRequest{
+		SatelliteId: satelliteID,
+		Limit:       int32(retainLogCfg.Limit),
+	})
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(os.Stdout)
+	_ = w.Write([]string{"satellite", "filter_created", "processed", "dry_run", "examined", "trashed", "bytes_trashed", "signature"})
+	for _, summary := range log.GetSummaries() {
+		signature := "valid"
+		if err := psserver.VerifyRetainSummary(ident.ID, summary); err != nil {
+			signature = err.Error()
+		}
+		_ = w.Write([]string{
+			summary.SatelliteId.String(),
+			time.Unix(summary.FilterCreatedUnixSec, 0).UTC().Format(time.RFC3339),
+			time.Unix(summary.ProcessedUnixSec, 0).UTC().Format(time.RFC3339),
+			strconv.FormatBool(summary.DryRun),
+			strconv.FormatInt(summary.PiecesExamined, 10),
+			strconv.FormatInt(summary.PiecesTrashed, 10),
+			strconv.FormatInt(summary.BytesTrashed, 10),
+			signature,
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func cmdAuditReceipts(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	ident, err := runCfg.Server.Identity.Load()
+	if err != nil {
+		return err
+	}
+
+	var satelliteID storj.NodeID
+	if auditReceiptsCfg.Satellite != "" {
+		satelliteID, err = storj.NodeIDFromString(auditReceiptsCfg.Satellite)
+		if err != nil {
+			return err
+		}
+	}
+
+	lc, err := psclient.NewLiteClient(ctx, transport.NewClient(ident), &pb.Node{
+		Address: &pb.NodeAddress{Address: auditReceiptsCfg.Address},
+		Type:    pb.NodeType_STORAGE,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := lc.AuditReceipts(ctx, &pb.AuditReceiptsRequest{
+		SatelliteId: satelliteID,
+		Limit:       int32(auditReceiptsCfg.Limit),
+	})
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(os.Stdout)
+	_ = w.Write([]string{"satellite", "audited", "piece", "stripe", "outcome", "signature"})
+	for _, receipt := range resp.GetReceipts() {
+		signature := "valid"
+		if err := psserver.VerifyOwnAuditReceipt(ident.ID, receipt); err != nil {
+			signature = err.Error()
+		}
+		_ = w.Write([]string{
+			receipt.SatelliteId.String(),
+			time.Unix(receipt.AuditedUnixSec, 0).UTC().Format(time.RFC3339),
+			receipt.PieceId,
+			strconv.FormatInt(receipt.StripeIndex, 10),
+			receipt.Outcome.String(),
+			signature,
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func cmdSetAllocated(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	var allocated memory.Size
+	if err := allocated.Set(args[0]); err != nil {
+		return err
+	}
+
+	ident, err := runCfg.Server.Identity.Load()
+	if err != nil {
+		return err
+	}
+
+	lc, err := psclient.NewLiteClient(ctx, transport.NewClient(ident), &pb.Node{
+		Address: &pb.NodeAddress{Address: setAllocatedCfg.Address},
+		Type:    pb.NodeType_STORAGE,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := lc.SetAllocatedSpace(ctx, &pb.SetAllocatedSpaceRequest{Allocated: allocated.Int64()})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Allocated disk space changed from %s to %s, %s used and %s available\n",
+		memory.Size(resp.Previous), allocated, memory.Size(resp.Used), memory.Size(resp.Available))
+	fmt.Println("Update storage.allocated-disk-space in the config to keep the allocation after a restart")
+	return nil
+}
+
+func cmdExit(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	ident, err := runCfg.Server.Identity.Load()
+	if err != nil {
+		zap.S().Fatal(err)
+	} else {
+		zap.S().Info("Node ID: ", ident.ID)
+	}
+
+	satelliteID, err := storj.NodeIDFromString(args[0])
+	if err != nil {
+		return err
+	}
+
+	conn, err := transport.NewClient(ident).DialNode(ctx, &pb.Node{
+		Id:      satelliteID,
+		Address: &pb.NodeAddress{Address: args[1]},
+		Type:    pb.NodeType_SATELLITE,
+	})
+	if err != nil {
+		return err
+	}
+	defer utils.LogClose(conn)
+
+	_, err = pb.NewOverlayClient(conn).AnnounceExit(ctx, &pb.AnnounceExitRequest{Reason: exitCfg.Reason})
+	if err != nil {
+		return err
 	}
 
+	fmt.Println("Exit announced, the satellite no longer stores new pieces on this node and migrates the existing ones.")
+	fmt.Println("Keep the node running until its pieces have been repaired to other nodes.")
 	return nil
 }
 
@@ -0,0 +1,80 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/shirou/gopsutil/disk"
+	"github.com/spf13/cobra"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/pkg/configcheck"
+	"storj.io/storj/pkg/process"
+)
+
+var verifyConfigJSON *bool
+
+func cmdVerifyConfig(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	var report configcheck.Report
+	verifyStorageNode(ctx, &report, &verifyCfg)
+
+	if *verifyConfigJSON {
+		err = report.PrintJSON(os.Stdout)
+	} else {
+		err = report.Print(os.Stdout)
+	}
+	if err != nil {
+		return err
+	}
+	return report.Err()
+}
+
+// verifyStorageNode adds the checks of the storage node configuration to report
+func verifyStorageNode(ctx context.Context, report *configcheck.Report, config *StorageNode) {
+	report.Check("identity", configcheck.Identity(config.Server.Identity))
+	report.Check("server.address", configcheck.Listenable(config.Server.Address))
+	report.Check("server.revocation-db-url", configcheck.Database(ctx, config.Server.RevocationDBURL))
+
+	report.Check("kademlia.db-path", configcheck.Writable(config.Kademlia.DBPath))
+	for _, address := range strings.Split(config.Kademlia.BootstrapAddr, ",") {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+		// bootstrapping is retried, so an unreachable node isn't fatal
+		problem := ""
+		if err := configcheck.Reachable(ctx, address); err != nil {
+			problem = err.Error()
+		}
+		report.Warn("kademlia.bootstrap-addr "+address, problem)
+	}
+	report.Check("kademlia.operator.wallet", isOperatorWalletValid(config.Kademlia.Operator.Wallet))
+	problem := ""
+	if err := isOperatorEmailValid(config.Kademlia.Operator.Email); err != nil {
+		problem = err.Error()
+	}
+	report.Warn("kademlia.operator.email", problem)
+
+	err := configcheck.Writable(config.Storage.Path)
+	report.Check("storage.path", err)
+	if err == nil {
+		// the server shrinks the allocation to the free space on startup
+		usage, err := disk.Usage(config.Storage.Path)
+		switch {
+		case err != nil:
+			report.Check("storage.allocated-disk-space", err)
+		case int64(usage.Free) < config.Storage.AllocatedDiskSpace.Int64():
+			report.Warn("storage.allocated-disk-space", fmt.Sprintf("%s exceeds the %s free on disk",
+				config.Storage.AllocatedDiskSpace, memory.Size(usage.Free)))
+		default:
+			report.Warn("storage.allocated-disk-space", "")
+		}
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Access is a named set of credentials for working against a satellite and project
+type Access struct {
+	SatelliteAddr string `json:"satellite-addr"`
+	APIKey        string `json:"api-key"`
+	EncKey        string `json:"enc-key"`
+}
+
+// Accesses contains all named accesses and the name of the default one
+type Accesses struct {
+	Default  string            `json:"default"`
+	Accesses map[string]Access `json:"accesses"`
+}
+
+var (
+	accessCmd = &cobra.Command{
+		Use:   "access",
+		Short: "Manage named accesses to satellites and projects",
+	}
+
+	accessCreateCfg Access
+)
+
+func init() {
+	CLICmd.AddCommand(accessCmd)
+
+	createCmd := addCmd(&cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a new named access",
+		Args:  cobra.ExactArgs(1),
+		RunE:  accessCreate,
+	}, accessCmd)
+	createCmd.Flags().StringVar(&accessCreateCfg.SatelliteAddr, "satellite-addr", "", "the address to use for the satellite")
+	createCmd.Flags().StringVar(&accessCreateCfg.APIKey, "api-key", "", "the api key to use for the satellite")
+	createCmd.Flags().StringVar(&accessCreateCfg.EncKey, "enc-key", "", "your root encryption key")
+
+	addCmd(&cobra.Command{
+		Use:   "list",
+		Short: "List named accesses",
+		Args:  cobra.NoArgs,
+		RunE:  accessList,
+	}, accessCmd)
+	addCmd(&cobra.Command{
+		Use:     "set-default <name>",
+		Aliases: []string{"switch"},
+		Short:   "Switch the access used when --access is not specified",
+		Args:    cobra.ExactArgs(1),
+		RunE:    accessSetDefault,
+	}, accessCmd)
+	addCmd(&cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a named access",
+		Args:  cobra.ExactArgs(1),
+		RunE:  accessDelete,
+	}, accessCmd)
+}
+
+// LoadAccesses loads the accesses from path, a missing file contains no accesses
+func LoadAccesses(path string) (*Accesses, error) {
+	accesses := &Accesses{Accesses: map[string]Access{}}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return accesses, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, accesses); err != nil {
+		return nil, fmt.Errorf("invalid access file %s: %v", path, err)
+	}
+	if accesses.Accesses == nil {
+		accesses.Accesses = map[string]Access{}
+	}
+	return accesses, nil
+}
+
+// Save writes the accesses to path, readable only by the current user
+func (accesses *Accesses) Save(path string) error {
+	data, err := json.MarshalIndent(accesses, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+// Find returns the access with the specified name, or the default access when name is empty
+func (accesses *Accesses) Find(name string) (access Access, ok bool) {
+	if name == "" {
+		name = accesses.Default
+	}
+	if name == "" {
+		return Access{}, false
+	}
+	access, ok = accesses.Accesses[name]
+	return access, ok
+}
+
+// applyAccess overrides the satellite and credentials with the selected
+// access, except for the ones which were set explicitly with flags
+func (c *Config) applyAccess(flags *pflag.FlagSet) error {
+	accesses, err := LoadAccesses(c.AccessFile)
+	if err != nil {
+		return err
+	}
+
+	access, ok := accesses.Find(c.Access)
+	if !ok {
+		if c.Access != "" {
+			return fmt.Errorf("access %q not found", c.Access)
+		}
+		return nil
+	}
+
+	explicit := func(name string) bool {
+		return flags != nil && flags.Changed(name)
+	}
+
+	if access.SatelliteAddr != "" {
+		if !explicit("client.overlay-addr") {
+			c.Client.OverlayAddr = access.SatelliteAddr
+		}
+		if !explicit("client.pointer-db-addr") {
+			c.Client.PointerDBAddr = access.SatelliteAddr
+		}
+	}
+	if access.APIKey != "" && !explicit("client.api-key") {
+		c.Client.APIKey = access.APIKey
+	}
+	if access.EncKey != "" && !explicit("enc.key") {
+		c.Enc.Key = access.EncKey
+	}
+	return nil
+}
+
+func accessCreate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	accesses, err := LoadAccesses(cfg.AccessFile)
+	if err != nil {
+		return err
+	}
+	if _, exists := accesses.Accesses[name]; exists {
+		return fmt.Errorf("access %q already exists", name)
+	}
+
+	accesses.Accesses[name] = accessCreateCfg
+	if accesses.Default == "" {
+		accesses.Default = name
+	}
+
+	if err := accesses.Save(cfg.AccessFile); err != nil {
+		return err
+	}
+
+	fmt.Printf("Access %s created\n", name)
+	return nil
+}
+
+func accessList(cmd *cobra.Command, args []string) error {
+	accesses, err := LoadAccesses(cfg.AccessFile)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(accesses.Accesses))
+	for name := range accesses.Accesses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		marker := " "
+		if name == accesses.Default {
+			marker = "*"
+		}
+		fmt.Printf("%s %-20s %s\n", marker, name, accesses.Accesses[name].SatelliteAddr)
+	}
+	return nil
+}
+
+func accessSetDefault(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	accesses, err := LoadAccesses(cfg.AccessFile)
+	if err != nil {
+		return err
+	}
+	if _, exists := accesses.Accesses[name]; !exists {
+		return fmt.Errorf("access %q not found", name)
+	}
+
+	accesses.Default = name
+	if err := accesses.Save(cfg.AccessFile); err != nil {
+		return err
+	}
+
+	fmt.Printf("Using access %s by default\n", name)
+	return nil
+}
+
+func accessDelete(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	accesses, err := LoadAccesses(cfg.AccessFile)
+	if err != nil {
+		return err
+	}
+	if _, exists := accesses.Accesses[name]; !exists {
+		return fmt.Errorf("access %q not found", name)
+	}
+
+	delete(accesses.Accesses, name)
+	if accesses.Default == name {
+		accesses.Default = ""
+	}
+
+	if err := accesses.Save(cfg.AccessFile); err != nil {
+		return err
+	}
+
+	fmt.Printf("Access %s deleted\n", name)
+	return nil
+}
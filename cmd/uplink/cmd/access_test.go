@@ -0,0 +1,130 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureStdout returns what fn prints to stdout
+func captureStdout(t *testing.T, fn func() error) (string, error) {
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+
+	stdout := os.Stdout
+	os.Stdout = writer
+	err = fn()
+	os.Stdout = stdout
+
+	require.NoError(t, writer.Close())
+	out, readErr := ioutil.ReadAll(reader)
+	require.NoError(t, readErr)
+	return string(out), err
+}
+
+func TestAccessCommands(t *testing.T) {
+	dir, err := ioutil.TempDir("", "uplink-access")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	saved := cfg
+	defer func() { cfg, accessCreateCfg = saved, Access{} }()
+	cfg.AccessFile = filepath.Join(dir, "accesses.json")
+	cfg.Access = ""
+
+	create := func(name string, access Access) error {
+		accessCreateCfg = access
+		_, err := captureStdout(t, func() error { return accessCreate(nil, []string{name}) })
+		return err
+	}
+	load := func() *Accesses {
+		accesses, err := LoadAccesses(cfg.AccessFile)
+		require.NoError(t, err)
+		return accesses
+	}
+
+	dev := Access{SatelliteAddr: "dev.example.com:7777", APIKey: "dev-key", EncKey: "dev-enc"}
+	prod := Access{SatelliteAddr: "prod.example.com:7777", APIKey: "prod-key", EncKey: "prod-enc"}
+
+	{ // create
+		require.NoError(t, create("dev", dev))
+		require.NoError(t, create("prod", prod))
+		assert.Error(t, create("dev", prod))
+
+		accesses := load()
+		assert.Equal(t, "dev", accesses.Default)
+		assert.Equal(t, map[string]Access{"dev": dev, "prod": prod}, accesses.Accesses)
+	}
+
+	{ // list
+		out, err := captureStdout(t, func() error { return accessList(nil, nil) })
+		require.NoError(t, err)
+		assert.Equal(t, "* dev                  dev.example.com:7777\n"+
+			"  prod                 prod.example.com:7777\n", out)
+	}
+
+	{ // use
+		_, err := captureStdout(t, func() error { return accessSetDefault(nil, []string{"prod"}) })
+		require.NoError(t, err)
+		assert.Equal(t, "prod", load().Default)
+
+		_, err = captureStdout(t, func() error { return accessSetDefault(nil, []string{"missing"}) })
+		assert.Error(t, err)
+
+		// commands run with the default access unless another one is selected
+		var used Config
+		command := addCmd(&cobra.Command{
+			Use: "test",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				used = cfg
+				return nil
+			},
+		}, &cobra.Command{})
+		cfg.AccessFile = filepath.Join(dir, "accesses.json")
+
+		require.NoError(t, command.RunE(command, nil))
+		assert.Equal(t, prod.SatelliteAddr, used.Client.OverlayAddr)
+		assert.Equal(t, prod.SatelliteAddr, used.Client.PointerDBAddr)
+		assert.Equal(t, prod.APIKey, used.Client.APIKey)
+		assert.Equal(t, prod.EncKey, used.Enc.Key)
+
+		cfg.Access = "dev"
+		require.NoError(t, command.RunE(command, nil))
+		assert.Equal(t, dev.SatelliteAddr, used.Client.OverlayAddr)
+		assert.Equal(t, dev.APIKey, used.Client.APIKey)
+		assert.Equal(t, dev.EncKey, used.Enc.Key)
+
+		cfg.Access = "missing"
+		assert.Error(t, command.RunE(command, nil))
+		cfg.Access = ""
+
+		// explicitly passed flags take precedence over the access
+		require.NoError(t, command.Flags().Set("client.pointer-db-addr", "explicit.example.com:7777"))
+		require.NoError(t, command.Flags().Set("enc.key", "explicit-enc"))
+		require.NoError(t, command.RunE(command, nil))
+		assert.Equal(t, prod.SatelliteAddr, used.Client.OverlayAddr)
+		assert.Equal(t, "explicit.example.com:7777", used.Client.PointerDBAddr)
+		assert.Equal(t, prod.APIKey, used.Client.APIKey)
+		assert.Equal(t, "explicit-enc", used.Enc.Key)
+	}
+
+	{ // delete
+		_, err := captureStdout(t, func() error { return accessDelete(nil, []string{"prod"}) })
+		require.NoError(t, err)
+
+		accesses := load()
+		assert.Equal(t, "", accesses.Default)
+		assert.Equal(t, map[string]Access{"dev": dev}, accesses.Accesses)
+
+		_, err = captureStdout(t, func() error { return accessDelete(nil, []string{"prod"}) })
+		assert.Error(t, err)
+	}
+}
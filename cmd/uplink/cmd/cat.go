@@ -4,6 +4,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -13,11 +14,12 @@ import (
 )
 
 func init() {
-	addCmd(&cobra.Command{
+	catCmd := addCmd(&cobra.Command{
 		Use:   "cat",
 		Short: "Copies a Storj object to standard out",
 		RunE:  catMain,
 	}, CLICmd)
+	addDiagnosticsFlag(catCmd)
 }
 
 // catMain is the function executed when catCmd is called
@@ -42,5 +44,7 @@ func catMain(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	return download(ctx, src, dst, false)
+	return diagnose(ctx, "download", []fpath.FPath{src, dst}, func(ctx context.Context) error {
+		return download(ctx, src, dst, false)
+	})
 }
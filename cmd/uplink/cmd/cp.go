@@ -33,6 +33,7 @@ func init() {
 		RunE:  copyMain,
 	}, CLICmd)
 	progress = cpCmd.Flags().Bool("progress", true, "if true, show progress")
+	addDiagnosticsFlag(cpCmd)
 }
 
 // upload transfers src from local machine to s3 compatible object dst
@@ -80,6 +81,9 @@ func upload(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgress
 		RedundancyScheme: cfg.GetRedundancyScheme(),
 		EncryptionScheme: cfg.GetEncryptionScheme(),
 	}
+	if fileInfo.Mode().IsRegular() {
+		createInfo.SizeHint = fileInfo.Size()
+	}
 	obj, err := metainfo.CreateObject(ctx, dst.Bucket(), dst.Path(), &createInfo)
 	if err != nil {
 		return convertError(err, dst)
@@ -223,6 +227,7 @@ func copy(ctx context.Context, src fpath.FPath, dst fpath.FPath) error {
 	}
 
 	createInfo := storj.CreateObject{
+		SizeHint:         readOnlyStream.Info().Size,
 		RedundancyScheme: cfg.GetRedundancyScheme(),
 		EncryptionScheme: cfg.GetEncryptionScheme(),
 	}
@@ -271,16 +276,24 @@ func copyMain(cmd *cobra.Command, args []string) (err error) {
 		return errors.New("At least one of the source or the desination must be a Storj URL")
 	}
 
+	paths := []fpath.FPath{src, dst}
+
 	// if uploading
 	if src.IsLocal() {
-		return upload(ctx, src, dst, *progress)
+		return diagnose(ctx, "upload", paths, func(ctx context.Context) error {
+			return upload(ctx, src, dst, *progress)
+		})
 	}
 
 	// if downloading
 	if dst.IsLocal() {
-		return download(ctx, src, dst, *progress)
+		return diagnose(ctx, "download", paths, func(ctx context.Context) error {
+			return download(ctx, src, dst, *progress)
+		})
 	}
 
 	// if copying from one remote location to another
-	return copy(ctx, src, dst)
+	return diagnose(ctx, "copy", paths, func(ctx context.Context) error {
+		return copy(ctx, src, dst)
+	})
 }
@@ -0,0 +1,332 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+)
+
+const (
+	// defaultDiagnosticsPath is where the bundle is written when
+	// --diagnostics is given without a path
+	defaultDiagnosticsPath = "uplink-diagnostics.json"
+	// maxDiagnosedSatelliteCalls is the number of the last calls to the
+	// satellite kept in a bundle
+	maxDiagnosedSatelliteCalls = 100
+	// redactedText replaces secrets and paths in the bundle
+	redactedText = "[redacted]"
+)
+
+var (
+	diagnosticsPath string
+
+	// diagnosed collects the calls of the command being diagnosed, it's nil
+	// when diagnostics are disabled
+	diagnosed *diagnostics
+)
+
+// addDiagnosticsFlag adds the --diagnostics flag to a transfer command
+func addDiagnosticsFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&diagnosticsPath, "diagnostics", "", "write a redacted diagnostics bundle to attach to bug reports to this path when the transfer fails")
+	cmd.Flags().Lookup("diagnostics").NoOptDefVal = defaultDiagnosticsPath
+}
+
+// diagnostics collects the calls made by a transfer to write them to a
+// bundle when it fails
+type diagnostics struct {
+	started time.Time
+
+	mu    sync.Mutex
+	calls []transport.Call
+}
+
+// observer returns the function observing calls, nil when diagnostics are
+// disabled
+func (diag *diagnostics) observer() func(call transport.Call) {
+	if diag == nil {
+		return nil
+	}
+	return diag.observe
+}
+
+// observe records a call
+func (diag *diagnostics) observe(call transport.Call) {
+	diag.mu.Lock()
+	defer diag.mu.Unlock()
+	diag.calls = append(diag.calls, call)
+}
+
+// diagnose runs the transfer fn, when it fails and diagnostics are enabled
+// a bundle describing the failure is written
+func diagnose(ctx context.Context, operation string, paths []fpath.FPath, fn func(ctx context.Context) error) error {
+	if diagnosticsPath == "" {
+		return fn(ctx)
+	}
+
+	diagnosed = &diagnostics{started: time.Now()}
+	defer func() { diagnosed = nil }()
+
+	err := fn(ctx)
+	if err == nil {
+		return nil
+	}
+
+	bundle := diagnosed.bundle(operation, err, newRedactor(paths))
+	if werr := writeDiagnostics(diagnosticsPath, bundle); werr != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write diagnostics: %v\n", werr)
+	} else {
+		fmt.Fprintf(os.Stderr, "Diagnostics written to %s, please attach them to bug reports\n", diagnosticsPath)
+	}
+	return err
+}
+
+// writeDiagnostics writes the bundle as indented json to path
+func writeDiagnostics(path string, bundle *jsonDiagnostics) error {
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// jsonDiagnostics is the diagnostics bundle of a failed transfer
+type jsonDiagnostics struct {
+	Version      int                     `json:"version"`
+	Operation    string                  `json:"operation"` // upload, download or copy
+	Started      time.Time               `json:"started"`
+	Duration     string                  `json:"duration"`
+	Error        string                  `json:"error"`
+	ErrorClasses []string                `json:"error_classes"`
+	Redundancy   jsonDiagnosedRedundancy `json:"redundancy"`
+	Encryption   jsonDiagnosedEncryption `json:"encryption"`
+	Config       jsonDiagnosedConfig     `json:"config"`
+	Satellite    []jsonDiagnosedCall     `json:"satellite"`
+	Nodes        []jsonDiagnosedNode     `json:"nodes"`
+}
+
+// jsonDiagnosedRedundancy is the redundancy scheme in a diagnostics bundle
+type jsonDiagnosedRedundancy struct {
+	Required int `json:"required"`
+	Repair   int `json:"repair"`
+	Optimal  int `json:"optimal"`
+	Total    int `json:"total"`
+}
+
+// jsonDiagnosedEncryption is the encryption scheme in a diagnostics bundle,
+// without the key
+type jsonDiagnosedEncryption struct {
+	Cipher    storj.Cipher `json:"cipher"`
+	BlockSize int32        `json:"block_size"`
+	PathType  int          `json:"path_type"`
+}
+
+// jsonDiagnosedConfig is the summary of the configuration in a diagnostics
+// bundle, the API key is only reported as set or not
+type jsonDiagnosedConfig struct {
+	OverlayAddr      string `json:"overlay_addr"`
+	PointerDBAddr    string `json:"pointerdb_addr"`
+	APIKeySet        bool   `json:"apikey_set"`
+	Region           string `json:"region,omitempty"`
+	NodeTags         string `json:"node_tags,omitempty"`
+	ExcludedNodeTags string `json:"excluded_node_tags,omitempty"`
+	MaxInlineSize    int64  `json:"max_inline_size"`
+	SegmentSize      int64  `json:"segment_size"`
+	TuneSegmentSize  bool   `json:"tune_segment_size"`
+	ErasureShareSize int64  `json:"erasure_share_size"`
+	MaxBufferMem     int64  `json:"max_buffer_mem"`
+}
+
+// jsonDiagnosedCall is a call to the satellite in a diagnostics bundle
+type jsonDiagnosedCall struct {
+	Address  string `json:"address"`
+	Method   string `json:"method,omitempty"` // empty for failed dials
+	Offset   string `json:"offset"`           // since the transfer started
+	Duration string `json:"duration"`
+	Code     string `json:"code"`
+	Error    string `json:"error,omitempty"`
+}
+
+// jsonDiagnosedNode are the calls to a storage node in a diagnostics bundle
+type jsonDiagnosedNode struct {
+	ID        storj.NodeID `json:"id"`
+	Address   string       `json:"address"`
+	Calls     int          `json:"calls"`
+	Failures  int          `json:"failures"`
+	Total     string       `json:"total"`
+	Max       string       `json:"max"`
+	LastError string       `json:"last_error,omitempty"`
+}
+
+// bundle returns the bundle of the calls collected for a transfer which
+// failed with err
+func (diag *diagnostics) bundle(operation string, err error, redact *strings.Replacer) *jsonDiagnostics {
+	diag.mu.Lock()
+	defer diag.mu.Unlock()
+
+	bundle := &jsonDiagnostics{
+		Version:      jsonVersion,
+		Operation:    operation,
+		Started:      diag.started.UTC(),
+		Duration:     time.Since(diag.started).String(),
+		Error:        redact.Replace(err.Error()),
+		ErrorClasses: errorClasses(err),
+		Redundancy: jsonDiagnosedRedundancy{
+			Required: cfg.RS.MinThreshold,
+			Repair:   cfg.RS.RepairThreshold,
+			Optimal:  cfg.RS.SuccessThreshold,
+			Total:    cfg.RS.MaxThreshold,
+		},
+		Encryption: jsonDiagnosedEncryption{
+			Cipher:    storj.Cipher(cfg.Enc.DataType),
+			BlockSize: int32(cfg.Enc.BlockSize),
+			PathType:  cfg.Enc.PathType,
+		},
+		Config: jsonDiagnosedConfig{
+			OverlayAddr:      cfg.Client.OverlayAddr,
+			PointerDBAddr:    cfg.Client.PointerDBAddr,
+			APIKeySet:        cfg.Client.APIKey != "",
+			Region:           cfg.Client.Region,
+			NodeTags:         cfg.Client.NodeTags,
+			ExcludedNodeTags: cfg.Client.ExcludedNodeTags,
+			MaxInlineSize:    cfg.Client.MaxInlineSize.Int64(),
+			SegmentSize:      cfg.Client.SegmentSize.Int64(),
+			TuneSegmentSize:  cfg.Client.TuneSegmentSize,
+			ErasureShareSize: cfg.RS.ErasureShareSize.Int64(),
+			MaxBufferMem:     cfg.RS.MaxBufferMem.Int64(),
+		},
+		Satellite: []jsonDiagnosedCall{},
+		Nodes:     []jsonDiagnosedNode{},
+	}
+
+	nodes := make(map[storj.NodeID]*jsonDiagnosedNode)
+	totals := make(map[storj.NodeID]time.Duration)
+	maxes := make(map[storj.NodeID]time.Duration)
+	for _, call := range diag.calls {
+		if call.Node.IsZero() {
+			bundle.Satellite = append(bundle.Satellite, jsonDiagnosedCall{
+				Address:  call.Address,
+				Method:   call.Method,
+				Offset:   call.Start.Sub(diag.started).String(),
+				Duration: call.Duration.String(),
+				Code:     status.Code(errs.Unwrap(call.Err)).String(),
+				Error:    redactError(redact, call.Err),
+			})
+			continue
+		}
+
+		node, ok := nodes[call.Node]
+		if !ok {
+			node = &jsonDiagnosedNode{ID: call.Node, Address: call.Address}
+			nodes[call.Node] = node
+		}
+		node.Calls++
+		if call.Err != nil {
+			node.Failures++
+			node.LastError = redactError(redact, call.Err)
+		}
+		totals[call.Node] += call.Duration
+		if call.Duration > maxes[call.Node] {
+			maxes[call.Node] = call.Duration
+		}
+	}
+
+	if len(bundle.Satellite) > maxDiagnosedSatelliteCalls {
+		bundle.Satellite = bundle.Satellite[len(bundle.Satellite)-maxDiagnosedSatelliteCalls:]
+	}
+
+	for id, node := range nodes {
+		node.Total = totals[id].String()
+		node.Max = maxes[id].String()
+		bundle.Nodes = append(bundle.Nodes, *node)
+	}
+	// the nodes which failed most come first
+	sort.Slice(bundle.Nodes, func(i, k int) bool {
+		if bundle.Nodes[i].Failures != bundle.Nodes[k].Failures {
+			return bundle.Nodes[i].Failures > bundle.Nodes[k].Failures
+		}
+		return bundle.Nodes[i].ID.Less(bundle.Nodes[k].ID)
+	})
+	return bundle
+}
+
+// errorClasses returns the names of the error classes err was wrapped with,
+// the status code of the rpc which failed and whether the context ended
+func errorClasses(err error) []string {
+	classes := []string{}
+	for _, class := range errs.Classes(err) {
+		classes = append(classes, string(*class))
+	}
+
+	cause := errs.Unwrap(err)
+	switch cause {
+	case context.Canceled:
+		classes = append(classes, "context canceled")
+	case context.DeadlineExceeded:
+		classes = append(classes, "context deadline exceeded")
+	default:
+		if s, ok := status.FromError(cause); ok && s != nil {
+			classes = append(classes, "rpc "+s.Code().String())
+		}
+	}
+	return classes
+}
+
+// redactError returns the redacted message of err, empty when it's nil
+func redactError(redact *strings.Replacer, err error) string {
+	if err == nil {
+		return ""
+	}
+	return redact.Replace(err.Error())
+}
+
+// newRedactor returns a replacer of the secrets of the configuration and of
+// the paths of a transfer
+func newRedactor(paths []fpath.FPath) *strings.Replacer {
+	var secrets []string
+	add := func(s string) {
+		if s != "" {
+			secrets = append(secrets, s)
+		}
+	}
+	add(cfg.Client.APIKey)
+	add(cfg.Enc.Key)
+	for _, path := range paths {
+		if path.Base() == "-" {
+			// standard input and output
+			continue
+		}
+		if path.IsLocal() {
+			add(path.Path())
+			add(path.Base())
+			continue
+		}
+		add(path.Path())
+		add(path.Bucket())
+	}
+
+	// longer secrets first, so that they aren't partially replaced
+	sort.Slice(secrets, func(i, k int) bool { return len(secrets[i]) > len(secrets[k]) })
+
+	var pairs []string
+	for _, secret := range secrets {
+		pairs = append(pairs, secret, redactedText)
+	}
+	return strings.NewReplacer(pairs...)
+}
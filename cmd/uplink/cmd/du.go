@@ -0,0 +1,95 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/pkg/process"
+	"storj.io/storj/pkg/storj"
+)
+
+var (
+	humanReadableFlag *bool
+	duJSONFlag        *bool
+)
+
+func init() {
+	duCmd := addCmd(&cobra.Command{
+		Use:   "du",
+		Short: "Summarize the number and size of objects below a prefix and in its directories",
+		RunE:  diskUsage,
+	}, CLICmd)
+	humanReadableFlag = duCmd.Flags().BoolP("human-readable", "H", false, "if true, print sizes in powers of 1024")
+	duJSONFlag = duCmd.Flags().Bool("json", false, "if true, print the usage as json, sizes are always in bytes")
+}
+
+func diskUsage(cmd *cobra.Command, args []string) error {
+	ctx := process.Ctx(cmd)
+
+	if len(args) == 0 {
+		return fmt.Errorf("No prefix specified, use format sj://bucket/prefix/")
+	}
+
+	src, err := fpath.New(args[0])
+	if err != nil {
+		return err
+	}
+
+	if src.IsLocal() {
+		return fmt.Errorf("No bucket specified, use format sj://bucket/")
+	}
+
+	metainfo, _, err := cfg.Metainfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	usage, err := metainfo.PrefixUsage(ctx, src.Bucket(), src.Path())
+	if err != nil {
+		return convertError(err, src)
+	}
+
+	if *duJSONFlag {
+		result := jsonUsage{
+			Version:     jsonVersion,
+			Prefix:      src.String(),
+			Total:       newJSONObjectUsage(usage.Total),
+			Directories: []jsonDirectoryUsage{},
+			More:        usage.More,
+		}
+		for _, dir := range usage.Directories {
+			result.Directories = append(result.Directories, jsonDirectoryUsage{Path: dir.Path, jsonObjectUsage: newJSONObjectUsage(dir.Usage)})
+		}
+		return printJSON(result)
+	}
+
+	fmt.Printf("%v %10v %8v %12v %12v %v\n", "   ", "OBJECTS", "SEGMENTS", "SIZE", "ENCRYPTED", "PATH")
+	for _, dir := range usage.Directories {
+		printUsage("DIR", dir.Usage, dir.Path)
+	}
+	if usage.More {
+		fmt.Println("... more directories aren't listed separately")
+	}
+	printUsage("TOT", usage.Total, src.String())
+
+	return nil
+}
+
+func printUsage(kind string, usage storj.ObjectUsage, path string) {
+	fmt.Printf("%v %10v %8v %12v %12v %v\n", kind, usage.Objects, usage.Segments,
+		formatSize(usage.PlainSize), formatSize(usage.EncryptedSize), path)
+}
+
+func formatSize(size int64) string {
+	if *humanReadableFlag {
+		return memory.Size(size).Base2String()
+	}
+	return strconv.FormatInt(size, 10)
+}
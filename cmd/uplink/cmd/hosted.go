@@ -0,0 +1,185 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/pkg/cfgstruct"
+	"storj.io/storj/pkg/miniogw"
+	"storj.io/storj/pkg/process"
+)
+
+const (
+	// hostedReportFD is the file descriptor hosted instances report the
+	// address they serve on to
+	hostedReportFD = 3
+	// hostedAccess is the name of the access of a hosted instance
+	hostedAccess = "hosted"
+)
+
+var (
+	hostedCfg struct {
+		Hosted miniogw.HostedConfig
+	}
+
+	// hostedInstanceFlag is set for the gateways started by 'gateway hosted'
+	hostedInstanceFlag *bool
+)
+
+func init() {
+	hostedCmd := addCmd(&cobra.Command{
+		Use:   "hosted",
+		Short: "Run the S3 gateway for many access grants behind one address",
+		Args:  cobra.NoArgs,
+		RunE:  cmdHosted,
+	}, GWCmd)
+
+	defaultConfDir := fpath.ApplicationDir("storj", "uplink")
+	if dirParam := cfgstruct.FindConfigDirParam(); dirParam != "" {
+		defaultConfDir = dirParam
+	}
+	cfgstruct.Bind(hostedCmd.Flags(), &hostedCfg, cfgstruct.ConfDir(defaultConfDir))
+}
+
+func cmdHosted(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	hosted := miniogw.NewHosted(zap.L().Named("hosted"), hostedCfg.Hosted, hostedInstance(executable))
+	front := &http.Server{Addr: cfg.Server.Address, Handler: hosted}
+	registration := &http.Server{Addr: hostedCfg.Hosted.Registration, Handler: hosted.RegistrationHandler()}
+
+	fmt.Printf("Starting Storj S3-compatible hosted gateway!\n\n")
+	fmt.Printf("Endpoint: %s\n", cfg.Server.Address)
+	fmt.Printf("Grant registration: %s\n", hostedCfg.Hosted.Registration)
+
+	group, ctx := errgroup.WithContext(ctx)
+	group.Go(func() error { return hosted.Run(ctx) })
+	for _, server := range []*http.Server{front, registration} {
+		server := server
+		group.Go(func() error {
+			err := server.ListenAndServe()
+			if err == http.ErrServerClosed {
+				return nil
+			}
+			return err
+		})
+		group.Go(func() error {
+			<-ctx.Done()
+			return server.Close()
+		})
+	}
+	return group.Wait()
+}
+
+// hostedInstance runs the gateway of a grant as a process of its own, so
+// that encryption keys and credentials are never shared between grants
+func hostedInstance(executable string) miniogw.HostedInstance {
+	return func(ctx context.Context, grant miniogw.HostedGrant, serving func(address string)) error {
+		dir := filepath.Join(cfg.Minio.Dir, "hosted", grant.AccessKey)
+		accessFile := filepath.Join(dir, "accesses.json")
+
+		accesses := &Accesses{
+			Default: hostedAccess,
+			Accesses: map[string]Access{hostedAccess: {
+				SatelliteAddr: grant.SatelliteAddr,
+				APIKey:        grant.APIKey,
+				EncKey:        grant.EncKey,
+			}},
+		}
+		if err := accesses.Save(accessFile); err != nil {
+			return err
+		}
+
+		report, reporter, err := os.Pipe()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = report.Close() }()
+
+		instance := exec.CommandContext(ctx, executable, "run", "--hosted-instance",
+			"--config-dir", *gwConfDir, "--access-file", accessFile, "--access", hostedAccess)
+		instance.Env = append(os.Environ(),
+			"STORJ_MINIO_ACCESS_KEY="+grant.AccessKey,
+			"STORJ_MINIO_SECRET_KEY="+grant.SecretKey,
+			"STORJ_MINIO_DIR="+filepath.Join(dir, "minio"),
+		)
+		instance.Stderr = os.Stderr
+		instance.ExtraFiles = []*os.File{reporter}
+
+		err = instance.Start()
+		_ = reporter.Close()
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			scanner := bufio.NewScanner(report)
+			if scanner.Scan() {
+				serving(scanner.Text())
+			}
+		}()
+		return instance.Wait()
+	}
+}
+
+// reportServing reports address to the hosted gateway which started this
+// gateway, as soon as the gateway serves requests on it
+func reportServing(ctx context.Context, address string) {
+	report := os.NewFile(hostedReportFD, "hosted report")
+	defer func() { _ = report.Close() }()
+
+	ready := "http://" + address + "/minio/health/ready"
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		resp, err := http.Get(ready)
+		if err != nil {
+			continue
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			if _, err := fmt.Fprintln(report, address); err != nil {
+				zap.S().Errorf("Failed to report the gateway address: %v", err)
+			}
+			return
+		}
+	}
+}
+
+// freeLocalAddress returns a loopback address which isn't listened on. A
+// hosted instance whose address is taken before it listens exits and is
+// restarted on another one.
+func freeLocalAddress() (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	address := listener.Addr().String()
+	return address, listener.Close()
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"storj.io/storj/pkg/storj"
+)
+
+// jsonVersion is the version of the json output of the commands. Fields may
+// be added within a version; it's bumped when fields are removed or change
+// their meaning.
+const jsonVersion = 1
+
+// jsonListItem is a line of the json output of ls
+type jsonListItem struct {
+	Version  int        `json:"version"`
+	Kind     string     `json:"kind"` // bucket, prefix, object or pending
+	Bucket   string     `json:"bucket"`
+	Path     string     `json:"path,omitempty"`
+	Size     int64      `json:"size,omitempty"`
+	Created  *time.Time `json:"created,omitempty"`
+	Modified *time.Time `json:"modified,omitempty"`
+}
+
+// jsonUsage is the json output of du
+type jsonUsage struct {
+	Version     int                  `json:"version"`
+	Prefix      string               `json:"prefix"`
+	Total       jsonObjectUsage      `json:"total"`
+	Directories []jsonDirectoryUsage `json:"directories"`
+	More        bool                 `json:"more"`
+}
+
+// jsonDirectoryUsage is the usage of a directory in the json output of du
+type jsonDirectoryUsage struct {
+	Path string `json:"path"`
+	jsonObjectUsage
+}
+
+// jsonObjectUsage is the usage of the objects below a prefix in the json
+// output of du
+type jsonObjectUsage struct {
+	Objects       int64 `json:"objects"`
+	Segments      int64 `json:"segments"`
+	Size          int64 `json:"size"`
+	EncryptedSize int64 `json:"encrypted_size"`
+}
+
+func newJSONObjectUsage(usage storj.ObjectUsage) jsonObjectUsage {
+	return jsonObjectUsage{
+		Objects:       usage.Objects,
+		Segments:      usage.Segments,
+		Size:          usage.PlainSize,
+		EncryptedSize: usage.EncryptedSize,
+	}
+}
+
+// printJSON prints value as a single line of json
+func printJSON(value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
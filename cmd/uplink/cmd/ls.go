@@ -17,6 +17,8 @@ import (
 
 var (
 	recursiveFlag *bool
+	pendingFlag   *bool
+	lsJSONFlag    *bool
 )
 
 func init() {
@@ -26,6 +28,8 @@ func init() {
 		RunE:  list,
 	}, CLICmd)
 	recursiveFlag = lsCmd.Flags().Bool("recursive", false, "if true, list recursively")
+	pendingFlag = lsCmd.Flags().Bool("pending", false, "if true, list the objects of interrupted uploads instead, which are always listed recursively")
+	lsJSONFlag = lsCmd.Flags().Bool("json", false, "if true, print a line of json per bucket, prefix and object")
 }
 
 func list(cmd *cobra.Command, args []string) error {
@@ -46,7 +50,11 @@ func list(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("No bucket specified, use format sj://bucket/")
 		}
 
-		err = listFiles(ctx, metainfo, src, false)
+		if *pendingFlag {
+			err = listPendingFiles(ctx, metainfo, src)
+		} else {
+			err = listFiles(ctx, metainfo, src, false)
+		}
 
 		return convertError(err, src)
 	}
@@ -62,7 +70,15 @@ func list(cmd *cobra.Command, args []string) error {
 		if len(list.Items) > 0 {
 			noBuckets = false
 			for _, bucket := range list.Items {
-				fmt.Println("BKT", formatTime(bucket.Created), bucket.Name)
+				if *lsJSONFlag {
+					created := bucket.Created
+					err = printJSON(jsonListItem{Version: jsonVersion, Kind: "bucket", Bucket: bucket.Name, Created: &created})
+					if err != nil {
+						return err
+					}
+				} else {
+					fmt.Println("BKT", formatTime(bucket.Created), bucket.Name)
+				}
 				if *recursiveFlag {
 					prefix, err := fpath.New(fmt.Sprintf("sj://%s/", bucket.Name))
 					if err != nil {
@@ -81,7 +97,7 @@ func list(cmd *cobra.Command, args []string) error {
 		startAfter = list.Items[len(list.Items)-1].Name
 	}
 
-	if noBuckets {
+	if noBuckets && !*lsJSONFlag {
 		fmt.Println("No buckets")
 	}
 
@@ -103,6 +119,13 @@ func listFiles(ctx context.Context, metainfo storj.Metainfo, prefix fpath.FPath,
 		}
 
 		for _, object := range list.Items {
+			if *lsJSONFlag {
+				if err := printJSONObject(prefix.Bucket(), object, "object"); err != nil {
+					return err
+				}
+				continue
+			}
+
 			path := object.Path
 			if prependBucket {
 				path = fmt.Sprintf("%s/%s", prefix.Bucket(), path)
@@ -124,6 +147,50 @@ func listFiles(ctx context.Context, metainfo storj.Metainfo, prefix fpath.FPath,
 	return nil
 }
 
+func listPendingFiles(ctx context.Context, metainfo storj.Metainfo, prefix fpath.FPath) error {
+	startAfter := ""
+
+	for {
+		list, err := metainfo.ListPendingObjects(ctx, prefix.Bucket(), storj.ListOptions{
+			Direction: storj.After,
+			Cursor:    startAfter,
+			Prefix:    prefix.Path(),
+			Recursive: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, object := range list.Items {
+			if *lsJSONFlag {
+				if err := printJSONObject(prefix.Bucket(), object, "pending"); err != nil {
+					return err
+				}
+				continue
+			}
+			fmt.Printf("%v %v %v\n", "PND", formatTime(object.Modified), object.Path)
+		}
+
+		if !list.More || len(list.Items) == 0 {
+			break
+		}
+
+		startAfter = list.Items[len(list.Items)-1].Path
+	}
+
+	return nil
+}
+
+// printJSONObject prints the object of bucket as a line of json, prefixes
+// are printed as such and other objects as kind
+func printJSONObject(bucket string, object storj.Object, kind string) error {
+	if object.IsPrefix {
+		return printJSON(jsonListItem{Version: jsonVersion, Kind: "prefix", Bucket: bucket, Path: object.Path})
+	}
+	modified := object.Modified
+	return printJSON(jsonListItem{Version: jsonVersion, Kind: kind, Bucket: bucket, Path: object.Path, Size: object.Size, Modified: &modified})
+}
+
 func formatTime(t time.Time) string {
 	return t.Local().Format("2006-01-02 15:04:05")
 }
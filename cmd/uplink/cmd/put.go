@@ -4,6 +4,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -13,11 +14,12 @@ import (
 )
 
 func init() {
-	addCmd(&cobra.Command{
+	putCmd := addCmd(&cobra.Command{
 		Use:   "put",
 		Short: "Copies data from standard in to a Storj object",
 		RunE:  putMain,
 	}, CLICmd)
+	addDiagnosticsFlag(putCmd)
 }
 
 // putMain is the function executed when putCmd is called
@@ -42,5 +44,7 @@ func putMain(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	return upload(ctx, src, dst, false)
+	return diagnose(ctx, "upload", []fpath.FPath{src, dst}, func(ctx context.Context) error {
+		return upload(ctx, src, dst, false)
+	})
 }
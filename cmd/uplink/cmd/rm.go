@@ -4,20 +4,33 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"storj.io/storj/internal/fpath"
+	"storj.io/storj/pkg/metainfo/kvmetainfo"
 	"storj.io/storj/pkg/process"
+	"storj.io/storj/pkg/storj"
+)
+
+var (
+	rmPendingFlag   *bool
+	rmRecursiveFlag *bool
+	rmResumeFlag    *string
 )
 
 func init() {
-	addCmd(&cobra.Command{
+	rmCmd := addCmd(&cobra.Command{
 		Use:   "rm",
 		Short: "Delete an object",
 		RunE:  deleteObject,
 	}, CLICmd)
+	rmPendingFlag = rmCmd.Flags().Bool("pending", false, "if true, delete the uploaded segments of an interrupted upload instead")
+	rmRecursiveFlag = rmCmd.Flags().Bool("recursive", false, "if true, delete all objects under the path")
+	rmResumeFlag = rmCmd.Flags().String("resume", "", "resume an interrupted recursive delete from the cursor it printed")
 }
 
 func deleteObject(cmd *cobra.Command, args []string) error {
@@ -41,7 +54,19 @@ func deleteObject(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	err = metainfo.DeleteObject(ctx, dst.Bucket(), dst.Path())
+	if *rmRecursiveFlag {
+		return deleteObjects(ctx, metainfo, dst)
+	}
+
+	if *rmPendingFlag {
+		var object storj.MutableObject
+		object, err = metainfo.ModifyPendingObject(ctx, dst.Bucket(), dst.Path())
+		if err == nil {
+			err = object.DeleteStream(ctx)
+		}
+	} else {
+		err = metainfo.DeleteObject(ctx, dst.Bucket(), dst.Path())
+	}
 	if err != nil {
 		return convertError(err, dst)
 	}
@@ -50,3 +75,35 @@ func deleteObject(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// objectsDeleter is implemented by the metainfo implementations which can
+// delete the objects under a prefix
+type objectsDeleter interface {
+	DeleteObjects(ctx context.Context, bucket string, prefix, cursor storj.Path, progress func(path storj.Path)) (kvmetainfo.DeleteList, error)
+}
+
+// deleteObjects deletes the objects under dst and prints where to resume when
+// interrupted
+func deleteObjects(ctx context.Context, metainfo storj.Metainfo, dst fpath.FPath) error {
+	deleter, ok := metainfo.(objectsDeleter)
+	if !ok {
+		return fmt.Errorf("Recursive delete is not supported")
+	}
+
+	prefix := dst.Path()
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	list, err := deleter.DeleteObjects(ctx, dst.Bucket(), prefix, *rmResumeFlag, func(path storj.Path) {
+		fmt.Printf("Deleted sj://%s/%s%s\n", dst.Bucket(), prefix, path)
+	})
+	if err != nil {
+		if list.More {
+			fmt.Printf("Interrupted, resume with --resume %q\n", list.Cursor)
+		}
+		return convertError(err, dst)
+	}
+
+	return nil
+}
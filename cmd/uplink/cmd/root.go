@@ -8,8 +8,10 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
 
 	"storj.io/storj/internal/fpath"
+	"storj.io/storj/pkg/audit"
 	"storj.io/storj/pkg/cfgstruct"
 	"storj.io/storj/pkg/miniogw"
 	"storj.io/storj/pkg/storage/streams"
@@ -19,10 +21,17 @@ import (
 // Config is miniogw.Config configuration
 type Config struct {
 	miniogw.Config
+
+	Access     string `help:"the name of the access to use, defaults to the access selected with 'uplink access set-default'" default:""`
+	AccessFile string `help:"path to the file containing named accesses" default:"$CONFDIR/accesses.json"`
 }
 
 var cfg Config
 
+// opened are the stream stores the running command opened, which are closed
+// once it returns
+var opened []streams.Store
+
 // CLICmd represents the base CLI command when called without any subcommands
 var CLICmd = &cobra.Command{
 	Use:   "uplink",
@@ -46,9 +55,37 @@ func addCmd(cmd *cobra.Command, root *cobra.Command) *cobra.Command {
 	}
 
 	cfgstruct.Bind(cmd.Flags(), &cfg, cfgstruct.ConfDir(defaultConfDir))
+
+	// the selected access overrides the loaded configuration but not the
+	// flags passed explicitly, commands which manage or check accesses apply
+	// them on their own
+	if run := cmd.RunE; run != nil && root != accessCmd && cmd.Annotations["access"] != "manual" {
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			if err := cfg.applyAccess(cmd.Flags()); err != nil {
+				return err
+			}
+			return run(cmd, args)
+		}
+	}
+	if run := cmd.RunE; run != nil {
+		cmd.RunE = func(cmd *cobra.Command, args []string) (err error) {
+			defer func() { err = errs.Combine(err, closeOpened()) }()
+			return run(cmd, args)
+		}
+	}
 	return cmd
 }
 
+// closeOpened closes the stream stores the command opened
+func closeOpened() error {
+	var group errs.Group
+	for _, store := range opened {
+		group.Add(store.Close())
+	}
+	opened = nil
+	return group.Err()
+}
+
 // Metainfo loads the storj.Metainfo
 //
 // Temporarily it also returns an instance of streams.Store until we improve
@@ -59,7 +96,23 @@ func (c *Config) Metainfo(ctx context.Context) (storj.Metainfo, streams.Store, e
 		return nil, nil, err
 	}
 
-	return c.GetMetainfo(ctx, identity)
+	// the calls of transfers being diagnosed are observed
+	metainfo, streams, err := c.GetObservedMetainfo(ctx, identity, diagnosed.observer())
+	if err != nil {
+		return nil, nil, err
+	}
+	opened = append(opened, streams)
+	return metainfo, streams, nil
+}
+
+// Verifier loads the audit.SegmentVerifier
+func (c *Config) Verifier(ctx context.Context) (*audit.SegmentVerifier, error) {
+	identity, err := c.Identity.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetVerifier(ctx, identity)
 }
 
 func convertError(err error, path fpath.FPath) error {
@@ -15,11 +15,13 @@ import (
 )
 
 func init() {
-	addCmd(&cobra.Command{
+	runCmd := addCmd(&cobra.Command{
 		Use:   "run",
 		Short: "Run the S3 gateway",
 		RunE:  cmdRun,
 	}, GWCmd)
+	hostedInstanceFlag = runCmd.Flags().Bool("hosted-instance", false, "serve on a free local address and report it to the hosted gateway which started this one")
+	_ = runCmd.Flags().MarkHidden("hosted-instance")
 }
 
 func cmdRun(cmd *cobra.Command, args []string) (err error) {
@@ -27,21 +29,30 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 		return fmt.Errorf("Invalid argument %#v. Try 'uplink run'", flagname)
 	}
 
-	address := cfg.Server.Address
-	host, port, err := net.SplitHostPort(address)
-	if err != nil {
-		return err
-	}
-	if host == "" {
-		address = net.JoinHostPort("localhost", port)
-	}
+	ctx := process.Ctx(cmd)
 
-	fmt.Printf("Starting Storj S3-compatible gateway!\n\n")
-	fmt.Printf("Endpoint: %s\n", address)
-	fmt.Printf("Access key: %s\n", cfg.Minio.AccessKey)
-	fmt.Printf("Secret key: %s\n", cfg.Minio.SecretKey)
+	if *hostedInstanceFlag {
+		cfg.Server.Address, err = freeLocalAddress()
+		if err != nil {
+			return err
+		}
+		go reportServing(ctx, cfg.Server.Address)
+	} else {
+		address := cfg.Server.Address
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+		if host == "" {
+			address = net.JoinHostPort("localhost", port)
+		}
+
+		fmt.Printf("Starting Storj S3-compatible gateway!\n\n")
+		fmt.Printf("Endpoint: %s\n", address)
+		fmt.Printf("Access key: %s\n", cfg.Minio.AccessKey)
+		fmt.Printf("Secret key: %s\n", cfg.Minio.SecretKey)
+	}
 
-	ctx := process.Ctx(cmd)
 	metainfo, _, err := cfg.Metainfo(ctx)
 	if err != nil {
 		return err
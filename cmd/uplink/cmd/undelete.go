@@ -0,0 +1,58 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/pkg/process"
+	"storj.io/storj/pkg/storj"
+)
+
+func init() {
+	addCmd(&cobra.Command{
+		Use:   "undelete",
+		Short: "Restore a deleted object until the satellite purges it",
+		RunE:  undeleteObject,
+	}, CLICmd)
+}
+
+func undeleteObject(cmd *cobra.Command, args []string) error {
+	ctx := process.Ctx(cmd)
+
+	if len(args) == 0 {
+		return fmt.Errorf("No object specified for undeletion")
+	}
+
+	dst, err := fpath.New(args[0])
+	if err != nil {
+		return err
+	}
+
+	if dst.IsLocal() {
+		return fmt.Errorf("No bucket specified, use format sj://bucket/")
+	}
+
+	metainfo, streams, err := cfg.Metainfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	bucket, err := metainfo.GetBucket(ctx, dst.Bucket())
+	if err != nil {
+		return convertError(err, dst)
+	}
+
+	err = streams.Undelete(ctx, storj.JoinPaths(dst.Bucket(), dst.Path()), bucket.PathCipher)
+	if err != nil {
+		return convertError(err, dst)
+	}
+
+	fmt.Printf("Undeleted %s\n", dst)
+
+	return nil
+}
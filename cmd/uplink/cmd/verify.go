@@ -0,0 +1,107 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/pkg/process"
+	"storj.io/storj/pkg/storj"
+)
+
+var (
+	stripesFlag *int
+)
+
+func init() {
+	verifyCmd := addCmd(&cobra.Command{
+		Use:   "verify",
+		Short: "Check the integrity of an object without downloading it",
+		RunE:  verifyObject,
+	}, CLICmd)
+	stripesFlag = verifyCmd.Flags().Int("stripes", 3, "the number of randomly chosen stripes of each segment to verify")
+}
+
+// verifyObject is the function executed when verifyCmd is called
+func verifyObject(cmd *cobra.Command, args []string) error {
+	ctx := process.Ctx(cmd)
+
+	if len(args) == 0 {
+		return fmt.Errorf("No object specified for verification")
+	}
+
+	src, err := fpath.New(args[0])
+	if err != nil {
+		return err
+	}
+
+	if src.IsLocal() {
+		return fmt.Errorf("No bucket specified, use format sj://bucket/")
+	}
+
+	metainfo, streams, err := cfg.Metainfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	verifier, err := cfg.Verifier(ctx)
+	if err != nil {
+		return err
+	}
+
+	bucket, err := metainfo.GetBucket(ctx, src.Bucket())
+	if err != nil {
+		return convertError(err, src)
+	}
+
+	paths, err := streams.SegmentPaths(ctx, storj.JoinPaths(src.Bucket(), src.Path()), bucket.PathCipher)
+	if err != nil {
+		return convertError(err, src)
+	}
+
+	recoverable, atRisk := true, false
+	bad := make(map[storj.NodeID]string)
+	for i, path := range paths {
+		health, err := verifier.Verify(ctx, path, *stripesFlag)
+		if err != nil {
+			return fmt.Errorf("verifying segment %d failed: %v", i, err)
+		}
+
+		if health.Inline {
+			fmt.Printf("SEG %d inline\n", i)
+			continue
+		}
+		fmt.Printf("SEG %d %d/%d pieces healthy, %d required, repair at %d, %d stripes verified\n",
+			i, len(health.Healthy), health.Total, health.Required, health.Repair, health.Stripes)
+
+		for _, id := range health.Failed {
+			bad[id] = "FAILED"
+		}
+		for _, id := range health.Offline {
+			if _, ok := bad[id]; !ok {
+				bad[id] = "OFFLINE"
+			}
+		}
+		recoverable = recoverable && health.Recoverable()
+		atRisk = atRisk || health.AtRisk()
+	}
+
+	for id, status := range bad {
+		fmt.Println(status, id)
+	}
+
+	switch {
+	case !recoverable:
+		fmt.Printf("%s is unrecoverable\n", src)
+	case atRisk:
+		fmt.Printf("%s is at risk\n", src)
+	default:
+		fmt.Printf("%s is healthy\n", src)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/vivint/infectious"
+
+	"storj.io/storj/pkg/configcheck"
+	"storj.io/storj/pkg/eestream"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/process"
+)
+
+var (
+	verifyConfigJSONFlag *bool
+)
+
+func init() {
+	verifyConfigCmd := addCmd(&cobra.Command{
+		Use:   "verify-config",
+		Short: "Check the uplink configuration and report every problem found",
+		RunE:  verifyConfig,
+		// a missing access is reported along with the other problems
+		Annotations: map[string]string{"access": "manual"},
+	}, CLICmd)
+	verifyConfigJSONFlag = verifyConfigCmd.Flags().Bool("json", false, "print the report as json")
+}
+
+// verifyConfig is the function executed when verifyConfigCmd is called
+func verifyConfig(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	var report configcheck.Report
+	verifyUplink(ctx, &report, &cfg, cmd.Flags())
+
+	if *verifyConfigJSONFlag {
+		err = report.PrintJSON(os.Stdout)
+	} else {
+		err = report.Print(os.Stdout)
+	}
+	if err != nil {
+		return err
+	}
+	return report.Err()
+}
+
+// verifyUplink adds the checks of the uplink configuration to report, the
+// explicitly passed flags override the selected access
+func verifyUplink(ctx context.Context, report *configcheck.Report, config *Config, flags *pflag.FlagSet) {
+	if config.Access != "" {
+		report.Check("access "+config.Access, config.applyAccess(flags))
+	}
+
+	report.Check("identity", configcheck.Identity(config.Identity))
+	report.Check("client.overlay-addr", configcheck.Reachable(ctx, config.Client.OverlayAddr))
+	report.Check("client.pointer-db-addr", configcheck.Reachable(ctx, config.Client.PointerDBAddr))
+
+	problem := ""
+	if config.Client.APIKey == "" {
+		problem = "no api key configured, the satellite rejects requests"
+	}
+	report.Warn("client.api-key", problem)
+
+	problem = ""
+	if config.Enc.Key == "" {
+		problem = "no encryption key configured, data is encrypted with an empty key"
+	}
+	report.Warn("enc.key", problem)
+
+	_, err := overlay.ParseTags(config.Client.NodeTags)
+	report.Check("client.node-tags", err)
+	_, err = overlay.ParseTags(config.Client.ExcludedNodeTags)
+	report.Check("client.excluded-node-tags", err)
+
+	if config.Client.MaxInlineSize > config.Client.SegmentSize {
+		report.Add("client.max-inline-size", configcheck.Failed,
+			fmt.Sprintf("%s exceeds client.segment-size %s", config.Client.MaxInlineSize, config.Client.SegmentSize))
+	}
+
+	fc, err := infectious.NewFEC(config.RS.MinThreshold, config.RS.MaxThreshold)
+	if err == nil {
+		_, err = eestream.NewRedundancyStrategy(eestream.NewRSScheme(fc, config.RS.ErasureShareSize.Int()), config.RS.RepairThreshold, config.RS.SuccessThreshold)
+	}
+	report.Check("rs thresholds", err)
+
+	stripeSize := config.RS.ErasureShareSize.Int() * config.RS.MinThreshold
+	if blockSize := config.Enc.BlockSize.Int(); blockSize <= 0 || stripeSize%blockSize != 0 {
+		report.Add("enc.block-size", configcheck.Failed,
+			fmt.Sprintf("%s doesn't divide the stripe size rs.erasure-share-size * rs.min-threshold of %d", config.Enc.BlockSize, stripeSize))
+	}
+}
@@ -1,124 +1,217 @@
 module storj.io/storj
 
+go 1.27.1
+
+exclude gopkg.in/olivere/elastic.v5 v5.0.72 // buggy import, see https://github.com/olivere/elastic/pull/869
+
 // force specific versions for minio
 require (
+	github.com/Shopify/go-lua v0.0.0-20181106184032-48449c60c0a9
+	github.com/alicebob/miniredis v0.0.0-20180911162847-3657542c8629
+	github.com/boltdb/bolt v1.3.1
 	github.com/btcsuite/btcutil v0.0.0-20180706230648-ab6388e0c60a
-	github.com/garyburd/redigo v1.0.1-0.20170216214944-0d253a66e6e1 // indirect
-	github.com/go-ole/go-ole v1.2.1 // indirect
+	github.com/cheggaaa/pb v1.0.5-0.20160713104425-73ae1d68fe0b
+	github.com/fatih/color v1.7.0
+	github.com/go-redis/redis v6.14.1+incompatible
+	github.com/gogo/protobuf v1.1.2-0.20181116123445-07eab6a8298c
+	github.com/golang-migrate/migrate/v3 v3.5.2
+	github.com/golang/mock v1.2.0
+	github.com/golang/protobuf v1.2.0
+	github.com/google/go-cmp v0.2.0
 	github.com/graphql-go/graphql v0.7.6
+	github.com/gtank/cryptopasta v0.0.0-20170601214702-1f550f6f2f69
 	github.com/hanwen/go-fuse v0.0.0-20181027161220-c029b69a13a7
-	github.com/inconshreveable/mousetrap v1.0.0 // indirect
-	github.com/mattn/go-colorable v0.0.9 // indirect
-
+	github.com/jbenet/go-base58 v0.0.0-20150317085156-6237cf65f3a6
+	github.com/jtolds/go-luar v0.0.0-20170419063437-0786921db8c0
+	github.com/jtolds/monkit-hw v0.0.0-20190108155550-0f753668cf20
+	github.com/lib/pq v1.0.0
+	github.com/loov/hrtime v0.0.0-20181214195526-37a208e8344e
+	github.com/loov/plot v0.0.0-20180510142208-e59891ae1271
+	github.com/mattn/go-sqlite3 v1.10.0
+	github.com/minio/cli v1.3.0
 	github.com/minio/minio v0.0.0-20180508161510-54cd29b51c38
-	github.com/mitchellh/mapstructure v1.1.1 // indirect
-
-	github.com/prometheus/client_golang v0.9.0-pre1.0.20180416233856-82f5ff156b29 // indirect
-	github.com/segmentio/go-prompt v1.2.1-0.20161017233205-f0d19b6901ad // indirect
+	github.com/minio/minio-go v6.0.3+incompatible
+	github.com/mr-tron/base58 v0.0.0-20180922112544-9ad991d48a42
+	github.com/nsf/jsondiff v0.0.0-20160203110537-7de28ed2b6e3
+	github.com/shirou/gopsutil v2.17.12+incompatible
+	github.com/skyrings/skyring-common v0.0.0-20160929130248-d1c0bb1cbd5e
+	github.com/spf13/cobra v0.0.3
+	github.com/spf13/pflag v1.0.3
+	github.com/spf13/viper v1.2.1
+	github.com/stretchr/testify v1.2.2
+	github.com/vivint/infectious v0.0.0-20180906161625-e155e6eb3575
+	github.com/zeebo/admission v0.0.0-20180821192747-f24f2a94a40c
+	github.com/zeebo/errs v1.1.0
+	go.uber.org/zap v1.9.1
+	golang.org/x/crypto v0.0.0-20190103213133-ff983b9c42bc
+	golang.org/x/net v0.0.0-20181106065722-10aee1819953
+	golang.org/x/sync v0.0.0-20181221193216-37e7f081c4d4
+	golang.org/x/sys v0.0.0-20190108104531-7fbe1cd0fcc2
+	golang.org/x/tools v0.0.0-20181221235234-d00ac6d27372
+	google.golang.org/grpc v1.16.0
+	gopkg.in/spacemonkeygo/monkit.v2 v2.0.0-20180827161543-6ebf5a752f9b
 )
 
-exclude gopkg.in/olivere/elastic.v5 v5.0.72 // buggy import, see https://github.com/olivere/elastic/pull/869
-
 require (
+	cloud.google.com/go v0.27.0 // indirect
+	contrib.go.opencensus.io/exporter/stackdriver v0.6.0 // indirect
+	git.apache.org/thrift.git v0.0.0-20180807212849-6e67faa92827 // indirect
 	github.com/BurntSushi/toml v0.3.1 // indirect
-	github.com/Shopify/go-lua v0.0.0-20181106184032-48449c60c0a9
+	github.com/Microsoft/go-winio v0.4.11 // indirect
 	github.com/Shopify/toxiproxy v2.1.3+incompatible // indirect
+	github.com/Sirupsen/logrus v1.0.6 // indirect
 	github.com/StackExchange/wmi v0.0.0-20180725035823-b12b22c5341f // indirect
 	github.com/alicebob/gopher-json v0.0.0-20180125190556-5a6b3ba71ee6 // indirect
-	github.com/alicebob/miniredis v0.0.0-20180911162847-3657542c8629
 	github.com/armon/go-metrics v0.0.0-20180917152333-f0300d1749da // indirect
-	github.com/boltdb/bolt v1.3.1
-	github.com/cheggaaa/pb v1.0.5-0.20160713104425-73ae1d68fe0b
+	github.com/aws/aws-sdk-go v1.15.34 // indirect
+	github.com/beorn7/perks v0.0.0-20180321164747-3a771d992973 // indirect
+	github.com/bitly/go-hostpool v0.0.0-20171023180738-a3a6125de932 // indirect
+	github.com/bmizerany/assert v0.0.0-20160611221934-b7ed37b82869 // indirect
+	github.com/client9/misspell v0.3.4 // indirect
+	github.com/cloudfoundry/gosigar v1.1.0 // indirect
+	github.com/cockroachdb/cockroach-go v0.0.0-20180212155653-59c0560478b7 // indirect
+	github.com/cznic/b v0.0.0-20180115125044-35e9bbe41f07 // indirect
+	github.com/cznic/fileutil v0.0.0-20180108211300-6a051e75936f // indirect
+	github.com/cznic/golex v0.0.0-20170803123110-4ab7c5e190e4 // indirect
+	github.com/cznic/internal v0.0.0-20180608152220-f44710a21d00 // indirect
+	github.com/cznic/lldb v1.1.0 // indirect
+	github.com/cznic/mathutil v0.0.0-20180504122225-ca4c9f2c1369 // indirect
+	github.com/cznic/ql v1.2.0 // indirect
+	github.com/cznic/sortutil v0.0.0-20150617083342-4c7342852e65 // indirect
+	github.com/cznic/strutil v0.0.0-20171016134553-529a34b1c186 // indirect
+	github.com/cznic/zappy v0.0.0-20160723133515-2533cb5b45cc // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible // indirect
 	github.com/djherbis/atime v1.0.0 // indirect
+	github.com/docker/distribution v0.0.0-20180720172123-0dae0957e5fe // indirect
+	github.com/docker/docker v0.0.0-20170502054910-90d35abf7b35 // indirect
+	github.com/docker/go-connections v0.4.0 // indirect
+	github.com/docker/go-units v0.3.3 // indirect
+	github.com/docker/libtrust v0.0.0-20160708172513-aabc10ec26b7 // indirect
 	github.com/dustin/go-humanize v0.0.0-20180713052910-9f541cc9db5d // indirect
 	github.com/eapache/go-resiliency v1.1.0 // indirect
 	github.com/eapache/go-xerial-snappy v0.0.0-20180814174437-776d5712da21 // indirect
 	github.com/eapache/queue v1.1.0 // indirect
 	github.com/eclipse/paho.mqtt.golang v1.1.1 // indirect
+	github.com/edsrzf/mmap-go v0.0.0-20170320065105-0bce6a688712 // indirect
 	github.com/elazarl/go-bindata-assetfs v1.0.0 // indirect
-	github.com/fatih/color v1.7.0
 	github.com/fatih/structs v1.0.0 // indirect
-	github.com/go-redis/redis v6.14.1+incompatible
-	github.com/gogo/protobuf v1.1.2-0.20181116123445-07eab6a8298c
-	github.com/golang-migrate/migrate/v3 v3.5.2
-	github.com/golang/mock v1.2.0
-	github.com/golang/protobuf v1.2.0
+	github.com/fortytw2/leaktest v1.2.0 // indirect
+	github.com/fsnotify/fsnotify v1.4.7 // indirect
+	github.com/fsouza/fake-gcs-server v1.2.0 // indirect
+	github.com/garyburd/redigo v1.0.1-0.20170216214944-0d253a66e6e1 // indirect
+	github.com/go-ini/ini v1.38.2 // indirect
+	github.com/go-ole/go-ole v1.2.1 // indirect
+	github.com/go-sql-driver/mysql v1.4.0 // indirect
+	github.com/gocql/gocql v0.0.0-20180913072538-864d5908455a // indirect
+	github.com/golang/glog v0.0.0-20160126235308-23def4e6c14b // indirect
+	github.com/golang/lint v0.0.0-20180702182130-06c8688daad7 // indirect
+	github.com/golang/snappy v0.0.0-20180518054509-2e65f85255db // indirect
 	github.com/gomodule/redigo v2.0.0+incompatible // indirect
-	github.com/google/go-cmp v0.2.0
+	github.com/google/go-github v17.0.0+incompatible // indirect
+	github.com/google/go-querystring v0.0.0-20170111101155-53e6ce116135 // indirect
+	github.com/google/martian v2.0.0-beta.2+incompatible // indirect
+	github.com/googleapis/gax-go v2.0.0+incompatible // indirect
+	github.com/gopherjs/gopherjs v0.0.0-20180825215210-0210a2f0f73c // indirect
+	github.com/gorilla/context v1.1.1 // indirect
 	github.com/gorilla/handlers v1.4.0 // indirect
+	github.com/gorilla/mux v1.6.2 // indirect
 	github.com/gorilla/rpc v1.1.0 // indirect
-	github.com/gtank/cryptopasta v0.0.0-20170601214702-1f550f6f2f69
+	github.com/hailocab/go-hostpool v0.0.0-20160125115350-e80d13ce29ed // indirect
 	github.com/hashicorp/go-immutable-radix v1.0.0 // indirect
 	github.com/hashicorp/go-msgpack v0.0.0-20150518234257-fa3f63826f7c // indirect
+	github.com/hashicorp/go-uuid v1.0.0 // indirect
+	github.com/hashicorp/golang-lru v0.5.0 // indirect
+	github.com/hashicorp/hcl v1.0.0 // indirect
 	github.com/hashicorp/raft v1.0.0 // indirect
 	github.com/howeyc/gopass v0.0.0-20170109162249-bf9dde6d0d2c // indirect
+	github.com/hpcloud/tail v1.0.0 // indirect
 	github.com/inconshreveable/go-update v0.0.0-20160112193335-8152e7eb6ccf // indirect
-	github.com/jbenet/go-base58 v0.0.0-20150317085156-6237cf65f3a6
-	github.com/jtolds/go-luar v0.0.0-20170419063437-0786921db8c0
-	github.com/jtolds/monkit-hw v0.0.0-20190108155550-0f753668cf20
+	github.com/inconshreveable/mousetrap v1.0.0 // indirect
+	github.com/jmespath/go-jmespath v0.0.0-20180206201540-c2b33e8439af // indirect
+	github.com/jtolds/gls v4.2.1+incompatible // indirect
+	github.com/kisielk/gotool v1.0.0 // indirect
 	github.com/klauspost/cpuid v0.0.0-20180405133222-e7e905edc00e // indirect
 	github.com/klauspost/reedsolomon v0.0.0-20180704173009-925cb01d6510 // indirect
-	github.com/lib/pq v1.0.0
-	github.com/loov/hrtime v0.0.0-20181214195526-37a208e8344e
-	github.com/loov/plot v0.0.0-20180510142208-e59891ae1271
+	github.com/kr/pretty v0.1.0 // indirect
+	github.com/kr/pty v1.1.1 // indirect
+	github.com/kr/text v0.1.0 // indirect
+	github.com/kshvakov/clickhouse v1.3.4 // indirect
+	github.com/magiconair/properties v1.8.0 // indirect
+	github.com/mailru/easyjson v0.0.0-20180730094502-03f2033d19d5 // indirect
+	github.com/mattn/go-colorable v0.0.9 // indirect
 	github.com/mattn/go-isatty v0.0.4 // indirect
 	github.com/mattn/go-runewidth v0.0.3 // indirect
-	github.com/mattn/go-sqlite3 v1.10.0
-	github.com/minio/cli v1.3.0
+	github.com/matttproud/golang_protobuf_extensions v1.0.1 // indirect
 	github.com/minio/dsync v0.0.0-20180124070302-439a0961af70 // indirect
 	github.com/minio/highwayhash v0.0.0-20180501080913-85fc8a2dacad // indirect
 	github.com/minio/lsync v0.0.0-20180328070428-f332c3883f63 // indirect
 	github.com/minio/mc v0.0.0-20180926130011-a215fbb71884 // indirect
-	github.com/minio/minio-go v6.0.3+incompatible
 	github.com/minio/sha256-simd v0.0.0-20171213220625-ad98a36ba0da // indirect
 	github.com/minio/sio v0.0.0-20180327104954-6a41828a60f0 // indirect
 	github.com/mitchellh/go-homedir v0.0.0-20180801233206-58046073cbff // indirect
-	github.com/mr-tron/base58 v0.0.0-20180922112544-9ad991d48a42
+	github.com/mitchellh/mapstructure v1.1.1 // indirect
 	github.com/nats-io/gnatsd v1.3.0 // indirect
 	github.com/nats-io/go-nats v1.6.0 // indirect
 	github.com/nats-io/go-nats-streaming v0.4.0 // indirect
 	github.com/nats-io/nats v1.6.0 // indirect
 	github.com/nats-io/nats-streaming-server v0.11.0 // indirect
 	github.com/nats-io/nuid v1.0.0 // indirect
-	github.com/nsf/jsondiff v0.0.0-20160203110537-7de28ed2b6e3
+	github.com/onsi/ginkgo v1.6.0 // indirect
+	github.com/onsi/gomega v1.4.2 // indirect
+	github.com/opencontainers/go-digest v1.0.0-rc1 // indirect
+	github.com/openzipkin/zipkin-go v0.1.1 // indirect
 	github.com/pascaldekloe/goe v0.0.0-20180627143212-57f6aae5913c // indirect
+	github.com/pelletier/go-toml v1.2.0 // indirect
 	github.com/pierrec/lz4 v2.0.5+incompatible // indirect
 	github.com/pkg/errors v0.8.1 // indirect
 	github.com/pkg/profile v1.2.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/prometheus/client_golang v0.9.0-pre1.0.20180416233856-82f5ff156b29 // indirect
+	github.com/prometheus/client_model v0.0.0-20180712105110-5c3871d89910 // indirect
+	github.com/prometheus/common v0.0.0-20180801064454-c7de2306084e // indirect
+	github.com/prometheus/procfs v0.0.0-20180725123919-05ee40e3a273 // indirect
 	github.com/rcrowley/go-metrics v0.0.0-20180503174638-e2704e165165 // indirect
 	github.com/rs/cors v1.5.0 // indirect
-	github.com/shirou/gopsutil v2.17.12+incompatible
-	github.com/skyrings/skyring-common v0.0.0-20160929130248-d1c0bb1cbd5e
+	github.com/segmentio/go-prompt v1.2.1-0.20161017233205-f0d19b6901ad // indirect
+	github.com/sirupsen/logrus v1.0.6 // indirect
+	github.com/smartystreets/assertions v0.0.0-20180820201707-7c9eb446e3cf // indirect
+	github.com/smartystreets/go-aws-auth v0.0.0-20180515143844-0c1422d1fdb9 // indirect
+	github.com/smartystreets/goconvey v0.0.0-20180222194500-ef6db91d284a // indirect
 	github.com/spacemonkeygo/errors v0.0.0-20171212215202-9064522e9fd1 // indirect
-	github.com/spf13/cobra v0.0.3
-	github.com/spf13/pflag v1.0.3
-	github.com/spf13/viper v1.2.1
+	github.com/spacemonkeygo/monotime v0.0.0-20180824235756-e3f48a95f98a // indirect
+	github.com/spacemonkeygo/spacelog v0.0.0-20180420211403-2296661a0572 // indirect
+	github.com/spf13/afero v1.1.2 // indirect
+	github.com/spf13/cast v1.2.0 // indirect
+	github.com/spf13/jwalterweatherman v1.0.0 // indirect
 	github.com/streadway/amqp v0.0.0-20180806233856-70e15c650864 // indirect
 	github.com/stretchr/objx v0.1.1 // indirect
-	github.com/stretchr/testify v1.2.2
 	github.com/tidwall/gjson v1.1.3 // indirect
 	github.com/tidwall/match v0.0.0-20171002075945-1731857f09b1 // indirect
-	github.com/vivint/infectious v0.0.0-20180906161625-e155e6eb3575
 	github.com/yuin/gopher-lua v0.0.0-20180918061612-799fa34954fb // indirect
-	github.com/zeebo/admission v0.0.0-20180821192747-f24f2a94a40c
-	github.com/zeebo/errs v1.1.0
 	github.com/zeebo/float16 v0.1.0 // indirect
 	github.com/zeebo/incenc v0.0.0-20180505221441-0d92902eec54 // indirect
+	go.opencensus.io v0.16.0 // indirect
 	go.uber.org/atomic v1.3.2 // indirect
 	go.uber.org/multierr v1.1.0 // indirect
-	go.uber.org/zap v1.9.1
-	golang.org/x/crypto v0.0.0-20190103213133-ff983b9c42bc
-	golang.org/x/net v0.0.0-20181106065722-10aee1819953
-	golang.org/x/sync v0.0.0-20181221193216-37e7f081c4d4
-	golang.org/x/sys v0.0.0-20190108104531-7fbe1cd0fcc2
+	golang.org/x/lint v0.0.0-20180702182130-06c8688daad7 // indirect
+	golang.org/x/oauth2 v0.0.0-20180821212333-d2e6202438be // indirect
+	golang.org/x/text v0.3.0 // indirect
 	golang.org/x/time v0.0.0-20180412165947-fbb02b2291d2 // indirect
-	golang.org/x/tools v0.0.0-20181221235234-d00ac6d27372
+	google.golang.org/api v0.0.0-20180910000450-7ca32eb868bf // indirect
+	google.golang.org/appengine v1.1.0 // indirect
 	google.golang.org/genproto v0.0.0-20181221175505-bd9b4fb69e2f // indirect
-	google.golang.org/grpc v1.16.0
 	gopkg.in/Shopify/sarama.v1 v1.18.0 // indirect
+	gopkg.in/airbrake/gobrake.v2 v2.0.9 // indirect
+	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127 // indirect
 	gopkg.in/cheggaaa/pb.v1 v1.0.25 // indirect
+	gopkg.in/fsnotify.v1 v1.4.7 // indirect
+	gopkg.in/gemnasium/logrus-airbrake-hook.v2 v2.1.2 // indirect
+	gopkg.in/inf.v0 v0.9.1 // indirect
+	gopkg.in/ini.v1 v1.38.2 // indirect
 	gopkg.in/olivere/elastic.v5 v5.0.76 // indirect
-	gopkg.in/spacemonkeygo/monkit.v2 v2.0.0-20180827161543-6ebf5a752f9b
+	gopkg.in/tomb.v1 v1.0.0-20141024135613-dd632973f1e7 // indirect
 	gopkg.in/vmihailenco/msgpack.v2 v2.9.1 // indirect
+	gopkg.in/yaml.v2 v2.2.1 // indirect
+	honnef.co/go/tools v0.0.0-20180728063816-88497007e858 // indirect
 )
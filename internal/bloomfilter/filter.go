@@ -0,0 +1,149 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package bloomfilter implements bloom filters of keys such as piece ids,
+// which can be sent to other peers serialized to protobuf
+package bloomfilter
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/pb"
+)
+
+// Error is the default bloom filter errs class
+var Error = errs.Class("bloom filter error")
+
+// MaxHashCount is the largest number of hash functions a filter may use
+const MaxHashCount = 32
+
+// Filter is a bloom filter: it contains every key added to it and, with the
+// false positive rate it was sized for, other keys
+type Filter struct {
+	seed      byte
+	hashCount byte
+	table     []byte
+}
+
+// NewOptimal returns a filter with a random seed, sized for expectedElements
+// keys to be contained with falsePositiveRate
+func NewOptimal(expectedElements int, falsePositiveRate float64) *Filter {
+	if expectedElements < 1 {
+		expectedElements = 1
+	}
+	bits := -float64(expectedElements) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)
+	hashCount := int(math.Ceil(bits / float64(expectedElements) * math.Ln2))
+	if hashCount < 1 {
+		hashCount = 1
+	}
+	if hashCount > MaxHashCount {
+		hashCount = MaxHashCount
+	}
+	return New(byte(rand.Intn(256)), hashCount, int(math.Ceil(bits/8)))
+}
+
+// New returns a filter of sizeInBytes bytes using hashCount hash functions
+// seeded with seed
+func New(seed byte, hashCount, sizeInBytes int) *Filter {
+	if hashCount < 1 {
+		hashCount = 1
+	}
+	if hashCount > MaxHashCount {
+		hashCount = MaxHashCount
+	}
+	if sizeInBytes < 1 {
+		sizeInBytes = 1
+	}
+	return &Filter{
+		seed:      seed,
+		hashCount: byte(hashCount),
+		table:     make([]byte, sizeInBytes),
+	}
+}
+
+// Add adds the key to the filter
+func (filter *Filter) Add(key string) {
+	h1, h2 := filter.hash(key)
+	bits := uint64(len(filter.table)) * 8
+	for i := uint64(0); i < uint64(filter.hashCount); i++ {
+		bit := (h1 + i*h2) % bits
+		filter.table[bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// Contains returns whether the key may have been added to the filter, keys
+// which were added are always contained
+func (filter *Filter) Contains(key string) bool {
+	h1, h2 := filter.hash(key)
+	bits := uint64(len(filter.table)) * 8
+	for i := uint64(0); i < uint64(filter.hashCount); i++ {
+		bit := (h1 + i*h2) % bits
+		if filter.table[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// HashCount returns the number of hash functions of the filter
+func (filter *Filter) HashCount() int { return int(filter.hashCount) }
+
+// Size returns the size of the filter in bytes
+func (filter *Filter) Size() int { return len(filter.table) }
+
+// FalsePositiveRate returns the rate other keys are contained with once
+// elements keys were added
+func (filter *Filter) FalsePositiveRate(elements int) float64 {
+	bits := float64(len(filter.table) * 8)
+	k := float64(filter.hashCount)
+	return math.Pow(1-math.Exp(-k*float64(elements)/bits), k)
+}
+
+// hash returns the two hashes of key the hash functions are derived from.
+// The hashes are the halves of the 64 bit FNV-1a hash of the seed and the
+// key, the second hash is odd so that the derived hashes differ.
+func (filter *Filter) hash(key string) (h1, h2 uint64) {
+	const (
+		offset64 = 14695981039346656037
+		prime64  = 1099511628211
+	)
+	h := uint64(offset64)
+	h ^= uint64(filter.seed)
+	h *= prime64
+	for i := 0; i < len(key); i++ {
+		h ^= uint64(key[i])
+		h *= prime64
+	}
+	return h & math.MaxUint32, (h >> 32) | 1
+}
+
+// ToPB returns the filter serialized to protobuf
+func (filter *Filter) ToPB() *pb.BloomFilter {
+	return &pb.BloomFilter{
+		Seed:      uint32(filter.seed),
+		HashCount: uint32(filter.hashCount),
+		Table:     append([]byte(nil), filter.table...),
+	}
+}
+
+// FromPB returns the filter serialized to msg
+func FromPB(msg *pb.BloomFilter) (*Filter, error) {
+	switch {
+	case msg == nil:
+		return nil, Error.New("missing filter")
+	case msg.Seed > math.MaxUint8:
+		return nil, Error.New("invalid seed %d", msg.Seed)
+	case msg.HashCount < 1 || msg.HashCount > MaxHashCount:
+		return nil, Error.New("invalid hash count %d", msg.HashCount)
+	case len(msg.Table) == 0:
+		return nil, Error.New("empty table")
+	}
+	return &Filter{
+		seed:      byte(msg.Seed),
+		hashCount: byte(msg.HashCount),
+		table:     append([]byte(nil), msg.Table...),
+	}, nil
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package bloomfilter_test
+
+import (
+	"strconv"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/bloomfilter"
+)
+
+func TestFilterContainsAdded(t *testing.T) {
+	// property: every added key is contained, whatever the parameters
+	property := func(seed byte, hashCount uint8, size uint16, keys []string) bool {
+		filter := bloomfilter.New(seed, int(hashCount%bloomfilter.MaxHashCount)+1, int(size%4096)+1)
+		for _, key := range keys {
+			filter.Add(key)
+		}
+		for _, key := range keys {
+			if !filter.Contains(key) {
+				return false
+			}
+		}
+		return true
+	}
+	require.NoError(t, quick.Check(property, nil))
+}
+
+func TestFilterPB(t *testing.T) {
+	// property: serialized filters contain the same keys
+	property := func(added []string, other []string) bool {
+		filter := bloomfilter.NewOptimal(len(added), 0.1)
+		for _, key := range added {
+			filter.Add(key)
+		}
+		decoded, err := bloomfilter.FromPB(filter.ToPB())
+		if err != nil {
+			return false
+		}
+		for _, key := range append(added, other...) {
+			if decoded.Contains(key) != filter.Contains(key) {
+				return false
+			}
+		}
+		return true
+	}
+	require.NoError(t, quick.Check(property, nil))
+
+	_, err := bloomfilter.FromPB(nil)
+	assert.Error(t, err)
+	invalid := bloomfilter.New(1, 2, 8).ToPB()
+	invalid.HashCount = bloomfilter.MaxHashCount + 1
+	_, err = bloomfilter.FromPB(invalid)
+	assert.Error(t, err)
+	invalid = bloomfilter.New(1, 2, 8).ToPB()
+	invalid.Table = nil
+	_, err = bloomfilter.FromPB(invalid)
+	assert.Error(t, err)
+}
+
+func TestFilterFalsePositiveRate(t *testing.T) {
+	const elements = 10000
+	for _, rate := range []float64{0.1, 0.01} {
+		filter := bloomfilter.NewOptimal(elements, rate)
+		for i := 0; i < elements; i++ {
+			filter.Add("added" + strconv.Itoa(i))
+		}
+		assert.InDelta(t, rate, filter.FalsePositiveRate(elements), rate/2)
+
+		falsePositives := 0
+		for i := 0; i < elements; i++ {
+			if filter.Contains("other" + strconv.Itoa(i)) {
+				falsePositives++
+			}
+		}
+		assert.InDelta(t, rate, float64(falsePositives)/elements, rate, "rate %v", rate)
+	}
+}
+
+func BenchmarkFilterAdd(b *testing.B) {
+	filter := bloomfilter.NewOptimal(b.N, 0.1)
+	keys := benchmarkKeys(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		filter.Add(keys[i%len(keys)])
+	}
+}
+
+func BenchmarkFilterContains(b *testing.B) {
+	filter := bloomfilter.NewOptimal(1000, 0.1)
+	keys := benchmarkKeys(1000)
+	for _, key := range keys[:500] {
+		filter.Add(key)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = filter.Contains(keys[i%len(keys)])
+	}
+}
+
+func benchmarkKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = "piece-" + strconv.Itoa(i) + "-5QpfHLDNYmG6EVy6GZ8CHz8fskMW7vQ9"
+	}
+	return keys
+}
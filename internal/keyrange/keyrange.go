@@ -0,0 +1,58 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package keyrange splits keyspaces into ranges, which can be iterated on
+// their own, e.g. concurrently or spread over time
+package keyrange
+
+// MaxRanges is the most ranges a keyspace is split into
+const MaxRanges = 1 << 16
+
+// Range is the keys from Start, inclusive, to End, exclusive. An empty End
+// is unbounded.
+type Range struct {
+	Start string
+	End   string
+}
+
+// Contains returns whether key is in the range
+func (r Range) Contains(key string) bool {
+	return key >= r.Start && (r.End == "" || key < r.End)
+}
+
+// Split splits the keys starting with prefix into n consecutive ranges, which
+// are of about the same size when the two bytes following prefix are
+// uniformly distributed, e.g. for keys ending in random ids. Every key
+// starting with prefix is in exactly one range; n is capped to MaxRanges.
+func Split(prefix string, n int) []Range {
+	if n < 1 {
+		n = 1
+	}
+	if n > MaxRanges {
+		n = MaxRanges
+	}
+
+	ranges := make([]Range, n)
+	ranges[0].Start = prefix
+	for i := 1; i < n; i++ {
+		boundary := uint32(i * MaxRanges / n)
+		key := prefix + string([]byte{byte(boundary >> 8), byte(boundary)})
+		ranges[i-1].End = key
+		ranges[i].Start = key
+	}
+	ranges[n-1].End = PrefixEnd(prefix)
+	return ranges
+}
+
+// PrefixEnd returns the first key after the keys starting with prefix, empty
+// when there's none
+func PrefixEnd(prefix string) string {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return string(end[:i+1])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package keyrange_test
+
+import (
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/keyrange"
+)
+
+func TestSplitPartitions(t *testing.T) {
+	// property: every key starting with the prefix is in exactly one range,
+	// other keys are in none
+	property := func(prefix string, n uint16, key string) bool {
+		ranges := keyrange.Split(prefix, int(n))
+		for _, key := range []string{key, prefix + key} {
+			containing := 0
+			for _, r := range ranges {
+				if r.Contains(key) {
+					containing++
+				}
+			}
+			expected := 0
+			if strings.HasPrefix(key, prefix) {
+				expected = 1
+			}
+			if containing != expected {
+				return false
+			}
+		}
+		return true
+	}
+	require.NoError(t, quick.Check(property, nil))
+}
+
+func TestSplit(t *testing.T) {
+	assert.Equal(t, []keyrange.Range{{Start: "p/", End: "p0"}}, keyrange.Split("p/", 0))
+	assert.Equal(t, []keyrange.Range{
+		{Start: "p/", End: "p/\x80\x00"},
+		{Start: "p/\x80\x00", End: "p0"},
+	}, keyrange.Split("p/", 2))
+	assert.Len(t, keyrange.Split("", keyrange.MaxRanges+1), keyrange.MaxRanges)
+
+	// the ranges of random keys are about the same size
+	ranges := keyrange.Split("", 4)
+	counts := make([]int, len(ranges))
+	for i := 0; i < 1<<16; i += 7 {
+		key := string([]byte{byte(i >> 8), byte(i), 'x'})
+		for j, r := range ranges {
+			if r.Contains(key) {
+				counts[j]++
+			}
+		}
+	}
+	for _, count := range counts {
+		assert.InDelta(t, counts[0], count, 1)
+	}
+}
+
+func TestPrefixEnd(t *testing.T) {
+	assert.Equal(t, "b", keyrange.PrefixEnd("a"))
+	assert.Equal(t, "b", keyrange.PrefixEnd("a\xff"))
+	assert.Equal(t, "", keyrange.PrefixEnd("\xff\xff"))
+	assert.Equal(t, "", keyrange.PrefixEnd(""))
+}
+
+func BenchmarkSplit(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = keyrange.Split("l/bucket/", 256)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"storj.io/storj/internal/memory"
 	"storj.io/storj/pkg/bwagreement"
 	"storj.io/storj/pkg/datarepair/checker"
+	"storj.io/storj/pkg/datarepair/rebalancer"
 	"storj.io/storj/pkg/datarepair/repairer"
 	"storj.io/storj/pkg/discovery"
 	"storj.io/storj/pkg/kademlia"
@@ -33,8 +34,11 @@ import (
 	"storj.io/storj/pkg/piecestore/psserver"
 	"storj.io/storj/pkg/pointerdb"
 	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/retryqueue"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
 	"storj.io/storj/pkg/utils"
+	"storj.io/storj/pkg/watchdog"
 	"storj.io/storj/satellite"
 	"storj.io/storj/satellite/satellitedb"
 	"storj.io/storj/storagenode"
@@ -140,6 +144,14 @@ func (planet *Planet) Start(ctx context.Context) {
 	}
 
 	planet.started = true
+
+	// wait until the network is ready, bootstrap retries until ctx is canceled
+	for _, satellite := range planet.Satellites {
+		_ = satellite.Kademlia.Service.WaitForBootstrap(ctx)
+	}
+	for _, storageNode := range planet.StorageNodes {
+		_ = storageNode.Kademlia.WaitForBootstrap(ctx)
+	}
 }
 
 // Size returns number of nodes in the network
@@ -221,13 +233,21 @@ func (planet *Planet) newSatellites(count int) ([]*satellite.Peer, error) {
 
 		config := satellite.Config{
 			PublicAddress: "127.0.0.1:0",
+			HealthAddress: "127.0.0.1:0",
 			Kademlia: kademlia.Config{
-				Alpha:  5,
-				DBPath: storageDir, // TODO: replace with master db
+				BootstrapBackoff: time.Second,
+				Alpha:            5,
+				DBPath:           storageDir, // TODO: replace with master db
+				BucketSize:       20,
+				ReplacementCache: 5,
 				Operator: kademlia.OperatorConfig{
 					Email:  prefix + "@example.com",
 					Wallet: "0x" + strings.Repeat("00", 20),
 				},
+				Refresh: kademlia.RefreshConfig{
+					Interval:  time.Minute,
+					Staleness: time.Hour,
+				},
 			},
 			Overlay: overlay.Config{
 				RefreshInterval: 30 * time.Second,
@@ -236,21 +256,44 @@ func (planet *Planet) newSatellites(count int) ([]*satellite.Peer, error) {
 					UptimeCount:       0,
 					AuditSuccessRatio: 0,
 					AuditCount:        0,
+					AddressValidity:   15 * time.Minute,
+				},
+				Vetting: overlay.VettingConfig{
+					NewNodePercentage: 0.05,
+					Interval:          30 * time.Second,
+				},
+				Network: overlay.NetworkConfig{
+					Interval:   30 * time.Second,
+					CountryTag: "country",
 				},
 			},
 			Discovery: discovery.Config{
 				RefreshInterval: 1 * time.Second,
+				Partition: discovery.PartitionConfig{
+					Interval:  0,
+					Samples:   8,
+					K:         20,
+					Threshold: 0.5,
+				},
 			},
 			PointerDB: pointerdb.Config{
-				DatabaseURL:          "bolt://" + filepath.Join(storageDir, "pointers.db"),
+				DatabaseURL:          "memory://",
 				MinRemoteSegmentSize: 0, // TODO: fix tests to work with 1024
 				MaxInlineSegmentSize: 8000,
 				Overlay:              true,
 				BwExpiration:         45,
+				CompressPointers:     memory.KiB,
+				ChecksumPointers:     true,
+				AddressValidity:      15 * time.Minute,
+				PurgeInterval:        30 * time.Second,
+				ReapInterval:         30 * time.Second,
+				DeletionWorkers:      2,
+				DeletionRetry:        retryqueue.DefaultConfig,
 			},
 			BwAgreement: bwagreement.Config{},
 			Checker: checker.Config{
-				Interval: 30 * time.Second,
+				Interval:             30 * time.Second,
+				LostPiecesExpiration: time.Hour,
 			},
 			Repairer: repairer.Config{
 				MaxRepair:     10,
@@ -259,8 +302,21 @@ func (planet *Planet) newSatellites(count int) ([]*satellite.Peer, error) {
 				PointerDBAddr: "", // overridden in satellite.New
 				MaxBufferMem:  4 * memory.MB,
 				APIKey:        "",
+				Retry:         retryqueue.DefaultConfig,
+			},
+			Rebalancer: rebalancer.Config{
+				Interval:  30 * time.Second,
+				RegionTag: "country",
 			},
 			// TODO: Audit    audit.Config
+			Watchdog: watchdog.Config{
+				Interval: time.Minute,
+				MaxStall: time.Hour,
+			},
+			Contacts: transport.ContactsConfig{
+				MaxConnections: 100,
+				MaxPerNode:     4,
+			},
 		}
 
 		peer, err := satellite.New(log, identity, db, &config)
@@ -313,18 +369,33 @@ func (planet *Planet) newStorageNodes(count int) ([]*storagenode.Peer, error) {
 		config := storagenode.Config{
 			PublicAddress: "127.0.0.1:0",
 			Kademlia: kademlia.Config{
-				Alpha:  5,
-				DBPath: storageDir, // TODO: replace with master db
+				BootstrapBackoff: time.Second,
+				Alpha:            5,
+				DBPath:           storageDir, // TODO: replace with master db
+				BucketSize:       20,
+				ReplacementCache: 5,
 				Operator: kademlia.OperatorConfig{
 					Email:  prefix + "@example.com",
 					Wallet: "0x" + strings.Repeat("00", 20),
 				},
+				Refresh: kademlia.RefreshConfig{
+					Interval:  time.Minute,
+					Staleness: time.Hour,
+				},
 			},
 			Storage: psserver.Config{
 				Path:                   "", // TODO: this argument won't be needed with master storagenodedb
 				AllocatedDiskSpace:     memory.TB,
 				AllocatedBandwidth:     memory.TB,
 				KBucketRefreshInterval: time.Minute,
+				Cache: psserver.CacheConfig{
+					Size:         4 * memory.MiB,
+					MaxPieceSize: memory.MiB,
+				},
+				Retain: psserver.RetainConfig{
+					TrashGrace:    time.Hour,
+					EmptyInterval: time.Hour,
+				},
 			},
 		}
 
@@ -0,0 +1,67 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package archive
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/accounting"
+)
+
+// Archive holds the raw tallies and rollups removed from the database
+type Archive struct {
+	CreatedAt     time.Time
+	RawsBefore    time.Time
+	RollupsBefore time.Time
+	Raws          []*accounting.Raw
+	Rollups       []*accounting.Rollup
+}
+
+// Name returns the name the archive is stored under
+func (archive *Archive) Name() string {
+	return "accounting-" + archive.CreatedAt.UTC().Format("20060102T150405Z") + ".json.gz"
+}
+
+// Empty returns whether the archive holds no rows
+func (archive *Archive) Empty() bool {
+	return len(archive.Raws) == 0 && len(archive.Rollups) == 0
+}
+
+// Write writes the archive as gzip compressed json
+func Write(w io.Writer, archive *Archive) (err error) {
+	compressed := gzip.NewWriter(w)
+	defer func() { err = errs.Combine(err, Error.Wrap(compressed.Close())) }()
+	return Error.Wrap(json.NewEncoder(compressed).Encode(archive))
+}
+
+// Read reads an archive written by Write
+func Read(r io.Reader) (_ *Archive, err error) {
+	compressed, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(compressed.Close())) }()
+
+	archive := &Archive{}
+	if err := json.NewDecoder(compressed).Decode(archive); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return archive, nil
+}
+
+// Import inserts the rows of the archive into db, e.g. a database set up
+// for analyzing the history
+func Import(ctx context.Context, db accounting.DB, archive *Archive) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if err := db.ImportRaws(ctx, archive.Raws); err != nil {
+		return Error.Wrap(err)
+	}
+	return Error.Wrap(db.ImportRollups(ctx, archive.Rollups))
+}
@@ -0,0 +1,140 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package archive_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/accounting"
+	"storj.io/storj/pkg/accounting/archive"
+	"storj.io/storj/satellite/satellitedb"
+)
+
+func newArchive() *archive.Archive {
+	now := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &archive.Archive{
+		CreatedAt:     now,
+		RawsBefore:    now.Add(-time.Hour),
+		RollupsBefore: now.Add(-24 * time.Hour),
+		Raws: []*accounting.Raw{{
+			ID:              1,
+			NodeID:          teststorj.NodeIDFromString("node"),
+			IntervalEndTime: now.Add(-2 * time.Hour),
+			DataTotal:       100,
+			DataType:        accounting.AtRest,
+			CreatedAt:       now.Add(-2 * time.Hour),
+		}},
+		Rollups: []*accounting.Rollup{{
+			ID:          2,
+			NodeID:      teststorj.NodeIDFromString("node"),
+			StartTime:   now.Add(-48 * time.Hour),
+			PutTotal:    1,
+			GetTotal:    2,
+			AtRestTotal: 3,
+		}},
+	}
+}
+
+func TestDirectory(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	exported := newArchive()
+	assert.Equal(t, "accounting-20190102T030405Z.json.gz", exported.Name())
+
+	dir := ctx.Dir("archives")
+	destination, err := archive.Open(dir)
+	require.NoError(t, err)
+	require.NoError(t, destination.Put(ctx, exported))
+
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1, "no temporary files are left behind")
+
+	loaded, err := archive.Load(ctx, "file://"+filepath.Join(dir, exported.Name()))
+	require.NoError(t, err)
+	assert.Equal(t, exported, loaded)
+
+	_, err = archive.Open("ftp://example.com/archives")
+	assert.Error(t, err)
+}
+
+func TestBucket(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	var mu sync.Mutex
+	objects := make(map[string][]byte)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			data, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			objects[r.URL.Path] = data
+		case http.MethodGet:
+			data, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		}
+	}))
+	defer server.Close()
+
+	exported := newArchive()
+	destination, err := archive.Open(server.URL + "/archives/")
+	require.NoError(t, err)
+	require.NoError(t, destination.Put(ctx, exported))
+	assert.Contains(t, objects, "/archives/"+exported.Name())
+
+	loaded, err := archive.Load(ctx, server.URL+"/archives/"+exported.Name())
+	require.NoError(t, err)
+	assert.Equal(t, exported, loaded)
+
+	_, err = archive.Load(ctx, server.URL+"/archives/missing.json.gz")
+	assert.Error(t, err)
+}
+
+func TestImport(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	db, err := satellitedb.NewInMemory()
+	require.NoError(t, err)
+	defer ctx.Check(db.Close)
+	require.NoError(t, db.CreateTables())
+
+	exported := newArchive()
+	require.NoError(t, archive.Import(ctx, db.Accounting(), exported))
+
+	raws, err := db.Accounting().GetRaw(ctx)
+	require.NoError(t, err)
+	require.Len(t, raws, 1)
+	assert.Equal(t, exported.Raws[0].NodeID, raws[0].NodeID)
+	assert.Equal(t, exported.Raws[0].DataTotal, raws[0].DataTotal)
+	assert.True(t, exported.Raws[0].CreatedAt.Equal(raws[0].CreatedAt), "creation times are kept")
+
+	rollups, err := db.Accounting().GetRollupsBefore(ctx, exported.RollupsBefore)
+	require.NoError(t, err)
+	require.Len(t, rollups, 1)
+	assert.Equal(t, exported.Rollups[0].PutTotal, rollups[0].PutTotal)
+	assert.True(t, exported.Rollups[0].StartTime.Equal(rollups[0].StartTime))
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package archive
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zeebo/errs"
+)
+
+// Destination stores archives
+type Destination interface {
+	// Put stores the archive under its name
+	Put(ctx context.Context, archive *Archive) error
+}
+
+// Open returns the destination at location, which is either a directory or
+// a http(s) URL of a bucket accepting PUT requests
+func Open(location string) (Destination, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	switch u.Scheme {
+	case "http", "https":
+		return &bucket{url: strings.TrimSuffix(location, "/")}, nil
+	case "file":
+		return &directory{path: u.Path}, nil
+	case "":
+		return &directory{path: location}, nil
+	default:
+		return nil, Error.New("unsupported archive location %q", location)
+	}
+}
+
+// Load reads the archive at location, which is either a file or a http(s) URL
+func Load(ctx context.Context, location string) (_ *Archive, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var data io.ReadCloser
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		req, err := http.NewRequest(http.MethodGet, location, nil)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, errs.Combine(Error.New("getting %s: %s", location, resp.Status), Error.Wrap(resp.Body.Close()))
+		}
+		data = resp.Body
+	} else {
+		data, err = os.Open(strings.TrimPrefix(location, "file://"))
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(data.Close())) }()
+	return Read(data)
+}
+
+// directory stores archives as files in a directory
+type directory struct {
+	path string
+}
+
+// Put writes the archive to a file in the directory
+func (dir *directory) Put(ctx context.Context, archive *Archive) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if err := os.MkdirAll(dir.path, 0755); err != nil {
+		return Error.Wrap(err)
+	}
+
+	// write to a temporary file first, so that no partial archive is left behind
+	path := filepath.Join(dir.path, archive.Name())
+	file, err := ioutil.TempFile(dir.path, archive.Name()+".tmp")
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	err = Write(file, archive)
+	err = errs.Combine(err, Error.Wrap(file.Close()))
+	if err == nil {
+		err = Error.Wrap(os.Rename(file.Name(), path))
+	}
+	if err != nil {
+		return errs.Combine(err, Error.Wrap(os.Remove(file.Name())))
+	}
+	return nil
+}
+
+// bucket stores archives with PUT requests under a base URL
+type bucket struct {
+	url string
+}
+
+// Put uploads the archive
+func (bucket *bucket) Put(ctx context.Context, archive *Archive) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var data bytes.Buffer
+	if err := Write(&data, archive); err != nil {
+		return err
+	}
+	location := bucket.url + "/" + archive.Name()
+	req, err := http.NewRequest(http.MethodPut, location, &data)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(resp.Body.Close())) }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return Error.New("putting %s: %s", location, resp.Status)
+	}
+	return nil
+}
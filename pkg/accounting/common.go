@@ -40,4 +40,13 @@ type CSVRow struct {
 	GetTotal          int64
 	Date              time.Time
 	Wallet            string
+	WalletFeatures    []string
+}
+
+// ProjectUsage is the storage used by a project, which its quotas are
+// enforced against
+type ProjectUsage struct {
+	StoredBytes int64
+	Objects     int64
+	UpdatedAt   time.Time
 }
@@ -7,6 +7,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/skyrings/skyring-common/tools/uuid"
+
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storj"
 )
@@ -52,8 +54,28 @@ type DB interface {
 	GetRaw(ctx context.Context) ([]*Raw, error)
 	// GetRawSince r retrieves all raw tallies sinces
 	GetRawSince(ctx context.Context, latestRollup time.Time) ([]*Raw, error)
+	// CountRawBefore counts the raw tallies with an interval ending before the time
+	CountRawBefore(ctx context.Context, before time.Time) (int64, error)
+	// GetRawBefore retrieves the raw tallies with an interval ending before the time
+	GetRawBefore(ctx context.Context, before time.Time) ([]*Raw, error)
+	// DeleteRawBefore deletes the raw tallies with an interval ending before the time
+	DeleteRawBefore(ctx context.Context, before time.Time) (int64, error)
+	// ImportRaws inserts archived raw tallies, keeping their creation time
+	ImportRaws(ctx context.Context, raws []*Raw) error
 	// SaveRollup records raw tallies of at rest data to the database
 	SaveRollup(ctx context.Context, latestTally time.Time, stats RollupStats) error
+	// GetRollupsBefore retrieves the rollups starting before the time
+	GetRollupsBefore(ctx context.Context, before time.Time) ([]*Rollup, error)
+	// CountRollupsBefore counts the rollups starting before the time
+	CountRollupsBefore(ctx context.Context, before time.Time) (int64, error)
+	// DeleteRollupsBefore deletes the rollups starting before the time
+	DeleteRollupsBefore(ctx context.Context, before time.Time) (int64, error)
+	// ImportRollups inserts archived rollups
+	ImportRollups(ctx context.Context, rollups []*Rollup) error
+	// GetProjectUsage returns the storage used by the project, zero when it didn't store anything yet
+	GetProjectUsage(ctx context.Context, projectID uuid.UUID) (ProjectUsage, error)
+	// AddProjectUsage adds to the storage used by the project, negative values free storage
+	AddProjectUsage(ctx context.Context, projectID uuid.UUID, storedBytes, objects int64) error
 	// QueryPaymentInfo queries StatDB, Accounting Rollup on nodeID
 	QueryPaymentInfo(ctx context.Context, start time.Time, end time.Time) ([]*CSVRow, error)
 	// Adds records to rollup for testing (TODO: remove before merge)
@@ -0,0 +1,67 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package retention
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/accounting"
+	"storj.io/storj/pkg/accounting/archive"
+	"storj.io/storj/pkg/bwagreement"
+	"storj.io/storj/pkg/lease"
+	"storj.io/storj/pkg/provider"
+)
+
+// Config contains configurable values for retention
+type Config struct {
+	Interval time.Duration `help:"how frequently old bandwidth agreements and raw tallies should be deleted" default:"24h0m0s"`
+	MaxAge   time.Duration `help:"how long bandwidth agreements and raw tallies are kept after they were rolled up, 0 keeps them forever" default:"2160h0m0s"`
+	DryRun   bool          `help:"only count the bandwidth agreements and raw tallies which would be deleted" default:"false"`
+
+	Archive      string        `help:"directory or http(s) URL of a bucket the deleted raw tallies and rollups are exported to, rollups are only deleted when it is set" default:""`
+	RollupMaxAge time.Duration `help:"how long rollups are kept before they are archived, 0 keeps them forever" default:"8760h0m0s"`
+}
+
+// Initialize a retention struct
+func (c Config) initialize(ctx context.Context) (Retention, error) {
+	db, ok := ctx.Value("masterdb").(interface {
+		BandwidthAgreement() bwagreement.DB
+		Accounting() accounting.DB
+	})
+	if !ok {
+		return nil, Error.Wrap(errs.New("unable to get master db instance"))
+	}
+	var destination archive.Destination
+	if c.Archive != "" {
+		var err error
+		destination, err = archive.Open(c.Archive)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+	}
+	return newRetention(zap.L(), db.Accounting(), db.BandwidthAgreement(), destination, c), nil
+}
+
+// Run runs the retention with configured values
+func (c Config) Run(ctx context.Context, server *provider.Provider) (err error) {
+	retention, err := c.initialize(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+
+	go func() {
+		// replicas sharing the database take turns running it
+		if err := lease.LoadFromContext(ctx).Run(ctx, "retention", retention.Run); err != nil {
+			defer cancel()
+			zap.L().Debug("Retention is shutting down", zap.Error(err))
+		}
+	}()
+
+	return server.Run(ctx)
+}
@@ -0,0 +1,200 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package retention
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/accounting"
+	"storj.io/storj/pkg/accounting/archive"
+	"storj.io/storj/pkg/bwagreement"
+)
+
+// Retention is the service for deleting bandwidth agreements and raw tallies
+// which were rolled up long enough ago, and for archiving aged rollups
+type Retention interface {
+	Run(ctx context.Context) error
+}
+
+type retention struct {
+	logger        *zap.Logger
+	ticker        *time.Ticker
+	config        Config
+	accountingDB  accounting.DB
+	bwAgreementDB bwagreement.DB
+	archive       archive.Destination
+}
+
+// Pruned are the number of rows a retention run deleted, or would have
+// deleted in a dry run
+type Pruned struct {
+	Agreements int64
+	Raws       int64
+	Rollups    int64
+}
+
+func newRetention(logger *zap.Logger, accountingDB accounting.DB, bwAgreementDB bwagreement.DB, destination archive.Destination, config Config) *retention {
+	return &retention{
+		logger:        logger,
+		ticker:        time.NewTicker(config.Interval),
+		config:        config,
+		accountingDB:  accountingDB,
+		bwAgreementDB: bwAgreementDB,
+		archive:       destination,
+	}
+}
+
+// Run the retention loop
+func (r *retention) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	for {
+		_, err = r.Prune(ctx, time.Now())
+		if err != nil {
+			r.logger.Error("Prune failed", zap.Error(err))
+		}
+		select {
+		case <-r.ticker.C: // wait for the next interval to happen
+		case <-ctx.Done(): // or the retention is canceled via context
+			return ctx.Err()
+		}
+	}
+}
+
+// Prune deletes the raw tallies which were rolled up and the bandwidth
+// agreements which were tallied and rolled up, when they are older than the
+// maximum age. Nothing is deleted before the first rollup. With an archive,
+// the deleted raw tallies and the rollups older than their maximum age are
+// exported to it first, and nothing is deleted when that fails.
+func (r *retention) Prune(ctx context.Context, now time.Time) (pruned Pruned, err error) {
+	defer mon.Task()(&ctx)(&err)
+	if r.config.MaxAge <= 0 {
+		return pruned, nil
+	}
+
+	lastRollup, isNil, err := r.accountingDB.LastRawTime(ctx, accounting.LastRollup)
+	if err != nil {
+		return pruned, Error.Wrap(err)
+	}
+	if isNil {
+		r.logger.Info("Retention found no rollup yet")
+		return pruned, nil
+	}
+	lastBwTally, isNil, err := r.accountingDB.LastRawTime(ctx, accounting.LastBandwidthTally)
+	if err != nil {
+		return pruned, Error.Wrap(err)
+	}
+
+	// raw tallies ending before the last rollup were rolled up, agreements
+	// created before the last bandwidth tally were tallied
+	rawsBefore := earliest(now.Add(-r.config.MaxAge), lastRollup)
+	var agreementsBefore time.Time
+	if !isNil {
+		agreementsBefore = earliest(rawsBefore, lastBwTally)
+	}
+	var rollupsBefore time.Time
+	if r.archive != nil && r.config.RollupMaxAge > 0 {
+		rollupsBefore = now.Add(-r.config.RollupMaxAge)
+	}
+
+	if r.config.DryRun {
+		pruned, err = r.count(ctx, agreementsBefore, rawsBefore, rollupsBefore)
+		if err != nil {
+			return pruned, Error.Wrap(err)
+		}
+		mon.IntVal("prunable_agreements").Observe(pruned.Agreements)
+		mon.IntVal("prunable_raws").Observe(pruned.Raws)
+		mon.IntVal("prunable_rollups").Observe(pruned.Rollups)
+		r.logger.Sugar().Infof("Retention would delete %d bandwidth agreements created before %s, %d raw tallies ending before %s and %d rollups starting before %s",
+			pruned.Agreements, agreementsBefore, pruned.Raws, rawsBefore, pruned.Rollups, rollupsBefore)
+		return pruned, nil
+	}
+
+	if r.archive != nil {
+		if err := r.export(ctx, now, rawsBefore, rollupsBefore); err != nil {
+			return pruned, Error.Wrap(err)
+		}
+	}
+
+	if !agreementsBefore.IsZero() {
+		pruned.Agreements, err = r.bwAgreementDB.DeleteAgreementsBefore(ctx, agreementsBefore)
+		if err != nil {
+			return pruned, Error.Wrap(err)
+		}
+	}
+	pruned.Raws, err = r.accountingDB.DeleteRawBefore(ctx, rawsBefore)
+	if err != nil {
+		return pruned, Error.Wrap(err)
+	}
+	if !rollupsBefore.IsZero() {
+		pruned.Rollups, err = r.accountingDB.DeleteRollupsBefore(ctx, rollupsBefore)
+		if err != nil {
+			return pruned, Error.Wrap(err)
+		}
+	}
+
+	mon.IntVal("deleted_agreements").Observe(pruned.Agreements)
+	mon.IntVal("deleted_raws").Observe(pruned.Raws)
+	mon.IntVal("deleted_rollups").Observe(pruned.Rollups)
+	r.logger.Sugar().Infof("Retention deleted %d bandwidth agreements created before %s, %d raw tallies ending before %s and %d rollups starting before %s",
+		pruned.Agreements, agreementsBefore, pruned.Raws, rawsBefore, pruned.Rollups, rollupsBefore)
+	return pruned, nil
+}
+
+// export stores the raw tallies and rollups Prune deletes in the archive
+func (r *retention) export(ctx context.Context, now, rawsBefore, rollupsBefore time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	exported := &archive.Archive{
+		CreatedAt:     now,
+		RawsBefore:    rawsBefore,
+		RollupsBefore: rollupsBefore,
+	}
+	exported.Raws, err = r.accountingDB.GetRawBefore(ctx, rawsBefore)
+	if err != nil {
+		return err
+	}
+	if !rollupsBefore.IsZero() {
+		exported.Rollups, err = r.accountingDB.GetRollupsBefore(ctx, rollupsBefore)
+		if err != nil {
+			return err
+		}
+	}
+	if exported.Empty() {
+		return nil
+	}
+
+	if err := r.archive.Put(ctx, exported); err != nil {
+		return err
+	}
+	r.logger.Sugar().Infof("Retention archived %d raw tallies and %d rollups as %s",
+		len(exported.Raws), len(exported.Rollups), exported.Name())
+	return nil
+}
+
+// count counts the rows Prune would delete
+func (r *retention) count(ctx context.Context, agreementsBefore, rawsBefore, rollupsBefore time.Time) (pruned Pruned, err error) {
+	if !agreementsBefore.IsZero() {
+		pruned.Agreements, err = r.bwAgreementDB.CountAgreementsBefore(ctx, agreementsBefore)
+		if err != nil {
+			return pruned, err
+		}
+	}
+	pruned.Raws, err = r.accountingDB.CountRawBefore(ctx, rawsBefore)
+	if err != nil || rollupsBefore.IsZero() {
+		return pruned, err
+	}
+	pruned.Rollups, err = r.accountingDB.CountRollupsBefore(ctx, rollupsBefore)
+	return pruned, err
+}
+
+// earliest returns the earlier of the two times
+func earliest(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
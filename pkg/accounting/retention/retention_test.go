@@ -0,0 +1,161 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package retention
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/accounting"
+	"storj.io/storj/pkg/accounting/archive"
+	"storj.io/storj/pkg/bwagreement"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/satellite/satellitedb"
+)
+
+func TestPrune(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	db, err := satellitedb.NewInMemory()
+	require.NoError(t, err)
+	defer ctx.Check(db.Close)
+	require.NoError(t, db.CreateTables())
+
+	accountingDB, bwAgreementDB := db.Accounting(), db.BandwidthAgreement()
+	config := Config{Interval: time.Hour, MaxAge: 24 * time.Hour}
+	r := newRetention(zap.NewNop(), accountingDB, bwAgreementDB, nil, config)
+	dryRun := newRetention(zap.NewNop(), accountingDB, bwAgreementDB, nil, Config{Interval: time.Hour, MaxAge: 24 * time.Hour, DryRun: true})
+
+	for _, serial := range []string{"first", "second"} {
+		err := bwAgreementDB.CreateAgreement(ctx, serial, bwagreement.Agreement{
+			Agreement: []byte(serial),
+			Signature: []byte(serial),
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+	}
+	later := time.Now().Add(48 * time.Hour)
+
+	// nothing is deleted before the first rollup
+	pruned, err := r.Prune(ctx, later)
+	require.NoError(t, err)
+	assert.Equal(t, Pruned{}, pruned)
+
+	tallied := time.Now().UTC().Add(time.Minute)
+	var bwTotals accounting.BWTally
+	bwTotals[pb.PayerBandwidthAllocation_PUT] = map[storj.NodeID]int64{teststorj.NodeIDFromString("node"): 100}
+	require.NoError(t, accountingDB.SaveBWRaw(ctx, tallied, true, bwTotals))
+
+	rolledUp := tallied.Add(time.Minute)
+	stats := accounting.RollupStats{tallied: {}}
+	require.NoError(t, accountingDB.SaveRollup(ctx, rolledUp, stats))
+
+	// raw tallies ending after the last rollup are kept
+	atRest := map[storj.NodeID]float64{teststorj.NodeIDFromString("node"): 100}
+	require.NoError(t, accountingDB.SaveAtRestRaw(ctx, rolledUp.Add(time.Minute), true, atRest))
+
+	// rows younger than the maximum age are kept
+	pruned, err = r.Prune(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, Pruned{}, pruned)
+
+	// dry runs only count
+	pruned, err = dryRun.Prune(ctx, later)
+	require.NoError(t, err)
+	assert.Equal(t, Pruned{Agreements: 2, Raws: 1}, pruned)
+
+	agreements, err := bwAgreementDB.GetAgreements(ctx)
+	require.NoError(t, err)
+	assert.Len(t, agreements, 2)
+
+	pruned, err = r.Prune(ctx, later)
+	require.NoError(t, err)
+	assert.Equal(t, Pruned{Agreements: 2, Raws: 1}, pruned)
+
+	agreements, err = bwAgreementDB.GetAgreements(ctx)
+	require.NoError(t, err)
+	assert.Len(t, agreements, 0)
+
+	raws, err := accountingDB.GetRaw(ctx)
+	require.NoError(t, err)
+	require.Len(t, raws, 1)
+	assert.Equal(t, accounting.AtRest, raws[0].DataType)
+}
+
+type failingDestination struct{}
+
+func (failingDestination) Put(ctx context.Context, archive *archive.Archive) error {
+	return errors.New("unavailable")
+}
+
+func TestPruneArchive(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	db, err := satellitedb.NewInMemory()
+	require.NoError(t, err)
+	defer ctx.Check(db.Close)
+	require.NoError(t, db.CreateTables())
+
+	accountingDB, bwAgreementDB := db.Accounting(), db.BandwidthAgreement()
+	config := Config{Interval: time.Hour, MaxAge: 24 * time.Hour, RollupMaxAge: 24 * time.Hour}
+	destination, err := archive.Open(ctx.Dir("archives"))
+	require.NoError(t, err)
+
+	nodeID := teststorj.NodeIDFromString("node")
+	tallied := time.Now().UTC().Add(time.Minute)
+	atRest := map[storj.NodeID]float64{nodeID: 100}
+	require.NoError(t, accountingDB.SaveAtRestRaw(ctx, tallied, true, atRest))
+	stats := accounting.RollupStats{tallied: {nodeID: {NodeID: nodeID, StartTime: tallied, AtRestTotal: 100}}}
+	require.NoError(t, accountingDB.SaveRollup(ctx, tallied.Add(time.Minute), stats))
+	later := time.Now().Add(48 * time.Hour)
+
+	// without an archive rollups are kept
+	dryRun := newRetention(zap.NewNop(), accountingDB, bwAgreementDB, nil, Config{Interval: time.Hour, MaxAge: 24 * time.Hour, RollupMaxAge: 24 * time.Hour, DryRun: true})
+	pruned, err := dryRun.Prune(ctx, later)
+	require.NoError(t, err)
+	assert.Equal(t, Pruned{Raws: 1}, pruned)
+
+	// nothing is deleted when archiving fails
+	failing := newRetention(zap.NewNop(), accountingDB, bwAgreementDB, failingDestination{}, config)
+	_, err = failing.Prune(ctx, later)
+	assert.Error(t, err)
+	raws, err := accountingDB.GetRaw(ctx)
+	require.NoError(t, err)
+	assert.Len(t, raws, 1)
+
+	r := newRetention(zap.NewNop(), accountingDB, bwAgreementDB, destination, config)
+	pruned, err = r.Prune(ctx, later)
+	require.NoError(t, err)
+	assert.Equal(t, Pruned{Raws: 1, Rollups: 1}, pruned)
+
+	count, err := accountingDB.CountRollupsBefore(ctx, later)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	archived, err := archive.Load(ctx, filepath.Join(ctx.Dir("archives"), (&archive.Archive{CreatedAt: later}).Name()))
+	require.NoError(t, err)
+	require.Len(t, archived.Raws, 1)
+	require.Len(t, archived.Rollups, 1)
+	assert.Equal(t, nodeID, archived.Raws[0].NodeID)
+	assert.Equal(t, float64(100), archived.Rollups[0].AtRestTotal)
+
+	// empty archives aren't stored
+	pruned, err = r.Prune(ctx, later.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, Pruned{}, pruned)
+	_, err = archive.Load(ctx, filepath.Join(ctx.Dir("archives"), (&archive.Archive{CreatedAt: later.Add(time.Hour)}).Name()))
+	assert.Error(t, err)
+}
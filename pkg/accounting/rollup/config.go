@@ -11,6 +11,7 @@ import (
 	"go.uber.org/zap"
 
 	"storj.io/storj/pkg/accounting"
+	"storj.io/storj/pkg/lease"
 	"storj.io/storj/pkg/provider"
 )
 
@@ -37,7 +38,8 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 	ctx, cancel := context.WithCancel(ctx)
 
 	go func() {
-		if err := rollup.Run(ctx); err != nil {
+		// replicas sharing the database take turns running it
+		if err := lease.LoadFromContext(ctx).Run(ctx, "rollup", rollup.Run); err != nil {
 			defer cancel()
 			zap.L().Debug("Rollup is shutting down", zap.Error(err))
 		}
@@ -12,6 +12,7 @@ import (
 
 	"storj.io/storj/pkg/accounting"
 	"storj.io/storj/pkg/bwagreement"
+	"storj.io/storj/pkg/lease"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pointerdb"
 	"storj.io/storj/pkg/provider"
@@ -39,7 +40,7 @@ func (c Config) initialize(ctx context.Context) (Tally, error) {
 	if !ok {
 		return nil, Error.Wrap(errs.New("unable to get master db instance"))
 	}
-	return newTally(zap.L(), db.Accounting(), db.BandwidthAgreement(), pointerdb, overlay, 0, c.Interval), nil
+	return newTally(zap.L(), db.Accounting(), db.BandwidthAgreement(), pointerdb, overlay, overlay.Cache(), overlay.Vetting(), 0, c.Interval), nil
 }
 
 // Run runs the tally with configured values
@@ -51,7 +52,8 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 	ctx, cancel := context.WithCancel(ctx)
 
 	go func() {
-		if err := tally.Run(ctx); err != nil {
+		// replicas sharing the database take turns running it
+		if err := lease.LoadFromContext(ctx).Run(ctx, "tally", tally.Run); err != nil {
 			defer cancel()
 			zap.L().Debug("Tally is shutting down", zap.Error(err))
 		}
@@ -12,10 +12,11 @@ import (
 
 	"storj.io/storj/pkg/accounting"
 	"storj.io/storj/pkg/bwagreement"
+	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/storage/meta"
 	"storj.io/storj/pkg/storj"
-	"storj.io/storj/storage"
 )
 
 // Tally is the service for accounting for data stored on each storage node
@@ -26,6 +27,8 @@ type Tally interface {
 type tally struct {
 	pointerdb     *pointerdb.Service
 	overlay       pb.OverlayServer
+	cache         *overlay.Cache
+	vetting       *overlay.Vetting
 	limit         int
 	logger        *zap.Logger
 	ticker        *time.Ticker
@@ -33,10 +36,12 @@ type tally struct {
 	bwAgreementDB bwagreement.DB // bwagreements database
 }
 
-func newTally(logger *zap.Logger, accountingDB accounting.DB, bwAgreementDB bwagreement.DB, pointerdb *pointerdb.Service, overlay pb.OverlayServer, limit int, interval time.Duration) *tally {
+func newTally(logger *zap.Logger, accountingDB accounting.DB, bwAgreementDB bwagreement.DB, pointerdb *pointerdb.Service, overlay pb.OverlayServer, cache *overlay.Cache, vetting *overlay.Vetting, limit int, interval time.Duration) *tally {
 	return &tally{
 		pointerdb:     pointerdb,
 		overlay:       overlay,
+		cache:         cache,
+		vetting:       vetting,
 		limit:         limit,
 		logger:        logger,
 		ticker:        time.NewTicker(interval),
@@ -79,15 +84,11 @@ func (t *tally) calculateAtRestData(ctx context.Context) (err error) {
 	}
 
 	var nodeData = make(map[storj.NodeID]float64)
-	err = t.pointerdb.Iterate("", "", true, false,
-		func(it storage.Iterator) error {
-			var item storage.ListItem
-			for it.Next(&item) {
-				pointer := &pb.Pointer{}
-				err = proto.Unmarshal(item.Value, pointer)
-				if err != nil {
-					return Error.Wrap(err)
-				}
+	var nodePieces = make(map[storj.NodeID]int64)
+	err = t.pointerdb.IteratePointers(ctx, pointerdb.IterateOptions{MetaFlags: meta.Remote | meta.Size},
+		func(ctx context.Context, batch []pointerdb.PointerItem) error {
+			for _, item := range batch {
+				pointer := item.Pointer
 				remote := pointer.GetRemote()
 				if remote == nil {
 					continue
@@ -112,6 +113,7 @@ func (t *tally) calculateAtRestData(ctx context.Context) (err error) {
 				for _, piece := range pieces {
 					t.logger.Info("found piece on Node ID" + piece.NodeId.String())
 					nodeData[piece.NodeId] += float64(pieceSize)
+					nodePieces[piece.NodeId]++
 				}
 			}
 			return nil
@@ -123,6 +125,19 @@ func (t *tally) calculateAtRestData(ctx context.Context) (err error) {
 	if err != nil {
 		return Error.Wrap(err)
 	}
+	// vetting requires new nodes to hold enough data
+	dataHeld := make(map[storj.NodeID]int64, len(nodeData))
+	var stored int64
+	for k, bytes := range nodeData {
+		dataHeld[k] = int64(bytes)
+		stored += int64(bytes)
+	}
+	t.vetting.ReportDataHeld(dataHeld)
+	// the public network totals include the stored data
+	t.cache.ReportStoredBytes(stored)
+	// node selection prefers nodes storing fewer pieces
+	t.cache.ReportPieceCounts(nodePieces)
+
 	//store byte hours, not just bytes
 	numHours := 1.0 //todo: something more considered?
 	if !isNil {
@@ -35,7 +35,7 @@ func TestQueryNoAgreements(t *testing.T) {
 	defer ctx.Check(db.Close)
 	assert.NoError(t, db.CreateTables())
 
-	tally := newTally(zap.NewNop(), db.Accounting(), db.BandwidthAgreement(), service, overlayServer, 0, time.Second)
+	tally := newTally(zap.NewNop(), db.Accounting(), db.BandwidthAgreement(), service, overlayServer, nil, nil, 0, time.Second)
 
 	err = tally.queryBW(ctx)
 	assert.NoError(t, err)
@@ -55,7 +55,7 @@ func TestQueryWithBw(t *testing.T) {
 	assert.NoError(t, db.CreateTables())
 
 	bwDb := db.BandwidthAgreement()
-	tally := newTally(zap.NewNop(), db.Accounting(), bwDb, service, overlayServer, 0, time.Second)
+	tally := newTally(zap.NewNop(), db.Accounting(), bwDb, service, overlayServer, nil, nil, 0, time.Second)
 
 	//get a private key
 	fiC, err := testidentity.NewTestIdentity(ctx)
@@ -18,8 +18,8 @@ import (
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/pointerdb"
 	"storj.io/storj/pkg/provider"
-	"storj.io/storj/pkg/storage/meta"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
 )
 
 // Stripe keeps track of a stripe's index and its parent segment
@@ -49,16 +49,18 @@ func (cursor *Cursor) NextStripe(ctx context.Context) (stripe *Stripe, err error
 	cursor.mutex.Lock()
 	defer cursor.mutex.Unlock()
 
-	var pointerItems []*pb.ListResponse_Item
-	var path storj.Path
-	var more bool
-
-	pointerItems, more, err = cursor.pointers.List("", cursor.lastPath, "", true, 0, meta.None)
+	var pointerItems []pointerdb.PointerItem
+	err = cursor.pointers.IteratePointers(ctx, pointerdb.IterateOptions{StartAfter: cursor.lastPath, Limit: storage.LookupLimit},
+		func(ctx context.Context, batch []pointerdb.PointerItem) error {
+			pointerItems = append(pointerItems, batch...)
+			return nil
+		})
 	if err != nil {
 		return nil, err
 	}
 
 	if len(pointerItems) == 0 {
+		cursor.lastPath = ""
 		return nil, nil
 	}
 
@@ -66,21 +68,15 @@ func (cursor *Cursor) NextStripe(ctx context.Context) (stripe *Stripe, err error
 	if err != nil {
 		return nil, err
 	}
+	pointer := pointerItem.Pointer
 
-	path = pointerItem.Path
-
-	// keep track of last path listed
-	if !more {
+	// keep track of last path iterated, a short page is the end of the pointers
+	if len(pointerItems) < storage.LookupLimit {
 		cursor.lastPath = ""
 	} else {
 		cursor.lastPath = pointerItems[len(pointerItems)-1].Path
 	}
 
-	// get pointer info
-	pointer, err := cursor.pointers.Get(path)
-	if err != nil {
-		return nil, err
-	}
 	peerIdentity := &identity.PeerIdentity{ID: cursor.identity.ID, Leaf: cursor.identity.Leaf}
 	pba, err := cursor.allocation.PayerBandwidthAllocation(ctx, peerIdentity, pb.PayerBandwidthAllocation_GET_AUDIT)
 	if err != nil {
@@ -151,10 +147,10 @@ func getRandomStripe(es eestream.ErasureScheme, pointer *pb.Pointer) (index int,
 	return int(randomStripeIndex.Int64()), nil
 }
 
-func getRandomPointer(pointerItems []*pb.ListResponse_Item) (pointer *pb.ListResponse_Item, err error) {
+func getRandomPointer(pointerItems []pointerdb.PointerItem) (pointer pointerdb.PointerItem, err error) {
 	randomNum, err := rand.Int(rand.Reader, big.NewInt(int64(len(pointerItems))))
 	if err != nil {
-		return &pb.ListResponse_Item{}, err
+		return pointerdb.PointerItem{}, err
 	}
 	randomNumInt64 := randomNum.Int64()
 	pointerItem := pointerItems[randomNumInt64]
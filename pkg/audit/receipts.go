@@ -0,0 +1,108 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/piecestore/psserver"
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/pkg/utils"
+)
+
+// ReceiptSender sends the audited nodes receipts of their audits signed by
+// the satellite, which nodes keep to prove their audit history
+type ReceiptSender struct {
+	log       *zap.Logger
+	transport transport.Client
+	overlay   overlay.Client
+	identity  *provider.FullIdentity
+}
+
+// NewReceiptSender creates a new sender of audit receipts
+func NewReceiptSender(log *zap.Logger, transport transport.Client, overlay overlay.Client, identity *provider.FullIdentity) *ReceiptSender {
+	return &ReceiptSender{log: log, transport: transport, overlay: overlay, identity: identity}
+}
+
+// Send sends the receipts of the audit of the stripe. Nodes which didn't
+// return a share are sent a receipt as well, as they may be online without
+// the piece; receipts which can't be delivered are dropped.
+func (sender *ReceiptSender) Send(ctx context.Context, stripe *Stripe, verified *RecordAuditsInfo) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	outcomes := make(map[storj.NodeID]pb.AuditReceipt_Outcome)
+	var nodeIDs storj.NodeIDList
+	record := func(ids storj.NodeIDList, outcome pb.AuditReceipt_Outcome) {
+		for _, id := range ids {
+			outcomes[id] = outcome
+			nodeIDs = append(nodeIDs, id)
+		}
+	}
+	record(verified.SuccessNodeIDs, pb.AuditReceipt_SUCCESS)
+	record(verified.FailNodeIDs, pb.AuditReceipt_FAILURE)
+	record(verified.OfflineNodeIDs, pb.AuditReceipt_OFFLINE)
+	if len(nodeIDs) == 0 {
+		return nil
+	}
+
+	nodes, err := sender.overlay.BulkLookup(ctx, nodeIDs)
+	if err != nil {
+		return err
+	}
+
+	pieceID := psclient.PieceID(stripe.Segment.GetRemote().GetPieceId())
+	audited := time.Now().Unix()
+	for i, node := range nodes {
+		if node == nil {
+			continue
+		}
+
+		derivedPieceID, err := pieceID.Derive(nodeIDs[i].Bytes())
+		if err != nil {
+			return err
+		}
+
+		receipt := &pb.AuditReceipt{
+			SatelliteId:    sender.identity.ID,
+			NodeId:         nodeIDs[i],
+			PieceId:        derivedPieceID.String(),
+			StripeIndex:    int64(stripe.Index),
+			Outcome:        outcomes[nodeIDs[i]],
+			AuditedUnixSec: audited,
+		}
+		if err := psserver.SignAuditReceipt(sender.identity, receipt); err != nil {
+			return err
+		}
+
+		if err := sender.send(ctx, node, receipt); err != nil {
+			sender.log.Debug("sending audit receipt failed", zap.String("node", nodeIDs[i].String()), zap.Error(err))
+			mon.Counter("audit_receipts_undelivered").Inc(1)
+			continue
+		}
+		mon.Counter("audit_receipts_delivered").Inc(1)
+	}
+	return nil
+}
+
+// send sends the receipt to the node
+func (sender *ReceiptSender) send(ctx context.Context, node *pb.Node, receipt *pb.AuditReceipt) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	conn, err := sender.transport.DialNode(ctx, node)
+	if err != nil {
+		return err
+	}
+	defer utils.LogClose(conn)
+
+	_, err = pb.NewPieceStoreRoutesClient(conn).StoreAuditReceipt(ctx, receipt)
+	return err
+}
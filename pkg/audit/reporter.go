@@ -5,9 +5,12 @@ package audit
 
 import (
 	"context"
+	"time"
 
 	"github.com/zeebo/errs"
+	"go.uber.org/zap"
 
+	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/statdb"
 	"storj.io/storj/pkg/storj"
 )
@@ -19,7 +22,9 @@ type reporter interface {
 // Reporter records audit reports in statdb and implements the reporter interface
 type Reporter struct {
 	statdb     statdb.DB
+	overlay    overlay.DB
 	maxRetries int
+	halfLife   time.Duration
 }
 
 // RecordAuditsInfo is a struct containing arguments/return values for RecordAudits()
@@ -30,14 +35,15 @@ type RecordAuditsInfo struct {
 }
 
 // NewReporter instantiates a reporter
-func NewReporter(ctx context.Context, statDBPort string, maxRetries int, apiKey string) (reporter *Reporter, err error) {
+func NewReporter(ctx context.Context, statDBPort string, maxRetries int, halfLife time.Duration, apiKey string) (reporter *Reporter, err error) {
 	sdb, ok := ctx.Value("masterdb").(interface {
 		StatDB() statdb.DB
+		OverlayCache() overlay.DB
 	})
 	if !ok {
 		return nil, errs.New("unable to get master db instance")
 	}
-	return &Reporter{statdb: sdb.StatDB(), maxRetries: maxRetries}, nil
+	return &Reporter{statdb: sdb.StatDB(), overlay: sdb.OverlayCache(), maxRetries: maxRetries, halfLife: halfLife}, nil
 }
 
 // RecordAudits saves failed audit details to statdb
@@ -46,6 +52,8 @@ func (reporter *Reporter) RecordAudits(ctx context.Context, req *RecordAuditsInf
 	failNodeIDs := req.FailNodeIDs
 	offlineNodeIDs := req.OfflineNodeIDs
 
+	reporter.recordContacts(ctx, req)
+
 	var errNodeIDs storj.NodeIDList
 
 	retries := 0
@@ -87,6 +95,27 @@ func (reporter *Reporter) RecordAudits(ctx context.Context, req *RecordAuditsInf
 	return nil, nil
 }
 
+// recordContacts records the audit in the overlay cache as a successful
+// contact with nodes which responded and a failed one with offline nodes
+func (reporter *Reporter) recordContacts(ctx context.Context, req *RecordAuditsInfo) {
+	if reporter.overlay == nil {
+		return
+	}
+
+	now := time.Now()
+	record := func(nodeIDs storj.NodeIDList, success bool) {
+		for _, nodeID := range nodeIDs {
+			err := reporter.overlay.UpdateLastContact(ctx, nodeID, success, now)
+			if err != nil {
+				zap.L().Debug("recording audit contact failed", zap.String("node", nodeID.String()), zap.Error(err))
+			}
+		}
+	}
+	record(req.SuccessNodeIDs, true)
+	record(req.FailNodeIDs, true)
+	record(req.OfflineNodeIDs, false)
+}
+
 // recordAuditFailStatus updates nodeIDs in statdb with isup=true, auditsuccess=false
 func (reporter *Reporter) recordAuditFailStatus(ctx context.Context, failedAuditNodeIDs storj.NodeIDList) (failed storj.NodeIDList, err error) {
 	failedIDs := storj.NodeIDList{}
@@ -96,6 +125,7 @@ func (reporter *Reporter) recordAuditFailStatus(ctx context.Context, failedAudit
 			NodeID:       nodeID,
 			IsUp:         true,
 			AuditSuccess: false,
+			HalfLife:     reporter.halfLife,
 		})
 		if err != nil {
 			failedIDs = append(failedIDs, nodeID)
@@ -113,7 +143,7 @@ func (reporter *Reporter) recordOfflineStatus(ctx context.Context, offlineNodeID
 	failedIDs := storj.NodeIDList{}
 
 	for _, nodeID := range offlineNodeIDs {
-		_, err := reporter.statdb.UpdateUptime(ctx, nodeID, false)
+		_, err := reporter.statdb.UpdateUptime(ctx, nodeID, false, reporter.halfLife)
 		if err != nil {
 			failedIDs = append(failedIDs, nodeID)
 		}
@@ -133,6 +163,7 @@ func (reporter *Reporter) recordAuditSuccessStatus(ctx context.Context, successN
 			NodeID:       nodeID,
 			IsUp:         true,
 			AuditSuccess: true,
+			HalfLife:     reporter.halfLife,
 		})
 		if err != nil {
 			failedIDs = append(failedIDs, nodeID)
@@ -0,0 +1,131 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"math/rand"
+
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/pointerdb/pdbclient"
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+)
+
+// SegmentHealth is what verifying a segment found out about its pieces
+type SegmentHealth struct {
+	Inline bool
+	// Stripes is the number of stripes which were verified
+	Stripes int
+	// Pieces is the number of pieces the pointer records
+	Pieces int
+	// Required, Repair and Total are the thresholds of the redundancy scheme
+	Required int
+	Repair   int
+	Total    int
+	// Healthy nodes returned correct shares of all verified stripes
+	Healthy storj.NodeIDList
+	// Failed nodes returned an altered share of a verified stripe
+	Failed storj.NodeIDList
+	// Offline nodes didn't return a share of a verified stripe
+	Offline storj.NodeIDList
+}
+
+// Recoverable returns whether enough nodes have correct pieces to download
+// the segment
+func (health SegmentHealth) Recoverable() bool {
+	return health.Inline || len(health.Healthy) >= health.Required
+}
+
+// AtRisk returns whether the segment is due for repair
+func (health SegmentHealth) AtRisk() bool {
+	return !health.Inline && len(health.Healthy) <= health.Repair
+}
+
+// SegmentVerifier lets uplinks check the integrity of segments without
+// downloading them, by auditing a sample of their stripes
+type SegmentVerifier struct {
+	pdb      pdbclient.Client
+	verifier *Verifier
+}
+
+// NewSegmentVerifier creates a new instance of SegmentVerifier
+func NewSegmentVerifier(tc transport.Client, oc overlay.Client, identity *provider.FullIdentity, pdb pdbclient.Client) *SegmentVerifier {
+	return &SegmentVerifier{pdb: pdb, verifier: NewVerifier(tc, oc, *identity)}
+}
+
+// Verify downloads the shares of up to stripes randomly chosen stripes of the
+// segment at path from the nodes storing its pieces, and checks them for
+// consistency with the erasure code
+func (v *SegmentVerifier) Verify(ctx context.Context, path storj.Path, stripes int) (health SegmentHealth, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pr, _, pba, err := v.pdb.Get(ctx, path)
+	if err != nil {
+		return health, Error.Wrap(err)
+	}
+	if pr.GetType() != pb.Pointer_REMOTE {
+		health.Inline = true
+		return health, nil
+	}
+
+	redundancy := pr.GetRemote().GetRedundancy()
+	health.Pieces = len(pr.GetRemote().GetRemotePieces())
+	health.Required = int(redundancy.GetMinReq())
+	health.Repair = int(redundancy.GetRepairThreshold())
+	health.Total = int(redundancy.GetTotal())
+
+	stripeSize := int64(redundancy.GetErasureShareSize()) * int64(redundancy.GetMinReq())
+	if stripeSize <= 0 {
+		return health, Error.New("invalid redundancy scheme for %s", path)
+	}
+	// the last segment could be smaller than stripe size
+	count := int(pr.GetSegmentSize() / stripeSize)
+	if count < 1 {
+		count = 1
+	}
+	if stripes < 1 {
+		stripes = 1
+	}
+	if stripes > count {
+		stripes = count
+	}
+
+	failed := make(map[storj.NodeID]bool)
+	offline := make(map[storj.NodeID]bool)
+	authorization := v.pdb.SignedMessage()
+	for _, index := range rand.Perm(count)[:stripes] {
+		verified, err := v.verifier.Verify(ctx, &Stripe{
+			Index:         index,
+			Segment:       pr,
+			PBA:           pba,
+			Authorization: authorization,
+		})
+		if err != nil {
+			return health, Error.Wrap(err)
+		}
+		health.Stripes++
+
+		for _, id := range verified.FailNodeIDs {
+			failed[id] = true
+		}
+		for _, id := range verified.OfflineNodeIDs {
+			offline[id] = true
+		}
+	}
+
+	for _, piece := range pr.GetRemote().GetRemotePieces() {
+		switch id := piece.NodeId; {
+		case failed[id]:
+			health.Failed = append(health.Failed, id)
+		case offline[id]:
+			health.Offline = append(health.Offline, id)
+		default:
+			health.Healthy = append(health.Healthy, id)
+		}
+	}
+	return health, nil
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/pb"
+	mock_pointerdb "storj.io/storj/pkg/pointerdb/pdbclient/mocks"
+)
+
+func TestSegmentVerifier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	someData := randData(32 * 1024)
+	shares := make(map[int]share)
+	for i := 0; i < 30; i++ {
+		shares[i] = share{PieceNumber: i, Data: someData}
+	}
+	for i := 0; i < 10; i++ {
+		shares[i] = share{PieceNumber: i, Error: Error.New("unable to get node")}
+	}
+
+	pointer := makePointer(30)
+	pointer.Remote.Redundancy.RepairThreshold = 25
+	pointer.SegmentSize = 10 * 80
+	for i, piece := range pointer.Remote.RemotePieces {
+		piece.NodeId = teststorj.NodeIDFromString(strconv.Itoa(i))
+	}
+
+	mockPDB := mock_pointerdb.NewMockClient(ctrl)
+	mockPDB.EXPECT().Get(gomock.Any(), "remote").Return(pointer, nil, nil, nil)
+	mockPDB.EXPECT().Get(gomock.Any(), "inline").Return(&pb.Pointer{Type: pb.Pointer_INLINE}, nil, nil, nil)
+	mockPDB.EXPECT().SignedMessage()
+
+	verifier := &SegmentVerifier{pdb: mockPDB, verifier: &Verifier{downloader: &mockDownloader{shares: shares}}}
+
+	health, err := verifier.Verify(ctx, "remote", 3)
+	require.NoError(t, err)
+	assert.Equal(t, 3, health.Stripes)
+	assert.Equal(t, 30, health.Pieces)
+	assert.Len(t, health.Healthy, 20)
+	assert.Len(t, health.Offline, 10)
+	assert.Len(t, health.Failed, 0)
+	assert.True(t, health.Recoverable())
+	assert.True(t, health.AtRisk())
+
+	health, err = verifier.Verify(ctx, "inline", 3)
+	require.NoError(t, err)
+	assert.True(t, health.Inline)
+	assert.True(t, health.Recoverable())
+	assert.False(t, health.AtRisk())
+}
@@ -21,6 +21,7 @@ type Service struct {
 	Cursor   *Cursor
 	Verifier *Verifier
 	Reporter reporter
+	Receipts *ReceiptSender
 	ticker   *time.Ticker
 }
 
@@ -45,6 +46,12 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 		return Error.New("programmer error: allocation responsibility unstarted")
 	}
 
+	// audits decay with the same half-life as the overlay's uptime checks
+	cache := overlay.LoadFromContext(ctx)
+	if cache == nil {
+		return Error.New("programmer error: overlay responsibility unstarted")
+	}
+
 	overlay, err := overlay.NewClient(identity, c.SatelliteAddr)
 	if err != nil {
 		return err
@@ -52,7 +59,7 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 	transport := transport.NewClient(identity)
 
 	log := zap.L()
-	service, err := NewService(ctx, log, c.SatelliteAddr, c.Interval, c.MaxRetriesStatDB, pointers, allocation, transport, overlay, *identity, c.APIKey)
+	service, err := NewService(ctx, log, c.SatelliteAddr, c.Interval, c.MaxRetriesStatDB, cache.ReputationHalfLife(), pointers, allocation, transport, overlay, *identity, c.APIKey)
 	if err != nil {
 		return err
 	}
@@ -64,13 +71,13 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 }
 
 // NewService instantiates a Service with access to a Cursor and Verifier
-func NewService(ctx context.Context, log *zap.Logger, statDBPort string, interval time.Duration, maxRetries int, pointers *pointerdb.Service, allocation *pointerdb.AllocationSigner, transport transport.Client, overlay overlay.Client,
+func NewService(ctx context.Context, log *zap.Logger, statDBPort string, interval time.Duration, maxRetries int, halfLife time.Duration, pointers *pointerdb.Service, allocation *pointerdb.AllocationSigner, transport transport.Client, overlay overlay.Client,
 	identity provider.FullIdentity, apiKey string) (service *Service, err error) {
 
 	//TODO: instead of statDBPort pass in the actual database interface
 	cursor := NewCursor(pointers, allocation, &identity)
 	verifier := NewVerifier(transport, overlay, identity)
-	reporter, err := NewReporter(ctx, statDBPort, maxRetries, apiKey)
+	reporter, err := NewReporter(ctx, statDBPort, maxRetries, halfLife, apiKey)
 	if err != nil {
 		return nil, err
 	}
@@ -80,6 +87,7 @@ func NewService(ctx context.Context, log *zap.Logger, statDBPort string, interva
 		Cursor:   cursor,
 		Verifier: verifier,
 		Reporter: reporter,
+		Receipts: NewReceiptSender(log.Named("receipts"), transport, overlay, &identity),
 		ticker:   time.NewTicker(interval),
 	}, nil
 }
@@ -113,11 +121,17 @@ func (service *Service) process(ctx context.Context) error {
 		return nil
 	}
 
-	verifiedNodes, err := service.Verifier.verify(ctx, stripe)
+	verifiedNodes, err := service.Verifier.Verify(ctx, stripe)
 	if err != nil {
 		return err
 	}
 
+	if service.Receipts != nil {
+		if err := service.Receipts.Send(ctx, stripe, verifiedNodes); err != nil {
+			service.log.Warn("sending audit receipts failed", zap.Error(err))
+		}
+	}
+
 	// TODO(moby) we need to decide if we want to do something with nodes that the reporter failed to update
 	_, err = service.Reporter.RecordAudits(ctx, verifiedNodes)
 	if err != nil {
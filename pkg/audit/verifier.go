@@ -141,6 +141,11 @@ func (d *defaultDownloader) DownloadShares(ctx context.Context, pointer *pb.Poin
 			}
 		}
 
+		if node == nil {
+			// report the node the overlay doesn't know as offline
+			node = &pb.Node{Id: pieces[i].NodeId}
+		}
+
 		shares[s.PieceNumber] = s
 		nodes[s.PieceNumber] = node
 	}
@@ -196,8 +201,8 @@ func calcPadded(size int64, blockSize int) int64 {
 	return size + int64(blockSize) - mod
 }
 
-// verify downloads shares then verifies the data correctness at the given stripe
-func (verifier *Verifier) verify(ctx context.Context, stripe *Stripe) (verifiedNodes *RecordAuditsInfo, err error) {
+// Verify downloads shares then verifies the data correctness at the given stripe
+func (verifier *Verifier) Verify(ctx context.Context, stripe *Stripe) (verifiedNodes *RecordAuditsInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	shares, nodes, err := verifier.downloader.DownloadShares(ctx, stripe.Segment, stripe.Index, stripe.PBA, stripe.Authorization)
@@ -8,9 +8,11 @@ import (
 
 	"github.com/gtank/cryptopasta"
 
+	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/peertls"
 	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/storj"
 )
 
 // GenerateSignature creates signature from identity id
@@ -30,6 +32,38 @@ func GenerateSignature(data []byte, identity *provider.FullIdentity) ([]byte, er
 	return signature, nil
 }
 
+// VerifyChainSignature checks that data was signed by the leaf of the
+// certificate chain, which must consist of the leaf and the CA that issued
+// it, and returns the node ID of the CA
+func VerifyChainSignature(data, signature []byte, chain [][]byte) (storj.NodeID, error) {
+	if len(chain) != 2 {
+		return storj.NodeID{}, Error.New("expected leaf and ca certificates, got %d", len(chain))
+	}
+
+	certs, err := identity.ParseCertChain(chain)
+	if err != nil {
+		return storj.NodeID{}, Error.Wrap(err)
+	}
+	leaf, ca := certs[peertls.LeafIndex], certs[peertls.CAIndex]
+
+	signer, err := identity.NodeIDFromKey(ca.PublicKey)
+	if err != nil {
+		return storj.NodeID{}, Error.Wrap(err)
+	}
+	if err := peertls.VerifySignature(leaf.Signature, leaf.RawTBSCertificate, ca.PublicKey); err != nil {
+		return storj.NodeID{}, Error.Wrap(err)
+	}
+
+	key, ok := leaf.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return storj.NodeID{}, peertls.ErrUnsupportedKey.New("%T", leaf.PublicKey)
+	}
+	if !cryptopasta.Verify(data, signature, key) {
+		return storj.NodeID{}, Error.New("invalid signature")
+	}
+	return signer, nil
+}
+
 // NewSignedMessage creates instance of signed message
 func NewSignedMessage(signature []byte, identity *provider.FullIdentity) (*pb.SignedMessage, error) {
 	k, ok := identity.Leaf.PublicKey.(*ecdsa.PublicKey)
@@ -26,6 +26,10 @@ type DB interface {
 	GetAgreements(context.Context) ([]Agreement, error)
 	// GetAgreementsSince gets all bandwidth agreements since specific time.
 	GetAgreementsSince(context.Context, time.Time) ([]Agreement, error)
+	// CountAgreementsBefore counts the bandwidth agreements created before specific time.
+	CountAgreementsBefore(context.Context, time.Time) (int64, error)
+	// DeleteAgreementsBefore deletes the bandwidth agreements created before specific time.
+	DeleteAgreementsBefore(context.Context, time.Time) (int64, error)
 }
 
 // Server is an implementation of the pb.BandwidthServer interface
@@ -11,11 +11,11 @@ import (
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
+	"storj.io/storj/internal/dburl"
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/peertls"
 	"storj.io/storj/pkg/provider"
-	"storj.io/storj/pkg/utils"
 	"storj.io/storj/storage/boltdb"
 	"storj.io/storj/storage/redis"
 )
@@ -127,22 +127,22 @@ func (c CertClientConfig) Sign(ctx context.Context, ident *identity.FullIdentity
 // NewAuthDB creates or opens the authorization database specified by the config
 func (c CertServerConfig) NewAuthDB() (*AuthorizationDB, error) {
 	// TODO: refactor db selection logic?
-	driver, source, err := utils.SplitDBURL(c.AuthorizationDBURL)
+	dbURL, err := dburl.Parse(c.AuthorizationDBURL)
 	if err != nil {
 		return nil, peertls.ErrRevocationDB.Wrap(err)
 	}
 
 	authDB := new(AuthorizationDB)
-	switch driver {
+	switch dbURL.Driver {
 	case "bolt":
-		_, err := os.Stat(source)
+		_, err := os.Stat(dbURL.Source)
 		if c.Overwrite && err == nil {
-			if err := os.Remove(source); err != nil {
+			if err := os.Remove(dbURL.Source); err != nil {
 				return nil, err
 			}
 		}
 
-		authDB.DB, err = boltdb.New(source, AuthorizationsBucket)
+		authDB.DB, err = boltdb.New(dbURL.Source, AuthorizationsBucket)
 		if err != nil {
 			return nil, ErrAuthorizationDB.Wrap(err)
 		}
@@ -160,7 +160,7 @@ func (c CertServerConfig) NewAuthDB() (*AuthorizationDB, error) {
 
 		authDB.DB = redisClient
 	default:
-		return nil, ErrAuthorizationDB.New("database scheme not supported: %s", driver)
+		return nil, ErrAuthorizationDB.New("database scheme not supported: %s", dbURL.Driver)
 	}
 
 	return authDB, nil
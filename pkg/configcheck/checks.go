@@ -0,0 +1,109 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package configcheck
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq" // registers the postgres driver
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/internal/dburl"
+	"storj.io/storj/pkg/identity"
+)
+
+// dialTimeout is how long Reachable waits for a connection
+const dialTimeout = 5 * time.Second
+
+// Identity checks that the identity loads
+func Identity(config identity.Config) error {
+	_, err := config.Load()
+	return err
+}
+
+// Writable checks that files can be created in dir, creating dir when it
+// doesn't exist yet
+func Writable(dir string) error {
+	if dir == "" {
+		return Error.New("no directory configured")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	file, err := ioutil.TempFile(dir, ".verify-config")
+	if err != nil {
+		return err
+	}
+	return errs.Combine(file.Close(), os.Remove(file.Name()))
+}
+
+// Database checks that the database can be used: databases on disk need a
+// writable directory and database servers need to answer
+func Database(ctx context.Context, databaseURL string) error {
+	parsed, err := dburl.Parse(databaseURL)
+	if err != nil {
+		return err
+	}
+
+	switch parsed.Driver {
+	case "bolt":
+		return Writable(filepath.Dir(parsed.Source))
+	case "sqlite3":
+		path := strings.SplitN(parsed.Source, "?", 2)[0]
+		if strings.HasPrefix(path, "file::memory:") {
+			return nil
+		}
+		return Writable(filepath.Dir(strings.TrimPrefix(path, "file:")))
+	case "postgres", "cockroach":
+		db, err := sql.Open("postgres", parsed.Source)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+		defer cancel()
+		return errs.Combine(db.PingContext(ctx), db.Close())
+	case "redis":
+		server, err := url.Parse(parsed.Source)
+		if err != nil {
+			return err
+		}
+		return Reachable(ctx, server.Host)
+	case "memory":
+		return nil
+	}
+	return Error.New("unsupported database %q", parsed.Driver)
+}
+
+// Listenable checks that the process can listen on address, which fails when
+// another process, e.g. an already running instance, listens on it
+func Listenable(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	return listener.Close()
+}
+
+// Reachable checks that a TCP connection to address can be opened
+func Reachable(ctx context.Context, address string) error {
+	if address == "" {
+		return Error.New("no address configured")
+	}
+
+	dialer := net.Dialer{Timeout: dialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package configcheck_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/configcheck"
+)
+
+func TestReport(t *testing.T) {
+	var report configcheck.Report
+	report.Check("identity", nil)
+	report.Warn("external address", "not configured")
+	report.Warn("wallet", "")
+	report.Check("database", errors.New("connection refused"))
+
+	assert.Equal(t, 2, report.Count(configcheck.OK))
+	assert.Equal(t, 1, report.Count(configcheck.Warning))
+	assert.Equal(t, 1, report.Count(configcheck.Failed))
+	assert.True(t, configcheck.Error.Has(report.Err()))
+
+	var table bytes.Buffer
+	require.NoError(t, report.Print(&table))
+	assert.Contains(t, table.String(), "connection refused")
+	assert.Contains(t, table.String(), "2 ok, 1 warnings, 1 failed")
+
+	var encoded bytes.Buffer
+	require.NoError(t, report.PrintJSON(&encoded))
+	var decoded configcheck.Report
+	require.NoError(t, json.Unmarshal(encoded.Bytes(), &decoded))
+	assert.Equal(t, report, decoded)
+
+	var passing configcheck.Report
+	passing.Check("identity", nil)
+	assert.NoError(t, passing.Err())
+}
+
+func TestChecks(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	dir := ctx.Dir("storage", "pieces")
+	assert.NoError(t, configcheck.Writable(dir))
+	assert.Error(t, configcheck.Writable(""))
+
+	file := filepath.Join(ctx.Dir("files"), "file")
+	require.NoError(t, ioutil.WriteFile(file, nil, 0600))
+	assert.Error(t, configcheck.Writable(file), "a file isn't a directory")
+
+	assert.NoError(t, configcheck.Database(ctx, "bolt://"+filepath.Join(dir, "pointerdb.db")))
+	assert.NoError(t, configcheck.Database(ctx, "sqlite3://"+filepath.Join(dir, "master.db")))
+	assert.NoError(t, configcheck.Database(ctx, "sqlite3://file::memory:?mode=memory"))
+	assert.Error(t, configcheck.Database(ctx, "bolt://"+filepath.Join(file, "pointerdb.db")))
+	assert.Error(t, configcheck.Database(ctx, "unknown"))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+
+	assert.NoError(t, configcheck.Reachable(ctx, address))
+	assert.Error(t, configcheck.Listenable(address), "the address is in use")
+
+	assert.NoError(t, configcheck.Database(ctx, "redis://"+address+"?db=2"))
+
+	require.NoError(t, listener.Close())
+	assert.Error(t, configcheck.Reachable(ctx, address))
+	assert.Error(t, configcheck.Database(ctx, "redis://"+address+"?db=2"))
+	assert.Error(t, configcheck.Database(ctx, "postgres://user@"+address+"/db?sslmode=disable"))
+	assert.NoError(t, configcheck.Listenable(address))
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package configcheck verifies the effective configuration of a process
+// before it runs, so that all problems are reported at once instead of
+// failing at first use deep in the runtime.
+package configcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/zeebo/errs"
+)
+
+// Error is the error class of failed configuration checks
+var Error = errs.Class("config check error")
+
+// Status is the outcome of a check
+type Status string
+
+const (
+	// OK means the checked setting works
+	OK = Status("ok")
+	// Warning means the checked setting works, but probably not as intended
+	Warning = Status("warning")
+	// Failed means the process fails with the checked setting
+	Failed = Status("failed")
+)
+
+// Result is the outcome of checking a setting
+type Result struct {
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Report collects the results of the checks of a configuration
+type Report struct {
+	Results []Result `json:"results"`
+}
+
+// Add adds the result of a check
+func (report *Report) Add(name string, status Status, detail string) {
+	report.Results = append(report.Results, Result{Name: name, Status: status, Detail: detail})
+}
+
+// Check adds a failed result with err, or an ok result when err is nil
+func (report *Report) Check(name string, err error) {
+	if err != nil {
+		report.Add(name, Failed, err.Error())
+		return
+	}
+	report.Add(name, OK, "")
+}
+
+// Warn adds a warning when problem isn't empty, otherwise an ok result
+func (report *Report) Warn(name string, problem string) {
+	if problem != "" {
+		report.Add(name, Warning, problem)
+		return
+	}
+	report.Add(name, OK, "")
+}
+
+// Count returns the number of results with status
+func (report *Report) Count(status Status) (count int) {
+	for _, result := range report.Results {
+		if result.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Err returns an error when any check failed
+func (report *Report) Err() error {
+	if failed := report.Count(Failed); failed > 0 {
+		return Error.New("%d of %d checks failed", failed, len(report.Results))
+	}
+	return nil
+}
+
+// Print writes the results as a table followed by a summary
+func (report *Report) Print(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "STATUS\tCHECK\tDETAIL")
+	for _, result := range report.Results {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", result.Status, result.Name, result.Detail)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d ok, %d warnings, %d failed\n", report.Count(OK), report.Count(Warning), report.Count(Failed))
+	return err
+}
+
+// PrintJSON writes the results as json
+func (report *Report) PrintJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
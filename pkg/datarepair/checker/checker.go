@@ -12,10 +12,14 @@ import (
 
 	"storj.io/storj/pkg/datarepair/irreparable"
 	"storj.io/storj/pkg/datarepair/queue"
+	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
 	"storj.io/storj/pkg/pointerdb"
 	"storj.io/storj/pkg/statdb"
+	"storj.io/storj/pkg/storage/meta"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/watchdog"
 	"storj.io/storj/storage"
 )
 
@@ -33,29 +37,36 @@ type checker struct {
 	pointerdb   *pointerdb.Service
 	repairQueue queue.RepairQueue
 	overlay     pb.OverlayServer
+	vetting     *overlay.Vetting
 	irrdb       irreparable.DB
+	lost        *LostPieces
 	limit       int
 	logger      *zap.Logger
 	ticker      *time.Ticker
+	loop        *watchdog.Loop
 }
 
-// NewChecker creates a new instance of checker
-func NewChecker(pointerdb *pointerdb.Service, sdb statdb.DB, repairQueue queue.RepairQueue, overlay pb.OverlayServer, irrdb irreparable.DB, limit int, logger *zap.Logger, interval time.Duration) Checker {
+// NewChecker creates a new instance of checker, vetting may be nil when no
+// nodes are drained and lost may be nil when nodes don't report lost pieces
+func NewChecker(pointerdb *pointerdb.Service, sdb statdb.DB, repairQueue queue.RepairQueue, overlay pb.OverlayServer, vetting *overlay.Vetting, irrdb irreparable.DB, lost *LostPieces, limit int, logger *zap.Logger, interval time.Duration, loop *watchdog.Loop) Checker {
 	// TODO: reorder arguments
-	return newChecker(pointerdb, sdb, repairQueue, overlay, irrdb, limit, logger, interval)
+	return newChecker(pointerdb, sdb, repairQueue, overlay, vetting, irrdb, lost, limit, logger, interval, loop)
 }
 
 // newChecker creates a new instance of checker
-func newChecker(pointerdb *pointerdb.Service, sdb statdb.DB, repairQueue queue.RepairQueue, overlay pb.OverlayServer, irrdb irreparable.DB, limit int, logger *zap.Logger, interval time.Duration) *checker {
+func newChecker(pointerdb *pointerdb.Service, sdb statdb.DB, repairQueue queue.RepairQueue, overlay pb.OverlayServer, vetting *overlay.Vetting, irrdb irreparable.DB, lost *LostPieces, limit int, logger *zap.Logger, interval time.Duration, loop *watchdog.Loop) *checker {
 	return &checker{
 		statdb:      sdb,
 		pointerdb:   pointerdb,
 		repairQueue: repairQueue,
 		overlay:     overlay,
+		vetting:     vetting,
 		irrdb:       irrdb,
+		lost:        lost,
 		limit:       limit,
 		logger:      logger,
 		ticker:      time.NewTicker(interval),
+		loop:        loop,
 	}
 }
 
@@ -68,6 +79,7 @@ func (c *checker) Run(ctx context.Context) (err error) {
 		if err != nil {
 			c.logger.Error("Checker failed", zap.Error(err))
 		}
+		c.loop.Cycle(err)
 
 		select {
 		case <-c.ticker.C: // wait for the next interval to happen
@@ -84,20 +96,18 @@ func (c *checker) Close() error { return nil }
 func (c *checker) IdentifyInjuredSegments(ctx context.Context) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	err = c.pointerdb.Iterate("", "", true, false,
-		func(it storage.Iterator) error {
-			var item storage.ListItem
-			lim := c.limit
-			if lim <= 0 || lim > storage.LookupLimit {
-				lim = storage.LookupLimit
-			}
-			for ; lim > 0 && it.Next(&item); lim-- {
-				pointer := &pb.Pointer{}
+	limit := c.limit
+	if limit <= 0 || limit > storage.LookupLimit {
+		limit = storage.LookupLimit
+	}
 
-				err = proto.Unmarshal(item.Value, pointer)
-				if err != nil {
-					return Error.New("error unmarshalling pointer %s", err)
-				}
+	// deleted segments aren't iterated, they aren't repaired while they wait
+	// to be purged
+	// only the redundancy scheme and pieces are needed to check a segment
+	err = c.pointerdb.IteratePointers(ctx, pointerdb.IterateOptions{Limit: limit, MetaFlags: meta.Remote},
+		func(ctx context.Context, batch []pointerdb.PointerItem) error {
+			for _, item := range batch {
+				pointer := item.Pointer
 
 				remote := pointer.GetRemote()
 				if remote == nil {
@@ -128,27 +138,57 @@ func (c *checker) IdentifyInjuredSegments(ctx context.Context) (err error) {
 
 				missingPieces := combineOfflineWithInvalid(offlineNodes, invalidNodes)
 
+				// pieces the nodes reported as lost are missing as well
+				lostPieces, err := c.lost.Lost(psclient.PieceID(remote.GetPieceId()), nodeIDs)
+				if err != nil {
+					return Error.New("error getting reported lost pieces %s", err)
+				}
+				if len(lostPieces) > 0 {
+					mon.Counter("reported_lost_pieces").Inc(int64(len(lostPieces)))
+					missingPieces = combineOfflineWithInvalid(missingPieces, lostPieces)
+				}
+
 				numHealthy := len(nodeIDs) - len(missingPieces)
-				if (int32(numHealthy) >= pointer.Remote.Redundancy.MinReq) && (int32(numHealthy) < pointer.Remote.Redundancy.RepairThreshold) {
+
+				// pieces of draining nodes are migrated, as long as enough
+				// pieces remain on the other nodes to repair from
+				drainingPieces := c.drainingNodes(nodeIDs, missingPieces)
+				if len(drainingPieces) > 0 && int32(numHealthy-len(drainingPieces)) >= pointer.Remote.Redundancy.MinReq {
+					mon.Counter("draining_pieces_queued").Inc(int64(len(drainingPieces)))
+					missingPieces = combineOfflineWithInvalid(missingPieces, drainingPieces)
+				} else {
+					drainingPieces = nil
+				}
+
+				if (int32(numHealthy) >= pointer.Remote.Redundancy.MinReq) && (int32(numHealthy) < pointer.Remote.Redundancy.RepairThreshold || len(drainingPieces) > 0) {
 					err = c.repairQueue.Enqueue(ctx, &pb.InjuredSegment{
-						Path:       string(item.Key),
+						Path:       item.Path,
 						LostPieces: missingPieces,
 					})
 					if err != nil {
 						return Error.New("error adding injured segment to queue %s", err)
 					}
 				} else if int32(numHealthy) < pointer.Remote.Redundancy.MinReq {
-					// make an entry in to the irreparable table
+					// make an entry in to the irreparable table, with the
+					// whole pointer
+					full, err := c.pointerdb.Get(item.Path)
+					if err != nil {
+						return Error.Wrap(err)
+					}
+					detail, err := proto.Marshal(full)
+					if err != nil {
+						return Error.Wrap(err)
+					}
 					segmentInfo := &irreparable.RemoteSegmentInfo{
-						EncryptedSegmentPath:   item.Key,
-						EncryptedSegmentDetail: item.Value,
+						EncryptedSegmentPath:   []byte(item.Path),
+						EncryptedSegmentDetail: detail,
 						LostPiecesCount:        int64(len(missingPieces)),
 						RepairUnixSec:          time.Now().Unix(),
 						RepairAttemptCount:     int64(1),
 					}
 
 					//add the entry if new or update attempt count if already exists
-					err := c.irrdb.IncrementRepairAttempts(ctx, segmentInfo)
+					err = c.irrdb.IncrementRepairAttempts(ctx, segmentInfo)
 					if err != nil {
 						return Error.New("error handling irreparable segment to queue %s", err)
 					}
@@ -166,15 +206,30 @@ func (c *checker) OfflineNodes(ctx context.Context, nodeIDs storj.NodeIDList) (o
 	if err != nil {
 		return []int32{}, err
 	}
-	nodes := pb.LookupResponsesToNodes(responses)
-	for i, n := range nodes {
-		if n == nil {
+	for i, response := range responses.LookupResponse {
+		// nodes which couldn't be looked up aren't known to be offline
+		if response.GetStatus() == pb.LookupStatus_NOT_FOUND {
 			offline = append(offline, int32(i))
 		}
 	}
 	return offline, nil
 }
 
+// drainingNodes returns the indices of nodes which announced their exit,
+// except for those already missing
+func (c *checker) drainingNodes(nodeIDs storj.NodeIDList, missing []int32) (draining []int32) {
+	missingMap := make(map[int32]bool)
+	for _, i := range missing {
+		missingMap[i] = true
+	}
+	for i, id := range nodeIDs {
+		if !missingMap[int32(i)] && c.vetting.Draining(id) {
+			draining = append(draining, int32(i))
+		}
+	}
+	return draining
+}
+
 // Find invalidNodes by checking the audit results that are place in statdb
 func (c *checker) invalidNodes(ctx context.Context, nodeIDs storj.NodeIDList) (invalidNodes []int32, err error) {
 	// filter if nodeIDs have invalid pieces from auditing results
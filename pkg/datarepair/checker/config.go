@@ -19,7 +19,8 @@ import (
 
 // Config contains configurable values for checker
 type Config struct {
-	Interval time.Duration `help:"how frequently checker should audit segments" default:"30s"`
+	Interval             time.Duration `help:"how frequently checker should audit segments" default:"30s"`
+	LostPiecesExpiration time.Duration `help:"how long pieces which storage nodes reported as lost are counted as missing" default:"168h0m0s"`
 }
 
 // Initialize a Checker struct
@@ -40,7 +41,7 @@ func (c Config) initialize(ctx context.Context) (Checker, error) {
 
 	o := overlay.LoadServerFromContext(ctx)
 
-	return newChecker(pdb, db.StatDB(), db.RepairQueue(), o, db.Irreparable(), 0, zap.L(), c.Interval), nil
+	return newChecker(pdb, db.StatDB(), db.RepairQueue(), o, nil, db.Irreparable(), nil, 0, zap.L(), c.Interval, nil), nil
 }
 
 // Run runs the checker with configured values
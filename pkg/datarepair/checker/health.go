@@ -0,0 +1,50 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package checker
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+)
+
+// HealthEndpoint receives reports about damaged pieces from storage nodes
+type HealthEndpoint struct {
+	log  *zap.Logger
+	lost *LostPieces
+}
+
+// NewHealthEndpoint creates a new piece health endpoint, which records the
+// reported pieces in lost
+func NewHealthEndpoint(log *zap.Logger, lost *LostPieces) *HealthEndpoint {
+	return &HealthEndpoint{log: log, lost: lost}
+}
+
+// ReportCorruption handles pieces which a storage node found to be corrupted
+// or missing. Nodes can only report their own pieces, which the checker then
+// counts as missing for the health of their segments.
+func (endpoint *HealthEndpoint) ReportCorruption(ctx context.Context, req *pb.CorruptionReport) (_ *pb.CorruptionReportResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	peer, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pieceIDs := make([]psclient.PieceID, 0, len(req.GetPieces()))
+	for _, piece := range req.GetPieces() {
+		endpoint.log.Warn("storage node reported corrupted piece",
+			zap.String("Node ID", peer.ID.String()),
+			zap.String("Piece ID", piece.GetPieceId()))
+		pieceIDs = append(pieceIDs, psclient.PieceID(piece.GetPieceId()))
+	}
+	endpoint.lost.Report(peer.ID, pieceIDs...)
+	mon.Counter("corrupted_pieces_reported").Inc(int64(len(req.GetPieces())))
+
+	return &pb.CorruptionReportResponse{}, nil
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package checker
+
+import (
+	"sync"
+	"time"
+
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/storj"
+)
+
+// LostPieces keeps the pieces storage nodes reported as lost, so the checker
+// counts them as missing before an audit finds them. Reports are kept until
+// they expire, since a piece is no longer looked up once it was repaired.
+type LostPieces struct {
+	expiration time.Duration
+	now        func() time.Time
+
+	mu    sync.Mutex
+	nodes map[storj.NodeID]map[psclient.PieceID]time.Time
+}
+
+// NewLostPieces creates an empty set of reported pieces, which are forgotten
+// after expiration
+func NewLostPieces(expiration time.Duration) *LostPieces {
+	return &LostPieces{
+		expiration: expiration,
+		now:        time.Now,
+		nodes:      make(map[storj.NodeID]map[psclient.PieceID]time.Time),
+	}
+}
+
+// Report records pieces which node lost, pieceIDs are the ids derived for
+// the node
+func (lost *LostPieces) Report(node storj.NodeID, pieceIDs ...psclient.PieceID) {
+	if lost == nil || len(pieceIDs) == 0 {
+		return
+	}
+
+	lost.mu.Lock()
+	defer lost.mu.Unlock()
+
+	now := lost.now()
+	lost.expire(now)
+
+	pieces, ok := lost.nodes[node]
+	if !ok {
+		pieces = make(map[psclient.PieceID]time.Time)
+		lost.nodes[node] = pieces
+	}
+	for _, id := range pieceIDs {
+		pieces[id] = now
+	}
+}
+
+// Lost returns the indices of nodeIDs which reported their piece of the
+// segment with the root piece id as lost
+func (lost *LostPieces) Lost(pieceID psclient.PieceID, nodeIDs storj.NodeIDList) (indices []int32, err error) {
+	if lost == nil {
+		return nil, nil
+	}
+
+	lost.mu.Lock()
+	defer lost.mu.Unlock()
+
+	deadline := lost.now().Add(-lost.expiration)
+	for i, nodeID := range nodeIDs {
+		pieces, ok := lost.nodes[nodeID]
+		if !ok {
+			continue
+		}
+
+		derived, err := pieceID.Derive(nodeID.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		if reported, ok := pieces[derived]; ok && reported.After(deadline) {
+			indices = append(indices, int32(i))
+		}
+	}
+	return indices, nil
+}
+
+// expire drops the reports older than the expiration
+func (lost *LostPieces) expire(now time.Time) {
+	deadline := now.Add(-lost.expiration)
+	for node, pieces := range lost.nodes {
+		for id, reported := range pieces {
+			if !reported.After(deadline) {
+				delete(pieces, id)
+			}
+		}
+		if len(pieces) == 0 {
+			delete(lost.nodes, node)
+		}
+	}
+}
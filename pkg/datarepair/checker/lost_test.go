@@ -0,0 +1,57 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package checker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestLostPieces(t *testing.T) {
+	now := time.Unix(1000, 0)
+	lost := NewLostPieces(time.Hour)
+	lost.now = func() time.Time { return now }
+
+	root := psclient.NewPieceID()
+	nodeIDs := teststorj.NodeIDsFromStrings("a", "b", "c")
+	derived := func(node storj.NodeID) psclient.PieceID {
+		id, err := root.Derive(node.Bytes())
+		require.NoError(t, err)
+		return id
+	}
+
+	// nodes can only report the pieces derived for them
+	lost.Report(nodeIDs[0], derived(nodeIDs[1]))
+	lost.Report(nodeIDs[2], derived(nodeIDs[2]))
+
+	indices, err := lost.Lost(root, nodeIDs)
+	require.NoError(t, err)
+	assert.Equal(t, []int32{2}, indices)
+
+	indices, err = lost.Lost(psclient.NewPieceID(), nodeIDs)
+	require.NoError(t, err)
+	assert.Empty(t, indices)
+
+	// reports expire
+	now = now.Add(time.Hour)
+	indices, err = lost.Lost(root, nodeIDs)
+	require.NoError(t, err)
+	assert.Empty(t, indices)
+
+	lost.Report(nodeIDs[1], derived(nodeIDs[1]))
+	assert.Len(t, lost.nodes, 1)
+
+	var none *LostPieces
+	none.Report(nodeIDs[0], derived(nodeIDs[0]))
+	indices, err = none.Lost(root, nodeIDs)
+	require.NoError(t, err)
+	assert.Empty(t, indices)
+}
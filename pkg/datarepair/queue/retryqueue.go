@@ -0,0 +1,85 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package queue
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/proto"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/retryqueue"
+	"storj.io/storj/pkg/utils"
+	"storj.io/storj/storage"
+)
+
+// RetryQueueName is the name of the retry queue of segments to repair
+const RetryQueueName = "repair"
+
+// RetryQueue implements the RepairQueue interface with a retry queue. Leased
+// segments are repaired again after a backoff, unless their repair is
+// acknowledged.
+type RetryQueue struct {
+	queue *retryqueue.Queue
+}
+
+// NewRetryQueue returns a repair queue storing segments in queue
+func NewRetryQueue(queue *retryqueue.Queue) *RetryQueue {
+	return &RetryQueue{queue: queue}
+}
+
+// Enqueue adds a repair segment to the queue
+func (q *RetryQueue) Enqueue(ctx context.Context, qi *pb.InjuredSegment) error {
+	val, err := proto.Marshal(qi)
+	if err != nil {
+		return Error.New("error marshalling injured seg %s", err)
+	}
+	return q.queue.Enqueue(ctx, val)
+}
+
+// Dequeue returns the next repair segment and removes it from the queue,
+// its repair isn't retried
+func (q *RetryQueue) Dequeue(ctx context.Context) (pb.InjuredSegment, error) {
+	seg, lease, err := q.Lease(ctx)
+	if err != nil {
+		return pb.InjuredSegment{}, err
+	}
+	return seg, lease.Ack(ctx)
+}
+
+// Lease returns the next repair segment, it's repaired again after a backoff
+// unless its repair is acknowledged through the lease
+func (q *RetryQueue) Lease(ctx context.Context) (pb.InjuredSegment, *retryqueue.Lease, error) {
+	lease, err := q.queue.Lease(ctx)
+	if err != nil {
+		return pb.InjuredSegment{}, nil, err
+	}
+	if lease == nil {
+		return pb.InjuredSegment{}, nil, storage.ErrEmptyQueue.New("")
+	}
+
+	seg := &pb.InjuredSegment{}
+	if err := proto.Unmarshal(lease.Payload, seg); err != nil {
+		err = Error.New("error unmarshalling segment %s", err)
+		return pb.InjuredSegment{}, nil, utils.CombineErrors(err, lease.Fail(ctx, err))
+	}
+	return *seg, lease, nil
+}
+
+// Peekqueue returns upto 'limit' of the entries from the repair queue
+func (q *RetryQueue) Peekqueue(ctx context.Context, limit int) ([]pb.InjuredSegment, error) {
+	items, err := q.queue.Peek(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	segs := make([]pb.InjuredSegment, 0, len(items))
+	for _, item := range items {
+		seg := &pb.InjuredSegment{}
+		if err := proto.Unmarshal(item.Payload, seg); err != nil {
+			return nil, err
+		}
+		segs = append(segs, *seg)
+	}
+	return segs, nil
+}
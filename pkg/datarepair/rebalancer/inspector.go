@@ -0,0 +1,73 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package rebalancer
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"storj.io/storj/pkg/pb"
+)
+
+// Inspector is a gRPC service for draining nodes and regions and following
+// their progress
+type Inspector struct {
+	rebalancer *Rebalancer
+}
+
+// NewInspector creates an Inspector
+func NewInspector(rebalancer *Rebalancer) *Inspector {
+	return &Inspector{rebalancer: rebalancer}
+}
+
+// Drain drains the nodes and the nodes of the regions
+func (srv *Inspector) Drain(ctx context.Context, req *pb.DrainRequest) (*pb.DrainResponse, error) {
+	if len(req.NodeIds) == 0 && len(req.GetRegions()) == 0 {
+		return nil, Error.New("no nodes or regions to drain")
+	}
+
+	reason := req.GetReason()
+	if reason == "" {
+		reason = "drained by operator"
+	}
+
+	drained, err := srv.rebalancer.Drain(ctx, req.NodeIds, req.GetRegions(), reason)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DrainResponse{Drained: drained}, nil
+}
+
+// DrainStatus returns the progress of the draining nodes at the last scan
+func (srv *Inspector) DrainStatus(ctx context.Context, req *pb.DrainStatusRequest) (*pb.DrainStatusResponse, error) {
+	regions, progress, scanned := srv.rebalancer.Status()
+
+	res := &pb.DrainStatusResponse{Regions: regions}
+	if !scanned.IsZero() {
+		scannedAt, err := ptypes.TimestampProto(scanned)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		res.ScannedAt = scannedAt
+	}
+
+	for _, node := range progress {
+		nodeProgress := &pb.NodeDrainProgress{
+			NodeId:          node.NodeID,
+			Region:          node.Region,
+			InitialPieces:   node.InitialPieces,
+			RemainingPieces: node.RemainingPieces,
+		}
+		if !node.Completed.IsZero() {
+			completedAt, err := ptypes.TimestampProto(node.Completed)
+			if err != nil {
+				return nil, Error.Wrap(err)
+			}
+			nodeProgress.CompletedAt = completedAt
+		}
+		res.Nodes = append(res.Nodes, nodeProgress)
+	}
+	return res, nil
+}
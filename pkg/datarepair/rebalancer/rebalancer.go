@@ -0,0 +1,365 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package rebalancer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/watchdog"
+	"storj.io/storj/storage"
+)
+
+// eventsPage is how many node events are listed at once when looking for
+// the completion of a drain
+const eventsPage = 100
+
+// Config contains configurable values for the rebalancer
+type Config struct {
+	Interval  time.Duration `help:"how frequently the nodes of drained regions are drained and the progress of draining nodes is tracked" default:"10m0s"`
+	RegionTag string        `help:"the node tag publishing the region of a node" default:"country"`
+	Regions   string        `help:"comma separated regions whose nodes are drained, in addition to the regions drained over the inspector" default:""`
+}
+
+// NodeProgress is the progress of a draining node
+type NodeProgress struct {
+	NodeID storj.NodeID
+	Region string
+	// InitialPieces are the pieces on the node when its progress was first
+	// tracked, RemainingPieces the pieces on the node at the last scan
+	InitialPieces   int64
+	RemainingPieces int64
+	// Completed is when no pieces remained, zero while the node is draining
+	Completed time.Time
+}
+
+// Rebalancer moves data off nodes and regions which are decommissioned.
+// Drained nodes aren't selected for uploads anymore and the checker queues
+// the segments with pieces on them for repair, so the repairer migrates the
+// pieces to other nodes over time. The rebalancer drains the nodes of drained
+// regions, including the ones joining later, and scans the pointerdb to track
+// how many pieces remain on every draining node. Nodes without pieces are
+// recorded as exited.
+type Rebalancer struct {
+	log       *zap.Logger
+	pointerdb *pointerdb.Service
+	cache     *overlay.Cache
+	vetting   *overlay.Vetting
+	events    overlay.EventsDB
+	config    Config
+	loop      *watchdog.Loop
+
+	mu       sync.Mutex
+	regions  map[string]bool
+	progress map[storj.NodeID]*NodeProgress
+	scanned  time.Time
+}
+
+// NewRebalancer creates a new rebalancer draining the configured regions
+func NewRebalancer(log *zap.Logger, pointerdb *pointerdb.Service, cache *overlay.Cache, vetting *overlay.Vetting, events overlay.EventsDB, config Config, loop *watchdog.Loop) *Rebalancer {
+	rebalancer := &Rebalancer{
+		log:       log,
+		pointerdb: pointerdb,
+		cache:     cache,
+		vetting:   vetting,
+		events:    events,
+		config:    config,
+		loop:      loop,
+		regions:   make(map[string]bool),
+		progress:  make(map[storj.NodeID]*NodeProgress),
+	}
+	for _, region := range strings.Split(config.Regions, ",") {
+		if region = normalizeRegion(region); region != "" {
+			rebalancer.regions[region] = true
+		}
+	}
+	return rebalancer
+}
+
+// normalizeRegion makes region tags comparable regardless of their case
+func normalizeRegion(region string) string {
+	return strings.ToUpper(strings.TrimSpace(region))
+}
+
+// Run drains the regions and tracks the progress of draining nodes every
+// interval until ctx is canceled
+func (rebalancer *Rebalancer) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ticker := time.NewTicker(rebalancer.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		err := rebalancer.Update(ctx, time.Now())
+		if err != nil {
+			rebalancer.log.Error("rebalancing failed", zap.Error(err))
+		}
+		rebalancer.loop.Cycle(err)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Drain drains the nodes and the reachable nodes of the regions. The regions
+// are remembered, so nodes joining them later are drained by Update. It
+// returns the nodes which weren't draining before.
+func (rebalancer *Rebalancer) Drain(ctx context.Context, nodeIDs storj.NodeIDList, regions []string, reason string) (drained storj.NodeIDList, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rebalancer.mu.Lock()
+	for _, region := range regions {
+		if region = normalizeRegion(region); region != "" {
+			rebalancer.regions[region] = true
+		}
+	}
+	rebalancer.mu.Unlock()
+
+	for _, nodeID := range nodeIDs {
+		ok, err := rebalancer.drain(ctx, nodeID, reason)
+		if err != nil {
+			return drained, err
+		}
+		if ok {
+			drained = append(drained, nodeID)
+		}
+	}
+
+	regionDrained, err := rebalancer.drainRegions(ctx)
+	return append(drained, regionDrained...), err
+}
+
+// drain drains the node unless it's draining already
+func (rebalancer *Rebalancer) drain(ctx context.Context, nodeID storj.NodeID, reason string) (bool, error) {
+	if rebalancer.vetting.Draining(nodeID) {
+		return false, nil
+	}
+	if err := rebalancer.vetting.Drain(ctx, nodeID, reason); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// drainRegions drains the reachable nodes of the drained regions
+func (rebalancer *Rebalancer) drainRegions(ctx context.Context) (drained storj.NodeIDList, err error) {
+	rebalancer.mu.Lock()
+	regions := len(rebalancer.regions)
+	rebalancer.mu.Unlock()
+	if regions == 0 {
+		return nil, nil
+	}
+
+	nodes, err := rebalancer.cache.Reachable(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		region := rebalancer.region(node)
+
+		rebalancer.mu.Lock()
+		drainRegion := rebalancer.regions[region]
+		rebalancer.mu.Unlock()
+		if !drainRegion {
+			continue
+		}
+
+		ok, err := rebalancer.drain(ctx, node.Id, fmt.Sprintf("region %s is decommissioned", region))
+		if err != nil {
+			// disqualified nodes don't need to be drained
+			rebalancer.log.Warn("draining node failed", zap.String("node", node.Id.String()), zap.Error(err))
+			continue
+		}
+		if ok {
+			drained = append(drained, node.Id)
+		}
+	}
+	return drained, nil
+}
+
+// region returns the normalized region tag of the node
+func (rebalancer *Rebalancer) region(node *pb.Node) string {
+	for _, tag := range node.GetTags().GetTags() {
+		if tag.Name == rebalancer.config.RegionTag {
+			return normalizeRegion(tag.Value)
+		}
+	}
+	return ""
+}
+
+// Update drains the nodes joining drained regions and counts the pieces
+// remaining on every draining node. Nodes without remaining pieces are
+// recorded as exited once.
+func (rebalancer *Rebalancer) Update(ctx context.Context, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if _, err := rebalancer.drainRegions(ctx); err != nil {
+		return err
+	}
+
+	draining := rebalancer.vetting.DrainingNodes()
+	counts, err := rebalancer.countPieces(draining)
+	if err != nil {
+		return err
+	}
+
+	regions := make(map[storj.NodeID]string, len(draining))
+	if len(draining) > 0 {
+		nodes, err := rebalancer.cache.GetAll(ctx, draining)
+		if err != nil {
+			return err
+		}
+		for _, node := range nodes {
+			if node != nil {
+				regions[node.Id] = rebalancer.region(node)
+			}
+		}
+	}
+
+	var completed []*NodeProgress
+	var remaining int64
+
+	rebalancer.mu.Lock()
+	progress := make(map[storj.NodeID]*NodeProgress, len(draining))
+	for _, nodeID := range draining {
+		count := counts[nodeID]
+		remaining += count
+
+		node, ok := rebalancer.progress[nodeID]
+		if !ok {
+			node = &NodeProgress{NodeID: nodeID, InitialPieces: count}
+		}
+		node.Region = regions[nodeID]
+		node.RemainingPieces = count
+		if count > node.InitialPieces {
+			// uploads which started before the drain may complete afterwards
+			node.InitialPieces = count
+		}
+		switch {
+		case count > 0:
+			node.Completed = time.Time{}
+		case node.Completed.IsZero():
+			node.Completed = now
+			completed = append(completed, node)
+		}
+		progress[nodeID] = node
+	}
+	rebalancer.progress = progress
+	rebalancer.scanned = now
+	rebalancer.mu.Unlock()
+
+	mon.IntVal("draining_nodes").Observe(int64(len(draining)))
+	mon.IntVal("draining_pieces_remaining").Observe(remaining)
+
+	for _, node := range completed {
+		exited, err := rebalancer.exited(ctx, node.NodeID)
+		if err != nil {
+			return err
+		}
+		if exited {
+			continue
+		}
+
+		rebalancer.log.Info("node drained",
+			zap.String("node", node.NodeID.String()),
+			zap.String("region", node.Region),
+			zap.Int64("pieces", node.InitialPieces))
+		err = rebalancer.events.Record(ctx, node.NodeID, pb.NodeEventType_EXITED,
+			fmt.Sprintf("drained %d pieces", node.InitialPieces))
+		if err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	return nil
+}
+
+// countPieces counts the remote pieces of the nodes in all pointers
+func (rebalancer *Rebalancer) countPieces(nodeIDs storj.NodeIDList) (map[storj.NodeID]int64, error) {
+	counts := make(map[storj.NodeID]int64, len(nodeIDs))
+	if len(nodeIDs) == 0 {
+		return counts, nil
+	}
+	for _, nodeID := range nodeIDs {
+		counts[nodeID] = 0
+	}
+
+	err := rebalancer.pointerdb.Iterate("", "", true, false,
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			for it.Next(&item) {
+				if !pointerdb.IsPointerKey(item.Key) {
+					// deleted pieces are removed by the purger
+					continue
+				}
+				pointer := &pb.Pointer{}
+				if err := pointerdb.UnmarshalPointer(item.Value, pointer); err != nil {
+					return Error.New("error unmarshalling pointer %q: %v", item.Key, err)
+				}
+				for _, piece := range pointer.GetRemote().GetRemotePieces() {
+					if _, ok := counts[piece.NodeId]; ok {
+						counts[piece.NodeId]++
+					}
+				}
+			}
+			return nil
+		})
+	return counts, err
+}
+
+// exited returns whether the node was recorded as exited since it was last
+// recorded as draining
+func (rebalancer *Rebalancer) exited(ctx context.Context, nodeID storj.NodeID) (bool, error) {
+	exited := false
+	var cursor int64
+	for {
+		events, err := rebalancer.events.List(ctx, nodeID, cursor, eventsPage)
+		if err != nil {
+			return false, Error.Wrap(err)
+		}
+		for _, event := range events {
+			switch event.Type {
+			case pb.NodeEventType_DRAINING:
+				exited = false
+			case pb.NodeEventType_EXITED:
+				exited = true
+			}
+			cursor = event.Id
+		}
+		if len(events) < eventsPage {
+			return exited, nil
+		}
+	}
+}
+
+// Status returns the drained regions, the progress of the draining nodes
+// ordered by node id and when they were last scanned
+func (rebalancer *Rebalancer) Status() (regions []string, progress []NodeProgress, scanned time.Time) {
+	rebalancer.mu.Lock()
+	defer rebalancer.mu.Unlock()
+
+	for region := range rebalancer.regions {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+
+	for _, node := range rebalancer.progress {
+		progress = append(progress, *node)
+	}
+	sort.Slice(progress, func(i, k int) bool {
+		return progress[i].NodeID.Less(progress[k].NodeID)
+	})
+	return regions, progress, rebalancer.scanned
+}
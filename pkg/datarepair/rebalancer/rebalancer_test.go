@@ -0,0 +1,125 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package rebalancer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/datarepair/rebalancer"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/overlay/overlaytest"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+	"storj.io/storj/storage/teststore"
+)
+
+func TestRebalancer(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		cache, nodes := overlaytest.NewCache(overlay.NodeSelectionConfig{},
+			overlaytest.NodeSpec{}, overlaytest.NodeSpec{}, overlaytest.NodeSpec{}, overlaytest.NodeSpec{})
+		tag := func(index int, region string) {
+			node, err := nodes.Get(ctx, overlaytest.NodeID(index))
+			require.NoError(t, err)
+			node.Tags = &pb.SignedNodeTags{Tags: []*pb.NodeTag{{Name: "region", Value: region}}}
+			require.NoError(t, nodes.Update(ctx, node))
+		}
+		tag(0, "de")
+		tag(1, "fr")
+
+		service := pointerdb.NewService(zaptest.NewLogger(t), teststore.New())
+		put := func(path string, indexes ...int) {
+			pointer := &pb.Pointer{Type: pb.Pointer_REMOTE, Remote: &pb.RemoteSegment{PieceId: path}}
+			for num, index := range indexes {
+				pointer.Remote.RemotePieces = append(pointer.Remote.RemotePieces,
+					&pb.RemotePiece{PieceNum: int32(num), NodeId: overlaytest.NodeID(index)})
+			}
+			require.NoError(t, service.Put(path, pointer))
+		}
+		put("l/bucket/a", 0, 1, 2)
+		put("l/bucket/b", 0)
+
+		vetting := overlay.NewVetting(zaptest.NewLogger(t), cache, db.NodeEvents(), overlay.VettingConfig{}, nil)
+		config := rebalancer.Config{Interval: time.Minute, RegionTag: "region"}
+		newRebalancer := func() *rebalancer.Rebalancer {
+			return rebalancer.NewRebalancer(zaptest.NewLogger(t), service, cache, vetting, db.NodeEvents(), config, nil)
+		}
+		rebalance := newRebalancer()
+
+		exited := func(index int) int {
+			events, err := db.NodeEvents().List(ctx, overlaytest.NodeID(index), 0, 100)
+			require.NoError(t, err)
+			count := 0
+			for _, event := range events {
+				if event.Type == pb.NodeEventType_EXITED {
+					count++
+				}
+			}
+			return count
+		}
+
+		{ // listed nodes and the nodes of regions are drained
+			drained, err := rebalance.Drain(ctx, storj.NodeIDList{overlaytest.NodeID(2)}, []string{"DE"}, "decommissioned")
+			require.NoError(t, err)
+			assert.Equal(t, storj.NodeIDList{overlaytest.NodeID(2), overlaytest.NodeID(0)}, drained)
+			assert.True(t, vetting.Draining(overlaytest.NodeID(0)))
+			assert.False(t, vetting.Draining(overlaytest.NodeID(1)))
+
+			drained, err = rebalance.Drain(ctx, storj.NodeIDList{overlaytest.NodeID(2)}, nil, "decommissioned")
+			require.NoError(t, err)
+			assert.Empty(t, drained)
+		}
+
+		now := time.Now()
+		{ // the pieces remaining on the draining nodes are counted
+			require.NoError(t, rebalance.Update(ctx, now))
+
+			regions, progress, scanned := rebalance.Status()
+			assert.Equal(t, []string{"DE"}, regions)
+			assert.Equal(t, now, scanned)
+			assert.Equal(t, []rebalancer.NodeProgress{
+				{NodeID: overlaytest.NodeID(0), Region: "DE", InitialPieces: 2, RemainingPieces: 2},
+				{NodeID: overlaytest.NodeID(2), InitialPieces: 1, RemainingPieces: 1},
+			}, progress)
+		}
+
+		{ // nodes joining drained regions are drained and drained nodes exit
+			tag(3, "de")
+			put("l/bucket/a", 1, 1, 2)
+			put("l/bucket/b", 1)
+
+			later := now.Add(time.Minute)
+			require.NoError(t, rebalance.Update(ctx, later))
+			assert.True(t, vetting.Draining(overlaytest.NodeID(3)))
+
+			_, progress, _ := rebalance.Status()
+			assert.Equal(t, []rebalancer.NodeProgress{
+				{NodeID: overlaytest.NodeID(0), Region: "DE", InitialPieces: 2, RemainingPieces: 0, Completed: later},
+				{NodeID: overlaytest.NodeID(2), InitialPieces: 1, RemainingPieces: 1},
+				{NodeID: overlaytest.NodeID(3), Region: "DE", InitialPieces: 0, RemainingPieces: 0, Completed: later},
+			}, progress)
+			assert.Equal(t, 1, exited(0))
+			assert.Equal(t, 0, exited(2))
+			assert.Equal(t, 1, exited(3))
+		}
+
+		{ // exits are recorded once, also after a restart
+			require.NoError(t, rebalance.Update(ctx, now.Add(2*time.Minute)))
+			require.NoError(t, newRebalancer().Update(ctx, now.Add(3*time.Minute)))
+			assert.Equal(t, 1, exited(0))
+			assert.Equal(t, 1, exited(3))
+		}
+	})
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"storj.io/storj/internal/memory"
+)
+
+// BudgetConfig limits the bandwidth repairs use and when they run, so repair
+// traffic doesn't compete with customer egress at peak
+type BudgetConfig struct {
+	Daily   memory.Size `help:"maximum bytes repairs transfer with storage nodes per day (UTC), 0 is unlimited" default:"0"`
+	Hourly  memory.Size `help:"maximum bytes repairs transfer with storage nodes per hour, 0 is unlimited" default:"0"`
+	Windows string      `help:"comma separated ranges of hours of the day (UTC) in which repairs start, e.g. 0-6,22-24, empty starts repairs at any time" default:""`
+}
+
+// window is a range of hours of the day, start included and end excluded
+type window struct{ start, end int }
+
+// Budget tracks the bandwidth repairs used in the current day and hour.
+// Repairs in progress are charged once they finish, so the budget can be
+// exceeded by the repairs running when it was exhausted.
+type Budget struct {
+	config  BudgetConfig
+	windows []window
+
+	mu     sync.Mutex
+	day    time.Time
+	hour   time.Time
+	daily  int64
+	hourly int64
+}
+
+// NewBudget returns a budget for config
+func NewBudget(config BudgetConfig) (*Budget, error) {
+	windows, err := parseWindows(config.Windows)
+	if err != nil {
+		return nil, err
+	}
+	return &Budget{config: config, windows: windows}, nil
+}
+
+// parseWindows parses comma separated ranges of hours such as 0-6,22-24
+func parseWindows(s string) (windows []window, err error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	for _, field := range strings.Split(s, ",") {
+		hours := strings.Split(strings.TrimSpace(field), "-")
+		if len(hours) != 2 {
+			return nil, Error.New("invalid repair window %q", field)
+		}
+		start, err := strconv.Atoi(hours[0])
+		if err != nil {
+			return nil, Error.New("invalid repair window %q", field)
+		}
+		end, err := strconv.Atoi(hours[1])
+		if err != nil {
+			return nil, Error.New("invalid repair window %q", field)
+		}
+		if start < 0 || end > 24 || start >= end {
+			return nil, Error.New("invalid repair window %q", field)
+		}
+		windows = append(windows, window{start: start, end: end})
+	}
+	return windows, nil
+}
+
+// Defer returns when repairs may start again, or the zero time when they may
+// start at now
+func (budget *Budget) Defer(now time.Time) time.Time {
+	if budget == nil {
+		return time.Time{}
+	}
+	now = now.UTC()
+
+	budget.mu.Lock()
+	defer budget.mu.Unlock()
+	budget.advance(now)
+
+	var until time.Time
+	if budget.config.Daily > 0 && budget.daily >= budget.config.Daily.Int64() {
+		until = budget.day.Add(24 * time.Hour)
+	} else if budget.config.Hourly > 0 && budget.hourly >= budget.config.Hourly.Int64() {
+		until = budget.hour.Add(time.Hour)
+	}
+
+	if opens := budget.nextWindow(latest(now, until)); !opens.Equal(latest(now, until)) {
+		until = opens
+	}
+	return until
+}
+
+// Use charges bytes transferred by a repair to the budget
+func (budget *Budget) Use(now time.Time, bytes int64) {
+	if budget == nil {
+		return
+	}
+
+	budget.mu.Lock()
+	defer budget.mu.Unlock()
+	budget.advance(now.UTC())
+
+	budget.daily += bytes
+	budget.hourly += bytes
+}
+
+// advance resets the usage when a new day or hour started
+func (budget *Budget) advance(now time.Time) {
+	if day := now.Truncate(24 * time.Hour); !day.Equal(budget.day) {
+		budget.day, budget.daily = day, 0
+	}
+	if hour := now.Truncate(time.Hour); !hour.Equal(budget.hour) {
+		budget.hour, budget.hourly = hour, 0
+	}
+}
+
+// nextWindow returns the first time at or after t which is in a repair
+// window
+func (budget *Budget) nextWindow(t time.Time) time.Time {
+	if len(budget.windows) == 0 {
+		return t
+	}
+
+	day := t.Truncate(24 * time.Hour)
+	var next time.Time
+	for _, window := range budget.windows {
+		start, end := day.Add(time.Duration(window.start)*time.Hour), day.Add(time.Duration(window.end)*time.Hour)
+		switch {
+		case !t.Before(start) && t.Before(end):
+			return t
+		case t.Before(start):
+			// the window opens later today
+		default:
+			// the window opens tomorrow
+			start = start.Add(24 * time.Hour)
+		}
+		if next.IsZero() || start.Before(next) {
+			next = start
+		}
+	}
+	return next
+}
+
+// latest returns the later of the two times
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
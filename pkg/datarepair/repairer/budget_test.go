@@ -0,0 +1,70 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repairer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/memory"
+)
+
+func TestBudget(t *testing.T) {
+	day := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	{ // unlimited budgets and nil budgets never defer
+		budget, err := NewBudget(BudgetConfig{})
+		require.NoError(t, err)
+		budget.Use(at(1, 0), 1<<40)
+		assert.True(t, budget.Defer(at(1, 0)).IsZero())
+
+		var none *Budget
+		none.Use(at(1, 0), 1)
+		assert.True(t, none.Defer(at(1, 0)).IsZero())
+	}
+
+	{ // exhausted hourly budgets defer to the next hour
+		budget, err := NewBudget(BudgetConfig{Hourly: memory.MB})
+		require.NoError(t, err)
+		budget.Use(at(10, 5), memory.MB.Int64()-1)
+		assert.True(t, budget.Defer(at(10, 10)).IsZero())
+
+		budget.Use(at(10, 15), 1)
+		assert.Equal(t, at(11, 0), budget.Defer(at(10, 20)))
+		assert.True(t, budget.Defer(at(11, 0)).IsZero())
+	}
+
+	{ // exhausted daily budgets defer to the next day
+		budget, err := NewBudget(BudgetConfig{Daily: memory.GB, Hourly: memory.MB})
+		require.NoError(t, err)
+		budget.Use(at(10, 0), memory.GB.Int64())
+		assert.Equal(t, at(24, 0), budget.Defer(at(12, 0)))
+		assert.True(t, budget.Defer(at(24, 0)).IsZero())
+	}
+
+	{ // repairs only start within the windows
+		budget, err := NewBudget(BudgetConfig{Hourly: memory.MB, Windows: "1-6, 22-24"})
+		require.NoError(t, err)
+		assert.True(t, budget.Defer(at(1, 0)).IsZero())
+		assert.True(t, budget.Defer(at(23, 59)).IsZero())
+		assert.Equal(t, at(22, 0), budget.Defer(at(6, 0)))
+		assert.Equal(t, at(1, 0), budget.Defer(at(0, 30)))
+
+		// an hourly budget exhausted at the end of a window defers to the
+		// next window
+		budget.Use(at(5, 30), memory.MB.Int64())
+		assert.Equal(t, at(22, 0), budget.Defer(at(5, 45)))
+	}
+
+	for _, windows := range []string{"1", "1-", "a-2", "6-2", "0-25", "3-3"} {
+		_, err := NewBudget(BudgetConfig{Windows: windows})
+		assert.Error(t, err, windows)
+	}
+}
@@ -14,8 +14,10 @@ import (
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pointerdb/pdbclient"
 	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/retryqueue"
 	ecclient "storj.io/storj/pkg/storage/ec"
 	"storj.io/storj/pkg/storage/segments"
+	"storj.io/storj/pkg/transport"
 )
 
 // Config contains configurable values for repairer
@@ -26,6 +28,8 @@ type Config struct {
 	PointerDBAddr string        `help:"Address to contact pointerdb server through"`
 	MaxBufferMem  memory.Size   `help:"maximum buffer memory (in bytes) to be allocated for read buffers" default:"4M"`
 	APIKey        string        `help:"repairer-specific pointerdb access credential"`
+	Budget        BudgetConfig
+	Retry         retryqueue.Config
 }
 
 // Run runs the repair service with configured values
@@ -37,12 +41,17 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 		return Error.New("unable to get master db instance")
 	}
 
-	repairer, err := c.GetSegmentRepairer(ctx, server.Identity())
+	repairer, err := c.GetSegmentRepairer(ctx, server.Identity(), transport.NewClient(server.Identity()))
 	if err != nil {
 		return Error.Wrap(err)
 	}
 
-	service := NewService(q.RepairQueue(), repairer, c.Interval, c.MaxRepair)
+	budget, err := NewBudget(c.Budget)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	service := NewService(q.RepairQueue(), repairer, c.Interval, c.MaxRepair, budget, nil)
 
 	ctx, cancel := context.WithCancel(ctx)
 
@@ -57,8 +66,9 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 	return server.Run(ctx)
 }
 
-// GetSegmentRepairer creates a new segment repairer from storeConfig values
-func (c Config) GetSegmentRepairer(ctx context.Context, identity *provider.FullIdentity) (ss SegmentRepairer, err error) {
+// GetSegmentRepairer creates a new segment repairer from storeConfig values,
+// which contacts the storage nodes with tc
+func (c Config) GetSegmentRepairer(ctx context.Context, identity *provider.FullIdentity, tc transport.Client) (ss SegmentRepairer, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	var oc overlay.Client
@@ -72,7 +82,7 @@ func (c Config) GetSegmentRepairer(ctx context.Context, identity *provider.FullI
 		return nil, err
 	}
 
-	ec := ecclient.NewClient(identity, c.MaxBufferMem.Int())
+	ec := ecclient.NewTransportClient(tc, c.MaxBufferMem.Int())
 
 	return segments.NewSegmentRepairer(oc, ec, pdb), nil
 }
@@ -11,13 +11,22 @@ import (
 
 	"storj.io/storj/internal/sync2"
 	"storj.io/storj/pkg/datarepair/queue"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/retryqueue"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/watchdog"
 	"storj.io/storj/storage"
 )
 
 // SegmentRepairer is a repairer for segments
 type SegmentRepairer interface {
-	Repair(ctx context.Context, path storj.Path, lostPieces []int32) (err error)
+	Repair(ctx context.Context, path storj.Path, lostPieces []int32) (transferred int64, err error)
+}
+
+// leaser is a repair queue leasing segments, their repairs are retried
+// unless they're acknowledged through the lease
+type leaser interface {
+	Lease(ctx context.Context) (pb.InjuredSegment, *retryqueue.Lease, error)
 }
 
 // Service contains the information needed to run the repair service
@@ -26,15 +35,19 @@ type Service struct {
 	repairer SegmentRepairer
 	limiter  *sync2.Limiter
 	ticker   *time.Ticker
+	budget   *Budget
+	progress *watchdog.Queue
 }
 
-// NewService creates repairing service
-func NewService(queue queue.RepairQueue, repairer SegmentRepairer, interval time.Duration, concurrency int) *Service {
+// NewService creates repairing service, budget and progress may be nil
+func NewService(queue queue.RepairQueue, repairer SegmentRepairer, interval time.Duration, concurrency int, budget *Budget, progress *watchdog.Queue) *Service {
 	return &Service{
 		queue:    queue,
 		repairer: repairer,
 		limiter:  sync2.NewLimiter(concurrency),
 		ticker:   time.NewTicker(interval),
+		budget:   budget,
+		progress: progress,
 	}
 }
 
@@ -64,19 +77,49 @@ func (service *Service) Run(ctx context.Context) (err error) {
 
 // process picks an item from repair queue and spawns a repair worker
 func (service *Service) process(ctx context.Context) error {
-	seg, err := service.queue.Dequeue(ctx)
+	if until := service.budget.Defer(time.Now()); !until.IsZero() {
+		// deferring repairs isn't the consumer being stuck
+		mon.Counter("deferred_repairs").Inc(1)
+		zap.L().Debug("Repairs deferred", zap.Time("until", until))
+		service.progress.Empty()
+		return nil
+	}
+
+	var seg pb.InjuredSegment
+	var lease *retryqueue.Lease
+	var err error
+	if leasing, ok := service.queue.(leaser); ok {
+		seg, lease, err = leasing.Lease(ctx)
+	} else {
+		seg, err = service.queue.Dequeue(ctx)
+	}
 	if err != nil {
 		if storage.ErrEmptyQueue.Has(err) {
+			service.progress.Empty()
 			return nil
 		}
+		service.progress.Failed(err)
 		return err
 	}
 
 	service.limiter.Go(ctx, func() {
-		err := service.repairer.Repair(ctx, seg.GetPath(), seg.GetLostPieces())
+		transferred, err := service.repairer.Repair(ctx, seg.GetPath(), seg.GetLostPieces())
+		service.budget.Use(time.Now(), transferred)
+		mon.IntVal("repair_transferred_bytes").Observe(transferred)
 		if err != nil {
 			zap.L().Error("Repair failed", zap.Error(err))
 		}
+		if lease != nil {
+			if err != nil {
+				err = lease.Fail(ctx, err)
+			} else {
+				err = lease.Ack(ctx)
+			}
+			if err != nil {
+				zap.L().Error("Completing repair lease failed", zap.Error(err))
+			}
+		}
+		service.progress.Drained(1)
 	})
 
 	return nil
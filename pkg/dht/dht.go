@@ -16,6 +16,7 @@ import (
 type DHT interface {
 	GetNodes(ctx context.Context, start storj.NodeID, limit int, restrictions ...pb.Restriction) ([]*pb.Node, error)
 	GetRoutingTable(ctx context.Context) (RoutingTable, error)
+	GetRecords(ctx context.Context) (Records, error)
 	Bootstrap(ctx context.Context) error
 	Ping(ctx context.Context, node pb.Node) (pb.Node, error)
 	FindNode(ctx context.Context, ID storj.NodeID) (pb.Node, error)
@@ -39,6 +40,12 @@ type RoutingTable interface {
 	GetBucketTimestamp(id []byte) (time.Time, error)
 }
 
+// Records contains the records of other nodes stored locally
+type Records interface {
+	Put(record *pb.Record) error
+	Get(key storj.NodeID) (record *pb.Record, ok bool)
+}
+
 // Bucket is a set of methods to act on kademlia k buckets
 type Bucket interface {
 	Routing() []pb.Node
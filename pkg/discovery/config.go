@@ -26,6 +26,7 @@ var (
 // Config loads on the configuration values from run flags
 type Config struct {
 	RefreshInterval time.Duration `help:"the interval at which the cache refreshes itself in seconds" default:"1s"`
+	Partition       PartitionConfig
 }
 
 // Run runs the Discovery boot up and initialization
@@ -49,6 +50,7 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 	}
 
 	discovery := NewDiscovery(zap.L().Named("discovery"), overlay, kad, stat.StatDB())
+	kad.AddObserver(discovery)
 
 	zap.L().Debug("Starting discovery")
 
@@ -6,6 +6,9 @@ package discovery
 import (
 	"context"
 
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storj"
 )
@@ -17,8 +20,22 @@ func (d *Discovery) GracefulDisconnect(id storj.NodeID) {
 
 // ConnFailure implements the Transport Observer interface `ConnFailure` function
 func (d *Discovery) ConnFailure(ctx context.Context, node *pb.Node, err error) {
+	d.recordContact(ctx, node, false)
 }
 
 // ConnSuccess implements the Transport Observer interface `ConnSuccess` function
 func (d *Discovery) ConnSuccess(ctx context.Context, node *pb.Node) {
+	d.recordContact(ctx, node, true)
+}
+
+// recordContact records the contact with nodes in the overlay cache, nodes
+// which aren't cached yet are added by the next refresh
+func (d *Discovery) recordContact(ctx context.Context, node *pb.Node, success bool) {
+	if node == nil || node.Id.IsZero() {
+		return
+	}
+	err := d.cache.RecordContact(ctx, node.Id, success)
+	if err != nil && err != overlay.ErrNodeNotFound {
+		d.log.Debug("recording contact failed", zap.String("node", node.Id.String()), zap.Error(err))
+	}
 }
@@ -0,0 +1,168 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/kademlia"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+// PartitionConfig defines how network partitions are detected
+type PartitionConfig struct {
+	Interval  time.Duration `help:"how often lookups of random node IDs are compared against the overlay cache, 0 disables partition detection" default:"10m0s"`
+	Samples   int           `help:"the number of random node IDs looked up by every partition check" default:"8"`
+	K         int           `help:"the number of closest cached nodes every lookup is compared against" default:"20"`
+	Threshold float64       `help:"the fraction of the closest cached nodes missing from the lookups above which a partition is reported" default:"0.5"`
+}
+
+// PartitionDetector looks up random node IDs and compares the nodes found
+// with the closest reachable nodes in the overlay cache. When the lookups miss
+// too many of them, the satellite probably sees only part of the network, so
+// it reports a probable partition and refreshes every bucket to find the rest.
+type PartitionDetector struct {
+	log       *zap.Logger
+	cache     *overlay.Cache
+	kad       *kademlia.Kademlia
+	refresher *kademlia.Refresher
+	config    PartitionConfig
+}
+
+// NewPartitionDetector returns a partition detector comparing the lookups of
+// kad with cache, refreshing the buckets with refresher
+func NewPartitionDetector(log *zap.Logger, cache *overlay.Cache, kad *kademlia.Kademlia, refresher *kademlia.Refresher, config PartitionConfig) *PartitionDetector {
+	return &PartitionDetector{
+		log:       log,
+		cache:     cache,
+		kad:       kad,
+		refresher: refresher,
+		config:    config,
+	}
+}
+
+// Run checks for a partition every interval until ctx is canceled
+func (detector *PartitionDetector) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if detector.config.Interval <= 0 {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	ticker := time.NewTicker(detector.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		partitioned, err := detector.Check(ctx)
+		if err != nil {
+			detector.log.Warn("partition check failed", zap.Error(err))
+			continue
+		}
+		if partitioned {
+			if _, err := detector.refresher.RefreshAll(ctx); err != nil {
+				detector.log.Warn("refreshing buckets after partition failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Check looks up the configured number of random node IDs and returns whether
+// the divergence of the nodes found from the overlay cache exceeds the
+// threshold
+func (detector *PartitionDetector) Check(ctx context.Context) (partitioned bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	cached, err := detector.cache.Reachable(ctx)
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+	if len(cached) == 0 {
+		// nothing to compare with yet
+		return false, nil
+	}
+
+	var expected, missing int
+	var errors errs.Group
+	for i := 0; i < detector.config.Samples; i++ {
+		id, err := randomID()
+		if err != nil {
+			return false, err
+		}
+
+		found, err := detector.kad.Lookup(ctx, id)
+		if err != nil {
+			if ctx.Err() != nil {
+				return false, ctx.Err()
+			}
+			errors.Add(err)
+			continue
+		}
+
+		closest := closestTo(id, cached, detector.config.K)
+		expected += len(closest)
+		missing += countMissing(closest, found)
+	}
+	if expected == 0 {
+		return false, Error.Wrap(errors.Err())
+	}
+
+	divergence := float64(missing) / float64(expected)
+	mon.FloatVal("partition_divergence").Observe(divergence)
+	if divergence <= detector.config.Threshold {
+		return false, nil
+	}
+
+	mon.Event("probable_partition")
+	detector.log.Warn("probable network partition, lookups miss the closest cached nodes",
+		zap.Float64("divergence", divergence), zap.Int("missing", missing), zap.Int("expected", expected))
+	return true, nil
+}
+
+// closestTo returns the k nodes closest to id, closest first
+func closestTo(id storj.NodeID, nodes []*pb.Node, k int) []*pb.Node {
+	sorted := append([]*pb.Node(nil), nodes...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(xor(sorted[i].Id, id), xor(sorted[j].Id, id)) < 0
+	})
+	if k > 0 && len(sorted) > k {
+		sorted = sorted[:k]
+	}
+	return sorted
+}
+
+// countMissing returns the number of expected nodes which weren't found
+func countMissing(expected, found []*pb.Node) (missing int) {
+	ids := make(map[storj.NodeID]struct{}, len(found))
+	for _, node := range found {
+		ids[node.Id] = struct{}{}
+	}
+	for _, node := range expected {
+		if _, ok := ids[node.Id]; !ok {
+			missing++
+		}
+	}
+	return missing
+}
+
+func xor(a, b storj.NodeID) []byte {
+	r := make([]byte, len(a))
+	for i := range a {
+		r[i] = a[i] ^ b[i]
+	}
+	return r
+}
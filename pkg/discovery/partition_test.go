@@ -0,0 +1,55 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package discovery_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestPartitionDetector(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	planet, err := testplanet.New(t, 1, 10, 0)
+	require.NoError(t, err)
+	defer ctx.Check(planet.Shutdown)
+
+	planet.Start(ctx)
+
+	satellite := planet.Satellites[0]
+	require.NoError(t, satellite.Discovery.Service.Refresh(ctx))
+
+	partitioned, err := satellite.Discovery.Partition.Check(ctx)
+	require.NoError(t, err)
+	assert.False(t, partitioned, "lookups find the cached nodes")
+
+	// nodes the satellite cannot see anymore, while they are still in the cache
+	for i := 0; i < 40; i++ {
+		var b [32]byte
+		_, err := rand.Read(b[:])
+		require.NoError(t, err)
+		id, err := storj.NodeIDFromBytes(b[:])
+		require.NoError(t, err)
+
+		err = satellite.Overlay.Service.Put(ctx, id, pb.Node{
+			Id:      id,
+			Type:    pb.NodeType_STORAGE,
+			Address: &pb.NodeAddress{Address: "127.0.0.1:1"},
+		})
+		require.NoError(t, err)
+	}
+
+	partitioned, err = satellite.Discovery.Partition.Check(ctx)
+	require.NoError(t, err)
+	assert.True(t, partitioned, "lookups miss most of the cached nodes")
+}
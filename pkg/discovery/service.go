@@ -15,6 +15,7 @@ import (
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/statdb"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/watchdog"
 )
 
 var (
@@ -30,16 +31,19 @@ type Discovery struct {
 	statdb statdb.DB
 
 	refreshInterval time.Duration
+	loop            *watchdog.Loop
 }
 
-// New returns a new discovery service.
-func New(logger *zap.Logger, ol *overlay.Cache, kad *kademlia.Kademlia, stat statdb.DB, refreshInterval time.Duration) *Discovery {
+// New returns a new discovery service. loop receives the completed refresh
+// cycles and may be nil.
+func New(logger *zap.Logger, ol *overlay.Cache, kad *kademlia.Kademlia, stat statdb.DB, refreshInterval time.Duration, loop *watchdog.Loop) *Discovery {
 	return &Discovery{
 		log:             logger,
 		cache:           ol,
 		kad:             kad,
 		statdb:          stat,
 		refreshInterval: refreshInterval,
+		loop:            loop,
 	}
 }
 
@@ -66,6 +70,7 @@ func (discovery *Discovery) Run(ctx context.Context) error {
 		if err != nil {
 			discovery.log.Error("Error with cache refresh: ", zap.Error(err))
 		}
+		discovery.loop.Cycle(err)
 
 		err = discovery.Discovery(ctx)
 		if err != nil {
@@ -87,13 +92,7 @@ func (discovery *Discovery) Refresh(ctx context.Context) error {
 	// TODO(coyle): make refresh work by looking on the network for new ndoes
 	nodes := discovery.kad.Seen()
 
-	for _, v := range nodes {
-		if err := discovery.cache.Put(ctx, v.Id, *v); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return discovery.cache.BulkPut(ctx, nodes)
 }
 
 // Bootstrap walks the initialized network and populates the cache
@@ -30,6 +30,12 @@ func makePadding(dataLen int64, blockSize int) []byte {
 	return paddingBytes
 }
 
+// PaddedSize returns the size of data of length dataLen after padding it to
+// a multiple of blockSize with Pad or PadReader
+func PaddedSize(dataLen int64, blockSize int) int64 {
+	return dataLen + int64(len(makePadding(dataLen, blockSize)))
+}
+
 // Pad takes a Ranger and returns another Ranger that is a multiple of
 // blockSize in length. The return value padding is a convenience to report how
 // much padding was added.
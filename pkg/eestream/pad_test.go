@@ -44,6 +44,9 @@ func TestPad(t *testing.T) {
 		if int64(padding+len(example.data)) != padded.Size() {
 			t.Fatalf("invalid padding")
 		}
+		if PaddedSize(int64(len(example.data)), example.blockSize) != padded.Size() {
+			t.Fatalf("invalid padded size: %d", examplenum)
+		}
 		unpadded, err := Unpad(padded, padding)
 		if err != nil {
 			t.Fatalf("unexpected error")
@@ -7,6 +7,8 @@ import (
 	"crypto/hmac"
 	"crypto/sha512"
 
+	"golang.org/x/crypto/nacl/secretbox"
+
 	"storj.io/storj/pkg/storj"
 )
 
@@ -94,6 +96,32 @@ func NewDecrypter(cipher storj.Cipher, key *storj.Key, startingNonce *storj.Nonc
 	}
 }
 
+// aesgcmOverhead is the size of the authentication tag of every AES-GCM block
+const aesgcmOverhead = 16
+
+// CalcDecryptedSize returns the size of encryptedSize bytes encrypted with
+// cipher in blocks of encryptedBlockSize once they're decrypted, including
+// the padding of the last block
+func CalcDecryptedSize(encryptedSize int64, cipher storj.Cipher, encryptedBlockSize int) (int64, error) {
+	var overhead int
+	switch cipher {
+	case storj.Unencrypted:
+		return encryptedSize, nil
+	case storj.AESGCM:
+		overhead = aesgcmOverhead
+	case storj.SecretBox:
+		overhead = secretbox.Overhead
+	default:
+		return 0, ErrInvalidConfig.New("encryption type %d is not supported", cipher)
+	}
+	if encryptedBlockSize <= overhead {
+		return 0, ErrInvalidConfig.New("encrypted block size %d too small", encryptedBlockSize)
+	}
+
+	blocks := (encryptedSize + int64(encryptedBlockSize) - 1) / int64(encryptedBlockSize)
+	return encryptedSize - blocks*int64(overhead), nil
+}
+
 // EncryptKey encrypts keyToEncrypt with the given cipher, key and nonce
 func EncryptKey(keyToEncrypt *storj.Key, cipher storj.Cipher, key *storj.Key, nonce *storj.Nonce) (storj.EncryptedPrivateKey, error) {
 	return Encrypt(keyToEncrypt[:], cipher, key, nonce)
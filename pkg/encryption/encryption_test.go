@@ -0,0 +1,40 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package encryption
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/pkg/storj"
+)
+
+func TestCalcDecryptedSize(t *testing.T) {
+	key := new(storj.Key)
+	nonce := new(storj.Nonce)
+
+	for _, cipher := range []storj.Cipher{storj.Unencrypted, storj.AESGCM, storj.SecretBox} {
+		for _, blocks := range []int{0, 1, 5} {
+			encrypter, err := NewEncrypter(cipher, key, nonce, 64)
+			require.NoError(t, err)
+
+			data := make([]byte, blocks*encrypter.InBlockSize())
+			encrypted, err := ioutil.ReadAll(TransformReader(ioutil.NopCloser(bytes.NewReader(data)), encrypter, 0))
+			require.NoError(t, err)
+
+			size, err := CalcDecryptedSize(int64(len(encrypted)), cipher, 64)
+			require.NoError(t, err)
+			assert.EqualValues(t, len(data), size, "%v %d", cipher, blocks)
+		}
+	}
+
+	_, err := CalcDecryptedSize(64, storj.AESGCM, 16)
+	assert.True(t, ErrInvalidConfig.Has(err))
+	_, err = CalcDecryptedSize(64, storj.Cipher(42), 64)
+	assert.True(t, ErrInvalidConfig.Has(err))
+}
@@ -0,0 +1,213 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gc
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/internal/bloomfilter"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/piecestore/psserver"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/storage/meta"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/pkg/utils"
+	"storj.io/storj/pkg/watchdog"
+)
+
+// Config contains configurable values for garbage collection
+type Config struct {
+	Interval          time.Duration `help:"how frequently storage nodes are sent the pieces to retain, 0 disables garbage collection" default:"120h0m0s"`
+	FalsePositiveRate float64       `help:"the false positive rate of the filters, garbage matching by chance is collected by a later run" default:"0.1"`
+	InitialPieces     int           `help:"how many pieces the filter of a node is sized for when its piece count wasn't tallied" default:"100000"`
+	UploadGrace       time.Duration `help:"how long uploads may take to commit their pointer, pieces stored within it before a run are retained" default:"1h0m0s"`
+}
+
+// Service garbage collects the pieces of deleted segments on storage nodes.
+// Every interval it sends each node a bloom filter of the pieces the
+// pointerdb references on it; nodes trash the pieces of the satellite which
+// aren't in the filter. Filters are only sent when the whole pointerdb was
+// iterated, a partial filter would trash live pieces.
+type Service struct {
+	log       *zap.Logger
+	pointerdb *pointerdb.Service
+	cache     *overlay.Cache
+	transport transport.Client
+	config    Config
+	loop      *watchdog.Loop
+}
+
+// NewService creates a new garbage collection service
+func NewService(log *zap.Logger, pointerdb *pointerdb.Service, cache *overlay.Cache, transport transport.Client, config Config, loop *watchdog.Loop) *Service {
+	return &Service{
+		log:       log,
+		pointerdb: pointerdb,
+		cache:     cache,
+		transport: transport,
+		config:    config,
+		loop:      loop,
+	}
+}
+
+// Run garbage collects every interval until ctx is canceled
+func (service *Service) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if service.config.Interval <= 0 {
+		service.log.Info("garbage collection disabled")
+		return nil
+	}
+
+	ticker := time.NewTicker(service.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		err := service.Collect(ctx)
+		if err != nil {
+			service.log.Error("garbage collection failed", zap.Error(err))
+		}
+		service.loop.Cycle(err)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Collect sends every node referenced by the pointerdb the filter of the
+// pieces it should retain. Nodes which can't be reached are skipped until
+// the next run.
+func (service *Service) Collect(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// pieces of uploads which didn't commit their pointer yet aren't in the
+	// filters, the grace retains them
+	createdAt := time.Now().Add(-service.config.UploadGrace)
+
+	filters, err := service.Filters(ctx)
+	if err != nil {
+		return err
+	}
+
+	for nodeID, filter := range filters {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		summary, err := service.send(ctx, nodeID, filter, createdAt)
+		if err != nil {
+			service.log.Debug("sending retain request failed", zap.String("node", nodeID.String()), zap.Error(err))
+			mon.Counter("gc_nodes_failed").Inc(1)
+			continue
+		}
+		if summary == nil {
+			mon.Counter("gc_nodes_queued").Inc(1)
+			service.log.Debug("node queued garbage collection", zap.String("node", nodeID.String()))
+			continue
+		}
+		mon.Counter("gc_nodes_collected").Inc(1)
+		mon.Counter("gc_pieces_trashed").Inc(summary.PiecesTrashed)
+		service.log.Debug("node garbage collected",
+			zap.String("node", nodeID.String()),
+			zap.Int64("examined", summary.PiecesExamined),
+			zap.Int64("trashed", summary.PiecesTrashed))
+	}
+	return nil
+}
+
+// Filters returns the bloom filters of the ids of the pieces stored on every
+// node referenced by the pointerdb, as derived for the node. The filters also
+// contain the ids nodes store the pieces with, which identify the pieces
+// stored before nodes recorded the derived ids.
+func (service *Service) Filters(ctx context.Context) (_ map[storj.NodeID]*bloomfilter.Filter, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	satelliteID := service.transport.Identity().ID
+
+	filters := make(map[storj.NodeID]*bloomfilter.Filter)
+	err = service.pointerdb.IteratePointers(ctx, pointerdb.IterateOptions{MetaFlags: meta.Remote},
+		func(ctx context.Context, batch []pointerdb.PointerItem) error {
+			for _, item := range batch {
+				remote := item.Pointer.GetRemote()
+				if remote == nil {
+					continue
+				}
+
+				pieceID := psclient.PieceID(remote.PieceId)
+				for _, piece := range remote.GetRemotePieces() {
+					derived, err := pieceID.Derive(piece.NodeId.Bytes())
+					if err != nil {
+						return Error.Wrap(err)
+					}
+					stored, err := psserver.StoredPieceID(derived.String(), satelliteID)
+					if err != nil {
+						return Error.Wrap(err)
+					}
+
+					filter, ok := filters[piece.NodeId]
+					if !ok {
+						filter = bloomfilter.NewOptimal(2*service.expectedPieces(piece.NodeId), service.config.FalsePositiveRate)
+						filters[piece.NodeId] = filter
+					}
+					filter.Add(derived.String())
+					filter.Add(stored)
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return filters, nil
+}
+
+// expectedPieces returns how many pieces the filter of the node is sized for
+func (service *Service) expectedPieces(nodeID storj.NodeID) int {
+	expected := service.config.InitialPieces
+	if count, ok := service.cache.PieceCount(nodeID); ok && int(count) > expected {
+		expected = int(count)
+	}
+	return expected
+}
+
+// send sends the filter to the node and returns the summary of the node, or
+// nil when the node queued the request and records the summary in its
+// retain log
+func (service *Service) send(ctx context.Context, nodeID storj.NodeID, filter *bloomfilter.Filter, createdAt time.Time) (_ *pb.RetainSummary, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	node, err := service.cache.Get(ctx, nodeID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	conn, err := service.transport.DialNode(ctx, node)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer utils.LogClose(conn)
+
+	resp, err := pb.NewPieceStoreRoutesClient(conn).Retain(ctx, &pb.RetainRequest{
+		Filter:         filter.ToPB(),
+		CreatedUnixSec: createdAt.Unix(),
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if resp.Summary == nil {
+		return nil, nil
+	}
+	if err := psserver.VerifyRetainSummary(nodeID, resp.Summary); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return resp.Summary, nil
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package gc_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/piecestore/psserver"
+	"storj.io/storj/pkg/piecestore/psserver/psdb"
+)
+
+func TestCollect(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	planet, err := testplanet.New(t, 1, 1, 0)
+	require.NoError(t, err)
+	defer ctx.Check(planet.Shutdown)
+
+	planet.Start(ctx)
+
+	satellite := planet.Satellites[0]
+	node := planet.StorageNodes[0]
+	require.NoError(t, satellite.Overlay.Service.Put(ctx, node.ID(), node.Local()))
+
+	pointer := &pb.Pointer{
+		Type: pb.Pointer_REMOTE,
+		Remote: &pb.RemoteSegment{
+			PieceId:      "retainedpieceretainedpiece",
+			RemotePieces: []*pb.RemotePiece{{PieceNum: 0, NodeId: node.ID()}},
+		},
+	}
+	require.NoError(t, satellite.Metainfo.Service.Put("l/bucket/object", pointer))
+
+	retained, err := psclient.PieceID(pointer.Remote.PieceId).Derive(node.ID().Bytes())
+	require.NoError(t, err)
+	garbage, err := psclient.PieceID("deletedpiecedeletedpiece").Derive(node.ID().Bytes())
+	require.NoError(t, err)
+
+	storage, db := node.DB.Storage(), node.DB.PSDB()
+	stored := time.Now().Add(-2 * time.Hour)
+	for _, id := range []string{"11111111111111111111", "22222222222222222222"} {
+		writer, err := storage.Writer(id)
+		require.NoError(t, err)
+		_, err = writer.Write([]byte("piece content"))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		path, err := storage.PiecePath(id)
+		require.NoError(t, err)
+		require.NoError(t, os.Chtimes(path, stored, stored))
+		require.NoError(t, db.AddTTL(id, 0, int64(len("piece content"))))
+	}
+	require.NoError(t, db.AddPieceHash(&psdb.PieceHash{ID: "11111111111111111111", PieceID: retained.String(), SatelliteID: satellite.ID()}))
+	require.NoError(t, db.AddPieceHash(&psdb.PieceHash{ID: "22222222222222222222", PieceID: garbage.String(), SatelliteID: satellite.ID()}))
+
+	filters, err := satellite.GarbageCollection.Filters(ctx)
+	require.NoError(t, err)
+	require.Len(t, filters, 1)
+	assert.True(t, filters[node.ID()].Contains(retained.String()))
+
+	// the ids the node stores the pieces with are in the filter as well
+	storedID, err := psserver.StoredPieceID(retained.String(), satellite.ID())
+	require.NoError(t, err)
+	assert.True(t, filters[node.ID()].Contains(storedID))
+
+	// the node processes the request in the background
+	require.NoError(t, satellite.GarbageCollection.Collect(ctx))
+
+	var summaries []*pb.RetainSummary
+	for i := 0; i < 100 && len(summaries) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+		summaries, err = db.GetRetainSummaries(satellite.ID(), 0)
+		require.NoError(t, err)
+	}
+
+	exists := func(id string) bool {
+		path, err := storage.PiecePath(id)
+		require.NoError(t, err)
+		_, err = os.Stat(path)
+		return err == nil
+	}
+	assert.True(t, exists("11111111111111111111"))
+	assert.False(t, exists("22222222222222222222"))
+
+	require.Len(t, summaries, 1)
+	assert.EqualValues(t, 2, summaries[0].PiecesExamined)
+	assert.EqualValues(t, 1, summaries[0].PiecesTrashed)
+}
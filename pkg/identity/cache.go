@@ -0,0 +1,114 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package identity
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"crypto/x509"
+	"sync"
+
+	"storj.io/storj/pkg/peertls"
+)
+
+// VerificationCache remembers certificate chains which have already passed
+// verification, so that repeated connections from the same peers can skip
+// the expensive signature checks. The cache keeps at most `size` chains and
+// evicts the least recently used one when full.
+type VerificationCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[[sha256.Size]byte]*list.Element
+}
+
+// NewVerificationCache returns a new verification cache holding at most size
+// chains. A nil cache is returned when size is not positive; a nil cache
+// doesn't cache anything.
+func NewVerificationCache(size int) *VerificationCache {
+	if size <= 0 {
+		return nil
+	}
+	return &VerificationCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[[sha256.Size]byte]*list.Element),
+	}
+}
+
+// Wrap returns a verification function which only calls next for chains that
+// aren't already known to be valid. Only successful verifications are cached.
+func (cache *VerificationCache) Wrap(next peertls.PeerCertVerificationFunc) peertls.PeerCertVerificationFunc {
+	if cache == nil || next == nil {
+		return next
+	}
+	return func(rawChain [][]byte, parsedChains [][]*x509.Certificate) error {
+		key := chainHash(rawChain)
+		if cache.lookup(key) {
+			mon.Counter("peer_verification_cache_hit").Inc(1)
+			return nil
+		}
+		mon.Counter("peer_verification_cache_miss").Inc(1)
+
+		if err := next(rawChain, parsedChains); err != nil {
+			return err
+		}
+
+		cache.add(key)
+		return nil
+	}
+}
+
+// Len returns the number of cached chains
+func (cache *VerificationCache) Len() int {
+	if cache == nil {
+		return 0
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.order.Len()
+}
+
+// lookup checks whether key is cached and marks it as recently used
+func (cache *VerificationCache) lookup(key [sha256.Size]byte) bool {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	elem, ok := cache.entries[key]
+	if ok {
+		cache.order.MoveToFront(elem)
+	}
+	return ok
+}
+
+// add inserts key into the cache, evicting the oldest entries when full
+func (cache *VerificationCache) add(key [sha256.Size]byte) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if elem, ok := cache.entries[key]; ok {
+		cache.order.MoveToFront(elem)
+		return
+	}
+
+	cache.entries[key] = cache.order.PushFront(key)
+	for cache.order.Len() > cache.size {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.entries, oldest.Value.([sha256.Size]byte))
+		mon.Counter("peer_verification_cache_evict").Inc(1)
+	}
+	mon.IntVal("peer_verification_cache_size").Observe(int64(cache.order.Len()))
+}
+
+// chainHash hashes all certificates of the raw chain
+func chainHash(rawChain [][]byte) (key [sha256.Size]byte) {
+	hash := sha256.New()
+	for _, cert := range rawChain {
+		sum := sha256.Sum256(cert)
+		_, _ = hash.Write(sum[:])
+	}
+	copy(key[:], hash.Sum(nil))
+	return key
+}
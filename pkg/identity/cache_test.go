@@ -0,0 +1,67 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package identity_test
+
+import (
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/pkg/identity"
+)
+
+func TestVerificationCache(t *testing.T) {
+	calls := 0
+	fail := false
+	verify := func(_ [][]byte, _ [][]*x509.Certificate) error {
+		calls++
+		if fail {
+			return errors.New("invalid chain")
+		}
+		return nil
+	}
+
+	chainA := [][]byte{[]byte("leaf-a"), []byte("ca-a")}
+	chainB := [][]byte{[]byte("leaf-b"), []byte("ca-b")}
+	chainC := [][]byte{[]byte("leaf-c"), []byte("ca-c")}
+
+	cache := identity.NewVerificationCache(2)
+	cached := cache.Wrap(verify)
+
+	assert.NoError(t, cached(chainA, nil))
+	assert.NoError(t, cached(chainA, nil))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 1, cache.Len())
+
+	// failures are not remembered
+	fail = true
+	assert.Error(t, cached(chainB, nil))
+	assert.Error(t, cached(chainB, nil))
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 1, cache.Len())
+
+	// least recently used chain is evicted
+	fail = false
+	assert.NoError(t, cached(chainB, nil))
+	assert.NoError(t, cached(chainA, nil))
+	assert.NoError(t, cached(chainC, nil))
+	assert.Equal(t, 5, calls)
+	assert.Equal(t, 2, cache.Len())
+
+	assert.NoError(t, cached(chainA, nil))
+	assert.Equal(t, 5, calls)
+	assert.NoError(t, cached(chainB, nil))
+	assert.Equal(t, 6, calls)
+
+	// disabled cache always verifies
+	disabled := identity.NewVerificationCache(0)
+	assert.Nil(t, disabled)
+	assert.Equal(t, 0, disabled.Len())
+	uncached := disabled.Wrap(verify)
+	assert.NoError(t, uncached(chainA, nil))
+	assert.NoError(t, uncached(chainA, nil))
+	assert.Equal(t, 8, calls)
+}
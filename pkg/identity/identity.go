@@ -310,6 +310,13 @@ func (fi *FullIdentity) RestChainRaw() [][]byte {
 // ServerOption returns a grpc `ServerOption` for incoming connections
 // to the node with this full identity
 func (fi *FullIdentity) ServerOption(pcvFuncs ...peertls.PeerCertVerificationFunc) (grpc.ServerOption, error) {
+	return fi.CachedServerOption(nil, pcvFuncs...)
+}
+
+// CachedServerOption returns a grpc `ServerOption` for incoming connections
+// to the node with this full identity, where peer certificate chains which
+// were already verified are remembered in cache
+func (fi *FullIdentity) CachedServerOption(cache *VerificationCache, pcvFuncs ...peertls.PeerCertVerificationFunc) (grpc.ServerOption, error) {
 	ch := [][]byte{fi.Leaf.Raw, fi.CA.Raw}
 	ch = append(ch, fi.RestChainRaw()...)
 	c, err := peertls.TLSCert(ch, fi.Leaf, fi.Key)
@@ -318,7 +325,7 @@ func (fi *FullIdentity) ServerOption(pcvFuncs ...peertls.PeerCertVerificationFun
 	}
 
 	pcvFuncs = append(
-		[]peertls.PeerCertVerificationFunc{peertls.VerifyPeerCertChains},
+		[]peertls.PeerCertVerificationFunc{cache.Wrap(peertls.VerifyPeerCertChains)},
 		pcvFuncs...,
 	)
 	tlsConfig := &tls.Config{
@@ -337,6 +344,13 @@ func (fi *FullIdentity) ServerOption(pcvFuncs ...peertls.PeerCertVerificationFun
 // to the node with this peer identity
 // id is an optional id of the node we are dialing
 func (fi *FullIdentity) DialOption(id storj.NodeID) (grpc.DialOption, error) {
+	return fi.DialOptionWithSessions(id, nil)
+}
+
+// DialOptionWithSessions returns a grpc `DialOption` like DialOption, which
+// resumes the TLS sessions stored in sessions. Resumed sessions skip the
+// verification of the peer, so sessions must only hold sessions with id.
+func (fi *FullIdentity) DialOptionWithSessions(id storj.NodeID, sessions tls.ClientSessionCache) (grpc.DialOption, error) {
 	ch := [][]byte{fi.Leaf.Raw, fi.CA.Raw}
 	ch = append(ch, fi.RestChainRaw()...)
 	c, err := peertls.TLSCert(ch, fi.Leaf, fi.Key)
@@ -351,6 +365,7 @@ func (fi *FullIdentity) DialOption(id storj.NodeID) (grpc.DialOption, error) {
 			peertls.VerifyPeerCertChains,
 			verifyIdentity(id),
 		),
+		ClientSessionCache: sessions,
 	}
 
 	return grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)), nil
@@ -0,0 +1,123 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kademlia
+
+import (
+	"net"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+// addressDiscovery collects the hosts other nodes report observing the pings
+// of this node coming from, like STUN does, to find the public address of
+// nodes behind routers
+type addressDiscovery struct {
+	quorum int // the number of nodes which must report the same host
+
+	mu       sync.Mutex
+	observed map[storj.NodeID]string
+}
+
+func newAddressDiscovery(quorum int) *addressDiscovery {
+	return &addressDiscovery{quorum: quorum, observed: make(map[storj.NodeID]string)}
+}
+
+// observe records the address reporter observed and returns the host most
+// reporters agree on, once at least quorum of them do
+func (discovery *addressDiscovery) observe(reporter storj.NodeID, address string) (host string, ok bool) {
+	// the port is the one of the outgoing connection, not the one listened on
+	host, _, err := net.SplitHostPort(address)
+	if err != nil || host == "" {
+		return "", false
+	}
+
+	discovery.mu.Lock()
+	defer discovery.mu.Unlock()
+
+	discovery.observed[reporter] = host
+
+	votes := make(map[string]int)
+	best := 0
+	for _, observed := range discovery.observed {
+		votes[observed]++
+		if votes[observed] > best {
+			best, host = votes[observed], observed
+		}
+	}
+	return host, best >= discovery.quorum
+}
+
+// reconcileAddress returns the address to announce given the configured one
+// and the host other nodes observe. The configured address is only replaced
+// when it can't be reached from other networks, e.g. it's unspecified or
+// private, keeping its port. A public ip configured by the operator wins,
+// mismatch is set when the observed host differs from it.
+func reconcileAddress(configured, observedHost string) (address string, mismatch bool) {
+	host, port, err := net.SplitHostPort(configured)
+	if err != nil || host == observedHost {
+		return configured, false
+	}
+
+	ip := net.ParseIP(host)
+	switch {
+	case host == "" || (ip != nil && unroutable(ip)):
+		return net.JoinHostPort(observedHost, port), false
+	case ip == nil:
+		// hostnames may well resolve to the observed host
+		return configured, false
+	default:
+		return configured, true
+	}
+}
+
+// unroutable returns whether other networks can't reach ip
+func unroutable(ip net.IP) bool {
+	return ip.IsUnspecified() || ip.IsLoopback() || ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast()
+}
+
+// SetAddressDiscovery enables replacing an unreachable configured address by
+// the one at least quorum nodes observe, a quorum of 0 disables it.
+// Must be called before anything starting to use kademlia.
+func (k *Kademlia) SetAddressDiscovery(quorum int) {
+	if quorum <= 0 {
+		k.addresses = nil
+		return
+	}
+	k.addresses = newAddressDiscovery(quorum)
+}
+
+// observeAddress records the address reporter observed this node at and
+// updates the address of self when enough nodes agree it's wrong
+func (k *Kademlia) observeAddress(reporter storj.NodeID, observed string) {
+	if k.addresses == nil || reporter.IsZero() || observed == "" {
+		return
+	}
+	host, ok := k.addresses.observe(reporter, observed)
+	if !ok {
+		return
+	}
+
+	self := k.routingTable.Local()
+	configured := self.GetAddress().GetAddress()
+	address, mismatch := reconcileAddress(configured, host)
+	if mismatch {
+		k.log.Debug("configured address differs from the observed one",
+			zap.String("configured", configured), zap.String("observed", host))
+	}
+	if address == configured {
+		return
+	}
+
+	self.Address = &pb.NodeAddress{Transport: self.GetAddress().GetTransport(), Address: address}
+	if err := k.routingTable.UpdateSelf(&self); err != nil {
+		k.log.Warn("updating the observed address failed", zap.Error(err))
+		return
+	}
+	k.log.Info("announcing the observed address", zap.String("configured", configured), zap.String("address", address))
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kademlia
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/pb"
+)
+
+func TestAddressDiscoveryQuorum(t *testing.T) {
+	discovery := newAddressDiscovery(2)
+	a, b, c := teststorj.NodeIDFromString("a"), teststorj.NodeIDFromString("b"), teststorj.NodeIDFromString("c")
+
+	_, ok := discovery.observe(a, "203.0.113.7:51234")
+	assert.False(t, ok)
+
+	// the same reporter only counts once
+	_, ok = discovery.observe(a, "203.0.113.7:51235")
+	assert.False(t, ok)
+
+	_, ok = discovery.observe(b, "invalid")
+	assert.False(t, ok)
+
+	host, ok := discovery.observe(b, "203.0.113.7:40000")
+	assert.True(t, ok)
+	assert.Equal(t, "203.0.113.7", host)
+
+	// the majority wins
+	host, ok = discovery.observe(c, "198.51.100.1:40000")
+	assert.True(t, ok)
+	assert.Equal(t, "203.0.113.7", host)
+}
+
+func TestReconcileAddress(t *testing.T) {
+	for _, tt := range []struct {
+		configured string
+		observed   string
+		address    string
+		mismatch   bool
+	}{
+		{"203.0.113.7:7777", "203.0.113.7", "203.0.113.7:7777", false},
+		{":7777", "203.0.113.7", "203.0.113.7:7777", false},
+		{"0.0.0.0:7777", "203.0.113.7", "203.0.113.7:7777", false},
+		{"[::]:7777", "2001:db8::1", "[2001:db8::1]:7777", false},
+		{"192.168.1.10:7777", "203.0.113.7", "203.0.113.7:7777", false},
+		{"127.0.0.1:7777", "203.0.113.7", "203.0.113.7:7777", false},
+		{"198.51.100.1:7777", "203.0.113.7", "198.51.100.1:7777", true},
+		{"node.example.com:7777", "203.0.113.7", "node.example.com:7777", false},
+		{"invalid", "203.0.113.7", "invalid", false},
+	} {
+		address, mismatch := reconcileAddress(tt.configured, tt.observed)
+		assert.Equal(t, tt.address, address, tt.configured)
+		assert.Equal(t, tt.mismatch, mismatch, tt.configured)
+	}
+}
+
+func TestBootstrapDiscoversAddress(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	bn, s, clean := testNode(t, []pb.Node{})
+	defer clean()
+	defer s.Stop()
+
+	n1, s1, clean1 := testNode(t, []pb.Node{bn.routingTable.self})
+	defer clean1()
+	defer s1.Stop()
+
+	// pretend the node was configured with an unspecified address
+	self := n1.routingTable.Local()
+	_, port, err := net.SplitHostPort(self.Address.Address)
+	require.NoError(t, err)
+	self.Address = &pb.NodeAddress{Address: net.JoinHostPort("0.0.0.0", port)}
+	require.NoError(t, n1.routingTable.UpdateSelf(&self))
+
+	n1.SetAddressDiscovery(1)
+	require.NoError(t, n1.Bootstrap(ctx))
+
+	assert.Equal(t, net.JoinHostPort("127.0.0.1", port), n1.routingTable.Local().Address.Address)
+}
+
+func TestPingReportsObservedAddress(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	bn, s, clean := testNode(t, []pb.Node{})
+	defer clean()
+	defer s.Stop()
+
+	n1, s1, clean1 := testNode(t, []pb.Node{})
+	defer clean1()
+	defer s1.Stop()
+
+	_, observed, err := n1.dialer.PingObserved(ctx, bn.routingTable.Local())
+	require.NoError(t, err)
+	host, _, err := net.SplitHostPort(observed)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", host)
+}
@@ -5,7 +5,8 @@ package kademlia
 
 import (
 	"context"
-	"flag"
+	"strings"
+	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
@@ -23,11 +24,6 @@ var (
 	mon   = monkit.Package()
 )
 
-var (
-	flagBucketSize           = flag.Int("kademlia.bucket-size", 20, "size of each Kademlia bucket")
-	flagReplacementCacheSize = flag.Int("kademlia.replacement-cache-size", 5, "size of Kademlia replacement cache")
-)
-
 //CtxKey Used as kademlia key
 type CtxKey int
 
@@ -39,16 +35,47 @@ const (
 type OperatorConfig struct {
 	Email  string `user:"true" help:"operator email address" default:""`
 	Wallet string `user:"true" help:"operator wallet adress" default:""`
+
+	WalletFeatures string `user:"true" help:"comma separated list of payout features the operator wallet opts into, e.g. zksync" default:""`
+}
+
+// Features returns the wallet features the operator opts into
+func (c OperatorConfig) Features() []string {
+	var features []string
+	for _, feature := range strings.Split(c.WalletFeatures, ",") {
+		if feature = strings.TrimSpace(feature); feature != "" {
+			features = append(features, feature)
+		}
+	}
+	return features
+}
+
+// Metadata returns the node metadata sent to other nodes
+func (c OperatorConfig) Metadata() *pb.NodeMetadata {
+	return &pb.NodeMetadata{
+		Email:          c.Email,
+		Wallet:         c.Wallet,
+		WalletFeatures: c.Features(),
+	}
 }
 
 // Config defines all of the things that are needed to start up Kademlia
 // server endpoints (and not necessarily client code).
 type Config struct {
-	BootstrapAddr   string `help:"the Kademlia node to bootstrap against" default:"127.0.0.1:7778"`
-	DBPath          string `help:"the path for storage node db services to be created on" default:"$CONFDIR/kademlia"`
-	Alpha           int    `help:"alpha is a system wide concurrency parameter" default:"5"`
-	ExternalAddress string `user:"true" help:"the public address of the Kademlia node, useful for nodes behind NAT" default:""`
-	Operator        OperatorConfig
+	BootstrapAddr    string        `help:"comma separated addresses of the Kademlia nodes to bootstrap against" default:"127.0.0.1:7778"`
+	BootstrapBackoff time.Duration `help:"the maximum delay between attempts to bootstrap" default:"30s"`
+	DBPath           string        `help:"the path for storage node db services to be created on" default:"$CONFDIR/kademlia"`
+	Alpha            int           `help:"alpha is a system wide concurrency parameter" default:"5"`
+	ExternalAddress  string        `user:"true" help:"the public address of the Kademlia node, useful for nodes behind NAT" default:""`
+	Tags             string        `user:"true" help:"comma separated name=value attributes the node publishes about itself, e.g. ssd=true,region=eu" default:""`
+	LookupCacheSize  int           `help:"the number of recent lookup results to cache, 0 disables caching" default:"1000"`
+	LookupCacheTTL   time.Duration `help:"how long lookup results are cached, unless the routing table changed" default:"1m"`
+	AddressQuorum    int           `help:"the number of nodes which must observe the same address of this node before it's announced instead of an unspecified or private configured address, 0 disables address discovery" default:"2"`
+	MinDifficulty    uint          `help:"the minimum number of trailing zero bits in the IDs of nodes added to the routing table, 0 accepts all nodes" default:"12"`
+	BucketSize       int           `help:"the maximum number of nodes in a bucket of the routing table (k)" default:"20"`
+	ReplacementCache int           `help:"the number of recently seen nodes kept per bucket to replace nodes which stop answering" default:"5"`
+	Operator         OperatorConfig
+	Refresh          RefreshConfig
 }
 
 // StorageNodeConfig is a Config that implements provider.Responsibility as
@@ -85,16 +112,13 @@ func (c Config) Run(ctx context.Context, server *provider.Provider,
 	defer mon.Task()(&ctx)(&err)
 
 	// TODO(coyle): I'm thinking we just remove this function and grab from the config.
-	zap.S().Debugf("kademlia bootstrap node: %q", c.BootstrapAddr)
-	in, err := GetIntroNode(c.BootstrapAddr)
+	zap.S().Debugf("kademlia bootstrap nodes: %q", c.BootstrapAddr)
+	in, err := GetIntroNodes(c.BootstrapAddr)
 	if err != nil {
 		return err
 	}
 
-	metadata := &pb.NodeMetadata{
-		Email:  c.Operator.Email,
-		Wallet: c.Operator.Wallet,
-	}
+	metadata := c.Operator.Metadata()
 
 	addr := server.Addr().String()
 	if c.ExternalAddress != "" {
@@ -102,12 +126,29 @@ func (c Config) Run(ctx context.Context, server *provider.Provider,
 	}
 
 	logger := zap.L()
-	kad, err := NewKademlia(logger, nodeType, []pb.Node{*in}, addr, metadata, server.Identity(), c.DBPath, c.Alpha)
+	kad, err := NewKademlia(logger, nodeType, in, addr, metadata, server.Identity(), c.DBPath, c.Alpha)
 	if err != nil {
 		return err
 	}
-	kad.StartRefresh(ctx)
 	defer func() { err = utils.CombineErrors(err, kad.Disconnect()) }()
+	kad.SetBootstrapBackoff(c.BootstrapBackoff)
+	kad.SetLookupCache(c.LookupCacheSize, c.LookupCacheTTL)
+	kad.SetAddressDiscovery(c.AddressQuorum)
+	kad.routingTable.SetMinimumDifficulty(uint16(c.MinDifficulty))
+	kad.routingTable.SetBucketSize(c.BucketSize, c.ReplacementCache)
+
+	go func() {
+		err := NewRefresher(logger.Named("refresh"), kad, c.Refresh).Run(ctx)
+		if err != nil && err != context.Canceled {
+			logger.Error("refresh returned", zap.Error(err))
+		}
+	}()
+
+	go func() {
+		if err := kad.VerifyRestored(ctx); err != nil && err != context.Canceled {
+			logger.Warn("Failed to verify restored Kademlia nodes", zap.Error(err))
+		}
+	}()
 
 	go func() {
 		if err = kad.Bootstrap(ctx); err != nil {
@@ -4,35 +4,65 @@
 package kademlia
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
-	"google.golang.org/grpc"
 
 	"storj.io/storj/internal/sync2"
+	"storj.io/storj/pkg/node"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/transport"
 )
 
+const (
+	// pingNonceSize is the size of the nonce a ping response must echo
+	pingNonceSize = 16
+	// connectionIdleTimeout is how long connections to other nodes are kept
+	// open for reuse after they were last used
+	connectionIdleTimeout = 5 * time.Minute
+)
+
+// network is how kademlia queries and pings other nodes. It's implemented
+// over grpc by Dialer and can be simulated in memory by tests.
+type network interface {
+	// Lookup queries ask about find, and also sends information about self.
+	Lookup(ctx context.Context, self pb.Node, ask pb.Node, find pb.Node) ([]*pb.Node, error)
+	// PingObserved pings target and returns the round trip time and the
+	// address target observed the ping coming from.
+	PingObserved(ctx context.Context, target pb.Node) (rtt time.Duration, observed string, err error)
+	// Store asks target to store record.
+	Store(ctx context.Context, target pb.Node, record *pb.Record) error
+	// FindValue asks target for the record stored under key, which is nil
+	// when target doesn't store key.
+	FindValue(ctx context.Context, target pb.Node, key storj.NodeID) (*pb.Record, error)
+	// Close prevents new connections to be made.
+	Close() error
+}
+
 // Dialer is a kademlia dialer
 type Dialer struct {
-	log       *zap.Logger
-	transport transport.Client
-	limit     sync2.Semaphore
+	log   *zap.Logger
+	pool  *transport.Pool
+	limit sync2.Semaphore
 }
 
 // Conn represents a kademlia connection
 type Conn struct {
-	conn   *grpc.ClientConn
+	conn   *transport.PooledConn
 	client pb.NodesClient
 }
 
-// NewDialer creates a dialer for kademlia.
-func NewDialer(log *zap.Logger, transport transport.Client) *Dialer {
+// NewDialer creates a dialer for kademlia, which reuses the connections to
+// nodes and tells the observers about every connection it makes.
+func NewDialer(log *zap.Logger, client transport.Client, obs ...transport.Observer) *Dialer {
 	dialer := &Dialer{
-		log:       log,
-		transport: transport,
+		log:  log,
+		pool: transport.NewPool(client, connectionIdleTimeout, obs...),
 	}
 	dialer.limit.Init(32) // TODO: limit should not be hardcoded
 	return dialer
@@ -41,7 +71,7 @@ func NewDialer(log *zap.Logger, transport transport.Client) *Dialer {
 // Close closes the pool resources and prevents new connections to be made.
 func (dialer *Dialer) Close() error {
 	dialer.limit.Close()
-	return nil
+	return dialer.pool.Close()
 }
 
 // Lookup queries ask about find, and also sends information about self.
@@ -66,36 +96,111 @@ func (dialer *Dialer) Lookup(ctx context.Context, self pb.Node, ask pb.Node, fin
 		return nil, errs.Combine(err, conn.disconnect())
 	}
 
-	return resp.Response, conn.disconnect()
+	// nodes which didn't sign their record could be announced by anyone
+	return node.Verified(resp.Response), conn.disconnect()
+}
+
+// Ping pings target and returns the round trip time of the ping, not
+// including dialing.
+func (dialer *Dialer) Ping(ctx context.Context, target pb.Node) (rtt time.Duration, err error) {
+	rtt, _, err = dialer.PingObserved(ctx, target)
+	return rtt, err
+}
+
+// PingObserved pings target like Ping and also returns the address target
+// observed the ping coming from, which is empty when target didn't report it.
+func (dialer *Dialer) PingObserved(ctx context.Context, target pb.Node) (rtt time.Duration, observed string, err error) {
+	if !dialer.limit.Lock() {
+		return 0, "", context.Canceled
+	}
+	defer dialer.limit.Unlock()
+
+	conn, err := dialer.dial(ctx, target)
+	if err != nil {
+		return 0, "", err
+	}
+
+	nonce := make([]byte, pingNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return 0, "", errs.Combine(err, conn.disconnect())
+	}
+
+	start := time.Now()
+	resp, err := conn.client.Ping(ctx, &pb.PingRequest{Nonce: nonce})
+	rtt = time.Since(start)
+	if err != nil {
+		return 0, "", errs.Combine(err, conn.disconnect())
+	}
+
+	// a node answering with a different nonce isn't the one which received
+	// the ping, e.g. a proxy answers on its behalf
+	if !bytes.Equal(resp.GetNonce(), nonce) {
+		return 0, "", errs.Combine(NodeErr.New("ping response of %s doesn't echo the nonce", target.Id), conn.disconnect())
+	}
+	return rtt, resp.GetObservedAddress(), conn.disconnect()
+}
+
+// Store asks target to store record.
+func (dialer *Dialer) Store(ctx context.Context, target pb.Node, record *pb.Record) error {
+	if !dialer.limit.Lock() {
+		return context.Canceled
+	}
+	defer dialer.limit.Unlock()
+
+	conn, err := dialer.dial(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.client.Store(ctx, &pb.StoreRequest{Record: record})
+	return errs.Combine(err, conn.disconnect())
 }
 
-// Ping pings target.
-func (dialer *Dialer) Ping(ctx context.Context, target pb.Node) (bool, error) {
+// FindValue asks target for the record stored under key, which is nil when
+// target doesn't store key.
+func (dialer *Dialer) FindValue(ctx context.Context, target pb.Node, key storj.NodeID) (*pb.Record, error) {
 	if !dialer.limit.Lock() {
-		return false, context.Canceled
+		return nil, context.Canceled
 	}
 	defer dialer.limit.Unlock()
 
 	conn, err := dialer.dial(ctx, target)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	_, err = conn.client.Ping(ctx, &pb.PingRequest{})
+	resp, err := conn.client.FindValue(ctx, &pb.FindValueRequest{Key: key})
+	if err != nil {
+		return nil, errs.Combine(err, conn.disconnect())
+	}
 
-	return err == nil, errs.Combine(err, conn.disconnect())
+	record := resp.GetRecord()
+	if record == nil {
+		return nil, conn.disconnect()
+	}
+	// anyone could answer with a record of another key or a forged one
+	if record.Key != key {
+		return nil, errs.Combine(RecordErr.New("%s answered with %s for %s", target.Id, record.Key, key), conn.disconnect())
+	}
+	if err := checkRecord(record, time.Now()); err != nil {
+		return nil, errs.Combine(err, conn.disconnect())
+	}
+	return record, conn.disconnect()
 }
 
 // dial dials the specified node.
 func (dialer *Dialer) dial(ctx context.Context, target pb.Node) (*Conn, error) {
-	grpcconn, err := dialer.transport.DialNode(ctx, &target)
+	conn, err := dialer.pool.Dial(ctx, &target)
+	if err != nil {
+		return nil, err
+	}
 	return &Conn{
-		conn:   grpcconn,
-		client: pb.NewNodesClient(grpcconn),
-	}, err
+		conn:   conn,
+		client: pb.NewNodesClient(conn.ClientConn),
+	}, nil
 }
 
-// disconnect disconnects this connection.
+// disconnect returns this connection to the pool.
 func (conn *Conn) disconnect() error {
 	return conn.conn.Close()
 }
@@ -0,0 +1,136 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kademlia
+
+import (
+	"time"
+
+	"storj.io/storj/pkg/storj"
+)
+
+// healthySeenAge is how long ago a node may have last answered to count as
+// fresh in the health score of its bucket
+const healthySeenAge = time.Hour
+
+// BucketStats are the statistics of a k bucket of the routing table
+type BucketStats struct {
+	ID           storj.NodeID // the end of the range of the bucket
+	LastUpdated  time.Time    // last lookup in the range of the bucket
+	Nodes        int          // nodes in the bucket other than self
+	Replacements int          // nodes waiting in the replacement cache
+	Fill         float64      // nodes relative to k
+	// Fresh are the nodes which answered within the healthy seen age, Unseen
+	// the nodes which didn't answer since they were added or last failed
+	Fresh  int
+	Unseen int
+	// AverageLastSeenAge is the average time since the nodes which were seen
+	// last answered
+	AverageLastSeenAge time.Duration
+	// Churn is the number of nodes added to or removed from the bucket
+	Churn uint64
+}
+
+// Score returns the health of the bucket between 0 and 1, a full bucket of
+// nodes which recently answered scores 1
+func (stats *BucketStats) Score() float64 {
+	if stats.Nodes == 0 {
+		return 0
+	}
+	return (stats.Fill + float64(stats.Fresh)/float64(stats.Nodes)) / 2
+}
+
+// RoutingHealth is the health of the view of the network of a routing table
+type RoutingHealth struct {
+	// Score is the average score of the buckets, between 0 and 1
+	Score   float64
+	Buckets []BucketStats
+}
+
+// bucketChurnOf returns the number of nodes added to or removed from a bucket
+func (rt *RoutingTable) bucketChurnOf(bID bucketID) uint64 {
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+	return rt.bucketChurn[bID]
+}
+
+// Health returns the statistics of the buckets of the routing table by now
+// and the health score computed from them
+func (rt *RoutingTable) Health(now time.Time) (*RoutingHealth, error) {
+	ids, err := rt.GetBucketIds()
+	if err != nil {
+		return nil, RoutingErr.Wrap(err)
+	}
+
+	health := &RoutingHealth{}
+	for _, id := range ids {
+		bID := keyToBucketID(id)
+
+		updated, err := rt.GetBucketTimestamp(id)
+		if err != nil {
+			return nil, RoutingErr.Wrap(err)
+		}
+		nodes, err := rt.getUnmarshaledNodesFromBucket(bID)
+		if err != nil {
+			return nil, err
+		}
+
+		stats := BucketStats{
+			ID:           storj.NodeID(bID),
+			LastUpdated:  updated,
+			Replacements: len(rt.replacements(bID)),
+			Churn:        rt.bucketChurnOf(bID),
+		}
+
+		var seenAge time.Duration
+		for _, node := range nodes {
+			if node.Id == rt.selfID {
+				continue
+			}
+			stats.Nodes++
+
+			seen, ok := rt.lastSeenAt(node.Id)
+			if !ok {
+				stats.Unseen++
+				continue
+			}
+			age := now.Sub(seen)
+			seenAge += age
+			if age <= healthySeenAge {
+				stats.Fresh++
+			}
+		}
+		if seen := stats.Nodes - stats.Unseen; seen > 0 {
+			stats.AverageLastSeenAge = seenAge / time.Duration(seen)
+		}
+		stats.Fill = float64(stats.Nodes) / float64(rt.K())
+		if stats.Fill > 1 {
+			stats.Fill = 1
+		}
+
+		health.Score += stats.Score()
+		health.Buckets = append(health.Buckets, stats)
+	}
+	if len(health.Buckets) > 0 {
+		health.Score /= float64(len(health.Buckets))
+	}
+	return health, nil
+}
+
+// reportHealth reports the health of the routing table by now to monkit
+func (rt *RoutingTable) reportHealth(now time.Time) error {
+	health, err := rt.Health(now)
+	if err != nil {
+		return err
+	}
+
+	mon.FloatVal("routing_health").Observe(health.Score)
+	mon.IntVal("routing_buckets").Observe(int64(len(health.Buckets)))
+	for _, bucket := range health.Buckets {
+		mon.FloatVal("routing_bucket_fill").Observe(bucket.Fill)
+		mon.IntVal("routing_bucket_unseen").Observe(int64(bucket.Unseen))
+		mon.IntVal("routing_bucket_last_seen_age").Observe(int64(bucket.AverageLastSeenAge / time.Second))
+		mon.IntVal("routing_bucket_churn").Observe(int64(bucket.Churn))
+	}
+	return nil
+}
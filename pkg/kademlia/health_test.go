@@ -0,0 +1,61 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kademlia
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/pb"
+)
+
+func TestRoutingHealth(t *testing.T) {
+	rt, cleanup := createRoutingTable(t, teststorj.NodeIDFromString("AA"))
+	defer cleanup()
+
+	seen := []*pb.Node{
+		{Id: teststorj.NodeIDFromString("BB"), Type: pb.NodeType_STORAGE},
+		{Id: teststorj.NodeIDFromString("CC"), Type: pb.NodeType_STORAGE},
+	}
+	for _, node := range seen {
+		require.NoError(t, rt.ConnectionSuccess(node))
+	}
+	ok, err := rt.addNode(&pb.Node{Id: teststorj.NodeIDFromString("DD"), Type: pb.NodeType_STORAGE})
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	now := time.Now()
+	health, err := rt.Health(now)
+	require.NoError(t, err)
+	require.Len(t, health.Buckets, 1)
+
+	bucket := health.Buckets[0]
+	assert.Equal(t, 3, bucket.Nodes)
+	assert.Equal(t, 0.5, bucket.Fill)
+	assert.Equal(t, 2, bucket.Fresh)
+	assert.Equal(t, 1, bucket.Unseen)
+	assert.Equal(t, uint64(3), bucket.Churn)
+	assert.True(t, bucket.AverageLastSeenAge < time.Minute)
+	assert.InDelta(t, (0.5+2.0/3.0)/2, health.Score, 1e-9)
+
+	{ // nodes which didn't answer for long aren't fresh
+		health, err := rt.Health(now.Add(2 * healthySeenAge))
+		require.NoError(t, err)
+		assert.Equal(t, 0, health.Buckets[0].Fresh)
+		assert.True(t, health.Buckets[0].AverageLastSeenAge > healthySeenAge)
+		assert.InDelta(t, 0.25, health.Score, 1e-9)
+	}
+
+	{ // removing a node churns its bucket
+		require.NoError(t, rt.ConnectionFailed(seen[0]))
+		health, err := rt.Health(now)
+		require.NoError(t, err)
+		assert.Equal(t, 2, health.Buckets[0].Nodes)
+		assert.Equal(t, uint64(4), health.Buckets[0].Churn)
+	}
+}
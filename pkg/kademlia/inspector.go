@@ -5,6 +5,9 @@ package kademlia
 
 import (
 	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
 
 	"storj.io/storj/pkg/dht"
 	"storj.io/storj/pkg/identity"
@@ -123,5 +126,107 @@ func (srv *Inspector) LookupNode(ctx context.Context, req *pb.LookupNodeRequest)
 
 	return &pb.LookupNodeResponse{
 		Node: &node,
+		Meta: node.Metadata,
 	}, nil
 }
+
+// DumpRoutingTable returns the k buckets with their nodes and replacement
+// caches, along with when the nodes last answered and their ping round trips.
+func (srv *Inspector) DumpRoutingTable(ctx context.Context, req *pb.DumpRoutingTableRequest) (*pb.DumpRoutingTableResponse, error) {
+	dhtrt, err := srv.dht.GetRoutingTable(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	rt, ok := dhtrt.(*RoutingTable)
+	if !ok {
+		return nil, Error.New("unable to dump routing table of type %T", dhtrt)
+	}
+
+	ids, err := rt.GetBucketIds()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	self := rt.Local()
+	resp := &pb.DumpRoutingTableResponse{Self: &self}
+	for _, id := range ids {
+		bID := keyToBucketID(id)
+
+		updated, err := rt.GetBucketTimestamp(id)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		lastUpdated, err := ptypes.TimestampProto(updated)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		nodes, err := rt.getUnmarshaledNodesFromBucket(bID)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		bucket := &pb.KBucket{Id: storj.NodeID(bID), LastUpdated: lastUpdated}
+		for _, node := range nodes {
+			bucket.Nodes = append(bucket.Nodes, routingTableNode(rt, node))
+		}
+		for _, node := range rt.replacements(bID) {
+			bucket.Replacements = append(bucket.Replacements, routingTableNode(rt, node))
+		}
+		resp.Buckets = append(resp.Buckets, bucket)
+	}
+	return resp, nil
+}
+
+// routingTableNode describes a node of the routing table
+func routingTableNode(rt *RoutingTable, node *pb.Node) *pb.RoutingTableNode {
+	described := &pb.RoutingTableNode{Node: node}
+	if seen, ok := rt.lastSeenAt(node.Id); ok {
+		described.LastSeen, _ = ptypes.TimestampProto(seen)
+	}
+	if latency, ok := rt.Latency(node.Id); ok {
+		described.LastRttNs = latency.Last.Nanoseconds()
+		described.AverageRttNs = latency.Average.Nanoseconds()
+		described.Pings = latency.Samples
+	}
+	return described
+}
+
+// RoutingHealth returns the statistics of the k buckets of the routing table
+// and its health score
+func (srv *Inspector) RoutingHealth(ctx context.Context, req *pb.RoutingHealthRequest) (*pb.RoutingHealthResponse, error) {
+	dhtrt, err := srv.dht.GetRoutingTable(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	rt, ok := dhtrt.(*RoutingTable)
+	if !ok {
+		return nil, Error.New("unable to score routing table of type %T", dhtrt)
+	}
+
+	health, err := rt.Health(time.Now())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	resp := &pb.RoutingHealthResponse{Score: health.Score}
+	for _, bucket := range health.Buckets {
+		lastUpdated, err := ptypes.TimestampProto(bucket.LastUpdated)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		resp.Buckets = append(resp.Buckets, &pb.KBucketStats{
+			Id:                   bucket.ID,
+			LastUpdated:          lastUpdated,
+			Nodes:                int64(bucket.Nodes),
+			Replacements:         int64(bucket.Replacements),
+			Fill:                 bucket.Fill,
+			Fresh:                int64(bucket.Fresh),
+			Unseen:               int64(bucket.Unseen),
+			AverageLastSeenAgeNs: bucket.AverageLastSeenAge.Nanoseconds(),
+			Churn:                int64(bucket.Churn),
+			Score:                bucket.Score(),
+		})
+	}
+	return resp, nil
+}
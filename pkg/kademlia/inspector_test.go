@@ -0,0 +1,78 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kademlia
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/pb"
+)
+
+func TestDumpRoutingTable(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	bn, s, clean := testNode(t, []pb.Node{})
+	defer clean()
+	defer s.Stop()
+
+	n1, s1, clean1 := testNode(t, []pb.Node{bn.routingTable.self})
+	defer clean1()
+	defer s1.Stop()
+
+	require.NoError(t, n1.Bootstrap(ctx))
+	_, err := n1.Ping(ctx, bn.routingTable.self)
+	require.NoError(t, err)
+
+	dump, err := NewInspector(n1, nil).DumpRoutingTable(ctx, &pb.DumpRoutingTableRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, n1.routingTable.self.Id, dump.Self.Id)
+	require.NotEmpty(t, dump.Buckets)
+
+	var found *pb.RoutingTableNode
+	for _, bucket := range dump.Buckets {
+		assert.NotNil(t, bucket.LastUpdated)
+		for _, node := range bucket.Nodes {
+			if node.Node.Id == bn.routingTable.self.Id {
+				found = node
+			}
+		}
+	}
+	require.NotNil(t, found, "bootstrap node missing in the dump")
+	assert.Equal(t, bn.routingTable.self.Address.Address, found.Node.Address.Address)
+	assert.NotNil(t, found.LastSeen)
+	assert.True(t, found.Pings >= 1)
+	assert.True(t, found.AverageRttNs > 0)
+}
+
+func TestInspectRoutingHealth(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	bn, s, clean := testNode(t, []pb.Node{})
+	defer clean()
+	defer s.Stop()
+
+	n1, s1, clean1 := testNode(t, []pb.Node{bn.routingTable.self})
+	defer clean1()
+	defer s1.Stop()
+
+	require.NoError(t, n1.Bootstrap(ctx))
+
+	health, err := NewInspector(n1, nil).RoutingHealth(ctx, &pb.RoutingHealthRequest{})
+	require.NoError(t, err)
+	require.NotEmpty(t, health.Buckets)
+	assert.True(t, health.Score > 0)
+
+	var nodes int64
+	for _, bucket := range health.Buckets {
+		assert.NotNil(t, bucket.LastUpdated)
+		nodes += bucket.Nodes
+	}
+	assert.Equal(t, int64(1), nodes)
+}
@@ -9,6 +9,8 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 	"unsafe"
@@ -16,7 +18,9 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 
+	"storj.io/storj/internal/sync2"
 	"storj.io/storj/pkg/dht"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/provider"
@@ -39,9 +43,11 @@ var (
 )
 
 type discoveryOptions struct {
-	concurrency    int
+	concurrency    int // alpha, the number of concurrent lookups
+	k              int // the number of closest nodes a lookup converges on
 	retries        int
 	bootstrap      bool
+	converge       bool // finding the target doesn't end the lookup
 	bootstrapNodes []pb.Node
 }
 
@@ -51,9 +57,19 @@ type Kademlia struct {
 	alpha           int // alpha is a system wide concurrency parameter
 	routingTable    *RoutingTable
 	bootstrapNodes  []pb.Node
-	dialer          *Dialer
+	dialer          network
+	observers       *observers
+	pingObservers   []func(id storj.NodeID, rtt time.Duration)
 	identity        *provider.FullIdentity
 	bootstrapCancel unsafe.Pointer // context.CancelFunc
+
+	bootstrapBackoff time.Duration // maximum delay between bootstrap attempts
+	bootstrapOnce    sync.Once
+	bootstrapped     chan struct{}
+
+	lookups   *lookupCache
+	addresses *addressDiscovery // nil when address discovery is disabled
+	records   *recordStore
 }
 
 // New returns a newly configured Kademlia instance
@@ -95,18 +111,36 @@ var NewWith = NewKademliaWithRoutingTable
 
 // NewKademliaWithRoutingTable returns a newly configured Kademlia instance
 func NewKademliaWithRoutingTable(log *zap.Logger, self pb.Node, bootstrapNodes []pb.Node, identity *provider.FullIdentity, alpha int, rt *RoutingTable) (*Kademlia, error) {
+	if err := rt.SignSelf(identity); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
 	k := &Kademlia{
 		log:            log,
 		alpha:          alpha,
 		routingTable:   rt,
 		bootstrapNodes: bootstrapNodes,
 		identity:       identity,
-
-		dialer: NewDialer(log.Named("dialer"), transport.NewClient(identity, rt)),
+		observers:      &observers{},
+		bootstrapped:   make(chan struct{}),
+		records:        newRecordStore(),
 	}
+	k.dialer = NewDialer(log.Named("dialer"), transport.NewClient(identity), rt, k.observers)
 	return k, nil
 }
 
+// AddObserver adds an observer which is told about the result of every
+// connection kademlia makes to a node
+func (k *Kademlia) AddObserver(observer transport.Observer) {
+	k.observers.add(observer)
+}
+
+// OnPing adds a function which is told about the round trip of every ping
+// answered by a node. Must be called before kademlia is used.
+func (k *Kademlia) OnPing(observe func(id storj.NodeID, rtt time.Duration)) {
+	k.pingObservers = append(k.pingObservers, observe)
+}
+
 // Close closes all kademlia connections and prevents new ones from being created.
 func (k *Kademlia) Close() error {
 	// Cancel the bootstrap context
@@ -182,42 +216,193 @@ func (k *Kademlia) GetRoutingTable(ctx context.Context) (dht.RoutingTable, error
 // Must be called before anything starting to use kademlia.
 func (k *Kademlia) SetBootstrapNodes(nodes []pb.Node) { k.bootstrapNodes = nodes }
 
-// Bootstrap contacts one of a set of pre defined trusted nodes on the network and
-// begins populating the local Kademlia node
-func (k *Kademlia) Bootstrap(ctx context.Context) error {
+// SetBootstrapBackoff sets the maximum delay between bootstrap attempts.
+// Must be called before anything starting to use kademlia.
+func (k *Kademlia) SetBootstrapBackoff(max time.Duration) { k.bootstrapBackoff = max }
+
+// SetLookupCache caches the results of at most size lookups for ttl, a size
+// or ttl of 0 disables caching.
+// Must be called before anything starting to use kademlia.
+func (k *Kademlia) SetLookupCache(size int, ttl time.Duration) { k.lookups = newLookupCache(size, ttl) }
+
+// Bootstrap contacts the pre defined trusted nodes on the network in
+// parallel and begins populating the local Kademlia node. It retries with
+// exponential backoff until one of them answered.
+func (k *Kademlia) Bootstrap(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
 	if len(k.bootstrapNodes) == 0 {
 		return BootstrapErr.New("no bootstrap nodes provided")
 	}
 	bootstrapContext, bootstrapCancel := context.WithCancel(ctx)
 	atomic.StorePointer(&k.bootstrapCancel, unsafe.Pointer(&bootstrapCancel))
-	//find nodes most similar to self
 
-	k.routingTable.mutex.Lock()
-	id := k.routingTable.self.Id
-	k.routingTable.mutex.Unlock()
-	_, err := k.lookup(bootstrapContext, id, true)
+	delay := time.Second
+	for {
+		err := k.bootstrap(bootstrapContext)
+		if err == nil {
+			k.bootstrapOnce.Do(func() { close(k.bootstrapped) })
+			return nil
+		}
+		if bootstrapContext.Err() != nil {
+			return bootstrapContext.Err()
+		}
+
+		k.log.Warn("bootstrap failed, retrying", zap.Duration("delay", delay), zap.Error(err))
+		if !sync2.Sleep(bootstrapContext, delay) {
+			return bootstrapContext.Err()
+		}
+		delay *= 2
+		if max := k.bootstrapBackoff; max > 0 && delay > max {
+			delay = max
+		}
+	}
+}
+
+// bootstrap pings the bootstrap nodes in parallel and looks up the nodes
+// near to self
+func (k *Kademlia) bootstrap(ctx context.Context) error {
+	self := k.routingTable.Local()
+
+	var group errgroup.Group
+	answered := make([]bool, len(k.bootstrapNodes))
+	others := 0
+	for i, node := range k.bootstrapNodes {
+		// the first node of a network bootstraps against itself
+		if node.Id == self.Id {
+			continue
+		}
+		others++
+
+		i, node := i, node
+		group.Go(func() error {
+			_, observed, err := k.dialer.PingObserved(ctx, node)
+			if err != nil {
+				k.log.Debug("bootstrap node unreachable", zap.String("address", node.GetAddress().GetAddress()), zap.Error(err))
+			}
+			answered[i] = err == nil
+			if err == nil {
+				k.observeAddress(node.Id, observed)
+			}
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	reached := 0
+	for _, ok := range answered {
+		if ok {
+			reached++
+		}
+	}
+	if others > 0 && reached == 0 {
+		return BootstrapErr.New("none of %d bootstrap nodes answered", others)
+	}
+
+	// find nodes most similar to self, which announces self with the
+	// address the bootstrap nodes observed
+	_, err := k.lookup(ctx, self.Id, true)
 	return err
 }
 
+// WaitForBootstrap waits until bootstrapping succeeded or the context is
+// canceled
+func (k *Kademlia) WaitForBootstrap(ctx context.Context) error {
+	select {
+	case <-k.bootstrapped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Ping checks that the provided node is still accessible on the network
 func (k *Kademlia) Ping(ctx context.Context, node pb.Node) (pb.Node, error) {
-	ok, err := k.dialer.Ping(ctx, node)
+	rtt, observed, err := k.dialer.PingObserved(ctx, node)
 	if err != nil {
 		return pb.Node{}, NodeErr.Wrap(err)
 	}
-	if !ok {
-		return pb.Node{}, NodeErr.New("Failed pinging node")
+	if !node.Id.IsZero() {
+		k.routingTable.RecordLatency(node.Id, rtt)
+		for _, observe := range k.pingObservers {
+			observe(node.Id, rtt)
+		}
+		k.observeAddress(node.Id, observed)
 	}
 	return node, nil
 }
 
+// VerifyRestored pings the nodes restored from a previous run, alpha at a
+// time, and removes the ones which don't answer from the routing table.
+// Restored nodes are used for lookups meanwhile, so it can run concurrently
+// with Bootstrap.
+func (k *Kademlia) VerifyRestored(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	restored := k.routingTable.takeRestored()
+	if len(restored) == 0 {
+		return nil
+	}
+
+	concurrency := k.alpha
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var removed int64
+	limiter := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, node := range restored {
+		node := node
+		select {
+		case limiter <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		}
+		wg.Add(1)
+		go func() {
+			defer func() { <-limiter; wg.Done() }()
+			if _, err := k.Ping(ctx, *node); err == nil || ctx.Err() != nil {
+				return
+			}
+			if err := k.routingTable.ConnectionFailed(node); err != nil {
+				k.log.Debug("could not remove unreachable restored node", zap.Error(err))
+				return
+			}
+			atomic.AddInt64(&removed, 1)
+		}()
+	}
+	wg.Wait()
+
+	k.log.Sugar().Infof("verified %d restored nodes, removed %d unreachable ones", len(restored), removed)
+	return ctx.Err()
+}
+
 // FindNode looks up the provided NodeID first in the local Node, and if it is not found
 // begins searching the network for the NodeID. Returns and error if node was not found
 func (k *Kademlia) FindNode(ctx context.Context, ID storj.NodeID) (pb.Node, error) {
-	return k.lookup(ctx, ID, false)
+	churn := k.routingTable.Churn()
+	if node, ok := k.lookups.get(ID, churn); ok {
+		return node, nil
+	}
+
+	node, err := k.lookup(ctx, ID, false)
+	if err != nil {
+		return node, err
+	}
+	k.lookups.add(node, churn)
+	return node, nil
 }
 
-//lookup initiates a kadmelia node lookup
+// Lookup looks up the k nodes closest to ID on the network, closest first.
+// Only the nodes which answered are returned.
+func (k *Kademlia) Lookup(ctx context.Context, ID storj.NodeID) (_ []*pb.Node, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return k.closest(ctx, ID)
+}
+
+// lookup initiates a kadmelia node lookup
 func (k *Kademlia) lookup(ctx context.Context, ID storj.NodeID, isBootstrap bool) (pb.Node, error) {
 	kb := k.routingTable.K()
 	var nodes []*pb.Node
@@ -233,7 +418,7 @@ func (k *Kademlia) lookup(ctx context.Context, ID storj.NodeID, isBootstrap bool
 		}
 	}
 	lookup := newPeerDiscovery(k.log, k.routingTable.Local(), nodes, k.dialer, ID, discoveryOptions{
-		concurrency: k.alpha, retries: defaultRetries, bootstrap: isBootstrap, bootstrapNodes: k.bootstrapNodes,
+		concurrency: k.alpha, k: kb, retries: defaultRetries, bootstrap: isBootstrap, bootstrapNodes: k.bootstrapNodes,
 	})
 	target, err := lookup.Run(ctx)
 	if err != nil {
@@ -284,56 +469,33 @@ func GetIntroNode(addr string) (*pb.Node, error) {
 	}, nil
 }
 
-// StartRefresh occasionally refreshes stale kad buckets
-func (k *Kademlia) StartRefresh(ctx context.Context) {
-	go func() {
-		err := k.RunRefresh(ctx)
-		if err != nil && err != context.Canceled {
-			k.log.Error("refresh returned", zap.Error(err))
-		}
-	}()
-}
-
-// RunRefresh occasionally refreshes stale kad buckets
-func (k *Kademlia) RunRefresh(ctx context.Context) error {
-	ticker := time.NewTicker(5 * time.Minute)
-	time.Sleep(time.Duration(rand.Intn(300)) * time.Second) //stagger
-	for {
-		if err := k.refresh(ctx); err != nil {
-			k.log.Warn("bucket refresh failed", zap.Error(err))
+// GetIntroNodes parses the comma separated addresses of the nodes to
+// bootstrap against
+func GetIntroNodes(addrs string) ([]pb.Node, error) {
+	var nodes []pb.Node
+	for _, addr := range strings.Split(addrs, ",") {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
 		}
-		select {
-		case <-ticker.C:
-		case <-ctx.Done():
-			ticker.Stop()
-			return ctx.Err()
+		node, err := GetIntroNode(addr)
+		if err != nil {
+			return nil, err
 		}
+		nodes = append(nodes, *node)
 	}
-}
-
-// refresh updates each Kademlia bucket not contacted in the last hour
-func (k *Kademlia) refresh(ctx context.Context) error {
-	bIDs, err := k.routingTable.GetBucketIds()
-	if err != nil {
-		return Error.Wrap(err)
-	}
-	now := time.Now()
-	startID := bucketID{}
-	var errors errs.Group
-	for _, bID := range bIDs {
-		ts, tErr := k.routingTable.GetBucketTimestamp(bID)
-		if tErr != nil {
-			errors.Add(tErr)
-		} else if now.After(ts.Add(time.Hour)) {
-			rID, _ := randomIDInRange(startID, keyToBucketID(bID))
-			_, _ = k.FindNode(ctx, rID) // ignore node not found
+	if len(nodes) == 0 {
+		node, err := GetIntroNode("")
+		if err != nil {
+			return nil, err
 		}
+		nodes = append(nodes, *node)
 	}
-	return Error.Wrap(errors.Err())
+	return nodes, nil
 }
 
 // randomIDInRange finds a random node ID with a range (start..end]
-func randomIDInRange(start, end bucketID) (storj.NodeID, error) {
+func randomIDInRange(rng *rand.Rand, start, end bucketID) (storj.NodeID, error) {
 	randID := storj.NodeID{}
 	divergedHigh := false
 	divergedLow := false
@@ -352,7 +514,7 @@ func randomIDInRange(start, end bucketID) (storj.NodeID, error) {
 		if s == e {
 			randID[x] = s
 		} else {
-			r := s + byte(rand.Intn(int(e-s))) + 1
+			r := s + byte(rng.Intn(int(e-s))) + 1
 			if r < e {
 				divergedHigh = true
 			}
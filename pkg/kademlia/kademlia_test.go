@@ -161,6 +161,44 @@ func TestBootstrap(t *testing.T) {
 	assert.Len(t, nodeIDs, 3)
 }
 
+func TestBootstrapRetry(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	bn, s, clean := testNode(t, []pb.Node{})
+	defer clean()
+	defer s.Stop()
+
+	// an address nothing listens on
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	unreachable := pb.Node{Address: &pb.NodeAddress{Address: lis.Addr().String()}, Type: pb.NodeType_STORAGE}
+	assert.NoError(t, lis.Close())
+
+	// one of the bootstrap nodes answering is enough
+	n1, s1, clean1 := testNode(t, []pb.Node{unreachable, bn.routingTable.self})
+	defer clean1()
+	defer s1.Stop()
+
+	assert.NoError(t, n1.Bootstrap(ctx))
+	assert.NoError(t, n1.WaitForBootstrap(ctx))
+
+	// bootstrap retries until the context is canceled
+	n2, s2, clean2 := testNode(t, []pb.Node{unreachable})
+	defer clean2()
+	defer s2.Stop()
+	n2.SetBootstrapBackoff(100 * time.Millisecond)
+
+	timeout, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	ctx.Go(func() error {
+		assert.Equal(t, context.DeadlineExceeded, n2.Bootstrap(timeout))
+		return nil
+	})
+	assert.Equal(t, context.DeadlineExceeded, n2.WaitForBootstrap(timeout))
+}
+
 func testNode(t *testing.T, bn []pb.Node) (*Kademlia, *grpc.Server, func()) {
 	ctx := testcontext.New(t)
 	// new address
@@ -192,12 +230,63 @@ func testNode(t *testing.T, bn []pb.Node) (*Kademlia, *grpc.Server, func()) {
 	}
 }
 
+func TestVerifyRestored(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	live, s, clean := testNode(t, []pb.Node{})
+	defer clean()
+	defer s.Stop()
+
+	dir, cleanup := mktempdir(t, "kademlia")
+	defer cleanup()
+	fid, err := testidentity.NewTestIdentity(ctx)
+	assert.NoError(t, err)
+	logger := zaptest.NewLogger(t)
+
+	liveNode := live.routingTable.Local()
+	deadNode := pb.Node{
+		Id:      teststorj.NodeIDFromString("dead"),
+		Type:    pb.NodeType_STORAGE,
+		Address: &pb.NodeAddress{Address: "127.0.0.1:1"},
+	}
+
+	k, err := NewKademlia(logger, pb.NodeType_STORAGE, nil, "127.0.0.1:0", nil, fid, dir, defaultAlpha)
+	assert.NoError(t, err)
+	assert.NoError(t, k.routingTable.ConnectionSuccess(&liveNode))
+	assert.NoError(t, k.routingTable.ConnectionSuccess(&deadNode))
+	assert.NoError(t, k.Disconnect())
+
+	// the routing table of the previous run is restored
+	k, err = NewKademlia(logger, pb.NodeType_STORAGE, nil, "127.0.0.1:0", nil, fid, dir, defaultAlpha)
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, k.Disconnect()) }()
+	assert.Len(t, k.routingTable.restored, 2)
+
+	nodes, err := k.routingTable.FindNear(liveNode.Id, 10)
+	assert.NoError(t, err)
+	assert.Len(t, nodes, 3)
+
+	// restored nodes which don't answer are removed
+	timedCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	assert.NoError(t, k.VerifyRestored(timedCtx))
+	assert.Empty(t, k.routingTable.restored)
+
+	nodeIDs, err := k.routingTable.nodeBucketDB.List(nil, 0)
+	assert.NoError(t, err)
+	assert.Len(t, nodeIDs, 2)
+	_, err = k.routingTable.nodeBucketDB.Get(deadNode.Id.Bytes())
+	assert.Error(t, err)
+}
+
 func TestRefresh(t *testing.T) {
 	ctx := testcontext.New(t)
 	defer ctx.Cleanup()
 	k, s, clean := testNode(t, []pb.Node{})
 	defer clean()
 	defer s.Stop()
+	refresher := NewRefresher(zaptest.NewLogger(t), k, RefreshConfig{Interval: time.Minute, Staleness: time.Hour})
 	//turn back time for only bucket
 	rt := k.routingTable
 	now := time.Now().UTC()
@@ -205,17 +294,23 @@ func TestRefresh(t *testing.T) {
 	err := rt.SetBucketTimestamp(bID[:], now.Add(-2*time.Hour))
 	assert.NoError(t, err)
 	//refresh should  call FindNode, updating the time
-	err = k.refresh(ctx)
+	refreshed, err := refresher.refresh(ctx, time.Now())
 	assert.NoError(t, err)
+	assert.Equal(t, 1, refreshed)
 	ts1, err := rt.GetBucketTimestamp(bID[:])
 	assert.NoError(t, err)
 	assert.True(t, now.Add(-5*time.Minute).Before(ts1))
 	//refresh should not call FindNode, leaving the previous time
-	err = k.refresh(ctx)
+	refreshed, err = refresher.refresh(ctx, time.Now())
 	assert.NoError(t, err)
+	assert.Equal(t, 0, refreshed)
 	ts2, err := rt.GetBucketTimestamp(bID[:])
 	assert.NoError(t, err)
 	assert.True(t, ts1.Equal(ts2))
+	//refresh should call FindNode once the bucket is stale again
+	refreshed, err = refresher.refresh(ctx, ts2.Add(time.Hour+time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, refreshed)
 }
 
 func TestGetNodes(t *testing.T) {
@@ -486,6 +581,7 @@ func newTestServer(ctx context.Context, nn []*pb.Node) (*grpc.Server, *mockNodes
 
 // TestRandomIds makes sure finds a random node ID is within a range (start..end]
 func TestRandomIds(t *testing.T) {
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 	for x := 0; x < 1000; x++ {
 		var start, end bucketID
 		// many valid options
@@ -494,16 +590,16 @@ func TestRandomIds(t *testing.T) {
 		if bytes.Compare(start[:], end[:]) > 0 {
 			start, end = end, start
 		}
-		id, err := randomIDInRange(start, end)
+		id, err := randomIDInRange(rng, start, end)
 		assert.NoError(t, err, "Unexpected err in randomIDInRange")
 		assert.True(t, bytes.Compare(id[:], start[:]) > 0, "Random id was less than starting id")
 		assert.True(t, bytes.Compare(id[:], end[:]) <= 0, "Random id was greater than end id")
 		//invalid range
-		_, err = randomIDInRange(end, start)
+		_, err = randomIDInRange(rng, end, start)
 		assert.Error(t, err, "Missing expected err in invalid randomIDInRange")
 		//no valid options
 		end = start
-		_, err = randomIDInRange(start, end)
+		_, err = randomIDInRange(rng, start, end)
 		assert.Error(t, err, "Missing expected err in empty randomIDInRange")
 		// one valid option
 		if start[31] == 255 {
@@ -511,7 +607,7 @@ func TestRandomIds(t *testing.T) {
 		} else {
 			end[31] = start[31] + 1
 		}
-		id, err = randomIDInRange(start, end)
+		id, err = randomIDInRange(rng, start, end)
 		assert.NoError(t, err, "Unexpected err in randomIDInRange")
 		assert.True(t, bytes.Equal(id[:], end[:]), "Not-so-random id was incorrect")
 	}
@@ -532,3 +628,11 @@ func (mn *mockNodesServer) Ping(ctx context.Context, req *pb.PingRequest) (*pb.P
 	atomic.AddInt32(&mn.pingCalled, 1)
 	return &pb.PingResponse{}, nil
 }
+
+func (mn *mockNodesServer) Store(ctx context.Context, req *pb.StoreRequest) (*pb.StoreResponse, error) {
+	return &pb.StoreResponse{}, nil
+}
+
+func (mn *mockNodesServer) FindValue(ctx context.Context, req *pb.FindValueRequest) (*pb.FindValueResponse, error) {
+	return &pb.FindValueResponse{}, nil
+}
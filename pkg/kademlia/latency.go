@@ -0,0 +1,58 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kademlia
+
+import (
+	"time"
+
+	"storj.io/storj/pkg/storj"
+)
+
+// latencyWeight is the weight of a new round trip in the moving average
+const latencyWeight = 0.2
+
+// Latency are the rolling statistics of the round trips of pings to a node
+type Latency struct {
+	Last    time.Duration
+	Average time.Duration // exponentially weighted moving average
+	Samples int64
+}
+
+// RecordLatency adds the round trip of a ping to a node to its statistics
+func (rt *RoutingTable) RecordLatency(id storj.NodeID, rtt time.Duration) {
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+
+	latency, ok := rt.latencies[id]
+	if !ok {
+		rt.latencies[id] = &Latency{Last: rtt, Average: rtt, Samples: 1}
+		return
+	}
+	latency.Last = rtt
+	latency.Average += time.Duration(latencyWeight * float64(rtt-latency.Average))
+	latency.Samples++
+}
+
+// Latency returns the round trip statistics of pings to a node, or false when
+// the node wasn't pinged since it last failed to answer
+func (rt *RoutingTable) Latency(id storj.NodeID) (Latency, bool) {
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+
+	latency, ok := rt.latencies[id]
+	if !ok {
+		return Latency{}, false
+	}
+	return *latency, true
+}
+
+// lastSeenAt returns when the node last answered, or false when it didn't
+// answer since it last failed to
+func (rt *RoutingTable) lastSeenAt(id storj.NodeID) (time.Time, bool) {
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+
+	seen, ok := rt.lastSeen[id]
+	return seen, ok
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kademlia
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/pb"
+)
+
+func TestLatency(t *testing.T) {
+	rt, cleanup := createRoutingTable(t, teststorj.NodeIDFromString("AA"))
+	defer cleanup()
+
+	node := &pb.Node{Id: teststorj.NodeIDFromString("BB"), Type: pb.NodeType_STORAGE}
+
+	_, ok := rt.Latency(node.Id)
+	assert.False(t, ok)
+
+	rt.RecordLatency(node.Id, 100*time.Millisecond)
+	latency, ok := rt.Latency(node.Id)
+	require.True(t, ok)
+	assert.Equal(t, Latency{Last: 100 * time.Millisecond, Average: 100 * time.Millisecond, Samples: 1}, latency)
+
+	rt.RecordLatency(node.Id, 200*time.Millisecond)
+	latency, ok = rt.Latency(node.Id)
+	require.True(t, ok)
+	assert.Equal(t, Latency{Last: 200 * time.Millisecond, Average: 120 * time.Millisecond, Samples: 2}, latency)
+
+	// statistics start over once the node failed to answer
+	require.NoError(t, rt.ConnectionFailed(node))
+	_, ok = rt.Latency(node.Id)
+	assert.False(t, ok)
+}
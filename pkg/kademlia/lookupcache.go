@@ -0,0 +1,104 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kademlia
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+// lookupCache remembers the results of recent lookups, so that repeated
+// lookups of the same node within the ttl skip the iterative search. Results
+// are dropped once the routing table churned since they were looked up. The
+// cache keeps at most `size` results and evicts the least recently used one
+// when full.
+type lookupCache struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[storj.NodeID]*list.Element
+}
+
+// lookupResult is a cached lookup result
+type lookupResult struct {
+	node     pb.Node
+	lookedUp time.Time
+	churn    uint64 // of the routing table when the lookup started
+}
+
+// newLookupCache returns a new lookup cache holding at most size results for
+// ttl. A nil cache is returned when size or ttl are not positive; a nil cache
+// doesn't cache anything.
+func newLookupCache(size int, ttl time.Duration) *lookupCache {
+	if size <= 0 || ttl <= 0 {
+		return nil
+	}
+	return &lookupCache{
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		order:   list.New(),
+		entries: make(map[storj.NodeID]*list.Element),
+	}
+}
+
+// get returns the cached result for id, when it is younger than the ttl and
+// the routing table didn't churn since
+func (cache *lookupCache) get(id storj.NodeID, churn uint64) (pb.Node, bool) {
+	if cache == nil {
+		return pb.Node{}, false
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	elem, ok := cache.entries[id]
+	if !ok {
+		mon.Counter("lookup_cache_miss").Inc(1)
+		return pb.Node{}, false
+	}
+
+	result := elem.Value.(*lookupResult)
+	if result.churn != churn || cache.now().Sub(result.lookedUp) >= cache.ttl {
+		cache.order.Remove(elem)
+		delete(cache.entries, id)
+		mon.Counter("lookup_cache_invalidated").Inc(1)
+		return pb.Node{}, false
+	}
+
+	cache.order.MoveToFront(elem)
+	mon.Counter("lookup_cache_hit").Inc(1)
+	return *pb.CopyNode(&result.node), true
+}
+
+// add caches the node found by a lookup started at churn, evicting the
+// oldest results when full
+func (cache *lookupCache) add(node pb.Node, churn uint64) {
+	if cache == nil {
+		return
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	result := &lookupResult{node: node, lookedUp: cache.now(), churn: churn}
+	if elem, ok := cache.entries[node.Id]; ok {
+		elem.Value = result
+		cache.order.MoveToFront(elem)
+		return
+	}
+
+	cache.entries[node.Id] = cache.order.PushFront(result)
+	for cache.order.Len() > cache.size {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*lookupResult).node.Id)
+		mon.Counter("lookup_cache_evict").Inc(1)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kademlia
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/pb"
+)
+
+func TestLookupCache(t *testing.T) {
+	now := time.Now()
+	cache := newLookupCache(2, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	a := pb.Node{Id: teststorj.NodeIDFromString("a"), Address: &pb.NodeAddress{Address: "a:7777"}}
+	b := pb.Node{Id: teststorj.NodeIDFromString("b"), Address: &pb.NodeAddress{Address: "b:7777"}}
+	c := pb.Node{Id: teststorj.NodeIDFromString("c"), Address: &pb.NodeAddress{Address: "c:7777"}}
+
+	_, ok := cache.get(a.Id, 0)
+	assert.False(t, ok)
+
+	cache.add(a, 0)
+	node, ok := cache.get(a.Id, 0)
+	assert.True(t, ok)
+	assert.Equal(t, a.Address.Address, node.Address.Address)
+
+	{ // the least recently used result is evicted
+		cache.add(b, 0)
+		_, ok = cache.get(a.Id, 0)
+		assert.True(t, ok)
+		cache.add(c, 0)
+
+		_, ok = cache.get(b.Id, 0)
+		assert.False(t, ok)
+		_, ok = cache.get(a.Id, 0)
+		assert.True(t, ok)
+	}
+
+	{ // results are dropped after the routing table churned
+		_, ok = cache.get(c.Id, 1)
+		assert.False(t, ok)
+		_, ok = cache.get(c.Id, 0)
+		assert.False(t, ok)
+	}
+
+	{ // results expire
+		now = now.Add(time.Minute)
+		_, ok = cache.get(a.Id, 0)
+		assert.False(t, ok)
+	}
+}
+
+func TestLookupCacheDisabled(t *testing.T) {
+	assert.Nil(t, newLookupCache(0, time.Minute))
+	assert.Nil(t, newLookupCache(10, 0))
+
+	var cache *lookupCache
+	cache.add(pb.Node{Id: teststorj.NodeIDFromString("a")}, 0)
+	_, ok := cache.get(teststorj.NodeIDFromString("a"), 0)
+	assert.False(t, ok)
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kademlia
+
+import (
+	"context"
+	"sync"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/transport"
+)
+
+// observers forwards the results of connections made by kademlia to the
+// observers added with AddObserver
+type observers struct {
+	mu   sync.RWMutex
+	list []transport.Observer
+}
+
+// add adds an observer
+func (obs *observers) add(observer transport.Observer) {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+	obs.list = append(obs.list, observer)
+}
+
+// ConnSuccess implements the transport.Observer interface
+func (obs *observers) ConnSuccess(ctx context.Context, node *pb.Node) {
+	obs.mu.RLock()
+	defer obs.mu.RUnlock()
+	for _, observer := range obs.list {
+		observer.ConnSuccess(ctx, node)
+	}
+}
+
+// ConnFailure implements the transport.Observer interface
+func (obs *observers) ConnFailure(ctx context.Context, node *pb.Node, err error) {
+	obs.mu.RLock()
+	defer obs.mu.RUnlock()
+	for _, observer := range obs.list {
+		observer.ConnFailure(ctx, node, err)
+	}
+}
@@ -18,32 +18,44 @@ import (
 type peerDiscovery struct {
 	log *zap.Logger
 
-	dialer *Dialer
+	dialer network
 	self   pb.Node
 	target storj.NodeID
 	opts   discoveryOptions
 
 	cond  sync.Cond
 	queue discoveryQueue
+	// closest are the k closest nodes which answered, protected by `cond.L`
+	closest discoveryQueue
 }
 
 // ErrMaxRetries is used when a lookup has been retried the max number of times
 var ErrMaxRetries = errs.Class("max retries exceeded for id:")
 
-func newPeerDiscovery(log *zap.Logger, self pb.Node, nodes []*pb.Node, dialer *Dialer, target storj.NodeID, opts discoveryOptions) *peerDiscovery {
+func newPeerDiscovery(log *zap.Logger, self pb.Node, nodes []*pb.Node, dialer network, target storj.NodeID, opts discoveryOptions) *peerDiscovery {
+	if opts.concurrency < 1 {
+		opts.concurrency = 1
+	}
+	if opts.k < 1 {
+		opts.k = opts.concurrency
+	}
 	discovery := &peerDiscovery{
-		log:    log,
-		dialer: dialer,
-		self:   self,
-		target: target,
-		opts:   opts,
-		cond:   sync.Cond{L: &sync.Mutex{}},
-		queue:  *newDiscoveryQueue(opts.concurrency),
+		log:     log,
+		dialer:  dialer,
+		self:    self,
+		target:  target,
+		opts:    opts,
+		cond:    sync.Cond{L: &sync.Mutex{}},
+		queue:   *newDiscoveryQueue(opts.k),
+		closest: *newDiscoveryQueue(opts.k),
 	}
 	discovery.queue.Insert(target, nodes...)
 	return discovery
 }
 
+// Run asks up to alpha nodes at a time for the nodes closest to the target,
+// closest candidates first. It finishes when the target was found or the k
+// closest nodes which answered are closer than every remaining candidate.
 func (lookup *peerDiscovery) Run(ctx context.Context) (target *pb.Node, err error) {
 	if lookup.queue.Len() == 0 {
 		return nil, nil // TODO: should we return an error here?
@@ -72,9 +84,20 @@ func (lookup *peerDiscovery) Run(ctx context.Context) (target *pb.Node, err erro
 						return
 					}
 
+					if lookup.converged() {
+						if working == 0 {
+							allDone = true
+							lookup.cond.Broadcast()
+							continue
+						}
+						// running requests may still find closer nodes
+						lookup.cond.Wait()
+						continue
+					}
+
 					next = lookup.queue.Closest()
 
-					if !lookup.opts.bootstrap && next != nil && next.Id == lookup.target {
+					if !lookup.opts.bootstrap && !lookup.opts.converge && next != nil && next.Id == lookup.target {
 						allDone = true
 						target = next
 						break // closest node is the target and is already in routing table (i.e. no lookup required)
@@ -110,9 +133,12 @@ func (lookup *peerDiscovery) Run(ctx context.Context) (target *pb.Node, err erro
 					}
 				}
 
-				lookup.queue.Insert(lookup.target, neighbors...)
+				lookup.queue.Insert(lookup.target, lookup.withoutSelf(neighbors)...)
 
 				lookup.cond.L.Lock()
+				if err == nil {
+					lookup.closest.Insert(lookup.target, next)
+				}
 				working--
 				allDone = allDone || isDone(ctx) || working == 0 && lookup.queue.Len() == 0
 				lookup.cond.L.Unlock()
@@ -128,6 +154,35 @@ func (lookup *peerDiscovery) Run(ctx context.Context) (target *pb.Node, err erro
 	return target, err
 }
 
+// Closest returns the k closest nodes which answered, closest first
+func (lookup *peerDiscovery) Closest() []*pb.Node {
+	lookup.cond.L.Lock()
+	defer lookup.cond.L.Unlock()
+	return lookup.closest.Nodes()
+}
+
+// converged returns whether k nodes answered and none of the remaining
+// candidates is closer than the furthest of them, must hold `cond.L`
+func (lookup *peerDiscovery) converged() bool {
+	furthest, ok := lookup.closest.Furthest()
+	if !ok || lookup.closest.Len() < lookup.opts.k {
+		return false
+	}
+	closest, ok := lookup.queue.Nearest()
+	return !ok || !closest.Less(furthest)
+}
+
+// withoutSelf returns the nodes other than the local node
+func (lookup *peerDiscovery) withoutSelf(nodes []*pb.Node) []*pb.Node {
+	others := nodes[:0]
+	for _, node := range nodes {
+		if node.Id != lookup.self.Id {
+			others = append(others, node)
+		}
+	}
+	return others
+}
+
 func isDone(ctx context.Context) bool {
 	select {
 	case <-ctx.Done():
@@ -197,21 +252,23 @@ func (queue *discoveryQueue) Reinsert(target storj.NodeID, node *pb.Node, limit
 	return true
 }
 
-// insert must hold lock while adding
+// insert must hold lock while adding. Every node is inserted at its place
+// in the sorted items, so large candidate sets don't resort the queue.
 func (queue *discoveryQueue) insert(target storj.NodeID, nodes ...*pb.Node) {
 	for _, node := range nodes {
-		queue.items = append(queue.items, queueItem{
-			node:     node,
-			priority: xorNodeID(target, node.Id),
+		priority := xorNodeID(target, node.Id)
+		i := sort.Search(len(queue.items), func(i int) bool {
+			return priority.Less(queue.items[i].priority)
 		})
-	}
-
-	sort.Slice(queue.items, func(i, k int) bool {
-		return queue.items[i].priority.Less(queue.items[k].priority)
-	})
+		if i >= queue.maxLen {
+			continue
+		}
 
-	if len(queue.items) > queue.maxLen {
-		queue.items = queue.items[:queue.maxLen]
+		if len(queue.items) < queue.maxLen {
+			queue.items = append(queue.items, queueItem{})
+		}
+		copy(queue.items[i+1:], queue.items[i:])
+		queue.items[i] = queueItem{node: node, priority: priority}
 	}
 }
 
@@ -229,6 +286,40 @@ func (queue *discoveryQueue) Closest() *pb.Node {
 	return item.node
 }
 
+// Nearest returns the priority of the closest item in the queue
+func (queue *discoveryQueue) Nearest() (storj.NodeID, bool) {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+
+	if len(queue.items) == 0 {
+		return storj.NodeID{}, false
+	}
+	return queue.items[0].priority, true
+}
+
+// Furthest returns the priority of the furthest item in the queue
+func (queue *discoveryQueue) Furthest() (storj.NodeID, bool) {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+
+	if len(queue.items) == 0 {
+		return storj.NodeID{}, false
+	}
+	return queue.items[len(queue.items)-1].priority, true
+}
+
+// Nodes returns the nodes in the queue, closest first
+func (queue *discoveryQueue) Nodes() []*pb.Node {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+
+	nodes := make([]*pb.Node, 0, len(queue.items))
+	for _, item := range queue.items {
+		nodes = append(nodes, item.node)
+	}
+	return nodes
+}
+
 // Len returns the number of items in the queue
 func (queue *discoveryQueue) Len() int {
 	queue.mu.Lock()
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storj"
@@ -98,3 +99,61 @@ func TestDiscoveryQueueRandom(t *testing.T) {
 		}
 	}
 }
+
+func TestPeerDiscoveryConverged(t *testing.T) {
+	target := storj.NodeID{1, 1}
+
+	near := &pb.Node{Id: storj.NodeID{3, 2}}      // -> 00000010:00000011
+	middle := &pb.Node{Id: storj.NodeID{6, 5}}    // -> 00000111:00000100
+	far := &pb.Node{Id: storj.NodeID{12, 1}}      // -> 00001101:00000000
+	further := &pb.Node{Id: storj.NodeID{18, 74}} // -> 00010011:01001011
+
+	lookup := newPeerDiscovery(zap.NewNop(), pb.Node{}, []*pb.Node{middle, further}, nil, target, discoveryOptions{concurrency: 2, k: 2})
+
+	furthest, ok := lookup.queue.Furthest()
+	assert.True(t, ok)
+	assert.Equal(t, xorNodeID(target, further.Id), furthest)
+	nearest, ok := lookup.queue.Nearest()
+	assert.True(t, ok)
+	assert.Equal(t, xorNodeID(target, middle.Id), nearest)
+
+	// fewer than k nodes answered
+	lookup.closest.Insert(target, far)
+	assert.False(t, lookup.converged())
+
+	// the candidate middle is closer than the answered far
+	lookup.closest.Insert(target, near)
+	assert.False(t, lookup.converged())
+
+	// the remaining candidate further isn't closer than any answered node
+	assert.Equal(t, middle.Id, lookup.queue.Closest().Id)
+	lookup.closest.Insert(target, middle)
+	assert.True(t, lookup.converged())
+
+	// no candidates left
+	assert.Equal(t, further.Id, lookup.queue.Closest().Id)
+	assert.True(t, lookup.converged())
+}
+
+func BenchmarkDiscoveryQueue(b *testing.B) {
+	newNode := func() *pb.Node {
+		var id storj.NodeID
+		_, _ = rand.Read(id[:])
+		return &pb.Node{Id: id}
+	}
+
+	var nodes []*pb.Node
+	for k := 0; k < 1000; k++ {
+		nodes = append(nodes, newNode())
+	}
+	target := newNode().Id
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for m := 0; m < b.N; m++ {
+		queue := newDiscoveryQueue(20)
+		for _, node := range nodes {
+			queue.insert(target, node)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kademlia
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/storj/pkg/dht"
+	"storj.io/storj/pkg/node"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+const (
+	// maxRecordSize is the size of the largest value stored for other nodes
+	maxRecordSize = 1024
+	// maxRecordTTL is how long records are stored at most, publishers must
+	// republish them to keep them around longer
+	maxRecordTTL = 24 * time.Hour
+	// maxRecords is the number of records stored for other nodes
+	maxRecords = 10000
+)
+
+var (
+	// RecordErr is the class for all errors pertaining to records stored in the DHT
+	RecordErr = errs.Class("record error")
+	// RecordNotFound is returned when none of the nodes closest to a key stores it
+	RecordNotFound = errs.Class("record not found")
+)
+
+// recordStore holds the records other nodes stored on this node and the
+// ones this node published itself
+type recordStore struct {
+	now func() time.Time
+
+	mu        sync.Mutex
+	records   map[storj.NodeID]*pb.Record
+	published map[storj.NodeID]*publishedRecord
+}
+
+// publishedRecord is a record published by this node
+type publishedRecord struct {
+	record   *pb.Record
+	storedAt time.Time // when it was last stored on the nodes closest to it
+}
+
+func newRecordStore() *recordStore {
+	return &recordStore{
+		now:       time.Now,
+		records:   make(map[storj.NodeID]*pb.Record),
+		published: make(map[storj.NodeID]*publishedRecord),
+	}
+}
+
+// Put stores the record of another node, replacing an older record of the
+// same publisher
+func (store *recordStore) Put(record *pb.Record) error {
+	now := store.now()
+	if err := checkRecord(record, now); err != nil {
+		return err
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	store.expire(now)
+	if existing, ok := store.records[record.Key]; ok {
+		if existing.Publisher != record.Publisher {
+			return RecordErr.New("%s is published by %s", record.Key, existing.Publisher)
+		}
+		if record.Signature.SignedAt < existing.Signature.SignedAt {
+			return RecordErr.New("%s is older than the stored one", record.Key)
+		}
+	} else if len(store.records) >= maxRecords {
+		return RecordErr.New("storing %d records already", len(store.records))
+	}
+	store.records[record.Key] = record
+	return nil
+}
+
+// Get returns the record stored for key, if it didn't expire
+func (store *recordStore) Get(key storj.NodeID) (*pb.Record, bool) {
+	now := store.now()
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if own, ok := store.published[key]; ok && expired(own.record, now) {
+		delete(store.published, key)
+	} else if ok {
+		return own.record, true
+	}
+
+	record, ok := store.records[key]
+	if ok && expired(record, now) {
+		delete(store.records, key)
+		return nil, false
+	}
+	return record, ok
+}
+
+// publish remembers a record this node published
+func (store *recordStore) publish(record *pb.Record, storedAt time.Time) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.published[record.Key] = &publishedRecord{record: record, storedAt: storedAt}
+}
+
+// republishable returns the published records which were last stored
+// before since and drops the expired ones
+func (store *recordStore) republishable(now, since time.Time) []*pb.Record {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	var records []*pb.Record
+	for key, own := range store.published {
+		switch {
+		case expired(own.record, now):
+			delete(store.published, key)
+		case own.storedAt.Before(since):
+			own.storedAt = now
+			records = append(records, own.record)
+		}
+	}
+	return records
+}
+
+// expire drops the expired records of other nodes, must hold mu
+func (store *recordStore) expire(now time.Time) {
+	for key, record := range store.records {
+		if expired(record, now) {
+			delete(store.records, key)
+		}
+	}
+}
+
+// expired returns whether record expired at now
+func expired(record *pb.Record, now time.Time) bool {
+	return !now.Before(time.Unix(record.ExpiresAt, 0))
+}
+
+// checkRecord checks that the record is signed by its publisher, small and
+// expires neither before now nor after the longest ttl
+func checkRecord(record *pb.Record, now time.Time) error {
+	if record == nil {
+		return RecordErr.New("missing record")
+	}
+	if len(record.Value) > maxRecordSize {
+		return RecordErr.New("value of %s is %d bytes, at most %d are allowed", record.Key, len(record.Value), maxRecordSize)
+	}
+	if expired(record, now) {
+		return RecordErr.New("%s expired", record.Key)
+	}
+	if time.Unix(record.ExpiresAt, 0).After(now.Add(maxRecordTTL)) {
+		return RecordErr.New("%s expires after more than %v", record.Key, maxRecordTTL)
+	}
+	return RecordErr.Wrap(node.VerifyRecord(record))
+}
+
+// GetRecords provides the records stored on this node
+func (k *Kademlia) GetRecords(ctx context.Context) (dht.Records, error) {
+	return k.records, nil
+}
+
+// StoreValue publishes value under key on the k nodes closest to key, which
+// keep it for ttl. The refresher republishes the record until it expires.
+func (k *Kademlia) StoreValue(ctx context.Context, key storj.NodeID, value []byte, ttl time.Duration) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if ttl > maxRecordTTL {
+		return RecordErr.New("ttl %v is longer than %v", ttl, maxRecordTTL)
+	}
+
+	now := time.Now()
+	record := &pb.Record{
+		Key:       key,
+		Value:     value,
+		Publisher: k.identity.ID,
+		ExpiresAt: now.Add(ttl).Unix(),
+	}
+	if err := node.SignRecord(k.identity, record, now); err != nil {
+		return RecordErr.Wrap(err)
+	}
+	if err := checkRecord(record, now); err != nil {
+		return err
+	}
+
+	k.records.publish(record, now)
+	return k.storeRecord(ctx, record)
+}
+
+// FindValue returns the record stored under key by the nodes closest to it
+func (k *Kademlia) FindValue(ctx context.Context, key storj.NodeID) (_ *pb.Record, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if record, ok := k.records.Get(key); ok {
+		return record, nil
+	}
+
+	nodes, err := k.closest(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes {
+		record, err := k.dialer.FindValue(ctx, *n, key)
+		if err != nil {
+			k.log.Debug("finding record failed", zap.Stringer("node", n.Id), zap.Error(err))
+			continue
+		}
+		if record != nil {
+			return record, nil
+		}
+	}
+	return nil, RecordNotFound.New("%s", key)
+}
+
+// republish stores the records published by this node again, when they
+// were last stored longer than interval ago
+func (k *Kademlia) republish(ctx context.Context, now time.Time, interval time.Duration) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var errors errs.Group
+	for _, record := range k.records.republishable(now, now.Add(-interval)) {
+		errors.Add(k.storeRecord(ctx, record))
+	}
+	return errors.Err()
+}
+
+// storeRecord stores record on the k nodes closest to its key, it fails
+// when none of them stored it
+func (k *Kademlia) storeRecord(ctx context.Context, record *pb.Record) error {
+	nodes, err := k.closest(ctx, record.Key)
+	if err != nil {
+		return err
+	}
+
+	var stored int64
+	var group errgroup.Group
+	for _, n := range nodes {
+		n := n
+		group.Go(func() error {
+			if err := k.dialer.Store(ctx, *n, record); err != nil {
+				k.log.Debug("storing record failed", zap.Stringer("node", n.Id), zap.Error(err))
+				return nil
+			}
+			atomic.AddInt64(&stored, 1)
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	mon.IntVal("record_copies").Observe(stored)
+	if stored == 0 && len(nodes) > 0 {
+		return RecordErr.New("none of %d nodes stored %s", len(nodes), record.Key)
+	}
+	return nil
+}
+
+// closest looks up the k nodes closest to key which answered, closest first
+func (k *Kademlia) closest(ctx context.Context, key storj.NodeID) ([]*pb.Node, error) {
+	kb := k.routingTable.K()
+	nodes, err := k.routingTable.FindNear(key, kb)
+	if err != nil {
+		return nil, err
+	}
+	lookup := newPeerDiscovery(k.log, k.routingTable.Local(), nodes, k.dialer, key, discoveryOptions{
+		concurrency: k.alpha, k: kb, retries: defaultRetries, converge: true,
+	})
+	if _, err := lookup.Run(ctx); err != nil {
+		return nil, err
+	}
+	return lookup.Closest(), nil
+}
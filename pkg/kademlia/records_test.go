@@ -0,0 +1,100 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kademlia
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/node"
+	"storj.io/storj/pkg/pb"
+)
+
+func TestRecordStore(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	ident, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+	other, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+
+	now := time.Now()
+	store := newRecordStore()
+	store.now = func() time.Time { return now }
+
+	newRecord := func(key string, value string, ttl time.Duration, signedAt time.Time) *pb.Record {
+		record := &pb.Record{
+			Key:       teststorj.NodeIDFromString(key),
+			Value:     []byte(value),
+			Publisher: ident.ID,
+			ExpiresAt: now.Add(ttl).Unix(),
+		}
+		require.NoError(t, node.SignRecord(ident, record, signedAt))
+		return record
+	}
+
+	_, ok := store.Get(teststorj.NodeIDFromString("a"))
+	assert.False(t, ok)
+
+	a := newRecord("a", "value", time.Hour, now)
+	require.NoError(t, store.Put(a))
+	record, ok := store.Get(a.Key)
+	assert.True(t, ok)
+	assert.Equal(t, a, record)
+
+	{ // publishers replace their records with newer ones only
+		assert.Error(t, store.Put(newRecord("a", "older", time.Hour, now.Add(-time.Minute))))
+		newer := newRecord("a", "newer", time.Hour, now.Add(time.Minute))
+		assert.NoError(t, store.Put(newer))
+		record, _ = store.Get(a.Key)
+		assert.Equal(t, newer, record)
+
+		taken := &pb.Record{Key: a.Key, Publisher: other.ID, ExpiresAt: now.Add(time.Hour).Unix()}
+		require.NoError(t, node.SignRecord(other, taken, now.Add(time.Hour)))
+		assert.Error(t, store.Put(taken), "keys of other publishers can't be taken")
+	}
+
+	{ // invalid records are refused
+		unsigned := newRecord("b", "value", time.Hour, now)
+		unsigned.Signature = nil
+		assert.Error(t, store.Put(unsigned))
+		assert.Error(t, store.Put(nil))
+		assert.Error(t, store.Put(newRecord("b", string(make([]byte, maxRecordSize+1)), time.Hour, now)))
+		assert.Error(t, store.Put(newRecord("b", "value", maxRecordTTL+time.Minute, now)))
+		assert.Error(t, store.Put(newRecord("b", "value", -time.Minute, now)))
+		_, ok = store.Get(teststorj.NodeIDFromString("b"))
+		assert.False(t, ok)
+	}
+
+	{ // records are dropped when they expire
+		now = now.Add(time.Hour)
+		_, ok = store.Get(a.Key)
+		assert.False(t, ok)
+	}
+
+	{ // published records are republished until they expire
+		c := newRecord("c", "value", 2*time.Hour, now)
+		store.publish(c, now)
+		record, ok = store.Get(c.Key)
+		assert.True(t, ok)
+		assert.Equal(t, c, record)
+
+		assert.Empty(t, store.republishable(now, now.Add(-time.Hour)))
+		now = now.Add(time.Hour + time.Second)
+		assert.Equal(t, []*pb.Record{c}, store.republishable(now, now.Add(-time.Hour)))
+		assert.Empty(t, store.republishable(now, now.Add(-time.Hour)))
+
+		now = now.Add(time.Hour)
+		assert.Empty(t, store.republishable(now, now))
+		_, ok = store.Get(c.Key)
+		assert.False(t, ok)
+	}
+}
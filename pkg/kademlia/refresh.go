@@ -0,0 +1,159 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kademlia
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+)
+
+// RefreshConfig defines how often Kademlia buckets are refreshed
+type RefreshConfig struct {
+	Interval  time.Duration `help:"how often buckets are checked for staleness, 0 disables refreshing" default:"5m0s"`
+	Staleness time.Duration `help:"buckets without a lookup for this long are refreshed with a lookup of a random node ID in their range" default:"1h0m0s"`
+	Republish time.Duration `help:"how often the records published by this node are stored again on the nodes closest to their key, 0 disables republishing" default:"1h0m0s"`
+}
+
+// Refresher refreshes the buckets of the routing table which had no lookup
+// in a while, so that every bucket keeps learning about the nodes in its range.
+// It pings the least recently seen node of buckets with replacements waiting,
+// and replaces it when it doesn't answer. It also republishes the records of
+// the node, so they stay stored on the nodes closest to them while nodes come
+// and go. After each round it reports the health of the routing table.
+type Refresher struct {
+	log    *zap.Logger
+	kad    *Kademlia
+	config RefreshConfig
+	rng    *rand.Rand // picks the node IDs looked up in stale buckets
+}
+
+// NewRefresher returns a refresher for the buckets of kad
+func NewRefresher(log *zap.Logger, kad *Kademlia, config RefreshConfig) *Refresher {
+	return &Refresher{
+		log:    log,
+		kad:    kad,
+		config: config,
+		rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Run refreshes stale buckets every interval until ctx is canceled
+func (refresher *Refresher) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if refresher.config.Interval <= 0 {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	// stagger the refreshes of nodes started at the same time
+	stagger := time.NewTimer(time.Duration(rand.Int63n(int64(refresher.config.Interval))))
+	select {
+	case <-stagger.C:
+	case <-ctx.Done():
+		stagger.Stop()
+		return ctx.Err()
+	}
+
+	ticker := time.NewTicker(refresher.config.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := refresher.refresh(ctx, time.Now()); err != nil {
+			refresher.log.Warn("bucket refresh failed", zap.Error(err))
+		}
+		if _, err := refresher.replaceUnresponsive(ctx); err != nil {
+			refresher.log.Warn("replacing unresponsive nodes failed", zap.Error(err))
+		}
+		if refresher.config.Republish > 0 {
+			if err := refresher.kad.republish(ctx, time.Now(), refresher.config.Republish); err != nil {
+				refresher.log.Warn("republishing records failed", zap.Error(err))
+			}
+		}
+		if err := refresher.kad.routingTable.reportHealth(time.Now()); err != nil {
+			refresher.log.Warn("reporting routing table health failed", zap.Error(err))
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// refresh looks up a random node ID in the range of each bucket without a
+// lookup since the staleness and returns the number of refreshed buckets
+func (refresher *Refresher) refresh(ctx context.Context, now time.Time) (refreshed int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rt := refresher.kad.routingTable
+	bIDs, err := rt.GetBucketIds()
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	// buckets are sorted and each covers the IDs (previous bucket ID..bucket ID]
+	startID := bucketID{}
+	var errors errs.Group
+	for _, bID := range bIDs {
+		endID := keyToBucketID(bID)
+		ts, tErr := rt.GetBucketTimestamp(bID)
+		if tErr != nil {
+			errors.Add(tErr)
+		} else if now.After(ts.Add(refresher.config.Staleness)) {
+			rID, rErr := randomIDInRange(refresher.rng, startID, endID)
+			if rErr != nil {
+				errors.Add(rErr)
+			} else {
+				_, _ = refresher.kad.FindNode(ctx, rID) // ignore node not found
+				refreshed++
+			}
+		}
+		startID = endID
+	}
+
+	mon.IntVal("refreshed_buckets").Observe(int64(refreshed))
+	return refreshed, Error.Wrap(errors.Err())
+}
+
+// RefreshAll refreshes every bucket, stale or not, and returns the number of
+// refreshed buckets
+func (refresher *Refresher) RefreshAll(ctx context.Context) (refreshed int, err error) {
+	// every bucket had its last lookup before now
+	return refresher.refresh(ctx, time.Now().Add(refresher.config.Staleness))
+}
+
+// replaceUnresponsive pings the least recently seen node of every bucket with
+// replacements waiting, and replaces the nodes which don't answer with the
+// most recently seen replacement. It returns the number of replaced nodes.
+func (refresher *Refresher) replaceUnresponsive(ctx context.Context) (replaced int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rt := refresher.kad.routingTable
+	candidates, err := rt.evictionCandidates()
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	var errors errs.Group
+	for _, node := range candidates {
+		if _, err := refresher.kad.Ping(ctx, *node); err == nil {
+			continue
+		}
+		if ctx.Err() != nil {
+			return replaced, ctx.Err()
+		}
+		if err := rt.ConnectionFailed(node); err != nil {
+			errors.Add(err)
+			continue
+		}
+		replaced++
+	}
+
+	mon.IntVal("replaced_nodes").Observe(int64(replaced))
+	return replaced, Error.Wrap(errors.Err())
+}
@@ -4,11 +4,18 @@
 package kademlia
 
 import (
+	"sort"
+	"time"
+
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
 )
 
+// addToReplacementCache remembers the node as the most recently seen
+// replacement of the bucket, dropping the least recently seen one when the
+// cache is full
 func (rt *RoutingTable) addToReplacementCache(kadBucketID bucketID, node *pb.Node) {
-	nodes := rt.replacementCache[kadBucketID]
+	nodes := removeReplacement(rt.replacementCache[kadBucketID], node.Id)
 	nodes = append(nodes, node)
 	if len(nodes) > rt.rcBucketSize {
 		copy(nodes, nodes[1:])
@@ -16,3 +23,73 @@ func (rt *RoutingTable) addToReplacementCache(kadBucketID bucketID, node *pb.Nod
 	}
 	rt.replacementCache[kadBucketID] = nodes
 }
+
+// removeFromReplacementCache forgets the node as a replacement of the bucket
+func (rt *RoutingTable) removeFromReplacementCache(kadBucketID bucketID, id storj.NodeID) {
+	nodes := removeReplacement(rt.replacementCache[kadBucketID], id)
+	if len(nodes) == 0 {
+		delete(rt.replacementCache, kadBucketID)
+		return
+	}
+	rt.replacementCache[kadBucketID] = nodes
+}
+
+// removeReplacement removes the node with id from nodes
+func removeReplacement(nodes []*pb.Node, id storj.NodeID) []*pb.Node {
+	for i, node := range nodes {
+		if node.Id == id {
+			return append(nodes[:i:i], nodes[i+1:]...)
+		}
+	}
+	return nodes
+}
+
+// replacements returns a copy of the replacement cache of a bucket
+func (rt *RoutingTable) replacements(kadBucketID bucketID) []*pb.Node {
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+	return append([]*pb.Node(nil), rt.replacementCache[kadBucketID]...)
+}
+
+// evictionCandidates returns the least recently seen node of every bucket
+// with replacements waiting for room. They are pinged and replaced when they
+// don't answer.
+func (rt *RoutingTable) evictionCandidates() ([]*pb.Node, error) {
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+
+	var bIDs []bucketID
+	for bID, replacements := range rt.replacementCache {
+		if len(replacements) > 0 {
+			bIDs = append(bIDs, bID)
+		}
+	}
+	sort.Slice(bIDs, func(i, k int) bool {
+		return string(bIDs[i][:]) < string(bIDs[k][:])
+	})
+
+	var candidates []*pb.Node
+	for _, bID := range bIDs {
+		nodes, err := rt.getUnmarshaledNodesFromBucket(bID)
+		if err != nil {
+			return nil, err
+		}
+
+		var oldest *pb.Node
+		var oldestSeen time.Time
+		for _, node := range nodes {
+			if node.Id == rt.selfID {
+				continue
+			}
+			// nodes not seen since the start count as the least recent
+			seen := rt.lastSeen[node.Id]
+			if oldest == nil || seen.Before(oldestSeen) {
+				oldest, oldestSeen = node, seen
+			}
+		}
+		if oldest != nil {
+			candidates = append(candidates, oldest)
+		}
+	}
+	return candidates, nil
+}
@@ -32,4 +32,11 @@ func TestAddToReplacementCache(t *testing.T) {
 	assert.Equal(t, []*pb.Node{node2, node3}, rt.replacementCache[kadBucketID2])
 	rt.addToReplacementCache(kadBucketID2, node4)
 	assert.Equal(t, []*pb.Node{node3, node4}, rt.replacementCache[kadBucketID2])
+
+	// nodes seen again become the most recently seen replacement
+	rt.addToReplacementCache(kadBucketID2, node3)
+	assert.Equal(t, []*pb.Node{node4, node3}, rt.replacementCache[kadBucketID2])
+
+	rt.removeFromReplacementCache(kadBucketID2, node4.Id)
+	assert.Equal(t, []*pb.Node{node3}, rt.replacementCache[kadBucketID2])
 }
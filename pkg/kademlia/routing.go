@@ -8,11 +8,15 @@ import (
 	"encoding/binary"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/node"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/utils"
@@ -24,6 +28,13 @@ const (
 	KademliaBucket = "kbuckets"
 	// NodeBucket is the string representing the bucket used for the kademlia routing table node ids
 	NodeBucket = "nodes"
+
+	// defaultBucketSize is the maximum number of nodes in a bucket unless
+	// configured otherwise
+	defaultBucketSize = 20
+	// defaultReplacementCacheSize is the number of replacements kept per
+	// bucket unless configured otherwise
+	defaultReplacementCacheSize = 5
 )
 
 // RoutingErr is the class for all errors pertaining to routing table operations
@@ -48,17 +59,24 @@ var emptyBucketID = bucketID{}
 
 // RoutingTable implements the RoutingTable interface
 type RoutingTable struct {
+	churn            uint64 // changes whenever nodes are added or removed, accessed atomically
 	log              *zap.Logger
-	self             pb.Node
+	self             pb.Node      // guarded by mutex, it's updated with the address and restrictions
+	selfID           storj.NodeID // the ID of self, which never changes
 	kadBucketDB      storage.KeyValueStore
 	nodeBucketDB     storage.KeyValueStore
 	transport        *pb.NodeTransport
 	mutex            *sync.Mutex
 	seen             map[storj.NodeID]*pb.Node
 	replacementCache map[bucketID][]*pb.Node
-	bucketSize       int // max number of nodes stored in a kbucket = 20 (k)
-	rcBucketSize     int // replacementCache bucket max length
-
+	bucketSize       int                        // max number of nodes stored in a kbucket = 20 (k)
+	rcBucketSize     int                        // replacementCache bucket max length
+	restored         []*pb.Node                 // nodes loaded from a previous run, not yet verified
+	latencies        map[storj.NodeID]*Latency  // ping round trips of nodes since they last failed
+	lastSeen         map[storj.NodeID]time.Time // last successful contact of nodes since they last failed
+	bucketChurn      map[bucketID]uint64        // number of nodes added to or removed from each bucket
+	identity         *identity.FullIdentity     // signs self, when set
+	minDifficulty    uint16                     // nodes with fewer trailing zero bits in their ID aren't added
 }
 
 // NewRoutingTable returns a newly configured instance of a RoutingTable
@@ -68,6 +86,7 @@ func NewRoutingTable(logger *zap.Logger, localNode pb.Node, kdb, ndb storage.Key
 	rt := &RoutingTable{
 		log:          logger,
 		self:         localNode,
+		selfID:       localNode.Id,
 		kadBucketDB:  kdb,
 		nodeBucketDB: ndb,
 		transport:    &defaultTransport,
@@ -75,10 +94,23 @@ func NewRoutingTable(logger *zap.Logger, localNode pb.Node, kdb, ndb storage.Key
 		mutex:            &sync.Mutex{},
 		seen:             make(map[storj.NodeID]*pb.Node),
 		replacementCache: make(map[bucketID][]*pb.Node),
+		latencies:        make(map[storj.NodeID]*Latency),
+		lastSeen:         make(map[storj.NodeID]time.Time),
+		bucketChurn:      make(map[bucketID]uint64),
 
-		bucketSize:   *flagBucketSize,
-		rcBucketSize: *flagReplacementCacheSize,
+		bucketSize:   defaultBucketSize,
+		rcBucketSize: defaultReplacementCacheSize,
+	}
+
+	restored, err := rt.loadNodes()
+	if err != nil {
+		return nil, RoutingErr.New("could not load nodes of previous run: %s", err)
 	}
+	rt.restored = restored
+	if len(restored) > 0 {
+		logger.Sugar().Infof("restored %d nodes of previous run", len(restored))
+	}
+
 	ok, err := rt.addNode(&localNode)
 	if !ok || err != nil {
 		return nil, RoutingErr.New("could not add localNode to routing table: %s", err)
@@ -86,6 +118,36 @@ func NewRoutingTable(logger *zap.Logger, localNode pb.Node, kdb, ndb storage.Key
 	return rt, nil
 }
 
+// loadNodes returns the nodes other than self which are persisted in the
+// node bucket db, e.g. by a previous run
+func (rt *RoutingTable) loadNodes() ([]*pb.Node, error) {
+	var nodes []*pb.Node
+	err := rt.iterate(storage.IterateOptions{Recurse: true}, func(it storage.Iterator) error {
+		var item storage.ListItem
+		for it.Next(&item) {
+			node := &pb.Node{}
+			if err := proto.Unmarshal(item.Value, node); err != nil {
+				return err
+			}
+			if node.Id != rt.selfID {
+				nodes = append(nodes, node)
+			}
+		}
+		return nil
+	})
+	return nodes, err
+}
+
+// takeRestored returns the nodes loaded from a previous run which haven't
+// been verified yet and forgets them
+func (rt *RoutingTable) takeRestored() []*pb.Node {
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+	restored := rt.restored
+	rt.restored = nil
+	return restored
+}
+
 // SelfClose close without closing dependencies
 // TODO: rename to Close and remove Close
 func (rt *RoutingTable) SelfClose() error {
@@ -107,6 +169,10 @@ func (rt *RoutingTable) Local() pb.Node {
 	return rt.self
 }
 
+// Churn returns a counter which changes whenever nodes are added to or removed
+// from the routing table
+func (rt *RoutingTable) Churn() uint64 { return atomic.LoadUint64(&rt.churn) }
+
 // K returns the currently configured maximum of nodes to store in a bucket
 func (rt *RoutingTable) K() int {
 	return rt.bucketSize
@@ -169,19 +235,38 @@ func (rt *RoutingTable) FindNear(id storj.NodeID, limit int) (nodes []*pb.Node,
 	return nodes, nil
 }
 
+// SignSelf signs the local node with ident, now and whenever it's updated,
+// since other nodes only accept signed node records
+func (rt *RoutingTable) SignSelf(ident *identity.FullIdentity) error {
+	rt.mutex.Lock()
+	rt.identity = ident
+	self := rt.self
+	rt.mutex.Unlock()
+
+	return rt.UpdateSelf(&self)
+}
+
 // UpdateSelf updates a node on the routing table
-func (rt *RoutingTable) UpdateSelf(node *pb.Node) error {
+func (rt *RoutingTable) UpdateSelf(self *pb.Node) error {
 	// TODO: replace UpdateSelf with UpdateRestrictions and UpdateAddress
 	rt.mutex.Lock()
-	if node.Id != rt.self.Id {
+	if self.Id != rt.selfID {
 		rt.mutex.Unlock()
 		return RoutingErr.New("self does not have a matching node id")
 	}
-	rt.self = *node
-	rt.seen[node.Id] = node
+	if rt.identity != nil {
+		signed := *self
+		if err := node.Sign(rt.identity, &signed, time.Now()); err != nil {
+			rt.mutex.Unlock()
+			return RoutingErr.Wrap(err)
+		}
+		self = &signed
+	}
+	rt.self = *self
+	rt.seen[self.Id] = self
 	rt.mutex.Unlock()
 
-	if err := rt.updateNode(node); err != nil {
+	if err := rt.updateNode(self); err != nil {
 		return RoutingErr.New("could not update node %s", err)
 	}
 
@@ -198,8 +283,17 @@ func (rt *RoutingTable) ConnectionSuccess(node *pb.Node) error {
 
 	node.Type.DPanicOnInvalid("connection success")
 
+	if node.Id != rt.selfID {
+		if err := node.Id.VerifyDifficulty(rt.minDifficulty); err != nil {
+			zap.L().Debug("ignoring node", zap.Error(err))
+			mon.Counter("low_difficulty_nodes_dropped").Inc(1)
+			return nil
+		}
+	}
+
 	rt.mutex.Lock()
 	rt.seen[node.Id] = node
+	rt.lastSeen[node.Id] = time.Now()
 	rt.mutex.Unlock()
 	v, err := rt.nodeBucketDB.Get(storage.Key(node.Id.Bytes()))
 	if err != nil && !storage.ErrKeyNotFound.Has(err) {
@@ -219,10 +313,29 @@ func (rt *RoutingTable) ConnectionSuccess(node *pb.Node) error {
 	return nil
 }
 
+// SetBucketSize sets the maximum number of nodes in a bucket (k) and the
+// number of nodes kept in the replacement cache of every bucket.
+// Must be called before the routing table is used.
+func (rt *RoutingTable) SetBucketSize(size, replacementCacheSize int) {
+	rt.bucketSize = size
+	rt.rcBucketSize = replacementCacheSize
+}
+
+// SetMinimumDifficulty makes the routing table ignore nodes whose ID has
+// fewer than minimum trailing zero bits, 0 accepts all nodes.
+// Must be called before the routing table is used.
+func (rt *RoutingTable) SetMinimumDifficulty(minimum uint16) {
+	rt.minDifficulty = minimum
+}
+
 // ConnectionFailed removes a node from the routing table when
 // a connection fails for the node on the network
 func (rt *RoutingTable) ConnectionFailed(node *pb.Node) error {
 	node.Type.DPanicOnInvalid("connection failed")
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+	delete(rt.latencies, node.Id)
+	delete(rt.lastSeen, node.Id)
 	err := rt.removeNode(node.Id)
 	if err != nil {
 		return RoutingErr.New("could not remove node %s", err)
@@ -6,6 +6,7 @@ package kademlia
 import (
 	"bytes"
 	"encoding/binary"
+	"sync/atomic"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -22,7 +23,7 @@ func (rt *RoutingTable) addNode(node *pb.Node) (bool, error) {
 	rt.mutex.Lock()
 	defer rt.mutex.Unlock()
 
-	if node.Id == rt.self.Id {
+	if node.Id == rt.selfID {
 		err := rt.createOrUpdateKBucket(firstBucketID, time.Now())
 		if err != nil {
 			return false, RoutingErr.New("could not create initial K bucket: %s", err)
@@ -83,6 +84,13 @@ func (rt *RoutingTable) addNode(node *pb.Node) (bool, error) {
 	if err != nil {
 		return false, RoutingErr.New("could not add node to nodeBucketDB: %s", err)
 	}
+	// the node might wait in the replacement cache of a bucket which was
+	// split since
+	for bID := range rt.replacementCache {
+		rt.removeFromReplacementCache(bID, node.Id)
+	}
+	atomic.AddUint64(&rt.churn, 1)
+	rt.bucketChurn[kadBucketID]++
 	err = rt.createOrUpdateKBucket(kadBucketID, time.Now())
 	if err != nil {
 		return false, RoutingErr.New("could not create or update K bucket: %s", err)
@@ -99,12 +107,13 @@ func (rt *RoutingTable) updateNode(node *pb.Node) error {
 	return nil
 }
 
-// removeNode will remove churned nodes and replace those entries with nodes from the replacement cache.
+// removeNode will remove churned nodes and replace those entries with the most recently seen nodes from the replacement cache.
 func (rt *RoutingTable) removeNode(nodeID storj.NodeID) error {
 	kadBucketID, err := rt.getKBucketID(nodeID)
 	if err != nil {
 		return RoutingErr.New("could not get k bucket %s", err)
 	}
+	rt.removeFromReplacementCache(kadBucketID, nodeID)
 	_, err = rt.nodeBucketDB.Get(nodeID.Bytes())
 	if storage.ErrKeyNotFound.Has(err) {
 		return nil
@@ -115,15 +124,24 @@ func (rt *RoutingTable) removeNode(nodeID storj.NodeID) error {
 	if err != nil {
 		return RoutingErr.New("could not delete node %s", err)
 	}
+	atomic.AddUint64(&rt.churn, 1)
+	rt.bucketChurn[kadBucketID]++
 	nodes := rt.replacementCache[kadBucketID]
-	if len(nodes) == 0 {
-		return nil
-	}
-	err = rt.putNode(nodes[len(nodes)-1])
-	if err != nil {
-		return err
+	for len(nodes) > 0 {
+		replacement := nodes[len(nodes)-1]
+		nodes = nodes[:len(nodes)-1]
+		rt.replacementCache[kadBucketID] = nodes
+
+		// replacements might have been added to the table in the meantime
+		v, err := rt.nodeBucketDB.Get(replacement.Id.Bytes())
+		if err != nil && !storage.ErrKeyNotFound.Has(err) {
+			return RoutingErr.New("could not get node %s", err)
+		}
+		if v == nil {
+			rt.bucketChurn[kadBucketID]++
+			return rt.putNode(replacement)
+		}
 	}
-	rt.replacementCache[kadBucketID] = nodes[:len(nodes)-1]
 	return nil
 }
 
@@ -177,7 +195,7 @@ func (rt *RoutingTable) getKBucketID(nodeID storj.NodeID) (bucketID, error) {
 // determineFurthestIDWithinK: helper, determines the furthest node within the k closest to local node
 func (rt *RoutingTable) determineFurthestIDWithinK(nodeIDs storj.NodeIDList) storj.NodeID {
 	nodeIDs = cloneNodeIDs(nodeIDs)
-	sortByXOR(nodeIDs, rt.self.Id)
+	sortByXOR(nodeIDs, rt.selfID)
 	if len(nodeIDs) < rt.bucketSize+1 { //adding 1 since we're not including local node in closest k
 		return nodeIDs[len(nodeIDs)-1]
 	}
@@ -200,14 +218,14 @@ func (rt *RoutingTable) nodeIsWithinNearestK(nodeID storj.NodeID) (bool, error)
 	}
 
 	furthestIDWithinK := rt.determineFurthestIDWithinK(nodeIDs)
-	existingXor := xorNodeID(furthestIDWithinK, rt.self.Id)
-	newXor := xorNodeID(nodeID, rt.self.Id)
+	existingXor := xorNodeID(furthestIDWithinK, rt.selfID)
+	newXor := xorNodeID(nodeID, rt.selfID)
 	return newXor.Less(existingXor), nil
 }
 
 // kadBucketContainsLocalNode returns true if the kbucket in question contains the local node
 func (rt *RoutingTable) kadBucketContainsLocalNode(queryID bucketID) (bool, error) {
-	bID, err := rt.getKBucketID(rt.self.Id)
+	bID, err := rt.getKBucketID(rt.selfID)
 	if err != nil {
 		return false, err
 	}
@@ -26,6 +26,7 @@ import (
 func newTestRoutingTable(localNode pb.Node) (*RoutingTable, error) {
 	rt := &RoutingTable{
 		self:         localNode,
+		selfID:       localNode.Id,
 		kadBucketDB:  storelogger.New(zap.L().Named("rt.kad"), teststore.New()),
 		nodeBucketDB: storelogger.New(zap.L().Named("rt.node"), teststore.New()),
 		transport:    &defaultTransport,
@@ -33,6 +34,9 @@ func newTestRoutingTable(localNode pb.Node) (*RoutingTable, error) {
 		mutex:            &sync.Mutex{},
 		seen:             make(map[storj.NodeID]*pb.Node),
 		replacementCache: make(map[bucketID][]*pb.Node),
+		latencies:        make(map[storj.NodeID]*Latency),
+		lastSeen:         make(map[storj.NodeID]time.Time),
+		bucketChurn:      make(map[bucketID]uint64),
 
 		bucketSize:   6,
 		rcBucketSize: 2,
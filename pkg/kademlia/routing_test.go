@@ -161,6 +161,30 @@ func TestConnectionSuccess(t *testing.T) {
 	}
 }
 
+func TestConnectionSuccessDifficulty(t *testing.T) {
+	id := storj.NodeID{0xAA, 31: 0xFF} // difficulty 0
+	rt, cleanup := createRoutingTable(t, id)
+	defer cleanup()
+	rt.SetMinimumDifficulty(8)
+
+	easy := &pb.Node{Id: storj.NodeID{0xBB, 31: 0x80}, Type: pb.NodeType_STORAGE}
+	hard := &pb.Node{Id: storj.NodeID{0xCC, 30: 0x01}, Type: pb.NodeType_STORAGE}
+
+	assert.NoError(t, rt.ConnectionSuccess(easy))
+	_, err := rt.nodeBucketDB.Get(easy.Id.Bytes())
+	assert.True(t, storage.ErrKeyNotFound.Has(err), "nodes below the minimum difficulty are ignored")
+
+	assert.NoError(t, rt.ConnectionSuccess(hard))
+	_, err = rt.nodeBucketDB.Get(hard.Id.Bytes())
+	assert.NoError(t, err)
+
+	// self is kept regardless of its difficulty
+	self := rt.Local()
+	assert.NoError(t, rt.ConnectionSuccess(&self))
+	_, err = rt.nodeBucketDB.Get(id.Bytes())
+	assert.NoError(t, err)
+}
+
 func TestUpdateSelf(t *testing.T) {
 	id := teststorj.NodeIDFromString("AA")
 	rt, cleanup := createRoutingTable(t, id)
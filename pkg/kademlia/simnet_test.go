@@ -0,0 +1,554 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kademlia
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/storage/teststore"
+)
+
+// simNetwork is an in-memory network of kademlia nodes, which pass messages
+// over channels instead of sockets. It makes routing, lookups and evictions
+// testable with thousands of nodes.
+type simNetwork struct {
+	t     testing.TB
+	rand  *rand.Rand
+	alpha int
+
+	mu      sync.Mutex
+	peers   map[storj.NodeID]*simPeer
+	order   []*simPeer
+	failed  map[storj.NodeID]map[storj.NodeID]bool // dials which failed, by the dialing node
+	queries int
+	pings   int
+}
+
+// simPeer is a node of the simulated network, serving the messages of its
+// inbox
+type simPeer struct {
+	network  *simNetwork
+	kademlia *Kademlia
+	inbox    chan simMessage
+	offline  bool // protected by network.mu
+}
+
+// simMessage is a query, or a ping when query is nil, sent to a peer
+type simMessage struct {
+	ctx   context.Context
+	query *pb.QueryRequest
+	reply chan []*pb.Node
+}
+
+// errSimOffline is returned when dialing offline or unknown simulated nodes
+var errSimOffline = errs.Class("simulated node offline")
+
+func newSimNetwork(t testing.TB, seed int64, alpha int) *simNetwork {
+	return &simNetwork{
+		t:      t,
+		rand:   rand.New(rand.NewSource(seed)),
+		alpha:  alpha,
+		peers:  make(map[storj.NodeID]*simPeer),
+		failed: make(map[storj.NodeID]map[storj.NodeID]bool),
+	}
+}
+
+// add adds a node with a random id to the network, bootstrapping against
+// the first node added
+func (network *simNetwork) add() *simPeer {
+	var id [32]byte
+	_, _ = network.rand.Read(id[:])
+	self := pb.Node{
+		Id:      teststorj.NodeIDFromBytes(id[:]),
+		Type:    pb.NodeType_STORAGE,
+		Address: &pb.NodeAddress{Address: "sim"},
+	}
+
+	rt, err := NewRoutingTable(zap.NewNop(), self, teststore.New(), teststore.New())
+	if err != nil {
+		network.t.Fatal(err)
+	}
+
+	k := &Kademlia{
+		log:          zap.NewNop(),
+		alpha:        network.alpha,
+		routingTable: rt,
+		observers:    &observers{},
+		bootstrapped: make(chan struct{}),
+		records:      newRecordStore(),
+	}
+	peer := &simPeer{network: network, kademlia: k, inbox: make(chan simMessage)}
+	k.dialer = &simDialer{peer: peer}
+
+	network.mu.Lock()
+	if len(network.order) > 0 {
+		k.bootstrapNodes = []pb.Node{network.order[0].kademlia.routingTable.Local()}
+	} else {
+		k.bootstrapNodes = []pb.Node{self}
+	}
+	network.peers[self.Id] = peer
+	network.order = append(network.order, peer)
+	network.mu.Unlock()
+
+	go peer.serve()
+	return peer
+}
+
+// refresh refreshes every bucket of every node once, like the refresher
+// does for buckets which went stale
+func (network *simNetwork) refresh(ctx context.Context) {
+	for _, peer := range network.order {
+		refresher := NewRefresher(zap.NewNop(), peer.kademlia, RefreshConfig{})
+		refresher.rng = rand.New(rand.NewSource(network.rand.Int63()))
+		if _, err := refresher.refresh(ctx, time.Now()); err != nil {
+			network.t.Fatal(err)
+		}
+	}
+}
+
+// grow adds n nodes to the network, bootstrapping them one after another
+func (network *simNetwork) grow(ctx context.Context, n int) {
+	for i := 0; i < n; i++ {
+		if err := network.add().kademlia.Bootstrap(ctx); err != nil {
+			network.t.Fatal(err)
+		}
+	}
+}
+
+// close stops serving the messages of all nodes
+func (network *simNetwork) close() {
+	network.mu.Lock()
+	defer network.mu.Unlock()
+	for _, peer := range network.order {
+		close(peer.inbox)
+	}
+	network.order, network.peers = nil, nil
+}
+
+// setOffline makes dialing peer fail, as if it left the network
+func (network *simNetwork) setOffline(peer *simPeer, offline bool) {
+	network.mu.Lock()
+	defer network.mu.Unlock()
+	peer.offline = offline
+}
+
+// closest returns the ids of the n online nodes closest to target
+func (network *simNetwork) closest(target storj.NodeID, n int) storj.NodeIDList {
+	network.mu.Lock()
+	defer network.mu.Unlock()
+
+	var ids storj.NodeIDList
+	for _, peer := range network.order {
+		if !peer.offline {
+			ids = append(ids, peer.id())
+		}
+	}
+	sort.Slice(ids, func(i, k int) bool { return xorCloser(target, ids[i], ids[k]) })
+	if len(ids) > n {
+		ids = ids[:n]
+	}
+	return ids
+}
+
+// dial returns the online peer with id, after recording the dial
+func (network *simNetwork) dial(from storj.NodeID, id storj.NodeID, query bool) (*simPeer, error) {
+	network.mu.Lock()
+	defer network.mu.Unlock()
+
+	if query {
+		network.queries++
+	} else {
+		network.pings++
+	}
+
+	peer, ok := network.peers[id]
+	if !ok || peer.offline {
+		if network.failed[from] == nil {
+			network.failed[from] = make(map[storj.NodeID]bool)
+		}
+		network.failed[from][id] = true
+		return nil, errSimOffline.New("%s", id)
+	}
+	return peer, nil
+}
+
+// knows returns whether id is in the routing table of peer
+func (peer *simPeer) knows(id storj.NodeID) bool {
+	_, err := peer.kademlia.routingTable.nodeBucketDB.Get(id.Bytes())
+	return err == nil
+}
+
+// tableSize returns the number of nodes in the routing table of peer
+func (peer *simPeer) tableSize() int {
+	keys, err := peer.kademlia.routingTable.nodeBucketDB.List(nil, 0)
+	if err != nil {
+		peer.network.t.Fatal(err)
+	}
+	return len(keys)
+}
+
+// id returns the node id of peer
+func (peer *simPeer) id() storj.NodeID { return peer.kademlia.routingTable.self.Id }
+
+// online returns the peers which aren't offline
+func (network *simNetwork) online() []*simPeer {
+	network.mu.Lock()
+	defer network.mu.Unlock()
+
+	var peers []*simPeer
+	for _, peer := range network.order {
+		if !peer.offline {
+			peers = append(peers, peer)
+		}
+	}
+	return peers
+}
+
+// stats returns the number of queries and pings sent since the last call
+func (network *simNetwork) stats() (queries, pings int) {
+	network.mu.Lock()
+	defer network.mu.Unlock()
+
+	queries, pings = network.queries, network.pings
+	network.queries, network.pings = 0, 0
+	return queries, pings
+}
+
+// serve answers the messages of the inbox concurrently, like a grpc server
+func (peer *simPeer) serve() {
+	for message := range peer.inbox {
+		go peer.handle(message)
+	}
+}
+
+// handle answers a message like the node server does, except that senders
+// aren't required to sign their records
+func (peer *simPeer) handle(message simMessage) {
+	if message.query == nil {
+		message.reply <- nil
+		return
+	}
+
+	rt := peer.kademlia.routingTable
+	if sender := message.query.GetSender(); message.query.GetPingback() && sender != nil {
+		if _, err := peer.kademlia.Ping(message.ctx, *sender); err != nil {
+			_ = rt.ConnectionFailed(sender)
+		} else {
+			_ = rt.ConnectionSuccess(sender)
+		}
+	}
+
+	nodes, err := rt.FindNear(message.query.GetTarget().Id, int(message.query.GetLimit()))
+	if err != nil {
+		peer.network.t.Error(err)
+	}
+	message.reply <- nodes
+}
+
+// simDialer sends the lookups and pings of a peer over the simulated
+// network, telling its observers about the outcome like the transport does
+type simDialer struct {
+	peer *simPeer
+}
+
+func (dialer *simDialer) observers() []transport.Observer {
+	return []transport.Observer{dialer.peer.kademlia.routingTable, dialer.peer.kademlia.observers}
+}
+
+func (dialer *simDialer) send(ctx context.Context, target pb.Node, query *pb.QueryRequest) ([]*pb.Node, error) {
+	peer, err := dialer.peer.network.dial(dialer.peer.id(), target.Id, query != nil)
+	if err != nil {
+		alertFail(ctx, dialer.observers(), &target, err)
+		return nil, err
+	}
+	alertSuccess(ctx, dialer.observers(), &target)
+
+	message := simMessage{ctx: ctx, query: query, reply: make(chan []*pb.Node, 1)}
+	select {
+	case peer.inbox <- message:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	select {
+	case nodes := <-message.reply:
+		return nodes, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (dialer *simDialer) Lookup(ctx context.Context, self pb.Node, ask pb.Node, find pb.Node) ([]*pb.Node, error) {
+	return dialer.send(ctx, ask, &pb.QueryRequest{Limit: 20, Sender: &self, Target: &find, Pingback: true})
+}
+
+func (dialer *simDialer) PingObserved(ctx context.Context, target pb.Node) (time.Duration, string, error) {
+	_, err := dialer.send(ctx, target, nil)
+	return time.Millisecond, "", err
+}
+
+// Store and FindValue are answered right away, as storing records doesn't
+// change routing tables
+func (dialer *simDialer) Store(ctx context.Context, target pb.Node, record *pb.Record) error {
+	peer, err := dialer.peer.network.dial(dialer.peer.id(), target.Id, true)
+	if err != nil {
+		alertFail(ctx, dialer.observers(), &target, err)
+		return err
+	}
+	alertSuccess(ctx, dialer.observers(), &target)
+	return peer.kademlia.records.Put(record)
+}
+
+func (dialer *simDialer) FindValue(ctx context.Context, target pb.Node, key storj.NodeID) (*pb.Record, error) {
+	peer, err := dialer.peer.network.dial(dialer.peer.id(), target.Id, true)
+	if err != nil {
+		alertFail(ctx, dialer.observers(), &target, err)
+		return nil, err
+	}
+	alertSuccess(ctx, dialer.observers(), &target)
+	record, _ := peer.kademlia.records.Get(key)
+	return record, nil
+}
+
+func (dialer *simDialer) Close() error { return nil }
+
+// xorCloser returns whether a is closer to target than b
+func xorCloser(target, a, b storj.NodeID) bool {
+	for i := range target {
+		da, db := a[i]^target[i], b[i]^target[i]
+		if da != db {
+			return da < db
+		}
+	}
+	return false
+}
+
+// alertFail and alertSuccess tell observers about dials like the transport
+func alertFail(ctx context.Context, obs []transport.Observer, node *pb.Node, err error) {
+	for _, o := range obs {
+		o.ConnFailure(ctx, node, err)
+	}
+}
+
+func alertSuccess(ctx context.Context, obs []transport.Observer, node *pb.Node) {
+	for _, o := range obs {
+		o.ConnSuccess(ctx, node)
+	}
+}
+
+// simAlpha is the lookup concurrency of simulated nodes. Querying one node at
+// a time makes every run of a simulation with the same seed identical.
+const simAlpha = 1
+
+func TestSimulatedNetwork(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	network := newSimNetwork(t, 1, simAlpha)
+	defer network.close()
+	network.grow(ctx, 1000)
+	network.refresh(ctx)
+
+	for _, peer := range network.order {
+		// every node knows its nearest neighbours
+		for _, id := range network.closest(peer.id(), 4)[1:] {
+			assert.True(t, peer.knows(id))
+		}
+	}
+
+	{ // lookups converge on the target from every node
+		const lookups = 500
+		_, _ = network.stats()
+		for i := 0; i < lookups; i++ {
+			from := network.order[network.rand.Intn(len(network.order))]
+			target := network.order[network.rand.Intn(len(network.order))]
+			if from == target {
+				continue
+			}
+			node, err := from.kademlia.FindNode(ctx, target.id())
+			require.NoError(t, err)
+			assert.Equal(t, target.id(), node.Id)
+		}
+		queries, pings := network.stats()
+		t.Logf("%d queries and %d pings for %d lookups", queries, pings, lookups)
+		assert.True(t, queries < 10*lookups, "lookups should take a few hops")
+	}
+}
+
+func TestSimulatedEviction(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	network := newSimNetwork(t, 2, simAlpha)
+	defer network.close()
+	network.grow(ctx, 1000)
+	network.refresh(ctx)
+
+	sizes := make(map[*simPeer]int)
+	for _, peer := range network.order {
+		sizes[peer] = peer.tableSize()
+	}
+
+	// a tenth of the nodes leave
+	for _, i := range network.rand.Perm(len(network.order))[:len(network.order)/10] {
+		network.setOffline(network.order[i], true)
+	}
+
+	online := network.online()
+	for i := 0; i < 2000; i++ {
+		from := online[network.rand.Intn(len(online))]
+		target := online[network.rand.Intn(len(online))]
+		if from == target {
+			continue
+		}
+		node, err := from.kademlia.FindNode(ctx, target.id())
+		require.NoError(t, err)
+		assert.Equal(t, target.id(), node.Id)
+	}
+
+	require.NotEmpty(t, network.failed)
+	for from, ids := range network.failed {
+		peer := network.peers[from]
+		for id := range ids {
+			assert.False(t, peer.knows(id), "nodes which didn't answer are evicted")
+		}
+	}
+
+	// evicted nodes are replaced by the ones waiting in the replacement
+	// cache, so the tables shrink by less than the number of evictions
+	var before, after, evicted int
+	for _, peer := range online {
+		before += sizes[peer]
+		after += peer.tableSize()
+		evicted += len(network.failed[peer.id()])
+	}
+	t.Logf("%d nodes known before, %d after evicting %d", before, after, evicted)
+	assert.True(t, after > before-evicted, "evicted nodes are replaced")
+}
+
+func TestSimulatedReplacement(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	network := newSimNetwork(t, 4, simAlpha)
+	defer network.close()
+	network.grow(ctx, 500)
+	network.refresh(ctx)
+
+	// a tenth of the nodes leave
+	for _, i := range network.rand.Perm(len(network.order))[:len(network.order)/10] {
+		network.setOffline(network.order[i], true)
+	}
+
+	var replaced int
+	for _, peer := range network.online() {
+		candidates, err := peer.kademlia.routingTable.evictionCandidates()
+		require.NoError(t, err)
+
+		size := peer.tableSize()
+		refresher := NewRefresher(zap.NewNop(), peer.kademlia, RefreshConfig{})
+		n, err := refresher.replaceUnresponsive(ctx)
+		require.NoError(t, err)
+		replaced += n
+
+		var offline int
+		for _, node := range candidates {
+			if network.failed[peer.id()][node.Id] {
+				offline++
+				assert.False(t, peer.knows(node.Id), "nodes which didn't answer are evicted")
+			}
+		}
+		assert.Equal(t, offline, n)
+		assert.Equal(t, size, peer.tableSize(), "evicted nodes are replaced")
+	}
+	assert.NotZero(t, replaced)
+}
+
+func TestSimulatedNetworkDeterministic(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	tables := func() (tables [][]storj.NodeID) {
+		network := newSimNetwork(t, 3, simAlpha)
+		defer network.close()
+		network.grow(ctx, 200)
+		network.refresh(ctx)
+		for _, peer := range network.order {
+			nodes, err := peer.kademlia.routingTable.FindNear(storj.NodeID{}, 1000)
+			require.NoError(t, err)
+			var ids []storj.NodeID
+			for _, node := range nodes {
+				ids = append(ids, node.Id)
+			}
+			tables = append(tables, ids)
+		}
+		return tables
+	}
+
+	assert.Equal(t, tables(), tables())
+}
+
+func TestSimulatedRecords(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	network := newSimNetwork(t, 4, simAlpha)
+	defer network.close()
+	network.grow(ctx, 300)
+	network.refresh(ctx)
+
+	publisher := network.order[network.rand.Intn(len(network.order))]
+	ident, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+	publisher.kademlia.identity = ident
+
+	key := teststorj.NodeIDFromString("contact hint")
+	require.NoError(t, publisher.kademlia.StoreValue(ctx, key, []byte("hint"), time.Hour))
+
+	closest := network.closest(key, publisher.kademlia.routingTable.K())
+	for _, id := range closest {
+		if id != publisher.id() {
+			_, ok := network.peers[id].kademlia.records.Get(key)
+			assert.True(t, ok, "the nodes closest to the key store the record")
+		}
+	}
+
+	find := func() {
+		for i := 0; i < 20; i++ {
+			peer := network.online()[network.rand.Intn(len(network.online()))]
+			record, err := peer.kademlia.FindValue(ctx, key)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("hint"), record.Value)
+			assert.Equal(t, ident.ID, record.Publisher)
+		}
+	}
+	find()
+
+	// the nodes storing the record leave, new ones get it when it's republished
+	for _, id := range closest {
+		if id != publisher.id() {
+			network.setOffline(network.peers[id], true)
+		}
+	}
+	require.NoError(t, publisher.kademlia.republish(ctx, time.Now(), 0))
+	find()
+
+	_, err = network.order[0].kademlia.FindValue(ctx, teststorj.NodeIDFromString("missing"))
+	assert.True(t, RecordNotFound.Has(err))
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package lease
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Coordinator runs singleton jobs in one replica of a satellite at a time.
+// Replicas share the leases of the jobs through the database, the replica
+// holding the lease of a job runs it and renews the lease, the others stand
+// by until it expires. A nil Coordinator runs the jobs right away.
+type Coordinator struct {
+	log    *zap.Logger
+	db     DB
+	config Config
+
+	// standby is called when a job stops or starts waiting for its lease
+	standby func(name string, standby bool)
+}
+
+// NewCoordinator creates a coordinator of the singleton jobs of the replica
+// named by config.Holder
+func NewCoordinator(log *zap.Logger, db DB, config Config) *Coordinator {
+	return &Coordinator{
+		log:     log,
+		db:      db,
+		config:  config,
+		standby: func(string, bool) {},
+	}
+}
+
+// SetStandby sets the function told when a job stops or starts waiting for
+// its lease, so that the watchdog doesn't report the jobs of other replicas
+// as stalled
+func (coordinator *Coordinator) SetStandby(standby func(name string, standby bool)) {
+	coordinator.standby = standby
+}
+
+// Run runs fn whenever the replica holds the lease of the job name, until ctx
+// is canceled or fn fails. When the lease can't be renewed, fn's context is
+// canceled and the replica stands by until it takes the lease again.
+func (coordinator *Coordinator) Run(ctx context.Context, name string, fn func(ctx context.Context) error) (err error) {
+	if coordinator == nil {
+		return fn(ctx)
+	}
+	defer mon.Task()(&ctx)(&err)
+
+	if coordinator.config.Duration <= 0 {
+		return Error.New("lease duration must be positive")
+	}
+
+	// renewing three times per lease survives a failed renewal
+	ticker := time.NewTicker(coordinator.config.Duration / 3)
+	defer ticker.Stop()
+
+	coordinator.standby(name, true)
+	for {
+		held, err := coordinator.acquire(ctx, name)
+		if err != nil {
+			coordinator.log.Warn("acquiring lease failed", zap.String("job", name), zap.Error(err))
+		}
+		if held {
+			if err := coordinator.lead(ctx, name, fn, ticker.C); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// lead runs fn while the lease of the job name is renewed on every tick. It
+// returns nil when the lease is lost, and the error of fn when it returns on
+// its own.
+func (coordinator *Coordinator) lead(ctx context.Context, name string, fn func(ctx context.Context) error, tick <-chan time.Time) error {
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- fn(jobCtx) }()
+
+	coordinator.log.Info("leading job", zap.String("job", name))
+	coordinator.standby(name, false)
+	mon.Counter("leases_acquired").Inc(1)
+
+	expires := time.Now().Add(coordinator.config.Duration)
+	for {
+		select {
+		case err := <-done:
+			// hand the job over right away instead of when the lease expires
+			if rerr := coordinator.db.Release(context.Background(), name, coordinator.config.Holder); rerr != nil {
+				coordinator.log.Warn("releasing lease failed", zap.String("job", name), zap.Error(rerr))
+			}
+			coordinator.standby(name, true)
+			return err
+
+		case now := <-tick:
+			held, err := coordinator.acquire(ctx, name)
+			if err == nil && held {
+				expires = now.Add(coordinator.config.Duration)
+				continue
+			}
+			// the lease is held until it expires, while renewals may fail
+			if err != nil && now.Add(coordinator.config.Duration/3).Before(expires) {
+				coordinator.log.Warn("renewing lease failed", zap.String("job", name), zap.Error(err))
+				continue
+			}
+
+			coordinator.log.Warn("lost lease, stopping job", zap.String("job", name), zap.Error(err))
+			mon.Counter("leases_lost").Inc(1)
+			cancel()
+			<-done
+			coordinator.standby(name, true)
+			return nil
+		}
+	}
+}
+
+// acquire takes or renews the lease of the job name
+func (coordinator *Coordinator) acquire(ctx context.Context, name string) (bool, error) {
+	now := time.Now()
+	held, err := coordinator.db.Acquire(ctx, name, coordinator.config.Holder, now, now.Add(coordinator.config.Duration))
+	return held, Error.Wrap(err)
+}
+
+type ctxKey int
+
+const ctxKeyCoordinator ctxKey = iota
+
+// WithCoordinator returns a context carrying the coordinator to the
+// responsibilities started with it
+func WithCoordinator(ctx context.Context, coordinator *Coordinator) context.Context {
+	return context.WithValue(ctx, ctxKeyCoordinator, coordinator)
+}
+
+// LoadFromContext gives access to the coordinator from the context, or
+// returns nil
+func LoadFromContext(ctx context.Context) *Coordinator {
+	if v, ok := ctx.Value(ctxKeyCoordinator).(*Coordinator); ok {
+		return v
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package lease
+
+import (
+	"context"
+	"time"
+)
+
+// Lease is the right of a satellite replica to run a singleton job until it
+// expires
+type Lease struct {
+	Name    string
+	Holder  string
+	Expires time.Time
+}
+
+// DB stores the leases shared by the replicas of a satellite
+type DB interface {
+	// Acquire takes the lease for holder until expires when it's free,
+	// expired by now or already held by holder, and returns whether holder
+	// holds it
+	Acquire(ctx context.Context, name, holder string, now, expires time.Time) (bool, error)
+	// Release gives up the lease when holder holds it
+	Release(ctx context.Context, name, holder string) error
+	// List returns all leases ordered by name
+	List(ctx context.Context) ([]*Lease, error)
+}
+
+// Config contains configurable values for coordinating satellite replicas
+type Config struct {
+	Holder   string        `help:"unique name of this replica holding the leases of singleton jobs, empty runs them without coordinating with other replicas" default:""`
+	Duration time.Duration `help:"how long a lease is held without being renewed, a replica takes over the jobs of one which stopped at most this long after" default:"1m0s"`
+}
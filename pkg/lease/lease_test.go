@@ -0,0 +1,128 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package lease_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/lease"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestLeases(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		leases := db.Leases()
+		now := time.Now()
+
+		held, err := leases.Acquire(ctx, "gc", "a", now, now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.True(t, held)
+
+		// only the holder renews an unexpired lease
+		held, err = leases.Acquire(ctx, "gc", "b", now, now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.False(t, held)
+		held, err = leases.Acquire(ctx, "gc", "a", now, now.Add(2*time.Minute))
+		require.NoError(t, err)
+		assert.True(t, held)
+
+		// an expired lease is taken over
+		later := now.Add(3 * time.Minute)
+		held, err = leases.Acquire(ctx, "gc", "b", later, later.Add(time.Minute))
+		require.NoError(t, err)
+		assert.True(t, held)
+
+		held, err = leases.Acquire(ctx, "tally", "a", now, now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.True(t, held)
+
+		list, err := leases.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Equal(t, "gc", list[0].Name)
+		assert.Equal(t, "b", list[0].Holder)
+		assert.Equal(t, "tally", list[1].Name)
+		assert.Equal(t, "a", list[1].Holder)
+
+		// only the holder releases a lease
+		require.NoError(t, leases.Release(ctx, "gc", "a"))
+		require.NoError(t, leases.Release(ctx, "tally", "a"))
+		list, err = leases.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, "gc", list[0].Name)
+	})
+}
+
+func TestCoordinator(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		config := lease.Config{Duration: 300 * time.Millisecond}
+		config.Holder = "a"
+		a := lease.NewCoordinator(zaptest.NewLogger(t), db.Leases(), config)
+		config.Holder = "b"
+		b := lease.NewCoordinator(zaptest.NewLogger(t), db.Leases(), config)
+
+		standby := make(chan bool, 10)
+		b.SetStandby(func(name string, on bool) { standby <- on })
+
+		running := make(chan string, 10)
+		job := func(holder string) func(ctx context.Context) error {
+			return func(ctx context.Context) error {
+				running <- holder
+				<-ctx.Done()
+				return ctx.Err()
+			}
+		}
+
+		ctxA, cancelA := context.WithCancel(ctx)
+		ctx.Go(func() error {
+			err := a.Run(ctxA, "gc", job("a"))
+			if err != context.Canceled {
+				return err
+			}
+			return nil
+		})
+		assert.Equal(t, "a", <-running)
+
+		ctxB, cancelB := context.WithCancel(ctx)
+		defer cancelB()
+		ctx.Go(func() error {
+			err := b.Run(ctxB, "gc", job("b"))
+			if err != context.Canceled {
+				return err
+			}
+			return nil
+		})
+		assert.True(t, <-standby)
+
+		// the other replica takes over once the leader stops
+		cancelA()
+		assert.Equal(t, "b", <-running)
+		assert.False(t, <-standby)
+		assert.Empty(t, running)
+	})
+}
+
+func TestNilCoordinator(t *testing.T) {
+	var coordinator *lease.Coordinator
+
+	failed := errors.New("failed")
+	err := coordinator.Run(context.Background(), "gc", func(ctx context.Context) error { return failed })
+	assert.Equal(t, failed, err)
+}
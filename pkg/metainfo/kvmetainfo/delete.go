@@ -0,0 +1,117 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kvmetainfo
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"storj.io/storj/internal/sync2"
+	"storj.io/storj/pkg/storj"
+)
+
+const (
+	// deletePageSize is the number of objects DeleteObjects lists at once
+	deletePageSize = 100
+	// deleteConcurrency is the number of objects DeleteObjects deletes at once
+	deleteConcurrency = 8
+)
+
+// DeleteList is the outcome of deleting the objects under a prefix. When the
+// deletion was interrupted More is true, and passing Cursor to DeleteObjects
+// resumes it.
+type DeleteList struct {
+	Bucket string
+	Prefix storj.Path
+	More   bool
+	Cursor storj.Path
+
+	// Deleted paths are relative to Prefix, like Cursor
+	Deleted []storj.Path
+}
+
+// DeleteObjects deletes the objects in bucket under prefix recursively, in
+// listing order after cursor, which is empty unless resuming an interrupted
+// deletion. progress, when not nil, is called for every deleted object, one
+// call at a time.
+//
+// When ctx is canceled or deleting an object fails, DeleteObjects waits for
+// the deletions in flight and returns the objects deleted so far with the
+// cursor to resume from. Objects after the cursor might be deleted already.
+func (db *DB) DeleteObjects(ctx context.Context, bucket string, prefix, cursor storj.Path, progress func(path storj.Path)) (list DeleteList, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	list = DeleteList{Bucket: bucket, Prefix: prefix, More: true, Cursor: cursor}
+	dir := strings.TrimSuffix(prefix, "/")
+
+	var mu sync.Mutex
+	deleted := func(path storj.Path) {
+		mu.Lock()
+		defer mu.Unlock()
+		list.Deleted = append(list.Deleted, path)
+		if progress != nil {
+			progress(path)
+		}
+	}
+
+	for {
+		page, err := db.ListObjects(ctx, bucket, storj.ListOptions{
+			Prefix:    prefix,
+			Cursor:    list.Cursor,
+			Direction: storj.After,
+			Recursive: true,
+			Limit:     deletePageSize,
+		})
+		if err != nil {
+			return list, err
+		}
+
+		errs := make([]error, len(page.Items))
+		limiter := sync2.NewLimiter(deleteConcurrency)
+		for i, item := range page.Items {
+			i, path := i, item.Path
+			fullpath := path
+			if dir != "" {
+				fullpath = storj.JoinPaths(dir, path)
+			}
+
+			started := limiter.Go(ctx, func() {
+				err := db.DeleteObject(ctx, bucket, fullpath)
+				if storj.ErrObjectNotFound.Has(err) {
+					// deleted by someone else meanwhile
+					err = nil
+				} else if err == nil {
+					deleted(path)
+				}
+				errs[i] = err
+			})
+			if !started {
+				for k := i; k < len(errs); k++ {
+					errs[k] = ctx.Err()
+				}
+				break
+			}
+		}
+		limiter.Wait()
+
+		// the cursor only moves past the objects which are all gone, so a
+		// resumed deletion retries the failed ones
+		for i, item := range page.Items {
+			if errs[i] != nil {
+				if ctx.Err() != nil {
+					// report the cancellation rather than how it failed a deletion
+					return list, ctx.Err()
+				}
+				return list, errs[i]
+			}
+			list.Cursor = item.Path
+		}
+
+		if !page.More {
+			list.More, list.Cursor = false, ""
+			return list, nil
+		}
+	}
+}
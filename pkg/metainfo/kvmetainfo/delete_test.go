@@ -0,0 +1,82 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kvmetainfo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/pkg/storj"
+)
+
+func TestDeleteObjects(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		bucket, err := db.CreateBucket(ctx, TestBucket, &storj.Bucket{PathCipher: storj.Unencrypted})
+		require.NoError(t, err)
+
+		var paths []storj.Path
+		for i := 0; i < 20; i++ {
+			paths = append(paths, fmt.Sprintf("%02d", i))
+			upload(ctx, t, db, bucket, "dir/"+paths[i], nil)
+		}
+		upload(ctx, t, db, bucket, "other", nil)
+
+		{ // a canceled deletion returns what it deleted and where to resume
+			canceled, cancel := context.WithCancel(ctx)
+			var returned int32
+			progress := func(path storj.Path) {
+				assert.Equal(t, int32(0), atomic.LoadInt32(&returned), "no deletions after returning")
+				cancel()
+			}
+
+			list, err := db.DeleteObjects(canceled, bucket.Name, "dir/", "", progress)
+			atomic.StoreInt32(&returned, 1)
+			assert.Equal(t, context.Canceled, err)
+			assert.True(t, list.More)
+			assert.NotEmpty(t, list.Deleted)
+			assert.True(t, len(list.Deleted) < len(paths))
+
+			for _, path := range list.Deleted {
+				_, err := db.GetObject(ctx, bucket.Name, "dir/"+path)
+				assert.True(t, storj.ErrObjectNotFound.Has(err))
+			}
+
+			// the remaining objects are all after the cursor
+			remaining, err := db.ListObjects(ctx, bucket.Name, optionsRecursive("dir/", "", storj.After, 0))
+			require.NoError(t, err)
+			for _, object := range remaining.Items {
+				assert.True(t, object.Path > list.Cursor)
+			}
+
+			// resuming deletes the rest
+			resumed, err := db.DeleteObjects(ctx, bucket.Name, "dir/", list.Cursor, nil)
+			require.NoError(t, err)
+			assert.False(t, resumed.More)
+			assert.Empty(t, resumed.Cursor)
+
+			// deletions interrupted by the cancellation might have removed
+			// objects without reporting them, but none is reported twice
+			all := append(list.Deleted, resumed.Deleted...)
+			sort.Strings(all)
+			for i, path := range all {
+				assert.Contains(t, paths, path)
+				if i > 0 {
+					assert.NotEqual(t, all[i-1], path)
+				}
+			}
+		}
+
+		{ // objects outside the prefix are kept
+			remaining, err := db.ListObjects(ctx, bucket.Name, optionsRecursive("", "", storj.After, 0))
+			require.NoError(t, err)
+			assert.Equal(t, []string{"other"}, getObjectPaths(remaining))
+		}
+	})
+}
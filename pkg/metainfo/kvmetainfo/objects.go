@@ -6,6 +6,8 @@ package kvmetainfo
 import (
 	"context"
 	"errors"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -96,6 +98,8 @@ func (db *DB) CreateObject(ctx context.Context, bucket string, path storj.Path,
 		info.Metadata = createInfo.Metadata
 		info.ContentType = createInfo.ContentType
 		info.Expires = createInfo.Expires
+		// the size is the expected one until the object is committed
+		info.Size = createInfo.SizeHint
 		info.RedundancyScheme = createInfo.RedundancyScheme
 		info.EncryptionScheme = createInfo.EncryptionScheme
 	}
@@ -141,13 +145,64 @@ func (db *DB) DeleteObject(ctx context.Context, bucket string, path storj.Path)
 // ModifyPendingObject creates an interface for updating a partially uploaded object
 func (db *DB) ModifyPendingObject(ctx context.Context, bucket string, path storj.Path) (object storj.MutableObject, err error) {
 	defer mon.Task()(&ctx)(&err)
-	return nil, errors.New("not implemented")
+
+	bucketInfo, err := db.GetBucket(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	if path == "" {
+		return nil, storj.ErrNoPath.New("")
+	}
+
+	return &mutableObject{
+		db:      db,
+		info:    storj.Object{Bucket: bucketInfo, Path: path},
+		pending: true,
+	}, nil
 }
 
-// ListPendingObjects lists pending objects in bucket based on the ListOptions
+// ListPendingObjects lists pending objects in bucket based on the ListOptions.
+// Pending objects are always listed recursively and their size is unknown.
 func (db *DB) ListPendingObjects(ctx context.Context, bucket string, options storj.ListOptions) (list storj.ObjectList, err error) {
 	defer mon.Task()(&ctx)(&err)
-	return storj.ObjectList{}, errors.New("not implemented")
+
+	if !options.Recursive {
+		return storj.ObjectList{}, errClass.New("pending objects can only be listed recursively")
+	}
+
+	bucketInfo, err := db.GetBucket(ctx, bucket)
+	if err != nil {
+		return storj.ObjectList{}, err
+	}
+
+	objects, err := db.buckets.GetObjectStore(ctx, bucket)
+	if err != nil {
+		return storj.ObjectList{}, err
+	}
+
+	startAfter, endBefore, err := listMarkers(options)
+	if err != nil {
+		return storj.ObjectList{}, err
+	}
+
+	items, more, err := objects.ListPending(ctx, options.Prefix, startAfter, endBefore, options.Limit)
+	if err != nil {
+		return storj.ObjectList{}, err
+	}
+
+	list = storj.ObjectList{
+		Bucket: bucket,
+		Prefix: options.Prefix,
+		More:   more,
+		Items:  make([]storj.Object, 0, len(items)),
+	}
+
+	for _, item := range items {
+		list.Items = append(list.Items, objectFromMeta(bucketInfo, item.Path, false, item.Meta))
+	}
+
+	return list, nil
 }
 
 // ListObjects lists objects in bucket based on the ListOptions
@@ -164,7 +219,33 @@ func (db *DB) ListObjects(ctx context.Context, bucket string, options storj.List
 		return storj.ObjectList{}, err
 	}
 
-	var startAfter, endBefore string
+	startAfter, endBefore, err := listMarkers(options)
+	if err != nil {
+		return storj.ObjectList{}, err
+	}
+
+	items, more, err := objects.List(ctx, options.Prefix, startAfter, endBefore, options.Recursive, options.Limit, meta.All)
+	if err != nil {
+		return storj.ObjectList{}, err
+	}
+
+	list = storj.ObjectList{
+		Bucket: bucket,
+		Prefix: options.Prefix,
+		More:   more,
+		Items:  make([]storj.Object, 0, len(items)),
+	}
+
+	for _, item := range items {
+		list.Items = append(list.Items, objectFromMeta(bucketInfo, item.Path, item.IsPrefix, item.Meta))
+	}
+
+	return list, nil
+}
+
+// listMarkers converts the cursor and direction of options to the markers
+// of a store listing
+func listMarkers(options storj.ListOptions) (startAfter, endBefore string, err error) {
 	switch options.Direction {
 	case storj.Before:
 		// before lists backwards from cursor, without cursor
@@ -179,7 +260,7 @@ func (db *DB) ListObjects(ctx context.Context, bucket string, options storj.List
 		// after lists forwards from cursor, without cursor
 		startAfter = options.Cursor
 	default:
-		return storj.ObjectList{}, errClass.New("invalid direction %d", options.Direction)
+		return "", "", errClass.New("invalid direction %d", options.Direction)
 	}
 
 	// TODO: remove this hack-fix of specifying the last key
@@ -187,23 +268,7 @@ func (db *DB) ListObjects(ctx context.Context, bucket string, options storj.List
 		endBefore = "\x7f\x7f\x7f\x7f\x7f\x7f\x7f"
 	}
 
-	items, more, err := objects.List(ctx, options.Prefix, startAfter, endBefore, options.Recursive, options.Limit, meta.All)
-	if err != nil {
-		return storj.ObjectList{}, err
-	}
-
-	list = storj.ObjectList{
-		Bucket: bucket,
-		Prefix: options.Prefix,
-		More:   more,
-		Items:  make([]storj.Object, 0, len(items)),
-	}
-
-	for _, item := range items {
-		list.Items = append(list.Items, objectFromMeta(bucketInfo, item.Path, item.IsPrefix, item.Meta))
-	}
-
-	return list, nil
+	return startAfter, endBefore, nil
 }
 
 type object struct {
@@ -233,7 +298,8 @@ func (db *DB) getInfo(ctx context.Context, prefix string, bucket string, path st
 		return object{}, storj.Object{}, err
 	}
 
-	pointer, _, _, err := db.pointers.Get(ctx, prefix+encryptedPath)
+	// only the metadata is needed, not the pieces to download the object
+	pointer, err := db.pointers.ObjectMeta(ctx, prefix+encryptedPath)
 	if err != nil {
 		if storage.ErrKeyNotFound.Has(err) {
 			err = storj.ErrObjectNotFound.Wrap(err)
@@ -241,10 +307,8 @@ func (db *DB) getInfo(ctx context.Context, prefix string, bucket string, path st
 		return object{}, storj.Object{}, err
 	}
 
-	var redundancyScheme *pb.RedundancyScheme
-	if pointer.GetType() == pb.Pointer_REMOTE {
-		redundancyScheme = pointer.GetRemote().GetRedundancy()
-	} else {
+	redundancyScheme := pointer.GetRedundancy()
+	if redundancyScheme == nil {
 		// TODO: handle better
 		redundancyScheme = &pb.RedundancyScheme{
 			Type:             pb.RedundancyScheme_RS,
@@ -381,6 +445,8 @@ func convertTime(ts *timestamp.Timestamp) time.Time {
 type mutableObject struct {
 	db   *DB
 	info storj.Object
+	// pending is set for partially uploaded objects
+	pending bool
 }
 
 func (object *mutableObject) Info() storj.Object { return object.info }
@@ -397,7 +463,16 @@ func (object *mutableObject) ContinueStream(ctx context.Context) (storj.MutableS
 }
 
 func (object *mutableObject) DeleteStream(ctx context.Context) error {
-	return errors.New("not implemented")
+	if !object.pending {
+		return errors.New("not implemented")
+	}
+
+	store, err := object.db.buckets.GetObjectStore(ctx, object.info.Bucket.Name)
+	if err != nil {
+		return err
+	}
+
+	return store.DeletePending(ctx, object.info.Path)
 }
 
 func (object *mutableObject) Commit(ctx context.Context) error {
@@ -405,3 +480,58 @@ func (object *mutableObject) Commit(ctx context.Context) error {
 	object.info = info
 	return err
 }
+
+// PrefixUsage aggregates the sizes of the objects below prefix and of its
+// directories on the satellite, without listing the objects
+func (db *DB) PrefixUsage(ctx context.Context, bucket string, prefix storj.Path) (usage storj.PrefixUsage, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	bucketInfo, err := db.GetBucket(ctx, bucket)
+	if err != nil {
+		return storj.PrefixUsage{}, err
+	}
+
+	encPrefix := bucket
+	if prefix = strings.TrimSuffix(prefix, "/"); prefix != "" {
+		encPrefix, err = streams.EncryptAfterBucket(storj.JoinPaths(bucket, prefix), bucketInfo.PathCipher, db.rootKey)
+		if err != nil {
+			return storj.PrefixUsage{}, err
+		}
+	}
+
+	res, err := db.pointers.PrefixUsage(ctx, encPrefix+"/", storage.LookupLimit)
+	if err != nil {
+		return storj.PrefixUsage{}, err
+	}
+
+	usage = storj.PrefixUsage{
+		Bucket: bucket,
+		Prefix: prefix,
+		Total:  objectUsageFromProto(res.GetTotal()),
+		More:   res.GetMore(),
+	}
+	for _, dir := range res.GetDirectories() {
+		decrypted, err := streams.DecryptAfterBucket(storj.JoinPaths(encPrefix, strings.TrimSuffix(dir.GetPath(), "/")), bucketInfo.PathCipher, db.rootKey)
+		if err != nil {
+			return storj.PrefixUsage{}, err
+		}
+		comps := storj.SplitPath(decrypted)
+		usage.Directories = append(usage.Directories, storj.DirectoryUsage{
+			Path:  comps[len(comps)-1] + "/",
+			Usage: objectUsageFromProto(dir.GetUsage()),
+		})
+	}
+	sort.Slice(usage.Directories, func(i, k int) bool {
+		return usage.Directories[i].Path < usage.Directories[k].Path
+	})
+	return usage, nil
+}
+
+func objectUsageFromProto(usage *pb.ObjectUsage) storj.ObjectUsage {
+	return storj.ObjectUsage{
+		Objects:       usage.GetObjects(),
+		Segments:      usage.GetSegments(),
+		EncryptedSize: usage.GetEncryptedSize(),
+		PlainSize:     usage.GetPlainSize(),
+	}
+}
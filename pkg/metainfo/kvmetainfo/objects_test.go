@@ -4,6 +4,7 @@
 package kvmetainfo
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"fmt"
@@ -14,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"storj.io/storj/internal/memory"
+	"storj.io/storj/pkg/storage/streams"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/stream"
 )
@@ -655,6 +657,131 @@ func TestListObjects(t *testing.T) {
 	})
 }
 
+func TestListPendingObjects(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		bucket, err := db.CreateBucket(ctx, TestBucket, &storj.Bucket{PathCipher: storj.Unencrypted})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		upload(ctx, t, db, bucket, "committed", []byte("data"))
+		for _, path := range []storj.Path{"a/pending", "b/pending", "pending"} {
+			uploadFirstSegment(ctx, t, db, bucket, path, []byte("data"))
+		}
+
+		_, err = db.ListPendingObjects(ctx, bucket.Name, options("", "", storj.After, 0))
+		assert.EqualError(t, err, "kvmetainfo: pending objects can only be listed recursively")
+
+		list, err := db.ListPendingObjects(ctx, bucket.Name, optionsRecursive("", "", storj.After, 0))
+		if assert.NoError(t, err) {
+			assert.False(t, list.More)
+			assert.Equal(t, []string{"a/pending", "b/pending", "pending"}, getObjectPaths(list))
+		}
+
+		list, err = db.ListPendingObjects(ctx, bucket.Name, optionsRecursive("", "a/pending", storj.After, 1))
+		if assert.NoError(t, err) {
+			assert.True(t, list.More)
+			assert.Equal(t, []string{"b/pending"}, getObjectPaths(list))
+		}
+
+		list, err = db.ListPendingObjects(ctx, bucket.Name, optionsRecursive("a/", "", storj.After, 0))
+		if assert.NoError(t, err) {
+			assert.False(t, list.More)
+			assert.Equal(t, []string{"pending"}, getObjectPaths(list))
+		}
+
+		// committed objects aren't pending
+		object, err := db.ModifyPendingObject(ctx, bucket.Name, "committed")
+		if assert.NoError(t, err) {
+			assert.Error(t, object.DeleteStream(ctx))
+		}
+
+		object, err = db.ModifyPendingObject(ctx, bucket.Name, "non-existing-file")
+		if assert.NoError(t, err) {
+			assert.True(t, storj.ErrObjectNotFound.Has(object.DeleteStream(ctx)))
+		}
+
+		object, err = db.ModifyPendingObject(ctx, bucket.Name, "a/pending")
+		if assert.NoError(t, err) {
+			assert.NoError(t, object.DeleteStream(ctx))
+		}
+
+		list, err = db.ListPendingObjects(ctx, bucket.Name, optionsRecursive("", "", storj.After, 0))
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"b/pending", "pending"}, getObjectPaths(list))
+		}
+
+		_, err = db.GetObject(ctx, bucket.Name, "committed")
+		assert.NoError(t, err)
+	})
+}
+
+func TestPrefixUsage(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		bucket, err := db.CreateBucket(ctx, TestBucket, &storj.Bucket{PathCipher: storj.AESGCM})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		for _, path := range []storj.Path{"a/x", "a/b/y", "b/z", "top"} {
+			upload(ctx, t, db, bucket, path, []byte("data"))
+		}
+		uploadFirstSegment(ctx, t, db, bucket, "c/pending", []byte("data"))
+
+		// objects smaller than an encryption block aren't padded, AES-GCM
+		// adds its 16 byte tag
+		objects := func(n int64) storj.ObjectUsage {
+			return storj.ObjectUsage{Objects: n, Segments: n, EncryptedSize: n * (4 + 16), PlainSize: n * 4}
+		}
+
+		usage, err := db.PrefixUsage(ctx, bucket.Name, "")
+		if assert.NoError(t, err) {
+			assert.Equal(t, objects(4), usage.Total)
+			assert.Equal(t, []storj.DirectoryUsage{
+				{Path: "a/", Usage: objects(2)},
+				{Path: "b/", Usage: objects(1)},
+			}, usage.Directories)
+			assert.False(t, usage.More)
+		}
+
+		usage, err = db.PrefixUsage(ctx, bucket.Name, "a/")
+		if assert.NoError(t, err) {
+			assert.Equal(t, objects(2), usage.Total)
+			assert.Equal(t, []storj.DirectoryUsage{{Path: "b/", Usage: objects(1)}}, usage.Directories)
+		}
+
+		usage, err = db.PrefixUsage(ctx, bucket.Name, "missing/")
+		if assert.NoError(t, err) {
+			assert.Equal(t, storj.ObjectUsage{}, usage.Total)
+			assert.Empty(t, usage.Directories)
+		}
+	})
+}
+
+// uploadFirstSegment stores the first segment of an object without
+// committing it, as an interrupted upload leaves it behind
+func uploadFirstSegment(ctx context.Context, t *testing.T, db *DB, bucket storj.Bucket, path storj.Path, data []byte) {
+	encPath, err := streams.EncryptAfterBucket(storj.JoinPaths(bucket.Name, path), bucket.PathCipher, db.rootKey)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = db.segments.Put(ctx, bytes.NewReader(data), int64(len(data)), time.Time{}, func() (storj.Path, []byte, error) {
+		return storj.JoinPaths("s0", encPath), nil, nil
+	})
+	assert.NoError(t, err)
+}
+
+func getObjectPaths(list storj.ObjectList) []string {
+	names := make([]string, len(list.Items))
+
+	for i, item := range list.Items {
+		names[i] = item.Path
+	}
+
+	return names
+}
+
 func options(prefix, cursor string, direction storj.ListDirection, limit int) storj.ListOptions {
 	return storj.ListOptions{
 		Prefix:    prefix,
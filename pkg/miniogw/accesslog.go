@@ -0,0 +1,492 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package miniogw
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	minio "github.com/minio/minio/cmd"
+	miniologger "github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/hash"
+	"go.uber.org/zap"
+
+	"storj.io/storj/internal/memory"
+)
+
+// AccessLogConfig determines where gateway access logs are written
+type AccessLogConfig struct {
+	Path     string        `help:"file to append S3 server access log records to, empty disables the file" default:""`
+	Bucket   string        `help:"bucket to deliver access log objects into, empty disables delivery" default:""`
+	Prefix   string        `help:"key prefix of the delivered access log objects" default:"logs/"`
+	Interval time.Duration `help:"how frequently buffered access logs are delivered into the bucket" default:"1h0m0s"`
+	Buffer   memory.Size   `help:"the most access logs buffered for delivery, the oldest entries are dropped while delivery fails" default:"16M"`
+}
+
+// Enabled returns whether access logs should be recorded at all
+func (config AccessLogConfig) Enabled() bool {
+	return config.Path != "" || config.Bucket != ""
+}
+
+// AccessLogEntry is a single request as recorded in the access log
+type AccessLogEntry struct {
+	Time       time.Time
+	RemoteIP   string
+	Requester  string
+	RequestID  string
+	Operation  string
+	Bucket     string
+	Key        string
+	RequestURI string
+	Status     int
+	ErrorCode  string
+	BytesSent  int64
+	ObjectSize int64
+	TotalTime  time.Duration
+	UserAgent  string
+}
+
+// String formats the entry in the S3 server access log format
+func (entry *AccessLogEntry) String() string {
+	millis := strconv.FormatInt(int64(entry.TotalTime/time.Millisecond), 10)
+	fields := []string{
+		accessLogField(entry.Requester),
+		accessLogField(entry.Bucket),
+		entry.Time.UTC().Format("[02/Jan/2006:15:04:05 -0700]"),
+		accessLogField(entry.RemoteIP),
+		accessLogField(entry.Requester),
+		accessLogField(entry.RequestID),
+		accessLogField(entry.Operation),
+		accessLogField(entry.Key),
+		accessLogQuoted(entry.RequestURI),
+		strconv.Itoa(entry.Status),
+		accessLogField(entry.ErrorCode),
+		accessLogSize(entry.BytesSent),
+		accessLogSize(entry.ObjectSize),
+		millis,
+		millis,
+		accessLogQuoted(""),
+		accessLogQuoted(entry.UserAgent),
+		"-",
+	}
+	return strings.Join(fields, " ")
+}
+
+func accessLogField(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return strings.Replace(value, " ", "%20", -1)
+}
+
+func accessLogQuoted(value string) string {
+	if value == "" {
+		return `"-"`
+	}
+	return strconv.Quote(value)
+}
+
+func accessLogSize(size int64) string {
+	if size <= 0 {
+		return "-"
+	}
+	return strconv.FormatInt(size, 10)
+}
+
+// AccessLogger writes access log entries and buffers them for delivery into a bucket
+type AccessLogger struct {
+	log    *zap.Logger
+	out    io.Writer
+	buffer int
+
+	mu      sync.Mutex
+	pending bytes.Buffer
+}
+
+// NewAccessLogger creates an access logger writing to out, which may be nil.
+// When buffer is positive, up to buffer bytes of the latest entries are also
+// kept until the next Flush.
+func NewAccessLogger(log *zap.Logger, out io.Writer, buffer int) *AccessLogger {
+	return &AccessLogger{log: log, out: out, buffer: buffer}
+}
+
+// Record adds an entry to the access log
+func (logger *AccessLogger) Record(entry *AccessLogEntry) {
+	line := entry.String() + "\n"
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+
+	if logger.out != nil {
+		if _, err := io.WriteString(logger.out, line); err != nil {
+			logger.log.Error("unable to write access log", zap.Error(err))
+		}
+	}
+	if logger.buffer > 0 {
+		_, _ = logger.pending.WriteString(line)
+		logger.dropOldest()
+	}
+}
+
+// dropOldest drops the oldest entries until the pending entries fit into the
+// buffer, logger.mu must be held
+func (logger *AccessLogger) dropOldest() {
+	var dropped int64
+	for logger.pending.Len() > logger.buffer {
+		end := bytes.IndexByte(logger.pending.Bytes(), '\n')
+		if end < 0 {
+			end = logger.pending.Len() - 1
+		}
+		_ = logger.pending.Next(end + 1)
+		dropped++
+	}
+	if dropped > 0 {
+		mon.Counter("access_log_entries_dropped").Inc(dropped)
+	}
+}
+
+// Flush uploads the buffered entries as a new object into bucket. Entries
+// are kept for the next attempt when the upload fails, as far as they fit
+// into the buffer along with the entries recorded meanwhile.
+func (logger *AccessLogger) Flush(ctx context.Context, layer minio.ObjectLayer, bucket, prefix string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	logger.mu.Lock()
+	data := append([]byte(nil), logger.pending.Bytes()...)
+	logger.pending.Reset()
+	logger.mu.Unlock()
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	defer func() {
+		if err != nil {
+			logger.mu.Lock()
+			rest := append(data, logger.pending.Bytes()...)
+			logger.pending.Reset()
+			_, _ = logger.pending.Write(rest)
+			logger.dropOldest()
+			logger.mu.Unlock()
+		}
+	}()
+
+	reader, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", "")
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	key := prefix + time.Now().UTC().Format("2006-01-02-15-04-05") + "-" + newRequestID()
+	_, err = layer.PutObject(ctx, bucket, key, reader, map[string]string{"content-type": "text/plain"})
+	return err
+}
+
+// run delivers the buffered entries every interval until the context is canceled
+func (logger *AccessLogger) run(ctx context.Context, layer minio.ObjectLayer, config AccessLogConfig) {
+	ticker := time.NewTicker(config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		if err := logger.Flush(ctx, layer, config.Bucket, config.Prefix); err != nil {
+			logger.log.Error("unable to deliver access logs", zap.String("bucket", config.Bucket), zap.Error(err))
+		}
+	}
+}
+
+type gatewayAccessLogging struct {
+	minio.Gateway
+	ctx    context.Context
+	logger *AccessLogger
+	config AccessLogConfig
+}
+
+// AccessLogging returns a wrapper of minio.Gateway that records every request
+// into the access log. Delivery into config.Bucket runs until ctx is canceled.
+func AccessLogging(ctx context.Context, gateway minio.Gateway, logger *AccessLogger, config AccessLogConfig) minio.Gateway {
+	return &gatewayAccessLogging{Gateway: gateway, ctx: ctx, logger: logger, config: config}
+}
+
+func (gw *gatewayAccessLogging) NewGatewayLayer(creds auth.Credentials) (minio.ObjectLayer, error) {
+	layer, err := gw.Gateway.NewGatewayLayer(creds)
+	if err != nil {
+		return nil, err
+	}
+	if gw.config.Bucket != "" && gw.config.Interval > 0 {
+		// delivery goes directly to the wrapped layer, so it isn't logged itself
+		go gw.logger.run(gw.ctx, layer, gw.config)
+	}
+	return &layerAccessLogging{ObjectLayer: layer, logger: gw.logger, requester: creds.AccessKey}, nil
+}
+
+// accessLogOperation describes how an ObjectLayer call is reported in the access log
+type accessLogOperation struct {
+	name   string
+	method string
+	status int
+}
+
+var (
+	opListBuckets    = accessLogOperation{"REST.GET.SERVICE", "GET", 200}
+	opMakeBucket     = accessLogOperation{"REST.PUT.BUCKET", "PUT", 200}
+	opGetBucketInfo  = accessLogOperation{"REST.HEAD.BUCKET", "HEAD", 200}
+	opDeleteBucket   = accessLogOperation{"REST.DELETE.BUCKET", "DELETE", 204}
+	opListObjects    = accessLogOperation{"REST.GET.BUCKET", "GET", 200}
+	opGetObject      = accessLogOperation{"REST.GET.OBJECT", "GET", 200}
+	opGetObjectInfo  = accessLogOperation{"REST.HEAD.OBJECT", "HEAD", 200}
+	opPutObject      = accessLogOperation{"REST.PUT.OBJECT", "PUT", 200}
+	opCopyObject     = accessLogOperation{"REST.COPY.OBJECT", "PUT", 200}
+	opDeleteObject   = accessLogOperation{"REST.DELETE.OBJECT", "DELETE", 204}
+	opListUploads    = accessLogOperation{"REST.GET.UPLOADS", "GET", 200}
+	opNewUpload      = accessLogOperation{"REST.POST.UPLOADS", "POST", 200}
+	opPutPart        = accessLogOperation{"REST.PUT.PART", "PUT", 200}
+	opCopyPart       = accessLogOperation{"REST.COPY.PART", "PUT", 200}
+	opListParts      = accessLogOperation{"REST.GET.UPLOAD", "GET", 200}
+	opAbortUpload    = accessLogOperation{"REST.DELETE.UPLOAD", "DELETE", 204}
+	opCompleteUpload = accessLogOperation{"REST.POST.UPLOAD", "POST", 200}
+)
+
+type layerAccessLogging struct {
+	minio.ObjectLayer
+	logger    *AccessLogger
+	requester string
+}
+
+// record adds the finished request to the access log
+func (layer *layerAccessLogging) record(ctx context.Context, op accessLogOperation, bucket, key string, start time.Time, sent, size int64, err error) {
+	entry := &AccessLogEntry{
+		Time:       start,
+		Requester:  layer.requester,
+		Operation:  op.name,
+		Bucket:     bucket,
+		Key:        key,
+		Status:     op.status,
+		BytesSent:  sent,
+		ObjectSize: size,
+		TotalTime:  time.Since(start),
+	}
+
+	if info := miniologger.GetReqInfo(ctx); info != nil {
+		entry.RemoteIP = info.RemoteHost
+		if host, _, err := net.SplitHostPort(info.RemoteHost); err == nil {
+			entry.RemoteIP = host
+		}
+		entry.RequestID = info.RequestID
+		entry.UserAgent = info.UserAgent
+	}
+	if entry.RequestID == "" {
+		entry.RequestID = newRequestID()
+	}
+
+	path := "/"
+	if bucket != "" {
+		path += bucket
+		if key != "" {
+			path += "/" + key
+		}
+	}
+	entry.RequestURI = op.method + " " + path + " HTTP/1.1"
+
+	if err != nil {
+		entry.Status, entry.ErrorCode = accessLogError(err)
+	}
+	layer.logger.Record(entry)
+}
+
+// accessLogError maps an ObjectLayer error to the S3 status and error code
+func accessLogError(err error) (status int, code string) {
+	switch err.(type) {
+	case minio.BucketNotFound:
+		return 404, "NoSuchBucket"
+	case minio.ObjectNotFound:
+		return 404, "NoSuchKey"
+	case minio.InvalidUploadID:
+		return 404, "NoSuchUpload"
+	case minio.BucketAlreadyExists, minio.BucketExists:
+		return 409, "BucketAlreadyExists"
+	case minio.BucketAlreadyOwnedByYou:
+		return 409, "BucketAlreadyOwnedByYou"
+	case minio.BucketNotEmpty:
+		return 409, "BucketNotEmpty"
+	case minio.BucketNameInvalid:
+		return 400, "InvalidBucketName"
+	case minio.ObjectNameInvalid:
+		return 400, "InvalidObjectName"
+	case minio.InvalidPart:
+		return 400, "InvalidPart"
+	case minio.InvalidRange:
+		return 416, "InvalidRange"
+	case minio.PrefixAccessDenied:
+		return 403, "AccessDenied"
+	case minio.NotImplemented:
+		return 501, "NotImplemented"
+	}
+	return 500, "InternalError"
+}
+
+// newRequestID returns a random id in the style of S3 request ids
+func newRequestID() string {
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return fmt.Sprintf("%016X", time.Now().UnixNano())
+	}
+	return strings.ToUpper(hex.EncodeToString(id[:]))
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	io.Writer
+	n int64
+}
+
+func (writer *countingWriter) Write(p []byte) (int, error) {
+	n, err := writer.Writer.Write(p)
+	writer.n += int64(n)
+	return n, err
+}
+
+func (layer *layerAccessLogging) ListBuckets(ctx context.Context) (buckets []minio.BucketInfo, err error) {
+	start := time.Now()
+	buckets, err = layer.ObjectLayer.ListBuckets(ctx)
+	layer.record(ctx, opListBuckets, "", "", start, 0, 0, err)
+	return buckets, err
+}
+
+func (layer *layerAccessLogging) MakeBucketWithLocation(ctx context.Context, bucket string, location string) (err error) {
+	start := time.Now()
+	err = layer.ObjectLayer.MakeBucketWithLocation(ctx, bucket, location)
+	layer.record(ctx, opMakeBucket, bucket, "", start, 0, 0, err)
+	return err
+}
+
+func (layer *layerAccessLogging) GetBucketInfo(ctx context.Context, bucket string) (bucketInfo minio.BucketInfo, err error) {
+	start := time.Now()
+	bucketInfo, err = layer.ObjectLayer.GetBucketInfo(ctx, bucket)
+	layer.record(ctx, opGetBucketInfo, bucket, "", start, 0, 0, err)
+	return bucketInfo, err
+}
+
+func (layer *layerAccessLogging) DeleteBucket(ctx context.Context, bucket string) (err error) {
+	start := time.Now()
+	err = layer.ObjectLayer.DeleteBucket(ctx, bucket)
+	layer.record(ctx, opDeleteBucket, bucket, "", start, 0, 0, err)
+	return err
+}
+
+func (layer *layerAccessLogging) ListObjects(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (result minio.ListObjectsInfo, err error) {
+	start := time.Now()
+	result, err = layer.ObjectLayer.ListObjects(ctx, bucket, prefix, marker, delimiter, maxKeys)
+	layer.record(ctx, opListObjects, bucket, "", start, 0, 0, err)
+	return result, err
+}
+
+func (layer *layerAccessLogging) ListObjectsV2(ctx context.Context, bucket, prefix, continuationToken, delimiter string, maxKeys int, fetchOwner bool, startAfter string) (result minio.ListObjectsV2Info, err error) {
+	start := time.Now()
+	result, err = layer.ObjectLayer.ListObjectsV2(ctx, bucket, prefix, continuationToken, delimiter, maxKeys, fetchOwner, startAfter)
+	layer.record(ctx, opListObjects, bucket, "", start, 0, 0, err)
+	return result, err
+}
+
+func (layer *layerAccessLogging) GetObject(ctx context.Context, bucket, object string, startOffset int64, length int64, writer io.Writer, etag string) (err error) {
+	start := time.Now()
+	counter := &countingWriter{Writer: writer}
+	err = layer.ObjectLayer.GetObject(ctx, bucket, object, startOffset, length, counter, etag)
+	size := length
+	if size <= 0 {
+		size = startOffset + counter.n
+	}
+	layer.record(ctx, opGetObject, bucket, object, start, counter.n, size, err)
+	return err
+}
+
+func (layer *layerAccessLogging) GetObjectInfo(ctx context.Context, bucket, object string) (objInfo minio.ObjectInfo, err error) {
+	start := time.Now()
+	objInfo, err = layer.ObjectLayer.GetObjectInfo(ctx, bucket, object)
+	layer.record(ctx, opGetObjectInfo, bucket, object, start, 0, objInfo.Size, err)
+	return objInfo, err
+}
+
+func (layer *layerAccessLogging) PutObject(ctx context.Context, bucket, object string, data *hash.Reader, metadata map[string]string) (objInfo minio.ObjectInfo, err error) {
+	start := time.Now()
+	objInfo, err = layer.ObjectLayer.PutObject(ctx, bucket, object, data, metadata)
+	layer.record(ctx, opPutObject, bucket, object, start, 0, data.Size(), err)
+	return objInfo, err
+}
+
+func (layer *layerAccessLogging) CopyObject(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, srcInfo minio.ObjectInfo) (objInfo minio.ObjectInfo, err error) {
+	start := time.Now()
+	objInfo, err = layer.ObjectLayer.CopyObject(ctx, srcBucket, srcObject, destBucket, destObject, srcInfo)
+	layer.record(ctx, opCopyObject, destBucket, destObject, start, 0, srcInfo.Size, err)
+	return objInfo, err
+}
+
+func (layer *layerAccessLogging) DeleteObject(ctx context.Context, bucket, object string) (err error) {
+	start := time.Now()
+	err = layer.ObjectLayer.DeleteObject(ctx, bucket, object)
+	layer.record(ctx, opDeleteObject, bucket, object, start, 0, 0, err)
+	return err
+}
+
+func (layer *layerAccessLogging) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result minio.ListMultipartsInfo, err error) {
+	start := time.Now()
+	result, err = layer.ObjectLayer.ListMultipartUploads(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads)
+	layer.record(ctx, opListUploads, bucket, "", start, 0, 0, err)
+	return result, err
+}
+
+func (layer *layerAccessLogging) NewMultipartUpload(ctx context.Context, bucket, object string, metadata map[string]string) (uploadID string, err error) {
+	start := time.Now()
+	uploadID, err = layer.ObjectLayer.NewMultipartUpload(ctx, bucket, object, metadata)
+	layer.record(ctx, opNewUpload, bucket, object, start, 0, 0, err)
+	return uploadID, err
+}
+
+func (layer *layerAccessLogging) CopyObjectPart(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, uploadID string, partID int, startOffset int64, length int64, srcInfo minio.ObjectInfo) (info minio.PartInfo, err error) {
+	start := time.Now()
+	info, err = layer.ObjectLayer.CopyObjectPart(ctx, srcBucket, srcObject, destBucket, destObject, uploadID, partID, startOffset, length, srcInfo)
+	layer.record(ctx, opCopyPart, destBucket, destObject, start, 0, length, err)
+	return info, err
+}
+
+func (layer *layerAccessLogging) PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, data *hash.Reader) (info minio.PartInfo, err error) {
+	start := time.Now()
+	info, err = layer.ObjectLayer.PutObjectPart(ctx, bucket, object, uploadID, partID, data)
+	layer.record(ctx, opPutPart, bucket, object, start, 0, data.Size(), err)
+	return info, err
+}
+
+func (layer *layerAccessLogging) ListObjectParts(ctx context.Context, bucket, object, uploadID string, partNumberMarker int, maxParts int) (result minio.ListPartsInfo, err error) {
+	start := time.Now()
+	result, err = layer.ObjectLayer.ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, maxParts)
+	layer.record(ctx, opListParts, bucket, object, start, 0, 0, err)
+	return result, err
+}
+
+func (layer *layerAccessLogging) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string) (err error) {
+	start := time.Now()
+	err = layer.ObjectLayer.AbortMultipartUpload(ctx, bucket, object, uploadID)
+	layer.record(ctx, opAbortUpload, bucket, object, start, 0, 0, err)
+	return err
+}
+
+func (layer *layerAccessLogging) CompleteMultipartUpload(ctx context.Context, bucket, object, uploadID string, uploadedParts []minio.CompletePart) (objInfo minio.ObjectInfo, err error) {
+	start := time.Now()
+	objInfo, err = layer.ObjectLayer.CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts)
+	layer.record(ctx, opCompleteUpload, bucket, object, start, 0, objInfo.Size, err)
+	return objInfo, err
+}
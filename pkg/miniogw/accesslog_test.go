@@ -0,0 +1,147 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package miniogw
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	minio "github.com/minio/minio/cmd"
+	miniologger "github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/hash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/pkg/storage/streams"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestAccessLogEntryString(t *testing.T) {
+	entry := &AccessLogEntry{
+		Time:       time.Date(2019, 2, 6, 0, 0, 38, 0, time.UTC),
+		RemoteIP:   "192.0.2.3",
+		Requester:  "access-key",
+		RequestID:  "3E57427F3EXAMPLE",
+		Operation:  "REST.GET.OBJECT",
+		Bucket:     "photos",
+		Key:        "2019/08/my puppy.jpg",
+		RequestURI: "GET /photos/2019/08/my puppy.jpg HTTP/1.1",
+		Status:     200,
+		BytesSent:  2662992,
+		ObjectSize: 3462992,
+		TotalTime:  70 * time.Millisecond,
+		UserAgent:  "S3Console/0.4",
+	}
+
+	assert.Equal(t, `access-key photos [06/Feb/2019:00:00:38 +0000] 192.0.2.3 access-key 3E57427F3EXAMPLE `+
+		`REST.GET.OBJECT 2019/08/my%20puppy.jpg "GET /photos/2019/08/my puppy.jpg HTTP/1.1" 200 - 2662992 3462992 70 70 "-" "S3Console/0.4" -`,
+		entry.String())
+
+	empty := &AccessLogEntry{Time: entry.Time, Operation: "REST.GET.SERVICE", Status: 500, ErrorCode: "InternalError"}
+	assert.Equal(t, `- - [06/Feb/2019:00:00:38 +0000] - - - REST.GET.SERVICE - "-" 500 InternalError - - 0 0 "-" "-" -`,
+		empty.String())
+}
+
+func TestAccessLogging(t *testing.T) {
+	runTest(t, func(ctx context.Context, layer minio.ObjectLayer, metainfo storj.Metainfo, streams streams.Store) {
+		var out bytes.Buffer
+		accessLogger := NewAccessLogger(zaptest.NewLogger(t), &out, 1<<20)
+		logged := &layerAccessLogging{ObjectLayer: layer, logger: accessLogger, requester: "access-key"}
+
+		reqctx := miniologger.SetReqInfo(ctx, &miniologger.ReqInfo{
+			RemoteHost: "192.0.2.3:4321",
+			UserAgent:  "test-agent",
+			RequestID:  "REQUESTID",
+		})
+
+		require.NoError(t, logged.MakeBucketWithLocation(reqctx, TestBucket, ""))
+
+		data, err := hash.NewReader(bytes.NewReader([]byte("test")), 4, "", "")
+		require.NoError(t, err)
+		_, err = logged.PutObject(reqctx, TestBucket, TestFile, data, nil)
+		require.NoError(t, err)
+
+		var downloaded bytes.Buffer
+		require.NoError(t, logged.GetObject(reqctx, TestBucket, TestFile, 0, -1, &downloaded, ""))
+		assert.Equal(t, "test", downloaded.String())
+
+		err = logged.DeleteObject(reqctx, TestBucket, "missing")
+		assert.Equal(t, minio.ObjectNotFound{Bucket: TestBucket, Object: "missing"}, err)
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 4)
+
+		expected := []struct{ operation, key, uri, status, sent, size string }{
+			{"REST.PUT.BUCKET", "-", `"PUT /` + TestBucket + ` HTTP/1.1"`, "200 -", "-", "-"},
+			{"REST.PUT.OBJECT", TestFile, `"PUT /` + TestBucket + "/" + TestFile + ` HTTP/1.1"`, "200 -", "-", "4"},
+			{"REST.GET.OBJECT", TestFile, `"GET /` + TestBucket + "/" + TestFile + ` HTTP/1.1"`, "200 -", "4", "4"},
+			{"REST.DELETE.OBJECT", "missing", `"DELETE /` + TestBucket + `/missing HTTP/1.1"`, "404 NoSuchKey", "-", "-"},
+		}
+		for i, line := range lines {
+			prefix := "access-key " + TestBucket + " ["
+			assert.True(t, strings.HasPrefix(line, prefix), line)
+			assert.Contains(t, line, "] 192.0.2.3 access-key REQUESTID "+expected[i].operation+" "+expected[i].key+" "+expected[i].uri+" "+
+				expected[i].status+" "+expected[i].sent+" "+expected[i].size+" ")
+			assert.True(t, strings.HasSuffix(line, `"-" "test-agent" -`), line)
+		}
+
+		// buffered entries are delivered into the log bucket
+		require.NoError(t, layer.MakeBucketWithLocation(ctx, DestBucket, ""))
+		require.NoError(t, accessLogger.Flush(ctx, layer, DestBucket, "logs/"))
+
+		list, err := layer.ListObjects(ctx, DestBucket, "logs/", "", "", 10)
+		require.NoError(t, err)
+		require.Len(t, list.Objects, 1)
+
+		var delivered bytes.Buffer
+		require.NoError(t, layer.GetObject(ctx, DestBucket, list.Objects[0].Name, 0, -1, &delivered, ""))
+		assert.Equal(t, out.String(), delivered.String())
+
+		// nothing left to deliver
+		require.NoError(t, accessLogger.Flush(ctx, layer, DestBucket, "logs/"))
+		list, err = layer.ListObjects(ctx, DestBucket, "logs/", "", "", 10)
+		require.NoError(t, err)
+		assert.Len(t, list.Objects, 1)
+	})
+}
+
+type failingLayer struct{ minio.ObjectLayer }
+
+func (failingLayer) PutObject(ctx context.Context, bucket, object string, data *hash.Reader, metadata map[string]string) (minio.ObjectInfo, error) {
+	return minio.ObjectInfo{}, minio.BucketNotFound{Bucket: bucket}
+}
+
+func TestAccessLogBuffer(t *testing.T) {
+	ctx := context.Background()
+
+	entry := func(key string) *AccessLogEntry {
+		return &AccessLogEntry{Time: time.Date(2019, 2, 6, 0, 0, 38, 0, time.UTC), Operation: "REST.GET.OBJECT", Key: key, Status: 200}
+	}
+	line := entry("a").String() + "\n"
+
+	accessLogger := NewAccessLogger(zaptest.NewLogger(t), nil, 2*len(line))
+	accessLogger.Record(entry("a"))
+	accessLogger.Record(entry("b"))
+	accessLogger.Record(entry("c"))
+
+	// the oldest entry is dropped to fit into the buffer
+	assert.Equal(t, entry("b").String()+"\n"+entry("c").String()+"\n", accessLogger.pending.String())
+
+	// entries of a failed delivery are kept as far as they fit with the newer ones
+	require.Error(t, accessLogger.Flush(ctx, failingLayer{}, DestBucket, "logs/"))
+	assert.Equal(t, entry("b").String()+"\n"+entry("c").String()+"\n", accessLogger.pending.String())
+
+	accessLogger.Record(entry("d"))
+	require.Error(t, accessLogger.Flush(ctx, failingLayer{}, DestBucket, "logs/"))
+	assert.Equal(t, entry("c").String()+"\n"+entry("d").String()+"\n", accessLogger.pending.String())
+
+	// nothing is kept without a buffer
+	unbuffered := NewAccessLogger(zaptest.NewLogger(t), nil, 0)
+	unbuffered.Record(entry("a"))
+	assert.Equal(t, 0, unbuffered.pending.Len())
+}
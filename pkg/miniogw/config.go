@@ -6,19 +6,25 @@ package miniogw
 import (
 	"context"
 	"errors"
+	"io"
 	"os"
+	"time"
 
 	"github.com/minio/cli"
 	minio "github.com/minio/minio/cmd"
 	"github.com/vivint/infectious"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 
 	"storj.io/storj/internal/memory"
+	"storj.io/storj/pkg/audit"
+	"storj.io/storj/pkg/auth/grpcauth"
 	"storj.io/storj/pkg/eestream"
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/metainfo/kvmetainfo"
 	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/pointerdb/pdbclient"
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/storage/buckets"
@@ -26,12 +32,22 @@ import (
 	"storj.io/storj/pkg/storage/segments"
 	"storj.io/storj/pkg/storage/streams"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+)
+
+const (
+	// latencyReportBatch is the number of round trips to nodes reported to
+	// the satellite at once
+	latencyReportBatch = 50
+	// latencyFlushTimeout is how long the round trips which are left are
+	// reported for when the stream store closes
+	latencyFlushTimeout = 10 * time.Second
 )
 
 // RSConfig is a configuration struct that keeps details about default
 // redundancy strategy information
 type RSConfig struct {
-	MaxBufferMem     memory.Size `help:"maximum buffer memory (in bytes) to be allocated for read buffers" default:"4M"`
+	MaxBufferMem     memory.Size `help:"maximum buffer memory (in bytes) to be allocated for read buffers and for resuming interrupted uploads of pieces" default:"4M"`
 	ErasureShareSize memory.Size `help:"the size of each new erasure sure in bytes" default:"1K"`
 	MinThreshold     int         `help:"the minimum pieces required to recover a segment. k." default:"29"`
 	RepairThreshold  int         `help:"the minimum safe pieces before a repair is triggered. m." default:"35"`
@@ -66,6 +82,15 @@ type ClientConfig struct {
 	APIKey        string      `help:"API Key (TODO: this needs to change to macaroons somehow)"`
 	MaxInlineSize memory.Size `help:"max inline segment size in bytes" default:"4K"`
 	SegmentSize   memory.Size `help:"the size of a segment in bytes" default:"64M"`
+
+	TuneSegmentSize bool `help:"choose the segment size from the object size, within the limits advertised by the satellite" default:"true"`
+
+	NodeTags         string `help:"comma separated name=value tags the storage nodes of uploads must have, e.g. region=eu, a tag without a value matches any value" default:""`
+	ExcludedNodeTags string `help:"comma separated name=value tags the storage nodes of uploads must not have, e.g. canary=true, a tag without a value matches any value" default:""`
+
+	Region string `help:"the region of the client, when set uploads prefer storage nodes with low round trips from it and the round trips of transfers are reported to the satellite for it" default:""`
+
+	Allocations pdbclient.AllocationCacheConfig
 }
 
 // ServerConfig determines how minio listens for requests
@@ -76,12 +101,13 @@ type ServerConfig struct {
 // Config is a general miniogw configuration struct. This should be everything
 // one needs to start a minio gateway.
 type Config struct {
-	Identity identity.Config
-	Server   ServerConfig
-	Minio    MinioConfig
-	Client   ClientConfig
-	RS       RSConfig
-	Enc      EncryptionConfig
+	Identity  identity.Config
+	Server    ServerConfig
+	Minio     MinioConfig
+	Client    ClientConfig
+	RS        RSConfig
+	Enc       EncryptionConfig
+	AccessLog AccessLogConfig
 }
 
 // Run starts a Minio Gateway given proper config
@@ -128,12 +154,38 @@ func (c Config) action(ctx context.Context, cliCtx *cli.Context, identity *provi
 		return err
 	}
 
-	minio.StartGateway(cliCtx, Logging(gw, zap.L()))
+	var gateway minio.Gateway = Logging(gw, zap.L())
+	if c.AccessLog.Enabled() {
+		var writer io.Writer
+		if c.AccessLog.Path != "" {
+			file, err := os.OpenFile(c.AccessLog.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+			if err != nil {
+				return Error.Wrap(err)
+			}
+			defer func() { err = errs.Combine(err, file.Close()) }()
+			writer = file
+		}
+		var buffer int
+		if c.AccessLog.Bucket != "" {
+			buffer = c.AccessLog.Buffer.Int()
+		}
+		accessLogger := NewAccessLogger(zap.L(), writer, buffer)
+		gateway = AccessLogging(ctx, gateway, accessLogger, c.AccessLog)
+	}
+
+	minio.StartGateway(cliCtx, gateway)
 	return Error.New("unexpected minio exit")
 }
 
 // GetMetainfo returns an implementation of storj.Metainfo
 func (c Config) GetMetainfo(ctx context.Context, identity *provider.FullIdentity) (db storj.Metainfo, ss streams.Store, err error) {
+	return c.GetObservedMetainfo(ctx, identity, nil)
+}
+
+// GetObservedMetainfo returns an implementation of storj.Metainfo whose calls
+// to the satellite and the storage nodes are passed to observe, e.g. to
+// diagnose failed transfers. A nil observe observes nothing.
+func (c Config) GetObservedMetainfo(ctx context.Context, identity *provider.FullIdentity, observe func(call transport.Call)) (db storj.Metainfo, ss streams.Store, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	if c.Client.OverlayAddr == "" || c.Client.PointerDBAddr == "" {
@@ -147,17 +199,22 @@ func (c Config) GetMetainfo(ctx context.Context, identity *provider.FullIdentity
 		return nil, nil, errlist.Err()
 	}
 
-	oc, err := overlay.NewClient(identity, c.Client.OverlayAddr)
+	oc, pointerdb, err := c.dialSatellite(identity, observe)
 	if err != nil {
-		return nil, nil, Error.New("failed to connect to overlay: %v", err)
+		return nil, nil, err
 	}
+	pdb := pdbclient.NewAllocationCache(pointerdb, c.Client.Allocations)
 
-	pdb, err := pdbclient.NewClient(identity, c.Client.PointerDBAddr, c.Client.APIKey)
-	if err != nil {
-		return nil, nil, Error.New("failed to connect to pointer DB: %v", err)
+	tc := transport.NewClient(identity)
+	if observe != nil {
+		tc = transport.ObserveCalls(tc, observe)
 	}
-
-	ec := ecclient.NewClient(identity, c.RS.MaxBufferMem.Int())
+	var reporter *overlay.LatencyReporter
+	if c.Client.Region != "" {
+		reporter = overlay.NewLatencyReporter(zap.L(), oc, c.Client.Region, latencyReportBatch)
+		tc = transport.ObserveLatency(tc, reporter.Observe)
+	}
+	ec := ecclient.NewTransportClient(tc, c.RS.MaxBufferMem.Int())
 	fc, err := infectious.NewFEC(c.RS.MinThreshold, c.RS.MaxThreshold)
 	if err != nil {
 		return nil, nil, Error.New("failed to create erasure coding client: %v", err)
@@ -167,7 +224,22 @@ func (c Config) GetMetainfo(ctx context.Context, identity *provider.FullIdentity
 		return nil, nil, Error.New("failed to create redundancy strategy: %v", err)
 	}
 
-	segments := segments.NewSegmentStore(oc, ec, pdb, rs, c.Client.MaxInlineSize.Int())
+	nodeTags, err := overlay.ParseTags(c.Client.NodeTags)
+	if err != nil {
+		return nil, nil, Error.Wrap(err)
+	}
+
+	excludedTags, err := overlay.ParseTags(c.Client.ExcludedNodeTags)
+	if err != nil {
+		return nil, nil, Error.Wrap(err)
+	}
+
+	segments := segments.NewSegmentStore(oc, ec, pdb, rs, c.Client.MaxInlineSize.Int(), overlay.Options{
+		Tags:             nodeTags,
+		ExcludedTags:     excludedTags,
+		PreferLowLatency: c.Client.Region != "",
+		Region:           c.Client.Region,
+	})
 
 	if c.RS.ErasureShareSize.Int()*c.RS.MinThreshold%c.Enc.BlockSize.Int() != 0 {
 		err = Error.New("EncryptionBlockSize must be a multiple of ErasureShareSize * RS MinThreshold")
@@ -177,16 +249,97 @@ func (c Config) GetMetainfo(ctx context.Context, identity *provider.FullIdentity
 	key := new(storj.Key)
 	copy(key[:], c.Enc.Key)
 
-	streams, err := streams.NewStreamStore(segments, c.Client.SegmentSize.Int64(), key, c.Enc.BlockSize.Int(), storj.Cipher(c.Enc.DataType))
+	segmentSizes := streams.SegmentSizes{Default: c.Client.SegmentSize.Int64()}
+	if c.Client.TuneSegmentSize {
+		segmentSizes.Min, segmentSizes.Max, err = pdb.SegmentLimits(ctx)
+		if err != nil {
+			// satellites without segment limits only support the default
+			zap.S().Warnf("segment size tuning disabled: %v", err)
+			segmentSizes.Min, segmentSizes.Max = 0, 0
+		}
+	}
+
+	streams, err := streams.NewStreamStore(segments, segmentSizes, key, c.Enc.BlockSize.Int(), storj.Cipher(c.Enc.DataType))
 	if err != nil {
 		return nil, nil, Error.New("failed to create stream store: %v", err)
 	}
+	if reporter != nil {
+		streams = reportingStore{Store: streams, reporter: reporter}
+	}
 
 	buckets := buckets.NewStore(streams)
 
 	return kvmetainfo.New(buckets, streams, segments, pdb, key), streams, nil
 }
 
+// reportingStore is a stream store which reports the round trips its
+// transfers observed which weren't reported yet when it's closed
+type reportingStore struct {
+	streams.Store
+	reporter *overlay.LatencyReporter
+}
+
+// Close reports the round trips which are left and closes the store
+func (store reportingStore) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), latencyFlushTimeout)
+	defer cancel()
+	return errs.Combine(store.reporter.Flush(ctx), store.Store.Close())
+}
+
+// dialSatellite connects to the overlay and pointerdb of the satellite, the
+// calls to them are passed to observe unless it's nil
+func (c Config) dialSatellite(identity *provider.FullIdentity, observe func(call transport.Call)) (overlay.Client, *pdbclient.PointerDB, error) {
+	if observe == nil {
+		oc, err := overlay.NewClient(identity, c.Client.OverlayAddr)
+		if err != nil {
+			return nil, nil, Error.New("failed to connect to overlay: %v", err)
+		}
+		pointerdb, err := pdbclient.NewClient(identity, c.Client.PointerDBAddr, c.Client.APIKey)
+		if err != nil {
+			return nil, nil, Error.New("failed to connect to pointer DB: %v", err)
+		}
+		return oc, pointerdb, nil
+	}
+
+	tc := transport.ObserveCalls(transport.NewClient(identity), observe)
+	conn, err := tc.DialAddress(context.Background(), c.Client.OverlayAddr)
+	if err != nil {
+		return nil, nil, Error.New("failed to connect to overlay: %v", err)
+	}
+	oc := overlay.NewClientFrom(pb.NewOverlayClient(conn))
+
+	conn, err = tc.DialAddress(context.Background(), c.Client.PointerDBAddr,
+		grpc.WithUnaryInterceptor(grpcauth.NewAPIKeyInjector(c.Client.APIKey)))
+	if err != nil {
+		return nil, nil, Error.New("failed to connect to pointer DB: %v", err)
+	}
+	return oc, pdbclient.New(pb.NewPointerDBClient(conn)), nil
+}
+
+// GetVerifier returns a verifier checking the integrity of stored segments
+func (c Config) GetVerifier(ctx context.Context, identity *provider.FullIdentity) (verifier *audit.SegmentVerifier, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if c.Client.OverlayAddr == "" {
+		return nil, errors.New("overlay address not specified")
+	}
+	if c.Client.PointerDBAddr == "" {
+		return nil, errors.New("pointerdb address not specified")
+	}
+
+	oc, err := overlay.NewClient(identity, c.Client.OverlayAddr)
+	if err != nil {
+		return nil, Error.New("failed to connect to overlay: %v", err)
+	}
+
+	pdb, err := pdbclient.NewClient(identity, c.Client.PointerDBAddr, c.Client.APIKey)
+	if err != nil {
+		return nil, Error.New("failed to connect to pointer DB: %v", err)
+	}
+
+	return audit.NewSegmentVerifier(transport.NewClient(identity), oc, identity, pdb), nil
+}
+
 // GetRedundancyScheme returns the configured redundancy scheme for new uploads
 func (c Config) GetRedundancyScheme() storj.RedundancyScheme {
 	return storj.RedundancyScheme{
@@ -19,6 +19,7 @@ import (
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/stream"
 	"storj.io/storj/pkg/utils"
+	"storj.io/storj/storage"
 )
 
 var (
@@ -187,110 +188,119 @@ func (layer *gatewayLayer) ListBuckets(ctx context.Context) (bucketItems []minio
 func (layer *gatewayLayer) ListObjects(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (result minio.ListObjectsInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if delimiter != "" && delimiter != "/" {
-		return minio.ListObjectsInfo{}, minio.UnsupportedDelimiter{Delimiter: delimiter}
-	}
-
-	startAfter := marker
-	recursive := delimiter == ""
-
-	var objects []minio.ObjectInfo
-	var prefixes []string
-
-	list, err := layer.gateway.metainfo.ListObjects(ctx, bucket, storj.ListOptions{
-		Direction: storj.After,
-		Cursor:    startAfter,
-		Prefix:    prefix,
-		Recursive: recursive,
-		Limit:     maxKeys,
-	})
+	objects, prefixes, last, more, err := layer.listObjects(ctx, bucket, prefix, marker, delimiter, maxKeys)
 	if err != nil {
-		return result, convertError(err, bucket, "")
-	}
-
-	if len(list.Items) > 0 {
-		for _, item := range list.Items {
-			path := item.Path
-			if recursive && prefix != "" {
-				path = storj.JoinPaths(strings.TrimSuffix(prefix, "/"), path)
-			}
-			if item.IsPrefix {
-				prefixes = append(prefixes, path)
-				continue
-			}
-			objects = append(objects, minio.ObjectInfo{
-				Bucket:      bucket,
-				IsDir:       false,
-				Name:        path,
-				ModTime:     item.Modified,
-				Size:        item.Size,
-				ETag:        hex.EncodeToString(item.Checksum),
-				ContentType: item.ContentType,
-				UserDefined: item.Metadata,
-			})
-		}
-		startAfter = list.Items[len(list.Items)-1].Path
+		return minio.ListObjectsInfo{}, err
 	}
 
 	result = minio.ListObjectsInfo{
-		IsTruncated: list.More,
+		IsTruncated: more,
 		Objects:     objects,
 		Prefixes:    prefixes,
 	}
-	if list.More {
-		result.NextMarker = startAfter
+	if more {
+		result.NextMarker = last
 	}
 
-	return result, err
+	return result, nil
 }
 
-// ListObjectsV2 - Not implemented stub
+// ListObjectsV2 lists objects like ListObjects, the continuation token is
+// the key after the last listed key
 func (layer *gatewayLayer) ListObjectsV2(ctx context.Context, bucket, prefix, continuationToken, delimiter string, maxKeys int, fetchOwner bool, startAfter string) (result minio.ListObjectsV2Info, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if delimiter != "" && delimiter != "/" {
-		return minio.ListObjectsV2Info{ContinuationToken: continuationToken}, minio.UnsupportedDelimiter{Delimiter: delimiter}
+	if continuationToken != "" {
+		startAfter = continuationToken
 	}
 
-	recursive := delimiter == ""
-	var nextContinuationToken string
+	objects, prefixes, last, more, err := layer.listObjects(ctx, bucket, prefix, startAfter, delimiter, maxKeys)
+	if err != nil {
+		return minio.ListObjectsV2Info{ContinuationToken: continuationToken}, err
+	}
 
-	var startAfterPath storj.Path
-	if continuationToken != "" {
-		startAfterPath = continuationToken
+	result = minio.ListObjectsV2Info{
+		IsTruncated:       more,
+		ContinuationToken: continuationToken,
+		Objects:           objects,
+		Prefixes:          prefixes,
 	}
-	if startAfterPath == "" && startAfter != "" {
-		startAfterPath = startAfter
+	if more {
+		result.NextContinuationToken = last + "\x00"
 	}
 
-	var objects []minio.ObjectInfo
-	var prefixes []string
+	return result, nil
+}
 
-	list, err := layer.gateway.metainfo.ListObjects(ctx, bucket, storj.ListOptions{
-		Direction: storj.After,
-		Cursor:    startAfterPath,
-		Prefix:    prefix,
-		Recursive: recursive,
-		Limit:     maxKeys,
-	})
-	if err != nil {
-		return minio.ListObjectsV2Info{ContinuationToken: continuationToken}, convertError(err, bucket, "")
+// listObjects lists the objects and common prefixes with the given prefix
+// after the key startAfter like S3 does: names are full object keys, the
+// prefix may end within a path component and with the "/" delimiter keys are
+// collapsed into common prefixes. last is the last listed name.
+func (layer *gatewayLayer) listObjects(ctx context.Context, bucket, prefix, startAfter, delimiter string, maxKeys int) (objects []minio.ObjectInfo, prefixes []string, last string, more bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if delimiter != "" && delimiter != "/" {
+		return nil, nil, "", false, minio.UnsupportedDelimiter{Delimiter: delimiter}
+	}
+	recursive := delimiter == ""
+
+	// paths are encrypted per component, so the satellite lists the
+	// directory of the prefix, collapsing its subdirectories into common
+	// prefixes, and the rest of the prefix is matched on the decrypted names
+	dir, partial := "", prefix
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		dir, partial = prefix[:i+1], prefix[i+1:]
+	}
+
+	var cursor storj.Path
+	switch {
+	case startAfter == "" || startAfter < dir:
+	case strings.HasPrefix(startAfter, dir):
+		cursor = startAfter[len(dir):]
+		if i := strings.IndexByte(cursor, '/'); i >= 0 && !recursive {
+			// the common prefix containing startAfter sorts before it
+			cursor = cursor[:i+1]
+		}
+	default:
+		// every key with the prefix sorts before startAfter
+		return nil, nil, "", false, nil
 	}
 
-	if len(list.Items) > 0 {
+	if partial != "" && maxKeys <= 0 {
+		maxKeys = storage.LookupLimit
+	}
+
+	for {
+		list, err := layer.gateway.metainfo.ListObjects(ctx, bucket, storj.ListOptions{
+			Direction: storj.After,
+			Cursor:    cursor,
+			Prefix:    strings.TrimSuffix(dir, "/"),
+			Recursive: recursive,
+			Limit:     maxKeys,
+		})
+		if err != nil {
+			return nil, nil, "", false, convertError(err, bucket, "")
+		}
+
 		for _, item := range list.Items {
-			path := item.Path
-			if recursive && prefix != "" {
-				path = storj.JoinPaths(strings.TrimSuffix(prefix, "/"), path)
+			cursor = item.Path
+			if !strings.HasPrefix(item.Path, partial) {
+				continue
+			}
+			if partial != "" && len(objects)+len(prefixes) == maxKeys {
+				// one more match than requested, so the listing is truncated
+				return objects, prefixes, last, true, nil
 			}
+
+			last = dir + item.Path
 			if item.IsPrefix {
-				prefixes = append(prefixes, path)
+				prefixes = append(prefixes, last)
 				continue
 			}
 			objects = append(objects, minio.ObjectInfo{
 				Bucket:      bucket,
 				IsDir:       false,
-				Name:        path,
+				Name:        last,
 				ModTime:     item.Modified,
 				Size:        item.Size,
 				ETag:        hex.EncodeToString(item.Checksum),
@@ -299,20 +309,11 @@ func (layer *gatewayLayer) ListObjectsV2(ctx context.Context, bucket, prefix, co
 			})
 		}
 
-		nextContinuationToken = list.Items[len(list.Items)-1].Path + "\x00"
-	}
-
-	result = minio.ListObjectsV2Info{
-		IsTruncated:       list.More,
-		ContinuationToken: continuationToken,
-		Objects:           objects,
-		Prefixes:          prefixes,
-	}
-	if list.More {
-		result.NextContinuationToken = nextContinuationToken
+		// without a partial component every listed item matches
+		if partial == "" || !list.More {
+			return objects, prefixes, last, list.More, nil
+		}
 	}
-
-	return result, err
 }
 
 func (layer *gatewayLayer) MakeBucketWithLocation(ctx context.Context, bucket string, location string) (err error) {
@@ -417,13 +418,16 @@ func (layer *gatewayLayer) PutObject(ctx context.Context, bucket, object string,
 		RedundancyScheme: layer.gateway.redundancy,
 		EncryptionScheme: layer.gateway.encryption,
 	}
+	if data != nil {
+		createInfo.SizeHint = data.Size()
+	}
 
 	return layer.putObject(ctx, bucket, object, data, &createInfo)
 }
 
 func (layer *gatewayLayer) Shutdown(ctx context.Context) (err error) {
 	defer mon.Task()(&ctx)(&err)
-	return nil
+	return layer.gateway.streams.Close()
 }
 
 func (layer *gatewayLayer) StorageInfo(context.Context) minio.StorageInfo {
@@ -9,7 +9,6 @@ import (
 	"encoding/hex"
 	"flag"
 	"fmt"
-	"strings"
 	"testing"
 	"time"
 
@@ -24,6 +23,7 @@ import (
 	"storj.io/storj/internal/testplanet"
 	"storj.io/storj/pkg/eestream"
 	"storj.io/storj/pkg/metainfo/kvmetainfo"
+	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storage/buckets"
 	"storj.io/storj/pkg/storage/ec"
@@ -432,6 +432,45 @@ func TestDeleteObject(t *testing.T) {
 	})
 }
 
+func TestListMultipartUploads(t *testing.T) {
+	runTest(t, func(ctx context.Context, layer minio.ObjectLayer, metainfo storj.Metainfo, streams streams.Store) {
+		// Check the error when listing the uploads of a non-existing bucket
+		_, err := layer.ListMultipartUploads(ctx, TestBucket, "", "", "", "", 0)
+		assert.Equal(t, minio.BucketNotFound{Bucket: TestBucket}, err)
+
+		// Create the bucket using the Metainfo API
+		_, err = metainfo.CreateBucket(ctx, TestBucket, nil)
+		assert.NoError(t, err)
+
+		// Start an upload using the Minio API
+		uploadID, err := layer.NewMultipartUpload(ctx, TestBucket, TestFile, map[string]string{})
+		assert.NoError(t, err)
+
+		// Check that the running upload is listed
+		list, err := layer.ListMultipartUploads(ctx, TestBucket, "", "", "", "", 0)
+		if assert.NoError(t, err) && assert.Len(t, list.Uploads, 1) {
+			assert.Equal(t, TestFile, list.Uploads[0].Object)
+			assert.Equal(t, uploadID, list.Uploads[0].UploadID)
+			assert.False(t, list.IsTruncated)
+		}
+
+		// Check that uploads outside of the prefix aren't listed
+		list, err = layer.ListMultipartUploads(ctx, TestBucket, "prefix/", "", "", "", 0)
+		if assert.NoError(t, err) {
+			assert.Empty(t, list.Uploads)
+		}
+
+		// Abort the upload and check that it isn't listed anymore
+		err = layer.AbortMultipartUpload(ctx, TestBucket, TestFile, uploadID)
+		assert.NoError(t, err)
+
+		list, err = layer.ListMultipartUploads(ctx, TestBucket, "", "", "", "", 0)
+		if assert.NoError(t, err) {
+			assert.Empty(t, list.Uploads)
+		}
+	})
+}
+
 func TestListObjects(t *testing.T) {
 	testListObjects(t, func(ctx context.Context, layer minio.ObjectLayer, bucket, prefix, marker, delimiter string, maxKeys int) ([]string, []minio.ObjectInfo, bool, error) {
 		list, err := layer.ListObjects(ctx, TestBucket, prefix, marker, delimiter, maxKeys)
@@ -580,28 +619,59 @@ func testListObjects(t *testing.T, listObjects func(context.Context, minio.Objec
 			}, {
 				prefix:    "a",
 				delimiter: "/",
-				objects:   []string{"xa", "xaa", "xb", "xbb", "xc"},
+				prefixes:  []string{"a/"},
+				objects:   []string{"a", "aa"},
+			}, {
+				prefix:    "a/",
+				delimiter: "/",
+				objects:   []string{"a/xa", "a/xaa", "a/xb", "a/xbb", "a/xc"},
+			}, {
+				prefix:    "a/xb",
+				delimiter: "/",
+				objects:   []string{"a/xb", "a/xbb"},
+			}, {
+				prefix:    "a/x",
+				delimiter: "/",
+				maxKeys:   2,
+				more:      true,
+				objects:   []string{"a/xa", "a/xaa"},
 			}, {
 				prefix:    "a/",
+				marker:    "a/xb",
 				delimiter: "/",
-				objects:   []string{"xa", "xaa", "xb", "xbb", "xc"},
+				objects:   []string{"a/xbb", "a/xc"},
 			}, {
 				prefix:    "a/",
-				marker:    "xb",
+				marker:    "`",
+				delimiter: "/",
+				objects:   []string{"a/xa", "a/xaa", "a/xb", "a/xbb", "a/xc"},
+			}, {
+				prefix:    "a/",
+				marker:    "b",
 				delimiter: "/",
-				objects:   []string{"xbb", "xc"},
+			}, {
+				marker:    "a/xbb",
+				delimiter: "/",
+				prefixes:  []string{"b/"},
+				objects:   []string{"aa", "b", "bb", "c"},
 			}, {
 				marker:  "a/xbb",
 				maxKeys: 5,
 				more:    true,
 				objects: []string{"a/xc", "aa", "b", "b/ya", "b/yaa"},
+			}, {
+				prefix:  "b",
+				objects: []string{"b", "b/ya", "b/yaa", "b/yb", "b/ybb", "b/yc", "bb"},
+			}, {
+				prefix:  "b/yb",
+				objects: []string{"b/yb", "b/ybb"},
 			}, {
 				prefix:    "a/",
-				marker:    "xaa",
+				marker:    "a/xaa",
 				delimiter: "/",
 				maxKeys:   2,
 				more:      true,
-				objects:   []string{"xb", "xbb"},
+				objects:   []string{"a/xb", "a/xbb"},
 			},
 		} {
 			errTag := fmt.Sprintf("%d. %+v", i, tt)
@@ -613,11 +683,7 @@ func testListObjects(t *testing.T, listObjects func(context.Context, minio.Objec
 				assert.Equal(t, tt.prefixes, prefixes, errTag)
 				assert.Equal(t, len(tt.objects), len(objects), errTag)
 				for i, objectInfo := range objects {
-					path := objectInfo.Name
-					if tt.prefix != "" {
-						path = storj.JoinPaths(strings.TrimSuffix(tt.prefix, "/"), path)
-					}
-					obj := files[path]
+					obj := files[objectInfo.Name]
 
 					assert.Equal(t, tt.objects[i], objectInfo.Name, errTag)
 					assert.Equal(t, TestBucket, objectInfo.Bucket, errTag)
@@ -682,12 +748,12 @@ func initEnv(planet *testplanet.Planet) (minio.ObjectLayer, storj.Metainfo, stre
 		return nil, nil, nil, err
 	}
 
-	segments := segments.NewSegmentStore(oc, ec, pdb, rs, int(8*memory.KB))
+	segments := segments.NewSegmentStore(oc, ec, pdb, rs, int(8*memory.KB), overlay.Options{})
 
 	key := new(storj.Key)
 	copy(key[:], TestEncKey)
 
-	streams, err := streams.NewStreamStore(segments, int64(64*memory.MB), key, int(1*memory.KB), storj.AESGCM)
+	streams, err := streams.NewStreamStore(segments, streams.SegmentSizes{Default: int64(64 * memory.MB)}, key, int(1*memory.KB), storj.AESGCM)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -0,0 +1,437 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package miniogw
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// minRestartDelay is how long the hosted gateway waits before restarting
+	// an instance which exited
+	minRestartDelay = time.Second
+	// maxRestartDelay is the longest wait before restarting an instance which
+	// keeps exiting, instances serving for longer restart after minRestartDelay
+	maxRestartDelay = time.Minute
+)
+
+// HostedConfig determines where the hosted gateway keeps its access grants
+// and how it limits the requests of every grant
+type HostedConfig struct {
+	Grants       string  `help:"file the registered access grants are kept in" default:"$CONFDIR/hosted-grants.json"`
+	Registration string  `help:"address to serve the endpoint registering access grants over, it isn't authenticated and must only be reachable by the operator" default:"localhost:7778"`
+	Rate         float64 `help:"the number of requests per second a single access grant may sustain, 0 disables rate limiting" default:"0"`
+	Burst        int     `help:"the number of requests a single access grant may make at once" default:"100"`
+}
+
+// HostedGrant is an access grant served by the hosted gateway. Requests
+// signed with its S3 credentials are served with its access to a satellite.
+type HostedGrant struct {
+	AccessKey     string `json:"access-key"`
+	SecretKey     string `json:"secret-key"`
+	SatelliteAddr string `json:"satellite-addr"`
+	APIKey        string `json:"api-key"`
+	EncKey        string `json:"enc-key"`
+}
+
+// Validate checks whether the grant can be served
+func (grant *HostedGrant) Validate() error {
+	switch {
+	case grant.AccessKey == "" || strings.ContainsAny(grant.AccessKey, `/\.`):
+		return Error.New("invalid access key %q", grant.AccessKey)
+	case grant.SecretKey == "":
+		return Error.New("access key %q has no secret key", grant.AccessKey)
+	case grant.SatelliteAddr == "" || grant.APIKey == "" || grant.EncKey == "":
+		return Error.New("access key %q needs a satellite address, an api key and an encryption key", grant.AccessKey)
+	}
+	return nil
+}
+
+// HostedInstance runs the gateway serving grant until ctx is canceled or
+// the gateway exits. It calls serving with the address of the gateway once
+// it serves requests.
+type HostedInstance func(ctx context.Context, grant HostedGrant, serving func(address string)) error
+
+// Hosted is the S3 front end of a hosted gateway. It serves many access
+// grants behind one listener by routing every request to the gateway
+// instance of the access key the request was signed with, so that the
+// encryption contexts of the grants stay isolated from each other.
+// Instances which exit are restarted until their grant is unregistered.
+type Hosted struct {
+	log      *zap.Logger
+	config   HostedConfig
+	instance HostedInstance
+
+	mu      sync.Mutex
+	ctx     context.Context // set while running
+	running sync.WaitGroup
+	grants  map[string]*hostedGrant
+}
+
+// hostedGrant is a registered grant and the state of its instance
+type hostedGrant struct {
+	grant  HostedGrant
+	cancel func()
+	proxy  *httputil.ReverseProxy // nil while the instance doesn't serve
+
+	tokens  float64
+	updated time.Time
+}
+
+// NewHosted creates a front end running the gateway of every grant with instance
+func NewHosted(log *zap.Logger, config HostedConfig, instance HostedInstance) *Hosted {
+	return &Hosted{log: log, config: config, instance: instance, grants: map[string]*hostedGrant{}}
+}
+
+// Run starts the instances of the grants kept in the grants file and of the
+// grants registered later on, until ctx is canceled
+func (hosted *Hosted) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	grants, err := LoadHostedGrants(hosted.config.Grants)
+	if err != nil {
+		return err
+	}
+
+	hosted.mu.Lock()
+	hosted.ctx = ctx
+	for _, grant := range grants {
+		hosted.start(grant)
+	}
+	hosted.mu.Unlock()
+
+	<-ctx.Done()
+
+	hosted.mu.Lock()
+	hosted.ctx = nil
+	hosted.mu.Unlock()
+
+	hosted.running.Wait()
+	return nil
+}
+
+// Register starts serving grant, replacing the grant with the same access key
+func (hosted *Hosted) Register(grant HostedGrant) error {
+	if err := grant.Validate(); err != nil {
+		return err
+	}
+
+	hosted.mu.Lock()
+	defer hosted.mu.Unlock()
+	if hosted.ctx == nil {
+		return Error.New("hosted gateway isn't running")
+	}
+
+	if existing, ok := hosted.grants[grant.AccessKey]; ok {
+		existing.cancel()
+	}
+	hosted.start(grant)
+	return hosted.save()
+}
+
+// Unregister stops serving the grant of accessKey, ok is false when it
+// wasn't registered
+func (hosted *Hosted) Unregister(accessKey string) (ok bool, err error) {
+	hosted.mu.Lock()
+	defer hosted.mu.Unlock()
+
+	existing, ok := hosted.grants[accessKey]
+	if !ok {
+		return false, nil
+	}
+	existing.cancel()
+	delete(hosted.grants, accessKey)
+	return true, hosted.save()
+}
+
+// start starts supervising the instance of grant, hosted.mu must be held
+func (hosted *Hosted) start(grant HostedGrant) {
+	ctx, cancel := context.WithCancel(hosted.ctx)
+	registered := &hostedGrant{
+		grant:   grant,
+		cancel:  cancel,
+		tokens:  float64(hosted.burst()),
+		updated: time.Now(),
+	}
+	hosted.grants[grant.AccessKey] = registered
+
+	hosted.running.Add(1)
+	go func() {
+		defer hosted.running.Done()
+		hosted.supervise(ctx, registered)
+	}()
+}
+
+// supervise runs the instance of registered and restarts it whenever it
+// exits, until ctx is canceled
+func (hosted *Hosted) supervise(ctx context.Context, registered *hostedGrant) {
+	log := hosted.log.With(zap.String("access key", registered.grant.AccessKey))
+	delay := minRestartDelay
+	for {
+		started := time.Now()
+		err := hosted.instance(ctx, registered.grant, func(address string) {
+			log.Info("instance serving", zap.String("address", address))
+			hosted.route(registered, address)
+		})
+		hosted.route(registered, "")
+		if ctx.Err() != nil {
+			return
+		}
+
+		if time.Since(started) > maxRestartDelay {
+			delay = minRestartDelay
+		}
+		log.Error("instance exited, restarting", zap.Duration("delay", delay), zap.Error(err))
+		mon.Counter("hosted_instance_restarts").Inc(1)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		}
+		if delay *= 2; delay > maxRestartDelay {
+			delay = maxRestartDelay
+		}
+	}
+}
+
+// route forwards the requests of registered to address, nowhere when empty
+func (hosted *Hosted) route(registered *hostedGrant, address string) {
+	var proxy *httputil.ReverseProxy
+	if address != "" {
+		proxy = httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "http", Host: address})
+		proxy.ErrorLog = zap.NewStdLog(hosted.log.Named(registered.grant.AccessKey))
+	}
+
+	hosted.mu.Lock()
+	defer hosted.mu.Unlock()
+	registered.proxy = proxy
+}
+
+// save writes the registered grants into the grants file, hosted.mu must be held
+func (hosted *Hosted) save() error {
+	grants := make([]HostedGrant, 0, len(hosted.grants))
+	for _, registered := range hosted.grants {
+		grants = append(grants, registered.grant)
+	}
+	sort.Slice(grants, func(i, k int) bool { return grants[i].AccessKey < grants[k].AccessKey })
+
+	data, err := json.MarshalIndent(grants, "", "  ")
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(hosted.config.Grants), 0700); err != nil {
+		return Error.Wrap(err)
+	}
+	return Error.Wrap(ioutil.WriteFile(hosted.config.Grants, data, 0600))
+}
+
+// LoadHostedGrants loads the grants kept in path, a missing file keeps no grants
+func LoadHostedGrants(path string) ([]HostedGrant, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var grants []HostedGrant
+	if err := json.Unmarshal(data, &grants); err != nil {
+		return nil, Error.New("invalid grants file %s: %v", path, err)
+	}
+	for _, grant := range grants {
+		if err := grant.Validate(); err != nil {
+			return nil, Error.New("invalid grants file %s: %v", path, err)
+		}
+	}
+	return grants, nil
+}
+
+// ServeHTTP forwards the request to the gateway instance of its access key.
+// The instance checks the signature, the Host header is kept for it.
+func (hosted *Hosted) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	accessKey := accessKeyOf(r)
+	if accessKey == "" {
+		writeHostedError(w, http.StatusForbidden, "AccessDenied", "Anonymous requests aren't served by the hosted gateway.")
+		return
+	}
+
+	registered, proxy, allowed := hosted.take(accessKey, time.Now())
+	switch {
+	case registered == nil:
+		writeHostedError(w, http.StatusForbidden, "InvalidAccessKeyId", "The access key you provided isn't hosted by this gateway.")
+	case !allowed:
+		mon.Counter("hosted_requests_limited").Inc(1)
+		writeHostedError(w, http.StatusServiceUnavailable, "SlowDown", "Please reduce your request rate.")
+	case proxy == nil:
+		writeHostedError(w, http.StatusServiceUnavailable, "ServiceUnavailable", "The gateway of your access key is starting, please retry.")
+	default:
+		proxy.ServeHTTP(w, r)
+	}
+}
+
+// take finds the grant of accessKey and takes a token from its bucket,
+// allowed is false when none is left
+func (hosted *Hosted) take(accessKey string, now time.Time) (registered *hostedGrant, proxy *httputil.ReverseProxy, allowed bool) {
+	hosted.mu.Lock()
+	defer hosted.mu.Unlock()
+
+	registered, ok := hosted.grants[accessKey]
+	if !ok {
+		return nil, nil, false
+	}
+	if hosted.config.Rate <= 0 {
+		return registered, registered.proxy, true
+	}
+
+	burst := float64(hosted.burst())
+	registered.tokens += now.Sub(registered.updated).Seconds() * hosted.config.Rate
+	if registered.tokens > burst {
+		registered.tokens = burst
+	}
+	registered.updated = now
+
+	if registered.tokens < 1 {
+		return registered, registered.proxy, false
+	}
+	registered.tokens--
+	return registered, registered.proxy, true
+}
+
+func (hosted *Hosted) burst() int {
+	if hosted.config.Burst < 1 {
+		return 1
+	}
+	return hosted.config.Burst
+}
+
+// hostedGrantStatus is how the registration endpoint lists a grant
+type hostedGrantStatus struct {
+	AccessKey     string `json:"access-key"`
+	SatelliteAddr string `json:"satellite-addr"`
+	Serving       bool   `json:"serving"`
+}
+
+// RegistrationHandler returns the endpoint registering grants: POST /grants
+// registers the grant in the body, DELETE /grants/<access key> unregisters
+// it and GET /grants lists the grants without their secrets.
+func (hosted *Hosted) RegistrationHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/grants", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			hosted.mu.Lock()
+			list := make([]hostedGrantStatus, 0, len(hosted.grants))
+			for _, registered := range hosted.grants {
+				list = append(list, hostedGrantStatus{
+					AccessKey:     registered.grant.AccessKey,
+					SatelliteAddr: registered.grant.SatelliteAddr,
+					Serving:       registered.proxy != nil,
+				})
+			}
+			hosted.mu.Unlock()
+			sort.Slice(list, func(i, k int) bool { return list[i].AccessKey < list[k].AccessKey })
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(list)
+		case http.MethodPost:
+			var grant HostedGrant
+			if err := json.NewDecoder(r.Body).Decode(&grant); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if err := grant.Validate(); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if err := hosted.Register(grant); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/grants/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		ok, err := hosted.Unregister(strings.TrimPrefix(r.URL.Path, "/grants/"))
+		switch {
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		case !ok:
+			http.Error(w, "access key not registered", http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	return mux
+}
+
+// accessKeyOf returns the access key a request was signed with, using either
+// signature version in the Authorization header or presigned query
+func accessKeyOf(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	switch {
+	case strings.HasPrefix(auth, "AWS4-HMAC-SHA256 "):
+		for _, field := range strings.Split(strings.TrimPrefix(auth, "AWS4-HMAC-SHA256 "), ",") {
+			field = strings.TrimSpace(field)
+			if strings.HasPrefix(field, "Credential=") {
+				return credentialAccessKey(strings.TrimPrefix(field, "Credential="))
+			}
+		}
+		return ""
+	case strings.HasPrefix(auth, "AWS "):
+		credential := strings.TrimPrefix(auth, "AWS ")
+		if i := strings.LastIndex(credential, ":"); i >= 0 {
+			return credential[:i]
+		}
+		return ""
+	}
+
+	query := r.URL.Query()
+	if credential := query.Get("X-Amz-Credential"); credential != "" {
+		return credentialAccessKey(credential)
+	}
+	return query.Get("AWSAccessKeyId")
+}
+
+// credentialAccessKey returns the access key of a version 4 credential scope
+func credentialAccessKey(credential string) string {
+	if i := strings.Index(credential, "/"); i >= 0 {
+		return credential[:i]
+	}
+	return credential
+}
+
+// writeHostedError responds with an S3 error
+func writeHostedError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, xml.Header)
+	_ = xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string
+		Message string
+	}{Code: code, Message: message})
+}
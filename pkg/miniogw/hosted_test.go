@@ -0,0 +1,197 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package miniogw
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestAccessKeyOf(t *testing.T) {
+	for _, test := range []struct {
+		auth, query, accessKey string
+	}{
+		{auth: "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20190101/us-east-1/s3/aws4_request, SignedHeaders=host, Signature=abc", accessKey: "AKIDEXAMPLE"},
+		{auth: "AWS AKIDEXAMPLE:c2lnbmF0dXJl", accessKey: "AKIDEXAMPLE"},
+		{query: "X-Amz-Credential=AKIDEXAMPLE%2F20190101%2Fus-east-1%2Fs3%2Faws4_request&X-Amz-Signature=abc", accessKey: "AKIDEXAMPLE"},
+		{query: "AWSAccessKeyId=AKIDEXAMPLE&Signature=abc", accessKey: "AKIDEXAMPLE"},
+		{auth: "Bearer token"},
+		{},
+	} {
+		r := httptest.NewRequest("GET", "/bucket/key?"+test.query, nil)
+		if test.auth != "" {
+			r.Header.Set("Authorization", test.auth)
+		}
+		assert.Equal(t, test.accessKey, accessKeyOf(r), test)
+	}
+}
+
+func TestHosted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir, err := ioutil.TempDir("", "hosted")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	// instances answer with their access key and serve until they crash
+	var started int32
+	crash := make(chan struct{}, 1)
+	instance := func(ctx context.Context, grant HostedGrant, serving func(address string)) error {
+		atomic.AddInt32(&started, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(grant.AccessKey + " " + r.Host))
+		}))
+		defer server.Close()
+		serving(strings.TrimPrefix(server.URL, "http://"))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-crash:
+			return errors.New("crashed")
+		}
+	}
+
+	config := HostedConfig{Grants: filepath.Join(dir, "grants.json"), Rate: 1, Burst: 2}
+	grant := func(accessKey string) HostedGrant {
+		return HostedGrant{AccessKey: accessKey, SecretKey: "secret", SatelliteAddr: "127.0.0.1:7777", APIKey: "key", EncKey: "enc"}
+	}
+	require.NoError(t, ioutil.WriteFile(config.Grants, []byte(`[{"access-key": "first-key", "secret-key": "secret", "satellite-addr": "127.0.0.1:7777", "api-key": "key", "enc-key": "enc"}]`), 0600))
+
+	hosted := NewHosted(zaptest.NewLogger(t), config, instance)
+	done := make(chan error)
+	go func() { done <- hosted.Run(ctx) }()
+
+	registration := httptest.NewServer(hosted.RegistrationHandler())
+	defer registration.Close()
+
+	request := func(accessKey string) (int, string) {
+		r := httptest.NewRequest("GET", "http://s3.example.com/bucket", nil)
+		if accessKey != "" {
+			r.Header.Set("Authorization", "AWS "+accessKey+":signature")
+		}
+		w := httptest.NewRecorder()
+		hosted.ServeHTTP(w, r)
+		body, err := ioutil.ReadAll(w.Body)
+		require.NoError(t, err)
+		return w.Code, string(body)
+	}
+	waitServing := func(accessKey string) {
+		for i := 0; i < 100; i++ {
+			hosted.mu.Lock()
+			registered, ok := hosted.grants[accessKey]
+			serving := ok && registered.proxy != nil
+			hosted.mu.Unlock()
+			if serving {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("%s isn't served", accessKey)
+	}
+
+	// grants are registered at runtime
+	body, err := json.Marshal(grant("second-key"))
+	require.NoError(t, err)
+	resp, err := http.Post(registration.URL+"/grants", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, err = http.Post(registration.URL+"/grants", "application/json", strings.NewReader(`{"access-key": "third-key"}`))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	waitServing("first-key")
+	waitServing("second-key")
+
+	// requests reach the instance of their access key with the signed host
+	code, text := request("first-key")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "first-key s3.example.com", text)
+	code, text = request("second-key")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "second-key s3.example.com", text)
+
+	code, text = request("unknown-key")
+	assert.Equal(t, http.StatusForbidden, code)
+	assert.Contains(t, text, "<Code>InvalidAccessKeyId</Code>")
+
+	code, text = request("")
+	assert.Equal(t, http.StatusForbidden, code)
+	assert.Contains(t, text, "<Code>AccessDenied</Code>")
+
+	// every access key is rate limited on its own
+	code, _ = request("first-key")
+	assert.Equal(t, http.StatusOK, code)
+	code, text = request("first-key")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Contains(t, text, "<Code>SlowDown</Code>")
+	code, _ = request("second-key")
+	assert.Equal(t, http.StatusOK, code)
+
+	// registered grants are kept and listed without their secrets
+	kept, err := LoadHostedGrants(config.Grants)
+	require.NoError(t, err)
+	assert.Equal(t, []HostedGrant{grant("first-key"), grant("second-key")}, kept)
+
+	resp, err = http.Get(registration.URL + "/grants")
+	require.NoError(t, err)
+	var list []hostedGrantStatus
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, []hostedGrantStatus{
+		{AccessKey: "first-key", SatelliteAddr: "127.0.0.1:7777", Serving: true},
+		{AccessKey: "second-key", SatelliteAddr: "127.0.0.1:7777", Serving: true},
+	}, list)
+
+	// crashed instances are restarted
+	crash <- struct{}{}
+	for i := 0; i < 300 && atomic.LoadInt32(&started) < 3; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&started))
+	waitServing("first-key")
+	waitServing("second-key")
+
+	// unregistered grants aren't served anymore
+	del, err := http.NewRequest(http.MethodDelete, registration.URL+"/grants/second-key", nil)
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(del)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	code, _ = request("second-key")
+	assert.Equal(t, http.StatusForbidden, code)
+
+	resp, err = http.DefaultClient.Do(del)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	kept, err = LoadHostedGrants(config.Grants)
+	require.NoError(t, err)
+	assert.Equal(t, []HostedGrant{grant("first-key")}, kept)
+
+	cancel()
+	require.NoError(t, <-done)
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -93,6 +94,14 @@ func (layer *gatewayLayer) PutObjectPart(ctx context.Context, bucket, object, up
 func (layer *gatewayLayer) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	if uploadID == interruptedUploadID {
+		mutableObject, err := layer.gateway.metainfo.ModifyPendingObject(ctx, bucket, object)
+		if err != nil {
+			return convertError(err, bucket, object)
+		}
+		return convertError(mutableObject.DeleteStream(ctx), bucket, object)
+	}
+
 	uploads := layer.gateway.multipart
 
 	upload, err := uploads.Remove(bucket, object, uploadID)
@@ -167,8 +176,80 @@ func (layer *gatewayLayer) ListObjectParts(ctx context.Context, bucket, object,
 	return list, nil
 }
 
+// interruptedUploadID is the upload ID of pending objects left behind by
+// uploads which this gateway isn't running, e.g. because it was restarted
+// during the upload. Such uploads can only be listed and aborted.
+const interruptedUploadID = "Interrupted"
+
+// ListMultipartUploads lists the uploads running in this gateway together with
+// the pending objects left behind by interrupted uploads. The uploads are
+// always listed recursively, ignoring the delimiter.
+func (layer *gatewayLayer) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result minio.ListMultipartsInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	list, err := layer.gateway.metainfo.ListPendingObjects(ctx, bucket, storj.ListOptions{
+		Prefix:    prefix,
+		Cursor:    strings.TrimPrefix(keyMarker, prefix),
+		Direction: storj.After,
+		Recursive: true,
+		Limit:     maxUploads,
+	})
+	if err != nil {
+		return minio.ListMultipartsInfo{}, convertError(err, bucket, "")
+	}
+
+	uploads := make(map[string]minio.MultipartInfo)
+	for _, item := range list.Items {
+		object := prefix + item.Path
+		uploads[object] = minio.MultipartInfo{
+			Object:    object,
+			UploadID:  interruptedUploadID,
+			Initiated: item.Modified,
+		}
+	}
+
+	// running uploads replace the pending objects they have stored so far
+	for _, upload := range layer.gateway.multipart.List(bucket, prefix) {
+		if upload.Object <= keyMarker {
+			continue
+		}
+		if list.More && len(list.Items) > 0 && upload.Object > prefix+list.Items[len(list.Items)-1].Path {
+			// will be listed on one of the next pages
+			continue
+		}
+		uploads[upload.Object] = minio.MultipartInfo{
+			Object:    upload.Object,
+			UploadID:  upload.ID,
+			Initiated: upload.Created,
+		}
+	}
+
+	result = minio.ListMultipartsInfo{
+		KeyMarker:      keyMarker,
+		UploadIDMarker: uploadIDMarker,
+		MaxUploads:     maxUploads,
+		IsTruncated:    list.More,
+		Prefix:         prefix,
+		Delimiter:      delimiter,
+		Uploads:        make([]minio.MultipartInfo, 0, len(uploads)),
+	}
+	for _, upload := range uploads {
+		result.Uploads = append(result.Uploads, upload)
+	}
+	sort.Slice(result.Uploads, func(i, k int) bool {
+		return result.Uploads[i].Object < result.Uploads[k].Object
+	})
+
+	if result.IsTruncated && len(result.Uploads) > 0 {
+		last := result.Uploads[len(result.Uploads)-1]
+		result.NextKeyMarker = last.Object
+		result.NextUploadIDMarker = last.UploadID
+	}
+
+	return result, nil
+}
+
 // TODO: implement
-// func (layer *gatewayLayer) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result minio.ListMultipartsInfo, err error) {
 // func (layer *gatewayLayer) CopyObjectPart(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, uploadID string, partID int, startOffset int64, length int64, srcInfo minio.ObjectInfo) (info minio.PartInfo, err error) {
 
 // MultipartUploads manages pending multipart uploads
@@ -222,6 +303,20 @@ func (uploads *MultipartUploads) Get(bucket, object, uploadID string) (*Multipar
 	return upload, nil
 }
 
+// List returns the pending uploads of objects in bucket starting with prefix
+func (uploads *MultipartUploads) List(bucket, prefix string) []*MultipartUpload {
+	uploads.mu.RLock()
+	defer uploads.mu.RUnlock()
+
+	var list []*MultipartUpload
+	for _, upload := range uploads.pending {
+		if upload.Bucket == bucket && strings.HasPrefix(upload.Object, prefix) {
+			list = append(list, upload)
+		}
+	}
+	return list
+}
+
 // Remove returns and removes a pending upload
 func (uploads *MultipartUploads) Remove(bucket, object, uploadID string) (*MultipartUpload, error) {
 	uploads.mu.RLock()
@@ -253,6 +348,7 @@ type MultipartUpload struct {
 	Bucket   string
 	Object   string
 	Metadata map[string]string
+	Created  time.Time
 	Done     chan (*MultipartUploadResult)
 	Stream   *MultipartStream
 
@@ -273,6 +369,7 @@ func NewMultipartUpload(uploadID string, bucket, object string, metadata map[str
 		Bucket:   bucket,
 		Object:   object,
 		Metadata: metadata,
+		Created:  time.Now(),
 		Done:     make(chan *MultipartUploadResult, 1),
 		Stream:   NewMultipartStream(),
 	}
@@ -375,6 +472,7 @@ func (stream *MultipartStream) Read(data []byte) (n int, err error) {
 	for {
 		// has an error occurred?
 		if stream.err != nil {
+			err := stream.err
 			stream.mu.Unlock()
 			return 0, Error.Wrap(err)
 		}
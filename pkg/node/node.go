@@ -41,7 +41,7 @@ func (node *Node) Lookup(ctx context.Context, to pb.Node, find pb.Node) ([]*pb.N
 	if err := rt.ConnectionSuccess(&to); err != nil {
 		return nil, NodeClientErr.Wrap(err)
 	}
-	return resp.Response, nil
+	return Verified(resp.Response), nil
 }
 
 // Ping attempts to establish a connection with a node to verify it is alive
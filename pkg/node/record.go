@@ -0,0 +1,72 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package node
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/pb"
+)
+
+// SignRecord signs the record with the identity of its publisher, replacing
+// a previous signature
+func SignRecord(ident *identity.FullIdentity, record *pb.Record, signedAt time.Time) error {
+	if record.Publisher != ident.ID {
+		return SignatureError.New("record of %s can't be signed by %s", record.Publisher, ident.ID)
+	}
+
+	signature := &pb.NodeSignature{
+		SignedAt: signedAt.Unix(),
+		Chain:    [][]byte{ident.Leaf.Raw, ident.CA.Raw},
+	}
+
+	data, err := recordSignedData(record, signature)
+	if err != nil {
+		return err
+	}
+	signature.Signature, err = auth.GenerateSignature(data, ident)
+	if err != nil {
+		return SignatureError.Wrap(err)
+	}
+
+	record.Signature = signature
+	return nil
+}
+
+// VerifyRecord checks that the record was signed by its publisher
+func VerifyRecord(record *pb.Record) error {
+	signature := record.GetSignature()
+	if signature == nil {
+		return SignatureError.New("record %s is not signed", record.Key)
+	}
+
+	data, err := recordSignedData(record, signature)
+	if err != nil {
+		return err
+	}
+	signer, err := auth.VerifyChainSignature(data, signature.Signature, signature.Chain)
+	if err != nil {
+		return SignatureError.Wrap(err)
+	}
+	if signer != record.Publisher {
+		return SignatureError.New("record of %s is signed by %s", record.Publisher, signer)
+	}
+	return nil
+}
+
+// recordSignedData returns the signed bytes of the record
+func recordSignedData(record *pb.Record, signature *pb.NodeSignature) ([]byte, error) {
+	data, err := proto.Marshal(&pb.Record{
+		Key:       record.Key,
+		Value:     record.Value,
+		Publisher: record.Publisher,
+		ExpiresAt: record.ExpiresAt,
+		Signature: &pb.NodeSignature{SignedAt: signature.SignedAt},
+	})
+	return data, SignatureError.Wrap(err)
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package node_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/node"
+	"storj.io/storj/pkg/pb"
+)
+
+func TestRecordSignature(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	ident, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+	other, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+
+	newRecord := func() *pb.Record {
+		return &pb.Record{
+			Key:       teststorj.NodeIDFromString("key"),
+			Value:     []byte("value"),
+			Publisher: ident.ID,
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		}
+	}
+
+	signed := newRecord()
+	require.NoError(t, node.SignRecord(ident, signed, time.Now()))
+	assert.NoError(t, node.VerifyRecord(signed))
+
+	// nodes can only sign the records they publish
+	assert.Error(t, node.SignRecord(other, newRecord(), time.Now()))
+
+	assert.Error(t, node.VerifyRecord(newRecord()))
+
+	changed := newRecord()
+	require.NoError(t, node.SignRecord(ident, changed, time.Now()))
+	changed.Value = []byte("other value")
+	assert.Error(t, node.VerifyRecord(changed))
+
+	extended := newRecord()
+	require.NoError(t, node.SignRecord(ident, extended, time.Now()))
+	extended.ExpiresAt += 1
+	assert.Error(t, node.VerifyRecord(extended))
+
+	forged := newRecord()
+	forged.Publisher = other.ID
+	forged.Signature = signed.Signature
+	assert.Error(t, node.VerifyRecord(forged))
+}
@@ -5,8 +5,10 @@ package node
 
 import (
 	"context"
+	"time"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc/peer"
 
 	"storj.io/storj/pkg/dht"
 	"storj.io/storj/pkg/pb"
@@ -33,7 +35,14 @@ func (server *Server) Query(ctx context.Context, req *pb.QueryRequest) (*pb.Quer
 		return &pb.QueryResponse{}, NodeClientErr.New("could not get routing table %server", err)
 	}
 
+	// only nodes which signed their record are added to the routing table
 	if req.GetPingback() {
+		if err := Verify(req.GetSender()); err != nil {
+			server.log.Debug("dropping unsigned sender", zap.Error(err))
+			mon.Counter("unsigned_nodes_dropped").Inc(1)
+			return server.near(rt, req)
+		}
+
 		_, err = server.dht.Ping(ctx, *req.Sender)
 		if err != nil {
 			server.log.Debug("connection to node failed", zap.Error(err), zap.String("nodeID", req.Sender.Id.String()))
@@ -51,6 +60,11 @@ func (server *Server) Query(ctx context.Context, req *pb.QueryRequest) (*pb.Quer
 		}
 	}
 
+	return server.near(rt, req)
+}
+
+// near responds with the nodes nearest to the target of the query
+func (server *Server) near(rt dht.RoutingTable, req *pb.QueryRequest) (*pb.QueryResponse, error) {
 	nodes, err := rt.FindNear(req.Target.Id, int(req.Limit))
 	if err != nil {
 		return &pb.QueryResponse{}, NodeClientErr.New("could not find near %server", err)
@@ -59,8 +73,38 @@ func (server *Server) Query(ctx context.Context, req *pb.QueryRequest) (*pb.Quer
 	return &pb.QueryResponse{Sender: req.Sender, Response: nodes}, nil
 }
 
-// Ping provides an easy way to verify a node is online and accepting requests
+// Store stores a record for the node which published it. The record must be
+// signed by its publisher.
+func (server *Server) Store(ctx context.Context, req *pb.StoreRequest) (*pb.StoreResponse, error) {
+	records, err := server.dht.GetRecords(ctx)
+	if err != nil {
+		return &pb.StoreResponse{}, NodeClientErr.New("could not get records %s", err)
+	}
+	if err := records.Put(req.GetRecord()); err != nil {
+		server.log.Debug("refusing record", zap.Error(err))
+		return &pb.StoreResponse{}, NodeClientErr.Wrap(err)
+	}
+	return &pb.StoreResponse{}, nil
+}
+
+// FindValue returns the record stored for the key of the request, if any
+func (server *Server) FindValue(ctx context.Context, req *pb.FindValueRequest) (*pb.FindValueResponse, error) {
+	records, err := server.dht.GetRecords(ctx)
+	if err != nil {
+		return &pb.FindValueResponse{}, NodeClientErr.New("could not get records %s", err)
+	}
+	record, _ := records.Get(req.Key)
+	return &pb.FindValueResponse{Record: record}, nil
+}
+
+// Ping provides an easy way to verify a node is online and accepting requests.
+// The nonce of the request is echoed, so the caller can tell the response is
+// from this node and measure the round trip. The address the ping came from
+// is reported back, so nodes behind routers can discover their public address.
 func (server *Server) Ping(ctx context.Context, req *pb.PingRequest) (*pb.PingResponse, error) {
-	//TODO
-	return &pb.PingResponse{}, nil
+	resp := &pb.PingResponse{Nonce: req.GetNonce(), ServerTime: time.Now().UnixNano()}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		resp.ObservedAddress = p.Addr.String()
+	}
+	return resp, nil
 }
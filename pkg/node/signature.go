@@ -0,0 +1,93 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package node
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/zeebo/errs"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/pb"
+)
+
+var (
+	// SignatureError is the error class for signing and verifying node records
+	SignatureError = errs.Class("node signature error")
+	mon            = monkit.Package()
+)
+
+// Sign signs the id, address and type of the node with the identity of the
+// node, replacing a previous signature
+func Sign(ident *identity.FullIdentity, node *pb.Node, signedAt time.Time) error {
+	if node.Id != ident.ID {
+		return SignatureError.New("node %s can't be signed by %s", node.Id, ident.ID)
+	}
+
+	signature := &pb.NodeSignature{
+		SignedAt: signedAt.Unix(),
+		Chain:    [][]byte{ident.Leaf.Raw, ident.CA.Raw},
+	}
+
+	data, err := signedData(node, signature)
+	if err != nil {
+		return err
+	}
+	signature.Signature, err = auth.GenerateSignature(data, ident)
+	if err != nil {
+		return SignatureError.Wrap(err)
+	}
+
+	node.Signature = signature
+	return nil
+}
+
+// Verify checks that the id, address and type of the node were signed by
+// the node itself
+func Verify(node *pb.Node) error {
+	signature := node.GetSignature()
+	if signature == nil {
+		return SignatureError.New("node %s is not signed", node.Id)
+	}
+
+	data, err := signedData(node, signature)
+	if err != nil {
+		return err
+	}
+	signer, err := auth.VerifyChainSignature(data, signature.Signature, signature.Chain)
+	if err != nil {
+		return SignatureError.Wrap(err)
+	}
+	if signer != node.Id {
+		return SignatureError.New("node %s is signed by %s", node.Id, signer)
+	}
+	return nil
+}
+
+// Verified returns the nodes with a valid signature, dropping the others
+func Verified(nodes []*pb.Node) []*pb.Node {
+	verified := make([]*pb.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if node == nil || Verify(node) != nil {
+			mon.Counter("unsigned_nodes_dropped").Inc(1)
+			continue
+		}
+		verified = append(verified, node)
+	}
+	return verified
+}
+
+// signedData returns the signed bytes of the node record
+func signedData(node *pb.Node, signature *pb.NodeSignature) ([]byte, error) {
+	data, err := proto.Marshal(&pb.Node{
+		Id:        node.Id,
+		Address:   node.Address,
+		Type:      node.Type,
+		Signature: &pb.NodeSignature{SignedAt: signature.SignedAt},
+	})
+	return data, SignatureError.Wrap(err)
+}
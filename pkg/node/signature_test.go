@@ -0,0 +1,62 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package node_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/pkg/node"
+	"storj.io/storj/pkg/pb"
+)
+
+func TestSignature(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	ident, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+	other, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+
+	newNode := func() *pb.Node {
+		return &pb.Node{
+			Id:      ident.ID,
+			Type:    pb.NodeType_STORAGE,
+			Address: &pb.NodeAddress{Address: "127.0.0.1:7777"},
+		}
+	}
+
+	signed := newNode()
+	require.NoError(t, node.Sign(ident, signed, time.Now()))
+	assert.NoError(t, node.Verify(signed))
+
+	// fields which aren't signed can change
+	signed.Restrictions = &pb.NodeRestrictions{FreeDisk: 1}
+	assert.NoError(t, node.Verify(signed))
+
+	// nodes can only sign their own record
+	assert.Error(t, node.Sign(other, newNode(), time.Now()))
+
+	unsigned := newNode()
+	assert.Error(t, node.Verify(unsigned))
+
+	changed := newNode()
+	require.NoError(t, node.Sign(ident, changed, time.Now()))
+	changed.Address.Address = "127.0.0.1:6666"
+	assert.Error(t, node.Verify(changed))
+
+	// records signed by another node
+	forged := &pb.Node{Id: other.ID, Type: pb.NodeType_STORAGE, Address: &pb.NodeAddress{Address: "127.0.0.1:7777"}}
+	forged.Signature = signed.Signature
+	assert.Error(t, node.Verify(forged))
+
+	verified := node.Verified([]*pb.Node{signed, unsigned, nil, changed, forged})
+	assert.Equal(t, []*pb.Node{signed}, verified)
+}
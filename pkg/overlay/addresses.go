@@ -0,0 +1,116 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/zeebo/errs"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+// AddressesError is the error class for signing and verifying node addresses
+var AddressesError = errs.Class("node addresses error")
+
+// SignNodeAddresses signs the addresses of the nodes with the identity of the
+// satellite handing them out, uplinks don't dial them after expires
+func SignNodeAddresses(ident *identity.FullIdentity, nodes []*pb.Node, expires time.Time) (*pb.SignedNodeAddresses, error) {
+	signed := &pb.SignedNodeAddresses{
+		ExpiresAt: expires.Unix(),
+		Chain:     [][]byte{ident.Leaf.Raw, ident.CA.Raw},
+	}
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		signed.Entries = append(signed.Entries, &pb.NodeAddressEntry{
+			NodeId:  node.Id,
+			Address: node.Address,
+		})
+	}
+
+	data, err := addressesData(ident.ID, signed)
+	if err != nil {
+		return nil, err
+	}
+	signed.Signature, err = auth.GenerateSignature(data, ident)
+	if err != nil {
+		return nil, AddressesError.Wrap(err)
+	}
+	return signed, nil
+}
+
+// VerifyNodeAddresses checks that the addresses of the nodes were signed by
+// the satellite and haven't expired at now. Nil nodes are skipped.
+func VerifyNodeAddresses(satellite storj.NodeID, signed *pb.SignedNodeAddresses, nodes []*pb.Node, now time.Time) error {
+	if signed == nil {
+		return AddressesError.New("missing signed addresses")
+	}
+	if now.Unix() > signed.ExpiresAt {
+		return AddressesError.New("addresses expired at %s", time.Unix(signed.ExpiresAt, 0).UTC())
+	}
+
+	data, err := addressesData(satellite, signed)
+	if err != nil {
+		return err
+	}
+	signer, err := auth.VerifyChainSignature(data, signed.Signature, signed.Chain)
+	if err != nil {
+		return AddressesError.Wrap(err)
+	}
+	if signer != satellite {
+		return AddressesError.New("addresses of %s are signed by %s", satellite, signer)
+	}
+
+	addresses := make(map[storj.NodeID]*pb.NodeAddress, len(signed.Entries))
+	for _, entry := range signed.Entries {
+		addresses[entry.NodeId] = entry.Address
+	}
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		address, ok := addresses[node.Id]
+		if !ok {
+			return AddressesError.New("address of %s isn't signed", node.Id)
+		}
+		if address.GetAddress() != node.Address.GetAddress() || address.GetTransport() != node.Address.GetTransport() {
+			return AddressesError.New("address %q of %s differs from the signed %q", node.Address.GetAddress(), node.Id, address.GetAddress())
+		}
+	}
+	return nil
+}
+
+// addressesData returns the signed bytes of the addresses, binding them to
+// the satellite
+func addressesData(satellite storj.NodeID, signed *pb.SignedNodeAddresses) ([]byte, error) {
+	data, err := proto.Marshal(&pb.SignedNodeAddresses{
+		Entries:   signed.Entries,
+		ExpiresAt: signed.ExpiresAt,
+	})
+	if err != nil {
+		return nil, AddressesError.Wrap(err)
+	}
+	return append(satellite.Bytes(), data...), nil
+}
+
+// VerifyPeerNodeAddresses checks that the addresses of the nodes were signed
+// by the satellite at the other end of the connection p
+func VerifyPeerNodeAddresses(p *peer.Peer, signed *pb.SignedNodeAddresses, nodes []*pb.Node) error {
+	if _, ok := p.AuthInfo.(credentials.TLSInfo); !ok {
+		return AddressesError.New("unknown satellite")
+	}
+	satellite, err := identity.PeerIdentityFromPeer(p)
+	if err != nil {
+		return AddressesError.Wrap(err)
+	}
+	return VerifyNodeAddresses(satellite.ID, signed, nodes, time.Now())
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+)
+
+func TestSignNodeAddresses(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	satellite, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+	other, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+
+	nodes := []*pb.Node{
+		{Id: teststorj.NodeIDFromString("node1"), Address: &pb.NodeAddress{Address: "127.0.0.1:7777"}},
+		nil,
+		{Id: teststorj.NodeIDFromString("node2"), Address: &pb.NodeAddress{Address: "127.0.0.2:7777"}},
+	}
+	now := time.Now()
+	signed, err := overlay.SignNodeAddresses(satellite, nodes, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Len(t, signed.Entries, 2)
+	assert.NoError(t, overlay.VerifyNodeAddresses(satellite.ID, signed, nodes, now))
+
+	{ // addresses are only valid until they expire
+		err := overlay.VerifyNodeAddresses(satellite.ID, signed, nodes, now.Add(2*time.Minute))
+		assert.True(t, overlay.AddressesError.Has(err))
+	}
+
+	{ // addresses are bound to the satellite that signed them
+		err := overlay.VerifyNodeAddresses(other.ID, signed, nodes, now)
+		assert.True(t, overlay.AddressesError.Has(err))
+
+		err = overlay.VerifyNodeAddresses(satellite.ID, nil, nodes, now)
+		assert.True(t, overlay.AddressesError.Has(err))
+	}
+
+	{ // nodes with other or unsigned addresses are rejected
+		redirected := []*pb.Node{{Id: nodes[0].Id, Address: &pb.NodeAddress{Address: "10.0.0.1:7777"}}}
+		assert.Error(t, overlay.VerifyNodeAddresses(satellite.ID, signed, redirected, now))
+
+		unknown := []*pb.Node{{Id: teststorj.NodeIDFromString("node3"), Address: nodes[0].Address}}
+		assert.Error(t, overlay.VerifyNodeAddresses(satellite.ID, signed, unknown, now))
+	}
+
+	{ // tampered addresses are rejected
+		tampered := *signed
+		tampered.ExpiresAt++
+		assert.Error(t, overlay.VerifyNodeAddresses(satellite.ID, &tampered, nodes, now))
+	}
+
+	{ // addresses signed by another satellite's leaf are rejected
+		forged, err := overlay.SignNodeAddresses(other, nodes, now.Add(time.Minute))
+		require.NoError(t, err)
+		forged.Chain[0] = signed.Chain[0]
+		assert.Error(t, overlay.VerifyNodeAddresses(satellite.ID, forged, nodes, now))
+	}
+}
@@ -6,6 +6,7 @@ package overlay
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
@@ -43,6 +44,12 @@ type DB interface {
 	List(ctx context.Context, cursor storj.NodeID, limit int) ([]*pb.Node, error)
 	// Update updates node information
 	Update(ctx context.Context, value *pb.Node) error
+	// UpdateBatch updates information of multiple nodes at once. Values
+	// without a reputation get the one from statdb, which is loaded in the
+	// same transaction, creating statdb entries for new nodes.
+	UpdateBatch(ctx context.Context, values []*pb.Node) error
+	// UpdateLastContact records when contacting the node last succeeded or failed
+	UpdateLastContact(ctx context.Context, nodeID storj.NodeID, success bool, at time.Time) error
 	// Delete deletes node based on id
 	Delete(ctx context.Context, id storj.NodeID) error
 	//GetWalletAddress gets the node's wallet address
@@ -51,13 +58,16 @@ type DB interface {
 
 // Cache is used to store overlay data in Redis
 type Cache struct {
-	db     DB
-	statDB statdb.DB
+	db          DB
+	statDB      statdb.DB
+	preferences NodeSelectionConfig
+	stats       *stats
+	pieces      pieceCounts
 }
 
 // NewCache returns a new Cache
-func NewCache(db DB, sdb statdb.DB) *Cache {
-	return &Cache{db: db, statDB: sdb}
+func NewCache(db DB, sdb statdb.DB, preferences NodeSelectionConfig) *Cache {
+	return &Cache{db: db, statDB: sdb, preferences: preferences, stats: newStats()}
 }
 
 // Close closes resources
@@ -75,7 +85,14 @@ func (cache *Cache) Get(ctx context.Context, nodeID storj.NodeID) (*pb.Node, err
 		return nil, ErrEmptyNode
 	}
 
-	return cache.db.Get(ctx, nodeID)
+	node, err := cache.db.Get(ctx, nodeID)
+	switch {
+	case err == nil:
+		cache.stats.lookup(1, 1)
+	case err == ErrNodeNotFound:
+		cache.stats.lookup(1, 0)
+	}
+	return node, err
 }
 
 // GetAll looks up the provided ids from the overlay cache
@@ -84,7 +101,96 @@ func (cache *Cache) GetAll(ctx context.Context, ids storj.NodeIDList) ([]*pb.Nod
 		return nil, OverlayError.New("no ids provided")
 	}
 
-	return cache.db.GetAll(ctx, ids)
+	nodes, err := cache.db.GetAll(ctx, ids)
+	if err == nil {
+		found := 0
+		for _, node := range nodes {
+			if node != nil {
+				found++
+			}
+		}
+		cache.stats.lookup(len(ids), found)
+	}
+	return nodes, err
+}
+
+// Reachable returns the storage nodes in the cache which aren't offline
+func (cache *Cache) Reachable(ctx context.Context) (_ []*pb.Node, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	now := time.Now()
+	var reachable []*pb.Node
+	var cursor storj.NodeID
+	for {
+		nodes, err := cache.db.List(ctx, cursor, storage.LookupLimit)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		var nextCursor storj.NodeID
+		for _, node := range nodes {
+			if node == nil {
+				continue
+			}
+			nextCursor = node.Id
+			if node.Id == cursor && !cursor.IsZero() {
+				// the cursor is listed on both pages
+				continue
+			}
+			if node.Type != pb.NodeType_STORAGE || offline(node, cache.preferences.OfflineGracePeriod, now) {
+				continue
+			}
+			reachable = append(reachable, node)
+		}
+
+		// no progress means the end was reached
+		if nextCursor.IsZero() || nextCursor == cursor {
+			return reachable, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+// ReputationHalfLife returns how long it takes for a node's past uptime checks
+// and audits to count half as much, so that audits decay like uptime checks
+func (cache *Cache) ReputationHalfLife() time.Duration {
+	return cache.preferences.ReputationHalfLife
+}
+
+// Stats returns counters of the cache activity since it was created
+func (cache *Cache) Stats() Stats {
+	return cache.stats.snapshot()
+}
+
+// ReportPieceCounts replaces the number of pieces stored on every node, nodes
+// missing from counts don't store any pieces. It's nil-safe, so that the tally
+// can report regardless of whether the overlay is running.
+func (cache *Cache) ReportPieceCounts(counts map[storj.NodeID]int64) {
+	if cache == nil {
+		return
+	}
+	cache.pieces.report(counts)
+}
+
+// ReportStoredBytes replaces the number of bytes stored on all nodes. Like
+// ReportPieceCounts it's nil-safe.
+func (cache *Cache) ReportStoredBytes(bytes int64) {
+	if cache == nil {
+		return
+	}
+	cache.pieces.reportStored(bytes)
+}
+
+// StoredBytes returns the number of bytes stored on all nodes according to
+// the last report and whether it has been reported
+func (cache *Cache) StoredBytes() (int64, bool) {
+	return cache.pieces.storedBytes()
+}
+
+// PieceCount returns the number of pieces stored on the node according to
+// the last report and whether piece counts have been reported
+func (cache *Cache) PieceCount(nodeID storj.NodeID) (int64, bool) {
+	return cache.pieces.count(nodeID)
 }
 
 // Put adds a nodeID to the redis cache with a binary representation of proto defined Node
@@ -98,22 +204,75 @@ func (cache *Cache) Put(ctx context.Context, nodeID storj.NodeID, value pb.Node)
 		return errors.New("invalid request")
 	}
 
+	if err := cache.loadReputation(ctx, &value); err != nil {
+		return err
+	}
+	cache.verifyTags(&value)
+
+	return cache.db.Update(ctx, &value)
+}
+
+// BulkPut adds multiple nodes to the cache, writing them to the database in a single batch
+func (cache *Cache) BulkPut(ctx context.Context, nodes []*pb.Node) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	values := make([]*pb.Node, 0, len(nodes))
+	for _, node := range nodes {
+		// nodes without an ID (i.e. bootstrap node) are not added, same as Put
+		if node == nil || node.Id.IsZero() {
+			continue
+		}
+
+		// the reputation is loaded by the database along with the update
+		value := *node
+		value.Reputation = nil
+		cache.verifyTags(&value)
+		values = append(values, &value)
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+	return cache.db.UpdateBatch(ctx, values)
+}
+
+// loadReputation sets the node reputation from statdb, creating a new statdb entry when necessary
+func (cache *Cache) loadReputation(ctx context.Context, value *pb.Node) error {
 	// get existing node rep, or create a new statdb node with 0 rep
-	stats, err := cache.statDB.CreateEntryIfNotExists(ctx, nodeID)
+	stats, err := cache.statDB.CreateEntryIfNotExists(ctx, value.Id)
 	if err != nil {
 		return err
 	}
 
-	value.Reputation = &pb.NodeStats{
+	value.Reputation = Reputation(stats)
+	return nil
+}
+
+// Reputation converts the statdb stats of a node into its reputation
+func Reputation(stats *statdb.NodeStats) *pb.NodeStats {
+	return &pb.NodeStats{
 		AuditSuccessRatio:  stats.AuditSuccessRatio,
 		AuditSuccessCount:  stats.AuditSuccessCount,
 		AuditCount:         stats.AuditCount,
 		UptimeRatio:        stats.UptimeRatio,
 		UptimeSuccessCount: stats.UptimeSuccessCount,
 		UptimeCount:        stats.UptimeCount,
+		AuditReputation:    stats.AuditReputation,
+		UptimeReputation:   stats.UptimeReputation,
 	}
+}
 
-	return cache.db.Update(ctx, &value)
+// verifyTags drops tags which weren't signed by the node, the node keeps the
+// tags it published earlier
+func (cache *Cache) verifyTags(value *pb.Node) {
+	if value.Tags == nil {
+		return
+	}
+	if err := VerifyTags(value.Id, value.Tags); err != nil {
+		zap.L().Debug("dropping unverified node tags", zap.String("node", value.Id.String()), zap.Error(err))
+		mon.Counter("tags_unverified").Inc(1)
+		value.Tags = nil
+	}
 }
 
 // Delete will remove the node from the cache. Used when a node hard disconnects or fails
@@ -125,15 +284,24 @@ func (cache *Cache) Delete(ctx context.Context, id storj.NodeID) error {
 	return cache.db.Delete(ctx, id)
 }
 
+// RecordContact records that contacting the node succeeded or failed just now
+func (cache *Cache) RecordContact(ctx context.Context, nodeID storj.NodeID, success bool) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return cache.db.UpdateLastContact(ctx, nodeID, success, time.Now())
+}
+
 // ConnFailure implements the Transport Observer `ConnFailure` function
 func (cache *Cache) ConnFailure(ctx context.Context, node *pb.Node, failureError error) {
 	// TODO: Kademlia paper specifies 5 unsuccessful PINGs before removing the node
 	// from our routing table, but this is the cache so maybe we want to treat
 	// it differently.
-	_, err := cache.statDB.UpdateUptime(ctx, node.Id, false)
+	_, err := cache.statDB.UpdateUptime(ctx, node.Id, false, cache.preferences.ReputationHalfLife)
 	if err != nil {
 		zap.L().Debug("error updating uptime for node in statDB", zap.Error(err))
 	}
+	if err := cache.RecordContact(ctx, node.Id, false); err != nil {
+		zap.L().Debug("error recording failed contact with node", zap.Error(err))
+	}
 }
 
 // ConnSuccess implements the Transport Observer `ConnSuccess` function
@@ -142,8 +310,11 @@ func (cache *Cache) ConnSuccess(ctx context.Context, node *pb.Node) {
 	if err != nil {
 		zap.L().Debug("error updating uptime for node in statDB", zap.Error(err))
 	}
-	_, err = cache.statDB.UpdateUptime(ctx, node.Id, true)
+	_, err = cache.statDB.UpdateUptime(ctx, node.Id, true, cache.preferences.ReputationHalfLife)
 	if err != nil {
 		zap.L().Debug("error updating statdDB with node connection info", zap.Error(err))
 	}
+	if err := cache.RecordContact(ctx, node.Id, true); err != nil {
+		zap.L().Debug("error recording successful contact with node", zap.Error(err))
+	}
 }
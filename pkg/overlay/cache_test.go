@@ -6,6 +6,7 @@ package overlay_test
 import (
 	"context"
 	"math/rand"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -37,7 +38,7 @@ func testCache(ctx context.Context, t *testing.T, store overlay.DB, sdb statdb.D
 	_, _ = rand.Read(valid2ID[:])
 	_, _ = rand.Read(missingID[:])
 
-	cache := overlay.NewCache(store, sdb)
+	cache := overlay.NewCache(store, sdb, overlay.NodeSelectionConfig{})
 
 	{ // Put
 		err := cache.Put(ctx, valid1ID, pb.Node{Id: valid1ID})
@@ -74,6 +75,27 @@ func testCache(ctx context.Context, t *testing.T, store overlay.DB, sdb statdb.D
 		// TODO: add erroring database test
 	}
 
+	{ // RecordContact
+		assert.NoError(t, cache.RecordContact(ctx, valid1ID, true))
+		assert.NoError(t, cache.RecordContact(ctx, valid1ID, false))
+		assert.True(t, cache.RecordContact(ctx, missingID, true) == overlay.ErrNodeNotFound)
+
+		// contacts aren't overwritten by node updates
+		assert.NoError(t, cache.Put(ctx, valid1ID, pb.Node{Id: valid1ID}))
+
+		valid1, err := cache.Get(ctx, valid1ID)
+		if assert.NoError(t, err) {
+			assert.NotNil(t, valid1.LastContactSuccess)
+			assert.NotNil(t, valid1.LastContactFailure)
+		}
+
+		valid2, err := cache.Get(ctx, valid2ID)
+		if assert.NoError(t, err) {
+			assert.Nil(t, valid2.LastContactSuccess)
+			assert.Nil(t, valid2.LastContactFailure)
+		}
+	}
+
 	{ // GetAll
 		nodes, err := cache.GetAll(ctx, storj.NodeIDList{valid2ID, valid1ID, valid2ID})
 		assert.NoError(t, err)
@@ -97,6 +119,102 @@ func testCache(ctx context.Context, t *testing.T, store overlay.DB, sdb statdb.D
 		// TODO: add erroring database test
 	}
 
+	{ // BulkPut
+		bulk1ID := storj.NodeID{}
+		bulk2ID := storj.NodeID{}
+		_, _ = rand.Read(bulk1ID[:])
+		_, _ = rand.Read(bulk2ID[:])
+
+		err := cache.BulkPut(ctx, []*pb.Node{
+			{Id: bulk1ID},
+			{}, // nodes without an ID are skipped
+			{Id: bulk2ID, Address: &pb.NodeAddress{Address: "127.0.0.1:9999"}},
+		})
+		assert.NoError(t, err)
+
+		nodes, err := cache.GetAll(ctx, storj.NodeIDList{bulk1ID, bulk2ID})
+		if assert.NoError(t, err) {
+			assert.Equal(t, bulk1ID, nodes[0].Id)
+			assert.Equal(t, bulk2ID, nodes[1].Id)
+			assert.Equal(t, "127.0.0.1:9999", nodes[1].Address.Address)
+		}
+
+		// statdb entries are created for new nodes
+		for _, id := range []storj.NodeID{bulk1ID, bulk2ID} {
+			_, err := sdb.Get(ctx, id)
+			assert.NoError(t, err)
+		}
+
+		// updating existing nodes, the reputation is loaded from statdb
+		_, err = sdb.UpdateUptime(ctx, bulk2ID, true, 0)
+		assert.NoError(t, err)
+		err = cache.BulkPut(ctx, []*pb.Node{
+			{
+				Id:         bulk2ID,
+				Address:    &pb.NodeAddress{Address: "127.0.0.1:7777"},
+				Reputation: &pb.NodeStats{UptimeCount: 100},
+			},
+		})
+		assert.NoError(t, err)
+
+		node, err := cache.Get(ctx, bulk2ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "127.0.0.1:7777", node.Address.Address)
+			assert.EqualValues(t, 1, node.Reputation.UptimeCount)
+			assert.EqualValues(t, 1, node.Reputation.UptimeSuccessCount)
+		}
+
+		err = cache.BulkPut(ctx, nil)
+		assert.NoError(t, err)
+	}
+
+	{ // Metadata
+		metaID := storj.NodeID{}
+		_, _ = rand.Read(metaID[:])
+
+		metadata := &pb.NodeMetadata{
+			Email:          "operator@example.com",
+			Wallet:         "0x" + strings.Repeat("ab", 20),
+			WalletFeatures: []string{"zksync"},
+		}
+		err := cache.Put(ctx, metaID, pb.Node{Id: metaID, Metadata: metadata})
+		assert.NoError(t, err)
+
+		// updates without metadata keep the known operator details
+		err = cache.Put(ctx, metaID, pb.Node{Id: metaID, Address: &pb.NodeAddress{Address: "127.0.0.1:5555"}})
+		assert.NoError(t, err)
+
+		node, err := cache.Get(ctx, metaID)
+		if assert.NoError(t, err) && assert.NotNil(t, node.Metadata) {
+			assert.Equal(t, metadata.Email, node.Metadata.Email)
+			assert.Equal(t, metadata.Wallet, node.Metadata.Wallet)
+			assert.Equal(t, metadata.WalletFeatures, node.Metadata.WalletFeatures)
+		}
+
+		wallet, err := store.GetWalletAddress(ctx, metaID)
+		assert.NoError(t, err)
+		assert.Equal(t, metadata.Wallet, wallet)
+
+		// a changed wallet replaces the old one
+		metadata.Wallet = "0x" + strings.Repeat("cd", 20)
+		err = cache.Put(ctx, metaID, pb.Node{Id: metaID, Metadata: metadata})
+		assert.NoError(t, err)
+
+		wallet, err = store.GetWalletAddress(ctx, metaID)
+		assert.NoError(t, err)
+		assert.Equal(t, metadata.Wallet, wallet)
+
+		// wallet features can be opted out of again
+		metadata.WalletFeatures = nil
+		err = cache.Put(ctx, metaID, pb.Node{Id: metaID, Metadata: metadata})
+		assert.NoError(t, err)
+
+		node, err = cache.Get(ctx, metaID)
+		if assert.NoError(t, err) && assert.NotNil(t, node.Metadata) {
+			assert.Empty(t, node.Metadata.WalletFeatures)
+		}
+	}
+
 	{ // Delete
 		// Test standard delete
 		err := cache.Delete(ctx, valid1ID)
@@ -5,8 +5,12 @@ package overlay
 
 import (
 	"context"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/zeebo/errs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
 
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/provider"
@@ -25,11 +29,12 @@ import (
 // ClientError creates class of errors for stack traces
 var ClientError = errs.Class("Client Error")
 
-//Client implements the Overlay Client interface
+// Client implements the Overlay Client interface
 type Client interface {
 	Choose(ctx context.Context, op Options) ([]*pb.Node, error)
 	Lookup(ctx context.Context, nodeID storj.NodeID) (*pb.Node, error)
 	BulkLookup(ctx context.Context, nodeIDs storj.NodeIDList) ([]*pb.Node, error)
+	ReportLatency(ctx context.Context, region string, latencies map[storj.NodeID]time.Duration) error
 }
 
 // client is the overlay concrete implementation of the client interface
@@ -47,6 +52,12 @@ type Options struct {
 	AuditSuccess float64
 	AuditCount   int64
 	Excluded     storj.NodeIDList
+	Tags         []*pb.NodeTag
+	ExcludedTags []*pb.NodeTag
+	// PreferLowLatency prefers nodes with low round trips from Region, or
+	// from the satellite when Region is empty
+	PreferLowLatency bool
+	Region           string
 }
 
 // NewClient returns a new intialized Overlay Client
@@ -73,17 +84,27 @@ func (client *client) Choose(ctx context.Context, op Options) ([]*pb.Node, error
 	var exIDs storj.NodeIDList
 	exIDs = append(exIDs, op.Excluded...)
 	// TODO(coyle): We will also need to communicate with the reputation service here
+	var satellite peer.Peer
 	resp, err := client.conn.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
 		Opts: &pb.OverlayOptions{
 			Amount:        int64(op.Amount),
 			Restrictions:  &pb.NodeRestrictions{FreeDisk: op.Space, FreeBandwidth: op.Bandwidth},
 			ExcludedNodes: exIDs,
+			Tags:          op.Tags,
+			ExcludedTags:  op.ExcludedTags,
+
+			PreferLowLatency: op.PreferLowLatency,
+			RegionHint:       op.Region,
 		},
-	})
+	}, grpc.Peer(&satellite))
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
 
+	// only dial the nodes the satellite selected
+	if err := VerifyPeerNodeAddresses(&satellite, resp.GetSignedAddresses(), resp.GetNodes()); err != nil {
+		return nil, Error.Wrap(err)
+	}
 	return resp.GetNodes(), nil
 }
 
@@ -97,7 +118,8 @@ func (client *client) Lookup(ctx context.Context, nodeID storj.NodeID) (*pb.Node
 	return resp.GetNode(), nil
 }
 
-// BulkLookup provides a list of Nodes with the given IDs
+// BulkLookup provides a list of Nodes with the given IDs. Nodes which weren't
+// found or couldn't be looked up are nil.
 func (client *client) BulkLookup(ctx context.Context, nodeIDs storj.NodeIDList) ([]*pb.Node, error) {
 	var reqs pb.LookupRequests
 	for _, v := range nodeIDs {
@@ -115,3 +137,15 @@ func (client *client) BulkLookup(ctx context.Context, nodeIDs storj.NodeIDList)
 	}
 	return nodes, nil
 }
+
+// ReportLatency reports the round trips of transfers to nodes observed from
+// region
+func (client *client) ReportLatency(ctx context.Context, region string, latencies map[storj.NodeID]time.Duration) error {
+	report := &pb.LatencyReport{Region: region}
+	for id, rtt := range latencies {
+		report.Latencies = append(report.Latencies, &pb.NodeLatency{NodeId: id, Rtt: ptypes.DurationProto(rtt)})
+	}
+
+	_, err := client.conn.ReportLatency(ctx, report)
+	return ClientError.Wrap(err)
+}
@@ -5,6 +5,7 @@ package overlay
 
 import (
 	"context"
+	"net"
 	"strings"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"go.uber.org/zap"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
+	"storj.io/storj/internal/memory"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/statdb"
@@ -30,6 +32,9 @@ var (
 type Config struct {
 	RefreshInterval time.Duration `help:"the interval at which the cache refreshes itself in seconds" default:"1s"`
 	Node            NodeSelectionConfig
+	Vetting         VettingConfig
+	RateLimit       RateLimitConfig
+	Network         NetworkConfig
 }
 
 // LookupConfig is a configuration struct for querying the overlay cache with one or more node IDs
@@ -41,10 +46,33 @@ type LookupConfig struct {
 // NodeSelectionConfig is a configuration struct to determine the minimum
 // values for nodes to select
 type NodeSelectionConfig struct {
-	UptimeRatio       float64 `help:"a node's ratio of being up/online vs. down/offline" default:"0"`
+	UptimeRatio       float64 `help:"a node's decayed ratio of being up/online vs. down/offline" default:"0"`
 	UptimeCount       int64   `help:"the number of times a node's uptime has been checked" default:"0"`
-	AuditSuccessRatio float64 `help:"a node's ratio of successful audits" default:"0"`
+	AuditSuccessRatio float64 `help:"a node's decayed ratio of successful audits" default:"0"`
 	AuditCount        int64   `help:"the number of times a node has been audited" default:"0"`
+
+	ReputationHalfLife time.Duration `help:"how long it takes for a node's past uptime checks and audits to count half as much towards its reputation" default:"720h0m0s"`
+	OfflineGracePeriod time.Duration `help:"how long after its last successful contact a node which failed to be contacted since is still selected, 0 selects offline nodes" default:"1h0m0s"`
+
+	FreeBandwidth memory.Size `help:"the minimum free bandwidth a node must advertise to be selected" default:"0B"`
+	FreeDisk      memory.Size `help:"the minimum free disk space a node must advertise to be selected" default:"0B"`
+
+	SelectionPages int `help:"the number of pages of nodes of the overlay cache listed for a storage node selection, starting from a random node, 0 lists the whole cache" default:"10"`
+
+	PieceCountBias float64 `help:"how strongly nodes storing fewer pieces than average are preferred, so that new capacity fills up evenly, 0 ignores piece counts" default:"0"`
+
+	MaxLatency       time.Duration `help:"the maximum average round trip of the satellite's pings and transfers to a node for it to be selected, nodes which weren't contacted yet are selected, 0 ignores latency" default:"0"`
+	LatencyReference time.Duration `help:"the round trip at which nodes are selected half as often as nodes next to the client when the client prefers low latency, 0 ignores the preference" default:"50ms"`
+	Region           string        `help:"the region of the satellite, which the round trips of its pings and transfers are recorded for" default:""`
+
+	LatencyReportRate  float64 `help:"the number of round trip reports per second a single uplink may sustain, 0 disables rate limiting" default:"1"`
+	LatencyReportBurst int     `help:"the number of round trip reports a single uplink may send at once" default:"10"`
+
+	AddressValidity time.Duration `help:"how long uplinks may dial the signed addresses of the nodes selected for them" default:"15m0s"`
+
+	BlacklistFile string `help:"file with node IDs, one per line, which are never selected nor returned by lookups, reloaded on SIGHUP" default:""`
+	WhitelistFile string `help:"file with node IDs, one per line, which are the only nodes selected for storage when not empty, reloaded on SIGHUP" default:""`
+	TagsFile      string `help:"file with operator tags of nodes, one node ID per line followed by its name=value tags, which replace the tags the nodes signed, reloaded on SIGHUP and updated by the inspector" default:""`
 }
 
 // CtxKey used for assigning cache and server
@@ -64,27 +92,45 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (
 	sdb, ok := ctx.Value("masterdb").(interface {
 		StatDB() statdb.DB
 		OverlayCache() DB
+		NodeEvents() EventsDB
 	})
 	if !ok {
 		return Error.Wrap(errs.New("unable to get master db instance"))
 	}
 
-	cache := NewCache(sdb.OverlayCache(), sdb.StatDB())
+	cache := NewCache(sdb.OverlayCache(), sdb.StatDB(), c.Node)
+
+	lists, err := NewNodeLists(zap.L(), c.Node.BlacklistFile, c.Node.WhitelistFile, c.Node.TagsFile)
+	if err != nil {
+		return err
+	}
+	go func() { _ = lists.Run(ctx) }()
+
+	vetting := NewVetting(zap.L(), cache, sdb.NodeEvents(), c.Vetting, nil)
+	go func() { _ = vetting.Run(ctx) }()
 
-	ns := &pb.NodeStats{
-		UptimeCount:       c.Node.UptimeCount,
-		UptimeRatio:       c.Node.UptimeRatio,
-		AuditSuccessRatio: c.Node.AuditSuccessRatio,
-		AuditCount:        c.Node.AuditCount,
+	network := NewNetwork(zap.L(), cache, c.Network, nil)
+	go func() { _ = network.Run(ctx) }()
+	if c.Network.Address != "" {
+		listener, err := net.Listen("tcp", c.Network.Address)
+		if err != nil {
+			return err
+		}
+		go func() {
+			if err := network.Serve(ctx, listener); err != nil {
+				zap.L().Error("serving network totals failed", zap.Error(err))
+			}
+		}()
 	}
 
-	srv := NewServer(zap.L(), cache, ns)
+	srv := NewServer(zap.L(), cache, c.Node, lists, vetting)
+	srv.SetSigner(server.Identity())
 	pb.RegisterOverlayServer(server.GRPC(), srv)
 
 	zap.S().Warn("Once the Peer refactor is done, the overlay inspector needs to be registered on a " +
 		"gRPC server that only listens on localhost")
 	// TODO: register on a private rpc server
-	pb.RegisterOverlayInspectorServer(server.GRPC(), NewInspector(cache))
+	pb.RegisterOverlayInspectorServer(server.GRPC(), NewInspector(srv, sdb.NodeEvents()))
 
 	ctx2 := context.WithValue(ctx, ctxKeyOverlay, cache)
 	ctx2 = context.WithValue(ctx2, ctxKeyOverlayServer, srv)
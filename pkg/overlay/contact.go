@@ -0,0 +1,45 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+
+	"storj.io/storj/pkg/pb"
+)
+
+// offline returns whether the last contact with the node failed and the last
+// successful contact is older than the grace period. Nodes which were never
+// contacted successfully are left to their uptime reputation, since their
+// first contact predates the tracking.
+func offline(node *pb.Node, gracePeriod time.Duration, now time.Time) bool {
+	if gracePeriod <= 0 || node.LastContactFailure == nil || node.LastContactSuccess == nil {
+		return false
+	}
+
+	failure, err := ptypes.Timestamp(node.LastContactFailure)
+	if err != nil {
+		return false
+	}
+	success, err := ptypes.Timestamp(node.LastContactSuccess)
+	if err != nil {
+		return false
+	}
+	return failure.After(success) && now.Sub(success) > gracePeriod
+}
+
+// formatContact formats the time of a contact for selection explanations
+func formatContact(contact *timestamp.Timestamp) string {
+	if contact == nil {
+		return "never"
+	}
+	at, err := ptypes.Timestamp(contact)
+	if err != nil {
+		return "invalid time"
+	}
+	return at.Format(time.RFC3339)
+}
@@ -0,0 +1,189 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+// DualReadDB is the overlay database during a migration between two
+// backends: the current backend is read and written as before, the new one
+// is written along and read to verify it returns the same nodes
+type DualReadDB struct {
+	log     *zap.Logger
+	current DB
+	next    DB
+}
+
+// NewDualReadDB returns the database migrating from current to next
+func NewDualReadDB(log *zap.Logger, current, next DB) *DualReadDB {
+	return &DualReadDB{log: log, current: current, next: next}
+}
+
+// Get looks up the node by nodeID
+func (db *DualReadDB) Get(ctx context.Context, nodeID storj.NodeID) (_ *pb.Node, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	node, err := db.current.Get(ctx, nodeID)
+	nextNode, nextErr := db.next.Get(ctx, nodeID)
+	db.compareErrors("get", err, nextErr)
+	if err == nil && nextErr == nil {
+		db.compareNodes("get", node, nextNode)
+	}
+	return node, err
+}
+
+// GetAll looks up nodes based on the ids from the overlay cache
+func (db *DualReadDB) GetAll(ctx context.Context, nodeIDs storj.NodeIDList) (_ []*pb.Node, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodes, err := db.current.GetAll(ctx, nodeIDs)
+	nextNodes, nextErr := db.next.GetAll(ctx, nodeIDs)
+	db.compareErrors("get all", err, nextErr)
+	if err == nil && nextErr == nil {
+		db.compareNodeLists("get all", nodes, nextNodes)
+	}
+	return nodes, err
+}
+
+// List lists nodes starting from cursor
+func (db *DualReadDB) List(ctx context.Context, cursor storj.NodeID, limit int) (_ []*pb.Node, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodes, err := db.current.List(ctx, cursor, limit)
+	nextNodes, nextErr := db.next.List(ctx, cursor, limit)
+	db.compareErrors("list", err, nextErr)
+	if err == nil && nextErr == nil {
+		db.compareNodeLists("list", nodes, nextNodes)
+	}
+	return nodes, err
+}
+
+// GetWalletAddress gets the node's wallet address
+func (db *DualReadDB) GetWalletAddress(ctx context.Context, id storj.NodeID) (_ string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	address, err := db.current.GetWalletAddress(ctx, id)
+	nextAddress, nextErr := db.next.GetWalletAddress(ctx, id)
+	db.compareErrors("get wallet address", err, nextErr)
+	if err == nil && nextErr == nil && address != nextAddress {
+		db.diverged("get wallet address", zap.String("node", id.String()),
+			zap.String("current", address), zap.String("next", nextAddress))
+	}
+	return address, err
+}
+
+// Update updates node information
+func (db *DualReadDB) Update(ctx context.Context, value *pb.Node) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := db.current.Update(ctx, value); err != nil {
+		return err
+	}
+	db.writeFailed("update", db.next.Update(ctx, value))
+	return nil
+}
+
+// UpdateBatch updates information of multiple nodes at once. The reputation
+// current sets on the values is written to next as well.
+func (db *DualReadDB) UpdateBatch(ctx context.Context, values []*pb.Node) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := db.current.UpdateBatch(ctx, values); err != nil {
+		return err
+	}
+	db.writeFailed("update batch", db.next.UpdateBatch(ctx, values))
+	return nil
+}
+
+// UpdateLastContact records when contacting the node last succeeded or failed
+func (db *DualReadDB) UpdateLastContact(ctx context.Context, nodeID storj.NodeID, success bool, at time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := db.current.UpdateLastContact(ctx, nodeID, success, at); err != nil {
+		return err
+	}
+	db.writeFailed("update last contact", db.next.UpdateLastContact(ctx, nodeID, success, at))
+	return nil
+}
+
+// Delete deletes node based on id
+func (db *DualReadDB) Delete(ctx context.Context, id storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := db.current.Delete(ctx, id); err != nil {
+		return err
+	}
+	db.writeFailed("delete", db.next.Delete(ctx, id))
+	return nil
+}
+
+// compareErrors reports reads failing on a single backend, a node missing
+// from one of them included
+func (db *DualReadDB) compareErrors(operation string, err, nextErr error) {
+	if (err == nil) == (nextErr == nil) {
+		return
+	}
+	db.diverged(operation, zap.NamedError("current", err), zap.NamedError("next", nextErr))
+}
+
+// compareNodeLists reports differences between the nodes read from the backends
+func (db *DualReadDB) compareNodeLists(operation string, nodes, nextNodes []*pb.Node) {
+	if len(nodes) != len(nextNodes) {
+		db.diverged(operation, zap.Int("current", len(nodes)), zap.Int("next", len(nextNodes)))
+		return
+	}
+	for i := range nodes {
+		db.compareNodes(operation, nodes[i], nextNodes[i])
+	}
+}
+
+// compareNodes reports differences between a node read from the backends
+func (db *DualReadDB) compareNodes(operation string, node, nextNode *pb.Node) {
+	if node == nil || nextNode == nil {
+		if node != nextNode {
+			db.diverged(operation, zap.Bool("current", node != nil), zap.Bool("next", nextNode != nil))
+		}
+		return
+	}
+
+	data, err := proto.Marshal(node)
+	if err != nil {
+		db.log.Warn("marshaling node failed", zap.Error(err))
+		return
+	}
+	nextData, err := proto.Marshal(nextNode)
+	if err != nil {
+		db.log.Warn("marshaling node failed", zap.Error(err))
+		return
+	}
+	if !bytes.Equal(data, nextData) {
+		db.diverged(operation, zap.String("node", node.Id.String()),
+			zap.Stringer("current", node), zap.Stringer("next", nextNode))
+	}
+}
+
+// diverged reports that the backends returned different results
+func (db *DualReadDB) diverged(operation string, fields ...zap.Field) {
+	mon.Counter("overlay_dual_read_divergence").Inc(1)
+	db.log.Warn("overlay backends diverged", append([]zap.Field{zap.String("operation", operation)}, fields...)...)
+}
+
+// writeFailed reports a write which failed on the new backend only, which
+// is left to diverge rather than failing the write
+func (db *DualReadDB) writeFailed(operation string, err error) {
+	if err == nil {
+		return
+	}
+	mon.Counter("overlay_dual_write_failed").Inc(1)
+	db.log.Warn("writing to the new overlay backend failed", zap.String("operation", operation), zap.Error(err))
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/satellitedb"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestDualReadDB(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		next, err := satellitedb.NewInMemory()
+		require.NoError(t, err)
+		defer ctx.Check(next.Close)
+		require.NoError(t, next.CreateTables())
+
+		core, logs := observer.New(zapcore.WarnLevel)
+		dual := overlay.NewDualReadDB(zap.New(core), db.OverlayCache(), next.OverlayCache())
+
+		// the migrating database behaves like the current one
+		testCache(ctx, t, dual, db.StatDB())
+		assert.Equal(t, 0, logs.Len())
+
+		{ // nodes which differ in the new database are reported
+			id := teststorj.NodeIDFromString("diverged")
+			require.NoError(t, dual.Update(ctx, &pb.Node{Id: id, Address: &pb.NodeAddress{Address: "127.0.0.1:1"}, Reputation: &pb.NodeStats{}}))
+			require.NoError(t, next.OverlayCache().Update(ctx, &pb.Node{Id: id, Address: &pb.NodeAddress{Address: "127.0.0.1:2"}, Reputation: &pb.NodeStats{}}))
+
+			node, err := dual.Get(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, "127.0.0.1:1", node.Address.Address)
+			assert.Equal(t, 1, logs.FilterMessage("overlay backends diverged").Len())
+		}
+
+		{ // nodes missing from the new database are reported
+			id := teststorj.NodeIDFromString("missing")
+			require.NoError(t, db.OverlayCache().Update(ctx, &pb.Node{Id: id, Reputation: &pb.NodeStats{}}))
+
+			_, err := dual.Get(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, 2, logs.FilterMessage("overlay backends diverged").Len())
+		}
+	})
+}
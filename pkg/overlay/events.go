@@ -0,0 +1,19 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"context"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+// EventsDB stores the lifecycle events of nodes
+type EventsDB interface {
+	// Record adds a new event for the node
+	Record(ctx context.Context, nodeID storj.NodeID, eventType pb.NodeEventType, data string) error
+	// List returns up to limit events with an id greater than cursor, for all nodes when nodeID is zero
+	List(ctx context.Context, nodeID storj.NodeID, cursor int64, limit int) ([]*pb.NodeEvent, error)
+}
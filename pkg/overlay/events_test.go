@@ -0,0 +1,85 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestNodeEvents(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		cache := overlay.NewCache(db.OverlayCache(), db.StatDB(), overlay.NodeSelectionConfig{})
+		inspector := overlay.NewInspector(overlay.NewServer(zaptest.NewLogger(t), cache, overlay.NodeSelectionConfig{}, nil, nil), db.NodeEvents())
+
+		node1 := storj.NodeID{1}
+		node2 := storj.NodeID{2}
+
+		require.NoError(t, cache.Put(ctx, node1, pb.Node{Id: node1, Address: &pb.NodeAddress{Address: "127.0.0.1:1"}}))
+		require.NoError(t, cache.Put(ctx, node2, pb.Node{Id: node2, Address: &pb.NodeAddress{Address: "127.0.0.1:2"}}))
+		// unchanged address doesn't create an event
+		require.NoError(t, cache.Put(ctx, node1, pb.Node{Id: node1, Address: &pb.NodeAddress{Address: "127.0.0.1:1"}}))
+		require.NoError(t, cache.Put(ctx, node1, pb.Node{Id: node1, Address: &pb.NodeAddress{Address: "127.0.0.1:3"}}))
+		require.NoError(t, db.NodeEvents().Record(ctx, node2, pb.NodeEventType_DISQUALIFIED, ""))
+
+		{ // all nodes
+			res, err := inspector.NodeEvents(ctx, &pb.NodeEventsRequest{})
+			require.NoError(t, err)
+			assert.False(t, res.More)
+			require.Len(t, res.Events, 4)
+
+			expected := []struct {
+				id        storj.NodeID
+				eventType pb.NodeEventType
+				data      string
+			}{
+				{node1, pb.NodeEventType_FIRST_CONTACT, "127.0.0.1:1"},
+				{node2, pb.NodeEventType_FIRST_CONTACT, "127.0.0.1:2"},
+				{node1, pb.NodeEventType_ADDRESS_CHANGED, "127.0.0.1:3"},
+				{node2, pb.NodeEventType_DISQUALIFIED, ""},
+			}
+			for i, event := range res.Events {
+				assert.Equal(t, expected[i].id, event.NodeId)
+				assert.Equal(t, expected[i].eventType, event.Type)
+				assert.Equal(t, expected[i].data, event.Data)
+				assert.NotNil(t, event.CreatedAt)
+			}
+		}
+
+		{ // single node with pagination
+			res, err := inspector.NodeEvents(ctx, &pb.NodeEventsRequest{NodeId: node1, Limit: 1})
+			require.NoError(t, err)
+			assert.True(t, res.More)
+			require.Len(t, res.Events, 1)
+			assert.Equal(t, pb.NodeEventType_FIRST_CONTACT, res.Events[0].Type)
+
+			token := res.NextPageToken
+			assert.NotEmpty(t, token)
+
+			res, err = inspector.NodeEvents(ctx, &pb.NodeEventsRequest{NodeId: node1, PageToken: token, Limit: 1})
+			require.NoError(t, err)
+			assert.False(t, res.More)
+			assert.Empty(t, res.NextPageToken)
+			require.Len(t, res.Events, 1)
+			assert.Equal(t, pb.NodeEventType_ADDRESS_CHANGED, res.Events[0].Type)
+
+			// tokens are bound to the node filter
+			_, err = inspector.NodeEvents(ctx, &pb.NodeEventsRequest{PageToken: token, Limit: 1})
+			assert.Error(t, err)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/storj"
+)
+
+// healthTimeout is how long the health check waits for the cache database
+const healthTimeout = 5 * time.Second
+
+// Health is the response served by the /health endpoint
+type Health struct {
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+	Overlay Stats  `json:"overlay"`
+}
+
+// HealthCheck is an additional check of the /health endpoint
+type HealthCheck func(ctx context.Context) error
+
+// HealthHandler returns an http.Handler reporting whether the cache database
+// is reachable and all checks pass, along with the cache stats. Failures are
+// reported with 503 Service Unavailable so that load balancers can take the
+// satellite out.
+func HealthHandler(cache *Cache, checks ...HealthCheck) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), healthTimeout)
+		defer cancel()
+
+		var group errs.Group
+		_, err := cache.db.List(ctx, storj.NodeID{}, 1)
+		group.Add(err)
+		for _, check := range checks {
+			group.Add(check(ctx))
+		}
+
+		health := Health{Status: "ok"}
+		code := http.StatusOK
+		if err := group.Err(); err != nil {
+			health.Status, health.Error = "unavailable", err.Error()
+			code = http.StatusServiceUnavailable
+		}
+		health.Overlay = cache.Stats()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(health)
+	})
+}
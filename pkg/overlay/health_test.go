@@ -0,0 +1,44 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/overlay/overlaytest"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestHealthHandler(t *testing.T) {
+	ctx := context.Background()
+
+	cache, _ := overlaytest.NewCache(overlay.NodeSelectionConfig{}, make([]overlaytest.NodeSpec, 2)...)
+
+	_, err := cache.Get(ctx, overlaytest.NodeID(0))
+	require.NoError(t, err)
+	_, err = cache.Get(ctx, overlaytest.NodeID(5))
+	assert.Equal(t, overlay.ErrNodeNotFound, err)
+	_, err = cache.GetAll(ctx, storj.NodeIDList{overlaytest.NodeID(1), overlaytest.NodeID(6), overlaytest.NodeID(7)})
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	overlay.HealthHandler(cache).ServeHTTP(recorder, httptest.NewRequest("GET", "/health", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+	var health overlay.Health
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&health))
+	assert.Equal(t, "ok", health.Status)
+	assert.Empty(t, health.Error)
+	assert.EqualValues(t, 2, health.Overlay.LookupHits)
+	assert.EqualValues(t, 3, health.Overlay.LookupMisses)
+}
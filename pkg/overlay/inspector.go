@@ -5,18 +5,44 @@ package overlay
 
 import (
 	"context"
+	"encoding/binary"
+	"sync"
 
+	"storj.io/storj/pkg/pagination"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+const (
+	defaultNodeEventsLimit = 100
+	maxNodeEventsLimit     = 1000
+
+	defaultExplainSelectionLimit = 100
+	maxExplainSelectionLimit     = 1000
 )
 
 // Inspector is a gRPC service for inspecting overlay cache internals
 type Inspector struct {
-	cache *Cache
+	cache  *Cache
+	server *Server
+	events EventsDB
+
+	pagesOnce sync.Once
+	pages     *pagination.Signer
+	pagesErr  error
 }
 
 // NewInspector creates an Inspector
-func NewInspector(cache *Cache) *Inspector {
-	return &Inspector{cache: cache}
+func NewInspector(server *Server, events EventsDB) *Inspector {
+	return &Inspector{cache: server.cache, server: server, events: events}
+}
+
+// signer returns the signer of page tokens, which is created on first use
+func (srv *Inspector) signer() (*pagination.Signer, error) {
+	srv.pagesOnce.Do(func() {
+		srv.pages, srv.pagesErr = pagination.NewRandomSigner()
+	})
+	return srv.pages, srv.pagesErr
 }
 
 // CountNodes returns the number of nodes in the cache
@@ -30,3 +56,129 @@ func (srv *Inspector) CountNodes(ctx context.Context, req *pb.CountNodesRequest)
 		Count: int64(len(overlayKeys)),
 	}, nil
 }
+
+// NodeEvents returns a page of node lifecycle events
+func (srv *Inspector) NodeEvents(ctx context.Context, req *pb.NodeEventsRequest) (*pb.NodeEventsResponse, error) {
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultNodeEventsLimit
+	}
+	if limit > maxNodeEventsLimit {
+		limit = maxNodeEventsLimit
+	}
+
+	pages, err := srv.signer()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	// events are listed by id, the token is bound to the node filter
+	listing := "node-events/" + req.NodeId.String()
+	position, err := pages.Decode(listing, req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	var cursor int64
+	if position != nil {
+		if len(position) != 8 {
+			return nil, pagination.Error.New("invalid page token")
+		}
+		cursor = int64(binary.BigEndian.Uint64(position))
+	}
+
+	// fetch one extra event to find out whether there are more
+	events, err := srv.events.List(ctx, req.NodeId, cursor, limit+1)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	resp := &pb.NodeEventsResponse{Events: events}
+	if len(events) > limit {
+		resp.Events, resp.More = events[:limit], true
+
+		var last [8]byte
+		binary.BigEndian.PutUint64(last[:], uint64(resp.Events[limit-1].Id))
+		resp.NextPageToken = pages.Encode(listing, last[:])
+	}
+	return resp, nil
+}
+
+// ExplainSelection reports for the first nodes in the cache which selection filter excludes them
+func (srv *Inspector) ExplainSelection(ctx context.Context, req *pb.ExplainSelectionRequest) (*pb.ExplainSelectionResponse, error) {
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultExplainSelectionLimit
+	}
+	if limit > maxExplainSelectionLimit {
+		limit = maxExplainSelectionLimit
+	}
+
+	pages, err := srv.signer()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	// nodes are listed by id
+	const listing = "explain-selection"
+	position, err := pages.Decode(listing, req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	var after storj.NodeID
+	if position != nil {
+		after, err = storj.NodeIDFromBytes(position)
+		if err != nil {
+			return nil, pagination.Error.New("invalid page token")
+		}
+	}
+
+	tags := tagFilter{required: req.GetTags(), excluded: req.GetExcludedTags()}
+	resp, err := srv.server.explain(ctx, req.GetRestrictions(), req.ExcludedNodes, tags, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	if resp.More {
+		resp.NextPageToken = pages.Encode(listing, resp.Nodes[len(resp.Nodes)-1].NodeId.Bytes())
+	}
+	return resp, nil
+}
+
+// NodeVetting returns the vetting state of a node and the statistics it's based on
+func (srv *Inspector) NodeVetting(ctx context.Context, req *pb.NodeVettingRequest) (*pb.NodeVettingResponse, error) {
+	vetting := srv.server.Vetting()
+	if vetting == nil {
+		return nil, Error.New("node vetting is disabled")
+	}
+
+	node, err := srv.cache.Get(ctx, req.NodeId)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return &pb.NodeVettingResponse{
+		Vetting: vetting.Inspect(node),
+	}, nil
+}
+
+// SetNodeTags sets the operator tags of a node
+func (srv *Inspector) SetNodeTags(ctx context.Context, req *pb.SetNodeTagsRequest) (*pb.SetNodeTagsResponse, error) {
+	if srv.server.lists == nil {
+		return nil, Error.New("node lists are disabled")
+	}
+	if err := srv.server.lists.SetTags(req.NodeId, req.GetTags()); err != nil {
+		return nil, err
+	}
+
+	var signed []*pb.NodeTag
+	node, err := srv.cache.Get(ctx, req.NodeId)
+	switch {
+	case err == nil:
+		signed = node.GetTags().GetTags()
+	case err != ErrNodeNotFound:
+		return nil, Error.Wrap(err)
+	}
+
+	return &pb.SetNodeTagsResponse{
+		Tags: mergeTags(signed, req.GetTags()),
+	}, nil
+}
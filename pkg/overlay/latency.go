@@ -0,0 +1,179 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/storj"
+)
+
+const (
+	// latencyWeight is the weight of a new round trip in the moving average
+	latencyWeight = 0.2
+	// maxLatencyRegions is the number of regions round trips are kept for,
+	// round trips observed from further regions are dropped
+	maxLatencyRegions = 64
+	// maxLatencyNodes is the number of nodes round trips are kept for per
+	// region
+	maxLatencyNodes = 100000
+	// maxObservedLatency is the longest round trip which is recorded, longer
+	// ones are measurement errors or stalled transfers
+	maxObservedLatency = time.Minute
+	// maxLatencyReporters is the number of uplinks whose regions are kept,
+	// uplinks which didn't report yet are refused beyond it
+	maxLatencyReporters = 100000
+)
+
+// Latencies are the round trips observed to nodes from the regions of their
+// observers: the pings and transfers of the satellite from its own region
+// and the transfers reported by uplinks from theirs. The round trips to a
+// node from a region are averaged exponentially.
+type Latencies struct {
+	mu        sync.Mutex
+	byRegion  map[string]map[storj.NodeID]time.Duration
+	reporters map[storj.NodeID]string
+}
+
+// NewLatencies returns an empty set of round trips
+func NewLatencies() *Latencies {
+	return &Latencies{
+		byRegion:  make(map[string]map[storj.NodeID]time.Duration),
+		reporters: make(map[storj.NodeID]string),
+	}
+}
+
+// Reporter ties an uplink to the region it reports round trips from the first
+// time it reports and returns false when it reports from another region
+// later on, or when too many uplinks reported already
+func (latencies *Latencies) Reporter(id storj.NodeID, region string) bool {
+	latencies.mu.Lock()
+	defer latencies.mu.Unlock()
+
+	bound, ok := latencies.reporters[id]
+	if !ok {
+		if len(latencies.reporters) >= maxLatencyReporters {
+			mon.Event("latency_reporter_dropped")
+			return false
+		}
+		latencies.reporters[id] = region
+		return true
+	}
+	return bound == region
+}
+
+// Observe records a round trip to a node observed from region
+func (latencies *Latencies) Observe(region string, id storj.NodeID, rtt time.Duration) {
+	if latencies == nil || rtt <= 0 || rtt > maxObservedLatency {
+		return
+	}
+
+	latencies.mu.Lock()
+	defer latencies.mu.Unlock()
+
+	nodes, ok := latencies.byRegion[region]
+	if !ok {
+		if len(latencies.byRegion) >= maxLatencyRegions {
+			mon.Event("latency_region_dropped")
+			return
+		}
+		nodes = make(map[storj.NodeID]time.Duration)
+		latencies.byRegion[region] = nodes
+	}
+
+	average, ok := nodes[id]
+	if !ok {
+		if len(nodes) >= maxLatencyNodes {
+			mon.Event("latency_node_dropped")
+			return
+		}
+		nodes[id] = rtt
+		return
+	}
+	nodes[id] = average + time.Duration(latencyWeight*float64(rtt-average))
+}
+
+// Latency returns the average round trip to a node observed from region, or
+// false when none was observed
+func (latencies *Latencies) Latency(region string, id storj.NodeID) (time.Duration, bool) {
+	if latencies == nil {
+		return 0, false
+	}
+
+	latencies.mu.Lock()
+	defer latencies.mu.Unlock()
+
+	latency, ok := latencies.byRegion[region][id]
+	return latency, ok
+}
+
+// LatencyReporter collects the round trips of an uplink's transfers and
+// reports them to the satellite for the uplink's region, batch round trips
+// at a time
+type LatencyReporter struct {
+	log    *zap.Logger
+	client Client
+	region string
+	batch  int
+
+	mu      sync.Mutex
+	pending map[storj.NodeID]time.Duration
+	sending sync.WaitGroup
+}
+
+// NewLatencyReporter returns a reporter of the round trips observed from
+// region
+func NewLatencyReporter(log *zap.Logger, client Client, region string, batch int) *LatencyReporter {
+	return &LatencyReporter{
+		log:     log,
+		client:  client,
+		region:  region,
+		batch:   batch,
+		pending: make(map[storj.NodeID]time.Duration),
+	}
+}
+
+// Observe adds the round trip of a transfer to the next report, which is
+// sent in the background once it is full. Only the last round trip to a
+// node is reported.
+func (reporter *LatencyReporter) Observe(id storj.NodeID, rtt time.Duration) {
+	reporter.mu.Lock()
+	reporter.pending[id] = rtt
+	if len(reporter.pending) < reporter.batch {
+		reporter.mu.Unlock()
+		return
+	}
+	pending := reporter.pending
+	reporter.pending = make(map[storj.NodeID]time.Duration)
+	reporter.sending.Add(1)
+	reporter.mu.Unlock()
+
+	go func() {
+		defer reporter.sending.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := reporter.client.ReportLatency(ctx, reporter.region, pending); err != nil {
+			reporter.log.Debug("reporting latencies failed", zap.Error(err))
+		}
+	}()
+}
+
+// Flush reports the collected round trips and waits for the reports sent in
+// the background
+func (reporter *LatencyReporter) Flush(ctx context.Context) error {
+	reporter.mu.Lock()
+	pending := reporter.pending
+	reporter.pending = make(map[storj.NodeID]time.Duration)
+	reporter.mu.Unlock()
+
+	defer reporter.sending.Wait()
+	if len(pending) == 0 {
+		return nil
+	}
+	return reporter.client.ReportLatency(ctx, reporter.region, pending)
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 
@@ -76,3 +77,15 @@ func (m *MockClient) BulkLookup(ctx context.Context, nodeIDs storj.NodeIDList) (
 func (mr *MockClientMockRecorder) BulkLookup(ctx, nodeIDs interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkLookup", reflect.TypeOf((*MockClient)(nil).BulkLookup), ctx, nodeIDs)
 }
+
+// ReportLatency mocks base method
+func (m *MockClient) ReportLatency(ctx context.Context, region string, latencies map[storj.NodeID]time.Duration) error {
+	ret := m.ctrl.Call(m, "ReportLatency", ctx, region, latencies)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReportLatency indicates an expected call of ReportLatency
+func (mr *MockClientMockRecorder) ReportLatency(ctx, region, latencies interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportLatency", reflect.TypeOf((*MockClient)(nil).ReportLatency), ctx, region, latencies)
+}
@@ -64,11 +64,24 @@ func (mo *Overlay) BulkLookup(ctx context.Context, reqs *pb.LookupRequests) (
 		// NOTE (Dylan): tests did not catch missing node case, need updating
 		n := mo.nodes[r.NodeId]
 		resp := &pb.LookupResponse{Node: n}
+		if n == nil {
+			resp.Status = pb.LookupStatus_NOT_FOUND
+		}
 		responses = append(responses, resp)
 	}
 	return &pb.LookupResponses{LookupResponse: responses}, nil
 }
 
+// AnnounceExit is not supported by the mock overlay
+func (mo *Overlay) AnnounceExit(ctx context.Context, req *pb.AnnounceExitRequest) (*pb.AnnounceExitResponse, error) {
+	return nil, errs.New("node exits aren't supported")
+}
+
+// ReportLatency ignores the reported round trips
+func (mo *Overlay) ReportLatency(ctx context.Context, req *pb.LatencyReport) (*pb.LatencyReportResponse, error) {
+	return &pb.LatencyReportResponse{}, nil
+}
+
 // Config specifies static nodes for mock overlay
 type Config struct {
 	Nodes string `help:"a comma-separated list of <node-id>:<ip>:<port>" default:""`
@@ -0,0 +1,197 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/watchdog"
+)
+
+// unknownCountry counts the nodes which don't publish their country
+const unknownCountry = "unknown"
+
+// NetworkConfig is a configuration struct for the public network totals
+type NetworkConfig struct {
+	Address    string        `help:"address to serve the public HTTP /stats/network endpoint on, empty disables it" default:""`
+	Interval   time.Duration `help:"how frequently the public network totals are computed" default:"15m0s"`
+	CountryTag string        `help:"the node tag publishing the country of a node" default:"country"`
+}
+
+// NetworkTotals are the public totals of the reachable storage nodes
+type NetworkTotals struct {
+	Nodes int64 `json:"nodes"`
+	// StoredBytes is the data stored on all nodes according to the last tally
+	StoredBytes   int64            `json:"storedBytes"`
+	FreeDisk      int64            `json:"freeDisk"`
+	FreeBandwidth int64            `json:"freeBandwidth"`
+	Countries     map[string]int64 `json:"countries"`
+	ComputedAt    time.Time        `json:"computedAt"`
+}
+
+// Network periodically computes the network totals served to public stats
+// pages, so that dashboards don't need to query the inspectors. The totals
+// are served over HTTP with caching headers, so that they can be cached by
+// proxies until the next computation.
+type Network struct {
+	log    *zap.Logger
+	cache  *Cache
+	config NetworkConfig
+	loop   *watchdog.Loop
+
+	mu      sync.Mutex
+	totals  *NetworkTotals
+	encoded []byte
+	etag    string
+}
+
+// NewNetwork creates a new network totals service
+func NewNetwork(log *zap.Logger, cache *Cache, config NetworkConfig, loop *watchdog.Loop) *Network {
+	return &Network{log: log, cache: cache, config: config, loop: loop}
+}
+
+// Serve serves the totals at /stats/network on listener until ctx is canceled
+func (network *Network) Serve(ctx context.Context, listener net.Listener) error {
+	mux := http.NewServeMux()
+	mux.Handle("/stats/network", network)
+	server := &http.Server{Handler: mux}
+
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+
+	err := server.Serve(listener)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// Run computes the totals every interval until ctx is canceled
+func (network *Network) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ticker := time.NewTicker(network.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		err := network.Update(ctx)
+		if err != nil {
+			network.log.Error("computing network totals failed", zap.Error(err))
+		}
+		network.loop.Cycle(err)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Update computes the totals from the reachable storage nodes
+func (network *Network) Update(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodes, err := network.cache.Reachable(ctx)
+	if err != nil {
+		return err
+	}
+
+	totals := &NetworkTotals{
+		Nodes:      int64(len(nodes)),
+		Countries:  make(map[string]int64),
+		ComputedAt: time.Now().UTC(),
+	}
+	totals.StoredBytes, _ = network.cache.StoredBytes()
+	for _, node := range nodes {
+		totals.FreeDisk += node.GetRestrictions().GetFreeDisk()
+		totals.FreeBandwidth += node.GetRestrictions().GetFreeBandwidth()
+
+		country := unknownCountry
+		for _, tag := range node.GetTags().GetTags() {
+			if tag.Name == network.config.CountryTag && tag.Value != "" {
+				country = strings.ToUpper(tag.Value)
+				break
+			}
+		}
+		totals.Countries[country]++
+	}
+
+	encoded, err := json.Marshal(totals)
+	if err != nil {
+		return err
+	}
+	hash := fnv.New64a()
+	_, _ = hash.Write(encoded)
+
+	mon.IntVal("network_nodes").Observe(totals.Nodes)
+	mon.IntVal("network_free_disk").Observe(totals.FreeDisk)
+
+	network.mu.Lock()
+	defer network.mu.Unlock()
+
+	network.totals = totals
+	network.encoded = encoded
+	network.etag = fmt.Sprintf(`"%x"`, hash.Sum64())
+	return nil
+}
+
+// Totals returns the last computed totals and whether they were computed
+func (network *Network) Totals() (NetworkTotals, bool) {
+	network.mu.Lock()
+	defer network.mu.Unlock()
+
+	if network.totals == nil {
+		return NetworkTotals{}, false
+	}
+	totals := *network.totals
+	totals.Countries = make(map[string]int64, len(network.totals.Countries))
+	for country, count := range network.totals.Countries {
+		totals.Countries[country] = count
+	}
+	return totals, true
+}
+
+// ServeHTTP serves the last computed totals as JSON, which may be cached
+// until the next computation. Until the first computation 503 Service
+// Unavailable is returned.
+func (network *Network) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	network.mu.Lock()
+	encoded, etag, totals := network.encoded, network.etag, network.totals
+	network.mu.Unlock()
+
+	if totals == nil {
+		http.Error(w, "network totals weren't computed yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	maxAge := time.Until(totals.ComputedAt.Add(network.config.Interval))
+	if maxAge < 0 {
+		maxAge = 0
+	}
+
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Last-Modified", totals.ComputedAt.Format(http.TimeFormat))
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(encoded)
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/overlay/overlaytest"
+	"storj.io/storj/pkg/pb"
+)
+
+func TestNetworkTotals(t *testing.T) {
+	ctx := context.Background()
+
+	cache, db := overlaytest.NewCache(overlay.NodeSelectionConfig{OfflineGracePeriod: time.Hour},
+		overlaytest.NodeSpec{FreeDisk: 100, FreeBandwidth: 10},
+		overlaytest.NodeSpec{FreeDisk: 200, FreeBandwidth: 20},
+		overlaytest.NodeSpec{FreeDisk: 300, FreeBandwidth: 30},
+		overlaytest.NodeSpec{FreeDisk: 400, Type: pb.NodeType_SATELLITE},
+	)
+	for i, country := range []string{"de", "DE"} {
+		node, err := db.Get(ctx, overlaytest.NodeID(i))
+		require.NoError(t, err)
+		node.Tags = &pb.SignedNodeTags{Tags: []*pb.NodeTag{{Name: "country", Value: country}}}
+		require.NoError(t, db.Update(ctx, node))
+	}
+	cache.ReportStoredBytes(1234)
+
+	network := overlay.NewNetwork(zap.NewNop(), cache, overlay.NetworkConfig{Interval: time.Minute, CountryTag: "country"}, nil)
+
+	recorder := httptest.NewRecorder()
+	network.ServeHTTP(recorder, httptest.NewRequest("GET", "/stats/network", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	require.NoError(t, network.Update(ctx))
+
+	totals, ok := network.Totals()
+	require.True(t, ok)
+	assert.EqualValues(t, 3, totals.Nodes)
+	assert.EqualValues(t, 1234, totals.StoredBytes)
+	assert.EqualValues(t, 600, totals.FreeDisk)
+	assert.EqualValues(t, 60, totals.FreeBandwidth)
+	assert.Equal(t, map[string]int64{"DE": 2, "unknown": 1}, totals.Countries)
+
+	recorder = httptest.NewRecorder()
+	network.ServeHTTP(recorder, httptest.NewRequest("GET", "/stats/network", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Header().Get("Cache-Control"), "public, max-age=")
+
+	var served overlay.NetworkTotals
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&served))
+	assert.Equal(t, totals.Countries, served.Countries)
+	assert.Equal(t, totals.Nodes, served.Nodes)
+
+	{ // unchanged totals aren't sent again
+		request := httptest.NewRequest("GET", "/stats/network", nil)
+		request.Header.Set("If-None-Match", recorder.Header().Get("ETag"))
+		recorder = httptest.NewRecorder()
+		network.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusNotModified, recorder.Code)
+		assert.Empty(t, recorder.Body.Bytes())
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/utils"
+)
+
+// NodeLists contains the node IDs operators have blacklisted or whitelisted.
+// Blacklisted nodes are never selected nor returned by lookups; when the
+// whitelist isn't empty only whitelisted nodes are selected for storage.
+// Operators can also tag nodes, e.g. to keep a private pool of nodes or to
+// roll out changes to some nodes first, and the operator tags replace the
+// tags of the same name which the nodes signed.
+type NodeLists struct {
+	log           *zap.Logger
+	blacklistFile string
+	whitelistFile string
+	tagsFile      string
+
+	mu        sync.RWMutex
+	blacklist map[storj.NodeID]struct{}
+	whitelist map[storj.NodeID]struct{}
+	tags      map[storj.NodeID][]*pb.NodeTag
+}
+
+// NewNodeLists loads the node lists and the operator tags from the given
+// files. Empty file names result in empty lists.
+func NewNodeLists(log *zap.Logger, blacklistFile, whitelistFile, tagsFile string) (*NodeLists, error) {
+	lists := &NodeLists{
+		log:           log,
+		blacklistFile: blacklistFile,
+		whitelistFile: whitelistFile,
+		tagsFile:      tagsFile,
+	}
+	return lists, lists.Reload()
+}
+
+// Reload reads the node lists from disk again. The current lists are kept
+// when any file can't be loaded.
+func (lists *NodeLists) Reload() error {
+	blacklist, err := loadNodeList(lists.blacklistFile)
+	if err != nil {
+		return Error.New("unable to load blacklist %q: %v", lists.blacklistFile, err)
+	}
+	whitelist, err := loadNodeList(lists.whitelistFile)
+	if err != nil {
+		return Error.New("unable to load whitelist %q: %v", lists.whitelistFile, err)
+	}
+	tags, err := loadNodeTags(lists.tagsFile)
+	if err != nil {
+		return Error.New("unable to load node tags %q: %v", lists.tagsFile, err)
+	}
+
+	lists.mu.Lock()
+	defer lists.mu.Unlock()
+
+	lists.blacklist, lists.whitelist, lists.tags = blacklist, whitelist, tags
+	return nil
+}
+
+// Run reloads the node lists whenever the process receives SIGHUP, until the
+// context is canceled
+func (lists *NodeLists) Run(ctx context.Context) error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-signals:
+			if err := lists.Reload(); err != nil {
+				lists.log.Error("node lists not reloaded", zap.Error(err))
+				continue
+			}
+			blacklisted, whitelisted := lists.Len()
+			lists.log.Info("node lists reloaded", zap.Int("blacklisted", blacklisted), zap.Int("whitelisted", whitelisted), zap.Int("tagged", lists.Tagged()))
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Len returns the number of blacklisted and whitelisted nodes
+func (lists *NodeLists) Len() (blacklisted, whitelisted int) {
+	if lists == nil {
+		return 0, 0
+	}
+
+	lists.mu.RLock()
+	defer lists.mu.RUnlock()
+
+	return len(lists.blacklist), len(lists.whitelist)
+}
+
+// Blacklisted returns whether the node is blacklisted
+func (lists *NodeLists) Blacklisted(id storj.NodeID) bool {
+	if lists == nil {
+		return false
+	}
+
+	lists.mu.RLock()
+	defer lists.mu.RUnlock()
+
+	_, ok := lists.blacklist[id]
+	return ok
+}
+
+// Whitelisted returns whether the node may be selected according to the
+// whitelist, which is the case for all nodes when the whitelist is empty
+func (lists *NodeLists) Whitelisted(id storj.NodeID) bool {
+	if lists == nil {
+		return true
+	}
+
+	lists.mu.RLock()
+	defer lists.mu.RUnlock()
+
+	if len(lists.whitelist) == 0 {
+		return true
+	}
+	_, ok := lists.whitelist[id]
+	return ok
+}
+
+// Tagged returns the number of nodes with operator tags
+func (lists *NodeLists) Tagged() int {
+	if lists == nil {
+		return 0
+	}
+
+	lists.mu.RLock()
+	defer lists.mu.RUnlock()
+
+	return len(lists.tags)
+}
+
+// Tags returns the operator tags of the node
+func (lists *NodeLists) Tags(id storj.NodeID) []*pb.NodeTag {
+	if lists == nil {
+		return nil
+	}
+
+	lists.mu.RLock()
+	defer lists.mu.RUnlock()
+
+	return lists.tags[id]
+}
+
+// SetTags replaces the operator tags of the node, no tags remove them. The
+// tags are saved to the tags file, so they are kept across restarts.
+func (lists *NodeLists) SetTags(id storj.NodeID, tags []*pb.NodeTag) error {
+	if err := checkTags(tags); err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		if tag.Name == "" || strings.ContainsAny(tag.Name+tag.Value, ",= \t\n") {
+			return TagsError.New("tag %q=%q can't be saved", tag.Name, tag.Value)
+		}
+	}
+
+	lists.mu.Lock()
+	defer lists.mu.Unlock()
+
+	if lists.tagsFile == "" {
+		return Error.New("no node tags file configured")
+	}
+
+	updated := make(map[storj.NodeID][]*pb.NodeTag, len(lists.tags)+1)
+	for other, otherTags := range lists.tags {
+		updated[other] = otherTags
+	}
+	if len(tags) > 0 {
+		updated[id] = tags
+	} else {
+		delete(updated, id)
+	}
+
+	if err := saveNodeTags(lists.tagsFile, updated); err != nil {
+		return Error.New("unable to save node tags %q: %v", lists.tagsFile, err)
+	}
+	lists.tags = updated
+	return nil
+}
+
+// loadNodeList reads node IDs from path, one per line. Blank lines and lines
+// starting with '#' are ignored.
+func loadNodeList(path string) (map[storj.NodeID]struct{}, error) {
+	list := make(map[storj.NodeID]struct{})
+	if path == "" {
+		return list, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer utils.LogClose(file)
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		id, err := storj.NodeIDFromString(text)
+		if err != nil {
+			return nil, Error.New("line %d: %v", line, err)
+		}
+		list[id] = struct{}{}
+	}
+	return list, scanner.Err()
+}
+
+// loadNodeTags reads the operator tags of nodes from path, one node per line
+// followed by its tags in the format of ParseTags, e.g.
+// "<node id> pool=private,canary=true". Blank lines and lines starting with
+// '#' are ignored.
+func loadNodeTags(path string) (map[storj.NodeID][]*pb.NodeTag, error) {
+	tags := make(map[storj.NodeID][]*pb.NodeTag)
+	if path == "" {
+		return tags, nil
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		// created by the first SetTags
+		return tags, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer utils.LogClose(file)
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(text, " ", 2)
+		id, err := storj.NodeIDFromString(fields[0])
+		if err != nil {
+			return nil, Error.New("line %d: %v", line, err)
+		}
+		var nodeTags []*pb.NodeTag
+		if len(fields) > 1 {
+			nodeTags, err = ParseTags(fields[1])
+			if err != nil {
+				return nil, Error.New("line %d: %v", line, err)
+			}
+		}
+		if len(nodeTags) > 0 {
+			tags[id] = nodeTags
+		}
+	}
+	return tags, scanner.Err()
+}
+
+// saveNodeTags replaces the file at path with the operator tags in the format
+// loadNodeTags reads
+func saveNodeTags(path string, tags map[storj.NodeID][]*pb.NodeTag) (err error) {
+	ids := make([]string, 0, len(tags))
+	lines := make(map[string]string, len(tags))
+	for id, nodeTags := range tags {
+		formatted := make([]string, 0, len(nodeTags))
+		for _, tag := range nodeTags {
+			formatted = append(formatted, tag.Name+"="+tag.Value)
+		}
+		ids = append(ids, id.String())
+		lines[id.String()] = strings.Join(formatted, ",")
+	}
+	sort.Strings(ids)
+
+	file, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(file.Name())
+		}
+	}()
+
+	writer := bufio.NewWriter(file)
+	for _, id := range ids {
+		_, _ = fmt.Fprintf(writer, "%s %s\n", id, lines[id])
+	}
+	if err := writer.Flush(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
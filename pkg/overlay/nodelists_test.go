@@ -0,0 +1,78 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/overlay"
+)
+
+func TestNodeLists(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	node1 := teststorj.NodeIDFromString("node1")
+	node2 := teststorj.NodeIDFromString("node2")
+	node3 := teststorj.NodeIDFromString("node3")
+
+	blacklist := ctx.File("blacklist")
+	whitelist := ctx.File("whitelist")
+	write := func(path string, lines ...string) {
+		require.NoError(t, ioutil.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644))
+	}
+
+	{ // no files
+		lists, err := overlay.NewNodeLists(zaptest.NewLogger(t), "", "", "")
+		require.NoError(t, err)
+		assert.False(t, lists.Blacklisted(node1))
+		assert.True(t, lists.Whitelisted(node1))
+	}
+
+	{ // nil lists allow everything
+		var lists *overlay.NodeLists
+		assert.False(t, lists.Blacklisted(node1))
+		assert.True(t, lists.Whitelisted(node1))
+	}
+
+	write(blacklist, "# misbehaving", node1.String(), "")
+	write(whitelist, node1.String(), "  "+node2.String()+"  ")
+
+	lists, err := overlay.NewNodeLists(zaptest.NewLogger(t), blacklist, whitelist, "")
+	require.NoError(t, err)
+
+	blacklisted, whitelisted := lists.Len()
+	assert.Equal(t, 1, blacklisted)
+	assert.Equal(t, 2, whitelisted)
+	assert.True(t, lists.Blacklisted(node1))
+	assert.False(t, lists.Blacklisted(node2))
+	assert.True(t, lists.Whitelisted(node2))
+	assert.False(t, lists.Whitelisted(node3))
+
+	{ // reload picks up changes
+		write(blacklist, node2.String())
+		write(whitelist)
+		require.NoError(t, lists.Reload())
+		assert.False(t, lists.Blacklisted(node1))
+		assert.True(t, lists.Blacklisted(node2))
+		assert.True(t, lists.Whitelisted(node3))
+	}
+
+	{ // invalid files keep the current lists
+		write(blacklist, "not a node id")
+		assert.Error(t, lists.Reload())
+		assert.True(t, lists.Blacklisted(node2))
+
+		_, err := overlay.NewNodeLists(zaptest.NewLogger(t), ctx.File("missing"), "", "")
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package overlaytest builds overlay caches from declarative node specs, so
+// node selection can be tested without starting a network.
+package overlaytest
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
+)
+
+// NodeSpec declares a node of the fixture. Zero values of ID, Address and
+// Type are replaced by defaults derived from the position of the spec.
+type NodeSpec struct {
+	ID      storj.NodeID
+	Address string
+	Type    pb.NodeType
+
+	FreeBandwidth int64
+	FreeDisk      int64
+
+	AuditCount       int64
+	AuditReputation  float64
+	UptimeCount      int64
+	UptimeReputation float64
+
+	Offline  bool
+	Metadata *pb.NodeMetadata
+}
+
+// NodeID returns the default id of the node at index, ids sort in index order
+func NodeID(index int) storj.NodeID {
+	var id storj.NodeID
+	binary.BigEndian.PutUint32(id[:4], uint32(index+1))
+	return id
+}
+
+// Node converts the spec at index into a node
+func (spec NodeSpec) Node(index int) *pb.Node {
+	id := spec.ID
+	if id.IsZero() {
+		id = NodeID(index)
+	}
+	address := spec.Address
+	if address == "" {
+		address = fmt.Sprintf("127.0.0.1:%d", 10000+index)
+	}
+	nodeType := spec.Type
+	if nodeType == pb.NodeType_INVALID {
+		nodeType = pb.NodeType_STORAGE
+	}
+
+	return &pb.Node{
+		Id:      id,
+		Address: &pb.NodeAddress{Address: address},
+		Type:    nodeType,
+		Restrictions: &pb.NodeRestrictions{
+			FreeBandwidth: spec.FreeBandwidth,
+			FreeDisk:      spec.FreeDisk,
+		},
+		Reputation: &pb.NodeStats{
+			NodeId:           id,
+			AuditCount:       spec.AuditCount,
+			AuditReputation:  spec.AuditReputation,
+			UptimeCount:      spec.UptimeCount,
+			UptimeReputation: spec.UptimeReputation,
+		},
+		Metadata: spec.Metadata,
+		IsUp:     !spec.Offline,
+	}
+}
+
+// NewCache creates an overlay cache containing the nodes described by specs.
+// The cache has no statdb, so nodes should be changed through the returned DB
+// instead of Cache.Put.
+func NewCache(config overlay.NodeSelectionConfig, specs ...NodeSpec) (*overlay.Cache, *DB) {
+	db := NewDB()
+	for i, spec := range specs {
+		db.put(spec.Node(i))
+	}
+	return overlay.NewCache(db, nil, config), db
+}
+
+// DB is an in-memory overlay.DB
+type DB struct {
+	mu       sync.Mutex
+	nodes    map[storj.NodeID]*pb.Node
+	failures map[storj.NodeID]error
+}
+
+var _ overlay.DB = (*DB)(nil)
+
+// NewDB creates an in-memory overlay.DB containing nodes
+func NewDB(nodes ...*pb.Node) *DB {
+	db := &DB{
+		nodes:    make(map[storj.NodeID]*pb.Node),
+		failures: make(map[storj.NodeID]error),
+	}
+	for _, node := range nodes {
+		db.put(node)
+	}
+	return db
+}
+
+func (db *DB) put(node *pb.Node) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.nodes[node.Id] = clone(node)
+}
+
+// Fail makes lookups of nodeID fail with err, or succeed again when err is nil
+func (db *DB) Fail(nodeID storj.NodeID, err error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err == nil {
+		delete(db.failures, nodeID)
+		return
+	}
+	db.failures[nodeID] = err
+}
+
+// clone deep copies the node, so callers can't modify the stored one
+func clone(node *pb.Node) *pb.Node {
+	data, err := proto.Marshal(node)
+	if err != nil {
+		panic(err)
+	}
+	var copied pb.Node
+	if err := proto.Unmarshal(data, &copied); err != nil {
+		panic(err)
+	}
+	return &copied
+}
+
+// Get looks up the node by nodeID
+func (db *DB) Get(ctx context.Context, nodeID storj.NodeID) (*pb.Node, error) {
+	if nodeID.IsZero() {
+		return nil, overlay.ErrEmptyNode
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.failures[nodeID]; err != nil {
+		return nil, err
+	}
+	node, ok := db.nodes[nodeID]
+	if !ok {
+		return nil, overlay.ErrNodeNotFound
+	}
+	return clone(node), nil
+}
+
+// GetAll looks up nodes based on the ids, missing nodes are nil
+func (db *DB) GetAll(ctx context.Context, nodeIDs storj.NodeIDList) ([]*pb.Node, error) {
+	nodes := make([]*pb.Node, len(nodeIDs))
+	for i, id := range nodeIDs {
+		node, err := db.Get(ctx, id)
+		if err != nil {
+			continue
+		}
+		nodes[i] = node
+	}
+	return nodes, nil
+}
+
+// List lists nodes in id order starting from cursor, including the cursor
+func (db *DB) List(ctx context.Context, cursor storj.NodeID, limit int) ([]*pb.Node, error) {
+	if limit <= 0 || limit > storage.LookupLimit {
+		limit = storage.LookupLimit
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	ids := make(storj.NodeIDList, 0, len(db.nodes))
+	for id := range db.nodes {
+		if id.Less(cursor) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, k int) bool { return ids[i].Less(ids[k]) })
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	nodes := make([]*pb.Node, 0, len(ids))
+	for _, id := range ids {
+		nodes = append(nodes, clone(db.nodes[id]))
+	}
+	return nodes, nil
+}
+
+// Update stores the node, replacing an existing one
+func (db *DB) Update(ctx context.Context, value *pb.Node) error {
+	if value == nil || value.Id.IsZero() {
+		return overlay.ErrEmptyNode
+	}
+	db.put(value)
+	return nil
+}
+
+// UpdateBatch stores multiple nodes at once, there is no statdb to load the
+// reputation of the nodes from
+func (db *DB) UpdateBatch(ctx context.Context, values []*pb.Node) error {
+	for _, value := range values {
+		if value == nil || value.Id.IsZero() {
+			return overlay.ErrEmptyNode
+		}
+	}
+	for _, value := range values {
+		db.put(value)
+	}
+	return nil
+}
+
+// UpdateLastContact records when contacting the node last succeeded or failed
+func (db *DB) UpdateLastContact(ctx context.Context, nodeID storj.NodeID, success bool, at time.Time) error {
+	timestamp, err := ptypes.TimestampProto(at)
+	if err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	node, ok := db.nodes[nodeID]
+	if !ok {
+		return overlay.ErrNodeNotFound
+	}
+	if success {
+		node.LastContactSuccess = timestamp
+	} else {
+		node.LastContactFailure = timestamp
+	}
+	return nil
+}
+
+// Delete deletes node based on id
+func (db *DB) Delete(ctx context.Context, id storj.NodeID) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	delete(db.nodes, id)
+	return nil
+}
+
+// GetWalletAddress gets the node's wallet address
+func (db *DB) GetWalletAddress(ctx context.Context, id storj.NodeID) (string, error) {
+	node, err := db.Get(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return node.GetMetadata().GetWallet(), nil
+}
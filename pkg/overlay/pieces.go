@@ -0,0 +1,91 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"sync"
+
+	"storj.io/storj/pkg/storj"
+)
+
+// minPieceCountBalance is the lowest balance factor of a node storing many
+// more pieces than average, so that full nodes are still selected sometimes
+const minPieceCountBalance = 0.1
+
+// pieceCounts holds the number of pieces stored on each node according to
+// the last report
+type pieceCounts struct {
+	mu      sync.RWMutex
+	counts  map[storj.NodeID]int64
+	average float64
+	// stored is the number of bytes stored on all nodes
+	stored         int64
+	storedReported bool
+}
+
+// report replaces the piece counts, nodes missing from counts don't store
+// any pieces
+func (pieces *pieceCounts) report(counts map[storj.NodeID]int64) {
+	var total int64
+	for _, count := range counts {
+		total += count
+	}
+
+	pieces.mu.Lock()
+	defer pieces.mu.Unlock()
+
+	pieces.counts = counts
+	pieces.average = 0
+	if len(counts) > 0 {
+		pieces.average = float64(total) / float64(len(counts))
+	}
+}
+
+// reportStored replaces the number of bytes stored on all nodes
+func (pieces *pieceCounts) reportStored(bytes int64) {
+	pieces.mu.Lock()
+	defer pieces.mu.Unlock()
+
+	pieces.stored, pieces.storedReported = bytes, true
+}
+
+// storedBytes returns the number of bytes stored on all nodes and whether it
+// has been reported
+func (pieces *pieceCounts) storedBytes() (int64, bool) {
+	pieces.mu.RLock()
+	defer pieces.mu.RUnlock()
+
+	return pieces.stored, pieces.storedReported
+}
+
+// count returns the number of pieces stored on the node and whether the
+// piece counts have been reported
+func (pieces *pieceCounts) count(nodeID storj.NodeID) (int64, bool) {
+	pieces.mu.RLock()
+	defer pieces.mu.RUnlock()
+
+	return pieces.counts[nodeID], pieces.counts != nil
+}
+
+// balance returns the factor by which the selection weight of the node is
+// scaled: nodes storing fewer pieces than average are preferred up to 1+bias
+// times and nodes storing more are avoided, down to minPieceCountBalance.
+func (pieces *pieceCounts) balance(nodeID storj.NodeID, bias float64) float64 {
+	if bias <= 0 {
+		return 1
+	}
+
+	pieces.mu.RLock()
+	defer pieces.mu.RUnlock()
+
+	if pieces.average <= 0 {
+		return 1
+	}
+
+	balance := 1 + bias*(pieces.average-float64(pieces.counts[nodeID]))/pieces.average
+	if balance < minPieceCountBalance {
+		return minPieceCountBalance
+	}
+	return balance
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/storj"
+)
+
+const (
+	// findStorageNodesMethod is the full gRPC method name of node selection
+	findStorageNodesMethod = "/overlay.Overlay/FindStorageNodes"
+	// rateLimitCleanupInterval is how often idle identities are forgotten
+	rateLimitCleanupInterval = time.Minute
+)
+
+// RateLimitConfig configures how often a single identity may select storage nodes
+type RateLimitConfig struct {
+	Rate  float64 `help:"the number of node selections per second a single identity may sustain, 0 disables rate limiting" default:"10"`
+	Burst int     `help:"the number of node selections a single identity may make at once" default:"100"`
+}
+
+// RateLimiter limits how often a single peer identity may call
+// FindStorageNodes, using a token bucket per identity
+type RateLimiter struct {
+	config RateLimitConfig
+
+	mu          sync.Mutex
+	buckets     map[storj.NodeID]*tokenBucket
+	lastCleanup time.Time
+}
+
+// tokenBucket holds the tokens an identity has left at the time they were
+// last counted
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewRateLimiter creates a new rate limiter from the config
+func NewRateLimiter(config RateLimitConfig) *RateLimiter {
+	return &RateLimiter{
+		config:      config,
+		buckets:     make(map[storj.NodeID]*tokenBucket),
+		lastCleanup: time.Now(),
+	}
+}
+
+// Allow takes a token from the bucket of the identity and returns false
+// when none is left. A nil or disabled RateLimiter allows every request.
+func (limiter *RateLimiter) Allow(id storj.NodeID) bool {
+	if limiter == nil || limiter.config.Rate <= 0 {
+		return true
+	}
+
+	now := time.Now()
+	burst := float64(limiter.config.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	if now.Sub(limiter.lastCleanup) >= rateLimitCleanupInterval {
+		limiter.cleanup(now, burst)
+	}
+
+	bucket, ok := limiter.buckets[id]
+	if !ok {
+		bucket = &tokenBucket{tokens: burst, updated: now}
+		limiter.buckets[id] = bucket
+	}
+
+	bucket.tokens += now.Sub(bucket.updated).Seconds() * limiter.config.Rate
+	if bucket.tokens > burst {
+		bucket.tokens = burst
+	}
+	bucket.updated = now
+
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// cleanup forgets the identities whose buckets have refilled completely,
+// they are indistinguishable from identities seen for the first time
+func (limiter *RateLimiter) cleanup(now time.Time, burst float64) {
+	for id, bucket := range limiter.buckets {
+		if bucket.tokens+now.Sub(bucket.updated).Seconds()*limiter.config.Rate >= burst {
+			delete(limiter.buckets, id)
+		}
+	}
+	limiter.lastCleanup = now
+}
+
+// UnaryInterceptor returns an interceptor which rejects FindStorageNodes
+// requests of identities exceeding their rate with ResourceExhausted. Other
+// methods and requests without a peer identity aren't limited.
+func (limiter *RateLimiter) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if info.FullMethod != findStorageNodesMethod {
+			return handler(ctx, req)
+		}
+
+		peer, err := identity.PeerIdentityFromContext(ctx)
+		if err != nil {
+			return handler(ctx, req)
+		}
+
+		if !limiter.Allow(peer.ID) {
+			mon.Counter("find_storage_nodes_rate_limited").Inc(1)
+			return nil, status.Errorf(codes.ResourceExhausted, "node selection rate of %s exceeded", peer.ID)
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/overlay"
+)
+
+func TestRateLimiter(t *testing.T) {
+	first := teststorj.NodeIDFromString("first")
+	second := teststorj.NodeIDFromString("second")
+
+	{ // identities are limited independently after their burst
+		limiter := overlay.NewRateLimiter(overlay.RateLimitConfig{Rate: 0.001, Burst: 2})
+		assert.True(t, limiter.Allow(first))
+		assert.True(t, limiter.Allow(first))
+		assert.False(t, limiter.Allow(first))
+		assert.True(t, limiter.Allow(second))
+	}
+
+	{ // tokens are refilled at the sustained rate
+		limiter := overlay.NewRateLimiter(overlay.RateLimitConfig{Rate: 100, Burst: 1})
+		assert.True(t, limiter.Allow(first))
+		assert.False(t, limiter.Allow(first))
+		time.Sleep(50 * time.Millisecond)
+		assert.True(t, limiter.Allow(first))
+	}
+
+	{ // nil and disabled limiters allow everything
+		var nilLimiter *overlay.RateLimiter
+		disabled := overlay.NewRateLimiter(overlay.RateLimitConfig{Rate: 0, Burst: 1})
+		for i := 0; i < 10; i++ {
+			assert.True(t, nilLimiter.Allow(first))
+			assert.True(t, disabled.Allow(first))
+		}
+	}
+}
+
+// peerContext returns a context of a call of a new peer identity
+func peerContext(t *testing.T) context.Context {
+	ctx := context.Background()
+
+	ident, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+	info := credentials.TLSInfo{State: tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{ident.Leaf, ident.CA},
+	}}
+	return peer.NewContext(ctx, &peer.Peer{AuthInfo: info})
+}
+
+func TestRateLimiterInterceptor(t *testing.T) {
+	ctx := peerContext(t)
+
+	interceptor := overlay.NewRateLimiter(overlay.RateLimitConfig{Rate: 0.001, Burst: 1}).UnaryInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	call := func(method string) error {
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	assert.NoError(t, call("/overlay.Overlay/FindStorageNodes"))
+	err := call("/overlay.Overlay/FindStorageNodes")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// other methods aren't limited
+	assert.NoError(t, call("/overlay.Overlay/Lookup"))
+}
@@ -0,0 +1,579 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/overlay/overlaytest"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
+)
+
+func TestNodeSelectionFilters(t *testing.T) {
+	ctx := context.Background()
+
+	config := overlay.NodeSelectionConfig{
+		UptimeRatio:       0.9,
+		UptimeCount:       10,
+		AuditSuccessRatio: 0.9,
+		AuditCount:        10,
+		FreeBandwidth:     memory.GiB,
+		FreeDisk:          memory.GiB,
+	}
+
+	good := func(spec overlaytest.NodeSpec) overlaytest.NodeSpec {
+		spec.FreeBandwidth, spec.FreeDisk = memory.GiB.Int64(), memory.GiB.Int64()
+		if spec.AuditCount == 0 {
+			spec.AuditCount = 20
+		}
+		if spec.UptimeCount == 0 {
+			spec.UptimeCount = 20
+		}
+		if spec.AuditReputation == 0 {
+			spec.AuditReputation = 1
+		}
+		if spec.UptimeReputation == 0 {
+			spec.UptimeReputation = 1
+		}
+		return spec
+	}
+
+	specs := []overlaytest.NodeSpec{
+		good(overlaytest.NodeSpec{}),
+		good(overlaytest.NodeSpec{Type: pb.NodeType_SATELLITE}),
+		{FreeBandwidth: memory.MiB.Int64(), FreeDisk: memory.GiB.Int64()},
+		good(overlaytest.NodeSpec{UptimeReputation: 0.5}),
+		good(overlaytest.NodeSpec{UptimeCount: 5}),
+		good(overlaytest.NodeSpec{AuditReputation: 0.5}),
+		good(overlaytest.NodeSpec{AuditCount: 5}),
+		good(overlaytest.NodeSpec{}),
+		good(overlaytest.NodeSpec{Address: "127.0.0.1:10007"}),
+		good(overlaytest.NodeSpec{}),
+	}
+	expected := []pb.SelectionResult{
+		pb.SelectionResult_ELIGIBLE,
+		pb.SelectionResult_NOT_STORAGE_NODE,
+		pb.SelectionResult_FREE_BANDWIDTH,
+		pb.SelectionResult_UPTIME_RATIO,
+		pb.SelectionResult_UPTIME_COUNT,
+		pb.SelectionResult_AUDIT_SUCCESS_RATIO,
+		pb.SelectionResult_AUDIT_COUNT,
+		pb.SelectionResult_ELIGIBLE,
+		pb.SelectionResult_DUPLICATE_ADDRESS,
+		pb.SelectionResult_EXCLUDED,
+	}
+
+	cache, _ := overlaytest.NewCache(config, specs...)
+	server := overlay.NewServer(zaptest.NewLogger(t), cache, config, nil, nil)
+	excluded := storj.NodeIDList{overlaytest.NodeID(9)}
+
+	explained, err := overlay.NewInspector(server, nil).ExplainSelection(ctx, &pb.ExplainSelectionRequest{
+		ExcludedNodes: excluded,
+	})
+	require.NoError(t, err)
+	require.Len(t, explained.Nodes, len(specs))
+	for i, node := range explained.Nodes {
+		assert.Equal(t, overlaytest.NodeID(i), node.NodeId)
+		assert.Equal(t, expected[i], node.Result, i)
+	}
+	assert.EqualValues(t, 2, explained.Eligible)
+
+	{ // paging through the nodes lists each node once
+		inspector := overlay.NewInspector(server, nil)
+		var paged []storj.NodeID
+		req := &pb.ExplainSelectionRequest{ExcludedNodes: excluded, Limit: 3}
+		for {
+			page, err := inspector.ExplainSelection(ctx, req)
+			require.NoError(t, err)
+			for _, node := range page.Nodes {
+				paged = append(paged, node.NodeId)
+			}
+			if !page.More {
+				break
+			}
+			req.PageToken = page.NextPageToken
+		}
+		require.Len(t, paged, len(specs))
+		for i, id := range paged {
+			assert.Equal(t, overlaytest.NodeID(i), id)
+		}
+	}
+
+	result, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+		Opts: &pb.OverlayOptions{Amount: 2, ExcludedNodes: excluded},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Nodes, 2)
+	// node 7 and 8 share an address, only one of them is selected
+	selected := nodeIDs(result.Nodes)
+	assert.Contains(t, selected, overlaytest.NodeID(0))
+	assert.True(t, contains(selected, overlaytest.NodeID(7)) != contains(selected, overlaytest.NodeID(8)), selected)
+
+	_, err = server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+		Opts: &pb.OverlayOptions{Amount: 3, ExcludedNodes: excluded},
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	stats := cache.Stats()
+	assert.EqualValues(t, len(specs), stats.CacheSize)
+	assert.EqualValues(t, 2, stats.Selections)
+	assert.EqualValues(t, 1, stats.SelectionFailures)
+	assert.Equal(t, map[string]int64{
+		"not_storage_node":    2,
+		"free_bandwidth":      2,
+		"uptime_ratio":        2,
+		"uptime_count":        2,
+		"audit_success_ratio": 2,
+		"audit_count":         2,
+		"excluded":            2,
+	}, stats.Rejections)
+}
+
+func TestNodeListsSelection(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	cache, _ := overlaytest.NewCache(overlay.NodeSelectionConfig{}, make([]overlaytest.NodeSpec, 4)...)
+
+	blacklisted := overlaytest.NodeID(0)
+	whitelisted := storj.NodeIDList{overlaytest.NodeID(1), overlaytest.NodeID(2)}
+
+	require.NoError(t, ioutil.WriteFile(ctx.File("blacklist"), []byte(blacklisted.String()), 0644))
+	require.NoError(t, ioutil.WriteFile(ctx.File("whitelist"), []byte(whitelisted[0].String()+"\n"+whitelisted[1].String()), 0644))
+
+	lists, err := overlay.NewNodeLists(zaptest.NewLogger(t), ctx.File("blacklist"), ctx.File("whitelist"), "")
+	require.NoError(t, err)
+	server := overlay.NewServer(zaptest.NewLogger(t), cache, overlay.NodeSelectionConfig{}, lists, nil)
+
+	result, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+		Opts: &pb.OverlayOptions{Amount: 2},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Nodes, 2)
+	assert.ElementsMatch(t, whitelisted, nodeIDs(result.Nodes))
+
+	_, err = server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+		Opts: &pb.OverlayOptions{Amount: 3},
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = server.Lookup(ctx, &pb.LookupRequest{NodeId: blacklisted})
+	assert.Error(t, err)
+
+	bulk, err := server.BulkLookup(ctx, &pb.LookupRequests{
+		LookupRequest: []*pb.LookupRequest{{NodeId: blacklisted}, {NodeId: overlaytest.NodeID(3)}},
+	})
+	require.NoError(t, err)
+	require.Len(t, bulk.LookupResponse, 2)
+	assert.Nil(t, bulk.LookupResponse[0].Node)
+	assert.Equal(t, pb.LookupStatus_NOT_FOUND, bulk.LookupResponse[0].Status)
+	assert.NotNil(t, bulk.LookupResponse[1].Node)
+	assert.Equal(t, pb.LookupStatus_FOUND, bulk.LookupResponse[1].Status)
+}
+
+func TestNodeSelectionDistribution(t *testing.T) {
+	ctx := context.Background()
+
+	const rounds = 500
+
+	specs := make([]overlaytest.NodeSpec, 10)
+	for i := range specs {
+		specs[i] = overlaytest.NodeSpec{AuditReputation: 1, UptimeReputation: 1}
+	}
+	// a node with poor reputation
+	specs[9].AuditReputation = 0.2
+
+	cache, _ := overlaytest.NewCache(overlay.NodeSelectionConfig{}, specs...)
+	server := overlay.NewServer(zaptest.NewLogger(t), cache, overlay.NodeSelectionConfig{}, nil, nil)
+
+	selected := map[storj.NodeID]int{}
+	for i := 0; i < rounds; i++ {
+		result, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+			Opts: &pb.OverlayOptions{Amount: 3},
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Nodes, 3)
+		for _, node := range result.Nodes {
+			selected[node.Id]++
+		}
+	}
+
+	// load is spread over all nodes instead of the first ones
+	for i := range specs {
+		assert.NotZero(t, selected[overlaytest.NodeID(i)], i)
+	}
+	// the node with poor reputation is selected less often than the others
+	for i := 0; i < 9; i++ {
+		assert.True(t, selected[overlaytest.NodeID(9)] < selected[overlaytest.NodeID(i)], i)
+	}
+}
+
+func TestNodeSelectionPieceCountBias(t *testing.T) {
+	ctx := context.Background()
+
+	const rounds = 500
+
+	specs := make([]overlaytest.NodeSpec, 10)
+	for i := range specs {
+		specs[i] = overlaytest.NodeSpec{AuditReputation: 1, UptimeReputation: 1}
+	}
+
+	config := overlay.NodeSelectionConfig{PieceCountBias: 1}
+	cache, _ := overlaytest.NewCache(config, specs...)
+	server := overlay.NewServer(zaptest.NewLogger(t), cache, config, nil, nil)
+
+	// nodes 0 to 4 are long-standing nodes storing many pieces, nodes 5 to 9
+	// are new capacity
+	counts := map[storj.NodeID]int64{}
+	for i := 0; i < 5; i++ {
+		counts[overlaytest.NodeID(i)] = 1000
+	}
+	cache.ReportPieceCounts(counts)
+
+	count, reported := cache.PieceCount(overlaytest.NodeID(0))
+	assert.True(t, reported)
+	assert.EqualValues(t, 1000, count)
+	count, _ = cache.PieceCount(overlaytest.NodeID(5))
+	assert.EqualValues(t, 0, count)
+
+	selected := map[storj.NodeID]int{}
+	for i := 0; i < rounds; i++ {
+		result, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+			Opts: &pb.OverlayOptions{Amount: 3},
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Nodes, 3)
+		for _, node := range result.Nodes {
+			selected[node.Id]++
+		}
+	}
+
+	var full, empty int
+	for i := 0; i < 5; i++ {
+		full += selected[overlaytest.NodeID(i)]
+		empty += selected[overlaytest.NodeID(i+5)]
+	}
+	// full nodes are still selected, but less often than empty ones
+	assert.NotZero(t, full)
+	assert.True(t, 3*full < 2*empty, "full %d, empty %d", full, empty)
+}
+
+func TestNodeSelectionPages(t *testing.T) {
+	ctx := context.Background()
+
+	// a bit more than two pages of nodes
+	specs := make([]overlaytest.NodeSpec, 2*storage.LookupLimit+500)
+	for i := range specs {
+		_, _ = rand.Read(specs[i].ID[:])
+		specs[i].AuditReputation, specs[i].UptimeReputation = 1, 1
+	}
+
+	{ // a single page doesn't list the whole cache
+		config := overlay.NodeSelectionConfig{SelectionPages: 1}
+		cache, _ := overlaytest.NewCache(config, specs...)
+		server := overlay.NewServer(zaptest.NewLogger(t), cache, config, nil, nil)
+
+		selected := map[storj.NodeID]bool{}
+		for i := 0; i < 20; i++ {
+			result, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+				Opts: &pb.OverlayOptions{Amount: 100},
+			})
+			require.NoError(t, err)
+			require.Len(t, result.Nodes, 100)
+			for _, node := range result.Nodes {
+				selected[node.Id] = true
+			}
+		}
+		// every selection starts from another random node
+		assert.True(t, len(selected) > storage.LookupLimit, len(selected))
+
+		_, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+			Opts: &pb.OverlayOptions{Amount: storage.LookupLimit + 1},
+		})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Zero(t, cache.Stats().CacheSize)
+	}
+
+	{ // listing wraps around to the nodes before the random start
+		config := overlay.NodeSelectionConfig{SelectionPages: 4}
+		cache, _ := overlaytest.NewCache(config, specs...)
+		server := overlay.NewServer(zaptest.NewLogger(t), cache, config, nil, nil)
+
+		result, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+			Opts: &pb.OverlayOptions{Amount: int64(len(specs))},
+		})
+		require.NoError(t, err)
+		assert.Len(t, result.Nodes, len(specs))
+		assert.EqualValues(t, len(specs), cache.Stats().CacheSize)
+	}
+}
+
+func nodeIDs(nodes []*pb.Node) storj.NodeIDList {
+	var ids storj.NodeIDList
+	for _, node := range nodes {
+		ids = append(ids, node.Id)
+	}
+	return ids
+}
+
+func contains(ids storj.NodeIDList, id storj.NodeID) bool {
+	for _, x := range ids {
+		if x == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestNodeSelectionOffline(t *testing.T) {
+	ctx := context.Background()
+
+	config := overlay.NodeSelectionConfig{OfflineGracePeriod: time.Hour}
+	specs := make([]overlaytest.NodeSpec, 4)
+	cache, nodes := overlaytest.NewCache(config, specs...)
+	server := overlay.NewServer(zaptest.NewLogger(t), cache, config, nil, nil)
+
+	now := time.Now()
+	contacts := []struct{ success, failure time.Time }{
+		{}, // never contacted
+		{success: now.Add(-time.Minute), failure: now.Add(-2 * time.Minute)},  // online
+		{success: now.Add(-30 * time.Minute), failure: now.Add(-time.Minute)}, // offline within the grace period
+		{success: now.Add(-2 * time.Hour), failure: now.Add(-time.Minute)},    // offline
+	}
+	for i, contact := range contacts {
+		if !contact.success.IsZero() {
+			require.NoError(t, nodes.UpdateLastContact(ctx, overlaytest.NodeID(i), true, contact.success))
+		}
+		if !contact.failure.IsZero() {
+			require.NoError(t, nodes.UpdateLastContact(ctx, overlaytest.NodeID(i), false, contact.failure))
+		}
+	}
+
+	inspector := overlay.NewInspector(server, nil)
+	explained, err := inspector.ExplainSelection(ctx, &pb.ExplainSelectionRequest{})
+	require.NoError(t, err)
+	require.Len(t, explained.Nodes, len(specs))
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, pb.SelectionResult_ELIGIBLE, explained.Nodes[i].Result, i)
+	}
+	assert.Equal(t, pb.SelectionResult_NODE_OFFLINE, explained.Nodes[3].Result)
+
+	_, err = server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+		Opts: &pb.OverlayOptions{Amount: 4},
+	})
+	assert.Error(t, err)
+
+	// offline nodes are selected again once they are contacted
+	require.NoError(t, nodes.UpdateLastContact(ctx, overlaytest.NodeID(3), true, now))
+	result, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+		Opts: &pb.OverlayOptions{Amount: 4},
+	})
+	require.NoError(t, err)
+	assert.Len(t, result.Nodes, 4)
+}
+
+func TestNodeSelectionLatency(t *testing.T) {
+	ctx := context.Background()
+
+	config := overlay.NodeSelectionConfig{MaxLatency: 100 * time.Millisecond, Region: "us"}
+	specs := make([]overlaytest.NodeSpec, 3)
+	cache, _ := overlaytest.NewCache(config, specs...)
+	server := overlay.NewServer(zaptest.NewLogger(t), cache, config, nil, nil)
+
+	latencies := overlay.NewLatencies()
+	latencies.Observe("us", overlaytest.NodeID(0), 20*time.Millisecond)
+	latencies.Observe("us", overlaytest.NodeID(1), 300*time.Millisecond)
+	// node 2 wasn't contacted yet, round trips from other regions don't count
+	latencies.Observe("eu", overlaytest.NodeID(2), 300*time.Millisecond)
+	server.SetLatencies(latencies)
+
+	inspector := overlay.NewInspector(server, nil)
+	explained, err := inspector.ExplainSelection(ctx, &pb.ExplainSelectionRequest{})
+	require.NoError(t, err)
+	require.Len(t, explained.Nodes, len(specs))
+	assert.Equal(t, pb.SelectionResult_ELIGIBLE, explained.Nodes[0].Result)
+	assert.Equal(t, pb.SelectionResult_HIGH_LATENCY, explained.Nodes[1].Result)
+	assert.Equal(t, "average round trip 300ms > 100ms", explained.Nodes[1].Detail)
+	assert.Equal(t, pb.SelectionResult_ELIGIBLE, explained.Nodes[2].Result)
+
+	result, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+		Opts: &pb.OverlayOptions{Amount: 2},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Nodes, 2)
+	for _, node := range result.Nodes {
+		assert.NotEqual(t, overlaytest.NodeID(1), node.Id)
+	}
+}
+
+func TestNodeSelectionPreferLowLatency(t *testing.T) {
+	ctx := context.Background()
+
+	config := overlay.NodeSelectionConfig{LatencyReference: 50 * time.Millisecond, Region: "us", LatencyReportRate: 0.001, LatencyReportBurst: 2}
+	specs := make([]overlaytest.NodeSpec, 3)
+	cache, _ := overlaytest.NewCache(config, specs...)
+	server := overlay.NewServer(zaptest.NewLogger(t), cache, config, nil, nil)
+
+	latencies := overlay.NewLatencies()
+	latencies.Observe("us", overlaytest.NodeID(0), 500*time.Millisecond)
+	latencies.Observe("us", overlaytest.NodeID(1), 5*time.Millisecond)
+	// node 2 wasn't contacted yet
+	server.SetLatencies(latencies)
+
+	report := &pb.LatencyReport{Region: "eu", Latencies: []*pb.NodeLatency{
+		{NodeId: overlaytest.NodeID(0), Rtt: ptypes.DurationProto(5 * time.Millisecond)},
+		{NodeId: overlaytest.NodeID(1), Rtt: ptypes.DurationProto(500 * time.Millisecond)},
+	}}
+
+	_, err := server.ReportLatency(ctx, &pb.LatencyReport{Latencies: report.Latencies})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// reports are tied to the identity of the uplink
+	_, err = server.ReportLatency(ctx, report)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	uplink := peerContext(t)
+	_, err = server.ReportLatency(uplink, report)
+	require.NoError(t, err)
+	_, err = server.ReportLatency(uplink, &pb.LatencyReport{Region: "us", Latencies: report.Latencies})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = server.ReportLatency(uplink, report)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	selected := func(opts *pb.OverlayOptions) map[storj.NodeID]int {
+		counts := make(map[storj.NodeID]int)
+		for i := 0; i < 1000; i++ {
+			result, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{Opts: opts})
+			require.NoError(t, err)
+			require.Len(t, result.Nodes, 1)
+			counts[result.Nodes[0].Id]++
+		}
+		return counts
+	}
+
+	{ // round trips from the satellite's region are used without a hint
+		counts := selected(&pb.OverlayOptions{Amount: 1, PreferLowLatency: true})
+		assert.True(t, counts[overlaytest.NodeID(1)] > counts[overlaytest.NodeID(2)], "%v", counts)
+		assert.True(t, counts[overlaytest.NodeID(2)] > counts[overlaytest.NodeID(0)], "%v", counts)
+	}
+
+	{ // round trips reported from the hinted region are preferred
+		counts := selected(&pb.OverlayOptions{Amount: 1, PreferLowLatency: true, RegionHint: "eu"})
+		assert.True(t, counts[overlaytest.NodeID(0)] > counts[overlaytest.NodeID(2)], "%v", counts)
+		assert.True(t, counts[overlaytest.NodeID(2)] > counts[overlaytest.NodeID(1)], "%v", counts)
+	}
+}
+
+func TestLatencies(t *testing.T) {
+	latencies := overlay.NewLatencies()
+
+	_, ok := latencies.Latency("", overlaytest.NodeID(0))
+	assert.False(t, ok)
+
+	latencies.Observe("", overlaytest.NodeID(0), 100*time.Millisecond)
+	latencies.Observe("", overlaytest.NodeID(0), 200*time.Millisecond)
+	latency, ok := latencies.Latency("", overlaytest.NodeID(0))
+	assert.True(t, ok)
+	assert.Equal(t, 120*time.Millisecond, latency)
+
+	_, ok = latencies.Latency("eu", overlaytest.NodeID(0))
+	assert.False(t, ok)
+
+	// invalid round trips aren't recorded
+	latencies.Observe("eu", overlaytest.NodeID(0), -time.Second)
+	latencies.Observe("eu", overlaytest.NodeID(0), time.Hour)
+	_, ok = latencies.Latency("eu", overlaytest.NodeID(0))
+	assert.False(t, ok)
+}
+
+// reportingClient collects the round trips reported to it
+type reportingClient struct {
+	overlay.Client
+
+	mu       sync.Mutex
+	reported map[storj.NodeID]time.Duration
+}
+
+func (client *reportingClient) ReportLatency(ctx context.Context, region string, latencies map[storj.NodeID]time.Duration) error {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	for id, rtt := range latencies {
+		client.reported[id] = rtt
+	}
+	return nil
+}
+
+func TestLatencyReporter(t *testing.T) {
+	client := &reportingClient{reported: make(map[storj.NodeID]time.Duration)}
+	reporter := overlay.NewLatencyReporter(zaptest.NewLogger(t), client, "eu", 2)
+
+	reporter.Observe(overlaytest.NodeID(0), time.Millisecond)
+	reporter.Observe(overlaytest.NodeID(1), 2*time.Millisecond)
+	reporter.Observe(overlaytest.NodeID(2), 3*time.Millisecond)
+
+	// flushing reports the round trips which are left and waits for the
+	// reports sent in the background
+	require.NoError(t, reporter.Flush(context.Background()))
+	assert.Equal(t, map[storj.NodeID]time.Duration{
+		overlaytest.NodeID(0): time.Millisecond,
+		overlaytest.NodeID(1): 2 * time.Millisecond,
+		overlaytest.NodeID(2): 3 * time.Millisecond,
+	}, client.reported)
+}
+
+func TestBulkLookupPartialFailure(t *testing.T) {
+	ctx := context.Background()
+
+	cache, db := overlaytest.NewCache(overlay.NodeSelectionConfig{}, make([]overlaytest.NodeSpec, 40)...)
+	server := overlay.NewServer(zaptest.NewLogger(t), cache, overlay.NodeSelectionConfig{}, nil, nil)
+
+	db.Fail(overlaytest.NodeID(7), errors.New("connection reset"))
+
+	var requests []*pb.LookupRequest
+	for i := 0; i < 50; i++ {
+		requests = append(requests, &pb.LookupRequest{NodeId: overlaytest.NodeID(i)})
+	}
+
+	bulk, err := server.BulkLookup(ctx, &pb.LookupRequests{LookupRequest: requests})
+	require.NoError(t, err)
+	require.Len(t, bulk.LookupResponse, len(requests))
+
+	for i, response := range bulk.LookupResponse {
+		switch {
+		case i == 7:
+			assert.Equal(t, pb.LookupStatus_ERROR, response.Status)
+			assert.Equal(t, "connection reset", response.Error)
+			assert.Nil(t, response.Node)
+		case i < 40:
+			assert.Equal(t, pb.LookupStatus_FOUND, response.Status, i)
+			if assert.NotNil(t, response.Node, i) {
+				assert.Equal(t, overlaytest.NodeID(i), response.Node.Id)
+			}
+		default:
+			assert.Equal(t, pb.LookupStatus_NOT_FOUND, response.Status, i)
+			assert.Nil(t, response.Node, i)
+		}
+	}
+
+	_, err = server.BulkLookup(ctx, &pb.LookupRequests{})
+	assert.Error(t, err)
+}
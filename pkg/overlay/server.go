@@ -7,43 +7,160 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/sync2"
+	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
 )
 
 // ServerError creates class of errors for stack traces
 var ServerError = errs.Class("Server Error")
 
+const (
+	// bulkLookupConcurrency is the number of nodes a BulkLookup looks up at once
+	bulkLookupConcurrency = 8
+	// maxLatencyReport is the number of round trips an uplink may report at once
+	maxLatencyReport = 1000
+)
+
 // Server implements our overlay RPC service
 type Server struct {
-	log       *zap.Logger
-	cache     *Cache
-	metrics   *monkit.Registry
-	nodeStats *pb.NodeStats
+	log            *zap.Logger
+	cache          *Cache
+	metrics        *monkit.Registry
+	nodeStats      *pb.NodeStats
+	restrictions   *pb.NodeRestrictions
+	lists          *NodeLists
+	vetting        *Vetting
+	pieceCountBias float64
+	offlineGrace   time.Duration
+	maxLatency     time.Duration
+	selectionPages int
+
+	latencies        *Latencies
+	region           string
+	latencyReference time.Duration
+	latencyReports   *RateLimiter
+
+	signer          *identity.FullIdentity
+	addressValidity time.Duration
 }
 
-// NewServer creates a new Overlay Server
-func NewServer(log *zap.Logger, cache *Cache, nodeStats *pb.NodeStats) *Server {
+// NewServer creates a new Overlay Server. lists may be nil when no nodes are
+// black or whitelisted and vetting may be nil when all nodes are considered
+// vetted.
+func NewServer(log *zap.Logger, cache *Cache, config NodeSelectionConfig, lists *NodeLists, vetting *Vetting) *Server {
 	return &Server{
-		cache:     cache,
-		log:       log,
-		metrics:   monkit.Default,
-		nodeStats: nodeStats,
+		cache:          cache,
+		log:            log,
+		metrics:        monkit.Default,
+		lists:          lists,
+		vetting:        vetting,
+		pieceCountBias: config.PieceCountBias,
+		offlineGrace:   config.OfflineGracePeriod,
+		maxLatency:     config.MaxLatency,
+		selectionPages: config.SelectionPages,
+
+		latencies:        NewLatencies(),
+		region:           config.Region,
+		latencyReference: config.LatencyReference,
+		latencyReports: NewRateLimiter(RateLimitConfig{
+			Rate:  config.LatencyReportRate,
+			Burst: config.LatencyReportBurst,
+		}),
+
+		addressValidity: config.AddressValidity,
+
+		nodeStats: &pb.NodeStats{
+			UptimeCount:       config.UptimeCount,
+			UptimeRatio:       config.UptimeRatio,
+			AuditSuccessRatio: config.AuditSuccessRatio,
+			AuditCount:        config.AuditCount,
+		},
+		restrictions: &pb.NodeRestrictions{
+			FreeBandwidth: config.FreeBandwidth.Int64(),
+			FreeDisk:      config.FreeDisk.Int64(),
+		},
 	}
 }
 
 // Close closes resources
 func (server *Server) Close() error { return nil }
 
+// SetLatencies sets the round trips to nodes the satellite and uplinks
+// observe, which uplinks report to and selection reads. Must be called before
+// the server is used.
+func (server *Server) SetLatencies(latencies *Latencies) {
+	server.latencies = latencies
+}
+
+// SetSigner sets the identity the addresses of the selected nodes are signed
+// with, so that uplinks can check they dial the nodes the satellite selected.
+// Must be called before the server is used.
+func (server *Server) SetSigner(ident *identity.FullIdentity) {
+	server.signer = ident
+}
+
+// localLatency returns the average round trip of the satellite's pings and
+// transfers to a node, or false when it wasn't measured
+func (server *Server) localLatency(id storj.NodeID) (time.Duration, bool) {
+	return server.latencies.Latency(server.region, id)
+}
+
+// slow returns whether the measured round trip to a node exceeds the maximum
+func (server *Server) slow(id storj.NodeID) bool {
+	if server.maxLatency <= 0 {
+		return false
+	}
+	latency, ok := server.localLatency(id)
+	return ok && latency > server.maxLatency
+}
+
+// latencyBias returns how much a node is preferred for its round trip from
+// region, or from the satellite when none was observed from region. Nodes
+// with round trips of the latency reference count half, nodes without a
+// measured round trip count as if they had it.
+func (server *Server) latencyBias(id storj.NodeID, region string) float64 {
+	if server.latencyReference <= 0 {
+		return 1
+	}
+
+	latency, ok := server.latencies.Latency(region, id)
+	if !ok {
+		latency, ok = server.localLatency(id)
+	}
+	if !ok {
+		latency = server.latencyReference
+	}
+	return 1 / (1 + float64(latency)/float64(server.latencyReference))
+}
+
+// Vetting returns the vetting subsystem used by node selection
+func (server *Server) Vetting() *Vetting { return server.vetting }
+
+// Cache returns the cache the server selects nodes from
+func (server *Server) Cache() *Cache { return server.cache }
+
 // Lookup finds the address of a node in our overlay network
 func (server *Server) Lookup(ctx context.Context, req *pb.LookupRequest) (*pb.LookupResponse, error) {
+	if server.lists.Blacklisted(req.NodeId) {
+		return nil, ErrNodeNotFound
+	}
+
 	na, err := server.cache.Get(ctx, req.NodeId)
 
 	if err != nil {
@@ -56,17 +173,56 @@ func (server *Server) Lookup(ctx context.Context, req *pb.LookupRequest) (*pb.Lo
 	}, nil
 }
 
-// BulkLookup finds the addresses of nodes in our overlay network
-func (server *Server) BulkLookup(ctx context.Context, reqs *pb.LookupRequests) (*pb.LookupResponses, error) {
-	ns, err := server.cache.GetAll(ctx, lookupRequestsToNodeIDs(reqs))
-	if err != nil {
-		return nil, ServerError.New("could not get nodes requested %s\n", err)
+// BulkLookup finds the addresses of nodes in our overlay network. Nodes are
+// looked up concurrently and every response reports whether its node was
+// found, so that a failing lookup doesn't fail the others.
+func (server *Server) BulkLookup(ctx context.Context, reqs *pb.LookupRequests) (_ *pb.LookupResponses, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ids := lookupRequestsToNodeIDs(reqs)
+	if len(ids) == 0 {
+		return nil, ServerError.New("no ids provided")
+	}
+
+	responses := make([]*pb.LookupResponse, len(ids))
+	limiter := sync2.NewLimiter(bulkLookupConcurrency)
+	for i, id := range ids {
+		i, id := i, id
+		started := limiter.Go(ctx, func() {
+			responses[i] = server.bulkLookup(ctx, id)
+		})
+		if !started {
+			responses[i] = &pb.LookupResponse{Status: pb.LookupStatus_ERROR, Error: ctx.Err().Error()}
+		}
+	}
+	limiter.Wait()
+
+	return &pb.LookupResponses{LookupResponse: responses}, nil
+}
+
+// bulkLookup looks up a single node of a BulkLookup request
+func (server *Server) bulkLookup(ctx context.Context, id storj.NodeID) *pb.LookupResponse {
+	if server.lists.Blacklisted(id) {
+		return &pb.LookupResponse{Status: pb.LookupStatus_NOT_FOUND}
+	}
+
+	node, err := server.cache.Get(ctx, id)
+	switch {
+	case err == nil:
+		return &pb.LookupResponse{Node: node, Status: pb.LookupStatus_FOUND}
+	case err == ErrNodeNotFound:
+		return &pb.LookupResponse{Status: pb.LookupStatus_NOT_FOUND}
+	default:
+		server.log.Error("Error looking up node", zap.Error(err), zap.String("nodeID", id.String()))
+		return &pb.LookupResponse{Status: pb.LookupStatus_ERROR, Error: err.Error()}
 	}
-	return nodesToLookupResponses(ns), nil
 }
 
 // FindStorageNodes searches the overlay network for nodes that meet the provided requirements
 func (server *Server) FindStorageNodes(ctx context.Context, req *pb.FindStorageNodesRequest) (resp *pb.FindStorageNodesResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer func(start time.Time) { server.cache.stats.selection(time.Since(start), err) }(time.Now())
+
 	opts := req.GetOpts()
 	maxNodes := req.GetMaxNodes()
 	if maxNodes <= 0 {
@@ -74,62 +230,224 @@ func (server *Server) FindStorageNodes(ctx context.Context, req *pb.FindStorageN
 	}
 
 	excluded := opts.ExcludedNodes
-	restrictions := opts.GetRestrictions()
+	tags := tagFilter{required: opts.GetTags(), excluded: opts.GetExcludedTags()}
+	restrictions := server.minimumRestrictions(opts.GetRestrictions())
 	reputation := server.nodeStats
+	region := opts.GetRegionHint()
+	if region == "" {
+		region = server.region
+	}
 
-	var startID storj.NodeID
-	result := []*pb.Node{}
-	for {
-		var nodes []*pb.Node
-		nodes, startID, err = server.populate(ctx, req.Start, maxNodes, restrictions, reputation, excluded)
+	// choose a weighted random sample of the qualifying nodes, keeping at
+	// most one node per address, so that load is spread across the network.
+	// Only a bounded number of nodes starting from a random node are listed,
+	// wrapping around at the end of the cache, so that the cost of a
+	// selection doesn't grow with the size of the network.
+	candidates := make(map[string]selectionCandidate)
+	seen := make(map[storj.NodeID]bool)
+
+	start := req.Start
+	randomStart := start.IsZero() && server.selectionPages > 0
+	if randomStart {
+		_, _ = rand.Read(start[:])
+	}
+
+	limit := server.selectionPages * storage.LookupLimit
+	cursor, wrapped, complete := start, false, false
+	for limit <= 0 || len(seen) < limit {
+		pageLimit := storage.LookupLimit
+		if remaining := limit - len(seen); limit > 0 && remaining < pageLimit {
+			pageLimit = remaining
+			if seen[cursor] {
+				// the cursor is listed by the previous page as well
+				pageLimit++
+			}
+		}
+
+		nodes, nextStart, err := server.populate(ctx, cursor, pageLimit, restrictions, reputation, excluded, tags, seen)
 		if err != nil {
 			return nil, Error.Wrap(err)
 		}
 
-		resultNodes := []*pb.Node{}
-		usedAddrs := make(map[string]bool)
 		for _, n := range nodes {
+			weight := selectionWeight(n) * server.cache.pieces.balance(n.Id, server.pieceCountBias)
+			if opts.GetPreferLowLatency() {
+				weight *= server.latencyBias(n.Id, region)
+			}
+			candidate := selectionCandidate{node: n, key: selectionKey(weight)}
 			addr := n.Address.GetAddress()
-			excluded = append(excluded, n.Id) // exclude all nodes on next iteration
-			if !usedAddrs[addr] {
-				resultNodes = append(resultNodes, n)
-				usedAddrs[addr] = true
+			if existing, ok := candidates[addr]; !ok || candidate.key > existing.key {
+				candidates[addr] = candidate
 			}
 		}
-		if len(resultNodes) <= 0 {
+
+		// no progress means the end was reached, after which a random
+		// start continues from the beginning up to itself
+		end := nextStart.IsZero() || nextStart == cursor
+		if wrapped && (end || !nextStart.Less(start)) {
+			complete = true
 			break
 		}
-
-		result = append(result, resultNodes...)
-
-		if len(result) >= int(maxNodes) || startID == (storj.NodeID{}) {
+		if end && randomStart && !wrapped {
+			cursor, wrapped = storj.NodeID{}, true
+			continue
+		}
+		if end {
+			complete = req.Start.IsZero()
 			break
 		}
+		cursor = nextStart
+	}
+	if complete {
+		server.cache.stats.size(len(seen))
+	}
 
+	if len(candidates) < int(maxNodes) {
+		return nil, status.Errorf(codes.ResourceExhausted, "requested %d nodes, only %d nodes matched the criteria requested", maxNodes, len(candidates))
 	}
 
-	if len(result) < int(maxNodes) {
-		return nil, status.Errorf(codes.ResourceExhausted, fmt.Sprintf("requested %d nodes, only %d nodes matched the criteria requested", maxNodes, len(result)))
+	sorted := make([]selectionCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		sorted = append(sorted, candidate)
+	}
+	sort.Slice(sorted, func(i, k int) bool { return sorted[i].key > sorted[k].key })
+
+	// new nodes only get their share of the selected nodes, unless there
+	// aren't enough vetted nodes
+	var vetted, unvetted []selectionCandidate
+	for _, candidate := range sorted {
+		if server.vetting.State(candidate.node) == pb.NodeVetting_NEW {
+			unvetted = append(unvetted, candidate)
+		} else {
+			vetted = append(vetted, candidate)
+		}
+	}
+	newNodes := server.vetting.newNodes(int(maxNodes))
+	if newNodes > len(unvetted) {
+		newNodes = len(unvetted)
+	}
+	if int(maxNodes)-newNodes > len(vetted) {
+		newNodes = int(maxNodes) - len(vetted)
 	}
 
-	if len(result) > int(maxNodes) {
-		result = result[:maxNodes]
+	result := make([]*pb.Node, 0, maxNodes)
+	for _, candidate := range unvetted[:newNodes] {
+		result = append(result, candidate.node)
+	}
+	for _, candidate := range vetted[:int(maxNodes)-newNodes] {
+		result = append(result, candidate.node)
 	}
 
-	return &pb.FindStorageNodesResponse{
+	resp = &pb.FindStorageNodesResponse{
 		Nodes: result,
-	}, nil
+	}
+	if server.signer != nil {
+		resp.SignedAddresses, err = SignNodeAddresses(server.signer, result, time.Now().Add(server.addressValidity))
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+	}
+	return resp, nil
 }
 
+// AnnounceExit marks the calling storage node as draining. It isn't selected
+// for new pieces from now on and the checker queues its pieces for repair, so
+// that the node can leave once they have been migrated.
+func (server *Server) AnnounceExit(ctx context.Context, req *pb.AnnounceExitRequest) (_ *pb.AnnounceExitResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if server.vetting == nil {
+		return nil, ServerError.New("node exits aren't supported")
+	}
+
+	peer, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+
+	node, err := server.cache.Get(ctx, peer.ID)
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+	if node.Type != pb.NodeType_STORAGE {
+		return nil, ServerError.New("node %s isn't a storage node", peer.ID)
+	}
+
+	if err := server.vetting.Drain(ctx, peer.ID, req.GetReason()); err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+	return &pb.AnnounceExitResponse{}, nil
+}
+
+// ReportLatency records the round trips of transfers an uplink observed to
+// nodes from its region, which selection prefers low ones of for clients
+// from the region. Every uplink reports from a single region, at a limited
+// rate, and round trips to itself aren't recorded.
+func (server *Server) ReportLatency(ctx context.Context, req *pb.LatencyReport) (_ *pb.LatencyReportResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if req.GetRegion() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no region reported")
+	}
+	if len(req.GetLatencies()) > maxLatencyReport {
+		return nil, status.Errorf(codes.InvalidArgument, "reported %d round trips, at most %d may be reported at once", len(req.GetLatencies()), maxLatencyReport)
+	}
+
+	peer, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if !server.latencyReports.Allow(peer.ID) {
+		mon.Counter("latency_reports_rate_limited").Inc(1)
+		return nil, status.Errorf(codes.ResourceExhausted, "latency report rate of %s exceeded", peer.ID)
+	}
+	if !server.latencies.Reporter(peer.ID, req.GetRegion()) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s can't report round trips from %q", peer.ID, req.GetRegion())
+	}
+
+	for _, latency := range req.GetLatencies() {
+		if latency.NodeId == peer.ID {
+			continue
+		}
+		rtt, err := ptypes.Duration(latency.GetRtt())
+		if err != nil {
+			continue
+		}
+		server.latencies.Observe(req.GetRegion(), latency.NodeId, rtt)
+	}
+	return &pb.LatencyReportResponse{}, nil
+}
+
+// minimumRestrictions combines the requested restrictions with the
+// configured minimum, picking the stricter of the two
+func (server *Server) minimumRestrictions(requested *pb.NodeRestrictions) *pb.NodeRestrictions {
+	restrictions := &pb.NodeRestrictions{
+		FreeBandwidth: server.restrictions.GetFreeBandwidth(),
+		FreeDisk:      server.restrictions.GetFreeDisk(),
+	}
+	if requested.GetFreeBandwidth() > restrictions.FreeBandwidth {
+		restrictions.FreeBandwidth = requested.GetFreeBandwidth()
+	}
+	if requested.GetFreeDisk() > restrictions.FreeDisk {
+		restrictions.FreeDisk = requested.GetFreeDisk()
+	}
+	return restrictions
+}
+
+// populate lists a page of nodes starting from startID and returns the ones
+// passing the selection filters. Nodes in seen, such as the cursor which is
+// listed on both pages, are skipped and the listed nodes are added to seen.
+// The returned start of the next page is zero when the end was reached.
 // TODO: nicer method arguments
 func (server *Server) populate(ctx context.Context,
-	startID storj.NodeID, maxNodes int64,
+	startID storj.NodeID, limit int,
 	minRestrictions *pb.NodeRestrictions,
 	minReputation *pb.NodeStats,
-	excluded storj.NodeIDList) ([]*pb.Node, storj.NodeID, error) {
+	excluded storj.NodeIDList,
+	tags tagFilter,
+	seen map[storj.NodeID]bool) ([]*pb.Node, storj.NodeID, error) {
 
 	// TODO: move the query into db
-	limit := int(maxNodes * 2)
 	nodes, err := server.cache.db.List(ctx, startID, limit)
 	if err != nil {
 		server.log.Error("Error listing nodes", zap.Error(err))
@@ -144,21 +462,19 @@ func (server *Server) populate(ctx context.Context,
 		}
 
 		nextStart = v.Id
-		if v.Type != pb.NodeType_STORAGE {
-			server.log.Debug("not storage node = " + v.Id.String() + " was " + v.Type.String())
+		if seen[v.Id] {
 			continue
 		}
+		seen[v.Id] = true
 
-		restrictions := v.GetRestrictions()
-		reputation := v.GetReputation()
-
-		if restrictions.GetFreeBandwidth() < minRestrictions.GetFreeBandwidth() ||
-			restrictions.GetFreeDisk() < minRestrictions.GetFreeDisk() ||
-			reputation.GetUptimeRatio() < minReputation.GetUptimeRatio() ||
-			reputation.GetUptimeCount() < minReputation.GetUptimeCount() ||
-			reputation.GetAuditSuccessRatio() < minReputation.GetAuditSuccessRatio() ||
-			reputation.GetAuditCount() < minReputation.GetAuditCount() ||
-			contains(excluded, v.Id) {
+		switch result := server.selectionFilter(v, minRestrictions, minReputation, excluded, tags); result {
+		case pb.SelectionResult_ELIGIBLE:
+		case pb.SelectionResult_NOT_STORAGE_NODE:
+			server.cache.stats.rejected(result)
+			server.log.Debug("not storage node = " + v.Id.String() + " was " + v.Type.String())
+			continue
+		default:
+			server.cache.stats.rejected(result)
 			server.log.Debug("excluded = " + v.Id.String())
 			continue
 		}
@@ -167,9 +483,192 @@ func (server *Server) populate(ctx context.Context,
 		result = append(result, v)
 	}
 
+	if len(nodes) < limit {
+		nextStart = storj.NodeID{}
+	}
 	return result, nextStart, nil
 }
 
+// minSelectionReputation is the reputation assumed for selection weights of
+// nodes with a lower one, so that new nodes still get a chance to be selected
+const minSelectionReputation = 0.1
+
+// selectionCandidate is a qualifying node with its random sampling key
+type selectionCandidate struct {
+	node *pb.Node
+	key  float64
+}
+
+// selectionWeight returns how much the node should be preferred: nodes with
+// better reputation and more free disk space are selected more often
+func selectionWeight(node *pb.Node) float64 {
+	reputation := node.GetReputation()
+	audit := math.Max(reputation.GetAuditReputation(), minSelectionReputation)
+	uptime := math.Max(reputation.GetUptimeReputation(), minSelectionReputation)
+	capacity := 1 + math.Log2(1+float64(node.GetRestrictions().GetFreeDisk())/float64(memory.GiB))
+	return audit * uptime * capacity
+}
+
+// selectionKey returns a random key for weighted sampling without replacement,
+// choosing the nodes with the largest keys picks each node with a probability
+// proportional to its weight (Efraimidis-Spirakis)
+func selectionKey(weight float64) float64 {
+	return math.Pow(rand.Float64(), 1/weight)
+}
+
+// selectionFilter returns the first selection filter the node doesn't pass,
+// or ELIGIBLE when the node may be selected
+func (server *Server) selectionFilter(node *pb.Node, minRestrictions *pb.NodeRestrictions, minReputation *pb.NodeStats, excluded storj.NodeIDList, tags tagFilter) pb.SelectionResult {
+	restrictions := node.GetRestrictions()
+	reputation := node.GetReputation()
+	state := server.vetting.State(node)
+	nodeTags := server.nodeTags(node)
+
+	switch {
+	case node.Type != pb.NodeType_STORAGE:
+		return pb.SelectionResult_NOT_STORAGE_NODE
+	case server.lists.Blacklisted(node.Id):
+		return pb.SelectionResult_BLACKLISTED
+	case !server.lists.Whitelisted(node.Id):
+		return pb.SelectionResult_NOT_WHITELISTED
+	case state == pb.NodeVetting_DISQUALIFIED:
+		return pb.SelectionResult_NODE_DISQUALIFIED
+	case state == pb.NodeVetting_SUSPENDED:
+		return pb.SelectionResult_NODE_SUSPENDED
+	case state == pb.NodeVetting_DRAINING:
+		return pb.SelectionResult_NODE_DRAINING
+	case offline(node, server.offlineGrace, time.Now()):
+		return pb.SelectionResult_NODE_OFFLINE
+	case server.slow(node.Id):
+		return pb.SelectionResult_HIGH_LATENCY
+	case restrictions.GetFreeBandwidth() < minRestrictions.GetFreeBandwidth():
+		return pb.SelectionResult_FREE_BANDWIDTH
+	case restrictions.GetFreeDisk() < minRestrictions.GetFreeDisk():
+		return pb.SelectionResult_FREE_DISK
+	case reputation.GetUptimeReputation() < minReputation.GetUptimeRatio():
+		return pb.SelectionResult_UPTIME_RATIO
+	case reputation.GetUptimeCount() < minReputation.GetUptimeCount():
+		return pb.SelectionResult_UPTIME_COUNT
+	case reputation.GetAuditReputation() < minReputation.GetAuditSuccessRatio():
+		return pb.SelectionResult_AUDIT_SUCCESS_RATIO
+	case reputation.GetAuditCount() < minReputation.GetAuditCount():
+		return pb.SelectionResult_AUDIT_COUNT
+	case !hasTags(nodeTags, tags.required):
+		return pb.SelectionResult_MISSING_TAGS
+	case hasAnyTag(nodeTags, tags.excluded):
+		return pb.SelectionResult_EXCLUDED_TAGS
+	case contains(excluded, node.Id):
+		return pb.SelectionResult_EXCLUDED
+	}
+	return pb.SelectionResult_ELIGIBLE
+}
+
+// selectionDetail describes the node value and the required value for the failed filter
+func (server *Server) selectionDetail(result pb.SelectionResult, node *pb.Node, minRestrictions *pb.NodeRestrictions, minReputation *pb.NodeStats, tags tagFilter) string {
+	restrictions := node.GetRestrictions()
+	reputation := node.GetReputation()
+
+	switch result {
+	case pb.SelectionResult_NOT_STORAGE_NODE:
+		return fmt.Sprintf("node type %s", node.Type)
+	case pb.SelectionResult_FREE_BANDWIDTH:
+		return fmt.Sprintf("free bandwidth %d < %d", restrictions.GetFreeBandwidth(), minRestrictions.GetFreeBandwidth())
+	case pb.SelectionResult_FREE_DISK:
+		return fmt.Sprintf("free disk %d < %d", restrictions.GetFreeDisk(), minRestrictions.GetFreeDisk())
+	case pb.SelectionResult_UPTIME_RATIO:
+		return fmt.Sprintf("uptime reputation %.4f < %.4f", reputation.GetUptimeReputation(), minReputation.GetUptimeRatio())
+	case pb.SelectionResult_UPTIME_COUNT:
+		return fmt.Sprintf("uptime count %d < %d", reputation.GetUptimeCount(), minReputation.GetUptimeCount())
+	case pb.SelectionResult_AUDIT_SUCCESS_RATIO:
+		return fmt.Sprintf("audit reputation %.4f < %.4f", reputation.GetAuditReputation(), minReputation.GetAuditSuccessRatio())
+	case pb.SelectionResult_AUDIT_COUNT:
+		return fmt.Sprintf("audit count %d < %d", reputation.GetAuditCount(), minReputation.GetAuditCount())
+	case pb.SelectionResult_BLACKLISTED:
+		return "node is blacklisted"
+	case pb.SelectionResult_NOT_WHITELISTED:
+		return "node is not whitelisted"
+	case pb.SelectionResult_NODE_DISQUALIFIED:
+		return "node is disqualified"
+	case pb.SelectionResult_NODE_SUSPENDED:
+		return fmt.Sprintf("node is suspended with uptime reputation %.4f", reputation.GetUptimeReputation())
+	case pb.SelectionResult_NODE_DRAINING:
+		return "node announced its exit"
+	case pb.SelectionResult_NODE_OFFLINE:
+		return fmt.Sprintf("last contact failed at %s, last succeeded at %s", formatContact(node.LastContactFailure), formatContact(node.LastContactSuccess))
+	case pb.SelectionResult_HIGH_LATENCY:
+		latency, _ := server.localLatency(node.Id)
+		return fmt.Sprintf("average round trip %s > %s", latency, server.maxLatency)
+	case pb.SelectionResult_MISSING_TAGS:
+		return fmt.Sprintf("tags %s don't match %s", formatTags(server.nodeTags(node)), formatTags(tags.required))
+	case pb.SelectionResult_EXCLUDED_TAGS:
+		return fmt.Sprintf("tags %s match the excluded %s", formatTags(server.nodeTags(node)), formatTags(tags.excluded))
+	case pb.SelectionResult_EXCLUDED:
+		return "excluded by the request"
+	case pb.SelectionResult_DUPLICATE_ADDRESS:
+		return fmt.Sprintf("address %s is used by an earlier node", node.Address.GetAddress())
+	}
+	return ""
+}
+
+// explain evaluates the selection filters for up to limit nodes of the cache
+// listed after the node after, from the start of the cache when it is zero. Of
+// nodes on a page sharing an address only the first one is reported as
+// eligible, while FindStorageNodes picks one of them at random.
+func (server *Server) explain(ctx context.Context, requested *pb.NodeRestrictions, excluded storj.NodeIDList, tags tagFilter, after storj.NodeID, limit int) (_ *pb.ExplainSelectionResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	restrictions := server.minimumRestrictions(requested)
+	reputation := server.nodeStats
+
+	// fetch one extra node to find out whether there are more, and the node
+	// listed last on the previous page which the listing starts with
+	nodes, err := server.cache.db.List(ctx, after, limit+2)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if !after.IsZero() && len(nodes) > 0 && nodes[0] != nil && nodes[0].Id == after {
+		nodes = nodes[1:]
+	}
+
+	resp := &pb.ExplainSelectionResponse{}
+	if len(nodes) > limit {
+		nodes = nodes[:limit]
+		resp.More = true
+	}
+
+	usedAddrs := make(map[string]bool)
+	for _, v := range nodes {
+		if v == nil {
+			continue
+		}
+
+		result := server.selectionFilter(v, restrictions, reputation, excluded, tags)
+		if result == pb.SelectionResult_ELIGIBLE {
+			addr := v.Address.GetAddress()
+			if usedAddrs[addr] {
+				result = pb.SelectionResult_DUPLICATE_ADDRESS
+			} else {
+				usedAddrs[addr] = true
+				resp.Eligible++
+			}
+		}
+
+		resp.Nodes = append(resp.Nodes, &pb.NodeSelection{
+			NodeId:  v.Id,
+			Address: v.Address.GetAddress(),
+			Result:  result,
+			Detail:  server.selectionDetail(result, v, restrictions, reputation, tags),
+		})
+	}
+
+	return resp, nil
+}
+
+// nodeTags returns the tags the node signed with its operator tags
+func (server *Server) nodeTags(node *pb.Node) []*pb.NodeTag {
+	return mergeTags(node.GetTags().GetTags(), server.lists.Tags(node.Id))
+}
+
 // contains checks if item exists in list
 func contains(nodeIDs storj.NodeIDList, searchID storj.NodeID) bool {
 	for _, id := range nodeIDs {
@@ -187,13 +686,3 @@ func lookupRequestsToNodeIDs(reqs *pb.LookupRequests) (ids storj.NodeIDList) {
 	}
 	return ids
 }
-
-// nodesToLookupResponses returns LookupResponses from the nodes
-func nodesToLookupResponses(nodes []*pb.Node) *pb.LookupResponses {
-	var rs []*pb.LookupResponse
-	for _, v := range nodes {
-		r := &pb.LookupResponse{Node: v}
-		rs = append(rs, r)
-	}
-	return &pb.LookupResponses{LookupResponse: rs}
-}
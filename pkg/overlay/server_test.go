@@ -9,10 +9,18 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"storj.io/storj/internal/memory"
 	"storj.io/storj/internal/testcontext"
 	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
 )
 
 func TestServer(t *testing.T) {
@@ -42,6 +50,46 @@ func TestServer(t *testing.T) {
 		assert.Len(t, result.Nodes, 2)
 	}
 
+	{ // FindStorageNodes with a free disk requirement no node satisfies
+		demanding := overlay.NewServer(zaptest.NewLogger(t), satellite.Overlay.Service, overlay.NodeSelectionConfig{
+			FreeDisk: 1 * memory.EB,
+		}, nil, nil)
+		_, err := demanding.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+			Opts: &pb.OverlayOptions{Amount: 2},
+		})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+		// explain shows which filter excluded the nodes
+		explained, err := overlay.NewInspector(demanding, nil).ExplainSelection(ctx, &pb.ExplainSelectionRequest{})
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, explained.Eligible)
+		for _, node := range explained.Nodes {
+			if node.Result != pb.SelectionResult_NOT_STORAGE_NODE {
+				assert.Equal(t, pb.SelectionResult_FREE_DISK, node.Result, node.NodeId.String())
+				assert.NotEmpty(t, node.Detail)
+			}
+		}
+	}
+
+	{ // ExplainSelection
+		excluded := planet.StorageNodes[0].ID()
+		explained, err := overlay.NewInspector(server, nil).ExplainSelection(ctx, &pb.ExplainSelectionRequest{
+			ExcludedNodes: storj.NodeIDList{excluded},
+		})
+		require.NoError(t, err)
+		assert.False(t, explained.More)
+
+		results := map[storj.NodeID]pb.SelectionResult{}
+		for _, node := range explained.Nodes {
+			results[node.NodeId] = node.Result
+		}
+		assert.Equal(t, pb.SelectionResult_EXCLUDED, results[excluded])
+		for _, node := range planet.StorageNodes[1:] {
+			assert.Equal(t, pb.SelectionResult_ELIGIBLE, results[node.ID()])
+		}
+		assert.EqualValues(t, len(planet.StorageNodes)-1, explained.Eligible)
+	}
+
 	{ // Lookup
 		result, err := server.Lookup(ctx, &pb.LookupRequest{
 			NodeId: planet.StorageNodes[0].ID(),
@@ -49,6 +97,11 @@ func TestServer(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, result)
 		assert.Equal(t, result.Node.Address.Address, planet.StorageNodes[0].Addr())
+
+		local := planet.StorageNodes[0].Local()
+		require.NotNil(t, result.Node.Metadata)
+		assert.Equal(t, local.Metadata.Wallet, result.Node.Metadata.Wallet)
+		assert.Equal(t, local.Metadata.Email, result.Node.Metadata.Email)
 	}
 
 	{ // BulkLookup
@@ -67,7 +120,72 @@ func TestServer(t *testing.T) {
 		for i, resp := range result.LookupResponse {
 			if assert.NotNil(t, resp.Node) {
 				assert.Equal(t, resp.Node.Address.Address, planet.StorageNodes[i].Addr())
+				assert.Equal(t, planet.StorageNodes[i].Local().Metadata.Wallet, resp.Node.GetMetadata().GetWallet())
 			}
 		}
 	}
 }
+
+func TestAnnounceExit(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	planet, err := testplanet.New(t, 1, 4, 1)
+	require.NoError(t, err)
+	defer ctx.Check(planet.Shutdown)
+
+	planet.Start(ctx)
+	// we wait a second for all the nodes to complete bootstrapping off the satellite
+	time.Sleep(2 * time.Second)
+
+	satellite := planet.Satellites[0]
+	satelliteInfo := satellite.Local()
+	leaving := planet.StorageNodes[0]
+
+	announce := func(ident *identity.FullIdentity) error {
+		conn, err := transport.NewClient(ident).DialNode(ctx, &satelliteInfo)
+		require.NoError(t, err)
+		defer ctx.Check(conn.Close)
+
+		_, err = pb.NewOverlayClient(conn).AnnounceExit(ctx, &pb.AnnounceExitRequest{Reason: "moving"})
+		return err
+	}
+
+	require.NoError(t, announce(leaving.Identity))
+	assert.True(t, satellite.Overlay.Vetting.Draining(leaving.ID()))
+
+	{ // draining nodes aren't selected anymore
+		explained, err := overlay.NewInspector(satellite.Overlay.Endpoint, nil).ExplainSelection(ctx, &pb.ExplainSelectionRequest{})
+		require.NoError(t, err)
+		for _, node := range explained.Nodes {
+			if node.NodeId == leaving.ID() {
+				assert.Equal(t, pb.SelectionResult_NODE_DRAINING, node.Result)
+			} else if node.Result != pb.SelectionResult_NOT_STORAGE_NODE {
+				assert.Equal(t, pb.SelectionResult_ELIGIBLE, node.Result, node.NodeId.String())
+			}
+		}
+
+		_, err = satellite.Overlay.Endpoint.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+			Opts: &pb.OverlayOptions{Amount: 4},
+		})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	}
+
+	// announcing again doesn't record another event
+	require.NoError(t, announce(leaving.Identity))
+	events, err := satellite.DB.NodeEvents().List(ctx, leaving.ID(), 0, 100)
+	require.NoError(t, err)
+	var draining int
+	for _, event := range events {
+		if event.Type == pb.NodeEventType_DRAINING {
+			draining++
+			assert.Equal(t, "moving", event.Data)
+		}
+	}
+	assert.Equal(t, 1, draining)
+
+	// only storage nodes can announce their exit
+	uplink, err := planet.NewIdentity()
+	require.NoError(t, err)
+	assert.Error(t, announce(uplink))
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"storj.io/storj/pkg/pb"
+)
+
+// Stats contains counters of overlay cache activity since the satellite started
+type Stats struct {
+	// CacheSize is the number of nodes found by the last complete selection scan
+	CacheSize int64 `json:"cacheSize"`
+
+	LookupHits   int64 `json:"lookupHits"`
+	LookupMisses int64 `json:"lookupMisses"`
+
+	Selections        int64         `json:"selections"`
+	SelectionFailures int64         `json:"selectionFailures"`
+	SelectionLatency  time.Duration `json:"selectionLatency"`
+
+	// Rejections counts nodes skipped during selection per failed filter
+	Rejections map[string]int64 `json:"rejections"`
+}
+
+// stats collects Stats and mirrors them into monkit
+type stats struct {
+	mu      sync.Mutex
+	current Stats
+}
+
+func newStats() *stats {
+	return &stats{current: Stats{Rejections: make(map[string]int64)}}
+}
+
+// snapshot returns a copy of the current counters
+func (stats *stats) snapshot() Stats {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	snapshot := stats.current
+	snapshot.Rejections = make(map[string]int64, len(stats.current.Rejections))
+	for filter, count := range stats.current.Rejections {
+		snapshot.Rejections[filter] = count
+	}
+	return snapshot
+}
+
+// lookup records a lookup of n nodes, of which found were in the cache
+func (stats *stats) lookup(n, found int) {
+	mon.Counter("lookup_hit").Inc(int64(found))
+	mon.Counter("lookup_miss").Inc(int64(n - found))
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.current.LookupHits += int64(found)
+	stats.current.LookupMisses += int64(n - found)
+}
+
+// selection records a finished FindStorageNodes call
+func (stats *stats) selection(latency time.Duration, err error) {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.current.Selections++
+	if err != nil {
+		stats.current.SelectionFailures++
+	}
+	stats.current.SelectionLatency = latency
+}
+
+// rejected records a node not passing a selection filter
+func (stats *stats) rejected(result pb.SelectionResult) {
+	filter := strings.ToLower(result.String())
+	mon.Counter("selection_rejected_" + filter).Inc(1)
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.current.Rejections[filter]++
+}
+
+// size records the number of nodes in the cache
+func (stats *stats) size(n int) {
+	mon.IntVal("cache_size").Observe(int64(n))
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.current.CacheSize = int64(n)
+}
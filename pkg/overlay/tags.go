@@ -0,0 +1,189 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+const (
+	// maxNodeTags is the number of tags a node may publish
+	maxNodeTags = 32
+	// maxNodeTagLength is the length of tag names and values
+	maxNodeTagLength = 64
+)
+
+// TagsError is the error class for publishing and verifying node tags
+var TagsError = errs.Class("node tags error")
+
+// ParseTags parses a comma separated list of name=value tags, e.g.
+// "ssd=true,region=eu". A tag without a value only requires the name when
+// it's used for selection.
+func ParseTags(s string) ([]*pb.NodeTag, error) {
+	var tags []*pb.NodeTag
+	for _, tag := range strings.Split(s, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+
+		parts := strings.SplitN(tag, "=", 2)
+		parsed := &pb.NodeTag{Name: strings.TrimSpace(parts[0])}
+		if len(parts) > 1 {
+			parsed.Value = strings.TrimSpace(parts[1])
+		}
+		if parsed.Name == "" {
+			return nil, TagsError.New("tag %q has no name", tag)
+		}
+		tags = append(tags, parsed)
+	}
+	return tags, checkTags(tags)
+}
+
+// SignTags signs the tags with the identity of the node publishing them
+func SignTags(ident *identity.FullIdentity, tags []*pb.NodeTag, signedAt time.Time) (*pb.SignedNodeTags, error) {
+	if err := checkTags(tags); err != nil {
+		return nil, err
+	}
+
+	signed := &pb.SignedNodeTags{
+		Tags:     tags,
+		SignedAt: signedAt.Unix(),
+		Chain:    [][]byte{ident.Leaf.Raw, ident.CA.Raw},
+	}
+
+	data, err := tagsData(ident.ID, signed)
+	if err != nil {
+		return nil, err
+	}
+	signed.Signature, err = auth.GenerateSignature(data, ident)
+	if err != nil {
+		return nil, TagsError.Wrap(err)
+	}
+	return signed, nil
+}
+
+// VerifyTags checks that the tags were signed by the node
+func VerifyTags(nodeID storj.NodeID, signed *pb.SignedNodeTags) error {
+	if err := checkTags(signed.GetTags()); err != nil {
+		return err
+	}
+
+	data, err := tagsData(nodeID, signed)
+	if err != nil {
+		return err
+	}
+	signer, err := auth.VerifyChainSignature(data, signed.Signature, signed.Chain)
+	if err != nil {
+		return TagsError.Wrap(err)
+	}
+	if signer != nodeID {
+		return TagsError.New("tags of %s are signed by %s", nodeID, signer)
+	}
+	return nil
+}
+
+// tagsData returns the signed bytes of the tags, binding them to the node
+func tagsData(nodeID storj.NodeID, signed *pb.SignedNodeTags) ([]byte, error) {
+	data, err := proto.Marshal(&pb.SignedNodeTags{
+		Tags:     signed.Tags,
+		SignedAt: signed.SignedAt,
+	})
+	if err != nil {
+		return nil, TagsError.Wrap(err)
+	}
+	return append(nodeID.Bytes(), data...), nil
+}
+
+// checkTags checks the number and length of the tags
+func checkTags(tags []*pb.NodeTag) error {
+	if len(tags) > maxNodeTags {
+		return TagsError.New("%d tags exceed the limit of %d", len(tags), maxNodeTags)
+	}
+	for _, tag := range tags {
+		if len(tag.Name) > maxNodeTagLength || len(tag.Value) > maxNodeTagLength {
+			return TagsError.New("tag %q exceeds %d characters", tag.Name, maxNodeTagLength)
+		}
+	}
+	return nil
+}
+
+// formatTags formats the tags the same way ParseTags parses them
+func formatTags(tags []*pb.NodeTag) string {
+	formatted := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		formatted = append(formatted, tag.Name+"="+tag.Value)
+	}
+	return "[" + strings.Join(formatted, ",") + "]"
+}
+
+// tagFilter are the tags selected nodes must have and must not have, tags
+// without a value match any value
+type tagFilter struct {
+	required []*pb.NodeTag
+	excluded []*pb.NodeTag
+}
+
+// hasTags returns whether tags contain all the required tags
+func hasTags(tags []*pb.NodeTag, required []*pb.NodeTag) bool {
+	for _, want := range required {
+		if !hasTag(tags, want) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasAnyTag returns whether tags contain any of the excluded tags
+func hasAnyTag(tags []*pb.NodeTag, excluded []*pb.NodeTag) bool {
+	for _, unwanted := range excluded {
+		if hasTag(tags, unwanted) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTag returns whether tags contain want, a want without a value matches
+// any value
+func hasTag(tags []*pb.NodeTag, want *pb.NodeTag) bool {
+	for _, tag := range tags {
+		if tag.Name == want.Name && (want.Value == "" || tag.Value == want.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeTags returns the tags a node signed with the operator tags of the
+// node, which replace the signed tags of the same name
+func mergeTags(signed, operator []*pb.NodeTag) []*pb.NodeTag {
+	if len(operator) == 0 {
+		return signed
+	}
+
+	merged := make([]*pb.NodeTag, 0, len(signed)+len(operator))
+	for _, tag := range signed {
+		overridden := false
+		for _, override := range operator {
+			if tag.Name == override.Name {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, tag)
+		}
+	}
+	return append(merged, operator...)
+}
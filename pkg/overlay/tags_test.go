@@ -0,0 +1,243 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/overlay/overlaytest"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestParseTags(t *testing.T) {
+	tags, err := overlay.ParseTags(" ssd=true, region=eu ,,gpu")
+	require.NoError(t, err)
+	assert.Equal(t, []*pb.NodeTag{
+		{Name: "ssd", Value: "true"},
+		{Name: "region", Value: "eu"},
+		{Name: "gpu"},
+	}, tags)
+
+	tags, err = overlay.ParseTags("")
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+
+	_, err = overlay.ParseTags("=eu")
+	assert.True(t, overlay.TagsError.Has(err))
+}
+
+func TestSignTags(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	ident, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+	other, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+
+	tags := []*pb.NodeTag{{Name: "ssd", Value: "true"}}
+	signed, err := overlay.SignTags(ident, tags, time.Now())
+	require.NoError(t, err)
+	assert.NoError(t, overlay.VerifyTags(ident.ID, signed))
+
+	{ // tags are bound to the node that signed them
+		err := overlay.VerifyTags(other.ID, signed)
+		assert.True(t, overlay.TagsError.Has(err))
+	}
+
+	{ // tampered tags are rejected
+		tampered := *signed
+		tampered.Tags = []*pb.NodeTag{{Name: "ssd", Value: "false"}}
+		assert.Error(t, overlay.VerifyTags(ident.ID, &tampered))
+
+		tampered = *signed
+		tampered.SignedAt++
+		assert.Error(t, overlay.VerifyTags(ident.ID, &tampered))
+	}
+
+	{ // tags signed by another node's leaf are rejected
+		forged, err := overlay.SignTags(other, tags, time.Now())
+		require.NoError(t, err)
+		forged.Chain[0] = signed.Chain[0]
+		assert.Error(t, overlay.VerifyTags(ident.ID, forged))
+	}
+}
+
+func TestCache_Tags(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		ident, err := testidentity.NewTestIdentity(ctx)
+		require.NoError(t, err)
+
+		cache := overlay.NewCache(db.OverlayCache(), db.StatDB(), overlay.NodeSelectionConfig{})
+		now := time.Now()
+
+		signed, err := overlay.SignTags(ident, []*pb.NodeTag{{Name: "region", Value: "eu"}}, now)
+		require.NoError(t, err)
+		require.NoError(t, cache.Put(ctx, ident.ID, pb.Node{Id: ident.ID, Tags: signed}))
+
+		node, err := cache.Get(ctx, ident.ID)
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(signed, node.GetTags()))
+
+		// updates without tags keep the known tags
+		require.NoError(t, cache.Put(ctx, ident.ID, pb.Node{Id: ident.ID}))
+		node, err = cache.Get(ctx, ident.ID)
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(signed, node.GetTags()))
+
+		// older tags don't replace newer ones
+		older, err := overlay.SignTags(ident, []*pb.NodeTag{{Name: "region", Value: "us"}}, now.Add(-time.Hour))
+		require.NoError(t, err)
+		require.NoError(t, cache.Put(ctx, ident.ID, pb.Node{Id: ident.ID, Tags: older}))
+		node, err = cache.Get(ctx, ident.ID)
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(signed, node.GetTags()))
+
+		// invalid tags are dropped
+		tampered, err := overlay.SignTags(ident, []*pb.NodeTag{{Name: "region", Value: "asia"}}, now.Add(time.Hour))
+		require.NoError(t, err)
+		tampered.Tags[0].Value = "ap"
+		require.NoError(t, cache.Put(ctx, ident.ID, pb.Node{Id: ident.ID, Tags: tampered}))
+		node, err = cache.Get(ctx, ident.ID)
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(signed, node.GetTags()))
+
+		// newer tags replace older ones
+		newer, err := overlay.SignTags(ident, []*pb.NodeTag{{Name: "region", Value: "ap"}}, now.Add(time.Hour))
+		require.NoError(t, err)
+		require.NoError(t, cache.Put(ctx, ident.ID, pb.Node{Id: ident.ID, Tags: newer}))
+		node, err = cache.Get(ctx, ident.ID)
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(newer, node.GetTags()))
+	})
+}
+
+func TestSelectionTags(t *testing.T) {
+	ctx := context.Background()
+
+	specs := make([]overlaytest.NodeSpec, 4)
+	cache, nodes := overlaytest.NewCache(overlay.NodeSelectionConfig{}, specs...)
+	server := overlay.NewServer(zaptest.NewLogger(t), cache, overlay.NodeSelectionConfig{}, nil, nil)
+
+	// nodes 0 and 1 have ssds, only node 0 is in the eu
+	for i, tags := range [][]*pb.NodeTag{
+		{{Name: "ssd", Value: "true"}, {Name: "region", Value: "eu"}},
+		{{Name: "ssd", Value: "true"}, {Name: "region", Value: "us"}},
+	} {
+		node := specs[i].Node(i)
+		node.Tags = &pb.SignedNodeTags{Tags: tags}
+		require.NoError(t, nodes.Update(ctx, node))
+	}
+
+	result, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+		Opts: &pb.OverlayOptions{Amount: 2, Tags: []*pb.NodeTag{{Name: "ssd", Value: "true"}}},
+	})
+	require.NoError(t, err)
+	assert.Len(t, result.Nodes, 2)
+
+	_, err = server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+		Opts: &pb.OverlayOptions{Amount: 2, Tags: []*pb.NodeTag{{Name: "region", Value: "eu"}}},
+	})
+	assert.Error(t, err)
+
+	inspector := overlay.NewInspector(server, nil)
+	explained, err := inspector.ExplainSelection(ctx, &pb.ExplainSelectionRequest{
+		Tags: []*pb.NodeTag{{Name: "ssd"}, {Name: "region", Value: "eu"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, explained.Nodes, len(specs))
+	assert.Equal(t, pb.SelectionResult_ELIGIBLE, explained.Nodes[0].Result)
+	for _, node := range explained.Nodes[1:] {
+		assert.Equal(t, pb.SelectionResult_MISSING_TAGS, node.Result)
+	}
+	assert.EqualValues(t, 1, explained.Eligible)
+}
+
+func TestOperatorTags(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	specs := make([]overlaytest.NodeSpec, 4)
+	cache, nodes := overlaytest.NewCache(overlay.NodeSelectionConfig{}, specs...)
+
+	tagsFile := ctx.File("tags")
+	lists, err := overlay.NewNodeLists(zaptest.NewLogger(t), "", "", tagsFile)
+	require.NoError(t, err)
+	server := overlay.NewServer(zaptest.NewLogger(t), cache, overlay.NodeSelectionConfig{}, lists, nil)
+	inspector := overlay.NewInspector(server, nil)
+
+	// node 0 signed that it's in the eu
+	node := specs[0].Node(0)
+	node.Tags = &pb.SignedNodeTags{Tags: []*pb.NodeTag{{Name: "region", Value: "eu"}}}
+	require.NoError(t, nodes.Update(ctx, node))
+
+	// the operator moves node 0 to the us and node 1 to a private pool
+	resp, err := inspector.SetNodeTags(ctx, &pb.SetNodeTagsRequest{
+		NodeId: node.Id,
+		Tags:   []*pb.NodeTag{{Name: "region", Value: "us"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*pb.NodeTag{{Name: "region", Value: "us"}}, resp.Tags)
+	_, err = inspector.SetNodeTags(ctx, &pb.SetNodeTagsRequest{
+		NodeId: specs[1].Node(1).Id,
+		Tags:   []*pb.NodeTag{{Name: "pool", Value: "private"}},
+	})
+	require.NoError(t, err)
+
+	_, err = server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+		Opts: &pb.OverlayOptions{Amount: 1, Tags: []*pb.NodeTag{{Name: "region", Value: "eu"}}},
+	})
+	assert.Error(t, err, "operator tags replace the signed tags")
+
+	result, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+		Opts: &pb.OverlayOptions{Amount: 1, Tags: []*pb.NodeTag{{Name: "pool", Value: "private"}}},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Nodes, 1)
+	assert.Equal(t, specs[1].Node(1).Id, result.Nodes[0].Id)
+
+	// the private pool is excluded from public uploads
+	excluded := []*pb.NodeTag{{Name: "pool"}}
+	result, err = server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+		Opts: &pb.OverlayOptions{Amount: 3, ExcludedTags: excluded},
+	})
+	require.NoError(t, err)
+	for _, selected := range result.Nodes {
+		assert.NotEqual(t, specs[1].Node(1).Id, selected.Id)
+	}
+	_, err = server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+		Opts: &pb.OverlayOptions{Amount: 4, ExcludedTags: excluded},
+	})
+	assert.Error(t, err)
+
+	explained, err := inspector.ExplainSelection(ctx, &pb.ExplainSelectionRequest{ExcludedTags: excluded})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, explained.Eligible)
+
+	// the tags are kept across restarts and can be removed
+	reloaded, err := overlay.NewNodeLists(zaptest.NewLogger(t), "", "", tagsFile)
+	require.NoError(t, err)
+	assert.Equal(t, 2, reloaded.Tagged())
+	assert.Equal(t, []*pb.NodeTag{{Name: "pool", Value: "private"}}, reloaded.Tags(specs[1].Node(1).Id))
+
+	require.NoError(t, reloaded.SetTags(specs[1].Node(1).Id, nil))
+	assert.Equal(t, 1, reloaded.Tagged())
+	require.NoError(t, lists.Reload())
+	assert.Empty(t, lists.Tags(specs[1].Node(1).Id))
+}
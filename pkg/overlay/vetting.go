@@ -0,0 +1,386 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/watchdog"
+	"storj.io/storj/storage"
+)
+
+// VettingConfig is a configuration struct for moving nodes through the
+// vetting states new, vetted, suspended and disqualified
+type VettingConfig struct {
+	AuditCount  int64       `help:"the number of audits a new node must go through before it's vetted" default:"0"`
+	UptimeCount int64       `help:"the number of uptime checks a new node must go through before it's vetted" default:"0"`
+	DataHeld    memory.Size `help:"the amount of data a new node must hold according to the last tally before it's vetted" default:"0B"`
+
+	NewNodePercentage float64 `help:"the share of the nodes selected for an upload which are new nodes, the rest are vetted ones as long as there are enough" default:"0.05"`
+
+	SuspensionUptimeReputation      float64 `help:"the uptime reputation below which a vetted node is suspended from selection until it recovers" default:"0"`
+	DisqualificationAuditReputation float64 `help:"the audit reputation below which an audited node is disqualified for good" default:"0"`
+
+	Interval time.Duration `help:"how frequently the vetting states of all nodes are updated" default:"5m0s"`
+}
+
+// Vetting moves nodes through the vetting states. New nodes only receive a
+// limited share of uploads until they are vetted, vetted nodes are suspended
+// from selection while their uptime is too low and nodes failing audits are
+// disqualified for good. Nodes announcing their exit are drained, they aren't
+// selected anymore while their pieces are repaired to other nodes. State
+// changes are recorded as node events.
+type Vetting struct {
+	log    *zap.Logger
+	cache  *Cache
+	events EventsDB
+	config VettingConfig
+	loop   *watchdog.Loop
+
+	mu         sync.Mutex
+	states     map[storj.NodeID]pb.NodeVetting_State
+	dataHeld   map[storj.NodeID]int64
+	dataLoaded bool
+}
+
+// NewVetting creates a new node vetting subsystem
+func NewVetting(log *zap.Logger, cache *Cache, events EventsDB, config VettingConfig, loop *watchdog.Loop) *Vetting {
+	return &Vetting{
+		log:      log,
+		cache:    cache,
+		events:   events,
+		config:   config,
+		loop:     loop,
+		states:   make(map[storj.NodeID]pb.NodeVetting_State),
+		dataHeld: make(map[storj.NodeID]int64),
+	}
+}
+
+// State returns the vetting state of the node, including changes of its
+// reputation which haven't been recorded yet. A nil Vetting considers every
+// node as vetted.
+func (vetting *Vetting) State(node *pb.Node) pb.NodeVetting_State {
+	if vetting == nil {
+		return pb.NodeVetting_VETTED
+	}
+
+	vetting.mu.Lock()
+	defer vetting.mu.Unlock()
+
+	state, _ := vetting.next(node)
+	return state
+}
+
+// newNodes returns how many of n selected nodes should be new nodes
+func (vetting *Vetting) newNodes(n int) int {
+	if vetting == nil {
+		return 0
+	}
+	return int(float64(n) * vetting.config.NewNodePercentage)
+}
+
+// Inspect returns the vetting state of the node together with the statistics
+// it's based on
+func (vetting *Vetting) Inspect(node *pb.Node) *pb.NodeVetting {
+	vetting.mu.Lock()
+	defer vetting.mu.Unlock()
+
+	state, _ := vetting.next(node)
+
+	dataHeld := int64(-1)
+	if vetting.dataLoaded {
+		dataHeld = vetting.dataHeld[node.Id]
+	}
+
+	reputation := node.GetReputation()
+	return &pb.NodeVetting{
+		NodeId:           node.Id,
+		State:            state,
+		AuditCount:       reputation.GetAuditCount(),
+		UptimeCount:      reputation.GetUptimeCount(),
+		DataHeld:         dataHeld,
+		AuditReputation:  reputation.GetAuditReputation(),
+		UptimeReputation: reputation.GetUptimeReputation(),
+	}
+}
+
+// Draining returns whether the node announced its exit. It's nil-safe, so that
+// the checker can ask regardless of whether vetting is running.
+func (vetting *Vetting) Draining(nodeID storj.NodeID) bool {
+	if vetting == nil {
+		return false
+	}
+
+	vetting.mu.Lock()
+	defer vetting.mu.Unlock()
+
+	return vetting.states[nodeID] == pb.NodeVetting_DRAINING
+}
+
+// DrainingNodes returns the nodes in the draining state. It's nil-safe like
+// Draining.
+func (vetting *Vetting) DrainingNodes() storj.NodeIDList {
+	if vetting == nil {
+		return nil
+	}
+
+	vetting.mu.Lock()
+	defer vetting.mu.Unlock()
+
+	var draining storj.NodeIDList
+	for nodeID, state := range vetting.states {
+		if state == pb.NodeVetting_DRAINING {
+			draining = append(draining, nodeID)
+		}
+	}
+	return draining
+}
+
+// Drain moves the node to the draining state, effective for selection
+// immediately, and records the change. Draining a disqualified node fails,
+// draining a node again doesn't change anything.
+func (vetting *Vetting) Drain(ctx context.Context, nodeID storj.NodeID, reason string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	vetting.mu.Lock()
+	previous := vetting.states[nodeID]
+	if previous != pb.NodeVetting_DISQUALIFIED {
+		vetting.states[nodeID] = pb.NodeVetting_DRAINING
+	}
+	vetting.mu.Unlock()
+
+	switch previous {
+	case pb.NodeVetting_DISQUALIFIED:
+		return Error.New("node %s is disqualified", nodeID)
+	case pb.NodeVetting_DRAINING:
+		return nil
+	}
+
+	vetting.log.Info("node vetting state changed",
+		zap.String("node", nodeID.String()),
+		zap.String("from", previous.String()),
+		zap.String("to", pb.NodeVetting_DRAINING.String()),
+		zap.String("reason", reason))
+
+	err = vetting.events.Record(ctx, nodeID, pb.NodeEventType_DRAINING, reason)
+	if err != nil {
+		vetting.mu.Lock()
+		vetting.states[nodeID] = previous
+		vetting.mu.Unlock()
+		return Error.Wrap(err)
+	}
+	return nil
+}
+
+// ReportDataHeld replaces the amount of data held by every node, nodes
+// missing from held don't hold any data. It's nil-safe, so that the tally can
+// report regardless of whether vetting is running.
+func (vetting *Vetting) ReportDataHeld(held map[storj.NodeID]int64) {
+	if vetting == nil {
+		return
+	}
+
+	vetting.mu.Lock()
+	defer vetting.mu.Unlock()
+
+	vetting.dataHeld = held
+	vetting.dataLoaded = true
+}
+
+// Run loads the recorded vetting states and updates the states of all nodes
+// every interval, until the context is canceled
+func (vetting *Vetting) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := vetting.load(ctx); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(vetting.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		err := vetting.update(ctx)
+		if err != nil {
+			vetting.log.Error("updating vetting states failed", zap.Error(err))
+		}
+		vetting.loop.Cycle(err)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// load restores the vetting states from the recorded node events
+func (vetting *Vetting) load(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	states := make(map[storj.NodeID]pb.NodeVetting_State)
+
+	var cursor int64
+	for {
+		events, err := vetting.events.List(ctx, storj.NodeID{}, cursor, maxNodeEventsLimit)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		if len(events) == 0 {
+			break
+		}
+
+		for _, event := range events {
+			// events are listed in the order they were recorded
+			if state, ok := vettingEventStates[event.Type]; ok {
+				states[event.NodeId] = state
+			}
+			cursor = event.Id
+		}
+	}
+
+	vetting.mu.Lock()
+	defer vetting.mu.Unlock()
+
+	vetting.states = states
+	return nil
+}
+
+// update moves all nodes in the cache to their next state, recording the
+// changes as node events
+func (vetting *Vetting) update(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	counts := make(map[pb.NodeVetting_State]int64)
+
+	var cursor storj.NodeID
+	for {
+		nodes, err := vetting.cache.db.List(ctx, cursor, storage.LookupLimit)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		var nextCursor storj.NodeID
+		for _, node := range nodes {
+			if node == nil {
+				continue
+			}
+			nextCursor = node.Id
+			if node.Id == cursor && !cursor.IsZero() {
+				// the cursor is listed on both pages
+				continue
+			}
+			if node.Type != pb.NodeType_STORAGE {
+				continue
+			}
+
+			state, err := vetting.transition(ctx, node)
+			if err != nil {
+				return err
+			}
+			counts[state]++
+		}
+
+		// no progress means the end was reached
+		if nextCursor.IsZero() || nextCursor == cursor {
+			break
+		}
+		cursor = nextCursor
+	}
+
+	for state := range pb.NodeVetting_State_name {
+		mon.IntVal("vetting_" + pb.NodeVetting_State(state).String()).Observe(counts[pb.NodeVetting_State(state)])
+	}
+	return nil
+}
+
+// transition moves the node to its next state and records the change
+func (vetting *Vetting) transition(ctx context.Context, node *pb.Node) (pb.NodeVetting_State, error) {
+	vetting.mu.Lock()
+	previous := vetting.states[node.Id]
+	state, reason := vetting.next(node)
+	vetting.states[node.Id] = state
+	vetting.mu.Unlock()
+
+	if state == previous {
+		return state, nil
+	}
+
+	vetting.log.Info("node vetting state changed",
+		zap.String("node", node.Id.String()),
+		zap.String("from", previous.String()),
+		zap.String("to", state.String()),
+		zap.String("reason", reason))
+
+	err := vetting.events.Record(ctx, node.Id, vettingStateEvents[state], reason)
+	if err != nil {
+		// retry recording the change during the next update
+		vetting.mu.Lock()
+		vetting.states[node.Id] = previous
+		vetting.mu.Unlock()
+		return state, Error.Wrap(err)
+	}
+	return state, nil
+}
+
+// next returns the state the node moves to from its current state and the
+// reason of the change, the caller must hold the mutex
+func (vetting *Vetting) next(node *pb.Node) (pb.NodeVetting_State, string) {
+	config := vetting.config
+	state := vetting.states[node.Id]
+	reputation := node.GetReputation()
+
+	audited := reputation.GetAuditCount() > 0 && reputation.GetAuditCount() >= config.AuditCount
+	monitored := reputation.GetUptimeCount() > 0
+
+	switch {
+	case state == pb.NodeVetting_DISQUALIFIED:
+		return state, ""
+	case audited && reputation.GetAuditReputation() < config.DisqualificationAuditReputation:
+		return pb.NodeVetting_DISQUALIFIED, fmt.Sprintf("audit reputation %.3f < %.3f", reputation.GetAuditReputation(), config.DisqualificationAuditReputation)
+	case state == pb.NodeVetting_DRAINING:
+		// draining nodes only leave, or fail audits on the way out
+		return state, ""
+	case state == pb.NodeVetting_NEW:
+		if reputation.GetAuditCount() < config.AuditCount || reputation.GetUptimeCount() < config.UptimeCount {
+			return state, ""
+		}
+		if config.DataHeld > 0 && (!vetting.dataLoaded || vetting.dataHeld[node.Id] < config.DataHeld.Int64()) {
+			return state, ""
+		}
+		return pb.NodeVetting_VETTED, fmt.Sprintf("%d audits, %d uptime checks, %d bytes held", reputation.GetAuditCount(), reputation.GetUptimeCount(), vetting.dataHeld[node.Id])
+	case monitored && reputation.GetUptimeReputation() < config.SuspensionUptimeReputation:
+		if state == pb.NodeVetting_SUSPENDED {
+			return state, ""
+		}
+		return pb.NodeVetting_SUSPENDED, fmt.Sprintf("uptime reputation %.3f < %.3f", reputation.GetUptimeReputation(), config.SuspensionUptimeReputation)
+	case state == pb.NodeVetting_SUSPENDED:
+		return pb.NodeVetting_VETTED, fmt.Sprintf("uptime reputation %.3f recovered", reputation.GetUptimeReputation())
+	}
+	return state, ""
+}
+
+// vettingStateEvents are the node events recording the change to a state
+var vettingStateEvents = map[pb.NodeVetting_State]pb.NodeEventType{
+	pb.NodeVetting_VETTED:       pb.NodeEventType_VETTED,
+	pb.NodeVetting_SUSPENDED:    pb.NodeEventType_SUSPENDED,
+	pb.NodeVetting_DISQUALIFIED: pb.NodeEventType_DISQUALIFIED,
+	pb.NodeVetting_DRAINING:     pb.NodeEventType_DRAINING,
+}
+
+// vettingEventStates are the states node events change to
+var vettingEventStates = map[pb.NodeEventType]pb.NodeVetting_State{
+	pb.NodeEventType_VETTED:       pb.NodeVetting_VETTED,
+	pb.NodeEventType_SUSPENDED:    pb.NodeVetting_SUSPENDED,
+	pb.NodeEventType_DISQUALIFIED: pb.NodeVetting_DISQUALIFIED,
+	pb.NodeEventType_DRAINING:     pb.NodeVetting_DRAINING,
+}
@@ -0,0 +1,184 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/overlay/overlaytest"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+var vettingConfig = overlay.VettingConfig{
+	AuditCount:                      5,
+	UptimeCount:                     5,
+	NewNodePercentage:               0.2,
+	SuspensionUptimeReputation:      0.5,
+	DisqualificationAuditReputation: 0.5,
+	Interval:                        10 * time.Millisecond,
+}
+
+func TestVettingStates(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		specs := []overlaytest.NodeSpec{
+			{AuditCount: 2, AuditReputation: 1, UptimeCount: 10, UptimeReputation: 1},
+			{AuditCount: 10, AuditReputation: 1, UptimeCount: 10, UptimeReputation: 1},
+			{AuditCount: 10, AuditReputation: 0.2, UptimeCount: 10, UptimeReputation: 1},
+			{AuditCount: 10, AuditReputation: 1, UptimeCount: 10, UptimeReputation: 0.2},
+		}
+		cache, nodes := overlaytest.NewCache(overlay.NodeSelectionConfig{}, specs...)
+		vetting := overlay.NewVetting(zaptest.NewLogger(t), cache, db.NodeEvents(), vettingConfig, nil)
+		server := overlay.NewServer(zaptest.NewLogger(t), cache, overlay.NodeSelectionConfig{}, nil, vetting)
+		inspector := overlay.NewInspector(server, db.NodeEvents())
+
+		{ // states are derived before any update is recorded
+			expected := []pb.NodeVetting_State{
+				pb.NodeVetting_NEW,
+				pb.NodeVetting_VETTED,
+				pb.NodeVetting_DISQUALIFIED,
+				pb.NodeVetting_VETTED,
+			}
+			for i, state := range expected {
+				res, err := inspector.NodeVetting(ctx, &pb.NodeVettingRequest{NodeId: overlaytest.NodeID(i)})
+				require.NoError(t, err)
+				assert.Equal(t, state, res.Vetting.State, i)
+				assert.EqualValues(t, -1, res.Vetting.DataHeld, i)
+			}
+		}
+
+		runCtx, cancel := context.WithCancel(ctx)
+		ctx.Go(func() error {
+			_ = vetting.Run(runCtx)
+			return nil
+		})
+		defer cancel()
+
+		// the poorly available node is vetted first and suspended afterwards
+		waitForEvents(ctx, t, db, overlaytest.NodeID(3), pb.NodeEventType_VETTED, pb.NodeEventType_SUSPENDED)
+		waitForEvents(ctx, t, db, overlaytest.NodeID(2), pb.NodeEventType_DISQUALIFIED)
+		waitForEvents(ctx, t, db, overlaytest.NodeID(1), pb.NodeEventType_VETTED)
+
+		explained, err := inspector.ExplainSelection(ctx, &pb.ExplainSelectionRequest{})
+		require.NoError(t, err)
+		require.Len(t, explained.Nodes, len(specs))
+		assert.Equal(t, pb.SelectionResult_ELIGIBLE, explained.Nodes[0].Result)
+		assert.Equal(t, pb.SelectionResult_ELIGIBLE, explained.Nodes[1].Result)
+		assert.Equal(t, pb.SelectionResult_NODE_DISQUALIFIED, explained.Nodes[2].Result)
+		assert.Equal(t, pb.SelectionResult_NODE_SUSPENDED, explained.Nodes[3].Result)
+
+		{ // suspended nodes recover, disqualified nodes don't
+			recovered := specs[2]
+			recovered.AuditReputation = 1
+			require.NoError(t, nodes.Update(ctx, recovered.Node(2)))
+			recovered = specs[3]
+			recovered.UptimeReputation = 1
+			require.NoError(t, nodes.Update(ctx, recovered.Node(3)))
+
+			waitForEvents(ctx, t, db, overlaytest.NodeID(3), pb.NodeEventType_VETTED, pb.NodeEventType_SUSPENDED, pb.NodeEventType_VETTED)
+			assert.Equal(t, pb.NodeVetting_DISQUALIFIED, vetting.State(recovered.Node(2)))
+		}
+
+		{ // draining nodes stay draining, also after a restart
+			require.NoError(t, vetting.Drain(ctx, overlaytest.NodeID(1), "leaving"))
+			assert.True(t, vetting.Draining(overlaytest.NodeID(1)))
+			assert.Error(t, vetting.Drain(ctx, overlaytest.NodeID(2), "leaving"))
+			waitForEvents(ctx, t, db, overlaytest.NodeID(1), pb.NodeEventType_VETTED, pb.NodeEventType_DRAINING)
+
+			restarted := overlay.NewVetting(zaptest.NewLogger(t), cache, db.NodeEvents(), vettingConfig, nil)
+			ctx.Go(func() error {
+				_ = restarted.Run(runCtx)
+				return nil
+			})
+			for start := time.Now(); !restarted.Draining(overlaytest.NodeID(1)) && time.Since(start) < 10*time.Second; {
+				time.Sleep(10 * time.Millisecond)
+			}
+			assert.Equal(t, pb.NodeVetting_DRAINING, restarted.State(specs[1].Node(1)))
+		}
+	})
+}
+
+func TestVettingSelection(t *testing.T) {
+	ctx := context.Background()
+
+	config := vettingConfig
+	config.Interval = time.Hour
+
+	// nodes 0 to 9 are new, nodes 10 to 19 are vetted
+	specs := make([]overlaytest.NodeSpec, 20)
+	for i := range specs {
+		specs[i] = overlaytest.NodeSpec{AuditReputation: 1, UptimeReputation: 1}
+		if i >= 10 {
+			specs[i].AuditCount, specs[i].UptimeCount = 10, 10
+		}
+	}
+
+	countNew := func(nodes []*pb.Node) (count int) {
+		for _, node := range nodes {
+			if node.GetReputation().GetAuditCount() == 0 {
+				count++
+			}
+		}
+		return count
+	}
+
+	cache, _ := overlaytest.NewCache(overlay.NodeSelectionConfig{}, specs...)
+	vetting := overlay.NewVetting(zaptest.NewLogger(t), cache, nil, config, nil)
+	server := overlay.NewServer(zaptest.NewLogger(t), cache, overlay.NodeSelectionConfig{}, nil, vetting)
+
+	{ // new nodes receive their share of the selection
+		result, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+			Opts: &pb.OverlayOptions{Amount: 10},
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Nodes, 10)
+		assert.Equal(t, 2, countNew(result.Nodes))
+	}
+
+	{ // new nodes fill up the selection when there aren't enough vetted nodes
+		var excluded storj.NodeIDList
+		for i := 10; i < 18; i++ {
+			excluded = append(excluded, overlaytest.NodeID(i))
+		}
+		result, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+			Opts: &pb.OverlayOptions{Amount: 10, ExcludedNodes: excluded},
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Nodes, 10)
+		assert.Equal(t, 8, countNew(result.Nodes))
+	}
+}
+
+// waitForEvents waits until the vetting events recorded for the node match expected
+func waitForEvents(ctx context.Context, t *testing.T, db satellite.DB, nodeID storj.NodeID, expected ...pb.NodeEventType) {
+	var types []pb.NodeEventType
+	for start := time.Now(); time.Since(start) < 10*time.Second; time.Sleep(10 * time.Millisecond) {
+		events, err := db.NodeEvents().List(ctx, nodeID, 0, 100)
+		require.NoError(t, err)
+
+		types = types[:0]
+		for _, event := range events {
+			if event.Type != pb.NodeEventType_FIRST_CONTACT && event.Type != pb.NodeEventType_ADDRESS_CHANGED {
+				types = append(types, event.Type)
+			}
+		}
+		if len(types) >= len(expected) {
+			break
+		}
+	}
+	assert.Equal(t, expected, types)
+}
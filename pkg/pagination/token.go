@@ -0,0 +1,79 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+
+	"github.com/zeebo/errs"
+)
+
+// Error is the error class for invalid page tokens
+var Error = errs.Class("pagination error")
+
+// macSize is the number of signature bytes a token carries
+const macSize = 16
+
+// Signer creates and checks the opaque tokens listings return to continue
+// after a page. A token holds the position of the last listed item in the
+// stable order of the listing, so later pages neither skip nor repeat items
+// when items are added or removed in between. Tokens are signed, so clients
+// can only continue listings they were given a token for.
+type Signer struct {
+	key []byte
+}
+
+// NewSigner returns a signer for tokens with key
+func NewSigner(key []byte) *Signer {
+	return &Signer{key: append([]byte(nil), key...)}
+}
+
+// NewRandomSigner returns a signer with a random key, its tokens are valid
+// until the process restarts
+func NewRandomSigner() (*Signer, error) {
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return &Signer{key: key}, nil
+}
+
+// Encode returns the token continuing listing after position. The listing
+// names the listing and its filters, tokens of one listing aren't valid for
+// another.
+func (signer *Signer) Encode(listing string, position []byte) string {
+	token := append(append([]byte(nil), position...), signer.mac(listing, position)...)
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+// Decode returns the position a token of listing continues after. The empty
+// token starts the listing and returns a nil position.
+func (signer *Signer) Decode(listing string, token string) (position []byte, err error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(data) < macSize {
+		return nil, Error.New("malformed page token")
+	}
+
+	position, mac := data[:len(data)-macSize], data[len(data)-macSize:]
+	if !hmac.Equal(mac, signer.mac(listing, position)) {
+		return nil, Error.New("invalid page token")
+	}
+	return position, nil
+}
+
+// mac returns the signature of position in listing
+func (signer *Signer) mac(listing string, position []byte) []byte {
+	hash := hmac.New(sha256.New, signer.key)
+	_, _ = hash.Write([]byte(listing))
+	_, _ = hash.Write([]byte{0})
+	_, _ = hash.Write(position)
+	return hash.Sum(nil)[:macSize]
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pagination_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/pkg/pagination"
+)
+
+func TestToken(t *testing.T) {
+	signer, err := pagination.NewRandomSigner()
+	require.NoError(t, err)
+
+	position, err := signer.Decode("events", "")
+	require.NoError(t, err)
+	assert.Nil(t, position)
+
+	token := signer.Encode("events", []byte("position"))
+	position, err = signer.Decode("events", token)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("position"), position)
+
+	// tokens are only valid for their listing
+	_, err = signer.Decode("nodes", token)
+	assert.True(t, pagination.Error.Has(err))
+
+	// and of their signer
+	other := pagination.NewSigner([]byte("other key"))
+	_, err = other.Decode("events", token)
+	assert.True(t, pagination.Error.Has(err))
+
+	// tampered tokens are rejected
+	forged := other.Encode("events", []byte("elsewhere"))
+	_, err = signer.Decode("events", forged)
+	assert.True(t, pagination.Error.Has(err))
+
+	for _, malformed := range []string{"!", "c2hvcnQ"} {
+		_, err = signer.Decode("events", malformed)
+		assert.True(t, pagination.Error.Has(err), malformed)
+	}
+}
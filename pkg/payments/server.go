@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/zeebo/errs"
@@ -105,6 +106,7 @@ func (srv *Server) GenerateCSV(ctx context.Context, req *pb.GenerateCSVRequest)
 		"bytes:BWPut",
 		"date",
 		"walletAddress",
+		"walletFeatures",
 	}
 	if err := w.Write(headers); err != nil {
 		return nil, PaymentsError.Wrap(err)
@@ -112,11 +114,12 @@ func (srv *Server) GenerateCSV(ctx context.Context, req *pb.GenerateCSVRequest)
 
 	for _, row := range rows {
 		nid := row.NodeID
-		wallet, err := srv.overlayDB.GetWalletAddress(ctx, nid)
+		node, err := srv.overlayDB.Get(ctx, nid)
 		if err != nil {
 			return nil, PaymentsError.Wrap(err)
 		}
-		row.Wallet = wallet
+		row.Wallet = node.GetMetadata().GetWallet()
+		row.WalletFeatures = node.GetMetadata().GetWalletFeatures()
 		record := structToStringSlice(row)
 		if err := w.Write(record); err != nil {
 			return nil, PaymentsError.Wrap(err)
@@ -152,6 +155,7 @@ func structToStringSlice(s *accounting.CSVRow) []string {
 		strconv.FormatInt(s.GetTotal, 10),
 		s.Date.Format("2006-01-02"),
 		s.Wallet,
+		strings.Join(s.WalletFeatures, ","),
 	}
 	return record
 }
@@ -7,6 +7,11 @@ import proto "github.com/gogo/protobuf/proto"
 import fmt "fmt"
 import math "math"
 
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
 var _ = fmt.Errorf
@@ -31,7 +36,7 @@ func (m *InjuredSegment) Reset()         { *m = InjuredSegment{} }
 func (m *InjuredSegment) String() string { return proto.CompactTextString(m) }
 func (*InjuredSegment) ProtoMessage()    {}
 func (*InjuredSegment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datarepair_13e4beab54f194bd, []int{0}
+	return fileDescriptor_datarepair_a3c07f7a7ae0f171, []int{0}
 }
 func (m *InjuredSegment) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InjuredSegment.Unmarshal(m, b)
@@ -65,20 +70,219 @@ func (m *InjuredSegment) GetLostPieces() []int32 {
 	return nil
 }
 
+// CorruptionReport contains the pieces a storage node found to be corrupted
+type CorruptionReport struct {
+	Pieces               []*CorruptedPiece `protobuf:"bytes,1,rep,name=pieces" json:"pieces,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CorruptionReport) Reset()         { *m = CorruptionReport{} }
+func (m *CorruptionReport) String() string { return proto.CompactTextString(m) }
+func (*CorruptionReport) ProtoMessage()    {}
+func (*CorruptionReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datarepair_a3c07f7a7ae0f171, []int{1}
+}
+func (m *CorruptionReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CorruptionReport.Unmarshal(m, b)
+}
+func (m *CorruptionReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CorruptionReport.Marshal(b, m, deterministic)
+}
+func (dst *CorruptionReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CorruptionReport.Merge(dst, src)
+}
+func (m *CorruptionReport) XXX_Size() int {
+	return xxx_messageInfo_CorruptionReport.Size(m)
+}
+func (m *CorruptionReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_CorruptionReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CorruptionReport proto.InternalMessageInfo
+
+func (m *CorruptionReport) GetPieces() []*CorruptedPiece {
+	if m != nil {
+		return m.Pieces
+	}
+	return nil
+}
+
+type CorruptedPiece struct {
+	PieceId              string   `protobuf:"bytes,1,opt,name=piece_id,json=pieceId,proto3" json:"piece_id,omitempty"`
+	DetectedUnixSec      int64    `protobuf:"varint,2,opt,name=detected_unix_sec,json=detectedUnixSec,proto3" json:"detected_unix_sec,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CorruptedPiece) Reset()         { *m = CorruptedPiece{} }
+func (m *CorruptedPiece) String() string { return proto.CompactTextString(m) }
+func (*CorruptedPiece) ProtoMessage()    {}
+func (*CorruptedPiece) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datarepair_a3c07f7a7ae0f171, []int{2}
+}
+func (m *CorruptedPiece) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CorruptedPiece.Unmarshal(m, b)
+}
+func (m *CorruptedPiece) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CorruptedPiece.Marshal(b, m, deterministic)
+}
+func (dst *CorruptedPiece) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CorruptedPiece.Merge(dst, src)
+}
+func (m *CorruptedPiece) XXX_Size() int {
+	return xxx_messageInfo_CorruptedPiece.Size(m)
+}
+func (m *CorruptedPiece) XXX_DiscardUnknown() {
+	xxx_messageInfo_CorruptedPiece.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CorruptedPiece proto.InternalMessageInfo
+
+func (m *CorruptedPiece) GetPieceId() string {
+	if m != nil {
+		return m.PieceId
+	}
+	return ""
+}
+
+func (m *CorruptedPiece) GetDetectedUnixSec() int64 {
+	if m != nil {
+		return m.DetectedUnixSec
+	}
+	return 0
+}
+
+type CorruptionReportResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CorruptionReportResponse) Reset()         { *m = CorruptionReportResponse{} }
+func (m *CorruptionReportResponse) String() string { return proto.CompactTextString(m) }
+func (*CorruptionReportResponse) ProtoMessage()    {}
+func (*CorruptionReportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datarepair_a3c07f7a7ae0f171, []int{3}
+}
+func (m *CorruptionReportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CorruptionReportResponse.Unmarshal(m, b)
+}
+func (m *CorruptionReportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CorruptionReportResponse.Marshal(b, m, deterministic)
+}
+func (dst *CorruptionReportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CorruptionReportResponse.Merge(dst, src)
+}
+func (m *CorruptionReportResponse) XXX_Size() int {
+	return xxx_messageInfo_CorruptionReportResponse.Size(m)
+}
+func (m *CorruptionReportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CorruptionReportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CorruptionReportResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*InjuredSegment)(nil), "repair.InjuredSegment")
+	proto.RegisterType((*CorruptionReport)(nil), "repair.CorruptionReport")
+	proto.RegisterType((*CorruptedPiece)(nil), "repair.CorruptedPiece")
+	proto.RegisterType((*CorruptionReportResponse)(nil), "repair.CorruptionReportResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// PieceHealthClient is the client API for PieceHealth service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PieceHealthClient interface {
+	ReportCorruption(ctx context.Context, in *CorruptionReport, opts ...grpc.CallOption) (*CorruptionReportResponse, error)
+}
+
+type pieceHealthClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPieceHealthClient(cc *grpc.ClientConn) PieceHealthClient {
+	return &pieceHealthClient{cc}
+}
+
+func (c *pieceHealthClient) ReportCorruption(ctx context.Context, in *CorruptionReport, opts ...grpc.CallOption) (*CorruptionReportResponse, error) {
+	out := new(CorruptionReportResponse)
+	err := c.cc.Invoke(ctx, "/repair.PieceHealth/ReportCorruption", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PieceHealthServer is the server API for PieceHealth service.
+type PieceHealthServer interface {
+	ReportCorruption(context.Context, *CorruptionReport) (*CorruptionReportResponse, error)
+}
+
+func RegisterPieceHealthServer(s *grpc.Server, srv PieceHealthServer) {
+	s.RegisterService(&_PieceHealth_serviceDesc, srv)
+}
+
+func _PieceHealth_ReportCorruption_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CorruptionReport)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PieceHealthServer).ReportCorruption(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/repair.PieceHealth/ReportCorruption",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PieceHealthServer).ReportCorruption(ctx, req.(*CorruptionReport))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PieceHealth_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "repair.PieceHealth",
+	HandlerType: (*PieceHealthServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReportCorruption",
+			Handler:    _PieceHealth_ReportCorruption_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "datarepair.proto",
 }
 
-func init() { proto.RegisterFile("datarepair.proto", fileDescriptor_datarepair_13e4beab54f194bd) }
+func init() { proto.RegisterFile("datarepair.proto", fileDescriptor_datarepair_a3c07f7a7ae0f171) }
 
-var fileDescriptor_datarepair_13e4beab54f194bd = []byte{
-	// 119 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x48, 0x49, 0x2c, 0x49,
-	0x2c, 0x4a, 0x2d, 0x48, 0xcc, 0x2c, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x83, 0xf0,
-	0x94, 0x5c, 0xb9, 0xf8, 0x3c, 0xf3, 0xb2, 0x4a, 0x8b, 0x52, 0x53, 0x82, 0x53, 0xd3, 0x73, 0x53,
-	0xf3, 0x4a, 0x84, 0x84, 0xb8, 0x58, 0x0a, 0x12, 0x4b, 0x32, 0x24, 0x18, 0x15, 0x18, 0x35, 0x38,
-	0x83, 0xc0, 0x6c, 0x21, 0x79, 0x2e, 0xee, 0x9c, 0xfc, 0xe2, 0x92, 0xf8, 0x82, 0xcc, 0xd4, 0xe4,
-	0xd4, 0x62, 0x09, 0x26, 0x05, 0x66, 0x0d, 0xd6, 0x20, 0x2e, 0x90, 0x50, 0x00, 0x58, 0xc4, 0x89,
-	0x25, 0x8a, 0xa9, 0x20, 0x29, 0x89, 0x0d, 0x6c, 0xb6, 0x31, 0x20, 0x00, 0x00, 0xff, 0xff, 0x13,
-	0xff, 0xff, 0x1e, 0x6f, 0x00, 0x00, 0x00,
+var fileDescriptor_datarepair_a3c07f7a7ae0f171 = []byte{
+	// 259 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x90, 0x41, 0x4b, 0xf3, 0x40,
+	0x10, 0x86, 0xbf, 0x24, 0xfd, 0xa2, 0x4e, 0xa0, 0xc6, 0x3d, 0x48, 0xec, 0xc5, 0x90, 0x53, 0xf0,
+	0x90, 0x43, 0xfd, 0x07, 0x15, 0xc1, 0xde, 0xca, 0x16, 0x11, 0xbc, 0x84, 0x6d, 0x76, 0xb0, 0x2b,
+	0x75, 0x77, 0xd9, 0x9d, 0x40, 0x7f, 0xbe, 0xb8, 0xdd, 0x2a, 0x16, 0xbc, 0xed, 0xcc, 0xfb, 0xcc,
+	0xec, 0xc3, 0x40, 0x29, 0x05, 0x09, 0x87, 0x56, 0x28, 0xd7, 0x59, 0x67, 0xc8, 0xb0, 0xfc, 0x50,
+	0x35, 0x8f, 0x30, 0x5d, 0xea, 0xf7, 0xd1, 0xa1, 0x5c, 0xe3, 0xdb, 0x07, 0x6a, 0x62, 0x0c, 0x26,
+	0x56, 0xd0, 0xb6, 0x4a, 0xea, 0xa4, 0xbd, 0xe0, 0xe1, 0xcd, 0x6e, 0xa1, 0xd8, 0x19, 0x4f, 0xbd,
+	0x55, 0x38, 0xa0, 0xaf, 0xd2, 0x3a, 0x6b, 0xff, 0x73, 0xf8, 0x6a, 0xad, 0x42, 0xa7, 0x59, 0x40,
+	0xf9, 0x60, 0x9c, 0x1b, 0x2d, 0x29, 0xa3, 0x39, 0x5a, 0xe3, 0x88, 0x75, 0x90, 0x47, 0x3e, 0xa9,
+	0xb3, 0xb6, 0x98, 0x5f, 0x77, 0xd1, 0x20, 0x92, 0x28, 0xc3, 0x30, 0x8f, 0x54, 0xf3, 0x02, 0xd3,
+	0xdf, 0x09, 0xbb, 0x81, 0xf3, 0x90, 0xf5, 0x4a, 0x46, 0x9d, 0xb3, 0x50, 0x2f, 0x25, 0xbb, 0x83,
+	0x2b, 0x89, 0x84, 0x03, 0xa1, 0xec, 0x47, 0xad, 0xf6, 0xbd, 0xc7, 0xa1, 0x4a, 0xeb, 0xa4, 0xcd,
+	0xf8, 0xe5, 0x31, 0x78, 0xd6, 0x6a, 0xbf, 0xc6, 0xa1, 0x99, 0x41, 0x75, 0x2a, 0xc7, 0xd1, 0x5b,
+	0xa3, 0x3d, 0xce, 0x7b, 0x28, 0xc2, 0x5f, 0x4f, 0x28, 0x76, 0xb4, 0x65, 0x2b, 0x28, 0x0f, 0xc0,
+	0xcf, 0x00, 0xab, 0x4e, 0xbc, 0xbf, 0x97, 0xcc, 0xea, 0xbf, 0x92, 0xe3, 0xfa, 0xe6, 0xdf, 0x62,
+	0xf2, 0x9a, 0xda, 0xcd, 0x26, 0x0f, 0x57, 0xbf, 0xff, 0x1c, 0x00, 0xce, 0xaf, 0xdb, 0xbd, 0x89,
+	0x01, 0x00, 0x00,
 }
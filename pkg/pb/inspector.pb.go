@@ -7,6 +7,7 @@ import proto "github.com/gogo/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import _ "github.com/gogo/protobuf/gogoproto"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
 
 import (
 	context "golang.org/x/net/context"
@@ -24,6 +25,152 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+// NodeEvents
+type NodeEventType int32
+
+const (
+	NodeEventType_FIRST_CONTACT   NodeEventType = 0
+	NodeEventType_VETTED          NodeEventType = 1
+	NodeEventType_SUSPENDED       NodeEventType = 2
+	NodeEventType_DISQUALIFIED    NodeEventType = 3
+	NodeEventType_EXITED          NodeEventType = 4
+	NodeEventType_ADDRESS_CHANGED NodeEventType = 5
+	NodeEventType_DRAINING        NodeEventType = 7
+)
+
+var NodeEventType_name = map[int32]string{
+	0: "FIRST_CONTACT",
+	1: "VETTED",
+	2: "SUSPENDED",
+	3: "DISQUALIFIED",
+	4: "EXITED",
+	5: "ADDRESS_CHANGED",
+	7: "DRAINING",
+}
+var NodeEventType_value = map[string]int32{
+	"FIRST_CONTACT":   0,
+	"VETTED":          1,
+	"SUSPENDED":       2,
+	"DISQUALIFIED":    3,
+	"EXITED":          4,
+	"ADDRESS_CHANGED": 5,
+	"DRAINING":        7,
+}
+
+func (x NodeEventType) String() string {
+	return proto.EnumName(NodeEventType_name, int32(x))
+}
+func (NodeEventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{0}
+}
+
+// ExplainSelection
+type SelectionResult int32
+
+const (
+	SelectionResult_ELIGIBLE            SelectionResult = 0
+	SelectionResult_NOT_STORAGE_NODE    SelectionResult = 1
+	SelectionResult_FREE_BANDWIDTH      SelectionResult = 2
+	SelectionResult_FREE_DISK           SelectionResult = 3
+	SelectionResult_UPTIME_RATIO        SelectionResult = 4
+	SelectionResult_UPTIME_COUNT        SelectionResult = 5
+	SelectionResult_AUDIT_SUCCESS_RATIO SelectionResult = 6
+	SelectionResult_AUDIT_COUNT         SelectionResult = 7
+	SelectionResult_EXCLUDED            SelectionResult = 8
+	SelectionResult_DUPLICATE_ADDRESS   SelectionResult = 9
+	SelectionResult_BLACKLISTED         SelectionResult = 10
+	SelectionResult_NOT_WHITELISTED     SelectionResult = 11
+	SelectionResult_NODE_SUSPENDED      SelectionResult = 12
+	SelectionResult_NODE_DISQUALIFIED   SelectionResult = 13
+	SelectionResult_MISSING_TAGS        SelectionResult = 14
+	SelectionResult_NODE_DRAINING       SelectionResult = 15
+	SelectionResult_NODE_OFFLINE        SelectionResult = 16
+	SelectionResult_HIGH_LATENCY        SelectionResult = 17
+	SelectionResult_EXCLUDED_TAGS       SelectionResult = 18
+)
+
+var SelectionResult_name = map[int32]string{
+	0:  "ELIGIBLE",
+	1:  "NOT_STORAGE_NODE",
+	2:  "FREE_BANDWIDTH",
+	3:  "FREE_DISK",
+	4:  "UPTIME_RATIO",
+	5:  "UPTIME_COUNT",
+	6:  "AUDIT_SUCCESS_RATIO",
+	7:  "AUDIT_COUNT",
+	8:  "EXCLUDED",
+	9:  "DUPLICATE_ADDRESS",
+	10: "BLACKLISTED",
+	11: "NOT_WHITELISTED",
+	12: "NODE_SUSPENDED",
+	13: "NODE_DISQUALIFIED",
+	14: "MISSING_TAGS",
+	15: "NODE_DRAINING",
+	16: "NODE_OFFLINE",
+	17: "HIGH_LATENCY",
+	18: "EXCLUDED_TAGS",
+}
+var SelectionResult_value = map[string]int32{
+	"ELIGIBLE":            0,
+	"NOT_STORAGE_NODE":    1,
+	"FREE_BANDWIDTH":      2,
+	"FREE_DISK":           3,
+	"UPTIME_RATIO":        4,
+	"UPTIME_COUNT":        5,
+	"AUDIT_SUCCESS_RATIO": 6,
+	"AUDIT_COUNT":         7,
+	"EXCLUDED":            8,
+	"DUPLICATE_ADDRESS":   9,
+	"BLACKLISTED":         10,
+	"NOT_WHITELISTED":     11,
+	"NODE_SUSPENDED":      12,
+	"NODE_DISQUALIFIED":   13,
+	"MISSING_TAGS":        14,
+	"NODE_DRAINING":       15,
+	"NODE_OFFLINE":        16,
+	"HIGH_LATENCY":        17,
+	"EXCLUDED_TAGS":       18,
+}
+
+func (x SelectionResult) String() string {
+	return proto.EnumName(SelectionResult_name, int32(x))
+}
+func (SelectionResult) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{1}
+}
+
+type NodeVetting_State int32
+
+const (
+	NodeVetting_NEW          NodeVetting_State = 0
+	NodeVetting_VETTED       NodeVetting_State = 1
+	NodeVetting_SUSPENDED    NodeVetting_State = 2
+	NodeVetting_DISQUALIFIED NodeVetting_State = 3
+	NodeVetting_DRAINING     NodeVetting_State = 4
+)
+
+var NodeVetting_State_name = map[int32]string{
+	0: "NEW",
+	1: "VETTED",
+	2: "SUSPENDED",
+	3: "DISQUALIFIED",
+	4: "DRAINING",
+}
+var NodeVetting_State_value = map[string]int32{
+	"NEW":          0,
+	"VETTED":       1,
+	"SUSPENDED":    2,
+	"DISQUALIFIED": 3,
+	"DRAINING":     4,
+}
+
+func (x NodeVetting_State) String() string {
+	return proto.EnumName(NodeVetting_State_name, int32(x))
+}
+func (NodeVetting_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{12, 0}
+}
+
 // GetStats
 type GetStatsRequest struct {
 	NodeId               NodeID   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
@@ -36,7 +183,7 @@ func (m *GetStatsRequest) Reset()         { *m = GetStatsRequest{} }
 func (m *GetStatsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetStatsRequest) ProtoMessage()    {}
 func (*GetStatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_27853f276f81267a, []int{0}
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{0}
 }
 func (m *GetStatsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStatsRequest.Unmarshal(m, b)
@@ -70,7 +217,7 @@ func (m *GetStatsResponse) Reset()         { *m = GetStatsResponse{} }
 func (m *GetStatsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetStatsResponse) ProtoMessage()    {}
 func (*GetStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_27853f276f81267a, []int{1}
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{1}
 }
 func (m *GetStatsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStatsResponse.Unmarshal(m, b)
@@ -134,7 +281,7 @@ func (m *CreateStatsRequest) Reset()         { *m = CreateStatsRequest{} }
 func (m *CreateStatsRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateStatsRequest) ProtoMessage()    {}
 func (*CreateStatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_27853f276f81267a, []int{2}
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{2}
 }
 func (m *CreateStatsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateStatsRequest.Unmarshal(m, b)
@@ -192,7 +339,7 @@ func (m *CreateStatsResponse) Reset()         { *m = CreateStatsResponse{} }
 func (m *CreateStatsResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateStatsResponse) ProtoMessage()    {}
 func (*CreateStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_27853f276f81267a, []int{3}
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{3}
 }
 func (m *CreateStatsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateStatsResponse.Unmarshal(m, b)
@@ -224,7 +371,7 @@ func (m *CountNodesResponse) Reset()         { *m = CountNodesResponse{} }
 func (m *CountNodesResponse) String() string { return proto.CompactTextString(m) }
 func (*CountNodesResponse) ProtoMessage()    {}
 func (*CountNodesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_27853f276f81267a, []int{4}
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{4}
 }
 func (m *CountNodesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CountNodesResponse.Unmarshal(m, b)
@@ -261,7 +408,7 @@ func (m *CountNodesRequest) Reset()         { *m = CountNodesRequest{} }
 func (m *CountNodesRequest) String() string { return proto.CompactTextString(m) }
 func (*CountNodesRequest) ProtoMessage()    {}
 func (*CountNodesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_27853f276f81267a, []int{5}
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{5}
 }
 func (m *CountNodesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CountNodesRequest.Unmarshal(m, b)
@@ -281,221 +428,1407 @@ func (m *CountNodesRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CountNodesRequest proto.InternalMessageInfo
 
-// GetBuckets
-type GetBucketsRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type NodeEvent struct {
+	Id                   int64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	NodeId               NodeID               `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	Type                 NodeEventType        `protobuf:"varint,3,opt,name=type,proto3,enum=inspector.NodeEventType" json:"type,omitempty"`
+	Data                 string               `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *GetBucketsRequest) Reset()         { *m = GetBucketsRequest{} }
-func (m *GetBucketsRequest) String() string { return proto.CompactTextString(m) }
-func (*GetBucketsRequest) ProtoMessage()    {}
-func (*GetBucketsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_27853f276f81267a, []int{6}
+func (m *NodeEvent) Reset()         { *m = NodeEvent{} }
+func (m *NodeEvent) String() string { return proto.CompactTextString(m) }
+func (*NodeEvent) ProtoMessage()    {}
+func (*NodeEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{6}
 }
-func (m *GetBucketsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetBucketsRequest.Unmarshal(m, b)
+func (m *NodeEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeEvent.Unmarshal(m, b)
 }
-func (m *GetBucketsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetBucketsRequest.Marshal(b, m, deterministic)
+func (m *NodeEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeEvent.Marshal(b, m, deterministic)
 }
-func (dst *GetBucketsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetBucketsRequest.Merge(dst, src)
+func (dst *NodeEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeEvent.Merge(dst, src)
 }
-func (m *GetBucketsRequest) XXX_Size() int {
-	return xxx_messageInfo_GetBucketsRequest.Size(m)
+func (m *NodeEvent) XXX_Size() int {
+	return xxx_messageInfo_NodeEvent.Size(m)
 }
-func (m *GetBucketsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetBucketsRequest.DiscardUnknown(m)
+func (m *NodeEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeEvent.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetBucketsRequest proto.InternalMessageInfo
+var xxx_messageInfo_NodeEvent proto.InternalMessageInfo
 
-type GetBucketsResponse struct {
-	Total                int64    `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
-	Ids                  []NodeID `protobuf:"bytes,2,rep,name=ids,customtype=NodeID" json:"ids,omitempty"`
+func (m *NodeEvent) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *NodeEvent) GetType() NodeEventType {
+	if m != nil {
+		return m.Type
+	}
+	return NodeEventType_FIRST_CONTACT
+}
+
+func (m *NodeEvent) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+func (m *NodeEvent) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+type NodeEventsRequest struct {
+	NodeId               NodeID   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	Limit                int32    `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	PageToken            string   `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetBucketsResponse) Reset()         { *m = GetBucketsResponse{} }
-func (m *GetBucketsResponse) String() string { return proto.CompactTextString(m) }
-func (*GetBucketsResponse) ProtoMessage()    {}
-func (*GetBucketsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_27853f276f81267a, []int{7}
+func (m *NodeEventsRequest) Reset()         { *m = NodeEventsRequest{} }
+func (m *NodeEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*NodeEventsRequest) ProtoMessage()    {}
+func (*NodeEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{7}
 }
-func (m *GetBucketsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetBucketsResponse.Unmarshal(m, b)
+func (m *NodeEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeEventsRequest.Unmarshal(m, b)
 }
-func (m *GetBucketsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetBucketsResponse.Marshal(b, m, deterministic)
+func (m *NodeEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeEventsRequest.Marshal(b, m, deterministic)
 }
-func (dst *GetBucketsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetBucketsResponse.Merge(dst, src)
+func (dst *NodeEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeEventsRequest.Merge(dst, src)
 }
-func (m *GetBucketsResponse) XXX_Size() int {
-	return xxx_messageInfo_GetBucketsResponse.Size(m)
+func (m *NodeEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_NodeEventsRequest.Size(m)
 }
-func (m *GetBucketsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetBucketsResponse.DiscardUnknown(m)
+func (m *NodeEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeEventsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetBucketsResponse proto.InternalMessageInfo
+var xxx_messageInfo_NodeEventsRequest proto.InternalMessageInfo
 
-func (m *GetBucketsResponse) GetTotal() int64 {
+func (m *NodeEventsRequest) GetLimit() int32 {
 	if m != nil {
-		return m.Total
+		return m.Limit
 	}
 	return 0
 }
 
-// GetBucket
-type GetBucketRequest struct {
-	Id                   NodeID   `protobuf:"bytes,1,opt,name=id,proto3,customtype=NodeID" json:"id"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *NodeEventsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
 }
 
-func (m *GetBucketRequest) Reset()         { *m = GetBucketRequest{} }
-func (m *GetBucketRequest) String() string { return proto.CompactTextString(m) }
-func (*GetBucketRequest) ProtoMessage()    {}
-func (*GetBucketRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_27853f276f81267a, []int{8}
+type NodeEventsResponse struct {
+	Events               []*NodeEvent `protobuf:"bytes,1,rep,name=events" json:"events,omitempty"`
+	More                 bool         `protobuf:"varint,2,opt,name=more,proto3" json:"more,omitempty"`
+	NextPageToken        string       `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
-func (m *GetBucketRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetBucketRequest.Unmarshal(m, b)
+
+func (m *NodeEventsResponse) Reset()         { *m = NodeEventsResponse{} }
+func (m *NodeEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeEventsResponse) ProtoMessage()    {}
+func (*NodeEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{8}
 }
-func (m *GetBucketRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetBucketRequest.Marshal(b, m, deterministic)
+func (m *NodeEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeEventsResponse.Unmarshal(m, b)
 }
-func (dst *GetBucketRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetBucketRequest.Merge(dst, src)
+func (m *NodeEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeEventsResponse.Marshal(b, m, deterministic)
 }
-func (m *GetBucketRequest) XXX_Size() int {
-	return xxx_messageInfo_GetBucketRequest.Size(m)
+func (dst *NodeEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeEventsResponse.Merge(dst, src)
 }
-func (m *GetBucketRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetBucketRequest.DiscardUnknown(m)
+func (m *NodeEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_NodeEventsResponse.Size(m)
+}
+func (m *NodeEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeEventsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetBucketRequest proto.InternalMessageInfo
+var xxx_messageInfo_NodeEventsResponse proto.InternalMessageInfo
 
-type GetBucketResponse struct {
-	Id                   NodeID   `protobuf:"bytes,1,opt,name=id,proto3,customtype=NodeID" json:"id"`
-	Nodes                []*Node  `protobuf:"bytes,2,rep,name=nodes" json:"nodes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *NodeEventsResponse) GetEvents() []*NodeEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
 }
 
-func (m *GetBucketResponse) Reset()         { *m = GetBucketResponse{} }
-func (m *GetBucketResponse) String() string { return proto.CompactTextString(m) }
-func (*GetBucketResponse) ProtoMessage()    {}
-func (*GetBucketResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_27853f276f81267a, []int{9}
+func (m *NodeEventsResponse) GetMore() bool {
+	if m != nil {
+		return m.More
+	}
+	return false
 }
-func (m *GetBucketResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetBucketResponse.Unmarshal(m, b)
+
+func (m *NodeEventsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
 }
-func (m *GetBucketResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetBucketResponse.Marshal(b, m, deterministic)
+
+type ExplainSelectionRequest struct {
+	Restrictions         *NodeRestrictions `protobuf:"bytes,1,opt,name=restrictions" json:"restrictions,omitempty"`
+	ExcludedNodes        []NodeID          `protobuf:"bytes,2,rep,name=excluded_nodes,json=excludedNodes,customtype=NodeID" json:"excluded_nodes,omitempty"`
+	Limit                int32             `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Tags                 []*NodeTag        `protobuf:"bytes,4,rep,name=tags" json:"tags,omitempty"`
+	PageToken            string            `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	ExcludedTags         []*NodeTag        `protobuf:"bytes,6,rep,name=excluded_tags,json=excludedTags" json:"excluded_tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
-func (dst *GetBucketResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetBucketResponse.Merge(dst, src)
+
+func (m *ExplainSelectionRequest) Reset()         { *m = ExplainSelectionRequest{} }
+func (m *ExplainSelectionRequest) String() string { return proto.CompactTextString(m) }
+func (*ExplainSelectionRequest) ProtoMessage()    {}
+func (*ExplainSelectionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{9}
 }
-func (m *GetBucketResponse) XXX_Size() int {
-	return xxx_messageInfo_GetBucketResponse.Size(m)
+func (m *ExplainSelectionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExplainSelectionRequest.Unmarshal(m, b)
 }
-func (m *GetBucketResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetBucketResponse.DiscardUnknown(m)
+func (m *ExplainSelectionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExplainSelectionRequest.Marshal(b, m, deterministic)
+}
+func (dst *ExplainSelectionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExplainSelectionRequest.Merge(dst, src)
+}
+func (m *ExplainSelectionRequest) XXX_Size() int {
+	return xxx_messageInfo_ExplainSelectionRequest.Size(m)
+}
+func (m *ExplainSelectionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExplainSelectionRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetBucketResponse proto.InternalMessageInfo
+var xxx_messageInfo_ExplainSelectionRequest proto.InternalMessageInfo
 
-func (m *GetBucketResponse) GetNodes() []*Node {
+func (m *ExplainSelectionRequest) GetRestrictions() *NodeRestrictions {
+	if m != nil {
+		return m.Restrictions
+	}
+	return nil
+}
+
+func (m *ExplainSelectionRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ExplainSelectionRequest) GetTags() []*NodeTag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *ExplainSelectionRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ExplainSelectionRequest) GetExcludedTags() []*NodeTag {
+	if m != nil {
+		return m.ExcludedTags
+	}
+	return nil
+}
+
+type NodeSelection struct {
+	NodeId               NodeID          `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	Address              string          `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Result               SelectionResult `protobuf:"varint,3,opt,name=result,proto3,enum=inspector.SelectionResult" json:"result,omitempty"`
+	Detail               string          `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *NodeSelection) Reset()         { *m = NodeSelection{} }
+func (m *NodeSelection) String() string { return proto.CompactTextString(m) }
+func (*NodeSelection) ProtoMessage()    {}
+func (*NodeSelection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{10}
+}
+func (m *NodeSelection) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeSelection.Unmarshal(m, b)
+}
+func (m *NodeSelection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeSelection.Marshal(b, m, deterministic)
+}
+func (dst *NodeSelection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeSelection.Merge(dst, src)
+}
+func (m *NodeSelection) XXX_Size() int {
+	return xxx_messageInfo_NodeSelection.Size(m)
+}
+func (m *NodeSelection) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeSelection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeSelection proto.InternalMessageInfo
+
+func (m *NodeSelection) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *NodeSelection) GetResult() SelectionResult {
+	if m != nil {
+		return m.Result
+	}
+	return SelectionResult_ELIGIBLE
+}
+
+func (m *NodeSelection) GetDetail() string {
+	if m != nil {
+		return m.Detail
+	}
+	return ""
+}
+
+type ExplainSelectionResponse struct {
+	Nodes                []*NodeSelection `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
+	Eligible             int64            `protobuf:"varint,2,opt,name=eligible,proto3" json:"eligible,omitempty"`
+	More                 bool             `protobuf:"varint,3,opt,name=more,proto3" json:"more,omitempty"`
+	NextPageToken        string           `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ExplainSelectionResponse) Reset()         { *m = ExplainSelectionResponse{} }
+func (m *ExplainSelectionResponse) String() string { return proto.CompactTextString(m) }
+func (*ExplainSelectionResponse) ProtoMessage()    {}
+func (*ExplainSelectionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{11}
+}
+func (m *ExplainSelectionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExplainSelectionResponse.Unmarshal(m, b)
+}
+func (m *ExplainSelectionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExplainSelectionResponse.Marshal(b, m, deterministic)
+}
+func (dst *ExplainSelectionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExplainSelectionResponse.Merge(dst, src)
+}
+func (m *ExplainSelectionResponse) XXX_Size() int {
+	return xxx_messageInfo_ExplainSelectionResponse.Size(m)
+}
+func (m *ExplainSelectionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExplainSelectionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExplainSelectionResponse proto.InternalMessageInfo
+
+func (m *ExplainSelectionResponse) GetNodes() []*NodeSelection {
 	if m != nil {
 		return m.Nodes
 	}
 	return nil
 }
 
-type Bucket struct {
-	Nodes                []*Node  `protobuf:"bytes,2,rep,name=nodes" json:"nodes,omitempty"`
+func (m *ExplainSelectionResponse) GetEligible() int64 {
+	if m != nil {
+		return m.Eligible
+	}
+	return 0
+}
+
+func (m *ExplainSelectionResponse) GetMore() bool {
+	if m != nil {
+		return m.More
+	}
+	return false
+}
+
+func (m *ExplainSelectionResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+// NodeVetting
+type NodeVetting struct {
+	NodeId               NodeID            `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	State                NodeVetting_State `protobuf:"varint,2,opt,name=state,proto3,enum=inspector.NodeVetting_State" json:"state,omitempty"`
+	AuditCount           int64             `protobuf:"varint,3,opt,name=audit_count,json=auditCount,proto3" json:"audit_count,omitempty"`
+	UptimeCount          int64             `protobuf:"varint,4,opt,name=uptime_count,json=uptimeCount,proto3" json:"uptime_count,omitempty"`
+	DataHeld             int64             `protobuf:"varint,5,opt,name=data_held,json=dataHeld,proto3" json:"data_held,omitempty"`
+	AuditReputation      float64           `protobuf:"fixed64,6,opt,name=audit_reputation,json=auditReputation,proto3" json:"audit_reputation,omitempty"`
+	UptimeReputation     float64           `protobuf:"fixed64,7,opt,name=uptime_reputation,json=uptimeReputation,proto3" json:"uptime_reputation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *NodeVetting) Reset()         { *m = NodeVetting{} }
+func (m *NodeVetting) String() string { return proto.CompactTextString(m) }
+func (*NodeVetting) ProtoMessage()    {}
+func (*NodeVetting) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{12}
+}
+func (m *NodeVetting) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeVetting.Unmarshal(m, b)
+}
+func (m *NodeVetting) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeVetting.Marshal(b, m, deterministic)
+}
+func (dst *NodeVetting) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeVetting.Merge(dst, src)
+}
+func (m *NodeVetting) XXX_Size() int {
+	return xxx_messageInfo_NodeVetting.Size(m)
+}
+func (m *NodeVetting) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeVetting.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeVetting proto.InternalMessageInfo
+
+func (m *NodeVetting) GetState() NodeVetting_State {
+	if m != nil {
+		return m.State
+	}
+	return NodeVetting_NEW
+}
+
+func (m *NodeVetting) GetAuditCount() int64 {
+	if m != nil {
+		return m.AuditCount
+	}
+	return 0
+}
+
+func (m *NodeVetting) GetUptimeCount() int64 {
+	if m != nil {
+		return m.UptimeCount
+	}
+	return 0
+}
+
+func (m *NodeVetting) GetDataHeld() int64 {
+	if m != nil {
+		return m.DataHeld
+	}
+	return 0
+}
+
+func (m *NodeVetting) GetAuditReputation() float64 {
+	if m != nil {
+		return m.AuditReputation
+	}
+	return 0
+}
+
+func (m *NodeVetting) GetUptimeReputation() float64 {
+	if m != nil {
+		return m.UptimeReputation
+	}
+	return 0
+}
+
+type NodeVettingRequest struct {
+	NodeId               NodeID   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Bucket) Reset()         { *m = Bucket{} }
-func (m *Bucket) String() string { return proto.CompactTextString(m) }
-func (*Bucket) ProtoMessage()    {}
-func (*Bucket) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_27853f276f81267a, []int{10}
+func (m *NodeVettingRequest) Reset()         { *m = NodeVettingRequest{} }
+func (m *NodeVettingRequest) String() string { return proto.CompactTextString(m) }
+func (*NodeVettingRequest) ProtoMessage()    {}
+func (*NodeVettingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{13}
+}
+func (m *NodeVettingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeVettingRequest.Unmarshal(m, b)
+}
+func (m *NodeVettingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeVettingRequest.Marshal(b, m, deterministic)
+}
+func (dst *NodeVettingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeVettingRequest.Merge(dst, src)
+}
+func (m *NodeVettingRequest) XXX_Size() int {
+	return xxx_messageInfo_NodeVettingRequest.Size(m)
+}
+func (m *NodeVettingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeVettingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeVettingRequest proto.InternalMessageInfo
+
+type NodeVettingResponse struct {
+	Vetting              *NodeVetting `protobuf:"bytes,1,opt,name=vetting" json:"vetting,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *NodeVettingResponse) Reset()         { *m = NodeVettingResponse{} }
+func (m *NodeVettingResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeVettingResponse) ProtoMessage()    {}
+func (*NodeVettingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{14}
+}
+func (m *NodeVettingResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeVettingResponse.Unmarshal(m, b)
+}
+func (m *NodeVettingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeVettingResponse.Marshal(b, m, deterministic)
+}
+func (dst *NodeVettingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeVettingResponse.Merge(dst, src)
+}
+func (m *NodeVettingResponse) XXX_Size() int {
+	return xxx_messageInfo_NodeVettingResponse.Size(m)
+}
+func (m *NodeVettingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeVettingResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeVettingResponse proto.InternalMessageInfo
+
+func (m *NodeVettingResponse) GetVetting() *NodeVetting {
+	if m != nil {
+		return m.Vetting
+	}
+	return nil
+}
+
+type SetNodeTagsRequest struct {
+	NodeId               NodeID     `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	Tags                 []*NodeTag `protobuf:"bytes,2,rep,name=tags" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SetNodeTagsRequest) Reset()         { *m = SetNodeTagsRequest{} }
+func (m *SetNodeTagsRequest) String() string { return proto.CompactTextString(m) }
+func (*SetNodeTagsRequest) ProtoMessage()    {}
+func (*SetNodeTagsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{15}
+}
+func (m *SetNodeTagsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetNodeTagsRequest.Unmarshal(m, b)
+}
+func (m *SetNodeTagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetNodeTagsRequest.Marshal(b, m, deterministic)
+}
+func (dst *SetNodeTagsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetNodeTagsRequest.Merge(dst, src)
+}
+func (m *SetNodeTagsRequest) XXX_Size() int {
+	return xxx_messageInfo_SetNodeTagsRequest.Size(m)
+}
+func (m *SetNodeTagsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetNodeTagsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetNodeTagsRequest proto.InternalMessageInfo
+
+func (m *SetNodeTagsRequest) GetTags() []*NodeTag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type SetNodeTagsResponse struct {
+	Tags                 []*NodeTag `protobuf:"bytes,1,rep,name=tags" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SetNodeTagsResponse) Reset()         { *m = SetNodeTagsResponse{} }
+func (m *SetNodeTagsResponse) String() string { return proto.CompactTextString(m) }
+func (*SetNodeTagsResponse) ProtoMessage()    {}
+func (*SetNodeTagsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{16}
+}
+func (m *SetNodeTagsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetNodeTagsResponse.Unmarshal(m, b)
+}
+func (m *SetNodeTagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetNodeTagsResponse.Marshal(b, m, deterministic)
+}
+func (dst *SetNodeTagsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetNodeTagsResponse.Merge(dst, src)
+}
+func (m *SetNodeTagsResponse) XXX_Size() int {
+	return xxx_messageInfo_SetNodeTagsResponse.Size(m)
+}
+func (m *SetNodeTagsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetNodeTagsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetNodeTagsResponse proto.InternalMessageInfo
+
+func (m *SetNodeTagsResponse) GetTags() []*NodeTag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+// Drain
+type DrainRequest struct {
+	NodeIds              []NodeID `protobuf:"bytes,1,rep,name=node_ids,json=nodeIds,customtype=NodeID" json:"node_ids,omitempty"`
+	Regions              []string `protobuf:"bytes,2,rep,name=regions" json:"regions,omitempty"`
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DrainRequest) Reset()         { *m = DrainRequest{} }
+func (m *DrainRequest) String() string { return proto.CompactTextString(m) }
+func (*DrainRequest) ProtoMessage()    {}
+func (*DrainRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{17}
+}
+func (m *DrainRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DrainRequest.Unmarshal(m, b)
+}
+func (m *DrainRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DrainRequest.Marshal(b, m, deterministic)
+}
+func (dst *DrainRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DrainRequest.Merge(dst, src)
+}
+func (m *DrainRequest) XXX_Size() int {
+	return xxx_messageInfo_DrainRequest.Size(m)
+}
+func (m *DrainRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DrainRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DrainRequest proto.InternalMessageInfo
+
+func (m *DrainRequest) GetRegions() []string {
+	if m != nil {
+		return m.Regions
+	}
+	return nil
+}
+
+func (m *DrainRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type DrainResponse struct {
+	Drained              []NodeID `protobuf:"bytes,1,rep,name=drained,customtype=NodeID" json:"drained,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DrainResponse) Reset()         { *m = DrainResponse{} }
+func (m *DrainResponse) String() string { return proto.CompactTextString(m) }
+func (*DrainResponse) ProtoMessage()    {}
+func (*DrainResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{18}
+}
+func (m *DrainResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DrainResponse.Unmarshal(m, b)
+}
+func (m *DrainResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DrainResponse.Marshal(b, m, deterministic)
+}
+func (dst *DrainResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DrainResponse.Merge(dst, src)
+}
+func (m *DrainResponse) XXX_Size() int {
+	return xxx_messageInfo_DrainResponse.Size(m)
+}
+func (m *DrainResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DrainResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DrainResponse proto.InternalMessageInfo
+
+type DrainStatusRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DrainStatusRequest) Reset()         { *m = DrainStatusRequest{} }
+func (m *DrainStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*DrainStatusRequest) ProtoMessage()    {}
+func (*DrainStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{19}
+}
+func (m *DrainStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DrainStatusRequest.Unmarshal(m, b)
+}
+func (m *DrainStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DrainStatusRequest.Marshal(b, m, deterministic)
+}
+func (dst *DrainStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DrainStatusRequest.Merge(dst, src)
+}
+func (m *DrainStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_DrainStatusRequest.Size(m)
+}
+func (m *DrainStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DrainStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DrainStatusRequest proto.InternalMessageInfo
+
+type NodeDrainProgress struct {
+	NodeId               NodeID               `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	Region               string               `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	InitialPieces        int64                `protobuf:"varint,3,opt,name=initial_pieces,json=initialPieces,proto3" json:"initial_pieces,omitempty"`
+	RemainingPieces      int64                `protobuf:"varint,4,opt,name=remaining_pieces,json=remainingPieces,proto3" json:"remaining_pieces,omitempty"`
+	CompletedAt          *timestamp.Timestamp `protobuf:"bytes,5,opt,name=completed_at,json=completedAt" json:"completed_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *NodeDrainProgress) Reset()         { *m = NodeDrainProgress{} }
+func (m *NodeDrainProgress) String() string { return proto.CompactTextString(m) }
+func (*NodeDrainProgress) ProtoMessage()    {}
+func (*NodeDrainProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{20}
+}
+func (m *NodeDrainProgress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeDrainProgress.Unmarshal(m, b)
+}
+func (m *NodeDrainProgress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeDrainProgress.Marshal(b, m, deterministic)
+}
+func (dst *NodeDrainProgress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeDrainProgress.Merge(dst, src)
+}
+func (m *NodeDrainProgress) XXX_Size() int {
+	return xxx_messageInfo_NodeDrainProgress.Size(m)
+}
+func (m *NodeDrainProgress) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeDrainProgress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeDrainProgress proto.InternalMessageInfo
+
+func (m *NodeDrainProgress) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *NodeDrainProgress) GetInitialPieces() int64 {
+	if m != nil {
+		return m.InitialPieces
+	}
+	return 0
+}
+
+func (m *NodeDrainProgress) GetRemainingPieces() int64 {
+	if m != nil {
+		return m.RemainingPieces
+	}
+	return 0
+}
+
+func (m *NodeDrainProgress) GetCompletedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CompletedAt
+	}
+	return nil
+}
+
+type DrainStatusResponse struct {
+	Regions              []string             `protobuf:"bytes,1,rep,name=regions" json:"regions,omitempty"`
+	Nodes                []*NodeDrainProgress `protobuf:"bytes,2,rep,name=nodes" json:"nodes,omitempty"`
+	ScannedAt            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=scanned_at,json=scannedAt" json:"scanned_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DrainStatusResponse) Reset()         { *m = DrainStatusResponse{} }
+func (m *DrainStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*DrainStatusResponse) ProtoMessage()    {}
+func (*DrainStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{21}
+}
+func (m *DrainStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DrainStatusResponse.Unmarshal(m, b)
+}
+func (m *DrainStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DrainStatusResponse.Marshal(b, m, deterministic)
+}
+func (dst *DrainStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DrainStatusResponse.Merge(dst, src)
+}
+func (m *DrainStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_DrainStatusResponse.Size(m)
+}
+func (m *DrainStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DrainStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DrainStatusResponse proto.InternalMessageInfo
+
+func (m *DrainStatusResponse) GetRegions() []string {
+	if m != nil {
+		return m.Regions
+	}
+	return nil
+}
+
+func (m *DrainStatusResponse) GetNodes() []*NodeDrainProgress {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *DrainStatusResponse) GetScannedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ScannedAt
+	}
+	return nil
+}
+
+// GetBuckets
+type GetBucketsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBucketsRequest) Reset()         { *m = GetBucketsRequest{} }
+func (m *GetBucketsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBucketsRequest) ProtoMessage()    {}
+func (*GetBucketsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{22}
+}
+func (m *GetBucketsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBucketsRequest.Unmarshal(m, b)
+}
+func (m *GetBucketsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBucketsRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetBucketsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBucketsRequest.Merge(dst, src)
+}
+func (m *GetBucketsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBucketsRequest.Size(m)
+}
+func (m *GetBucketsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBucketsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBucketsRequest proto.InternalMessageInfo
+
+type GetBucketsResponse struct {
+	Total                int64    `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Ids                  []NodeID `protobuf:"bytes,2,rep,name=ids,customtype=NodeID" json:"ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBucketsResponse) Reset()         { *m = GetBucketsResponse{} }
+func (m *GetBucketsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBucketsResponse) ProtoMessage()    {}
+func (*GetBucketsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{23}
+}
+func (m *GetBucketsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBucketsResponse.Unmarshal(m, b)
+}
+func (m *GetBucketsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBucketsResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetBucketsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBucketsResponse.Merge(dst, src)
+}
+func (m *GetBucketsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBucketsResponse.Size(m)
+}
+func (m *GetBucketsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBucketsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBucketsResponse proto.InternalMessageInfo
+
+func (m *GetBucketsResponse) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+// GetBucket
+type GetBucketRequest struct {
+	Id                   NodeID   `protobuf:"bytes,1,opt,name=id,proto3,customtype=NodeID" json:"id"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBucketRequest) Reset()         { *m = GetBucketRequest{} }
+func (m *GetBucketRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBucketRequest) ProtoMessage()    {}
+func (*GetBucketRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{24}
+}
+func (m *GetBucketRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBucketRequest.Unmarshal(m, b)
+}
+func (m *GetBucketRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBucketRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetBucketRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBucketRequest.Merge(dst, src)
+}
+func (m *GetBucketRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBucketRequest.Size(m)
+}
+func (m *GetBucketRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBucketRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBucketRequest proto.InternalMessageInfo
+
+type GetBucketResponse struct {
+	Id                   NodeID   `protobuf:"bytes,1,opt,name=id,proto3,customtype=NodeID" json:"id"`
+	Nodes                []*Node  `protobuf:"bytes,2,rep,name=nodes" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBucketResponse) Reset()         { *m = GetBucketResponse{} }
+func (m *GetBucketResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBucketResponse) ProtoMessage()    {}
+func (*GetBucketResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{25}
+}
+func (m *GetBucketResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBucketResponse.Unmarshal(m, b)
+}
+func (m *GetBucketResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBucketResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetBucketResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBucketResponse.Merge(dst, src)
+}
+func (m *GetBucketResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBucketResponse.Size(m)
+}
+func (m *GetBucketResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBucketResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBucketResponse proto.InternalMessageInfo
+
+func (m *GetBucketResponse) GetNodes() []*Node {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type Bucket struct {
+	Nodes                []*Node  `protobuf:"bytes,2,rep,name=nodes" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Bucket) Reset()         { *m = Bucket{} }
+func (m *Bucket) String() string { return proto.CompactTextString(m) }
+func (*Bucket) ProtoMessage()    {}
+func (*Bucket) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{26}
+}
+func (m *Bucket) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Bucket.Unmarshal(m, b)
+}
+func (m *Bucket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Bucket.Marshal(b, m, deterministic)
+}
+func (dst *Bucket) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Bucket.Merge(dst, src)
+}
+func (m *Bucket) XXX_Size() int {
+	return xxx_messageInfo_Bucket.Size(m)
+}
+func (m *Bucket) XXX_DiscardUnknown() {
+	xxx_messageInfo_Bucket.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Bucket proto.InternalMessageInfo
+
+func (m *Bucket) GetNodes() []*Node {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type BucketList struct {
+	Nodes                []*Node  `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BucketList) Reset()         { *m = BucketList{} }
+func (m *BucketList) String() string { return proto.CompactTextString(m) }
+func (*BucketList) ProtoMessage()    {}
+func (*BucketList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{27}
+}
+func (m *BucketList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BucketList.Unmarshal(m, b)
+}
+func (m *BucketList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BucketList.Marshal(b, m, deterministic)
+}
+func (dst *BucketList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BucketList.Merge(dst, src)
+}
+func (m *BucketList) XXX_Size() int {
+	return xxx_messageInfo_BucketList.Size(m)
+}
+func (m *BucketList) XXX_DiscardUnknown() {
+	xxx_messageInfo_BucketList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BucketList proto.InternalMessageInfo
+
+func (m *BucketList) GetNodes() []*Node {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+// DumpRoutingTable
+type DumpRoutingTableRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DumpRoutingTableRequest) Reset()         { *m = DumpRoutingTableRequest{} }
+func (m *DumpRoutingTableRequest) String() string { return proto.CompactTextString(m) }
+func (*DumpRoutingTableRequest) ProtoMessage()    {}
+func (*DumpRoutingTableRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{28}
+}
+func (m *DumpRoutingTableRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DumpRoutingTableRequest.Unmarshal(m, b)
+}
+func (m *DumpRoutingTableRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DumpRoutingTableRequest.Marshal(b, m, deterministic)
+}
+func (dst *DumpRoutingTableRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DumpRoutingTableRequest.Merge(dst, src)
+}
+func (m *DumpRoutingTableRequest) XXX_Size() int {
+	return xxx_messageInfo_DumpRoutingTableRequest.Size(m)
+}
+func (m *DumpRoutingTableRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DumpRoutingTableRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DumpRoutingTableRequest proto.InternalMessageInfo
+
+type DumpRoutingTableResponse struct {
+	Self                 *Node      `protobuf:"bytes,1,opt,name=self" json:"self,omitempty"`
+	Buckets              []*KBucket `protobuf:"bytes,2,rep,name=buckets" json:"buckets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *DumpRoutingTableResponse) Reset()         { *m = DumpRoutingTableResponse{} }
+func (m *DumpRoutingTableResponse) String() string { return proto.CompactTextString(m) }
+func (*DumpRoutingTableResponse) ProtoMessage()    {}
+func (*DumpRoutingTableResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{29}
+}
+func (m *DumpRoutingTableResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DumpRoutingTableResponse.Unmarshal(m, b)
+}
+func (m *DumpRoutingTableResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DumpRoutingTableResponse.Marshal(b, m, deterministic)
+}
+func (dst *DumpRoutingTableResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DumpRoutingTableResponse.Merge(dst, src)
+}
+func (m *DumpRoutingTableResponse) XXX_Size() int {
+	return xxx_messageInfo_DumpRoutingTableResponse.Size(m)
+}
+func (m *DumpRoutingTableResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DumpRoutingTableResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DumpRoutingTableResponse proto.InternalMessageInfo
+
+func (m *DumpRoutingTableResponse) GetSelf() *Node {
+	if m != nil {
+		return m.Self
+	}
+	return nil
+}
+
+func (m *DumpRoutingTableResponse) GetBuckets() []*KBucket {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+type KBucket struct {
+	Id                   NodeID               `protobuf:"bytes,1,opt,name=id,proto3,customtype=NodeID" json:"id"`
+	LastUpdated          *timestamp.Timestamp `protobuf:"bytes,2,opt,name=last_updated,json=lastUpdated" json:"last_updated,omitempty"`
+	Nodes                []*RoutingTableNode  `protobuf:"bytes,3,rep,name=nodes" json:"nodes,omitempty"`
+	Replacements         []*RoutingTableNode  `protobuf:"bytes,4,rep,name=replacements" json:"replacements,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *KBucket) Reset()         { *m = KBucket{} }
+func (m *KBucket) String() string { return proto.CompactTextString(m) }
+func (*KBucket) ProtoMessage()    {}
+func (*KBucket) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{30}
+}
+func (m *KBucket) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KBucket.Unmarshal(m, b)
+}
+func (m *KBucket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KBucket.Marshal(b, m, deterministic)
+}
+func (dst *KBucket) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KBucket.Merge(dst, src)
+}
+func (m *KBucket) XXX_Size() int {
+	return xxx_messageInfo_KBucket.Size(m)
+}
+func (m *KBucket) XXX_DiscardUnknown() {
+	xxx_messageInfo_KBucket.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KBucket proto.InternalMessageInfo
+
+func (m *KBucket) GetLastUpdated() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastUpdated
+	}
+	return nil
+}
+
+func (m *KBucket) GetNodes() []*RoutingTableNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *KBucket) GetReplacements() []*RoutingTableNode {
+	if m != nil {
+		return m.Replacements
+	}
+	return nil
+}
+
+type RoutingTableNode struct {
+	Node                 *Node                `protobuf:"bytes,1,opt,name=node" json:"node,omitempty"`
+	LastSeen             *timestamp.Timestamp `protobuf:"bytes,2,opt,name=last_seen,json=lastSeen" json:"last_seen,omitempty"`
+	LastRttNs            int64                `protobuf:"varint,3,opt,name=last_rtt_ns,json=lastRttNs,proto3" json:"last_rtt_ns,omitempty"`
+	AverageRttNs         int64                `protobuf:"varint,4,opt,name=average_rtt_ns,json=averageRttNs,proto3" json:"average_rtt_ns,omitempty"`
+	Pings                int64                `protobuf:"varint,5,opt,name=pings,proto3" json:"pings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *RoutingTableNode) Reset()         { *m = RoutingTableNode{} }
+func (m *RoutingTableNode) String() string { return proto.CompactTextString(m) }
+func (*RoutingTableNode) ProtoMessage()    {}
+func (*RoutingTableNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{31}
+}
+func (m *RoutingTableNode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoutingTableNode.Unmarshal(m, b)
+}
+func (m *RoutingTableNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoutingTableNode.Marshal(b, m, deterministic)
+}
+func (dst *RoutingTableNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoutingTableNode.Merge(dst, src)
+}
+func (m *RoutingTableNode) XXX_Size() int {
+	return xxx_messageInfo_RoutingTableNode.Size(m)
+}
+func (m *RoutingTableNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoutingTableNode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoutingTableNode proto.InternalMessageInfo
+
+func (m *RoutingTableNode) GetNode() *Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+func (m *RoutingTableNode) GetLastSeen() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastSeen
+	}
+	return nil
+}
+
+func (m *RoutingTableNode) GetLastRttNs() int64 {
+	if m != nil {
+		return m.LastRttNs
+	}
+	return 0
+}
+
+func (m *RoutingTableNode) GetAverageRttNs() int64 {
+	if m != nil {
+		return m.AverageRttNs
+	}
+	return 0
+}
+
+func (m *RoutingTableNode) GetPings() int64 {
+	if m != nil {
+		return m.Pings
+	}
+	return 0
+}
+
+// RoutingHealth
+type RoutingHealthRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RoutingHealthRequest) Reset()         { *m = RoutingHealthRequest{} }
+func (m *RoutingHealthRequest) String() string { return proto.CompactTextString(m) }
+func (*RoutingHealthRequest) ProtoMessage()    {}
+func (*RoutingHealthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{32}
+}
+func (m *RoutingHealthRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoutingHealthRequest.Unmarshal(m, b)
+}
+func (m *RoutingHealthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoutingHealthRequest.Marshal(b, m, deterministic)
+}
+func (dst *RoutingHealthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoutingHealthRequest.Merge(dst, src)
+}
+func (m *RoutingHealthRequest) XXX_Size() int {
+	return xxx_messageInfo_RoutingHealthRequest.Size(m)
+}
+func (m *RoutingHealthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoutingHealthRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoutingHealthRequest proto.InternalMessageInfo
+
+type RoutingHealthResponse struct {
+	Score                float64         `protobuf:"fixed64,1,opt,name=score,proto3" json:"score,omitempty"`
+	Buckets              []*KBucketStats `protobuf:"bytes,2,rep,name=buckets" json:"buckets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *RoutingHealthResponse) Reset()         { *m = RoutingHealthResponse{} }
+func (m *RoutingHealthResponse) String() string { return proto.CompactTextString(m) }
+func (*RoutingHealthResponse) ProtoMessage()    {}
+func (*RoutingHealthResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{33}
+}
+func (m *RoutingHealthResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoutingHealthResponse.Unmarshal(m, b)
+}
+func (m *RoutingHealthResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoutingHealthResponse.Marshal(b, m, deterministic)
+}
+func (dst *RoutingHealthResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoutingHealthResponse.Merge(dst, src)
+}
+func (m *RoutingHealthResponse) XXX_Size() int {
+	return xxx_messageInfo_RoutingHealthResponse.Size(m)
+}
+func (m *RoutingHealthResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoutingHealthResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoutingHealthResponse proto.InternalMessageInfo
+
+func (m *RoutingHealthResponse) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *RoutingHealthResponse) GetBuckets() []*KBucketStats {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+type KBucketStats struct {
+	Id                   NodeID               `protobuf:"bytes,1,opt,name=id,proto3,customtype=NodeID" json:"id"`
+	LastUpdated          *timestamp.Timestamp `protobuf:"bytes,2,opt,name=last_updated,json=lastUpdated" json:"last_updated,omitempty"`
+	Nodes                int64                `protobuf:"varint,3,opt,name=nodes,proto3" json:"nodes,omitempty"`
+	Replacements         int64                `protobuf:"varint,4,opt,name=replacements,proto3" json:"replacements,omitempty"`
+	Fill                 float64              `protobuf:"fixed64,5,opt,name=fill,proto3" json:"fill,omitempty"`
+	Fresh                int64                `protobuf:"varint,6,opt,name=fresh,proto3" json:"fresh,omitempty"`
+	Unseen               int64                `protobuf:"varint,7,opt,name=unseen,proto3" json:"unseen,omitempty"`
+	AverageLastSeenAgeNs int64                `protobuf:"varint,8,opt,name=average_last_seen_age_ns,json=averageLastSeenAgeNs,proto3" json:"average_last_seen_age_ns,omitempty"`
+	Churn                int64                `protobuf:"varint,9,opt,name=churn,proto3" json:"churn,omitempty"`
+	Score                float64              `protobuf:"fixed64,10,opt,name=score,proto3" json:"score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *KBucketStats) Reset()         { *m = KBucketStats{} }
+func (m *KBucketStats) String() string { return proto.CompactTextString(m) }
+func (*KBucketStats) ProtoMessage()    {}
+func (*KBucketStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{34}
 }
-func (m *Bucket) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Bucket.Unmarshal(m, b)
+func (m *KBucketStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KBucketStats.Unmarshal(m, b)
 }
-func (m *Bucket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Bucket.Marshal(b, m, deterministic)
+func (m *KBucketStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KBucketStats.Marshal(b, m, deterministic)
 }
-func (dst *Bucket) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Bucket.Merge(dst, src)
+func (dst *KBucketStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KBucketStats.Merge(dst, src)
 }
-func (m *Bucket) XXX_Size() int {
-	return xxx_messageInfo_Bucket.Size(m)
+func (m *KBucketStats) XXX_Size() int {
+	return xxx_messageInfo_KBucketStats.Size(m)
 }
-func (m *Bucket) XXX_DiscardUnknown() {
-	xxx_messageInfo_Bucket.DiscardUnknown(m)
+func (m *KBucketStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_KBucketStats.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Bucket proto.InternalMessageInfo
+var xxx_messageInfo_KBucketStats proto.InternalMessageInfo
 
-func (m *Bucket) GetNodes() []*Node {
+func (m *KBucketStats) GetLastUpdated() *timestamp.Timestamp {
 	if m != nil {
-		return m.Nodes
+		return m.LastUpdated
 	}
 	return nil
 }
 
-type BucketList struct {
-	Nodes                []*Node  `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *KBucketStats) GetNodes() int64 {
+	if m != nil {
+		return m.Nodes
+	}
+	return 0
 }
 
-func (m *BucketList) Reset()         { *m = BucketList{} }
-func (m *BucketList) String() string { return proto.CompactTextString(m) }
-func (*BucketList) ProtoMessage()    {}
-func (*BucketList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_27853f276f81267a, []int{11}
-}
-func (m *BucketList) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_BucketList.Unmarshal(m, b)
+func (m *KBucketStats) GetReplacements() int64 {
+	if m != nil {
+		return m.Replacements
+	}
+	return 0
 }
-func (m *BucketList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_BucketList.Marshal(b, m, deterministic)
+
+func (m *KBucketStats) GetFill() float64 {
+	if m != nil {
+		return m.Fill
+	}
+	return 0
 }
-func (dst *BucketList) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BucketList.Merge(dst, src)
+
+func (m *KBucketStats) GetFresh() int64 {
+	if m != nil {
+		return m.Fresh
+	}
+	return 0
 }
-func (m *BucketList) XXX_Size() int {
-	return xxx_messageInfo_BucketList.Size(m)
+
+func (m *KBucketStats) GetUnseen() int64 {
+	if m != nil {
+		return m.Unseen
+	}
+	return 0
 }
-func (m *BucketList) XXX_DiscardUnknown() {
-	xxx_messageInfo_BucketList.DiscardUnknown(m)
+
+func (m *KBucketStats) GetAverageLastSeenAgeNs() int64 {
+	if m != nil {
+		return m.AverageLastSeenAgeNs
+	}
+	return 0
 }
 
-var xxx_messageInfo_BucketList proto.InternalMessageInfo
+func (m *KBucketStats) GetChurn() int64 {
+	if m != nil {
+		return m.Churn
+	}
+	return 0
+}
 
-func (m *BucketList) GetNodes() []*Node {
+func (m *KBucketStats) GetScore() float64 {
 	if m != nil {
-		return m.Nodes
+		return m.Score
 	}
-	return nil
+	return 0
 }
 
 // PingNode
@@ -511,7 +1844,7 @@ func (m *PingNodeRequest) Reset()         { *m = PingNodeRequest{} }
 func (m *PingNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*PingNodeRequest) ProtoMessage()    {}
 func (*PingNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_27853f276f81267a, []int{12}
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{35}
 }
 func (m *PingNodeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PingNodeRequest.Unmarshal(m, b)
@@ -549,7 +1882,7 @@ func (m *PingNodeResponse) Reset()         { *m = PingNodeResponse{} }
 func (m *PingNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*PingNodeResponse) ProtoMessage()    {}
 func (*PingNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_27853f276f81267a, []int{13}
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{36}
 }
 func (m *PingNodeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PingNodeResponse.Unmarshal(m, b)
@@ -588,7 +1921,7 @@ func (m *LookupNodeRequest) Reset()         { *m = LookupNodeRequest{} }
 func (m *LookupNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*LookupNodeRequest) ProtoMessage()    {}
 func (*LookupNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_27853f276f81267a, []int{14}
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{37}
 }
 func (m *LookupNodeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LookupNodeRequest.Unmarshal(m, b)
@@ -634,7 +1967,7 @@ func (m *LookupNodeResponse) Reset()         { *m = LookupNodeResponse{} }
 func (m *LookupNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*LookupNodeResponse) ProtoMessage()    {}
 func (*LookupNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_27853f276f81267a, []int{15}
+	return fileDescriptor_inspector_26cbc5452bd0872f, []int{38}
 }
 func (m *LookupNodeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LookupNodeResponse.Unmarshal(m, b)
@@ -675,16 +2008,42 @@ func init() {
 	proto.RegisterType((*CreateStatsResponse)(nil), "inspector.CreateStatsResponse")
 	proto.RegisterType((*CountNodesResponse)(nil), "inspector.CountNodesResponse")
 	proto.RegisterType((*CountNodesRequest)(nil), "inspector.CountNodesRequest")
+	proto.RegisterType((*NodeEvent)(nil), "inspector.NodeEvent")
+	proto.RegisterType((*NodeEventsRequest)(nil), "inspector.NodeEventsRequest")
+	proto.RegisterType((*NodeEventsResponse)(nil), "inspector.NodeEventsResponse")
+	proto.RegisterType((*ExplainSelectionRequest)(nil), "inspector.ExplainSelectionRequest")
+	proto.RegisterType((*NodeSelection)(nil), "inspector.NodeSelection")
+	proto.RegisterType((*ExplainSelectionResponse)(nil), "inspector.ExplainSelectionResponse")
+	proto.RegisterType((*NodeVetting)(nil), "inspector.NodeVetting")
+	proto.RegisterType((*NodeVettingRequest)(nil), "inspector.NodeVettingRequest")
+	proto.RegisterType((*NodeVettingResponse)(nil), "inspector.NodeVettingResponse")
+	proto.RegisterType((*SetNodeTagsRequest)(nil), "inspector.SetNodeTagsRequest")
+	proto.RegisterType((*SetNodeTagsResponse)(nil), "inspector.SetNodeTagsResponse")
+	proto.RegisterType((*DrainRequest)(nil), "inspector.DrainRequest")
+	proto.RegisterType((*DrainResponse)(nil), "inspector.DrainResponse")
+	proto.RegisterType((*DrainStatusRequest)(nil), "inspector.DrainStatusRequest")
+	proto.RegisterType((*NodeDrainProgress)(nil), "inspector.NodeDrainProgress")
+	proto.RegisterType((*DrainStatusResponse)(nil), "inspector.DrainStatusResponse")
 	proto.RegisterType((*GetBucketsRequest)(nil), "inspector.GetBucketsRequest")
 	proto.RegisterType((*GetBucketsResponse)(nil), "inspector.GetBucketsResponse")
 	proto.RegisterType((*GetBucketRequest)(nil), "inspector.GetBucketRequest")
 	proto.RegisterType((*GetBucketResponse)(nil), "inspector.GetBucketResponse")
 	proto.RegisterType((*Bucket)(nil), "inspector.Bucket")
 	proto.RegisterType((*BucketList)(nil), "inspector.BucketList")
+	proto.RegisterType((*DumpRoutingTableRequest)(nil), "inspector.DumpRoutingTableRequest")
+	proto.RegisterType((*DumpRoutingTableResponse)(nil), "inspector.DumpRoutingTableResponse")
+	proto.RegisterType((*KBucket)(nil), "inspector.KBucket")
+	proto.RegisterType((*RoutingTableNode)(nil), "inspector.RoutingTableNode")
+	proto.RegisterType((*RoutingHealthRequest)(nil), "inspector.RoutingHealthRequest")
+	proto.RegisterType((*RoutingHealthResponse)(nil), "inspector.RoutingHealthResponse")
+	proto.RegisterType((*KBucketStats)(nil), "inspector.KBucketStats")
 	proto.RegisterType((*PingNodeRequest)(nil), "inspector.PingNodeRequest")
 	proto.RegisterType((*PingNodeResponse)(nil), "inspector.PingNodeResponse")
 	proto.RegisterType((*LookupNodeRequest)(nil), "inspector.LookupNodeRequest")
 	proto.RegisterType((*LookupNodeResponse)(nil), "inspector.LookupNodeResponse")
+	proto.RegisterEnum("inspector.NodeEventType", NodeEventType_name, NodeEventType_value)
+	proto.RegisterEnum("inspector.SelectionResult", SelectionResult_name, SelectionResult_value)
+	proto.RegisterEnum("inspector.NodeVetting_State", NodeVetting_State_name, NodeVetting_State_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -709,6 +2068,10 @@ type KadInspectorClient interface {
 	PingNode(ctx context.Context, in *PingNodeRequest, opts ...grpc.CallOption) (*PingNodeResponse, error)
 	// LookupNode triggers a Kademlia FindNode and returns the response
 	LookupNode(ctx context.Context, in *LookupNodeRequest, opts ...grpc.CallOption) (*LookupNodeResponse, error)
+	// DumpRoutingTable returns the k buckets with their nodes and the statistics about them
+	DumpRoutingTable(ctx context.Context, in *DumpRoutingTableRequest, opts ...grpc.CallOption) (*DumpRoutingTableResponse, error)
+	// RoutingHealth returns the statistics of the k buckets and the health score of the routing table
+	RoutingHealth(ctx context.Context, in *RoutingHealthRequest, opts ...grpc.CallOption) (*RoutingHealthResponse, error)
 }
 
 type kadInspectorClient struct {
@@ -764,6 +2127,24 @@ func (c *kadInspectorClient) LookupNode(ctx context.Context, in *LookupNodeReque
 	return out, nil
 }
 
+func (c *kadInspectorClient) DumpRoutingTable(ctx context.Context, in *DumpRoutingTableRequest, opts ...grpc.CallOption) (*DumpRoutingTableResponse, error) {
+	out := new(DumpRoutingTableResponse)
+	err := c.cc.Invoke(ctx, "/inspector.KadInspector/DumpRoutingTable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kadInspectorClient) RoutingHealth(ctx context.Context, in *RoutingHealthRequest, opts ...grpc.CallOption) (*RoutingHealthResponse, error) {
+	out := new(RoutingHealthResponse)
+	err := c.cc.Invoke(ctx, "/inspector.KadInspector/RoutingHealth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // KadInspectorServer is the server API for KadInspector service.
 type KadInspectorServer interface {
 	// CountNodes returns the number of nodes in the routing table
@@ -776,6 +2157,10 @@ type KadInspectorServer interface {
 	PingNode(context.Context, *PingNodeRequest) (*PingNodeResponse, error)
 	// LookupNode triggers a Kademlia FindNode and returns the response
 	LookupNode(context.Context, *LookupNodeRequest) (*LookupNodeResponse, error)
+	// DumpRoutingTable returns the k buckets with their nodes and the statistics about them
+	DumpRoutingTable(context.Context, *DumpRoutingTableRequest) (*DumpRoutingTableResponse, error)
+	// RoutingHealth returns the statistics of the k buckets and the health score of the routing table
+	RoutingHealth(context.Context, *RoutingHealthRequest) (*RoutingHealthResponse, error)
 }
 
 func RegisterKadInspectorServer(s *grpc.Server, srv KadInspectorServer) {
@@ -872,6 +2257,42 @@ func _KadInspector_LookupNode_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _KadInspector_DumpRoutingTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DumpRoutingTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KadInspectorServer).DumpRoutingTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inspector.KadInspector/DumpRoutingTable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KadInspectorServer).DumpRoutingTable(ctx, req.(*DumpRoutingTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KadInspector_RoutingHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoutingHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KadInspectorServer).RoutingHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inspector.KadInspector/RoutingHealth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KadInspectorServer).RoutingHealth(ctx, req.(*RoutingHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _KadInspector_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "inspector.KadInspector",
 	HandlerType: (*KadInspectorServer)(nil),
@@ -896,6 +2317,14 @@ var _KadInspector_serviceDesc = grpc.ServiceDesc{
 			MethodName: "LookupNode",
 			Handler:    _KadInspector_LookupNode_Handler,
 		},
+		{
+			MethodName: "DumpRoutingTable",
+			Handler:    _KadInspector_DumpRoutingTable_Handler,
+		},
+		{
+			MethodName: "RoutingHealth",
+			Handler:    _KadInspector_RoutingHealth_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "inspector.proto",
@@ -907,6 +2336,14 @@ var _KadInspector_serviceDesc = grpc.ServiceDesc{
 type OverlayInspectorClient interface {
 	// CountNodes returns the number of nodes in the cache
 	CountNodes(ctx context.Context, in *CountNodesRequest, opts ...grpc.CallOption) (*CountNodesResponse, error)
+	// NodeEvents returns the recorded lifecycle events of nodes
+	NodeEvents(ctx context.Context, in *NodeEventsRequest, opts ...grpc.CallOption) (*NodeEventsResponse, error)
+	// ExplainSelection runs the storage node selection filters and reports why nodes are excluded
+	ExplainSelection(ctx context.Context, in *ExplainSelectionRequest, opts ...grpc.CallOption) (*ExplainSelectionResponse, error)
+	// NodeVetting returns the vetting state of a node and the statistics it's based on
+	NodeVetting(ctx context.Context, in *NodeVettingRequest, opts ...grpc.CallOption) (*NodeVettingResponse, error)
+	// SetNodeTags sets the operator tags of a node, which override the tags the node signed
+	SetNodeTags(ctx context.Context, in *SetNodeTagsRequest, opts ...grpc.CallOption) (*SetNodeTagsResponse, error)
 }
 
 type overlayInspectorClient struct {
@@ -926,10 +2363,54 @@ func (c *overlayInspectorClient) CountNodes(ctx context.Context, in *CountNodesR
 	return out, nil
 }
 
+func (c *overlayInspectorClient) NodeEvents(ctx context.Context, in *NodeEventsRequest, opts ...grpc.CallOption) (*NodeEventsResponse, error) {
+	out := new(NodeEventsResponse)
+	err := c.cc.Invoke(ctx, "/inspector.OverlayInspector/NodeEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *overlayInspectorClient) ExplainSelection(ctx context.Context, in *ExplainSelectionRequest, opts ...grpc.CallOption) (*ExplainSelectionResponse, error) {
+	out := new(ExplainSelectionResponse)
+	err := c.cc.Invoke(ctx, "/inspector.OverlayInspector/ExplainSelection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *overlayInspectorClient) NodeVetting(ctx context.Context, in *NodeVettingRequest, opts ...grpc.CallOption) (*NodeVettingResponse, error) {
+	out := new(NodeVettingResponse)
+	err := c.cc.Invoke(ctx, "/inspector.OverlayInspector/NodeVetting", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *overlayInspectorClient) SetNodeTags(ctx context.Context, in *SetNodeTagsRequest, opts ...grpc.CallOption) (*SetNodeTagsResponse, error) {
+	out := new(SetNodeTagsResponse)
+	err := c.cc.Invoke(ctx, "/inspector.OverlayInspector/SetNodeTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OverlayInspectorServer is the server API for OverlayInspector service.
 type OverlayInspectorServer interface {
 	// CountNodes returns the number of nodes in the cache
 	CountNodes(context.Context, *CountNodesRequest) (*CountNodesResponse, error)
+	// NodeEvents returns the recorded lifecycle events of nodes
+	NodeEvents(context.Context, *NodeEventsRequest) (*NodeEventsResponse, error)
+	// ExplainSelection runs the storage node selection filters and reports why nodes are excluded
+	ExplainSelection(context.Context, *ExplainSelectionRequest) (*ExplainSelectionResponse, error)
+	// NodeVetting returns the vetting state of a node and the statistics it's based on
+	NodeVetting(context.Context, *NodeVettingRequest) (*NodeVettingResponse, error)
+	// SetNodeTags sets the operator tags of a node, which override the tags the node signed
+	SetNodeTags(context.Context, *SetNodeTagsRequest) (*SetNodeTagsResponse, error)
 }
 
 func RegisterOverlayInspectorServer(s *grpc.Server, srv OverlayInspectorServer) {
@@ -954,6 +2435,78 @@ func _OverlayInspector_CountNodes_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OverlayInspector_NodeEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OverlayInspectorServer).NodeEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inspector.OverlayInspector/NodeEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OverlayInspectorServer).NodeEvents(ctx, req.(*NodeEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OverlayInspector_ExplainSelection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainSelectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OverlayInspectorServer).ExplainSelection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inspector.OverlayInspector/ExplainSelection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OverlayInspectorServer).ExplainSelection(ctx, req.(*ExplainSelectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OverlayInspector_NodeVetting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeVettingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OverlayInspectorServer).NodeVetting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inspector.OverlayInspector/NodeVetting",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OverlayInspectorServer).NodeVetting(ctx, req.(*NodeVettingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OverlayInspector_SetNodeTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetNodeTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OverlayInspectorServer).SetNodeTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inspector.OverlayInspector/SetNodeTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OverlayInspectorServer).SetNodeTags(ctx, req.(*SetNodeTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _OverlayInspector_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "inspector.OverlayInspector",
 	HandlerType: (*OverlayInspectorServer)(nil),
@@ -962,6 +2515,123 @@ var _OverlayInspector_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CountNodes",
 			Handler:    _OverlayInspector_CountNodes_Handler,
 		},
+		{
+			MethodName: "NodeEvents",
+			Handler:    _OverlayInspector_NodeEvents_Handler,
+		},
+		{
+			MethodName: "ExplainSelection",
+			Handler:    _OverlayInspector_ExplainSelection_Handler,
+		},
+		{
+			MethodName: "NodeVetting",
+			Handler:    _OverlayInspector_NodeVetting_Handler,
+		},
+		{
+			MethodName: "SetNodeTags",
+			Handler:    _OverlayInspector_SetNodeTags_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "inspector.proto",
+}
+
+// RebalancerInspectorClient is the client API for RebalancerInspector service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type RebalancerInspectorClient interface {
+	// Drain drains nodes and the nodes of regions, their pieces are repaired to other nodes
+	Drain(ctx context.Context, in *DrainRequest, opts ...grpc.CallOption) (*DrainResponse, error)
+	// DrainStatus returns the progress of the draining nodes
+	DrainStatus(ctx context.Context, in *DrainStatusRequest, opts ...grpc.CallOption) (*DrainStatusResponse, error)
+}
+
+type rebalancerInspectorClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRebalancerInspectorClient(cc *grpc.ClientConn) RebalancerInspectorClient {
+	return &rebalancerInspectorClient{cc}
+}
+
+func (c *rebalancerInspectorClient) Drain(ctx context.Context, in *DrainRequest, opts ...grpc.CallOption) (*DrainResponse, error) {
+	out := new(DrainResponse)
+	err := c.cc.Invoke(ctx, "/inspector.RebalancerInspector/Drain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rebalancerInspectorClient) DrainStatus(ctx context.Context, in *DrainStatusRequest, opts ...grpc.CallOption) (*DrainStatusResponse, error) {
+	out := new(DrainStatusResponse)
+	err := c.cc.Invoke(ctx, "/inspector.RebalancerInspector/DrainStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RebalancerInspectorServer is the server API for RebalancerInspector service.
+type RebalancerInspectorServer interface {
+	// Drain drains nodes and the nodes of regions, their pieces are repaired to other nodes
+	Drain(context.Context, *DrainRequest) (*DrainResponse, error)
+	// DrainStatus returns the progress of the draining nodes
+	DrainStatus(context.Context, *DrainStatusRequest) (*DrainStatusResponse, error)
+}
+
+func RegisterRebalancerInspectorServer(s *grpc.Server, srv RebalancerInspectorServer) {
+	s.RegisterService(&_RebalancerInspector_serviceDesc, srv)
+}
+
+func _RebalancerInspector_Drain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RebalancerInspectorServer).Drain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inspector.RebalancerInspector/Drain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RebalancerInspectorServer).Drain(ctx, req.(*DrainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RebalancerInspector_DrainStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RebalancerInspectorServer).DrainStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inspector.RebalancerInspector/DrainStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RebalancerInspectorServer).DrainStatus(ctx, req.(*DrainStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _RebalancerInspector_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "inspector.RebalancerInspector",
+	HandlerType: (*RebalancerInspectorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Drain",
+			Handler:    _RebalancerInspector_Drain_Handler,
+		},
+		{
+			MethodName: "DrainStatus",
+			Handler:    _RebalancerInspector_DrainStatus_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "inspector.proto",
@@ -1068,49 +2738,145 @@ var _StatDBInspector_serviceDesc = grpc.ServiceDesc{
 	Metadata: "inspector.proto",
 }
 
-func init() { proto.RegisterFile("inspector.proto", fileDescriptor_inspector_27853f276f81267a) }
-
-var fileDescriptor_inspector_27853f276f81267a = []byte{
-	// 644 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xcb, 0x6e, 0xd3, 0x40,
-	0x14, 0xc5, 0x4e, 0x9a, 0x26, 0x37, 0x51, 0x1e, 0x93, 0x22, 0x45, 0x4e, 0x68, 0xc2, 0x2c, 0x20,
-	0xea, 0x22, 0x42, 0x66, 0x87, 0xc4, 0x26, 0xa9, 0x28, 0x51, 0xc3, 0x43, 0xae, 0xd8, 0x20, 0x50,
-	0x35, 0x8d, 0x47, 0x91, 0x95, 0x36, 0x63, 0x3c, 0x63, 0x24, 0x7e, 0x85, 0x35, 0x1f, 0xc3, 0x37,
-	0xb0, 0xe8, 0x86, 0xdf, 0x60, 0x81, 0x3c, 0x63, 0x7b, 0xec, 0x38, 0x51, 0x2b, 0x24, 0x76, 0xf6,
-	0x39, 0xc7, 0x67, 0xee, 0xb9, 0xf7, 0x6a, 0x0c, 0x2d, 0x6f, 0xc3, 0x7d, 0xba, 0x14, 0x2c, 0x98,
-	0xf8, 0x01, 0x13, 0x0c, 0xd5, 0x52, 0xc0, 0x82, 0x15, 0x5b, 0x31, 0x05, 0x5b, 0xb0, 0x61, 0x2e,
-	0x55, 0xcf, 0xf8, 0x05, 0xb4, 0xce, 0xa8, 0xb8, 0x10, 0x44, 0x70, 0x87, 0x7e, 0x09, 0x29, 0x17,
-	0xe8, 0x29, 0x1c, 0x46, 0x82, 0x4b, 0xcf, 0xed, 0x19, 0x23, 0x63, 0xdc, 0x98, 0x36, 0x7f, 0xde,
-	0x0e, 0x1f, 0xfc, 0xba, 0x1d, 0x56, 0xde, 0x32, 0x97, 0xce, 0x4f, 0x9d, 0x4a, 0x44, 0xcf, 0x5d,
-	0xfc, 0xdd, 0x80, 0xb6, 0xfe, 0x98, 0xfb, 0x6c, 0xc3, 0x29, 0x1a, 0x42, 0x9d, 0x84, 0xae, 0x27,
-	0x2e, 0x97, 0x2c, 0xdc, 0x08, 0xe9, 0x50, 0x72, 0x40, 0x42, 0xb3, 0x08, 0xd1, 0x82, 0x80, 0x08,
-	0x8f, 0xf5, 0xcc, 0x91, 0x31, 0x36, 0x62, 0x81, 0x13, 0x21, 0xe8, 0x31, 0x34, 0x42, 0x5f, 0x78,
-	0x37, 0x34, 0xb6, 0x28, 0x49, 0x8b, 0xba, 0xc2, 0x94, 0x87, 0x96, 0x28, 0x93, 0xb2, 0x34, 0x89,
-	0x25, 0xd2, 0x05, 0xff, 0x36, 0x00, 0xcd, 0x02, 0x4a, 0x04, 0xfd, 0xa7, 0x70, 0xdb, 0x39, 0xcc,
-	0x42, 0x8e, 0x09, 0x74, 0x95, 0x80, 0x87, 0xcb, 0x25, 0xe5, 0x3c, 0x57, 0x6d, 0x47, 0x52, 0x17,
-	0x8a, 0xd9, 0xae, 0x59, 0x09, 0xcb, 0xc5, 0x58, 0xcf, 0xe0, 0x28, 0x96, 0xe4, 0x3d, 0x0f, 0xa4,
-	0x14, 0x29, 0x2e, 0x6b, 0x8a, 0x1f, 0x42, 0x37, 0x17, 0x52, 0x0d, 0x01, 0x9f, 0x00, 0x92, 0x7c,
-	0x94, 0x49, 0x8f, 0xe6, 0x08, 0x0e, 0xb2, 0x43, 0x51, 0x2f, 0xb8, 0x0b, 0x9d, 0xac, 0x56, 0xb6,
-	0x29, 0x02, 0xcf, 0xa8, 0x98, 0x86, 0xcb, 0x35, 0x4d, 0x7b, 0x87, 0x5f, 0x03, 0xca, 0x82, 0xda,
-	0x55, 0x30, 0x41, 0xae, 0x13, 0x57, 0xf9, 0x82, 0x06, 0x50, 0xf2, 0x5c, 0xde, 0x33, 0x47, 0xa5,
-	0x71, 0x63, 0x0a, 0x99, 0xfe, 0x46, 0x30, 0xb6, 0xe5, 0xe2, 0x28, 0xa7, 0x64, 0x32, 0xc7, 0x60,
-	0xee, 0x1d, 0x8a, 0xe9, 0xb9, 0xf8, 0x43, 0xa6, 0xa4, 0xf4, 0xf0, 0x3b, 0x3e, 0x42, 0x23, 0x38,
-	0x88, 0xe6, 0xa9, 0x0a, 0xa9, 0xdb, 0x30, 0x91, 0xab, 0x1f, 0x09, 0x1c, 0x45, 0xe0, 0x13, 0xa8,
-	0x28, 0xcf, 0x7b, 0x68, 0x27, 0x00, 0x4a, 0xbb, 0xf0, 0x78, 0x46, 0x6f, 0xec, 0xd3, 0x9f, 0x43,
-	0xeb, 0xbd, 0xb7, 0x59, 0x49, 0xe8, 0x7e, 0x29, 0x51, 0x0f, 0x0e, 0x89, 0xeb, 0x06, 0x94, 0x73,
-	0xb9, 0x72, 0x35, 0x27, 0x79, 0xc5, 0x18, 0xda, 0xda, 0x2c, 0x8e, 0xdf, 0x04, 0x93, 0xad, 0xa5,
-	0x5b, 0xd5, 0x31, 0xd9, 0x1a, 0xbf, 0x84, 0xce, 0x82, 0xb1, 0x75, 0xe8, 0x67, 0x8f, 0x6c, 0xa6,
-	0x47, 0xd6, 0xee, 0x38, 0xe2, 0x13, 0xa0, 0xec, 0xe7, 0x69, 0x8f, 0xcb, 0x51, 0x1c, 0xe9, 0x90,
-	0x8f, 0x29, 0x71, 0xf4, 0x04, 0xca, 0x37, 0x54, 0x10, 0x69, 0x56, 0xb7, 0x91, 0xe6, 0xdf, 0x50,
-	0x41, 0x5c, 0x22, 0x88, 0x23, 0x79, 0xfb, 0x8f, 0x09, 0x8d, 0x73, 0xe2, 0xce, 0x93, 0x3b, 0x09,
-	0xcd, 0x01, 0xf4, 0xe6, 0xa1, 0xc1, 0x44, 0x5f, 0x5f, 0x85, 0x85, 0xb4, 0x1e, 0xed, 0x61, 0xe3,
-	0x1a, 0xe7, 0x00, 0x7a, 0x35, 0x73, 0x56, 0x85, 0x35, 0xce, 0x59, 0xed, 0xd8, 0xe7, 0x57, 0x50,
-	0x4b, 0x51, 0xd4, 0xdf, 0xa5, 0x4d, 0x8c, 0x06, 0xbb, 0xc9, 0xd8, 0x67, 0x06, 0xd5, 0x64, 0x5e,
-	0xc8, 0xca, 0x28, 0xb7, 0x36, 0xc2, 0xea, 0xef, 0xe4, 0x74, 0x2e, 0x3d, 0x91, 0x5c, 0xae, 0xc2,
-	0x9c, 0x73, 0xb9, 0x8a, 0x63, 0xb4, 0x3f, 0x43, 0xfb, 0xdd, 0x57, 0x1a, 0x5c, 0x93, 0x6f, 0xff,
-	0x63, 0x02, 0xf6, 0x0f, 0x03, 0x5a, 0xd1, 0x25, 0x74, 0x3a, 0xd5, 0xf6, 0x33, 0xa8, 0x26, 0xff,
-	0x87, 0x5c, 0x0b, 0xb6, 0xfe, 0x38, 0x56, 0x7f, 0x27, 0x17, 0xb7, 0x60, 0x01, 0xf5, 0xcc, 0x15,
-	0x87, 0x72, 0x65, 0x14, 0xee, 0x77, 0xeb, 0x78, 0x1f, 0xad, 0xdc, 0xa6, 0xe5, 0x8f, 0xa6, 0x7f,
-	0x75, 0x55, 0x91, 0x3f, 0xbf, 0xe7, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x78, 0xf1, 0xf1, 0x7a,
-	0x32, 0x07, 0x00, 0x00,
+func init() { proto.RegisterFile("inspector.proto", fileDescriptor_inspector_26cbc5452bd0872f) }
+
+var fileDescriptor_inspector_26cbc5452bd0872f = []byte{
+	// 2179 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x4b, 0x73, 0xdb, 0xc8,
+	0x11, 0x36, 0xf8, 0x66, 0x8b, 0x12, 0xa1, 0x11, 0x6d, 0x33, 0xb4, 0x2d, 0x6b, 0xb1, 0x8f, 0x38,
+	0x8e, 0x8b, 0xbb, 0x66, 0x2a, 0x8f, 0x75, 0x95, 0x2b, 0x45, 0x91, 0x10, 0x85, 0x88, 0xa6, 0x14,
+	0x10, 0x5c, 0x6f, 0xb2, 0xa9, 0xc2, 0x42, 0xc4, 0x98, 0x46, 0x19, 0x02, 0x18, 0x60, 0xe8, 0xd8,
+	0x97, 0x9c, 0xf2, 0x13, 0x72, 0xca, 0x21, 0xa9, 0xa4, 0x72, 0xcd, 0x8f, 0xc8, 0x29, 0xb9, 0xe4,
+	0x0f, 0xa4, 0x52, 0x7b, 0x49, 0xe5, 0x9e, 0x63, 0x8e, 0xa9, 0x79, 0xe0, 0xc5, 0x87, 0x24, 0x6f,
+	0xd5, 0xde, 0x30, 0xdd, 0xdf, 0x34, 0xba, 0xbf, 0xe9, 0xe9, 0xe9, 0x19, 0xa8, 0x3b, 0x5e, 0x38,
+	0xc7, 0x53, 0xe2, 0x07, 0xed, 0x79, 0xe0, 0x13, 0x1f, 0x55, 0x63, 0x41, 0xeb, 0xfe, 0xcc, 0xf7,
+	0x67, 0x2e, 0xfe, 0x98, 0x29, 0xce, 0x17, 0x2f, 0x3e, 0x26, 0xce, 0x05, 0x0e, 0x89, 0x75, 0x31,
+	0xe7, 0xd8, 0x16, 0xcc, 0xfc, 0x99, 0x1f, 0x7d, 0x7b, 0xbe, 0x8d, 0xf9, 0xb7, 0xf2, 0x04, 0xea,
+	0x03, 0x4c, 0xc6, 0xc4, 0x22, 0xa1, 0x8e, 0x7f, 0xb9, 0xc0, 0x21, 0x41, 0xdf, 0x86, 0x32, 0x05,
+	0x98, 0x8e, 0xdd, 0x94, 0x0e, 0xa4, 0x07, 0xb5, 0xc3, 0x9d, 0xbf, 0x7f, 0x75, 0xff, 0xc6, 0x3f,
+	0xbf, 0xba, 0x5f, 0x1a, 0xf9, 0x36, 0xd6, 0xfa, 0x7a, 0x89, 0xaa, 0x35, 0x5b, 0xf9, 0x9d, 0x04,
+	0x72, 0x32, 0x39, 0x9c, 0xfb, 0x5e, 0x88, 0xd1, 0x7d, 0xd8, 0xb2, 0x16, 0xb6, 0x43, 0xcc, 0xa9,
+	0xbf, 0xf0, 0x08, 0xb3, 0x90, 0xd7, 0x81, 0x89, 0x7a, 0x54, 0x92, 0x00, 0x02, 0x8b, 0x38, 0x7e,
+	0x33, 0x77, 0x20, 0x3d, 0x90, 0x04, 0x40, 0xa7, 0x12, 0xf4, 0x1e, 0xd4, 0x16, 0x73, 0xea, 0xbf,
+	0x30, 0x91, 0x67, 0x26, 0xb6, 0xb8, 0x8c, 0xdb, 0x48, 0x20, 0xdc, 0x48, 0x81, 0x19, 0x11, 0x10,
+	0x66, 0x45, 0xf9, 0xb7, 0x04, 0xa8, 0x17, 0x60, 0x8b, 0xe0, 0xaf, 0x15, 0xdc, 0x72, 0x1c, 0xb9,
+	0x95, 0x38, 0xda, 0xb0, 0xc7, 0x01, 0xe1, 0x62, 0x3a, 0xc5, 0x61, 0x98, 0xf1, 0x76, 0x97, 0xa9,
+	0xc6, 0x5c, 0xb3, 0xec, 0x33, 0x07, 0x16, 0x56, 0xc3, 0xfa, 0x04, 0x1a, 0x02, 0x92, 0xb5, 0x59,
+	0x64, 0x50, 0xc4, 0x75, 0x69, 0xa3, 0xca, 0x4d, 0xd8, 0xcb, 0x04, 0xc9, 0x17, 0x41, 0x79, 0x08,
+	0x88, 0xe9, 0x69, 0x4c, 0xc9, 0xd2, 0x34, 0xa0, 0x98, 0x5e, 0x14, 0x3e, 0x50, 0xf6, 0x60, 0x37,
+	0x8d, 0x65, 0x34, 0x29, 0x7f, 0x95, 0xa0, 0x4a, 0x05, 0xea, 0x6b, 0xec, 0x11, 0xb4, 0x03, 0x39,
+	0xc1, 0x57, 0x5e, 0xcf, 0x39, 0x76, 0x9a, 0xc4, 0xdc, 0xa5, 0x24, 0x3e, 0x82, 0x02, 0x79, 0x3b,
+	0xc7, 0x8c, 0x94, 0x9d, 0x4e, 0xb3, 0x9d, 0x64, 0x70, 0x6c, 0xdc, 0x78, 0x3b, 0xc7, 0x3a, 0x43,
+	0x21, 0x04, 0x05, 0xdb, 0x22, 0x16, 0x63, 0xa6, 0xaa, 0xb3, 0x6f, 0xf4, 0x29, 0xc0, 0x94, 0x05,
+	0x68, 0x9b, 0x16, 0x27, 0x62, 0xab, 0xd3, 0x6a, 0xf3, 0x6c, 0x6f, 0x47, 0xd9, 0xde, 0x36, 0xa2,
+	0x6c, 0xd7, 0xab, 0x02, 0xdd, 0x25, 0xca, 0xaf, 0x60, 0x37, 0xfe, 0xcb, 0xbb, 0xaf, 0x7f, 0x03,
+	0x8a, 0xae, 0x73, 0xe1, 0xf0, 0x05, 0x2d, 0xea, 0x7c, 0x80, 0xee, 0x01, 0xcc, 0xad, 0x19, 0x36,
+	0x89, 0xff, 0x0a, 0x7b, 0xc2, 0xd1, 0x2a, 0x95, 0x18, 0x54, 0xf0, 0x93, 0x42, 0x25, 0x27, 0xe7,
+	0x95, 0x5f, 0x03, 0x4a, 0xff, 0x58, 0xb0, 0xff, 0x08, 0x4a, 0x98, 0x49, 0x9a, 0xd2, 0x41, 0xfe,
+	0xc1, 0x56, 0xa7, 0xb1, 0x8e, 0x0d, 0x5d, 0x60, 0x28, 0x17, 0x17, 0x7e, 0x80, 0x19, 0xbf, 0x15,
+	0x9d, 0x7d, 0xa3, 0x8f, 0xa0, 0xee, 0xe1, 0x37, 0xc4, 0x4c, 0x79, 0x90, 0x67, 0x1e, 0x6c, 0x53,
+	0xf1, 0x59, 0xe4, 0x85, 0xf2, 0xdb, 0x1c, 0xdc, 0x56, 0xdf, 0xcc, 0x5d, 0xcb, 0xf1, 0xc6, 0xd8,
+	0xc5, 0x53, 0xe2, 0xf8, 0x5e, 0x14, 0xff, 0x13, 0xa8, 0x05, 0x38, 0x24, 0x81, 0xc3, 0xa4, 0x21,
+	0x23, 0x61, 0xab, 0x73, 0xab, 0xcd, 0x4a, 0x02, 0x75, 0x43, 0x4f, 0x69, 0xf5, 0x0c, 0x16, 0x3d,
+	0x86, 0x1d, 0xfc, 0x66, 0xea, 0x2e, 0x6c, 0x6c, 0x9b, 0x14, 0x1f, 0x36, 0x73, 0x07, 0xf9, 0x07,
+	0xb5, 0x43, 0x48, 0xd1, 0xb7, 0x1d, 0x21, 0xe8, 0x38, 0xdc, 0xc0, 0xe2, 0x7b, 0x50, 0x20, 0xd6,
+	0x2c, 0x6c, 0x16, 0x18, 0x11, 0xdb, 0xc9, 0xcf, 0x0d, 0x6b, 0xa6, 0x33, 0xd5, 0x12, 0xd1, 0xc5,
+	0x25, 0xa2, 0x51, 0x07, 0xe2, 0x1f, 0x99, 0xcc, 0x54, 0x69, 0x9d, 0xa9, 0x5a, 0x84, 0x31, 0xac,
+	0x59, 0xa8, 0xfc, 0x5e, 0x82, 0x6d, 0xaa, 0x89, 0x39, 0xb9, 0x7e, 0x32, 0x34, 0xa1, 0x6c, 0xd9,
+	0x76, 0x80, 0xc3, 0x90, 0x2d, 0x48, 0x55, 0x8f, 0x86, 0xa8, 0x03, 0xa5, 0x00, 0x87, 0x0b, 0x97,
+	0x88, 0x1c, 0x6f, 0xa5, 0x56, 0x35, 0x45, 0x3e, 0x45, 0xe8, 0x02, 0x89, 0x6e, 0x41, 0xc9, 0xc6,
+	0xc4, 0x72, 0x5c, 0x91, 0x40, 0x62, 0xa4, 0xfc, 0x49, 0x82, 0xe6, 0xea, 0xba, 0x89, 0xf4, 0x69,
+	0x43, 0x91, 0x73, 0xce, 0xb3, 0x67, 0x79, 0x2f, 0x25, 0x13, 0x38, 0x0c, 0xb5, 0xa0, 0x82, 0x5d,
+	0x67, 0xe6, 0x9c, 0xbb, 0x58, 0x14, 0xaf, 0x78, 0x1c, 0x27, 0x57, 0xfe, 0xf2, 0xe4, 0x2a, 0xac,
+	0x4b, 0xae, 0xff, 0xe6, 0x60, 0x8b, 0xfe, 0xf0, 0x33, 0x4c, 0x88, 0xe3, 0xcd, 0xae, 0xcf, 0x61,
+	0x07, 0x8a, 0x21, 0xb1, 0x08, 0xf7, 0x66, 0xa7, 0x73, 0x77, 0x29, 0x00, 0x61, 0xaf, 0x4d, 0x0b,
+	0x19, 0xd6, 0x39, 0x74, 0xb9, 0x08, 0xe7, 0x57, 0x8a, 0xf0, 0x35, 0x8a, 0xea, 0x1d, 0xa8, 0xd2,
+	0x4a, 0x62, 0xbe, 0xc4, 0xae, 0x2d, 0x2a, 0x69, 0x85, 0x0a, 0x8e, 0xb1, 0x6b, 0xa3, 0xef, 0x80,
+	0x2c, 0x0e, 0x23, 0x3c, 0x5f, 0x10, 0x7a, 0x70, 0x78, 0xcd, 0x12, 0x3b, 0x4c, 0xea, 0x4c, 0xae,
+	0xc7, 0x62, 0xf4, 0x5d, 0xd8, 0x8d, 0xce, 0x9c, 0x04, 0x5b, 0x66, 0x58, 0x99, 0x2b, 0x12, 0xb0,
+	0x72, 0x02, 0x45, 0x16, 0x08, 0x2a, 0x43, 0x7e, 0xa4, 0x3e, 0x97, 0x6f, 0x20, 0x80, 0xd2, 0x67,
+	0xaa, 0x61, 0xa8, 0x7d, 0x59, 0x42, 0xdb, 0x50, 0x1d, 0x4f, 0xc6, 0x67, 0xea, 0xa8, 0xaf, 0xf6,
+	0xe5, 0x1c, 0x92, 0xa1, 0xd6, 0xd7, 0xc6, 0x3f, 0x9d, 0x74, 0x87, 0xda, 0x91, 0xa6, 0xf6, 0xe5,
+	0x3c, 0xaa, 0x41, 0xa5, 0xaf, 0x77, 0xb5, 0x91, 0x36, 0x1a, 0xc8, 0x05, 0xe5, 0x29, 0xa0, 0x14,
+	0x43, 0xef, 0x7c, 0x4c, 0x0f, 0x60, 0x2f, 0x33, 0x5d, 0x24, 0xd4, 0x27, 0x50, 0x7e, 0xcd, 0x45,
+	0x71, 0x11, 0x58, 0xbb, 0x22, 0x7a, 0x04, 0x53, 0xbe, 0x04, 0x34, 0xc6, 0x44, 0x6c, 0xae, 0x77,
+	0xaf, 0xa8, 0xd1, 0xae, 0xcf, 0x6d, 0xdc, 0xf5, 0xca, 0x8f, 0x60, 0x2f, 0xf3, 0x07, 0xe1, 0x6a,
+	0x34, 0x53, 0xda, 0x3c, 0x73, 0x06, 0xb5, 0x7e, 0x60, 0x39, 0x71, 0x9d, 0xfb, 0x10, 0x2a, 0xc2,
+	0x2b, 0x3e, 0x2d, 0x5b, 0xa5, 0xca, 0xdc, 0xa5, 0x90, 0x6e, 0xec, 0x00, 0xcf, 0x58, 0x25, 0xa4,
+	0x6e, 0x55, 0xf5, 0x68, 0x48, 0x37, 0x69, 0x80, 0xad, 0xd0, 0x8f, 0x6a, 0xac, 0x18, 0x29, 0xdf,
+	0x87, 0x6d, 0xf1, 0x23, 0xe1, 0xdc, 0x07, 0x50, 0xb6, 0xa9, 0x00, 0xdb, 0xeb, 0x7e, 0x24, 0x54,
+	0x4a, 0x03, 0x10, 0x9b, 0x46, 0xb3, 0x62, 0x11, 0x1f, 0xb3, 0xff, 0x91, 0xf8, 0x19, 0xc5, 0x54,
+	0x67, 0x81, 0x3f, 0x63, 0x35, 0xe5, 0xda, 0x8c, 0x32, 0x1f, 0xa9, 0xbb, 0xa2, 0x2a, 0x89, 0x11,
+	0xfa, 0x10, 0x76, 0x1c, 0xcf, 0x21, 0x8e, 0xe5, 0x9a, 0x73, 0x07, 0x4f, 0x71, 0x28, 0x76, 0xce,
+	0xb6, 0x90, 0x9e, 0x31, 0x21, 0x4d, 0xfe, 0x00, 0x5f, 0x58, 0x8e, 0xe7, 0x78, 0xb3, 0x08, 0xc8,
+	0x37, 0x50, 0x3d, 0x96, 0x0b, 0xe8, 0x53, 0xa8, 0x4d, 0xfd, 0x8b, 0xb9, 0x8b, 0xaf, 0x7d, 0x10,
+	0x6f, 0xc5, 0xf8, 0x2e, 0xa1, 0xa5, 0x77, 0x2f, 0x13, 0xbe, 0xe0, 0x2e, 0x45, 0xbf, 0x94, 0xa5,
+	0xbf, 0x13, 0x95, 0x3b, 0x9e, 0x2d, 0xcb, 0xd5, 0x22, 0x43, 0x58, 0x54, 0xf2, 0x3e, 0x05, 0x08,
+	0xa7, 0x96, 0xe7, 0x71, 0x17, 0xf3, 0x57, 0xf7, 0x0a, 0x02, 0xdd, 0x65, 0x4d, 0xd0, 0x00, 0x93,
+	0xc3, 0xc5, 0xf4, 0x15, 0x8e, 0x7b, 0x05, 0xe5, 0x18, 0x50, 0x5a, 0x98, 0x74, 0x51, 0xc4, 0x27,
+	0x96, 0x1b, 0x75, 0x51, 0x6c, 0x80, 0xee, 0x42, 0xde, 0xb1, 0xb9, 0xb7, 0xd9, 0x0c, 0xa0, 0x62,
+	0xa5, 0x03, 0x72, 0x6c, 0x29, 0xca, 0xd0, 0x7d, 0xc8, 0x6d, 0x5c, 0xe0, 0x9c, 0x63, 0x2b, 0x93,
+	0x94, 0x4b, 0xf1, 0xcf, 0xaf, 0x98, 0x84, 0x0e, 0xb2, 0xb4, 0x41, 0xea, 0x5c, 0xe7, 0x0a, 0xe5,
+	0x21, 0x94, 0xb8, 0xcd, 0x6b, 0x60, 0xdb, 0x00, 0x1c, 0x3b, 0x74, 0xc2, 0x14, 0x5e, 0xda, 0x84,
+	0xff, 0x16, 0xdc, 0xee, 0x2f, 0x2e, 0xe6, 0xba, 0xbf, 0xa0, 0xf5, 0xc2, 0xb0, 0xce, 0x5d, 0x1c,
+	0x71, 0xf9, 0x12, 0x9a, 0xab, 0xaa, 0x38, 0xa8, 0x42, 0x88, 0xdd, 0x17, 0xa2, 0x0c, 0xa5, 0xed,
+	0x32, 0x39, 0x7a, 0x04, 0xe5, 0x73, 0xbe, 0x08, 0xc2, 0x55, 0x94, 0xca, 0x86, 0x13, 0xc1, 0x50,
+	0x04, 0x51, 0xfe, 0x25, 0x41, 0x59, 0x08, 0xaf, 0xa4, 0xeb, 0x29, 0xd4, 0x5c, 0x2b, 0x24, 0xe6,
+	0x62, 0x6e, 0x5b, 0x04, 0xf3, 0x6e, 0xf6, 0x8a, 0xb4, 0xa6, 0xf8, 0x09, 0x87, 0xa3, 0xc7, 0x11,
+	0x23, 0x79, 0xe6, 0xd6, 0x9d, 0x94, 0x5b, 0xe9, 0x40, 0x53, 0x14, 0xa1, 0x1f, 0xd3, 0xfe, 0x6b,
+	0xee, 0x5a, 0x53, 0x7c, 0xc1, 0x7a, 0xc1, 0xc2, 0xd5, 0x33, 0x33, 0x13, 0x94, 0xbf, 0x49, 0x20,
+	0x2f, 0x43, 0x28, 0x83, 0xd4, 0xfc, 0x3a, 0x06, 0xe9, 0x27, 0xfa, 0x21, 0x54, 0x59, 0x9c, 0x21,
+	0xc6, 0xde, 0x35, 0x82, 0xac, 0x50, 0xf0, 0x18, 0x63, 0x0f, 0xed, 0x03, 0x0b, 0xd8, 0x0c, 0x08,
+	0x31, 0xbd, 0xa8, 0x8c, 0x30, 0x5b, 0x3a, 0x21, 0xa3, 0x10, 0x7d, 0x00, 0x3b, 0xd6, 0x6b, 0x1c,
+	0xd0, 0x9e, 0x41, 0x40, 0x78, 0x01, 0xa9, 0x09, 0x29, 0x47, 0x35, 0xa0, 0x38, 0x77, 0xbc, 0x59,
+	0x28, 0x8e, 0x5f, 0x3e, 0x50, 0x6e, 0x41, 0x43, 0x04, 0x72, 0x8c, 0x2d, 0x97, 0xbc, 0x8c, 0x52,
+	0xe5, 0x4b, 0xb8, 0xb9, 0x24, 0x4f, 0x76, 0x5e, 0x38, 0xf5, 0x03, 0x1e, 0xa6, 0xa4, 0xf3, 0x01,
+	0x7a, 0xbc, 0x9c, 0x1d, 0xb7, 0x57, 0xb3, 0x83, 0xdf, 0x8e, 0xe2, 0x14, 0xf9, 0x47, 0x0e, 0x6a,
+	0x69, 0xcd, 0x37, 0x9d, 0x27, 0x8d, 0x24, 0x4f, 0x58, 0xfc, 0x6c, 0x80, 0x94, 0x95, 0x54, 0x60,
+	0xcc, 0xa5, 0x65, 0xb4, 0x53, 0x7b, 0xe1, 0xb8, 0x2e, 0x23, 0x4e, 0xd2, 0xd9, 0x37, 0xb5, 0xf6,
+	0x22, 0xc0, 0xe1, 0x4b, 0xd6, 0xa8, 0xe4, 0x75, 0x3e, 0xa0, 0x67, 0xc1, 0xc2, 0x63, 0xeb, 0x5b,
+	0x66, 0x62, 0x31, 0x42, 0x3f, 0x80, 0x66, 0xb4, 0x42, 0x71, 0x0a, 0x98, 0x74, 0xe4, 0x85, 0xcd,
+	0x0a, 0x43, 0x36, 0x84, 0x7e, 0x28, 0x16, 0xbd, 0x3b, 0xc3, 0x7c, 0xcd, 0xa6, 0x2f, 0x17, 0x81,
+	0xd7, 0xac, 0x8a, 0xcb, 0x22, 0x1d, 0x24, 0x4b, 0x00, 0xa9, 0x25, 0x50, 0x4e, 0xa0, 0x7e, 0xe6,
+	0x78, 0x33, 0x7e, 0x7d, 0xb8, 0x56, 0x75, 0xdb, 0xdc, 0x51, 0x2b, 0x0a, 0xc8, 0x89, 0x31, 0xb1,
+	0xf2, 0x3b, 0x90, 0xf3, 0x5f, 0x31, 0x6b, 0x15, 0x3d, 0xe7, 0xbf, 0x52, 0x9e, 0xc2, 0xee, 0xd0,
+	0xf7, 0x5f, 0x2d, 0xe6, 0xe9, 0x5f, 0x26, 0xb7, 0xd4, 0xea, 0x15, 0xbf, 0xf8, 0x05, 0xa0, 0xf4,
+	0xf4, 0xa4, 0x0c, 0x5d, 0xba, 0x89, 0x3e, 0x82, 0xc2, 0x05, 0x26, 0x96, 0x58, 0x7c, 0x94, 0xe8,
+	0x9f, 0x61, 0x62, 0xd1, 0x8e, 0x52, 0x67, 0xfa, 0x87, 0xbf, 0x11, 0xf7, 0x8c, 0xf8, 0x7a, 0x8b,
+	0x76, 0x61, 0xfb, 0x48, 0xd3, 0xc7, 0x86, 0xd9, 0x3b, 0x1d, 0x19, 0xdd, 0x9e, 0xf1, 0xae, 0xed,
+	0x20, 0x40, 0x49, 0xfd, 0x5c, 0xa3, 0xe0, 0x02, 0xda, 0x83, 0x7a, 0xb7, 0xdf, 0xd7, 0xd5, 0xf1,
+	0xd8, 0xec, 0x1d, 0x77, 0x47, 0x03, 0xb5, 0x2f, 0x17, 0x33, 0xfd, 0x62, 0x59, 0x29, 0x54, 0x4a,
+	0x72, 0xe9, 0xe1, 0xff, 0x72, 0x50, 0x5f, 0xba, 0x81, 0x50, 0x9c, 0x3a, 0xd4, 0x06, 0xda, 0xe1,
+	0x50, 0x95, 0x6f, 0xa0, 0x06, 0xc8, 0xa3, 0x53, 0xc3, 0x1c, 0x1b, 0xa7, 0x7a, 0x77, 0xa0, 0x9a,
+	0xa3, 0xd3, 0xbe, 0x2a, 0x4b, 0x08, 0xc1, 0xce, 0x91, 0xae, 0xaa, 0xe6, 0x61, 0x77, 0xd4, 0x7f,
+	0xae, 0xf5, 0x8d, 0x63, 0x39, 0x47, 0x3d, 0x64, 0xb2, 0xbe, 0x36, 0x3e, 0x91, 0xf3, 0xd4, 0xc3,
+	0xc9, 0x99, 0xa1, 0x3d, 0x53, 0x4d, 0xbd, 0x6b, 0x68, 0xa7, 0x72, 0x21, 0x25, 0xe9, 0x9d, 0x4e,
+	0x46, 0x86, 0x5c, 0x44, 0xb7, 0x61, 0xaf, 0x3b, 0xe9, 0x6b, 0x86, 0x39, 0x9e, 0xf4, 0x7a, 0xd4,
+	0x5b, 0x0e, 0x2d, 0xa1, 0x3a, 0x6c, 0x71, 0x05, 0x47, 0x96, 0x99, 0x53, 0x9f, 0xf7, 0x86, 0x13,
+	0x1a, 0x7d, 0x05, 0xdd, 0x84, 0xdd, 0xfe, 0xe4, 0x6c, 0xa8, 0xf5, 0xba, 0x86, 0x6a, 0x8a, 0x48,
+	0xe5, 0x2a, 0x9d, 0x75, 0x38, 0xec, 0xf6, 0x4e, 0x86, 0xda, 0x98, 0xf2, 0x00, 0x94, 0x07, 0xea,
+	0xfc, 0xf3, 0x63, 0xcd, 0x50, 0x85, 0x70, 0x8b, 0xfa, 0x4e, 0xa3, 0x30, 0x13, 0x3a, 0x6b, 0xd4,
+	0x20, 0x93, 0x65, 0x38, 0xdd, 0xa6, 0x1e, 0x3f, 0xd3, 0xc6, 0x63, 0x6d, 0x34, 0x30, 0x8d, 0xee,
+	0x60, 0x2c, 0xef, 0xd0, 0x55, 0xe2, 0xc0, 0x88, 0xc9, 0x3a, 0x05, 0x31, 0xd1, 0xe9, 0xd1, 0xd1,
+	0x50, 0x1b, 0xa9, 0xb2, 0x4c, 0x25, 0xc7, 0xda, 0xe0, 0xd8, 0x1c, 0x76, 0x0d, 0x75, 0xd4, 0xfb,
+	0x99, 0xbc, 0x4b, 0xa7, 0x45, 0xee, 0x73, 0x4b, 0xa8, 0xf3, 0x97, 0x02, 0xd4, 0x4e, 0x2c, 0x5b,
+	0x8b, 0xca, 0x10, 0xd2, 0x00, 0x92, 0x37, 0x16, 0x94, 0x6e, 0x66, 0x56, 0x9e, 0x5e, 0x5a, 0xf7,
+	0x36, 0x68, 0x45, 0x96, 0x6a, 0x00, 0x49, 0x53, 0x92, 0x31, 0xb5, 0xd2, 0xc0, 0xb4, 0xee, 0x6d,
+	0xd0, 0x0a, 0x53, 0x47, 0x50, 0x8d, 0xa5, 0xe8, 0xce, 0x3a, 0x6c, 0x64, 0xe8, 0xee, 0x7a, 0xa5,
+	0xb0, 0xd3, 0x83, 0x4a, 0xb4, 0x63, 0x51, 0xfa, 0xfe, 0xbb, 0x54, 0x13, 0x5a, 0x77, 0xd6, 0xea,
+	0x92, 0xb8, 0x92, 0x3d, 0x99, 0x89, 0x6b, 0x65, 0xa7, 0xb7, 0xee, 0x6d, 0xd0, 0x0a, 0x53, 0x5f,
+	0x80, 0xbc, 0xdc, 0x6b, 0x20, 0x25, 0x35, 0x65, 0x43, 0x8f, 0xd2, 0x7a, 0xff, 0x52, 0x8c, 0x30,
+	0xae, 0xc3, 0x76, 0xe6, 0x74, 0x42, 0xf7, 0x57, 0xcf, 0xee, 0xcc, 0x79, 0xd6, 0x3a, 0xd8, 0x0c,
+	0xe0, 0x36, 0x3b, 0x7f, 0xcc, 0x83, 0x7c, 0xfa, 0x1a, 0x07, 0xae, 0xf5, 0xf6, 0x9b, 0xca, 0x99,
+	0xe4, 0x41, 0x0a, 0xdd, 0x5d, 0xf7, 0xf0, 0xb4, 0xd6, 0xd4, 0x9a, 0x57, 0xac, 0x2f, 0x40, 0x5e,
+	0x7e, 0xa2, 0xc8, 0x70, 0xbb, 0xe1, 0xdd, 0xa9, 0xf5, 0xfe, 0xa5, 0x18, 0x61, 0x7c, 0x98, 0x7d,
+	0x5a, 0xb8, 0xb7, 0xe1, 0x42, 0x2a, 0x4c, 0xee, 0x6f, 0x52, 0x27, 0xd6, 0x52, 0x97, 0xc9, 0x8c,
+	0xb5, 0xd5, 0x6b, 0x6c, 0x6b, 0x7f, 0x93, 0x5a, 0xac, 0xd1, 0x1f, 0x24, 0xd8, 0xd3, 0xf1, 0xb9,
+	0xe5, 0x5a, 0xde, 0x14, 0x07, 0xc9, 0x32, 0x3d, 0x81, 0x22, 0xbb, 0x8c, 0xa0, 0x74, 0xdb, 0x91,
+	0xbe, 0x8a, 0xb6, 0x9a, 0xab, 0x8a, 0xc4, 0xc3, 0xd4, 0xad, 0x28, 0xe3, 0xe1, 0xea, 0x65, 0xb1,
+	0xb5, 0xbf, 0x49, 0x2d, 0x3c, 0xfc, 0xb3, 0x04, 0x75, 0x2a, 0xea, 0x1f, 0x26, 0xde, 0xf5, 0xa0,
+	0x12, 0xbd, 0xd0, 0x67, 0xb6, 0xe6, 0xd2, 0x9b, 0x7f, 0xeb, 0xce, 0x5a, 0x5d, 0xe2, 0x66, 0xea,
+	0x91, 0x39, 0xe3, 0xe6, 0xea, 0x0b, 0x7b, 0x6b, 0x7f, 0x93, 0x9a, 0x5b, 0x3b, 0x2c, 0xfc, 0x3c,
+	0x37, 0x3f, 0x3f, 0x2f, 0xb1, 0x9e, 0xe9, 0x7b, 0xff, 0x1f, 0x00, 0x40, 0x36, 0xc3, 0x04, 0xd5,
+	0x18, 0x00, 0x00,
 }
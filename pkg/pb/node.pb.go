@@ -7,6 +7,7 @@ import proto "github.com/gogo/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import _ "github.com/gogo/protobuf/gogoproto"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -49,7 +50,7 @@ func (x NodeType) String() string {
 	return proto.EnumName(NodeType_name, int32(x))
 }
 func (NodeType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_node_67af40c3abfaa4ce, []int{0}
+	return fileDescriptor_node_02dcd6339bbe13d1, []int{0}
 }
 
 // NodeTransport is an enum of possible transports for the overlay network
@@ -70,10 +71,10 @@ func (x NodeTransport) String() string {
 	return proto.EnumName(NodeTransport_name, int32(x))
 }
 func (NodeTransport) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_node_67af40c3abfaa4ce, []int{1}
+	return fileDescriptor_node_02dcd6339bbe13d1, []int{1}
 }
 
-//  NodeRestrictions contains all relevant data about a nodes ability to store data
+// NodeRestrictions contains all relevant data about a nodes ability to store data
 type NodeRestrictions struct {
 	FreeBandwidth        int64    `protobuf:"varint,1,opt,name=free_bandwidth,json=freeBandwidth,proto3" json:"free_bandwidth,omitempty"`
 	FreeDisk             int64    `protobuf:"varint,2,opt,name=free_disk,json=freeDisk,proto3" json:"free_disk,omitempty"`
@@ -86,7 +87,7 @@ func (m *NodeRestrictions) Reset()         { *m = NodeRestrictions{} }
 func (m *NodeRestrictions) String() string { return proto.CompactTextString(m) }
 func (*NodeRestrictions) ProtoMessage()    {}
 func (*NodeRestrictions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_67af40c3abfaa4ce, []int{0}
+	return fileDescriptor_node_02dcd6339bbe13d1, []int{0}
 }
 func (m *NodeRestrictions) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodeRestrictions.Unmarshal(m, b)
@@ -124,28 +125,32 @@ func (m *NodeRestrictions) GetFreeDisk() int64 {
 // Node represents a node in the overlay network
 // Node is info for a updating a single storagenode, used in the Update rpc calls
 type Node struct {
-	Id                   NodeID            `protobuf:"bytes,1,opt,name=id,proto3,customtype=NodeID" json:"id"`
-	Address              *NodeAddress      `protobuf:"bytes,2,opt,name=address" json:"address,omitempty"`
-	Type                 NodeType          `protobuf:"varint,3,opt,name=type,proto3,enum=node.NodeType" json:"type,omitempty"`
-	Restrictions         *NodeRestrictions `protobuf:"bytes,4,opt,name=restrictions" json:"restrictions,omitempty"`
-	Reputation           *NodeStats        `protobuf:"bytes,5,opt,name=reputation" json:"reputation,omitempty"`
-	Metadata             *NodeMetadata     `protobuf:"bytes,6,opt,name=metadata" json:"metadata,omitempty"`
-	LatencyList          []int64           `protobuf:"varint,7,rep,packed,name=latency_list,json=latencyList" json:"latency_list,omitempty"`
-	AuditSuccess         bool              `protobuf:"varint,8,opt,name=audit_success,json=auditSuccess,proto3" json:"audit_success,omitempty"`
-	IsUp                 bool              `protobuf:"varint,9,opt,name=is_up,json=isUp,proto3" json:"is_up,omitempty"`
-	UpdateLatency        bool              `protobuf:"varint,10,opt,name=update_latency,json=updateLatency,proto3" json:"update_latency,omitempty"`
-	UpdateAuditSuccess   bool              `protobuf:"varint,11,opt,name=update_audit_success,json=updateAuditSuccess,proto3" json:"update_audit_success,omitempty"`
-	UpdateUptime         bool              `protobuf:"varint,12,opt,name=update_uptime,json=updateUptime,proto3" json:"update_uptime,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Id                   NodeID               `protobuf:"bytes,1,opt,name=id,proto3,customtype=NodeID" json:"id"`
+	Address              *NodeAddress         `protobuf:"bytes,2,opt,name=address" json:"address,omitempty"`
+	Type                 NodeType             `protobuf:"varint,3,opt,name=type,proto3,enum=node.NodeType" json:"type,omitempty"`
+	Restrictions         *NodeRestrictions    `protobuf:"bytes,4,opt,name=restrictions" json:"restrictions,omitempty"`
+	Reputation           *NodeStats           `protobuf:"bytes,5,opt,name=reputation" json:"reputation,omitempty"`
+	Metadata             *NodeMetadata        `protobuf:"bytes,6,opt,name=metadata" json:"metadata,omitempty"`
+	LatencyList          []int64              `protobuf:"varint,7,rep,packed,name=latency_list,json=latencyList" json:"latency_list,omitempty"`
+	AuditSuccess         bool                 `protobuf:"varint,8,opt,name=audit_success,json=auditSuccess,proto3" json:"audit_success,omitempty"`
+	IsUp                 bool                 `protobuf:"varint,9,opt,name=is_up,json=isUp,proto3" json:"is_up,omitempty"`
+	UpdateLatency        bool                 `protobuf:"varint,10,opt,name=update_latency,json=updateLatency,proto3" json:"update_latency,omitempty"`
+	UpdateAuditSuccess   bool                 `protobuf:"varint,11,opt,name=update_audit_success,json=updateAuditSuccess,proto3" json:"update_audit_success,omitempty"`
+	UpdateUptime         bool                 `protobuf:"varint,12,opt,name=update_uptime,json=updateUptime,proto3" json:"update_uptime,omitempty"`
+	Tags                 *SignedNodeTags      `protobuf:"bytes,13,opt,name=tags" json:"tags,omitempty"`
+	LastContactSuccess   *timestamp.Timestamp `protobuf:"bytes,14,opt,name=last_contact_success,json=lastContactSuccess" json:"last_contact_success,omitempty"`
+	LastContactFailure   *timestamp.Timestamp `protobuf:"bytes,15,opt,name=last_contact_failure,json=lastContactFailure" json:"last_contact_failure,omitempty"`
+	Signature            *NodeSignature       `protobuf:"bytes,16,opt,name=signature" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *Node) Reset()         { *m = Node{} }
 func (m *Node) String() string { return proto.CompactTextString(m) }
 func (*Node) ProtoMessage()    {}
 func (*Node) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_67af40c3abfaa4ce, []int{1}
+	return fileDescriptor_node_02dcd6339bbe13d1, []int{1}
 }
 func (m *Node) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Node.Unmarshal(m, b)
@@ -242,20 +247,107 @@ func (m *Node) GetUpdateUptime() bool {
 	return false
 }
 
+func (m *Node) GetTags() *SignedNodeTags {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *Node) GetLastContactSuccess() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastContactSuccess
+	}
+	return nil
+}
+
+func (m *Node) GetLastContactFailure() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastContactFailure
+	}
+	return nil
+}
+
+func (m *Node) GetSignature() *NodeSignature {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// NodeSignature is the signature of a node's id, address and type by the
+// node itself, so that nobody else can announce records for it
+type NodeSignature struct {
+	SignedAt             int64    `protobuf:"varint,1,opt,name=signed_at,json=signedAt,proto3" json:"signed_at,omitempty"`
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	Chain                [][]byte `protobuf:"bytes,3,rep,name=chain" json:"chain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeSignature) Reset()         { *m = NodeSignature{} }
+func (m *NodeSignature) String() string { return proto.CompactTextString(m) }
+func (*NodeSignature) ProtoMessage()    {}
+func (*NodeSignature) Descriptor() ([]byte, []int) {
+	return fileDescriptor_node_02dcd6339bbe13d1, []int{2}
+}
+func (m *NodeSignature) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeSignature.Unmarshal(m, b)
+}
+func (m *NodeSignature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeSignature.Marshal(b, m, deterministic)
+}
+func (dst *NodeSignature) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeSignature.Merge(dst, src)
+}
+func (m *NodeSignature) XXX_Size() int {
+	return xxx_messageInfo_NodeSignature.Size(m)
+}
+func (m *NodeSignature) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeSignature.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeSignature proto.InternalMessageInfo
+
+func (m *NodeSignature) GetSignedAt() int64 {
+	if m != nil {
+		return m.SignedAt
+	}
+	return 0
+}
+
+func (m *NodeSignature) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *NodeSignature) GetChain() [][]byte {
+	if m != nil {
+		return m.Chain
+	}
+	return nil
+}
+
 // NodeAddress contains the information needed to communicate with a node on the network
 type NodeAddress struct {
-	Transport            NodeTransport `protobuf:"varint,1,opt,name=transport,proto3,enum=node.NodeTransport" json:"transport,omitempty"`
-	Address              string        `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	Transport NodeTransport `protobuf:"varint,1,opt,name=transport,proto3,enum=node.NodeTransport" json:"transport,omitempty"`
+	Address   string        `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// relay is the address of the relay forwarding connections to nodes
+	// which can't be dialed directly
+	Relay                string   `protobuf:"bytes,3,opt,name=relay,proto3" json:"relay,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *NodeAddress) Reset()         { *m = NodeAddress{} }
 func (m *NodeAddress) String() string { return proto.CompactTextString(m) }
 func (*NodeAddress) ProtoMessage()    {}
 func (*NodeAddress) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_67af40c3abfaa4ce, []int{2}
+	return fileDescriptor_node_02dcd6339bbe13d1, []int{3}
 }
 func (m *NodeAddress) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodeAddress.Unmarshal(m, b)
@@ -289,6 +381,13 @@ func (m *NodeAddress) GetAddress() string {
 	return ""
 }
 
+func (m *NodeAddress) GetRelay() string {
+	if m != nil {
+		return m.Relay
+	}
+	return ""
+}
+
 // NodeStats is the reputation characteristics of a node
 type NodeStats struct {
 	NodeId               NodeID   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
@@ -299,6 +398,8 @@ type NodeStats struct {
 	AuditSuccessCount    int64    `protobuf:"varint,6,opt,name=audit_success_count,json=auditSuccessCount,proto3" json:"audit_success_count,omitempty"`
 	UptimeCount          int64    `protobuf:"varint,7,opt,name=uptime_count,json=uptimeCount,proto3" json:"uptime_count,omitempty"`
 	UptimeSuccessCount   int64    `protobuf:"varint,8,opt,name=uptime_success_count,json=uptimeSuccessCount,proto3" json:"uptime_success_count,omitempty"`
+	AuditReputation      float64  `protobuf:"fixed64,9,opt,name=audit_reputation,json=auditReputation,proto3" json:"audit_reputation,omitempty"`
+	UptimeReputation     float64  `protobuf:"fixed64,10,opt,name=uptime_reputation,json=uptimeReputation,proto3" json:"uptime_reputation,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -308,7 +409,7 @@ func (m *NodeStats) Reset()         { *m = NodeStats{} }
 func (m *NodeStats) String() string { return proto.CompactTextString(m) }
 func (*NodeStats) ProtoMessage()    {}
 func (*NodeStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_67af40c3abfaa4ce, []int{3}
+	return fileDescriptor_node_02dcd6339bbe13d1, []int{4}
 }
 func (m *NodeStats) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodeStats.Unmarshal(m, b)
@@ -377,9 +478,239 @@ func (m *NodeStats) GetUptimeSuccessCount() int64 {
 	return 0
 }
 
+func (m *NodeStats) GetAuditReputation() float64 {
+	if m != nil {
+		return m.AuditReputation
+	}
+	return 0
+}
+
+func (m *NodeStats) GetUptimeReputation() float64 {
+	if m != nil {
+		return m.UptimeReputation
+	}
+	return 0
+}
+
+// NodeTag is an attribute a node publishes about itself, e.g. ssd=true
+type NodeTag struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeTag) Reset()         { *m = NodeTag{} }
+func (m *NodeTag) String() string { return proto.CompactTextString(m) }
+func (*NodeTag) ProtoMessage()    {}
+func (*NodeTag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_node_02dcd6339bbe13d1, []int{5}
+}
+func (m *NodeTag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeTag.Unmarshal(m, b)
+}
+func (m *NodeTag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeTag.Marshal(b, m, deterministic)
+}
+func (dst *NodeTag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeTag.Merge(dst, src)
+}
+func (m *NodeTag) XXX_Size() int {
+	return xxx_messageInfo_NodeTag.Size(m)
+}
+func (m *NodeTag) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeTag.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeTag proto.InternalMessageInfo
+
+func (m *NodeTag) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *NodeTag) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+// SignedNodeTags are the tags of a node signed by its identity, so that they
+// can't be changed while the node info is passed around the network
+type SignedNodeTags struct {
+	Tags                 []*NodeTag `protobuf:"bytes,1,rep,name=tags" json:"tags,omitempty"`
+	SignedAt             int64      `protobuf:"varint,2,opt,name=signed_at,json=signedAt,proto3" json:"signed_at,omitempty"`
+	Signature            []byte     `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Chain                [][]byte   `protobuf:"bytes,4,rep,name=chain" json:"chain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SignedNodeTags) Reset()         { *m = SignedNodeTags{} }
+func (m *SignedNodeTags) String() string { return proto.CompactTextString(m) }
+func (*SignedNodeTags) ProtoMessage()    {}
+func (*SignedNodeTags) Descriptor() ([]byte, []int) {
+	return fileDescriptor_node_02dcd6339bbe13d1, []int{6}
+}
+func (m *SignedNodeTags) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedNodeTags.Unmarshal(m, b)
+}
+func (m *SignedNodeTags) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedNodeTags.Marshal(b, m, deterministic)
+}
+func (dst *SignedNodeTags) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedNodeTags.Merge(dst, src)
+}
+func (m *SignedNodeTags) XXX_Size() int {
+	return xxx_messageInfo_SignedNodeTags.Size(m)
+}
+func (m *SignedNodeTags) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedNodeTags.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedNodeTags proto.InternalMessageInfo
+
+func (m *SignedNodeTags) GetTags() []*NodeTag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *SignedNodeTags) GetSignedAt() int64 {
+	if m != nil {
+		return m.SignedAt
+	}
+	return 0
+}
+
+func (m *SignedNodeTags) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *SignedNodeTags) GetChain() [][]byte {
+	if m != nil {
+		return m.Chain
+	}
+	return nil
+}
+
+// SignedNodeAddresses are the addresses of the nodes a satellite selected
+// or returned for a segment, signed by the satellite, so that uplinks only
+// dial addresses the satellite handed out
+type SignedNodeAddresses struct {
+	Entries              []*NodeAddressEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+	ExpiresAt            int64               `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Signature            []byte              `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Chain                [][]byte            `protobuf:"bytes,4,rep,name=chain" json:"chain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *SignedNodeAddresses) Reset()         { *m = SignedNodeAddresses{} }
+func (m *SignedNodeAddresses) String() string { return proto.CompactTextString(m) }
+func (*SignedNodeAddresses) ProtoMessage()    {}
+func (*SignedNodeAddresses) Descriptor() ([]byte, []int) {
+	return fileDescriptor_node_02dcd6339bbe13d1, []int{7}
+}
+func (m *SignedNodeAddresses) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedNodeAddresses.Unmarshal(m, b)
+}
+func (m *SignedNodeAddresses) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedNodeAddresses.Marshal(b, m, deterministic)
+}
+func (dst *SignedNodeAddresses) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedNodeAddresses.Merge(dst, src)
+}
+func (m *SignedNodeAddresses) XXX_Size() int {
+	return xxx_messageInfo_SignedNodeAddresses.Size(m)
+}
+func (m *SignedNodeAddresses) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedNodeAddresses.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedNodeAddresses proto.InternalMessageInfo
+
+func (m *SignedNodeAddresses) GetEntries() []*NodeAddressEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *SignedNodeAddresses) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *SignedNodeAddresses) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *SignedNodeAddresses) GetChain() [][]byte {
+	if m != nil {
+		return m.Chain
+	}
+	return nil
+}
+
+type NodeAddressEntry struct {
+	NodeId               NodeID       `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	Address              *NodeAddress `protobuf:"bytes,2,opt,name=address" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *NodeAddressEntry) Reset()         { *m = NodeAddressEntry{} }
+func (m *NodeAddressEntry) String() string { return proto.CompactTextString(m) }
+func (*NodeAddressEntry) ProtoMessage()    {}
+func (*NodeAddressEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_node_02dcd6339bbe13d1, []int{8}
+}
+func (m *NodeAddressEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeAddressEntry.Unmarshal(m, b)
+}
+func (m *NodeAddressEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeAddressEntry.Marshal(b, m, deterministic)
+}
+func (dst *NodeAddressEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeAddressEntry.Merge(dst, src)
+}
+func (m *NodeAddressEntry) XXX_Size() int {
+	return xxx_messageInfo_NodeAddressEntry.Size(m)
+}
+func (m *NodeAddressEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeAddressEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeAddressEntry proto.InternalMessageInfo
+
+func (m *NodeAddressEntry) GetAddress() *NodeAddress {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
 type NodeMetadata struct {
 	Email                string   `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
 	Wallet               string   `protobuf:"bytes,2,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	WalletFeatures       []string `protobuf:"bytes,3,rep,name=wallet_features,json=walletFeatures" json:"wallet_features,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -389,7 +720,7 @@ func (m *NodeMetadata) Reset()         { *m = NodeMetadata{} }
 func (m *NodeMetadata) String() string { return proto.CompactTextString(m) }
 func (*NodeMetadata) ProtoMessage()    {}
 func (*NodeMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_67af40c3abfaa4ce, []int{4}
+	return fileDescriptor_node_02dcd6339bbe13d1, []int{9}
 }
 func (m *NodeMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodeMetadata.Unmarshal(m, b)
@@ -423,59 +754,92 @@ func (m *NodeMetadata) GetWallet() string {
 	return ""
 }
 
+func (m *NodeMetadata) GetWalletFeatures() []string {
+	if m != nil {
+		return m.WalletFeatures
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*NodeRestrictions)(nil), "node.NodeRestrictions")
 	proto.RegisterType((*Node)(nil), "node.Node")
+	proto.RegisterType((*NodeSignature)(nil), "node.NodeSignature")
 	proto.RegisterType((*NodeAddress)(nil), "node.NodeAddress")
 	proto.RegisterType((*NodeStats)(nil), "node.NodeStats")
+	proto.RegisterType((*NodeTag)(nil), "node.NodeTag")
+	proto.RegisterType((*SignedNodeTags)(nil), "node.SignedNodeTags")
+	proto.RegisterType((*SignedNodeAddresses)(nil), "node.SignedNodeAddresses")
+	proto.RegisterType((*NodeAddressEntry)(nil), "node.NodeAddressEntry")
 	proto.RegisterType((*NodeMetadata)(nil), "node.NodeMetadata")
 	proto.RegisterEnum("node.NodeType", NodeType_name, NodeType_value)
 	proto.RegisterEnum("node.NodeTransport", NodeTransport_name, NodeTransport_value)
 }
 
-func init() { proto.RegisterFile("node.proto", fileDescriptor_node_67af40c3abfaa4ce) }
-
-var fileDescriptor_node_67af40c3abfaa4ce = []byte{
-	// 652 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x94, 0xc1, 0x4e, 0xdb, 0x40,
-	0x10, 0x86, 0x49, 0x6c, 0x9c, 0x78, 0xec, 0xa4, 0x66, 0x40, 0xc8, 0x6a, 0xd5, 0x12, 0x82, 0xaa,
-	0x46, 0x54, 0x4a, 0x29, 0x3d, 0x51, 0xf5, 0x92, 0x00, 0x42, 0x51, 0xdd, 0x10, 0x6d, 0x0c, 0x07,
-	0x2e, 0x96, 0x89, 0xb7, 0x74, 0x45, 0x88, 0x2d, 0x7b, 0x2d, 0xc4, 0x1b, 0xf6, 0xd0, 0x27, 0xe8,
-	0x81, 0x57, 0xe8, 0x2b, 0x54, 0xbb, 0xeb, 0x24, 0xb6, 0xaa, 0xde, 0xb2, 0xff, 0xff, 0x79, 0xc6,
-	0x3b, 0xff, 0xc4, 0x00, 0x8b, 0x38, 0xa2, 0xfd, 0x24, 0x8d, 0x79, 0x8c, 0xba, 0xf8, 0xfd, 0x12,
-	0xee, 0xe2, 0xbb, 0x58, 0x29, 0xdd, 0x6b, 0x70, 0xc6, 0x71, 0x44, 0x09, 0xcd, 0x78, 0xca, 0x66,
-	0x9c, 0xc5, 0x8b, 0x0c, 0xdf, 0x42, 0xfb, 0x7b, 0x4a, 0x69, 0x70, 0x1b, 0x2e, 0xa2, 0x47, 0x16,
-	0xf1, 0x1f, 0x6e, 0xad, 0x53, 0xeb, 0x69, 0xa4, 0x25, 0xd4, 0xe1, 0x52, 0xc4, 0x57, 0x60, 0x4a,
-	0x2c, 0x62, 0xd9, 0xbd, 0x5b, 0x97, 0x44, 0x53, 0x08, 0x67, 0x2c, 0xbb, 0xef, 0xfe, 0xd1, 0x40,
-	0x17, 0x85, 0xf1, 0x0d, 0xd4, 0x59, 0x24, 0x0b, 0xd8, 0xc3, 0xf6, 0xcf, 0xe7, 0xbd, 0x8d, 0xdf,
-	0xcf, 0x7b, 0x86, 0x70, 0x46, 0x67, 0xa4, 0xce, 0x22, 0x7c, 0x0f, 0x8d, 0x30, 0x8a, 0x52, 0x9a,
-	0x65, 0xb2, 0x86, 0x75, 0xbc, 0xd5, 0x97, 0x2f, 0x2c, 0x90, 0x81, 0x32, 0xc8, 0x92, 0xc0, 0x2e,
-	0xe8, 0xfc, 0x29, 0xa1, 0xae, 0xd6, 0xa9, 0xf5, 0xda, 0xc7, 0xed, 0x35, 0xe9, 0x3f, 0x25, 0x94,
-	0x48, 0x0f, 0x3f, 0x83, 0x9d, 0x96, 0x6e, 0xe3, 0xea, 0xb2, 0xea, 0xee, 0x9a, 0x2d, 0xdf, 0x95,
-	0x54, 0x58, 0xfc, 0x00, 0x90, 0xd2, 0x24, 0xe7, 0xa1, 0x38, 0xba, 0x9b, 0xf2, 0xc9, 0x17, 0xeb,
-	0x27, 0xa7, 0x3c, 0xe4, 0x19, 0x29, 0x21, 0xd8, 0x87, 0xe6, 0x03, 0xe5, 0x61, 0x14, 0xf2, 0xd0,
-	0x35, 0x24, 0x8e, 0x6b, 0xfc, 0x5b, 0xe1, 0x90, 0x15, 0x83, 0xfb, 0x60, 0xcf, 0x43, 0x4e, 0x17,
-	0xb3, 0xa7, 0x60, 0xce, 0x32, 0xee, 0x36, 0x3a, 0x5a, 0x4f, 0x23, 0x56, 0xa1, 0x79, 0x2c, 0xe3,
-	0x78, 0x00, 0xad, 0x30, 0x8f, 0x18, 0x0f, 0xb2, 0x7c, 0x36, 0x13, 0x63, 0x69, 0x76, 0x6a, 0xbd,
-	0x26, 0xb1, 0xa5, 0x38, 0x55, 0x1a, 0x6e, 0xc3, 0x26, 0xcb, 0x82, 0x3c, 0x71, 0x4d, 0x69, 0xea,
-	0x2c, 0xbb, 0x4a, 0x44, 0x6e, 0x79, 0x12, 0x85, 0x9c, 0x06, 0x45, 0x3d, 0x17, 0xa4, 0xdb, 0x52,
-	0xaa, 0xa7, 0x44, 0x3c, 0x82, 0x9d, 0x02, 0xab, 0xf6, 0xb1, 0x24, 0x8c, 0xca, 0x1b, 0x94, 0xbb,
-	0x1d, 0x40, 0x51, 0x22, 0xc8, 0x13, 0xce, 0x1e, 0xa8, 0x6b, 0xab, 0x57, 0x52, 0xe2, 0x95, 0xd4,
-	0xba, 0x37, 0x60, 0x95, 0x32, 0xc3, 0x8f, 0x60, 0xf2, 0x34, 0x5c, 0x64, 0x49, 0x9c, 0x72, 0x19,
-	0x7f, 0xfb, 0x78, 0xbb, 0x94, 0xd7, 0xd2, 0x22, 0x6b, 0x0a, 0xdd, 0xea, 0x2a, 0x98, 0xab, 0xdc,
-	0xbb, 0xbf, 0xea, 0x60, 0xae, 0x02, 0xc0, 0x77, 0xd0, 0x10, 0x85, 0x82, 0xff, 0xee, 0x95, 0x21,
-	0xec, 0x51, 0x84, 0xaf, 0x01, 0x96, 0xd3, 0x3e, 0x39, 0x2a, 0x56, 0xd4, 0x2c, 0x94, 0x93, 0x23,
-	0xec, 0xc3, 0x76, 0x65, 0x02, 0x41, 0x2a, 0x42, 0x95, 0xcb, 0x55, 0x23, 0x5b, 0xe5, 0x79, 0x13,
-	0x61, 0x88, 0xf0, 0xd4, 0xfd, 0x0b, 0x50, 0x97, 0xa0, 0xa5, 0x34, 0x85, 0xec, 0x81, 0xa5, 0x4a,
-	0xce, 0xe2, 0x7c, 0xc1, 0xe5, 0x06, 0x69, 0x04, 0xa4, 0x74, 0x2a, 0x94, 0x7f, 0x7b, 0x2a, 0xd0,
-	0x90, 0x60, 0xa5, 0xa7, 0xe2, 0xd7, 0x3d, 0x15, 0xd8, 0x90, 0x60, 0xd1, 0x53, 0x21, 0x32, 0x4f,
-	0x89, 0x54, 0x6b, 0x36, 0x25, 0x8a, 0xca, 0x2b, 0x17, 0xed, 0x7e, 0x01, 0xbb, 0xbc, 0x9f, 0xb8,
-	0x03, 0x9b, 0xf4, 0x21, 0x64, 0x73, 0x39, 0x4e, 0x93, 0xa8, 0x03, 0xee, 0x82, 0xf1, 0x18, 0xce,
-	0xe7, 0x94, 0x17, 0x69, 0x14, 0xa7, 0xc3, 0x31, 0x34, 0x97, 0x7f, 0x39, 0xb4, 0xa0, 0x31, 0x1a,
-	0x5f, 0x0f, 0xbc, 0xd1, 0x99, 0xb3, 0x81, 0x2d, 0x30, 0xa7, 0x03, 0xff, 0xdc, 0xf3, 0x46, 0xfe,
-	0xb9, 0x53, 0x13, 0xde, 0xd4, 0xbf, 0x24, 0x83, 0x8b, 0x73, 0xa7, 0x8e, 0x00, 0xc6, 0xd5, 0xc4,
-	0x1b, 0x8d, 0xbf, 0x3a, 0x9a, 0xe0, 0x86, 0x97, 0x97, 0xfe, 0xd4, 0x27, 0x83, 0x89, 0xa3, 0x1f,
-	0xee, 0x43, 0xab, 0xb2, 0x12, 0xe8, 0x80, 0xed, 0x9f, 0x4e, 0x02, 0xdf, 0x9b, 0x06, 0x17, 0x64,
-	0x72, 0xea, 0x6c, 0x0c, 0xf5, 0x9b, 0x7a, 0x72, 0x7b, 0x6b, 0xc8, 0x4f, 0xd6, 0xa7, 0xbf, 0x01,
-	0x00, 0x00, 0xff, 0xff, 0xc0, 0x8a, 0x68, 0xa5, 0xd2, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("node.proto", fileDescriptor_node_02dcd6339bbe13d1) }
+
+var fileDescriptor_node_02dcd6339bbe13d1 = []byte{
+	// 978 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xcb, 0x6e, 0xdb, 0x46,
+	0x14, 0xb5, 0x44, 0x5a, 0x12, 0xaf, 0x1e, 0xa6, 0xc7, 0x86, 0x41, 0xb8, 0x0f, 0xcb, 0x0a, 0x8a,
+	0xa8, 0x09, 0x20, 0xbb, 0xce, 0x2a, 0xdd, 0xc9, 0x8f, 0x04, 0x42, 0x55, 0xdb, 0x18, 0xd1, 0x59,
+	0x74, 0xc3, 0x8e, 0xc5, 0xb1, 0x3c, 0x08, 0x4d, 0x12, 0x9c, 0x61, 0x53, 0xed, 0xfb, 0x15, 0x5d,
+	0xf7, 0x63, 0xfa, 0x0d, 0x5d, 0x64, 0xdd, 0xcf, 0x28, 0xe6, 0x41, 0x93, 0x4c, 0x90, 0xa2, 0xe9,
+	0x8e, 0x73, 0xee, 0x99, 0xfb, 0xe0, 0x9c, 0x33, 0x03, 0x10, 0x27, 0x21, 0x9d, 0xa4, 0x59, 0x22,
+	0x12, 0x64, 0xcb, 0xef, 0x7d, 0x58, 0x25, 0xab, 0x44, 0x23, 0xfb, 0x07, 0xab, 0x24, 0x59, 0x45,
+	0xf4, 0x48, 0xad, 0x6e, 0xf3, 0xbb, 0x23, 0xc1, 0x1e, 0x28, 0x17, 0xe4, 0x21, 0xd5, 0x84, 0xd1,
+	0x1b, 0x70, 0x2f, 0x93, 0x90, 0x62, 0xca, 0x45, 0xc6, 0x96, 0x82, 0x25, 0x31, 0x47, 0xdf, 0xc0,
+	0xe0, 0x2e, 0xa3, 0x34, 0xb8, 0x25, 0x71, 0xf8, 0x8e, 0x85, 0xe2, 0xde, 0x6b, 0x0c, 0x1b, 0x63,
+	0x0b, 0xf7, 0x25, 0x7a, 0x5a, 0x80, 0xe8, 0x0b, 0x70, 0x14, 0x2d, 0x64, 0xfc, 0xad, 0xd7, 0x54,
+	0x8c, 0x8e, 0x04, 0xce, 0x19, 0x7f, 0x3b, 0xfa, 0x7b, 0x13, 0x6c, 0x99, 0x18, 0x7d, 0x0d, 0x4d,
+	0x16, 0xaa, 0x04, 0xbd, 0xd3, 0xc1, 0x9f, 0xef, 0x0f, 0x36, 0xfe, 0x7a, 0x7f, 0xd0, 0x92, 0x91,
+	0xd9, 0x39, 0x6e, 0xb2, 0x10, 0x3d, 0x87, 0x36, 0x09, 0xc3, 0x8c, 0x72, 0xae, 0x72, 0x74, 0x4f,
+	0xb6, 0x27, 0x6a, 0x22, 0x49, 0x99, 0xea, 0x00, 0x2e, 0x18, 0x68, 0x04, 0xb6, 0x58, 0xa7, 0xd4,
+	0xb3, 0x86, 0x8d, 0xf1, 0xe0, 0x64, 0x50, 0x32, 0xfd, 0x75, 0x4a, 0xb1, 0x8a, 0xa1, 0xef, 0xa1,
+	0x97, 0x55, 0xa6, 0xf1, 0x6c, 0x95, 0x75, 0xaf, 0xe4, 0x56, 0x67, 0xc5, 0x35, 0x2e, 0x3a, 0x02,
+	0xc8, 0x68, 0x9a, 0x0b, 0x22, 0x97, 0xde, 0xa6, 0xda, 0xb9, 0x55, 0xee, 0x5c, 0x08, 0x22, 0x38,
+	0xae, 0x50, 0xd0, 0x04, 0x3a, 0x0f, 0x54, 0x90, 0x90, 0x08, 0xe2, 0xb5, 0x14, 0x1d, 0x95, 0xf4,
+	0x1f, 0x4d, 0x04, 0x3f, 0x72, 0xd0, 0x21, 0xf4, 0x22, 0x22, 0x68, 0xbc, 0x5c, 0x07, 0x11, 0xe3,
+	0xc2, 0x6b, 0x0f, 0xad, 0xb1, 0x85, 0xbb, 0x06, 0x9b, 0x33, 0x2e, 0xd0, 0x13, 0xe8, 0x93, 0x3c,
+	0x64, 0x22, 0xe0, 0xf9, 0x72, 0x29, 0x7f, 0x4b, 0x67, 0xd8, 0x18, 0x77, 0x70, 0x4f, 0x81, 0x0b,
+	0x8d, 0xa1, 0x1d, 0xd8, 0x64, 0x3c, 0xc8, 0x53, 0xcf, 0x51, 0x41, 0x9b, 0xf1, 0x9b, 0x54, 0x9e,
+	0x5b, 0x9e, 0x86, 0x44, 0xd0, 0xc0, 0xe4, 0xf3, 0x40, 0x45, 0xfb, 0x1a, 0x9d, 0x6b, 0x10, 0x1d,
+	0xc3, 0xae, 0xa1, 0xd5, 0xeb, 0x74, 0x15, 0x19, 0xe9, 0xd8, 0xb4, 0x5a, 0xed, 0x09, 0x98, 0x14,
+	0x41, 0x9e, 0x4a, 0x01, 0x79, 0x3d, 0xdd, 0x92, 0x06, 0x6f, 0x14, 0x86, 0xc6, 0x60, 0x0b, 0xb2,
+	0xe2, 0x5e, 0x5f, 0xfd, 0x86, 0x5d, 0xfd, 0x1b, 0x16, 0x6c, 0x15, 0xd3, 0x50, 0x9d, 0x10, 0x59,
+	0x71, 0xac, 0x18, 0x68, 0x0e, 0xbb, 0x11, 0xe1, 0x22, 0x58, 0x26, 0xb1, 0x20, 0xcb, 0xb2, 0x81,
+	0x81, 0xda, 0xb9, 0x3f, 0xd1, 0x9a, 0x9d, 0x14, 0x9a, 0x9d, 0xf8, 0x85, 0x66, 0x31, 0x92, 0xfb,
+	0xce, 0xf4, 0xb6, 0xa2, 0xb9, 0x0f, 0xb3, 0xdd, 0x11, 0x16, 0xe5, 0x19, 0xf5, 0xb6, 0x3e, 0x2b,
+	0xdb, 0x2b, 0xbd, 0x0b, 0x7d, 0x07, 0x0e, 0x67, 0xab, 0x98, 0x08, 0x99, 0xc2, 0x55, 0x29, 0x76,
+	0x2a, 0x02, 0x28, 0x42, 0xb8, 0x64, 0x8d, 0x7e, 0x86, 0x7e, 0x2d, 0x26, 0x8d, 0xc1, 0xd5, 0xdc,
+	0x01, 0x11, 0xc6, 0x3a, 0x1d, 0x0d, 0x4c, 0x05, 0xfa, 0xb2, 0x5a, 0x40, 0x2a, 0xbe, 0x57, 0xc9,
+	0x85, 0x76, 0x61, 0x73, 0x79, 0x4f, 0x58, 0xec, 0x59, 0x43, 0x6b, 0xdc, 0xc3, 0x7a, 0x31, 0x4a,
+	0xa1, 0x5b, 0xb1, 0x83, 0xec, 0x51, 0x64, 0x24, 0xe6, 0x69, 0x92, 0xe9, 0xfc, 0x83, 0x6a, 0x8f,
+	0x7e, 0x11, 0xc2, 0x25, 0x0b, 0x79, 0x75, 0x97, 0x39, 0xa5, 0xa5, 0x76, 0x61, 0x33, 0xa3, 0x11,
+	0x59, 0x2b, 0x4f, 0x39, 0x58, 0x2f, 0x46, 0x7f, 0x58, 0xe0, 0x3c, 0x2a, 0x1e, 0x3d, 0x85, 0xb6,
+	0x4c, 0x1f, 0x7c, 0xd2, 0xc8, 0x2d, 0x19, 0x9e, 0x85, 0xe8, 0x2b, 0x80, 0x42, 0xde, 0x2f, 0x8f,
+	0xcd, 0x9d, 0xe0, 0x18, 0xe4, 0xe5, 0x31, 0x9a, 0xc0, 0x4e, 0x4d, 0x72, 0x41, 0x26, 0x5d, 0xa4,
+	0x2a, 0x37, 0xf0, 0x76, 0x55, 0xe0, 0x58, 0x06, 0xa4, 0x5b, 0xb4, 0xe0, 0x0c, 0xd1, 0x56, 0xc4,
+	0xae, 0xc6, 0x34, 0xe5, 0x00, 0xba, 0x3a, 0xe5, 0x32, 0xc9, 0x63, 0xa1, 0x2c, 0x6b, 0x61, 0x50,
+	0xd0, 0x99, 0x44, 0x3e, 0xae, 0xa9, 0x89, 0x2d, 0x45, 0xac, 0xd5, 0xd4, 0xfc, 0xb2, 0xa6, 0x26,
+	0xb6, 0x15, 0xd1, 0xd4, 0xd4, 0x14, 0x65, 0x20, 0x45, 0xa9, 0xe7, 0xec, 0x28, 0x2a, 0xd2, 0xb1,
+	0x5a, 0xd2, 0x6f, 0xc1, 0xd5, 0x4d, 0x54, 0x6e, 0x17, 0x47, 0x0d, 0xb3, 0xa5, 0x70, 0xfc, 0x08,
+	0xa3, 0xe7, 0xb0, 0x5d, 0xcc, 0x5c, 0x72, 0x41, 0x71, 0x5d, 0x33, 0xf8, 0x23, 0x3e, 0x7a, 0x01,
+	0x6d, 0xe3, 0x2d, 0x84, 0xc0, 0x8e, 0xc9, 0x03, 0x55, 0x07, 0xe4, 0x60, 0xf5, 0x2d, 0xcf, 0xf6,
+	0x17, 0x12, 0xe5, 0xd4, 0x9c, 0xb9, 0x5e, 0x8c, 0x7e, 0x6b, 0xc0, 0xa0, 0xee, 0x4b, 0x74, 0x68,
+	0xbc, 0xdb, 0x18, 0x5a, 0xe3, 0xee, 0x49, 0xbf, 0x22, 0x26, 0xb2, 0x32, 0xa6, 0xad, 0x89, 0xba,
+	0xf9, 0x6f, 0xa2, 0xb6, 0x3e, 0x29, 0x6a, 0xbb, 0x2a, 0xea, 0xdf, 0x1b, 0xb0, 0x53, 0xb6, 0x61,
+	0xb4, 0x4d, 0x39, 0x3a, 0x86, 0x36, 0x8d, 0x45, 0xc6, 0x68, 0xd1, 0xce, 0xde, 0x47, 0x0f, 0xc2,
+	0x45, 0x2c, 0xb2, 0x35, 0x2e, 0x68, 0x52, 0x75, 0xf4, 0xd7, 0x94, 0x65, 0x94, 0x97, 0xbd, 0x39,
+	0x06, 0xf9, 0x9f, 0xcd, 0xdd, 0x83, 0xfb, 0x61, 0xbd, 0xff, 0xee, 0x82, 0xcf, 0x79, 0xd2, 0x46,
+	0x14, 0x7a, 0xd5, 0xb7, 0x42, 0xf6, 0x43, 0x1f, 0x08, 0x8b, 0xcc, 0x41, 0xea, 0x05, 0xda, 0x83,
+	0xd6, 0x3b, 0x12, 0x45, 0x54, 0x98, 0xa3, 0x34, 0x2b, 0xf4, 0x14, 0xb6, 0xf4, 0x57, 0x70, 0x47,
+	0xd5, 0x3c, 0x5c, 0xdd, 0x1c, 0x0e, 0x1e, 0x68, 0xf8, 0x95, 0x41, 0x9f, 0x5d, 0x42, 0xa7, 0x78,
+	0x27, 0x51, 0x17, 0xda, 0xb3, 0xcb, 0x37, 0xd3, 0xf9, 0xec, 0xdc, 0xdd, 0x40, 0x7d, 0x70, 0x16,
+	0x53, 0xff, 0x62, 0x3e, 0x9f, 0xf9, 0x17, 0x6e, 0x43, 0xc6, 0x16, 0xfe, 0x15, 0x9e, 0xbe, 0xbe,
+	0x70, 0x9b, 0x08, 0xa0, 0x75, 0x73, 0x3d, 0x9f, 0x5d, 0xfe, 0xe0, 0x5a, 0x92, 0x77, 0x7a, 0x75,
+	0xe5, 0x2f, 0x7c, 0x3c, 0xbd, 0x76, 0xed, 0x67, 0x87, 0xd0, 0xaf, 0x5d, 0x36, 0xc8, 0x85, 0x9e,
+	0x7f, 0x76, 0x1d, 0xf8, 0xf3, 0x45, 0xf0, 0x1a, 0x5f, 0x9f, 0xb9, 0x1b, 0xa7, 0xf6, 0x4f, 0xcd,
+	0xf4, 0xf6, 0xb6, 0xa5, 0x2e, 0xde, 0x17, 0xff, 0x0c, 0x00, 0xa5, 0xf5, 0x20, 0xa1, 0xa8, 0x08,
+	0x00, 0x00,
 }
@@ -25,6 +25,33 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+// LookupStatus is the outcome of looking up a single node
+type LookupStatus int32
+
+const (
+	LookupStatus_FOUND     LookupStatus = 0
+	LookupStatus_NOT_FOUND LookupStatus = 1
+	LookupStatus_ERROR     LookupStatus = 2
+)
+
+var LookupStatus_name = map[int32]string{
+	0: "FOUND",
+	1: "NOT_FOUND",
+	2: "ERROR",
+}
+var LookupStatus_value = map[string]int32{
+	"FOUND":     0,
+	"NOT_FOUND": 1,
+	"ERROR":     2,
+}
+
+func (x LookupStatus) String() string {
+	return proto.EnumName(LookupStatus_name, int32(x))
+}
+func (LookupStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{0}
+}
+
 type Restriction_Operator int32
 
 const (
@@ -54,7 +81,7 @@ func (x Restriction_Operator) String() string {
 	return proto.EnumName(Restriction_Operator_name, int32(x))
 }
 func (Restriction_Operator) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{11, 0}
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{21, 0}
 }
 
 type Restriction_Operand int32
@@ -77,7 +104,7 @@ func (x Restriction_Operand) String() string {
 	return proto.EnumName(Restriction_Operand_name, int32(x))
 }
 func (Restriction_Operand) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{11, 1}
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{21, 1}
 }
 
 // LookupRequest is is request message for the lookup rpc call
@@ -92,7 +119,7 @@ func (m *LookupRequest) Reset()         { *m = LookupRequest{} }
 func (m *LookupRequest) String() string { return proto.CompactTextString(m) }
 func (*LookupRequest) ProtoMessage()    {}
 func (*LookupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{0}
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{0}
 }
 func (m *LookupRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LookupRequest.Unmarshal(m, b)
@@ -114,17 +141,20 @@ var xxx_messageInfo_LookupRequest proto.InternalMessageInfo
 
 // LookupResponse is is response message for the lookup rpc call
 type LookupResponse struct {
-	Node                 *Node    `protobuf:"bytes,1,opt,name=node" json:"node,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Node *Node `protobuf:"bytes,1,opt,name=node" json:"node,omitempty"`
+	// status and error are only set in BulkLookup responses
+	Status               LookupStatus `protobuf:"varint,2,opt,name=status,proto3,enum=overlay.LookupStatus" json:"status,omitempty"`
+	Error                string       `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *LookupResponse) Reset()         { *m = LookupResponse{} }
 func (m *LookupResponse) String() string { return proto.CompactTextString(m) }
 func (*LookupResponse) ProtoMessage()    {}
 func (*LookupResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{1}
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{1}
 }
 func (m *LookupResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LookupResponse.Unmarshal(m, b)
@@ -151,6 +181,20 @@ func (m *LookupResponse) GetNode() *Node {
 	return nil
 }
 
+func (m *LookupResponse) GetStatus() LookupStatus {
+	if m != nil {
+		return m.Status
+	}
+	return LookupStatus_FOUND
+}
+
+func (m *LookupResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 // LookupRequests is a list of LookupRequest
 type LookupRequests struct {
 	LookupRequest        []*LookupRequest `protobuf:"bytes,1,rep,name=lookup_request,json=lookupRequest" json:"lookup_request,omitempty"`
@@ -163,7 +207,7 @@ func (m *LookupRequests) Reset()         { *m = LookupRequests{} }
 func (m *LookupRequests) String() string { return proto.CompactTextString(m) }
 func (*LookupRequests) ProtoMessage()    {}
 func (*LookupRequests) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{2}
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{2}
 }
 func (m *LookupRequests) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LookupRequests.Unmarshal(m, b)
@@ -202,7 +246,7 @@ func (m *LookupResponses) Reset()         { *m = LookupResponses{} }
 func (m *LookupResponses) String() string { return proto.CompactTextString(m) }
 func (*LookupResponses) ProtoMessage()    {}
 func (*LookupResponses) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{3}
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{3}
 }
 func (m *LookupResponses) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LookupResponses.Unmarshal(m, b)
@@ -231,17 +275,18 @@ func (m *LookupResponses) GetLookupResponse() []*LookupResponse {
 
 // FindStorageNodesResponse is is response message for the FindStorageNodes rpc call
 type FindStorageNodesResponse struct {
-	Nodes                []*Node  `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Nodes                []*Node              `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
+	SignedAddresses      *SignedNodeAddresses `protobuf:"bytes,2,opt,name=signed_addresses,json=signedAddresses" json:"signed_addresses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *FindStorageNodesResponse) Reset()         { *m = FindStorageNodesResponse{} }
 func (m *FindStorageNodesResponse) String() string { return proto.CompactTextString(m) }
 func (*FindStorageNodesResponse) ProtoMessage()    {}
 func (*FindStorageNodesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{4}
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{4}
 }
 func (m *FindStorageNodesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FindStorageNodesResponse.Unmarshal(m, b)
@@ -268,6 +313,13 @@ func (m *FindStorageNodesResponse) GetNodes() []*Node {
 	return nil
 }
 
+func (m *FindStorageNodesResponse) GetSignedAddresses() *SignedNodeAddresses {
+	if m != nil {
+		return m.SignedAddresses
+	}
+	return nil
+}
+
 // FindStorageNodesRequest is is request message for the FindStorageNodes rpc call
 type FindStorageNodesRequest struct {
 	ObjectSize           int64              `protobuf:"varint,1,opt,name=object_size,json=objectSize,proto3" json:"object_size,omitempty"`
@@ -284,7 +336,7 @@ func (m *FindStorageNodesRequest) Reset()         { *m = FindStorageNodesRequest
 func (m *FindStorageNodesRequest) String() string { return proto.CompactTextString(m) }
 func (*FindStorageNodesRequest) ProtoMessage()    {}
 func (*FindStorageNodesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{5}
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{5}
 }
 func (m *FindStorageNodesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FindStorageNodesRequest.Unmarshal(m, b)
@@ -340,6 +392,10 @@ type OverlayOptions struct {
 	Amount               int64              `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
 	Restrictions         *NodeRestrictions  `protobuf:"bytes,5,opt,name=restrictions" json:"restrictions,omitempty"`
 	ExcludedNodes        []NodeID           `protobuf:"bytes,6,rep,name=excluded_nodes,json=excludedNodes,customtype=NodeID" json:"excluded_nodes,omitempty"`
+	Tags                 []*NodeTag         `protobuf:"bytes,7,rep,name=tags" json:"tags,omitempty"`
+	ExcludedTags         []*NodeTag         `protobuf:"bytes,8,rep,name=excluded_tags,json=excludedTags" json:"excluded_tags,omitempty"`
+	PreferLowLatency     bool               `protobuf:"varint,9,opt,name=prefer_low_latency,json=preferLowLatency,proto3" json:"prefer_low_latency,omitempty"`
+	RegionHint           string             `protobuf:"bytes,10,opt,name=region_hint,json=regionHint,proto3" json:"region_hint,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
 	XXX_unrecognized     []byte             `json:"-"`
 	XXX_sizecache        int32              `json:"-"`
@@ -349,7 +405,7 @@ func (m *OverlayOptions) Reset()         { *m = OverlayOptions{} }
 func (m *OverlayOptions) String() string { return proto.CompactTextString(m) }
 func (*OverlayOptions) ProtoMessage()    {}
 func (*OverlayOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{6}
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{6}
 }
 func (m *OverlayOptions) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_OverlayOptions.Unmarshal(m, b)
@@ -404,6 +460,222 @@ func (m *OverlayOptions) GetRestrictions() *NodeRestrictions {
 	return nil
 }
 
+func (m *OverlayOptions) GetTags() []*NodeTag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *OverlayOptions) GetExcludedTags() []*NodeTag {
+	if m != nil {
+		return m.ExcludedTags
+	}
+	return nil
+}
+
+func (m *OverlayOptions) GetPreferLowLatency() bool {
+	if m != nil {
+		return m.PreferLowLatency
+	}
+	return false
+}
+
+func (m *OverlayOptions) GetRegionHint() string {
+	if m != nil {
+		return m.RegionHint
+	}
+	return ""
+}
+
+// AnnounceExitRequest is the request message for the AnnounceExit rpc call
+type AnnounceExitRequest struct {
+	Reason               string   `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AnnounceExitRequest) Reset()         { *m = AnnounceExitRequest{} }
+func (m *AnnounceExitRequest) String() string { return proto.CompactTextString(m) }
+func (*AnnounceExitRequest) ProtoMessage()    {}
+func (*AnnounceExitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{7}
+}
+func (m *AnnounceExitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AnnounceExitRequest.Unmarshal(m, b)
+}
+func (m *AnnounceExitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AnnounceExitRequest.Marshal(b, m, deterministic)
+}
+func (dst *AnnounceExitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnnounceExitRequest.Merge(dst, src)
+}
+func (m *AnnounceExitRequest) XXX_Size() int {
+	return xxx_messageInfo_AnnounceExitRequest.Size(m)
+}
+func (m *AnnounceExitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnnounceExitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnnounceExitRequest proto.InternalMessageInfo
+
+func (m *AnnounceExitRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+// NodeLatency is the round trip of a transfer to a node
+type NodeLatency struct {
+	NodeId               NodeID             `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	Rtt                  *duration.Duration `protobuf:"bytes,2,opt,name=rtt" json:"rtt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *NodeLatency) Reset()         { *m = NodeLatency{} }
+func (m *NodeLatency) String() string { return proto.CompactTextString(m) }
+func (*NodeLatency) ProtoMessage()    {}
+func (*NodeLatency) Descriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{8}
+}
+func (m *NodeLatency) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeLatency.Unmarshal(m, b)
+}
+func (m *NodeLatency) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeLatency.Marshal(b, m, deterministic)
+}
+func (dst *NodeLatency) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeLatency.Merge(dst, src)
+}
+func (m *NodeLatency) XXX_Size() int {
+	return xxx_messageInfo_NodeLatency.Size(m)
+}
+func (m *NodeLatency) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeLatency.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeLatency proto.InternalMessageInfo
+
+func (m *NodeLatency) GetRtt() *duration.Duration {
+	if m != nil {
+		return m.Rtt
+	}
+	return nil
+}
+
+// LatencyReport is the request message for the ReportLatency rpc call
+type LatencyReport struct {
+	Region               string         `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`
+	Latencies            []*NodeLatency `protobuf:"bytes,2,rep,name=latencies" json:"latencies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *LatencyReport) Reset()         { *m = LatencyReport{} }
+func (m *LatencyReport) String() string { return proto.CompactTextString(m) }
+func (*LatencyReport) ProtoMessage()    {}
+func (*LatencyReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{9}
+}
+func (m *LatencyReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LatencyReport.Unmarshal(m, b)
+}
+func (m *LatencyReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LatencyReport.Marshal(b, m, deterministic)
+}
+func (dst *LatencyReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LatencyReport.Merge(dst, src)
+}
+func (m *LatencyReport) XXX_Size() int {
+	return xxx_messageInfo_LatencyReport.Size(m)
+}
+func (m *LatencyReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_LatencyReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LatencyReport proto.InternalMessageInfo
+
+func (m *LatencyReport) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *LatencyReport) GetLatencies() []*NodeLatency {
+	if m != nil {
+		return m.Latencies
+	}
+	return nil
+}
+
+// LatencyReportResponse is the response message for the ReportLatency rpc call
+type LatencyReportResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LatencyReportResponse) Reset()         { *m = LatencyReportResponse{} }
+func (m *LatencyReportResponse) String() string { return proto.CompactTextString(m) }
+func (*LatencyReportResponse) ProtoMessage()    {}
+func (*LatencyReportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{10}
+}
+func (m *LatencyReportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LatencyReportResponse.Unmarshal(m, b)
+}
+func (m *LatencyReportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LatencyReportResponse.Marshal(b, m, deterministic)
+}
+func (dst *LatencyReportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LatencyReportResponse.Merge(dst, src)
+}
+func (m *LatencyReportResponse) XXX_Size() int {
+	return xxx_messageInfo_LatencyReportResponse.Size(m)
+}
+func (m *LatencyReportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LatencyReportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LatencyReportResponse proto.InternalMessageInfo
+
+// AnnounceExitResponse is the response message for the AnnounceExit rpc call
+type AnnounceExitResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AnnounceExitResponse) Reset()         { *m = AnnounceExitResponse{} }
+func (m *AnnounceExitResponse) String() string { return proto.CompactTextString(m) }
+func (*AnnounceExitResponse) ProtoMessage()    {}
+func (*AnnounceExitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{11}
+}
+func (m *AnnounceExitResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AnnounceExitResponse.Unmarshal(m, b)
+}
+func (m *AnnounceExitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AnnounceExitResponse.Marshal(b, m, deterministic)
+}
+func (dst *AnnounceExitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnnounceExitResponse.Merge(dst, src)
+}
+func (m *AnnounceExitResponse) XXX_Size() int {
+	return xxx_messageInfo_AnnounceExitResponse.Size(m)
+}
+func (m *AnnounceExitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnnounceExitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnnounceExitResponse proto.InternalMessageInfo
+
 type QueryRequest struct {
 	Sender               *Node    `protobuf:"bytes,1,opt,name=sender" json:"sender,omitempty"`
 	Target               *Node    `protobuf:"bytes,2,opt,name=target" json:"target,omitempty"`
@@ -418,7 +690,7 @@ func (m *QueryRequest) Reset()         { *m = QueryRequest{} }
 func (m *QueryRequest) String() string { return proto.CompactTextString(m) }
 func (*QueryRequest) ProtoMessage()    {}
 func (*QueryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{7}
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{12}
 }
 func (m *QueryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryRequest.Unmarshal(m, b)
@@ -478,7 +750,7 @@ func (m *QueryResponse) Reset()         { *m = QueryResponse{} }
 func (m *QueryResponse) String() string { return proto.CompactTextString(m) }
 func (*QueryResponse) ProtoMessage()    {}
 func (*QueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{8}
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{13}
 }
 func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponse.Unmarshal(m, b)
@@ -513,6 +785,7 @@ func (m *QueryResponse) GetResponse() []*Node {
 }
 
 type PingRequest struct {
+	Nonce                []byte   `protobuf:"bytes,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -522,7 +795,7 @@ func (m *PingRequest) Reset()         { *m = PingRequest{} }
 func (m *PingRequest) String() string { return proto.CompactTextString(m) }
 func (*PingRequest) ProtoMessage()    {}
 func (*PingRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{9}
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{14}
 }
 func (m *PingRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PingRequest.Unmarshal(m, b)
@@ -542,7 +815,17 @@ func (m *PingRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PingRequest proto.InternalMessageInfo
 
+func (m *PingRequest) GetNonce() []byte {
+	if m != nil {
+		return m.Nonce
+	}
+	return nil
+}
+
 type PingResponse struct {
+	Nonce                []byte   `protobuf:"bytes,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	ServerTime           int64    `protobuf:"varint,2,opt,name=server_time,json=serverTime,proto3" json:"server_time,omitempty"`
+	ObservedAddress      string   `protobuf:"bytes,3,opt,name=observed_address,json=observedAddress,proto3" json:"observed_address,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -552,7 +835,7 @@ func (m *PingResponse) Reset()         { *m = PingResponse{} }
 func (m *PingResponse) String() string { return proto.CompactTextString(m) }
 func (*PingResponse) ProtoMessage()    {}
 func (*PingResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{10}
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{15}
 }
 func (m *PingResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PingResponse.Unmarshal(m, b)
@@ -572,6 +855,221 @@ func (m *PingResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PingResponse proto.InternalMessageInfo
 
+func (m *PingResponse) GetNonce() []byte {
+	if m != nil {
+		return m.Nonce
+	}
+	return nil
+}
+
+func (m *PingResponse) GetServerTime() int64 {
+	if m != nil {
+		return m.ServerTime
+	}
+	return 0
+}
+
+func (m *PingResponse) GetObservedAddress() string {
+	if m != nil {
+		return m.ObservedAddress
+	}
+	return ""
+}
+
+// Record is a small value stored redundantly by the nodes closest to its key
+type Record struct {
+	Key                  NodeID         `protobuf:"bytes,1,opt,name=key,proto3,customtype=NodeID" json:"key"`
+	Value                []byte         `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Publisher            NodeID         `protobuf:"bytes,3,opt,name=publisher,proto3,customtype=NodeID" json:"publisher"`
+	ExpiresAt            int64          `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Signature            *NodeSignature `protobuf:"bytes,5,opt,name=signature" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Record) Reset()         { *m = Record{} }
+func (m *Record) String() string { return proto.CompactTextString(m) }
+func (*Record) ProtoMessage()    {}
+func (*Record) Descriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{16}
+}
+func (m *Record) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Record.Unmarshal(m, b)
+}
+func (m *Record) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Record.Marshal(b, m, deterministic)
+}
+func (dst *Record) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Record.Merge(dst, src)
+}
+func (m *Record) XXX_Size() int {
+	return xxx_messageInfo_Record.Size(m)
+}
+func (m *Record) XXX_DiscardUnknown() {
+	xxx_messageInfo_Record.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Record proto.InternalMessageInfo
+
+func (m *Record) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *Record) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *Record) GetSignature() *NodeSignature {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type StoreRequest struct {
+	Record               *Record  `protobuf:"bytes,1,opt,name=record" json:"record,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StoreRequest) Reset()         { *m = StoreRequest{} }
+func (m *StoreRequest) String() string { return proto.CompactTextString(m) }
+func (*StoreRequest) ProtoMessage()    {}
+func (*StoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{17}
+}
+func (m *StoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StoreRequest.Unmarshal(m, b)
+}
+func (m *StoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StoreRequest.Marshal(b, m, deterministic)
+}
+func (dst *StoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreRequest.Merge(dst, src)
+}
+func (m *StoreRequest) XXX_Size() int {
+	return xxx_messageInfo_StoreRequest.Size(m)
+}
+func (m *StoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreRequest proto.InternalMessageInfo
+
+func (m *StoreRequest) GetRecord() *Record {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
+type StoreResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StoreResponse) Reset()         { *m = StoreResponse{} }
+func (m *StoreResponse) String() string { return proto.CompactTextString(m) }
+func (*StoreResponse) ProtoMessage()    {}
+func (*StoreResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{18}
+}
+func (m *StoreResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StoreResponse.Unmarshal(m, b)
+}
+func (m *StoreResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StoreResponse.Marshal(b, m, deterministic)
+}
+func (dst *StoreResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreResponse.Merge(dst, src)
+}
+func (m *StoreResponse) XXX_Size() int {
+	return xxx_messageInfo_StoreResponse.Size(m)
+}
+func (m *StoreResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreResponse proto.InternalMessageInfo
+
+type FindValueRequest struct {
+	Key                  NodeID   `protobuf:"bytes,1,opt,name=key,proto3,customtype=NodeID" json:"key"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FindValueRequest) Reset()         { *m = FindValueRequest{} }
+func (m *FindValueRequest) String() string { return proto.CompactTextString(m) }
+func (*FindValueRequest) ProtoMessage()    {}
+func (*FindValueRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{19}
+}
+func (m *FindValueRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindValueRequest.Unmarshal(m, b)
+}
+func (m *FindValueRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindValueRequest.Marshal(b, m, deterministic)
+}
+func (dst *FindValueRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindValueRequest.Merge(dst, src)
+}
+func (m *FindValueRequest) XXX_Size() int {
+	return xxx_messageInfo_FindValueRequest.Size(m)
+}
+func (m *FindValueRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindValueRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindValueRequest proto.InternalMessageInfo
+
+type FindValueResponse struct {
+	Record               *Record  `protobuf:"bytes,1,opt,name=record" json:"record,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FindValueResponse) Reset()         { *m = FindValueResponse{} }
+func (m *FindValueResponse) String() string { return proto.CompactTextString(m) }
+func (*FindValueResponse) ProtoMessage()    {}
+func (*FindValueResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{20}
+}
+func (m *FindValueResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindValueResponse.Unmarshal(m, b)
+}
+func (m *FindValueResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindValueResponse.Marshal(b, m, deterministic)
+}
+func (dst *FindValueResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindValueResponse.Merge(dst, src)
+}
+func (m *FindValueResponse) XXX_Size() int {
+	return xxx_messageInfo_FindValueResponse.Size(m)
+}
+func (m *FindValueResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindValueResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindValueResponse proto.InternalMessageInfo
+
+func (m *FindValueResponse) GetRecord() *Record {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
 type Restriction struct {
 	Operator             Restriction_Operator `protobuf:"varint,1,opt,name=operator,proto3,enum=overlay.Restriction_Operator" json:"operator,omitempty"`
 	Operand              Restriction_Operand  `protobuf:"varint,2,opt,name=operand,proto3,enum=overlay.Restriction_Operand" json:"operand,omitempty"`
@@ -585,7 +1083,7 @@ func (m *Restriction) Reset()         { *m = Restriction{} }
 func (m *Restriction) String() string { return proto.CompactTextString(m) }
 func (*Restriction) ProtoMessage()    {}
 func (*Restriction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{11}
+	return fileDescriptor_overlay_7c7577b547b7b6f9, []int{21}
 }
 func (m *Restriction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Restriction.Unmarshal(m, b)
@@ -634,11 +1132,22 @@ func init() {
 	proto.RegisterType((*FindStorageNodesResponse)(nil), "overlay.FindStorageNodesResponse")
 	proto.RegisterType((*FindStorageNodesRequest)(nil), "overlay.FindStorageNodesRequest")
 	proto.RegisterType((*OverlayOptions)(nil), "overlay.OverlayOptions")
+	proto.RegisterType((*AnnounceExitRequest)(nil), "overlay.AnnounceExitRequest")
+	proto.RegisterType((*NodeLatency)(nil), "overlay.NodeLatency")
+	proto.RegisterType((*LatencyReport)(nil), "overlay.LatencyReport")
+	proto.RegisterType((*LatencyReportResponse)(nil), "overlay.LatencyReportResponse")
+	proto.RegisterType((*AnnounceExitResponse)(nil), "overlay.AnnounceExitResponse")
 	proto.RegisterType((*QueryRequest)(nil), "overlay.QueryRequest")
 	proto.RegisterType((*QueryResponse)(nil), "overlay.QueryResponse")
 	proto.RegisterType((*PingRequest)(nil), "overlay.PingRequest")
 	proto.RegisterType((*PingResponse)(nil), "overlay.PingResponse")
+	proto.RegisterType((*Record)(nil), "overlay.Record")
+	proto.RegisterType((*StoreRequest)(nil), "overlay.StoreRequest")
+	proto.RegisterType((*StoreResponse)(nil), "overlay.StoreResponse")
+	proto.RegisterType((*FindValueRequest)(nil), "overlay.FindValueRequest")
+	proto.RegisterType((*FindValueResponse)(nil), "overlay.FindValueResponse")
 	proto.RegisterType((*Restriction)(nil), "overlay.Restriction")
+	proto.RegisterEnum("overlay.LookupStatus", LookupStatus_name, LookupStatus_value)
 	proto.RegisterEnum("overlay.Restriction_Operator", Restriction_Operator_name, Restriction_Operator_value)
 	proto.RegisterEnum("overlay.Restriction_Operand", Restriction_Operand_name, Restriction_Operand_value)
 }
@@ -661,6 +1170,10 @@ type OverlayClient interface {
 	BulkLookup(ctx context.Context, in *LookupRequests, opts ...grpc.CallOption) (*LookupResponses, error)
 	// FindStorageNodes finds a list of nodes in the network that meet the specified request parameters
 	FindStorageNodes(ctx context.Context, in *FindStorageNodesRequest, opts ...grpc.CallOption) (*FindStorageNodesResponse, error)
+	// AnnounceExit marks the calling storage node as draining, its pieces are migrated to other nodes before it leaves
+	AnnounceExit(ctx context.Context, in *AnnounceExitRequest, opts ...grpc.CallOption) (*AnnounceExitResponse, error)
+	// ReportLatency records the round trips of transfers an uplink observed to nodes from its region
+	ReportLatency(ctx context.Context, in *LatencyReport, opts ...grpc.CallOption) (*LatencyReportResponse, error)
 }
 
 type overlayClient struct {
@@ -698,6 +1211,24 @@ func (c *overlayClient) FindStorageNodes(ctx context.Context, in *FindStorageNod
 	return out, nil
 }
 
+func (c *overlayClient) AnnounceExit(ctx context.Context, in *AnnounceExitRequest, opts ...grpc.CallOption) (*AnnounceExitResponse, error) {
+	out := new(AnnounceExitResponse)
+	err := c.cc.Invoke(ctx, "/overlay.Overlay/AnnounceExit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *overlayClient) ReportLatency(ctx context.Context, in *LatencyReport, opts ...grpc.CallOption) (*LatencyReportResponse, error) {
+	out := new(LatencyReportResponse)
+	err := c.cc.Invoke(ctx, "/overlay.Overlay/ReportLatency", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OverlayServer is the server API for Overlay service.
 type OverlayServer interface {
 	// Lookup finds a nodes address from the network
@@ -706,6 +1237,10 @@ type OverlayServer interface {
 	BulkLookup(context.Context, *LookupRequests) (*LookupResponses, error)
 	// FindStorageNodes finds a list of nodes in the network that meet the specified request parameters
 	FindStorageNodes(context.Context, *FindStorageNodesRequest) (*FindStorageNodesResponse, error)
+	// AnnounceExit marks the calling storage node as draining, its pieces are migrated to other nodes before it leaves
+	AnnounceExit(context.Context, *AnnounceExitRequest) (*AnnounceExitResponse, error)
+	// ReportLatency records the round trips of transfers an uplink observed to nodes from its region
+	ReportLatency(context.Context, *LatencyReport) (*LatencyReportResponse, error)
 }
 
 func RegisterOverlayServer(s *grpc.Server, srv OverlayServer) {
@@ -766,6 +1301,42 @@ func _Overlay_FindStorageNodes_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Overlay_AnnounceExit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnnounceExitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OverlayServer).AnnounceExit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/overlay.Overlay/AnnounceExit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OverlayServer).AnnounceExit(ctx, req.(*AnnounceExitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Overlay_ReportLatency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LatencyReport)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OverlayServer).ReportLatency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/overlay.Overlay/ReportLatency",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OverlayServer).ReportLatency(ctx, req.(*LatencyReport))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Overlay_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "overlay.Overlay",
 	HandlerType: (*OverlayServer)(nil),
@@ -782,6 +1353,14 @@ var _Overlay_serviceDesc = grpc.ServiceDesc{
 			MethodName: "FindStorageNodes",
 			Handler:    _Overlay_FindStorageNodes_Handler,
 		},
+		{
+			MethodName: "AnnounceExit",
+			Handler:    _Overlay_AnnounceExit_Handler,
+		},
+		{
+			MethodName: "ReportLatency",
+			Handler:    _Overlay_ReportLatency_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "overlay.proto",
@@ -793,6 +1372,10 @@ var _Overlay_serviceDesc = grpc.ServiceDesc{
 type NodesClient interface {
 	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error)
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	// Store stores a small signed record on the node
+	Store(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*StoreResponse, error)
+	// FindValue returns the record the node stores for a key
+	FindValue(ctx context.Context, in *FindValueRequest, opts ...grpc.CallOption) (*FindValueResponse, error)
 }
 
 type nodesClient struct {
@@ -821,10 +1404,32 @@ func (c *nodesClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.Ca
 	return out, nil
 }
 
+func (c *nodesClient) Store(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*StoreResponse, error) {
+	out := new(StoreResponse)
+	err := c.cc.Invoke(ctx, "/overlay.Nodes/Store", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodesClient) FindValue(ctx context.Context, in *FindValueRequest, opts ...grpc.CallOption) (*FindValueResponse, error) {
+	out := new(FindValueResponse)
+	err := c.cc.Invoke(ctx, "/overlay.Nodes/FindValue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NodesServer is the server API for Nodes service.
 type NodesServer interface {
 	Query(context.Context, *QueryRequest) (*QueryResponse, error)
 	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	// Store stores a small signed record on the node
+	Store(context.Context, *StoreRequest) (*StoreResponse, error)
+	// FindValue returns the record the node stores for a key
+	FindValue(context.Context, *FindValueRequest) (*FindValueResponse, error)
 }
 
 func RegisterNodesServer(s *grpc.Server, srv NodesServer) {
@@ -867,6 +1472,42 @@ func _Nodes_Ping_Handler(srv interface{}, ctx context.Context, dec func(interfac
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Nodes_Store_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodesServer).Store(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/overlay.Nodes/Store",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodesServer).Store(ctx, req.(*StoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Nodes_FindValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodesServer).FindValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/overlay.Nodes/FindValue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodesServer).FindValue(ctx, req.(*FindValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Nodes_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "overlay.Nodes",
 	HandlerType: (*NodesServer)(nil),
@@ -879,66 +1520,106 @@ var _Nodes_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Ping",
 			Handler:    _Nodes_Ping_Handler,
 		},
+		{
+			MethodName: "Store",
+			Handler:    _Nodes_Store_Handler,
+		},
+		{
+			MethodName: "FindValue",
+			Handler:    _Nodes_FindValue_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "overlay.proto",
 }
 
-func init() { proto.RegisterFile("overlay.proto", fileDescriptor_overlay_99a51b72c26b0776) }
-
-var fileDescriptor_overlay_99a51b72c26b0776 = []byte{
-	// 845 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xdd, 0x8e, 0xdb, 0x44,
-	0x14, 0x5e, 0xe7, 0xc7, 0xc9, 0x9e, 0x24, 0x5e, 0x6b, 0xd4, 0xee, 0x06, 0x03, 0xdd, 0x60, 0x55,
-	0xb0, 0x12, 0x55, 0x0a, 0x29, 0xaa, 0x68, 0x05, 0x02, 0xa2, 0xa4, 0x65, 0xd5, 0xa8, 0x4b, 0x27,
-	0x91, 0x2a, 0xc1, 0x85, 0xe5, 0xc4, 0x83, 0x31, 0xeb, 0x78, 0x8c, 0x67, 0x5c, 0xed, 0xf6, 0x09,
-	0x78, 0x13, 0x5e, 0x85, 0x67, 0xe0, 0x62, 0x1f, 0x81, 0x07, 0xe0, 0x0a, 0xcd, 0x8f, 0x1d, 0x67,
-	0x77, 0x53, 0xf5, 0x6a, 0xe6, 0x9c, 0xf3, 0x7d, 0x67, 0xe6, 0x3b, 0x73, 0xe6, 0x40, 0x8f, 0xbe,
-	0x21, 0x59, 0xec, 0x5f, 0x0e, 0xd3, 0x8c, 0x72, 0x8a, 0x5a, 0xda, 0x74, 0xee, 0x85, 0x94, 0x86,
-	0x31, 0x79, 0x28, 0xdd, 0xcb, 0xfc, 0xd7, 0x87, 0x41, 0x9e, 0xf9, 0x3c, 0xa2, 0x89, 0x02, 0x3a,
-	0x10, 0xd2, 0x90, 0x16, 0xfb, 0x84, 0x06, 0x44, 0xed, 0xdd, 0xaf, 0xa1, 0x37, 0xa3, 0xf4, 0x3c,
-	0x4f, 0x31, 0xf9, 0x23, 0x27, 0x8c, 0xa3, 0xcf, 0xa0, 0x25, 0xc2, 0x5e, 0x14, 0xf4, 0x8d, 0x81,
-	0x71, 0xd2, 0x1d, 0x5b, 0x7f, 0x5f, 0x1d, 0xef, 0xfd, 0x73, 0x75, 0x6c, 0xbe, 0xa4, 0x01, 0x39,
-	0x9d, 0x60, 0x53, 0x84, 0x4f, 0x03, 0xf7, 0x0b, 0xb0, 0x0a, 0x26, 0x4b, 0x69, 0xc2, 0x08, 0xba,
-	0x07, 0x0d, 0x11, 0x93, 0xbc, 0xce, 0x08, 0x86, 0xf2, 0x18, 0xc1, 0xc2, 0xd2, 0xef, 0x9e, 0x6d,
-	0x18, 0xf2, 0x2c, 0x86, 0xbe, 0x05, 0x2b, 0x96, 0x1e, 0x2f, 0x53, 0xae, 0xbe, 0x31, 0xa8, 0x9f,
-	0x74, 0x46, 0x87, 0xc3, 0x42, 0xe6, 0x16, 0x01, 0xf7, 0xe2, 0xaa, 0xe9, 0xce, 0xe1, 0x60, 0xfb,
-	0x0a, 0x0c, 0x7d, 0x0f, 0x07, 0x65, 0x46, 0xe5, 0xd3, 0x29, 0x8f, 0x6e, 0xa4, 0x54, 0x61, 0x6c,
-	0xc5, 0x5b, 0xb6, 0xfb, 0x0d, 0xf4, 0x9f, 0x45, 0x49, 0x30, 0xe7, 0x34, 0xf3, 0x43, 0x22, 0xae,
-	0xcf, 0x4a, 0x85, 0x03, 0x68, 0x0a, 0x25, 0x4c, 0xe7, 0xac, 0x4a, 0x54, 0x01, 0xf7, 0x5f, 0x03,
-	0x8e, 0x6e, 0xd2, 0x55, 0x69, 0x8f, 0xa1, 0x43, 0x97, 0xbf, 0x93, 0x15, 0xf7, 0x58, 0xf4, 0x56,
-	0x95, 0xa9, 0x8e, 0x41, 0xb9, 0xe6, 0xd1, 0x5b, 0x82, 0xc6, 0x70, 0xb0, 0xa2, 0x09, 0xcf, 0xfc,
-	0x15, 0xf7, 0x62, 0x92, 0x84, 0xfc, 0xb7, 0x7e, 0x4d, 0xd6, 0xf2, 0x83, 0xa1, 0x7a, 0xde, 0x61,
-	0xf1, 0xbc, 0xc3, 0x89, 0x7e, 0x5e, 0x6c, 0x15, 0x8c, 0x99, 0x24, 0xa0, 0xcf, 0xa1, 0x41, 0x53,
-	0xce, 0xfa, 0x75, 0x49, 0xdc, 0xa8, 0x3e, 0x53, 0xeb, 0x59, 0x2a, 0x58, 0x0c, 0x4b, 0x10, 0xba,
-	0x0f, 0x4d, 0xc6, 0xfd, 0x8c, 0xf7, 0x1b, 0xb7, 0x3e, 0xb5, 0x0a, 0xa2, 0x0f, 0x61, 0x7f, 0xed,
-	0x5f, 0x78, 0x4a, 0x79, 0x53, 0xde, 0xba, 0xbd, 0xf6, 0x2f, 0xa4, 0x36, 0xf7, 0xaf, 0x1a, 0x58,
-	0xdb, 0xb9, 0xd1, 0x53, 0xe8, 0x08, 0x7c, 0xec, 0x73, 0x92, 0xac, 0x2e, 0x75, 0x3b, 0xbc, 0x43,
-	0x02, 0xac, 0xfd, 0x8b, 0x99, 0x02, 0xa3, 0x07, 0xb0, 0xbf, 0x8e, 0x12, 0x8f, 0x71, 0x9f, 0x33,
-	0x2d, 0xfe, 0x60, 0x53, 0xe5, 0xb9, 0x70, 0xe3, 0xf6, 0x3a, 0x4a, 0xe4, 0x0e, 0xdd, 0x07, 0x4b,
-	0xa2, 0x53, 0x42, 0x02, 0xef, 0x7c, 0x99, 0x2a, 0xd9, 0x75, 0xdc, 0x15, 0x08, 0xe1, 0x7c, 0xb1,
-	0x4c, 0x19, 0x3a, 0x04, 0xd3, 0x5f, 0xd3, 0x3c, 0x51, 0x32, 0xeb, 0x58, 0x5b, 0xe8, 0x29, 0x74,
-	0x33, 0xc2, 0x78, 0x16, 0xad, 0xe4, 0xbd, 0xa5, 0x34, 0xd1, 0x7b, 0x9b, 0x47, 0xad, 0x44, 0xf1,
-	0x16, 0x16, 0x7d, 0x09, 0x16, 0xb9, 0x58, 0xc5, 0x79, 0x40, 0x02, 0x5d, 0x18, 0x73, 0x50, 0x3f,
-	0xe9, 0x8e, 0xa1, 0x52, 0xbe, 0x5e, 0x81, 0x50, 0x95, 0xfa, 0xd3, 0x80, 0xee, 0xab, 0x9c, 0x64,
-	0x97, 0x45, 0x3f, 0xb8, 0x60, 0x32, 0x92, 0x04, 0x24, 0xbb, 0xe5, 0xc7, 0xe8, 0x88, 0xc0, 0x70,
-	0x3f, 0x0b, 0x09, 0xd7, 0xc5, 0xd8, 0xc2, 0xa8, 0x08, 0xba, 0x03, 0xcd, 0x38, 0x5a, 0x47, 0x5c,
-	0x8b, 0x57, 0x06, 0x72, 0xa0, 0x9d, 0x46, 0x49, 0xb8, 0xf4, 0x57, 0xe7, 0x52, 0x77, 0x1b, 0x97,
-	0xb6, 0xfb, 0x0b, 0xf4, 0xf4, 0x4d, 0x74, 0x63, 0xbf, 0xcf, 0x55, 0x3e, 0x85, 0x76, 0xf9, 0xa7,
-	0x6a, 0x37, 0xfa, 0xbf, 0x8c, 0xb9, 0x3d, 0xe8, 0xfc, 0x14, 0x25, 0x61, 0xf1, 0x49, 0x2d, 0xe8,
-	0x2a, 0x53, 0x87, 0xff, 0x33, 0xa0, 0x53, 0x29, 0x2c, 0x7a, 0x02, 0x6d, 0x9a, 0x92, 0xcc, 0xe7,
-	0x54, 0x1d, 0x6e, 0x8d, 0x3e, 0x2e, 0x9b, 0xb6, 0x82, 0x1b, 0x9e, 0x69, 0x10, 0x2e, 0xe1, 0xe8,
-	0x31, 0xb4, 0xe4, 0x3e, 0x09, 0x64, 0x75, 0xac, 0xd1, 0x47, 0xbb, 0x99, 0x49, 0x80, 0x0b, 0xb0,
-	0x28, 0xd8, 0x1b, 0x3f, 0xce, 0x49, 0x51, 0x30, 0x69, 0xb8, 0x5f, 0x41, 0xbb, 0x38, 0x03, 0x99,
-	0x50, 0x9b, 0x2d, 0xec, 0x3d, 0xb1, 0x4e, 0x5f, 0xd9, 0x86, 0x58, 0x9f, 0x2f, 0xec, 0x1a, 0x6a,
-	0x41, 0x7d, 0xb6, 0x98, 0xda, 0x75, 0xb1, 0x79, 0xbe, 0x98, 0xda, 0x0d, 0xf7, 0x01, 0xb4, 0x74,
-	0x7e, 0x84, 0xc0, 0x7a, 0x86, 0xa7, 0x53, 0x6f, 0xfc, 0xc3, 0xcb, 0xc9, 0xeb, 0xd3, 0xc9, 0xe2,
-	0x47, 0x7b, 0x0f, 0xf5, 0x60, 0x5f, 0xfa, 0x26, 0xa7, 0xf3, 0x17, 0xb6, 0x31, 0xba, 0x32, 0xa0,
-	0xa5, 0x7f, 0x0b, 0x7a, 0x02, 0xa6, 0x1a, 0x45, 0x68, 0xc7, 0xb8, 0x73, 0x76, 0xcd, 0x2c, 0xf4,
-	0x1d, 0xc0, 0x38, 0x8f, 0xcf, 0x35, 0xfd, 0xe8, 0x76, 0x3a, 0x73, 0xfa, 0x3b, 0xf8, 0x0c, 0xbd,
-	0x06, 0xfb, 0xfa, 0x94, 0x42, 0x83, 0x12, 0xbd, 0x63, 0x80, 0x39, 0x9f, 0xbc, 0x03, 0xa1, 0x32,
-	0x8f, 0x38, 0x34, 0x55, 0xb6, 0xc7, 0xd0, 0x94, 0x2d, 0x86, 0xee, 0x96, 0xa4, 0x6a, 0xf3, 0x3b,
-	0x87, 0xd7, 0xdd, 0x5a, 0xda, 0x23, 0x68, 0x88, 0x76, 0x41, 0x77, 0xca, 0x78, 0xa5, 0x99, 0x9c,
-	0xbb, 0xd7, 0xbc, 0x8a, 0x34, 0x6e, 0xfc, 0x5c, 0x4b, 0x97, 0x4b, 0x53, 0x8e, 0x96, 0x47, 0xff,
-	0x07, 0x00, 0x00, 0xff, 0xff, 0xae, 0x57, 0x27, 0x55, 0x24, 0x07, 0x00, 0x00,
+func init() { proto.RegisterFile("overlay.proto", fileDescriptor_overlay_7c7577b547b7b6f9) }
+
+var fileDescriptor_overlay_7c7577b547b7b6f9 = []byte{
+	// 1349 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0x4d, 0x73, 0x13, 0x47,
+	0x13, 0x66, 0xf5, 0xad, 0xd6, 0xe7, 0x3b, 0x18, 0x5b, 0xe8, 0x0d, 0x58, 0x6c, 0xa8, 0xc4, 0x09,
+	0x60, 0x0a, 0x41, 0x91, 0x40, 0x25, 0x95, 0x58, 0x25, 0x01, 0x2e, 0x5c, 0x56, 0x18, 0x2b, 0xa1,
+	0x2a, 0x39, 0x6c, 0xad, 0xb4, 0x83, 0xd8, 0x58, 0xda, 0xd9, 0xcc, 0xcc, 0x82, 0xcd, 0x31, 0xa7,
+	0xfc, 0xac, 0x1c, 0x73, 0xc9, 0x1f, 0xc8, 0x81, 0x9f, 0x90, 0xca, 0x21, 0xa7, 0x9c, 0x52, 0xf3,
+	0xb1, 0xab, 0x95, 0x2d, 0x05, 0x4e, 0xbb, 0xdd, 0xfd, 0x74, 0x4f, 0x77, 0x4f, 0xcf, 0x33, 0x03,
+	0x35, 0xfa, 0x8a, 0xb0, 0x99, 0x7b, 0xba, 0x1b, 0x32, 0x2a, 0x28, 0x2a, 0x1a, 0xb1, 0x7d, 0x75,
+	0x4a, 0xe9, 0x74, 0x46, 0x6e, 0x2b, 0xf5, 0x38, 0x7a, 0x71, 0xdb, 0x8b, 0x98, 0x2b, 0x7c, 0x1a,
+	0x68, 0x60, 0x1b, 0xa6, 0x74, 0x4a, 0xe3, 0xff, 0x80, 0x7a, 0x44, 0xff, 0xdb, 0x9f, 0x43, 0xed,
+	0x80, 0xd2, 0xe3, 0x28, 0xc4, 0xe4, 0xa7, 0x88, 0x70, 0x81, 0x3e, 0x86, 0xa2, 0x34, 0x3b, 0xbe,
+	0xd7, 0xb2, 0x3a, 0xd6, 0x4e, 0xb5, 0x57, 0xff, 0xed, 0xed, 0xf6, 0x85, 0x3f, 0xde, 0x6e, 0x17,
+	0x0e, 0xa9, 0x47, 0xf6, 0xfb, 0xb8, 0x20, 0xcd, 0xfb, 0x9e, 0x1d, 0x41, 0x3d, 0xf6, 0xe4, 0x21,
+	0x0d, 0x38, 0x41, 0x57, 0x21, 0x27, 0x6d, 0xca, 0xaf, 0xd2, 0x85, 0x5d, 0xb5, 0x8c, 0xf4, 0xc2,
+	0x4a, 0x8f, 0x6e, 0x41, 0x81, 0x0b, 0x57, 0x44, 0xbc, 0x95, 0xe9, 0x58, 0x3b, 0xf5, 0xee, 0xa5,
+	0xdd, 0xb8, 0x18, 0x1d, 0xe8, 0x48, 0x19, 0xb1, 0x01, 0xa1, 0x0d, 0xc8, 0x13, 0xc6, 0x28, 0x6b,
+	0x65, 0x3b, 0xd6, 0x4e, 0x19, 0x6b, 0xc1, 0x1e, 0x42, 0x7d, 0x29, 0x61, 0x8e, 0xbe, 0x84, 0xfa,
+	0x4c, 0x69, 0x1c, 0xa6, 0x55, 0x2d, 0xab, 0x93, 0xdd, 0xa9, 0x74, 0x37, 0xcf, 0x84, 0x37, 0x0e,
+	0xb8, 0x36, 0x4b, 0x8b, 0xf6, 0x11, 0x34, 0x96, 0xeb, 0xe0, 0xe8, 0x6b, 0x68, 0x24, 0x11, 0xb5,
+	0xce, 0x84, 0xdc, 0x3a, 0x17, 0x52, 0x9b, 0x71, 0x7d, 0xb6, 0x24, 0xdb, 0x3f, 0x5b, 0xd0, 0x7a,
+	0xe4, 0x07, 0xde, 0x91, 0xa0, 0xcc, 0x9d, 0x12, 0xd9, 0x04, 0x9e, 0xf4, 0xa9, 0x03, 0x79, 0xd9,
+	0x0f, 0x6e, 0x82, 0xa6, 0x1b, 0xa5, 0x0d, 0xa8, 0x0f, 0x4d, 0xee, 0x4f, 0x03, 0xe2, 0x39, 0xae,
+	0xe7, 0x31, 0xc2, 0x39, 0xd1, 0x3d, 0xab, 0x74, 0x2f, 0x6b, 0xf0, 0x91, 0xb2, 0x4a, 0x97, 0xbd,
+	0x18, 0x80, 0x1b, 0xda, 0x25, 0x51, 0xd8, 0x7f, 0x5a, 0xb0, 0x75, 0x3e, 0x09, 0xbd, 0xcd, 0xdb,
+	0x50, 0xa1, 0xe3, 0x1f, 0xc9, 0x44, 0x38, 0xdc, 0x7f, 0xa3, 0xb7, 0x2c, 0x8b, 0x41, 0xab, 0x8e,
+	0xfc, 0x37, 0x04, 0xf5, 0xa0, 0x31, 0xa1, 0x81, 0x60, 0xee, 0x44, 0x38, 0x33, 0x12, 0x4c, 0xc5,
+	0xcb, 0x24, 0x03, 0x3d, 0x6a, 0xbb, 0xf1, 0xa8, 0xed, 0xf6, 0xcd, 0xa8, 0xe1, 0x7a, 0xec, 0x71,
+	0xa0, 0x1c, 0xd0, 0x0d, 0xc8, 0xd1, 0x50, 0x70, 0xb5, 0x81, 0xe9, 0xe6, 0x0d, 0xf5, 0x77, 0x18,
+	0x4a, 0x2f, 0x8e, 0x15, 0x08, 0x5d, 0x87, 0x3c, 0x17, 0x2e, 0x13, 0xad, 0xdc, 0xca, 0xb1, 0xd3,
+	0x46, 0xf4, 0x7f, 0x28, 0xcf, 0xdd, 0x13, 0x47, 0xf7, 0x2f, 0xaf, 0xb2, 0x2e, 0xcd, 0xdd, 0x13,
+	0x55, 0x9b, 0xfd, 0x7b, 0x16, 0xea, 0xcb, 0xb1, 0xd1, 0x43, 0xa8, 0x48, 0xfc, 0xcc, 0x15, 0x24,
+	0x98, 0x9c, 0xb6, 0xac, 0x77, 0x95, 0x00, 0x73, 0xf7, 0xe4, 0x40, 0x83, 0xd1, 0x4d, 0x28, 0xcf,
+	0xfd, 0xc0, 0x91, 0xe3, 0x18, 0xb7, 0xbf, 0xb1, 0xd8, 0x2b, 0x39, 0xad, 0x1c, 0x97, 0xe6, 0x7e,
+	0xa0, 0xfe, 0xd0, 0x75, 0xa8, 0x2b, 0x74, 0x48, 0x88, 0xe7, 0x1c, 0x8f, 0x43, 0x5d, 0x76, 0x16,
+	0x57, 0x25, 0x42, 0x2a, 0x9f, 0x8e, 0x43, 0x8e, 0x36, 0xa1, 0xe0, 0xce, 0x69, 0x14, 0xe8, 0x32,
+	0xb3, 0xd8, 0x48, 0xe8, 0x21, 0x54, 0x19, 0xe1, 0x82, 0xf9, 0x13, 0x95, 0xb7, 0x2a, 0x4d, 0x8e,
+	0xf0, 0x62, 0x34, 0x52, 0x56, 0xbc, 0x84, 0x45, 0x77, 0xa0, 0x4e, 0x4e, 0x26, 0xb3, 0xc8, 0x23,
+	0x9e, 0x69, 0x4c, 0xa1, 0x93, 0xdd, 0xa9, 0xf6, 0x20, 0xd5, 0xbe, 0x5a, 0x8c, 0x38, 0x54, 0x03,
+	0x76, 0x0d, 0x72, 0xc2, 0x9d, 0xf2, 0x56, 0x51, 0x4d, 0x60, 0x6d, 0xb1, 0xcc, 0xc8, 0x9d, 0x62,
+	0x65, 0x42, 0x5d, 0x48, 0x7c, 0x1c, 0x85, 0x2d, 0xad, 0xc2, 0x56, 0x63, 0xcc, 0x48, 0xfa, 0xdc,
+	0x04, 0x14, 0x32, 0xf2, 0x82, 0x30, 0x67, 0x46, 0x5f, 0x27, 0x4d, 0x2f, 0x77, 0xac, 0x9d, 0x12,
+	0x6e, 0x6a, 0xcb, 0x01, 0x7d, 0x1d, 0xf7, 0x77, 0x1b, 0x2a, 0x8c, 0x4c, 0x7d, 0x1a, 0x38, 0x2f,
+	0xfd, 0x40, 0xb4, 0x40, 0x1d, 0x73, 0xd0, 0xaa, 0x27, 0x7e, 0x20, 0xec, 0x5b, 0x70, 0x71, 0x2f,
+	0x08, 0x68, 0x14, 0x4c, 0xc8, 0xe0, 0xc4, 0x17, 0xf1, 0xec, 0x6e, 0x42, 0x81, 0x11, 0x97, 0xd3,
+	0x40, 0x6d, 0x67, 0x19, 0x1b, 0xc9, 0x9e, 0x40, 0x45, 0xa6, 0x15, 0x87, 0x7f, 0x5f, 0x26, 0x43,
+	0x37, 0x20, 0xcb, 0x84, 0x78, 0xf7, 0x78, 0x4b, 0x94, 0xfd, 0x03, 0xd4, 0xcc, 0x02, 0x98, 0x84,
+	0x94, 0x99, 0x6c, 0x64, 0xca, 0x8b, 0x6c, 0xa4, 0x84, 0xba, 0x50, 0xd6, 0x0d, 0xf0, 0xd5, 0xe1,
+	0x95, 0xbd, 0xdb, 0x48, 0x4e, 0x40, 0x2a, 0x4f, 0xbc, 0x80, 0xd9, 0x5b, 0x70, 0x69, 0x29, 0x78,
+	0xc2, 0x27, 0x9b, 0xb0, 0xb1, 0xdc, 0x09, 0xa3, 0xff, 0xc5, 0x82, 0xea, 0xb3, 0x88, 0xb0, 0xd3,
+	0xb8, 0x37, 0x36, 0x14, 0x38, 0x09, 0x3c, 0xc2, 0x56, 0xb0, 0xb0, 0xb1, 0x48, 0x8c, 0x70, 0xd9,
+	0x94, 0xc4, 0x25, 0x2f, 0x61, 0xb4, 0x45, 0x92, 0xef, 0xcc, 0x9f, 0xfb, 0xc2, 0x0c, 0xb1, 0x16,
+	0x50, 0x1b, 0x4a, 0xa1, 0x1f, 0x4c, 0xc7, 0xee, 0xe4, 0x58, 0xcd, 0x6f, 0x09, 0x27, 0xb2, 0x6c,
+	0x8c, 0xc9, 0xc4, 0xd0, 0xdc, 0xfb, 0xa4, 0xf2, 0x11, 0x94, 0x12, 0x8a, 0xcd, 0x9c, 0x63, 0xc3,
+	0xc4, 0x66, 0x7f, 0x08, 0x95, 0x6f, 0xfc, 0x60, 0x1a, 0x57, 0xb9, 0x21, 0x19, 0x34, 0x98, 0x68,
+	0xde, 0xaa, 0x62, 0x2d, 0xd8, 0x21, 0x54, 0x35, 0xc8, 0x24, 0xb0, 0x12, 0x25, 0xa7, 0x8e, 0x13,
+	0xf6, 0x8a, 0x30, 0x47, 0xf8, 0x73, 0xa2, 0x5a, 0x90, 0xc5, 0xa0, 0x55, 0x23, 0x7f, 0x4e, 0xd0,
+	0x27, 0xd0, 0xa4, 0x63, 0x25, 0x27, 0xf4, 0x6b, 0xae, 0xa0, 0x46, 0xac, 0x37, 0x1c, 0x6b, 0xff,
+	0x6a, 0x41, 0x01, 0x93, 0x09, 0x65, 0x1e, 0xea, 0x40, 0xf6, 0x98, 0x9c, 0xae, 0x99, 0x34, 0x69,
+	0x92, 0xe9, 0xbc, 0x72, 0x67, 0x91, 0x5e, 0xb2, 0x8a, 0xb5, 0x20, 0x49, 0x26, 0x8c, 0xc6, 0x33,
+	0x9f, 0xbf, 0x24, 0xfa, 0xa6, 0x3b, 0xef, 0xbd, 0x00, 0xa0, 0x2b, 0x00, 0xe4, 0x24, 0xf4, 0x19,
+	0xe1, 0x8e, 0x1b, 0x53, 0x48, 0xd9, 0x68, 0xf6, 0x04, 0xba, 0x03, 0x65, 0x79, 0x09, 0xb8, 0x22,
+	0x62, 0xc4, 0x50, 0xc8, 0xc5, 0x14, 0x63, 0xc5, 0x26, 0xbc, 0x40, 0xd9, 0x9f, 0x41, 0x55, 0xde,
+	0x0f, 0x64, 0x71, 0xff, 0x17, 0x98, 0xaa, 0xc8, 0xec, 0x5a, 0x23, 0x99, 0x59, 0x5d, 0x28, 0x36,
+	0x66, 0xbb, 0x01, 0x35, 0xe3, 0x68, 0xf6, 0xe8, 0x1e, 0x34, 0xe5, 0x6d, 0xf3, 0x9d, 0x2c, 0x2b,
+	0x8e, 0xf6, 0xce, 0xae, 0xd8, 0x5f, 0xc0, 0xff, 0x52, 0x5e, 0x66, 0xe7, 0xde, 0x3b, 0x89, 0x7f,
+	0x2c, 0xa8, 0xa4, 0x98, 0x11, 0x3d, 0x80, 0x12, 0x0d, 0x09, 0x73, 0x05, 0xd5, 0x53, 0x57, 0xef,
+	0x5e, 0x49, 0xb9, 0x26, 0xb8, 0xdd, 0xa1, 0x01, 0xe1, 0x04, 0x8e, 0xee, 0x43, 0x51, 0xfd, 0x07,
+	0x9e, 0x79, 0x9e, 0x7c, 0xb0, 0xde, 0x33, 0xf0, 0x70, 0x0c, 0x5e, 0x6c, 0xab, 0x39, 0x29, 0x4a,
+	0xb0, 0xef, 0x41, 0x29, 0x5e, 0x03, 0x15, 0x20, 0x73, 0x30, 0x6a, 0x5e, 0x90, 0xdf, 0xc1, 0xb3,
+	0xa6, 0x25, 0xbf, 0x8f, 0x47, 0xcd, 0x0c, 0x2a, 0x42, 0xf6, 0x60, 0x34, 0x68, 0x66, 0xe5, 0xcf,
+	0xe3, 0xd1, 0xa0, 0x99, 0xb3, 0x6f, 0x42, 0xd1, 0xc4, 0x47, 0x08, 0xea, 0x8f, 0xf0, 0x60, 0xe0,
+	0xf4, 0xf6, 0x0e, 0xfb, 0xcf, 0xf7, 0xfb, 0xa3, 0x27, 0xcd, 0x0b, 0xa8, 0x06, 0x65, 0xa5, 0xeb,
+	0xef, 0x1f, 0x3d, 0x6d, 0x5a, 0x9f, 0xde, 0x85, 0x6a, 0xfa, 0xe1, 0x84, 0xca, 0x90, 0x7f, 0x34,
+	0xfc, 0xf6, 0xb0, 0xaf, 0x91, 0x87, 0xc3, 0x91, 0xa3, 0x45, 0x4b, 0x5a, 0x06, 0x18, 0x0f, 0x71,
+	0x33, 0xd3, 0xfd, 0x3b, 0x03, 0x45, 0x73, 0x47, 0xa2, 0x07, 0x50, 0xd0, 0x01, 0xd0, 0x9a, 0xb7,
+	0x52, 0x7b, 0xdd, 0x83, 0x07, 0x7d, 0x05, 0xd0, 0x8b, 0x66, 0xc7, 0xc6, 0x7d, 0x6b, 0xb5, 0x3b,
+	0x6f, 0xb7, 0xd6, 0xf8, 0x73, 0xf4, 0x5c, 0x4f, 0x4b, 0xfa, 0x6d, 0x82, 0x3a, 0x09, 0x7a, 0xcd,
+	0xb3, 0xa5, 0x7d, 0xed, 0x3f, 0x10, 0x26, 0xb3, 0xa7, 0x50, 0x4d, 0x53, 0x25, 0x5a, 0x6c, 0xe3,
+	0x8a, 0xbb, 0xa4, 0x7d, 0x65, 0x8d, 0xd5, 0x04, 0x7b, 0x0c, 0x35, 0xcd, 0xc4, 0xf1, 0xa5, 0x92,
+	0x6a, 0x54, 0x9a, 0xa8, 0xdb, 0x57, 0x57, 0xeb, 0xe3, 0x40, 0xdd, 0xbf, 0x2c, 0xc8, 0xeb, 0x22,
+	0xef, 0x43, 0x5e, 0xf1, 0x24, 0x5a, 0x3c, 0x7f, 0xd3, 0x0c, 0xde, 0xde, 0x3c, 0xab, 0x36, 0xa9,
+	0xdc, 0x85, 0x9c, 0x64, 0x37, 0xb4, 0xb8, 0x44, 0x52, 0x8c, 0xd8, 0xbe, 0x74, 0x46, 0x6b, 0x9c,
+	0xee, 0x43, 0x5e, 0x1d, 0xd2, 0xd4, 0x62, 0xe9, 0xd3, 0xde, 0xde, 0x3c, 0xab, 0x36, 0x7e, 0x3d,
+	0x28, 0x27, 0xa7, 0x12, 0x5d, 0x5e, 0x6a, 0x7a, 0xfa, 0x7c, 0xb7, 0xdb, 0xab, 0x4c, 0x3a, 0x46,
+	0x2f, 0xf7, 0x7d, 0x26, 0x1c, 0x8f, 0x0b, 0xea, 0x1e, 0xbd, 0xfb, 0xef, 0x00, 0xa5, 0x95, 0x2d,
+	0x5d, 0xb9, 0x0c, 0x00, 0x00,
 }
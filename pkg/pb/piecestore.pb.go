@@ -54,7 +54,33 @@ func (x PayerBandwidthAllocation_Action) String() string {
 	return proto.EnumName(PayerBandwidthAllocation_Action_name, int32(x))
 }
 func (PayerBandwidthAllocation_Action) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_664948018be76fe5, []int{0, 0}
+	return fileDescriptor_piecestore_603cc90da9283016, []int{0, 0}
+}
+
+type AuditReceipt_Outcome int32
+
+const (
+	AuditReceipt_SUCCESS AuditReceipt_Outcome = 0
+	AuditReceipt_FAILURE AuditReceipt_Outcome = 1
+	AuditReceipt_OFFLINE AuditReceipt_Outcome = 2
+)
+
+var AuditReceipt_Outcome_name = map[int32]string{
+	0: "SUCCESS",
+	1: "FAILURE",
+	2: "OFFLINE",
+}
+var AuditReceipt_Outcome_value = map[string]int32{
+	"SUCCESS": 0,
+	"FAILURE": 1,
+	"OFFLINE": 2,
+}
+
+func (x AuditReceipt_Outcome) String() string {
+	return proto.EnumName(AuditReceipt_Outcome_name, int32(x))
+}
+func (AuditReceipt_Outcome) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{30, 0}
 }
 
 type PayerBandwidthAllocation struct {
@@ -69,7 +95,7 @@ func (m *PayerBandwidthAllocation) Reset()         { *m = PayerBandwidthAllocati
 func (m *PayerBandwidthAllocation) String() string { return proto.CompactTextString(m) }
 func (*PayerBandwidthAllocation) ProtoMessage()    {}
 func (*PayerBandwidthAllocation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_664948018be76fe5, []int{0}
+	return fileDescriptor_piecestore_603cc90da9283016, []int{0}
 }
 func (m *PayerBandwidthAllocation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PayerBandwidthAllocation.Unmarshal(m, b)
@@ -121,7 +147,7 @@ func (m *PayerBandwidthAllocation_Data) Reset()         { *m = PayerBandwidthAll
 func (m *PayerBandwidthAllocation_Data) String() string { return proto.CompactTextString(m) }
 func (*PayerBandwidthAllocation_Data) ProtoMessage()    {}
 func (*PayerBandwidthAllocation_Data) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_664948018be76fe5, []int{0, 0}
+	return fileDescriptor_piecestore_603cc90da9283016, []int{0, 0}
 }
 func (m *PayerBandwidthAllocation_Data) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PayerBandwidthAllocation_Data.Unmarshal(m, b)
@@ -195,7 +221,7 @@ func (m *RenterBandwidthAllocation) Reset()         { *m = RenterBandwidthAlloca
 func (m *RenterBandwidthAllocation) String() string { return proto.CompactTextString(m) }
 func (*RenterBandwidthAllocation) ProtoMessage()    {}
 func (*RenterBandwidthAllocation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_664948018be76fe5, []int{1}
+	return fileDescriptor_piecestore_603cc90da9283016, []int{1}
 }
 func (m *RenterBandwidthAllocation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RenterBandwidthAllocation.Unmarshal(m, b)
@@ -242,7 +268,7 @@ func (m *RenterBandwidthAllocation_Data) Reset()         { *m = RenterBandwidthA
 func (m *RenterBandwidthAllocation_Data) String() string { return proto.CompactTextString(m) }
 func (*RenterBandwidthAllocation_Data) ProtoMessage()    {}
 func (*RenterBandwidthAllocation_Data) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_664948018be76fe5, []int{1, 0}
+	return fileDescriptor_piecestore_603cc90da9283016, []int{1, 0}
 }
 func (m *RenterBandwidthAllocation_Data) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RenterBandwidthAllocation_Data.Unmarshal(m, b)
@@ -289,7 +315,7 @@ func (m *PieceStore) Reset()         { *m = PieceStore{} }
 func (m *PieceStore) String() string { return proto.CompactTextString(m) }
 func (*PieceStore) ProtoMessage()    {}
 func (*PieceStore) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_664948018be76fe5, []int{2}
+	return fileDescriptor_piecestore_603cc90da9283016, []int{2}
 }
 func (m *PieceStore) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceStore.Unmarshal(m, b)
@@ -332,9 +358,16 @@ func (m *PieceStore) GetAuthorization() *SignedMessage {
 
 type PieceStore_PieceData struct {
 	// TODO: may want to use customtype and fixed-length byte slice
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ExpirationUnixSec    int64    `protobuf:"varint,2,opt,name=expiration_unix_sec,json=expirationUnixSec,proto3" json:"expiration_unix_sec,omitempty"`
-	Content              []byte   `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Id                string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpirationUnixSec int64  `protobuf:"varint,2,opt,name=expiration_unix_sec,json=expirationUnixSec,proto3" json:"expiration_unix_sec,omitempty"`
+	Content           []byte `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// piece_size is the size the uploader declares when opening the stream,
+	// pieces exceeding it are rejected, 0 if unknown
+	PieceSize int64 `protobuf:"varint,4,opt,name=piece_size,json=pieceSize,proto3" json:"piece_size,omitempty"`
+	// offset is where the content starts in the piece. When opening the
+	// stream it's where an interrupted upload is resumed, at most the bytes
+	// written according to PartialPiece. Content without offset is appended.
+	Offset               int64    `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -344,7 +377,7 @@ func (m *PieceStore_PieceData) Reset()         { *m = PieceStore_PieceData{} }
 func (m *PieceStore_PieceData) String() string { return proto.CompactTextString(m) }
 func (*PieceStore_PieceData) ProtoMessage()    {}
 func (*PieceStore_PieceData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_664948018be76fe5, []int{2, 0}
+	return fileDescriptor_piecestore_603cc90da9283016, []int{2, 0}
 }
 func (m *PieceStore_PieceData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceStore_PieceData.Unmarshal(m, b)
@@ -385,6 +418,20 @@ func (m *PieceStore_PieceData) GetContent() []byte {
 	return nil
 }
 
+func (m *PieceStore_PieceData) GetPieceSize() int64 {
+	if m != nil {
+		return m.PieceSize
+	}
+	return 0
+}
+
+func (m *PieceStore_PieceData) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
 type PieceId struct {
 	// TODO: may want to use customtype and fixed-length byte slice
 	Id                   string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -398,7 +445,7 @@ func (m *PieceId) Reset()         { *m = PieceId{} }
 func (m *PieceId) String() string { return proto.CompactTextString(m) }
 func (*PieceId) ProtoMessage()    {}
 func (*PieceId) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_664948018be76fe5, []int{3}
+	return fileDescriptor_piecestore_603cc90da9283016, []int{3}
 }
 func (m *PieceId) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceId.Unmarshal(m, b)
@@ -445,7 +492,7 @@ func (m *PieceSummary) Reset()         { *m = PieceSummary{} }
 func (m *PieceSummary) String() string { return proto.CompactTextString(m) }
 func (*PieceSummary) ProtoMessage()    {}
 func (*PieceSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_664948018be76fe5, []int{4}
+	return fileDescriptor_piecestore_603cc90da9283016, []int{4}
 }
 func (m *PieceSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceSummary.Unmarshal(m, b)
@@ -499,7 +546,7 @@ func (m *PieceRetrieval) Reset()         { *m = PieceRetrieval{} }
 func (m *PieceRetrieval) String() string { return proto.CompactTextString(m) }
 func (*PieceRetrieval) ProtoMessage()    {}
 func (*PieceRetrieval) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_664948018be76fe5, []int{5}
+	return fileDescriptor_piecestore_603cc90da9283016, []int{5}
 }
 func (m *PieceRetrieval) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceRetrieval.Unmarshal(m, b)
@@ -554,7 +601,7 @@ func (m *PieceRetrieval_PieceData) Reset()         { *m = PieceRetrieval_PieceDa
 func (m *PieceRetrieval_PieceData) String() string { return proto.CompactTextString(m) }
 func (*PieceRetrieval_PieceData) ProtoMessage()    {}
 func (*PieceRetrieval_PieceData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_664948018be76fe5, []int{5, 0}
+	return fileDescriptor_piecestore_603cc90da9283016, []int{5, 0}
 }
 func (m *PieceRetrieval_PieceData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceRetrieval_PieceData.Unmarshal(m, b)
@@ -607,7 +654,7 @@ func (m *PieceRetrievalStream) Reset()         { *m = PieceRetrievalStream{} }
 func (m *PieceRetrievalStream) String() string { return proto.CompactTextString(m) }
 func (*PieceRetrievalStream) ProtoMessage()    {}
 func (*PieceRetrievalStream) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_664948018be76fe5, []int{6}
+	return fileDescriptor_piecestore_603cc90da9283016, []int{6}
 }
 func (m *PieceRetrievalStream) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceRetrievalStream.Unmarshal(m, b)
@@ -654,7 +701,7 @@ func (m *PieceDelete) Reset()         { *m = PieceDelete{} }
 func (m *PieceDelete) String() string { return proto.CompactTextString(m) }
 func (*PieceDelete) ProtoMessage()    {}
 func (*PieceDelete) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_664948018be76fe5, []int{7}
+	return fileDescriptor_piecestore_603cc90da9283016, []int{7}
 }
 func (m *PieceDelete) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceDelete.Unmarshal(m, b)
@@ -699,7 +746,7 @@ func (m *PieceDeleteSummary) Reset()         { *m = PieceDeleteSummary{} }
 func (m *PieceDeleteSummary) String() string { return proto.CompactTextString(m) }
 func (*PieceDeleteSummary) ProtoMessage()    {}
 func (*PieceDeleteSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_664948018be76fe5, []int{8}
+	return fileDescriptor_piecestore_603cc90da9283016, []int{8}
 }
 func (m *PieceDeleteSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceDeleteSummary.Unmarshal(m, b)
@@ -738,7 +785,7 @@ func (m *PieceStoreSummary) Reset()         { *m = PieceStoreSummary{} }
 func (m *PieceStoreSummary) String() string { return proto.CompactTextString(m) }
 func (*PieceStoreSummary) ProtoMessage()    {}
 func (*PieceStoreSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_664948018be76fe5, []int{9}
+	return fileDescriptor_piecestore_603cc90da9283016, []int{9}
 }
 func (m *PieceStoreSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PieceStoreSummary.Unmarshal(m, b)
@@ -772,6 +819,60 @@ func (m *PieceStoreSummary) GetTotalReceived() int64 {
 	return 0
 }
 
+type PartialPieceSummary struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Written              int64    `protobuf:"varint,2,opt,name=written,proto3" json:"written,omitempty"`
+	ExpirationUnixSec    int64    `protobuf:"varint,3,opt,name=expiration_unix_sec,json=expirationUnixSec,proto3" json:"expiration_unix_sec,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartialPieceSummary) Reset()         { *m = PartialPieceSummary{} }
+func (m *PartialPieceSummary) String() string { return proto.CompactTextString(m) }
+func (*PartialPieceSummary) ProtoMessage()    {}
+func (*PartialPieceSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{10}
+}
+func (m *PartialPieceSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartialPieceSummary.Unmarshal(m, b)
+}
+func (m *PartialPieceSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartialPieceSummary.Marshal(b, m, deterministic)
+}
+func (dst *PartialPieceSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartialPieceSummary.Merge(dst, src)
+}
+func (m *PartialPieceSummary) XXX_Size() int {
+	return xxx_messageInfo_PartialPieceSummary.Size(m)
+}
+func (m *PartialPieceSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartialPieceSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartialPieceSummary proto.InternalMessageInfo
+
+func (m *PartialPieceSummary) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PartialPieceSummary) GetWritten() int64 {
+	if m != nil {
+		return m.Written
+	}
+	return 0
+}
+
+func (m *PartialPieceSummary) GetExpirationUnixSec() int64 {
+	if m != nil {
+		return m.ExpirationUnixSec
+	}
+	return 0
+}
+
 type StatsReq struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -782,7 +883,7 @@ func (m *StatsReq) Reset()         { *m = StatsReq{} }
 func (m *StatsReq) String() string { return proto.CompactTextString(m) }
 func (*StatsReq) ProtoMessage()    {}
 func (*StatsReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_664948018be76fe5, []int{10}
+	return fileDescriptor_piecestore_603cc90da9283016, []int{11}
 }
 func (m *StatsReq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatsReq.Unmarshal(m, b)
@@ -816,7 +917,7 @@ func (m *StatSummary) Reset()         { *m = StatSummary{} }
 func (m *StatSummary) String() string { return proto.CompactTextString(m) }
 func (*StatSummary) ProtoMessage()    {}
 func (*StatSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_664948018be76fe5, []int{11}
+	return fileDescriptor_piecestore_603cc90da9283016, []int{12}
 }
 func (m *StatSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatSummary.Unmarshal(m, b)
@@ -877,7 +978,7 @@ func (m *SignedMessage) Reset()         { *m = SignedMessage{} }
 func (m *SignedMessage) String() string { return proto.CompactTextString(m) }
 func (*SignedMessage) ProtoMessage()    {}
 func (*SignedMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_664948018be76fe5, []int{12}
+	return fileDescriptor_piecestore_603cc90da9283016, []int{13}
 }
 func (m *SignedMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedMessage.Unmarshal(m, b)
@@ -928,7 +1029,7 @@ func (m *DashboardReq) Reset()         { *m = DashboardReq{} }
 func (m *DashboardReq) String() string { return proto.CompactTextString(m) }
 func (*DashboardReq) ProtoMessage()    {}
 func (*DashboardReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_664948018be76fe5, []int{13}
+	return fileDescriptor_piecestore_603cc90da9283016, []int{14}
 }
 func (m *DashboardReq) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DashboardReq.Unmarshal(m, b)
@@ -955,6 +1056,9 @@ type DashboardStats struct {
 	Stats                *StatSummary       `protobuf:"bytes,4,opt,name=stats" json:"stats,omitempty"`
 	Connection           bool               `protobuf:"varint,5,opt,name=connection,proto3" json:"connection,omitempty"`
 	Uptime               *duration.Duration `protobuf:"bytes,6,opt,name=uptime" json:"uptime,omitempty"`
+	Scrub                *ScrubStats        `protobuf:"bytes,7,opt,name=scrub" json:"scrub,omitempty"`
+	Wallet               string             `protobuf:"bytes,8,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	WalletFeatures       []string           `protobuf:"bytes,9,rep,name=wallet_features,json=walletFeatures" json:"wallet_features,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
 	XXX_unrecognized     []byte             `json:"-"`
 	XXX_sizecache        int32              `json:"-"`
@@ -964,7 +1068,7 @@ func (m *DashboardStats) Reset()         { *m = DashboardStats{} }
 func (m *DashboardStats) String() string { return proto.CompactTextString(m) }
 func (*DashboardStats) ProtoMessage()    {}
 func (*DashboardStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_piecestore_664948018be76fe5, []int{14}
+	return fileDescriptor_piecestore_603cc90da9283016, []int{15}
 }
 func (m *DashboardStats) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DashboardStats.Unmarshal(m, b)
@@ -1026,196 +1130,1388 @@ func (m *DashboardStats) GetUptime() *duration.Duration {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*PayerBandwidthAllocation)(nil), "piecestoreroutes.PayerBandwidthAllocation")
-	proto.RegisterType((*PayerBandwidthAllocation_Data)(nil), "piecestoreroutes.PayerBandwidthAllocation.Data")
-	proto.RegisterType((*RenterBandwidthAllocation)(nil), "piecestoreroutes.RenterBandwidthAllocation")
-	proto.RegisterType((*RenterBandwidthAllocation_Data)(nil), "piecestoreroutes.RenterBandwidthAllocation.Data")
-	proto.RegisterType((*PieceStore)(nil), "piecestoreroutes.PieceStore")
-	proto.RegisterType((*PieceStore_PieceData)(nil), "piecestoreroutes.PieceStore.PieceData")
-	proto.RegisterType((*PieceId)(nil), "piecestoreroutes.PieceId")
-	proto.RegisterType((*PieceSummary)(nil), "piecestoreroutes.PieceSummary")
-	proto.RegisterType((*PieceRetrieval)(nil), "piecestoreroutes.PieceRetrieval")
-	proto.RegisterType((*PieceRetrieval_PieceData)(nil), "piecestoreroutes.PieceRetrieval.PieceData")
-	proto.RegisterType((*PieceRetrievalStream)(nil), "piecestoreroutes.PieceRetrievalStream")
-	proto.RegisterType((*PieceDelete)(nil), "piecestoreroutes.PieceDelete")
-	proto.RegisterType((*PieceDeleteSummary)(nil), "piecestoreroutes.PieceDeleteSummary")
-	proto.RegisterType((*PieceStoreSummary)(nil), "piecestoreroutes.PieceStoreSummary")
-	proto.RegisterType((*StatsReq)(nil), "piecestoreroutes.StatsReq")
-	proto.RegisterType((*StatSummary)(nil), "piecestoreroutes.StatSummary")
-	proto.RegisterType((*SignedMessage)(nil), "piecestoreroutes.SignedMessage")
-	proto.RegisterType((*DashboardReq)(nil), "piecestoreroutes.DashboardReq")
-	proto.RegisterType((*DashboardStats)(nil), "piecestoreroutes.DashboardStats")
-	proto.RegisterEnum("piecestoreroutes.PayerBandwidthAllocation_Action", PayerBandwidthAllocation_Action_name, PayerBandwidthAllocation_Action_value)
+func (m *DashboardStats) GetScrub() *ScrubStats {
+	if m != nil {
+		return m.Scrub
+	}
+	return nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *DashboardStats) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
 
-// PieceStoreRoutesClient is the client API for PieceStoreRoutes service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type PieceStoreRoutesClient interface {
-	Piece(ctx context.Context, in *PieceId, opts ...grpc.CallOption) (*PieceSummary, error)
-	Retrieve(ctx context.Context, opts ...grpc.CallOption) (PieceStoreRoutes_RetrieveClient, error)
-	Store(ctx context.Context, opts ...grpc.CallOption) (PieceStoreRoutes_StoreClient, error)
-	Delete(ctx context.Context, in *PieceDelete, opts ...grpc.CallOption) (*PieceDeleteSummary, error)
-	Stats(ctx context.Context, in *StatsReq, opts ...grpc.CallOption) (*StatSummary, error)
-	Dashboard(ctx context.Context, in *DashboardReq, opts ...grpc.CallOption) (PieceStoreRoutes_DashboardClient, error)
+func (m *DashboardStats) GetWalletFeatures() []string {
+	if m != nil {
+		return m.WalletFeatures
+	}
+	return nil
 }
 
-type pieceStoreRoutesClient struct {
-	cc *grpc.ClientConn
+// ScrubStats contains the progress of the piece integrity scrubber
+type ScrubStats struct {
+	PiecesChecked        int64    `protobuf:"varint,1,opt,name=pieces_checked,json=piecesChecked,proto3" json:"pieces_checked,omitempty"`
+	PiecesTotal          int64    `protobuf:"varint,2,opt,name=pieces_total,json=piecesTotal,proto3" json:"pieces_total,omitempty"`
+	BytesChecked         int64    `protobuf:"varint,3,opt,name=bytes_checked,json=bytesChecked,proto3" json:"bytes_checked,omitempty"`
+	Corrupted            int64    `protobuf:"varint,4,opt,name=corrupted,proto3" json:"corrupted,omitempty"`
+	Passes               int64    `protobuf:"varint,5,opt,name=passes,proto3" json:"passes,omitempty"`
+	LastPassUnixSec      int64    `protobuf:"varint,6,opt,name=last_pass_unix_sec,json=lastPassUnixSec,proto3" json:"last_pass_unix_sec,omitempty"`
+	Quarantined          int64    `protobuf:"varint,7,opt,name=quarantined,proto3" json:"quarantined,omitempty"`
+	Unreported           int64    `protobuf:"varint,8,opt,name=unreported,proto3" json:"unreported,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func NewPieceStoreRoutesClient(cc *grpc.ClientConn) PieceStoreRoutesClient {
-	return &pieceStoreRoutesClient{cc}
+func (m *ScrubStats) Reset()         { *m = ScrubStats{} }
+func (m *ScrubStats) String() string { return proto.CompactTextString(m) }
+func (*ScrubStats) ProtoMessage()    {}
+func (*ScrubStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{16}
+}
+func (m *ScrubStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScrubStats.Unmarshal(m, b)
+}
+func (m *ScrubStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScrubStats.Marshal(b, m, deterministic)
+}
+func (dst *ScrubStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScrubStats.Merge(dst, src)
+}
+func (m *ScrubStats) XXX_Size() int {
+	return xxx_messageInfo_ScrubStats.Size(m)
+}
+func (m *ScrubStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScrubStats.DiscardUnknown(m)
 }
 
-func (c *pieceStoreRoutesClient) Piece(ctx context.Context, in *PieceId, opts ...grpc.CallOption) (*PieceSummary, error) {
-	out := new(PieceSummary)
-	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/Piece", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ScrubStats proto.InternalMessageInfo
+
+func (m *ScrubStats) GetPiecesChecked() int64 {
+	if m != nil {
+		return m.PiecesChecked
 	}
-	return out, nil
+	return 0
 }
 
-func (c *pieceStoreRoutesClient) Retrieve(ctx context.Context, opts ...grpc.CallOption) (PieceStoreRoutes_RetrieveClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_PieceStoreRoutes_serviceDesc.Streams[0], "/piecestoreroutes.PieceStoreRoutes/Retrieve", opts...)
-	if err != nil {
-		return nil, err
+func (m *ScrubStats) GetPiecesTotal() int64 {
+	if m != nil {
+		return m.PiecesTotal
 	}
-	x := &pieceStoreRoutesRetrieveClient{stream}
-	return x, nil
+	return 0
 }
 
-type PieceStoreRoutes_RetrieveClient interface {
-	Send(*PieceRetrieval) error
-	Recv() (*PieceRetrievalStream, error)
-	grpc.ClientStream
+func (m *ScrubStats) GetBytesChecked() int64 {
+	if m != nil {
+		return m.BytesChecked
+	}
+	return 0
 }
 
-type pieceStoreRoutesRetrieveClient struct {
-	grpc.ClientStream
+func (m *ScrubStats) GetCorrupted() int64 {
+	if m != nil {
+		return m.Corrupted
+	}
+	return 0
 }
 
-func (x *pieceStoreRoutesRetrieveClient) Send(m *PieceRetrieval) error {
-	return x.ClientStream.SendMsg(m)
+func (m *ScrubStats) GetPasses() int64 {
+	if m != nil {
+		return m.Passes
+	}
+	return 0
 }
 
-func (x *pieceStoreRoutesRetrieveClient) Recv() (*PieceRetrievalStream, error) {
-	m := new(PieceRetrievalStream)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *ScrubStats) GetLastPassUnixSec() int64 {
+	if m != nil {
+		return m.LastPassUnixSec
 	}
-	return m, nil
+	return 0
 }
 
-func (c *pieceStoreRoutesClient) Store(ctx context.Context, opts ...grpc.CallOption) (PieceStoreRoutes_StoreClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_PieceStoreRoutes_serviceDesc.Streams[1], "/piecestoreroutes.PieceStoreRoutes/Store", opts...)
-	if err != nil {
-		return nil, err
+func (m *ScrubStats) GetQuarantined() int64 {
+	if m != nil {
+		return m.Quarantined
 	}
-	x := &pieceStoreRoutesStoreClient{stream}
-	return x, nil
+	return 0
 }
 
-type PieceStoreRoutes_StoreClient interface {
-	Send(*PieceStore) error
-	CloseAndRecv() (*PieceStoreSummary, error)
-	grpc.ClientStream
+func (m *ScrubStats) GetUnreported() int64 {
+	if m != nil {
+		return m.Unreported
+	}
+	return 0
 }
 
-type pieceStoreRoutesStoreClient struct {
-	grpc.ClientStream
+type UsageRequest struct {
+	// satellite to return the usage of, all satellites when empty
+	SatelliteId          NodeID   `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	FromUnixSec          int64    `protobuf:"varint,2,opt,name=from_unix_sec,json=fromUnixSec,proto3" json:"from_unix_sec,omitempty"`
+	ToUnixSec            int64    `protobuf:"varint,3,opt,name=to_unix_sec,json=toUnixSec,proto3" json:"to_unix_sec,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (x *pieceStoreRoutesStoreClient) Send(m *PieceStore) error {
-	return x.ClientStream.SendMsg(m)
+func (m *UsageRequest) Reset()         { *m = UsageRequest{} }
+func (m *UsageRequest) String() string { return proto.CompactTextString(m) }
+func (*UsageRequest) ProtoMessage()    {}
+func (*UsageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{17}
 }
-
-func (x *pieceStoreRoutesStoreClient) CloseAndRecv() (*PieceStoreSummary, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	m := new(PieceStoreSummary)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *UsageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UsageRequest.Unmarshal(m, b)
 }
-
-func (c *pieceStoreRoutesClient) Delete(ctx context.Context, in *PieceDelete, opts ...grpc.CallOption) (*PieceDeleteSummary, error) {
-	out := new(PieceDeleteSummary)
-	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/Delete", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *UsageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UsageRequest.Marshal(b, m, deterministic)
+}
+func (dst *UsageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UsageRequest.Merge(dst, src)
+}
+func (m *UsageRequest) XXX_Size() int {
+	return xxx_messageInfo_UsageRequest.Size(m)
+}
+func (m *UsageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UsageRequest.DiscardUnknown(m)
 }
 
-func (c *pieceStoreRoutesClient) Stats(ctx context.Context, in *StatsReq, opts ...grpc.CallOption) (*StatSummary, error) {
-	out := new(StatSummary)
-	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/Stats", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_UsageRequest proto.InternalMessageInfo
+
+func (m *UsageRequest) GetFromUnixSec() int64 {
+	if m != nil {
+		return m.FromUnixSec
 	}
-	return out, nil
+	return 0
 }
 
-func (c *pieceStoreRoutesClient) Dashboard(ctx context.Context, in *DashboardReq, opts ...grpc.CallOption) (PieceStoreRoutes_DashboardClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_PieceStoreRoutes_serviceDesc.Streams[2], "/piecestoreroutes.PieceStoreRoutes/Dashboard", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &pieceStoreRoutesDashboardClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *UsageRequest) GetToUnixSec() int64 {
+	if m != nil {
+		return m.ToUnixSec
 	}
-	return x, nil
+	return 0
 }
 
-type PieceStoreRoutes_DashboardClient interface {
-	Recv() (*DashboardStats, error)
-	grpc.ClientStream
+type UsageResponse struct {
+	Satellites           []*SatelliteUsage `protobuf:"bytes,1,rep,name=satellites" json:"satellites,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-type pieceStoreRoutesDashboardClient struct {
-	grpc.ClientStream
+func (m *UsageResponse) Reset()         { *m = UsageResponse{} }
+func (m *UsageResponse) String() string { return proto.CompactTextString(m) }
+func (*UsageResponse) ProtoMessage()    {}
+func (*UsageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{18}
+}
+func (m *UsageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UsageResponse.Unmarshal(m, b)
+}
+func (m *UsageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UsageResponse.Marshal(b, m, deterministic)
+}
+func (dst *UsageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UsageResponse.Merge(dst, src)
+}
+func (m *UsageResponse) XXX_Size() int {
+	return xxx_messageInfo_UsageResponse.Size(m)
+}
+func (m *UsageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UsageResponse.DiscardUnknown(m)
 }
 
-func (x *pieceStoreRoutesDashboardClient) Recv() (*DashboardStats, error) {
-	m := new(DashboardStats)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+var xxx_messageInfo_UsageResponse proto.InternalMessageInfo
+
+func (m *UsageResponse) GetSatellites() []*SatelliteUsage {
+	if m != nil {
+		return m.Satellites
 	}
-	return m, nil
+	return nil
 }
 
-// PieceStoreRoutesServer is the server API for PieceStoreRoutes service.
-type PieceStoreRoutesServer interface {
-	Piece(context.Context, *PieceId) (*PieceSummary, error)
-	Retrieve(PieceStoreRoutes_RetrieveServer) error
-	Store(PieceStoreRoutes_StoreServer) error
-	Delete(context.Context, *PieceDelete) (*PieceDeleteSummary, error)
-	Stats(context.Context, *StatsReq) (*StatSummary, error)
-	Dashboard(*DashboardReq, PieceStoreRoutes_DashboardServer) error
+// SatelliteUsage is the usage time series of a satellite
+type SatelliteUsage struct {
+	SatelliteId          NodeID        `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	Points               []*UsagePoint `protobuf:"bytes,2,rep,name=points" json:"points,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func RegisterPieceStoreRoutesServer(s *grpc.Server, srv PieceStoreRoutesServer) {
-	s.RegisterService(&_PieceStoreRoutes_serviceDesc, srv)
+func (m *SatelliteUsage) Reset()         { *m = SatelliteUsage{} }
+func (m *SatelliteUsage) String() string { return proto.CompactTextString(m) }
+func (*SatelliteUsage) ProtoMessage()    {}
+func (*SatelliteUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{19}
+}
+func (m *SatelliteUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SatelliteUsage.Unmarshal(m, b)
+}
+func (m *SatelliteUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SatelliteUsage.Marshal(b, m, deterministic)
+}
+func (dst *SatelliteUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SatelliteUsage.Merge(dst, src)
+}
+func (m *SatelliteUsage) XXX_Size() int {
+	return xxx_messageInfo_SatelliteUsage.Size(m)
+}
+func (m *SatelliteUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_SatelliteUsage.DiscardUnknown(m)
 }
 
-func _PieceStoreRoutes_Piece_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+var xxx_messageInfo_SatelliteUsage proto.InternalMessageInfo
+
+func (m *SatelliteUsage) GetPoints() []*UsagePoint {
+	if m != nil {
+		return m.Points
+	}
+	return nil
+}
+
+// UsagePoint is the usage of a satellite during an hour, in bytes
+type UsagePoint struct {
+	HourUnixSec          int64    `protobuf:"varint,1,opt,name=hour_unix_sec,json=hourUnixSec,proto3" json:"hour_unix_sec,omitempty"`
+	Ingress              int64    `protobuf:"varint,2,opt,name=ingress,proto3" json:"ingress,omitempty"`
+	IngressRepair        int64    `protobuf:"varint,3,opt,name=ingress_repair,json=ingressRepair,proto3" json:"ingress_repair,omitempty"`
+	Egress               int64    `protobuf:"varint,4,opt,name=egress,proto3" json:"egress,omitempty"`
+	EgressAudit          int64    `protobuf:"varint,5,opt,name=egress_audit,json=egressAudit,proto3" json:"egress_audit,omitempty"`
+	EgressRepair         int64    `protobuf:"varint,6,opt,name=egress_repair,json=egressRepair,proto3" json:"egress_repair,omitempty"`
+	DiskUsed             int64    `protobuf:"varint,7,opt,name=disk_used,json=diskUsed,proto3" json:"disk_used,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UsagePoint) Reset()         { *m = UsagePoint{} }
+func (m *UsagePoint) String() string { return proto.CompactTextString(m) }
+func (*UsagePoint) ProtoMessage()    {}
+func (*UsagePoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{20}
+}
+func (m *UsagePoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UsagePoint.Unmarshal(m, b)
+}
+func (m *UsagePoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UsagePoint.Marshal(b, m, deterministic)
+}
+func (dst *UsagePoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UsagePoint.Merge(dst, src)
+}
+func (m *UsagePoint) XXX_Size() int {
+	return xxx_messageInfo_UsagePoint.Size(m)
+}
+func (m *UsagePoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_UsagePoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UsagePoint proto.InternalMessageInfo
+
+func (m *UsagePoint) GetHourUnixSec() int64 {
+	if m != nil {
+		return m.HourUnixSec
+	}
+	return 0
+}
+
+func (m *UsagePoint) GetIngress() int64 {
+	if m != nil {
+		return m.Ingress
+	}
+	return 0
+}
+
+func (m *UsagePoint) GetIngressRepair() int64 {
+	if m != nil {
+		return m.IngressRepair
+	}
+	return 0
+}
+
+func (m *UsagePoint) GetEgress() int64 {
+	if m != nil {
+		return m.Egress
+	}
+	return 0
+}
+
+func (m *UsagePoint) GetEgressAudit() int64 {
+	if m != nil {
+		return m.EgressAudit
+	}
+	return 0
+}
+
+func (m *UsagePoint) GetEgressRepair() int64 {
+	if m != nil {
+		return m.EgressRepair
+	}
+	return 0
+}
+
+func (m *UsagePoint) GetDiskUsed() int64 {
+	if m != nil {
+		return m.DiskUsed
+	}
+	return 0
+}
+
+// RetainSummary is the outcome of processing a garbage collection retain
+// request of a satellite, signed by the storage node for later review
+type RetainSummary struct {
+	SatelliteId          NodeID   `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	FilterCreatedUnixSec int64    `protobuf:"varint,2,opt,name=filter_created_unix_sec,json=filterCreatedUnixSec,proto3" json:"filter_created_unix_sec,omitempty"`
+	ProcessedUnixSec     int64    `protobuf:"varint,3,opt,name=processed_unix_sec,json=processedUnixSec,proto3" json:"processed_unix_sec,omitempty"`
+	DryRun               bool     `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	PiecesExamined       int64    `protobuf:"varint,5,opt,name=pieces_examined,json=piecesExamined,proto3" json:"pieces_examined,omitempty"`
+	PiecesTrashed        int64    `protobuf:"varint,6,opt,name=pieces_trashed,json=piecesTrashed,proto3" json:"pieces_trashed,omitempty"`
+	BytesTrashed         int64    `protobuf:"varint,7,opt,name=bytes_trashed,json=bytesTrashed,proto3" json:"bytes_trashed,omitempty"`
+	Signature            []byte   `protobuf:"bytes,8,opt,name=signature,proto3" json:"signature,omitempty"`
+	Chain                [][]byte `protobuf:"bytes,9,rep,name=chain" json:"chain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RetainSummary) Reset()         { *m = RetainSummary{} }
+func (m *RetainSummary) String() string { return proto.CompactTextString(m) }
+func (*RetainSummary) ProtoMessage()    {}
+func (*RetainSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{21}
+}
+func (m *RetainSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetainSummary.Unmarshal(m, b)
+}
+func (m *RetainSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetainSummary.Marshal(b, m, deterministic)
+}
+func (dst *RetainSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetainSummary.Merge(dst, src)
+}
+func (m *RetainSummary) XXX_Size() int {
+	return xxx_messageInfo_RetainSummary.Size(m)
+}
+func (m *RetainSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetainSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetainSummary proto.InternalMessageInfo
+
+func (m *RetainSummary) GetFilterCreatedUnixSec() int64 {
+	if m != nil {
+		return m.FilterCreatedUnixSec
+	}
+	return 0
+}
+
+func (m *RetainSummary) GetProcessedUnixSec() int64 {
+	if m != nil {
+		return m.ProcessedUnixSec
+	}
+	return 0
+}
+
+func (m *RetainSummary) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *RetainSummary) GetPiecesExamined() int64 {
+	if m != nil {
+		return m.PiecesExamined
+	}
+	return 0
+}
+
+func (m *RetainSummary) GetPiecesTrashed() int64 {
+	if m != nil {
+		return m.PiecesTrashed
+	}
+	return 0
+}
+
+func (m *RetainSummary) GetBytesTrashed() int64 {
+	if m != nil {
+		return m.BytesTrashed
+	}
+	return 0
+}
+
+func (m *RetainSummary) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *RetainSummary) GetChain() [][]byte {
+	if m != nil {
+		return m.Chain
+	}
+	return nil
+}
+
+// RetainRequest is a garbage collection request of a satellite, pieces of the
+// satellite which aren't in the filter are trashed
+type RetainRequest struct {
+	Filter               *BloomFilter `protobuf:"bytes,1,opt,name=filter" json:"filter,omitempty"`
+	CreatedUnixSec       int64        `protobuf:"varint,2,opt,name=created_unix_sec,json=createdUnixSec,proto3" json:"created_unix_sec,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *RetainRequest) Reset()         { *m = RetainRequest{} }
+func (m *RetainRequest) String() string { return proto.CompactTextString(m) }
+func (*RetainRequest) ProtoMessage()    {}
+func (*RetainRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{22}
+}
+func (m *RetainRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetainRequest.Unmarshal(m, b)
+}
+func (m *RetainRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetainRequest.Marshal(b, m, deterministic)
+}
+func (dst *RetainRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetainRequest.Merge(dst, src)
+}
+func (m *RetainRequest) XXX_Size() int {
+	return xxx_messageInfo_RetainRequest.Size(m)
+}
+func (m *RetainRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetainRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetainRequest proto.InternalMessageInfo
+
+func (m *RetainRequest) GetFilter() *BloomFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *RetainRequest) GetCreatedUnixSec() int64 {
+	if m != nil {
+		return m.CreatedUnixSec
+	}
+	return 0
+}
+
+type RetainResponse struct {
+	Summary              *RetainSummary `protobuf:"bytes,1,opt,name=summary" json:"summary,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RetainResponse) Reset()         { *m = RetainResponse{} }
+func (m *RetainResponse) String() string { return proto.CompactTextString(m) }
+func (*RetainResponse) ProtoMessage()    {}
+func (*RetainResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{23}
+}
+func (m *RetainResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetainResponse.Unmarshal(m, b)
+}
+func (m *RetainResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetainResponse.Marshal(b, m, deterministic)
+}
+func (dst *RetainResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetainResponse.Merge(dst, src)
+}
+func (m *RetainResponse) XXX_Size() int {
+	return xxx_messageInfo_RetainResponse.Size(m)
+}
+func (m *RetainResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetainResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetainResponse proto.InternalMessageInfo
+
+func (m *RetainResponse) GetSummary() *RetainSummary {
+	if m != nil {
+		return m.Summary
+	}
+	return nil
+}
+
+type RestoreTrashRequest struct {
+	SatelliteId          NodeID   `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreTrashRequest) Reset()         { *m = RestoreTrashRequest{} }
+func (m *RestoreTrashRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreTrashRequest) ProtoMessage()    {}
+func (*RestoreTrashRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{24}
+}
+func (m *RestoreTrashRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTrashRequest.Unmarshal(m, b)
+}
+func (m *RestoreTrashRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTrashRequest.Marshal(b, m, deterministic)
+}
+func (dst *RestoreTrashRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTrashRequest.Merge(dst, src)
+}
+func (m *RestoreTrashRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreTrashRequest.Size(m)
+}
+func (m *RestoreTrashRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTrashRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTrashRequest proto.InternalMessageInfo
+
+type RestoreTrashResponse struct {
+	PiecesRestored       int64    `protobuf:"varint,1,opt,name=pieces_restored,json=piecesRestored,proto3" json:"pieces_restored,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreTrashResponse) Reset()         { *m = RestoreTrashResponse{} }
+func (m *RestoreTrashResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreTrashResponse) ProtoMessage()    {}
+func (*RestoreTrashResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{25}
+}
+func (m *RestoreTrashResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTrashResponse.Unmarshal(m, b)
+}
+func (m *RestoreTrashResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTrashResponse.Marshal(b, m, deterministic)
+}
+func (dst *RestoreTrashResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTrashResponse.Merge(dst, src)
+}
+func (m *RestoreTrashResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreTrashResponse.Size(m)
+}
+func (m *RestoreTrashResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTrashResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTrashResponse proto.InternalMessageInfo
+
+func (m *RestoreTrashResponse) GetPiecesRestored() int64 {
+	if m != nil {
+		return m.PiecesRestored
+	}
+	return 0
+}
+
+type RetainLogRequest struct {
+	// satellite to return the summaries of, all satellites when empty
+	SatelliteId          NodeID   `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	Limit                int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RetainLogRequest) Reset()         { *m = RetainLogRequest{} }
+func (m *RetainLogRequest) String() string { return proto.CompactTextString(m) }
+func (*RetainLogRequest) ProtoMessage()    {}
+func (*RetainLogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{26}
+}
+func (m *RetainLogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetainLogRequest.Unmarshal(m, b)
+}
+func (m *RetainLogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetainLogRequest.Marshal(b, m, deterministic)
+}
+func (dst *RetainLogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetainLogRequest.Merge(dst, src)
+}
+func (m *RetainLogRequest) XXX_Size() int {
+	return xxx_messageInfo_RetainLogRequest.Size(m)
+}
+func (m *RetainLogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetainLogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetainLogRequest proto.InternalMessageInfo
+
+func (m *RetainLogRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type RetainLogResponse struct {
+	Summaries            []*RetainSummary `protobuf:"bytes,1,rep,name=summaries" json:"summaries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *RetainLogResponse) Reset()         { *m = RetainLogResponse{} }
+func (m *RetainLogResponse) String() string { return proto.CompactTextString(m) }
+func (*RetainLogResponse) ProtoMessage()    {}
+func (*RetainLogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{27}
+}
+func (m *RetainLogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetainLogResponse.Unmarshal(m, b)
+}
+func (m *RetainLogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetainLogResponse.Marshal(b, m, deterministic)
+}
+func (dst *RetainLogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetainLogResponse.Merge(dst, src)
+}
+func (m *RetainLogResponse) XXX_Size() int {
+	return xxx_messageInfo_RetainLogResponse.Size(m)
+}
+func (m *RetainLogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetainLogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetainLogResponse proto.InternalMessageInfo
+
+func (m *RetainLogResponse) GetSummaries() []*RetainSummary {
+	if m != nil {
+		return m.Summaries
+	}
+	return nil
+}
+
+// BloomFilter is a serialized bloom filter, e.g. of the piece ids a
+// satellite retains
+type BloomFilter struct {
+	Seed                 uint32   `protobuf:"varint,1,opt,name=seed,proto3" json:"seed,omitempty"`
+	HashCount            uint32   `protobuf:"varint,2,opt,name=hash_count,json=hashCount,proto3" json:"hash_count,omitempty"`
+	Table                []byte   `protobuf:"bytes,3,opt,name=table,proto3" json:"table,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BloomFilter) Reset()         { *m = BloomFilter{} }
+func (m *BloomFilter) String() string { return proto.CompactTextString(m) }
+func (*BloomFilter) ProtoMessage()    {}
+func (*BloomFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{28}
+}
+func (m *BloomFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BloomFilter.Unmarshal(m, b)
+}
+func (m *BloomFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BloomFilter.Marshal(b, m, deterministic)
+}
+func (dst *BloomFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BloomFilter.Merge(dst, src)
+}
+func (m *BloomFilter) XXX_Size() int {
+	return xxx_messageInfo_BloomFilter.Size(m)
+}
+func (m *BloomFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_BloomFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BloomFilter proto.InternalMessageInfo
+
+func (m *BloomFilter) GetSeed() uint32 {
+	if m != nil {
+		return m.Seed
+	}
+	return 0
+}
+
+func (m *BloomFilter) GetHashCount() uint32 {
+	if m != nil {
+		return m.HashCount
+	}
+	return 0
+}
+
+func (m *BloomFilter) GetTable() []byte {
+	if m != nil {
+		return m.Table
+	}
+	return nil
+}
+
+// SignedSatelliteList is a list of satellites storage nodes may trust,
+// published by a trust list source and signed with the identity of its signer
+type SignedSatelliteList struct {
+	SatelliteIds         []NodeID `protobuf:"bytes,1,rep,name=satellite_ids,json=satelliteIds,customtype=NodeID" json:"satellite_ids"`
+	SignedAt             int64    `protobuf:"varint,2,opt,name=signed_at,json=signedAt,proto3" json:"signed_at,omitempty"`
+	Signature            []byte   `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Chain                [][]byte `protobuf:"bytes,4,rep,name=chain" json:"chain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedSatelliteList) Reset()         { *m = SignedSatelliteList{} }
+func (m *SignedSatelliteList) String() string { return proto.CompactTextString(m) }
+func (*SignedSatelliteList) ProtoMessage()    {}
+func (*SignedSatelliteList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{29}
+}
+func (m *SignedSatelliteList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedSatelliteList.Unmarshal(m, b)
+}
+func (m *SignedSatelliteList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedSatelliteList.Marshal(b, m, deterministic)
+}
+func (dst *SignedSatelliteList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedSatelliteList.Merge(dst, src)
+}
+func (m *SignedSatelliteList) XXX_Size() int {
+	return xxx_messageInfo_SignedSatelliteList.Size(m)
+}
+func (m *SignedSatelliteList) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedSatelliteList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedSatelliteList proto.InternalMessageInfo
+
+func (m *SignedSatelliteList) GetSignedAt() int64 {
+	if m != nil {
+		return m.SignedAt
+	}
+	return 0
+}
+
+func (m *SignedSatelliteList) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *SignedSatelliteList) GetChain() [][]byte {
+	if m != nil {
+		return m.Chain
+	}
+	return nil
+}
+
+// AuditReceipt is the outcome of an audit of a storage node, signed by the
+// satellite which audited it, so that the node can prove its audit history
+type AuditReceipt struct {
+	SatelliteId          NodeID               `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	NodeId               NodeID               `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	PieceId              string               `protobuf:"bytes,3,opt,name=piece_id,json=pieceId,proto3" json:"piece_id,omitempty"`
+	StripeIndex          int64                `protobuf:"varint,4,opt,name=stripe_index,json=stripeIndex,proto3" json:"stripe_index,omitempty"`
+	Outcome              AuditReceipt_Outcome `protobuf:"varint,5,opt,name=outcome,proto3,enum=piecestoreroutes.AuditReceipt_Outcome" json:"outcome,omitempty"`
+	AuditedUnixSec       int64                `protobuf:"varint,6,opt,name=audited_unix_sec,json=auditedUnixSec,proto3" json:"audited_unix_sec,omitempty"`
+	Signature            []byte               `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+	Chain                [][]byte             `protobuf:"bytes,8,rep,name=chain" json:"chain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *AuditReceipt) Reset()         { *m = AuditReceipt{} }
+func (m *AuditReceipt) String() string { return proto.CompactTextString(m) }
+func (*AuditReceipt) ProtoMessage()    {}
+func (*AuditReceipt) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{30}
+}
+func (m *AuditReceipt) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditReceipt.Unmarshal(m, b)
+}
+func (m *AuditReceipt) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditReceipt.Marshal(b, m, deterministic)
+}
+func (dst *AuditReceipt) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditReceipt.Merge(dst, src)
+}
+func (m *AuditReceipt) XXX_Size() int {
+	return xxx_messageInfo_AuditReceipt.Size(m)
+}
+func (m *AuditReceipt) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditReceipt.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditReceipt proto.InternalMessageInfo
+
+func (m *AuditReceipt) GetPieceId() string {
+	if m != nil {
+		return m.PieceId
+	}
+	return ""
+}
+
+func (m *AuditReceipt) GetStripeIndex() int64 {
+	if m != nil {
+		return m.StripeIndex
+	}
+	return 0
+}
+
+func (m *AuditReceipt) GetOutcome() AuditReceipt_Outcome {
+	if m != nil {
+		return m.Outcome
+	}
+	return AuditReceipt_SUCCESS
+}
+
+func (m *AuditReceipt) GetAuditedUnixSec() int64 {
+	if m != nil {
+		return m.AuditedUnixSec
+	}
+	return 0
+}
+
+func (m *AuditReceipt) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *AuditReceipt) GetChain() [][]byte {
+	if m != nil {
+		return m.Chain
+	}
+	return nil
+}
+
+type StoreAuditReceiptResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StoreAuditReceiptResponse) Reset()         { *m = StoreAuditReceiptResponse{} }
+func (m *StoreAuditReceiptResponse) String() string { return proto.CompactTextString(m) }
+func (*StoreAuditReceiptResponse) ProtoMessage()    {}
+func (*StoreAuditReceiptResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{31}
+}
+func (m *StoreAuditReceiptResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StoreAuditReceiptResponse.Unmarshal(m, b)
+}
+func (m *StoreAuditReceiptResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StoreAuditReceiptResponse.Marshal(b, m, deterministic)
+}
+func (dst *StoreAuditReceiptResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreAuditReceiptResponse.Merge(dst, src)
+}
+func (m *StoreAuditReceiptResponse) XXX_Size() int {
+	return xxx_messageInfo_StoreAuditReceiptResponse.Size(m)
+}
+func (m *StoreAuditReceiptResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreAuditReceiptResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreAuditReceiptResponse proto.InternalMessageInfo
+
+type AuditReceiptsRequest struct {
+	// satellite to return the receipts of, all satellites when empty
+	SatelliteId          NodeID   `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	Limit                int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuditReceiptsRequest) Reset()         { *m = AuditReceiptsRequest{} }
+func (m *AuditReceiptsRequest) String() string { return proto.CompactTextString(m) }
+func (*AuditReceiptsRequest) ProtoMessage()    {}
+func (*AuditReceiptsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{32}
+}
+func (m *AuditReceiptsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditReceiptsRequest.Unmarshal(m, b)
+}
+func (m *AuditReceiptsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditReceiptsRequest.Marshal(b, m, deterministic)
+}
+func (dst *AuditReceiptsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditReceiptsRequest.Merge(dst, src)
+}
+func (m *AuditReceiptsRequest) XXX_Size() int {
+	return xxx_messageInfo_AuditReceiptsRequest.Size(m)
+}
+func (m *AuditReceiptsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditReceiptsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditReceiptsRequest proto.InternalMessageInfo
+
+func (m *AuditReceiptsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type AuditReceiptsResponse struct {
+	Receipts             []*AuditReceipt `protobuf:"bytes,1,rep,name=receipts" json:"receipts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *AuditReceiptsResponse) Reset()         { *m = AuditReceiptsResponse{} }
+func (m *AuditReceiptsResponse) String() string { return proto.CompactTextString(m) }
+func (*AuditReceiptsResponse) ProtoMessage()    {}
+func (*AuditReceiptsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{33}
+}
+func (m *AuditReceiptsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditReceiptsResponse.Unmarshal(m, b)
+}
+func (m *AuditReceiptsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditReceiptsResponse.Marshal(b, m, deterministic)
+}
+func (dst *AuditReceiptsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditReceiptsResponse.Merge(dst, src)
+}
+func (m *AuditReceiptsResponse) XXX_Size() int {
+	return xxx_messageInfo_AuditReceiptsResponse.Size(m)
+}
+func (m *AuditReceiptsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditReceiptsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditReceiptsResponse proto.InternalMessageInfo
+
+func (m *AuditReceiptsResponse) GetReceipts() []*AuditReceipt {
+	if m != nil {
+		return m.Receipts
+	}
+	return nil
+}
+
+type SetAllocatedSpaceRequest struct {
+	Allocated            int64    `protobuf:"varint,1,opt,name=allocated,proto3" json:"allocated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetAllocatedSpaceRequest) Reset()         { *m = SetAllocatedSpaceRequest{} }
+func (m *SetAllocatedSpaceRequest) String() string { return proto.CompactTextString(m) }
+func (*SetAllocatedSpaceRequest) ProtoMessage()    {}
+func (*SetAllocatedSpaceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{34}
+}
+func (m *SetAllocatedSpaceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetAllocatedSpaceRequest.Unmarshal(m, b)
+}
+func (m *SetAllocatedSpaceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetAllocatedSpaceRequest.Marshal(b, m, deterministic)
+}
+func (dst *SetAllocatedSpaceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetAllocatedSpaceRequest.Merge(dst, src)
+}
+func (m *SetAllocatedSpaceRequest) XXX_Size() int {
+	return xxx_messageInfo_SetAllocatedSpaceRequest.Size(m)
+}
+func (m *SetAllocatedSpaceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetAllocatedSpaceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetAllocatedSpaceRequest proto.InternalMessageInfo
+
+func (m *SetAllocatedSpaceRequest) GetAllocated() int64 {
+	if m != nil {
+		return m.Allocated
+	}
+	return 0
+}
+
+type SetAllocatedSpaceResponse struct {
+	Previous             int64    `protobuf:"varint,1,opt,name=previous,proto3" json:"previous,omitempty"`
+	Used                 int64    `protobuf:"varint,2,opt,name=used,proto3" json:"used,omitempty"`
+	Available            int64    `protobuf:"varint,3,opt,name=available,proto3" json:"available,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetAllocatedSpaceResponse) Reset()         { *m = SetAllocatedSpaceResponse{} }
+func (m *SetAllocatedSpaceResponse) String() string { return proto.CompactTextString(m) }
+func (*SetAllocatedSpaceResponse) ProtoMessage()    {}
+func (*SetAllocatedSpaceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_piecestore_603cc90da9283016, []int{35}
+}
+func (m *SetAllocatedSpaceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetAllocatedSpaceResponse.Unmarshal(m, b)
+}
+func (m *SetAllocatedSpaceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetAllocatedSpaceResponse.Marshal(b, m, deterministic)
+}
+func (dst *SetAllocatedSpaceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetAllocatedSpaceResponse.Merge(dst, src)
+}
+func (m *SetAllocatedSpaceResponse) XXX_Size() int {
+	return xxx_messageInfo_SetAllocatedSpaceResponse.Size(m)
+}
+func (m *SetAllocatedSpaceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetAllocatedSpaceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetAllocatedSpaceResponse proto.InternalMessageInfo
+
+func (m *SetAllocatedSpaceResponse) GetPrevious() int64 {
+	if m != nil {
+		return m.Previous
+	}
+	return 0
+}
+
+func (m *SetAllocatedSpaceResponse) GetUsed() int64 {
+	if m != nil {
+		return m.Used
+	}
+	return 0
+}
+
+func (m *SetAllocatedSpaceResponse) GetAvailable() int64 {
+	if m != nil {
+		return m.Available
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*PayerBandwidthAllocation)(nil), "piecestoreroutes.PayerBandwidthAllocation")
+	proto.RegisterType((*PayerBandwidthAllocation_Data)(nil), "piecestoreroutes.PayerBandwidthAllocation.Data")
+	proto.RegisterType((*RenterBandwidthAllocation)(nil), "piecestoreroutes.RenterBandwidthAllocation")
+	proto.RegisterType((*RenterBandwidthAllocation_Data)(nil), "piecestoreroutes.RenterBandwidthAllocation.Data")
+	proto.RegisterType((*PieceStore)(nil), "piecestoreroutes.PieceStore")
+	proto.RegisterType((*PieceStore_PieceData)(nil), "piecestoreroutes.PieceStore.PieceData")
+	proto.RegisterType((*PieceId)(nil), "piecestoreroutes.PieceId")
+	proto.RegisterType((*PieceSummary)(nil), "piecestoreroutes.PieceSummary")
+	proto.RegisterType((*PieceRetrieval)(nil), "piecestoreroutes.PieceRetrieval")
+	proto.RegisterType((*PieceRetrieval_PieceData)(nil), "piecestoreroutes.PieceRetrieval.PieceData")
+	proto.RegisterType((*PieceRetrievalStream)(nil), "piecestoreroutes.PieceRetrievalStream")
+	proto.RegisterType((*PieceDelete)(nil), "piecestoreroutes.PieceDelete")
+	proto.RegisterType((*PieceDeleteSummary)(nil), "piecestoreroutes.PieceDeleteSummary")
+	proto.RegisterType((*PieceStoreSummary)(nil), "piecestoreroutes.PieceStoreSummary")
+	proto.RegisterType((*PartialPieceSummary)(nil), "piecestoreroutes.PartialPieceSummary")
+	proto.RegisterType((*StatsReq)(nil), "piecestoreroutes.StatsReq")
+	proto.RegisterType((*StatSummary)(nil), "piecestoreroutes.StatSummary")
+	proto.RegisterType((*SignedMessage)(nil), "piecestoreroutes.SignedMessage")
+	proto.RegisterType((*DashboardReq)(nil), "piecestoreroutes.DashboardReq")
+	proto.RegisterType((*DashboardStats)(nil), "piecestoreroutes.DashboardStats")
+	proto.RegisterType((*ScrubStats)(nil), "piecestoreroutes.ScrubStats")
+	proto.RegisterType((*UsageRequest)(nil), "piecestoreroutes.UsageRequest")
+	proto.RegisterType((*UsageResponse)(nil), "piecestoreroutes.UsageResponse")
+	proto.RegisterType((*SatelliteUsage)(nil), "piecestoreroutes.SatelliteUsage")
+	proto.RegisterType((*UsagePoint)(nil), "piecestoreroutes.UsagePoint")
+	proto.RegisterType((*RetainSummary)(nil), "piecestoreroutes.RetainSummary")
+	proto.RegisterType((*RetainRequest)(nil), "piecestoreroutes.RetainRequest")
+	proto.RegisterType((*RetainResponse)(nil), "piecestoreroutes.RetainResponse")
+	proto.RegisterType((*RestoreTrashRequest)(nil), "piecestoreroutes.RestoreTrashRequest")
+	proto.RegisterType((*RestoreTrashResponse)(nil), "piecestoreroutes.RestoreTrashResponse")
+	proto.RegisterType((*RetainLogRequest)(nil), "piecestoreroutes.RetainLogRequest")
+	proto.RegisterType((*RetainLogResponse)(nil), "piecestoreroutes.RetainLogResponse")
+	proto.RegisterType((*BloomFilter)(nil), "piecestoreroutes.BloomFilter")
+	proto.RegisterType((*SignedSatelliteList)(nil), "piecestoreroutes.SignedSatelliteList")
+	proto.RegisterType((*AuditReceipt)(nil), "piecestoreroutes.AuditReceipt")
+	proto.RegisterType((*StoreAuditReceiptResponse)(nil), "piecestoreroutes.StoreAuditReceiptResponse")
+	proto.RegisterType((*AuditReceiptsRequest)(nil), "piecestoreroutes.AuditReceiptsRequest")
+	proto.RegisterType((*AuditReceiptsResponse)(nil), "piecestoreroutes.AuditReceiptsResponse")
+	proto.RegisterType((*SetAllocatedSpaceRequest)(nil), "piecestoreroutes.SetAllocatedSpaceRequest")
+	proto.RegisterType((*SetAllocatedSpaceResponse)(nil), "piecestoreroutes.SetAllocatedSpaceResponse")
+	proto.RegisterEnum("piecestoreroutes.PayerBandwidthAllocation_Action", PayerBandwidthAllocation_Action_name, PayerBandwidthAllocation_Action_value)
+	proto.RegisterEnum("piecestoreroutes.AuditReceipt_Outcome", AuditReceipt_Outcome_name, AuditReceipt_Outcome_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// PieceStoreRoutesClient is the client API for PieceStoreRoutes service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PieceStoreRoutesClient interface {
+	Piece(ctx context.Context, in *PieceId, opts ...grpc.CallOption) (*PieceSummary, error)
+	Retrieve(ctx context.Context, opts ...grpc.CallOption) (PieceStoreRoutes_RetrieveClient, error)
+	Store(ctx context.Context, opts ...grpc.CallOption) (PieceStoreRoutes_StoreClient, error)
+	// PartialPiece returns how many bytes of an interrupted upload were
+	// durably written, so that the upload can be resumed from there
+	PartialPiece(ctx context.Context, in *PieceId, opts ...grpc.CallOption) (*PartialPieceSummary, error)
+	Delete(ctx context.Context, in *PieceDelete, opts ...grpc.CallOption) (*PieceDeleteSummary, error)
+	Stats(ctx context.Context, in *StatsReq, opts ...grpc.CallOption) (*StatSummary, error)
+	Dashboard(ctx context.Context, in *DashboardReq, opts ...grpc.CallOption) (PieceStoreRoutes_DashboardClient, error)
+	// Usage returns hourly usage per satellite, only to local callers
+	Usage(ctx context.Context, in *UsageRequest, opts ...grpc.CallOption) (*UsageResponse, error)
+	// Retain queues trashing the pieces of the calling satellite which aren't
+	// in its bloom filter, the summary is recorded in the retain log
+	Retain(ctx context.Context, in *RetainRequest, opts ...grpc.CallOption) (*RetainResponse, error)
+	// RestoreTrash restores the trashed pieces of a satellite, to the satellite
+	// itself or to local callers
+	RestoreTrash(ctx context.Context, in *RestoreTrashRequest, opts ...grpc.CallOption) (*RestoreTrashResponse, error)
+	// RetainLog returns the summaries of processed garbage collection retain
+	// requests, only to local callers
+	RetainLog(ctx context.Context, in *RetainLogRequest, opts ...grpc.CallOption) (*RetainLogResponse, error)
+	// StoreAuditReceipt stores the receipt of an audit of the node, signed by
+	// the satellite which audited it
+	StoreAuditReceipt(ctx context.Context, in *AuditReceipt, opts ...grpc.CallOption) (*StoreAuditReceiptResponse, error)
+	// AuditReceipts returns the stored audit receipts, only to local callers
+	AuditReceipts(ctx context.Context, in *AuditReceiptsRequest, opts ...grpc.CallOption) (*AuditReceiptsResponse, error)
+	// SetAllocatedSpace changes the disk space allocated to pieces while the
+	// node runs, only to local callers
+	SetAllocatedSpace(ctx context.Context, in *SetAllocatedSpaceRequest, opts ...grpc.CallOption) (*SetAllocatedSpaceResponse, error)
+}
+
+type pieceStoreRoutesClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPieceStoreRoutesClient(cc *grpc.ClientConn) PieceStoreRoutesClient {
+	return &pieceStoreRoutesClient{cc}
+}
+
+func (c *pieceStoreRoutesClient) Piece(ctx context.Context, in *PieceId, opts ...grpc.CallOption) (*PieceSummary, error) {
+	out := new(PieceSummary)
+	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/Piece", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pieceStoreRoutesClient) Retrieve(ctx context.Context, opts ...grpc.CallOption) (PieceStoreRoutes_RetrieveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_PieceStoreRoutes_serviceDesc.Streams[0], "/piecestoreroutes.PieceStoreRoutes/Retrieve", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &pieceStoreRoutesRetrieveClient{stream}
+	return x, nil
+}
+
+type PieceStoreRoutes_RetrieveClient interface {
+	Send(*PieceRetrieval) error
+	Recv() (*PieceRetrievalStream, error)
+	grpc.ClientStream
+}
+
+type pieceStoreRoutesRetrieveClient struct {
+	grpc.ClientStream
+}
+
+func (x *pieceStoreRoutesRetrieveClient) Send(m *PieceRetrieval) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *pieceStoreRoutesRetrieveClient) Recv() (*PieceRetrievalStream, error) {
+	m := new(PieceRetrievalStream)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *pieceStoreRoutesClient) Store(ctx context.Context, opts ...grpc.CallOption) (PieceStoreRoutes_StoreClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_PieceStoreRoutes_serviceDesc.Streams[1], "/piecestoreroutes.PieceStoreRoutes/Store", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &pieceStoreRoutesStoreClient{stream}
+	return x, nil
+}
+
+type PieceStoreRoutes_StoreClient interface {
+	Send(*PieceStore) error
+	CloseAndRecv() (*PieceStoreSummary, error)
+	grpc.ClientStream
+}
+
+type pieceStoreRoutesStoreClient struct {
+	grpc.ClientStream
+}
+
+func (x *pieceStoreRoutesStoreClient) Send(m *PieceStore) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *pieceStoreRoutesStoreClient) CloseAndRecv() (*PieceStoreSummary, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(PieceStoreSummary)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *pieceStoreRoutesClient) PartialPiece(ctx context.Context, in *PieceId, opts ...grpc.CallOption) (*PartialPieceSummary, error) {
+	out := new(PartialPieceSummary)
+	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/PartialPiece", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pieceStoreRoutesClient) Delete(ctx context.Context, in *PieceDelete, opts ...grpc.CallOption) (*PieceDeleteSummary, error) {
+	out := new(PieceDeleteSummary)
+	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pieceStoreRoutesClient) Stats(ctx context.Context, in *StatsReq, opts ...grpc.CallOption) (*StatSummary, error) {
+	out := new(StatSummary)
+	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/Stats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pieceStoreRoutesClient) Dashboard(ctx context.Context, in *DashboardReq, opts ...grpc.CallOption) (PieceStoreRoutes_DashboardClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_PieceStoreRoutes_serviceDesc.Streams[2], "/piecestoreroutes.PieceStoreRoutes/Dashboard", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &pieceStoreRoutesDashboardClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PieceStoreRoutes_DashboardClient interface {
+	Recv() (*DashboardStats, error)
+	grpc.ClientStream
+}
+
+type pieceStoreRoutesDashboardClient struct {
+	grpc.ClientStream
+}
+
+func (x *pieceStoreRoutesDashboardClient) Recv() (*DashboardStats, error) {
+	m := new(DashboardStats)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *pieceStoreRoutesClient) Usage(ctx context.Context, in *UsageRequest, opts ...grpc.CallOption) (*UsageResponse, error) {
+	out := new(UsageResponse)
+	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/Usage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pieceStoreRoutesClient) Retain(ctx context.Context, in *RetainRequest, opts ...grpc.CallOption) (*RetainResponse, error) {
+	out := new(RetainResponse)
+	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/Retain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pieceStoreRoutesClient) RestoreTrash(ctx context.Context, in *RestoreTrashRequest, opts ...grpc.CallOption) (*RestoreTrashResponse, error) {
+	out := new(RestoreTrashResponse)
+	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/RestoreTrash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pieceStoreRoutesClient) RetainLog(ctx context.Context, in *RetainLogRequest, opts ...grpc.CallOption) (*RetainLogResponse, error) {
+	out := new(RetainLogResponse)
+	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/RetainLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pieceStoreRoutesClient) StoreAuditReceipt(ctx context.Context, in *AuditReceipt, opts ...grpc.CallOption) (*StoreAuditReceiptResponse, error) {
+	out := new(StoreAuditReceiptResponse)
+	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/StoreAuditReceipt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pieceStoreRoutesClient) AuditReceipts(ctx context.Context, in *AuditReceiptsRequest, opts ...grpc.CallOption) (*AuditReceiptsResponse, error) {
+	out := new(AuditReceiptsResponse)
+	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/AuditReceipts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pieceStoreRoutesClient) SetAllocatedSpace(ctx context.Context, in *SetAllocatedSpaceRequest, opts ...grpc.CallOption) (*SetAllocatedSpaceResponse, error) {
+	out := new(SetAllocatedSpaceResponse)
+	err := c.cc.Invoke(ctx, "/piecestoreroutes.PieceStoreRoutes/SetAllocatedSpace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PieceStoreRoutesServer is the server API for PieceStoreRoutes service.
+type PieceStoreRoutesServer interface {
+	Piece(context.Context, *PieceId) (*PieceSummary, error)
+	Retrieve(PieceStoreRoutes_RetrieveServer) error
+	Store(PieceStoreRoutes_StoreServer) error
+	// PartialPiece returns how many bytes of an interrupted upload were
+	// durably written, so that the upload can be resumed from there
+	PartialPiece(context.Context, *PieceId) (*PartialPieceSummary, error)
+	Delete(context.Context, *PieceDelete) (*PieceDeleteSummary, error)
+	Stats(context.Context, *StatsReq) (*StatSummary, error)
+	Dashboard(*DashboardReq, PieceStoreRoutes_DashboardServer) error
+	// Usage returns hourly usage per satellite, only to local callers
+	Usage(context.Context, *UsageRequest) (*UsageResponse, error)
+	// Retain queues trashing the pieces of the calling satellite which aren't
+	// in its bloom filter, the summary is recorded in the retain log
+	Retain(context.Context, *RetainRequest) (*RetainResponse, error)
+	// RestoreTrash restores the trashed pieces of a satellite, to the satellite
+	// itself or to local callers
+	RestoreTrash(context.Context, *RestoreTrashRequest) (*RestoreTrashResponse, error)
+	// RetainLog returns the summaries of processed garbage collection retain
+	// requests, only to local callers
+	RetainLog(context.Context, *RetainLogRequest) (*RetainLogResponse, error)
+	// StoreAuditReceipt stores the receipt of an audit of the node, signed by
+	// the satellite which audited it
+	StoreAuditReceipt(context.Context, *AuditReceipt) (*StoreAuditReceiptResponse, error)
+	// AuditReceipts returns the stored audit receipts, only to local callers
+	AuditReceipts(context.Context, *AuditReceiptsRequest) (*AuditReceiptsResponse, error)
+	// SetAllocatedSpace changes the disk space allocated to pieces while the
+	// node runs, only to local callers
+	SetAllocatedSpace(context.Context, *SetAllocatedSpaceRequest) (*SetAllocatedSpaceResponse, error)
+}
+
+func RegisterPieceStoreRoutesServer(s *grpc.Server, srv PieceStoreRoutesServer) {
+	s.RegisterService(&_PieceStoreRoutes_serviceDesc, srv)
+}
+
+func _PieceStoreRoutes_Piece_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PieceId)
 	if err := dec(in); err != nil {
 		return nil, err
@@ -1285,6 +2581,24 @@ func (x *pieceStoreRoutesStoreServer) Recv() (*PieceStore, error) {
 	return m, nil
 }
 
+func _PieceStoreRoutes_PartialPiece_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PieceId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PieceStoreRoutesServer).PartialPiece(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/piecestoreroutes.PieceStoreRoutes/PartialPiece",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PieceStoreRoutesServer).PartialPiece(ctx, req.(*PieceId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PieceStoreRoutes_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PieceDelete)
 	if err := dec(in); err != nil {
@@ -1342,6 +2656,132 @@ func (x *pieceStoreRoutesDashboardServer) Send(m *DashboardStats) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _PieceStoreRoutes_Usage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PieceStoreRoutesServer).Usage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/piecestoreroutes.PieceStoreRoutes/Usage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PieceStoreRoutesServer).Usage(ctx, req.(*UsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PieceStoreRoutes_Retain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PieceStoreRoutesServer).Retain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/piecestoreroutes.PieceStoreRoutes/Retain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PieceStoreRoutesServer).Retain(ctx, req.(*RetainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PieceStoreRoutes_RestoreTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PieceStoreRoutesServer).RestoreTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/piecestoreroutes.PieceStoreRoutes/RestoreTrash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PieceStoreRoutesServer).RestoreTrash(ctx, req.(*RestoreTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PieceStoreRoutes_RetainLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetainLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PieceStoreRoutesServer).RetainLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/piecestoreroutes.PieceStoreRoutes/RetainLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PieceStoreRoutesServer).RetainLog(ctx, req.(*RetainLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PieceStoreRoutes_StoreAuditReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditReceipt)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PieceStoreRoutesServer).StoreAuditReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/piecestoreroutes.PieceStoreRoutes/StoreAuditReceipt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PieceStoreRoutesServer).StoreAuditReceipt(ctx, req.(*AuditReceipt))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PieceStoreRoutes_AuditReceipts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditReceiptsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PieceStoreRoutesServer).AuditReceipts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/piecestoreroutes.PieceStoreRoutes/AuditReceipts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PieceStoreRoutesServer).AuditReceipts(ctx, req.(*AuditReceiptsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PieceStoreRoutes_SetAllocatedSpace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAllocatedSpaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PieceStoreRoutesServer).SetAllocatedSpace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/piecestoreroutes.PieceStoreRoutes/SetAllocatedSpace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PieceStoreRoutesServer).SetAllocatedSpace(ctx, req.(*SetAllocatedSpaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _PieceStoreRoutes_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "piecestoreroutes.PieceStoreRoutes",
 	HandlerType: (*PieceStoreRoutesServer)(nil),
@@ -1350,6 +2790,10 @@ var _PieceStoreRoutes_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Piece",
 			Handler:    _PieceStoreRoutes_Piece_Handler,
 		},
+		{
+			MethodName: "PartialPiece",
+			Handler:    _PieceStoreRoutes_PartialPiece_Handler,
+		},
 		{
 			MethodName: "Delete",
 			Handler:    _PieceStoreRoutes_Delete_Handler,
@@ -1358,6 +2802,34 @@ var _PieceStoreRoutes_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Stats",
 			Handler:    _PieceStoreRoutes_Stats_Handler,
 		},
+		{
+			MethodName: "Usage",
+			Handler:    _PieceStoreRoutes_Usage_Handler,
+		},
+		{
+			MethodName: "Retain",
+			Handler:    _PieceStoreRoutes_Retain_Handler,
+		},
+		{
+			MethodName: "RestoreTrash",
+			Handler:    _PieceStoreRoutes_RestoreTrash_Handler,
+		},
+		{
+			MethodName: "RetainLog",
+			Handler:    _PieceStoreRoutes_RetainLog_Handler,
+		},
+		{
+			MethodName: "StoreAuditReceipt",
+			Handler:    _PieceStoreRoutes_StoreAuditReceipt_Handler,
+		},
+		{
+			MethodName: "AuditReceipts",
+			Handler:    _PieceStoreRoutes_AuditReceipts_Handler,
+		},
+		{
+			MethodName: "SetAllocatedSpace",
+			Handler:    _PieceStoreRoutes_SetAllocatedSpace_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1380,79 +2852,149 @@ var _PieceStoreRoutes_serviceDesc = grpc.ServiceDesc{
 	Metadata: "piecestore.proto",
 }
 
-func init() { proto.RegisterFile("piecestore.proto", fileDescriptor_piecestore_664948018be76fe5) }
-
-var fileDescriptor_piecestore_664948018be76fe5 = []byte{
-	// 1130 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x4b, 0x6f, 0xdb, 0xc6,
-	0x13, 0x17, 0x29, 0x5b, 0x8f, 0x91, 0x25, 0x2b, 0x9b, 0xe0, 0xff, 0x97, 0x85, 0xd8, 0x16, 0x98,
-	0x26, 0x55, 0x13, 0x40, 0x8e, 0x15, 0xa0, 0x77, 0xbb, 0x32, 0x02, 0xa1, 0xa8, 0xe3, 0xae, 0xec,
-	0x4b, 0x0e, 0x65, 0x56, 0xe4, 0x58, 0x26, 0x42, 0x91, 0x2c, 0xb9, 0x74, 0x6d, 0x7f, 0xa5, 0xa2,
-	0xdf, 0xa3, 0x9f, 0xa0, 0x87, 0x1e, 0x02, 0x14, 0xe8, 0xb9, 0xc7, 0x5e, 0x0a, 0x14, 0xc5, 0xee,
-	0xf2, 0x21, 0x5b, 0x0f, 0x17, 0x41, 0x73, 0xdb, 0x79, 0xec, 0xcc, 0xec, 0x6f, 0x7f, 0xb3, 0xb3,
-	0xd0, 0x0c, 0x1c, 0xb4, 0x30, 0xe2, 0x7e, 0x88, 0xbd, 0x20, 0xf4, 0xb9, 0x4f, 0x66, 0x34, 0xa1,
-	0x1f, 0x73, 0x8c, 0xda, 0xe0, 0xf9, 0x76, 0x62, 0x6d, 0xc3, 0xc4, 0x9f, 0xf8, 0xc9, 0x7a, 0x67,
-	0xe2, 0xfb, 0x13, 0x17, 0xf7, 0xa4, 0x34, 0x8e, 0xcf, 0xf7, 0xec, 0x38, 0x64, 0xdc, 0xf1, 0x3d,
-	0x65, 0x37, 0xfe, 0x2e, 0x42, 0xeb, 0x84, 0x5d, 0x63, 0x78, 0xc8, 0x3c, 0xfb, 0x07, 0xc7, 0xe6,
-	0x17, 0x07, 0xae, 0xeb, 0x5b, 0xd2, 0x85, 0x3c, 0x86, 0x6a, 0xe4, 0x4c, 0x3c, 0xc6, 0xe3, 0x10,
-	0x5b, 0x5a, 0x47, 0xeb, 0x6e, 0xd0, 0x5c, 0x41, 0x08, 0xac, 0xd9, 0x8c, 0xb3, 0x96, 0x2e, 0x0d,
-	0x72, 0xdd, 0xfe, 0x5d, 0x87, 0xb5, 0x01, 0xe3, 0x8c, 0xec, 0xc3, 0x46, 0xc4, 0x38, 0xba, 0xae,
-	0xc3, 0xd1, 0x74, 0x6c, 0xb5, 0xfb, 0xb0, 0xf1, 0xf3, 0x87, 0xdd, 0xc2, 0xaf, 0x1f, 0x76, 0x4b,
-	0xc7, 0xbe, 0x8d, 0xc3, 0x01, 0xad, 0x65, 0x3e, 0x43, 0x9b, 0xbc, 0x80, 0x6a, 0x1c, 0xb8, 0x8e,
-	0xf7, 0x5e, 0xf8, 0xeb, 0x0b, 0xfd, 0x2b, 0xca, 0x61, 0x68, 0x93, 0x2d, 0xa8, 0x4c, 0xd9, 0x95,
-	0x19, 0x39, 0x37, 0xd8, 0x2a, 0x76, 0xb4, 0x6e, 0x91, 0x96, 0xa7, 0xec, 0x6a, 0xe4, 0xdc, 0x20,
-	0xe9, 0xc1, 0x43, 0xbc, 0x0a, 0x1c, 0x75, 0x4c, 0x33, 0xf6, 0x9c, 0x2b, 0x33, 0x42, 0xab, 0xb5,
-	0x26, 0xbd, 0x1e, 0xe4, 0xa6, 0x33, 0xcf, 0xb9, 0x1a, 0xa1, 0x45, 0x9e, 0x40, 0x3d, 0xc2, 0xd0,
-	0x61, 0xae, 0xe9, 0xc5, 0xd3, 0x31, 0x86, 0xad, 0xf5, 0x8e, 0xd6, 0xad, 0xd2, 0x0d, 0xa5, 0x3c,
-	0x96, 0x3a, 0x32, 0x84, 0x12, 0xb3, 0xc4, 0xae, 0x56, 0xa9, 0xa3, 0x75, 0x1b, 0xfd, 0xfd, 0xde,
-	0xdd, 0x2b, 0xe8, 0x2d, 0x83, 0xb1, 0x77, 0x20, 0x37, 0xd2, 0x24, 0x00, 0xe9, 0x42, 0xd3, 0x0a,
-	0x91, 0x71, 0xb4, 0xf3, 0xe2, 0xca, 0xb2, 0xb8, 0x46, 0xa2, 0x4f, 0x2b, 0xfb, 0x3f, 0x94, 0x83,
-	0x78, 0x6c, 0xbe, 0xc7, 0xeb, 0x56, 0x45, 0x82, 0x5c, 0x0a, 0xe2, 0xf1, 0xd7, 0x78, 0x6d, 0x0c,
-	0xa1, 0xa4, 0x82, 0x92, 0x32, 0x14, 0x4f, 0xce, 0x4e, 0x9b, 0x05, 0xb1, 0x78, 0x7d, 0x74, 0xda,
-	0xd4, 0x48, 0x1d, 0xaa, 0xaf, 0x8f, 0x4e, 0xcd, 0x83, 0xb3, 0xc1, 0xf0, 0xb4, 0xa9, 0x93, 0x06,
-	0x80, 0x10, 0xe9, 0xd1, 0xc9, 0xc1, 0x90, 0x36, 0x8b, 0x42, 0x3e, 0x39, 0xcb, 0xe4, 0x35, 0xe3,
-	0x2f, 0x0d, 0xb6, 0x28, 0x7a, 0xfc, 0xbf, 0x62, 0xc0, 0x8f, 0x5a, 0xc2, 0x80, 0x33, 0x68, 0x06,
-	0x02, 0x11, 0x93, 0x65, 0xe1, 0x64, 0x84, 0x5a, 0xff, 0xf9, 0xbf, 0xc7, 0x8e, 0x6e, 0xca, 0x18,
-	0x33, 0x15, 0x3d, 0x82, 0x75, 0xee, 0x73, 0xe6, 0xca, 0xa4, 0x45, 0xaa, 0x04, 0xf2, 0x25, 0x6c,
-	0x8a, 0x70, 0x6c, 0x82, 0xa6, 0x68, 0x04, 0xc1, 0xa0, 0xe2, 0x42, 0x06, 0xd5, 0x13, 0x37, 0x29,
-	0xda, 0xc6, 0x6f, 0x3a, 0xc0, 0x89, 0x28, 0x66, 0x24, 0x8a, 0x21, 0xdf, 0xc1, 0xa3, 0x71, 0x5a,
-	0xc4, 0x7c, 0xdd, 0x2f, 0xe6, 0xeb, 0x5e, 0x8a, 0x1c, 0x7d, 0x38, 0x5e, 0x00, 0xe7, 0x11, 0x80,
-	0x0c, 0x61, 0x66, 0xb0, 0xd5, 0xfa, 0xcf, 0x16, 0xa0, 0x91, 0x55, 0xa4, 0x96, 0x02, 0x4f, 0x5a,
-	0x0d, 0xd2, 0x25, 0x39, 0x82, 0x3a, 0x8b, 0xf9, 0x85, 0x1f, 0x3a, 0x37, 0xaa, 0xbe, 0xa2, 0x8c,
-	0xb4, 0x3b, 0x1f, 0x69, 0xe4, 0x4c, 0x3c, 0xb4, 0xbf, 0xc1, 0x28, 0x62, 0x13, 0xa4, 0xb7, 0x77,
-	0xb5, 0x11, 0xaa, 0x59, 0x78, 0xd2, 0x00, 0x3d, 0x69, 0xd3, 0x2a, 0xd5, 0x1d, 0x7b, 0x59, 0x17,
-	0xe9, 0xcb, 0xba, 0xa8, 0x05, 0x65, 0xcb, 0xf7, 0x38, 0x7a, 0x5c, 0x21, 0x4f, 0x53, 0xd1, 0x78,
-	0x07, 0x65, 0x99, 0x66, 0x68, 0xcf, 0x25, 0x99, 0x3b, 0x88, 0xfe, 0x31, 0x07, 0x31, 0xa6, 0xb0,
-	0xa1, 0x20, 0x8b, 0xa7, 0x53, 0x16, 0x5e, 0xcf, 0xa5, 0xd9, 0x4e, 0x61, 0x97, 0xcf, 0x85, 0x3a,
-	0x82, 0x82, 0x73, 0xd5, 0x83, 0x51, 0x5c, 0x72, 0x54, 0xe3, 0x17, 0x1d, 0x1a, 0x32, 0x1f, 0x45,
-	0x1e, 0x3a, 0x78, 0xc9, 0xdc, 0x4f, 0x4e, 0x9c, 0xe1, 0x02, 0xe2, 0x3c, 0x5f, 0x42, 0x9c, 0xac,
-	0xaa, 0x4f, 0x4a, 0x1e, 0xba, 0x8a, 0x3c, 0xf7, 0x00, 0xfe, 0x3f, 0x28, 0xf9, 0xe7, 0xe7, 0x11,
-	0xf2, 0x04, 0xe3, 0x44, 0x32, 0xde, 0xc0, 0xa3, 0xdb, 0x27, 0x18, 0xf1, 0x10, 0xd9, 0xf4, 0x4e,
-	0x38, 0xed, 0x6e, 0xb8, 0x19, 0xea, 0xe9, 0xb7, 0xa9, 0x67, 0x43, 0x4d, 0x15, 0x89, 0x2e, 0x72,
-	0xbc, 0x9f, 0x7e, 0x1f, 0x05, 0x85, 0xd1, 0x03, 0x32, 0x93, 0x25, 0x25, 0x61, 0x0b, 0xca, 0x53,
-	0xe5, 0x9f, 0x64, 0x4c, 0x45, 0xe3, 0x14, 0x1e, 0xe4, 0x1d, 0x7e, 0xaf, 0x3b, 0x79, 0x0a, 0x0d,
-	0xf9, 0xc8, 0x99, 0x21, 0x5a, 0xe8, 0x5c, 0xa2, 0x9d, 0x00, 0x5a, 0x97, 0x5a, 0x9a, 0x28, 0x0d,
-	0x80, 0xca, 0x88, 0x33, 0x1e, 0x51, 0xfc, 0xde, 0xf8, 0x49, 0x83, 0x9a, 0x10, 0xd2, 0xe0, 0xdb,
-	0x00, 0x71, 0x84, 0xb6, 0x19, 0x05, 0xcc, 0xca, 0x00, 0x14, 0x9a, 0x91, 0x50, 0x90, 0xcf, 0x61,
-	0x93, 0x5d, 0x32, 0xc7, 0x65, 0x63, 0x17, 0x13, 0x1f, 0x95, 0xa2, 0x91, 0xa9, 0x95, 0xe3, 0x53,
-	0x68, 0xc8, 0x38, 0x19, 0x45, 0x93, 0x0b, 0xac, 0x0b, 0x6d, 0x46, 0x66, 0xb2, 0x07, 0x0f, 0xf3,
-	0x78, 0xb9, 0xaf, 0x9a, 0xc0, 0x24, 0x33, 0x65, 0x1b, 0x8c, 0x77, 0x50, 0xbf, 0x85, 0x70, 0x36,
-	0x59, 0xb4, 0x7c, 0xb2, 0xdc, 0x9e, 0x45, 0xfa, 0xdd, 0x59, 0x24, 0x38, 0x12, 0x8f, 0x5d, 0xc7,
-	0x92, 0xe3, 0x52, 0x3d, 0x41, 0x55, 0xa5, 0x11, 0x13, 0xb3, 0x01, 0x1b, 0x03, 0x16, 0x5d, 0x8c,
-	0x7d, 0x16, 0xda, 0x02, 0xa1, 0x3f, 0x35, 0x68, 0x64, 0x0a, 0x89, 0x9b, 0x98, 0xb6, 0xe9, 0xec,
-	0x50, 0x37, 0x50, 0xf2, 0xe4, 0x90, 0x20, 0x5f, 0x40, 0x53, 0x1a, 0x2c, 0xdf, 0xf3, 0x50, 0x8e,
-	0xdd, 0x28, 0xc1, 0x67, 0x53, 0xe8, 0xbf, 0xca, 0xd5, 0xe2, 0x16, 0x99, 0x6d, 0x87, 0x18, 0x45,
-	0xb2, 0x84, 0x2a, 0x4d, 0x45, 0xf2, 0x0a, 0xd6, 0x23, 0x91, 0x46, 0xa2, 0x50, 0xeb, 0x6f, 0x2f,
-	0xe0, 0x58, 0x7e, 0x61, 0x54, 0xf9, 0x92, 0x1d, 0x80, 0x3c, 0xa9, 0xfc, 0x97, 0x54, 0xe8, 0x8c,
-	0x86, 0xec, 0x43, 0x29, 0x0e, 0xb8, 0x33, 0x45, 0xf9, 0x2b, 0xa9, 0xf5, 0xb7, 0x7a, 0xea, 0xbb,
-	0xd7, 0x4b, 0xbf, 0x7b, 0xbd, 0x41, 0xf2, 0xdd, 0xa3, 0x89, 0x63, 0xff, 0x8f, 0x22, 0x34, 0x73,
-	0xf6, 0x51, 0x99, 0x9a, 0x0c, 0x60, 0x5d, 0xea, 0xc8, 0xd6, 0x92, 0x37, 0x65, 0x68, 0xb7, 0x77,
-	0x96, 0xcd, 0x29, 0x55, 0xb2, 0x51, 0x20, 0x6f, 0xa1, 0x92, 0x74, 0x2e, 0x92, 0xce, 0x7d, 0x8f,
-	0x53, 0xfb, 0xd9, 0x7d, 0x1e, 0xaa, 0xf9, 0x8d, 0x42, 0x57, 0x7b, 0xa9, 0x91, 0x63, 0x58, 0x57,
-	0x23, 0xfa, 0xf1, 0xaa, 0x71, 0xd9, 0x7e, 0xb2, 0xca, 0x9a, 0x55, 0xda, 0xd5, 0xc8, 0x1b, 0x28,
-	0x25, 0x8f, 0xc2, 0xf6, 0x92, 0x2d, 0xca, 0xdc, 0xfe, 0x6c, 0xa5, 0x39, 0x3f, 0xfc, 0x40, 0x14,
-	0x28, 0xee, 0xac, 0xbd, 0xf8, 0x66, 0x45, 0x5f, 0xb6, 0x57, 0xdf, 0xba, 0x51, 0x20, 0xdf, 0x42,
-	0x35, 0x63, 0x25, 0x59, 0x80, 0xf8, 0x2c, 0x87, 0xdb, 0x9d, 0x15, 0x76, 0x99, 0xd2, 0x28, 0xbc,
-	0xd4, 0x0e, 0xd7, 0xde, 0xea, 0xc1, 0x78, 0x5c, 0x92, 0x8c, 0x78, 0xf5, 0x4f, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0x14, 0xe3, 0x9c, 0x65, 0x4c, 0x0c, 0x00, 0x00,
+func init() { proto.RegisterFile("piecestore.proto", fileDescriptor_piecestore_603cc90da9283016) }
+
+var fileDescriptor_piecestore_603cc90da9283016 = []byte{
+	// 2252 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x5f, 0x73, 0x1c, 0x47,
+	0x11, 0xd7, 0xde, 0xe9, 0xfe, 0x6c, 0xdf, 0x1f, 0x9f, 0x47, 0x82, 0x9c, 0x2e, 0xb6, 0x7c, 0x59,
+	0x63, 0x5b, 0xc4, 0x94, 0x6c, 0xcb, 0x84, 0x02, 0xaa, 0x28, 0x22, 0x4b, 0x72, 0x38, 0xe2, 0xd8,
+	0xca, 0x9c, 0x94, 0x87, 0x50, 0xc5, 0x66, 0xee, 0x76, 0x24, 0x6d, 0xf9, 0x6e, 0x77, 0xbd, 0x33,
+	0x6b, 0x4b, 0x7e, 0xe7, 0x43, 0x50, 0xf0, 0xc0, 0x3b, 0xc5, 0xf7, 0xe0, 0x89, 0x47, 0x1e, 0x78,
+	0xc8, 0x13, 0x2f, 0x14, 0x55, 0x7c, 0x00, 0xa8, 0xa2, 0xa8, 0xf9, 0xb7, 0xbb, 0xa7, 0xbb, 0x95,
+	0x82, 0x92, 0xbc, 0x6d, 0xff, 0xba, 0xa7, 0xa7, 0xa7, 0xa7, 0xbb, 0xa7, 0x7b, 0xa1, 0x13, 0xf9,
+	0x74, 0x4c, 0x19, 0x0f, 0x63, 0xba, 0x19, 0xc5, 0x21, 0x0f, 0x51, 0x0e, 0x89, 0xc3, 0x84, 0x53,
+	0xd6, 0x83, 0x20, 0xf4, 0x34, 0xb7, 0x07, 0xc7, 0xe1, 0x71, 0xa8, 0xbf, 0xd7, 0x8f, 0xc3, 0xf0,
+	0x78, 0x42, 0x1f, 0x48, 0x6a, 0x94, 0x1c, 0x3d, 0xf0, 0x92, 0x98, 0x70, 0x3f, 0x0c, 0x14, 0xdf,
+	0xf9, 0x6f, 0x19, 0xba, 0xfb, 0xe4, 0x8c, 0xc6, 0x4f, 0x48, 0xe0, 0xbd, 0xf1, 0x3d, 0x7e, 0xb2,
+	0x3d, 0x99, 0x84, 0x63, 0x29, 0x82, 0x6e, 0x80, 0xcd, 0xfc, 0xe3, 0x80, 0xf0, 0x24, 0xa6, 0x5d,
+	0xab, 0x6f, 0x6d, 0x34, 0x71, 0x06, 0x20, 0x04, 0xcb, 0x1e, 0xe1, 0xa4, 0x5b, 0x92, 0x0c, 0xf9,
+	0xdd, 0xfb, 0x7b, 0x09, 0x96, 0x77, 0x09, 0x27, 0xe8, 0x11, 0x34, 0x19, 0xe1, 0x74, 0x32, 0xf1,
+	0x39, 0x75, 0x7d, 0x4f, 0xad, 0x7e, 0xd2, 0xfe, 0xf3, 0x97, 0xb7, 0x96, 0xfe, 0xf6, 0xe5, 0xad,
+	0xea, 0xf3, 0xd0, 0xa3, 0x83, 0x5d, 0xdc, 0x48, 0x65, 0x06, 0x1e, 0xba, 0x0f, 0x76, 0x12, 0x4d,
+	0xfc, 0xe0, 0xa5, 0x90, 0x2f, 0x2d, 0x94, 0xaf, 0x2b, 0x81, 0x81, 0x87, 0xd6, 0xa0, 0x3e, 0x25,
+	0xa7, 0x2e, 0xf3, 0xdf, 0xd2, 0x6e, 0xb9, 0x6f, 0x6d, 0x94, 0x71, 0x6d, 0x4a, 0x4e, 0x87, 0xfe,
+	0x5b, 0x8a, 0x36, 0x61, 0x85, 0x9e, 0x46, 0xbe, 0x3a, 0xa6, 0x9b, 0x04, 0xfe, 0xa9, 0xcb, 0xe8,
+	0xb8, 0xbb, 0x2c, 0xa5, 0xae, 0x67, 0xac, 0xc3, 0xc0, 0x3f, 0x1d, 0xd2, 0x31, 0xba, 0x0d, 0x2d,
+	0x46, 0x63, 0x9f, 0x4c, 0xdc, 0x20, 0x99, 0x8e, 0x68, 0xdc, 0xad, 0xf4, 0xad, 0x0d, 0x1b, 0x37,
+	0x15, 0xf8, 0x5c, 0x62, 0x68, 0x00, 0x55, 0x32, 0x16, 0xab, 0xba, 0xd5, 0xbe, 0xb5, 0xd1, 0xde,
+	0x7a, 0xb4, 0x79, 0xfe, 0x0a, 0x36, 0x8b, 0xdc, 0xb8, 0xb9, 0x2d, 0x17, 0x62, 0xad, 0x00, 0x6d,
+	0x40, 0x67, 0x1c, 0x53, 0xc2, 0xa9, 0x97, 0x19, 0x57, 0x93, 0xc6, 0xb5, 0x35, 0x6e, 0x2c, 0x7b,
+	0x07, 0x6a, 0x51, 0x32, 0x72, 0x5f, 0xd2, 0xb3, 0x6e, 0x5d, 0x3a, 0xb9, 0x1a, 0x25, 0xa3, 0x8f,
+	0xe9, 0x99, 0x33, 0x80, 0xaa, 0x52, 0x8a, 0x6a, 0x50, 0xde, 0x3f, 0x3c, 0xe8, 0x2c, 0x89, 0x8f,
+	0x8f, 0xf6, 0x0e, 0x3a, 0x16, 0x6a, 0x81, 0xfd, 0xd1, 0xde, 0x81, 0xbb, 0x7d, 0xb8, 0x3b, 0x38,
+	0xe8, 0x94, 0x50, 0x1b, 0x40, 0x90, 0x78, 0x6f, 0x7f, 0x7b, 0x80, 0x3b, 0x65, 0x41, 0xef, 0x1f,
+	0xa6, 0xf4, 0xb2, 0xf3, 0x1f, 0x0b, 0xd6, 0x30, 0x0d, 0xf8, 0x37, 0x15, 0x01, 0x7f, 0xb4, 0x74,
+	0x04, 0x1c, 0x42, 0x27, 0x12, 0x1e, 0x71, 0x49, 0xaa, 0x4e, 0x6a, 0x68, 0x6c, 0xbd, 0xff, 0xd5,
+	0x7d, 0x87, 0xaf, 0x49, 0x1d, 0x39, 0x8b, 0x56, 0xa1, 0xc2, 0x43, 0x4e, 0x26, 0x72, 0xd3, 0x32,
+	0x56, 0x04, 0xfa, 0x11, 0x5c, 0x13, 0xea, 0xc8, 0x31, 0x75, 0x45, 0x22, 0x88, 0x08, 0x2a, 0x2f,
+	0x8c, 0xa0, 0x96, 0x16, 0x93, 0xa4, 0xe7, 0xfc, 0xb6, 0x0c, 0xb0, 0x2f, 0x8c, 0x19, 0x0a, 0x63,
+	0xd0, 0xaf, 0x61, 0x75, 0x64, 0x8c, 0x98, 0xb7, 0xfb, 0xfe, 0xbc, 0xdd, 0x85, 0x9e, 0xc3, 0x2b,
+	0xa3, 0x79, 0x10, 0xed, 0x01, 0x48, 0x15, 0x6e, 0xea, 0xb6, 0xc6, 0xd6, 0xdd, 0x05, 0xde, 0x48,
+	0x2d, 0x52, 0x9f, 0xc2, 0x9f, 0xd8, 0x8e, 0xcc, 0x27, 0xda, 0x83, 0x16, 0x49, 0xf8, 0x49, 0x18,
+	0xfb, 0x6f, 0x95, 0x7d, 0x65, 0xa9, 0xe9, 0xd6, 0xbc, 0xa6, 0xa1, 0x7f, 0x1c, 0x50, 0xef, 0x13,
+	0xca, 0x18, 0x39, 0xa6, 0x78, 0x76, 0x55, 0xef, 0xf7, 0x16, 0xd8, 0xa9, 0x7e, 0xd4, 0x86, 0x92,
+	0xce, 0x53, 0x1b, 0x97, 0x7c, 0xaf, 0x28, 0x8d, 0x4a, 0x45, 0x69, 0xd4, 0x85, 0xda, 0x38, 0x0c,
+	0x38, 0x0d, 0xb8, 0x72, 0x3d, 0x36, 0x24, 0xba, 0x69, 0x4e, 0x2d, 0xb3, 0x55, 0xe5, 0xa1, 0x3a,
+	0x8d, 0xcc, 0xd7, 0xef, 0x42, 0x35, 0x3c, 0x3a, 0x62, 0x94, 0xcb, 0xc4, 0x2b, 0x63, 0x4d, 0x39,
+	0x5f, 0x40, 0x4d, 0x5a, 0x37, 0xf0, 0xe6, 0x6c, 0x9b, 0x73, 0x40, 0xe9, 0x2a, 0x0e, 0x70, 0xa6,
+	0xd0, 0x54, 0xae, 0x4e, 0xa6, 0x53, 0x12, 0x9f, 0xcd, 0x6d, 0x33, 0x6b, 0x78, 0xe9, 0xbc, 0xe1,
+	0x05, 0x1e, 0x2a, 0x17, 0x78, 0xc8, 0xf9, 0x6b, 0x09, 0xda, 0x72, 0x3f, 0x4c, 0x79, 0xec, 0xd3,
+	0xd7, 0x64, 0xf2, 0xad, 0x07, 0xdc, 0x60, 0x41, 0xc0, 0xbd, 0x5f, 0x10, 0x70, 0xa9, 0x55, 0xdf,
+	0x6a, 0xd0, 0xe1, 0x8b, 0x62, 0xee, 0x12, 0x87, 0x67, 0x91, 0x52, 0x9e, 0x89, 0x94, 0x17, 0xb0,
+	0x3a, 0x7b, 0x82, 0x21, 0x8f, 0x29, 0x99, 0x9e, 0x53, 0x67, 0x9d, 0x57, 0x97, 0x8b, 0xd8, 0xd2,
+	0x4c, 0xc4, 0x3a, 0x1e, 0x34, 0x94, 0x91, 0x74, 0x42, 0x39, 0xbd, 0x3c, 0xfc, 0xae, 0xe4, 0x0a,
+	0x67, 0x13, 0x50, 0x6e, 0x17, 0x13, 0x84, 0x5d, 0xa8, 0x4d, 0x95, 0xbc, 0xde, 0xd1, 0x90, 0xce,
+	0x01, 0x5c, 0xcf, 0x2a, 0xc3, 0xa5, 0xe2, 0xe8, 0x0e, 0xb4, 0x65, 0x71, 0x74, 0x63, 0x3a, 0xa6,
+	0xfe, 0x6b, 0xea, 0x69, 0x87, 0xb6, 0x24, 0x8a, 0x35, 0xe8, 0x84, 0xb0, 0xb2, 0x4f, 0x62, 0xee,
+	0x93, 0xc9, 0x85, 0xb9, 0xd0, 0x85, 0xda, 0x9b, 0xd8, 0xe7, 0x9c, 0x06, 0x5a, 0x8d, 0x21, 0xff,
+	0xef, 0x34, 0x00, 0xa8, 0x0f, 0x39, 0xe1, 0x0c, 0xd3, 0x57, 0xce, 0x9f, 0x2c, 0x68, 0x08, 0xc2,
+	0xec, 0x7a, 0x13, 0x20, 0x61, 0xd4, 0x73, 0x59, 0x44, 0xc6, 0xe9, 0x8d, 0x09, 0x64, 0x28, 0x00,
+	0x74, 0x0f, 0xae, 0x91, 0xd7, 0xc4, 0x9f, 0x90, 0xd1, 0x84, 0x6a, 0x19, 0x65, 0x4c, 0x3b, 0x85,
+	0x95, 0xe0, 0x1d, 0x68, 0x4b, 0x3d, 0x69, 0x4e, 0x68, 0x73, 0x5a, 0x02, 0x4d, 0xb3, 0x07, 0x3d,
+	0x80, 0x95, 0x4c, 0x5f, 0x26, 0xab, 0x4a, 0x14, 0x4a, 0x59, 0xe9, 0x02, 0xe7, 0x0b, 0x68, 0xcd,
+	0x5c, 0x69, 0xfa, 0x04, 0x5a, 0xd9, 0x13, 0x38, 0xfb, 0x68, 0x96, 0xce, 0x3f, 0x9a, 0x22, 0x28,
+	0x93, 0xd1, 0xc4, 0x1f, 0xcb, 0x77, 0x5d, 0x95, 0x4a, 0x5b, 0x21, 0xe2, 0x69, 0x6f, 0x43, 0x73,
+	0x97, 0xb0, 0x93, 0x51, 0x48, 0x62, 0x4f, 0x78, 0xe8, 0x9f, 0x25, 0x68, 0xa7, 0x80, 0xf4, 0x9b,
+	0x68, 0x0b, 0xcc, 0x23, 0xa7, 0xee, 0xa7, 0x1a, 0xc8, 0xd7, 0x0c, 0x7d, 0x1f, 0x3a, 0x92, 0x31,
+	0x0e, 0x83, 0x80, 0xca, 0xfe, 0x80, 0x69, 0xff, 0x5c, 0x13, 0xf8, 0x4e, 0x06, 0x8b, 0xeb, 0x24,
+	0x9e, 0x17, 0x53, 0xc6, 0xa4, 0x09, 0x36, 0x36, 0x24, 0x7a, 0x0c, 0x15, 0x26, 0xb6, 0x91, 0x5e,
+	0x68, 0x6c, 0xdd, 0x5c, 0x10, 0xd4, 0xd9, 0x85, 0x61, 0x25, 0x8b, 0xd6, 0x01, 0xb2, 0x4d, 0x65,
+	0x1d, 0xaf, 0xe3, 0x1c, 0x82, 0x1e, 0x41, 0x35, 0x89, 0xb8, 0x3f, 0xa5, 0xb2, 0x7d, 0x6a, 0x6c,
+	0xad, 0x6d, 0xaa, 0xbe, 0x74, 0xd3, 0xf4, 0xa5, 0x9b, 0xbb, 0xba, 0x2f, 0xc5, 0x5a, 0x10, 0x6d,
+	0x41, 0x85, 0x8d, 0xe3, 0x64, 0x24, 0x7b, 0xa3, 0xc6, 0xd6, 0x8d, 0x05, 0x76, 0x08, 0xb6, 0x0a,
+	0x25, 0x25, 0x2a, 0x0a, 0xc4, 0x1b, 0x32, 0x99, 0x50, 0x2e, 0xfb, 0x25, 0x1b, 0x6b, 0x4a, 0xc4,
+	0x8d, 0xfa, 0x72, 0x8f, 0xa8, 0xbc, 0x05, 0xd6, 0xb5, 0xfb, 0xe5, 0x0d, 0x1b, 0xb7, 0x15, 0xfc,
+	0x54, 0xa3, 0xce, 0x1f, 0x4a, 0x00, 0x99, 0x5a, 0x11, 0x46, 0x6a, 0x57, 0x77, 0x7c, 0x42, 0xc7,
+	0x2f, 0xa9, 0xa7, 0x43, 0xb2, 0xa5, 0xd0, 0x1d, 0x05, 0xa2, 0xf7, 0xa0, 0xa9, 0xc5, 0xf2, 0xad,
+	0x49, 0x43, 0x61, 0x07, 0x02, 0x12, 0x4d, 0xe6, 0xe8, 0x8c, 0xe7, 0x14, 0xa9, 0x78, 0x6c, 0x4a,
+	0xd0, 0xe8, 0xb9, 0x01, 0xf6, 0x38, 0x8c, 0xe3, 0x24, 0xe2, 0xd4, 0x33, 0xef, 0x64, 0x0a, 0x88,
+	0xc3, 0x45, 0x84, 0x31, 0xca, 0xcc, 0x3b, 0xa9, 0x28, 0x74, 0x1f, 0xd0, 0x84, 0x30, 0xee, 0x0a,
+	0x32, 0x4b, 0xbf, 0xaa, 0xba, 0x77, 0xc1, 0xd9, 0x27, 0x8c, 0x99, 0x57, 0xba, 0x0f, 0x8d, 0x57,
+	0x09, 0x89, 0x49, 0xc0, 0xfd, 0x80, 0x7a, 0xba, 0xef, 0xcc, 0x43, 0xe2, 0x2a, 0x93, 0x20, 0xa6,
+	0x51, 0x18, 0x0b, 0x2b, 0xea, 0x52, 0x20, 0x87, 0x38, 0xbf, 0xb1, 0xa0, 0x79, 0x28, 0xcb, 0x19,
+	0x7d, 0x95, 0x50, 0xc6, 0xaf, 0xd2, 0xea, 0x3b, 0xd0, 0x3a, 0x8a, 0xc3, 0xe9, 0xf9, 0xae, 0xa2,
+	0x21, 0x40, 0x63, 0xe9, 0x3a, 0x34, 0x78, 0x78, 0xbe, 0x9c, 0xd8, 0x3c, 0x34, 0x65, 0xe4, 0x53,
+	0x68, 0x69, 0x33, 0x58, 0x14, 0x06, 0x8c, 0xa2, 0x0f, 0x01, 0xd2, 0x3d, 0x58, 0xd7, 0xea, 0x97,
+	0x37, 0x1a, 0x5b, 0xfd, 0x05, 0x51, 0x63, 0x64, 0xd4, 0xea, 0xdc, 0x1a, 0xe7, 0x0c, 0xda, 0xb3,
+	0xdc, 0xab, 0x9c, 0xed, 0x87, 0x50, 0x8d, 0x42, 0x3f, 0xe0, 0x22, 0xf5, 0xca, 0x8b, 0x03, 0x57,
+	0xea, 0xde, 0x17, 0x42, 0x58, 0xcb, 0x3a, 0xff, 0xb2, 0x00, 0x32, 0x58, 0x38, 0xe8, 0x24, 0x4c,
+	0xe2, 0xec, 0xf8, 0x2a, 0xee, 0x1a, 0x02, 0xcc, 0x35, 0x5c, 0x7e, 0x70, 0x2c, 0x53, 0x58, 0x57,
+	0x64, 0x4d, 0x8a, 0xb0, 0xd5, 0x9f, 0x6e, 0x4c, 0x23, 0xe2, 0xc7, 0xa6, 0xfa, 0x69, 0x14, 0x4b,
+	0x50, 0x04, 0x14, 0x55, 0xeb, 0x55, 0xac, 0x69, 0x4a, 0x84, 0xb3, 0xfa, 0x72, 0x49, 0xe2, 0xf9,
+	0xa6, 0x2d, 0x6b, 0x28, 0x6c, 0x5b, 0x40, 0x22, 0x9c, 0xe9, 0xcc, 0x06, 0x2a, 0xdc, 0x9a, 0x34,
+	0xaf, 0xff, 0x5d, 0xb0, 0x3d, 0x9f, 0xbd, 0x74, 0x13, 0x96, 0x46, 0x5a, 0x5d, 0x00, 0x87, 0x8c,
+	0x7a, 0xce, 0x3f, 0x4a, 0xd0, 0xc2, 0x94, 0x13, 0x3f, 0x30, 0xb5, 0xff, 0x0a, 0xbe, 0xfe, 0x00,
+	0xde, 0x39, 0xf2, 0x27, 0x9c, 0xc6, 0xee, 0xdc, 0x44, 0xa5, 0x5c, 0xb2, 0xaa, 0xd8, 0x3b, 0xb3,
+	0x73, 0xd5, 0x0f, 0x00, 0x45, 0x71, 0x38, 0xa6, 0x8c, 0xe5, 0x57, 0x28, 0x1f, 0x75, 0x52, 0x4e,
+	0x6e, 0x0a, 0xf3, 0xe2, 0x33, 0x37, 0x4e, 0x02, 0xe9, 0xa7, 0x3a, 0xae, 0x7a, 0xf1, 0x19, 0x4e,
+	0x02, 0x51, 0x55, 0x74, 0xda, 0xd3, 0x53, 0x32, 0x95, 0xf9, 0xa4, 0x5c, 0xa5, 0x8b, 0xc6, 0x9e,
+	0x46, 0x73, 0x65, 0x84, 0xc7, 0x84, 0x9d, 0x50, 0xaf, 0x5b, 0xcd, 0x97, 0x91, 0x03, 0x05, 0x66,
+	0x35, 0xc2, 0x48, 0xd5, 0x72, 0x35, 0xc2, 0x08, 0xcd, 0x3c, 0x2e, 0xf5, 0xf3, 0x8f, 0xcb, 0x2a,
+	0x54, 0xc6, 0x27, 0xc4, 0x0f, 0x64, 0x79, 0x6b, 0x62, 0x45, 0x38, 0x91, 0x71, 0xb5, 0x49, 0xd9,
+	0x0f, 0xa0, 0xaa, 0x1c, 0xd3, 0xb5, 0x8a, 0x8a, 0xfc, 0x93, 0x49, 0x18, 0x4e, 0x9f, 0x4a, 0x21,
+	0xac, 0x85, 0x17, 0x4e, 0xae, 0xa5, 0x45, 0x93, 0xab, 0xf3, 0x31, 0xb4, 0xcd, 0x8e, 0x3a, 0x3b,
+	0x7f, 0x02, 0x35, 0xa6, 0x2e, 0xba, 0x6b, 0x15, 0x75, 0x4b, 0x33, 0xf1, 0x80, 0x8d, 0xbc, 0xf3,
+	0x0b, 0x58, 0xc1, 0x4a, 0x4e, 0x3a, 0xe1, 0xea, 0x75, 0xc7, 0xf9, 0x39, 0xac, 0xce, 0x6a, 0xd2,
+	0xc6, 0x65, 0x37, 0x19, 0x2b, 0xb6, 0x29, 0xf4, 0xfa, 0xde, 0xf4, 0x22, 0xcf, 0xf9, 0x15, 0x74,
+	0x94, 0x91, 0xcf, 0xc2, 0xe3, 0xaf, 0x51, 0xff, 0x56, 0xa1, 0x32, 0xf1, 0xa7, 0xbe, 0xea, 0x3b,
+	0x2b, 0x58, 0x11, 0x0e, 0x86, 0xeb, 0x39, 0xe5, 0xda, 0xb4, 0x9f, 0x81, 0xad, 0xfc, 0xe0, 0xa7,
+	0x45, 0xed, 0x52, 0xcf, 0x65, 0x2b, 0x9c, 0xcf, 0xa0, 0x91, 0xbb, 0x49, 0xd1, 0xae, 0x30, 0xaa,
+	0x4f, 0xd7, 0xc2, 0xf2, 0x5b, 0x34, 0x24, 0x27, 0x84, 0x9d, 0xb8, 0xe3, 0x30, 0xd1, 0x9d, 0x70,
+	0x0b, 0xdb, 0x02, 0xd9, 0x11, 0x80, 0xb0, 0x95, 0x8b, 0x26, 0x48, 0xb7, 0x2a, 0x8a, 0x70, 0x7e,
+	0x67, 0xc1, 0x8a, 0xea, 0x84, 0xd2, 0x8a, 0xf9, 0xcc, 0x67, 0x1c, 0x3d, 0x86, 0x56, 0xde, 0x19,
+	0xca, 0xe4, 0x79, 0x6f, 0x34, 0x73, 0xde, 0x60, 0xa2, 0x50, 0x30, 0xa9, 0xcb, 0x25, 0x5c, 0x07,
+	0x54, 0x5d, 0x01, 0xdb, 0x7c, 0x36, 0xe0, 0xcb, 0x85, 0x01, 0xbf, 0x9c, 0x0f, 0xf8, 0x7f, 0x97,
+	0xa0, 0x29, 0x0b, 0x95, 0xec, 0x72, 0xa3, 0x2b, 0xdd, 0xd1, 0xbd, 0xac, 0xcb, 0x5a, 0xfc, 0x33,
+	0xca, 0x74, 0x5d, 0x6b, 0x50, 0x57, 0x53, 0x86, 0xfe, 0xe9, 0x60, 0xe3, 0x5a, 0xa4, 0xe7, 0xd6,
+	0xf7, 0xa0, 0xc9, 0x78, 0xec, 0x47, 0xd4, 0xf5, 0x03, 0x8f, 0x9e, 0xea, 0x3a, 0xdb, 0x50, 0xd8,
+	0x40, 0x40, 0xe8, 0x43, 0xa8, 0x85, 0x09, 0x1f, 0x87, 0x53, 0x2a, 0x8b, 0x47, 0x7b, 0xd1, 0xff,
+	0x80, 0xfc, 0x51, 0x36, 0x5f, 0x28, 0x69, 0x6c, 0x96, 0x89, 0xac, 0x94, 0x75, 0x3a, 0x9f, 0x95,
+	0x55, 0xdd, 0x15, 0x2b, 0xdc, 0x54, 0xb2, 0x19, 0x57, 0xd6, 0x0a, 0x5d, 0x59, 0xcf, 0xbb, 0xf2,
+	0x21, 0xd4, 0xf4, 0x8e, 0xa8, 0x01, 0xb5, 0xe1, 0xe1, 0xce, 0xce, 0xde, 0x70, 0xd8, 0x59, 0x12,
+	0xc4, 0xd3, 0xed, 0xc1, 0xb3, 0x43, 0xbc, 0xd7, 0xb1, 0x04, 0xf1, 0xe2, 0xe9, 0xd3, 0x67, 0x83,
+	0xe7, 0x7b, 0x9d, 0x92, 0xf3, 0x2e, 0xac, 0xc9, 0x09, 0x25, 0x6f, 0xb5, 0x09, 0x67, 0xc7, 0x85,
+	0xd5, 0x3c, 0xce, 0xbe, 0xf1, 0x24, 0x1a, 0xc2, 0x77, 0xce, 0x6d, 0xa0, 0x13, 0xe9, 0xa7, 0x50,
+	0x8f, 0x35, 0xa6, 0xf3, 0x68, 0xfd, 0x62, 0x4f, 0xe3, 0x54, 0xde, 0xf9, 0x31, 0x74, 0x87, 0x94,
+	0xeb, 0xb9, 0x5a, 0x0f, 0x23, 0xc6, 0xf2, 0x1b, 0x60, 0x13, 0xc3, 0x30, 0x13, 0x4b, 0x0a, 0x38,
+	0x3e, 0xac, 0x2d, 0x58, 0xa9, 0x4d, 0xea, 0x41, 0x3d, 0x8a, 0xe9, 0x6b, 0x3f, 0x4c, 0x98, 0x5e,
+	0x99, 0xd2, 0x22, 0x53, 0xe5, 0xbb, 0xa9, 0xd2, 0x41, 0x7e, 0xcb, 0xad, 0xcc, 0x4c, 0x62, 0x1a,
+	0xa2, 0x14, 0xd8, 0xfa, 0x8b, 0x0d, 0x9d, 0x6c, 0x3e, 0xc4, 0xf2, 0x40, 0x68, 0x17, 0x2a, 0x12,
+	0x43, 0x6b, 0x05, 0x53, 0xff, 0xc0, 0xeb, 0xad, 0x17, 0xb0, 0x74, 0x39, 0x71, 0x96, 0xd0, 0xe7,
+	0x50, 0xd7, 0xb3, 0x35, 0x45, 0xfd, 0xcb, 0x7e, 0x1f, 0xf4, 0xee, 0x5e, 0x26, 0xa1, 0xc6, 0x73,
+	0x67, 0x69, 0xc3, 0x7a, 0x68, 0xa1, 0xe7, 0x50, 0x91, 0x06, 0xa3, 0x1b, 0x17, 0xfd, 0x08, 0xeb,
+	0xdd, 0xbe, 0x88, 0x9b, 0x5a, 0xba, 0x61, 0x21, 0x0c, 0xcd, 0xfc, 0x3c, 0x7b, 0xd1, 0xc1, 0xef,
+	0x2c, 0x60, 0xcd, 0x8f, 0xc2, 0xce, 0x12, 0x7a, 0x01, 0x55, 0xfd, 0x2b, 0xe0, 0x66, 0x81, 0x36,
+	0xc5, 0xee, 0x7d, 0xef, 0x42, 0x76, 0xa6, 0x70, 0x17, 0x2a, 0x6a, 0xc2, 0xe8, 0x2d, 0x1e, 0xaf,
+	0x44, 0x5e, 0xf4, 0x2e, 0x1e, 0xbd, 0x9c, 0x25, 0xf4, 0x29, 0xd8, 0xe9, 0x68, 0x88, 0x16, 0xdc,
+	0x62, 0x7e, 0x90, 0xec, 0xf5, 0x2f, 0xe0, 0xcb, 0x2d, 0x9d, 0xa5, 0x87, 0x16, 0xfa, 0x25, 0x54,
+	0x54, 0xe7, 0xbb, 0x5e, 0xd0, 0xb6, 0xea, 0xb0, 0xef, 0xdd, 0x2a, 0xe4, 0xeb, 0x4c, 0x5f, 0x42,
+	0x9f, 0x40, 0x55, 0xbd, 0x4b, 0xa8, 0xf0, 0xc5, 0x32, 0xda, 0xfa, 0xc5, 0x02, 0xa9, 0x3a, 0x17,
+	0x9a, 0xf9, 0xc7, 0x1b, 0xdd, 0x59, 0xb4, 0x66, 0xae, 0x4d, 0xe8, 0xdd, 0xbd, 0x4c, 0x2c, 0xdd,
+	0xe0, 0x33, 0xb0, 0xd3, 0xf7, 0x17, 0x39, 0x45, 0x16, 0x65, 0x2f, 0x7f, 0xef, 0xf6, 0x85, 0x32,
+	0xa9, 0xde, 0x11, 0x5c, 0x9f, 0x2b, 0x88, 0xe8, 0x92, 0xe2, 0xd3, 0xbb, 0xbf, 0xe8, 0xf2, 0x8b,
+	0xaa, 0xaa, 0xd8, 0xa3, 0x95, 0xe7, 0x30, 0x74, 0xc9, 0x33, 0x62, 0x0a, 0x6f, 0xef, 0xde, 0xa5,
+	0x72, 0xe9, 0x1e, 0x01, 0x5c, 0x9f, 0xab, 0x65, 0x68, 0xc1, 0xdf, 0xc4, 0xa2, 0x52, 0xd9, 0xbb,
+	0xff, 0x95, 0x64, 0xcd, 0x7e, 0x4f, 0x96, 0x3f, 0x2f, 0x45, 0xa3, 0x51, 0x55, 0xfe, 0x22, 0x78,
+	0xfc, 0xbf, 0x01, 0x00, 0xb9, 0x58, 0xa1, 0xd7, 0x06, 0x1b, 0x00, 0x00,
 }
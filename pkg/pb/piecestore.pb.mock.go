@@ -44,6 +44,24 @@ func (m *MockPieceStoreRoutesClient) Dashboard(ctx context.Context, req *Dashboa
 	return nil, nil
 }
 
+// PartialPiece mocks base method
+func (m *MockPieceStoreRoutesClient) PartialPiece(arg0 context.Context, arg1 *PieceId, arg2 ...grpc.CallOption) (*PartialPieceSummary, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PartialPiece", varargs...)
+	ret0, _ := ret[0].(*PartialPieceSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PartialPiece indicates an expected call of PartialPiece
+func (mr *MockPieceStoreRoutesClientMockRecorder) PartialPiece(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PartialPiece", reflect.TypeOf((*MockPieceStoreRoutesClient)(nil).PartialPiece), varargs...)
+}
+
 // Delete mocks base method
 func (m *MockPieceStoreRoutesClient) Delete(arg0 context.Context, arg1 *PieceDelete, arg2 ...grpc.CallOption) (*PieceDeleteSummary, error) {
 	varargs := []interface{}{arg0, arg1}
@@ -134,6 +152,132 @@ func (mr *MockPieceStoreRoutesClientMockRecorder) Store(arg0 interface{}, arg1 .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockPieceStoreRoutesClient)(nil).Store), varargs...)
 }
 
+// Usage mocks base method
+func (m *MockPieceStoreRoutesClient) Usage(arg0 context.Context, arg1 *UsageRequest, arg2 ...grpc.CallOption) (*UsageResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Usage", varargs...)
+	ret0, _ := ret[0].(*UsageResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Usage indicates an expected call of Usage
+func (mr *MockPieceStoreRoutesClientMockRecorder) Usage(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Usage", reflect.TypeOf((*MockPieceStoreRoutesClient)(nil).Usage), varargs...)
+}
+
+// Retain mocks base method
+func (m *MockPieceStoreRoutesClient) Retain(arg0 context.Context, arg1 *RetainRequest, arg2 ...grpc.CallOption) (*RetainResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Retain", varargs...)
+	ret0, _ := ret[0].(*RetainResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Retain indicates an expected call of Retain
+func (mr *MockPieceStoreRoutesClientMockRecorder) Retain(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retain", reflect.TypeOf((*MockPieceStoreRoutesClient)(nil).Retain), varargs...)
+}
+
+// RestoreTrash mocks base method
+func (m *MockPieceStoreRoutesClient) RestoreTrash(arg0 context.Context, arg1 *RestoreTrashRequest, arg2 ...grpc.CallOption) (*RestoreTrashResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RestoreTrash", varargs...)
+	ret0, _ := ret[0].(*RestoreTrashResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreTrash indicates an expected call of RestoreTrash
+func (mr *MockPieceStoreRoutesClientMockRecorder) RestoreTrash(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTrash", reflect.TypeOf((*MockPieceStoreRoutesClient)(nil).RestoreTrash), varargs...)
+}
+
+// RetainLog mocks base method
+func (m *MockPieceStoreRoutesClient) RetainLog(arg0 context.Context, arg1 *RetainLogRequest, arg2 ...grpc.CallOption) (*RetainLogResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RetainLog", varargs...)
+	ret0, _ := ret[0].(*RetainLogResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetainLog indicates an expected call of RetainLog
+func (mr *MockPieceStoreRoutesClientMockRecorder) RetainLog(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetainLog", reflect.TypeOf((*MockPieceStoreRoutesClient)(nil).RetainLog), varargs...)
+}
+
+// StoreAuditReceipt mocks base method
+func (m *MockPieceStoreRoutesClient) StoreAuditReceipt(arg0 context.Context, arg1 *AuditReceipt, arg2 ...grpc.CallOption) (*StoreAuditReceiptResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StoreAuditReceipt", varargs...)
+	ret0, _ := ret[0].(*StoreAuditReceiptResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoreAuditReceipt indicates an expected call of StoreAuditReceipt
+func (mr *MockPieceStoreRoutesClientMockRecorder) StoreAuditReceipt(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreAuditReceipt", reflect.TypeOf((*MockPieceStoreRoutesClient)(nil).StoreAuditReceipt), varargs...)
+}
+
+// AuditReceipts mocks base method
+func (m *MockPieceStoreRoutesClient) AuditReceipts(arg0 context.Context, arg1 *AuditReceiptsRequest, arg2 ...grpc.CallOption) (*AuditReceiptsResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AuditReceipts", varargs...)
+	ret0, _ := ret[0].(*AuditReceiptsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuditReceipts indicates an expected call of AuditReceipts
+func (mr *MockPieceStoreRoutesClientMockRecorder) AuditReceipts(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditReceipts", reflect.TypeOf((*MockPieceStoreRoutesClient)(nil).AuditReceipts), varargs...)
+}
+
+// SetAllocatedSpace mocks base method
+func (m *MockPieceStoreRoutesClient) SetAllocatedSpace(arg0 context.Context, arg1 *SetAllocatedSpaceRequest, arg2 ...grpc.CallOption) (*SetAllocatedSpaceResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetAllocatedSpace", varargs...)
+	ret0, _ := ret[0].(*SetAllocatedSpaceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAllocatedSpace indicates an expected call of SetAllocatedSpace
+func (mr *MockPieceStoreRoutesClientMockRecorder) SetAllocatedSpace(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAllocatedSpace", reflect.TypeOf((*MockPieceStoreRoutesClient)(nil).SetAllocatedSpace), varargs...)
+}
+
 // MockPieceStoreRoutes_RetrieveClient is a mock of PieceStoreRoutes_RetrieveClient interface
 type MockPieceStoreRoutes_RetrieveClient struct {
 	ctrl     *gomock.Controller
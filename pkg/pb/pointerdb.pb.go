@@ -42,7 +42,7 @@ func (x RedundancyScheme_SchemeType) String() string {
 	return proto.EnumName(RedundancyScheme_SchemeType_name, int32(x))
 }
 func (RedundancyScheme_SchemeType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_3f3e047a48f87feb, []int{0, 0}
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{0, 0}
 }
 
 type Pointer_DataType int32
@@ -65,7 +65,36 @@ func (x Pointer_DataType) String() string {
 	return proto.EnumName(Pointer_DataType_name, int32(x))
 }
 func (Pointer_DataType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_3f3e047a48f87feb, []int{3, 0}
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{3, 0}
+}
+
+type PointerMutation_Operation int32
+
+const (
+	PointerMutation_PUT      PointerMutation_Operation = 0
+	PointerMutation_DELETE   PointerMutation_Operation = 1
+	PointerMutation_UNDELETE PointerMutation_Operation = 2
+	PointerMutation_EXPIRE   PointerMutation_Operation = 3
+)
+
+var PointerMutation_Operation_name = map[int32]string{
+	0: "PUT",
+	1: "DELETE",
+	2: "UNDELETE",
+	3: "EXPIRE",
+}
+var PointerMutation_Operation_value = map[string]int32{
+	"PUT":      0,
+	"DELETE":   1,
+	"UNDELETE": 2,
+	"EXPIRE":   3,
+}
+
+func (x PointerMutation_Operation) String() string {
+	return proto.EnumName(PointerMutation_Operation_name, int32(x))
+}
+func (PointerMutation_Operation) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{31, 0}
 }
 
 type RedundancyScheme struct {
@@ -85,7 +114,7 @@ func (m *RedundancyScheme) Reset()         { *m = RedundancyScheme{} }
 func (m *RedundancyScheme) String() string { return proto.CompactTextString(m) }
 func (*RedundancyScheme) ProtoMessage()    {}
 func (*RedundancyScheme) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_3f3e047a48f87feb, []int{0}
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{0}
 }
 func (m *RedundancyScheme) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RedundancyScheme.Unmarshal(m, b)
@@ -159,7 +188,7 @@ func (m *RemotePiece) Reset()         { *m = RemotePiece{} }
 func (m *RemotePiece) String() string { return proto.CompactTextString(m) }
 func (*RemotePiece) ProtoMessage()    {}
 func (*RemotePiece) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_3f3e047a48f87feb, []int{1}
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{1}
 }
 func (m *RemotePiece) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemotePiece.Unmarshal(m, b)
@@ -201,7 +230,7 @@ func (m *RemoteSegment) Reset()         { *m = RemoteSegment{} }
 func (m *RemoteSegment) String() string { return proto.CompactTextString(m) }
 func (*RemoteSegment) ProtoMessage()    {}
 func (*RemoteSegment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_3f3e047a48f87feb, []int{2}
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{2}
 }
 func (m *RemoteSegment) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemoteSegment.Unmarshal(m, b)
@@ -250,23 +279,28 @@ func (m *RemoteSegment) GetMerkleRoot() []byte {
 }
 
 type Pointer struct {
-	Type                 Pointer_DataType     `protobuf:"varint,1,opt,name=type,proto3,enum=pointerdb.Pointer_DataType" json:"type,omitempty"`
-	InlineSegment        []byte               `protobuf:"bytes,3,opt,name=inline_segment,json=inlineSegment,proto3" json:"inline_segment,omitempty"`
-	Remote               *RemoteSegment       `protobuf:"bytes,4,opt,name=remote" json:"remote,omitempty"`
-	SegmentSize          int64                `protobuf:"varint,5,opt,name=segment_size,json=segmentSize,proto3" json:"segment_size,omitempty"`
-	CreationDate         *timestamp.Timestamp `protobuf:"bytes,6,opt,name=creation_date,json=creationDate" json:"creation_date,omitempty"`
-	ExpirationDate       *timestamp.Timestamp `protobuf:"bytes,7,opt,name=expiration_date,json=expirationDate" json:"expiration_date,omitempty"`
-	Metadata             []byte               `protobuf:"bytes,8,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Type           Pointer_DataType     `protobuf:"varint,1,opt,name=type,proto3,enum=pointerdb.Pointer_DataType" json:"type,omitempty"`
+	InlineSegment  []byte               `protobuf:"bytes,3,opt,name=inline_segment,json=inlineSegment,proto3" json:"inline_segment,omitempty"`
+	Remote         *RemoteSegment       `protobuf:"bytes,4,opt,name=remote" json:"remote,omitempty"`
+	SegmentSize    int64                `protobuf:"varint,5,opt,name=segment_size,json=segmentSize,proto3" json:"segment_size,omitempty"`
+	CreationDate   *timestamp.Timestamp `protobuf:"bytes,6,opt,name=creation_date,json=creationDate" json:"creation_date,omitempty"`
+	ExpirationDate *timestamp.Timestamp `protobuf:"bytes,7,opt,name=expiration_date,json=expirationDate" json:"expiration_date,omitempty"`
+	Metadata       []byte               `protobuf:"bytes,8,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// version is incremented by every put of the pointer
+	Version int64 `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
+	// project_id is the project whose quota the pointer counts against,
+	// set by the satellite
+	ProjectId            []byte   `protobuf:"bytes,10,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Pointer) Reset()         { *m = Pointer{} }
 func (m *Pointer) String() string { return proto.CompactTextString(m) }
 func (*Pointer) ProtoMessage()    {}
 func (*Pointer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_3f3e047a48f87feb, []int{3}
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{3}
 }
 func (m *Pointer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Pointer.Unmarshal(m, b)
@@ -335,10 +369,28 @@ func (m *Pointer) GetMetadata() []byte {
 	return nil
 }
 
+func (m *Pointer) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Pointer) GetProjectId() []byte {
+	if m != nil {
+		return m.ProjectId
+	}
+	return nil
+}
+
 // PutRequest is a request message for the Put rpc call
 type PutRequest struct {
-	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	Pointer              *Pointer `protobuf:"bytes,2,opt,name=pointer" json:"pointer,omitempty"`
+	Path    string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Pointer *Pointer `protobuf:"bytes,2,opt,name=pointer" json:"pointer,omitempty"`
+	// a conditional put fails with Aborted unless the pointer at path has
+	// expected_version, version 0 expects no pointer at path
+	Conditional          bool     `protobuf:"varint,3,opt,name=conditional,proto3" json:"conditional,omitempty"`
+	ExpectedVersion      int64    `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -348,7 +400,7 @@ func (m *PutRequest) Reset()         { *m = PutRequest{} }
 func (m *PutRequest) String() string { return proto.CompactTextString(m) }
 func (*PutRequest) ProtoMessage()    {}
 func (*PutRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_3f3e047a48f87feb, []int{4}
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{4}
 }
 func (m *PutRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutRequest.Unmarshal(m, b)
@@ -382,9 +434,25 @@ func (m *PutRequest) GetPointer() *Pointer {
 	return nil
 }
 
+func (m *PutRequest) GetConditional() bool {
+	if m != nil {
+		return m.Conditional
+	}
+	return false
+}
+
+func (m *PutRequest) GetExpectedVersion() int64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
 // GetRequest is a request message for the Get rpc call
 type GetRequest struct {
-	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// meta_flags select the fields of the pointer returned, all when 0
+	MetaFlags            uint32   `protobuf:"fixed32,2,opt,name=meta_flags,json=metaFlags,proto3" json:"meta_flags,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -394,7 +462,7 @@ func (m *GetRequest) Reset()         { *m = GetRequest{} }
 func (m *GetRequest) String() string { return proto.CompactTextString(m) }
 func (*GetRequest) ProtoMessage()    {}
 func (*GetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_3f3e047a48f87feb, []int{5}
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{5}
 }
 func (m *GetRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetRequest.Unmarshal(m, b)
@@ -421,14 +489,23 @@ func (m *GetRequest) GetPath() string {
 	return ""
 }
 
+func (m *GetRequest) GetMetaFlags() uint32 {
+	if m != nil {
+		return m.MetaFlags
+	}
+	return 0
+}
+
 // ListRequest is a request message for the List rpc call
 type ListRequest struct {
-	Prefix               string   `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
-	StartAfter           string   `protobuf:"bytes,2,opt,name=start_after,json=startAfter,proto3" json:"start_after,omitempty"`
-	EndBefore            string   `protobuf:"bytes,3,opt,name=end_before,json=endBefore,proto3" json:"end_before,omitempty"`
-	Recursive            bool     `protobuf:"varint,4,opt,name=recursive,proto3" json:"recursive,omitempty"`
-	Limit                int32    `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
-	MetaFlags            uint32   `protobuf:"fixed32,6,opt,name=meta_flags,json=metaFlags,proto3" json:"meta_flags,omitempty"`
+	Prefix     string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	StartAfter string `protobuf:"bytes,2,opt,name=start_after,json=startAfter,proto3" json:"start_after,omitempty"`
+	EndBefore  string `protobuf:"bytes,3,opt,name=end_before,json=endBefore,proto3" json:"end_before,omitempty"`
+	Recursive  bool   `protobuf:"varint,4,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	Limit      int32  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	MetaFlags  uint32 `protobuf:"fixed32,6,opt,name=meta_flags,json=metaFlags,proto3" json:"meta_flags,omitempty"`
+	// name_prefix limits the listing to paths starting with prefix/name_prefix
+	NamePrefix           string   `protobuf:"bytes,7,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -438,7 +515,7 @@ func (m *ListRequest) Reset()         { *m = ListRequest{} }
 func (m *ListRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRequest) ProtoMessage()    {}
 func (*ListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_3f3e047a48f87feb, []int{6}
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{6}
 }
 func (m *ListRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
@@ -500,8 +577,16 @@ func (m *ListRequest) GetMetaFlags() uint32 {
 	return 0
 }
 
+func (m *ListRequest) GetNamePrefix() string {
+	if m != nil {
+		return m.NamePrefix
+	}
+	return ""
+}
+
 // PutResponse is a response message for the Put rpc call
 type PutResponse struct {
+	Version              int64    `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -511,7 +596,7 @@ func (m *PutResponse) Reset()         { *m = PutResponse{} }
 func (m *PutResponse) String() string { return proto.CompactTextString(m) }
 func (*PutResponse) ProtoMessage()    {}
 func (*PutResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_3f3e047a48f87feb, []int{7}
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{7}
 }
 func (m *PutResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutResponse.Unmarshal(m, b)
@@ -531,12 +616,20 @@ func (m *PutResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PutResponse proto.InternalMessageInfo
 
+func (m *PutResponse) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 // GetResponse is a response message for the Get rpc call
 type GetResponse struct {
 	Pointer              *Pointer                  `protobuf:"bytes,1,opt,name=pointer" json:"pointer,omitempty"`
 	Nodes                []*Node                   `protobuf:"bytes,2,rep,name=nodes" json:"nodes,omitempty"`
 	Pba                  *PayerBandwidthAllocation `protobuf:"bytes,3,opt,name=pba" json:"pba,omitempty"`
 	Authorization        *SignedMessage            `protobuf:"bytes,4,opt,name=authorization" json:"authorization,omitempty"`
+	SignedAddresses      *SignedNodeAddresses      `protobuf:"bytes,5,opt,name=signed_addresses,json=signedAddresses" json:"signed_addresses,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
 	XXX_unrecognized     []byte                    `json:"-"`
 	XXX_sizecache        int32                     `json:"-"`
@@ -546,7 +639,7 @@ func (m *GetResponse) Reset()         { *m = GetResponse{} }
 func (m *GetResponse) String() string { return proto.CompactTextString(m) }
 func (*GetResponse) ProtoMessage()    {}
 func (*GetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_3f3e047a48f87feb, []int{8}
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{8}
 }
 func (m *GetResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetResponse.Unmarshal(m, b)
@@ -594,6 +687,13 @@ func (m *GetResponse) GetAuthorization() *SignedMessage {
 	return nil
 }
 
+func (m *GetResponse) GetSignedAddresses() *SignedNodeAddresses {
+	if m != nil {
+		return m.SignedAddresses
+	}
+	return nil
+}
+
 // ListResponse is a response message for the List rpc call
 type ListResponse struct {
 	Items                []*ListResponse_Item `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
@@ -607,7 +707,7 @@ func (m *ListResponse) Reset()         { *m = ListResponse{} }
 func (m *ListResponse) String() string { return proto.CompactTextString(m) }
 func (*ListResponse) ProtoMessage()    {}
 func (*ListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_3f3e047a48f87feb, []int{9}
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{9}
 }
 func (m *ListResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListResponse.Unmarshal(m, b)
@@ -654,7 +754,7 @@ func (m *ListResponse_Item) Reset()         { *m = ListResponse_Item{} }
 func (m *ListResponse_Item) String() string { return proto.CompactTextString(m) }
 func (*ListResponse_Item) ProtoMessage()    {}
 func (*ListResponse_Item) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_3f3e047a48f87feb, []int{9, 0}
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{9, 0}
 }
 func (m *ListResponse_Item) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListResponse_Item.Unmarshal(m, b)
@@ -706,7 +806,7 @@ func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
 func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRequest) ProtoMessage()    {}
 func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_3f3e047a48f87feb, []int{10}
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{10}
 }
 func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
@@ -744,7 +844,7 @@ func (m *DeleteResponse) Reset()         { *m = DeleteResponse{} }
 func (m *DeleteResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteResponse) ProtoMessage()    {}
 func (*DeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_3f3e047a48f87feb, []int{11}
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{11}
 }
 func (m *DeleteResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteResponse.Unmarshal(m, b)
@@ -764,6 +864,403 @@ func (m *DeleteResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DeleteResponse proto.InternalMessageInfo
 
+type UndeleteRequest struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UndeleteRequest) Reset()         { *m = UndeleteRequest{} }
+func (m *UndeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*UndeleteRequest) ProtoMessage()    {}
+func (*UndeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{12}
+}
+func (m *UndeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UndeleteRequest.Unmarshal(m, b)
+}
+func (m *UndeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UndeleteRequest.Marshal(b, m, deterministic)
+}
+func (dst *UndeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UndeleteRequest.Merge(dst, src)
+}
+func (m *UndeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_UndeleteRequest.Size(m)
+}
+func (m *UndeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UndeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UndeleteRequest proto.InternalMessageInfo
+
+func (m *UndeleteRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+// UndeleteResponse is a response message for the Undelete rpc call
+type UndeleteResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UndeleteResponse) Reset()         { *m = UndeleteResponse{} }
+func (m *UndeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*UndeleteResponse) ProtoMessage()    {}
+func (*UndeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{13}
+}
+func (m *UndeleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UndeleteResponse.Unmarshal(m, b)
+}
+func (m *UndeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UndeleteResponse.Marshal(b, m, deterministic)
+}
+func (dst *UndeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UndeleteResponse.Merge(dst, src)
+}
+func (m *UndeleteResponse) XXX_Size() int {
+	return xxx_messageInfo_UndeleteResponse.Size(m)
+}
+func (m *UndeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UndeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UndeleteResponse proto.InternalMessageInfo
+
+// BatchGetRequest is a request message for the BatchGet rpc call
+type BatchGetRequest struct {
+	Paths []string `protobuf:"bytes,1,rep,name=paths" json:"paths,omitempty"`
+	// meta_flags select the fields of the pointers returned, all when 0
+	MetaFlags            uint32   `protobuf:"fixed32,2,opt,name=meta_flags,json=metaFlags,proto3" json:"meta_flags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchGetRequest) Reset()         { *m = BatchGetRequest{} }
+func (m *BatchGetRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchGetRequest) ProtoMessage()    {}
+func (*BatchGetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{14}
+}
+func (m *BatchGetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchGetRequest.Unmarshal(m, b)
+}
+func (m *BatchGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchGetRequest.Marshal(b, m, deterministic)
+}
+func (dst *BatchGetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchGetRequest.Merge(dst, src)
+}
+func (m *BatchGetRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchGetRequest.Size(m)
+}
+func (m *BatchGetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchGetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchGetRequest proto.InternalMessageInfo
+
+func (m *BatchGetRequest) GetPaths() []string {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
+
+func (m *BatchGetRequest) GetMetaFlags() uint32 {
+	if m != nil {
+		return m.MetaFlags
+	}
+	return 0
+}
+
+// BatchGetResponse is a response message for the BatchGet rpc call, the items
+// are in the order of the requested paths and missing paths have no pointer
+type BatchGetResponse struct {
+	Items                []*BatchGetResponse_Item `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *BatchGetResponse) Reset()         { *m = BatchGetResponse{} }
+func (m *BatchGetResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchGetResponse) ProtoMessage()    {}
+func (*BatchGetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{15}
+}
+func (m *BatchGetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchGetResponse.Unmarshal(m, b)
+}
+func (m *BatchGetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchGetResponse.Marshal(b, m, deterministic)
+}
+func (dst *BatchGetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchGetResponse.Merge(dst, src)
+}
+func (m *BatchGetResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchGetResponse.Size(m)
+}
+func (m *BatchGetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchGetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchGetResponse proto.InternalMessageInfo
+
+func (m *BatchGetResponse) GetItems() []*BatchGetResponse_Item {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type BatchGetResponse_Item struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Pointer              *Pointer `protobuf:"bytes,2,opt,name=pointer" json:"pointer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchGetResponse_Item) Reset()         { *m = BatchGetResponse_Item{} }
+func (m *BatchGetResponse_Item) String() string { return proto.CompactTextString(m) }
+func (*BatchGetResponse_Item) ProtoMessage()    {}
+func (*BatchGetResponse_Item) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{15, 0}
+}
+func (m *BatchGetResponse_Item) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchGetResponse_Item.Unmarshal(m, b)
+}
+func (m *BatchGetResponse_Item) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchGetResponse_Item.Marshal(b, m, deterministic)
+}
+func (dst *BatchGetResponse_Item) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchGetResponse_Item.Merge(dst, src)
+}
+func (m *BatchGetResponse_Item) XXX_Size() int {
+	return xxx_messageInfo_BatchGetResponse_Item.Size(m)
+}
+func (m *BatchGetResponse_Item) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchGetResponse_Item.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchGetResponse_Item proto.InternalMessageInfo
+
+func (m *BatchGetResponse_Item) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *BatchGetResponse_Item) GetPointer() *Pointer {
+	if m != nil {
+		return m.Pointer
+	}
+	return nil
+}
+
+// BatchPutRequest is a request message for the BatchPut rpc call
+type BatchPutRequest struct {
+	Items                []*BatchPutRequest_Item `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *BatchPutRequest) Reset()         { *m = BatchPutRequest{} }
+func (m *BatchPutRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchPutRequest) ProtoMessage()    {}
+func (*BatchPutRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{16}
+}
+func (m *BatchPutRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchPutRequest.Unmarshal(m, b)
+}
+func (m *BatchPutRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchPutRequest.Marshal(b, m, deterministic)
+}
+func (dst *BatchPutRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchPutRequest.Merge(dst, src)
+}
+func (m *BatchPutRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchPutRequest.Size(m)
+}
+func (m *BatchPutRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchPutRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchPutRequest proto.InternalMessageInfo
+
+func (m *BatchPutRequest) GetItems() []*BatchPutRequest_Item {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type BatchPutRequest_Item struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Pointer              *Pointer `protobuf:"bytes,2,opt,name=pointer" json:"pointer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchPutRequest_Item) Reset()         { *m = BatchPutRequest_Item{} }
+func (m *BatchPutRequest_Item) String() string { return proto.CompactTextString(m) }
+func (*BatchPutRequest_Item) ProtoMessage()    {}
+func (*BatchPutRequest_Item) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{16, 0}
+}
+func (m *BatchPutRequest_Item) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchPutRequest_Item.Unmarshal(m, b)
+}
+func (m *BatchPutRequest_Item) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchPutRequest_Item.Marshal(b, m, deterministic)
+}
+func (dst *BatchPutRequest_Item) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchPutRequest_Item.Merge(dst, src)
+}
+func (m *BatchPutRequest_Item) XXX_Size() int {
+	return xxx_messageInfo_BatchPutRequest_Item.Size(m)
+}
+func (m *BatchPutRequest_Item) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchPutRequest_Item.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchPutRequest_Item proto.InternalMessageInfo
+
+func (m *BatchPutRequest_Item) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *BatchPutRequest_Item) GetPointer() *Pointer {
+	if m != nil {
+		return m.Pointer
+	}
+	return nil
+}
+
+// BatchPutResponse is a response message for the BatchPut rpc call
+type BatchPutResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchPutResponse) Reset()         { *m = BatchPutResponse{} }
+func (m *BatchPutResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchPutResponse) ProtoMessage()    {}
+func (*BatchPutResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{17}
+}
+func (m *BatchPutResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchPutResponse.Unmarshal(m, b)
+}
+func (m *BatchPutResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchPutResponse.Marshal(b, m, deterministic)
+}
+func (dst *BatchPutResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchPutResponse.Merge(dst, src)
+}
+func (m *BatchPutResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchPutResponse.Size(m)
+}
+func (m *BatchPutResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchPutResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchPutResponse proto.InternalMessageInfo
+
+// BatchDeleteRequest is a request message for the BatchDelete rpc call
+type BatchDeleteRequest struct {
+	Paths                []string `protobuf:"bytes,1,rep,name=paths" json:"paths,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchDeleteRequest) Reset()         { *m = BatchDeleteRequest{} }
+func (m *BatchDeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchDeleteRequest) ProtoMessage()    {}
+func (*BatchDeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{18}
+}
+func (m *BatchDeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchDeleteRequest.Unmarshal(m, b)
+}
+func (m *BatchDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchDeleteRequest.Marshal(b, m, deterministic)
+}
+func (dst *BatchDeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchDeleteRequest.Merge(dst, src)
+}
+func (m *BatchDeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchDeleteRequest.Size(m)
+}
+func (m *BatchDeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchDeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchDeleteRequest proto.InternalMessageInfo
+
+func (m *BatchDeleteRequest) GetPaths() []string {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
+
+// BatchDeleteResponse is a response message for the BatchDelete rpc call
+type BatchDeleteResponse struct {
+	NotFound             []string `protobuf:"bytes,1,rep,name=not_found,json=notFound" json:"not_found,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchDeleteResponse) Reset()         { *m = BatchDeleteResponse{} }
+func (m *BatchDeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchDeleteResponse) ProtoMessage()    {}
+func (*BatchDeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{19}
+}
+func (m *BatchDeleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchDeleteResponse.Unmarshal(m, b)
+}
+func (m *BatchDeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchDeleteResponse.Marshal(b, m, deterministic)
+}
+func (dst *BatchDeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchDeleteResponse.Merge(dst, src)
+}
+func (m *BatchDeleteResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchDeleteResponse.Size(m)
+}
+func (m *BatchDeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchDeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchDeleteResponse proto.InternalMessageInfo
+
+func (m *BatchDeleteResponse) GetNotFound() []string {
+	if m != nil {
+		return m.NotFound
+	}
+	return nil
+}
+
 // IterateRequest is a request message for the Iterate rpc call
 type IterateRequest struct {
 	Prefix               string   `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
@@ -779,7 +1276,7 @@ func (m *IterateRequest) Reset()         { *m = IterateRequest{} }
 func (m *IterateRequest) String() string { return proto.CompactTextString(m) }
 func (*IterateRequest) ProtoMessage()    {}
 func (*IterateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_3f3e047a48f87feb, []int{12}
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{20}
 }
 func (m *IterateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IterateRequest.Unmarshal(m, b)
@@ -838,7 +1335,7 @@ func (m *PayerBandwidthAllocationRequest) Reset()         { *m = PayerBandwidthA
 func (m *PayerBandwidthAllocationRequest) String() string { return proto.CompactTextString(m) }
 func (*PayerBandwidthAllocationRequest) ProtoMessage()    {}
 func (*PayerBandwidthAllocationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_3f3e047a48f87feb, []int{13}
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{21}
 }
 func (m *PayerBandwidthAllocationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PayerBandwidthAllocationRequest.Unmarshal(m, b)
@@ -876,7 +1373,7 @@ func (m *PayerBandwidthAllocationResponse) Reset()         { *m = PayerBandwidth
 func (m *PayerBandwidthAllocationResponse) String() string { return proto.CompactTextString(m) }
 func (*PayerBandwidthAllocationResponse) ProtoMessage()    {}
 func (*PayerBandwidthAllocationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_pointerdb_3f3e047a48f87feb, []int{14}
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{22}
 }
 func (m *PayerBandwidthAllocationResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PayerBandwidthAllocationResponse.Unmarshal(m, b)
@@ -903,9 +1400,951 @@ func (m *PayerBandwidthAllocationResponse) GetPba() *PayerBandwidthAllocation {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*RedundancyScheme)(nil), "pointerdb.RedundancyScheme")
-	proto.RegisterType((*RemotePiece)(nil), "pointerdb.RemotePiece")
+type SegmentLimitsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SegmentLimitsRequest) Reset()         { *m = SegmentLimitsRequest{} }
+func (m *SegmentLimitsRequest) String() string { return proto.CompactTextString(m) }
+func (*SegmentLimitsRequest) ProtoMessage()    {}
+func (*SegmentLimitsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{23}
+}
+func (m *SegmentLimitsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SegmentLimitsRequest.Unmarshal(m, b)
+}
+func (m *SegmentLimitsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SegmentLimitsRequest.Marshal(b, m, deterministic)
+}
+func (dst *SegmentLimitsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SegmentLimitsRequest.Merge(dst, src)
+}
+func (m *SegmentLimitsRequest) XXX_Size() int {
+	return xxx_messageInfo_SegmentLimitsRequest.Size(m)
+}
+func (m *SegmentLimitsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SegmentLimitsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SegmentLimitsRequest proto.InternalMessageInfo
+
+type SegmentLimitsResponse struct {
+	MinSegmentSize       int64    `protobuf:"varint,1,opt,name=min_segment_size,json=minSegmentSize,proto3" json:"min_segment_size,omitempty"`
+	MaxSegmentSize       int64    `protobuf:"varint,2,opt,name=max_segment_size,json=maxSegmentSize,proto3" json:"max_segment_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SegmentLimitsResponse) Reset()         { *m = SegmentLimitsResponse{} }
+func (m *SegmentLimitsResponse) String() string { return proto.CompactTextString(m) }
+func (*SegmentLimitsResponse) ProtoMessage()    {}
+func (*SegmentLimitsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{24}
+}
+func (m *SegmentLimitsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SegmentLimitsResponse.Unmarshal(m, b)
+}
+func (m *SegmentLimitsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SegmentLimitsResponse.Marshal(b, m, deterministic)
+}
+func (dst *SegmentLimitsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SegmentLimitsResponse.Merge(dst, src)
+}
+func (m *SegmentLimitsResponse) XXX_Size() int {
+	return xxx_messageInfo_SegmentLimitsResponse.Size(m)
+}
+func (m *SegmentLimitsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SegmentLimitsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SegmentLimitsResponse proto.InternalMessageInfo
+
+func (m *SegmentLimitsResponse) GetMinSegmentSize() int64 {
+	if m != nil {
+		return m.MinSegmentSize
+	}
+	return 0
+}
+
+func (m *SegmentLimitsResponse) GetMaxSegmentSize() int64 {
+	if m != nil {
+		return m.MaxSegmentSize
+	}
+	return 0
+}
+
+// ObjectMetaRequest is a request message for the ObjectMeta rpc call
+type ObjectMetaRequest struct {
+	// path of the last segment of the object
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ObjectMetaRequest) Reset()         { *m = ObjectMetaRequest{} }
+func (m *ObjectMetaRequest) String() string { return proto.CompactTextString(m) }
+func (*ObjectMetaRequest) ProtoMessage()    {}
+func (*ObjectMetaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{25}
+}
+func (m *ObjectMetaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ObjectMetaRequest.Unmarshal(m, b)
+}
+func (m *ObjectMetaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ObjectMetaRequest.Marshal(b, m, deterministic)
+}
+func (dst *ObjectMetaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObjectMetaRequest.Merge(dst, src)
+}
+func (m *ObjectMetaRequest) XXX_Size() int {
+	return xxx_messageInfo_ObjectMetaRequest.Size(m)
+}
+func (m *ObjectMetaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObjectMetaRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ObjectMetaRequest proto.InternalMessageInfo
+
+func (m *ObjectMetaRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+// ObjectMetaResponse is a response message for the ObjectMeta rpc call
+type ObjectMetaResponse struct {
+	CreationDate   *timestamp.Timestamp `protobuf:"bytes,1,opt,name=creation_date,json=creationDate" json:"creation_date,omitempty"`
+	ExpirationDate *timestamp.Timestamp `protobuf:"bytes,2,opt,name=expiration_date,json=expirationDate" json:"expiration_date,omitempty"`
+	SegmentSize    int64                `protobuf:"varint,3,opt,name=segment_size,json=segmentSize,proto3" json:"segment_size,omitempty"`
+	// metadata holds the stream info with the size and content type of the
+	// object, encrypted by the uplink
+	Metadata []byte `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// redundancy of a remote segment, unset for inline segments
+	Redundancy           *RedundancyScheme `protobuf:"bytes,5,opt,name=redundancy" json:"redundancy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ObjectMetaResponse) Reset()         { *m = ObjectMetaResponse{} }
+func (m *ObjectMetaResponse) String() string { return proto.CompactTextString(m) }
+func (*ObjectMetaResponse) ProtoMessage()    {}
+func (*ObjectMetaResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{26}
+}
+func (m *ObjectMetaResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ObjectMetaResponse.Unmarshal(m, b)
+}
+func (m *ObjectMetaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ObjectMetaResponse.Marshal(b, m, deterministic)
+}
+func (dst *ObjectMetaResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObjectMetaResponse.Merge(dst, src)
+}
+func (m *ObjectMetaResponse) XXX_Size() int {
+	return xxx_messageInfo_ObjectMetaResponse.Size(m)
+}
+func (m *ObjectMetaResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObjectMetaResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ObjectMetaResponse proto.InternalMessageInfo
+
+func (m *ObjectMetaResponse) GetCreationDate() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreationDate
+	}
+	return nil
+}
+
+func (m *ObjectMetaResponse) GetExpirationDate() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpirationDate
+	}
+	return nil
+}
+
+func (m *ObjectMetaResponse) GetSegmentSize() int64 {
+	if m != nil {
+		return m.SegmentSize
+	}
+	return 0
+}
+
+func (m *ObjectMetaResponse) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *ObjectMetaResponse) GetRedundancy() *RedundancyScheme {
+	if m != nil {
+		return m.Redundancy
+	}
+	return nil
+}
+
+// PrefixUsageRequest is a request message for the PrefixUsage rpc call
+type PrefixUsageRequest struct {
+	// prefix is a bucket optionally followed by an encrypted path ending in a slash
+	Prefix string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// limit is the maximum number of directories aggregated separately
+	Limit                int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrefixUsageRequest) Reset()         { *m = PrefixUsageRequest{} }
+func (m *PrefixUsageRequest) String() string { return proto.CompactTextString(m) }
+func (*PrefixUsageRequest) ProtoMessage()    {}
+func (*PrefixUsageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{27}
+}
+func (m *PrefixUsageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrefixUsageRequest.Unmarshal(m, b)
+}
+func (m *PrefixUsageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrefixUsageRequest.Marshal(b, m, deterministic)
+}
+func (dst *PrefixUsageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrefixUsageRequest.Merge(dst, src)
+}
+func (m *PrefixUsageRequest) XXX_Size() int {
+	return xxx_messageInfo_PrefixUsageRequest.Size(m)
+}
+func (m *PrefixUsageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrefixUsageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrefixUsageRequest proto.InternalMessageInfo
+
+func (m *PrefixUsageRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *PrefixUsageRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ObjectUsage struct {
+	Objects       int64 `protobuf:"varint,1,opt,name=objects,proto3" json:"objects,omitempty"`
+	Segments      int64 `protobuf:"varint,2,opt,name=segments,proto3" json:"segments,omitempty"`
+	EncryptedSize int64 `protobuf:"varint,3,opt,name=encrypted_size,json=encryptedSize,proto3" json:"encrypted_size,omitempty"`
+	// plain_size includes the padding of the last encryption block of every segment
+	PlainSize            int64    `protobuf:"varint,4,opt,name=plain_size,json=plainSize,proto3" json:"plain_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ObjectUsage) Reset()         { *m = ObjectUsage{} }
+func (m *ObjectUsage) String() string { return proto.CompactTextString(m) }
+func (*ObjectUsage) ProtoMessage()    {}
+func (*ObjectUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{28}
+}
+func (m *ObjectUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ObjectUsage.Unmarshal(m, b)
+}
+func (m *ObjectUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ObjectUsage.Marshal(b, m, deterministic)
+}
+func (dst *ObjectUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObjectUsage.Merge(dst, src)
+}
+func (m *ObjectUsage) XXX_Size() int {
+	return xxx_messageInfo_ObjectUsage.Size(m)
+}
+func (m *ObjectUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObjectUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ObjectUsage proto.InternalMessageInfo
+
+func (m *ObjectUsage) GetObjects() int64 {
+	if m != nil {
+		return m.Objects
+	}
+	return 0
+}
+
+func (m *ObjectUsage) GetSegments() int64 {
+	if m != nil {
+		return m.Segments
+	}
+	return 0
+}
+
+func (m *ObjectUsage) GetEncryptedSize() int64 {
+	if m != nil {
+		return m.EncryptedSize
+	}
+	return 0
+}
+
+func (m *ObjectUsage) GetPlainSize() int64 {
+	if m != nil {
+		return m.PlainSize
+	}
+	return 0
+}
+
+type DirectoryUsage struct {
+	Path                 string       `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Usage                *ObjectUsage `protobuf:"bytes,2,opt,name=usage" json:"usage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *DirectoryUsage) Reset()         { *m = DirectoryUsage{} }
+func (m *DirectoryUsage) String() string { return proto.CompactTextString(m) }
+func (*DirectoryUsage) ProtoMessage()    {}
+func (*DirectoryUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{29}
+}
+func (m *DirectoryUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DirectoryUsage.Unmarshal(m, b)
+}
+func (m *DirectoryUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DirectoryUsage.Marshal(b, m, deterministic)
+}
+func (dst *DirectoryUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DirectoryUsage.Merge(dst, src)
+}
+func (m *DirectoryUsage) XXX_Size() int {
+	return xxx_messageInfo_DirectoryUsage.Size(m)
+}
+func (m *DirectoryUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_DirectoryUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DirectoryUsage proto.InternalMessageInfo
+
+func (m *DirectoryUsage) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *DirectoryUsage) GetUsage() *ObjectUsage {
+	if m != nil {
+		return m.Usage
+	}
+	return nil
+}
+
+// PrefixUsageResponse is a response message for the PrefixUsage rpc call
+type PrefixUsageResponse struct {
+	Total                *ObjectUsage      `protobuf:"bytes,1,opt,name=total" json:"total,omitempty"`
+	Directories          []*DirectoryUsage `protobuf:"bytes,2,rep,name=directories" json:"directories,omitempty"`
+	More                 bool              `protobuf:"varint,3,opt,name=more,proto3" json:"more,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *PrefixUsageResponse) Reset()         { *m = PrefixUsageResponse{} }
+func (m *PrefixUsageResponse) String() string { return proto.CompactTextString(m) }
+func (*PrefixUsageResponse) ProtoMessage()    {}
+func (*PrefixUsageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{30}
+}
+func (m *PrefixUsageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrefixUsageResponse.Unmarshal(m, b)
+}
+func (m *PrefixUsageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrefixUsageResponse.Marshal(b, m, deterministic)
+}
+func (dst *PrefixUsageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrefixUsageResponse.Merge(dst, src)
+}
+func (m *PrefixUsageResponse) XXX_Size() int {
+	return xxx_messageInfo_PrefixUsageResponse.Size(m)
+}
+func (m *PrefixUsageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrefixUsageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrefixUsageResponse proto.InternalMessageInfo
+
+func (m *PrefixUsageResponse) GetTotal() *ObjectUsage {
+	if m != nil {
+		return m.Total
+	}
+	return nil
+}
+
+func (m *PrefixUsageResponse) GetDirectories() []*DirectoryUsage {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+func (m *PrefixUsageResponse) GetMore() bool {
+	if m != nil {
+		return m.More
+	}
+	return false
+}
+
+// PointerMutation is an entry of the pointerdb audit log, which records
+// mutations before they are applied
+type PointerMutation struct {
+	Operation PointerMutation_Operation `protobuf:"varint,1,opt,name=operation,proto3,enum=pointerdb.PointerMutation_Operation" json:"operation,omitempty"`
+	Path      string                    `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Time      *timestamp.Timestamp      `protobuf:"bytes,3,opt,name=time" json:"time,omitempty"`
+	// caller is the node ID of the peer requesting the mutation, empty for mutations of the satellite itself
+	Caller string `protobuf:"bytes,4,opt,name=caller,proto3" json:"caller,omitempty"`
+	// previous_hash is the SHA-256 of the stored pointer which is replaced or deleted, empty when there was none
+	PreviousHash []byte `protobuf:"bytes,5,opt,name=previous_hash,json=previousHash,proto3" json:"previous_hash,omitempty"`
+	// value is the stored pointer which is put or restored, dropped when the log is compacted
+	Value                []byte   `protobuf:"bytes,6,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PointerMutation) Reset()         { *m = PointerMutation{} }
+func (m *PointerMutation) String() string { return proto.CompactTextString(m) }
+func (*PointerMutation) ProtoMessage()    {}
+func (*PointerMutation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{31}
+}
+func (m *PointerMutation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PointerMutation.Unmarshal(m, b)
+}
+func (m *PointerMutation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PointerMutation.Marshal(b, m, deterministic)
+}
+func (dst *PointerMutation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PointerMutation.Merge(dst, src)
+}
+func (m *PointerMutation) XXX_Size() int {
+	return xxx_messageInfo_PointerMutation.Size(m)
+}
+func (m *PointerMutation) XXX_DiscardUnknown() {
+	xxx_messageInfo_PointerMutation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PointerMutation proto.InternalMessageInfo
+
+func (m *PointerMutation) GetOperation() PointerMutation_Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return PointerMutation_PUT
+}
+
+func (m *PointerMutation) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *PointerMutation) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *PointerMutation) GetCaller() string {
+	if m != nil {
+		return m.Caller
+	}
+	return ""
+}
+
+func (m *PointerMutation) GetPreviousHash() []byte {
+	if m != nil {
+		return m.PreviousHash
+	}
+	return nil
+}
+
+func (m *PointerMutation) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+// PieceDeletion is the deletion of a piece from a storage node, retried by
+// the purger until the node confirms it
+type PieceDeletion struct {
+	NodeId               NodeID   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	PieceId              string   `protobuf:"bytes,2,opt,name=piece_id,json=pieceId,proto3" json:"piece_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PieceDeletion) Reset()         { *m = PieceDeletion{} }
+func (m *PieceDeletion) String() string { return proto.CompactTextString(m) }
+func (*PieceDeletion) ProtoMessage()    {}
+func (*PieceDeletion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{32}
+}
+func (m *PieceDeletion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PieceDeletion.Unmarshal(m, b)
+}
+func (m *PieceDeletion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PieceDeletion.Marshal(b, m, deterministic)
+}
+func (dst *PieceDeletion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PieceDeletion.Merge(dst, src)
+}
+func (m *PieceDeletion) XXX_Size() int {
+	return xxx_messageInfo_PieceDeletion.Size(m)
+}
+func (m *PieceDeletion) XXX_DiscardUnknown() {
+	xxx_messageInfo_PieceDeletion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PieceDeletion proto.InternalMessageInfo
+
+func (m *PieceDeletion) GetPieceId() string {
+	if m != nil {
+		return m.PieceId
+	}
+	return ""
+}
+
+// BucketInfo is the record of a bucket and of the defaults of its objects
+type BucketInfo struct {
+	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Created              *timestamp.Timestamp `protobuf:"bytes,2,opt,name=created" json:"created,omitempty"`
+	DefaultRedundancy    *RedundancyScheme    `protobuf:"bytes,3,opt,name=default_redundancy,json=defaultRedundancy" json:"default_redundancy,omitempty"`
+	DefaultSegmentSize   int64                `protobuf:"varint,4,opt,name=default_segment_size,json=defaultSegmentSize,proto3" json:"default_segment_size,omitempty"`
+	PathCipher           int32                `protobuf:"varint,5,opt,name=path_cipher,json=pathCipher,proto3" json:"path_cipher,omitempty"`
+	EncryptionCipher     int32                `protobuf:"varint,6,opt,name=encryption_cipher,json=encryptionCipher,proto3" json:"encryption_cipher,omitempty"`
+	EncryptionBlockSize  int32                `protobuf:"varint,7,opt,name=encryption_block_size,json=encryptionBlockSize,proto3" json:"encryption_block_size,omitempty"`
+	Placement            string               `protobuf:"bytes,8,opt,name=placement,proto3" json:"placement,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *BucketInfo) Reset()         { *m = BucketInfo{} }
+func (m *BucketInfo) String() string { return proto.CompactTextString(m) }
+func (*BucketInfo) ProtoMessage()    {}
+func (*BucketInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{33}
+}
+func (m *BucketInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BucketInfo.Unmarshal(m, b)
+}
+func (m *BucketInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BucketInfo.Marshal(b, m, deterministic)
+}
+func (dst *BucketInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BucketInfo.Merge(dst, src)
+}
+func (m *BucketInfo) XXX_Size() int {
+	return xxx_messageInfo_BucketInfo.Size(m)
+}
+func (m *BucketInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_BucketInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BucketInfo proto.InternalMessageInfo
+
+func (m *BucketInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *BucketInfo) GetCreated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+func (m *BucketInfo) GetDefaultRedundancy() *RedundancyScheme {
+	if m != nil {
+		return m.DefaultRedundancy
+	}
+	return nil
+}
+
+func (m *BucketInfo) GetDefaultSegmentSize() int64 {
+	if m != nil {
+		return m.DefaultSegmentSize
+	}
+	return 0
+}
+
+func (m *BucketInfo) GetPathCipher() int32 {
+	if m != nil {
+		return m.PathCipher
+	}
+	return 0
+}
+
+func (m *BucketInfo) GetEncryptionCipher() int32 {
+	if m != nil {
+		return m.EncryptionCipher
+	}
+	return 0
+}
+
+func (m *BucketInfo) GetEncryptionBlockSize() int32 {
+	if m != nil {
+		return m.EncryptionBlockSize
+	}
+	return 0
+}
+
+func (m *BucketInfo) GetPlacement() string {
+	if m != nil {
+		return m.Placement
+	}
+	return ""
+}
+
+// BucketCreateRequest is a request message for the CreateBucket rpc call
+type BucketCreateRequest struct {
+	Bucket               *BucketInfo `protobuf:"bytes,1,opt,name=bucket" json:"bucket,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *BucketCreateRequest) Reset()         { *m = BucketCreateRequest{} }
+func (m *BucketCreateRequest) String() string { return proto.CompactTextString(m) }
+func (*BucketCreateRequest) ProtoMessage()    {}
+func (*BucketCreateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{34}
+}
+func (m *BucketCreateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BucketCreateRequest.Unmarshal(m, b)
+}
+func (m *BucketCreateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BucketCreateRequest.Marshal(b, m, deterministic)
+}
+func (dst *BucketCreateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BucketCreateRequest.Merge(dst, src)
+}
+func (m *BucketCreateRequest) XXX_Size() int {
+	return xxx_messageInfo_BucketCreateRequest.Size(m)
+}
+func (m *BucketCreateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BucketCreateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BucketCreateRequest proto.InternalMessageInfo
+
+func (m *BucketCreateRequest) GetBucket() *BucketInfo {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+// BucketCreateResponse is a response message for the CreateBucket rpc call
+type BucketCreateResponse struct {
+	Bucket               *BucketInfo `protobuf:"bytes,1,opt,name=bucket" json:"bucket,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *BucketCreateResponse) Reset()         { *m = BucketCreateResponse{} }
+func (m *BucketCreateResponse) String() string { return proto.CompactTextString(m) }
+func (*BucketCreateResponse) ProtoMessage()    {}
+func (*BucketCreateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{35}
+}
+func (m *BucketCreateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BucketCreateResponse.Unmarshal(m, b)
+}
+func (m *BucketCreateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BucketCreateResponse.Marshal(b, m, deterministic)
+}
+func (dst *BucketCreateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BucketCreateResponse.Merge(dst, src)
+}
+func (m *BucketCreateResponse) XXX_Size() int {
+	return xxx_messageInfo_BucketCreateResponse.Size(m)
+}
+func (m *BucketCreateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BucketCreateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BucketCreateResponse proto.InternalMessageInfo
+
+func (m *BucketCreateResponse) GetBucket() *BucketInfo {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+// BucketGetRequest is a request message for the GetBucket rpc call
+type BucketGetRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BucketGetRequest) Reset()         { *m = BucketGetRequest{} }
+func (m *BucketGetRequest) String() string { return proto.CompactTextString(m) }
+func (*BucketGetRequest) ProtoMessage()    {}
+func (*BucketGetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{36}
+}
+func (m *BucketGetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BucketGetRequest.Unmarshal(m, b)
+}
+func (m *BucketGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BucketGetRequest.Marshal(b, m, deterministic)
+}
+func (dst *BucketGetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BucketGetRequest.Merge(dst, src)
+}
+func (m *BucketGetRequest) XXX_Size() int {
+	return xxx_messageInfo_BucketGetRequest.Size(m)
+}
+func (m *BucketGetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BucketGetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BucketGetRequest proto.InternalMessageInfo
+
+func (m *BucketGetRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// BucketGetResponse is a response message for the GetBucket rpc call
+type BucketGetResponse struct {
+	Bucket               *BucketInfo `protobuf:"bytes,1,opt,name=bucket" json:"bucket,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *BucketGetResponse) Reset()         { *m = BucketGetResponse{} }
+func (m *BucketGetResponse) String() string { return proto.CompactTextString(m) }
+func (*BucketGetResponse) ProtoMessage()    {}
+func (*BucketGetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{37}
+}
+func (m *BucketGetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BucketGetResponse.Unmarshal(m, b)
+}
+func (m *BucketGetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BucketGetResponse.Marshal(b, m, deterministic)
+}
+func (dst *BucketGetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BucketGetResponse.Merge(dst, src)
+}
+func (m *BucketGetResponse) XXX_Size() int {
+	return xxx_messageInfo_BucketGetResponse.Size(m)
+}
+func (m *BucketGetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BucketGetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BucketGetResponse proto.InternalMessageInfo
+
+func (m *BucketGetResponse) GetBucket() *BucketInfo {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+// BucketDeleteRequest is a request message for the DeleteBucket rpc call
+type BucketDeleteRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BucketDeleteRequest) Reset()         { *m = BucketDeleteRequest{} }
+func (m *BucketDeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*BucketDeleteRequest) ProtoMessage()    {}
+func (*BucketDeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{38}
+}
+func (m *BucketDeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BucketDeleteRequest.Unmarshal(m, b)
+}
+func (m *BucketDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BucketDeleteRequest.Marshal(b, m, deterministic)
+}
+func (dst *BucketDeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BucketDeleteRequest.Merge(dst, src)
+}
+func (m *BucketDeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_BucketDeleteRequest.Size(m)
+}
+func (m *BucketDeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BucketDeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BucketDeleteRequest proto.InternalMessageInfo
+
+func (m *BucketDeleteRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// BucketDeleteResponse is a response message for the DeleteBucket rpc call
+type BucketDeleteResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BucketDeleteResponse) Reset()         { *m = BucketDeleteResponse{} }
+func (m *BucketDeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*BucketDeleteResponse) ProtoMessage()    {}
+func (*BucketDeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{39}
+}
+func (m *BucketDeleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BucketDeleteResponse.Unmarshal(m, b)
+}
+func (m *BucketDeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BucketDeleteResponse.Marshal(b, m, deterministic)
+}
+func (dst *BucketDeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BucketDeleteResponse.Merge(dst, src)
+}
+func (m *BucketDeleteResponse) XXX_Size() int {
+	return xxx_messageInfo_BucketDeleteResponse.Size(m)
+}
+func (m *BucketDeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BucketDeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BucketDeleteResponse proto.InternalMessageInfo
+
+// BucketListRequest is a request message for the ListBuckets rpc call
+type BucketListRequest struct {
+	StartAfter           string   `protobuf:"bytes,1,opt,name=start_after,json=startAfter,proto3" json:"start_after,omitempty"`
+	Limit                int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BucketListRequest) Reset()         { *m = BucketListRequest{} }
+func (m *BucketListRequest) String() string { return proto.CompactTextString(m) }
+func (*BucketListRequest) ProtoMessage()    {}
+func (*BucketListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{40}
+}
+func (m *BucketListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BucketListRequest.Unmarshal(m, b)
+}
+func (m *BucketListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BucketListRequest.Marshal(b, m, deterministic)
+}
+func (dst *BucketListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BucketListRequest.Merge(dst, src)
+}
+func (m *BucketListRequest) XXX_Size() int {
+	return xxx_messageInfo_BucketListRequest.Size(m)
+}
+func (m *BucketListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BucketListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BucketListRequest proto.InternalMessageInfo
+
+func (m *BucketListRequest) GetStartAfter() string {
+	if m != nil {
+		return m.StartAfter
+	}
+	return ""
+}
+
+func (m *BucketListRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// BucketListResponse is a response message for the ListBuckets rpc call
+type BucketListResponse struct {
+	Buckets              []*BucketInfo `protobuf:"bytes,1,rep,name=buckets" json:"buckets,omitempty"`
+	More                 bool          `protobuf:"varint,2,opt,name=more,proto3" json:"more,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *BucketListResponse) Reset()         { *m = BucketListResponse{} }
+func (m *BucketListResponse) String() string { return proto.CompactTextString(m) }
+func (*BucketListResponse) ProtoMessage()    {}
+func (*BucketListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pointerdb_204320a18b048cb9, []int{41}
+}
+func (m *BucketListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BucketListResponse.Unmarshal(m, b)
+}
+func (m *BucketListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BucketListResponse.Marshal(b, m, deterministic)
+}
+func (dst *BucketListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BucketListResponse.Merge(dst, src)
+}
+func (m *BucketListResponse) XXX_Size() int {
+	return xxx_messageInfo_BucketListResponse.Size(m)
+}
+func (m *BucketListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BucketListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BucketListResponse proto.InternalMessageInfo
+
+func (m *BucketListResponse) GetBuckets() []*BucketInfo {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+func (m *BucketListResponse) GetMore() bool {
+	if m != nil {
+		return m.More
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*RedundancyScheme)(nil), "pointerdb.RedundancyScheme")
+	proto.RegisterType((*RemotePiece)(nil), "pointerdb.RemotePiece")
 	proto.RegisterType((*RemoteSegment)(nil), "pointerdb.RemoteSegment")
 	proto.RegisterType((*Pointer)(nil), "pointerdb.Pointer")
 	proto.RegisterType((*PutRequest)(nil), "pointerdb.PutRequest")
@@ -917,84 +2356,271 @@ func init() {
 	proto.RegisterType((*ListResponse_Item)(nil), "pointerdb.ListResponse.Item")
 	proto.RegisterType((*DeleteRequest)(nil), "pointerdb.DeleteRequest")
 	proto.RegisterType((*DeleteResponse)(nil), "pointerdb.DeleteResponse")
+	proto.RegisterType((*UndeleteRequest)(nil), "pointerdb.UndeleteRequest")
+	proto.RegisterType((*UndeleteResponse)(nil), "pointerdb.UndeleteResponse")
+	proto.RegisterType((*BatchGetRequest)(nil), "pointerdb.BatchGetRequest")
+	proto.RegisterType((*BatchGetResponse)(nil), "pointerdb.BatchGetResponse")
+	proto.RegisterType((*BatchGetResponse_Item)(nil), "pointerdb.BatchGetResponse.Item")
+	proto.RegisterType((*BatchPutRequest)(nil), "pointerdb.BatchPutRequest")
+	proto.RegisterType((*BatchPutRequest_Item)(nil), "pointerdb.BatchPutRequest.Item")
+	proto.RegisterType((*BatchPutResponse)(nil), "pointerdb.BatchPutResponse")
+	proto.RegisterType((*BatchDeleteRequest)(nil), "pointerdb.BatchDeleteRequest")
+	proto.RegisterType((*BatchDeleteResponse)(nil), "pointerdb.BatchDeleteResponse")
 	proto.RegisterType((*IterateRequest)(nil), "pointerdb.IterateRequest")
 	proto.RegisterType((*PayerBandwidthAllocationRequest)(nil), "pointerdb.PayerBandwidthAllocationRequest")
 	proto.RegisterType((*PayerBandwidthAllocationResponse)(nil), "pointerdb.PayerBandwidthAllocationResponse")
+	proto.RegisterType((*SegmentLimitsRequest)(nil), "pointerdb.SegmentLimitsRequest")
+	proto.RegisterType((*SegmentLimitsResponse)(nil), "pointerdb.SegmentLimitsResponse")
+	proto.RegisterType((*ObjectMetaRequest)(nil), "pointerdb.ObjectMetaRequest")
+	proto.RegisterType((*ObjectMetaResponse)(nil), "pointerdb.ObjectMetaResponse")
+	proto.RegisterType((*PrefixUsageRequest)(nil), "pointerdb.PrefixUsageRequest")
+	proto.RegisterType((*ObjectUsage)(nil), "pointerdb.ObjectUsage")
+	proto.RegisterType((*DirectoryUsage)(nil), "pointerdb.DirectoryUsage")
+	proto.RegisterType((*PrefixUsageResponse)(nil), "pointerdb.PrefixUsageResponse")
+	proto.RegisterType((*PointerMutation)(nil), "pointerdb.PointerMutation")
+	proto.RegisterType((*PieceDeletion)(nil), "pointerdb.PieceDeletion")
+	proto.RegisterType((*BucketInfo)(nil), "pointerdb.BucketInfo")
+	proto.RegisterType((*BucketCreateRequest)(nil), "pointerdb.BucketCreateRequest")
+	proto.RegisterType((*BucketCreateResponse)(nil), "pointerdb.BucketCreateResponse")
+	proto.RegisterType((*BucketGetRequest)(nil), "pointerdb.BucketGetRequest")
+	proto.RegisterType((*BucketGetResponse)(nil), "pointerdb.BucketGetResponse")
+	proto.RegisterType((*BucketDeleteRequest)(nil), "pointerdb.BucketDeleteRequest")
+	proto.RegisterType((*BucketDeleteResponse)(nil), "pointerdb.BucketDeleteResponse")
+	proto.RegisterType((*BucketListRequest)(nil), "pointerdb.BucketListRequest")
+	proto.RegisterType((*BucketListResponse)(nil), "pointerdb.BucketListResponse")
 	proto.RegisterEnum("pointerdb.RedundancyScheme_SchemeType", RedundancyScheme_SchemeType_name, RedundancyScheme_SchemeType_value)
 	proto.RegisterEnum("pointerdb.Pointer_DataType", Pointer_DataType_name, Pointer_DataType_value)
+	proto.RegisterEnum("pointerdb.PointerMutation_Operation", PointerMutation_Operation_name, PointerMutation_Operation_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// PointerDBClient is the client API for PointerDB service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PointerDBClient interface {
+	// Put formats and hands off a file path to be saved to boltdb
+	Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error)
+	// Get formats and hands off a file path to get a small value from boltdb
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	// List calls the bolt client's List function and returns all file paths
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// ListStream lists all the paths of a request in pages of limit items,
+	// which are sent as the client receives them
+	ListStream(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (PointerDB_ListStreamClient, error)
+	// Delete formats and hands off a file path to delete from boltdb
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// Undelete restores a deleted segment until its pieces are purged
+	Undelete(ctx context.Context, in *UndeleteRequest, opts ...grpc.CallOption) (*UndeleteResponse, error)
+	// BatchGet gets the pointers of many paths at once
+	BatchGet(ctx context.Context, in *BatchGetRequest, opts ...grpc.CallOption) (*BatchGetResponse, error)
+	// BatchPut puts the pointers of many paths in a single transaction
+	BatchPut(ctx context.Context, in *BatchPutRequest, opts ...grpc.CallOption) (*BatchPutResponse, error)
+	// BatchDelete deletes the pointers of many paths in a single transaction
+	BatchDelete(ctx context.Context, in *BatchDeleteRequest, opts ...grpc.CallOption) (*BatchDeleteResponse, error)
+	// PayerBandwidthAllocation returns signed payer bandwidth allocation struct
+	PayerBandwidthAllocation(ctx context.Context, in *PayerBandwidthAllocationRequest, opts ...grpc.CallOption) (*PayerBandwidthAllocationResponse, error)
+	// SegmentLimits returns the segment sizes uplinks may choose from
+	SegmentLimits(ctx context.Context, in *SegmentLimitsRequest, opts ...grpc.CallOption) (*SegmentLimitsResponse, error)
+	// PrefixUsage aggregates the sizes of the objects below a prefix and of its directories
+	PrefixUsage(ctx context.Context, in *PrefixUsageRequest, opts ...grpc.CallOption) (*PrefixUsageResponse, error)
+	// CreateBucket creates the record of a bucket
+	CreateBucket(ctx context.Context, in *BucketCreateRequest, opts ...grpc.CallOption) (*BucketCreateResponse, error)
+	// GetBucket gets the record of a bucket
+	GetBucket(ctx context.Context, in *BucketGetRequest, opts ...grpc.CallOption) (*BucketGetResponse, error)
+	// DeleteBucket deletes the record of a bucket without objects
+	DeleteBucket(ctx context.Context, in *BucketDeleteRequest, opts ...grpc.CallOption) (*BucketDeleteResponse, error)
+	// ListBuckets lists the records of the buckets in name order
+	ListBuckets(ctx context.Context, in *BucketListRequest, opts ...grpc.CallOption) (*BucketListResponse, error)
+	// ObjectMeta returns the metadata of the last segment of an object, without
+	// the pieces and allocations needed to download it
+	ObjectMeta(ctx context.Context, in *ObjectMetaRequest, opts ...grpc.CallOption) (*ObjectMetaResponse, error)
+}
+
+type pointerDBClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPointerDBClient(cc *grpc.ClientConn) PointerDBClient {
+	return &pointerDBClient{cc}
+}
+
+func (c *pointerDBClient) Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error) {
+	out := new(PutResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/Put", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pointerDBClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pointerDBClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pointerDBClient) ListStream(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (PointerDB_ListStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_PointerDB_serviceDesc.Streams[0], "/pointerdb.PointerDB/ListStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &pointerDBListStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PointerDB_ListStreamClient interface {
+	Recv() (*ListResponse, error)
+	grpc.ClientStream
+}
+
+type pointerDBListStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *pointerDBListStreamClient) Recv() (*ListResponse, error) {
+	m := new(ListResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *pointerDBClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (c *pointerDBClient) Undelete(ctx context.Context, in *UndeleteRequest, opts ...grpc.CallOption) (*UndeleteResponse, error) {
+	out := new(UndeleteResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/Undelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (c *pointerDBClient) BatchGet(ctx context.Context, in *BatchGetRequest, opts ...grpc.CallOption) (*BatchGetResponse, error) {
+	out := new(BatchGetResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/BatchGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-// PointerDBClient is the client API for PointerDB service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type PointerDBClient interface {
-	// Put formats and hands off a file path to be saved to boltdb
-	Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error)
-	// Get formats and hands off a file path to get a small value from boltdb
-	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
-	// List calls the bolt client's List function and returns all file paths
-	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
-	// Delete formats and hands off a file path to delete from boltdb
-	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
-	// PayerBandwidthAllocation returns signed payer bandwidth allocation struct
-	PayerBandwidthAllocation(ctx context.Context, in *PayerBandwidthAllocationRequest, opts ...grpc.CallOption) (*PayerBandwidthAllocationResponse, error)
+func (c *pointerDBClient) BatchPut(ctx context.Context, in *BatchPutRequest, opts ...grpc.CallOption) (*BatchPutResponse, error) {
+	out := new(BatchPutResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/BatchPut", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type pointerDBClient struct {
-	cc *grpc.ClientConn
+func (c *pointerDBClient) BatchDelete(ctx context.Context, in *BatchDeleteRequest, opts ...grpc.CallOption) (*BatchDeleteResponse, error) {
+	out := new(BatchDeleteResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/BatchDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func NewPointerDBClient(cc *grpc.ClientConn) PointerDBClient {
-	return &pointerDBClient{cc}
+func (c *pointerDBClient) PayerBandwidthAllocation(ctx context.Context, in *PayerBandwidthAllocationRequest, opts ...grpc.CallOption) (*PayerBandwidthAllocationResponse, error) {
+	out := new(PayerBandwidthAllocationResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/PayerBandwidthAllocation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *pointerDBClient) Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error) {
-	out := new(PutResponse)
-	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/Put", in, out, opts...)
+func (c *pointerDBClient) SegmentLimits(ctx context.Context, in *SegmentLimitsRequest, opts ...grpc.CallOption) (*SegmentLimitsResponse, error) {
+	out := new(SegmentLimitsResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/SegmentLimits", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *pointerDBClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
-	out := new(GetResponse)
-	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/Get", in, out, opts...)
+func (c *pointerDBClient) PrefixUsage(ctx context.Context, in *PrefixUsageRequest, opts ...grpc.CallOption) (*PrefixUsageResponse, error) {
+	out := new(PrefixUsageResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/PrefixUsage", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *pointerDBClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
-	out := new(ListResponse)
-	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/List", in, out, opts...)
+func (c *pointerDBClient) CreateBucket(ctx context.Context, in *BucketCreateRequest, opts ...grpc.CallOption) (*BucketCreateResponse, error) {
+	out := new(BucketCreateResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/CreateBucket", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *pointerDBClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
-	out := new(DeleteResponse)
-	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/Delete", in, out, opts...)
+func (c *pointerDBClient) GetBucket(ctx context.Context, in *BucketGetRequest, opts ...grpc.CallOption) (*BucketGetResponse, error) {
+	out := new(BucketGetResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/GetBucket", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *pointerDBClient) PayerBandwidthAllocation(ctx context.Context, in *PayerBandwidthAllocationRequest, opts ...grpc.CallOption) (*PayerBandwidthAllocationResponse, error) {
-	out := new(PayerBandwidthAllocationResponse)
-	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/PayerBandwidthAllocation", in, out, opts...)
+func (c *pointerDBClient) DeleteBucket(ctx context.Context, in *BucketDeleteRequest, opts ...grpc.CallOption) (*BucketDeleteResponse, error) {
+	out := new(BucketDeleteResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/DeleteBucket", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pointerDBClient) ListBuckets(ctx context.Context, in *BucketListRequest, opts ...grpc.CallOption) (*BucketListResponse, error) {
+	out := new(BucketListResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/ListBuckets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pointerDBClient) ObjectMeta(ctx context.Context, in *ObjectMetaRequest, opts ...grpc.CallOption) (*ObjectMetaResponse, error) {
+	out := new(ObjectMetaResponse)
+	err := c.cc.Invoke(ctx, "/pointerdb.PointerDB/ObjectMeta", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1009,10 +2635,36 @@ type PointerDBServer interface {
 	Get(context.Context, *GetRequest) (*GetResponse, error)
 	// List calls the bolt client's List function and returns all file paths
 	List(context.Context, *ListRequest) (*ListResponse, error)
+	// ListStream lists all the paths of a request in pages of limit items,
+	// which are sent as the client receives them
+	ListStream(*ListRequest, PointerDB_ListStreamServer) error
 	// Delete formats and hands off a file path to delete from boltdb
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	// Undelete restores a deleted segment until its pieces are purged
+	Undelete(context.Context, *UndeleteRequest) (*UndeleteResponse, error)
+	// BatchGet gets the pointers of many paths at once
+	BatchGet(context.Context, *BatchGetRequest) (*BatchGetResponse, error)
+	// BatchPut puts the pointers of many paths in a single transaction
+	BatchPut(context.Context, *BatchPutRequest) (*BatchPutResponse, error)
+	// BatchDelete deletes the pointers of many paths in a single transaction
+	BatchDelete(context.Context, *BatchDeleteRequest) (*BatchDeleteResponse, error)
 	// PayerBandwidthAllocation returns signed payer bandwidth allocation struct
 	PayerBandwidthAllocation(context.Context, *PayerBandwidthAllocationRequest) (*PayerBandwidthAllocationResponse, error)
+	// SegmentLimits returns the segment sizes uplinks may choose from
+	SegmentLimits(context.Context, *SegmentLimitsRequest) (*SegmentLimitsResponse, error)
+	// PrefixUsage aggregates the sizes of the objects below a prefix and of its directories
+	PrefixUsage(context.Context, *PrefixUsageRequest) (*PrefixUsageResponse, error)
+	// CreateBucket creates the record of a bucket
+	CreateBucket(context.Context, *BucketCreateRequest) (*BucketCreateResponse, error)
+	// GetBucket gets the record of a bucket
+	GetBucket(context.Context, *BucketGetRequest) (*BucketGetResponse, error)
+	// DeleteBucket deletes the record of a bucket without objects
+	DeleteBucket(context.Context, *BucketDeleteRequest) (*BucketDeleteResponse, error)
+	// ListBuckets lists the records of the buckets in name order
+	ListBuckets(context.Context, *BucketListRequest) (*BucketListResponse, error)
+	// ObjectMeta returns the metadata of the last segment of an object, without
+	// the pieces and allocations needed to download it
+	ObjectMeta(context.Context, *ObjectMetaRequest) (*ObjectMetaResponse, error)
 }
 
 func RegisterPointerDBServer(s *grpc.Server, srv PointerDBServer) {
@@ -1073,6 +2725,27 @@ func _PointerDB_List_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PointerDB_ListStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PointerDBServer).ListStream(m, &pointerDBListStreamServer{stream})
+}
+
+type PointerDB_ListStreamServer interface {
+	Send(*ListResponse) error
+	grpc.ServerStream
+}
+
+type pointerDBListStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *pointerDBListStreamServer) Send(m *ListResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _PointerDB_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteRequest)
 	if err := dec(in); err != nil {
@@ -1091,6 +2764,78 @@ func _PointerDB_Delete_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PointerDB_Undelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PointerDBServer).Undelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pointerdb.PointerDB/Undelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PointerDBServer).Undelete(ctx, req.(*UndeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PointerDB_BatchGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PointerDBServer).BatchGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pointerdb.PointerDB/BatchGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PointerDBServer).BatchGet(ctx, req.(*BatchGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PointerDB_BatchPut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchPutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PointerDBServer).BatchPut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pointerdb.PointerDB/BatchPut",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PointerDBServer).BatchPut(ctx, req.(*BatchPutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PointerDB_BatchDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PointerDBServer).BatchDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pointerdb.PointerDB/BatchDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PointerDBServer).BatchDelete(ctx, req.(*BatchDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PointerDB_PayerBandwidthAllocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PayerBandwidthAllocationRequest)
 	if err := dec(in); err != nil {
@@ -1109,6 +2854,132 @@ func _PointerDB_PayerBandwidthAllocation_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PointerDB_SegmentLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SegmentLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PointerDBServer).SegmentLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pointerdb.PointerDB/SegmentLimits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PointerDBServer).SegmentLimits(ctx, req.(*SegmentLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PointerDB_PrefixUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrefixUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PointerDBServer).PrefixUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pointerdb.PointerDB/PrefixUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PointerDBServer).PrefixUsage(ctx, req.(*PrefixUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PointerDB_CreateBucket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BucketCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PointerDBServer).CreateBucket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pointerdb.PointerDB/CreateBucket",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PointerDBServer).CreateBucket(ctx, req.(*BucketCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PointerDB_GetBucket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BucketGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PointerDBServer).GetBucket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pointerdb.PointerDB/GetBucket",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PointerDBServer).GetBucket(ctx, req.(*BucketGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PointerDB_DeleteBucket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BucketDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PointerDBServer).DeleteBucket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pointerdb.PointerDB/DeleteBucket",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PointerDBServer).DeleteBucket(ctx, req.(*BucketDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PointerDB_ListBuckets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BucketListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PointerDBServer).ListBuckets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pointerdb.PointerDB/ListBuckets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PointerDBServer).ListBuckets(ctx, req.(*BucketListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PointerDB_ObjectMeta_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObjectMetaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PointerDBServer).ObjectMeta(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pointerdb.PointerDB/ObjectMeta",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PointerDBServer).ObjectMeta(ctx, req.(*ObjectMetaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _PointerDB_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pointerdb.PointerDB",
 	HandlerType: (*PointerDBServer)(nil),
@@ -1129,86 +3000,201 @@ var _PointerDB_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _PointerDB_Delete_Handler,
 		},
+		{
+			MethodName: "Undelete",
+			Handler:    _PointerDB_Undelete_Handler,
+		},
+		{
+			MethodName: "BatchGet",
+			Handler:    _PointerDB_BatchGet_Handler,
+		},
+		{
+			MethodName: "BatchPut",
+			Handler:    _PointerDB_BatchPut_Handler,
+		},
+		{
+			MethodName: "BatchDelete",
+			Handler:    _PointerDB_BatchDelete_Handler,
+		},
 		{
 			MethodName: "PayerBandwidthAllocation",
 			Handler:    _PointerDB_PayerBandwidthAllocation_Handler,
 		},
+		{
+			MethodName: "SegmentLimits",
+			Handler:    _PointerDB_SegmentLimits_Handler,
+		},
+		{
+			MethodName: "PrefixUsage",
+			Handler:    _PointerDB_PrefixUsage_Handler,
+		},
+		{
+			MethodName: "CreateBucket",
+			Handler:    _PointerDB_CreateBucket_Handler,
+		},
+		{
+			MethodName: "GetBucket",
+			Handler:    _PointerDB_GetBucket_Handler,
+		},
+		{
+			MethodName: "DeleteBucket",
+			Handler:    _PointerDB_DeleteBucket_Handler,
+		},
+		{
+			MethodName: "ListBuckets",
+			Handler:    _PointerDB_ListBuckets_Handler,
+		},
+		{
+			MethodName: "ObjectMeta",
+			Handler:    _PointerDB_ObjectMeta_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListStream",
+			Handler:       _PointerDB_ListStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pointerdb.proto",
 }
 
-func init() { proto.RegisterFile("pointerdb.proto", fileDescriptor_pointerdb_3f3e047a48f87feb) }
-
-var fileDescriptor_pointerdb_3f3e047a48f87feb = []byte{
-	// 1092 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x5d, 0x6f, 0x1b, 0x45,
-	0x17, 0xae, 0xbf, 0xe3, 0xb3, 0x76, 0xea, 0x77, 0xd4, 0x37, 0xdd, 0xba, 0x45, 0x09, 0x8b, 0x80,
-	0xd2, 0x56, 0x5b, 0x30, 0x95, 0x90, 0x28, 0x08, 0x35, 0x24, 0x54, 0x96, 0xda, 0x10, 0x8d, 0x73,
-	0xc5, 0xcd, 0x32, 0xf1, 0x1e, 0xdb, 0x23, 0xbc, 0x1f, 0x9d, 0x99, 0x2d, 0x4d, 0xff, 0x09, 0xff,
-	0x84, 0x1b, 0x2e, 0x91, 0xf8, 0x0d, 0x5c, 0xf4, 0x02, 0xf1, 0x33, 0xb8, 0x40, 0xf3, 0xb1, 0xf6,
-	0xba, 0x21, 0x69, 0x05, 0x37, 0xc9, 0x9e, 0x73, 0x9e, 0x73, 0xe6, 0x9c, 0xf3, 0x3c, 0x33, 0x86,
-	0xab, 0x79, 0xc6, 0x53, 0x85, 0x22, 0x3e, 0x0d, 0x73, 0x91, 0xa9, 0x8c, 0x74, 0x57, 0x8e, 0xe1,
-	0xee, 0x3c, 0xcb, 0xe6, 0x4b, 0xbc, 0x6f, 0x02, 0xa7, 0xc5, 0xec, 0xbe, 0xe2, 0x09, 0x4a, 0xc5,
-	0x92, 0xdc, 0x62, 0x87, 0x30, 0xcf, 0xe6, 0x59, 0xf9, 0x9d, 0x66, 0x31, 0xba, 0xef, 0x41, 0xce,
-	0x71, 0x8a, 0x52, 0x65, 0xc2, 0x79, 0x82, 0x9f, 0xea, 0x30, 0xa0, 0x18, 0x17, 0x69, 0xcc, 0xd2,
-	0xe9, 0xd9, 0x64, 0xba, 0xc0, 0x04, 0xc9, 0xe7, 0xd0, 0x54, 0x67, 0x39, 0xfa, 0xb5, 0xbd, 0xda,
-	0xed, 0xed, 0xd1, 0x07, 0xe1, 0xba, 0x95, 0xd7, 0xa1, 0xa1, 0xfd, 0x77, 0x72, 0x96, 0x23, 0x35,
-	0x39, 0xe4, 0x3a, 0x74, 0x12, 0x9e, 0x46, 0x02, 0x9f, 0xf9, 0xf5, 0xbd, 0xda, 0xed, 0x16, 0x6d,
-	0x27, 0x3c, 0xa5, 0xf8, 0x8c, 0x5c, 0x83, 0x96, 0xca, 0x14, 0x5b, 0xfa, 0x0d, 0xe3, 0xb6, 0x06,
-	0xf9, 0x08, 0x06, 0x02, 0x73, 0xc6, 0x45, 0xa4, 0x16, 0x02, 0xe5, 0x22, 0x5b, 0xc6, 0x7e, 0xd3,
-	0x00, 0xae, 0x5a, 0xff, 0x49, 0xe9, 0x26, 0x77, 0xe1, 0x7f, 0xb2, 0x98, 0x4e, 0x51, 0xca, 0x0a,
-	0xb6, 0x65, 0xb0, 0x03, 0x17, 0x58, 0x83, 0xef, 0x01, 0x41, 0xc1, 0x64, 0x21, 0x30, 0x92, 0x0b,
-	0xa6, 0xff, 0xf2, 0x97, 0xe8, 0xb7, 0x2d, 0xda, 0x45, 0x26, 0x3a, 0x30, 0xe1, 0x2f, 0x31, 0xb8,
-	0x06, 0xb0, 0x1e, 0x84, 0xb4, 0xa1, 0x4e, 0x27, 0x83, 0x2b, 0xc1, 0x04, 0x3c, 0x8a, 0x49, 0xa6,
-	0xf0, 0x58, 0x6f, 0x8d, 0xdc, 0x84, 0xae, 0x59, 0x5f, 0x94, 0x16, 0x89, 0x59, 0x4d, 0x8b, 0x6e,
-	0x19, 0xc7, 0x51, 0x91, 0x90, 0x0f, 0xa1, 0xa3, 0xf7, 0x1c, 0xf1, 0xd8, 0x8c, 0xdd, 0xdb, 0xdf,
-	0xfe, 0xed, 0xd5, 0xee, 0x95, 0xdf, 0x5f, 0xed, 0xb6, 0x8f, 0xb2, 0x18, 0xc7, 0x07, 0xb4, 0xad,
-	0xc3, 0xe3, 0x38, 0xf8, 0xb5, 0x06, 0x7d, 0x5b, 0x75, 0x82, 0xf3, 0x04, 0x53, 0x45, 0x1e, 0x02,
-	0x88, 0xd5, 0x5a, 0x4d, 0x61, 0x6f, 0x74, 0xf3, 0x92, 0x9d, 0xd3, 0x0a, 0x9c, 0xdc, 0x00, 0xdb,
-	0x43, 0x79, 0x70, 0x97, 0x76, 0x8c, 0x3d, 0x8e, 0xc9, 0x43, 0xe8, 0x0b, 0x73, 0x50, 0x64, 0x59,
-	0xf7, 0x1b, 0x7b, 0x8d, 0xdb, 0xde, 0x68, 0x67, 0xa3, 0xf4, 0x6a, 0x3c, 0xda, 0x13, 0x6b, 0x43,
-	0x92, 0x5d, 0xf0, 0x12, 0x14, 0x3f, 0x2c, 0x31, 0x12, 0x59, 0xa6, 0x0c, 0x25, 0x3d, 0x0a, 0xd6,
-	0x45, 0xb3, 0x4c, 0x05, 0x7f, 0xd5, 0xa1, 0x73, 0x6c, 0x0b, 0x91, 0xfb, 0x1b, 0x7a, 0xa9, 0xf6,
-	0xee, 0x10, 0xe1, 0x01, 0x53, 0xac, 0x22, 0x92, 0xf7, 0x61, 0x9b, 0xa7, 0x4b, 0x9e, 0x62, 0x24,
-	0xed, 0x12, 0x8c, 0x28, 0x7a, 0xb4, 0x6f, 0xbd, 0xe5, 0x66, 0x3e, 0x86, 0xb6, 0x6d, 0xca, 0x9c,
-	0xef, 0x8d, 0xfc, 0x73, 0xad, 0x3b, 0x24, 0x75, 0x38, 0xf2, 0x2e, 0xf4, 0x5c, 0x45, 0x4b, 0xb8,
-	0x96, 0x47, 0x83, 0x7a, 0xce, 0xa7, 0xb9, 0x26, 0x5f, 0x41, 0x7f, 0x2a, 0x90, 0x29, 0x9e, 0xa5,
-	0x51, 0xcc, 0x94, 0x15, 0x85, 0x37, 0x1a, 0x86, 0xf6, 0x52, 0x85, 0xe5, 0xa5, 0x0a, 0x4f, 0xca,
-	0x4b, 0x45, 0x7b, 0x65, 0xc2, 0x01, 0x53, 0x48, 0xbe, 0x86, 0xab, 0xf8, 0x22, 0xe7, 0xa2, 0x52,
-	0xa2, 0xf3, 0xc6, 0x12, 0xdb, 0xeb, 0x14, 0x53, 0x64, 0x08, 0x5b, 0x09, 0x2a, 0x16, 0x33, 0xc5,
-	0xfc, 0x2d, 0x33, 0xfb, 0xca, 0x0e, 0x02, 0xd8, 0x2a, 0xf7, 0x45, 0x00, 0xda, 0xe3, 0xa3, 0x27,
-	0xe3, 0xa3, 0xc3, 0xc1, 0x15, 0xfd, 0x4d, 0x0f, 0x9f, 0x7e, 0x7b, 0x72, 0x38, 0xa8, 0x05, 0x47,
-	0x00, 0xc7, 0x85, 0xa2, 0xf8, 0xac, 0x40, 0xa9, 0x08, 0x81, 0x66, 0xce, 0xd4, 0xc2, 0x10, 0xd0,
-	0xa5, 0xe6, 0x9b, 0xdc, 0x83, 0x8e, 0xdb, 0x96, 0x11, 0x86, 0x37, 0x22, 0xe7, 0x79, 0xa1, 0x25,
-	0x24, 0xd8, 0x03, 0x78, 0x8c, 0x97, 0xd5, 0x0b, 0x7e, 0xae, 0x81, 0xf7, 0x84, 0xcb, 0x15, 0x66,
-	0x07, 0xda, 0xb9, 0xc0, 0x19, 0x7f, 0xe1, 0x50, 0xce, 0xd2, 0xca, 0x91, 0x8a, 0x09, 0x15, 0xb1,
-	0x59, 0x79, 0x76, 0x97, 0x82, 0x71, 0x3d, 0xd2, 0x1e, 0xf2, 0x0e, 0x00, 0xa6, 0x71, 0x74, 0x8a,
-	0xb3, 0x4c, 0xa0, 0x21, 0xbe, 0x4b, 0xbb, 0x98, 0xc6, 0xfb, 0xc6, 0x41, 0x6e, 0x41, 0x57, 0xe0,
-	0xb4, 0x10, 0x92, 0x3f, 0xb7, 0xbc, 0x6f, 0xd1, 0xb5, 0x43, 0xbf, 0x22, 0x4b, 0x9e, 0x70, 0xe5,
-	0x2e, 0xbe, 0x35, 0x74, 0x49, 0xbd, 0xbd, 0x68, 0xb6, 0x64, 0x73, 0x69, 0x08, 0xed, 0xd0, 0xae,
-	0xf6, 0x7c, 0xa3, 0x1d, 0x41, 0x1f, 0x3c, 0xb3, 0x2c, 0x99, 0x67, 0xa9, 0xc4, 0xe0, 0x8f, 0x1a,
-	0x78, 0x66, 0x58, 0x6b, 0x57, 0x37, 0x55, 0x7b, 0xe3, 0xa6, 0xc8, 0x1e, 0xb4, 0xf4, 0x55, 0x96,
-	0x7e, 0xdd, 0x5c, 0x27, 0x08, 0xcd, 0xfb, 0xaa, 0x6f, 0x39, 0xb5, 0x01, 0xf2, 0x05, 0x34, 0xf2,
-	0x53, 0x66, 0x26, 0xf3, 0x46, 0x77, 0xc2, 0xf5, 0x9b, 0x2b, 0xb2, 0x42, 0xa1, 0x0c, 0x8f, 0xd9,
-	0x19, 0x8a, 0x7d, 0x96, 0xc6, 0x3f, 0xf2, 0x58, 0x2d, 0x1e, 0x2d, 0x97, 0xd9, 0xd4, 0x08, 0x83,
-	0xea, 0x34, 0x72, 0x08, 0x7d, 0x56, 0xa8, 0x45, 0x26, 0xf8, 0x4b, 0xe3, 0x75, 0xda, 0xdf, 0x3d,
-	0x5f, 0x67, 0xc2, 0xe7, 0x29, 0xc6, 0x4f, 0x51, 0x4a, 0x36, 0x47, 0xba, 0x99, 0x15, 0xfc, 0x52,
-	0x83, 0x9e, 0xa5, 0xcb, 0x4d, 0x39, 0x82, 0x16, 0x57, 0x98, 0x48, 0xbf, 0x66, 0xfa, 0xbe, 0x55,
-	0x99, 0xb1, 0x8a, 0x0b, 0xc7, 0x0a, 0x13, 0x6a, 0xa1, 0x5a, 0x07, 0x89, 0x26, 0xa9, 0x6e, 0x68,
-	0x30, 0xdf, 0x43, 0x84, 0xa6, 0x86, 0xfc, 0x77, 0xcd, 0xe9, 0x07, 0x95, 0xcb, 0xc8, 0x89, 0xa8,
-	0x61, 0x8e, 0xd8, 0xe2, 0xf2, 0xd8, 0xd8, 0xc1, 0x7b, 0xd0, 0x3f, 0xc0, 0x25, 0x2a, 0xbc, 0x4c,
-	0x93, 0x03, 0xd8, 0x2e, 0x41, 0x8e, 0x5b, 0x01, 0xdb, 0x63, 0x85, 0x82, 0xad, 0xf3, 0x2e, 0xd2,
-	0xe9, 0x35, 0x68, 0xcd, 0xb8, 0x90, 0xca, 0x29, 0xd4, 0x1a, 0xc4, 0x87, 0x8e, 0x15, 0x1b, 0xba,
-	0x8e, 0x4a, 0xd3, 0x46, 0x9e, 0xa3, 0x8e, 0x34, 0xcb, 0x88, 0x31, 0x83, 0x25, 0xec, 0x5e, 0x48,
-	0xa9, 0x6b, 0x62, 0x0c, 0x6d, 0x36, 0x35, 0x6c, 0xda, 0x37, 0xf2, 0x93, 0xb7, 0x57, 0x45, 0xf8,
-	0xc8, 0x24, 0x52, 0x57, 0x20, 0xf8, 0x1e, 0xf6, 0x2e, 0x3e, 0xcd, 0x71, 0xed, 0x14, 0x58, 0xfb,
-	0x57, 0x0a, 0x1c, 0xfd, 0x59, 0x87, 0xae, 0x23, 0xeb, 0x60, 0x9f, 0x3c, 0x80, 0xc6, 0x71, 0xa1,
-	0xc8, 0xff, 0xab, 0x4c, 0xae, 0x5e, 0x9e, 0xe1, 0xce, 0xeb, 0x6e, 0xd7, 0xc1, 0x03, 0x68, 0x3c,
-	0xc6, 0xcd, 0xac, 0xf5, 0xfb, 0xb2, 0x91, 0x55, 0xbd, 0x89, 0x9f, 0x41, 0x53, 0x6b, 0x91, 0xec,
-	0x9c, 0x13, 0xa7, 0xcd, 0xbb, 0x7e, 0x81, 0x68, 0xc9, 0x97, 0xd0, 0xb6, 0x42, 0x20, 0xd5, 0xdf,
-	0x88, 0x0d, 0x01, 0x0d, 0x6f, 0xfc, 0x43, 0xc4, 0xa5, 0x4b, 0xf0, 0x2f, 0x5a, 0x09, 0xb9, 0x53,
-	0x9d, 0xf0, 0x72, 0x9a, 0x87, 0x77, 0xdf, 0x0a, 0x6b, 0x0f, 0xdd, 0x6f, 0x7e, 0x57, 0xcf, 0x4f,
-	0x4f, 0xdb, 0xe6, 0xc7, 0xe2, 0xd3, 0xbf, 0x03, 0x00, 0x00, 0xff, 0xff, 0xfa, 0x56, 0x48, 0xf9,
-	0xf0, 0x09, 0x00, 0x00,
+func init() { proto.RegisterFile("pointerdb.proto", fileDescriptor_pointerdb_204320a18b048cb9) }
+
+var fileDescriptor_pointerdb_204320a18b048cb9 = []byte{
+	// 2142 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xdd, 0x72, 0x1b, 0x59,
+	0x11, 0xce, 0x48, 0xd6, 0xcf, 0xb4, 0x24, 0x5b, 0x39, 0x71, 0xbc, 0x8a, 0x9c, 0x44, 0x66, 0x96,
+	0xdd, 0x64, 0xb3, 0x8b, 0x12, 0x44, 0x80, 0xaa, 0x0d, 0x54, 0x2a, 0x8a, 0x1d, 0xaf, 0x52, 0x8e,
+	0xa3, 0x3a, 0x72, 0x28, 0xe0, 0x46, 0x8c, 0x67, 0xda, 0xd6, 0x10, 0x69, 0x46, 0x99, 0x39, 0x13,
+	0xec, 0xbc, 0x01, 0xb7, 0x14, 0x45, 0x15, 0xc5, 0x0b, 0xf0, 0x02, 0x5c, 0x72, 0x49, 0x15, 0xaf,
+	0x00, 0x17, 0x7b, 0xc7, 0x1b, 0xf0, 0x00, 0xd4, 0xf9, 0x19, 0xcd, 0x19, 0xfd, 0xd8, 0xc9, 0xb2,
+	0x7b, 0x93, 0xe8, 0x74, 0x7f, 0xdd, 0xa7, 0x4f, 0xff, 0x4d, 0xb7, 0x61, 0x63, 0x1a, 0x78, 0x3e,
+	0xc3, 0xd0, 0x3d, 0x6e, 0x4f, 0xc3, 0x80, 0x05, 0xc4, 0x9c, 0x11, 0x9a, 0xad, 0xd3, 0x20, 0x38,
+	0x1d, 0xe3, 0x7d, 0xc1, 0x38, 0x8e, 0x4f, 0xee, 0x33, 0x6f, 0x82, 0x11, 0xb3, 0x27, 0x53, 0x89,
+	0x6d, 0xc2, 0x69, 0x70, 0x1a, 0x24, 0xbf, 0xfd, 0xc0, 0x45, 0xf5, 0xbb, 0x3e, 0xf5, 0xd0, 0xc1,
+	0x88, 0x05, 0xa1, 0xa2, 0x58, 0x7f, 0xce, 0x41, 0x9d, 0xa2, 0x1b, 0xfb, 0xae, 0xed, 0x3b, 0xe7,
+	0x03, 0x67, 0x84, 0x13, 0x24, 0x5f, 0xc2, 0x1a, 0x3b, 0x9f, 0x62, 0xc3, 0xd8, 0x31, 0xee, 0xae,
+	0x77, 0x3e, 0x6d, 0xa7, 0xa6, 0xcc, 0x43, 0xdb, 0xf2, 0xbf, 0xa3, 0xf3, 0x29, 0x52, 0x21, 0x43,
+	0x3e, 0x82, 0xd2, 0xc4, 0xf3, 0x87, 0x21, 0xbe, 0x69, 0xe4, 0x76, 0x8c, 0xbb, 0x05, 0x5a, 0x9c,
+	0x78, 0x3e, 0xc5, 0x37, 0x64, 0x13, 0x0a, 0x2c, 0x60, 0xf6, 0xb8, 0x91, 0x17, 0x64, 0x79, 0x20,
+	0x9f, 0x41, 0x3d, 0xc4, 0xa9, 0xed, 0x85, 0x43, 0x36, 0x0a, 0x31, 0x1a, 0x05, 0x63, 0xb7, 0xb1,
+	0x26, 0x00, 0x1b, 0x92, 0x7e, 0x94, 0x90, 0xc9, 0xe7, 0x70, 0x35, 0x8a, 0x1d, 0x07, 0xa3, 0x48,
+	0xc3, 0x16, 0x04, 0xb6, 0xae, 0x18, 0x29, 0xf8, 0x0b, 0x20, 0x18, 0xda, 0x51, 0x1c, 0xe2, 0x30,
+	0x1a, 0xd9, 0xfc, 0x5f, 0xef, 0x1d, 0x36, 0x8a, 0x12, 0xad, 0x38, 0x03, 0xce, 0x18, 0x78, 0xef,
+	0xd0, 0xda, 0x04, 0x48, 0x1f, 0x42, 0x8a, 0x90, 0xa3, 0x83, 0xfa, 0x15, 0x6b, 0x00, 0x15, 0x8a,
+	0x93, 0x80, 0x61, 0x9f, 0x7b, 0x8d, 0x6c, 0x83, 0x29, 0xdc, 0x37, 0xf4, 0xe3, 0x89, 0x70, 0x4d,
+	0x81, 0x96, 0x05, 0xe1, 0x30, 0x9e, 0x90, 0x3b, 0x50, 0xe2, 0x7e, 0x1e, 0x7a, 0xae, 0x78, 0x76,
+	0xb5, 0xbb, 0xfe, 0xcf, 0xaf, 0x5b, 0x57, 0xfe, 0xfd, 0x75, 0xab, 0x78, 0x18, 0xb8, 0xd8, 0xdb,
+	0xa5, 0x45, 0xce, 0xee, 0xb9, 0xd6, 0x3f, 0x0c, 0xa8, 0x49, 0xad, 0x03, 0x3c, 0x9d, 0xa0, 0xcf,
+	0xc8, 0x23, 0x80, 0x70, 0xe6, 0x56, 0xa1, 0xb8, 0xd2, 0xd9, 0xbe, 0xc0, 0xe7, 0x54, 0x83, 0x93,
+	0x1b, 0x20, 0x6d, 0x48, 0x2e, 0x36, 0x69, 0x49, 0x9c, 0x7b, 0x2e, 0x79, 0x04, 0xb5, 0x50, 0x5c,
+	0x34, 0x94, 0x51, 0x6f, 0xe4, 0x77, 0xf2, 0x77, 0x2b, 0x9d, 0xad, 0x8c, 0xea, 0xd9, 0xf3, 0x68,
+	0x35, 0x4c, 0x0f, 0x11, 0x69, 0x41, 0x65, 0x82, 0xe1, 0xeb, 0x31, 0x0e, 0xc3, 0x20, 0x60, 0x22,
+	0x24, 0x55, 0x0a, 0x92, 0x44, 0x83, 0x80, 0x59, 0x7f, 0xcb, 0x43, 0xa9, 0x2f, 0x15, 0x91, 0xfb,
+	0x99, 0x7c, 0xd1, 0x6d, 0x57, 0x88, 0xf6, 0xae, 0xcd, 0x6c, 0x2d, 0x49, 0x3e, 0x81, 0x75, 0xcf,
+	0x1f, 0x7b, 0x3e, 0x0e, 0x23, 0xe9, 0x04, 0x91, 0x14, 0x55, 0x5a, 0x93, 0xd4, 0xc4, 0x33, 0x0f,
+	0xa0, 0x28, 0x8d, 0x12, 0xf7, 0x57, 0x3a, 0x8d, 0x05, 0xd3, 0x15, 0x92, 0x2a, 0x1c, 0xf9, 0x1e,
+	0x54, 0x95, 0x46, 0x19, 0x70, 0x9e, 0x1e, 0x79, 0x5a, 0x51, 0x34, 0x1e, 0x6b, 0xf2, 0x18, 0x6a,
+	0x4e, 0x88, 0x36, 0xf3, 0x02, 0x7f, 0xe8, 0xda, 0x4c, 0x26, 0x45, 0xa5, 0xd3, 0x6c, 0xcb, 0xa2,
+	0x6a, 0x27, 0x45, 0xd5, 0x3e, 0x4a, 0x8a, 0x8a, 0x56, 0x13, 0x81, 0x5d, 0x9b, 0x21, 0x79, 0x0a,
+	0x1b, 0x78, 0x36, 0xf5, 0x42, 0x4d, 0x45, 0xe9, 0x52, 0x15, 0xeb, 0xa9, 0x88, 0x50, 0xd2, 0x84,
+	0xf2, 0x04, 0x99, 0xed, 0xda, 0xcc, 0x6e, 0x94, 0xc5, 0xdb, 0x67, 0x67, 0xd2, 0x80, 0xd2, 0x5b,
+	0x0c, 0x23, 0x2f, 0xf0, 0x1b, 0xa6, 0xb0, 0x3f, 0x39, 0x92, 0x5b, 0x00, 0xd3, 0x30, 0xf8, 0x2d,
+	0x3a, 0x8c, 0xc7, 0x1b, 0x84, 0x9c, 0xa9, 0x28, 0x3d, 0xd7, 0xb2, 0xa0, 0x9c, 0x38, 0x9a, 0x00,
+	0x14, 0x7b, 0x87, 0x07, 0xbd, 0xc3, 0xbd, 0xfa, 0x15, 0xfe, 0x9b, 0xee, 0xbd, 0x78, 0x79, 0xb4,
+	0x57, 0x37, 0xac, 0xbf, 0x18, 0x00, 0xfd, 0x98, 0x51, 0x7c, 0x13, 0x63, 0xc4, 0x08, 0x81, 0xb5,
+	0xa9, 0xcd, 0x46, 0x22, 0x74, 0x26, 0x15, 0xbf, 0xc9, 0x17, 0x50, 0x52, 0x7e, 0x16, 0x29, 0x55,
+	0xe9, 0x90, 0xc5, 0x88, 0xd2, 0x04, 0x42, 0x76, 0xa0, 0xe2, 0x04, 0xbe, 0xeb, 0xf1, 0xa7, 0xa9,
+	0xea, 0x2e, 0x53, 0x9d, 0xc4, 0x6b, 0x1c, 0xcf, 0xa6, 0xe8, 0x30, 0x74, 0x87, 0xc9, 0xc3, 0xd6,
+	0xc4, 0xc3, 0x36, 0x12, 0xfa, 0x2f, 0x24, 0xd9, 0x7a, 0x0c, 0xb0, 0x8f, 0x17, 0x1a, 0x77, 0x0b,
+	0x80, 0x3b, 0x6a, 0x78, 0x32, 0xb6, 0x4f, 0x23, 0x61, 0x5f, 0x89, 0x9a, 0x9c, 0xf2, 0x8c, 0x13,
+	0xac, 0x7f, 0x19, 0x50, 0x39, 0xf0, 0xa2, 0x99, 0x8a, 0x2d, 0x28, 0x4e, 0x43, 0x3c, 0xf1, 0xce,
+	0x94, 0x12, 0x75, 0xe2, 0xf9, 0x1d, 0x31, 0x3b, 0x64, 0x43, 0xfb, 0x24, 0x79, 0xa7, 0x49, 0x41,
+	0x90, 0x9e, 0x70, 0x0a, 0xbf, 0x07, 0x7d, 0x77, 0x78, 0x8c, 0x27, 0x41, 0x88, 0xe2, 0x55, 0x26,
+	0x35, 0xd1, 0x77, 0xbb, 0x82, 0x40, 0x6e, 0x82, 0x19, 0xa2, 0x13, 0x87, 0x91, 0xf7, 0x56, 0x66,
+	0x67, 0x99, 0xa6, 0x04, 0xde, 0xeb, 0xc6, 0xde, 0xc4, 0x63, 0xaa, 0x3d, 0xc9, 0xc3, 0x9c, 0xe9,
+	0xc5, 0x39, 0xd3, 0xb9, 0x49, 0xbe, 0x3d, 0xc1, 0xa1, 0xb2, 0xb7, 0x24, 0x4d, 0xe2, 0xa4, 0xbe,
+	0xa0, 0x58, 0x77, 0xa0, 0x22, 0x22, 0x17, 0x4d, 0x03, 0x3f, 0x42, 0x3d, 0x4d, 0x8c, 0x4c, 0x9a,
+	0x58, 0x7f, 0xcd, 0x41, 0x65, 0x1f, 0x53, 0xa4, 0x16, 0x50, 0xe3, 0x7d, 0x02, 0x5a, 0xe0, 0xbd,
+	0x8a, 0x3b, 0x97, 0xf7, 0x0b, 0x68, 0xf3, 0x53, 0x9b, 0xb7, 0x31, 0x2a, 0x19, 0xe4, 0x67, 0x90,
+	0x9f, 0x1e, 0xdb, 0xc2, 0x29, 0x95, 0xce, 0xbd, 0x76, 0xfa, 0x51, 0x09, 0x83, 0x98, 0x61, 0xd4,
+	0xee, 0xdb, 0xe7, 0x18, 0x76, 0x6d, 0xdf, 0xfd, 0x9d, 0xe7, 0xb2, 0xd1, 0x93, 0xf1, 0x38, 0x70,
+	0x44, 0xe6, 0x53, 0x2e, 0x46, 0xf6, 0xa0, 0x66, 0xc7, 0x6c, 0x14, 0x84, 0xde, 0x3b, 0x41, 0x55,
+	0xc5, 0xdd, 0x5a, 0xd4, 0x33, 0xf0, 0x4e, 0x7d, 0x74, 0x5f, 0x60, 0x14, 0xd9, 0xa7, 0x48, 0xb3,
+	0x52, 0x64, 0x17, 0xea, 0x91, 0xe0, 0x0f, 0x6d, 0xd7, 0x0d, 0x31, 0x8a, 0x30, 0x12, 0xee, 0xae,
+	0x74, 0x6e, 0x48, 0x8b, 0xa5, 0x34, 0xb7, 0xfb, 0x49, 0x02, 0xa0, 0x1b, 0x52, 0x64, 0x46, 0xb0,
+	0xfe, 0x6e, 0x40, 0x55, 0xe6, 0x8b, 0xf2, 0x55, 0x07, 0x0a, 0x1e, 0xc3, 0x49, 0xd4, 0x30, 0xc4,
+	0xeb, 0x6f, 0x6a, 0x9e, 0xd2, 0x71, 0xed, 0x1e, 0xc3, 0x09, 0x95, 0x50, 0x9e, 0xa7, 0x13, 0x9e,
+	0x25, 0x39, 0x91, 0x07, 0xe2, 0x77, 0x13, 0x61, 0x8d, 0x43, 0xbe, 0x85, 0x02, 0xdb, 0x06, 0xd3,
+	0x8b, 0x92, 0xac, 0x90, 0xe5, 0x55, 0xf6, 0x22, 0x95, 0x13, 0x1f, 0x43, 0x6d, 0x17, 0xc7, 0xc8,
+	0xf0, 0x82, 0x9a, 0xb1, 0xea, 0xb0, 0x9e, 0x80, 0xa4, 0xf5, 0xd6, 0x27, 0xb0, 0xf1, 0xca, 0x77,
+	0x2f, 0x15, 0x24, 0x50, 0x4f, 0x61, 0x4a, 0xf4, 0x19, 0x6c, 0x74, 0x6d, 0xe6, 0x8c, 0xb4, 0x3a,
+	0xdd, 0x84, 0x02, 0x87, 0x4b, 0x9f, 0x99, 0x54, 0x1e, 0x2e, 0xab, 0xd4, 0x3f, 0x1a, 0x50, 0x4f,
+	0x15, 0x29, 0xef, 0xff, 0x24, 0xeb, 0xfd, 0x1d, 0xcd, 0x2f, 0xf3, 0x58, 0x3d, 0x02, 0xcd, 0xaf,
+	0xbe, 0x2d, 0x6f, 0x5b, 0x7f, 0x30, 0xd4, 0xfb, 0xb4, 0x26, 0xf9, 0xe3, 0xac, 0x55, 0xad, 0x79,
+	0xab, 0x52, 0xe8, 0x77, 0x64, 0x14, 0x81, 0x7a, 0x7a, 0x91, 0x8a, 0xc3, 0x3d, 0x20, 0x82, 0x96,
+	0x0d, 0xff, 0xd2, 0x50, 0x58, 0x1d, 0xb8, 0x96, 0xc1, 0x2a, 0x6f, 0x6f, 0x83, 0xe9, 0x07, 0x6c,
+	0x78, 0x12, 0xc4, 0xbe, 0xab, 0x04, 0xca, 0x7e, 0xc0, 0x9e, 0xf1, 0xb3, 0x15, 0xc2, 0x7a, 0x8f,
+	0x61, 0x68, 0x33, 0xbc, 0xac, 0x97, 0x6e, 0x42, 0xe1, 0xc4, 0x0b, 0x23, 0xa6, 0xba, 0xa8, 0x3c,
+	0xf0, 0xf6, 0x24, 0x1b, 0x22, 0xaa, 0xa4, 0x4d, 0x8e, 0x92, 0xc3, 0x7b, 0x55, 0xd2, 0x39, 0x93,
+	0xa3, 0x35, 0x86, 0xd6, 0xca, 0xde, 0xa1, 0x8c, 0xe8, 0x41, 0xd1, 0x76, 0x58, 0xd2, 0xf4, 0xd6,
+	0x3b, 0x3f, 0x7c, 0xff, 0xf6, 0xd3, 0x7e, 0x22, 0x04, 0xa9, 0x52, 0x60, 0xfd, 0x06, 0x76, 0x56,
+	0xdf, 0xa6, 0x5c, 0xa4, 0x5a, 0x9d, 0xf1, 0x8d, 0x5a, 0x9d, 0xb5, 0x05, 0x9b, 0x6a, 0x42, 0x39,
+	0xe0, 0x5f, 0x80, 0x48, 0x3d, 0xc2, 0x7a, 0x0d, 0xd7, 0xe7, 0xe8, 0xea, 0xba, 0xbb, 0x50, 0xe7,
+	0xd3, 0x73, 0x66, 0x86, 0x91, 0xcd, 0x7d, 0x7d, 0xe2, 0xf9, 0x03, 0x6d, 0x8c, 0xe1, 0x48, 0xfb,
+	0x2c, 0x8b, 0xcc, 0x29, 0xa4, 0x7d, 0xa6, 0x21, 0xad, 0x3b, 0x70, 0xf5, 0xe5, 0x31, 0x9f, 0x10,
+	0x5e, 0x20, 0xb3, 0x2f, 0xaa, 0xf6, 0x3f, 0xe5, 0x80, 0xe8, 0x48, 0x65, 0xd3, 0xc2, 0xc0, 0x64,
+	0xfc, 0xff, 0x03, 0x53, 0xee, 0x83, 0x07, 0xa6, 0xf9, 0xc9, 0x2e, 0xbf, 0x38, 0xd9, 0xe9, 0x33,
+	0xd5, 0xda, 0xdc, 0x4c, 0x95, 0x1d, 0xb2, 0x0b, 0x1f, 0x34, 0x64, 0x5b, 0x5d, 0x20, 0xb2, 0xdd,
+	0xbe, 0x12, 0x1f, 0xa2, 0xcb, 0xcb, 0x41, 0x7e, 0xfc, 0x73, 0xda, 0xc7, 0xdf, 0xfa, 0xbd, 0x01,
+	0x15, 0xe9, 0x5c, 0xa1, 0x84, 0x17, 0x41, 0x20, 0x8e, 0x51, 0xf2, 0xf5, 0x56, 0x47, 0xfe, 0x0c,
+	0xf5, 0xaa, 0x48, 0x45, 0x74, 0x76, 0xe6, 0x83, 0x33, 0xfa, 0x4e, 0x78, 0x3e, 0xe5, 0xb3, 0x94,
+	0xe6, 0x87, 0xda, 0x8c, 0x2a, 0x3c, 0xc1, 0xe7, 0xc4, 0xb1, 0xed, 0xf9, 0x12, 0x22, 0x67, 0x2d,
+	0x53, 0x50, 0x44, 0x46, 0x50, 0x58, 0xdf, 0xf5, 0x42, 0x74, 0x58, 0x10, 0x9e, 0x4b, 0x6b, 0x96,
+	0xb7, 0xa8, 0x42, 0xcc, 0x99, 0x2a, 0x58, 0xfa, 0xde, 0xa0, 0x3d, 0x84, 0x4a, 0x10, 0x6f, 0xe7,
+	0xd7, 0x32, 0x4e, 0x9a, 0xcd, 0x1e, 0x6a, 0xed, 0x33, 0x2e, 0xd6, 0x22, 0x40, 0xe4, 0x11, 0x54,
+	0x5c, 0x65, 0x99, 0x37, 0x9b, 0x40, 0x6e, 0x68, 0x32, 0x59, 0xbb, 0xa9, 0x8e, 0x9e, 0x7d, 0x86,
+	0xf3, 0xe9, 0x67, 0x98, 0x8f, 0x42, 0x1b, 0xaa, 0x9d, 0xbe, 0x88, 0x99, 0x9c, 0x1c, 0xba, 0x60,
+	0x06, 0x53, 0x94, 0xb9, 0xa5, 0xba, 0xc8, 0xf7, 0x17, 0xbb, 0x6f, 0x02, 0x6f, 0xbf, 0x4c, 0xb0,
+	0x34, 0x15, 0x9b, 0x39, 0x2c, 0xa7, 0x39, 0xac, 0x0d, 0x6b, 0x7c, 0x11, 0x6f, 0xe4, 0x2f, 0x4d,
+	0x6e, 0x81, 0xe3, 0x09, 0xe4, 0xd8, 0xe3, 0x31, 0x86, 0x22, 0x42, 0x26, 0x55, 0x27, 0xf2, 0x31,
+	0xd4, 0xa6, 0x21, 0xbe, 0xf5, 0x82, 0x38, 0x1a, 0x8e, 0xec, 0x68, 0x24, 0xd2, 0xb5, 0x4a, 0xab,
+	0x09, 0xf1, 0x2b, 0x3b, 0x1a, 0xf1, 0x2c, 0x7b, 0x6b, 0x8f, 0x63, 0xb9, 0xbe, 0x54, 0xa9, 0x3c,
+	0x58, 0x5f, 0x82, 0x39, 0x33, 0x97, 0x94, 0x20, 0xdf, 0x7f, 0x75, 0x24, 0xe7, 0xff, 0xdd, 0xbd,
+	0x83, 0x3d, 0x3e, 0xff, 0x93, 0x2a, 0x94, 0x5f, 0x1d, 0xaa, 0x53, 0x8e, 0x73, 0xf6, 0x7e, 0xd9,
+	0xef, 0xd1, 0xbd, 0x7a, 0xde, 0x1a, 0x40, 0x4d, 0x2c, 0x7f, 0xe2, 0x23, 0xc1, 0xe5, 0xb5, 0x9d,
+	0xd6, 0xb8, 0x68, 0xa7, 0xbd, 0x60, 0x09, 0xb5, 0xfe, 0x9b, 0x03, 0xe8, 0xc6, 0xce, 0x6b, 0x64,
+	0x3d, 0xff, 0x24, 0xe0, 0x6e, 0xe3, 0x03, 0x6d, 0x92, 0x67, 0xfc, 0x37, 0x79, 0x08, 0x25, 0xd1,
+	0x2e, 0xd0, 0x7d, 0x8f, 0xb6, 0x90, 0x40, 0xc9, 0x73, 0x20, 0x2e, 0x9e, 0xd8, 0xf1, 0x98, 0x0d,
+	0xb5, 0xc2, 0xce, 0x5f, 0x5e, 0xd8, 0x57, 0x95, 0x58, 0xca, 0x20, 0x0f, 0x60, 0x33, 0xd1, 0x95,
+	0xe9, 0x31, 0xb2, 0x70, 0x92, 0x7b, 0xf4, 0xee, 0xdb, 0x82, 0x0a, 0x0f, 0xf9, 0xd0, 0xf1, 0xa6,
+	0x23, 0x0c, 0xd5, 0x98, 0x0f, 0x9c, 0xf4, 0x54, 0x50, 0xf8, 0x1f, 0x2b, 0x54, 0x49, 0xf2, 0x9e,
+	0xa7, 0x60, 0xc9, 0x9f, 0x1f, 0x66, 0x0c, 0x05, 0xee, 0xc0, 0x75, 0x0d, 0x7c, 0x3c, 0x0e, 0x9c,
+	0xd7, 0xd2, 0x80, 0x92, 0x10, 0xb8, 0x96, 0x32, 0xbb, 0x9c, 0x27, 0x2c, 0xb8, 0x09, 0xbc, 0xa0,
+	0x1d, 0x14, 0xdb, 0x73, 0x59, 0xae, 0x27, 0x33, 0x82, 0xb5, 0x0b, 0xd7, 0xa4, 0xd7, 0x9f, 0x0a,
+	0x77, 0x25, 0x2d, 0xeb, 0x07, 0x50, 0x3c, 0x16, 0x64, 0x55, 0x8d, 0xd7, 0xf5, 0x49, 0x66, 0x16,
+	0x25, 0xaa, 0x40, 0xd6, 0x1e, 0x6c, 0x66, 0xb5, 0xa8, 0x9a, 0xfe, 0x40, 0x35, 0x9f, 0x42, 0x5d,
+	0x52, 0xb3, 0xab, 0xdd, 0x7c, 0x22, 0x58, 0x5d, 0xb8, 0xaa, 0xe1, 0xbe, 0xd9, 0x5d, 0x9f, 0x25,
+	0x0f, 0x5f, 0x98, 0x8a, 0x17, 0xae, 0xdb, 0x82, 0xcd, 0x2c, 0x54, 0x0d, 0x56, 0xcf, 0x13, 0x33,
+	0xf4, 0x3d, 0x72, 0x6e, 0x5f, 0x34, 0x16, 0xf6, 0xc5, 0xe5, 0x5d, 0xff, 0x57, 0x40, 0x74, 0x5d,
+	0xea, 0x4d, 0xf7, 0xa1, 0x24, 0xcd, 0x4d, 0x26, 0xca, 0x15, 0x8f, 0x4a, 0x50, 0xcb, 0x16, 0x8c,
+	0xce, 0x7f, 0x4c, 0x30, 0x55, 0xab, 0xda, 0xed, 0x92, 0x87, 0x90, 0xef, 0xc7, 0x8c, 0xe8, 0x8a,
+	0xd2, 0xa9, 0xb4, 0xb9, 0x35, 0x4f, 0x56, 0x86, 0x3c, 0x84, 0xfc, 0x3e, 0x66, 0xa5, 0xf6, 0x71,
+	0xa9, 0x94, 0x1e, 0x92, 0x9f, 0xc2, 0x1a, 0x7f, 0x0e, 0xd9, 0x5a, 0xd8, 0x8d, 0xa4, 0xdc, 0x47,
+	0x2b, 0x76, 0x26, 0xf2, 0x18, 0x80, 0x9f, 0x07, 0x2c, 0x44, 0x7b, 0xf2, 0xc1, 0xe2, 0x0f, 0x0c,
+	0xf2, 0x73, 0x28, 0xca, 0x60, 0x11, 0xfd, 0x4f, 0x41, 0x99, 0x50, 0x37, 0x6f, 0x2c, 0xe1, 0xa8,
+	0xfb, 0x9f, 0x42, 0x39, 0x59, 0x67, 0x48, 0x53, 0x83, 0xcd, 0xad, 0x42, 0xcd, 0xed, 0xa5, 0xbc,
+	0x54, 0x49, 0xb2, 0x8a, 0x64, 0x94, 0xcc, 0x2d, 0x45, 0xcd, 0xed, 0xa5, 0xbc, 0x39, 0x25, 0xfd,
+	0x78, 0x89, 0x92, 0x7e, 0xbc, 0x5a, 0x89, 0x1e, 0xbd, 0x03, 0xa8, 0x68, 0x53, 0x3d, 0xb9, 0x35,
+	0x8f, 0xcd, 0xfa, 0xe5, 0xf6, 0x2a, 0xb6, 0xd2, 0x16, 0x41, 0x63, 0xd5, 0x30, 0x4b, 0xee, 0xe9,
+	0xf9, 0x73, 0xf1, 0x80, 0xde, 0xfc, 0xfc, 0xbd, 0xb0, 0xea, 0x52, 0x0a, 0xb5, 0xcc, 0x20, 0x4c,
+	0xf4, 0xdd, 0x6a, 0xd9, 0xe8, 0xdc, 0xdc, 0x59, 0x0d, 0x48, 0xdd, 0xa2, 0x0d, 0x22, 0x19, 0xb7,
+	0x2c, 0x4e, 0x71, 0xcd, 0xdb, 0xab, 0xd8, 0x4a, 0xdb, 0x4b, 0xa8, 0xca, 0xee, 0x27, 0xeb, 0x92,
+	0xdc, 0x5e, 0x28, 0xd5, 0x4c, 0x8b, 0x6d, 0xb6, 0x56, 0xf2, 0x95, 0xc2, 0x67, 0x60, 0xee, 0x23,
+	0x53, 0xda, 0xb6, 0x17, 0xd0, 0x5a, 0x06, 0xdd, 0x5c, 0xce, 0x4c, 0x0d, 0x93, 0x11, 0x5c, 0x69,
+	0x58, 0x36, 0xfe, 0xad, 0x95, 0x7c, 0xa5, 0xf0, 0xb9, 0xfc, 0xcb, 0x59, 0x57, 0xf5, 0x9c, 0xc5,
+	0xdb, 0xf5, 0x22, 0xbd, 0xb5, 0x82, 0xab, 0x74, 0xf5, 0x00, 0xd2, 0x4d, 0x22, 0xa3, 0x6a, 0x61,
+	0x15, 0x69, 0xde, 0x5a, 0xc1, 0x95, 0xaa, 0xba, 0x6b, 0xbf, 0xce, 0x4d, 0x8f, 0x8f, 0x8b, 0x62,
+	0x16, 0xf8, 0xd1, 0xff, 0x06, 0x00, 0x8d, 0x05, 0x32, 0xc8, 0x17, 0x19, 0x00, 0x00,
 }
@@ -4,6 +4,7 @@
 package pb
 
 import (
+	"github.com/gogo/protobuf/proto"
 	"go.uber.org/zap"
 
 	"storj.io/storj/pkg/storj"
@@ -52,12 +53,14 @@ func CopyNode(src *Node) (dst *Node) {
 		node.Address = &NodeAddress{
 			Transport: src.Address.Transport,
 			Address:   src.Address.Address,
+			Relay:     src.Address.Relay,
 		}
 	}
 	if src.Metadata != nil {
 		node.Metadata = &NodeMetadata{
-			Email:  src.Metadata.Email,
-			Wallet: src.Metadata.Wallet,
+			Email:          src.Metadata.Email,
+			Wallet:         src.Metadata.Wallet,
+			WalletFeatures: append([]string(nil), src.Metadata.WalletFeatures...),
 		}
 	}
 	if src.Restrictions != nil {
@@ -66,6 +69,12 @@ func CopyNode(src *Node) (dst *Node) {
 			FreeDisk:      src.Restrictions.FreeDisk,
 		}
 	}
+	if src.Tags != nil {
+		node.Tags = proto.Clone(src.Tags).(*SignedNodeTags)
+	}
+	if src.Signature != nil {
+		node.Signature = proto.Clone(src.Signature).(*NodeSignature)
+	}
 
 	node.Type = src.Type
 
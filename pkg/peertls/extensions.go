@@ -15,7 +15,7 @@ import (
 
 	"github.com/zeebo/errs"
 
-	"storj.io/storj/pkg/utils"
+	"storj.io/storj/internal/dburl"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/boltdb"
 	"storj.io/storj/storage/redis"
@@ -408,15 +408,15 @@ func verifyCAWhitelistSignedLeafFunc(caWhitelist []*x509.Certificate) extensionV
 
 // NewRevDB returns a new revocation database given the URL
 func NewRevDB(revocationDBURL string) (*RevocationDB, error) {
-	driver, source, err := utils.SplitDBURL(revocationDBURL)
+	dbURL, err := dburl.Parse(revocationDBURL)
 	if err != nil {
 		return nil, ErrRevocationDB.Wrap(err)
 	}
 
 	var db *RevocationDB
-	switch driver {
+	switch dbURL.Driver {
 	case "bolt":
-		db, err = NewRevocationDBBolt(source)
+		db, err = NewRevocationDBBolt(dbURL.Source)
 		if err != nil {
 			return nil, ErrRevocationDB.Wrap(err)
 		}
@@ -426,7 +426,7 @@ func NewRevDB(revocationDBURL string) (*RevocationDB, error) {
 			return nil, ErrRevocationDB.Wrap(err)
 		}
 	default:
-		return nil, ErrRevocationDB.New("database scheme not supported: %s", driver)
+		return nil, ErrRevocationDB.New("database scheme not supported: %s", dbURL.Driver)
 	}
 
 	return db, nil
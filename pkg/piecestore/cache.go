@@ -0,0 +1,160 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pstore
+
+import (
+	"bytes"
+	"container/list"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+)
+
+var mon = monkit.Package()
+
+// PieceCache keeps the data of frequently retrieved pieces in memory, so that
+// popular pieces are served without seeking on disk. The cache holds at most
+// `size` bytes and evicts the least recently used pieces when full.
+type PieceCache struct {
+	mu           sync.Mutex
+	size         int64
+	maxPieceSize int64
+	used         int64
+	order        *list.List
+	entries      map[string]*list.Element
+}
+
+// cachedPiece is the data of a piece in the cache
+type cachedPiece struct {
+	id   string
+	data []byte
+}
+
+// NewPieceCache returns a new piece cache holding at most size bytes of pieces
+// no larger than maxPieceSize. A nil cache is returned when size is not
+// positive; a nil cache doesn't cache anything.
+func NewPieceCache(size, maxPieceSize int64) *PieceCache {
+	if size <= 0 {
+		return nil
+	}
+	if maxPieceSize <= 0 || maxPieceSize > size {
+		maxPieceSize = size
+	}
+	return &PieceCache{
+		size:         size,
+		maxPieceSize: maxPieceSize,
+		order:        list.New(),
+		entries:      make(map[string]*list.Element),
+	}
+}
+
+// Get returns the data of the piece and marks it as recently used
+func (cache *PieceCache) Get(id string) (data []byte, ok bool) {
+	if cache == nil {
+		return nil, false
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	elem, ok := cache.entries[id]
+	if !ok {
+		mon.Counter("piece_cache_miss").Inc(1)
+		return nil, false
+	}
+	mon.Counter("piece_cache_hit").Inc(1)
+	cache.order.MoveToFront(elem)
+	return elem.Value.(*cachedPiece).data, true
+}
+
+// Fits returns whether pieces of size are cached
+func (cache *PieceCache) Fits(size int64) bool {
+	return cache != nil && size > 0 && size <= cache.maxPieceSize
+}
+
+// Add caches the data of the piece, evicting the oldest pieces when full.
+// The cache keeps data, so it mustn't be modified afterwards.
+func (cache *PieceCache) Add(id string, data []byte) {
+	if !cache.Fits(int64(len(data))) {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if elem, ok := cache.entries[id]; ok {
+		cache.remove(elem)
+	}
+
+	cache.entries[id] = cache.order.PushFront(&cachedPiece{id: id, data: data})
+	cache.used += int64(len(data))
+	for cache.used > cache.size {
+		cache.remove(cache.order.Back())
+		mon.Counter("piece_cache_evict").Inc(1)
+	}
+	mon.IntVal("piece_cache_used").Observe(cache.used)
+}
+
+// Remove removes the piece from the cache
+func (cache *PieceCache) Remove(id string) {
+	if cache == nil {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if elem, ok := cache.entries[id]; ok {
+		cache.remove(elem)
+	}
+}
+
+// Used returns the number of cached bytes
+func (cache *PieceCache) Used() int64 {
+	if cache == nil {
+		return 0
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.used
+}
+
+// remove removes elem from the cache, the lock must be held
+func (cache *PieceCache) remove(elem *list.Element) {
+	piece := cache.order.Remove(elem).(*cachedPiece)
+	delete(cache.entries, piece.id)
+	cache.used -= int64(len(piece.data))
+}
+
+// cachedReader returns a reader of length bytes of data from offset
+func cachedReader(data []byte, offset, length int64) io.ReadCloser {
+	return ioutil.NopCloser(bytes.NewReader(data[offset : offset+length]))
+}
+
+// cachingReader reads a whole piece and adds it to the cache when the piece
+// was read completely
+type cachingReader struct {
+	io.ReadCloser
+	add  func(data []byte)
+	size int64
+	data []byte
+}
+
+// Read reads from the piece, keeping what was read
+func (reader *cachingReader) Read(p []byte) (n int, err error) {
+	n, err = reader.ReadCloser.Read(p)
+	reader.data = append(reader.data, p[:n]...)
+	return n, err
+}
+
+// Close closes the piece and caches it when it was read completely
+func (reader *cachingReader) Close() error {
+	if int64(len(reader.data)) == reader.size {
+		reader.add(reader.data)
+	}
+	reader.data = nil
+	return reader.ReadCloser.Close()
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pstore
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+)
+
+func TestPieceCache(t *testing.T) {
+	disabled := NewPieceCache(0, 0)
+	disabled.Add("a", []byte{1})
+	_, ok := disabled.Get("a")
+	assert.False(t, ok)
+
+	cache := NewPieceCache(10, 4)
+	assert.False(t, cache.Fits(5))
+
+	cache.Add("a", []byte{1, 2, 3, 4})
+	cache.Add("b", []byte{1, 2, 3, 4})
+	cache.Add("big", []byte{1, 2, 3, 4, 5})
+	assert.Equal(t, int64(8), cache.Used())
+
+	_, ok = cache.Get("big")
+	assert.False(t, ok, "pieces larger than the limit aren't cached")
+
+	// "a" becomes the most recently used, so "b" is evicted
+	data, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte{1, 2, 3, 4}, data)
+
+	cache.Add("c", []byte{1, 2, 3})
+	assert.Equal(t, int64(7), cache.Used())
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	_, ok = cache.Get("a")
+	assert.True(t, ok)
+
+	cache.Remove("a")
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, int64(3), cache.Used())
+}
+
+func TestStoreCache(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	store := NewStorage(ctx.Dir("example"))
+	defer ctx.Check(store.Close)
+	store.SetCache(NewPieceCache(1<<20, 1<<20))
+
+	pieceID := strings.Repeat("AB01", 10)
+
+	source := make([]byte, 8000)
+	_, _ = rand.Read(source[:])
+
+	w, err := store.Writer(pieceID)
+	require.NoError(t, err)
+	_, err = io.Copy(w, bytes.NewReader(source))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	read := func(offset, length int64) []byte {
+		reader, err := store.Reader(ctx, pieceID, offset, length)
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+		require.NoError(t, reader.Close())
+		return data
+	}
+
+	assert.Equal(t, source[10:1010], read(10, 1000))
+	assert.Equal(t, int64(0), store.cache.Used(), "reads of parts aren't cached")
+
+	assert.Equal(t, source, read(0, -1))
+	assert.Equal(t, int64(len(source)), store.cache.Used())
+
+	// reads are served from the cache
+	path, err := store.PiecePath(pieceID)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, make([]byte, len(source)), 0600))
+	assert.Equal(t, source[10:1010], read(10, 1000))
+	assert.Equal(t, source[7000:], read(7000, 16000))
+
+	_, err = store.Reader(ctx, pieceID, int64(len(source)), 1)
+	assert.Error(t, err)
+
+	require.NoError(t, store.Delete(pieceID))
+	assert.Equal(t, int64(0), store.cache.Used())
+	_, err = store.Reader(ctx, pieceID, 0, -1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pstore
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// partialDir is the directory next to the stored pieces in which pieces are
+// written until their upload completes
+const partialDir = "partial"
+
+// PartialPath returns the path of the partial piece of an upload
+func (storage *Storage) PartialPath(pieceID string) (string, error) {
+	if len(pieceID) < IDLength {
+		return "", Error.New("invalid id length")
+	}
+	return filepath.Join(storage.dir, partialDir, pieceID), nil
+}
+
+// PartialWriter returns the file of the partial piece of an upload, positioned
+// at offset. Uploads starting at offset 0 create a new partial piece, resumed
+// uploads drop the content of the partial piece after offset.
+func (storage *Storage) PartialWriter(pieceID string, offset int64) (*os.File, error) {
+	path, err := storage.PartialPath(pieceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset == 0 {
+		if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return nil, Error.Wrap(err)
+		}
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+		return file, Error.Wrap(err)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	info, err := file.Stat()
+	if err == nil && info.Size() < offset {
+		err = Error.New("partial piece has %d bytes, can't resume at %d", info.Size(), offset)
+	}
+	if err == nil {
+		err = file.Truncate(offset)
+	}
+	if err == nil {
+		_, err = file.Seek(offset, io.SeekStart)
+	}
+	if err != nil {
+		_ = file.Close()
+		return nil, Error.Wrap(err)
+	}
+	return file, nil
+}
+
+// CommitPartial moves the partial piece of a completed upload to the stored
+// pieces
+func (storage *Storage) CommitPartial(pieceID string) error {
+	partial, err := storage.PartialPath(pieceID)
+	if err != nil {
+		return err
+	}
+	path, err := storage.PiecePath(pieceID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		return Error.New("piece %s already exists", pieceID)
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return Error.Wrap(err)
+	}
+	return Error.Wrap(os.Rename(partial, path))
+}
+
+// DeletePartial deletes the partial piece of an upload
+func (storage *Storage) DeletePartial(pieceID string) error {
+	path, err := storage.PartialPath(pieceID)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		err = nil
+	}
+	return Error.Wrap(err)
+}
+
+// PartialPieces returns the ids of the partial pieces and when they were
+// last written
+func (storage *Storage) PartialPieces() (map[string]time.Time, error) {
+	infos, err := ioutil.ReadDir(filepath.Join(storage.dir, partialDir))
+	if os.IsNotExist(err) {
+		return map[string]time.Time{}, nil
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	pieces := make(map[string]time.Time, len(infos))
+	for _, info := range infos {
+		if !info.IsDir() {
+			pieces[info.Name()] = info.ModTime()
+		}
+	}
+	return pieces, nil
+}
@@ -8,7 +8,6 @@ import (
 	"crypto"
 	"crypto/ecdsa"
 	"flag"
-	"fmt"
 	"io"
 	"log"
 	"time"
@@ -17,6 +16,8 @@ import (
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"storj.io/storj/internal/memory"
 	"storj.io/storj/pkg/pb"
@@ -28,6 +29,10 @@ import (
 // ClientError is any error returned by the client
 var ClientError = errs.Class("piecestore client error")
 
+// ErrInterrupted is returned when an upload was cut off by the connection to
+// the node breaking, after which it can be resumed
+var ErrInterrupted = errs.Class("piece upload interrupted")
+
 var (
 	defaultBandwidthMsgSize memory.Size = 32 * memory.KB
 	maxBandwidthMsgSize     memory.Size = 64 * memory.KB
@@ -45,7 +50,9 @@ func init() {
 // Client is an interface describing the functions for interacting with piecestore nodes
 type Client interface {
 	Meta(ctx context.Context, id PieceID) (*pb.PieceSummary, error)
-	Put(ctx context.Context, id PieceID, data io.Reader, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) error
+	Put(ctx context.Context, id PieceID, data io.Reader, size int64, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) error
+	PartialPiece(ctx context.Context, id PieceID, authorization *pb.SignedMessage) (written int64, err error)
+	Resume(ctx context.Context, id PieceID, data io.ReadSeeker, size int64, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) error
 	Get(ctx context.Context, id PieceID, size int64, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (ranger.Ranger, error)
 	Delete(ctx context.Context, pieceID PieceID, authorization *pb.SignedMessage) error
 	io.Closer
@@ -118,26 +125,66 @@ func (ps *PieceStore) Meta(ctx context.Context, id PieceID) (*pb.PieceSummary, e
 	return ps.client.Piece(ctx, &pb.PieceId{Id: id.String()})
 }
 
-// Put uploads a Piece to a piece store Server
-func (ps *PieceStore) Put(ctx context.Context, id PieceID, data io.Reader, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) error {
-	stream, err := ps.client.Store(ctx)
+// Put uploads a Piece to a piece store Server. The size is declared to the
+// server when opening the stream, so it can reserve space and reject
+// uploads early, 0 uploads a piece of unknown size. Pieces longer than a
+// declared size are rejected.
+func (ps *PieceStore) Put(ctx context.Context, id PieceID, data io.Reader, size int64, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (err error) {
+	return ps.put(ctx, id, data, 0, size, ttl, ba, authorization)
+}
+
+// PartialPiece returns how many bytes of an interrupted upload of the piece
+// the server durably wrote, 0 when there's nothing to resume
+func (ps *PieceStore) PartialPiece(ctx context.Context, id PieceID, authorization *pb.SignedMessage) (written int64, err error) {
+	summary, err := ps.client.PartialPiece(ctx, &pb.PieceId{Id: id.String(), Authorization: authorization})
+	if err != nil {
+		return 0, err
+	}
+	return summary.GetWritten(), nil
+}
+
+// Resume uploads a Piece like Put, continuing an interrupted upload of it
+// from the bytes the server durably wrote. The data is read from there on.
+func (ps *PieceStore) Resume(ctx context.Context, id PieceID, data io.ReadSeeker, size int64, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (err error) {
+	offset, err := ps.PartialPiece(ctx, id, authorization)
 	if err != nil {
 		return err
 	}
+	if _, err := data.Seek(offset, io.SeekStart); err != nil {
+		return ClientError.Wrap(err)
+	}
+	if offset > 0 {
+		zap.S().Infof("Resuming upload of piece %s at %d bytes", id, offset)
+	}
+	return ps.put(ctx, id, data, offset, size, ttl, ba, authorization)
+}
+
+// put uploads the piece from offset on
+func (ps *PieceStore) put(ctx context.Context, id PieceID, data io.Reader, offset, size int64, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (err error) {
+	// uploads count the whole time they take
+	storeCtx, cancel := context.WithCancel(ctx)
+	deadline := newTransferDeadline(cancel)
+	deadline.Wait()
+	defer func() {
+		err = deadline.Err(err)
+		deadline.Stop()
+	}()
+
+	stream, err := ps.client.Store(storeCtx)
+	if err != nil {
+		return interrupted(stream, err)
+	}
 
 	msg := &pb.PieceStore{
-		PieceData:     &pb.PieceStore_PieceData{Id: id.String(), ExpirationUnixSec: ttl.Unix()},
+		PieceData:     &pb.PieceStore_PieceData{Id: id.String(), ExpirationUnixSec: ttl.Unix(), PieceSize: size, Offset: offset},
 		Authorization: authorization,
 	}
 	if err = stream.Send(msg); err != nil {
-		if _, closeErr := stream.CloseAndRecv(); closeErr != nil {
-			zap.S().Errorf("error closing stream %s :: %v.Send() = %v", closeErr, stream, closeErr)
-		}
-
-		return fmt.Errorf("%v.Send() = %v", stream, err)
+		return interrupted(stream, err)
 	}
 
-	writer := &StreamWriter{signer: ps, stream: stream, pba: ba}
+	// the bandwidth allocations of resumed uploads count the whole piece
+	writer := &StreamWriter{signer: ps, stream: stream, totalWritten: offset, pba: ba, deadline: deadline}
 
 	defer func() {
 		if err := writer.Close(); err != nil && err != io.EOF {
@@ -163,14 +210,32 @@ func (ps *PieceStore) Put(ctx context.Context, id PieceID, data io.Reader, ttl t
 	return bufw.Flush()
 }
 
+// interrupted returns the error of an upload stream, wrapped in
+// ErrInterrupted when the connection broke. Sends fail with io.EOF when the
+// stream ended, its status tells why.
+func interrupted(stream pb.PieceStoreRoutes_StoreClient, err error) error {
+	if err == io.EOF && stream != nil {
+		if _, closeErr := stream.CloseAndRecv(); closeErr != nil {
+			err = closeErr
+		}
+	}
+	if status.Code(err) == codes.Unavailable {
+		return ErrInterrupted.Wrap(err)
+	}
+	return err
+}
+
 // Get begins downloading a Piece from a piece store Server
 func (ps *PieceStore) Get(ctx context.Context, id PieceID, size int64, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (ranger.Ranger, error) {
-	stream, err := ps.client.Retrieve(ctx)
+	retrieveCtx, cancel := context.WithCancel(ctx)
+
+	stream, err := ps.client.Retrieve(retrieveCtx)
 	if err != nil {
+		cancel()
 		return nil, err
 	}
 
-	return PieceRangerSize(ps, stream, id, size, ba, authorization), nil
+	return &pieceRanger{c: ps, id: id, size: size, stream: stream, pba: ba, authorization: authorization, cancel: cancel}, nil
 }
 
 // Delete a Piece from a piece store Server
@@ -0,0 +1,170 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psclient
+
+import (
+	"flag"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/internal/memory"
+)
+
+// ErrSlowTransfer is returned when a piece transfer falls below the minimum throughput
+var ErrSlowTransfer = errs.Class("piece transfer too slow")
+
+var (
+	minThroughput = 128 * memory.KiB
+	minTimeout    = 10 * time.Second
+)
+
+func init() {
+	flag.Var(&minThroughput,
+		"piecestore.rpc.client.min-throughput",
+		"minimum sustained bytes per second of a piece transfer before it is canceled, 0 disables")
+	flag.DurationVar(&minTimeout,
+		"piecestore.rpc.client.min-timeout",
+		minTimeout,
+		"time a piece transfer is allowed to take on top of the time needed at the minimum throughput")
+}
+
+// transferDeadline cancels a transfer that didn't move enough bytes in time.
+// A transfer of n bytes is allowed to wait minTimeout + n/minThroughput on
+// the node, so large pieces get proportionally more time while stalled
+// transfers are canceled as soon as they fall behind. Only the time between
+// Wait and Pause counts, so a transfer isn't canceled for waiting on its own
+// side.
+type transferDeadline struct {
+	cancel func()
+
+	mu          sync.Mutex
+	timer       *time.Timer
+	waited      time.Duration // time waited before the current wait
+	waitStart   time.Time     // zero when not waiting
+	transferred int64
+	stopped     bool
+	expired     bool
+}
+
+// newTransferDeadline starts tracking a transfer, cancel is called when it
+// expires or stops. No time counts until the transfer waits on the node.
+func newTransferDeadline(cancel func()) *transferDeadline {
+	return &transferDeadline{cancel: cancel}
+}
+
+// allowed returns how long the transfer may wait for the bytes transferred so far
+func (deadline *transferDeadline) allowed() time.Duration {
+	return minTimeout + time.Duration(deadline.transferred)*time.Second/time.Duration(minThroughput.Int64())
+}
+
+// elapsed returns how long the transfer waited on the node
+func (deadline *transferDeadline) elapsed() time.Duration {
+	if deadline.waitStart.IsZero() {
+		return deadline.waited
+	}
+	return deadline.waited + time.Since(deadline.waitStart)
+}
+
+// check cancels the transfer when it is behind, otherwise waits until it could be
+func (deadline *transferDeadline) check() {
+	deadline.mu.Lock()
+	if deadline.stopped || deadline.waitStart.IsZero() {
+		deadline.mu.Unlock()
+		return
+	}
+	if remaining := deadline.allowed() - deadline.elapsed(); remaining > 0 {
+		deadline.timer.Reset(remaining)
+		deadline.mu.Unlock()
+		return
+	}
+	deadline.expired = true
+	deadline.mu.Unlock()
+
+	deadline.cancel()
+}
+
+// Wait starts counting the time the transfer waits on the node
+func (deadline *transferDeadline) Wait() {
+	if deadline == nil {
+		return
+	}
+
+	deadline.mu.Lock()
+	defer deadline.mu.Unlock()
+
+	if deadline.stopped || !deadline.waitStart.IsZero() || minThroughput <= 0 {
+		return
+	}
+	deadline.waitStart = time.Now()
+
+	remaining := deadline.allowed() - deadline.waited
+	if deadline.timer == nil {
+		deadline.timer = time.AfterFunc(remaining, deadline.check)
+	} else {
+		deadline.timer.Reset(remaining)
+	}
+}
+
+// Pause stops counting time while the transfer waits on its own side
+func (deadline *transferDeadline) Pause() {
+	if deadline == nil {
+		return
+	}
+
+	deadline.mu.Lock()
+	defer deadline.mu.Unlock()
+
+	if deadline.waitStart.IsZero() {
+		return
+	}
+	deadline.waited += time.Since(deadline.waitStart)
+	deadline.waitStart = time.Time{}
+	deadline.timer.Stop()
+}
+
+// Transferred records progress of the transfer
+func (deadline *transferDeadline) Transferred(n int) {
+	if deadline == nil {
+		return
+	}
+
+	deadline.mu.Lock()
+	defer deadline.mu.Unlock()
+
+	deadline.transferred += int64(n)
+}
+
+// Stop stops tracking the transfer and releases its context
+func (deadline *transferDeadline) Stop() {
+	if deadline == nil {
+		return
+	}
+
+	deadline.mu.Lock()
+	deadline.stopped = true
+	if deadline.timer != nil {
+		deadline.timer.Stop()
+	}
+	deadline.mu.Unlock()
+
+	deadline.cancel()
+}
+
+// Err replaces err with ErrSlowTransfer when the deadline canceled the transfer
+func (deadline *transferDeadline) Err(err error) error {
+	if deadline == nil || err == nil {
+		return err
+	}
+
+	deadline.mu.Lock()
+	defer deadline.mu.Unlock()
+
+	if deadline.expired {
+		return ErrSlowTransfer.New("transferred %d bytes in %v, minimum throughput is %v/s",
+			deadline.transferred, deadline.elapsed().Round(time.Millisecond), minThroughput)
+	}
+	return err
+}
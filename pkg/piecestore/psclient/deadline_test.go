@@ -0,0 +1,88 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/internal/memory"
+)
+
+func TestTransferDeadline(t *testing.T) {
+	defer func(throughput memory.Size, timeout time.Duration) {
+		minThroughput, minTimeout = throughput, timeout
+	}(minThroughput, minTimeout)
+	minThroughput, minTimeout = 10*memory.KiB, 50*time.Millisecond
+
+	transferErr := errors.New("transfer failed")
+
+	{ // stalled transfer is canceled
+		ctx, cancel := context.WithCancel(context.Background())
+		deadline := newTransferDeadline(cancel)
+		deadline.Wait()
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatal("stalled transfer wasn't canceled")
+		}
+		assert.True(t, ErrSlowTransfer.Has(deadline.Err(transferErr)))
+		assert.NoError(t, deadline.Err(nil))
+		deadline.Stop()
+	}
+
+	{ // transfer at the minimum throughput gets more time than the minimum timeout
+		ctx, cancel := context.WithCancel(context.Background())
+		deadline := newTransferDeadline(cancel)
+		deadline.Wait()
+
+		for i := 0; i < 20; i++ {
+			deadline.Transferred(memory.KiB.Int())
+			time.Sleep(10 * time.Millisecond)
+		}
+		assert.NoError(t, ctx.Err())
+
+		deadline.Stop()
+		assert.Error(t, ctx.Err())
+		assert.Equal(t, transferErr, deadline.Err(transferErr))
+	}
+
+	{ // only the time waiting on the other side counts
+		ctx, cancel := context.WithCancel(context.Background())
+		deadline := newTransferDeadline(cancel)
+		time.Sleep(2 * minTimeout)
+		assert.NoError(t, ctx.Err())
+
+		deadline.Wait()
+		time.Sleep(minTimeout / 2)
+		deadline.Pause()
+		time.Sleep(2 * minTimeout)
+		assert.NoError(t, ctx.Err())
+
+		deadline.Wait()
+		select {
+		case <-ctx.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatal("stalled transfer wasn't canceled")
+		}
+		assert.True(t, ErrSlowTransfer.Has(deadline.Err(transferErr)))
+		deadline.Stop()
+	}
+
+	{ // disabled
+		minThroughput = 0
+
+		ctx, cancel := context.WithCancel(context.Background())
+		deadline := newTransferDeadline(cancel)
+		deadline.Wait()
+		time.Sleep(2 * minTimeout)
+		assert.NoError(t, ctx.Err())
+		deadline.Stop()
+	}
+}
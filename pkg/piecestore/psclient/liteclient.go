@@ -14,6 +14,10 @@ import (
 type LiteClient interface {
 	Stats(ctx context.Context) (*pb.StatSummary, error)
 	Dashboard(ctx context.Context) (pb.PieceStoreRoutes_DashboardClient, error)
+	Usage(ctx context.Context, req *pb.UsageRequest) (*pb.UsageResponse, error)
+	RetainLog(ctx context.Context, req *pb.RetainLogRequest) (*pb.RetainLogResponse, error)
+	AuditReceipts(ctx context.Context, req *pb.AuditReceiptsRequest) (*pb.AuditReceiptsResponse, error)
+	SetAllocatedSpace(ctx context.Context, req *pb.SetAllocatedSpaceRequest) (*pb.SetAllocatedSpaceResponse, error)
 }
 
 // PieceStoreLite is the struct that holds the client
@@ -31,6 +35,26 @@ func (psl *PieceStoreLite) Stats(ctx context.Context) (*pb.StatSummary, error) {
 	return psl.client.Stats(ctx, &pb.StatsReq{})
 }
 
+// Usage returns the hourly usage per satellite of a local storage node
+func (psl *PieceStoreLite) Usage(ctx context.Context, req *pb.UsageRequest) (*pb.UsageResponse, error) {
+	return psl.client.Usage(ctx, req)
+}
+
+// RetainLog returns the garbage collection summaries of a local storage node
+func (psl *PieceStoreLite) RetainLog(ctx context.Context, req *pb.RetainLogRequest) (*pb.RetainLogResponse, error) {
+	return psl.client.RetainLog(ctx, req)
+}
+
+// AuditReceipts returns the audit receipts stored by a local storage node
+func (psl *PieceStoreLite) AuditReceipts(ctx context.Context, req *pb.AuditReceiptsRequest) (*pb.AuditReceiptsResponse, error) {
+	return psl.client.AuditReceipts(ctx, req)
+}
+
+// SetAllocatedSpace changes the disk space allocated by a local storage node
+func (psl *PieceStoreLite) SetAllocatedSpace(ctx context.Context, req *pb.SetAllocatedSpaceRequest) (*pb.SetAllocatedSpaceResponse, error) {
+	return psl.client.SetAllocatedSpace(ctx, req)
+}
+
 // NewLiteClient returns a new LiteClient
 func NewLiteClient(ctx context.Context, tc transport.Client, n *pb.Node) (LiteClient, error) {
 	conn, err := tc.DialNode(ctx, n)
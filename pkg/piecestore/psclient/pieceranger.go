@@ -25,6 +25,8 @@ type pieceRanger struct {
 	stream        pb.PieceStoreRoutes_RetrieveClient
 	pba           *pb.PayerBandwidthAllocation
 	authorization *pb.SignedMessage
+	// cancel cancels the stream when the download is too slow
+	cancel func()
 }
 
 // PieceRanger PieceRanger returns a Ranger from a PieceID.
@@ -63,10 +65,20 @@ func (r *pieceRanger) Range(ctx context.Context, offset, length int64) (io.ReadC
 		return ioutil.NopCloser(bytes.NewReader([]byte{})), nil
 	}
 
+	// the deadline counts the time the reader waits on the node, rather
+	// than the time it's open
+	var deadline *transferDeadline
+	if r.cancel != nil {
+		deadline = newTransferDeadline(r.cancel)
+	}
+
 	// send piece data
 	if err := r.stream.Send(&pb.PieceRetrieval{PieceData: &pb.PieceRetrieval_PieceData{Id: r.id.String(), PieceSize: length, Offset: offset}, Authorization: r.authorization}); err != nil {
+		deadline.Stop()
 		return nil, err
 	}
 
-	return NewStreamReader(r.c, r.stream, r.pba, r.size), nil
+	reader := NewStreamReader(r.c, r.stream, r.pba, r.size)
+	reader.deadline = deadline
+	return reader, nil
 }
@@ -4,8 +4,6 @@
 package psclient
 
 import (
-	"fmt"
-
 	"github.com/gogo/protobuf/proto"
 	"go.uber.org/zap"
 
@@ -20,6 +18,7 @@ type StreamWriter struct {
 	signer       *PieceStore // We need this for signing
 	totalWritten int64
 	pba          *pb.PayerBandwidthAllocation
+	deadline     *transferDeadline
 }
 
 // Write Piece data to a piece store server upload stream
@@ -42,7 +41,7 @@ func (s *StreamWriter) Write(b []byte) (int, error) {
 	}
 
 	msg := &pb.PieceStore{
-		PieceData: &pb.PieceStore_PieceData{Content: b},
+		PieceData: &pb.PieceStore_PieceData{Content: b, Offset: s.totalWritten},
 		BandwidthAllocation: &pb.RenterBandwidthAllocation{
 			Data: serializedAllocation, Signature: sig,
 		},
@@ -52,8 +51,9 @@ func (s *StreamWriter) Write(b []byte) (int, error) {
 
 	// Second we send the actual content
 	if err := s.stream.Send(msg); err != nil {
-		return 0, fmt.Errorf("%v.Send() = %v", s.stream, err)
+		return 0, interrupted(s.stream, err)
 	}
+	s.deadline.Transferred(len(b))
 
 	return len(b), nil
 }
@@ -79,6 +79,7 @@ type StreamReader struct {
 	downloaded    int64
 	allocated     int64
 	size          int64
+	deadline      *transferDeadline
 }
 
 // NewStreamReader creates a StreamReader for reading data from the piece store server
@@ -151,13 +152,17 @@ func NewStreamReader(client *PieceStore, stream pb.PieceStoreRoutes_RetrieveClie
 	}()
 
 	sr.src = utils.NewReaderSource(func() ([]byte, error) {
+		sr.deadline.Wait()
 		resp, err := stream.Recv()
+		sr.deadline.Pause()
 		if err != nil {
+			err = sr.deadline.Err(err)
 			sr.pendingAllocs.Fail(err)
 			return nil, err
 		}
 
 		sr.downloaded += int64(len(resp.GetContent()))
+		sr.deadline.Transferred(len(resp.GetContent()))
 
 		err = sr.pendingAllocs.Consume(int64(len(resp.GetContent())))
 		if err != nil {
@@ -178,6 +183,7 @@ func (s *StreamReader) Read(b []byte) (int, error) {
 
 // Close the piece store server Read Stream
 func (s *StreamReader) Close() error {
+	defer s.deadline.Stop()
 	return utils.CombineErrors(
 		s.stream.CloseSend(),
 		s.client.Close(),
@@ -0,0 +1,106 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/zeebo/errs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/server"
+	"storj.io/storj/pkg/storj"
+)
+
+// AuditReceiptError is a type of error for invalid audit receipts
+var AuditReceiptError = errs.Class("audit receipt error")
+
+// SignAuditReceipt signs the receipt with the identity of the satellite
+func SignAuditReceipt(ident *identity.FullIdentity, receipt *pb.AuditReceipt) (err error) {
+	receipt.Signature, receipt.Chain = nil, nil
+	data, err := proto.Marshal(receipt)
+	if err != nil {
+		return AuditReceiptError.Wrap(err)
+	}
+	receipt.Signature, err = auth.GenerateSignature(data, ident)
+	if err != nil {
+		return AuditReceiptError.Wrap(err)
+	}
+	receipt.Chain = [][]byte{ident.Leaf.Raw, ident.CA.Raw}
+	return nil
+}
+
+// VerifyAuditReceipt checks that the receipt was signed by the satellite it
+// names
+func VerifyAuditReceipt(receipt *pb.AuditReceipt) error {
+	unsigned := *receipt
+	unsigned.Signature, unsigned.Chain = nil, nil
+	data, err := proto.Marshal(&unsigned)
+	if err != nil {
+		return AuditReceiptError.Wrap(err)
+	}
+	signer, err := auth.VerifyChainSignature(data, receipt.Signature, receipt.Chain)
+	if err != nil {
+		return AuditReceiptError.Wrap(err)
+	}
+	if signer != receipt.SatelliteId {
+		return AuditReceiptError.New("receipt of %s is signed by %s", receipt.SatelliteId, signer)
+	}
+	return nil
+}
+
+// VerifyOwnAuditReceipt checks that the receipt was signed by the satellite
+// it names and is a receipt of an audit of the node
+func VerifyOwnAuditReceipt(nodeID storj.NodeID, receipt *pb.AuditReceipt) error {
+	if receipt.NodeId != nodeID {
+		return AuditReceiptError.New("receipt of an audit of %s", receipt.NodeId)
+	}
+	return VerifyAuditReceipt(receipt)
+}
+
+// StoreAuditReceipt stores the receipt of an audit sent by the trusted
+// satellite which signed it
+func (s *Server) StoreAuditReceipt(ctx context.Context, receipt *pb.AuditReceipt) (_ *pb.StoreAuditReceiptResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	peer, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if peer.ID != receipt.SatelliteId {
+		return nil, status.Errorf(codes.PermissionDenied, "receipts of %s are only accepted from it", receipt.SatelliteId)
+	}
+	if !s.Trust.Trusted(receipt.SatelliteId) {
+		return nil, status.Errorf(codes.PermissionDenied, "untrusted satellite %s", receipt.SatelliteId)
+	}
+	if err := VerifyAuditReceipt(receipt); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.DB.AddAuditReceipt(receipt); err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+	mon.Counter("audit_receipts_stored").Inc(1)
+	return &pb.StoreAuditReceiptResponse{}, nil
+}
+
+// AuditReceipts returns the stored audit receipts to local callers
+func (s *Server) AuditReceipts(ctx context.Context, req *pb.AuditReceiptsRequest) (_ *pb.AuditReceiptsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !server.IsLocal(ctx) {
+		return nil, status.Errorf(codes.PermissionDenied, "audit receipts are only served to local callers")
+	}
+
+	receipts, err := s.DB.GetAuditReceipts(req.SatelliteId, int(req.Limit))
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+	return &pb.AuditReceiptsResponse{Receipts: receipts}, nil
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/pb"
+)
+
+func TestAuditReceipts(t *testing.T) {
+	ctx := context.Background()
+	s, cleanup := newTestServerStruct(t)
+	defer cleanup()
+
+	satellite, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+	other, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+	node := teststorj.NodeIDFromString("node")
+
+	from := func(ident *identity.FullIdentity) context.Context {
+		info := credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{ident.Leaf, ident.CA}}}
+		return peer.NewContext(ctx, &peer.Peer{AuthInfo: info})
+	}
+	receipt := func(outcome pb.AuditReceipt_Outcome, audited time.Time) *pb.AuditReceipt {
+		receipt := &pb.AuditReceipt{
+			SatelliteId:    satellite.ID,
+			NodeId:         node,
+			PieceId:        "piece",
+			Outcome:        outcome,
+			AuditedUnixSec: audited.Unix(),
+		}
+		require.NoError(t, SignAuditReceipt(satellite, receipt))
+		return receipt
+	}
+
+	now := time.Now()
+	_, err = s.StoreAuditReceipt(from(satellite), receipt(pb.AuditReceipt_SUCCESS, now.Add(-time.Hour)))
+	require.NoError(t, err)
+	_, err = s.StoreAuditReceipt(from(satellite), receipt(pb.AuditReceipt_FAILURE, now))
+	require.NoError(t, err)
+
+	{ // receipts are only accepted from the satellite which signed them
+		_, err = s.StoreAuditReceipt(from(other), receipt(pb.AuditReceipt_SUCCESS, now))
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		tampered := receipt(pb.AuditReceipt_FAILURE, now)
+		tampered.Outcome = pb.AuditReceipt_SUCCESS
+		_, err = s.StoreAuditReceipt(from(satellite), tampered)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	{ // the receipts are served to local callers, newest first
+		local := peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}})
+		resp, err := s.AuditReceipts(local, &pb.AuditReceiptsRequest{SatelliteId: satellite.ID})
+		require.NoError(t, err)
+		require.Len(t, resp.Receipts, 2)
+		assert.Equal(t, pb.AuditReceipt_FAILURE, resp.Receipts[0].Outcome)
+		assert.Equal(t, pb.AuditReceipt_SUCCESS, resp.Receipts[1].Outcome)
+		for _, receipt := range resp.Receipts {
+			assert.NoError(t, VerifyOwnAuditReceipt(node, receipt))
+			assert.Error(t, VerifyOwnAuditReceipt(other.ID, receipt))
+		}
+
+		resp, err = s.AuditReceipts(local, &pb.AuditReceiptsRequest{SatelliteId: other.ID})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Receipts)
+
+		_, err = s.AuditReceipts(ctx, &pb.AuditReceiptsRequest{})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	}
+}
@@ -19,16 +19,18 @@ var (
 	Error = errs.Class("kademlia bucket refresher error")
 )
 
-// refreshService contains the information needed to run the bucket refresher service
-type refreshService struct {
+// RefreshService periodically advertises the storage node's free disk and
+// bandwidth by updating its own entry in the kademlia routing table
+type RefreshService struct {
 	log    *zap.Logger
 	ticker *time.Ticker
 	rt     *kademlia.RoutingTable
 	server *Server
 }
 
-func newService(log *zap.Logger, interval time.Duration, rt *kademlia.RoutingTable, server *Server) *refreshService {
-	return &refreshService{
+// NewRefreshService creates a new bucket refresher service
+func NewRefreshService(log *zap.Logger, interval time.Duration, rt *kademlia.RoutingTable, server *Server) *RefreshService {
+	return &RefreshService{
 		log:    log,
 		ticker: time.NewTicker(interval),
 		rt:     rt,
@@ -37,7 +39,7 @@ func newService(log *zap.Logger, interval time.Duration, rt *kademlia.RoutingTab
 }
 
 // Run runs the bucket refresher service
-func (service *refreshService) Run(ctx context.Context) {
+func (service *RefreshService) Run(ctx context.Context) {
 	for {
 		err := service.process(ctx)
 		if err != nil {
@@ -53,7 +55,7 @@ func (service *refreshService) Run(ctx context.Context) {
 }
 
 // process will attempt to update the kademlia bucket with the latest information about the storage node
-func (service *refreshService) process(ctx context.Context) error {
+func (service *RefreshService) process(ctx context.Context) error {
 	stats, err := service.server.Stats(ctx, nil)
 	if err != nil {
 		return Error.Wrap(err)
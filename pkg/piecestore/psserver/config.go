@@ -31,8 +31,25 @@ type Config struct {
 	Path                         string        `help:"path to store data in" default:"$CONFDIR/storage"`
 	AllocatedDiskSpace           memory.Size   `user:"true" help:"total allocated disk space in bytes" default:"1TB"`
 	AllocatedBandwidth           memory.Size   `user:"true" help:"total allocated bandwidth in bytes" default:"500GiB"`
+	ReservedDiskSpace            memory.Size   `user:"true" help:"disk space kept free on the disk pieces are stored on, uploads are rejected when they would use it" default:"1GiB"`
 	KBucketRefreshInterval       time.Duration `help:"how frequently Kademlia bucket should be refreshed with node stats" default:"1h0m0s"`
 	AgreementSenderCheckInterval time.Duration `help:"duration between agreement checks" default:"1h0m0s"`
+	PartialExpiration            time.Duration `help:"how long the partial pieces of interrupted uploads are kept to resume the uploads, 0 deletes them right away" default:"1h0m0s"`
+	Scrub                        ScrubConfig
+	Trust                        TrustConfig
+	Cache                        CacheConfig
+	Retain                       RetainConfig
+}
+
+// CacheConfig configures keeping frequently retrieved pieces in memory
+type CacheConfig struct {
+	Size         memory.Size `help:"memory for caching frequently retrieved pieces, 0 disables the cache" default:"0"`
+	MaxPieceSize memory.Size `help:"pieces larger than this aren't cached" default:"4MiB"`
+}
+
+// NewCache returns the piece cache configured by config, nil when disabled
+func (config CacheConfig) NewCache() *pstore.PieceCache {
+	return pstore.NewPieceCache(config.Size.Int64(), config.MaxPieceSize.Int64())
 }
 
 // Run implements provider.Responsibility
@@ -41,6 +58,7 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 
 	// piecestore Storage Driver
 	storage := pstore.NewStorage(filepath.Join(c.Path, "piece-store-data"))
+	storage.SetCache(c.Cache.NewCache())
 
 	db, err := psdb.Open(ctx, storage, filepath.Join(c.Path, "piecestore.db"))
 	if err != nil {
@@ -77,13 +95,34 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 	}
 
 	// Initialize Refresh process for updating storage node meta in kademlia
-	refreshProcess := newService(zap.L(), c.KBucketRefreshInterval, krt, s)
+	refreshProcess := NewRefreshService(zap.L(), c.KBucketRefreshInterval, krt, s)
 	go refreshProcess.Run(ctx)
 
 	// Initialize agreementsender process for sending received bandwidth agreements to satellites
 	agreementSender := agreementsender.New(zap.L(), s.DB, server.Identity(), kad, c.AgreementSenderCheckInterval)
 	go agreementSender.Run(ctx)
 
+	// Initialize trust for deciding which satellites uploads are accepted from
+	s.Trust, err = NewTrust(zap.L(), c.Trust)
+	if err != nil {
+		return err
+	}
+	go func() { _ = s.Trust.Run(ctx) }()
+
+	// Initialize scrubber for verifying stored pieces
+	s.Scrubber = NewScrubber(zap.L(), storage, db, c.Scrub, NewCorruptionReporter(server.Identity(), kad))
+	go func() { _ = s.Scrubber.Run(ctx) }()
+
+	// Initialize retainer for garbage collecting pieces
+	s.Retainer, err = NewRetainer(zap.L(), storage, db, server.Identity(), c.Retain)
+	if err != nil {
+		return err
+	}
+	go func() { _ = s.Retainer.Run(ctx) }()
+
+	// Delete the expired partial pieces of interrupted uploads
+	go func() { _ = s.Partials.Run(ctx) }()
+
 	s.log.Info("Started Node", zap.String("ID", fmt.Sprint(server.Identity().ID)))
 	return server.Run(ctx)
 }
@@ -0,0 +1,195 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/pb"
+	pstore "storj.io/storj/pkg/piecestore"
+	"storj.io/storj/pkg/piecestore/psserver/psdb"
+)
+
+// Partials keeps the partial pieces of interrupted uploads until they expire,
+// so that the uploads can be resumed where they were interrupted. A nil
+// Partials or one without expiration deletes them right away.
+type Partials struct {
+	log        *zap.Logger
+	storage    *pstore.Storage
+	db         *psdb.DB
+	expiration time.Duration
+}
+
+// NewPartials creates the keeper of the partial pieces of interrupted uploads
+func NewPartials(log *zap.Logger, storage *pstore.Storage, db *psdb.DB, expiration time.Duration) *Partials {
+	return &Partials{
+		log:        log,
+		storage:    storage,
+		db:         db,
+		expiration: expiration,
+	}
+}
+
+// enabled returns whether partial pieces are kept
+func (partials *Partials) enabled() bool {
+	return partials != nil && partials.expiration > 0
+}
+
+// Get returns the partial upload of the piece by now, nil when there's none
+// to resume
+func (partials *Partials) Get(id string, now time.Time) (*psdb.PartialUpload, error) {
+	if !partials.enabled() {
+		return nil, nil
+	}
+
+	upload, err := partials.db.GetPartialUpload(id)
+	if err != nil || upload == nil {
+		return nil, err
+	}
+	if upload.Expires < now.Unix() {
+		return nil, nil
+	}
+
+	path, err := partials.storage.PartialPath(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, partials.db.DeletePartialUpload(id)
+	}
+	return upload, nil
+}
+
+// keep closes the file of the partial piece of an interrupted upload, which
+// wrote written bytes, and keeps it to be resumed
+func (partials *Partials) keep(id string, file *os.File, written int64) error {
+	// only the bytes which survive a crash may be resumed from
+	if err := errs.Combine(file.Sync(), file.Close()); err != nil {
+		return errs.Combine(err, partials.delete(id))
+	}
+
+	err := partials.db.SetPartialUpload(&psdb.PartialUpload{
+		ID:      id,
+		Written: written,
+		Expires: time.Now().Add(partials.expiration).Unix(),
+	})
+	if err != nil {
+		return errs.Combine(err, partials.delete(id))
+	}
+	mon.Counter("uploads_interrupted").Inc(1)
+	return nil
+}
+
+// delete deletes the partial piece and forgets its upload
+func (partials *Partials) delete(id string) error {
+	return errs.Combine(
+		partials.storage.DeletePartial(id),
+		partials.db.DeletePartialUpload(id),
+	)
+}
+
+// Run deletes the expired partial pieces until ctx is canceled
+func (partials *Partials) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !partials.enabled() {
+		return nil
+	}
+
+	ticker := time.NewTicker(partials.expiration)
+	defer ticker.Stop()
+
+	for {
+		if err := partials.DeleteExpired(ctx, time.Now()); err != nil {
+			partials.log.Error("deleting expired partial pieces failed", zap.Error(err))
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// DeleteExpired deletes the partial pieces which expired by now. Partial
+// pieces without upload are written by uploads in progress, or were left
+// behind by a crash, and expire after not being written for the expiration.
+func (partials *Partials) DeleteExpired(ctx context.Context, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pieces, err := partials.storage.PartialPieces()
+	if err != nil {
+		return err
+	}
+
+	for id, modified := range pieces {
+		upload, err := partials.db.GetPartialUpload(id)
+		if err != nil {
+			return err
+		}
+
+		expired := modified.Add(partials.expiration).Before(now)
+		if upload != nil {
+			expired = upload.Expires < now.Unix()
+		}
+		if !expired {
+			continue
+		}
+
+		if err := partials.delete(id); err != nil {
+			partials.log.Warn("deleting partial piece failed", zap.String("piece", id), zap.Error(err))
+			continue
+		}
+		mon.Counter("partial_pieces_expired").Inc(1)
+	}
+	return nil
+}
+
+// interrupted keeps the partial piece of an interrupted upload which wrote
+// written bytes, unless there's nothing to resume
+func (s *Server) interrupted(id string, file *os.File, written int64) {
+	var err error
+	if s.Partials.enabled() && written > 0 {
+		err = s.Partials.keep(id, file, written)
+	} else {
+		err = errs.Combine(file.Close(), s.storage.DeletePartial(id))
+	}
+	if err != nil {
+		s.log.Error("failed to keep partial piece of interrupted upload", zap.String("piece", id), zap.Error(err))
+	}
+}
+
+// PartialPiece returns how many bytes of an interrupted upload of the piece
+// were durably written
+func (s *Server) PartialPiece(ctx context.Context, in *pb.PieceId) (_ *pb.PartialPieceSummary, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	authorization := in.GetAuthorization()
+	if err := s.verifier(authorization); err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+
+	id, err := getNamespacedPieceID([]byte(in.GetId()), getNamespace(authorization))
+	if err != nil {
+		return nil, err
+	}
+
+	upload, err := s.Partials.Get(id, time.Now())
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+
+	summary := &pb.PartialPieceSummary{Id: in.GetId()}
+	if upload != nil {
+		summary.Written = upload.Written
+		summary.ExpirationUnixSec = upload.Expires
+	}
+	return summary, nil
+}
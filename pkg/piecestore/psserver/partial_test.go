@@ -0,0 +1,283 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gtank/cryptopasta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+)
+
+func TestResumeUpload(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	s := TS.s
+	s.Partials = NewPartials(zaptest.NewLogger(t), s.storage, s.DB, time.Hour)
+
+	id := "11111111111111111111"
+
+	{ // an interrupted upload keeps what it wrote
+		file, err := s.storage.PartialWriter(id, 0)
+		require.NoError(t, err)
+		_, err = file.Write([]byte("xyz"))
+		require.NoError(t, err)
+		s.interrupted(id, file, 3)
+
+		summary, err := TS.c.PartialPiece(ctx, &pb.PieceId{Id: id})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), summary.Written)
+	}
+
+	{ // resuming past the written bytes fails
+		stream, err := TS.c.Store(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&pb.PieceStore{PieceData: &pb.PieceStore_PieceData{Id: id, ExpirationUnixSec: 9999999999, PieceSize: 5, Offset: 4}}))
+		_, err = stream.CloseAndRecv()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no partial piece to resume at offset 4")
+	}
+
+	{ // the upload resumes at the written bytes
+		stream, err := TS.c.Store(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&pb.PieceStore{PieceData: &pb.PieceStore_PieceData{Id: id, ExpirationUnixSec: 9999999999, PieceSize: 5, Offset: 3}}))
+
+		pbaData, err := proto.Marshal(&pb.PayerBandwidthAllocation_Data{
+			SatelliteId: teststorj.NodeIDFromString("satelliteid"),
+			UplinkId:    teststorj.NodeIDFromString("uplinkid"),
+			Action:      pb.PayerBandwidthAllocation_PUT,
+		})
+		require.NoError(t, err)
+		msg := &pb.PieceStore{
+			PieceData: &pb.PieceStore_PieceData{Content: []byte("wq"), Offset: 3},
+			BandwidthAllocation: &pb.RenterBandwidthAllocation{
+				Data: serializeData(&pb.RenterBandwidthAllocation_Data{
+					PayerAllocation: &pb.PayerBandwidthAllocation{Data: pbaData},
+					Total:           5,
+				}),
+			},
+		}
+		msg.BandwidthAllocation.Signature, err = cryptopasta.Sign(msg.BandwidthAllocation.Data, TS.k.(*ecdsa.PrivateKey))
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(msg))
+
+		resp, err := stream.CloseAndRecv()
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), resp.TotalReceived)
+
+		path, err := s.storage.PiecePath(id)
+		require.NoError(t, err)
+		content, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "xyzwq", string(content))
+
+		hash, err := s.DB.GetPieceHash(id)
+		require.NoError(t, err)
+		expected := sha256.Sum256([]byte("xyzwq"))
+		assert.Equal(t, expected[:], hash.Hash)
+
+		upload, err := s.DB.GetPartialUpload(id)
+		require.NoError(t, err)
+		assert.Nil(t, upload)
+	}
+
+	{ // rejected uploads don't keep what they wrote
+		rejected := "22222222222222222222"
+		stream, err := TS.c.Store(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&pb.PieceStore{PieceData: &pb.PieceStore_PieceData{Id: rejected, ExpirationUnixSec: 9999999999, PieceSize: 5}}))
+		require.NoError(t, stream.Send(&pb.PieceStore{PieceData: &pb.PieceStore_PieceData{Content: []byte("xy")}}))
+		require.NoError(t, stream.Send(&pb.PieceStore{PieceData: &pb.PieceStore_PieceData{Content: []byte("z"), Offset: 4}}))
+		_, err = stream.CloseAndRecv()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "content at offset 4, expected 2")
+
+		upload, err := s.DB.GetPartialUpload(rejected)
+		require.NoError(t, err)
+		assert.Nil(t, upload)
+	}
+}
+
+// interruptingClient breaks the connection of the next upload after limit
+// bytes of content, broken is called to break it
+type interruptingClient struct {
+	pb.PieceStoreRoutesClient
+	limit  int64
+	broken func(sent int64, cancel func())
+}
+
+func (client *interruptingClient) Store(ctx context.Context, opts ...grpc.CallOption) (pb.PieceStoreRoutes_StoreClient, error) {
+	if client.limit < 0 {
+		return client.PieceStoreRoutesClient.Store(ctx, opts...)
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	stream, err := client.PieceStoreRoutesClient.Store(streamCtx, opts...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	interrupting := &interruptingStream{PieceStoreRoutes_StoreClient: stream, client: client, limit: client.limit, cancel: cancel}
+	client.limit = -1
+	return interrupting, nil
+}
+
+type interruptingStream struct {
+	pb.PieceStoreRoutes_StoreClient
+	client *interruptingClient
+	limit  int64
+	sent   int64
+	cancel func()
+}
+
+func (stream *interruptingStream) Send(msg *pb.PieceStore) error {
+	size := int64(len(msg.GetPieceData().GetContent()))
+	if size > 0 && stream.sent+size > stream.limit {
+		stream.client.broken(stream.sent, stream.cancel)
+		return status.Error(codes.Unavailable, "connection lost")
+	}
+	stream.sent += size
+	return stream.PieceStoreRoutes_StoreClient.Send(msg)
+}
+
+func TestResumeThroughClient(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	s := TS.s
+	s.Partials = NewPartials(zaptest.NewLogger(t), s.storage, s.DB, time.Hour)
+
+	id := "11111111111111111111"
+	data := make([]byte, 100*memory.KiB.Int())
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	waitFor := func(condition func() bool) {
+		for start := time.Now(); !condition(); time.Sleep(10 * time.Millisecond) {
+			require.True(t, time.Since(start) < 10*time.Second, "timed out")
+		}
+	}
+
+	client := &interruptingClient{PieceStoreRoutesClient: TS.c, limit: 32 * memory.KiB.Int64()}
+	client.broken = func(sent int64, cancel func()) {
+		path, err := s.storage.PartialPath(id)
+		require.NoError(t, err)
+		// partial pieces are preallocated, so their content tells what was written
+		waitFor(func() bool {
+			content, err := ioutil.ReadFile(path)
+			return err == nil && int64(len(content)) >= sent && bytes.Equal(content[:sent], data[:sent])
+		})
+		cancel()
+		// the server keeps the partial piece once it notices the broken stream
+		waitFor(func() bool {
+			upload, err := s.DB.GetPartialUpload(id)
+			require.NoError(t, err)
+			return upload != nil
+		})
+	}
+
+	node := &pb.Node{Id: teststorj.NodeIDFromString("storagenode"), Type: pb.NodeType_STORAGE}
+	ps, err := psclient.NewCustomRoute(client, node, 0, TS.k)
+	require.NoError(t, err)
+
+	pbaData, err := proto.Marshal(&pb.PayerBandwidthAllocation_Data{
+		SatelliteId: teststorj.NodeIDFromString("satelliteid"),
+		UplinkId:    teststorj.NodeIDFromString("uplinkid"),
+		Action:      pb.PayerBandwidthAllocation_PUT,
+	})
+	require.NoError(t, err)
+	pba := &pb.PayerBandwidthAllocation{Data: pbaData}
+	ttl := time.Now().Add(time.Hour)
+
+	// the data is sent in chunks, rather than at once as bytes.Reader writes it
+	err = ps.Put(ctx, psclient.PieceID(id), io.LimitReader(bytes.NewReader(data), int64(len(data))), int64(len(data)), ttl, pba, nil)
+	require.True(t, psclient.ErrInterrupted.Has(err), "%v", err)
+
+	written, err := ps.PartialPiece(ctx, psclient.PieceID(id), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 32*memory.KiB.Int64(), written)
+
+	require.NoError(t, ps.Resume(ctx, psclient.PieceID(id), bytes.NewReader(data), int64(len(data)), ttl, pba, nil))
+
+	path, err := s.storage.PiecePath(id)
+	require.NoError(t, err)
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, data, content)
+
+	hash, err := s.DB.GetPieceHash(id)
+	require.NoError(t, err)
+	expected := sha256.Sum256(data)
+	assert.Equal(t, expected[:], hash.Hash)
+}
+
+func TestDeleteExpiredPartials(t *testing.T) {
+	ctx := context.Background()
+	s, cleanup := newTestServerStruct(t)
+	defer cleanup()
+
+	partials := NewPartials(zaptest.NewLogger(t), s.storage, s.DB, time.Hour)
+	s.Partials = partials
+
+	exists := func(id string) bool {
+		path, err := s.storage.PartialPath(id)
+		require.NoError(t, err)
+		_, err = os.Stat(path)
+		return err == nil
+	}
+
+	// an interrupted upload and one left behind by a crash
+	for _, id := range []string{"11111111111111111111", "22222222222222222222"} {
+		file, err := s.storage.PartialWriter(id, 0)
+		require.NoError(t, err)
+		_, err = file.Write([]byte("xyz"))
+		require.NoError(t, err)
+		if id == "11111111111111111111" {
+			s.interrupted(id, file, 3)
+		} else {
+			require.NoError(t, file.Close())
+		}
+	}
+
+	require.NoError(t, partials.DeleteExpired(ctx, time.Now()))
+	assert.True(t, exists("11111111111111111111"))
+	assert.True(t, exists("22222222222222222222"))
+
+	require.NoError(t, partials.DeleteExpired(ctx, time.Now().Add(2*time.Hour)))
+	assert.False(t, exists("11111111111111111111"))
+	assert.False(t, exists("22222222222222222222"))
+
+	upload, err := s.DB.GetPartialUpload("11111111111111111111")
+	require.NoError(t, err)
+	assert.Nil(t, upload)
+}
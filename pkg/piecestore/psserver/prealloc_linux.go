@@ -0,0 +1,22 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// +build linux
+
+package psserver
+
+import (
+	"os"
+	"syscall"
+)
+
+// preallocate reserves size bytes on disk for the piece file, so an upload
+// to a full disk fails before any data is received
+func preallocate(file *os.File, size int64) error {
+	err := syscall.Fallocate(int(file.Fd()), 0, 0, size)
+	if err == syscall.ENOSPC {
+		return err
+	}
+	// file systems without fallocate support are written to as before
+	return nil
+}
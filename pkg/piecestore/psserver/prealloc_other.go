@@ -0,0 +1,13 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// +build !linux
+
+package psserver
+
+import "os"
+
+// preallocate is a no-op where files can't be preallocated
+func preallocate(file *os.File, size int64) error {
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psdb
+
+import (
+	"github.com/gogo/protobuf/proto"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+// AddAuditReceipt records the receipt of an audit signed by a satellite
+func (db *DB) AddAuditReceipt(receipt *pb.AuditReceipt) error {
+	data, err := proto.Marshal(receipt)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	defer db.locked()()
+
+	_, err = db.DB.Exec(`INSERT INTO audit_receipts (satellite, audited, receipt) VALUES (?, ?, ?)`,
+		receipt.SatelliteId.Bytes(), receipt.AuditedUnixSec, data)
+	return err
+}
+
+// GetAuditReceipts returns up to limit audit receipts of the satellite, all
+// satellites when satelliteID is zero. The newest receipts are returned
+// first, limit 0 returns all of them.
+func (db *DB) GetAuditReceipts(satelliteID storj.NodeID, limit int) ([]*pb.AuditReceipt, error) {
+	defer db.locked()()
+
+	if limit <= 0 {
+		limit = -1
+	}
+	query := `SELECT receipt FROM audit_receipts WHERE ? OR satellite = ? ORDER BY audited DESC, rowid DESC LIMIT ?`
+	rows, err := db.DB.Query(query, satelliteID.IsZero(), satelliteID.Bytes(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			zap.S().Errorf("failed to close rows when selecting from audit_receipts: %+v", closeErr)
+		}
+	}()
+
+	receipts := []*pb.AuditReceipt{}
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return receipts, err
+		}
+		receipt := &pb.AuditReceipt{}
+		if err := proto.Unmarshal(data, receipt); err != nil {
+			return receipts, Error.Wrap(err)
+		}
+		receipts = append(receipts, receipt)
+	}
+	return receipts, rows.Err()
+}
@@ -0,0 +1,47 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psdb
+
+import (
+	"database/sql"
+)
+
+// PartialUpload is the partial piece of an interrupted upload, kept until it
+// expires so that the upload can be resumed
+type PartialUpload struct {
+	ID      string
+	Written int64 // bytes durably written
+	Expires int64 // unix time
+}
+
+// SetPartialUpload records the bytes durably written by an interrupted upload
+func (db *DB) SetPartialUpload(upload *PartialUpload) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec(`INSERT OR REPLACE INTO partial_uploads (id, written, expires) VALUES (?, ?, ?)`,
+		upload.ID, upload.Written, upload.Expires)
+	return err
+}
+
+// GetPartialUpload returns the partial upload of a piece, or nil when there
+// is none
+func (db *DB) GetPartialUpload(id string) (*PartialUpload, error) {
+	defer db.locked()()
+
+	upload := &PartialUpload{}
+	err := db.DB.QueryRow(`SELECT id, written, expires FROM partial_uploads WHERE id = ?`, id).
+		Scan(&upload.ID, &upload.Written, &upload.Expires)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return upload, err
+}
+
+// DeletePartialUpload forgets about the partial upload of a piece
+func (db *DB) DeletePartialUpload(id string) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec(`DELETE FROM partial_uploads WHERE id=?`, id)
+	return err
+}
@@ -48,6 +48,31 @@ type Agreement struct {
 	Signature []byte
 }
 
+// PieceHash is the content hash recorded for a stored piece
+type PieceHash struct {
+	ID          string       // namespaced id used for storage
+	PieceID     string       // id as known by the uplink and satellite
+	SatelliteID storj.NodeID // satellite that paid for the upload
+	Hash        []byte
+	Verified    int64 // unix time of the last successful verification
+}
+
+// TrashedPiece is a garbage collected piece kept in the trash until its
+// grace period ends
+type TrashedPiece struct {
+	PieceHash
+	Expires int64 // expiration of the piece before it was trashed
+	Size    int64
+	Trashed int64 // unix time the piece was trashed
+}
+
+// QuarantinedPiece is a piece which failed verification
+type QuarantinedPiece struct {
+	ID       string
+	PieceID  string
+	Detected int64
+}
+
 // Open opens DB at DBPath
 func Open(ctx context.Context, storage *pstore.Storage, DBPath string) (db *DB, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -126,6 +151,46 @@ func (db *DB) init() (err error) {
 		return err
 	}
 
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `piece_hashes` (`id` TEXT UNIQUE, `piece` TEXT, `satellite` BLOB, `hash` BLOB, `verified` INT(10));")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `quarantine` (`id` TEXT UNIQUE, `piece` TEXT, `satellite` BLOB, `detected` INT(10), `reported` INT(1));")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `bandwidth_usage_hourly` (`satellite` BLOB, `action` INT(1), `hour` INT(10), `size` INT(10), UNIQUE (`satellite`, `action`, `hour`));")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `disk_usage_hourly` (`satellite` BLOB, `hour` INT(10), `used` INT(10), UNIQUE (`satellite`, `hour`));")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `retain_log` (`satellite` BLOB, `processed` INT(10), `summary` BLOB);")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `audit_receipts` (`satellite` BLOB, `audited` INT(10), `receipt` BLOB);")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `partial_uploads` (`id` TEXT UNIQUE, `written` INT(10), `expires` INT(10));")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("CREATE TABLE IF NOT EXISTS `trash` (`id` TEXT UNIQUE, `piece` TEXT, `satellite` BLOB, `hash` BLOB, `verified` INT(10), `expires` INT(10), `size` INT(10), `trashed` INT(10));")
+	if err != nil {
+		return err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return err
@@ -184,6 +249,12 @@ func (db *DB) DeleteExpired(ctx context.Context) (err error) {
 			return err
 		}
 
+		for _, id := range expired {
+			if _, err := tx.Exec(`DELETE FROM piece_hashes WHERE id=?`, id); err != nil {
+				return err
+			}
+		}
+
 		return tx.Commit()
 	}()
 
@@ -201,13 +272,16 @@ func (db *DB) DeleteExpired(ctx context.Context) (err error) {
 	return nil
 }
 
-// garbageCollect will periodically run DeleteExpired
+// garbageCollect will periodically run DeleteExpired and record the disk usage
 func (db *DB) garbageCollect(ctx context.Context) {
 	for range db.check.C {
 		err := db.DeleteExpired(ctx)
 		if err != nil {
 			zap.S().Errorf("failed checking entries: %+v", err)
 		}
+		if err := db.RecordDiskUsage(time.Now()); err != nil {
+			zap.S().Errorf("failed recording disk usage: %+v", err)
+		}
 	}
 }
 
@@ -413,3 +487,201 @@ func (db *DB) GetTotalBandwidthBetween(startdate time.Time, enddate time.Time) (
 	err = db.DB.QueryRow(`SELECT SUM(size) FROM bwusagetbl WHERE daystartdate BETWEEN ? AND ?`, startTimeUnix, endTimeUnix).Scan(&totalbwusage)
 	return totalbwusage, err
 }
+
+// AddPieceHash records the content hash of a stored piece
+func (db *DB) AddPieceHash(hash *PieceHash) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec(`INSERT OR REPLACE INTO piece_hashes (id, piece, satellite, hash, verified) VALUES (?, ?, ?, ?, ?)`,
+		hash.ID, hash.PieceID, hash.SatelliteID.Bytes(), hash.Hash, hash.Verified)
+	return err
+}
+
+// GetPieceHashes returns up to limit piece hashes ordered by id, starting after the given id
+func (db *DB) GetPieceHashes(after string, limit int) ([]*PieceHash, error) {
+	defer db.locked()()
+
+	rows, err := db.DB.Query(`SELECT id, piece, satellite, hash, verified FROM piece_hashes WHERE id > ? ORDER BY id LIMIT ?`, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			zap.S().Errorf("failed to close rows when selecting from piece_hashes: %+v", closeErr)
+		}
+	}()
+
+	hashes := []*PieceHash{}
+	for rows.Next() {
+		hash := &PieceHash{}
+		var satellite []byte
+		if err := rows.Scan(&hash.ID, &hash.PieceID, &satellite, &hash.Hash, &hash.Verified); err != nil {
+			return hashes, err
+		}
+		hash.SatelliteID, err = storj.NodeIDFromBytes(satellite)
+		if err != nil {
+			return hashes, err
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, rows.Err()
+}
+
+// GetPieceHash returns the recorded hash of a piece, or nil when no hash
+// is recorded
+func (db *DB) GetPieceHash(id string) (*PieceHash, error) {
+	defer db.locked()()
+
+	hash := &PieceHash{}
+	var satellite []byte
+	err := db.DB.QueryRow(`SELECT id, piece, satellite, hash, verified FROM piece_hashes WHERE id = ?`, id).
+		Scan(&hash.ID, &hash.PieceID, &satellite, &hash.Hash, &hash.Verified)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	hash.SatelliteID, err = storj.NodeIDFromBytes(satellite)
+	return hash, err
+}
+
+// CountPieceHashes returns the number of pieces with a recorded hash
+func (db *DB) CountPieceHashes() (count int64, err error) {
+	defer db.locked()()
+
+	err = db.DB.QueryRow(`SELECT COUNT(*) FROM piece_hashes`).Scan(&count)
+	return count, err
+}
+
+// SetPieceVerified updates the last verification time of a piece
+func (db *DB) SetPieceVerified(id string, verified int64) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec(`UPDATE piece_hashes SET verified = ? WHERE id = ?`, verified, id)
+	return err
+}
+
+// DeletePieceHash deletes the recorded hash of a piece
+func (db *DB) DeletePieceHash(id string) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec(`DELETE FROM piece_hashes WHERE id=?`, id)
+	if err == sql.ErrNoRows {
+		err = nil
+	}
+	return err
+}
+
+// QuarantinePiece marks a piece as corrupted, removing it from the stored pieces
+func (db *DB) QuarantinePiece(ctx context.Context, hash *PieceHash, detected int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.locked()()
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.Exec(`INSERT OR REPLACE INTO quarantine (id, piece, satellite, detected, reported) VALUES (?, ?, ?, ?, 0)`,
+		hash.ID, hash.PieceID, hash.SatelliteID.Bytes(), detected)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(`DELETE FROM piece_hashes WHERE id=?`, hash.ID); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(`DELETE FROM ttl WHERE id=?`, hash.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetUnreportedQuarantine returns quarantined pieces which haven't been reported yet, grouped by satellite
+func (db *DB) GetUnreportedQuarantine() (map[storj.NodeID][]*QuarantinedPiece, error) {
+	defer db.locked()()
+
+	rows, err := db.DB.Query(`SELECT id, piece, satellite, detected FROM quarantine WHERE reported = 0 ORDER BY satellite`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			zap.S().Errorf("failed to close rows when selecting from quarantine: %+v", closeErr)
+		}
+	}()
+
+	pieces := make(map[storj.NodeID][]*QuarantinedPiece)
+	for rows.Next() {
+		piece := &QuarantinedPiece{}
+		var satellite []byte
+		if err := rows.Scan(&piece.ID, &piece.PieceID, &satellite, &piece.Detected); err != nil {
+			return pieces, err
+		}
+		satelliteID, err := storj.NodeIDFromBytes(satellite)
+		if err != nil {
+			return nil, err
+		}
+		pieces[satelliteID] = append(pieces[satelliteID], piece)
+	}
+	return pieces, rows.Err()
+}
+
+// CountQuarantine returns the number of quarantined pieces and how many of
+// them weren't reported yet
+func (db *DB) CountQuarantine() (total, unreported int64, err error) {
+	defer db.locked()()
+
+	err = db.DB.QueryRow(`SELECT COUNT(*), COALESCE(SUM(reported = 0), 0) FROM quarantine`).Scan(&total, &unreported)
+	return total, unreported, err
+}
+
+// GetReportedQuarantine returns the quarantined pieces which were reported
+// to their satellite and detected before the given unix time
+func (db *DB) GetReportedQuarantine(before int64) ([]*QuarantinedPiece, error) {
+	defer db.locked()()
+
+	rows, err := db.DB.Query(`SELECT id, piece, detected FROM quarantine WHERE reported = 1 AND detected < ? ORDER BY id`, before)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			zap.S().Errorf("failed to close rows when selecting from quarantine: %+v", closeErr)
+		}
+	}()
+
+	pieces := []*QuarantinedPiece{}
+	for rows.Next() {
+		piece := &QuarantinedPiece{}
+		if err := rows.Scan(&piece.ID, &piece.PieceID, &piece.Detected); err != nil {
+			return pieces, err
+		}
+		pieces = append(pieces, piece)
+	}
+	return pieces, rows.Err()
+}
+
+// DeleteQuarantine forgets about a quarantined piece
+func (db *DB) DeleteQuarantine(id string) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec(`DELETE FROM quarantine WHERE id=?`, id)
+	return err
+}
+
+// MarkQuarantineReported marks quarantined pieces as reported to their satellite
+func (db *DB) MarkQuarantineReported(ids []string) error {
+	defer db.locked()()
+
+	for _, id := range ids {
+		if _, err := db.DB.Exec(`UPDATE quarantine SET reported = 1 WHERE id = ?`, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psdb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+// AddRetainSummary records the signed summary of a processed retain request
+func (db *DB) AddRetainSummary(summary *pb.RetainSummary) error {
+	data, err := proto.Marshal(summary)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	defer db.locked()()
+
+	_, err = db.DB.Exec(`INSERT INTO retain_log (satellite, processed, summary) VALUES (?, ?, ?)`,
+		summary.SatelliteId.Bytes(), summary.ProcessedUnixSec, data)
+	return err
+}
+
+// GetRetainSummaries returns up to limit summaries of processed retain
+// requests of the satellite, all satellites when satelliteID is zero. The
+// newest summaries are returned first, limit 0 returns all of them.
+func (db *DB) GetRetainSummaries(satelliteID storj.NodeID, limit int) ([]*pb.RetainSummary, error) {
+	defer db.locked()()
+
+	if limit <= 0 {
+		limit = -1
+	}
+	query := `SELECT summary FROM retain_log WHERE ? OR satellite = ? ORDER BY processed DESC, rowid DESC LIMIT ?`
+	rows, err := db.DB.Query(query, satelliteID.IsZero(), satelliteID.Bytes(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			zap.S().Errorf("failed to close rows when selecting from retain_log: %+v", closeErr)
+		}
+	}()
+
+	summaries := []*pb.RetainSummary{}
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return summaries, err
+		}
+		summary := &pb.RetainSummary{}
+		if err := proto.Unmarshal(data, summary); err != nil {
+			return summaries, Error.Wrap(err)
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, rows.Err()
+}
+
+// StoredPiece is a piece in the ttl table with its recorded hash, which is
+// nil for the pieces stored before hashes were recorded
+type StoredPiece struct {
+	ID   string
+	Size int64
+	Hash *PieceHash
+}
+
+// GetStoredPieces returns up to limit stored pieces ordered by id, starting
+// after the given id
+func (db *DB) GetStoredPieces(after string, limit int) ([]*StoredPiece, error) {
+	defer db.locked()()
+
+	rows, err := db.DB.Query(`SELECT ttl.id, ttl.size, piece_hashes.piece, piece_hashes.satellite, piece_hashes.hash, piece_hashes.verified
+		FROM ttl LEFT JOIN piece_hashes ON piece_hashes.id = ttl.id WHERE ttl.id > ? ORDER BY ttl.id LIMIT ?`, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			zap.S().Errorf("failed to close rows when selecting from ttl: %+v", closeErr)
+		}
+	}()
+
+	pieces := []*StoredPiece{}
+	for rows.Next() {
+		piece := &StoredPiece{}
+		var pieceID sql.NullString
+		var satellite, hash []byte
+		var verified sql.NullInt64
+		if err := rows.Scan(&piece.ID, &piece.Size, &pieceID, &satellite, &hash, &verified); err != nil {
+			return pieces, err
+		}
+		if pieceID.Valid {
+			piece.Hash = &PieceHash{ID: piece.ID, PieceID: pieceID.String, Hash: hash, Verified: verified.Int64}
+			piece.Hash.SatelliteID, err = storj.NodeIDFromBytes(satellite)
+			if err != nil {
+				return pieces, err
+			}
+		}
+		pieces = append(pieces, piece)
+	}
+	return pieces, rows.Err()
+}
+
+// TrashPiece moves the records of a piece to the trash, so that it can be
+// restored until it's emptied. Pieces stored before hashes were recorded are
+// trashed for satelliteID.
+func (db *DB) TrashPiece(ctx context.Context, id string, satelliteID storj.NodeID, trashed int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.locked()()
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.Exec(`INSERT OR REPLACE INTO trash (id, piece, satellite, hash, verified, expires, size, trashed)
+		SELECT piece_hashes.id, piece, satellite, hash, verified, COALESCE(expires, 0), COALESCE(size, 0), ?
+		FROM piece_hashes LEFT JOIN ttl ON ttl.id = piece_hashes.id WHERE piece_hashes.id = ?`, trashed, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`INSERT OR IGNORE INTO trash (id, piece, satellite, hash, verified, expires, size, trashed)
+		SELECT id, '', ?, NULL, 0, expires, size, ? FROM ttl WHERE id = ?`, satelliteID.Bytes(), trashed, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(`DELETE FROM piece_hashes WHERE id=?`, id); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(`DELETE FROM ttl WHERE id=?`, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetTrash returns the trashed pieces of the satellite, all satellites when
+// satelliteID is zero, which were trashed before the given unix time
+func (db *DB) GetTrash(satelliteID storj.NodeID, before int64) ([]*TrashedPiece, error) {
+	defer db.locked()()
+
+	query := `SELECT id, piece, satellite, hash, verified, expires, size, trashed FROM trash WHERE (? OR satellite = ?) AND trashed < ? ORDER BY id`
+	rows, err := db.DB.Query(query, satelliteID.IsZero(), satelliteID.Bytes(), before)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			zap.S().Errorf("failed to close rows when selecting from trash: %+v", closeErr)
+		}
+	}()
+
+	pieces := []*TrashedPiece{}
+	for rows.Next() {
+		piece := &TrashedPiece{}
+		var satellite []byte
+		if err := rows.Scan(&piece.ID, &piece.PieceID, &satellite, &piece.Hash, &piece.Verified, &piece.Expires, &piece.Size, &piece.Trashed); err != nil {
+			return pieces, err
+		}
+		piece.SatelliteID, err = storj.NodeIDFromBytes(satellite)
+		if err != nil {
+			return pieces, err
+		}
+		pieces = append(pieces, piece)
+	}
+	return pieces, rows.Err()
+}
+
+// GetTrashedPiece returns the trashed piece with the id, nil when it isn't
+// in the trash
+func (db *DB) GetTrashedPiece(id string) (*TrashedPiece, error) {
+	defer db.locked()()
+
+	piece := &TrashedPiece{}
+	var satellite []byte
+	err := db.DB.QueryRow(`SELECT id, piece, satellite, hash, verified, expires, size, trashed FROM trash WHERE id = ?`, id).
+		Scan(&piece.ID, &piece.PieceID, &satellite, &piece.Hash, &piece.Verified, &piece.Expires, &piece.Size, &piece.Trashed)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	piece.SatelliteID, err = storj.NodeIDFromBytes(satellite)
+	return piece, err
+}
+
+// RestorePiece moves the records of a trashed piece back to the stored
+// pieces
+func (db *DB) RestorePiece(ctx context.Context, piece *TrashedPiece) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer db.locked()()
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// pieces stored before hashes were recorded are restored without one
+	if piece.PieceID != "" {
+		_, err = tx.Exec(`INSERT OR REPLACE INTO piece_hashes (id, piece, satellite, hash, verified) VALUES (?, ?, ?, ?, ?)`,
+			piece.ID, piece.PieceID, piece.SatelliteID.Bytes(), piece.Hash, piece.Verified)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`INSERT OR REPLACE INTO ttl (id, created, expires, size) VALUES (?, ?, ?, ?)`,
+		piece.ID, time.Now().Unix(), piece.Expires, piece.Size)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(`DELETE FROM trash WHERE id=?`, piece.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteTrash forgets about a trashed piece
+func (db *DB) DeleteTrash(id string) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec(`DELETE FROM trash WHERE id=?`, id)
+	return err
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psdb
+
+import (
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+// UsagePoint is the usage of a satellite during an hour
+type UsagePoint struct {
+	Hour      time.Time                                    // start of the hour
+	Bandwidth map[pb.PayerBandwidthAllocation_Action]int64 // bytes transferred by action
+	DiskUsed  int64                                        // bytes stored when last recorded during the hour
+}
+
+// AddUsage adds size bytes transferred for satellite with action to the hour of now
+func (db *DB) AddUsage(satelliteID storj.NodeID, action pb.PayerBandwidthAllocation_Action, size int64, now time.Time) error {
+	defer db.locked()()
+
+	hour := now.Truncate(time.Hour).Unix()
+	_, err := db.DB.Exec(`INSERT OR IGNORE INTO bandwidth_usage_hourly (satellite, action, hour, size) VALUES (?, ?, ?, 0)`,
+		satelliteID.Bytes(), int32(action), hour)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.DB.Exec(`UPDATE bandwidth_usage_hourly SET size = size + ? WHERE satellite = ? AND action = ? AND hour = ?`,
+		size, satelliteID.Bytes(), int32(action), hour)
+	return err
+}
+
+// RecordDiskUsage records the bytes stored for each satellite as the disk usage of the hour of now
+func (db *DB) RecordDiskUsage(now time.Time) error {
+	defer db.locked()()
+
+	_, err := db.DB.Exec(`INSERT OR REPLACE INTO disk_usage_hourly (satellite, hour, used)
+		SELECT piece_hashes.satellite, ?, SUM(ttl.size) FROM ttl JOIN piece_hashes ON ttl.id = piece_hashes.id
+		GROUP BY piece_hashes.satellite`, now.Truncate(time.Hour).Unix())
+	return err
+}
+
+// GetUsage returns the hourly usage of satellite, or of all satellites when
+// satellite is zero, for the hours starting in [from, to). The points of each
+// satellite are ordered by hour, hours without usage are left out.
+func (db *DB) GetUsage(satelliteID storj.NodeID, from, to time.Time) (map[storj.NodeID][]*UsagePoint, error) {
+	defer db.locked()()
+
+	type key struct {
+		satellite storj.NodeID
+		hour      int64
+	}
+	points := make(map[key]*UsagePoint)
+	point := func(satellite []byte, hour int64) (*UsagePoint, error) {
+		satelliteID, err := storj.NodeIDFromBytes(satellite)
+		if err != nil {
+			return nil, err
+		}
+		k := key{satelliteID, hour}
+		if points[k] == nil {
+			points[k] = &UsagePoint{
+				Hour:      time.Unix(hour, 0),
+				Bandwidth: make(map[pb.PayerBandwidthAllocation_Action]int64),
+			}
+		}
+		return points[k], nil
+	}
+
+	start, end := from.Truncate(time.Hour).Unix(), to.Unix()
+	filter := ` WHERE ? <= hour AND hour < ?`
+	args := []interface{}{start, end}
+	if !satelliteID.IsZero() {
+		filter += ` AND satellite = ?`
+		args = append(args, satelliteID.Bytes())
+	}
+
+	err := func() error {
+		rows, err := db.DB.Query(`SELECT satellite, action, hour, size FROM bandwidth_usage_hourly`+filter, args...)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := rows.Close(); closeErr != nil {
+				zap.S().Errorf("failed to close rows when selecting from bandwidth_usage_hourly: %+v", closeErr)
+			}
+		}()
+
+		for rows.Next() {
+			var satellite []byte
+			var action int32
+			var hour, size int64
+			if err := rows.Scan(&satellite, &action, &hour, &size); err != nil {
+				return err
+			}
+			p, err := point(satellite, hour)
+			if err != nil {
+				return err
+			}
+			p.Bandwidth[pb.PayerBandwidthAllocation_Action(action)] += size
+		}
+		return rows.Err()
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	err = func() error {
+		rows, err := db.DB.Query(`SELECT satellite, hour, used FROM disk_usage_hourly`+filter, args...)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := rows.Close(); closeErr != nil {
+				zap.S().Errorf("failed to close rows when selecting from disk_usage_hourly: %+v", closeErr)
+			}
+		}()
+
+		for rows.Next() {
+			var satellite []byte
+			var hour, used int64
+			if err := rows.Scan(&satellite, &hour, &used); err != nil {
+				return err
+			}
+			p, err := point(satellite, hour)
+			if err != nil {
+				return err
+			}
+			p.DiskUsed = used
+		}
+		return rows.Err()
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make(map[storj.NodeID][]*UsagePoint)
+	for k, p := range points {
+		usage[k.satellite] = append(usage[k.satellite], p)
+	}
+	for _, satellitePoints := range usage {
+		sort.Slice(satellitePoints, func(i, k int) bool {
+			return satellitePoints[i].Hour.Before(satellitePoints[k].Hour)
+		})
+	}
+	return usage, nil
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestUsage(t *testing.T) {
+	db, cleanup := newDB(t)
+	defer cleanup()
+
+	satellite1 := teststorj.NodeIDFromString("satellite1")
+	satellite2 := teststorj.NodeIDFromString("satellite2")
+
+	hour := time.Now().Truncate(time.Hour).Add(-2 * time.Hour)
+	next := hour.Add(time.Hour)
+
+	require.NoError(t, db.AddUsage(satellite1, pb.PayerBandwidthAllocation_PUT, 100, hour))
+	require.NoError(t, db.AddUsage(satellite1, pb.PayerBandwidthAllocation_PUT, 50, hour.Add(30*time.Minute)))
+	require.NoError(t, db.AddUsage(satellite1, pb.PayerBandwidthAllocation_GET_AUDIT, 10, hour))
+	require.NoError(t, db.AddUsage(satellite1, pb.PayerBandwidthAllocation_GET, 20, next))
+	require.NoError(t, db.AddUsage(satellite2, pb.PayerBandwidthAllocation_GET_REPAIR, 30, next))
+
+	// only pieces with a known satellite count towards the disk usage
+	require.NoError(t, db.AddTTL("piece1", 0, 1000))
+	require.NoError(t, db.AddPieceHash(&PieceHash{ID: "piece1", SatelliteID: satellite1}))
+	require.NoError(t, db.AddTTL("piece2", 0, 500))
+	require.NoError(t, db.AddPieceHash(&PieceHash{ID: "piece2", SatelliteID: satellite1}))
+	require.NoError(t, db.AddTTL("unknown", 0, 2000))
+	require.NoError(t, db.RecordDiskUsage(next))
+
+	usage, err := db.GetUsage(storj.NodeID{}, hour, time.Now())
+	require.NoError(t, err)
+	require.Len(t, usage, 2)
+
+	points := usage[satellite1]
+	require.Len(t, points, 2)
+	assert.Equal(t, hour.Unix(), points[0].Hour.Unix())
+	assert.Equal(t, int64(150), points[0].Bandwidth[pb.PayerBandwidthAllocation_PUT])
+	assert.Equal(t, int64(10), points[0].Bandwidth[pb.PayerBandwidthAllocation_GET_AUDIT])
+	assert.Equal(t, int64(0), points[0].DiskUsed)
+	assert.Equal(t, next.Unix(), points[1].Hour.Unix())
+	assert.Equal(t, int64(20), points[1].Bandwidth[pb.PayerBandwidthAllocation_GET])
+	assert.Equal(t, int64(1500), points[1].DiskUsed)
+
+	require.Len(t, usage[satellite2], 1)
+	assert.Equal(t, int64(30), usage[satellite2][0].Bandwidth[pb.PayerBandwidthAllocation_GET_REPAIR])
+
+	{ // filter by satellite
+		usage, err := db.GetUsage(satellite2, hour, time.Now())
+		require.NoError(t, err)
+		require.Len(t, usage, 1)
+		assert.Len(t, usage[satellite2], 1)
+	}
+
+	{ // filter by time
+		usage, err := db.GetUsage(storj.NodeID{}, hour, next)
+		require.NoError(t, err)
+		require.Len(t, usage, 1)
+		assert.Len(t, usage[satellite1], 1)
+	}
+}
@@ -4,16 +4,23 @@
 package psserver
 
 import (
+	"io"
+
 	"github.com/gogo/protobuf/proto"
 	"github.com/zeebo/errs"
 
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/utils"
 )
 
 // StreamWriterError is a type of error for failures in StreamWriter
 var StreamWriterError = errs.Class("stream writer error")
 
+// errInterrupted wraps the errors of upload streams which broke, the partial
+// pieces of which are kept to be resumed
+var errInterrupted = errs.Class("upload interrupted")
+
 // StreamWriter -- Struct for writing piece to server upload stream
 type StreamWriter struct {
 	server *Server
@@ -39,10 +46,15 @@ func (s *StreamWriter) Write(b []byte) (int, error) {
 type StreamReader struct {
 	src                 *utils.ReaderSource
 	bandwidthAllocation *pb.RenterBandwidthAllocation
+	satelliteID         storj.NodeID
+	action              pb.PayerBandwidthAllocation_Action
 	currentTotal        int64
 	bandwidthRemaining  int64
 	spaceRemaining      int64
+	declaredSize        int64
 	sofar               int64
+	// offset is where the next content starts in the piece
+	offset int64
 }
 
 // NewStreamReader returns a new StreamReader for Server.Store
@@ -54,13 +66,21 @@ func NewStreamReader(s *Server, stream pb.PieceStoreRoutes_StoreServer, bandwidt
 	sr.src = utils.NewReaderSource(func() ([]byte, error) {
 
 		recv, err := stream.Recv()
-		if err != nil {
+		if err == io.EOF {
 			return nil, err
 		}
+		if err != nil {
+			return nil, errInterrupted.Wrap(err)
+		}
 
 		pd := recv.GetPieceData()
 		ba := recv.GetBandwidthAllocation()
 
+		if pd.GetOffset() != 0 && pd.GetOffset() != sr.offset {
+			return nil, StreamWriterError.New("content at offset %d, expected %d", pd.GetOffset(), sr.offset)
+		}
+		sr.offset += int64(len(pd.GetContent()))
+
 		if ba != nil {
 			if err = s.verifySignature(stream.Context(), ba); err != nil {
 				return nil, err
@@ -84,6 +104,8 @@ func NewStreamReader(s *Server, stream pb.PieceStoreRoutes_StoreServer, bandwidt
 			// Update bandwidthallocation to be stored
 			if deserializedData.GetTotal() > sr.currentTotal {
 				sr.bandwidthAllocation = ba
+				sr.satelliteID = pbaData.SatelliteId
+				sr.action = pbaData.Action
 				sr.currentTotal = deserializedData.GetTotal()
 			}
 		}
@@ -111,6 +133,9 @@ func (s *StreamReader) Read(b []byte) (int, error) {
 	if s.sofar >= s.spaceRemaining {
 		return n, StreamWriterError.New("out of space")
 	}
+	if s.declaredSize > 0 && s.sofar > s.declaredSize {
+		return n, StreamWriterError.New("piece exceeds declared size of %d bytes", s.declaredSize)
+	}
 
 	return n, nil
 }
@@ -0,0 +1,431 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"context"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/internal/bloomfilter"
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/pb"
+	pstore "storj.io/storj/pkg/piecestore"
+	"storj.io/storj/pkg/piecestore/psserver/psdb"
+	"storj.io/storj/pkg/server"
+	"storj.io/storj/pkg/storj"
+)
+
+// RetainError is a type of error for failures in Retainer
+var RetainError = errs.Class("retain error")
+
+const retainBatchSize = 1000
+
+// RetainConfig contains configuration for garbage collecting pieces
+type RetainConfig struct {
+	DryRun          bool          `help:"only report the pieces garbage collection would trash, without trashing them" default:"false"`
+	TrashGrace      time.Duration `help:"how long trashed pieces are kept so that they can be restored" default:"168h0m0s"`
+	EmptyInterval   time.Duration `help:"how frequently trashed pieces past their grace period are deleted" default:"1h0m0s"`
+	LegacySatellite string        `help:"the ID of the satellite the pieces stored before their satellite was recorded belong to, its retain requests garbage collect them, they're retained when empty" default:""`
+}
+
+// RetainFilter tells which pieces a satellite still stores data in, e.g. a
+// bloom filter of the piece ids in its pointers
+type RetainFilter interface {
+	Contains(pieceID string) bool
+}
+
+// Retainer garbage collects the pieces of a satellite which aren't retained
+// by its filter. Collected pieces are kept in the trash for a grace period,
+// in which they can be restored. Every processed filter is recorded as a
+// summary signed by the node, so that operators can review what was
+// collected.
+type Retainer struct {
+	log      *zap.Logger
+	storage  *pstore.Storage
+	db       *psdb.DB
+	identity *identity.FullIdentity
+	config   RetainConfig
+	legacy   storj.NodeID
+
+	mu     sync.Mutex
+	queued map[storj.NodeID]*retainRequest
+	wake   chan struct{}
+}
+
+// retainRequest is a retain request queued to be processed in the background
+type retainRequest struct {
+	filter    RetainFilter
+	createdAt time.Time
+}
+
+// NewRetainer creates a new piece garbage collector
+func NewRetainer(log *zap.Logger, storage *pstore.Storage, db *psdb.DB, identity *identity.FullIdentity, config RetainConfig) (*Retainer, error) {
+	retainer := &Retainer{
+		log:      log,
+		storage:  storage,
+		db:       db,
+		identity: identity,
+		config:   config,
+		queued:   make(map[storj.NodeID]*retainRequest),
+		wake:     make(chan struct{}, 1),
+	}
+	if config.LegacySatellite != "" {
+		var err error
+		retainer.legacy, err = storj.NodeIDFromString(config.LegacySatellite)
+		if err != nil {
+			return nil, RetainError.New("invalid legacy satellite: %v", err)
+		}
+	}
+	return retainer, nil
+}
+
+// Queue queues the retain request of the satellite to be processed in the
+// background, replacing the one of the satellite which is still queued
+func (retainer *Retainer) Queue(satelliteID storj.NodeID, filter RetainFilter, createdAt time.Time) {
+	retainer.mu.Lock()
+	retainer.queued[satelliteID] = &retainRequest{filter: filter, createdAt: createdAt}
+	retainer.mu.Unlock()
+
+	select {
+	case retainer.wake <- struct{}{}:
+	default:
+	}
+}
+
+// processQueue processes the queued retain requests, their summaries are
+// recorded in the retain log
+func (retainer *Retainer) processQueue(ctx context.Context) {
+	for {
+		retainer.mu.Lock()
+		var satelliteID storj.NodeID
+		var request *retainRequest
+		for satelliteID, request = range retainer.queued {
+			delete(retainer.queued, satelliteID)
+			break
+		}
+		retainer.mu.Unlock()
+
+		if request == nil || ctx.Err() != nil {
+			return
+		}
+		if _, err := retainer.Retain(ctx, satelliteID, request.filter, request.createdAt); err != nil {
+			retainer.log.Error("processing retain request failed", zap.String("satellite", satelliteID.String()), zap.Error(err))
+		}
+	}
+}
+
+// Retain trashes the pieces of the satellite which aren't in filter. Pieces
+// stored after the filter was created are retained, as the filter can't
+// know about them. In dry run mode the pieces are only counted.
+//
+// Every stored piece is examined: the ones with a recorded hash are looked up
+// in the filter by the piece ID the satellite knows them by, the ones stored
+// before hashes were recorded belong to the legacy satellite, which adds the
+// IDs they're stored with to its filters.
+func (retainer *Retainer) Retain(ctx context.Context, satelliteID storj.NodeID, filter RetainFilter, createdAt time.Time) (summary *pb.RetainSummary, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	summary = &pb.RetainSummary{
+		SatelliteId:          satelliteID,
+		FilterCreatedUnixSec: createdAt.Unix(),
+		DryRun:               retainer.config.DryRun,
+	}
+
+	var after string
+	for {
+		pieces, err := retainer.db.GetStoredPieces(after, retainBatchSize)
+		if err != nil {
+			return nil, RetainError.Wrap(err)
+		}
+		if len(pieces) == 0 {
+			break
+		}
+		after = pieces[len(pieces)-1].ID
+
+		for _, piece := range pieces {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			var pieceID string
+			switch {
+			case piece.Hash != nil && piece.Hash.SatelliteID == satelliteID:
+				pieceID = piece.Hash.PieceID
+			case piece.Hash == nil && !retainer.legacy.IsZero() && retainer.legacy == satelliteID:
+				pieceID = piece.ID
+			default:
+				continue
+			}
+
+			path, err := retainer.storage.PiecePath(piece.ID)
+			if err != nil {
+				return nil, RetainError.Wrap(err)
+			}
+			info, err := os.Stat(path)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return nil, RetainError.Wrap(err)
+			}
+			if !info.ModTime().Before(createdAt) {
+				continue
+			}
+
+			summary.PiecesExamined++
+			if filter.Contains(pieceID) {
+				continue
+			}
+
+			if retainer.config.DryRun {
+				retainer.log.Info("garbage collection would trash piece", zap.String("piece", piece.ID), zap.Int64("size", info.Size()))
+			} else if err := retainer.trash(ctx, piece.ID, satelliteID); err != nil {
+				retainer.log.Warn("trashing piece failed", zap.String("piece", piece.ID), zap.Error(err))
+				continue
+			}
+			summary.PiecesTrashed++
+			summary.BytesTrashed += info.Size()
+		}
+	}
+
+	summary.ProcessedUnixSec = time.Now().Unix()
+	if err := SignRetainSummary(retainer.identity, summary); err != nil {
+		return nil, err
+	}
+	if err := retainer.db.AddRetainSummary(summary); err != nil {
+		return nil, RetainError.Wrap(err)
+	}
+
+	mon.Counter("retain_pieces_trashed").Inc(summary.PiecesTrashed)
+	retainer.log.Info("processed retain request",
+		zap.String("satellite", satelliteID.String()),
+		zap.Bool("dry run", summary.DryRun),
+		zap.Int64("examined", summary.PiecesExamined),
+		zap.Int64("trashed", summary.PiecesTrashed),
+		zap.Int64("bytes", summary.BytesTrashed))
+	return summary, nil
+}
+
+// trash moves the piece to the trash, where it's kept for the grace period.
+// The records are moved back when the piece can't be moved, so that it isn't
+// left stored without them.
+func (retainer *Retainer) trash(ctx context.Context, id string, satelliteID storj.NodeID) error {
+	if err := retainer.db.TrashPiece(ctx, id, satelliteID, time.Now().Unix()); err != nil {
+		return err
+	}
+	if err := retainer.storage.Trash(id); err != nil {
+		return errs.Combine(err, retainer.untrash(ctx, id))
+	}
+	return nil
+}
+
+// untrash moves the records of a piece which couldn't be trashed back
+func (retainer *Retainer) untrash(ctx context.Context, id string) error {
+	piece, err := retainer.db.GetTrashedPiece(id)
+	if err != nil || piece == nil {
+		return err
+	}
+	return retainer.db.RestorePiece(ctx, piece)
+}
+
+// Run processes the queued retain requests and periodically deletes the
+// trashed pieces past their grace period
+func (retainer *Retainer) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var empty <-chan time.Time
+	if retainer.config.EmptyInterval > 0 {
+		ticker := time.NewTicker(retainer.config.EmptyInterval)
+		defer ticker.Stop()
+		empty = ticker.C
+
+		if err := retainer.EmptyTrash(ctx, time.Now()); err != nil {
+			retainer.log.Error("emptying trash failed", zap.Error(err))
+		}
+	} else {
+		retainer.log.Info("emptying trash disabled")
+	}
+
+	for {
+		select {
+		case <-retainer.wake:
+			retainer.processQueue(ctx)
+		case <-empty:
+			if err := retainer.EmptyTrash(ctx, time.Now()); err != nil {
+				retainer.log.Error("emptying trash failed", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// EmptyTrash deletes the pieces which were trashed longer than the grace
+// period before now
+func (retainer *Retainer) EmptyTrash(ctx context.Context, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pieces, err := retainer.db.GetTrash(storj.NodeID{}, now.Add(-retainer.config.TrashGrace).Unix())
+	if err != nil {
+		return RetainError.Wrap(err)
+	}
+
+	for _, piece := range pieces {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := retainer.storage.DeleteTrashed(piece.ID); err != nil {
+			retainer.log.Warn("deleting trashed piece failed", zap.String("piece", piece.ID), zap.Error(err))
+			continue
+		}
+		if err := retainer.db.DeleteTrash(piece.ID); err != nil {
+			return RetainError.Wrap(err)
+		}
+		mon.Counter("retain_pieces_deleted").Inc(1)
+	}
+	return nil
+}
+
+// Restore moves the trashed pieces of the satellite back to the stored
+// pieces, undoing garbage collection by mistake. It returns the number of
+// restored pieces.
+func (retainer *Retainer) Restore(ctx context.Context, satelliteID storj.NodeID) (restored int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pieces, err := retainer.db.GetTrash(satelliteID, math.MaxInt64)
+	if err != nil {
+		return 0, RetainError.Wrap(err)
+	}
+
+	for _, piece := range pieces {
+		if err := ctx.Err(); err != nil {
+			return restored, err
+		}
+		if err := retainer.storage.Restore(piece.ID); err != nil {
+			retainer.log.Warn("restoring piece failed", zap.String("piece", piece.ID), zap.Error(err))
+			continue
+		}
+		if err := retainer.db.RestorePiece(ctx, piece); err != nil {
+			return restored, RetainError.Wrap(err)
+		}
+		restored++
+	}
+
+	mon.Counter("retain_pieces_restored").Inc(restored)
+	retainer.log.Info("restored trashed pieces",
+		zap.String("satellite", satelliteID.String()),
+		zap.Int64("restored", restored))
+	return restored, nil
+}
+
+// StoredPieceID returns the ID a storage node stores a piece with, which is
+// namespaced by the satellite authorizing its upload
+func StoredPieceID(pieceID string, satelliteID storj.NodeID) (string, error) {
+	return getNamespacedPieceID([]byte(pieceID), satelliteID.Bytes())
+}
+
+// SignRetainSummary signs the summary with the identity of the node
+func SignRetainSummary(ident *identity.FullIdentity, summary *pb.RetainSummary) (err error) {
+	summary.Signature, summary.Chain = nil, nil
+	data, err := proto.Marshal(summary)
+	if err != nil {
+		return RetainError.Wrap(err)
+	}
+	summary.Signature, err = auth.GenerateSignature(data, ident)
+	if err != nil {
+		return RetainError.Wrap(err)
+	}
+	summary.Chain = [][]byte{ident.Leaf.Raw, ident.CA.Raw}
+	return nil
+}
+
+// VerifyRetainSummary checks that the summary was signed by the node
+func VerifyRetainSummary(nodeID storj.NodeID, summary *pb.RetainSummary) error {
+	unsigned := *summary
+	unsigned.Signature, unsigned.Chain = nil, nil
+	data, err := proto.Marshal(&unsigned)
+	if err != nil {
+		return RetainError.Wrap(err)
+	}
+	signer, err := auth.VerifyChainSignature(data, summary.Signature, summary.Chain)
+	if err != nil {
+		return RetainError.Wrap(err)
+	}
+	if signer != nodeID {
+		return RetainError.New("summary of %s is signed by %s", nodeID, signer)
+	}
+	return nil
+}
+
+// Retain queues garbage collecting the pieces of the calling satellite which
+// aren't in the filter of the request. The response doesn't wait for it, the
+// summary is recorded in the retain log once the request was processed.
+func (s *Server) Retain(ctx context.Context, req *pb.RetainRequest) (_ *pb.RetainResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	peer, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if !s.Trust.Trusted(peer.ID) {
+		return nil, status.Errorf(codes.PermissionDenied, "untrusted satellite %s", peer.ID)
+	}
+	if req.Filter == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing filter")
+	}
+	filter, err := bloomfilter.FromPB(req.Filter)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	s.Retainer.Queue(peer.ID, filter, time.Unix(req.CreatedUnixSec, 0))
+	return &pb.RetainResponse{}, nil
+}
+
+// RestoreTrash restores the trashed pieces of a satellite, requested by the
+// satellite itself or by local callers
+func (s *Server) RestoreTrash(ctx context.Context, req *pb.RestoreTrashRequest) (_ *pb.RestoreTrashResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !server.IsLocal(ctx) {
+		peer, err := identity.PeerIdentityFromContext(ctx)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		if peer.ID != req.SatelliteId {
+			return nil, status.Errorf(codes.PermissionDenied, "trash of %s is only restored for it or local callers", req.SatelliteId)
+		}
+	}
+
+	restored, err := s.Retainer.Restore(ctx, req.SatelliteId)
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+	return &pb.RestoreTrashResponse{PiecesRestored: restored}, nil
+}
+
+// RetainLog returns the summaries of processed retain requests to local callers
+func (s *Server) RetainLog(ctx context.Context, req *pb.RetainLogRequest) (_ *pb.RetainLogResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !server.IsLocal(ctx) {
+		return nil, status.Errorf(codes.PermissionDenied, "retain log is only served to local callers")
+	}
+
+	summaries, err := s.DB.GetRetainSummaries(req.SatelliteId, int(req.Limit))
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+	return &pb.RetainLogResponse{Summaries: summaries}, nil
+}
@@ -0,0 +1,379 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psserver/psdb"
+)
+
+type retainSet map[string]bool
+
+func (set retainSet) Contains(pieceID string) bool { return set[pieceID] }
+
+func TestRetain(t *testing.T) {
+	ctx := context.Background()
+	s, cleanup := newTestServerStruct(t)
+	defer cleanup()
+
+	ident, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+
+	satellite := teststorj.NodeIDFromString("satellite")
+	other := teststorj.NodeIDFromString("other")
+	content := []byte("retained piece content")
+
+	stored := time.Now().Add(-time.Hour)
+	for id, owner := range map[string]bool{
+		"11111111111111111111": true,
+		"22222222222222222222": true,
+		"33333333333333333333": true,
+		"44444444444444444444": false,
+	} {
+		writer, err := s.storage.Writer(id)
+		require.NoError(t, err)
+		_, err = writer.Write(content)
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		path, err := s.storage.PiecePath(id)
+		require.NoError(t, err)
+		require.NoError(t, os.Chtimes(path, stored, stored))
+
+		hash := &psdb.PieceHash{ID: id, PieceID: "piece-" + id, SatelliteID: satellite}
+		if !owner {
+			hash.SatelliteID = other
+		}
+		require.NoError(t, s.DB.AddTTL(id, 0, int64(len(content))))
+		require.NoError(t, s.DB.AddPieceHash(hash))
+	}
+
+	// a piece stored after the filter was created
+	writer, err := s.storage.Writer("55555555555555555555")
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	require.NoError(t, s.DB.AddPieceHash(&psdb.PieceHash{ID: "55555555555555555555", PieceID: "piece-5", SatelliteID: satellite}))
+
+	filter := retainSet{"piece-11111111111111111111": true}
+	createdAt := time.Now().Add(-time.Minute)
+
+	{ // a dry run only reports the pieces
+		retainer, err := NewRetainer(zaptest.NewLogger(t), s.storage, s.DB, ident, RetainConfig{DryRun: true})
+		require.NoError(t, err)
+		summary, err := retainer.Retain(ctx, satellite, filter, createdAt)
+		require.NoError(t, err)
+		assert.True(t, summary.DryRun)
+		assert.EqualValues(t, 3, summary.PiecesExamined)
+		assert.EqualValues(t, 2, summary.PiecesTrashed)
+		assert.EqualValues(t, 2*len(content), summary.BytesTrashed)
+		assert.NoError(t, VerifyRetainSummary(ident.ID, summary))
+
+		count, err := s.DB.CountPieceHashes()
+		require.NoError(t, err)
+		assert.EqualValues(t, 5, count)
+	}
+
+	{ // unretained pieces are trashed
+		retainer, err := NewRetainer(zaptest.NewLogger(t), s.storage, s.DB, ident, RetainConfig{})
+		require.NoError(t, err)
+		summary, err := retainer.Retain(ctx, satellite, filter, createdAt)
+		require.NoError(t, err)
+		assert.False(t, summary.DryRun)
+		assert.EqualValues(t, 2, summary.PiecesTrashed)
+
+		for id, exists := range map[string]bool{
+			"11111111111111111111": true,
+			"22222222222222222222": false,
+			"33333333333333333333": false,
+			"44444444444444444444": true,
+			"55555555555555555555": true,
+		} {
+			path, err := s.storage.PiecePath(id)
+			require.NoError(t, err)
+			_, err = os.Stat(path)
+			assert.Equal(t, exists, err == nil, id)
+		}
+
+		count, err := s.DB.CountPieceHashes()
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, count)
+	}
+
+	{ // the summaries are served to local callers, newest first
+		local := peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}})
+		resp, err := s.RetainLog(local, &pb.RetainLogRequest{SatelliteId: satellite})
+		require.NoError(t, err)
+		require.Len(t, resp.Summaries, 2)
+		assert.False(t, resp.Summaries[0].DryRun)
+		assert.True(t, resp.Summaries[1].DryRun)
+		for _, summary := range resp.Summaries {
+			assert.NoError(t, VerifyRetainSummary(ident.ID, summary))
+		}
+
+		tampered := *resp.Summaries[0]
+		tampered.PiecesTrashed = 0
+		assert.Error(t, VerifyRetainSummary(ident.ID, &tampered))
+
+		resp, err = s.RetainLog(local, &pb.RetainLogRequest{SatelliteId: other})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Summaries)
+
+		_, err = s.RetainLog(ctx, &pb.RetainLogRequest{})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	}
+}
+
+func TestRetainTrash(t *testing.T) {
+	ctx := context.Background()
+	s, cleanup := newTestServerStruct(t)
+	defer cleanup()
+
+	ident, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+
+	satellite := teststorj.NodeIDFromString("satellite")
+	content := []byte("trashed piece content")
+	ids := []string{"11111111111111111111", "22222222222222222222"}
+
+	stored := time.Now().Add(-time.Hour)
+	for _, id := range ids {
+		writer, err := s.storage.Writer(id)
+		require.NoError(t, err)
+		_, err = writer.Write(content)
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		path, err := s.storage.PiecePath(id)
+		require.NoError(t, err)
+		require.NoError(t, os.Chtimes(path, stored, stored))
+
+		require.NoError(t, s.DB.AddTTL(id, 0, int64(len(content))))
+		require.NoError(t, s.DB.AddPieceHash(&psdb.PieceHash{ID: id, PieceID: "piece-" + id, SatelliteID: satellite, Hash: []byte{1}}))
+	}
+
+	exists := func(id string) bool {
+		path, err := s.storage.PiecePath(id)
+		require.NoError(t, err)
+		_, err = os.Stat(path)
+		return err == nil
+	}
+
+	config := RetainConfig{TrashGrace: time.Hour}
+	retainer, err := NewRetainer(zaptest.NewLogger(t), s.storage, s.DB, ident, config)
+	require.NoError(t, err)
+	s.Retainer = retainer
+
+	summary, err := retainer.Retain(ctx, satellite, retainSet{}, time.Now())
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, summary.PiecesTrashed)
+
+	used, err := s.DB.SumTTLSizes()
+	require.NoError(t, err)
+	assert.Zero(t, used)
+
+	{ // the trash is only restored for the satellite or local callers
+		_, err := s.RestoreTrash(ctx, &pb.RestoreTrashRequest{SatelliteId: satellite})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		local := peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}})
+		resp, err := s.RestoreTrash(local, &pb.RestoreTrashRequest{SatelliteId: satellite})
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, resp.PiecesRestored)
+
+		for _, id := range ids {
+			assert.True(t, exists(id), id)
+			hash, err := s.DB.GetPieceHash(id)
+			require.NoError(t, err)
+			require.NotNil(t, hash)
+			assert.Equal(t, "piece-"+id, hash.PieceID)
+			assert.Equal(t, []byte{1}, hash.Hash)
+		}
+
+		used, err := s.DB.SumTTLSizes()
+		require.NoError(t, err)
+		assert.EqualValues(t, 2*len(content), used)
+	}
+
+	{ // trashed pieces are deleted after the grace period
+		_, err := retainer.Retain(ctx, satellite, retainSet{"piece-" + ids[0]: true}, time.Now())
+		require.NoError(t, err)
+		assert.True(t, exists(ids[0]))
+		assert.False(t, exists(ids[1]))
+
+		require.NoError(t, retainer.EmptyTrash(ctx, time.Now()))
+		trash, err := s.DB.GetTrash(satellite, math.MaxInt64)
+		require.NoError(t, err)
+		assert.Len(t, trash, 1)
+
+		require.NoError(t, retainer.EmptyTrash(ctx, time.Now().Add(2*config.TrashGrace)))
+		trash, err = s.DB.GetTrash(satellite, math.MaxInt64)
+		require.NoError(t, err)
+		assert.Empty(t, trash)
+
+		restored, err := retainer.Restore(ctx, satellite)
+		require.NoError(t, err)
+		assert.Zero(t, restored)
+		assert.False(t, exists(ids[1]))
+	}
+
+	{ // pieces which can't be moved to the trash keep their records
+		require.NoError(t, os.RemoveAll(filepath.Join(s.storage.Dir(), "trash")))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(s.storage.Dir(), "trash"), nil, 0600))
+
+		hash, err := s.DB.GetPieceHash(ids[0])
+		require.NoError(t, err)
+		require.Error(t, retainer.trash(ctx, hash.ID, satellite))
+		assert.True(t, exists(ids[0]))
+
+		hash, err = s.DB.GetPieceHash(ids[0])
+		require.NoError(t, err)
+		assert.NotNil(t, hash)
+		trashed, err := s.DB.GetTrashedPiece(ids[0])
+		require.NoError(t, err)
+		assert.Nil(t, trashed)
+		used, err := s.DB.SumTTLSizes()
+		require.NoError(t, err)
+		assert.EqualValues(t, len(content), used)
+	}
+}
+
+func TestRetainLegacy(t *testing.T) {
+	ctx := context.Background()
+	s, cleanup := newTestServerStruct(t)
+	defer cleanup()
+
+	ident, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+
+	satellite := teststorj.NodeIDFromString("satellite")
+	other := teststorj.NodeIDFromString("other")
+	content := []byte("legacy piece content")
+
+	// pieces stored before hashes were recorded only have a ttl row
+	ids := make([]string, 3)
+	stored := time.Now().Add(-time.Hour)
+	for i := range ids {
+		ids[i], err = StoredPieceID(fmt.Sprintf("legacypiece%d", i), satellite)
+		require.NoError(t, err)
+
+		writer, err := s.storage.Writer(ids[i])
+		require.NoError(t, err)
+		_, err = writer.Write(content)
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		path, err := s.storage.PiecePath(ids[i])
+		require.NoError(t, err)
+		require.NoError(t, os.Chtimes(path, stored, stored))
+		require.NoError(t, s.DB.AddTTL(ids[i], 0, int64(len(content))))
+	}
+	filter := retainSet{ids[0]: true}
+
+	exists := func(id string) bool {
+		path, err := s.storage.PiecePath(id)
+		require.NoError(t, err)
+		_, err = os.Stat(path)
+		return err == nil
+	}
+
+	{ // without a legacy satellite the pieces are retained
+		retainer, err := NewRetainer(zaptest.NewLogger(t), s.storage, s.DB, ident, RetainConfig{})
+		require.NoError(t, err)
+		summary, err := retainer.Retain(ctx, satellite, filter, time.Now())
+		require.NoError(t, err)
+		assert.Zero(t, summary.PiecesExamined)
+	}
+
+	_, err = NewRetainer(zaptest.NewLogger(t), s.storage, s.DB, ident, RetainConfig{LegacySatellite: "invalid"})
+	assert.Error(t, err)
+
+	retainer, err := NewRetainer(zaptest.NewLogger(t), s.storage, s.DB, ident, RetainConfig{LegacySatellite: satellite.String()})
+	require.NoError(t, err)
+
+	{ // only the legacy satellite collects them
+		summary, err := retainer.Retain(ctx, other, retainSet{}, time.Now())
+		require.NoError(t, err)
+		assert.Zero(t, summary.PiecesExamined)
+	}
+
+	{ // the legacy satellite collects them by the ids they're stored with
+		summary, err := retainer.Retain(ctx, satellite, filter, time.Now())
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, summary.PiecesExamined)
+		assert.EqualValues(t, 2, summary.PiecesTrashed)
+		assert.True(t, exists(ids[0]))
+		assert.False(t, exists(ids[1]))
+		assert.False(t, exists(ids[2]))
+	}
+
+	{ // and restores them without a hash
+		restored, err := retainer.Restore(ctx, satellite)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, restored)
+		for _, id := range ids {
+			assert.True(t, exists(id), id)
+			hash, err := s.DB.GetPieceHash(id)
+			require.NoError(t, err)
+			assert.Nil(t, hash)
+		}
+
+		used, err := s.DB.SumTTLSizes()
+		require.NoError(t, err)
+		assert.EqualValues(t, 3*len(content), used)
+	}
+}
+
+func TestRetainQueue(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, cleanup := newTestServerStruct(t)
+	defer cleanup()
+
+	ident, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+	satellite := teststorj.NodeIDFromString("satellite")
+
+	retainer, err := NewRetainer(zaptest.NewLogger(t), s.storage, s.DB, ident, RetainConfig{})
+	require.NoError(t, err)
+
+	// requests are processed in the background and recorded in the log
+	retainer.Queue(satellite, retainSet{}, time.Now())
+	done := make(chan error)
+	go func() { done <- retainer.Run(ctx) }()
+
+	for i := 0; i < 100; i++ {
+		summaries, err := s.DB.GetRetainSummaries(satellite, 0)
+		require.NoError(t, err)
+		if len(summaries) > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	summaries, err := s.DB.GetRetainSummaries(satellite, 0)
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.NoError(t, VerifyRetainSummary(ident.ID, summaries[0]))
+
+	cancel()
+	require.NoError(t, <-done)
+}
@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/zeebo/errs"
@@ -66,6 +68,11 @@ func (s *Server) Retrieve(stream pb.PieceStoreRoutes_RetrieveServer) (err error)
 	// Verify that the path exists
 	fileInfo, err := os.Stat(path)
 	if err != nil {
+		if os.IsNotExist(err) && s.Scrubber != nil {
+			if lostErr := s.Scrubber.Lost(ctx, id); lostErr != nil {
+				s.log.Error("unable to quarantine lost piece", zap.String("Piece ID", id), zap.Error(lostErr))
+			}
+		}
 		return RetrieveError.Wrap(err)
 	}
 
@@ -105,6 +112,10 @@ func (s *Server) retrieveData(ctx context.Context, stream pb.PieceStoreRoutes_Re
 	allocationTracking := sync2.NewThrottle()
 	totalAllocated := int64(0)
 
+	// payer of the allocations, for the usage statistics
+	var payerMu sync.Mutex
+	var payer *pb.PayerBandwidthAllocation_Data
+
 	// Bandwidth Allocation recv loop
 	go func() {
 		var lastTotal int64
@@ -164,6 +175,10 @@ func (s *Server) retrieveData(ctx context.Context, stream pb.PieceStoreRoutes_Re
 
 			atomic.StoreInt64(&totalAllocated, allocData.GetTotal())
 
+			payerMu.Lock()
+			payer = pbaData
+			payerMu.Unlock()
+
 			if err = allocationTracking.Produce(allocData.GetTotal() - lastTotal); err != nil {
 				return
 			}
@@ -205,6 +220,15 @@ func (s *Server) retrieveData(ctx context.Context, stream pb.PieceStoreRoutes_Re
 		return retrieved, allocated, StoreError.New("failed to write bandwidth info to database: %v", err)
 	}
 
+	payerMu.Lock()
+	usagePayer := payer
+	payerMu.Unlock()
+	if usagePayer != nil {
+		if err = s.DB.AddUsage(usagePayer.SatelliteId, usagePayer.Action, used, time.Now()); err != nil {
+			return retrieved, allocated, RetrieveError.New("failed to write usage to database: %v", err)
+		}
+	}
+
 	// TODO: handle errors
 	// _ = stream.Close()
 
@@ -0,0 +1,435 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/sync2"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/kademlia"
+	"storj.io/storj/pkg/pb"
+	pstore "storj.io/storj/pkg/piecestore"
+	"storj.io/storj/pkg/piecestore/psserver/psdb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/pkg/utils"
+)
+
+var (
+	// ScrubError is a type of error for failures in Scrubber
+	ScrubError = errs.Class("scrub error")
+)
+
+const (
+	scrubBatchSize = 100
+	scrubChunkSize = 32 * memory.KiB
+)
+
+// ScrubConfig contains configuration for verifying stored pieces
+type ScrubConfig struct {
+	Rate     memory.Size   `help:"how many bytes per second the scrubber is allowed to read, 0 disables scrubbing" default:"1MiB"`
+	Interval time.Duration `help:"how frequently all stored pieces are verified" default:"24h0m0s"`
+	// Retention is counted from the detection, pieces are only deleted once
+	// they were reported to their satellite
+	QuarantineRetention time.Duration `help:"how long quarantined pieces are kept for inspection, 0 keeps them forever" default:"720h0m0s"`
+}
+
+// CorruptionReporter reports corrupted pieces to the satellite owning them
+type CorruptionReporter interface {
+	ReportCorruption(ctx context.Context, satellite storj.NodeID, pieces []*psdb.QuarantinedPiece) error
+}
+
+// Scrubber periodically verifies the content hash of stored pieces,
+// quarantining pieces which don't match
+type Scrubber struct {
+	log      *zap.Logger
+	storage  *pstore.Storage
+	db       *psdb.DB
+	config   ScrubConfig
+	reporter CorruptionReporter
+
+	// lost is signaled when pieces were found lost while serving them
+	lost chan struct{}
+
+	mu    sync.Mutex
+	stats pb.ScrubStats
+}
+
+// NewScrubber creates a new piece scrubber
+func NewScrubber(log *zap.Logger, storage *pstore.Storage, db *psdb.DB, config ScrubConfig, reporter CorruptionReporter) *Scrubber {
+	return &Scrubber{
+		log:      log,
+		storage:  storage,
+		db:       db,
+		config:   config,
+		reporter: reporter,
+		lost:     make(chan struct{}, 1),
+	}
+}
+
+// Run runs scrubbing passes every interval until the context is canceled.
+// Pieces found lost while serving them are reported right away, also when
+// scrubbing is disabled.
+func (scrubber *Scrubber) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// quarantined pieces of previous runs count as well
+	if err := scrubber.countQuarantine(); err != nil {
+		scrubber.log.Warn("unable to count quarantined pieces", zap.Error(err))
+	}
+
+	// without scrubbing there's no next pass to wait for
+	var next <-chan time.Time
+	if scrubber.config.Rate > 0 {
+		ticker := time.NewTicker(scrubber.config.Interval)
+		defer ticker.Stop()
+		next = ticker.C
+	} else {
+		scrubber.log.Info("scrubbing disabled")
+	}
+
+	for {
+		if next != nil {
+			if err := scrubber.Scrub(ctx); err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				scrubber.log.Error("scrub failed", zap.Error(err))
+			}
+		}
+
+		if err := scrubber.EmptyQuarantine(ctx, time.Now()); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			scrubber.log.Error("emptying quarantine failed", zap.Error(err))
+		}
+
+		if !scrubber.wait(ctx, next) {
+			return nil
+		}
+	}
+}
+
+// wait waits for the next pass, reporting pieces found lost in between. It
+// returns false when the context is canceled.
+func (scrubber *Scrubber) wait(ctx context.Context, next <-chan time.Time) bool {
+	for {
+		select {
+		case <-next:
+			return true
+		case <-scrubber.lost:
+			if err := scrubber.report(ctx); err != nil {
+				scrubber.log.Error("reporting lost pieces failed", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// Stats returns the progress of the scrubber
+func (scrubber *Scrubber) Stats() *pb.ScrubStats {
+	scrubber.mu.Lock()
+	defer scrubber.mu.Unlock()
+
+	stats := scrubber.stats
+	return &stats
+}
+
+// Scrub verifies all stored pieces once and reports the corrupted ones
+func (scrubber *Scrubber) Scrub(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	total, err := scrubber.db.CountPieceHashes()
+	if err != nil {
+		return ScrubError.Wrap(err)
+	}
+
+	var corruptedBefore int64
+	scrubber.update(func(stats *pb.ScrubStats) {
+		stats.PiecesChecked = 0
+		stats.PiecesTotal = total
+		stats.BytesChecked = 0
+		corruptedBefore = stats.Corrupted
+	})
+
+	after := ""
+	for {
+		hashes, err := scrubber.db.GetPieceHashes(after, scrubBatchSize)
+		if err != nil {
+			return ScrubError.Wrap(err)
+		}
+		if len(hashes) == 0 {
+			break
+		}
+		after = hashes[len(hashes)-1].ID
+
+		for _, hash := range hashes {
+			if err := scrubber.verify(ctx, hash); err != nil {
+				return err
+			}
+		}
+	}
+
+	var corrupted int64
+	scrubber.update(func(stats *pb.ScrubStats) {
+		stats.Passes++
+		stats.LastPassUnixSec = time.Now().Unix()
+		corrupted = stats.Corrupted - corruptedBefore
+	})
+	mon.IntVal("scrub_pass_corrupted_pieces").Observe(corrupted)
+
+	return scrubber.report(ctx)
+}
+
+// verify checks a single piece, quarantining it when it's missing or corrupted
+func (scrubber *Scrubber) verify(ctx context.Context, hash *psdb.PieceHash) error {
+	sum, size, err := scrubber.hash(ctx, hash.ID)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	scrubber.update(func(stats *pb.ScrubStats) {
+		stats.PiecesChecked++
+		stats.BytesChecked += size
+	})
+	mon.IntVal("scrub_bytes_checked").Observe(size)
+
+	switch {
+	case err == nil && bytes.Equal(sum, hash.Hash):
+		return ScrubError.Wrap(scrubber.db.SetPieceVerified(hash.ID, time.Now().Unix()))
+	case err != nil && !os.IsNotExist(err):
+		// unable to read the piece, try again during the next pass
+		scrubber.log.Warn("unable to verify piece", zap.String("Piece ID", hash.ID), zap.Error(err))
+		return nil
+	case err != nil:
+		// deletes remove the file before the hash, so the piece may have
+		// been deleted or expired since the batch was read
+		current, dbErr := scrubber.db.GetPieceHash(hash.ID)
+		if dbErr != nil {
+			return ScrubError.Wrap(dbErr)
+		}
+		if current == nil {
+			return nil
+		}
+	}
+
+	scrubber.log.Warn("piece failed verification", zap.String("Piece ID", hash.ID), zap.Bool("missing", err != nil))
+	mon.Counter("scrub_corrupted_pieces").Inc(1)
+
+	if err := scrubber.storage.Quarantine(hash.ID); err != nil {
+		return ScrubError.Wrap(err)
+	}
+	if err := scrubber.db.QuarantinePiece(ctx, hash, time.Now().Unix()); err != nil {
+		return ScrubError.Wrap(err)
+	}
+
+	scrubber.update(func(stats *pb.ScrubStats) {
+		stats.Corrupted++
+	})
+	return nil
+}
+
+// Lost quarantines a piece which was found missing while serving it and
+// reports it to its satellite without waiting for the next pass. Pieces
+// without a recorded hash aren't known to be stored and are ignored.
+func (scrubber *Scrubber) Lost(ctx context.Context, id string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	hash, err := scrubber.db.GetPieceHash(id)
+	if err != nil {
+		return ScrubError.Wrap(err)
+	}
+	if hash == nil {
+		return nil
+	}
+
+	scrubber.log.Warn("piece found missing", zap.String("Piece ID", id))
+	mon.Counter("lost_pieces_found").Inc(1)
+
+	if err := scrubber.storage.Quarantine(id); err != nil {
+		return ScrubError.Wrap(err)
+	}
+	if err := scrubber.db.QuarantinePiece(ctx, hash, time.Now().Unix()); err != nil {
+		return ScrubError.Wrap(err)
+	}
+
+	scrubber.update(func(stats *pb.ScrubStats) {
+		stats.Corrupted++
+	})
+	if err := scrubber.countQuarantine(); err != nil {
+		return err
+	}
+
+	select {
+	case scrubber.lost <- struct{}{}:
+	default: // a report is already pending
+	}
+	return nil
+}
+
+// EmptyQuarantine deletes the quarantined pieces which were reported and
+// detected longer than the retention before now
+func (scrubber *Scrubber) EmptyQuarantine(ctx context.Context, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if scrubber.config.QuarantineRetention <= 0 {
+		return nil
+	}
+
+	pieces, err := scrubber.db.GetReportedQuarantine(now.Add(-scrubber.config.QuarantineRetention).Unix())
+	if err != nil {
+		return ScrubError.Wrap(err)
+	}
+
+	for _, piece := range pieces {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := scrubber.storage.DeleteQuarantined(piece.ID); err != nil {
+			scrubber.log.Warn("deleting quarantined piece failed", zap.String("Piece ID", piece.ID), zap.Error(err))
+			continue
+		}
+		if err := scrubber.db.DeleteQuarantine(piece.ID); err != nil {
+			return ScrubError.Wrap(err)
+		}
+		mon.Counter("scrub_quarantine_deleted").Inc(1)
+	}
+	return scrubber.countQuarantine()
+}
+
+// hash reads the piece no faster than the configured rate and returns its hash
+func (scrubber *Scrubber) hash(ctx context.Context, id string) (sum []byte, size int64, err error) {
+	path, err := scrubber.storage.PiecePath(id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer utils.LogClose(file)
+
+	hash := sha256.New()
+	buf := make([]byte, scrubChunkSize.Int())
+	for {
+		n, err := file.Read(buf)
+		_, _ = hash.Write(buf[:n])
+		size += int64(n)
+
+		delay := time.Duration(n) * time.Second / time.Duration(scrubber.config.Rate.Int64())
+		if !sync2.Sleep(ctx, delay) {
+			return nil, size, ctx.Err()
+		}
+
+		if err == io.EOF {
+			return hash.Sum(nil), size, nil
+		}
+		if err != nil {
+			return nil, size, err
+		}
+	}
+}
+
+// report sends the quarantined pieces to their satellites
+func (scrubber *Scrubber) report(ctx context.Context) error {
+	if scrubber.reporter == nil {
+		return scrubber.countQuarantine()
+	}
+
+	unreported, err := scrubber.db.GetUnreportedQuarantine()
+	if err != nil {
+		return ScrubError.Wrap(err)
+	}
+
+	for satellite, pieces := range unreported {
+		if err := scrubber.reporter.ReportCorruption(ctx, satellite, pieces); err != nil {
+			scrubber.log.Warn("unable to report corrupted pieces, will retry", zap.String("satellite id", satellite.String()), zap.Error(err))
+			continue
+		}
+
+		ids := make([]string, 0, len(pieces))
+		for _, piece := range pieces {
+			ids = append(ids, piece.ID)
+		}
+		if err := scrubber.db.MarkQuarantineReported(ids); err != nil {
+			return ScrubError.Wrap(err)
+		}
+	}
+	return scrubber.countQuarantine()
+}
+
+// countQuarantine updates the number of quarantined pieces in the stats and
+// reports them to monkit
+func (scrubber *Scrubber) countQuarantine() error {
+	total, unreported, err := scrubber.db.CountQuarantine()
+	if err != nil {
+		return ScrubError.Wrap(err)
+	}
+
+	scrubber.update(func(stats *pb.ScrubStats) {
+		stats.Quarantined = total
+		stats.Unreported = unreported
+	})
+	mon.IntVal("scrub_quarantined_pieces").Observe(total)
+	mon.IntVal("scrub_unreported_pieces").Observe(unreported)
+	return nil
+}
+
+func (scrubber *Scrubber) update(fn func(stats *pb.ScrubStats)) {
+	scrubber.mu.Lock()
+	defer scrubber.mu.Unlock()
+	fn(&scrubber.stats)
+}
+
+// satelliteReporter reports corrupted pieces through the satellite PieceHealth endpoint
+type satelliteReporter struct {
+	transport transport.Client
+	kad       *kademlia.Kademlia
+}
+
+// NewCorruptionReporter creates a reporter which looks up satellites through kademlia
+func NewCorruptionReporter(identity *identity.FullIdentity, kad *kademlia.Kademlia) CorruptionReporter {
+	return &satelliteReporter{transport: transport.NewClient(identity), kad: kad}
+}
+
+// ReportCorruption sends the corrupted pieces to the satellite
+func (reporter *satelliteReporter) ReportCorruption(ctx context.Context, satelliteID storj.NodeID, pieces []*psdb.QuarantinedPiece) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	satellite, err := reporter.kad.FindNode(ctx, satelliteID)
+	if err != nil {
+		return ScrubError.Wrap(err)
+	}
+
+	conn, err := reporter.transport.DialNode(ctx, &satellite)
+	if err != nil {
+		return ScrubError.Wrap(err)
+	}
+	defer utils.LogClose(conn)
+
+	report := &pb.CorruptionReport{}
+	for _, piece := range pieces {
+		report.Pieces = append(report.Pieces, &pb.CorruptedPiece{
+			PieceId:         piece.PieceID,
+			DetectedUnixSec: piece.Detected,
+		})
+	}
+
+	_, err = pb.NewPieceHealthClient(conn).ReportCorruption(ctx, report)
+	return ScrubError.Wrap(err)
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/piecestore/psserver/psdb"
+	"storj.io/storj/pkg/storj"
+)
+
+type mockReporter struct {
+	reported map[storj.NodeID][]*psdb.QuarantinedPiece
+}
+
+func (reporter *mockReporter) ReportCorruption(ctx context.Context, satellite storj.NodeID, pieces []*psdb.QuarantinedPiece) error {
+	reporter.reported[satellite] = append(reporter.reported[satellite], pieces...)
+	return nil
+}
+
+func TestScrubber(t *testing.T) {
+	ctx := context.Background()
+	s, cleanup := newTestServerStruct(t)
+	defer cleanup()
+
+	satellite := teststorj.NodeIDFromString("satellite")
+	content := []byte("scrubbed piece content")
+	sum := sha256.Sum256(content)
+
+	for _, id := range []string{"11111111111111111111", "22222222222222222222", "33333333333333333333"} {
+		writer, err := s.storage.Writer(id)
+		require.NoError(t, err)
+		_, err = writer.Write(content)
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		require.NoError(t, s.DB.AddTTL(id, 0, int64(len(content))))
+		require.NoError(t, s.DB.AddPieceHash(&psdb.PieceHash{
+			ID:          id,
+			PieceID:     "piece-" + id,
+			SatelliteID: satellite,
+			Hash:        sum[:],
+		}))
+	}
+
+	// corrupt one piece and lose another
+	corruptPath, err := s.storage.PiecePath("22222222222222222222")
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(corruptPath, []byte("bit rot"), 0600))
+
+	lostPath, err := s.storage.PiecePath("33333333333333333333")
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(lostPath))
+
+	reporter := &mockReporter{reported: map[storj.NodeID][]*psdb.QuarantinedPiece{}}
+	scrubber := NewScrubber(zaptest.NewLogger(t), s.storage, s.DB, ScrubConfig{Rate: memory.GiB}, reporter)
+	require.NoError(t, scrubber.Scrub(ctx))
+
+	stats := scrubber.Stats()
+	assert.EqualValues(t, 3, stats.PiecesChecked)
+	assert.EqualValues(t, 3, stats.PiecesTotal)
+	assert.EqualValues(t, 2, stats.Corrupted)
+	assert.EqualValues(t, 1, stats.Passes)
+	assert.EqualValues(t, 2, stats.Quarantined)
+	assert.EqualValues(t, 0, stats.Unreported)
+
+	reported := reporter.reported[satellite]
+	require.Len(t, reported, 2)
+	assert.Equal(t, "piece-22222222222222222222", reported[0].PieceID)
+	assert.Equal(t, "piece-33333333333333333333", reported[1].PieceID)
+
+	// corrupted piece is not served anymore
+	_, err = os.Stat(corruptPath)
+	assert.True(t, os.IsNotExist(err))
+
+	count, err := s.DB.CountPieceHashes()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+
+	// already reported pieces are not reported again
+	require.NoError(t, scrubber.Scrub(ctx))
+	assert.Len(t, reporter.reported[satellite], 2)
+	assert.EqualValues(t, 2, scrubber.Stats().Passes)
+
+	// pieces deleted while their batch is scrubbed aren't corrupted
+	deleted, err := s.DB.GetPieceHash("11111111111111111111")
+	require.NoError(t, err)
+	require.NoError(t, s.deleteByID(deleted.ID))
+	require.NoError(t, scrubber.verify(ctx, deleted))
+	assert.EqualValues(t, 2, scrubber.Stats().Corrupted)
+	assert.EqualValues(t, 2, scrubber.Stats().Quarantined)
+
+	// reported pieces are deleted after the retention
+	scrubber.config.QuarantineRetention = time.Hour
+	require.NoError(t, scrubber.EmptyQuarantine(ctx, time.Now()))
+	assert.EqualValues(t, 2, scrubber.Stats().Quarantined)
+
+	require.NoError(t, scrubber.EmptyQuarantine(ctx, time.Now().Add(2*time.Hour)))
+	assert.EqualValues(t, 0, scrubber.Stats().Quarantined)
+	_, err = os.Stat(filepath.Join(s.storage.Dir(), "quarantine", "22222222222222222222"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestScrubberLost(t *testing.T) {
+	ctx := context.Background()
+	s, cleanup := newTestServerStruct(t)
+	defer cleanup()
+
+	satellite := teststorj.NodeIDFromString("satellite")
+	id := "11111111111111111111"
+	require.NoError(t, s.DB.AddPieceHash(&psdb.PieceHash{
+		ID:          id,
+		PieceID:     "piece-" + id,
+		SatelliteID: satellite,
+		Hash:        []byte("hash"),
+	}))
+
+	reporter := &mockReporter{reported: map[storj.NodeID][]*psdb.QuarantinedPiece{}}
+	scrubber := NewScrubber(zaptest.NewLogger(t), s.storage, s.DB, ScrubConfig{}, reporter)
+
+	// pieces which aren't known to be stored are ignored
+	require.NoError(t, scrubber.Lost(ctx, "22222222222222222222"))
+	assert.Len(t, scrubber.lost, 0)
+
+	require.NoError(t, scrubber.Lost(ctx, id))
+	assert.Len(t, scrubber.lost, 1)
+	assert.EqualValues(t, 1, scrubber.Stats().Corrupted)
+	assert.EqualValues(t, 1, scrubber.Stats().Unreported)
+
+	count, err := s.DB.CountPieceHashes()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, count)
+
+	require.NoError(t, scrubber.report(ctx))
+	reported := reporter.reported[satellite]
+	require.Len(t, reported, 1)
+	assert.Equal(t, "piece-"+id, reported[0].PieceID)
+	assert.EqualValues(t, 1, scrubber.Stats().Quarantined)
+	assert.EqualValues(t, 0, scrubber.Stats().Unreported)
+}
@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
@@ -63,10 +64,17 @@ type Server struct {
 	storage          *pstore.Storage
 	DB               *psdb.DB
 	pkey             crypto.PrivateKey
-	totalAllocated   int64
+	space            *SpaceManager
 	totalBwAllocated int64
 	verifier         auth.SignedMessageVerifier
 	kad              *kademlia.Kademlia
+	Scrubber         *Scrubber
+	Trust            *Trust
+	Retainer         *Retainer
+	Partials         *Partials
+
+	ingressMu      sync.Mutex
+	pendingIngress int64
 }
 
 // NewEndpoint -- initializes a new endpoint for a piecestore server
@@ -82,7 +90,8 @@ func NewEndpoint(log *zap.Logger, config Config, storage *pstore.Storage, db *ps
 	if err != nil {
 		return nil, ServerError.Wrap(err)
 	}
-	freeDiskSpace := int64(diskSpace.Free)
+	// the reserved headroom is never allocated
+	freeDiskSpace := int64(diskSpace.Free) - config.ReservedDiskSpace.Int64()
 
 	// get how much is currently used, if for the first time totalUsed = 0
 	totalUsed, err := db.SumTTLSizes()
@@ -129,10 +138,11 @@ func NewEndpoint(log *zap.Logger, config Config, storage *pstore.Storage, db *ps
 		storage:          storage,
 		DB:               db,
 		pkey:             pkey,
-		totalAllocated:   allocatedDiskSpace,
+		space:            NewSpaceManager(log, storage.Dir(), db, allocatedDiskSpace, config.ReservedDiskSpace.Int64()),
 		totalBwAllocated: allocatedBandwidth,
 		verifier:         auth.NewSignedMessageVerifier(),
 		kad:              k,
+		Partials:         NewPartials(log, storage, db, config.PartialExpiration),
 	}, nil
 }
 
@@ -143,9 +153,10 @@ func New(log *zap.Logger, storage *pstore.Storage, db *psdb.DB, config Config, p
 		storage:          storage,
 		DB:               db,
 		pkey:             pkey,
-		totalAllocated:   config.AllocatedDiskSpace.Int64(),
+		space:            NewSpaceManager(log, storage.Dir(), db, config.AllocatedDiskSpace.Int64(), config.ReservedDiskSpace.Int64()),
 		totalBwAllocated: config.AllocatedBandwidth.Int64(),
 		verifier:         auth.NewSignedMessageVerifier(),
+		Partials:         NewPartials(log, storage, db, config.PartialExpiration),
 	}
 }
 
@@ -207,7 +218,7 @@ func (s *Server) Piece(ctx context.Context, in *pb.PieceId) (*pb.PieceSummary, e
 func (s *Server) Stats(ctx context.Context, in *pb.StatsReq) (*pb.StatSummary, error) {
 	s.log.Debug("Getting Stats...")
 
-	totalUsed, err := s.DB.SumTTLSizes()
+	space, err := s.space.Usage()
 	if err != nil {
 		return nil, err
 	}
@@ -217,7 +228,7 @@ func (s *Server) Stats(ctx context.Context, in *pb.StatsReq) (*pb.StatSummary, e
 		return nil, err
 	}
 
-	return &pb.StatSummary{UsedSpace: totalUsed, AvailableSpace: (s.totalAllocated - totalUsed), UsedBandwidth: totalUsedBandwidth, AvailableBandwidth: (s.totalBwAllocated - totalUsedBandwidth)}, nil
+	return &pb.StatSummary{UsedSpace: space.Used, AvailableSpace: space.Available, UsedBandwidth: totalUsedBandwidth, AvailableBandwidth: (s.totalBwAllocated - totalUsedBandwidth)}, nil
 }
 
 // Dashboard is a stream that sends data every `interval` seconds to the listener.
@@ -277,6 +288,10 @@ func (s *Server) deleteByID(id string) error {
 		return err
 	}
 
+	if err := s.DB.DeletePieceHash(id); err != nil {
+		return err
+	}
+
 	s.log.Debug("Deleted", zap.String("Piece ID", id))
 
 	return nil
@@ -308,6 +323,8 @@ func (s *Server) verifyPayerAllocation(pba *pb.PayerBandwidthAllocation_Data, ac
 		return StoreError.New("payer bandwidth allocation: missing uplink id")
 	case !strings.HasPrefix(pba.Action.String(), actionPrefix):
 		return StoreError.New("payer bandwidth allocation: invalid action %v", pba.Action.String())
+	case actionPrefix == "PUT" && !s.Trust.Trusted(pba.SatelliteId):
+		return StoreError.New("payer bandwidth allocation: untrusted satellite %s", pba.SatelliteId)
 	}
 	return nil
 }
@@ -347,17 +364,26 @@ func (s *Server) getDashboardData(ctx context.Context) (*pb.DashboardStats, erro
 		return &pb.DashboardStats{}, ServerError.Wrap(err)
 	}
 
-	nodes, err := s.kad.GetNodes(ctx, rt.Local().Id, 0)
+	local := rt.Local()
+	nodes, err := s.kad.GetNodes(ctx, local.Id, 0)
 	if err != nil {
 		return &pb.DashboardStats{}, ServerError.Wrap(err)
 	}
 
+	var scrub *pb.ScrubStats
+	if s.Scrubber != nil {
+		scrub = s.Scrubber.Stats()
+	}
+
 	return &pb.DashboardStats{
-		NodeId:          rt.Local().Id.String(),
+		NodeId:          local.Id.String(),
 		NodeConnections: int64(len(nodes)),
 		Address:         "",
 		Connection:      true,
 		Uptime:          ptypes.DurationProto(time.Since(s.startTime)),
 		Stats:           statsSummary,
+		Scrub:           scrub,
+		Wallet:          local.GetMetadata().GetWallet(),
+		WalletFeatures:  local.GetMetadata().GetWalletFeatures(),
 	}, nil
 }
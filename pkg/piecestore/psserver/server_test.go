@@ -17,11 +17,13 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/gtank/cryptopasta"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap/zaptest"
 	"golang.org/x/net/context"
@@ -308,6 +310,7 @@ func TestStore(t *testing.T) {
 	tests := []struct {
 		id            string
 		ttl           int64
+		size          int64
 		content       []byte
 		message       string
 		totalReceived int64
@@ -321,6 +324,33 @@ func TestStore(t *testing.T) {
 			totalReceived: 5,
 			err:           "",
 		},
+		{ // should successfully store data smaller than the declared size
+			id:            "99999999999999999998",
+			ttl:           9999999999,
+			size:          1024,
+			content:       []byte("xyzwq"),
+			message:       "OK",
+			totalReceived: 5,
+			err:           "",
+		},
+		{ // should err with data exceeding the declared size
+			id:            "99999999999999999997",
+			ttl:           9999999999,
+			size:          3,
+			content:       []byte("xyzwq"),
+			message:       "",
+			totalReceived: 0,
+			err:           "rpc error: code = Unknown desc = stream writer error: piece exceeds declared size of 3 bytes",
+		},
+		{ // should err with a negative declared size
+			id:            "99999999999999999996",
+			ttl:           9999999999,
+			size:          -1,
+			content:       []byte("xyzwq"),
+			message:       "",
+			totalReceived: 0,
+			err:           "rpc error: code = Unknown desc = store error: invalid piece size -1",
+		},
 		{ // should err with invalid id length
 			id:            "butts",
 			ttl:           9999999999,
@@ -346,7 +376,7 @@ func TestStore(t *testing.T) {
 			assert.NoError(err)
 
 			// Write the buffer to the stream we opened earlier
-			err = stream.Send(&pb.PieceStore{PieceData: &pb.PieceStore_PieceData{Id: tt.id, ExpirationUnixSec: tt.ttl}})
+			err = stream.Send(&pb.PieceStore{PieceData: &pb.PieceStore_PieceData{Id: tt.id, ExpirationUnixSec: tt.ttl, PieceSize: tt.size}})
 			assert.NoError(err)
 
 			pbad := &pb.PayerBandwidthAllocation_Data{
@@ -390,6 +420,8 @@ func TestStore(t *testing.T) {
 			defer func() {
 				_, err := db.Exec(fmt.Sprintf(`DELETE FROM ttl WHERE id="%s"`, tt.id))
 				assert.NoError(err)
+				_, err = db.Exec(`DELETE FROM bandwidth_agreements`)
+				assert.NoError(err)
 			}()
 
 			// check db to make sure agreement and signature were stored correctly
@@ -424,6 +456,55 @@ func TestStore(t *testing.T) {
 	}
 }
 
+func TestUsage(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	TS := NewTestServer(t)
+	defer TS.Stop()
+
+	satelliteID := teststorj.NodeIDFromString("satelliteid")
+	content := []byte("xyzwq")
+
+	stream, err := TS.c.Store(ctx)
+	require.NoError(t, err)
+	err = stream.Send(&pb.PieceStore{PieceData: &pb.PieceStore_PieceData{Id: "99999999999999999999", ExpirationUnixSec: 9999999999}})
+	require.NoError(t, err)
+
+	pbaData, err := proto.Marshal(&pb.PayerBandwidthAllocation_Data{
+		SatelliteId: satelliteID,
+		UplinkId:    teststorj.NodeIDFromString("uplinkid"),
+		Action:      pb.PayerBandwidthAllocation_PUT,
+	})
+	require.NoError(t, err)
+	msg := &pb.PieceStore{
+		PieceData: &pb.PieceStore_PieceData{Content: content},
+		BandwidthAllocation: &pb.RenterBandwidthAllocation{
+			Data: serializeData(&pb.RenterBandwidthAllocation_Data{
+				PayerAllocation: &pb.PayerBandwidthAllocation{Data: pbaData},
+				Total:           int64(len(content)),
+			}),
+		},
+	}
+	msg.BandwidthAllocation.Signature, err = cryptopasta.Sign(msg.BandwidthAllocation.Data, TS.k.(*ecdsa.PrivateKey))
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(msg))
+	_, err = stream.CloseAndRecv()
+	require.NoError(t, err)
+
+	usage, err := TS.c.Usage(ctx, &pb.UsageRequest{FromUnixSec: time.Now().Add(-time.Hour).Unix()})
+	require.NoError(t, err)
+	require.Len(t, usage.Satellites, 1)
+	assert.Equal(t, satelliteID, usage.Satellites[0].SatelliteId)
+	require.Len(t, usage.Satellites[0].Points, 1)
+
+	point := usage.Satellites[0].Points[0]
+	assert.Equal(t, time.Now().Truncate(time.Hour).Unix(), point.HourUnixSec)
+	assert.Equal(t, int64(len(content)), point.Ingress)
+	assert.Equal(t, int64(0), point.Egress)
+	assert.Equal(t, int64(len(content)), point.DiskUsed)
+}
+
 func TestPbaValidation(t *testing.T) {
 	ctx := testcontext.New(t)
 	defer ctx.Cleanup()
@@ -584,6 +665,35 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestReserveIngress(t *testing.T) {
+	s := &Server{}
+
+	pending, err := s.reserveIngress(60, 100, 200)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), pending)
+
+	// the pending upload counts against the remaining bandwidth and space
+	_, err = s.reserveIngress(50, 100, 200)
+	assert.EqualError(t, err, "store error: not enough bandwidth for piece of 50 bytes")
+	_, err = s.reserveIngress(50, 200, 100)
+	assert.EqualError(t, err, "store error: not enough space for piece of 50 bytes")
+
+	// undeclared uploads reserve nothing, but are limited by pending uploads
+	pending, err = s.reserveIngress(0, 100, 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(60), pending)
+
+	s.releaseIngress(60)
+	pending, err = s.reserveIngress(100, 100, 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), pending)
+	s.releaseIngress(100)
+
+	// nothing is accepted when the node is full
+	_, err = s.reserveIngress(0, 100, 0)
+	assert.EqualError(t, err, "store error: storage node is full")
+}
+
 func newTestServerStruct(t *testing.T) (*Server, func()) {
 	tmp, err := ioutil.TempDir("", "storj-piecestore")
 	if err != nil {
@@ -607,7 +717,7 @@ func newTestServerStruct(t *testing.T) (*Server, func()) {
 		storage:          storage,
 		DB:               psDB,
 		verifier:         verifier,
-		totalAllocated:   math.MaxInt64,
+		space:            NewSpaceManager(zaptest.NewLogger(t), tempDir, psDB, math.MaxInt64, 0),
 		totalBwAllocated: math.MaxInt64,
 	}
 	return server, func() {
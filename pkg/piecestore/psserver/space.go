@@ -0,0 +1,187 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/shirou/gopsutil/disk"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psserver/psdb"
+	"storj.io/storj/pkg/server"
+)
+
+// SpaceError is the error class of allocations the space manager refuses
+var SpaceError = errs.Class("space error")
+
+// SpaceUsage is the disk space of a storage node
+type SpaceUsage struct {
+	Allocated int64 // bytes the operator allocated to pieces
+	Used      int64 // bytes used by stored pieces
+	DiskFree  int64 // bytes free on the disk pieces are stored on
+	Reserved  int64 // bytes kept free on the disk
+	Available int64 // bytes new pieces may use
+}
+
+// Full returns whether no new pieces fit
+func (usage SpaceUsage) Full() bool { return usage.Available <= 0 }
+
+// SpaceManager tracks the disk space used by pieces against the allocated
+// space. It keeps the reserved headroom free on the disk, so that a node
+// never fills the disk it shares with other data. The allocated space can be
+// changed while the node runs.
+type SpaceManager struct {
+	log      *zap.Logger
+	dir      string
+	db       *psdb.DB
+	reserved int64
+
+	mu        sync.Mutex
+	allocated int64
+
+	// diskFree returns the free bytes on the disk of a directory
+	diskFree func(dir string) (int64, error)
+}
+
+// NewSpaceManager returns a space manager of the pieces stored in dir,
+// allowing them to use allocated bytes while keeping reserved bytes free on
+// the disk
+func NewSpaceManager(log *zap.Logger, dir string, db *psdb.DB, allocated, reserved int64) *SpaceManager {
+	return &SpaceManager{
+		log:       log,
+		dir:       dir,
+		db:        db,
+		allocated: allocated,
+		reserved:  reserved,
+		diskFree:  diskFree,
+	}
+}
+
+// diskFree returns the free bytes on the disk of dir, or of its closest
+// parent when it doesn't exist yet
+func diskFree(dir string) (int64, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return 0, err
+	}
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	usage, err := disk.Usage(dir)
+	if err != nil {
+		return 0, err
+	}
+	return int64(usage.Free), nil
+}
+
+// Usage returns the disk space used and available for new pieces
+func (space *SpaceManager) Usage() (SpaceUsage, error) {
+	used, err := space.db.SumTTLSizes()
+	if err != nil {
+		return SpaceUsage{}, ServerError.Wrap(err)
+	}
+	free, err := space.diskFree(space.dir)
+	if err != nil {
+		return SpaceUsage{}, ServerError.Wrap(err)
+	}
+
+	allocated := space.Allocated()
+	usage := SpaceUsage{
+		Allocated: allocated,
+		Used:      used,
+		DiskFree:  free,
+		Reserved:  space.reserved,
+		Available: allocated - used,
+	}
+	if onDisk := free - space.reserved; onDisk < usage.Available {
+		usage.Available = onDisk
+	}
+	if usage.Available < 0 {
+		usage.Available = 0
+	}
+
+	mon.IntVal("space_used").Observe(usage.Used)
+	mon.IntVal("space_available").Observe(usage.Available)
+	return usage, nil
+}
+
+// Allocated returns the space allocated to pieces
+func (space *SpaceManager) Allocated() int64 {
+	space.mu.Lock()
+	defer space.mu.Unlock()
+	return space.allocated
+}
+
+// SetAllocated changes the space allocated to pieces and returns the
+// previous allocation. The allocation can't shrink below the space used by
+// stored pieces, they would have to be deleted to fit.
+func (space *SpaceManager) SetAllocated(allocated int64) (previous int64, err error) {
+	if allocated < 0 {
+		return 0, SpaceError.New("allocated space can't be negative")
+	}
+
+	space.mu.Lock()
+	defer space.mu.Unlock()
+
+	used, err := space.db.SumTTLSizes()
+	if err != nil {
+		return 0, ServerError.Wrap(err)
+	}
+	if allocated < used {
+		return 0, SpaceError.New("can't allocate %s, stored pieces already use %s", memory.Size(allocated), memory.Size(used))
+	}
+
+	previous, space.allocated = space.allocated, allocated
+	space.log.Info("allocated disk space changed",
+		zap.Stringer("from", memory.Size(previous)),
+		zap.Stringer("to", memory.Size(allocated)))
+	mon.IntVal("space_allocated").Observe(allocated)
+	return previous, nil
+}
+
+// SetAllocatedSpace changes the space allocated to pieces for local callers.
+// Satellites learn about the change when the node next advertises its free
+// space.
+func (s *Server) SetAllocatedSpace(ctx context.Context, req *pb.SetAllocatedSpaceRequest) (_ *pb.SetAllocatedSpaceResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !server.IsLocal(ctx) {
+		return nil, status.Errorf(codes.PermissionDenied, "allocated space is only changed by local callers")
+	}
+
+	previous, err := s.space.SetAllocated(req.Allocated)
+	if SpaceError.Has(err) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := s.space.Usage()
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SetAllocatedSpaceResponse{
+		Previous:  previous,
+		Used:      usage.Used,
+		Available: usage.Available,
+	}, nil
+}
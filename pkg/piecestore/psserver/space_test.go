@@ -0,0 +1,98 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/pkg/pb"
+)
+
+func TestSpaceManager(t *testing.T) {
+	s, cleanup := newTestServerStruct(t)
+	defer cleanup()
+
+	free := int64(1000)
+	space := NewSpaceManager(zaptest.NewLogger(t), s.storage.Dir(), s.DB, 500, 100)
+	space.diskFree = func(dir string) (int64, error) { return free, nil }
+	s.space = space
+
+	require.NoError(t, s.DB.AddTTL("11111111111111111111", 0, 200))
+
+	{ // limited by the allocated space
+		usage, err := space.Usage()
+		require.NoError(t, err)
+		assert.Equal(t, SpaceUsage{Allocated: 500, Used: 200, DiskFree: 1000, Reserved: 100, Available: 300}, usage)
+		assert.False(t, usage.Full())
+	}
+
+	{ // limited by the reserved headroom on the disk
+		free = 250
+		usage, err := space.Usage()
+		require.NoError(t, err)
+		assert.Equal(t, int64(150), usage.Available)
+
+		stats, err := s.Stats(context.Background(), &pb.StatsReq{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(200), stats.UsedSpace)
+		assert.Equal(t, int64(150), stats.AvailableSpace)
+	}
+
+	{ // full once the disk is filled up to the headroom
+		free = 50
+		usage, err := space.Usage()
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), usage.Available)
+		assert.True(t, usage.Full())
+	}
+}
+
+func TestSetAllocatedSpace(t *testing.T) {
+	ctx := context.Background()
+	s, cleanup := newTestServerStruct(t)
+	defer cleanup()
+
+	space := NewSpaceManager(zaptest.NewLogger(t), s.storage.Dir(), s.DB, 500, 100)
+	space.diskFree = func(dir string) (int64, error) { return 10000, nil }
+	s.space = space
+
+	require.NoError(t, s.DB.AddTTL("11111111111111111111", 0, 200))
+
+	local := peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}})
+
+	{ // the allocation grows and shrinks while the node runs
+		resp, err := s.SetAllocatedSpace(local, &pb.SetAllocatedSpaceRequest{Allocated: 1000})
+		require.NoError(t, err)
+		assert.Equal(t, &pb.SetAllocatedSpaceResponse{Previous: 500, Used: 200, Available: 800}, resp)
+
+		resp, err = s.SetAllocatedSpace(local, &pb.SetAllocatedSpaceRequest{Allocated: 200})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1000), resp.Previous)
+		assert.Equal(t, int64(0), resp.Available)
+
+		stats, err := s.Stats(ctx, &pb.StatsReq{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), stats.AvailableSpace)
+	}
+
+	{ // the allocation can't shrink below the used space
+		_, err := s.SetAllocatedSpace(local, &pb.SetAllocatedSpaceRequest{Allocated: 199})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Equal(t, int64(200), space.Allocated())
+	}
+
+	{ // only local callers change the allocation
+		_, err := s.SetAllocatedSpace(ctx, &pb.SetAllocatedSpaceRequest{Allocated: 1000})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	}
+}
@@ -5,14 +5,18 @@ package psserver
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io"
+	"os"
 	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
 	"storj.io/storj/pkg/pb"
+	pstore "storj.io/storj/pkg/piecestore"
+	"storj.io/storj/pkg/piecestore/psserver/psdb"
 	"storj.io/storj/pkg/utils"
 )
 
@@ -51,11 +55,19 @@ func (s *Server) Store(reqStream pb.PieceStoreRoutes_StoreServer) (err error) {
 		return StoreError.New("piece ID not specified")
 	}
 
+	if pd.GetPieceSize() < 0 {
+		return StoreError.New("invalid piece size %d", pd.GetPieceSize())
+	}
+
+	if pd.GetOffset() < 0 || (pd.GetPieceSize() > 0 && pd.GetOffset() > pd.GetPieceSize()) {
+		return StoreError.New("invalid offset %d", pd.GetOffset())
+	}
+
 	id, err := getNamespacedPieceID([]byte(pd.GetId()), getNamespace(authorization))
 	if err != nil {
 		return err
 	}
-	total, err := s.storeData(ctx, reqStream, id)
+	total, err := s.storeData(ctx, reqStream, pd.GetId(), id, pd.GetPieceSize(), pd.GetOffset())
 	if err != nil {
 		return err
 	}
@@ -73,45 +85,189 @@ func (s *Server) Store(reqStream pb.PieceStoreRoutes_StoreServer) (err error) {
 	return reqStream.SendAndClose(&pb.PieceStoreSummary{Message: OK, TotalReceived: total})
 }
 
-func (s *Server) storeData(ctx context.Context, stream pb.PieceStoreRoutes_StoreServer, id string) (total int64, err error) {
+// storeData stores the piece received on stream, size is the piece size the
+// uploader declared or 0 if unknown. Uploads are written to a partial piece,
+// which is stored once the upload completes. Uploads interrupted by their
+// stream breaking keep their partial piece, so that they can be resumed at
+// an offset up to the bytes they wrote.
+func (s *Server) storeData(ctx context.Context, stream pb.PieceStoreRoutes_StoreServer, pieceID, id string, size, offset int64) (total int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	// Delete data if we error
-	defer func() {
-		if err != nil && err != io.EOF {
-			if deleteErr := s.deleteByID(id); deleteErr != nil {
-				s.log.Error("Failed on deleteByID in Store", zap.Error(deleteErr))
-			}
-		}
-	}()
-
-	// Initialize file for storing data
-	storeFile, err := s.storage.Writer(id)
+	path, err := s.storage.PiecePath(id)
 	if err != nil {
 		return 0, err
 	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		return 0, StoreError.New("piece %s already exists", pieceID)
+	}
 
-	defer utils.LogClose(storeFile)
+	if offset > 0 {
+		upload, err := s.Partials.Get(id, time.Now())
+		if err != nil {
+			return 0, err
+		}
+		if upload == nil || upload.Written < offset {
+			return 0, StoreError.New("no partial piece to resume at offset %d", offset)
+		}
+		mon.Counter("uploads_resumed").Inc(1)
+	}
 
 	bwUsed, err := s.DB.GetTotalBandwidthBetween(getBeginningOfMonth(), time.Now())
 	if err != nil {
 		return 0, err
 	}
-	spaceUsed, err := s.DB.SumTTLSizes()
+	space, err := s.space.Usage()
 	if err != nil {
 		return 0, err
 	}
 	bwLeft := s.totalBwAllocated - bwUsed
-	spaceLeft := s.totalAllocated - spaceUsed
-	reader := NewStreamReader(s, stream, bwLeft, spaceLeft)
+	spaceLeft := space.Available
 
-	total, err = io.Copy(storeFile, reader)
+	// only the remainder of resumed uploads is received
+	remaining := size
+	if size > 0 {
+		remaining = size - offset
+	}
 
+	// reject declared pieces which don't fit, before any data is received
+	pending, err := s.reserveIngress(remaining, bwLeft, spaceLeft)
+	if err != nil {
+		return 0, err
+	}
+	defer s.releaseIngress(remaining)
+
+	file, err := s.storage.PartialWriter(id, offset)
+	if err != nil {
+		return 0, err
+	}
+
+	// hash the content while storing, so the scrubber can verify it later,
+	// resumed uploads hash what they wrote before first
+	hash := sha256.New()
+	if offset > 0 {
+		if err := hashPartial(s.storage, id, offset, hash); err != nil {
+			s.interrupted(id, file, offset)
+			return 0, err
+		}
+	}
+
+	if offset == 0 && size > 0 {
+		if err := preallocate(file, size); err != nil {
+			s.interrupted(id, file, 0)
+			return 0, StoreError.New("failed to preallocate piece: %v", err)
+		}
+	}
+
+	reader := NewStreamReader(s, stream, bwLeft-pending, spaceLeft-pending)
+	reader.declaredSize = remaining
+	reader.offset = offset
+
+	received, err := io.Copy(io.MultiWriter(file, hash), reader)
+	total = offset + received
 	if err != nil && err != io.EOF {
+		// only uploads cut off by the connection may be resumed, rejected
+		// ones start over
+		if errInterrupted.Has(err) {
+			s.interrupted(id, file, total)
+		} else {
+			s.interrupted(id, file, 0)
+		}
 		return 0, err
 	}
 
+	// declared sizes are upper bounds, drop the preallocated remainder
+	if total < size {
+		if err := file.Truncate(total); err != nil {
+			s.interrupted(id, file, 0)
+			return 0, err
+		}
+	}
+
+	if err := file.Close(); err != nil {
+		s.interrupted(id, file, 0)
+		return 0, err
+	}
+	if err := s.storage.CommitPartial(id); err != nil {
+		return 0, utils.CombineErrors(err, s.storage.DeletePartial(id))
+	}
+	if offset > 0 {
+		if err := s.DB.DeletePartialUpload(id); err != nil {
+			s.log.Warn("failed to forget resumed upload", zap.String("piece", id), zap.Error(err))
+		}
+	}
+
+	// Delete data if we error
+	defer func() {
+		if err != nil {
+			if deleteErr := s.deleteByID(id); deleteErr != nil {
+				s.log.Error("Failed on deleteByID in Store", zap.Error(deleteErr))
+			}
+		}
+	}()
+
 	err = s.DB.WriteBandwidthAllocToDB(reader.bandwidthAllocation)
+	if err != nil {
+		return total, err
+	}
+
+	err = s.DB.AddUsage(reader.satelliteID, reader.action, total, time.Now())
+	if err != nil {
+		return total, err
+	}
+
+	err = s.DB.AddPieceHash(&psdb.PieceHash{
+		ID:          id,
+		PieceID:     pieceID,
+		SatelliteID: reader.satelliteID,
+		Hash:        hash.Sum(nil),
+		Verified:    time.Now().Unix(),
+	})
 
 	return total, err
 }
+
+// hashPartial hashes the first offset bytes of the partial piece
+func hashPartial(storage *pstore.Storage, id string, offset int64, hash io.Writer) error {
+	path, err := storage.PartialPath(id)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer utils.LogClose(file)
+
+	_, err = io.CopyN(hash, file, offset)
+	return err
+}
+
+// reserveIngress reserves size bytes of the remaining bandwidth and space for
+// an upload and returns the bytes reserved by the other uploads in progress.
+// Uploads are rejected when no space is left, whether their size was declared
+// or not.
+func (s *Server) reserveIngress(size, bwLeft, spaceLeft int64) (pending int64, err error) {
+	s.ingressMu.Lock()
+	defer s.ingressMu.Unlock()
+
+	pending = s.pendingIngress
+	if spaceLeft-pending <= 0 {
+		mon.Counter("uploads_rejected_full").Inc(1)
+		return pending, StoreError.New("storage node is full")
+	}
+	if size > bwLeft-pending {
+		return pending, StoreError.New("not enough bandwidth for piece of %d bytes", size)
+	}
+	if size > spaceLeft-pending {
+		return pending, StoreError.New("not enough space for piece of %d bytes", size)
+	}
+	s.pendingIngress += size
+	return pending, nil
+}
+
+// releaseIngress releases the bytes reserved by reserveIngress
+func (s *Server) releaseIngress(size int64) {
+	s.ingressMu.Lock()
+	defer s.ingressMu.Unlock()
+	s.pendingIngress -= size
+}
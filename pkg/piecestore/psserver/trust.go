@@ -0,0 +1,279 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+// TrustError is the error class for fetching and verifying trusted satellite lists
+var TrustError = errs.Class("trust error")
+
+const (
+	// maxTrustListSize is the size of the largest trusted satellite list fetched
+	maxTrustListSize = memory.MiB
+	// trustFetchTimeout is how long fetching a single list may take
+	trustFetchTimeout = time.Minute
+)
+
+// TrustConfig configures which satellites the storage node accepts uploads from
+type TrustConfig struct {
+	Sources    string        `user:"true" help:"comma separated URLs of signed lists of trusted satellites, when neither sources nor satellites are configured every satellite is trusted" default:""`
+	Signers    string        `help:"comma separated IDs of the nodes allowed to sign trusted satellite lists" default:""`
+	Satellites string        `user:"true" help:"comma separated IDs of satellites trusted in addition to the listed ones" default:""`
+	Exclusions string        `user:"true" help:"comma separated IDs of satellites which are never trusted, even when listed" default:""`
+	Interval   time.Duration `help:"how frequently the trusted satellite lists are fetched" default:"6h0m0s"`
+}
+
+// Trust decides which satellites the storage node accepts uploads from. It
+// merges the satellites of signed lists fetched from the configured sources
+// with the static satellites, excluded satellites are never trusted.
+type Trust struct {
+	log    *zap.Logger
+	config TrustConfig
+	client *http.Client
+
+	sources    []string
+	signers    map[storj.NodeID]bool
+	static     map[storj.NodeID]bool
+	excluded   map[storj.NodeID]bool
+	restricted bool
+
+	mu     sync.RWMutex
+	lists  map[string]*pb.SignedSatelliteList
+	listed map[storj.NodeID]bool
+}
+
+// NewTrust creates a new trust subsystem from the config
+func NewTrust(log *zap.Logger, config TrustConfig) (*Trust, error) {
+	trust := &Trust{
+		log:    log,
+		config: config,
+		client: &http.Client{Timeout: trustFetchTimeout},
+		lists:  make(map[string]*pb.SignedSatelliteList),
+		listed: make(map[storj.NodeID]bool),
+	}
+
+	for _, source := range strings.Split(config.Sources, ",") {
+		if source = strings.TrimSpace(source); source != "" {
+			trust.sources = append(trust.sources, source)
+		}
+	}
+
+	var err error
+	if trust.signers, err = parseNodeIDSet(config.Signers); err != nil {
+		return nil, err
+	}
+	if trust.static, err = parseNodeIDSet(config.Satellites); err != nil {
+		return nil, err
+	}
+	if trust.excluded, err = parseNodeIDSet(config.Exclusions); err != nil {
+		return nil, err
+	}
+
+	if len(trust.sources) > 0 && len(trust.signers) == 0 {
+		return nil, TrustError.New("trusted satellite lists require signers")
+	}
+	trust.restricted = len(trust.sources) > 0 || len(trust.static) > 0
+	return trust, nil
+}
+
+// Trusted returns whether uploads for the satellite are accepted. A nil Trust
+// trusts every satellite.
+func (trust *Trust) Trusted(satelliteID storj.NodeID) bool {
+	if trust == nil {
+		return true
+	}
+	switch {
+	case trust.excluded[satelliteID]:
+		return false
+	case !trust.restricted, trust.static[satelliteID]:
+		return true
+	}
+
+	trust.mu.RLock()
+	defer trust.mu.RUnlock()
+	return trust.listed[satelliteID]
+}
+
+// Run fetches the trusted satellite lists every interval, until the context
+// is canceled
+func (trust *Trust) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(trust.sources) == 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(trust.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := trust.Refresh(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			trust.log.Warn("fetching trusted satellite lists failed", zap.Error(err))
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Refresh fetches the lists of all sources and merges them. Sources which
+// fail keep contributing the list they served before.
+func (trust *Trust) Refresh(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var errlist errs.Group
+	fetched := make(map[string]*pb.SignedSatelliteList, len(trust.sources))
+	for _, source := range trust.sources {
+		list, err := trust.fetch(ctx, source)
+		if err != nil {
+			errlist.Add(TrustError.New("%s: %v", source, err))
+			continue
+		}
+		fetched[source] = list
+	}
+
+	trust.mu.Lock()
+	defer trust.mu.Unlock()
+
+	listed := make(map[storj.NodeID]bool)
+	for _, source := range trust.sources {
+		list, ok := fetched[source]
+		previous := trust.lists[source]
+		// an older list, e.g. a replayed one, doesn't replace a newer one
+		if !ok || (previous != nil && list.SignedAt < previous.SignedAt) {
+			list = previous
+		}
+		if list == nil {
+			continue
+		}
+
+		trust.lists[source] = list
+		for _, id := range list.SatelliteIds {
+			listed[id] = true
+		}
+	}
+	trust.listed = listed
+	mon.IntVal("trusted_satellites_listed").Observe(int64(len(listed)))
+
+	return errlist.Err()
+}
+
+// fetch downloads and verifies the list of a source
+func (trust *Trust) fetch(ctx context.Context, source string) (_ *pb.SignedSatelliteList, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	req, err := http.NewRequest(http.MethodGet, source, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := trust.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, resp.Body.Close()) }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, TrustError.New("unexpected status %s", resp.Status)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxTrustListSize.Int64()+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxTrustListSize.Int64() {
+		return nil, TrustError.New("list exceeds %s", maxTrustListSize)
+	}
+
+	list := &pb.SignedSatelliteList{}
+	if err := proto.Unmarshal(data, list); err != nil {
+		return nil, err
+	}
+	return list, VerifySatelliteList(trust.signers, list)
+}
+
+// SignSatelliteList signs a list of trusted satellites with the identity of
+// its signer
+func SignSatelliteList(ident *identity.FullIdentity, satellites storj.NodeIDList, signedAt time.Time) (*pb.SignedSatelliteList, error) {
+	list := &pb.SignedSatelliteList{
+		SatelliteIds: satellites,
+		SignedAt:     signedAt.Unix(),
+		Chain:        [][]byte{ident.Leaf.Raw, ident.CA.Raw},
+	}
+
+	data, err := satelliteListData(list)
+	if err != nil {
+		return nil, err
+	}
+	list.Signature, err = auth.GenerateSignature(data, ident)
+	if err != nil {
+		return nil, TrustError.Wrap(err)
+	}
+	return list, nil
+}
+
+// VerifySatelliteList checks that the list was signed by one of the signers
+func VerifySatelliteList(signers map[storj.NodeID]bool, list *pb.SignedSatelliteList) error {
+	data, err := satelliteListData(list)
+	if err != nil {
+		return err
+	}
+	signer, err := auth.VerifyChainSignature(data, list.Signature, list.Chain)
+	if err != nil {
+		return TrustError.Wrap(err)
+	}
+	if !signers[signer] {
+		return TrustError.New("list signed by %s, which isn't a trusted signer", signer)
+	}
+	return nil
+}
+
+// satelliteListData returns the signed bytes of the list
+func satelliteListData(list *pb.SignedSatelliteList) ([]byte, error) {
+	data, err := proto.Marshal(&pb.SignedSatelliteList{
+		SatelliteIds: list.SatelliteIds,
+		SignedAt:     list.SignedAt,
+	})
+	return data, TrustError.Wrap(err)
+}
+
+// parseNodeIDSet parses a comma separated list of node IDs
+func parseNodeIDSet(s string) (map[storj.NodeID]bool, error) {
+	ids := make(map[storj.NodeID]bool)
+	for _, str := range strings.Split(s, ",") {
+		if str = strings.TrimSpace(str); str == "" {
+			continue
+		}
+		id, err := storj.NodeIDFromString(str)
+		if err != nil {
+			return nil, TrustError.New("invalid node id %q: %v", str, err)
+		}
+		ids[id] = true
+	}
+	return ids, nil
+}
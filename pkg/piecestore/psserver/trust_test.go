@@ -0,0 +1,156 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestTrust(t *testing.T) {
+	ctx := context.Background()
+
+	signer, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+	other, err := testidentity.NewTestIdentity(ctx)
+	require.NoError(t, err)
+
+	listed := teststorj.NodeIDFromString("listed")
+	static := teststorj.NodeIDFromString("static")
+	excluded := teststorj.NodeIDFromString("excluded")
+	unknown := teststorj.NodeIDFromString("unknown")
+
+	var mu sync.Mutex
+	var served []byte
+	serve := func(list *pb.SignedSatelliteList) {
+		data, err := proto.Marshal(list)
+		require.NoError(t, err)
+		mu.Lock()
+		served = data
+		mu.Unlock()
+	}
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if served == nil {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(served)
+	}))
+	defer source.Close()
+
+	trust, err := NewTrust(zaptest.NewLogger(t), TrustConfig{
+		Sources:    source.URL,
+		Signers:    signer.ID.String(),
+		Satellites: static.String(),
+		Exclusions: excluded.String(),
+	})
+	require.NoError(t, err)
+
+	{ // static satellites are trusted before any list is fetched
+		assert.Error(t, trust.Refresh(ctx))
+		assert.True(t, trust.Trusted(static))
+		assert.False(t, trust.Trusted(listed))
+	}
+
+	now := time.Now()
+	{ // listed satellites are trusted, except for excluded ones
+		list, err := SignSatelliteList(signer, storj.NodeIDList{listed, excluded}, now)
+		require.NoError(t, err)
+		serve(list)
+
+		require.NoError(t, trust.Refresh(ctx))
+		assert.True(t, trust.Trusted(listed))
+		assert.True(t, trust.Trusted(static))
+		assert.False(t, trust.Trusted(excluded))
+		assert.False(t, trust.Trusted(unknown))
+	}
+
+	{ // invalid and older lists don't replace the last valid one
+		forged, err := SignSatelliteList(other, storj.NodeIDList{unknown}, now.Add(time.Hour))
+		require.NoError(t, err)
+		serve(forged)
+		assert.Error(t, trust.Refresh(ctx))
+
+		older, err := SignSatelliteList(signer, storj.NodeIDList{unknown}, now.Add(-time.Hour))
+		require.NoError(t, err)
+		serve(older)
+		assert.NoError(t, trust.Refresh(ctx))
+
+		tampered, err := SignSatelliteList(signer, storj.NodeIDList{listed}, now.Add(time.Hour))
+		require.NoError(t, err)
+		tampered.SatelliteIds = append(tampered.SatelliteIds, unknown)
+		serve(tampered)
+		assert.Error(t, trust.Refresh(ctx))
+
+		assert.True(t, trust.Trusted(listed))
+		assert.False(t, trust.Trusted(unknown))
+	}
+
+	{ // newer lists replace older ones
+		newer, err := SignSatelliteList(signer, storj.NodeIDList{unknown}, now.Add(time.Hour))
+		require.NoError(t, err)
+		serve(newer)
+		require.NoError(t, trust.Refresh(ctx))
+		assert.False(t, trust.Trusted(listed))
+		assert.True(t, trust.Trusted(unknown))
+	}
+}
+
+func TestTrustConfig(t *testing.T) {
+	var nilTrust *Trust
+	assert.True(t, nilTrust.Trusted(teststorj.NodeIDFromString("any")))
+
+	excluded := teststorj.NodeIDFromString("excluded")
+	trust, err := NewTrust(zaptest.NewLogger(t), TrustConfig{Exclusions: excluded.String()})
+	require.NoError(t, err)
+	assert.True(t, trust.Trusted(teststorj.NodeIDFromString("any")))
+	assert.False(t, trust.Trusted(excluded))
+
+	_, err = NewTrust(zaptest.NewLogger(t), TrustConfig{Sources: "https://example.com/satellites"})
+	assert.True(t, TrustError.Has(err))
+
+	_, err = NewTrust(zaptest.NewLogger(t), TrustConfig{Satellites: "not a node id"})
+	assert.True(t, TrustError.Has(err))
+}
+
+func TestUntrustedAllocation(t *testing.T) {
+	s, cleanup := newTestServerStruct(t)
+	defer cleanup()
+
+	trusted := teststorj.NodeIDFromString("trusted")
+	untrusted := teststorj.NodeIDFromString("untrusted")
+
+	var err error
+	s.Trust, err = NewTrust(zaptest.NewLogger(t), TrustConfig{Satellites: trusted.String()})
+	require.NoError(t, err)
+
+	allocation := func(satellite storj.NodeID, action pb.PayerBandwidthAllocation_Action) *pb.PayerBandwidthAllocation_Data {
+		return &pb.PayerBandwidthAllocation_Data{
+			SatelliteId: satellite,
+			UplinkId:    teststorj.NodeIDFromString("uplink"),
+			Action:      action,
+		}
+	}
+
+	assert.NoError(t, s.verifyPayerAllocation(allocation(trusted, pb.PayerBandwidthAllocation_PUT), "PUT"))
+	assert.Error(t, s.verifyPayerAllocation(allocation(untrusted, pb.PayerBandwidthAllocation_PUT), "PUT"))
+	// pieces already stored can still be retrieved
+	assert.NoError(t, s.verifyPayerAllocation(allocation(untrusted, pb.PayerBandwidthAllocation_GET), "GET"))
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"sort"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/server"
+	"storj.io/storj/pkg/storj"
+)
+
+// Usage returns the hourly bandwidth and disk usage per satellite for
+// rendering graphs or exporting to monitoring. It is only served to callers
+// on the same host, as it reveals which satellites the node works for.
+func (s *Server) Usage(ctx context.Context, req *pb.UsageRequest) (_ *pb.UsageResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !server.IsLocal(ctx) {
+		return nil, status.Errorf(codes.PermissionDenied, "usage is only served to local callers")
+	}
+
+	now := time.Now()
+	to := now
+	if req.ToUnixSec != 0 {
+		to = time.Unix(req.ToUnixSec, 0)
+	}
+
+	// the current hour shows the disk usage as of now
+	if err := s.DB.RecordDiskUsage(now); err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+
+	usage, err := s.DB.GetUsage(req.SatelliteId, time.Unix(req.FromUnixSec, 0), to)
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+
+	satellites := make(storj.NodeIDList, 0, len(usage))
+	for satelliteID := range usage {
+		satellites = append(satellites, satelliteID)
+	}
+	sort.Sort(satellites)
+
+	resp := &pb.UsageResponse{}
+	for _, satelliteID := range satellites {
+		satelliteUsage := &pb.SatelliteUsage{SatelliteId: satelliteID}
+		for _, point := range usage[satelliteID] {
+			satelliteUsage.Points = append(satelliteUsage.Points, &pb.UsagePoint{
+				HourUnixSec:   point.Hour.Unix(),
+				Ingress:       point.Bandwidth[pb.PayerBandwidthAllocation_PUT],
+				IngressRepair: point.Bandwidth[pb.PayerBandwidthAllocation_PUT_REPAIR],
+				Egress:        point.Bandwidth[pb.PayerBandwidthAllocation_GET],
+				EgressAudit:   point.Bandwidth[pb.PayerBandwidthAllocation_GET_AUDIT],
+				EgressRepair:  point.Bandwidth[pb.PayerBandwidthAllocation_GET_REPAIR],
+				DiskUsed:      point.DiskUsed,
+			})
+		}
+		resp.Satellites = append(resp.Satellites, satelliteUsage)
+	}
+	return resp, nil
+}
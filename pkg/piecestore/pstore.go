@@ -16,14 +16,24 @@ import (
 
 // Storage stores piecestore pieces
 type Storage struct {
-	dir string
+	dir   string
+	cache *PieceCache
 }
 
 // NewStorage creates database for storing pieces
 func NewStorage(dir string) *Storage {
-	return &Storage{dir}
+	return &Storage{dir: dir}
 }
 
+// SetCache sets the cache of frequently retrieved pieces, nil disables
+// caching. Must be called before the storage is used.
+func (storage *Storage) SetCache(cache *PieceCache) {
+	storage.cache = cache
+}
+
+// Dir returns the directory pieces are stored in
+func (storage *Storage) Dir() string { return storage.dir }
+
 // Close closes resources
 func (storage *Storage) Close() error { return nil }
 
@@ -71,30 +81,66 @@ func (storage *Storage) Reader(ctx context.Context, pieceID string, offset int64
 		return nil, err
 	}
 
+	if data, ok := storage.cache.Get(pieceID); ok {
+		offset, length, err := clampRange(int64(len(data)), offset, length)
+		if err != nil {
+			return nil, err
+		}
+		return cachedReader(data, offset, length), nil
+	}
+
 	info, err := os.Stat(path)
 	if err != nil {
 		return nil, err
 	}
 
-	if offset >= info.Size() || offset < 0 {
-		return nil, Error.New("invalid offset: %v", offset)
-	}
-
-	if length <= -1 {
-		length = info.Size()
+	offset, length, err = clampRange(info.Size(), offset, length)
+	if err != nil {
+		return nil, err
 	}
 
-	// If trying to read past the end of the file, just read to the end
-	if info.Size() < offset+length {
-		length = info.Size() - offset
+	rr, err := ranger.FileRanger(path)
+	if err != nil {
+		return nil, err
 	}
 
-	rr, err := ranger.FileRanger(path)
+	reader, err := rr.Range(ctx, offset, length)
 	if err != nil {
 		return nil, err
 	}
 
-	return rr.Range(ctx, offset, length)
+	// only whole pieces are cached, reads of parts are e.g. audits
+	if offset == 0 && length == info.Size() && storage.cache.Fits(length) {
+		return &cachingReader{
+			ReadCloser: reader,
+			add: func(data []byte) {
+				// the piece may have been deleted while it was read
+				if _, err := os.Stat(path); err == nil {
+					storage.cache.Add(pieceID, data)
+				}
+			},
+			size: length,
+			data: make([]byte, 0, length),
+		}, nil
+	}
+	return reader, nil
+}
+
+// clampRange checks offset and limits length to the size of the piece
+func clampRange(size, offset, length int64) (int64, int64, error) {
+	if offset >= size || offset < 0 {
+		return 0, 0, Error.New("invalid offset: %v", offset)
+	}
+
+	if length <= -1 {
+		length = size
+	}
+
+	// If trying to read past the end of the file, just read to the end
+	if size < offset+length {
+		length = size - offset
+	}
+	return offset, length, nil
 }
 
 // Delete deletes piece from storage
@@ -104,9 +150,79 @@ func (storage *Storage) Delete(pieceID string) error {
 		return err
 	}
 
+	storage.cache.Remove(pieceID)
+
 	err = os.Remove(path)
 	if os.IsNotExist(err) {
 		err = nil
 	}
 	return err
 }
+
+// Quarantine moves a piece out of the storage, keeping it for inspection, so it won't be served anymore
+func (storage *Storage) Quarantine(pieceID string) error {
+	return storage.moveOut(pieceID, "quarantine")
+}
+
+// Trash moves a piece which is garbage collected out of the storage, so it
+// can be restored when it was collected by mistake
+func (storage *Storage) Trash(pieceID string) error {
+	return storage.moveOut(pieceID, "trash")
+}
+
+// Restore moves a trashed piece back to the stored pieces
+func (storage *Storage) Restore(pieceID string) error {
+	path, err := storage.PiecePath(pieceID)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return Error.Wrap(err)
+	}
+	return Error.Wrap(os.Rename(filepath.Join(storage.dir, "trash", pieceID), path))
+}
+
+// DeleteTrashed deletes a trashed piece for good
+func (storage *Storage) DeleteTrashed(pieceID string) error {
+	return storage.deleteMovedOut(pieceID, "trash")
+}
+
+// DeleteQuarantined deletes a quarantined piece for good
+func (storage *Storage) DeleteQuarantined(pieceID string) error {
+	return storage.deleteMovedOut(pieceID, "quarantine")
+}
+
+// deleteMovedOut deletes a piece moved to the directory name
+func (storage *Storage) deleteMovedOut(pieceID, name string) error {
+	if len(pieceID) < IDLength {
+		return Error.New("invalid id length")
+	}
+
+	err := os.Remove(filepath.Join(storage.dir, name, pieceID))
+	if os.IsNotExist(err) {
+		err = nil
+	}
+	return Error.Wrap(err)
+}
+
+// moveOut moves a piece to the directory name next to the stored pieces
+func (storage *Storage) moveOut(pieceID, name string) error {
+	path, err := storage.PiecePath(pieceID)
+	if err != nil {
+		return err
+	}
+
+	storage.cache.Remove(pieceID)
+
+	dir := filepath.Join(storage.dir, name)
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return Error.Wrap(err)
+	}
+
+	err = os.Rename(path, filepath.Join(dir, pieceID))
+	if os.IsNotExist(err) {
+		err = nil
+	}
+	return Error.Wrap(err)
+}
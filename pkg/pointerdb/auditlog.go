@@ -0,0 +1,256 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/watchdog"
+	"storj.io/storj/storage"
+)
+
+// AuditLog is an append-only log of the mutations of pointers, kept in a
+// store separate from the pointers. Mutations are appended before they are
+// applied, so a mutation which then fails leaves an entry without effect;
+// the previous hash of the next mutation of the path tells whether it took
+// effect. Entries are keyed by the time they were logged, so a log must not
+// be shared by several satellites.
+type AuditLog struct {
+	db storage.KeyValueStore
+
+	mu     sync.Mutex
+	loaded bool  // whether last was read from db
+	last   int64 // unix nanoseconds of the last key, so keys are unique
+}
+
+// NewAuditLog returns an audit log appending to db
+func NewAuditLog(db storage.KeyValueStore) *AuditLog {
+	return &AuditLog{db: db}
+}
+
+// auditKey returns the key of the entry logged at unix nanoseconds
+func auditKey(nanos int64) storage.Key {
+	return storage.Key(fmt.Sprintf("%020d", nanos))
+}
+
+// hashValue returns the hash of a stored pointer recorded in the audit log,
+// nil when there is no pointer
+func hashValue(value []byte) []byte {
+	if value == nil {
+		return nil
+	}
+	hash := sha256.Sum256(value)
+	return hash[:]
+}
+
+// Append appends a mutation, its time is set when it's missing
+func (log *AuditLog) Append(mutation *pb.PointerMutation) error {
+	log.mu.Lock()
+	defer log.mu.Unlock()
+
+	if !log.loaded {
+		if err := log.loadLast(); err != nil {
+			return err
+		}
+	}
+
+	if mutation.Time == nil {
+		mutation.Time = ptypes.TimestampNow()
+	}
+	logged, err := ptypes.Timestamp(mutation.Time)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	// entries are ordered by their time, which is unique
+	nanos := logged.UnixNano()
+	if nanos <= log.last {
+		nanos = log.last + 1
+		mutation.Time, err = ptypes.TimestampProto(time.Unix(0, nanos))
+		if err != nil {
+			return Error.Wrap(err)
+		}
+	}
+
+	value, err := proto.Marshal(mutation)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if err := log.db.Put(auditKey(nanos), value); err != nil {
+		return err
+	}
+	log.last = nanos
+	return nil
+}
+
+// loadLast reads the time of the last entry of a log appended to before,
+// so that entries aren't overwritten when the clock went back since
+func (log *AuditLog) loadLast() error {
+	err := log.db.Iterate(storage.IterateOptions{Recurse: true, Reverse: true},
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			if !it.Next(&item) {
+				return nil
+			}
+			last, err := strconv.ParseInt(item.Key.String(), 10, 64)
+			if err != nil {
+				return Error.New("invalid audit log key %q", item.Key)
+			}
+			log.last = last
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+	log.loaded = true
+	return nil
+}
+
+// Iterate calls fn with the mutations logged since from, in the order they
+// were logged
+func (log *AuditLog) Iterate(from time.Time, fn func(mutation *pb.PointerMutation) error) error {
+	return log.db.Iterate(storage.IterateOptions{First: auditKey(from.UnixNano()), Recurse: true},
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			for it.Next(&item) {
+				mutation := &pb.PointerMutation{}
+				if err := proto.Unmarshal(item.Value, mutation); err != nil {
+					return Error.New("error unmarshaling mutation %q: %v", item.Key, err)
+				}
+				if err := fn(mutation); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+// Compact drops the pointers put by the mutations logged before
+// compactBefore, keeping their hashes and callers, and deletes the mutations
+// logged before deleteBefore
+func (log *AuditLog) Compact(compactBefore, deleteBefore time.Time) (compacted, deleted int, err error) {
+	for {
+		var batch storage.Batch
+		err := log.db.Iterate(storage.IterateOptions{Recurse: true},
+			func(it storage.Iterator) error {
+				var item storage.ListItem
+				for it.Next(&item) && len(batch.Puts)+len(batch.Deletes) < storage.LookupLimit {
+					mutation := &pb.PointerMutation{}
+					if err := proto.Unmarshal(item.Value, mutation); err != nil {
+						return Error.New("error unmarshaling mutation %q: %v", item.Key, err)
+					}
+					logged, err := ptypes.Timestamp(mutation.Time)
+					if err != nil {
+						return Error.New("invalid time of mutation %q: %v", item.Key, err)
+					}
+
+					switch {
+					case logged.Before(deleteBefore):
+						batch.Deletes = append(batch.Deletes, storage.CloneKey(item.Key))
+					case logged.Before(compactBefore):
+						if mutation.Value == nil {
+							continue
+						}
+						mutation.Value = nil
+						value, err := proto.Marshal(mutation)
+						if err != nil {
+							return Error.Wrap(err)
+						}
+						batch.Puts = append(batch.Puts, storage.ListItem{Key: storage.CloneKey(item.Key), Value: value})
+					default:
+						// entries are logged in order, the rest is newer
+						return nil
+					}
+				}
+				return nil
+			})
+		if err != nil {
+			return compacted, deleted, err
+		}
+		if len(batch.Puts)+len(batch.Deletes) == 0 {
+			return compacted, deleted, nil
+		}
+
+		if err := log.db.ApplyBatch(batch); err != nil {
+			return compacted, deleted, err
+		}
+		compacted += len(batch.Puts)
+		deleted += len(batch.Deletes)
+	}
+}
+
+// Close closes the store of the log
+func (log *AuditLog) Close() error { return log.db.Close() }
+
+// AuditLogCompactor compacts and rotates the audit log: the pointers put are
+// dropped from entries older than compactAfter and entries older than
+// retention are deleted
+type AuditLogCompactor struct {
+	log          *zap.Logger
+	auditLog     *AuditLog
+	compactAfter time.Duration
+	retention    time.Duration
+	interval     time.Duration
+	loop         *watchdog.Loop
+}
+
+// NewAuditLogCompactor creates a compactor of auditLog, a retention of 0
+// keeps entries forever
+func NewAuditLogCompactor(log *zap.Logger, auditLog *AuditLog, compactAfter, retention, interval time.Duration, loop *watchdog.Loop) *AuditLogCompactor {
+	return &AuditLogCompactor{
+		log:          log,
+		auditLog:     auditLog,
+		compactAfter: compactAfter,
+		retention:    retention,
+		interval:     interval,
+		loop:         loop,
+	}
+}
+
+// Run compacts the audit log every interval, until the context is canceled
+func (compactor *AuditLogCompactor) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ticker := time.NewTicker(compactor.interval)
+	defer ticker.Stop()
+
+	for {
+		err := compactor.Compact(ctx, time.Now())
+		if err != nil {
+			compactor.log.Error("compacting the audit log failed", zap.Error(err))
+		}
+		compactor.loop.Cycle(err)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Compact compacts the entries which are old by now
+func (compactor *AuditLogCompactor) Compact(ctx context.Context, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var deleteBefore time.Time
+	if compactor.retention > 0 {
+		deleteBefore = now.Add(-compactor.retention)
+	}
+
+	compacted, deleted, err := compactor.auditLog.Compact(now.Add(-compactor.compactAfter), deleteBefore)
+	mon.IntVal("audit_log_compacted").Observe(int64(compacted))
+	mon.IntVal("audit_log_deleted").Observe(int64(deleted))
+	return err
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb_test
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/teststore"
+)
+
+func TestAuditLog(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	db := teststore.New()
+	auditLog := pointerdb.NewAuditLog(teststore.New())
+	defer ctx.Check(auditLog.Close)
+
+	service := pointerdb.NewService(zaptest.NewLogger(t), db)
+	service.SetAuditLog(auditLog)
+	uplink := service.WithCaller("uplink")
+
+	start := time.Now()
+	pointer := func() *pb.Pointer { return &pb.Pointer{Type: pb.Pointer_INLINE, InlineSegment: []byte("data")} }
+	require.NoError(t, uplink.Put("l/bucket/a", pointer()))
+	require.NoError(t, uplink.Put("l/bucket/a", pointer()))
+	require.NoError(t, uplink.Delete("l/bucket/a"))
+	require.NoError(t, service.Undelete("l/bucket/a"))
+	require.NoError(t, uplink.PutAll([]string{"l/bucket/a", "l/bucket/b"}, []*pb.Pointer{pointer(), pointer()}))
+	_, err := uplink.DeleteAll([]string{"l/bucket/b", "l/bucket/c"})
+	require.NoError(t, err)
+
+	var mutations []*pb.PointerMutation
+	require.NoError(t, auditLog.Iterate(start, func(mutation *pb.PointerMutation) error {
+		mutations = append(mutations, mutation)
+		return nil
+	}))
+
+	type entry struct {
+		operation pb.PointerMutation_Operation
+		path      string
+		caller    string
+	}
+	var entries []entry
+	for _, mutation := range mutations {
+		entries = append(entries, entry{mutation.Operation, mutation.Path, mutation.Caller})
+	}
+	assert.Equal(t, []entry{
+		{pb.PointerMutation_PUT, "l/bucket/a", "uplink"},
+		{pb.PointerMutation_PUT, "l/bucket/a", "uplink"},
+		{pb.PointerMutation_DELETE, "l/bucket/a", "uplink"},
+		{pb.PointerMutation_UNDELETE, "l/bucket/a", ""},
+		{pb.PointerMutation_PUT, "l/bucket/a", "uplink"},
+		{pb.PointerMutation_PUT, "l/bucket/b", "uplink"},
+		{pb.PointerMutation_DELETE, "l/bucket/b", "uplink"},
+	}, entries)
+
+	// every mutation of a path records the hash of the pointer it replaced
+	hash := func(value []byte) []byte {
+		sum := sha256.Sum256(value)
+		return sum[:]
+	}
+	assert.Empty(t, mutations[0].PreviousHash)
+	assert.Equal(t, hash(mutations[0].Value), mutations[1].PreviousHash)
+	assert.Equal(t, hash(mutations[1].Value), mutations[2].PreviousHash)
+	assert.Empty(t, mutations[2].Value)
+	assert.Empty(t, mutations[3].PreviousHash)
+	assert.Equal(t, mutations[1].Value, mutations[3].Value)
+	assert.Equal(t, hash(mutations[3].Value), mutations[4].PreviousHash)
+
+	stored, err := db.Get(storage.Key("l/bucket/a"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte(stored), mutations[4].Value)
+
+	{ // old entries are compacted and then deleted
+		logged := func(index int) time.Time {
+			at, err := ptypes.Timestamp(mutations[index].Time)
+			require.NoError(t, err)
+			return at
+		}
+
+		compacted, deleted, err := auditLog.Compact(logged(2), time.Time{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, compacted)
+		assert.Equal(t, 0, deleted)
+
+		compacted, deleted, err = auditLog.Compact(logged(5), logged(4))
+		require.NoError(t, err)
+		assert.Equal(t, 1, compacted)
+		assert.Equal(t, 4, deleted)
+
+		var remaining []*pb.PointerMutation
+		require.NoError(t, auditLog.Iterate(time.Time{}, func(mutation *pb.PointerMutation) error {
+			remaining = append(remaining, mutation)
+			return nil
+		}))
+		require.Len(t, remaining, 3)
+		assert.Empty(t, remaining[0].Value)
+		assert.Equal(t, mutations[4].PreviousHash, remaining[0].PreviousHash)
+		assert.NotEmpty(t, remaining[1].Value)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storage/meta"
+	"storj.io/storj/storage"
+)
+
+// GetAll gets the pointers of paths, the pointer of a missing path is nil
+func (s *Service) GetAll(paths []string) (pointers []*pb.Pointer, err error) {
+	return s.GetAllFields(paths, meta.All)
+}
+
+// GetAllFields gets only the fields selected by metaFlags of the pointers of
+// paths, the pointer of a missing path is nil
+func (s *Service) GetAllFields(paths []string, metaFlags uint32) (pointers []*pb.Pointer, err error) {
+	values, err := s.DB.GetAll(pathKeys(paths))
+	if err != nil {
+		return nil, err
+	}
+
+	pointers = make([]*pb.Pointer, len(values))
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+		pointers[i] = &pb.Pointer{}
+		if err := UnmarshalPointerFields(value, pointers[i], metaFlags); err != nil {
+			return nil, Error.New("error unmarshaling pointer %q: %v", paths[i], err)
+		}
+	}
+	return pointers, nil
+}
+
+// PutAll puts pointers[i] at paths[i] in a single transaction, either all
+// pointers are put or none. Unlike Put it doesn't guard against concurrent
+// puts of the same paths, the last put wins.
+func (s *Service) PutAll(paths []string, pointers []*pb.Pointer) (err error) {
+	if len(paths) != len(pointers) {
+		return Error.New("got %d paths for %d pointers", len(paths), len(pointers))
+	}
+	for _, path := range paths {
+		if !IsPointerKey(storage.Key(path)) {
+			return Error.New("invalid path %q", path)
+		}
+	}
+
+	defer s.invalidateAll(paths)
+
+	old, err := s.DB.GetAll(pathKeys(paths))
+	if err != nil {
+		return err
+	}
+
+	now := ptypes.TimestampNow()
+	batch := storage.Batch{Puts: make(storage.Items, 0, len(paths))}
+	for i, pointer := range pointers {
+		var version int64
+		if old[i] != nil {
+			current := &pb.Pointer{}
+			if err := UnmarshalPointer(old[i], current); err != nil {
+				return Error.New("error unmarshaling pointer %q: %v", paths[i], err)
+			}
+			version = current.Version
+		}
+
+		pointer.CreationDate = now
+		pointer.Version = version + 1
+
+		pointerBytes, err := s.marshalPointer(pointer)
+		if err != nil {
+			return err
+		}
+		if err := s.logMutation(pb.PointerMutation_PUT, paths[i], old[i], pointerBytes); err != nil {
+			return err
+		}
+		batch.Puts = append(batch.Puts, storage.ListItem{Key: storage.Key(paths[i]), Value: pointerBytes})
+		if expiresAt, ok := pointerExpiration(pointer); ok && s.expirationIndex {
+			batch.Puts = append(batch.Puts, storage.ListItem{Key: expiresKey(paths[i], expiresAt), Value: storage.Value(paths[i])})
+		}
+	}
+	return s.DB.ApplyBatch(batch)
+}
+
+// DeleteAll marks the pointers at paths deleted in a single transaction, like
+// Delete does for a single path. The missing paths are returned.
+func (s *Service) DeleteAll(paths []string) (notFound []string, err error) {
+	defer s.invalidateAll(paths)
+
+	keys := pathKeys(paths)
+	values, err := s.DB.GetAll(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var batch storage.Batch
+	for i, value := range values {
+		if value == nil {
+			notFound = append(notFound, paths[i])
+			continue
+		}
+		if err := s.logMutation(pb.PointerMutation_DELETE, paths[i], value, nil); err != nil {
+			return nil, err
+		}
+		batch.Puts = append(batch.Puts, storage.ListItem{Key: deletedKey(paths[i], now), Value: value})
+		batch.Deletes = append(batch.Deletes, keys[i])
+	}
+	if len(batch.Deletes) == 0 {
+		return notFound, nil
+	}
+	return notFound, s.DB.ApplyBatch(batch)
+}
+
+// pathKeys converts paths to storage keys
+func pathKeys(paths []string) storage.Keys {
+	keys := make(storage.Keys, len(paths))
+	for i, path := range paths {
+		keys[i] = storage.Key(path)
+	}
+	return keys
+}
+
+// invalidateAll removes the pointers at paths from the cache
+func (s *Service) invalidateAll(paths []string) {
+	for _, path := range paths {
+		s.cache.Invalidate(path)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/teststore"
+)
+
+func TestBatch(t *testing.T) {
+	ctx := context.Background()
+	db := teststore.New()
+	service := NewService(zap.NewNop(), db)
+	s := Server{service: service, logger: zap.NewNop(), config: Config{MaxInlineSegmentSize: 8 * memory.KiB}}
+
+	require.NoError(t, service.Put("l/photos/a", &pb.Pointer{InlineSegment: []byte("old")}))
+
+	{ // puts are applied in a single transaction and bump the versions
+		_, err := s.BatchPut(ctx, &pb.BatchPutRequest{Items: []*pb.BatchPutRequest_Item{
+			{Path: "l/photos/a", Pointer: &pb.Pointer{InlineSegment: []byte("a")}},
+			{Path: "l/photos/b", Pointer: &pb.Pointer{InlineSegment: []byte("b")}},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, 1, db.CallCount.ApplyBatch)
+
+		resp, err := s.BatchGet(ctx, &pb.BatchGetRequest{Paths: []string{"l/photos/b", "l/photos/missing", "l/photos/a"}})
+		require.NoError(t, err)
+		require.Len(t, resp.Items, 3)
+		assert.Equal(t, "l/photos/b", resp.Items[0].Path)
+		assert.Equal(t, []byte("b"), resp.Items[0].Pointer.InlineSegment)
+		assert.Equal(t, int64(1), resp.Items[0].Pointer.Version)
+		assert.Nil(t, resp.Items[1].Pointer)
+		assert.Equal(t, []byte("a"), resp.Items[2].Pointer.InlineSegment)
+		assert.Equal(t, int64(2), resp.Items[2].Pointer.Version)
+	}
+
+	{ // an invalid pointer fails the whole batch
+		_, err := s.BatchPut(ctx, &pb.BatchPutRequest{Items: []*pb.BatchPutRequest_Item{
+			{Path: "l/photos/c", Pointer: &pb.Pointer{InlineSegment: []byte("c")}},
+			{Path: "l/photos/d", Pointer: &pb.Pointer{InlineSegment: make([]byte, 9*memory.KiB)}},
+		}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = s.BatchPut(ctx, &pb.BatchPutRequest{Items: []*pb.BatchPutRequest_Item{
+			{Path: "l/photos/c", Pointer: &pb.Pointer{InlineSegment: []byte("c")}},
+			{Path: "", Pointer: &pb.Pointer{}},
+		}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = service.Get("l/photos/c")
+		assert.True(t, storage.ErrKeyNotFound.Has(err))
+	}
+
+	{ // deletes are undeletable and report the missing paths
+		resp, err := s.BatchDelete(ctx, &pb.BatchDeleteRequest{Paths: []string{"l/photos/a", "l/photos/missing", "l/photos/b"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"l/photos/missing"}, resp.NotFound)
+
+		pointers, err := service.GetAll([]string{"l/photos/a", "l/photos/b"})
+		require.NoError(t, err)
+		assert.Equal(t, []*pb.Pointer{nil, nil}, pointers)
+
+		require.NoError(t, service.Undelete("l/photos/b"))
+		pointer, err := service.Get("l/photos/b")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("b"), pointer.InlineSegment)
+	}
+
+	{ // batches are limited
+		paths := make([]string, storage.LookupLimit+1)
+		_, err := s.BatchGet(ctx, &pb.BatchGetRequest{Paths: paths})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = s.BatchDelete(ctx, &pb.BatchDeleteRequest{Paths: paths})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/storage"
+)
+
+// bucketsPrefix starts the keys of the bucket records. Like deleted pointers
+// the records live outside of every listed prefix.
+const bucketsPrefix = "\x00buckets/"
+
+// IsBucketKey returns whether key holds a bucket record rather than a
+// pointer, for callers iterating over the whole pointerdb
+func IsBucketKey(key storage.Key) bool {
+	return bytes.HasPrefix(key, []byte(bucketsPrefix))
+}
+
+func bucketKey(name string) storage.Key {
+	return storage.Key(bucketsPrefix + name)
+}
+
+// validateBucketName checks that name is a single path component
+func validateBucketName(name string) error {
+	if name == "" || strings.ContainsAny(name, "/\x00") {
+		return ErrBucketName.New("%q", name)
+	}
+	return nil
+}
+
+// CreateBucket stores the record of a new bucket, setting its creation time
+func (s *Service) CreateBucket(bucket *pb.BucketInfo) (err error) {
+	if err := validateBucketName(bucket.GetName()); err != nil {
+		return err
+	}
+
+	bucket.Created = ptypes.TimestampNow()
+	value, err := proto.Marshal(bucket)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	err = s.DB.CompareAndSwap(bucketKey(bucket.Name), nil, value)
+	if storage.ErrValueChanged.Has(err) {
+		return ErrBucketExists.New("%q", bucket.Name)
+	}
+	return err
+}
+
+// GetBucket gets the record of a bucket
+func (s *Service) GetBucket(name string) (bucket *pb.BucketInfo, err error) {
+	if err := validateBucketName(name); err != nil {
+		return nil, err
+	}
+
+	value, err := s.DB.Get(bucketKey(name))
+	if err != nil {
+		return nil, err
+	}
+
+	bucket = &pb.BucketInfo{}
+	if err := proto.Unmarshal(value, bucket); err != nil {
+		return nil, Error.New("error unmarshaling bucket %q: %v", name, err)
+	}
+	return bucket, nil
+}
+
+// DeleteBucket deletes the record of a bucket, which mustn't have objects
+func (s *Service) DeleteBucket(name string) (err error) {
+	if err := validateBucketName(name); err != nil {
+		return err
+	}
+
+	// the last segment of every object is stored below l/
+	objects, _, err := storage.ListV2(s.DB, storage.ListOptions{
+		Prefix:    storage.Key("l/" + name + "/"),
+		Recursive: true,
+		Limit:     1,
+	})
+	if err != nil {
+		return err
+	}
+	if len(objects) > 0 {
+		return ErrBucketNotEmpty.New("%q", name)
+	}
+
+	return s.DB.Delete(bucketKey(name))
+}
+
+// ListBuckets lists up to limit bucket records in name order, starting after
+// the bucket startAfter
+func (s *Service) ListBuckets(startAfter string, limit int) (buckets []*pb.BucketInfo, more bool, err error) {
+	if limit <= 0 || limit > storage.LookupLimit {
+		limit = storage.LookupLimit
+	}
+
+	items, more, err := storage.ListV2(s.DB, storage.ListOptions{
+		Prefix:       storage.Key(bucketsPrefix),
+		StartAfter:   storage.Key(startAfter),
+		Recursive:    true,
+		IncludeValue: true,
+		Limit:        limit,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, item := range items {
+		bucket := &pb.BucketInfo{}
+		if err := proto.Unmarshal(item.Value, bucket); err != nil {
+			return nil, false, Error.New("error unmarshaling bucket %q: %v", item.Key, err)
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets, more, nil
+}
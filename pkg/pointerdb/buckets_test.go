@@ -0,0 +1,88 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/storage/teststore"
+)
+
+func TestBuckets(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(zap.NewNop(), teststore.New())
+	s := Server{service: service, logger: zap.NewNop()}
+
+	for _, name := range []string{"photos", "logs"} {
+		resp, err := s.CreateBucket(ctx, &pb.BucketCreateRequest{Bucket: &pb.BucketInfo{
+			Name:                name,
+			DefaultRedundancy:   &pb.RedundancyScheme{MinReq: 2, Total: 4},
+			EncryptionCipher:    1,
+			EncryptionBlockSize: 1024,
+			Placement:           "eu",
+		}})
+		require.NoError(t, err)
+		assert.NotNil(t, resp.Bucket.Created)
+	}
+
+	{ // buckets are created once and names are single path components
+		_, err := s.CreateBucket(ctx, &pb.BucketCreateRequest{Bucket: &pb.BucketInfo{Name: "photos"}})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+		_, err = s.CreateBucket(ctx, &pb.BucketCreateRequest{Bucket: &pb.BucketInfo{Name: "a/b"}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	{ // the records are returned as created
+		resp, err := s.GetBucket(ctx, &pb.BucketGetRequest{Name: "photos"})
+		require.NoError(t, err)
+		assert.Equal(t, int32(4), resp.Bucket.DefaultRedundancy.Total)
+		assert.Equal(t, int32(1024), resp.Bucket.EncryptionBlockSize)
+		assert.Equal(t, "eu", resp.Bucket.Placement)
+
+		_, err = s.GetBucket(ctx, &pb.BucketGetRequest{Name: "missing"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		list, err := s.ListBuckets(ctx, &pb.BucketListRequest{})
+		require.NoError(t, err)
+		require.Len(t, list.Buckets, 2)
+		assert.Equal(t, "logs", list.Buckets[0].Name)
+		assert.Equal(t, "photos", list.Buckets[1].Name)
+
+		list, err = s.ListBuckets(ctx, &pb.BucketListRequest{StartAfter: "logs"})
+		require.NoError(t, err)
+		require.Len(t, list.Buckets, 1)
+		assert.Equal(t, "photos", list.Buckets[0].Name)
+	}
+
+	{ // bucket records are hidden from pointer listings
+		require.NoError(t, service.Put("l/photos/a", &pb.Pointer{InlineSegment: []byte("a")}))
+
+		items, _, err := service.List("", "", "", true, 0, 0)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, "l/photos/a", items[0].Path)
+	}
+
+	{ // buckets with objects can't be deleted
+		_, err := s.DeleteBucket(ctx, &pb.BucketDeleteRequest{Name: "photos"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		_, err = s.DeleteBucket(ctx, &pb.BucketDeleteRequest{Name: "logs"})
+		require.NoError(t, err)
+		_, err = s.GetBucket(ctx, &pb.BucketGetRequest{Name: "logs"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		_, err = s.DeleteBucket(ctx, &pb.BucketDeleteRequest{Name: "logs"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb
+
+import (
+	"container/list"
+	"sync"
+
+	"storj.io/storj/internal/memory"
+)
+
+// PointerCache keeps the stored pointers of frequently read paths in memory,
+// so that popular objects are downloaded without reading their pointers from
+// the database every time. The cache holds at most `size` bytes of paths and
+// pointers and evicts the least recently used pointers when full.
+//
+// Pointers are invalidated by the mutations of the service they were cached
+// by, so a database must not be mutated by other services while it's cached.
+type PointerCache struct {
+	mu      sync.Mutex
+	size    int64
+	used    int64
+	order   *list.List
+	entries map[string]*list.Element
+
+	// generation counts the invalidations, so that a pointer read before an
+	// invalidation isn't added after it
+	generation uint64
+}
+
+// cachedPointer is a stored pointer in the cache
+type cachedPointer struct {
+	path  string
+	value []byte
+}
+
+// NewPointerCache returns a new pointer cache holding at most size bytes.
+// A nil cache is returned when size is not positive; a nil cache doesn't
+// cache anything.
+func NewPointerCache(size memory.Size) *PointerCache {
+	if size <= 0 {
+		return nil
+	}
+	return &PointerCache{
+		size:    size.Int64(),
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the stored pointer at path and marks it as recently used. On a
+// miss, the generation to add the pointer read from the database with is
+// returned.
+func (cache *PointerCache) Get(path string) (value []byte, generation uint64, ok bool) {
+	if cache == nil {
+		return nil, 0, false
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	elem, ok := cache.entries[path]
+	if !ok {
+		mon.Counter("pointer_cache_miss").Inc(1)
+		mon.FloatVal("pointer_cache_hit_rate").Observe(0)
+		return nil, cache.generation, false
+	}
+	mon.Counter("pointer_cache_hit").Inc(1)
+	mon.FloatVal("pointer_cache_hit_rate").Observe(1)
+	cache.order.MoveToFront(elem)
+	return elem.Value.(*cachedPointer).value, 0, true
+}
+
+// Add caches the stored pointer at path read after Get returned generation,
+// unless it was invalidated since. The cache keeps value, so it mustn't be
+// modified afterwards.
+func (cache *PointerCache) Add(path string, value []byte, generation uint64) {
+	if cache == nil || int64(len(path)+len(value)) > cache.size {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if generation != cache.generation {
+		return
+	}
+	if elem, ok := cache.entries[path]; ok {
+		cache.remove(elem)
+	}
+
+	cache.entries[path] = cache.order.PushFront(&cachedPointer{path: path, value: value})
+	cache.used += int64(len(path) + len(value))
+	for cache.used > cache.size {
+		cache.remove(cache.order.Back())
+		mon.Counter("pointer_cache_evict").Inc(1)
+	}
+	mon.IntVal("pointer_cache_used").Observe(cache.used)
+}
+
+// Invalidate removes the pointer at path from the cache and keeps pointers
+// being read from being added
+func (cache *PointerCache) Invalidate(path string) {
+	if cache == nil {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.generation++
+	if elem, ok := cache.entries[path]; ok {
+		cache.remove(elem)
+	}
+}
+
+// Used returns the number of cached bytes
+func (cache *PointerCache) Used() int64 {
+	if cache == nil {
+		return 0
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.used
+}
+
+// remove removes elem from the cache, the lock must be held
+func (cache *PointerCache) remove(elem *list.Element) {
+	pointer := cache.order.Remove(elem).(*cachedPointer)
+	delete(cache.entries, pointer.path)
+	cache.used -= int64(len(pointer.path) + len(pointer.value))
+}
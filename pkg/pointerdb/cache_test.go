@@ -0,0 +1,92 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/teststore"
+)
+
+func TestPointerCache(t *testing.T) {
+	db := teststore.New()
+	service := pointerdb.NewService(zaptest.NewLogger(t), db)
+	service.SetCache(memory.KiB)
+
+	pointer := func(data string) *pb.Pointer {
+		return &pb.Pointer{Type: pb.Pointer_INLINE, InlineSegment: []byte(data)}
+	}
+	get := func(path string) string {
+		got, err := service.Get(path)
+		require.NoError(t, err)
+		return string(got.InlineSegment)
+	}
+
+	require.NoError(t, service.Put("l/bucket/a", pointer("first")))
+	assert.Equal(t, "first", get("l/bucket/a"))
+
+	// the cached pointer is returned, even though the database changed
+	stored, err := db.Get(storage.Key("l/bucket/a"))
+	require.NoError(t, err)
+	require.NoError(t, db.Put(storage.Key("l/bucket/b"), stored))
+	require.NoError(t, db.Delete(storage.Key("l/bucket/a")))
+	assert.Equal(t, "first", get("l/bucket/a"))
+
+	// mutations of the service invalidate the cache
+	require.NoError(t, service.Put("l/bucket/a", pointer("second")))
+	assert.Equal(t, "second", get("l/bucket/a"))
+
+	require.NoError(t, service.PutAll([]string{"l/bucket/a"}, []*pb.Pointer{pointer("third")}))
+	assert.Equal(t, "third", get("l/bucket/a"))
+
+	require.NoError(t, service.Delete("l/bucket/a"))
+	_, err = service.Get("l/bucket/a")
+	assert.True(t, storage.ErrKeyNotFound.Has(err))
+
+	assert.Equal(t, "first", get("l/bucket/b"))
+	_, err = service.DeleteAll([]string{"l/bucket/b"})
+	require.NoError(t, err)
+	_, err = service.Get("l/bucket/b")
+	assert.True(t, storage.ErrKeyNotFound.Has(err))
+}
+
+func TestPointerCacheEviction(t *testing.T) {
+	cache := pointerdb.NewPointerCache(10)
+	cache.Add("a", []byte("1234"), 0)
+	cache.Add("b", []byte("1234"), 0)
+	assert.Equal(t, int64(10), cache.Used())
+
+	// a is used more recently, so b is evicted
+	_, _, ok := cache.Get("a")
+	assert.True(t, ok)
+	cache.Add("c", []byte("12"), 0)
+	_, _, ok = cache.Get("b")
+	assert.False(t, ok)
+	_, _, ok = cache.Get("a")
+	assert.True(t, ok)
+
+	// pointers read before an invalidation aren't added
+	_, generation, ok := cache.Get("d")
+	assert.False(t, ok)
+	cache.Invalidate("d")
+	cache.Add("d", []byte("1"), generation)
+	_, _, ok = cache.Get("d")
+	assert.False(t, ok)
+
+	// pointers larger than the cache aren't cached
+	_, generation, _ = cache.Get("e")
+	cache.Add("e", []byte("123456789012"), generation)
+	_, _, ok = cache.Get("e")
+	assert.False(t, ok)
+
+	assert.Nil(t, pointerdb.NewPointerCache(0))
+}
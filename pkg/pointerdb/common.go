@@ -9,3 +9,20 @@ import (
 
 // Error is the default boltdb errs class
 var Error = errs.Class("pointerdb error")
+
+// ErrPathExists is returned when undeleting a path where a new pointer was put
+var ErrPathExists = errs.Class("path exists")
+
+// ErrVersionChanged is returned by conditional puts when the pointer doesn't
+// have the expected version anymore
+var ErrVersionChanged = errs.Class("pointer version changed")
+
+// ErrBucketName is returned for bucket names which aren't a single path
+// component
+var ErrBucketName = errs.Class("invalid bucket name")
+
+// ErrBucketExists is returned when creating a bucket which was created before
+var ErrBucketExists = errs.Class("bucket exists")
+
+// ErrBucketNotEmpty is returned when deleting a bucket which has objects
+var ErrBucketNotEmpty = errs.Class("bucket not empty")
@@ -5,18 +5,24 @@ package pointerdb
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
+	"storj.io/storj/internal/dburl"
 	"storj.io/storj/internal/memory"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/provider"
-	"storj.io/storj/pkg/utils"
+	"storj.io/storj/pkg/retryqueue"
+	ecclient "storj.io/storj/pkg/storage/ec"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/boltdb"
+	"storj.io/storj/storage/cockroachkv"
 	"storj.io/storj/storage/postgreskv"
 	"storj.io/storj/storage/storelogger"
+	"storj.io/storj/storage/teststore"
 )
 
 // CtxKeyPointerdb Used as pointerdb key
@@ -35,24 +41,88 @@ type Config struct {
 	DatabaseURL          string      `help:"the database connection string to use" default:"bolt://$CONFDIR/pointerdb.db"`
 	MinRemoteSegmentSize memory.Size `default:"1240" help:"minimum remote segment size"`
 	MaxInlineSegmentSize memory.Size `default:"8000" help:"maximum inline segment size"`
+	MinSegmentSize       memory.Size `default:"1MiB" help:"minimum segment size uplinks may choose for objects smaller than their default segment size"`
+	MaxSegmentSize       memory.Size `default:"64MiB" help:"maximum segment size uplinks may choose for large objects"`
 	Overlay              bool        `default:"true" help:"toggle flag if overlay is enabled"`
 	BwExpiration         int         `default:"45"   help:"lifespan of bandwidth agreements in days"`
+	CompressPointers     memory.Size `default:"1KiB" help:"pointers serializing to more than this are stored compressed, 0 disables compression"`
+	PointerCacheSize     memory.Size `default:"0" help:"how much memory to cache the pointers read in, 0 disables the cache"`
+	ChecksumPointers     bool        `default:"true" help:"store pointers with checksums which are verified when they're read, so corrupted pointers are detected"`
+
+	AddressValidity time.Duration `default:"15m0s" help:"how long uplinks may dial the signed addresses of the nodes storing the pieces they get"`
+
+	StorageQuota memory.Size `default:"0" help:"how much every project may store, 0 disables the limit"`
+	ObjectQuota  int64       `default:"0" help:"how many objects every project may store, 0 disables the limit"`
+
+	UndeleteWindow        time.Duration `default:"0s" help:"how long deleted objects can be undeleted before their pieces are purged"`
+	BucketUndeleteWindows string        `default:"" help:"undelete windows of single buckets overriding the default, e.g. photos=168h,logs=0s"`
+	PurgeInterval         time.Duration `default:"1m0s" help:"how frequently the pieces of deleted objects past their undelete window are purged"`
+	ReapInterval          time.Duration `default:"1h0m0s" help:"how frequently expired pointers are deleted"`
+	DeletionWorkers       int           `default:"4" help:"how many queued piece deletions are sent to the storage nodes at once"`
+	DeletionRetry         retryqueue.Config
+
+	AuditLogURL             string        `default:"" help:"the database pointer mutations are logged to before they are applied, e.g. bolt://$CONFDIR/pointerdb-audit.db, empty disables the audit log"`
+	AuditLogCompactAfter    time.Duration `default:"168h0m0s" help:"how long the pointers put are kept in the audit log, only the hashes and callers of older mutations are kept"`
+	AuditLogRetention       time.Duration `default:"2160h0m0s" help:"how long mutations are kept in the audit log, 0 keeps them forever"`
+	AuditLogCompactInterval time.Duration `default:"1h0m0s" help:"how frequently the audit log is compacted"`
+}
+
+// ParseUndeleteWindows parses comma separated bucket=duration undelete
+// windows such as photos=168h,logs=0s
+func ParseUndeleteWindows(s string) (map[string]time.Duration, error) {
+	windows := make(map[string]time.Duration)
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, Error.New("invalid undelete window %q", field)
+		}
+		window, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+		if err != nil || window < 0 {
+			return nil, Error.New("invalid undelete window %q", field)
+		}
+		windows[strings.TrimSpace(parts[0])] = window
+	}
+	return windows, nil
 }
 
-// NewStore returns database for storing pointer data
+// NewStore returns database for storing pointer data. memory:// databases
+// are kept in memory and lost when closed, e.g. for tests.
 func NewStore(dbURLString string) (db storage.KeyValueStore, err error) {
-	driver, source, err := utils.SplitDBURL(dbURLString)
+	dbURL, err := dburl.Parse(dbURLString)
 	if err != nil {
-		return nil, err
+		return nil, Error.Wrap(err)
 	}
-	if driver == "bolt" {
-		db, err = boltdb.New(source, BoltPointerBucket)
-	} else if driver == "postgresql" || driver == "postgres" {
-		db, err = postgreskv.New(source)
-	} else {
-		err = Error.New("unsupported db scheme: %s", driver)
+
+	switch dbURL.Driver {
+	case "bolt":
+		return boltdb.NewWithOptions(dbURL.Source, BoltPointerBucket, boltdb.Options{
+			Timeout:    dbURL.Options.Timeout,
+			NoSync:     dbURL.Options.NoSync,
+			NoGrowSync: dbURL.Options.NoGrowSync,
+		})
+	case "postgres":
+		client, err := postgreskv.New(dbURL.Source)
+		if err != nil {
+			return nil, err
+		}
+		dbURL.ConfigureSQL(client.DB())
+		return client, nil
+	case "cockroach":
+		client, err := cockroachkv.New(dbURL.Source)
+		if err != nil {
+			return nil, err
+		}
+		dbURL.ConfigureSQL(client.DB())
+		return client, nil
+	case "memory":
+		return teststore.New(), nil
+	default:
+		return nil, Error.New("unsupported db scheme: %s", dbURL.Driver)
 	}
-	return db, err
 }
 
 // Run implements the provider.Responsibility interface
@@ -66,13 +136,63 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) error {
 	cache := overlay.LoadFromContext(ctx)
 	dblogged := storelogger.New(zap.L().Named("pdb"), db)
 
+	windows, err := ParseUndeleteWindows(c.BucketUndeleteWindows)
+	if err != nil {
+		return err
+	}
+
 	service := NewService(zap.L(), dblogged)
+	service.SetCompression(c.CompressPointers)
+	service.SetCache(c.PointerCacheSize)
+	service.SetChecksums(c.ChecksumPointers)
+	service.SetUndeleteWindow(c.UndeleteWindow, windows)
+	service.SetExpirationIndex(IndexesExpiration(c.DatabaseURL))
+	var auditLog *AuditLog
+	if c.AuditLogURL != "" {
+		auditDB, err := NewStore(c.AuditLogURL)
+		if err != nil {
+			return err
+		}
+		auditLog = NewAuditLog(auditDB)
+		defer func() { _ = auditLog.Close() }()
+		service.SetAuditLog(auditLog)
+	}
 	allocation := NewAllocationSigner(server.Identity(), c.BwExpiration)
 	s := NewServer(zap.L(), service, allocation, cache, c, server.Identity())
 	pb.RegisterPointerDBServer(server.GRPC(), s)
 	// add the server to the context
 	ctx = context.WithValue(ctx, ctxKey, service)
 	ctx = context.WithValue(ctx, ctxKeyAllocation, allocation)
+
+	reaper := NewReaper(zap.L().Named("pdb:reaper"), service, c.ReapInterval, nil)
+	var cancel func()
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		if err := reaper.Run(ctx); err != nil {
+			zap.L().Debug("reaper is shutting down", zap.Error(err))
+		}
+	}()
+
+	if auditLog != nil {
+		compactor := NewAuditLogCompactor(zap.L().Named("pdb:auditlog"), auditLog, c.AuditLogCompactAfter, c.AuditLogRetention, c.AuditLogCompactInterval, nil)
+		go func() {
+			if err := compactor.Run(ctx); err != nil {
+				zap.L().Debug("audit log compactor is shutting down", zap.Error(err))
+			}
+		}()
+	}
+
+	if cache != nil {
+		ec := ecclient.NewClient(server.Identity(), 0)
+		purger := NewPurger(zap.L().Named("pdb:purger"), service, cache, ec, server.Identity(), c.PurgeInterval, nil)
+		go func() {
+			if err := purger.Run(ctx); err != nil {
+				zap.L().Debug("purger is shutting down", zap.Error(err))
+			}
+		}()
+	}
+
 	return server.Run(ctx)
 }
 
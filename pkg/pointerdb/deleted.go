@@ -0,0 +1,190 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
+)
+
+// deletedPrefix starts the keys of deleted pointers waiting to be purged.
+// Pointer paths never start with a zero byte, so deleted pointers are hidden
+// from lists and gets by living outside of every listed prefix.
+const deletedPrefix = "\x00deleted/"
+
+// deletedTimeLength is the length of the hex deletion time ending the key of
+// a deleted pointer, so the deletions of a path sort by time
+const deletedTimeLength = 16
+
+// DeletedSegment is a deleted segment pointer, which can be undeleted until
+// its pieces are purged
+type DeletedSegment struct {
+	Path      string
+	DeletedAt time.Time
+	Pointer   *pb.Pointer
+
+	key   storage.Key
+	value storage.Value
+}
+
+// IsDeletedKey returns whether key holds a deleted pointer rather than a
+// live one, for callers iterating over the whole pointerdb
+func IsDeletedKey(key storage.Key) bool {
+	return bytes.HasPrefix(key, []byte(deletedPrefix))
+}
+
+// deletedKeyPrefix returns the prefix of the keys of all deletions of path
+func deletedKeyPrefix(path string) storage.Key {
+	return storage.Key(deletedPrefix + path + "\x00")
+}
+
+func deletedKey(path string, deletedAt time.Time) storage.Key {
+	return storage.Key(fmt.Sprintf("%s%016x", deletedKeyPrefix(path), deletedAt.UnixNano()))
+}
+
+// parseDeletedKey returns the path and the deletion time of a deleted key
+func parseDeletedKey(key storage.Key) (path string, deletedAt time.Time, err error) {
+	if !IsDeletedKey(key) || len(key) < len(deletedPrefix)+1+deletedTimeLength {
+		return "", time.Time{}, Error.New("invalid deleted key %q", key)
+	}
+	timePos := len(key) - deletedTimeLength
+	nanos, err := strconv.ParseUint(string(key[timePos:]), 16, 64)
+	if err != nil {
+		return "", time.Time{}, Error.New("invalid deleted key %q: %v", key, err)
+	}
+	return string(key[len(deletedPrefix) : timePos-1]), time.Unix(0, int64(nanos)), nil
+}
+
+// SetUndeleteWindow sets how long deleted segments can be undeleted before
+// their pieces are purged, overridden for the buckets in buckets. Must be
+// called before the service is used.
+func (s *Service) SetUndeleteWindow(window time.Duration, buckets map[string]time.Duration) {
+	s.undeleteWindow = window
+	s.bucketUndeleteWindows = buckets
+}
+
+// UndeleteWindow returns how long deleted segments at path can be undeleted
+func (s *Service) UndeleteWindow(path string) time.Duration {
+	// segment paths look like "l/bucket/encrypted/path" or "s0/bucket/..."
+	components := storj.SplitPath(path)
+	if len(components) > 1 {
+		if window, ok := s.bucketUndeleteWindows[components[1]]; ok {
+			return window
+		}
+	}
+	return s.undeleteWindow
+}
+
+// latestDeletion returns the key and the pointer of the latest deletion of
+// path
+func (s *Service) latestDeletion(path string) (latest *storage.ListItem, err error) {
+	err = s.DB.Iterate(storage.IterateOptions{Prefix: deletedKeyPrefix(path), Recurse: true},
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			for it.Next(&item) {
+				latest = &storage.ListItem{Key: storage.CloneKey(item.Key), Value: storage.CloneValue(item.Value)}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		return nil, storage.ErrKeyNotFound.New("no deleted pointer at %q", path)
+	}
+	return latest, nil
+}
+
+// GetDeleted returns the pointer Undelete would restore at path
+func (s *Service) GetDeleted(path string) (pointer *pb.Pointer, err error) {
+	latest, err := s.latestDeletion(path)
+	if err != nil {
+		return nil, err
+	}
+	pointer = &pb.Pointer{}
+	if err := UnmarshalPointer(latest.Value, pointer); err != nil {
+		return nil, Error.New("error unmarshaling deleted pointer %q: %v", latest.Key, err)
+	}
+	return pointer, nil
+}
+
+// Undelete restores the latest deletion of path, which fails when a new
+// pointer was put at path since
+func (s *Service) Undelete(path string) (err error) {
+	defer s.cache.Invalidate(path)
+
+	latest, err := s.latestDeletion(path)
+	if err != nil {
+		return err
+	}
+
+	if err := s.logMutation(pb.PointerMutation_UNDELETE, path, nil, latest.Value); err != nil {
+		return err
+	}
+
+	err = s.DB.CompareAndSwap(storage.Key(path), nil, latest.Value)
+	if storage.ErrValueChanged.Has(err) {
+		return ErrPathExists.New("%q", path)
+	}
+	if err != nil {
+		return err
+	}
+	return s.DB.Delete(latest.Key)
+}
+
+// Deleted returns up to limit deleted segments whose undelete window passed
+// by now, so their pieces can be purged
+func (s *Service) Deleted(now time.Time, limit int) (segments []DeletedSegment, err error) {
+	err = s.DB.Iterate(storage.IterateOptions{Prefix: storage.Key(deletedPrefix), Recurse: true},
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			for len(segments) < limit && it.Next(&item) {
+				path, deletedAt, err := parseDeletedKey(item.Key)
+				if err != nil {
+					return err
+				}
+				if now.Sub(deletedAt) < s.UndeleteWindow(path) {
+					continue
+				}
+
+				pointer := &pb.Pointer{}
+				if err := UnmarshalPointer(item.Value, pointer); err != nil {
+					return Error.New("error unmarshaling deleted pointer %q: %v", item.Key, err)
+				}
+				segments = append(segments, DeletedSegment{
+					Path:      path,
+					DeletedAt: deletedAt,
+					Pointer:   pointer,
+					key:       storage.CloneKey(item.Key),
+					value:     storage.CloneValue(item.Value),
+				})
+			}
+			return nil
+		})
+	return segments, err
+}
+
+// Undeleted returns whether the deleted segment is still or again live at its
+// path, after a delete or an undelete which didn't complete
+func (s *Service) Undeleted(segment DeletedSegment) (bool, error) {
+	value, err := s.DB.Get(storage.Key(segment.Path))
+	if storage.ErrKeyNotFound.Has(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(value, segment.value), nil
+}
+
+// Forget drops a deleted segment for good, after its pieces were purged
+func (s *Service) Forget(segment DeletedSegment) error {
+	return s.DB.Delete(segment.key)
+}
@@ -0,0 +1,141 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/storage/meta"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/teststore"
+)
+
+func TestUndelete(t *testing.T) {
+	service := NewService(zap.NewNop(), teststore.New())
+
+	pointer := remotePointer(t, 3)
+	require.NoError(t, service.Put("l/photos/a", pointer))
+	require.NoError(t, service.Delete("l/photos/a"))
+
+	{ // deleted pointers are hidden
+		_, err := service.Get("l/photos/a")
+		assert.True(t, storage.ErrKeyNotFound.Has(err))
+
+		for _, recursive := range []bool{false, true} {
+			items, _, err := service.List("", "", "", recursive, 0, meta.All)
+			require.NoError(t, err)
+			assert.Empty(t, items)
+		}
+
+		err = service.Delete("l/photos/a")
+		assert.True(t, storage.ErrKeyNotFound.Has(err))
+	}
+
+	{ // undeleting restores the pointer
+		require.NoError(t, service.Undelete("l/photos/a"))
+		restored, err := service.Get("l/photos/a")
+		require.NoError(t, err)
+		assertPointerEqual(t, pointer, restored)
+
+		err = service.Undelete("l/photos/a")
+		assert.True(t, storage.ErrKeyNotFound.Has(err))
+	}
+
+	{ // new pointers aren't overwritten by undeleting
+		require.NoError(t, service.Delete("l/photos/a"))
+		require.NoError(t, service.Put("l/photos/a", remotePointer(t, 1)))
+		err := service.Undelete("l/photos/a")
+		assert.True(t, ErrPathExists.Has(err))
+	}
+
+	{ // deleted pointers can't be forged
+		err := service.Put(deletedPrefix+"l/photos/b", pointer)
+		assert.True(t, Error.Has(err))
+	}
+}
+
+func TestListSkipsDeleted(t *testing.T) {
+	service := NewService(zap.NewNop(), teststore.New())
+
+	for _, path := range []string{"l/a", "l/b", "l/c", "l/d"} {
+		require.NoError(t, service.Put(path, remotePointer(t, 1)))
+	}
+	require.NoError(t, service.Delete("l/a"))
+	require.NoError(t, service.Delete("l/b"))
+
+	// the deleted pointers sort first, but don't count towards the limit
+	items, more, err := service.List("", "", "", true, 2, meta.None)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "l/c", items[0].Path)
+	assert.Equal(t, "l/d", items[1].Path)
+	assert.False(t, more)
+
+	items, more, err = service.List("", "", "l/d", true, 1, meta.None)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "l/c", items[0].Path)
+	assert.True(t, more)
+}
+
+func TestDeletedWindows(t *testing.T) {
+	service := NewService(zap.NewNop(), teststore.New())
+	service.SetUndeleteWindow(time.Hour, map[string]time.Duration{"logs": 0})
+
+	require.NoError(t, service.Put("l/photos/a", remotePointer(t, 1)))
+	require.NoError(t, service.Put("s0/logs/b", remotePointer(t, 1)))
+	require.NoError(t, service.Delete("l/photos/a"))
+	require.NoError(t, service.Delete("s0/logs/b"))
+
+	now := time.Now()
+	deleted, err := service.Deleted(now, 10)
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, "s0/logs/b", deleted[0].Path)
+	assert.WithinDuration(t, now, deleted[0].DeletedAt, time.Minute)
+
+	deleted, err = service.Deleted(now.Add(2*time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, deleted, 2)
+	assert.Equal(t, "l/photos/a", deleted[0].Path)
+
+	deleted, err = service.Deleted(now.Add(2*time.Hour), 1)
+	require.NoError(t, err)
+	assert.Len(t, deleted, 1)
+
+	// a pointer which is live again isn't purged
+	undeleted, err := service.Undeleted(deleted[0])
+	require.NoError(t, err)
+	assert.False(t, undeleted)
+	require.NoError(t, service.DB.Put(storage.Key(deleted[0].Path), deleted[0].value))
+	undeleted, err = service.Undeleted(deleted[0])
+	require.NoError(t, err)
+	assert.True(t, undeleted)
+
+	require.NoError(t, service.Forget(deleted[0]))
+	deleted, err = service.Deleted(now.Add(2*time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, "s0/logs/b", deleted[0].Path)
+}
+
+func TestParseUndeleteWindows(t *testing.T) {
+	windows, err := ParseUndeleteWindows(" photos=168h, logs=0s,,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"photos": 168 * time.Hour, "logs": 0}, windows)
+
+	windows, err = ParseUndeleteWindows("")
+	require.NoError(t, err)
+	assert.Empty(t, windows)
+
+	for _, invalid := range []string{"photos", "=1h", "photos=soon", "photos=-1h"} {
+		_, err := ParseUndeleteWindows(invalid)
+		assert.True(t, Error.Has(err), invalid)
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"hash/crc32"
+	"io/ioutil"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storage/meta"
+)
+
+// compressedPrefix starts stored pointers which are compressed with flate.
+// Serialized pointers never start with a zero byte, as that would be a tag of
+// the invalid field number 0, so plain and compressed pointers can be told
+// apart and stay readable side by side.
+var compressedPrefix = []byte{0, 1}
+
+// checksummedPrefix starts stored pointers which are followed by the CRC-32C
+// checksum of the plain or compressed pointer following it
+var checksummedPrefix = []byte{0, 2}
+
+// checksumLength is the length of the checksum of a checksummed pointer
+const checksumLength = 4
+
+// ErrPointerCorrupted is returned when a stored pointer doesn't match its
+// checksum
+var ErrPointerCorrupted = errs.Class("pointer corrupted")
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// ChecksumPointer prepends the checksum of data, a pointer serialized by
+// MarshalPointer, so corruption is detected when it's read
+func ChecksumPointer(data []byte) []byte {
+	checksummed := make([]byte, len(checksummedPrefix)+checksumLength+len(data))
+	copy(checksummed, checksummedPrefix)
+	binary.BigEndian.PutUint32(checksummed[len(checksummedPrefix):], crc32.Checksum(data, castagnoli))
+	copy(checksummed[len(checksummedPrefix)+checksumLength:], data)
+	return checksummed
+}
+
+// MarshalPointer serializes pointer for storing. Pointers serializing to more
+// than threshold bytes are compressed, when that makes them smaller; a
+// threshold of 0 disables compression.
+func MarshalPointer(pointer *pb.Pointer, threshold int) ([]byte, error) {
+	data, err := proto.Marshal(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if threshold <= 0 || len(data) <= threshold {
+		return data, nil
+	}
+
+	var compressed bytes.Buffer
+	compressed.Write(compressedPrefix)
+	w, err := flate.NewWriter(&compressed, flate.BestSpeed)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if compressed.Len() >= len(data) {
+		mon.Counter("pointer_incompressible").Inc(1)
+		return data, nil
+	}
+	mon.IntVal("pointer_compressed_ratio_percent").Observe(int64(100 * compressed.Len() / len(data)))
+	return compressed.Bytes(), nil
+}
+
+// UnmarshalPointer parses a stored pointer, verifying its checksum and
+// decompressing it when needed
+func UnmarshalPointer(data []byte, pointer *pb.Pointer) error {
+	data, err := decodePointer(data)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(data, pointer)
+}
+
+// UnmarshalPointerFields parses only the fields of a stored pointer selected
+// by metaFlags, skipping the others without parsing them. The type and
+// version are always parsed, meta.Remote selects the remote segment with its
+// redundancy scheme and pieces and meta.Inline the inline segment.
+func UnmarshalPointerFields(data []byte, pointer *pb.Pointer, metaFlags uint32) error {
+	if metaFlags == meta.All {
+		return UnmarshalPointer(data, pointer)
+	}
+
+	data, err := decodePointer(data)
+	if err != nil {
+		return err
+	}
+
+	pointer.Reset()
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return Error.New("invalid pointer field key")
+		}
+		size, err := fieldSize(data[n:], key&7)
+		if err != nil {
+			return err
+		}
+		field := data[:n+size]
+		data = data[n+size:]
+
+		if !pointerFieldSelected(key>>3, metaFlags) {
+			continue
+		}
+		if err := proto.UnmarshalMerge(field, pointer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pointerFieldSelected returns whether metaFlags select the field of
+// pb.Pointer with number
+func pointerFieldSelected(number uint64, metaFlags uint32) bool {
+	switch number {
+	case 1, 9: // type and version
+		return true
+	case 3:
+		return metaFlags&meta.Inline != 0
+	case 4:
+		return metaFlags&meta.Remote != 0
+	case 5:
+		return metaFlags&meta.Size != 0
+	case 6:
+		return metaFlags&meta.Modified != 0
+	case 7:
+		return metaFlags&meta.Expiration != 0
+	case 8:
+		return metaFlags&meta.UserDefined != 0
+	}
+	return false
+}
+
+// fieldSize returns the size of the value of a serialized field of wireType
+// starting data
+func fieldSize(data []byte, wireType uint64) (int, error) {
+	switch wireType {
+	case proto.WireVarint:
+		_, n := binary.Uvarint(data)
+		if n <= 0 {
+			return 0, Error.New("invalid pointer varint")
+		}
+		return n, nil
+	case proto.WireFixed64:
+		if len(data) < 8 {
+			return 0, Error.New("truncated pointer field")
+		}
+		return 8, nil
+	case proto.WireBytes:
+		length, n := binary.Uvarint(data)
+		if n <= 0 || length > uint64(len(data)-n) {
+			return 0, Error.New("truncated pointer field")
+		}
+		return n + int(length), nil
+	case proto.WireFixed32:
+		if len(data) < 4 {
+			return 0, Error.New("truncated pointer field")
+		}
+		return 4, nil
+	}
+	return 0, Error.New("unsupported wire type %d in pointer", wireType)
+}
+
+// decodePointer verifies the checksum of a stored pointer and decompresses
+// it when needed, returning the serialized pointer
+func decodePointer(data []byte) ([]byte, error) {
+	if bytes.HasPrefix(data, checksummedPrefix) {
+		if len(data) < len(checksummedPrefix)+checksumLength {
+			mon.Counter("pointer_corrupted").Inc(1)
+			return nil, ErrPointerCorrupted.New("checksum truncated")
+		}
+		expected := binary.BigEndian.Uint32(data[len(checksummedPrefix):])
+		data = data[len(checksummedPrefix)+checksumLength:]
+		if actual := crc32.Checksum(data, castagnoli); actual != expected {
+			mon.Counter("pointer_corrupted").Inc(1)
+			return nil, ErrPointerCorrupted.New("checksum %08x, expected %08x", actual, expected)
+		}
+	}
+
+	if bytes.HasPrefix(data, compressedPrefix) {
+		r := flate.NewReader(bytes.NewReader(data[len(compressedPrefix):]))
+		decompressed, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, Error.New("error decompressing pointer: %v", err)
+		}
+		if err := r.Close(); err != nil {
+			return nil, Error.Wrap(err)
+		}
+		data = decompressed
+	}
+	return data, nil
+}
@@ -0,0 +1,214 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storage/meta"
+	"storj.io/storj/storage/teststore"
+)
+
+func remotePointer(t *testing.T, pieces int) *pb.Pointer {
+	pointer := &pb.Pointer{
+		Type: pb.Pointer_REMOTE,
+		Remote: &pb.RemoteSegment{
+			Redundancy: &pb.RedundancyScheme{Type: pb.RedundancyScheme_RS, MinReq: 29, Total: 95, RepairThreshold: 35, SuccessThreshold: 80},
+			PieceId:    "piece",
+		},
+		SegmentSize: 64 * memory.MiB.Int64(),
+		Metadata:    make([]byte, 256),
+	}
+	for i := 0; i < pieces; i++ {
+		var id [32]byte
+		_, err := rand.Read(id[:])
+		require.NoError(t, err)
+		pointer.Remote.RemotePieces = append(pointer.Remote.RemotePieces, &pb.RemotePiece{
+			PieceNum: int32(i),
+			NodeId:   teststorj.NodeIDFromBytes(id[:]),
+		})
+	}
+	return pointer
+}
+
+// assertPointerEqual compares pointers by their serialization, as proto.Equal
+// doesn't handle node ids
+func assertPointerEqual(t *testing.T, expected, actual *pb.Pointer) {
+	expectedData, err := proto.Marshal(expected)
+	require.NoError(t, err)
+	actualData, err := proto.Marshal(actual)
+	require.NoError(t, err)
+	assert.Equal(t, expectedData, actualData)
+}
+
+func TestPointerEncoding(t *testing.T) {
+	large := remotePointer(t, 95)
+	plain, err := proto.Marshal(large)
+	require.NoError(t, err)
+
+	{ // large pointers are compressed
+		data, err := MarshalPointer(large, memory.KiB.Int())
+		require.NoError(t, err)
+		assert.True(t, len(data) < len(plain), "%d >= %d", len(data), len(plain))
+
+		decoded := &pb.Pointer{}
+		require.NoError(t, UnmarshalPointer(data, decoded))
+		assertPointerEqual(t, large, decoded)
+	}
+
+	{ // small pointers and disabled compression store plain pointers
+		small := remotePointer(t, 1)
+		data, err := MarshalPointer(small, memory.KiB.Int())
+		require.NoError(t, err)
+		expected, err := proto.Marshal(small)
+		require.NoError(t, err)
+		assert.Equal(t, expected, data)
+
+		data, err = MarshalPointer(large, 0)
+		require.NoError(t, err)
+		assert.Equal(t, plain, data)
+	}
+
+	{ // plain pointers stay readable
+		decoded := &pb.Pointer{}
+		require.NoError(t, UnmarshalPointer(plain, decoded))
+		assertPointerEqual(t, large, decoded)
+	}
+
+	{ // corrupted compressed pointers fail
+		err := UnmarshalPointer(append(append([]byte{}, compressedPrefix...), 0xff, 0xff), &pb.Pointer{})
+		assert.True(t, Error.Has(err))
+	}
+}
+
+func TestServiceCompression(t *testing.T) {
+	db := teststore.New()
+	service := NewService(zap.NewNop(), db)
+	service.SetCompression(memory.KiB)
+
+	pointer := remotePointer(t, 95)
+	require.NoError(t, service.Put("a/b", pointer))
+
+	stored, err := db.Get([]byte("a/b"))
+	require.NoError(t, err)
+	assert.Equal(t, compressedPrefix, []byte(stored[:len(compressedPrefix)]))
+
+	got, err := service.Get("a/b")
+	require.NoError(t, err)
+	assertPointerEqual(t, pointer, got)
+}
+
+func TestPointerChecksums(t *testing.T) {
+	db := teststore.New()
+	service := NewService(zap.NewNop(), db)
+	service.SetCompression(memory.KiB)
+	service.SetChecksums(true)
+
+	small, large := remotePointer(t, 1), remotePointer(t, 95)
+	require.NoError(t, service.Put("a/small", small))
+	require.NoError(t, service.Put("a/large", large))
+
+	{ // checksummed pointers are read, compressed or not
+		got, err := service.Get("a/small")
+		require.NoError(t, err)
+		assertPointerEqual(t, small, got)
+
+		got, err = service.Get("a/large")
+		require.NoError(t, err)
+		assertPointerEqual(t, large, got)
+	}
+
+	{ // corrupted pointers fail with a distinct error
+		stored, err := db.Get([]byte("a/large"))
+		require.NoError(t, err)
+		assert.Equal(t, checksummedPrefix, []byte(stored[:len(checksummedPrefix)]))
+
+		corrupted := append([]byte{}, stored...)
+		corrupted[len(corrupted)-1] ^= 0xff
+		require.NoError(t, db.Put([]byte("a/large"), corrupted))
+
+		_, err = service.Get("a/large")
+		assert.True(t, ErrPointerCorrupted.Has(err))
+
+		err = UnmarshalPointer(checksummedPrefix, &pb.Pointer{})
+		assert.True(t, ErrPointerCorrupted.Has(err))
+	}
+
+	{ // pointers stored without checksums stay readable
+		service.SetChecksums(false)
+		require.NoError(t, service.Put("a/plain", small))
+
+		stored, err := db.Get([]byte("a/plain"))
+		require.NoError(t, err)
+		expected, err := proto.Marshal(small)
+		require.NoError(t, err)
+		assert.Equal(t, expected, []byte(stored))
+
+		got, err := service.Get("a/plain")
+		require.NoError(t, err)
+		assertPointerEqual(t, small, got)
+	}
+}
+
+func TestPointerFields(t *testing.T) {
+	pointer := remotePointer(t, 95)
+	pointer.ExpirationDate = &timestamp.Timestamp{Seconds: 1000}
+	pointer.CreationDate = &timestamp.Timestamp{Seconds: 500}
+
+	service := NewService(zap.NewNop(), teststore.New())
+	service.SetCompression(memory.KiB)
+	service.SetChecksums(true)
+	require.NoError(t, service.Put("a/b", pointer))
+	// put sets the version
+	version := pointer.Version
+
+	{ // the remote segment only
+		got, err := service.GetFields("a/b", meta.Remote)
+		require.NoError(t, err)
+		assertPointerEqual(t, &pb.Pointer{Type: pb.Pointer_REMOTE, Remote: pointer.Remote, Version: version}, got)
+	}
+
+	{ // the expiration only
+		got, err := service.GetFields("a/b", meta.Expiration)
+		require.NoError(t, err)
+		assertPointerEqual(t, &pb.Pointer{Type: pb.Pointer_REMOTE, ExpirationDate: pointer.ExpirationDate, Version: version}, got)
+	}
+
+	{ // several fields
+		pointers, err := service.GetAllFields([]string{"a/b", "a/missing"}, meta.Size|meta.Modified|meta.UserDefined)
+		require.NoError(t, err)
+		require.Len(t, pointers, 2)
+		assertPointerEqual(t, &pb.Pointer{
+			Type:         pb.Pointer_REMOTE,
+			SegmentSize:  pointer.SegmentSize,
+			CreationDate: pointer.CreationDate,
+			Metadata:     pointer.Metadata,
+			Version:      version,
+		}, pointers[0])
+		assert.Nil(t, pointers[1])
+	}
+
+	{ // all fields
+		got, err := service.GetFields("a/b", meta.All)
+		require.NoError(t, err)
+		assertPointerEqual(t, pointer, got)
+	}
+
+	{ // truncated pointers fail
+		data, err := proto.Marshal(pointer)
+		require.NoError(t, err)
+		err = UnmarshalPointerFields(data[:len(data)/2], &pb.Pointer{}, meta.Expiration)
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"storj.io/storj/internal/dburl"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/storage"
+)
+
+// expiresPrefix starts the keys of the expiration index, which sorts the
+// paths of expiring pointers by their expiration time. Like deleted pointers
+// the entries live outside of every listed prefix.
+const expiresPrefix = "\x00expires/"
+
+// expiresTimeLength is the length of the hex expiration time in the key of
+// an expiration index entry
+const expiresTimeLength = 16
+
+// ExpiredPointer is a pointer whose expiration time passed, or a stale
+// expiration index entry when Pointer is nil
+type ExpiredPointer struct {
+	Path      string
+	ExpiresAt time.Time
+	Pointer   *pb.Pointer
+
+	key   storage.Key
+	value storage.Value
+}
+
+// IsExpiresKey returns whether key is an expiration index entry rather than
+// a pointer, for callers iterating over the whole pointerdb
+func IsExpiresKey(key storage.Key) bool {
+	return bytes.HasPrefix(key, []byte(expiresPrefix))
+}
+
+// IsPointerKey returns whether key holds a live pointer, rather than a
+// deleted pointer, an expiration index entry or a bucket record
+func IsPointerKey(key storage.Key) bool {
+	return !IsDeletedKey(key) && !IsExpiresKey(key) && !IsBucketKey(key)
+}
+
+func expiresKey(path string, expiresAt time.Time) storage.Key {
+	return storage.Key(fmt.Sprintf("%s%016x/%s", expiresPrefix, expiresAt.UnixNano(), path))
+}
+
+// parseExpiresKey returns the path and the expiration time of an index entry
+func parseExpiresKey(key storage.Key) (path string, expiresAt time.Time, err error) {
+	if !IsExpiresKey(key) || len(key) < len(expiresPrefix)+expiresTimeLength+1 {
+		return "", time.Time{}, Error.New("invalid expires key %q", key)
+	}
+	timeEnd := len(expiresPrefix) + expiresTimeLength
+	nanos, err := strconv.ParseUint(string(key[len(expiresPrefix):timeEnd]), 16, 64)
+	if err != nil {
+		return "", time.Time{}, Error.New("invalid expires key %q: %v", key, err)
+	}
+	return string(key[timeEnd+1:]), time.Unix(0, int64(nanos)), nil
+}
+
+// pointerExpiration returns when pointer expires. Pointers of objects
+// without expiration have no or a zero expiration date.
+func pointerExpiration(pointer *pb.Pointer) (time.Time, bool) {
+	if pointer.GetExpirationDate() == nil || pointer.GetExpirationDate().Seconds <= 0 {
+		return time.Time{}, false
+	}
+	expiresAt, err := ptypes.Timestamp(pointer.GetExpirationDate())
+	if err != nil {
+		return time.Time{}, false
+	}
+	return expiresAt, true
+}
+
+// IndexesExpiration returns whether the pointers of the database at
+// databaseURL should be indexed by expiration time. Indexing costs a write
+// per expiring pointer, which pays off on postgres and cockroach where the
+// index is ordered by the primary key; bolt databases are scanned instead.
+func IndexesExpiration(databaseURL string) bool {
+	dbURL, err := dburl.Parse(databaseURL)
+	return err == nil && (dbURL.Driver == "postgres" || dbURL.Driver == "cockroach")
+}
+
+// SetExpirationIndex sets whether puts of expiring pointers add them to the
+// expiration index, so Expired doesn't need to scan all pointers. Pointers
+// put while the index was disabled aren't found when it's enabled. Must be
+// called before the service is used.
+func (s *Service) SetExpirationIndex(enabled bool) {
+	s.expirationIndex = enabled
+}
+
+// Expired returns up to limit pointers which expired by now
+func (s *Service) Expired(now time.Time, limit int) (expired []ExpiredPointer, err error) {
+	if s.expirationIndex {
+		return s.expiredIndexed(now, limit)
+	}
+	return s.expiredScanned(now, limit)
+}
+
+// expiredIndexed returns the expired pointers of the expiration index
+func (s *Service) expiredIndexed(now time.Time, limit int) (expired []ExpiredPointer, err error) {
+	var entries []ExpiredPointer
+	err = s.DB.Iterate(storage.IterateOptions{Prefix: storage.Key(expiresPrefix), Recurse: true},
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			for len(entries) < limit && it.Next(&item) {
+				path, expiresAt, err := parseExpiresKey(item.Key)
+				if err != nil {
+					return err
+				}
+				if expiresAt.After(now) {
+					return nil
+				}
+				entries = append(entries, ExpiredPointer{Path: path, ExpiresAt: expiresAt, key: storage.CloneKey(item.Key)})
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		value, err := s.DB.Get(storage.Key(entry.Path))
+		switch {
+		case storage.ErrKeyNotFound.Has(err):
+		case err != nil:
+			return nil, err
+		default:
+			pointer := &pb.Pointer{}
+			if err := UnmarshalPointer(value, pointer); err != nil {
+				return nil, Error.New("error unmarshaling pointer %q: %v", entry.Path, err)
+			}
+			// the entry is stale when another pointer was put at the path since
+			if expiresAt, ok := pointerExpiration(pointer); ok && expiresAt.Equal(entry.ExpiresAt) {
+				entry.Pointer, entry.value = pointer, value
+			}
+		}
+		expired = append(expired, entry)
+	}
+	return expired, nil
+}
+
+// expiredScanned returns the expired pointers found scanning all pointers
+func (s *Service) expiredScanned(now time.Time, limit int) (expired []ExpiredPointer, err error) {
+	err = s.DB.Iterate(storage.IterateOptions{Recurse: true},
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			for len(expired) < limit && it.Next(&item) {
+				if !IsPointerKey(item.Key) {
+					continue
+				}
+				pointer := &pb.Pointer{}
+				if err := UnmarshalPointer(item.Value, pointer); err != nil {
+					return Error.New("error unmarshaling pointer %q: %v", item.Key, err)
+				}
+				expiresAt, ok := pointerExpiration(pointer)
+				if !ok || expiresAt.After(now) {
+					continue
+				}
+				expired = append(expired, ExpiredPointer{
+					Path:      string(item.Key),
+					ExpiresAt: expiresAt,
+					Pointer:   pointer,
+					value:     storage.CloneValue(item.Value),
+				})
+			}
+			return nil
+		})
+	return expired, err
+}
+
+// DeleteExpired deletes the expired pointer like Delete, so its pieces are
+// purged after the undelete window, and drops its expiration index entry.
+// It returns false without deleting when the pointer was replaced since it
+// was found expired.
+func (s *Service) DeleteExpired(expired ExpiredPointer) (deleted bool, err error) {
+	if expired.Pointer != nil {
+		defer s.cache.Invalidate(expired.Path)
+
+		if err := s.logMutation(pb.PointerMutation_EXPIRE, expired.Path, expired.value, nil); err != nil {
+			return false, err
+		}
+
+		key := deletedKey(expired.Path, time.Now())
+		if err := s.DB.Put(key, expired.value); err != nil {
+			return false, err
+		}
+		err := s.DB.CompareAndSwap(storage.Key(expired.Path), expired.value, nil)
+		switch {
+		case storage.ErrValueChanged.Has(err):
+			if err := s.DB.Delete(key); err != nil {
+				return false, err
+			}
+		case err != nil:
+			return false, err
+		default:
+			deleted = true
+		}
+	}
+
+	if expired.key != nil {
+		if err := s.DB.Delete(expired.key); err != nil && !storage.ErrKeyNotFound.Has(err) {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
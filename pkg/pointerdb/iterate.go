@@ -0,0 +1,119 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb
+
+import (
+	"context"
+
+	"storj.io/storj/internal/keyrange"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storage/meta"
+	"storj.io/storj/storage"
+)
+
+// PointerItem is a pointer and its path
+type PointerItem struct {
+	Path    string
+	Pointer *pb.Pointer
+}
+
+// IterateOptions are the options of iterating pointers
+type IterateOptions struct {
+	Prefix     string         // Prefix limits the iteration to the paths starting with it
+	StartAfter string         // StartAfter is the path the iteration resumes after
+	Range      keyrange.Range // Range limits the iteration to a range of paths, e.g. of keyrange.Split
+	BatchSize  int            // BatchSize is capped to storage.LookupLimit
+	Limit      int            // Limit stops the iteration after as many pointers, 0 iterates all
+	// MetaFlags select the fields of the pointers which are parsed, 0 parses
+	// all of them
+	MetaFlags uint32
+}
+
+// IteratePointers streams the live pointers in key order to fn, a batch at a
+// time. The store is read one batch at a time, so fn may take its time and
+// only a batch is held in memory. The iteration stops at the first error
+// returned by fn.
+func (s *Service) IteratePointers(ctx context.Context, opts IterateOptions, fn func(ctx context.Context, batch []PointerItem) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 || batchSize > storage.LookupLimit {
+		batchSize = storage.LookupLimit
+	}
+
+	after := opts.StartAfter
+	remaining := opts.Limit
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if opts.Limit > 0 && remaining < batchSize {
+			batchSize = remaining
+		}
+
+		batch, more, err := s.readBatch(opts.Prefix, opts.Range, after, batchSize, opts.MetaFlags)
+		if err != nil {
+			return err
+		}
+		if len(batch) > 0 {
+			if err := fn(ctx, batch); err != nil {
+				return err
+			}
+			after = batch[len(batch)-1].Path
+			remaining -= len(batch)
+			mon.IntVal("iterate_batch_size").Observe(int64(len(batch)))
+		}
+		if !more || (opts.Limit > 0 && remaining <= 0) {
+			return nil
+		}
+	}
+}
+
+// readBatch reads up to limit pointers with prefix in r after the path
+// after, parsing the fields selected by metaFlags. more tells whether the
+// store has more keys.
+func (s *Service) readBatch(prefix string, r keyrange.Range, after string, limit int, metaFlags uint32) (batch []PointerItem, more bool, err error) {
+	if metaFlags == meta.None {
+		metaFlags = meta.All
+	}
+
+	first := storage.Key(prefix)
+	if r.Start > first.String() {
+		first = storage.Key(r.Start)
+	}
+	if after != "" && after > first.String() {
+		first = storage.Key(after)
+	}
+
+	err = s.DB.Iterate(storage.IterateOptions{
+		Prefix:  storage.Key(prefix),
+		First:   first,
+		Recurse: true,
+	}, func(it storage.Iterator) error {
+		var item storage.ListItem
+		for it.Next(&item) {
+			if r.End != "" && item.Key.String() >= r.End {
+				return nil
+			}
+			if len(batch) >= limit {
+				more = true
+				return nil
+			}
+			if after != "" && item.Key.String() == after {
+				continue
+			}
+			if !IsPointerKey(item.Key) {
+				continue
+			}
+
+			pointer := &pb.Pointer{}
+			if err := UnmarshalPointerFields(item.Value, pointer, metaFlags); err != nil {
+				return Error.New("error unmarshaling pointer %q: %v", item.Key, err)
+			}
+			batch = append(batch, PointerItem{Path: item.Key.String(), Pointer: pointer})
+		}
+		return nil
+	})
+	return batch, more, err
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/storj/internal/keyrange"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/storage/teststore"
+)
+
+func TestIteratePointers(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(zap.NewNop(), teststore.New())
+
+	for _, path := range []string{"l/a/1", "l/a/2", "l/a/3", "l/b/1", "l/b/2"} {
+		require.NoError(t, service.Put(path, &pb.Pointer{InlineSegment: []byte(path)}))
+	}
+	require.NoError(t, service.Delete("l/a/2"))
+
+	iterate := func(opts IterateOptions) (paths []string, batches int) {
+		err := service.IteratePointers(ctx, opts, func(ctx context.Context, batch []PointerItem) error {
+			for _, item := range batch {
+				assert.Equal(t, item.Path, string(item.Pointer.InlineSegment))
+				paths = append(paths, item.Path)
+			}
+			batches++
+			return nil
+		})
+		require.NoError(t, err)
+		return paths, batches
+	}
+
+	{ // live pointers are streamed in key order, a batch at a time
+		paths, batches := iterate(IterateOptions{BatchSize: 2})
+		assert.Equal(t, []string{"l/a/1", "l/a/3", "l/b/1", "l/b/2"}, paths)
+		assert.Equal(t, 2, batches)
+	}
+
+	{ // the iteration is limited to a prefix and resumes after a path
+		paths, _ := iterate(IterateOptions{Prefix: "l/a/"})
+		assert.Equal(t, []string{"l/a/1", "l/a/3"}, paths)
+
+		paths, _ = iterate(IterateOptions{StartAfter: "l/a/3"})
+		assert.Equal(t, []string{"l/b/1", "l/b/2"}, paths)
+
+		paths, _ = iterate(IterateOptions{Limit: 3, BatchSize: 2})
+		assert.Equal(t, []string{"l/a/1", "l/a/3", "l/b/1"}, paths)
+	}
+
+	{ // the ranges of a split keyspace are iterated on their own
+		var all []string
+		for _, r := range keyrange.Split("l/", 3) {
+			paths, _ := iterate(IterateOptions{Prefix: "l/", Range: r, BatchSize: 1})
+			all = append(all, paths...)
+		}
+		assert.Equal(t, []string{"l/a/1", "l/a/3", "l/b/1", "l/b/2"}, all)
+
+		paths, _ := iterate(IterateOptions{Range: keyrange.Range{Start: "l/a/3", End: "l/b/2"}})
+		assert.Equal(t, []string{"l/a/3", "l/b/1"}, paths)
+	}
+
+	{ // an error of the callback stops the iteration
+		stop := errors.New("stop")
+		batches := 0
+		err := service.IteratePointers(ctx, IterateOptions{BatchSize: 1}, func(ctx context.Context, batch []PointerItem) error {
+			batches++
+			return stop
+		})
+		assert.Equal(t, stop, err)
+		assert.Equal(t, 1, batches)
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pdbclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	"storj.io/storj/pkg/pb"
+)
+
+// AllocationCacheConfig configures how many upload allocations are fetched
+// ahead of time
+type AllocationCacheConfig struct {
+	Size   int           `help:"the number of upload allocations fetched ahead of time, so uploads continue while the satellite is briefly unavailable, 0 disables caching" default:"4"`
+	MaxAge time.Duration `help:"how long upload allocations fetched ahead of time are used" default:"10m0s"`
+}
+
+// AllocationCache is a Client which keeps signed PUT allocations at hand.
+// Every allocation is handed out once, since storage nodes settle each
+// serial number only once.
+type AllocationCache struct {
+	Client
+	config AllocationCacheConfig
+	now    func() time.Time
+
+	mu     sync.Mutex
+	cached []cachedAllocation
+}
+
+// cachedAllocation is a fetched allocation with the limits it was signed with
+type cachedAllocation struct {
+	pba     *pb.PayerBandwidthAllocation
+	limits  allocationLimits
+	expires time.Time
+}
+
+// allocationLimits are the limits of an allocation which invalidate the
+// cached allocations when the satellite changes them
+type allocationLimits struct {
+	maxSize int64
+	ttl     int64
+}
+
+// NewAllocationCache returns a Client which fetches upload allocations of
+// client ahead of time
+func NewAllocationCache(client Client, config AllocationCacheConfig) *AllocationCache {
+	return &AllocationCache{
+		Client: client,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+// PayerBandwidthAllocation returns a cached allocation for uploads and
+// refills the cache. Fetching errors are only returned when no cached
+// allocation is left.
+func (cache *AllocationCache) PayerBandwidthAllocation(ctx context.Context, action pb.PayerBandwidthAllocation_Action) (_ *pb.PayerBandwidthAllocation, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if action != pb.PayerBandwidthAllocation_PUT || cache.config.Size <= 0 {
+		return cache.Client.PayerBandwidthAllocation(ctx, action)
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	fillErr := cache.fill(ctx)
+	if len(cache.cached) == 0 {
+		if fillErr == nil {
+			fillErr = Error.New("no upload allocation available")
+		}
+		return nil, fillErr
+	}
+	if fillErr != nil {
+		mon.Counter("cached_allocation_used_offline").Inc(1)
+	}
+
+	pba := cache.cached[0].pba
+	cache.cached = cache.cached[1:]
+	return pba, nil
+}
+
+// Invalidate drops all cached allocations
+func (cache *AllocationCache) Invalidate() {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.cached = nil
+}
+
+// fill drops expired allocations and fetches new ones until the cache is
+// full or fetching fails
+func (cache *AllocationCache) fill(ctx context.Context) error {
+	now := cache.now()
+
+	usable := cache.cached[:0]
+	for _, cached := range cache.cached {
+		if now.Before(cached.expires) {
+			usable = append(usable, cached)
+		}
+	}
+	cache.cached = usable
+
+	for len(cache.cached) < cache.config.Size {
+		pba, err := cache.Client.PayerBandwidthAllocation(ctx, pb.PayerBandwidthAllocation_PUT)
+		if err != nil {
+			return err
+		}
+
+		fetched, err := cache.newCachedAllocation(pba, cache.now())
+		if err != nil {
+			return err
+		}
+
+		// allocations signed with other limits are outdated
+		if len(cache.cached) > 0 && cache.cached[0].limits != fetched.limits {
+			mon.Counter("cached_allocations_invalidated").Inc(int64(len(cache.cached)))
+			cache.cached = cache.cached[:0]
+		}
+		cache.cached = append(cache.cached, fetched)
+	}
+	return nil
+}
+
+// newCachedAllocation parses the allocation data, the allocation expires
+// after the configured age or at its own expiration, whichever is earlier
+func (cache *AllocationCache) newCachedAllocation(pba *pb.PayerBandwidthAllocation, fetched time.Time) (cachedAllocation, error) {
+	var data pb.PayerBandwidthAllocation_Data
+	if err := proto.Unmarshal(pba.GetData(), &data); err != nil {
+		return cachedAllocation{}, Error.Wrap(err)
+	}
+
+	expires := fetched.Add(cache.config.MaxAge)
+	if data.ExpirationUnixSec > 0 {
+		if expiration := time.Unix(data.ExpirationUnixSec, 0); expiration.Before(expires) {
+			expires = expiration
+		}
+	}
+
+	return cachedAllocation{
+		pba: pba,
+		limits: allocationLimits{
+			maxSize: data.MaxSize,
+			ttl:     data.ExpirationUnixSec - data.CreatedUnixSec,
+		},
+		expires: expires,
+	}, nil
+}
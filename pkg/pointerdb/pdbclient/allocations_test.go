@@ -0,0 +1,167 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pdbclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/pkg/pb"
+)
+
+// allocatingClient hands out allocations with increasing serial numbers
+type allocatingClient struct {
+	Client
+	now     func() time.Time
+	maxSize int64
+	ttl     time.Duration
+	err     error
+	serial  int
+	calls   int
+}
+
+func (client *allocatingClient) PayerBandwidthAllocation(ctx context.Context, action pb.PayerBandwidthAllocation_Action) (*pb.PayerBandwidthAllocation, error) {
+	client.calls++
+	if client.err != nil {
+		return nil, client.err
+	}
+
+	client.serial++
+	created := client.now()
+	data, err := proto.Marshal(&pb.PayerBandwidthAllocation_Data{
+		Action:            action,
+		MaxSize:           client.maxSize,
+		SerialNumber:      string(rune('a' + client.serial - 1)),
+		CreatedUnixSec:    created.Unix(),
+		ExpirationUnixSec: created.Add(client.ttl).Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PayerBandwidthAllocation{Data: data}, nil
+}
+
+func serialNumber(t *testing.T, pba *pb.PayerBandwidthAllocation) string {
+	var data pb.PayerBandwidthAllocation_Data
+	require.NoError(t, proto.Unmarshal(pba.GetData(), &data))
+	return data.SerialNumber
+}
+
+func newTestAllocationCache(size int, maxAge, ttl time.Duration) (*AllocationCache, *allocatingClient, *time.Time) {
+	now := time.Unix(1000, 0)
+	clock := func() time.Time { return now }
+	client := &allocatingClient{now: clock, maxSize: 64, ttl: ttl}
+	cache := NewAllocationCache(client, AllocationCacheConfig{Size: size, MaxAge: maxAge})
+	cache.now = clock
+	return cache, client, &now
+}
+
+func TestAllocationCache(t *testing.T) {
+	ctx := context.Background()
+	put := pb.PayerBandwidthAllocation_PUT
+
+	{ // allocations are prefetched and each one is used once, oldest first
+		cache, client, _ := newTestAllocationCache(3, time.Minute, time.Hour)
+
+		pba, err := cache.PayerBandwidthAllocation(ctx, put)
+		require.NoError(t, err)
+		assert.Equal(t, "a", serialNumber(t, pba))
+		assert.Equal(t, 3, client.calls)
+
+		pba, err = cache.PayerBandwidthAllocation(ctx, put)
+		require.NoError(t, err)
+		assert.Equal(t, "b", serialNumber(t, pba))
+		assert.Equal(t, 4, client.calls)
+	}
+
+	{ // cached allocations are used while the satellite is unavailable
+		cache, client, _ := newTestAllocationCache(2, time.Minute, time.Hour)
+
+		_, err := cache.PayerBandwidthAllocation(ctx, put)
+		require.NoError(t, err)
+
+		client.err = errors.New("satellite unavailable")
+		pba, err := cache.PayerBandwidthAllocation(ctx, put)
+		require.NoError(t, err)
+		assert.Equal(t, "b", serialNumber(t, pba))
+
+		_, err = cache.PayerBandwidthAllocation(ctx, put)
+		assert.EqualError(t, err, "satellite unavailable")
+
+		client.err = nil
+		pba, err = cache.PayerBandwidthAllocation(ctx, put)
+		require.NoError(t, err)
+		assert.Equal(t, "c", serialNumber(t, pba))
+	}
+
+	{ // allocations expire after the configured age, the boundary included
+		cache, client, now := newTestAllocationCache(2, time.Minute, time.Hour)
+
+		_, err := cache.PayerBandwidthAllocation(ctx, put)
+		require.NoError(t, err)
+
+		client.err = errors.New("satellite unavailable")
+		*now = now.Add(time.Minute - time.Second)
+		pba, err := cache.PayerBandwidthAllocation(ctx, put)
+		require.NoError(t, err)
+		assert.Equal(t, "b", serialNumber(t, pba))
+
+		cache, client, now = newTestAllocationCache(2, time.Minute, time.Hour)
+		_, err = cache.PayerBandwidthAllocation(ctx, put)
+		require.NoError(t, err)
+
+		client.err = errors.New("satellite unavailable")
+		*now = now.Add(time.Minute)
+		_, err = cache.PayerBandwidthAllocation(ctx, put)
+		assert.Error(t, err)
+	}
+
+	{ // allocations expire with their own expiration when it is earlier
+		cache, client, now := newTestAllocationCache(2, time.Hour, time.Minute)
+
+		_, err := cache.PayerBandwidthAllocation(ctx, put)
+		require.NoError(t, err)
+
+		*now = now.Add(time.Minute)
+		pba, err := cache.PayerBandwidthAllocation(ctx, put)
+		require.NoError(t, err)
+		assert.Equal(t, "c", serialNumber(t, pba))
+		assert.Equal(t, 4, client.calls)
+	}
+
+	{ // changed limits invalidate the cached allocations
+		cache, client, _ := newTestAllocationCache(3, time.Minute, time.Hour)
+
+		_, err := cache.PayerBandwidthAllocation(ctx, put)
+		require.NoError(t, err)
+
+		client.maxSize = 128
+		pba, err := cache.PayerBandwidthAllocation(ctx, put)
+		require.NoError(t, err)
+		assert.Equal(t, "d", serialNumber(t, pba))
+
+		cache.Invalidate()
+		pba, err = cache.PayerBandwidthAllocation(ctx, put)
+		require.NoError(t, err)
+		assert.Equal(t, "g", serialNumber(t, pba))
+	}
+
+	{ // other actions and disabled caches aren't cached
+		cache, client, _ := newTestAllocationCache(3, time.Minute, time.Hour)
+		_, err := cache.PayerBandwidthAllocation(ctx, pb.PayerBandwidthAllocation_GET)
+		require.NoError(t, err)
+		assert.Equal(t, 1, client.calls)
+
+		cache, client, _ = newTestAllocationCache(0, time.Minute, time.Hour)
+		_, err = cache.PayerBandwidthAllocation(ctx, put)
+		require.NoError(t, err)
+		assert.Equal(t, 1, client.calls)
+	}
+}
@@ -5,17 +5,21 @@ package pdbclient
 
 import (
 	"context"
+	"io"
 	"sync/atomic"
 	"unsafe"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
 	"storj.io/storj/pkg/auth/grpcauth"
+	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/storage/meta"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/transport"
 	"storj.io/storj/storage"
@@ -50,11 +54,16 @@ type ListItem struct {
 type Client interface {
 	Put(ctx context.Context, path storj.Path, pointer *pb.Pointer) error
 	Get(ctx context.Context, path storj.Path) (*pb.Pointer, []*pb.Node, *pb.PayerBandwidthAllocation, error)
+	ObjectMeta(ctx context.Context, path storj.Path) (*pb.ObjectMetaResponse, error)
 	List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error)
+	ListStream(ctx context.Context, prefix, startAfter storj.Path, recursive bool, pageSize int, metaFlags uint32, fn func(items []ListItem, more bool) error) error
 	Delete(ctx context.Context, path storj.Path) error
+	Undelete(ctx context.Context, path storj.Path) error
 
 	SignedMessage() *pb.SignedMessage
 	PayerBandwidthAllocation(context.Context, pb.PayerBandwidthAllocation_Action) (*pb.PayerBandwidthAllocation, error)
+	SegmentLimits(ctx context.Context) (minSize, maxSize int64, err error)
+	PrefixUsage(ctx context.Context, prefix storj.Path, limit int) (*pb.PrefixUsageResponse, error)
 
 	// Disconnect() error // TODO: implement
 }
@@ -87,13 +96,41 @@ func (pdb *PointerDB) Put(ctx context.Context, path storj.Path, pointer *pb.Poin
 	return err
 }
 
+// PutIfVersion puts the pointer when the stored pointer at path has version,
+// version 0 expects no pointer at path. It returns the new version.
+func (pdb *PointerDB) PutIfVersion(ctx context.Context, path storj.Path, pointer *pb.Pointer, version int64) (newVersion int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	res, err := pdb.client.Put(ctx, &pb.PutRequest{
+		Path:            path,
+		Pointer:         pointer,
+		Conditional:     true,
+		ExpectedVersion: version,
+	})
+	if err != nil {
+		if status.Code(err) == codes.Aborted {
+			return 0, ErrVersionChanged.Wrap(err)
+		}
+		return 0, Error.Wrap(err)
+	}
+	return res.GetVersion(), nil
+}
+
 // Get is the interface to make a GET request, needs PATH and APIKey
 func (pdb *PointerDB) Get(ctx context.Context, path storj.Path) (pointer *pb.Pointer, nodes []*pb.Node, pba *pb.PayerBandwidthAllocation, err error) {
+	return pdb.GetFields(ctx, path, meta.None)
+}
+
+// GetFields is Get returning only the fields of the pointer selected by
+// metaFlags, all of them with meta.None. The nodes are only returned along
+// with the remote segment.
+func (pdb *PointerDB) GetFields(ctx context.Context, path storj.Path, metaFlags uint32) (pointer *pb.Pointer, nodes []*pb.Node, pba *pb.PayerBandwidthAllocation, err error) {
 	defer mon.Task()(&ctx)(&err)
 	for _, v := range nodes {
 		v.Type.DPanicOnInvalid("pdb Get")
 	}
-	res, err := pdb.client.Get(ctx, &pb.GetRequest{Path: path})
+	var satellite peer.Peer
+	res, err := pdb.client.Get(ctx, &pb.GetRequest{Path: path, MetaFlags: metaFlags}, grpc.Peer(&satellite))
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
 			return nil, nil, nil, storage.ErrKeyNotFound.Wrap(err)
@@ -101,17 +138,45 @@ func (pdb *PointerDB) Get(ctx context.Context, path storj.Path) (pointer *pb.Poi
 		return nil, nil, nil, Error.Wrap(err)
 	}
 
+	// only dial the nodes storing the pieces
+	if len(res.GetNodes()) > 0 {
+		if err := overlay.VerifyPeerNodeAddresses(&satellite, res.GetSignedAddresses(), res.GetNodes()); err != nil {
+			return nil, nil, nil, Error.Wrap(err)
+		}
+	}
+
 	atomic.StorePointer(&pdb.authorization, unsafe.Pointer(res.GetAuthorization()))
 
 	return res.GetPointer(), res.GetNodes(), res.GetPba(), nil
 }
 
+// ObjectMeta returns the metadata of the last segment of an object at path,
+// without anything needed to download it
+func (pdb *PointerDB) ObjectMeta(ctx context.Context, path storj.Path) (resp *pb.ObjectMetaResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	resp, err = pdb.client.ObjectMeta(ctx, &pb.ObjectMetaRequest{Path: path})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, storage.ErrKeyNotFound.Wrap(err)
+		}
+		return nil, Error.Wrap(err)
+	}
+	return resp, nil
+}
+
 // List is the interface to make a LIST request, needs StartingPathKey, Limit, and APIKey
 func (pdb *PointerDB) List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error) {
+	return pdb.ListWithNamePrefix(ctx, prefix, "", startAfter, endBefore, recursive, limit, metaFlags)
+}
+
+// ListWithNamePrefix is List limited to the paths starting with prefix/namePrefix
+func (pdb *PointerDB) ListWithNamePrefix(ctx context.Context, prefix storj.Path, namePrefix string, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	res, err := pdb.client.List(ctx, &pb.ListRequest{
 		Prefix:     prefix,
+		NamePrefix: namePrefix,
 		StartAfter: startAfter,
 		EndBefore:  endBefore,
 		Recursive:  recursive,
@@ -135,15 +200,131 @@ func (pdb *PointerDB) List(ctx context.Context, prefix, startAfter, endBefore st
 	return items, res.GetMore(), nil
 }
 
+// ListStream lists all the paths after startAfter in prefix in pages of
+// pageSize items, which are passed to fn as they're received. Unlike List it
+// doesn't hold the whole listing in memory. The listing stops at the first
+// error returned by fn.
+func (pdb *PointerDB) ListStream(ctx context.Context, prefix, startAfter storj.Path, recursive bool, pageSize int, metaFlags uint32, fn func(items []ListItem, more bool) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := pdb.client.ListStream(ctx, &pb.ListRequest{
+		Prefix:     prefix,
+		StartAfter: startAfter,
+		Recursive:  recursive,
+		Limit:      int32(pageSize),
+		MetaFlags:  metaFlags,
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		list := res.GetItems()
+		items := make([]ListItem, len(list))
+		for i, itm := range list {
+			items[i] = ListItem{
+				Path:     itm.GetPath(),
+				Pointer:  itm.GetPointer(),
+				IsPrefix: itm.IsPrefix,
+			}
+		}
+		if err := fn(items, res.GetMore()); err != nil {
+			return err
+		}
+	}
+}
+
 // Delete is the interface to make a Delete request, needs Path and APIKey
 func (pdb *PointerDB) Delete(ctx context.Context, path storj.Path) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	_, err = pdb.client.Delete(ctx, &pb.DeleteRequest{Path: path})
+	if status.Code(err) == codes.NotFound {
+		return storage.ErrKeyNotFound.Wrap(err)
+	}
+
+	return err
+}
+
+// Undelete restores a deleted pointer until the satellite purged its pieces
+func (pdb *PointerDB) Undelete(ctx context.Context, path storj.Path) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = pdb.client.Undelete(ctx, &pb.UndeleteRequest{Path: path})
+	if status.Code(err) == codes.NotFound {
+		return storage.ErrKeyNotFound.Wrap(err)
+	}
 
 	return err
 }
 
+// BatchGet gets the pointers of paths in one request, the pointer of a
+// missing path is nil
+func (pdb *PointerDB) BatchGet(ctx context.Context, paths []storj.Path) (pointers []*pb.Pointer, err error) {
+	return pdb.BatchGetFields(ctx, paths, meta.None)
+}
+
+// BatchGetFields is BatchGet returning only the fields of the pointers
+// selected by metaFlags, all of them with meta.None
+func (pdb *PointerDB) BatchGetFields(ctx context.Context, paths []storj.Path, metaFlags uint32) (pointers []*pb.Pointer, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	res, err := pdb.client.BatchGet(ctx, &pb.BatchGetRequest{Paths: paths, MetaFlags: metaFlags})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if len(res.GetItems()) != len(paths) {
+		return nil, Error.New("got %d pointers for %d paths", len(res.GetItems()), len(paths))
+	}
+
+	pointers = make([]*pb.Pointer, len(paths))
+	for i, item := range res.GetItems() {
+		pointers[i] = item.GetPointer()
+	}
+	return pointers, nil
+}
+
+// BatchPut puts pointers[i] at paths[i] in one request, either all pointers
+// are put or none
+func (pdb *PointerDB) BatchPut(ctx context.Context, paths []storj.Path, pointers []*pb.Pointer) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(paths) != len(pointers) {
+		return Error.New("got %d paths for %d pointers", len(paths), len(pointers))
+	}
+
+	req := &pb.BatchPutRequest{Items: make([]*pb.BatchPutRequest_Item, len(paths))}
+	for i, path := range paths {
+		req.Items[i] = &pb.BatchPutRequest_Item{Path: path, Pointer: pointers[i]}
+	}
+
+	_, err = pdb.client.BatchPut(ctx, req)
+	return Error.Wrap(err)
+}
+
+// BatchDelete deletes the pointers at paths in one request, either all
+// pointers are deleted or none. The missing paths are returned.
+func (pdb *PointerDB) BatchDelete(ctx context.Context, paths []storj.Path) (notFound []storj.Path, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	res, err := pdb.client.BatchDelete(ctx, &pb.BatchDeleteRequest{Paths: paths})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return res.GetNotFound(), nil
+}
+
 // PayerBandwidthAllocation gets payer bandwidth allocation message
 func (pdb *PointerDB) PayerBandwidthAllocation(ctx context.Context, action pb.PayerBandwidthAllocation_Action) (resp *pb.PayerBandwidthAllocation, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -155,7 +336,78 @@ func (pdb *PointerDB) PayerBandwidthAllocation(ctx context.Context, action pb.Pa
 	return response.GetPba(), nil
 }
 
+// SegmentLimits gets the segment sizes the satellite allows uplinks to choose from
+func (pdb *PointerDB) SegmentLimits(ctx context.Context) (minSize, maxSize int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	response, err := pdb.client.SegmentLimits(ctx, &pb.SegmentLimitsRequest{})
+	if err != nil {
+		return 0, 0, Error.Wrap(err)
+	}
+	return response.GetMinSegmentSize(), response.GetMaxSegmentSize(), nil
+}
+
 // SignedMessage gets signed message from last request
 func (pdb *PointerDB) SignedMessage() *pb.SignedMessage {
 	return (*pb.SignedMessage)(atomic.LoadPointer(&pdb.authorization))
 }
+
+// PrefixUsage aggregates the sizes of the committed objects below prefix and
+// of up to limit of its directories
+func (pdb *PointerDB) PrefixUsage(ctx context.Context, prefix storj.Path, limit int) (resp *pb.PrefixUsageResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	resp, err = pdb.client.PrefixUsage(ctx, &pb.PrefixUsageRequest{Prefix: prefix, Limit: int32(limit)})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return resp, nil
+}
+
+// CreateBucket creates the record of a bucket, the created record is returned
+func (pdb *PointerDB) CreateBucket(ctx context.Context, bucket *pb.BucketInfo) (created *pb.BucketInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	resp, err := pdb.client.CreateBucket(ctx, &pb.BucketCreateRequest{Bucket: bucket})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return resp.GetBucket(), nil
+}
+
+// GetBucket gets the record of a bucket
+func (pdb *PointerDB) GetBucket(ctx context.Context, name string) (bucket *pb.BucketInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	resp, err := pdb.client.GetBucket(ctx, &pb.BucketGetRequest{Name: name})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, storage.ErrKeyNotFound.Wrap(err)
+		}
+		return nil, Error.Wrap(err)
+	}
+	return resp.GetBucket(), nil
+}
+
+// DeleteBucket deletes the record of a bucket without objects
+func (pdb *PointerDB) DeleteBucket(ctx context.Context, name string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = pdb.client.DeleteBucket(ctx, &pb.BucketDeleteRequest{Name: name})
+	if status.Code(err) == codes.NotFound {
+		return storage.ErrKeyNotFound.Wrap(err)
+	}
+	return Error.Wrap(err)
+}
+
+// ListBuckets lists up to limit bucket records in name order, starting after
+// the bucket startAfter
+func (pdb *PointerDB) ListBuckets(ctx context.Context, startAfter string, limit int) (buckets []*pb.BucketInfo, more bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	resp, err := pdb.client.ListBuckets(ctx, &pb.BucketListRequest{StartAfter: startAfter, Limit: int32(limit)})
+	if err != nil {
+		return nil, false, Error.Wrap(err)
+	}
+	return resp.GetBuckets(), resp.GetMore(), nil
+}
@@ -153,7 +153,7 @@ func TestGet(t *testing.T) {
 		gc := NewMockPointerDBClient(ctrl)
 		pdb := PointerDB{client: gc}
 
-		gc.EXPECT().Get(gomock.Any(), &getRequest).Return(&getResponse, tt.err)
+		gc.EXPECT().Get(gomock.Any(), &getRequest, gomock.Any()).Return(&getResponse, tt.err)
 
 		pointer, nodes, pba, err := pdb.Get(ctx, tt.path)
 		for _, v := range nodes {
@@ -9,3 +9,7 @@ import (
 
 // Error is the pdbclient error class
 var Error = errs.Class("pointerdb client error")
+
+// ErrVersionChanged is returned by PutIfVersion when the pointer doesn't have
+// the expected version anymore
+var ErrVersionChanged = errs.Class("pointer version changed")
@@ -78,6 +78,18 @@ func (mr *MockClientMockRecorder) List(arg0, arg1, arg2, arg3, arg4, arg5, arg6
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockClient)(nil).List), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
+// ListStream mocks base method
+func (m *MockClient) ListStream(arg0 context.Context, arg1, arg2 string, arg3 bool, arg4 int, arg5 uint32, arg6 func([]pdbclient.ListItem, bool) error) error {
+	ret := m.ctrl.Call(m, "ListStream", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListStream indicates an expected call of ListStream
+func (mr *MockClientMockRecorder) ListStream(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStream", reflect.TypeOf((*MockClient)(nil).ListStream), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
 // PayerBandwidthAllocation mocks base method
 func (m *MockClient) PayerBandwidthAllocation(arg0 context.Context, arg1 pb.PayerBandwidthAllocation_Action) (*pb.PayerBandwidthAllocation, error) {
 	ret := m.ctrl.Call(m, "PayerBandwidthAllocation", arg0, arg1)
@@ -103,6 +115,46 @@ func (mr *MockClientMockRecorder) Put(arg0, arg1, arg2 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockClient)(nil).Put), arg0, arg1, arg2)
 }
 
+// ObjectMeta mocks base method
+func (m *MockClient) ObjectMeta(arg0 context.Context, arg1 string) (*pb.ObjectMetaResponse, error) {
+	ret := m.ctrl.Call(m, "ObjectMeta", arg0, arg1)
+	ret0, _ := ret[0].(*pb.ObjectMetaResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ObjectMeta indicates an expected call of ObjectMeta
+func (mr *MockClientMockRecorder) ObjectMeta(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObjectMeta", reflect.TypeOf((*MockClient)(nil).ObjectMeta), arg0, arg1)
+}
+
+// PrefixUsage mocks base method
+func (m *MockClient) PrefixUsage(arg0 context.Context, arg1 string, arg2 int) (*pb.PrefixUsageResponse, error) {
+	ret := m.ctrl.Call(m, "PrefixUsage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*pb.PrefixUsageResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrefixUsage indicates an expected call of PrefixUsage
+func (mr *MockClientMockRecorder) PrefixUsage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrefixUsage", reflect.TypeOf((*MockClient)(nil).PrefixUsage), arg0, arg1, arg2)
+}
+
+// SegmentLimits mocks base method
+func (m *MockClient) SegmentLimits(arg0 context.Context) (int64, int64, error) {
+	ret := m.ctrl.Call(m, "SegmentLimits", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SegmentLimits indicates an expected call of SegmentLimits
+func (mr *MockClientMockRecorder) SegmentLimits(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SegmentLimits", reflect.TypeOf((*MockClient)(nil).SegmentLimits), arg0)
+}
+
 // SignedMessage mocks base method
 func (m *MockClient) SignedMessage() *pb.SignedMessage {
 	ret := m.ctrl.Call(m, "SignedMessage")
@@ -114,3 +166,15 @@ func (m *MockClient) SignedMessage() *pb.SignedMessage {
 func (mr *MockClientMockRecorder) SignedMessage() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignedMessage", reflect.TypeOf((*MockClient)(nil).SignedMessage))
 }
+
+// Undelete mocks base method
+func (m *MockClient) Undelete(arg0 context.Context, arg1 string) error {
+	ret := m.ctrl.Call(m, "Undelete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Undelete indicates an expected call of Undelete
+func (mr *MockClientMockRecorder) Undelete(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Undelete", reflect.TypeOf((*MockClient)(nil).Undelete), arg0, arg1)
+}
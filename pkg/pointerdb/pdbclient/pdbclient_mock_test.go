@@ -94,6 +94,24 @@ func (mr *MockPointerDBClientMockRecorder) List(arg0, arg1 interface{}, arg2 ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPointerDBClient)(nil).List), varargs...)
 }
 
+// ListStream mocks base method
+func (m *MockPointerDBClient) ListStream(arg0 context.Context, arg1 *pb.ListRequest, arg2 ...grpc.CallOption) (pb.PointerDB_ListStreamClient, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListStream", varargs...)
+	ret0, _ := ret[0].(pb.PointerDB_ListStreamClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStream indicates an expected call of ListStream
+func (mr *MockPointerDBClientMockRecorder) ListStream(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStream", reflect.TypeOf((*MockPointerDBClient)(nil).ListStream), varargs...)
+}
+
 // PayerBandwidthAllocation mocks base method
 func (m *MockPointerDBClient) PayerBandwidthAllocation(arg0 context.Context, arg1 *pb.PayerBandwidthAllocationRequest, arg2 ...grpc.CallOption) (*pb.PayerBandwidthAllocationResponse, error) {
 	varargs := []interface{}{arg0, arg1}
@@ -129,3 +147,201 @@ func (mr *MockPointerDBClientMockRecorder) Put(arg0, arg1 interface{}, arg2 ...i
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockPointerDBClient)(nil).Put), varargs...)
 }
+
+// ObjectMeta mocks base method
+func (m *MockPointerDBClient) ObjectMeta(arg0 context.Context, arg1 *pb.ObjectMetaRequest, arg2 ...grpc.CallOption) (*pb.ObjectMetaResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ObjectMeta", varargs...)
+	ret0, _ := ret[0].(*pb.ObjectMetaResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ObjectMeta indicates an expected call of ObjectMeta
+func (mr *MockPointerDBClientMockRecorder) ObjectMeta(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObjectMeta", reflect.TypeOf((*MockPointerDBClient)(nil).ObjectMeta), varargs...)
+}
+
+// PrefixUsage mocks base method
+func (m *MockPointerDBClient) PrefixUsage(arg0 context.Context, arg1 *pb.PrefixUsageRequest, arg2 ...grpc.CallOption) (*pb.PrefixUsageResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PrefixUsage", varargs...)
+	ret0, _ := ret[0].(*pb.PrefixUsageResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrefixUsage indicates an expected call of PrefixUsage
+func (mr *MockPointerDBClientMockRecorder) PrefixUsage(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrefixUsage", reflect.TypeOf((*MockPointerDBClient)(nil).PrefixUsage), varargs...)
+}
+
+// SegmentLimits mocks base method
+func (m *MockPointerDBClient) SegmentLimits(arg0 context.Context, arg1 *pb.SegmentLimitsRequest, arg2 ...grpc.CallOption) (*pb.SegmentLimitsResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SegmentLimits", varargs...)
+	ret0, _ := ret[0].(*pb.SegmentLimitsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SegmentLimits indicates an expected call of SegmentLimits
+func (mr *MockPointerDBClientMockRecorder) SegmentLimits(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SegmentLimits", reflect.TypeOf((*MockPointerDBClient)(nil).SegmentLimits), varargs...)
+}
+
+// Undelete mocks base method
+func (m *MockPointerDBClient) Undelete(arg0 context.Context, arg1 *pb.UndeleteRequest, arg2 ...grpc.CallOption) (*pb.UndeleteResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Undelete", varargs...)
+	ret0, _ := ret[0].(*pb.UndeleteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Undelete indicates an expected call of Undelete
+func (mr *MockPointerDBClientMockRecorder) Undelete(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Undelete", reflect.TypeOf((*MockPointerDBClient)(nil).Undelete), varargs...)
+}
+
+// BatchGet mocks base method
+func (m *MockPointerDBClient) BatchGet(arg0 context.Context, arg1 *pb.BatchGetRequest, arg2 ...grpc.CallOption) (*pb.BatchGetResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BatchGet", varargs...)
+	ret0, _ := ret[0].(*pb.BatchGetResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGet indicates an expected call of BatchGet
+func (mr *MockPointerDBClientMockRecorder) BatchGet(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGet", reflect.TypeOf((*MockPointerDBClient)(nil).BatchGet), varargs...)
+}
+
+// BatchPut mocks base method
+func (m *MockPointerDBClient) BatchPut(arg0 context.Context, arg1 *pb.BatchPutRequest, arg2 ...grpc.CallOption) (*pb.BatchPutResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BatchPut", varargs...)
+	ret0, _ := ret[0].(*pb.BatchPutResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchPut indicates an expected call of BatchPut
+func (mr *MockPointerDBClientMockRecorder) BatchPut(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchPut", reflect.TypeOf((*MockPointerDBClient)(nil).BatchPut), varargs...)
+}
+
+// BatchDelete mocks base method
+func (m *MockPointerDBClient) BatchDelete(arg0 context.Context, arg1 *pb.BatchDeleteRequest, arg2 ...grpc.CallOption) (*pb.BatchDeleteResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BatchDelete", varargs...)
+	ret0, _ := ret[0].(*pb.BatchDeleteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchDelete indicates an expected call of BatchDelete
+func (mr *MockPointerDBClientMockRecorder) BatchDelete(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDelete", reflect.TypeOf((*MockPointerDBClient)(nil).BatchDelete), varargs...)
+}
+
+// CreateBucket mocks base method
+func (m *MockPointerDBClient) CreateBucket(arg0 context.Context, arg1 *pb.BucketCreateRequest, arg2 ...grpc.CallOption) (*pb.BucketCreateResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateBucket", varargs...)
+	ret0, _ := ret[0].(*pb.BucketCreateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBucket indicates an expected call of CreateBucket
+func (mr *MockPointerDBClientMockRecorder) CreateBucket(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBucket", reflect.TypeOf((*MockPointerDBClient)(nil).CreateBucket), varargs...)
+}
+
+// GetBucket mocks base method
+func (m *MockPointerDBClient) GetBucket(arg0 context.Context, arg1 *pb.BucketGetRequest, arg2 ...grpc.CallOption) (*pb.BucketGetResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetBucket", varargs...)
+	ret0, _ := ret[0].(*pb.BucketGetResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBucket indicates an expected call of GetBucket
+func (mr *MockPointerDBClientMockRecorder) GetBucket(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBucket", reflect.TypeOf((*MockPointerDBClient)(nil).GetBucket), varargs...)
+}
+
+// DeleteBucket mocks base method
+func (m *MockPointerDBClient) DeleteBucket(arg0 context.Context, arg1 *pb.BucketDeleteRequest, arg2 ...grpc.CallOption) (*pb.BucketDeleteResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteBucket", varargs...)
+	ret0, _ := ret[0].(*pb.BucketDeleteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBucket indicates an expected call of DeleteBucket
+func (mr *MockPointerDBClientMockRecorder) DeleteBucket(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBucket", reflect.TypeOf((*MockPointerDBClient)(nil).DeleteBucket), varargs...)
+}
+
+// ListBuckets mocks base method
+func (m *MockPointerDBClient) ListBuckets(arg0 context.Context, arg1 *pb.BucketListRequest, arg2 ...grpc.CallOption) (*pb.BucketListResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListBuckets", varargs...)
+	ret0, _ := ret[0].(*pb.BucketListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBuckets indicates an expected call of ListBuckets
+func (mr *MockPointerDBClientMockRecorder) ListBuckets(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBuckets", reflect.TypeOf((*MockPointerDBClient)(nil).ListBuckets), varargs...)
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pdbclient_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/pointerdb/pdbclient"
+	"storj.io/storj/pkg/storage/meta"
+)
+
+func TestListStream(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	planet, err := testplanet.New(t, 1, 0, 1)
+	require.NoError(t, err)
+	defer ctx.Check(planet.Shutdown)
+
+	planet.Start(ctx)
+
+	client, err := planet.Uplinks[0].DialPointerDB(planet.Satellites[0], "apikey")
+	require.NoError(t, err)
+
+	var expected []string
+	for i := 0; i < 7; i++ {
+		path := fmt.Sprintf("l/bucket/object%d", i)
+		err := client.Put(ctx, path, &pb.Pointer{
+			Type:          pb.Pointer_INLINE,
+			InlineSegment: []byte(path),
+			SegmentSize:   int64(len(path)),
+		})
+		require.NoError(t, err)
+		expected = append(expected, path[len("l/bucket/"):])
+	}
+	require.NoError(t, client.Put(ctx, "l/other/object", &pb.Pointer{Type: pb.Pointer_INLINE, InlineSegment: []byte("other")}))
+
+	list := func(startAfter string, pageSize int) (paths []string, pages []int) {
+		hasMore := true
+		err := client.ListStream(ctx, "l/bucket", startAfter, true, pageSize, meta.All, func(items []pdbclient.ListItem, more bool) error {
+			assert.True(t, hasMore, "page after the last page")
+			for _, item := range items {
+				assert.NotNil(t, item.Pointer)
+				paths = append(paths, item.Path)
+			}
+			pages = append(pages, len(items))
+			hasMore = more
+			return nil
+		})
+		require.NoError(t, err)
+		assert.False(t, hasMore)
+		return paths, pages
+	}
+
+	{ // all paths are listed in pages
+		paths, pages := list("", 3)
+		assert.Equal(t, expected, paths)
+		assert.Equal(t, []int{3, 3, 1}, pages)
+	}
+
+	{ // listing resumes after a path
+		paths, pages := list("object4", 0)
+		assert.Equal(t, expected[5:], paths)
+		assert.Equal(t, []int{2}, pages)
+	}
+
+	{ // the listing stops at the first error
+		stop := errors.New("stop")
+		pages := 0
+		err := client.ListStream(ctx, "l/bucket", "", true, 2, meta.None, func(items []pdbclient.ListItem, more bool) error {
+			pages++
+			return stop
+		})
+		assert.Equal(t, stop, err)
+		assert.Equal(t, 1, pages)
+	}
+}
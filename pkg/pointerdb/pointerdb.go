@@ -5,6 +5,9 @@ package pointerdb
 
 import (
 	"context"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
@@ -13,10 +16,12 @@ import (
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
 	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	pointerdbAuth "storj.io/storj/pkg/pointerdb/auth"
 	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/storage/meta"
 	"storj.io/storj/storage"
 )
 
@@ -33,6 +38,7 @@ type Server struct {
 	cache      *overlay.Cache
 	config     Config
 	identity   *provider.FullIdentity
+	quota      *Quota
 }
 
 // NewServer creates instance of Server
@@ -47,6 +53,11 @@ func NewServer(logger *zap.Logger, service *Service, allocation *AllocationSigne
 	}
 }
 
+// SetQuota makes puts count against the quota of the caller's project
+func (s *Server) SetQuota(quota *Quota) {
+	s.quota = quota
+}
+
 // Close closes resources
 func (s *Server) Close() error { return nil }
 
@@ -67,17 +78,26 @@ func (s *Server) validateAuth(ctx context.Context) error {
 	return nil
 }
 
-func (s *Server) validateSegment(req *pb.PutRequest) error {
+// caller returns the service logging mutations for the calling peer
+func (s *Server) caller(ctx context.Context) *Service {
+	peer, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return s.service.WithCaller("")
+	}
+	return s.service.WithCaller(peer.ID.String())
+}
+
+func (s *Server) validateSegment(pointer *pb.Pointer) error {
 	min := s.config.MinRemoteSegmentSize
-	remote := req.GetPointer().Remote
-	remoteSize := req.GetPointer().GetSegmentSize()
+	remote := pointer.GetRemote()
+	remoteSize := pointer.GetSegmentSize()
 
 	if remote != nil && remoteSize < int64(min) {
 		return segmentError.New("remote segment size %d less than minimum allowed %d", remoteSize, min)
 	}
 
 	max := s.config.MaxInlineSegmentSize.Int()
-	inlineSize := len(req.GetPointer().InlineSegment)
+	inlineSize := len(pointer.GetInlineSegment())
 
 	if inlineSize > max {
 		return segmentError.New("inline segment size %d greater than maximum allowed %d", inlineSize, max)
@@ -86,25 +106,115 @@ func (s *Server) validateSegment(req *pb.PutRequest) error {
 	return nil
 }
 
+// reserveQuota reserves the storage of replacing the pointers at paths with
+// pointers in the quota of the caller's project, nil pointers free the
+// storage of deleted paths. The pointers are stamped with the project, so
+// the reaper can release them when they expire. The returned function undoes
+// the reservation when the mutation fails.
+func (s *Server) reserveQuota(ctx context.Context, paths []string, pointers []*pb.Pointer) (undo func(), err error) {
+	undo = func() {}
+	if s.quota == nil {
+		return undo, nil
+	}
+	projectID, ok := s.quota.Project(ctx)
+	if !ok {
+		return undo, nil
+	}
+
+	previous, err := s.service.GetAll(paths)
+	if err != nil {
+		return undo, err
+	}
+	for _, pointer := range pointers {
+		if pointer != nil {
+			pointer.ProjectId = projectID[:]
+		}
+	}
+
+	storedBytes, objects := pointersUsage(paths, pointers)
+	previousBytes, previousObjects := pointersUsage(paths, previous)
+	storedBytes, objects = storedBytes-previousBytes, objects-previousObjects
+
+	if err := s.quota.Reserve(ctx, projectID, storedBytes, objects); err != nil {
+		return undo, err
+	}
+	return func() {
+		if err := s.quota.Release(ctx, projectID, storedBytes, objects); err != nil {
+			s.logger.Error("err releasing quota", zap.Error(err))
+		}
+	}, nil
+}
+
+// reserveUndelete reserves the storage of the pointer undeleting path
+// restores, which stopped counting against the quota when it was deleted
+func (s *Server) reserveUndelete(ctx context.Context, path string) (undo func(), err error) {
+	undo = func() {}
+	if s.quota == nil {
+		return undo, nil
+	}
+	projectID, ok := s.quota.Project(ctx)
+	if !ok {
+		return undo, nil
+	}
+
+	pointer, err := s.service.GetDeleted(path)
+	if err != nil {
+		return undo, err
+	}
+	storedBytes, objects := pointerUsage(path, pointer)
+
+	if err := s.quota.Reserve(ctx, projectID, storedBytes, objects); err != nil {
+		return undo, err
+	}
+	return func() {
+		if err := s.quota.Release(ctx, projectID, storedBytes, objects); err != nil {
+			s.logger.Error("err releasing quota", zap.Error(err))
+		}
+	}, nil
+}
+
+// quotaError converts the error of reserving quota to a status
+func (s *Server) quotaError(err error) error {
+	if ErrQuotaExceeded.Has(err) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	s.logger.Error("err reserving quota", zap.Error(err))
+	return status.Error(codes.Internal, err.Error())
+}
+
 // Put formats and hands off a key/value (path/pointer) to be saved to boltdb
 func (s *Server) Put(ctx context.Context, req *pb.PutRequest) (resp *pb.PutResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	err = s.validateSegment(req)
+	err = s.validateSegment(req.GetPointer())
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	if err = s.validateAuth(ctx); err != nil {
 		return nil, err
 	}
 
-	if err = s.service.Put(req.GetPath(), req.GetPointer()); err != nil {
+	undo, err := s.reserveQuota(ctx, []string{req.GetPath()}, []*pb.Pointer{req.GetPointer()})
+	if err != nil {
+		return nil, s.quotaError(err)
+	}
+
+	if req.GetConditional() {
+		err = s.caller(ctx).PutIfVersion(req.GetPath(), req.GetPointer(), req.GetExpectedVersion())
+	} else {
+		err = s.caller(ctx).Put(req.GetPath(), req.GetPointer())
+	}
+	if err != nil {
+		undo()
+		if ErrVersionChanged.Has(err) {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
 		s.logger.Error("err putting pointer", zap.Error(err))
-		return nil, status.Errorf(codes.Internal, err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	return &pb.PutResponse{}, nil
+	return &pb.PutResponse{Version: req.GetPointer().GetVersion()}, nil
 }
 
 // Get formats and hands off a file path to get from boltdb
@@ -115,25 +225,28 @@ func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (resp *pb.GetRespo
 		return nil, err
 	}
 
-	pointer, err := s.service.Get(req.GetPath())
+	pointer, err := s.service.GetFields(req.GetPath(), requestedFields(req.GetMetaFlags()))
 	if err != nil {
-		if storage.ErrKeyNotFound.Has(err) {
-			return nil, status.Errorf(codes.NotFound, err.Error())
+		switch {
+		case storage.ErrKeyNotFound.Has(err):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case ErrPointerCorrupted.Has(err):
+			return nil, status.Error(codes.DataLoss, err.Error())
 		}
 		s.logger.Error("err getting pointer", zap.Error(err))
-		return nil, status.Errorf(codes.Internal, err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	pba, err := s.PayerBandwidthAllocation(ctx, &pb.PayerBandwidthAllocationRequest{Action: pb.PayerBandwidthAllocation_GET})
 	if err != nil {
 		s.logger.Error("err getting payer bandwidth allocation", zap.Error(err))
-		return nil, status.Errorf(codes.Internal, err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	authorization, err := s.getSignedMessage()
 	if err != nil {
 		s.logger.Error("err getting signed message", zap.Error(err))
-		return nil, status.Errorf(codes.Internal, err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	nodes := []*pb.Node{}
@@ -160,16 +273,62 @@ func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (resp *pb.GetRespo
 	for _, v := range nodes {
 		v.Type.DPanicOnInvalid("pdb server Get")
 	}
+	signed, err := overlay.SignNodeAddresses(s.identity, nodes, time.Now().Add(s.config.AddressValidity))
+	if err != nil {
+		s.logger.Error("err signing node addresses", zap.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
 	r = &pb.GetResponse{
-		Pointer:       pointer,
-		Nodes:         nodes,
-		Pba:           pba.GetPba(),
-		Authorization: authorization,
+		Pointer:         pointer,
+		Nodes:           nodes,
+		Pba:             pba.GetPba(),
+		Authorization:   authorization,
+		SignedAddresses: signed,
 	}
 
 	return r, nil
 }
 
+// ObjectMeta returns the metadata of the last segment of an object. Unlike
+// Get it neither signs allocations nor looks up the nodes storing the pieces,
+// so uplinks describe objects cheaply.
+func (s *Server) ObjectMeta(ctx context.Context, req *pb.ObjectMetaRequest) (resp *pb.ObjectMetaResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = s.validateAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	pointer, err := s.service.GetFields(req.GetPath(), meta.Modified|meta.Expiration|meta.Size|meta.UserDefined|meta.Remote)
+	if err != nil {
+		switch {
+		case storage.ErrKeyNotFound.Has(err):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case ErrPointerCorrupted.Has(err):
+			return nil, status.Error(codes.DataLoss, err.Error())
+		}
+		s.logger.Error("err getting pointer", zap.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.ObjectMetaResponse{
+		CreationDate:   pointer.GetCreationDate(),
+		ExpirationDate: pointer.GetExpirationDate(),
+		SegmentSize:    pointer.GetSegmentSize(),
+		Metadata:       pointer.GetMetadata(),
+		Redundancy:     pointer.GetRemote().GetRedundancy(),
+	}, nil
+}
+
+// requestedFields returns the meta flags selecting the pointer fields
+// requested by a get, which requests all of them with meta.None
+func requestedFields(metaFlags uint32) uint32 {
+	if metaFlags == meta.None {
+		return meta.All
+	}
+	return metaFlags
+}
+
 // List returns all Path keys in the Pointers bucket
 func (s *Server) List(ctx context.Context, req *pb.ListRequest) (resp *pb.ListResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -178,7 +337,15 @@ func (s *Server) List(ctx context.Context, req *pb.ListRequest) (resp *pb.ListRe
 		return nil, err
 	}
 
-	items, more, err := s.service.List(req.Prefix, req.StartAfter, req.EndBefore, req.Recursive, req.Limit, req.MetaFlags)
+	items, more, err := s.service.ListWithOptions(ListOptions{
+		Prefix:     req.Prefix,
+		NamePrefix: req.NamePrefix,
+		StartAfter: req.StartAfter,
+		EndBefore:  req.EndBefore,
+		Recursive:  req.Recursive,
+		Limit:      req.Limit,
+		MetaFlags:  req.MetaFlags,
+	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "ListV2: %v", err)
 	}
@@ -186,6 +353,38 @@ func (s *Server) List(ctx context.Context, req *pb.ListRequest) (resp *pb.ListRe
 	return &pb.ListResponse{Items: items, More: more}, nil
 }
 
+// ListStream lists all the paths of the request in pages of req.Limit items,
+// which are sent as the client receives them
+func (s *Server) ListStream(req *pb.ListRequest, stream pb.PointerDB_ListStreamServer) (err error) {
+	ctx := stream.Context()
+	defer mon.Task()(&ctx)(&err)
+
+	if err = s.validateAuth(ctx); err != nil {
+		return err
+	}
+
+	err = s.service.ListPages(ctx, ListOptions{
+		Prefix:     req.Prefix,
+		NamePrefix: req.NamePrefix,
+		StartAfter: req.StartAfter,
+		EndBefore:  req.EndBefore,
+		Recursive:  req.Recursive,
+		Limit:      req.Limit,
+		MetaFlags:  req.MetaFlags,
+	}, func(ctx context.Context, items []*pb.ListResponse_Item, more bool) error {
+		// Send blocks until the client has room for the page
+		mon.IntVal("list_stream_page_size").Observe(int64(len(items)))
+		return stream.Send(&pb.ListResponse{Items: items, More: more})
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Errorf(codes.Internal, "ListStream: %v", err)
+	}
+	return nil
+}
+
 // Delete formats and hands off a file path to delete from boltdb
 func (s *Server) Delete(ctx context.Context, req *pb.DeleteRequest) (resp *pb.DeleteResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -194,15 +393,156 @@ func (s *Server) Delete(ctx context.Context, req *pb.DeleteRequest) (resp *pb.De
 		return nil, err
 	}
 
-	err = s.service.Delete(req.GetPath())
+	undo, err := s.reserveQuota(ctx, []string{req.GetPath()}, []*pb.Pointer{nil})
 	if err != nil {
+		return nil, s.quotaError(err)
+	}
+
+	err = s.caller(ctx).Delete(req.GetPath())
+	if err != nil {
+		undo()
+		if storage.ErrKeyNotFound.Has(err) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
 		s.logger.Error("err deleting path and pointer", zap.Error(err))
-		return nil, status.Errorf(codes.Internal, err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	return &pb.DeleteResponse{}, nil
 }
 
+// Undelete restores a deleted pointer whose pieces weren't purged yet
+func (s *Server) Undelete(ctx context.Context, req *pb.UndeleteRequest) (resp *pb.UndeleteResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = s.validateAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	undo, err := s.reserveUndelete(ctx, req.GetPath())
+	if err != nil {
+		if storage.ErrKeyNotFound.Has(err) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, s.quotaError(err)
+	}
+
+	err = s.caller(ctx).Undelete(req.GetPath())
+	if err != nil {
+		undo()
+		switch {
+		case storage.ErrKeyNotFound.Has(err):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case ErrPathExists.Has(err):
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		s.logger.Error("err undeleting path", zap.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.UndeleteResponse{}, nil
+}
+
+// BatchGet gets the pointers of many paths at once
+func (s *Server) BatchGet(ctx context.Context, req *pb.BatchGetRequest) (resp *pb.BatchGetResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = s.validateAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(req.GetPaths()) > storage.LookupLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "batch of %d paths exceeds limit %d", len(req.GetPaths()), storage.LookupLimit)
+	}
+
+	pointers, err := s.service.GetAllFields(req.GetPaths(), requestedFields(req.GetMetaFlags()))
+	if err != nil {
+		s.logger.Error("err getting pointers", zap.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp = &pb.BatchGetResponse{Items: make([]*pb.BatchGetResponse_Item, len(pointers))}
+	for i, pointer := range pointers {
+		resp.Items[i] = &pb.BatchGetResponse_Item{Path: req.GetPaths()[i], Pointer: pointer}
+	}
+	return resp, nil
+}
+
+// BatchPut puts the pointers of many paths in a single transaction
+func (s *Server) BatchPut(ctx context.Context, req *pb.BatchPutRequest) (resp *pb.BatchPutResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = s.validateAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	// expiring pointers are put with an expiration index entry
+	if 2*len(req.GetItems()) > storage.LookupLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "batch of %d pointers exceeds limit %d", len(req.GetItems()), storage.LookupLimit/2)
+	}
+
+	paths := make([]string, len(req.GetItems()))
+	pointers := make([]*pb.Pointer, len(req.GetItems()))
+	for i, item := range req.GetItems() {
+		if item.GetPointer() == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%q: missing pointer", item.GetPath())
+		}
+		if err := s.validateSegment(item.GetPointer()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%q: %v", item.GetPath(), err)
+		}
+		paths[i], pointers[i] = item.GetPath(), item.GetPointer()
+	}
+
+	undo, err := s.reserveQuota(ctx, paths, pointers)
+	if err != nil {
+		return nil, s.quotaError(err)
+	}
+
+	err = s.caller(ctx).PutAll(paths, pointers)
+	if err != nil {
+		undo()
+		if storage.ErrEmptyKey.Has(err) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger.Error("err putting pointers", zap.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.BatchPutResponse{}, nil
+}
+
+// BatchDelete deletes the pointers of many paths in a single transaction, the
+// missing paths are returned rather than failing the batch
+func (s *Server) BatchDelete(ctx context.Context, req *pb.BatchDeleteRequest) (resp *pb.BatchDeleteResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = s.validateAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	// every deleted path is a put and a delete in the batch
+	if 2*len(req.GetPaths()) > storage.LookupLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "batch of %d paths exceeds limit %d", len(req.GetPaths()), storage.LookupLimit/2)
+	}
+
+	undo, err := s.reserveQuota(ctx, req.GetPaths(), make([]*pb.Pointer, len(req.GetPaths())))
+	if err != nil {
+		return nil, s.quotaError(err)
+	}
+
+	notFound, err := s.caller(ctx).DeleteAll(req.GetPaths())
+	if err != nil {
+		undo()
+		if storage.ErrEmptyKey.Has(err) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger.Error("err deleting pointers", zap.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.BatchDeleteResponse{NotFound: notFound}, nil
+}
+
 // Iterate iterates over items based on IterateRequest
 func (s *Server) Iterate(ctx context.Context, req *pb.IterateRequest, f func(it storage.Iterator) error) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -231,17 +571,168 @@ func (s *Server) PayerBandwidthAllocation(ctx context.Context, req *pb.PayerBand
 
 	pba, err := s.allocation.PayerBandwidthAllocation(ctx, pi, req.GetAction())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	return &pb.PayerBandwidthAllocationResponse{Pba: pba}, nil
 }
 
 func (s *Server) getSignedMessage() (*pb.SignedMessage, error) {
-	signature, err := auth.GenerateSignature(s.identity.ID.Bytes(), s.identity)
+	return signedMessage(s.identity)
+}
+
+// signedMessage returns the authorization of the satellite with identity for
+// storage nodes, which also namespaces the pieces
+func signedMessage(identity *provider.FullIdentity) (*pb.SignedMessage, error) {
+	signature, err := auth.GenerateSignature(identity.ID.Bytes(), identity)
 	if err != nil {
 		return nil, err
 	}
 
-	return auth.NewSignedMessage(signature, s.identity)
+	return auth.NewSignedMessage(signature, identity)
+}
+
+// SegmentLimits returns the segment sizes uplinks may choose from
+func (s *Server) SegmentLimits(ctx context.Context, req *pb.SegmentLimitsRequest) (res *pb.SegmentLimitsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = s.validateAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	return &pb.SegmentLimitsResponse{
+		MinSegmentSize: s.config.MinSegmentSize.Int64(),
+		MaxSegmentSize: s.config.MaxSegmentSize.Int64(),
+	}, nil
+}
+
+// PrefixUsage aggregates the sizes of the committed objects below a prefix
+// and of its directories
+func (s *Server) PrefixUsage(ctx context.Context, req *pb.PrefixUsageRequest) (res *pb.PrefixUsageResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = s.validateAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	if strings.Trim(req.GetPrefix(), "/") == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "no bucket")
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 || limit > storage.LookupLimit {
+		limit = storage.LookupLimit
+	}
+
+	total, dirs, more, err := s.service.PrefixUsage(req.GetPrefix(), limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res = &pb.PrefixUsageResponse{Total: usageToProto(total), More: more}
+	for path, usage := range dirs {
+		res.Directories = append(res.Directories, &pb.DirectoryUsage{Path: path, Usage: usageToProto(usage)})
+	}
+	sort.Slice(res.Directories, func(i, k int) bool {
+		return res.Directories[i].Path < res.Directories[k].Path
+	})
+	return res, nil
+}
+
+func usageToProto(usage Usage) *pb.ObjectUsage {
+	return &pb.ObjectUsage{
+		Objects:       usage.Objects,
+		Segments:      usage.Segments,
+		EncryptedSize: usage.EncryptedSize,
+		PlainSize:     usage.PlainSize,
+	}
+}
+
+// CreateBucket creates the record of a bucket
+func (s *Server) CreateBucket(ctx context.Context, req *pb.BucketCreateRequest) (resp *pb.BucketCreateResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = s.validateAuth(ctx); err != nil {
+		return nil, err
+	}
+	if req.GetBucket() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "no bucket")
+	}
+
+	err = s.service.CreateBucket(req.GetBucket())
+	if err != nil {
+		switch {
+		case ErrBucketExists.Has(err):
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		case ErrBucketName.Has(err):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger.Error("err creating bucket", zap.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.BucketCreateResponse{Bucket: req.GetBucket()}, nil
+}
+
+// GetBucket gets the record of a bucket
+func (s *Server) GetBucket(ctx context.Context, req *pb.BucketGetRequest) (resp *pb.BucketGetResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = s.validateAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	bucket, err := s.service.GetBucket(req.GetName())
+	if err != nil {
+		switch {
+		case storage.ErrKeyNotFound.Has(err):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case ErrBucketName.Has(err):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger.Error("err getting bucket", zap.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.BucketGetResponse{Bucket: bucket}, nil
+}
+
+// DeleteBucket deletes the record of a bucket without objects
+func (s *Server) DeleteBucket(ctx context.Context, req *pb.BucketDeleteRequest) (resp *pb.BucketDeleteResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = s.validateAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	err = s.service.DeleteBucket(req.GetName())
+	if err != nil {
+		switch {
+		case storage.ErrKeyNotFound.Has(err):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case ErrBucketNotEmpty.Has(err):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case ErrBucketName.Has(err):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger.Error("err deleting bucket", zap.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.BucketDeleteResponse{}, nil
+}
+
+// ListBuckets lists the records of the buckets in name order
+func (s *Server) ListBuckets(ctx context.Context, req *pb.BucketListRequest) (resp *pb.BucketListResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = s.validateAuth(ctx); err != nil {
+		return nil, err
+	}
+
+	buckets, more, err := s.service.ListBuckets(req.GetStartAfter(), int(req.GetLimit()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.BucketListResponse{Buckets: buckets, More: more}, nil
 }
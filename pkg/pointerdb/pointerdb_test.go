@@ -9,18 +9,21 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
+	"storj.io/storj/internal/memory"
 	"storj.io/storj/internal/testidentity"
 	"storj.io/storj/pkg/auth"
 	"storj.io/storj/pkg/pb"
@@ -66,6 +69,63 @@ func TestServicePut(t *testing.T) {
 	}
 }
 
+func TestServicePutIfVersion(t *testing.T) {
+	service := NewService(zap.NewNop(), teststore.New())
+	s := Server{service: service, logger: zap.NewNop()}
+
+	require.NoError(t, service.Put("a/b/c", &pb.Pointer{}))
+	pointer, err := service.Get("a/b/c")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), pointer.Version)
+
+	err = service.PutIfVersion("a/b/c", &pb.Pointer{}, 0)
+	assert.True(t, ErrVersionChanged.Has(err))
+
+	require.NoError(t, service.PutIfVersion("a/b/c", &pb.Pointer{}, 1))
+	pointer, err = service.Get("a/b/c")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), pointer.Version)
+
+	{ // conditional puts through the server
+		ctx := context.Background()
+
+		_, err := s.Put(ctx, &pb.PutRequest{Path: "a/b/c", Pointer: &pb.Pointer{}, Conditional: true, ExpectedVersion: 1})
+		assert.Equal(t, codes.Aborted, status.Code(err))
+
+		resp, err := s.Put(ctx, &pb.PutRequest{Path: "a/b/c", Pointer: &pb.Pointer{}, Conditional: true, ExpectedVersion: 2})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), resp.Version)
+
+		resp, err = s.Put(ctx, &pb.PutRequest{Path: "a/b/d", Pointer: &pb.Pointer{}, Conditional: true})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), resp.Version)
+	}
+
+	{ // only one of the concurrent puts of the same version succeeds
+		const n = 10
+		var wg sync.WaitGroup
+		errs := make([]error, n)
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = service.PutIfVersion("a/b/c", &pb.Pointer{SegmentSize: int64(i)}, 3)
+			}(i)
+		}
+		wg.Wait()
+
+		succeeded := 0
+		for _, err := range errs {
+			if err == nil {
+				succeeded++
+				continue
+			}
+			assert.True(t, ErrVersionChanged.Has(err), err)
+		}
+		assert.Equal(t, 1, succeeded)
+	}
+}
+
 func TestServiceGet(t *testing.T) {
 	ctx := context.Background()
 	ca, err := testidentity.NewTestCA(ctx)
@@ -126,6 +186,38 @@ func TestServiceGet(t *testing.T) {
 	}
 }
 
+func TestServiceObjectMeta(t *testing.T) {
+	ctx := auth.WithAPIKey(context.Background(), nil)
+
+	db := teststore.New()
+	service := NewService(zap.NewNop(), db)
+	s := NewServer(zap.NewNop(), service, nil, nil, Config{}, nil)
+
+	redundancy := &pb.RedundancyScheme{MinReq: 2, Total: 4}
+	pointer := &pb.Pointer{
+		Type:         pb.Pointer_REMOTE,
+		CreationDate: ptypes.TimestampNow(),
+		SegmentSize:  123,
+		Metadata:     []byte("encrypted stream info"),
+		Remote: &pb.RemoteSegment{
+			Redundancy:   redundancy,
+			PieceId:      "pieceid",
+			RemotePieces: []*pb.RemotePiece{{PieceNum: 1}},
+		},
+	}
+	require.NoError(t, service.Put("l/bucket/object", pointer))
+
+	resp, err := s.ObjectMeta(ctx, &pb.ObjectMetaRequest{Path: "l/bucket/object"})
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(pointer.CreationDate, resp.CreationDate))
+	assert.Equal(t, int64(123), resp.SegmentSize)
+	assert.Equal(t, []byte("encrypted stream info"), resp.Metadata)
+	assert.True(t, proto.Equal(redundancy, resp.Redundancy))
+
+	_, err = s.ObjectMeta(ctx, &pb.ObjectMetaRequest{Path: "l/bucket/missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
 func TestServiceDelete(t *testing.T) {
 	for i, tt := range []struct {
 		apiKey    []byte
@@ -318,6 +410,23 @@ func TestServiceList(t *testing.T) {
 					{Path: "söng2.mp3"},
 				},
 			},
+		}, {
+			Request: pb.ListRequest{Prefix: "müsic/", NamePrefix: "söng", Limit: 2},
+			Expected: &pb.ListResponse{
+				Items: []*pb.ListResponse_Item{
+					{Path: "söng1.mp3"},
+					{Path: "söng2.mp3"},
+				},
+				More: true,
+			},
+		}, {
+			Request: pb.ListRequest{NamePrefix: "müsic"},
+			Expected: &pb.ListResponse{
+				Items: []*pb.ListResponse_Item{
+					{Path: "müsic"},
+					{Path: "müsic/", IsPrefix: true},
+				},
+			},
 		}, {
 			Request: pb.ListRequest{Prefix: "müs", Recursive: true, EndBefore: "ic/söng4.mp3", Limit: 1},
 			Expected: &pb.ListResponse{
@@ -350,3 +459,13 @@ func TestServiceList(t *testing.T) {
 		}
 	}
 }
+
+func TestServiceSegmentLimits(t *testing.T) {
+	config := Config{MinSegmentSize: memory.MiB, MaxSegmentSize: 256 * memory.MiB}
+	s := Server{logger: zap.NewNop(), config: config}
+
+	limits, err := s.SegmentLimits(auth.WithAPIKey(context.Background(), nil), &pb.SegmentLimitsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, memory.MiB.Int64(), limits.MinSegmentSize)
+	assert.Equal(t, 256*memory.MiB.Int64(), limits.MaxSegmentSize)
+}
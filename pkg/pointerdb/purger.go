@@ -0,0 +1,208 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/retryqueue"
+	ecclient "storj.io/storj/pkg/storage/ec"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/utils"
+	"storj.io/storj/pkg/watchdog"
+	"storj.io/storj/storage"
+)
+
+// DeletionQueueName is the name of the retry queue of piece deletions
+const DeletionQueueName = "piece-deletion"
+
+// Purger deletes the pieces of deleted segments from the storage nodes once
+// their undelete window passed, and then forgets the segments for good
+type Purger struct {
+	log      *zap.Logger
+	service  *Service
+	cache    *overlay.Cache
+	ec       ecclient.Client
+	identity *provider.FullIdentity
+	interval time.Duration
+	loop     *watchdog.Loop
+
+	deletions *retryqueue.Queue
+	workers   int
+}
+
+// NewPurger creates a purger of the deleted segments of service
+func NewPurger(log *zap.Logger, service *Service, cache *overlay.Cache, ec ecclient.Client, identity *provider.FullIdentity, interval time.Duration, loop *watchdog.Loop) *Purger {
+	return &Purger{
+		log:      log,
+		service:  service,
+		cache:    cache,
+		ec:       ec,
+		identity: identity,
+		interval: interval,
+		loop:     loop,
+	}
+}
+
+// SetDeletionQueue makes the purger queue the deletion of every piece
+// instead of deleting the pieces of a segment at once: segments are forgotten
+// as soon as their deletions are queued and each deletion is retried until
+// its node confirms it. The queued deletions are sent by as many workers at
+// once.
+func (purger *Purger) SetDeletionQueue(queue *retryqueue.Queue, workers int) {
+	purger.deletions = queue
+	purger.workers = workers
+}
+
+// Run purges deleted segments every interval, until the context is canceled
+func (purger *Purger) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ticker := time.NewTicker(purger.interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := purger.Purge(ctx, time.Now())
+		if err != nil {
+			purger.log.Error("purging deleted segments failed", zap.Error(err))
+		}
+		if purger.deletions != nil {
+			if _, deletionErr := purger.ProcessDeletions(ctx); deletionErr != nil {
+				purger.log.Error("processing piece deletions failed", zap.Error(deletionErr))
+				err = utils.CombineErrors(err, deletionErr)
+			}
+			if backlog, dead, backlogErr := purger.deletions.Backlog(ctx); backlogErr != nil {
+				purger.log.Warn("counting piece deletions failed", zap.Error(backlogErr))
+			} else if backlog > 0 || dead > 0 {
+				purger.log.Debug("piece deletions queued", zap.Int64("backlog", backlog), zap.Int64("dead", dead))
+			}
+		}
+		purger.loop.Cycle(err)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Purge deletes the pieces of the segments whose undelete window passed by
+// now. Segments whose pieces couldn't be deleted from any node are retried
+// in the next cycle.
+func (purger *Purger) Purge(ctx context.Context, now time.Time) (purged int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	segments, err := purger.service.Deleted(now, storage.LookupLimit)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, segment := range segments {
+		if err := ctx.Err(); err != nil {
+			return purged, err
+		}
+
+		undeleted, err := purger.service.Undeleted(segment)
+		if err != nil {
+			return purged, err
+		}
+		if !undeleted {
+			if err := purger.deletePieces(ctx, segment.Pointer); err != nil {
+				purger.log.Warn("deleting pieces failed", zap.String("path", segment.Path), zap.Error(err))
+				mon.Event("purge_failed")
+				continue
+			}
+		}
+
+		if err := purger.service.Forget(segment); err != nil {
+			return purged, err
+		}
+		purged++
+		mon.IntVal("purge_delay_seconds").Observe(int64(now.Sub(segment.DeletedAt) / time.Second))
+	}
+	return purged, nil
+}
+
+// deletePieces fans the deletion of the pieces of pointer out to their
+// nodes, or queues it when there's a deletion queue
+func (purger *Purger) deletePieces(ctx context.Context, pointer *pb.Pointer) error {
+	remote := pointer.GetRemote()
+	if pointer.GetType() != pb.Pointer_REMOTE || len(remote.GetRemotePieces()) == 0 {
+		return nil
+	}
+
+	if purger.deletions != nil {
+		for _, piece := range remote.GetRemotePieces() {
+			payload, err := proto.Marshal(&pb.PieceDeletion{NodeId: piece.NodeId, PieceId: remote.GetPieceId()})
+			if err != nil {
+				return err
+			}
+			if err := purger.deletions.Enqueue(ctx, payload); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var ids storj.NodeIDList
+	for _, piece := range remote.GetRemotePieces() {
+		ids = append(ids, piece.NodeId)
+	}
+	nodes, err := purger.cache.GetAll(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		if node != nil {
+			node.Type.DPanicOnInvalid("purger")
+		}
+	}
+
+	authorization, err := signedMessage(purger.identity)
+	if err != nil {
+		return err
+	}
+	return purger.ec.Delete(ctx, nodes, psclient.PieceID(remote.GetPieceId()), authorization)
+}
+
+// ProcessDeletions deletes the queued pieces from their nodes with the
+// workers of the purger. Deletions failing are retried with a backoff until
+// they're moved to the dead letters, the deletions from nodes which aren't
+// known anymore are dropped.
+func (purger *Purger) ProcessDeletions(ctx context.Context) (deleted int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	authorization, err := signedMessage(purger.identity)
+	if err != nil {
+		return 0, err
+	}
+
+	return purger.deletions.ProcessConcurrently(ctx, storage.LookupLimit, purger.workers, func(ctx context.Context, payload []byte) error {
+		deletion := &pb.PieceDeletion{}
+		if err := proto.Unmarshal(payload, deletion); err != nil {
+			return err
+		}
+
+		node, err := purger.cache.Get(ctx, deletion.NodeId)
+		if err == overlay.ErrNodeNotFound {
+			mon.Event("piece_deletion_node_gone")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		node.Type.DPanicOnInvalid("purger")
+
+		return purger.ec.Delete(ctx, []*pb.Node{node}, psclient.PieceID(deletion.PieceId), authorization)
+	})
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/retryqueue"
+	mock_ecclient "storj.io/storj/pkg/storage/ec/mocks"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+	"storj.io/storj/storage/teststore"
+)
+
+func TestPurger(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		satelliteIdentity, err := testidentity.NewTestIdentity(ctx)
+		require.NoError(t, err)
+		nodeIdentity, err := testidentity.NewTestIdentity(ctx)
+		require.NoError(t, err)
+
+		cache := overlay.NewCache(db.OverlayCache(), db.StatDB(), overlay.NodeSelectionConfig{})
+		node := pb.Node{Id: nodeIdentity.ID, Type: pb.NodeType_STORAGE, Address: &pb.NodeAddress{Address: "127.0.0.1:0"}}
+		require.NoError(t, cache.Put(ctx, node.Id, node))
+
+		service := pointerdb.NewService(zaptest.NewLogger(t), teststore.New())
+		service.SetUndeleteWindow(time.Hour, nil)
+		ec := mock_ecclient.NewMockClient(ctrl)
+		purger := pointerdb.NewPurger(zaptest.NewLogger(t), service, cache, ec, satelliteIdentity, time.Minute, nil)
+
+		pointer := &pb.Pointer{
+			Type: pb.Pointer_REMOTE,
+			Remote: &pb.RemoteSegment{
+				PieceId:      "piece",
+				RemotePieces: []*pb.RemotePiece{{PieceNum: 0, NodeId: node.Id}},
+			},
+		}
+		require.NoError(t, service.Put("l/bucket/a", pointer))
+		require.NoError(t, service.Put("l/bucket/b", &pb.Pointer{Type: pb.Pointer_INLINE, InlineSegment: []byte("data")}))
+		require.NoError(t, service.Delete("l/bucket/a"))
+		require.NoError(t, service.Delete("l/bucket/b"))
+
+		now := time.Now()
+
+		{ // nothing is purged within the undelete window
+			purged, err := purger.Purge(ctx, now)
+			require.NoError(t, err)
+			assert.Equal(t, 0, purged)
+		}
+
+		{ // segments whose pieces couldn't be deleted are retried
+			ec.EXPECT().Delete(gomock.Any(), gomock.Any(), psclient.PieceID("piece"), gomock.Any()).Return(errors.New("offline"))
+			purged, err := purger.Purge(ctx, now.Add(2*time.Hour))
+			require.NoError(t, err)
+			assert.Equal(t, 1, purged)
+		}
+
+		{ // pieces are deleted from their nodes with the satellite's authorization
+			ec.EXPECT().Delete(gomock.Any(), gomock.Any(), psclient.PieceID("piece"), gomock.Any()).
+				Do(func(_ interface{}, nodes []*pb.Node, _ psclient.PieceID, authorization *pb.SignedMessage) {
+					require.Len(t, nodes, 1)
+					assert.Equal(t, node.Id, nodes[0].Id)
+					assert.Equal(t, satelliteIdentity.ID.Bytes(), authorization.GetData())
+				})
+			purged, err := purger.Purge(ctx, now.Add(2*time.Hour))
+			require.NoError(t, err)
+			assert.Equal(t, 1, purged)
+		}
+
+		err = service.Undelete("l/bucket/a")
+		assert.Error(t, err)
+		deleted, err := service.Deleted(now.Add(2*time.Hour), 10)
+		require.NoError(t, err)
+		assert.Empty(t, deleted)
+	})
+}
+
+func TestPurgerDeletionQueue(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		satelliteIdentity, err := testidentity.NewTestIdentity(ctx)
+		require.NoError(t, err)
+		nodeIdentity, err := testidentity.NewTestIdentity(ctx)
+		require.NoError(t, err)
+		goneIdentity, err := testidentity.NewTestIdentity(ctx)
+		require.NoError(t, err)
+
+		cache := overlay.NewCache(db.OverlayCache(), db.StatDB(), overlay.NodeSelectionConfig{})
+		node := pb.Node{Id: nodeIdentity.ID, Type: pb.NodeType_STORAGE, Address: &pb.NodeAddress{Address: "127.0.0.1:0"}}
+		require.NoError(t, cache.Put(ctx, node.Id, node))
+
+		service := pointerdb.NewService(zaptest.NewLogger(t), teststore.New())
+		ec := mock_ecclient.NewMockClient(ctrl)
+		purger := pointerdb.NewPurger(zaptest.NewLogger(t), service, cache, ec, satelliteIdentity, time.Minute, nil)
+		deletions := retryqueue.New(db.RetryQueue(), pointerdb.DeletionQueueName, retryqueue.DefaultConfig)
+		purger.SetDeletionQueue(deletions, 2)
+
+		pointer := &pb.Pointer{
+			Type: pb.Pointer_REMOTE,
+			Remote: &pb.RemoteSegment{
+				PieceId: "piece",
+				RemotePieces: []*pb.RemotePiece{
+					{PieceNum: 0, NodeId: node.Id},
+					{PieceNum: 1, NodeId: goneIdentity.ID},
+				},
+			},
+		}
+		require.NoError(t, service.Put("l/bucket/a", pointer))
+		require.NoError(t, service.Delete("l/bucket/a"))
+
+		{ // segments are forgotten once the deletions of their pieces are queued
+			purged, err := purger.Purge(ctx, time.Now())
+			require.NoError(t, err)
+			assert.Equal(t, 1, purged)
+
+			queued, err := deletions.Peek(ctx, 10)
+			require.NoError(t, err)
+			assert.Len(t, queued, 2)
+		}
+
+		{ // failed deletions are retried, deletions from unknown nodes are dropped
+			ec.EXPECT().Delete(gomock.Any(), gomock.Any(), psclient.PieceID("piece"), gomock.Any()).
+				Do(func(_ interface{}, nodes []*pb.Node, _ psclient.PieceID, _ *pb.SignedMessage) {
+					require.Len(t, nodes, 1)
+					assert.Equal(t, node.Id, nodes[0].Id)
+				}).
+				Return(errors.New("offline"))
+			deleted, err := purger.ProcessDeletions(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, 1, deleted)
+
+			queued, err := deletions.Peek(ctx, 10)
+			require.NoError(t, err)
+			require.Len(t, queued, 1)
+			assert.Equal(t, "offline", queued[0].LastError)
+
+			backlog, dead, err := deletions.Backlog(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), backlog)
+			assert.Equal(t, int64(0), dead)
+		}
+	})
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb
+
+import (
+	"context"
+	"strings"
+
+	"github.com/skyrings/skyring-common/tools/uuid"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/pkg/accounting"
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/pb"
+)
+
+// ErrQuotaExceeded is returned when a put would take a project over its quota
+var ErrQuotaExceeded = errs.Class("quota exceeded")
+
+// UsageDB stores the usage of projects
+type UsageDB interface {
+	// GetProjectUsage returns the storage used by the project, zero when it didn't store anything yet
+	GetProjectUsage(ctx context.Context, projectID uuid.UUID) (accounting.ProjectUsage, error)
+	// AddProjectUsage adds to the storage used by the project, negative values free storage
+	AddProjectUsage(ctx context.Context, projectID uuid.UUID, storedBytes, objects int64) error
+}
+
+// ProjectResolver returns the project of an API key, an error when the key
+// doesn't belong to a project
+type ProjectResolver func(ctx context.Context, apiKey []byte) (uuid.UUID, error)
+
+// Quota tracks the storage and objects of projects and rejects puts taking
+// a project over its limits. Concurrent puts are checked against the same
+// usage, so a project may go over its limits by the puts in flight.
+type Quota struct {
+	log      *zap.Logger
+	usage    UsageDB
+	projects ProjectResolver
+
+	storageLimit int64
+	objectLimit  int64
+}
+
+// NewQuota creates a quota limiting every project to storageLimit bytes and
+// objectLimit objects, a limit of 0 disables it
+func NewQuota(log *zap.Logger, usage UsageDB, projects ProjectResolver, storageLimit memory.Size, objectLimit int64) *Quota {
+	return &Quota{
+		log:          log,
+		usage:        usage,
+		projects:     projects,
+		storageLimit: storageLimit.Int64(),
+		objectLimit:  objectLimit,
+	}
+}
+
+// Project returns the project of the API key the request was made with,
+// false when there's none. Requests without a project aren't limited.
+func (quota *Quota) Project(ctx context.Context) (projectID uuid.UUID, ok bool) {
+	apiKey, ok := auth.GetAPIKey(ctx)
+	if !ok {
+		return uuid.UUID{}, false
+	}
+	projectID, err := quota.projects(ctx, apiKey)
+	if err != nil {
+		quota.log.Debug("no project for api key", zap.Error(err))
+		return uuid.UUID{}, false
+	}
+	return projectID, true
+}
+
+// Reserve adds storedBytes and objects to the usage of the project, failing
+// with ErrQuotaExceeded when growing the usage takes it over a limit
+func (quota *Quota) Reserve(ctx context.Context, projectID uuid.UUID, storedBytes, objects int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if storedBytes == 0 && objects == 0 {
+		return nil
+	}
+	if storedBytes > 0 || objects > 0 {
+		usage, err := quota.usage.GetProjectUsage(ctx, projectID)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		if quota.storageLimit > 0 && storedBytes > 0 && usage.StoredBytes+storedBytes > quota.storageLimit {
+			mon.Counter("quota_exceeded_storage").Inc(1)
+			return ErrQuotaExceeded.New("project %s would store %d bytes, limit is %d", projectID.String(), usage.StoredBytes+storedBytes, quota.storageLimit)
+		}
+		if quota.objectLimit > 0 && objects > 0 && usage.Objects+objects > quota.objectLimit {
+			mon.Counter("quota_exceeded_objects").Inc(1)
+			return ErrQuotaExceeded.New("project %s would store %d objects, limit is %d", projectID.String(), usage.Objects+objects, quota.objectLimit)
+		}
+	}
+	return Error.Wrap(quota.usage.AddProjectUsage(ctx, projectID, storedBytes, objects))
+}
+
+// Release removes storedBytes and objects from the usage of the project
+func (quota *Quota) Release(ctx context.Context, projectID uuid.UUID, storedBytes, objects int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if storedBytes == 0 && objects == 0 {
+		return nil
+	}
+	return Error.Wrap(quota.usage.AddProjectUsage(ctx, projectID, -storedBytes, -objects))
+}
+
+// ReleaseExpired releases the storage of a pointer the reaper deleted from
+// the quota of the project it was put by. Deleted pointers stop counting
+// when they're deleted, so purging their pieces doesn't release anything.
+func (quota *Quota) ReleaseExpired(ctx context.Context, expired ExpiredPointer) {
+	var projectID uuid.UUID
+	if len(expired.Pointer.GetProjectId()) != len(projectID) {
+		return
+	}
+	copy(projectID[:], expired.Pointer.GetProjectId())
+
+	storedBytes, objects := pointerUsage(expired.Path, expired.Pointer)
+	if err := quota.Release(ctx, projectID, storedBytes, objects); err != nil {
+		quota.log.Error("releasing quota of expired pointer failed", zap.String("path", expired.Path), zap.Error(err))
+	}
+}
+
+// pointerUsage returns the storage the pointer at path counts against the
+// quota, the last segments of objects count as an object
+func pointerUsage(path string, pointer *pb.Pointer) (storedBytes, objects int64) {
+	if pointer == nil {
+		return 0, 0
+	}
+	storedBytes = pointer.GetSegmentSize()
+	if storedBytes == 0 {
+		storedBytes = int64(len(pointer.GetInlineSegment()))
+	}
+	if strings.HasPrefix(path, "l/") {
+		objects = 1
+	}
+	return storedBytes, objects
+}
+
+// pointersUsage returns the storage of the pointers at paths, nil pointers
+// are skipped
+func pointersUsage(paths []string, pointers []*pb.Pointer) (storedBytes, objects int64) {
+	for i, pointer := range pointers {
+		bytes, count := pointerUsage(paths[i], pointer)
+		storedBytes += bytes
+		objects += count
+	}
+	return storedBytes, objects
+}
@@ -0,0 +1,158 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/skyrings/skyring-common/tools/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/teststore"
+)
+
+func TestQuota(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		projectID, err := uuid.New()
+		require.NoError(t, err)
+		projects := func(ctx context.Context, apiKey []byte) (uuid.UUID, error) {
+			if string(apiKey) != "project-key" {
+				return uuid.UUID{}, errors.New("unknown key")
+			}
+			return *projectID, nil
+		}
+
+		service := pointerdb.NewService(zaptest.NewLogger(t), teststore.New())
+		server := pointerdb.NewServer(zaptest.NewLogger(t), service, nil, nil, pointerdb.Config{MaxInlineSegmentSize: 8 * memory.KiB}, nil)
+		quota := pointerdb.NewQuota(zaptest.NewLogger(t), db.Accounting(), projects, 10*memory.B, 2)
+		server.SetQuota(quota)
+
+		put := func(ctx context.Context, path string, size int) error {
+			_, err := server.Put(ctx, &pb.PutRequest{Path: path, Pointer: &pb.Pointer{
+				Type:          pb.Pointer_INLINE,
+				InlineSegment: make([]byte, size),
+				SegmentSize:   int64(size),
+			}})
+			return err
+		}
+		usage := func() (int64, int64) {
+			usage, err := db.Accounting().GetProjectUsage(ctx, *projectID)
+			require.NoError(t, err)
+			return usage.StoredBytes, usage.Objects
+		}
+		projectCtx := auth.WithAPIKey(ctx, []byte("project-key"))
+
+		{ // puts count against the project, last segments as objects
+			require.NoError(t, put(projectCtx, "s0/photos/a", 3))
+			require.NoError(t, put(projectCtx, "l/photos/a", 3))
+			bytes, objects := usage()
+			assert.Equal(t, int64(6), bytes)
+			assert.Equal(t, int64(1), objects)
+		}
+
+		{ // replacing a pointer counts the difference
+			require.NoError(t, put(projectCtx, "l/photos/a", 4))
+			bytes, objects := usage()
+			assert.Equal(t, int64(7), bytes)
+			assert.Equal(t, int64(1), objects)
+		}
+
+		{ // puts over the limits are rejected
+			err := put(projectCtx, "l/photos/b", 4)
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+			require.NoError(t, put(projectCtx, "l/photos/b", 1))
+			err = put(projectCtx, "l/photos/c", 1)
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+			bytes, objects := usage()
+			assert.Equal(t, int64(8), bytes)
+			assert.Equal(t, int64(2), objects)
+		}
+
+		{ // requests without a project aren't limited
+			require.NoError(t, put(ctx, "l/other/a", 100))
+			require.NoError(t, put(auth.WithAPIKey(ctx, []byte("unknown")), "l/other/b", 100))
+		}
+
+		{ // deletes free the storage
+			_, err := server.Delete(projectCtx, &pb.DeleteRequest{Path: "l/photos/a"})
+			require.NoError(t, err)
+			_, err = server.BatchDelete(projectCtx, &pb.BatchDeleteRequest{Paths: []string{"s0/photos/a", "l/photos/missing"}})
+			require.NoError(t, err)
+			bytes, objects := usage()
+			assert.Equal(t, int64(1), bytes)
+			assert.Equal(t, int64(1), objects)
+
+			require.NoError(t, put(projectCtx, "l/photos/c", 9))
+		}
+
+		{ // undeletes count against the project again
+			_, err := server.Delete(projectCtx, &pb.DeleteRequest{Path: "l/photos/c"})
+			require.NoError(t, err)
+			_, err = server.Undelete(projectCtx, &pb.UndeleteRequest{Path: "l/photos/c"})
+			require.NoError(t, err)
+			bytes, objects := usage()
+			assert.Equal(t, int64(10), bytes)
+			assert.Equal(t, int64(2), objects)
+
+			_, err = server.Delete(projectCtx, &pb.DeleteRequest{Path: "l/photos/c"})
+			require.NoError(t, err)
+			require.NoError(t, put(projectCtx, "l/photos/d", 9))
+			_, err = server.Undelete(projectCtx, &pb.UndeleteRequest{Path: "l/photos/c"})
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+			_, err = service.Get("l/photos/c")
+			assert.True(t, storage.ErrKeyNotFound.Has(err))
+			bytes, objects = usage()
+			assert.Equal(t, int64(10), bytes)
+			assert.Equal(t, int64(2), objects)
+		}
+
+		{ // reaped pointers free the storage
+			_, err := server.Delete(projectCtx, &pb.DeleteRequest{Path: "l/photos/d"})
+			require.NoError(t, err)
+
+			expiration, err := ptypes.TimestampProto(time.Now().Add(-time.Hour))
+			require.NoError(t, err)
+			_, err = server.Put(projectCtx, &pb.PutRequest{Path: "l/photos/e", Pointer: &pb.Pointer{
+				Type:           pb.Pointer_INLINE,
+				InlineSegment:  make([]byte, 5),
+				SegmentSize:    5,
+				ExpirationDate: expiration,
+			}})
+			require.NoError(t, err)
+			bytes, objects := usage()
+			assert.Equal(t, int64(6), bytes)
+			assert.Equal(t, int64(2), objects)
+
+			reaper := pointerdb.NewReaper(zaptest.NewLogger(t), service, time.Hour, nil)
+			reaper.OnDelete(quota.ReleaseExpired)
+			reaped, err := reaper.Reap(ctx, time.Now())
+			require.NoError(t, err)
+			assert.Equal(t, 1, reaped)
+			bytes, objects = usage()
+			assert.Equal(t, int64(1), bytes)
+			assert.Equal(t, int64(1), objects)
+		}
+	})
+}
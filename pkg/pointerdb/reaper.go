@@ -0,0 +1,102 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/watchdog"
+	"storj.io/storj/storage"
+)
+
+// Reaper deletes the pointers whose expiration date passed. Reaped pointers
+// are deleted like deleted objects, so the purger deletes their pieces.
+type Reaper struct {
+	log      *zap.Logger
+	service  *Service
+	interval time.Duration
+	loop     *watchdog.Loop
+
+	handlers []func(ctx context.Context, expired ExpiredPointer)
+}
+
+// NewReaper creates a reaper of the expired pointers of service
+func NewReaper(log *zap.Logger, service *Service, interval time.Duration, loop *watchdog.Loop) *Reaper {
+	return &Reaper{
+		log:      log,
+		service:  service,
+		interval: interval,
+		loop:     loop,
+	}
+}
+
+// OnDelete registers handler to be called with every reaped pointer. Must
+// be called before the reaper runs.
+func (reaper *Reaper) OnDelete(handler func(ctx context.Context, expired ExpiredPointer)) {
+	reaper.handlers = append(reaper.handlers, handler)
+}
+
+// Run reaps expired pointers every interval, until the context is canceled
+func (reaper *Reaper) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ticker := time.NewTicker(reaper.interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := reaper.Reap(ctx, time.Now())
+		if err != nil {
+			reaper.log.Error("reaping expired pointers failed", zap.Error(err))
+		}
+		reaper.loop.Cycle(err)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Reap deletes the pointers which expired by now and returns how many were
+// deleted
+func (reaper *Reaper) Reap(ctx context.Context, now time.Time) (reaped int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for {
+		expired, err := reaper.service.Expired(now, storage.LookupLimit)
+		if err != nil {
+			return reaped, err
+		}
+
+		for _, pointer := range expired {
+			if err := ctx.Err(); err != nil {
+				return reaped, err
+			}
+
+			deleted, err := reaper.service.DeleteExpired(pointer)
+			if err != nil {
+				return reaped, err
+			}
+			if !deleted {
+				continue
+			}
+
+			reaped++
+			mon.IntVal("reap_delay_seconds").Observe(int64(now.Sub(pointer.ExpiresAt) / time.Second))
+			reaper.log.Debug("reaped expired pointer", zap.String("path", pointer.Path))
+			for _, handler := range reaper.handlers {
+				handler(ctx, pointer)
+			}
+		}
+
+		if len(expired) < storage.LookupLimit {
+			mon.Counter("pointers_reaped").Inc(int64(reaped))
+			return reaped, nil
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/teststore"
+)
+
+func TestReaper(t *testing.T) {
+	for _, indexed := range []bool{false, true} {
+		indexed := indexed
+		t.Run(map[bool]string{false: "scanned", true: "indexed"}[indexed], func(t *testing.T) {
+			ctx := testcontext.New(t)
+			defer ctx.Cleanup()
+
+			db := teststore.New()
+			service := pointerdb.NewService(zaptest.NewLogger(t), db)
+			service.SetExpirationIndex(indexed)
+
+			now := time.Now()
+			expiring := func(expiresAt time.Time) *pb.Pointer {
+				expiration, err := ptypes.TimestampProto(expiresAt)
+				require.NoError(t, err)
+				return &pb.Pointer{Type: pb.Pointer_INLINE, InlineSegment: []byte("data"), ExpirationDate: expiration}
+			}
+
+			require.NoError(t, service.Put("l/bucket/expired", expiring(now.Add(-time.Hour))))
+			require.NoError(t, service.Put("l/bucket/later", expiring(now.Add(time.Hour))))
+			require.NoError(t, service.Put("l/bucket/forever", &pb.Pointer{Type: pb.Pointer_INLINE, InlineSegment: []byte("data")}))
+			require.NoError(t, service.Put("l/bucket/zero", expiring(time.Time{})))
+			// extended before it expired, which leaves a stale index entry
+			require.NoError(t, service.Put("l/bucket/extended", expiring(now.Add(-time.Minute))))
+			require.NoError(t, service.Put("l/bucket/extended", expiring(now.Add(time.Hour))))
+
+			reaper := pointerdb.NewReaper(zaptest.NewLogger(t), service, time.Hour, nil)
+			var events []string
+			reaper.OnDelete(func(ctx context.Context, expired pointerdb.ExpiredPointer) {
+				events = append(events, expired.Path)
+			})
+
+			reaped, err := reaper.Reap(ctx, now)
+			require.NoError(t, err)
+			assert.Equal(t, 1, reaped)
+			assert.Equal(t, []string{"l/bucket/expired"}, events)
+
+			_, err = service.Get("l/bucket/expired")
+			assert.True(t, storage.ErrKeyNotFound.Has(err))
+			for _, path := range []string{"l/bucket/later", "l/bucket/forever", "l/bucket/zero", "l/bucket/extended"} {
+				_, err := service.Get(path)
+				assert.NoError(t, err, path)
+			}
+
+			// reaped pointers are purged like deleted ones
+			deleted, err := service.Deleted(time.Now().Add(time.Minute), 10)
+			require.NoError(t, err)
+			require.Len(t, deleted, 1)
+			assert.Equal(t, "l/bucket/expired", deleted[0].Path)
+
+			// index entries are hidden from lists
+			items, _, err := service.List("", "", "", true, 0, 0)
+			require.NoError(t, err)
+			assert.Len(t, items, 4)
+
+			reaped, err = reaper.Reap(ctx, now.Add(2*time.Hour))
+			require.NoError(t, err)
+			assert.Equal(t, 2, reaped)
+
+			expired, err := service.Expired(now.Add(2*time.Hour), 10)
+			require.NoError(t, err)
+			assert.Empty(t, expired)
+		})
+	}
+}
@@ -4,11 +4,14 @@
 package pointerdb
 
 import (
-	"github.com/gogo/protobuf/proto"
+	"context"
+	"time"
+
 	"github.com/golang/protobuf/ptypes"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
+	"storj.io/storj/internal/memory"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storage/meta"
 	"storj.io/storj/storage"
@@ -16,8 +19,20 @@ import (
 
 // Service structure
 type Service struct {
-	logger *zap.Logger
-	DB     storage.KeyValueStore
+	logger    *zap.Logger
+	DB        storage.KeyValueStore
+	compress  int  // threshold for compressing pointers, see MarshalPointer
+	checksums bool // whether pointers are stored with checksums, see ChecksumPointer
+
+	undeleteWindow        time.Duration
+	bucketUndeleteWindows map[string]time.Duration
+
+	expirationIndex bool
+
+	auditLog *AuditLog
+	caller   string // the node ID of the peer mutations are logged for
+
+	cache *PointerCache
 }
 
 // NewService creates new pointerdb service
@@ -25,70 +40,287 @@ func NewService(logger *zap.Logger, db storage.KeyValueStore) *Service {
 	return &Service{logger: logger, DB: db}
 }
 
+// SetCompression sets the serialized size above which pointers are stored
+// compressed, 0 disables compression. Stored pointers are read regardless of
+// this setting. Must be called before the service is used.
+func (s *Service) SetCompression(threshold memory.Size) {
+	s.compress = threshold.Int()
+}
+
+// SetChecksums sets whether pointers are stored with checksums verified when
+// they're read. Stored pointers are read regardless of this setting. Must be
+// called before the service is used.
+func (s *Service) SetChecksums(enabled bool) {
+	s.checksums = enabled
+}
+
+// marshalPointer serializes pointer for storing with the compression and
+// checksums of the service
+func (s *Service) marshalPointer(pointer *pb.Pointer) ([]byte, error) {
+	data, err := MarshalPointer(pointer, s.compress)
+	if err != nil || !s.checksums {
+		return data, err
+	}
+	return ChecksumPointer(data), nil
+}
+
+// SetAuditLog sets the log mutations are appended to before they are
+// applied, nil disables logging. Must be called before the service is used.
+func (s *Service) SetAuditLog(log *AuditLog) {
+	s.auditLog = log
+}
+
+// SetCache sets the size of the cache of pointers read, 0 disables caching.
+// Must be called before the service is used.
+func (s *Service) SetCache(size memory.Size) {
+	s.cache = NewPointerCache(size)
+}
+
+// WithCaller returns the service logging its mutations as requested by the
+// peer with the node ID caller
+func (s *Service) WithCaller(caller string) *Service {
+	service := *s
+	service.caller = caller
+	return &service
+}
+
+// logMutation appends the mutation of path replacing the stored pointer
+// previous with value to the audit log
+func (s *Service) logMutation(operation pb.PointerMutation_Operation, path string, previous, value []byte) error {
+	if s.auditLog == nil {
+		return nil
+	}
+	return s.auditLog.Append(&pb.PointerMutation{
+		Operation:    operation,
+		Path:         path,
+		Caller:       s.caller,
+		PreviousHash: hashValue(previous),
+		Value:        value,
+	})
+}
+
 // Put puts pointer to db under specific path
 func (s *Service) Put(path string, pointer *pb.Pointer) (err error) {
-	// Update the pointer with the creation date
-	pointer.CreationDate = ptypes.TimestampNow()
+	return s.put(path, pointer, func(current int64) error { return nil })
+}
 
-	pointerBytes, err := proto.Marshal(pointer)
-	if err != nil {
-		return err
+// PutIfVersion puts pointer to db under specific path when the stored pointer
+// has version, version 0 expects no pointer at path. The new version is set
+// in pointer.
+func (s *Service) PutIfVersion(path string, pointer *pb.Pointer, version int64) (err error) {
+	return s.put(path, pointer, func(current int64) error {
+		if current != version {
+			return ErrVersionChanged.New("%q has version %d, expected %d", path, current, version)
+		}
+		return nil
+	})
+}
+
+// put puts pointer with the version after the stored one, when check accepts
+// the stored version. A concurrent put is retried, so check sees its version.
+func (s *Service) put(path string, pointer *pb.Pointer, check func(current int64) error) (err error) {
+	if !IsPointerKey(storage.Key(path)) {
+		return Error.New("invalid path %q", path)
 	}
+	defer s.cache.Invalidate(path)
 
-	// TODO(kaloyan): make sure that we know we are overwriting the pointer!
-	// In such case we should delete the pieces of the old segment if it was
-	// a remote one.
-	if err = s.DB.Put([]byte(path), pointerBytes); err != nil {
-		return err
+	// the index entry is added first, so no expiring pointer is missed;
+	// stale entries are dropped by the reaper
+	if expiresAt, ok := pointerExpiration(pointer); ok && s.expirationIndex {
+		if err := s.DB.Put(expiresKey(path, expiresAt), storage.Value(path)); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	for {
+		var current int64
+		oldBytes, err := s.DB.Get(storage.Key(path))
+		switch {
+		case storage.ErrKeyNotFound.Has(err):
+			oldBytes = nil
+		case err != nil:
+			return err
+		default:
+			old := &pb.Pointer{}
+			if err := UnmarshalPointer(oldBytes, old); err != nil {
+				return Error.New("error unmarshaling pointer: %v", err)
+			}
+			current = old.Version
+		}
+
+		if err := check(current); err != nil {
+			return err
+		}
+
+		// Update the pointer with the creation date and the version
+		pointer.CreationDate = ptypes.TimestampNow()
+		pointer.Version = current + 1
+
+		pointerBytes, err := s.marshalPointer(pointer)
+		if err != nil {
+			return err
+		}
+
+		if err := s.logMutation(pb.PointerMutation_PUT, path, oldBytes, pointerBytes); err != nil {
+			return err
+		}
+
+		// TODO(kaloyan): make sure that we know we are overwriting the pointer!
+		// In such case we should delete the pieces of the old segment if it was
+		// a remote one.
+		err = s.DB.CompareAndSwap(storage.Key(path), oldBytes, pointerBytes)
+		if !storage.ErrValueChanged.Has(err) {
+			return err
+		}
+	}
 }
 
 // Get gets pointer from db
 func (s *Service) Get(path string) (pointer *pb.Pointer, err error) {
-	pointerBytes, err := s.DB.Get([]byte(path))
-	if err != nil {
-		return nil, err
+	return s.GetFields(path, meta.All)
+}
+
+// GetFields gets only the fields of the pointer selected by metaFlags from
+// db, see UnmarshalPointerFields
+func (s *Service) GetFields(path string, metaFlags uint32) (pointer *pb.Pointer, err error) {
+	pointerBytes, generation, cached := s.cache.Get(path)
+	if !cached {
+		pointerBytes, err = s.DB.Get([]byte(path))
+		if err != nil {
+			return nil, err
+		}
+		s.cache.Add(path, pointerBytes, generation)
 	}
 
 	pointer = &pb.Pointer{}
-	err = proto.Unmarshal(pointerBytes, pointer)
+	err = UnmarshalPointerFields(pointerBytes, pointer, metaFlags)
 	if err != nil {
+		if ErrPointerCorrupted.Has(err) {
+			s.logger.Error("pointer corrupted", zap.String("path", path), zap.Error(err))
+			return nil, err
+		}
 		return nil, errs.New("error unmarshaling pointer: %v", err)
 	}
 
 	return pointer, nil
 }
 
+// ListOptions are the options of listing pointers
+type ListOptions struct {
+	Prefix     string // Prefix is the directory to list, a delimiter is appended when missing
+	NamePrefix string // NamePrefix limits the listing to paths starting with Prefix/NamePrefix
+	StartAfter string // StartAfter is relative to Prefix
+	EndBefore  string // EndBefore is relative to Prefix
+	Recursive  bool
+	Limit      int32 // Limit is capped to storage.LookupLimit
+	MetaFlags  uint32
+}
+
 // List returns all Path keys in the pointers bucket
 func (s *Service) List(prefix string, startAfter string, endBefore string, recursive bool, limit int32,
 	metaFlags uint32) (items []*pb.ListResponse_Item, more bool, err error) {
+	return s.ListWithOptions(ListOptions{
+		Prefix:     prefix,
+		StartAfter: startAfter,
+		EndBefore:  endBefore,
+		Recursive:  recursive,
+		Limit:      limit,
+		MetaFlags:  metaFlags,
+	})
+}
 
+// ListWithOptions lists the pointers matching opts. Deleted pointers don't
+// count towards the limit, so a full page is returned while more pointers
+// exist.
+func (s *Service) ListWithOptions(opts ListOptions) (items []*pb.ListResponse_Item, more bool, err error) {
 	var prefixKey storage.Key
-	if prefix != "" {
-		prefixKey = storage.Key(prefix)
-		if prefix[len(prefix)-1] != storage.Delimiter {
+	if opts.Prefix != "" {
+		prefixKey = storage.Key(opts.Prefix)
+		if opts.Prefix[len(opts.Prefix)-1] != storage.Delimiter {
 			prefixKey = append(prefixKey, storage.Delimiter)
 		}
 	}
 
-	rawItems, more, err := storage.ListV2(s.DB, storage.ListOptions{
-		Prefix:       prefixKey,
-		StartAfter:   storage.Key(startAfter),
-		EndBefore:    storage.Key(endBefore),
-		Recursive:    recursive,
-		Limit:        int(limit),
-		IncludeValue: metaFlags != meta.None,
-	})
-	if err != nil {
-		return nil, false, err
+	limit := int(opts.Limit)
+	if limit <= 0 || limit > storage.LookupLimit {
+		limit = storage.LookupLimit
+	}
+
+	startAfter, endBefore := opts.StartAfter, opts.EndBefore
+	reverse := endBefore != ""
+	for {
+		rawItems, rawMore, err := storage.ListV2(s.DB, storage.ListOptions{
+			Prefix:       prefixKey,
+			NamePrefix:   storage.Key(opts.NamePrefix),
+			StartAfter:   storage.Key(startAfter),
+			EndBefore:    storage.Key(endBefore),
+			Recursive:    opts.Recursive,
+			Limit:        limit - len(items),
+			IncludeValue: opts.MetaFlags != meta.None,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		more = rawMore
+
+		var page []*pb.ListResponse_Item
+		for _, rawItem := range rawItems {
+			if !IsPointerKey(rawItem.Key) {
+				continue
+			}
+			page = append(page, s.createListItem(rawItem, opts.MetaFlags))
+		}
+
+		if !reverse {
+			items = append(items, page...)
+		} else {
+			items = append(page, items...)
+		}
+
+		if !more || len(rawItems) == 0 || len(items) >= limit {
+			return items, more, nil
+		}
+
+		// continue after the last listed key, which may have been hidden
+		if !reverse {
+			startAfter = rawItems[len(rawItems)-1].Key.String()
+		} else {
+			endBefore = rawItems[0].Key.String()
+		}
 	}
+}
+
+// ListPages lists all the paths of opts in pages of opts.Limit items, which
+// are passed to fn one at a time, so that only a page is held in memory. The
+// listing stops at the first error returned by fn.
+func (s *Service) ListPages(ctx context.Context, opts ListOptions, fn func(ctx context.Context, items []*pb.ListResponse_Item, more bool) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	reverse := opts.EndBefore != ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
-	for _, rawItem := range rawItems {
-		items = append(items, s.createListItem(rawItem, metaFlags))
+		items, more, err := s.ListWithOptions(opts)
+		if err != nil {
+			return err
+		}
+		more = more && len(items) > 0
+		if err := fn(ctx, items, more); err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+
+		// paths are relative to the prefix, as StartAfter and EndBefore
+		if !reverse {
+			opts.StartAfter = items[len(items)-1].Path
+		} else {
+			opts.EndBefore = items[0].Path
+		}
 	}
-	return items, more, nil
 }
 
 // createListItem creates a new list item with the given path. It also adds
@@ -117,7 +349,7 @@ func (s *Service) setMetadata(item *pb.ListResponse_Item, data []byte, metaFlags
 	}
 
 	pr := &pb.Pointer{}
-	err = proto.Unmarshal(data, pr)
+	err = UnmarshalPointer(data, pr)
 	if err != nil {
 		return err
 	}
@@ -141,8 +373,25 @@ func (s *Service) setMetadata(item *pb.ListResponse_Item, data []byte, metaFlags
 	return nil
 }
 
-// Delete deletes from item from db
+// Delete marks the pointer at path deleted, which hides it right away. It's
+// undeletable until the purger deletes its pieces, see Undelete.
 func (s *Service) Delete(path string) (err error) {
+	defer s.cache.Invalidate(path)
+
+	pointerBytes, err := s.DB.Get([]byte(path))
+	if err != nil {
+		return err
+	}
+
+	if err := s.logMutation(pb.PointerMutation_DELETE, path, pointerBytes, nil); err != nil {
+		return err
+	}
+
+	// the deleted pointer is stored first, so a failed delete doesn't leak
+	// pieces; the purger doesn't purge pointers which are still live
+	if err := s.DB.Put(deletedKey(path, time.Now()), pointerBytes); err != nil {
+		return err
+	}
 	return s.DB.Delete([]byte(path))
 }
 
@@ -0,0 +1,158 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+
+	"storj.io/storj/pkg/encryption"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
+)
+
+// Usage is the aggregated size of objects
+type Usage struct {
+	Objects       int64
+	Segments      int64
+	EncryptedSize int64
+	// PlainSize is the size of the segments before they were encrypted,
+	// which includes the padding of their last encryption block
+	PlainSize int64
+}
+
+// add adds the usage of a segment, objects count their last segment
+func (usage *Usage) add(encryptedSize, plainSize int64, last bool) {
+	if last {
+		usage.Objects++
+	}
+	usage.Segments++
+	usage.EncryptedSize += encryptedSize
+	usage.PlainSize += plainSize
+}
+
+// streamEncryption is how the segments of a stream are encrypted
+type streamEncryption struct {
+	cipher    storj.Cipher
+	blockSize int
+}
+
+// plainSize returns the size of a segment of the stream before encryption.
+// Segments of streams with unknown encryption count with their encrypted size.
+func (enc streamEncryption) plainSize(encryptedSize int64) int64 {
+	size, err := encryption.CalcDecryptedSize(encryptedSize, enc.cipher, enc.blockSize)
+	if err != nil {
+		return encryptedSize
+	}
+	return size
+}
+
+// PrefixUsage aggregates the usage of the committed objects below prefix,
+// which is a bucket optionally followed by an encrypted path ending in a
+// slash. The usage of up to limit directories directly below prefix is also
+// aggregated separately, keyed by their path component with a trailing
+// slash; more reports whether there were more directories.
+//
+// Objects are read from the last segments, the other segments are matched
+// with them one segment index after the other, so the paths of all objects
+// below prefix are held in memory.
+func (s *Service) PrefixUsage(prefix string, limit int) (total Usage, dirs map[string]Usage, more bool, err error) {
+	prefix = strings.TrimSuffix(prefix, "/") + "/"
+	dirs = make(map[string]Usage)
+
+	// dir returns the directory of the object below prefix, empty when the
+	// object is directly below prefix or its directory isn't aggregated
+	dir := func(path string) string {
+		i := strings.IndexByte(path, '/')
+		if i < 0 {
+			return ""
+		}
+		name := path[:i+1]
+		if _, ok := dirs[name]; !ok && len(dirs) >= limit {
+			more = true
+			return ""
+		}
+		return name
+	}
+
+	objects := make(map[string]streamEncryption)
+	err = s.Iterate("l/"+prefix, "", true, false,
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			for it.Next(&item) {
+				pointer := &pb.Pointer{}
+				if err := UnmarshalPointer(item.Value, pointer); err != nil {
+					return Error.New("error unmarshaling pointer %q: %v", item.Key, err)
+				}
+				streamMeta := &pb.StreamMeta{}
+				if err := proto.Unmarshal(pointer.GetMetadata(), streamMeta); err != nil {
+					return Error.New("error unmarshaling stream meta %q: %v", item.Key, err)
+				}
+
+				path := string(item.Key[len("l/"+prefix):])
+				enc := streamEncryption{
+					cipher:    storj.Cipher(streamMeta.EncryptionType),
+					blockSize: int(streamMeta.EncryptionBlockSize),
+				}
+				objects[path] = enc
+
+				size := pointer.GetSegmentSize()
+				total.add(size, enc.plainSize(size), true)
+				if name := dir(path); name != "" {
+					usage := dirs[name]
+					usage.add(size, enc.plainSize(size), true)
+					dirs[name] = usage
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return Usage{}, nil, false, err
+	}
+
+	// objects with n segments have the segments s0 to s(n-2), so no object
+	// has further segments once no object has a segment
+	for index := 0; len(objects) > 0; index++ {
+		segmentPrefix := fmt.Sprintf("s%d/%s", index, prefix)
+		found := false
+		err = s.Iterate(segmentPrefix, "", true, false,
+			func(it storage.Iterator) error {
+				var item storage.ListItem
+				for it.Next(&item) {
+					path := string(item.Key[len(segmentPrefix):])
+					enc, ok := objects[path]
+					if !ok {
+						// the segment of an upload which wasn't committed
+						continue
+					}
+					found = true
+
+					pointer := &pb.Pointer{}
+					if err := UnmarshalPointer(item.Value, pointer); err != nil {
+						return Error.New("error unmarshaling pointer %q: %v", item.Key, err)
+					}
+
+					size := pointer.GetSegmentSize()
+					total.add(size, enc.plainSize(size), false)
+					if name := dir(path); name != "" {
+						usage := dirs[name]
+						usage.add(size, enc.plainSize(size), false)
+						dirs[name] = usage
+					}
+				}
+				return nil
+			})
+		if err != nil {
+			return Usage{}, nil, false, err
+		}
+		if !found {
+			break
+		}
+	}
+
+	return total, dirs, more, nil
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pointerdb_test
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage/teststore"
+)
+
+func TestPrefixUsage(t *testing.T) {
+	service := pointerdb.NewService(zaptest.NewLogger(t), teststore.New())
+
+	streamMeta, err := proto.Marshal(&pb.StreamMeta{
+		EncryptionType:      int32(storj.AESGCM),
+		EncryptionBlockSize: 1024,
+	})
+	require.NoError(t, err)
+
+	put := func(path string, size int64, last bool) {
+		pointer := &pb.Pointer{Type: pb.Pointer_REMOTE, SegmentSize: size}
+		if last {
+			pointer.Metadata = streamMeta
+		}
+		require.NoError(t, service.Put(path, pointer))
+	}
+
+	// two segments of two blocks and a last segment of one block
+	put("s0/bucket/a/x", 2048, false)
+	put("s1/bucket/a/x", 2048, false)
+	put("l/bucket/a/x", 1024, true)
+	put("l/bucket/b/y", 1024, true)
+	put("l/bucket/z", 1024, true)
+	// segment of an upload which wasn't committed
+	put("s0/bucket/c/pending", 2048, false)
+	// another bucket
+	put("l/other/a/x", 1024, true)
+
+	{ // all objects of the bucket
+		total, dirs, more, err := service.PrefixUsage("bucket/", 10)
+		require.NoError(t, err)
+		assert.Equal(t, pointerdb.Usage{Objects: 3, Segments: 5, EncryptedSize: 7 * 1024, PlainSize: 7 * (1024 - 16)}, total)
+		assert.Equal(t, map[string]pointerdb.Usage{
+			"a/": {Objects: 1, Segments: 3, EncryptedSize: 5 * 1024, PlainSize: 5 * (1024 - 16)},
+			"b/": {Objects: 1, Segments: 1, EncryptedSize: 1024, PlainSize: 1024 - 16},
+		}, dirs)
+		assert.False(t, more)
+	}
+
+	{ // directories beyond the limit are only counted in the total
+		total, dirs, more, err := service.PrefixUsage("bucket", 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total.Objects)
+		assert.Len(t, dirs, 1)
+		assert.Contains(t, dirs, "a/")
+		assert.True(t, more)
+	}
+
+	{ // objects below a path
+		total, dirs, _, err := service.PrefixUsage("bucket/a/", 10)
+		require.NoError(t, err)
+		assert.Equal(t, pointerdb.Usage{Objects: 1, Segments: 3, EncryptedSize: 5 * 1024, PlainSize: 5 * (1024 - 16)}, total)
+		assert.Empty(t, dirs)
+	}
+}
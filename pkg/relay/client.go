@@ -0,0 +1,156 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package relay
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"storj.io/storj/pkg/storj"
+)
+
+// Dial connects to the node through the relay at address
+func Dial(ctx context.Context, address string, id storj.NodeID) (_ net.Conn, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	conn, err := request(ctx, address, cmdDial, id.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// the relay answers once the node accepted the connection
+	_ = conn.SetReadDeadline(time.Now().Add(handshakeTimeout + acceptTimeout))
+	if err := readResponse(conn); err != nil {
+		_ = conn.Close()
+		return nil, Error.Wrap(err)
+	}
+	_ = conn.SetReadDeadline(time.Time{})
+	return conn, nil
+}
+
+// Check returns whether the relay at relayAddress can dial address
+func Check(ctx context.Context, relayAddress, address string) (reachable bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	conn, err := request(ctx, relayAddress, cmdCheck, address)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * handshakeTimeout))
+	fields, err := readLine(conn)
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+	return len(fields) > 0 && fields[0] == respOK, nil
+}
+
+// Listen registers the node at the relay at address and returns a listener
+// of the connections relayed to the node
+func Listen(ctx context.Context, address string, id storj.NodeID) (_ net.Listener, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	control, err := request(ctx, address, cmdListen, id.String())
+	if err != nil {
+		return nil, err
+	}
+	_ = control.SetReadDeadline(time.Now().Add(handshakeTimeout))
+	if err := readResponse(control); err != nil {
+		_ = control.Close()
+		return nil, Error.Wrap(err)
+	}
+	_ = control.SetReadDeadline(time.Time{})
+
+	listener := &listener{
+		address:  address,
+		control:  control,
+		accepted: make(chan net.Conn),
+		closed:   make(chan struct{}),
+	}
+	go listener.run()
+	return listener, nil
+}
+
+// request connects to the relay and sends a protocol line
+func request(ctx context.Context, address string, fields ...string) (net.Conn, error) {
+	dialer := net.Dialer{Timeout: handshakeTimeout, KeepAlive: time.Minute}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if err := writeLine(conn, fields...); err != nil {
+		_ = conn.Close()
+		return nil, Error.Wrap(err)
+	}
+	return conn, nil
+}
+
+// listener accepts the connections the relay asks for on the control
+// connection
+type listener struct {
+	address  string
+	control  net.Conn
+	accepted chan net.Conn
+
+	closeOnce sync.Once
+	closed    chan struct{}
+	err       error
+}
+
+// run opens a connection to the relay for every connect request
+func (listener *listener) run() {
+	for {
+		fields, err := readLine(listener.control)
+		if err != nil {
+			listener.fail(Error.Wrap(err))
+			return
+		}
+		if len(fields) != 2 || fields[0] != cmdConnect {
+			continue
+		}
+
+		go func(token string) {
+			conn, err := request(context.Background(), listener.address, cmdAccept, token)
+			if err != nil {
+				return
+			}
+			select {
+			case listener.accepted <- conn:
+			case <-listener.closed:
+				_ = conn.Close()
+			}
+		}(fields[1])
+	}
+}
+
+// fail closes the listener, err is returned by Accept
+func (listener *listener) fail(err error) {
+	listener.closeOnce.Do(func() {
+		listener.err = err
+		close(listener.closed)
+		_ = listener.control.Close()
+	})
+}
+
+// Accept waits for the next relayed connection
+func (listener *listener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-listener.accepted:
+		return conn, nil
+	case <-listener.closed:
+		return nil, listener.err
+	}
+}
+
+// Close closes the control connection, so the relay stops relaying
+func (listener *listener) Close() error {
+	listener.fail(Error.New("listener closed"))
+	return nil
+}
+
+// Addr returns the local address of the control connection
+func (listener *listener) Addr() net.Addr { return listener.control.LocalAddr() }
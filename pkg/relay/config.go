@@ -0,0 +1,48 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package relay
+
+import (
+	"context"
+	"net"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/server"
+)
+
+// Config configures the relay of a satellite or bootstrap node
+type Config struct {
+	Address string `help:"address to relay connections to nodes which can't be dialed directly on, empty disables relaying" default:""`
+}
+
+// NodeConfig configures how a node behind NAT is reached
+type NodeConfig struct {
+	Address string `help:"address of the relay forwarding connections when the node can't be dialed directly, e.g. of the bootstrap node, empty disables relaying" default:""`
+}
+
+// Run implements server.Service, it runs the relay next to the server
+func (c Config) Run(ctx context.Context, server *server.Server) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if c.Address == "" {
+		return server.Run(ctx)
+	}
+
+	listener, err := net.Listen("tcp", c.Address)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	relay := NewServer(zap.L().Named("relay"), listener)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		if err := relay.Run(ctx); err != nil && err != context.Canceled {
+			zap.L().Error("Relay stopped", zap.Error(err))
+		}
+	}()
+
+	return server.Run(ctx)
+}
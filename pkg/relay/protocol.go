@@ -0,0 +1,102 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package relay
+
+import (
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The relay protocol exchanges single lines of space separated fields
+// before the connections are spliced. Relayed connections carry TLS
+// between the dialer and the node, the relay only forwards bytes.
+//
+//	node:   LISTEN <node id>   relay: OK, on the control connection
+//	dialer: DIAL <node id>     relay: CONNECT <token>, on the control connection
+//	node:   ACCEPT <token>     on a new connection, spliced with the dialer
+//	relay:  OK                 to the dialer, once the node accepted
+//	client: CHECK <address>    relay: OK when it could dial the address
+const (
+	cmdListen  = "LISTEN"
+	cmdDial    = "DIAL"
+	cmdConnect = "CONNECT"
+	cmdAccept  = "ACCEPT"
+	cmdCheck   = "CHECK"
+
+	respOK    = "OK"
+	respError = "ERR"
+)
+
+const (
+	// maxLineLength is the maximum length of a protocol line
+	maxLineLength = 512
+	// handshakeTimeout is how long peers have to send their protocol line
+	handshakeTimeout = 10 * time.Second
+	// acceptTimeout is how long a node has to accept a relayed connection
+	acceptTimeout = 10 * time.Second
+)
+
+// writeLine writes the fields as a protocol line
+func writeLine(conn net.Conn, fields ...string) error {
+	_, err := io.WriteString(conn, strings.Join(fields, " ")+"\n")
+	return err
+}
+
+// readLine reads the fields of a protocol line. It reads byte by byte, so
+// no data following the line is consumed.
+func readLine(conn net.Conn) ([]string, error) {
+	var line []byte
+	var b [1]byte
+	for {
+		if _, err := io.ReadFull(conn, b[:]); err != nil {
+			return nil, err
+		}
+		if b[0] == '\n' {
+			return strings.Fields(string(line)), nil
+		}
+		if len(line) >= maxLineLength {
+			return nil, Error.New("protocol line too long")
+		}
+		line = append(line, b[0])
+	}
+}
+
+// readResponse reads an OK response, any other response is returned as error
+func readResponse(conn net.Conn) error {
+	fields, err := readLine(conn)
+	if err != nil {
+		return err
+	}
+	if len(fields) == 0 || fields[0] != respOK {
+		return Error.New("%s", strings.Join(fields, " "))
+	}
+	return nil
+}
+
+// splice copies data between a and b in both directions until either is
+// closed, then it closes both
+func splice(a, b net.Conn) {
+	var once sync.Once
+	closeBoth := func() {
+		_ = a.Close()
+		_ = b.Close()
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(a, b)
+		once.Do(closeBoth)
+	}()
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(b, a)
+		once.Do(closeBoth)
+	}()
+	wg.Wait()
+}
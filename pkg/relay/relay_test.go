@@ -0,0 +1,189 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package relay
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/pb"
+)
+
+func newTestServer(t *testing.T, ctx *testcontext.Context) *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := NewServer(zaptest.NewLogger(t), listener)
+	ctx.Go(func() error {
+		err := server.Run(ctx)
+		if err == context.Canceled {
+			return nil
+		}
+		return err
+	})
+	return server
+}
+
+func TestRelay(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	server := newTestServer(t, ctx)
+	defer ctx.Check(server.Close)
+	address := server.Addr().String()
+	id := teststorj.NodeIDFromString("node")
+
+	_, err := Dial(ctx, address, id)
+	assert.EqualError(t, err, "relay error: ERR node not connected")
+
+	listener, err := Listen(ctx, address, id)
+	require.NoError(t, err)
+	defer ctx.Check(listener.Close)
+
+	// the node echoes what it receives
+	ctx.Go(func() error {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return nil
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	})
+
+	for _, message := range []string{"hello", "world"} {
+		conn, err := Dial(ctx, address, id)
+		require.NoError(t, err)
+
+		_, err = conn.Write([]byte(message))
+		require.NoError(t, err)
+		echo := make([]byte, len(message))
+		_, err = io.ReadFull(conn, echo)
+		require.NoError(t, err)
+		assert.Equal(t, message, string(echo))
+		require.NoError(t, conn.Close())
+	}
+}
+
+func TestCheck(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	server := newTestServer(t, ctx)
+	defer ctx.Check(server.Close)
+	address := server.Addr().String()
+
+	open, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ctx.Check(open.Close)
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, closed.Close())
+
+	reachable, err := Check(ctx, address, open.Addr().String())
+	require.NoError(t, err)
+	assert.True(t, reachable)
+
+	reachable, err = Check(ctx, address, closed.Addr().String())
+	require.NoError(t, err)
+	assert.False(t, reachable)
+
+	// addresses of other hosts aren't dialed
+	reachable, err = Check(ctx, address, "192.0.2.1:7777")
+	require.NoError(t, err)
+	assert.False(t, reachable)
+}
+
+// testSelf is a node's own routing table entry
+type testSelf struct {
+	mu   sync.Mutex
+	node pb.Node
+}
+
+func (self *testSelf) Local() pb.Node {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	return self.node
+}
+
+func (self *testSelf) UpdateSelf(node *pb.Node) error {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	self.node = *node
+	return nil
+}
+
+func TestService(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	server := newTestServer(t, ctx)
+	defer ctx.Check(server.Close)
+	address := server.Addr().String()
+
+	// the node's advertised address can't be dialed
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, closed.Close())
+
+	id := teststorj.NodeIDFromString("node")
+	self := &testSelf{node: pb.Node{Id: id, Address: &pb.NodeAddress{Address: closed.Addr().String()}}}
+
+	grpcServer := grpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+	defer grpcServer.Stop()
+
+	serviceCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	service := NewService(zaptest.NewLogger(t), NodeConfig{Address: address}, self, grpcServer)
+	ctx.Go(func() error {
+		err := service.Run(serviceCtx)
+		if err == context.Canceled {
+			return nil
+		}
+		return err
+	})
+
+	// the node advertises the relay and serves the relayed connections
+	for self.Local().Address.Relay == "" {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, address, self.Local().Address.Relay)
+
+	conn, err := grpc.DialContext(ctx, self.Local().Address.Address, grpc.WithInsecure(),
+		grpc.WithDialer(func(_ string, timeout time.Duration) (net.Conn, error) {
+			return Dial(ctx, address, id)
+		}))
+	require.NoError(t, err)
+	defer ctx.Check(conn.Close)
+
+	var resp *healthpb.HealthCheckResponse
+	for i := 0; i < 100; i++ {
+		// the node may not have registered at the relay yet
+		resp, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.FailFast(false))
+		if err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+}
@@ -0,0 +1,286 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package relay
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/storj"
+)
+
+// Server relays connections to nodes which can't be dialed directly. Such
+// nodes keep a control connection to the server, over which they are asked
+// to open a connection for every dialer.
+type Server struct {
+	log      *zap.Logger
+	listener net.Listener
+
+	closeOnce sync.Once
+	closeErr  error
+	closed    chan struct{}
+
+	mu       sync.Mutex
+	nodes    map[storj.NodeID]*controlConn
+	accepted map[string]chan net.Conn
+}
+
+// controlConn is the control connection of a node
+type controlConn struct {
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewServer returns a relay accepting connections on listener
+func NewServer(log *zap.Logger, listener net.Listener) *Server {
+	return &Server{
+		log:      log,
+		listener: listener,
+		closed:   make(chan struct{}),
+		nodes:    make(map[storj.NodeID]*controlConn),
+		accepted: make(map[string]chan net.Conn),
+	}
+}
+
+// Addr returns the address the relay accepts connections on
+func (server *Server) Addr() net.Addr { return server.listener.Addr() }
+
+// Run accepts connections until ctx is canceled or the server is closed
+func (server *Server) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+
+	for {
+		conn, err := server.listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			select {
+			case <-server.closed:
+				return nil
+			default:
+			}
+			return Error.Wrap(err)
+		}
+		go server.handle(ctx, conn)
+	}
+}
+
+// Close stops accepting connections and closes the control connections
+func (server *Server) Close() error {
+	server.closeOnce.Do(func() {
+		close(server.closed)
+		server.closeErr = server.listener.Close()
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		for id, control := range server.nodes {
+			_ = control.conn.Close()
+			delete(server.nodes, id)
+		}
+	})
+	return server.closeErr
+}
+
+// handle serves the protocol line received on conn
+func (server *Server) handle(ctx context.Context, conn net.Conn) {
+	_ = conn.SetReadDeadline(time.Now().Add(handshakeTimeout))
+	fields, err := readLine(conn)
+	if err != nil || len(fields) != 2 {
+		_ = conn.Close()
+		return
+	}
+	_ = conn.SetReadDeadline(time.Time{})
+
+	switch fields[0] {
+	case cmdListen:
+		server.listen(conn, fields[1])
+	case cmdAccept:
+		server.accept(conn, fields[1])
+	case cmdDial:
+		server.dial(ctx, conn, fields[1])
+	case cmdCheck:
+		server.check(ctx, conn, fields[1])
+	default:
+		_ = writeLine(conn, respError, "unknown command")
+		_ = conn.Close()
+	}
+}
+
+// listen registers the control connection of a node until it is closed
+func (server *Server) listen(conn net.Conn, nodeID string) {
+	id, err := storj.NodeIDFromString(nodeID)
+	if err != nil {
+		_ = writeLine(conn, respError, "invalid node id")
+		_ = conn.Close()
+		return
+	}
+
+	control := &controlConn{conn: conn}
+	server.mu.Lock()
+	if previous, ok := server.nodes[id]; ok {
+		_ = previous.conn.Close()
+	}
+	server.nodes[id] = control
+	server.mu.Unlock()
+	mon.Counter("relayed_nodes").Inc(1)
+
+	defer func() {
+		server.mu.Lock()
+		if server.nodes[id] == control {
+			delete(server.nodes, id)
+		}
+		server.mu.Unlock()
+		mon.Counter("relayed_nodes").Dec(1)
+		_ = conn.Close()
+	}()
+
+	control.mu.Lock()
+	err = writeLine(conn, respOK)
+	control.mu.Unlock()
+	if err != nil {
+		return
+	}
+
+	// nodes don't send anything else, reading only detects closing
+	var buf [1]byte
+	for {
+		if _, err := conn.Read(buf[:]); err != nil {
+			return
+		}
+	}
+}
+
+// accept hands the connection a node opened for a dialer to the dialer
+func (server *Server) accept(conn net.Conn, token string) {
+	server.mu.Lock()
+	accepted, ok := server.accepted[token]
+	delete(server.accepted, token)
+	server.mu.Unlock()
+
+	if !ok {
+		_ = conn.Close()
+		return
+	}
+	accepted <- conn
+}
+
+// dial asks the node to open a connection and splices it with conn
+func (server *Server) dial(ctx context.Context, conn net.Conn, nodeID string) {
+	defer func() { _ = conn.Close() }()
+
+	id, err := storj.NodeIDFromString(nodeID)
+	if err != nil {
+		_ = writeLine(conn, respError, "invalid node id")
+		return
+	}
+
+	var tokenBytes [16]byte
+	if _, err := rand.Read(tokenBytes[:]); err != nil {
+		_ = writeLine(conn, respError, "internal error")
+		return
+	}
+	token := hex.EncodeToString(tokenBytes[:])
+	accepted := make(chan net.Conn, 1)
+
+	server.mu.Lock()
+	control, ok := server.nodes[id]
+	if ok {
+		server.accepted[token] = accepted
+	}
+	server.mu.Unlock()
+	if !ok {
+		_ = writeLine(conn, respError, "node not connected")
+		return
+	}
+	defer func() {
+		server.mu.Lock()
+		delete(server.accepted, token)
+		server.mu.Unlock()
+		// the node may have accepted after the dialer gave up
+		select {
+		case nodeConn := <-accepted:
+			_ = nodeConn.Close()
+		default:
+		}
+	}()
+
+	control.mu.Lock()
+	err = writeLine(control.conn, cmdConnect, token)
+	control.mu.Unlock()
+	if err != nil {
+		_ = writeLine(conn, respError, "node not connected")
+		return
+	}
+
+	timer := time.NewTimer(acceptTimeout)
+	defer timer.Stop()
+
+	select {
+	case nodeConn := <-accepted:
+		if err := writeLine(conn, respOK); err != nil {
+			_ = nodeConn.Close()
+			return
+		}
+		mon.Counter("relayed_connections").Inc(1)
+		splice(conn, nodeConn)
+	case <-timer.C:
+		_ = writeLine(conn, respError, "node did not accept")
+	case <-ctx.Done():
+	}
+}
+
+// check replies whether the address can be dialed. Only addresses of the
+// host the check comes from are dialed, so the relay can't be used to scan
+// other hosts.
+func (server *Server) check(ctx context.Context, conn net.Conn, address string) {
+	defer func() { _ = conn.Close() }()
+
+	if !sameHost(ctx, conn.RemoteAddr(), address) {
+		_ = writeLine(conn, respError, "address of another host")
+		return
+	}
+
+	dialer := net.Dialer{Timeout: handshakeTimeout}
+	checked, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		_ = writeLine(conn, respError, "unreachable")
+		return
+	}
+	_ = checked.Close()
+	_ = writeLine(conn, respOK)
+}
+
+// sameHost returns whether the host of address resolves to the IP of remote
+func sameHost(ctx context.Context, remote net.Addr, address string) bool {
+	remoteTCP, ok := remote.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return false
+	}
+	for _, addr := range addrs {
+		if addr.IP.Equal(remoteTCP.IP) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package relay
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"storj.io/storj/pkg/pb"
+)
+
+// maxRetryDelay is the longest time between attempts to connect to the relay
+const maxRetryDelay = time.Minute
+
+// Self is the node's own routing table entry
+type Self interface {
+	Local() pb.Node
+	UpdateSelf(node *pb.Node) error
+}
+
+// Service checks at startup whether the node can be dialed directly. When
+// it can't, the node advertises the relay and serves the connections
+// relayed to it.
+type Service struct {
+	log    *zap.Logger
+	config NodeConfig
+	self   Self
+	server *grpc.Server
+}
+
+// NewService returns a service which serves relayed connections with server
+func NewService(log *zap.Logger, config NodeConfig, self Self, server *grpc.Server) *Service {
+	return &Service{
+		log:    log,
+		config: config,
+		self:   self,
+		server: server,
+	}
+}
+
+// Run checks whether the node is reachable and otherwise serves relayed
+// connections until ctx is canceled
+func (service *Service) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if service.config.Address == "" {
+		return nil
+	}
+
+	self := service.self.Local()
+	reachable, err := Check(ctx, service.config.Address, self.GetAddress().GetAddress())
+	if err != nil {
+		service.log.Warn("Failed to check reachability, not relaying", zap.Error(err))
+		return nil
+	}
+	if reachable {
+		service.log.Info("Node can be dialed directly, not relaying")
+		return nil
+	}
+
+	service.log.Sugar().Infof("Node can't be dialed directly, relaying through %s", service.config.Address)
+	if err := service.advertise(service.config.Address); err != nil {
+		return err
+	}
+
+	delay := time.Second
+	for {
+		started := time.Now()
+		err := service.serve(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err == grpc.ErrServerStopped {
+			return nil
+		}
+		service.log.Warn("Relay connection lost", zap.Error(err))
+		if time.Since(started) > maxRetryDelay {
+			delay = time.Second
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if delay *= 2; delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+	}
+}
+
+// advertise sets the relay in the node's own address
+func (service *Service) advertise(relay string) error {
+	self := service.self.Local()
+	address := pb.NodeAddress{}
+	if self.Address != nil {
+		address = *self.Address
+	}
+	address.Relay = relay
+	self.Address = &address
+	return Error.Wrap(service.self.UpdateSelf(&self))
+}
+
+// serve serves the connections relayed to the node until the relay
+// connection is lost
+func (service *Service) serve(ctx context.Context) error {
+	listener, err := Listen(ctx, service.config.Address, service.self.Local().Id)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+
+	return service.server.Serve(listener)
+}
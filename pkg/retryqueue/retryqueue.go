@@ -0,0 +1,279 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package retryqueue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+)
+
+// Item is a payload in a retry queue
+type Item struct {
+	ID      int64
+	Queue   string
+	Payload []byte
+	// Attempts is the number of times the item was leased
+	Attempts int
+	// NextAttempt is when the item is due to be leased, or when the lease of
+	// a leased item expires
+	NextAttempt time.Time
+	LastError   string
+	// Dead items exhausted their attempts and are only kept for inspection
+	Dead      bool
+	CreatedAt time.Time
+}
+
+// DB stores the items of retry queues
+type DB interface {
+	// Enqueue adds a payload to the queue, due at the given time
+	Enqueue(ctx context.Context, queue string, payload []byte, at time.Time) error
+	// Lease returns the item of the queue due first by now and postpones it
+	// until the lease expires, counting the attempt. Nil is returned when no
+	// item is due.
+	Lease(ctx context.Context, queue string, now, expires time.Time) (*Item, error)
+	// Ack deletes the item
+	Ack(ctx context.Context, id int64) error
+	// Retry postpones the item to the given time, recording why it failed
+	Retry(ctx context.Context, id int64, at time.Time, lastError string) error
+	// Bury marks the item dead, recording why it failed
+	Bury(ctx context.Context, id int64, lastError string) error
+	// List returns up to limit live or dead items of the queue, the item due
+	// first comes first
+	List(ctx context.Context, queue string, dead bool, limit int) ([]*Item, error)
+	// Count returns the number of live or dead items of the queue
+	Count(ctx context.Context, queue string, dead bool) (int64, error)
+}
+
+// Config is the backoff policy of a queue
+type Config struct {
+	InitialBackoff time.Duration `help:"how long a failed item waits for its first retry, doubling with every failure" default:"1m0s"`
+	MaxBackoff     time.Duration `help:"maximum time a failed item waits for its next retry" default:"6h0m0s"`
+	MaxAttempts    int           `help:"number of attempts after which an item is moved to the dead letters, 0 retries forever" default:"10"`
+	LeaseDuration  time.Duration `help:"how long a leased item is hidden from other workers before it's retried, unless it's acknowledged" default:"1h0m0s"`
+}
+
+// DefaultConfig is the default backoff policy
+var DefaultConfig = Config{
+	InitialBackoff: time.Minute,
+	MaxBackoff:     6 * time.Hour,
+	MaxAttempts:    10,
+	LeaseDuration:  time.Hour,
+}
+
+// Backoff returns how long an item waits for its retry after failing the
+// given number of attempts
+func (config Config) Backoff(attempts int) time.Duration {
+	backoff := config.InitialBackoff
+	for i := 1; i < attempts && backoff < config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if config.MaxBackoff > 0 && backoff > config.MaxBackoff {
+		backoff = config.MaxBackoff
+	}
+	return backoff
+}
+
+// Queue is a durable queue whose items are retried with a backoff until
+// they're acknowledged. Items failing all their attempts are moved to the
+// dead letters.
+type Queue struct {
+	db     DB
+	name   string
+	config Config
+	mon    *monkit.Scope
+}
+
+// New returns the queue with name stored in db
+func New(db DB, name string, config Config) *Queue {
+	return &Queue{
+		db:     db,
+		name:   name,
+		config: config,
+		mon:    monkit.ScopeNamed(mon.Name() + "." + name),
+	}
+}
+
+// Name returns the name of the queue
+func (queue *Queue) Name() string { return queue.name }
+
+// Enqueue adds the payload to the queue
+func (queue *Queue) Enqueue(ctx context.Context, payload []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := queue.db.Enqueue(ctx, queue.name, payload, time.Now()); err != nil {
+		return Error.Wrap(err)
+	}
+	queue.mon.Counter("enqueued").Inc(1)
+	return nil
+}
+
+// Lease leases the next item which is due, nil is returned when no item is
+// due. The item is hidden until it's acknowledged or failed through the
+// lease, or the lease expires.
+func (queue *Queue) Lease(ctx context.Context) (_ *Lease, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	now := time.Now()
+	item, err := queue.db.Lease(ctx, queue.name, now, now.Add(queue.config.LeaseDuration))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if item == nil {
+		return nil, nil
+	}
+
+	queue.mon.Counter("leased").Inc(1)
+	if item.Attempts > 1 {
+		queue.mon.Counter("retried").Inc(1)
+	}
+	queue.mon.IntVal("wait_seconds").Observe(int64(now.Sub(item.CreatedAt) / time.Second))
+	return &Lease{queue: queue, Item: item}, nil
+}
+
+// Peek returns up to limit live items, the item due first comes first
+func (queue *Queue) Peek(ctx context.Context, limit int) (_ []*Item, err error) {
+	defer mon.Task()(&ctx)(&err)
+	items, err := queue.db.List(ctx, queue.name, false, limit)
+	return items, Error.Wrap(err)
+}
+
+// DeadLetters returns up to limit items which failed all their attempts
+func (queue *Queue) DeadLetters(ctx context.Context, limit int) (_ []*Item, err error) {
+	defer mon.Task()(&ctx)(&err)
+	items, err := queue.db.List(ctx, queue.name, true, limit)
+	return items, Error.Wrap(err)
+}
+
+// Backlog returns the number of live items and of dead letters of the
+// queue, which are also reported as metrics
+func (queue *Queue) Backlog(ctx context.Context) (live, dead int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	live, err = queue.db.Count(ctx, queue.name, false)
+	if err != nil {
+		return 0, 0, Error.Wrap(err)
+	}
+	dead, err = queue.db.Count(ctx, queue.name, true)
+	if err != nil {
+		return 0, 0, Error.Wrap(err)
+	}
+	queue.mon.IntVal("backlog").Observe(live)
+	queue.mon.IntVal("dead_letters").Observe(dead)
+	return live, dead, nil
+}
+
+// Process leases up to limit due items one after the other and handles them
+// with fn: items are acknowledged when fn succeeds and retried otherwise.
+// The number of items handled successfully is returned.
+func (queue *Queue) Process(ctx context.Context, limit int, fn func(ctx context.Context, payload []byte) error) (processed int, err error) {
+	return queue.ProcessConcurrently(ctx, limit, 1, fn)
+}
+
+// ProcessConcurrently is Process with as many workers leasing and handling
+// items at once
+func (queue *Queue) ProcessConcurrently(ctx context.Context, limit, workers int, fn func(ctx context.Context, payload []byte) error) (processed int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	var (
+		mu      sync.Mutex
+		leased  int
+		errlist errs.Group
+	)
+	// next reserves the lease of the next item, false when the limit is
+	// reached or a worker failed
+	next := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		if leased >= limit || len(errlist) > 0 {
+			return false
+		}
+		leased++
+		return true
+	}
+	done := func(succeeded bool, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if succeeded {
+			processed++
+		}
+		errlist.Add(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for next() {
+				if err := ctx.Err(); err != nil {
+					done(false, err)
+					return
+				}
+
+				lease, err := queue.Lease(ctx)
+				if err != nil || lease == nil {
+					done(false, err)
+					return
+				}
+
+				if cause := fn(ctx, lease.Payload); cause != nil {
+					done(false, lease.Fail(ctx, cause))
+					continue
+				}
+				err = lease.Ack(ctx)
+				done(err == nil, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return processed, errlist.Err()
+}
+
+// Lease is a leased item of a queue
+type Lease struct {
+	queue *Queue
+	*Item
+}
+
+// Ack removes the item from the queue, as it was handled
+func (lease *Lease) Ack(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := lease.queue.db.Ack(ctx, lease.ID); err != nil {
+		return Error.Wrap(err)
+	}
+	lease.queue.mon.Counter("acked").Inc(1)
+	return nil
+}
+
+// Fail retries the item after a backoff, or moves it to the dead letters
+// when it failed all its attempts
+func (lease *Lease) Fail(ctx context.Context, cause error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	queue := lease.queue
+	if queue.config.MaxAttempts > 0 && lease.Attempts >= queue.config.MaxAttempts {
+		if err := queue.db.Bury(ctx, lease.ID, cause.Error()); err != nil {
+			return Error.Wrap(err)
+		}
+		queue.mon.Counter("dead").Inc(1)
+		return nil
+	}
+
+	at := time.Now().Add(queue.config.Backoff(lease.Attempts))
+	if err := queue.db.Retry(ctx, lease.ID, at, cause.Error()); err != nil {
+		return Error.Wrap(err)
+	}
+	queue.mon.Counter("failed").Inc(1)
+	return nil
+}
@@ -0,0 +1,145 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package retryqueue_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/retryqueue"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestBackoff(t *testing.T) {
+	config := retryqueue.Config{InitialBackoff: time.Minute, MaxBackoff: 5 * time.Minute}
+	assert.Equal(t, time.Minute, config.Backoff(1))
+	assert.Equal(t, 2*time.Minute, config.Backoff(2))
+	assert.Equal(t, 4*time.Minute, config.Backoff(3))
+	assert.Equal(t, 5*time.Minute, config.Backoff(4))
+	assert.Equal(t, 5*time.Minute, config.Backoff(100))
+}
+
+func TestQueue(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		config := retryqueue.Config{InitialBackoff: 0, MaxAttempts: 2, LeaseDuration: time.Hour}
+		queue := retryqueue.New(db.RetryQueue(), "test", config)
+		other := retryqueue.New(db.RetryQueue(), "other", config)
+
+		require.NoError(t, queue.Enqueue(ctx, []byte("a")))
+		require.NoError(t, queue.Enqueue(ctx, []byte("b")))
+
+		{ // leased items are hidden until they're acknowledged or failed
+			a, err := queue.Lease(ctx)
+			require.NoError(t, err)
+			require.NotNil(t, a)
+			assert.Equal(t, []byte("a"), a.Payload)
+			assert.Equal(t, 1, a.Attempts)
+
+			b, err := queue.Lease(ctx)
+			require.NoError(t, err)
+			require.NotNil(t, b)
+			assert.Equal(t, []byte("b"), b.Payload)
+
+			none, err := queue.Lease(ctx)
+			require.NoError(t, err)
+			assert.Nil(t, none)
+
+			none, err = other.Lease(ctx)
+			require.NoError(t, err)
+			assert.Nil(t, none)
+
+			require.NoError(t, a.Ack(ctx))
+			require.NoError(t, b.Fail(ctx, errors.New("first failure")))
+			assert.Error(t, a.Ack(ctx))
+		}
+
+		{ // failed items are retried, until they fail all their attempts
+			b, err := queue.Lease(ctx)
+			require.NoError(t, err)
+			require.NotNil(t, b)
+			assert.Equal(t, []byte("b"), b.Payload)
+			assert.Equal(t, 2, b.Attempts)
+			assert.Equal(t, "first failure", b.LastError)
+			require.NoError(t, b.Fail(ctx, errors.New("second failure")))
+
+			none, err := queue.Lease(ctx)
+			require.NoError(t, err)
+			assert.Nil(t, none)
+
+			live, err := queue.Peek(ctx, 10)
+			require.NoError(t, err)
+			assert.Empty(t, live)
+
+			dead, err := queue.DeadLetters(ctx, 10)
+			require.NoError(t, err)
+			require.Len(t, dead, 1)
+			assert.Equal(t, []byte("b"), dead[0].Payload)
+			assert.Equal(t, "second failure", dead[0].LastError)
+		}
+
+		{ // processing acknowledges the items handled and retries the others
+			queue := retryqueue.New(db.RetryQueue(), "process", retryqueue.DefaultConfig)
+			require.NoError(t, queue.Enqueue(ctx, []byte("c")))
+			require.NoError(t, queue.Enqueue(ctx, []byte("d")))
+
+			processed, err := queue.Process(ctx, 10, func(ctx context.Context, payload []byte) error {
+				if string(payload) == "d" {
+					return errors.New("failure")
+				}
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 1, processed)
+
+			live, err := queue.Peek(ctx, 10)
+			require.NoError(t, err)
+			require.Len(t, live, 1)
+			assert.Equal(t, []byte("d"), live[0].Payload)
+			assert.True(t, live[0].NextAttempt.After(time.Now()))
+		}
+
+		{ // workers process every item once, up to the limit
+			queue := retryqueue.New(db.RetryQueue(), "workers", retryqueue.DefaultConfig)
+			for i := 0; i < 20; i++ {
+				require.NoError(t, queue.Enqueue(ctx, []byte{byte(i)}))
+			}
+
+			var mu sync.Mutex
+			seen := make(map[byte]int)
+			handle := func(ctx context.Context, payload []byte) error {
+				mu.Lock()
+				defer mu.Unlock()
+				seen[payload[0]]++
+				return nil
+			}
+			processed, err := queue.ProcessConcurrently(ctx, 15, 4, handle)
+			require.NoError(t, err)
+			assert.Equal(t, 15, processed)
+
+			live, dead, err := queue.Backlog(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, int64(5), live)
+			assert.Equal(t, int64(0), dead)
+
+			processed, err = queue.ProcessConcurrently(ctx, 100, 4, handle)
+			require.NoError(t, err)
+			assert.Equal(t, 5, processed)
+			assert.Len(t, seen, 20)
+			for _, count := range seen {
+				assert.Equal(t, 1, count)
+			}
+		}
+	})
+}
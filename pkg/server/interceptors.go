@@ -43,7 +43,8 @@ func unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServ
 	return resp, err
 }
 
-func combineInterceptors(a, b grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+// CombineInterceptors returns an interceptor which runs a before b
+func CombineInterceptors(a, b grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		return a(ctx, req, info, func(actx context.Context, areq interface{}) (interface{}, error) {
 			return b(actx, areq, info, func(bctx context.Context, breq interface{}) (interface{}, error) {
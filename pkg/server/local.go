@@ -0,0 +1,39 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package server
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// IsLocal returns whether the caller of an rpc connected from a loopback address
+func IsLocal(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	addr, ok := p.Addr.(*net.TCPAddr)
+	return ok && addr.IP.IsLoopback()
+}
+
+// LocalOnly returns an interceptor rejecting calls of methods starting with
+// any of the prefixes, e.g. "/inspector.", from callers which aren't local.
+// It allows serving operator endpoints on the public server.
+func LocalOnly(prefixes ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(info.FullMethod, prefix) && !IsLocal(ctx) {
+				return nil, status.Errorf(codes.PermissionDenied, "%s is only served to local callers", info.FullMethod)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package server_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/pkg/server"
+)
+
+func TestLocalOnly(t *testing.T) {
+	local := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1234}})
+	remote := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1234}})
+
+	assert.True(t, server.IsLocal(local))
+	assert.False(t, server.IsLocal(remote))
+	assert.False(t, server.IsLocal(context.Background()))
+
+	interceptor := server.LocalOnly("/inspector.")
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	call := func(ctx context.Context, method string) (interface{}, error) {
+		return interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	}
+
+	resp, err := call(local, "/inspector.KadInspector/DumpRoutingTable")
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	_, err = call(remote, "/inspector.KadInspector/DumpRoutingTable")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	resp, err = call(remote, "/node.Nodes/Query")
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
@@ -18,6 +18,7 @@ type Options struct {
 	Ident    *identity.FullIdentity
 	RevDB    *peertls.RevocationDB
 	PCVFuncs []peertls.PeerCertVerificationFunc
+	Verified *identity.VerificationCache
 }
 
 // NewOptions is a constructor for `serverOptions` given an identity and config
@@ -36,7 +37,7 @@ func NewOptions(i *identity.FullIdentity, c Config) (*Options, error) {
 }
 
 func (opts *Options) grpcOpts() (grpc.ServerOption, error) {
-	return opts.Ident.ServerOption(opts.PCVFuncs...)
+	return opts.Ident.CachedServerOption(opts.Verified, opts.PCVFuncs...)
 }
 
 // configure adds peer certificate verification functions and revocation
@@ -45,6 +46,8 @@ func (opts *Options) configure(c Config) (err error) {
 	var pcvs []peertls.PeerCertVerificationFunc
 	parseOpts := peertls.ParseExtOptions{}
 
+	opts.Verified = identity.NewVerificationCache(c.PeerIdentityCacheSize)
+
 	if c.UsePeerCAWhitelist {
 		whitelist := []byte(DefaultPeerCAWhitelist)
 		if c.PeerCAWhitelistPath != "" {
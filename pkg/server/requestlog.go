@@ -0,0 +1,322 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/watchdog"
+	"storj.io/storj/storage"
+)
+
+// RequestLogConfig configures the log of the requests to public endpoints
+type RequestLogConfig struct {
+	URL           string        `help:"the database requests to public endpoints are logged to, e.g. bolt://$CONFDIR/requests.db, empty disables the request log" default:""`
+	SampleRate    float64       `help:"the fraction of successful requests which are logged, failed requests are always logged" default:"1"`
+	Retention     time.Duration `help:"how long requests are kept in the request log, 0 keeps them forever" default:"720h0m0s"`
+	PruneInterval time.Duration `help:"how frequently requests older than the retention are deleted" default:"1h0m0s"`
+}
+
+// RequestRecord is an entry of the request log
+type RequestRecord struct {
+	Time       time.Time     `json:"time"`
+	Method     string        `json:"method"`
+	Caller     string        `json:"caller,omitempty"`
+	APIKeyHash string        `json:"apikey_hash,omitempty"`
+	Resource   string        `json:"resource,omitempty"`
+	Code       string        `json:"code"`
+	Error      string        `json:"error,omitempty"`
+	Latency    time.Duration `json:"latency"`
+}
+
+// RequestLog is a log of the requests to the public endpoints of a server,
+// recording who called which method on which resource, and how it went. API
+// keys are only logged as hashes. Entries are keyed by the time they were
+// logged, so a log must not be shared by several servers.
+type RequestLog struct {
+	db         storage.KeyValueStore
+	sampleRate float64
+
+	mu     sync.Mutex
+	rand   *rand.Rand
+	loaded bool  // whether last was read from db
+	last   int64 // unix nanoseconds of the last key, so keys are unique
+}
+
+// NewRequestLog returns a request log appending to db, sampling the
+// successful requests at sampleRate
+func NewRequestLog(db storage.KeyValueStore, sampleRate float64) *RequestLog {
+	return &RequestLog{
+		db:         db,
+		sampleRate: sampleRate,
+		rand:       rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// requestKey returns the key of the entry logged at unix nanoseconds
+func requestKey(nanos int64) storage.Key {
+	return storage.Key(fmt.Sprintf("%020d", nanos))
+}
+
+// HashAPIKey returns the hash of an API key recorded in the request log
+func HashAPIKey(apiKey []byte) string {
+	hash := sha256.Sum256(apiKey)
+	return hex.EncodeToString(hash[:16])
+}
+
+// sampled returns whether a request with the code is logged
+func (log *RequestLog) sampled(code codes.Code) bool {
+	if code != codes.OK || log.sampleRate >= 1 {
+		return true
+	}
+	if log.sampleRate <= 0 {
+		return false
+	}
+
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	return log.rand.Float64() < log.sampleRate
+}
+
+// Append appends a record, its time is made unique when another record was
+// logged at the same time
+func (log *RequestLog) Append(record *RequestRecord) error {
+	log.mu.Lock()
+	defer log.mu.Unlock()
+
+	if !log.loaded {
+		if err := log.loadLast(); err != nil {
+			return err
+		}
+	}
+
+	nanos := record.Time.UnixNano()
+	if nanos <= log.last {
+		nanos = log.last + 1
+		record.Time = time.Unix(0, nanos).UTC()
+	}
+
+	value, err := json.Marshal(record)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if err := log.db.Put(requestKey(nanos), value); err != nil {
+		return err
+	}
+	log.last = nanos
+	return nil
+}
+
+// loadLast reads the time of the last entry of a log appended to before,
+// so that entries aren't overwritten when the clock went back since
+func (log *RequestLog) loadLast() error {
+	err := log.db.Iterate(storage.IterateOptions{Recurse: true, Reverse: true},
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			if !it.Next(&item) {
+				return nil
+			}
+			last, err := strconv.ParseInt(item.Key.String(), 10, 64)
+			if err != nil {
+				return Error.New("invalid request log key %q", item.Key)
+			}
+			log.last = last
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+	log.loaded = true
+	return nil
+}
+
+// Iterate calls fn with the requests logged since from, in the order they
+// were logged
+func (log *RequestLog) Iterate(from time.Time, fn func(record *RequestRecord) error) error {
+	return log.db.Iterate(storage.IterateOptions{First: requestKey(from.UnixNano()), Recurse: true},
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			for it.Next(&item) {
+				record := &RequestRecord{}
+				if err := json.Unmarshal(item.Value, record); err != nil {
+					return Error.New("error unmarshaling request %q: %v", item.Key, err)
+				}
+				if err := fn(record); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+// Prune deletes the requests logged before
+func (log *RequestLog) Prune(before time.Time) (deleted int, err error) {
+	last := requestKey(before.UnixNano())
+	for {
+		var batch storage.Batch
+		err := log.db.Iterate(storage.IterateOptions{Recurse: true},
+			func(it storage.Iterator) error {
+				var item storage.ListItem
+				for it.Next(&item) && len(batch.Deletes) < storage.LookupLimit {
+					if !item.Key.Less(last) {
+						// entries are logged in order, the rest is newer
+						return nil
+					}
+					batch.Deletes = append(batch.Deletes, storage.CloneKey(item.Key))
+				}
+				return nil
+			})
+		if err != nil {
+			return deleted, err
+		}
+		if len(batch.Deletes) == 0 {
+			return deleted, nil
+		}
+
+		if err := log.db.ApplyBatch(batch); err != nil {
+			return deleted, err
+		}
+		deleted += len(batch.Deletes)
+	}
+}
+
+// Close closes the store of the log
+func (log *RequestLog) Close() error { return log.db.Close() }
+
+// requestResource returns the path, prefix or bucket a request is about
+func requestResource(req interface{}) string {
+	switch req := req.(type) {
+	case interface{ GetPath() string }:
+		return req.GetPath()
+	case interface{ GetPrefix() string }:
+		return req.GetPrefix()
+	case interface{ GetBucket() string }:
+		return req.GetBucket()
+	case interface{ GetName() string }:
+		return req.GetName()
+	}
+	return ""
+}
+
+// requestAPIKey returns the API key sent with a request
+func requestAPIKey(ctx context.Context) ([]byte, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	apikeys := md["apikey"]
+	if len(apikeys) == 0 {
+		return nil, false
+	}
+	return []byte(apikeys[0]), true
+}
+
+// UnaryInterceptor returns an interceptor logging the requests it handles.
+// Failing to log a request doesn't fail the request, it's only counted.
+func (log *RequestLog) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		if !log.sampled(code) {
+			return resp, err
+		}
+
+		record := &RequestRecord{
+			Time:     start.UTC(),
+			Method:   info.FullMethod,
+			Resource: requestResource(req),
+			Code:     code.String(),
+			Latency:  time.Since(start),
+		}
+		if err != nil {
+			record.Error = status.Convert(err).Message()
+		}
+		if peer, perr := identity.PeerIdentityFromContext(ctx); perr == nil {
+			record.Caller = peer.ID.String()
+		}
+		if apiKey, ok := requestAPIKey(ctx); ok {
+			record.APIKeyHash = HashAPIKey(apiKey)
+		}
+
+		if lerr := log.Append(record); lerr != nil {
+			mon.Counter("request_log_failed").Inc(1)
+			zap.S().Debugf("logging request %s failed: %v", info.FullMethod, lerr)
+		}
+		return resp, err
+	}
+}
+
+// RequestLogPruner deletes the requests older than the retention from a
+// request log
+type RequestLogPruner struct {
+	log        *zap.Logger
+	requestLog *RequestLog
+	retention  time.Duration
+	interval   time.Duration
+	loop       *watchdog.Loop
+}
+
+// NewRequestLogPruner creates a pruner of requestLog, a retention of 0 keeps
+// requests forever
+func NewRequestLogPruner(log *zap.Logger, requestLog *RequestLog, retention, interval time.Duration, loop *watchdog.Loop) *RequestLogPruner {
+	return &RequestLogPruner{
+		log:        log,
+		requestLog: requestLog,
+		retention:  retention,
+		interval:   interval,
+		loop:       loop,
+	}
+}
+
+// Run prunes the request log every interval, until the context is canceled
+func (pruner *RequestLogPruner) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if pruner.retention <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(pruner.interval)
+	defer ticker.Stop()
+
+	for {
+		err := pruner.Prune(ctx, time.Now())
+		if err != nil {
+			pruner.log.Error("pruning the request log failed", zap.Error(err))
+		}
+		pruner.loop.Cycle(err)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Prune deletes the requests which are older than the retention by now
+func (pruner *RequestLogPruner) Prune(ctx context.Context, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	deleted, err := pruner.requestLog.Prune(now.Add(-pruner.retention))
+	mon.IntVal("request_log_pruned").Observe(int64(deleted))
+	return err
+}
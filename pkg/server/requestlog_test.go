@@ -0,0 +1,106 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package server_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/server"
+	"storj.io/storj/storage/teststore"
+)
+
+func TestRequestLog(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	requestLog := server.NewRequestLog(teststore.New(), 1)
+	defer ctx.Check(requestLog.Close)
+
+	interceptor := requestLog.UnaryInterceptor()
+	call := func(ctx context.Context, method string, req interface{}, err error) {
+		_, _ = interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, err
+		})
+	}
+
+	start := time.Now()
+	withKey := metadata.NewIncomingContext(ctx, metadata.Pairs("apikey", "secret"))
+	call(withKey, "/pointerdb.PointerDB/Get", &pb.GetRequest{Path: "l/bucket/a"}, nil)
+	call(withKey, "/pointerdb.PointerDB/Delete", &pb.DeleteRequest{Path: "l/bucket/b"}, status.Error(codes.NotFound, "not found"))
+	call(ctx, "/node.Nodes/Query", nil, nil)
+
+	var records []*server.RequestRecord
+	require.NoError(t, requestLog.Iterate(start, func(record *server.RequestRecord) error {
+		records = append(records, record)
+		return nil
+	}))
+	require.Len(t, records, 3)
+
+	assert.Equal(t, "/pointerdb.PointerDB/Get", records[0].Method)
+	assert.Equal(t, "l/bucket/a", records[0].Resource)
+	assert.Equal(t, codes.OK.String(), records[0].Code)
+	assert.Equal(t, server.HashAPIKey([]byte("secret")), records[0].APIKeyHash)
+	assert.NotContains(t, records[0].APIKeyHash, "secret")
+
+	assert.Equal(t, "l/bucket/b", records[1].Resource)
+	assert.Equal(t, codes.NotFound.String(), records[1].Code)
+	assert.Equal(t, "not found", records[1].Error)
+	assert.True(t, records[0].Time.Before(records[1].Time))
+
+	assert.Empty(t, records[2].APIKeyHash)
+	assert.Empty(t, records[2].Resource)
+
+	// records logged before are pruned
+	deleted, err := requestLog.Prune(records[2].Time)
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	records = nil
+	require.NoError(t, requestLog.Iterate(start, func(record *server.RequestRecord) error {
+		records = append(records, record)
+		return nil
+	}))
+	require.Len(t, records, 1)
+	assert.Equal(t, "/node.Nodes/Query", records[0].Method)
+}
+
+func TestRequestLogSampling(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	requestLog := server.NewRequestLog(teststore.New(), 0)
+	defer ctx.Check(requestLog.Close)
+
+	interceptor := requestLog.UnaryInterceptor()
+	call := func(err error) {
+		_, _ = interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/node.Nodes/Query"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, err
+		})
+	}
+
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		call(nil)
+	}
+	call(status.Error(codes.PermissionDenied, "denied"))
+
+	// failed requests are logged regardless of the sample rate
+	var logged []string
+	require.NoError(t, requestLog.Iterate(start, func(record *server.RequestRecord) error {
+		logged = append(logged, record.Code)
+		return nil
+	}))
+	assert.Equal(t, []string{"PermissionDenied"}, logged)
+}
@@ -40,7 +40,7 @@ func NewServer(opts *Options, lis net.Listener,
 
 	unaryInterceptor := unaryInterceptor
 	if interceptor != nil {
-		unaryInterceptor = combineInterceptors(unaryInterceptor, interceptor)
+		unaryInterceptor = CombineInterceptors(unaryInterceptor, interceptor)
 	}
 
 	return &Server{
@@ -0,0 +1,59 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package statdb
+
+import (
+	"math"
+	"time"
+)
+
+// Reputation is an exponentially weighted success ratio. Alpha is the
+// weighted number of successes and Beta the weighted number of failures;
+// both lose half of their weight every half-life, so recent results
+// matter more than old ones.
+type Reputation struct {
+	Alpha float64
+	Beta  float64
+}
+
+// NewReputation creates a reputation from undecayed success and total counts
+func NewReputation(successCount, totalCount int64) Reputation {
+	return Reputation{
+		Alpha: float64(successCount),
+		Beta:  float64(totalCount - successCount),
+	}
+}
+
+// Decay returns the reputation after elapsed time has passed. A non-positive
+// half-life disables decay.
+func (rep Reputation) Decay(elapsed, halfLife time.Duration) Reputation {
+	if halfLife <= 0 || elapsed <= 0 {
+		return rep
+	}
+	factor := math.Pow(0.5, float64(elapsed)/float64(halfLife))
+	return Reputation{
+		Alpha: rep.Alpha * factor,
+		Beta:  rep.Beta * factor,
+	}
+}
+
+// Update decays the reputation by elapsed time and adds a new result
+func (rep Reputation) Update(success bool, elapsed, halfLife time.Duration) Reputation {
+	rep = rep.Decay(elapsed, halfLife)
+	if success {
+		rep.Alpha++
+	} else {
+		rep.Beta++
+	}
+	return rep
+}
+
+// Score returns the weighted success ratio, 0 when there are no results
+func (rep Reputation) Score() float64 {
+	total := rep.Alpha + rep.Beta
+	if total <= 0 {
+		return 0
+	}
+	return rep.Alpha / total
+}
@@ -0,0 +1,31 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package statdb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/pkg/statdb"
+)
+
+func TestReputation(t *testing.T) {
+	rep := statdb.NewReputation(3, 4)
+	assert.EqualValues(t, 0.75, rep.Score())
+	assert.EqualValues(t, 0, statdb.Reputation{}.Score())
+
+	// decay doesn't change the score, only the weight of past results
+	decayed := rep.Decay(time.Hour, time.Hour)
+	assert.EqualValues(t, statdb.Reputation{Alpha: 1.5, Beta: 0.5}, decayed)
+	assert.EqualValues(t, 0.75, decayed.Score())
+
+	// no decay without a half-life
+	assert.Equal(t, rep, rep.Decay(time.Hour, 0))
+
+	// recent results matter more
+	assert.EqualValues(t, statdb.Reputation{Alpha: 1.5, Beta: 1.5}, rep.Update(false, time.Hour, time.Hour))
+	assert.EqualValues(t, statdb.Reputation{Alpha: 3, Beta: 2}, rep.Update(false, time.Hour, 0))
+}
@@ -5,6 +5,7 @@ package statdb
 
 import (
 	"context"
+	"time"
 
 	"storj.io/storj/pkg/storj"
 )
@@ -20,9 +21,9 @@ type DB interface {
 	// Update all parts of single storagenode's stats.
 	Update(ctx context.Context, request *UpdateRequest) (stats *NodeStats, err error)
 	// UpdateUptime updates a single storagenode's uptime stats.
-	UpdateUptime(ctx context.Context, nodeID storj.NodeID, isUp bool) (stats *NodeStats, err error)
+	UpdateUptime(ctx context.Context, nodeID storj.NodeID, isUp bool, halfLife time.Duration) (stats *NodeStats, err error)
 	// UpdateAuditSuccess updates a single storagenode's audit stats.
-	UpdateAuditSuccess(ctx context.Context, nodeID storj.NodeID, auditSuccess bool) (stats *NodeStats, err error)
+	UpdateAuditSuccess(ctx context.Context, nodeID storj.NodeID, auditSuccess bool, halfLife time.Duration) (stats *NodeStats, err error)
 	// UpdateBatch for updating multiple storage nodes' stats.
 	UpdateBatch(ctx context.Context, requests []*UpdateRequest) (statslist []*NodeStats, failed []*UpdateRequest, err error)
 	// CreateEntryIfNotExists creates a node stats entry if it didn't already exist.
@@ -34,6 +35,8 @@ type UpdateRequest struct {
 	NodeID       storj.NodeID
 	AuditSuccess bool
 	IsUp         bool
+	// HalfLife is how long it takes for a result to count half as much, 0 disables decay.
+	HalfLife time.Duration
 }
 
 // NodeStats contains statistics abot a node.
@@ -45,4 +48,6 @@ type NodeStats struct {
 	UptimeRatio        float64
 	UptimeSuccessCount int64
 	UptimeCount        int64
+	AuditReputation    float64
+	UptimeReputation   float64
 }
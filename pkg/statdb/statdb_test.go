@@ -6,6 +6,7 @@ package statdb_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -188,7 +189,7 @@ func testDatabase(ctx context.Context, t *testing.T, sdb statdb.DB) {
 		assert.EqualValues(t, currUptimeSuccess, stats.UptimeSuccessCount)
 		assert.EqualValues(t, uptimeRatio, stats.UptimeRatio)
 
-		stats, err = sdb.UpdateUptime(ctx, nodeID, false)
+		stats, err = sdb.UpdateUptime(ctx, nodeID, false, 0)
 		assert.NoError(t, err)
 
 		currUptimeCount++
@@ -196,6 +197,7 @@ func testDatabase(ctx context.Context, t *testing.T, sdb statdb.DB) {
 		assert.EqualValues(t, auditSuccessRatio, stats.AuditSuccessRatio)
 		assert.EqualValues(t, currAuditCount, stats.AuditCount)
 		assert.EqualValues(t, newUptimeRatio, stats.UptimeRatio)
+		assert.InDelta(t, newUptimeRatio, stats.UptimeReputation, 1e-9)
 	}
 
 	{ // TestUpdateAuditSuccessExists
@@ -213,7 +215,7 @@ func testDatabase(ctx context.Context, t *testing.T, sdb statdb.DB) {
 		assert.EqualValues(t, currUptimeSuccess, stats.UptimeSuccessCount)
 		assert.EqualValues(t, uptimeRatio, stats.UptimeRatio)
 
-		stats, err = sdb.UpdateAuditSuccess(ctx, nodeID, false)
+		stats, err = sdb.UpdateAuditSuccess(ctx, nodeID, false, 0)
 		assert.NoError(t, err)
 
 		currAuditCount++
@@ -221,6 +223,27 @@ func testDatabase(ctx context.Context, t *testing.T, sdb statdb.DB) {
 		assert.EqualValues(t, newAuditRatio, stats.AuditSuccessRatio)
 		assert.EqualValues(t, currAuditCount, stats.AuditCount)
 		assert.EqualValues(t, uptimeRatio, stats.UptimeRatio)
+		assert.InDelta(t, newAuditRatio, stats.AuditReputation, 1e-9)
+	}
+
+	{ // TestReputationDecay
+		decayNodeID := storj.NodeID{255, 255}
+
+		stats, err := sdb.Create(ctx, decayNodeID, &statdb.NodeStats{
+			AuditSuccessCount:  100,
+			AuditCount:         100,
+			UptimeSuccessCount: 100,
+			UptimeCount:        100,
+		})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, stats.AuditReputation)
+		assert.EqualValues(t, 1, stats.UptimeReputation)
+
+		// with a tiny half-life the old results are forgotten
+		stats, err = sdb.UpdateAuditSuccess(ctx, decayNodeID, false, time.Nanosecond)
+		assert.NoError(t, err)
+		assert.EqualValues(t, getRatio(100, 101), stats.AuditSuccessRatio)
+		assert.InDelta(t, 0, stats.AuditReputation, 1e-6)
 	}
 
 	{ // TestUpdateBatchExists
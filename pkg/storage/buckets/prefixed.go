@@ -64,3 +64,19 @@ func (o *prefixedObjStore) List(ctx context.Context, prefix, startAfter, endBefo
 
 	return o.store.List(ctx, storj.JoinPaths(o.prefix, prefix), startAfter, endBefore, recursive, limit, metaFlags)
 }
+
+func (o *prefixedObjStore) ListPending(ctx context.Context, prefix, startAfter, endBefore storj.Path, limit int) (items []objects.ListItem, more bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return o.store.ListPending(ctx, storj.JoinPaths(o.prefix, prefix), startAfter, endBefore, limit)
+}
+
+func (o *prefixedObjStore) DeletePending(ctx context.Context, path storj.Path) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(path) == 0 {
+		return storj.ErrNoPath.New("")
+	}
+
+	return o.store.DeletePending(ctx, storj.JoinPaths(o.prefix, path))
+}
@@ -25,10 +25,11 @@ import (
 
 var mon = monkit.Package()
 
-// Client defines an interface for storing erasure coded data to piece store nodes
+// Client defines an interface for storing erasure coded data to piece store nodes.
+// The expected size passed to Put is the maximum size of data, 0 if unknown.
 type Client interface {
 	Put(ctx context.Context, nodes []*pb.Node, rs eestream.RedundancyStrategy,
-		pieceID psclient.PieceID, data io.Reader, expiration time.Time, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (successfulNodes []*pb.Node, err error)
+		pieceID psclient.PieceID, data io.Reader, expectedSize int64, expiration time.Time, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (successfulNodes []*pb.Node, err error)
 	Get(ctx context.Context, nodes []*pb.Node, es eestream.ErasureScheme,
 		pieceID psclient.PieceID, size int64, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (ranger.Ranger, error)
 	Delete(ctx context.Context, nodes []*pb.Node, pieceID psclient.PieceID, authorization *pb.SignedMessage) error
@@ -45,7 +46,11 @@ type ecClient struct {
 
 // NewClient from the given identity and max buffer memory
 func NewClient(identity *provider.FullIdentity, memoryLimit int) Client {
-	tc := transport.NewClient(identity)
+	return NewTransportClient(transport.NewClient(identity), memoryLimit)
+}
+
+// NewTransportClient from the given transport client and max buffer memory
+func NewTransportClient(tc transport.Client, memoryLimit int) Client {
 	return &ecClient{
 		transport:       tc,
 		memoryLimit:     memoryLimit,
@@ -59,7 +64,7 @@ func (ec *ecClient) newPSClient(ctx context.Context, n *pb.Node) (psclient.Clien
 }
 
 func (ec *ecClient) Put(ctx context.Context, nodes []*pb.Node, rs eestream.RedundancyStrategy,
-	pieceID psclient.PieceID, data io.Reader, expiration time.Time, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (successfulNodes []*pb.Node, err error) {
+	pieceID psclient.PieceID, data io.Reader, expectedSize int64, expiration time.Time, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (successfulNodes []*pb.Node, err error) {
 	defer mon.Task()(&ctx)(&err)
 	if len(nodes) != rs.TotalCount() {
 		return nil, Error.New("size of nodes slice (%d) does not match total count (%d) of erasure scheme", len(nodes), rs.TotalCount())
@@ -73,8 +78,10 @@ func (ec *ecClient) Put(ctx context.Context, nodes []*pb.Node, rs eestream.Redun
 		return nil, Error.New("duplicated nodes are not allowed")
 	}
 
+	encodeMemory, replayLimit := splitMemory(ec.memoryLimit, len(nodes))
+
 	padded := eestream.PadReader(ioutil.NopCloser(data), rs.StripeSize())
-	readers, err := eestream.EncodeReader(ctx, padded, rs, ec.memoryLimit)
+	readers, err := eestream.EncodeReader(ctx, padded, rs, encodeMemory)
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +92,11 @@ func (ec *ecClient) Put(ctx context.Context, nodes []*pb.Node, rs eestream.Redun
 	}
 	infos := make(chan info, len(nodes))
 
+	var pieceSize int64
+	if expectedSize > 0 {
+		pieceSize = calcPieceSize(expectedSize, rs)
+	}
+
 	for i, n := range nodes {
 
 		if n != nil {
@@ -104,17 +116,7 @@ func (ec *ecClient) Put(ctx context.Context, nodes []*pb.Node, rs eestream.Redun
 				infos <- info{i: i, err: err}
 				return
 			}
-			ps, err := ec.newPSClient(ctx, n)
-			if err != nil {
-				zap.S().Errorf("Failed dialing for putting piece %s -> %s to node %s: %v",
-					pieceID, derivedPieceID, n.Id, err)
-				infos <- info{i: i, err: err}
-				return
-			}
-			err = ps.Put(ctx, derivedPieceID, readers[i], expiration, pba, authorization)
-			// normally the bellow call should be deferred, but doing so fails
-			// randomly the unit tests
-			utils.LogClose(ps)
+			err = ec.putPiece(ctx, n, pieceID, derivedPieceID, readers[i], replayLimit, pieceSize, expiration, pba, authorization)
 			// io.ErrUnexpectedEOF means the piece upload was interrupted due to slow connection.
 			// No error logging for this case.
 			if err != nil && err != io.ErrUnexpectedEOF {
@@ -158,6 +160,37 @@ func (ec *ecClient) Put(ctx context.Context, nodes []*pb.Node, rs eestream.Redun
 	return successfulNodes, nil
 }
 
+// putPiece uploads the piece to the node, resuming the upload when the
+// connection to the node breaks as long as no more than replayLimit bytes of
+// the piece were sent
+func (ec *ecClient) putPiece(ctx context.Context, n *pb.Node, pieceID, derivedPieceID psclient.PieceID, data io.Reader, replayLimit int,
+	pieceSize int64, expiration time.Time, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (err error) {
+	replay := newReplayReader(data, replayLimit)
+	for resumes := 0; ; resumes++ {
+		ps, err := ec.newPSClient(ctx, n)
+		if err != nil {
+			zap.S().Errorf("Failed dialing for putting piece %s -> %s to node %s: %v",
+				pieceID, derivedPieceID, n.Id, err)
+			return err
+		}
+		if resumes == 0 {
+			err = ps.Put(ctx, derivedPieceID, replay, pieceSize, expiration, pba, authorization)
+		} else {
+			err = ps.Resume(ctx, derivedPieceID, replay, pieceSize, expiration, pba, authorization)
+		}
+		// normally the bellow call should be deferred, but doing so fails
+		// randomly the unit tests
+		utils.LogClose(ps)
+
+		if !psclient.ErrInterrupted.Has(err) || resumes >= maxResumes || ctx.Err() != nil {
+			return err
+		}
+		zap.S().Infof("Resuming interrupted upload of piece %s -> %s to node %s: %v",
+			pieceID, derivedPieceID, n.Id, err)
+		mon.Meter("piece_upload_resumed").Mark(1)
+	}
+}
+
 func (ec *ecClient) Get(ctx context.Context, nodes []*pb.Node, es eestream.ErasureScheme,
 	pieceID psclient.PieceID, size int64, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (rr ranger.Ranger, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -368,3 +401,11 @@ func nonNilCount(nodes []*pb.Node) int {
 	}
 	return total
 }
+
+// calcPieceSize returns the size of the pieces data of dataSize is erasure
+// encoded into
+func calcPieceSize(dataSize int64, es eestream.ErasureScheme) int64 {
+	stripeSize := es.StripeSize()
+	stripes := eestream.PaddedSize(dataSize, stripeSize) / int64(stripeSize)
+	return stripes * int64(es.ErasureShareSize())
+}
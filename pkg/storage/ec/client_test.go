@@ -4,6 +4,7 @@
 package ecclient
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
@@ -19,6 +20,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/vivint/infectious"
 
+	"storj.io/storj/internal/memory"
 	"storj.io/storj/internal/teststorj"
 	"storj.io/storj/pkg/eestream"
 	"storj.io/storj/pkg/pb"
@@ -134,8 +136,8 @@ TestLoop:
 			}
 			ps := NewMockPSClient(ctrl)
 			gomock.InOrder(
-				ps.EXPECT().Put(gomock.Any(), derivedID, gomock.Any(), ttl, gomock.Any(), gomock.Any()).Return(errs[n]).
-					Do(func(ctx context.Context, id psclient.PieceID, data io.Reader, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) {
+				ps.EXPECT().Put(gomock.Any(), derivedID, gomock.Any(), gomock.Any(), ttl, gomock.Any(), gomock.Any()).Return(errs[n]).
+					Do(func(ctx context.Context, id psclient.PieceID, data io.Reader, size int64, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) {
 						// simulate that the mocked piece store client is reading the data
 						_, err := io.Copy(ioutil.Discard, data)
 						assert.NoError(t, err, errTag)
@@ -151,7 +153,7 @@ TestLoop:
 		r := io.LimitReader(rand.Reader, int64(size))
 		ec := ecClient{newPSClientFunc: mockNewPSClient(clients), memoryLimit: tt.mbm}
 
-		successfulNodes, err := ec.Put(ctx, tt.nodes, rs, id, r, ttl, nil, nil)
+		successfulNodes, err := ec.Put(ctx, tt.nodes, rs, id, r, int64(size), ttl, nil, nil)
 
 		if tt.errString != "" {
 			assert.EqualError(t, err, tt.errString, errTag)
@@ -169,6 +171,122 @@ TestLoop:
 	}
 }
 
+func TestPutResume(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	size := 32 * 1024
+	k := 2
+	n := 4
+	fc, err := infectious.NewFEC(k, n)
+	if !assert.NoError(t, err) {
+		return
+	}
+	es := eestream.NewRSScheme(fc, size/n)
+	rs, err := eestream.NewRedundancyStrategy(es, 0, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	id := psclient.NewPieceID()
+	ttl := time.Now()
+	nodes := []*pb.Node{node0, node1, node2, node3}
+
+	clients := make(map[*pb.Node]psclient.Client, len(nodes))
+	for _, n := range nodes {
+		derivedID, err := id.Derive(n.Id.Bytes())
+		if !assert.NoError(t, err) {
+			return
+		}
+		ps := NewMockPSClient(ctrl)
+		clients[n] = ps
+
+		if n != node0 {
+			gomock.InOrder(
+				ps.EXPECT().Put(gomock.Any(), derivedID, gomock.Any(), gomock.Any(), ttl, gomock.Any(), gomock.Any()).Return(nil).
+					Do(func(ctx context.Context, id psclient.PieceID, data io.Reader, size int64, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) {
+						_, err := io.Copy(ioutil.Discard, data)
+						assert.NoError(t, err)
+					}),
+				ps.EXPECT().Close().Return(nil),
+			)
+			continue
+		}
+
+		// the connection to node0 breaks after the whole piece was sent,
+		// of which the node wrote 100 bytes
+		var sent []byte
+		gomock.InOrder(
+			ps.EXPECT().Put(gomock.Any(), derivedID, gomock.Any(), gomock.Any(), ttl, gomock.Any(), gomock.Any()).Return(psclient.ErrInterrupted.New("connection lost")).
+				Do(func(ctx context.Context, id psclient.PieceID, data io.Reader, size int64, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) {
+					var err error
+					sent, err = ioutil.ReadAll(data)
+					assert.NoError(t, err)
+				}),
+			ps.EXPECT().Close().Return(nil),
+			ps.EXPECT().Resume(gomock.Any(), derivedID, gomock.Any(), gomock.Any(), ttl, gomock.Any(), gomock.Any()).Return(nil).
+				Do(func(ctx context.Context, id psclient.PieceID, data io.ReadSeeker, size int64, ttl time.Time, ba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) {
+					_, err := data.Seek(100, io.SeekStart)
+					assert.NoError(t, err)
+					rest, err := ioutil.ReadAll(data)
+					assert.NoError(t, err)
+					assert.Equal(t, sent[100:], rest)
+				}),
+			ps.EXPECT().Close().Return(nil),
+		)
+	}
+
+	// the resumed piece is kept in the buffer memory
+	r := io.LimitReader(rand.Reader, int64(size))
+	ec := ecClient{newPSClientFunc: mockNewPSClient(clients), memoryLimit: memory.MiB.Int()}
+
+	successfulNodes, err := ec.Put(ctx, nodes, rs, id, r, int64(size), ttl, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, nodes, successfulNodes)
+}
+
+func TestSplitMemory(t *testing.T) {
+	for _, tt := range []struct {
+		memoryLimit, pieces int
+		encode, replay      int
+	}{
+		{memoryLimit: 0, pieces: 4, encode: 0, replay: 0},
+		{memoryLimit: 4 * memory.MiB.Int(), pieces: 4, encode: 2 * memory.MiB.Int(), replay: 512 * memory.KiB.Int()},
+		{memoryLimit: 64 * memory.MiB.Int(), pieces: 4, encode: 48 * memory.MiB.Int(), replay: maxResumeBuffer.Int()},
+		{memoryLimit: 1000, pieces: 3, encode: 502, replay: 166},
+		{memoryLimit: 1000, pieces: 0, encode: 1000, replay: 0},
+	} {
+		encode, replay := splitMemory(tt.memoryLimit, tt.pieces)
+		assert.Equal(t, tt.encode, encode, "%+v", tt)
+		assert.Equal(t, tt.replay, replay, "%+v", tt)
+	}
+}
+
+func TestReplayReader(t *testing.T) {
+	data := []byte("0123456789")
+
+	replay := newReplayReader(bytes.NewReader(data), 4)
+	buf := make([]byte, 3)
+	_, err := io.ReadFull(replay, buf)
+	assert.NoError(t, err)
+
+	// the read bytes are read again after seeking back
+	_, err = replay.Seek(1, io.SeekStart)
+	assert.NoError(t, err)
+	rest, err := ioutil.ReadAll(replay)
+	assert.NoError(t, err)
+	assert.Equal(t, data[1:], rest)
+
+	// bytes past the limit aren't kept
+	_, err = replay.Seek(1, io.SeekStart)
+	assert.Error(t, err)
+	_, err = replay.Seek(int64(len(data)), io.SeekStart)
+	assert.NoError(t, err)
+	_, err = replay.Seek(int64(len(data))+1, io.SeekStart)
+	assert.Error(t, err)
+}
+
 func mockNewPSClient(clients map[*pb.Node]psclient.Client) psClientFunc {
 	return func(_ context.Context, _ transport.Client, n *pb.Node, _ int) (psclient.Client, error) {
 		n.Type.DPanicOnInvalid("mock new ps client")
@@ -95,16 +95,41 @@ func (mr *MockPSClientMockRecorder) Meta(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Meta", reflect.TypeOf((*MockPSClient)(nil).Meta), arg0, arg1)
 }
 
+// PartialPiece mocks base method
+func (m *MockPSClient) PartialPiece(arg0 context.Context, arg1 client.PieceID, arg2 *pb.SignedMessage) (int64, error) {
+	ret := m.ctrl.Call(m, "PartialPiece", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PartialPiece indicates an expected call of PartialPiece
+func (mr *MockPSClientMockRecorder) PartialPiece(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PartialPiece", reflect.TypeOf((*MockPSClient)(nil).PartialPiece), arg0, arg1, arg2)
+}
+
 // Put mocks base method
-func (m *MockPSClient) Put(arg0 context.Context, arg1 client.PieceID, arg2 io.Reader, arg3 time.Time, arg4 *pb.PayerBandwidthAllocation, arg5 *pb.SignedMessage) error {
-	ret := m.ctrl.Call(m, "Put", arg0, arg1, arg2, arg3, arg4, arg5)
+func (m *MockPSClient) Put(arg0 context.Context, arg1 client.PieceID, arg2 io.Reader, arg3 int64, arg4 time.Time, arg5 *pb.PayerBandwidthAllocation, arg6 *pb.SignedMessage) error {
+	ret := m.ctrl.Call(m, "Put", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Put indicates an expected call of Put
-func (mr *MockPSClientMockRecorder) Put(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockPSClient)(nil).Put), arg0, arg1, arg2, arg3, arg4, arg5)
+func (mr *MockPSClientMockRecorder) Put(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockPSClient)(nil).Put), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+// Resume mocks base method
+func (m *MockPSClient) Resume(arg0 context.Context, arg1 client.PieceID, arg2 io.ReadSeeker, arg3 int64, arg4 time.Time, arg5 *pb.PayerBandwidthAllocation, arg6 *pb.SignedMessage) error {
+	ret := m.ctrl.Call(m, "Resume", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resume indicates an expected call of Resume
+func (mr *MockPSClientMockRecorder) Resume(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockPSClient)(nil).Resume), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
 // Stats mocks base method
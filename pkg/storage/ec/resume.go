@@ -0,0 +1,86 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ecclient
+
+import (
+	"io"
+
+	"storj.io/storj/internal/memory"
+)
+
+const (
+	// maxResumes is how often an interrupted upload of a piece is resumed
+	maxResumes = 2
+	// maxResumeBuffer is how much of a piece is kept at most to resume its
+	// upload, uploads of larger pieces can only be resumed past it
+	maxResumeBuffer = 4 * memory.MiB
+)
+
+// splitMemory splits the memory limit of an upload of pieces between the
+// encoder and the buffers kept to resume the uploads of the pieces, which
+// get half of it and at most maxResumeBuffer each
+func splitMemory(memoryLimit, pieces int) (encode, replay int) {
+	if pieces <= 0 {
+		return memoryLimit, 0
+	}
+	replay = memoryLimit / 2 / pieces
+	if replay > maxResumeBuffer.Int() {
+		replay = maxResumeBuffer.Int()
+	}
+	return memoryLimit - replay*pieces, replay
+}
+
+// replayReader reads data, keeping the first limit bytes read so that reading
+// can start over from an offset which was already read
+type replayReader struct {
+	data  io.Reader
+	limit int
+
+	kept    []byte
+	dropped bool
+	read    int64
+	pos     int64
+}
+
+// newReplayReader creates a reader of data which keeps up to limit bytes
+func newReplayReader(data io.Reader, limit int) *replayReader {
+	return &replayReader{data: data, limit: limit}
+}
+
+// Read reads the kept bytes after seeking back, data afterwards
+func (r *replayReader) Read(p []byte) (n int, err error) {
+	if r.pos < r.read {
+		n = copy(p, r.kept[r.pos:])
+		r.pos += int64(n)
+		return n, nil
+	}
+
+	n, err = r.data.Read(p)
+	if !r.dropped {
+		if len(r.kept)+n <= r.limit {
+			r.kept = append(r.kept, p[:n]...)
+		} else {
+			r.kept, r.dropped = nil, true
+		}
+	}
+	r.read += int64(n)
+	r.pos += int64(n)
+	return n, err
+}
+
+// Seek moves back to an offset which was read, it fails when the bytes from
+// there on weren't kept
+func (r *replayReader) Seek(offset int64, whence int) (int64, error) {
+	if whence != io.SeekStart {
+		return r.pos, Error.New("unsupported whence %d", whence)
+	}
+	if offset < 0 || offset > r.read {
+		return r.pos, Error.New("offset %d outside of the %d bytes read", offset, r.read)
+	}
+	if offset < r.read && r.dropped {
+		return r.pos, Error.New("bytes from offset %d weren't kept", offset)
+	}
+	r.pos = offset
+	return offset, nil
+}
@@ -3,7 +3,7 @@
 
 package meta
 
-// Meta flags for the List method
+// Meta flags select the fields of pointers returned by List and Get
 const (
 	// None represents no meta flags
 	None = 0
@@ -17,6 +17,10 @@ const (
 	Checksum
 	// UserDefined meta flag
 	UserDefined
+	// Remote meta flag, the remote segment with its redundancy scheme and pieces
+	Remote
+	// Inline meta flag, the inline segment
+	Inline
 	// All represents all the meta flags
 	All = ^uint32(0)
 )
@@ -44,6 +44,8 @@ type Store interface {
 	Put(ctx context.Context, path storj.Path, data io.Reader, metadata pb.SerializableMeta, expiration time.Time) (meta Meta, err error)
 	Delete(ctx context.Context, path storj.Path) (err error)
 	List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error)
+	ListPending(ctx context.Context, prefix, startAfter, endBefore storj.Path, limit int) (items []ListItem, more bool, err error)
+	DeletePending(ctx context.Context, path storj.Path) (err error)
 }
 
 type objStore struct {
@@ -103,7 +105,7 @@ func (o *objStore) Put(ctx context.Context, path storj.Path, data io.Reader, met
 	if err != nil {
 		return Meta{}, err
 	}
-	m, err := o.store.Put(ctx, path, o.pathCipher, data, b, expiration)
+	m, err := o.store.Put(ctx, path, o.pathCipher, data, 0, b, expiration)
 	return convertMeta(m), err
 }
 
@@ -144,6 +146,45 @@ func (o *objStore) List(ctx context.Context, prefix, startAfter, endBefore storj
 	return items, more, nil
 }
 
+func (o *objStore) ListPending(ctx context.Context, prefix, startAfter, endBefore storj.Path, limit int) (
+	items []ListItem, more bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	strItems, more, err := o.store.ListPending(ctx, prefix, startAfter, endBefore, o.pathCipher, limit)
+	if err != nil {
+		return nil, false, err
+	}
+
+	items = make([]ListItem, len(strItems))
+	for i, itm := range strItems {
+		items[i] = ListItem{
+			Path: itm.Path,
+			Meta: Meta{
+				Modified:   itm.Meta.Modified,
+				Expiration: itm.Meta.Expiration,
+			},
+		}
+	}
+
+	return items, more, nil
+}
+
+func (o *objStore) DeletePending(ctx context.Context, path storj.Path) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(path) == 0 {
+		return storj.ErrNoPath.New("")
+	}
+
+	err = o.store.DeletePending(ctx, path, o.pathCipher)
+
+	if storage.ErrKeyNotFound.Has(err) {
+		err = storj.ErrObjectNotFound.Wrap(err)
+	}
+
+	return err
+}
+
 // convertMeta converts stream metadata to object metadata
 func convertMeta(m streams.Meta) Meta {
 	ser := pb.SerializableMeta{}
@@ -79,16 +79,16 @@ func (mr *MockStoreMockRecorder) Repair(ctx, path, lostPieces interface{}) *gomo
 }
 
 // Put mocks base method
-func (m *MockStore) Put(ctx context.Context, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (Meta, error) {
-	ret := m.ctrl.Call(m, "Put", ctx, data, expiration, segmentInfo)
+func (m *MockStore) Put(ctx context.Context, data io.Reader, expectedSize int64, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (Meta, error) {
+	ret := m.ctrl.Call(m, "Put", ctx, data, expectedSize, expiration, segmentInfo)
 	ret0, _ := ret[0].(Meta)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Put indicates an expected call of Put
-func (mr *MockStoreMockRecorder) Put(ctx, data, expiration, segmentInfo interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockStore)(nil).Put), ctx, data, expiration, segmentInfo)
+func (mr *MockStoreMockRecorder) Put(ctx, data, expectedSize, expiration, segmentInfo interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockStore)(nil).Put), ctx, data, expectedSize, expiration, segmentInfo)
 }
 
 // Delete mocks base method
@@ -103,6 +103,18 @@ func (mr *MockStoreMockRecorder) Delete(ctx, path interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStore)(nil).Delete), ctx, path)
 }
 
+// Undelete mocks base method
+func (m *MockStore) Undelete(ctx context.Context, path storj.Path) error {
+	ret := m.ctrl.Call(m, "Undelete", ctx, path)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Undelete indicates an expected call of Undelete
+func (mr *MockStoreMockRecorder) Undelete(ctx, path interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Undelete", reflect.TypeOf((*MockStore)(nil).Undelete), ctx, path)
+}
+
 // List mocks base method
 func (m *MockStore) List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) ([]ListItem, bool, error) {
 	ret := m.ctrl.Call(m, "List", ctx, prefix, startAfter, endBefore, recursive, limit, metaFlags)
@@ -28,18 +28,20 @@ func NewSegmentRepairer(oc overlay.Client, ec ecclient.Client, pdb pdbclient.Cli
 	return &Repairer{oc: oc, ec: ec, pdb: pdb}
 }
 
-// Repair retrieves an at-risk segment and repairs and stores lost pieces on new nodes
-func (s *Repairer) Repair(ctx context.Context, path storj.Path, lostPieces []int32) (err error) {
+// Repair retrieves an at-risk segment and repairs and stores lost pieces on new nodes.
+// It returns an estimate of the bytes transferred with storage nodes, which
+// is also set when the repair fails halfway.
+func (s *Repairer) Repair(ctx context.Context, path storj.Path, lostPieces []int32) (transferred int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	// Read the segment's pointer's info from the PointerDB
 	pr, originalNodes, _, err := s.pdb.Get(ctx, path)
 	if err != nil {
-		return Error.Wrap(err)
+		return 0, Error.Wrap(err)
 	}
 
 	if pr.GetType() != pb.Pointer_REMOTE {
-		return Error.New("cannot repair inline segment %s", psclient.PieceID(pr.GetInlineSegment()))
+		return 0, Error.New("cannot repair inline segment %s", psclient.PieceID(pr.GetInlineSegment()))
 	}
 
 	seg := pr.GetRemote()
@@ -47,7 +49,7 @@ func (s *Repairer) Repair(ctx context.Context, path storj.Path, lostPieces []int
 
 	originalNodes, err = lookupAndAlignNodes(ctx, s.oc, originalNodes, seg)
 	if err != nil {
-		return Error.Wrap(err)
+		return 0, Error.Wrap(err)
 	}
 
 	// Get the nodes list that needs to be excluded
@@ -79,11 +81,11 @@ func (s *Repairer) Repair(ctx context.Context, path storj.Path, lostPieces []int
 	op := overlay.Options{Amount: totalNilNodes, Space: 0, Excluded: excludeNodeIDs}
 	newNodes, err := s.oc.Choose(ctx, op)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	if totalNilNodes != len(newNodes) {
-		return Error.New("Number of new nodes from overlay (%d) does not equal total nil nodes (%d)", len(newNodes), totalNilNodes)
+		return 0, Error.New("Number of new nodes from overlay (%d) does not equal total nil nodes (%d)", len(newNodes), totalNilNodes)
 	}
 
 	totalRepairCount := len(newNodes)
@@ -93,7 +95,7 @@ func (s *Repairer) Repair(ctx context.Context, path storj.Path, lostPieces []int
 	for i, vr := range healthyNodes {
 		// Check that totalRepairCount is non-negative
 		if totalRepairCount < 0 {
-			return Error.New("Total repair count (%d) less than zero", totalRepairCount)
+			return 0, Error.New("Total repair count (%d) less than zero", totalRepairCount)
 		}
 
 		// Find the nil nodes in the healthyNodes list
@@ -109,39 +111,48 @@ func (s *Repairer) Repair(ctx context.Context, path storj.Path, lostPieces []int
 
 	// Check that all nil nodes have a replacement prepared
 	if totalRepairCount != 0 {
-		return Error.New("Failed to replace all nil nodes (%d). (%d) new nodes not inserted", len(newNodes), totalRepairCount)
+		return 0, Error.New("Failed to replace all nil nodes (%d). (%d) new nodes not inserted", len(newNodes), totalRepairCount)
 	}
 
 	rs, err := makeRedundancyStrategy(pr.GetRemote().GetRedundancy())
 	if err != nil {
-		return Error.Wrap(err)
+		return 0, Error.Wrap(err)
 	}
 
 	signedMessage := s.pdb.SignedMessage()
 	pbaGet, err := s.pdb.PayerBandwidthAllocation(ctx, pb.PayerBandwidthAllocation_GET_REPAIR)
 	if err != nil {
-		return Error.Wrap(err)
+		return 0, Error.Wrap(err)
 	}
 	// Download the segment using just the healthyNodes
 	rr, err := s.ec.Get(ctx, healthyNodes, rs, pid, pr.GetSegmentSize(), pbaGet, signedMessage)
 	if err != nil {
-		return Error.Wrap(err)
+		return 0, Error.Wrap(err)
 	}
 
 	r, err := rr.Range(ctx, 0, rr.Size())
 	if err != nil {
-		return Error.Wrap(err)
+		return 0, Error.Wrap(err)
 	}
 	defer utils.LogClose(r)
 
 	pbaPut, err := s.pdb.PayerBandwidthAllocation(ctx, pb.PayerBandwidthAllocation_PUT_REPAIR)
 	if err != nil {
-		return Error.Wrap(err)
+		return 0, Error.Wrap(err)
+	}
+	// Upload the repaired pieces to the repairNodes, which downloads the
+	// segment from the healthy nodes while encoding the new pieces
+	successfulNodes, err := s.ec.Put(ctx, repairNodes, rs, pid, r, rr.Size(), convertTime(pr.GetExpirationDate()), pbaPut, signedMessage)
+	required := int64(rs.RequiredCount())
+	pieceSize := (rr.Size() + required - 1) / required
+	transferred = pieceSize * required
+	for _, node := range successfulNodes {
+		if node != nil {
+			transferred += pieceSize
+		}
 	}
-	// Upload the repaired pieces to the repairNodes
-	successfulNodes, err := s.ec.Put(ctx, repairNodes, rs, pid, r, convertTime(pr.GetExpirationDate()), pbaPut, signedMessage)
 	if err != nil {
-		return Error.Wrap(err)
+		return transferred, Error.Wrap(err)
 	}
 
 	// Merge the successful nodes list into the healthy nodes list
@@ -155,9 +166,9 @@ func (s *Repairer) Repair(ctx context.Context, path storj.Path, lostPieces []int
 	metadata := pr.GetMetadata()
 	pointer, err := makeRemotePointer(healthyNodes, rs, pid, rr.Size(), pr.GetExpirationDate(), metadata)
 	if err != nil {
-		return err
+		return transferred, err
 	}
 
 	// update the segment info in the pointerDB
-	return s.pdb.Put(ctx, path, pointer)
+	return transferred, s.pdb.Put(ctx, path, pointer)
 }
@@ -108,7 +108,7 @@ func TestSegmentStoreRepairRemote(t *testing.T) {
 			).Return(ranger.ByteRanger([]byte(tt.data)), nil),
 			mockPDB.EXPECT().PayerBandwidthAllocation(gomock.Any(), gomock.Any()),
 			mockEC.EXPECT().Put(
-				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
 			).Return(tt.newNodes, nil),
 			mockPDB.EXPECT().Put(
 				gomock.Any(), gomock.Any(), gomock.Any(),
@@ -116,7 +116,8 @@ func TestSegmentStoreRepairRemote(t *testing.T) {
 		}
 		gomock.InOrder(calls...)
 
-		err := sr.Repair(ctx, tt.pathInput, tt.lostPieces)
+		transferred, err := sr.Repair(ctx, tt.pathInput, tt.lostPieces)
 		assert.NoError(t, err)
+		assert.True(t, transferred > 0)
 	}
 }
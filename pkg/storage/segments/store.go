@@ -48,8 +48,9 @@ type ListItem struct {
 type Store interface {
 	Meta(ctx context.Context, path storj.Path) (meta Meta, err error)
 	Get(ctx context.Context, path storj.Path) (rr ranger.Ranger, meta Meta, err error)
-	Put(ctx context.Context, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (meta Meta, err error)
+	Put(ctx context.Context, data io.Reader, expectedSize int64, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (meta Meta, err error)
 	Delete(ctx context.Context, path storj.Path) (err error)
+	Undelete(ctx context.Context, path storj.Path) (err error)
 	List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error)
 }
 
@@ -59,11 +60,13 @@ type segmentStore struct {
 	pdb           pdbclient.Client
 	rs            eestream.RedundancyStrategy
 	thresholdSize int
+	placement     overlay.Options
 }
 
-// NewSegmentStore creates a new instance of segmentStore
-func NewSegmentStore(oc overlay.Client, ec ecclient.Client, pdb pdbclient.Client, rs eestream.RedundancyStrategy, threshold int) Store {
-	return &segmentStore{oc: oc, ec: ec, pdb: pdb, rs: rs, thresholdSize: threshold}
+// NewSegmentStore creates a new instance of segmentStore, remote segments are
+// uploaded to nodes chosen with the tags and latency preference of placement
+func NewSegmentStore(oc overlay.Client, ec ecclient.Client, pdb pdbclient.Client, rs eestream.RedundancyStrategy, threshold int, placement overlay.Options) Store {
+	return &segmentStore{oc: oc, ec: ec, pdb: pdb, rs: rs, thresholdSize: threshold, placement: placement}
 }
 
 // Meta retrieves the metadata of the segment
@@ -78,8 +81,9 @@ func (s *segmentStore) Meta(ctx context.Context, path storj.Path) (meta Meta, er
 	return convertMeta(pr), nil
 }
 
-// Put uploads a segment to an erasure code client
-func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (meta Meta, err error) {
+// Put uploads a segment to an erasure code client. The expected size is the
+// maximum size of data, 0 if unknown, and is declared to the storage nodes.
+func (s *segmentStore) Put(ctx context.Context, data io.Reader, expectedSize int64, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (meta Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	exp, err := ptypes.TimestampProto(expiration)
@@ -115,10 +119,15 @@ func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.
 		// uses overlay client to request a list of nodes according to configured standards
 		nodes, err := s.oc.Choose(ctx,
 			overlay.Options{
-				Amount:    s.rs.TotalCount(),
-				Bandwidth: sizedReader.Size() / int64(s.rs.TotalCount()),
-				Space:     sizedReader.Size() / int64(s.rs.TotalCount()),
-				Excluded:  nil,
+				Amount:       s.rs.TotalCount(),
+				Bandwidth:    sizedReader.Size() / int64(s.rs.TotalCount()),
+				Space:        sizedReader.Size() / int64(s.rs.TotalCount()),
+				Excluded:     nil,
+				Tags:         s.placement.Tags,
+				ExcludedTags: s.placement.ExcludedTags,
+
+				PreferLowLatency: s.placement.PreferLowLatency,
+				Region:           s.placement.Region,
 			})
 		if err != nil {
 			return Meta{}, Error.Wrap(err)
@@ -135,7 +144,7 @@ func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.
 			return Meta{}, Error.Wrap(err)
 		}
 
-		successfulNodes, err := s.ec.Put(ctx, nodes, s.rs, pieceID, sizedReader, expiration, pba, authorization)
+		successfulNodes, err := s.ec.Put(ctx, nodes, s.rs, pieceID, sizedReader, expectedSize, expiration, pba, authorization)
 		if err != nil {
 			return Meta{}, Error.Wrap(err)
 		}
@@ -257,39 +266,20 @@ func makeRemotePointer(nodes []*pb.Node, rs eestream.RedundancyStrategy, pieceID
 	return pointer, nil
 }
 
-// Delete tells piece stores to delete a segment and deletes pointer from pointerdb
+// Delete deletes the segment's pointer from pointerdb. The satellite purges
+// the pieces from the piece stores once the segment can't be undeleted
+// anymore.
 func (s *segmentStore) Delete(ctx context.Context, path storj.Path) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	pr, nodes, _, err := s.pdb.Get(ctx, path)
-	if err != nil {
-		return Error.Wrap(err)
-	}
-
-	if pr.GetType() == pb.Pointer_REMOTE {
-		seg := pr.GetRemote()
-		pid := psclient.PieceID(seg.PieceId)
-
-		nodes, err = lookupAndAlignNodes(ctx, s.oc, nodes, seg)
-		if err != nil {
-			return Error.Wrap(err)
-		}
-		for _, v := range nodes {
-			if v != nil {
-				v.Type.DPanicOnInvalid("ss delete")
-			}
-		}
+	return Error.Wrap(s.pdb.Delete(ctx, path))
+}
 
-		authorization := s.pdb.SignedMessage()
-		// ecclient sends delete request
-		err = s.ec.Delete(ctx, nodes, pid, authorization)
-		if err != nil {
-			return Error.Wrap(err)
-		}
-	}
+// Undelete restores a deleted segment whose pieces weren't purged yet
+func (s *segmentStore) Undelete(ctx context.Context, path storj.Path) (err error) {
+	defer mon.Task()(&ctx)(&err)
 
-	// deletes pointer from pointerdb
-	return s.pdb.Delete(ctx, path)
+	return Error.Wrap(s.pdb.Undelete(ctx, path))
 }
 
 // List retrieves paths to segments and their metadata stored in the pointerdb
@@ -17,6 +17,7 @@ import (
 	"storj.io/storj/internal/teststorj"
 	"storj.io/storj/pkg/eestream"
 	mock_eestream "storj.io/storj/pkg/eestream/mocks"
+	"storj.io/storj/pkg/overlay"
 	mock_overlay "storj.io/storj/pkg/overlay/mocks"
 	mock_pointerdb "storj.io/storj/pkg/pointerdb/pdbclient/mocks"
 	mock_ecclient "storj.io/storj/pkg/storage/ec/mocks"
@@ -25,6 +26,7 @@ import (
 	pdb "storj.io/storj/pkg/pointerdb/pdbclient"
 	"storj.io/storj/pkg/storage/meta"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
 )
 
 var (
@@ -42,7 +44,7 @@ func TestNewSegmentStore(t *testing.T) {
 		ErasureScheme: mock_eestream.NewMockErasureScheme(ctrl),
 	}
 
-	ss := NewSegmentStore(mockOC, mockEC, mockPDB, rs, 10)
+	ss := NewSegmentStore(mockOC, mockEC, mockPDB, rs, 10, overlay.Options{})
 	assert.NotNil(t, ss)
 }
 
@@ -57,7 +59,7 @@ func TestSegmentStoreMeta(t *testing.T) {
 		ErasureScheme: mock_eestream.NewMockErasureScheme(ctrl),
 	}
 
-	ss := segmentStore{mockOC, mockEC, mockPDB, rs, 10}
+	ss := segmentStore{mockOC, mockEC, mockPDB, rs, 10, overlay.Options{}}
 	assert.NotNil(t, ss)
 
 	var mExp time.Time
@@ -106,7 +108,7 @@ func TestSegmentStorePutRemote(t *testing.T) {
 			ErasureScheme: mockES,
 		}
 
-		ss := segmentStore{mockOC, mockEC, mockPDB, rs, tt.thresholdSize}
+		ss := segmentStore{mockOC, mockEC, mockPDB, rs, tt.thresholdSize, overlay.Options{}}
 		assert.NotNil(t, ss)
 
 		calls := []*gomock.Call{
@@ -121,7 +123,7 @@ func TestSegmentStorePutRemote(t *testing.T) {
 			mockPDB.EXPECT().SignedMessage(),
 			mockPDB.EXPECT().PayerBandwidthAllocation(gomock.Any(), gomock.Any()),
 			mockEC.EXPECT().Put(
-				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
 			),
 			mockES.EXPECT().RequiredCount().Return(1),
 			mockES.EXPECT().TotalCount().Return(1),
@@ -135,7 +137,7 @@ func TestSegmentStorePutRemote(t *testing.T) {
 		}
 		gomock.InOrder(calls...)
 
-		_, err := ss.Put(ctx, strings.NewReader(tt.readerContent), tt.expiration, func() (storj.Path, []byte, error) {
+		_, err := ss.Put(ctx, strings.NewReader(tt.readerContent), int64(len(tt.readerContent)), tt.expiration, func() (storj.Path, []byte, error) {
 			return tt.pathInput, tt.mdInput, nil
 		})
 		assert.NoError(t, err, tt.name)
@@ -164,7 +166,7 @@ func TestSegmentStorePutInline(t *testing.T) {
 			ErasureScheme: mockES,
 		}
 
-		ss := segmentStore{mockOC, mockEC, mockPDB, rs, tt.thresholdSize}
+		ss := segmentStore{mockOC, mockEC, mockPDB, rs, tt.thresholdSize, overlay.Options{}}
 		assert.NotNil(t, ss)
 
 		calls := []*gomock.Call{
@@ -177,7 +179,7 @@ func TestSegmentStorePutInline(t *testing.T) {
 		}
 		gomock.InOrder(calls...)
 
-		_, err := ss.Put(ctx, strings.NewReader(tt.readerContent), tt.expiration, func() (storj.Path, []byte, error) {
+		_, err := ss.Put(ctx, strings.NewReader(tt.readerContent), int64(len(tt.readerContent)), tt.expiration, func() (storj.Path, []byte, error) {
 			return tt.pathInput, tt.mdInput, nil
 		})
 		assert.NoError(t, err, tt.name)
@@ -210,7 +212,7 @@ func TestSegmentStoreGetInline(t *testing.T) {
 			ErasureScheme: mockES,
 		}
 
-		ss := segmentStore{mockOC, mockEC, mockPDB, rs, tt.thresholdSize}
+		ss := segmentStore{mockOC, mockEC, mockPDB, rs, tt.thresholdSize, overlay.Options{}}
 		assert.NotNil(t, ss)
 
 		calls := []*gomock.Call{
@@ -257,7 +259,7 @@ func TestSegmentStoreGetRemote(t *testing.T) {
 			ErasureScheme: mockES,
 		}
 
-		ss := segmentStore{mockOC, mockEC, mockPDB, rs, tt.thresholdSize}
+		ss := segmentStore{mockOC, mockEC, mockPDB, rs, tt.thresholdSize, overlay.Options{}}
 		assert.NotNil(t, ss)
 
 		calls := []*gomock.Call{
@@ -294,120 +296,30 @@ func TestSegmentStoreGetRemote(t *testing.T) {
 	}
 }
 
-func TestSegmentStoreDeleteInline(t *testing.T) {
+func TestSegmentStoreDelete(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ti := time.Unix(0, 0).UTC()
-	someTime, err := ptypes.TimestampProto(ti)
-	assert.NoError(t, err)
-
-	for _, tt := range []struct {
-		pathInput     string
-		thresholdSize int
-		pointerType   pb.Pointer_DataType
-		inlineContent []byte
-		size          int64
-		metadata      []byte
-	}{
-		{"path/1/2/3", 10, pb.Pointer_INLINE, []byte("000"), int64(3), []byte("metadata")},
-	} {
-		mockOC := mock_overlay.NewMockClient(ctrl)
-		mockEC := mock_ecclient.NewMockClient(ctrl)
-		mockPDB := mock_pointerdb.NewMockClient(ctrl)
-		mockES := mock_eestream.NewMockErasureScheme(ctrl)
-		rs := eestream.RedundancyStrategy{
-			ErasureScheme: mockES,
-		}
-
-		ss := segmentStore{mockOC, mockEC, mockPDB, rs, tt.thresholdSize}
-		assert.NotNil(t, ss)
-
-		calls := []*gomock.Call{
-			mockPDB.EXPECT().Get(
-				gomock.Any(), gomock.Any(),
-			).Return(&pb.Pointer{
-				Type:           tt.pointerType,
-				InlineSegment:  tt.inlineContent,
-				CreationDate:   someTime,
-				ExpirationDate: someTime,
-				SegmentSize:    tt.size,
-				Metadata:       tt.metadata,
-			}, nil, nil, nil),
-			mockPDB.EXPECT().Delete(
-				gomock.Any(), gomock.Any(),
-			),
-		}
-		gomock.InOrder(calls...)
-
-		err := ss.Delete(ctx, tt.pathInput)
-		assert.NoError(t, err)
+	mockOC := mock_overlay.NewMockClient(ctrl)
+	mockEC := mock_ecclient.NewMockClient(ctrl)
+	mockPDB := mock_pointerdb.NewMockClient(ctrl)
+	mockES := mock_eestream.NewMockErasureScheme(ctrl)
+	rs := eestream.RedundancyStrategy{
+		ErasureScheme: mockES,
 	}
-}
-
-func TestSegmentStoreDeleteRemote(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	ti := time.Unix(0, 0).UTC()
-	someTime, err := ptypes.TimestampProto(ti)
-	assert.NoError(t, err)
 
-	for _, tt := range []struct {
-		pathInput     string
-		thresholdSize int
-		pointerType   pb.Pointer_DataType
-		size          int64
-		metadata      []byte
-	}{
-		{"path/1/2/3", 10, pb.Pointer_REMOTE, int64(3), []byte("metadata")},
-	} {
-		mockOC := mock_overlay.NewMockClient(ctrl)
-		mockEC := mock_ecclient.NewMockClient(ctrl)
-		mockPDB := mock_pointerdb.NewMockClient(ctrl)
-		mockES := mock_eestream.NewMockErasureScheme(ctrl)
-		rs := eestream.RedundancyStrategy{
-			ErasureScheme: mockES,
-		}
+	ss := segmentStore{mockOC, mockEC, mockPDB, rs, 10, overlay.Options{}}
 
-		ss := segmentStore{mockOC, mockEC, mockPDB, rs, tt.thresholdSize}
-		assert.NotNil(t, ss)
+	// pieces are purged by the satellite, so only the pointer is deleted
+	gomock.InOrder(
+		mockPDB.EXPECT().Delete(gomock.Any(), "path/1/2/3"),
+		mockPDB.EXPECT().Delete(gomock.Any(), "path/4").Return(storage.ErrKeyNotFound.New("path/4")),
+		mockPDB.EXPECT().Undelete(gomock.Any(), "path/1/2/3"),
+	)
 
-		calls := []*gomock.Call{
-			mockPDB.EXPECT().Get(
-				gomock.Any(), gomock.Any(),
-			).Return(&pb.Pointer{
-				Type: tt.pointerType,
-				Remote: &pb.RemoteSegment{
-					Redundancy: &pb.RedundancyScheme{
-						Type:             pb.RedundancyScheme_RS,
-						MinReq:           1,
-						Total:            2,
-						RepairThreshold:  1,
-						SuccessThreshold: 2,
-					},
-					PieceId:      "here's my piece id",
-					RemotePieces: []*pb.RemotePiece{},
-				},
-				CreationDate:   someTime,
-				ExpirationDate: someTime,
-				SegmentSize:    tt.size,
-				Metadata:       tt.metadata,
-			}, nil, nil, nil),
-			mockOC.EXPECT().BulkLookup(gomock.Any(), gomock.Any()),
-			mockPDB.EXPECT().SignedMessage(),
-			mockEC.EXPECT().Delete(
-				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
-			),
-			mockPDB.EXPECT().Delete(
-				gomock.Any(), gomock.Any(),
-			),
-		}
-		gomock.InOrder(calls...)
-
-		err := ss.Delete(ctx, tt.pathInput)
-		assert.NoError(t, err)
-	}
+	assert.NoError(t, ss.Delete(ctx, "path/1/2/3"))
+	assert.True(t, storage.ErrKeyNotFound.Has(ss.Delete(ctx, "path/4")))
+	assert.NoError(t, ss.Undelete(ctx, "path/1/2/3"))
 }
 
 func TestSegmentStoreList(t *testing.T) {
@@ -432,7 +344,7 @@ func TestSegmentStoreList(t *testing.T) {
 			ErasureScheme: mockES,
 		}
 
-		ss := segmentStore{mockOC, mockEC, mockPDB, rs, tt.thresholdSize}
+		ss := segmentStore{mockOC, mockEC, mockPDB, rs, tt.thresholdSize, overlay.Options{}}
 		assert.NotNil(t, ss)
 
 		ti := time.Unix(0, 0).UTC()
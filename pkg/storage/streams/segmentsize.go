@@ -0,0 +1,64 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package streams
+
+import (
+	"storj.io/storj/internal/memory"
+)
+
+const (
+	// segmentSizeAlignment is the granularity of chosen segment sizes
+	segmentSizeAlignment = int64(memory.MiB)
+	// targetSegmentCount is the number of segments large objects are split
+	// into, as far as the maximum segment size allows
+	targetSegmentCount = 16
+)
+
+// SegmentSizes chooses the segment size of a stream from its expected size
+type SegmentSizes struct {
+	// Default is the segment size when the object size isn't known or the
+	// satellite doesn't allow choosing it
+	Default int64
+	// Min and Max are the segment sizes the satellite allows, zero disables
+	// choosing the segment size
+	Min int64
+	Max int64
+}
+
+// Tuned returns whether the segment size is chosen from the object size
+func (sizes SegmentSizes) Tuned() bool {
+	return sizes.Min > 0 && sizes.Max >= sizes.Min
+}
+
+// For returns the segment size for an object of sizeHint bytes. Objects
+// smaller than the default segment size are stored in a segment just large
+// enough for them and objects larger than targetSegmentCount default
+// segments use larger segments, within the satellite limits. An object of
+// unknown size, sizeHint <= 0, uses the default segment size.
+func (sizes SegmentSizes) For(sizeHint int64) int64 {
+	if sizeHint <= 0 || !sizes.Tuned() {
+		return sizes.Default
+	}
+
+	size := sizes.Default
+	switch {
+	case sizeHint < sizes.Default:
+		size = alignSegmentSize(sizeHint)
+	case sizeHint > sizes.Default*targetSegmentCount:
+		size = alignSegmentSize((sizeHint + targetSegmentCount - 1) / targetSegmentCount)
+	}
+
+	if size < sizes.Min {
+		size = sizes.Min
+	}
+	if size > sizes.Max {
+		size = sizes.Max
+	}
+	return size
+}
+
+// alignSegmentSize rounds size up to a multiple of segmentSizeAlignment
+func alignSegmentSize(size int64) int64 {
+	return (size + segmentSizeAlignment - 1) / segmentSizeAlignment * segmentSizeAlignment
+}
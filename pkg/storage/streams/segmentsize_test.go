@@ -0,0 +1,41 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package streams
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/internal/memory"
+)
+
+func TestSegmentSizes(t *testing.T) {
+	fixed := SegmentSizes{Default: 64 * memory.MiB.Int64()}
+	tuned := SegmentSizes{Default: 64 * memory.MiB.Int64(), Min: memory.MiB.Int64(), Max: 256 * memory.MiB.Int64()}
+
+	for i, test := range []struct {
+		sizes    SegmentSizes
+		hint     int64
+		expected memory.Size
+	}{
+		{fixed, 0, 64 * memory.MiB},
+		{fixed, 10 * memory.KiB.Int64(), 64 * memory.MiB},
+		{fixed, 10 * memory.GiB.Int64(), 64 * memory.MiB},
+
+		{tuned, 0, 64 * memory.MiB},
+		{tuned, -1, 64 * memory.MiB},
+		{tuned, 10 * memory.KiB.Int64(), memory.MiB},
+		{tuned, 10*memory.MiB.Int64() + 1, 11 * memory.MiB},
+		{tuned, 64 * memory.MiB.Int64(), 64 * memory.MiB},
+		{tuned, memory.GiB.Int64(), 64 * memory.MiB},
+		{tuned, 2 * memory.GiB.Int64(), 128 * memory.MiB},
+		{tuned, 100 * memory.GiB.Int64(), 256 * memory.MiB},
+
+		// the satellite maximum is lower than the default
+		{SegmentSizes{Default: 64 * memory.MiB.Int64(), Min: memory.MiB.Int64(), Max: 32 * memory.MiB.Int64()}, 48 * memory.MiB.Int64(), 32 * memory.MiB},
+	} {
+		assert.Equal(t, test.expected.Int64(), test.sizes.For(test.hint), i)
+	}
+}
@@ -58,23 +58,28 @@ func convertMeta(lastSegmentMeta segments.Meta) (Meta, error) {
 type Store interface {
 	Meta(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (Meta, error)
 	Get(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (ranger.Ranger, Meta, error)
-	Put(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time) (Meta, error)
+	Put(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, sizeHint int64, metadata []byte, expiration time.Time) (Meta, error)
 	Delete(ctx context.Context, path storj.Path, pathCipher storj.Cipher) error
+	Undelete(ctx context.Context, path storj.Path, pathCipher storj.Cipher) error
 	List(ctx context.Context, prefix, startAfter, endBefore storj.Path, pathCipher storj.Cipher, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error)
+	ListPending(ctx context.Context, prefix, startAfter, endBefore storj.Path, pathCipher storj.Cipher, limit int) (items []ListItem, more bool, err error)
+	DeletePending(ctx context.Context, path storj.Path, pathCipher storj.Cipher) error
+	SegmentPaths(ctx context.Context, path storj.Path, pathCipher storj.Cipher) ([]storj.Path, error)
+	Close() error
 }
 
 // streamStore is a store for streams
 type streamStore struct {
 	segments     segments.Store
-	segmentSize  int64
+	segmentSizes SegmentSizes
 	rootKey      *storj.Key
 	encBlockSize int
 	cipher       storj.Cipher
 }
 
 // NewStreamStore stuff
-func NewStreamStore(segments segments.Store, segmentSize int64, rootKey *storj.Key, encBlockSize int, cipher storj.Cipher) (Store, error) {
-	if segmentSize <= 0 {
+func NewStreamStore(segments segments.Store, segmentSizes SegmentSizes, rootKey *storj.Key, encBlockSize int, cipher storj.Cipher) (Store, error) {
+	if segmentSizes.Default <= 0 {
 		return nil, errs.New("segment size must be larger than 0")
 	}
 	if rootKey == nil {
@@ -86,18 +91,19 @@ func NewStreamStore(segments segments.Store, segmentSize int64, rootKey *storj.K
 
 	return &streamStore{
 		segments:     segments,
-		segmentSize:  segmentSize,
+		segmentSizes: segmentSizes,
 		rootKey:      rootKey,
 		encBlockSize: encBlockSize,
 		cipher:       cipher,
 	}, nil
 }
 
-// Put breaks up data as it comes in into s.segmentSize length pieces, then
+// Put breaks up data as it comes in into segment size length pieces, then
 // store the first piece at s0/<path>, second piece at s1/<path>, and the
 // *last* piece at l/<path>. Store the given metadata, along with the number
-// of segments, in a new protobuf, in the metadata of l/<path>.
-func (s *streamStore) Put(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time) (m Meta, err error) {
+// of segments, in a new protobuf, in the metadata of l/<path>. The segment
+// size is chosen from sizeHint, the expected size of data or 0 if unknown.
+func (s *streamStore) Put(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, sizeHint int64, metadata []byte, expiration time.Time) (m Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
 	// previously file uploaded?
 	err = s.Delete(ctx, path, pathCipher)
@@ -107,7 +113,7 @@ func (s *streamStore) Put(ctx context.Context, path storj.Path, pathCipher storj
 		return Meta{}, err
 	}
 
-	m, lastSegment, err := s.upload(ctx, path, pathCipher, data, metadata, expiration)
+	m, lastSegment, err := s.upload(ctx, path, pathCipher, data, s.segmentSizes.For(sizeHint), metadata, expiration)
 	if err != nil {
 		s.cancelHandler(context.Background(), lastSegment, path, pathCipher)
 	}
@@ -115,7 +121,7 @@ func (s *streamStore) Put(ctx context.Context, path storj.Path, pathCipher storj
 	return m, err
 }
 
-func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time) (m Meta, lastSegment int64, err error) {
+func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, segmentSize int64, metadata []byte, expiration time.Time) (m Meta, lastSegment int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	var currentSegment int64
@@ -172,16 +178,20 @@ func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher st
 		}
 
 		sizeReader := NewSizeReader(eofReader)
-		segmentReader := io.LimitReader(sizeReader, s.segmentSize)
+		segmentReader := io.LimitReader(sizeReader, segmentSize)
 		peekReader := segments.NewPeekThresholdReader(segmentReader)
 		largeData, err := peekReader.IsLargerThan(encrypter.InBlockSize())
 		if err != nil {
 			return Meta{}, currentSegment, err
 		}
 		var transformedReader io.Reader
+		var expectedSize int64
 		if largeData {
 			paddedReader := eestream.PadReader(ioutil.NopCloser(peekReader), encrypter.InBlockSize())
 			transformedReader = encryption.TransformReader(paddedReader, encrypter, 0)
+			// the last segment may be shorter, full segments are the limit
+			blocks := eestream.PaddedSize(segmentSize, encrypter.InBlockSize()) / int64(encrypter.InBlockSize())
+			expectedSize = blocks * int64(encrypter.OutBlockSize())
 		} else {
 			data, err := ioutil.ReadAll(peekReader)
 			if err != nil {
@@ -192,9 +202,10 @@ func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher st
 				return Meta{}, currentSegment, err
 			}
 			transformedReader = bytes.NewReader(cipherData)
+			expectedSize = int64(len(cipherData))
 		}
 
-		putMeta, err = s.segments.Put(ctx, transformedReader, expiration, func() (storj.Path, []byte, error) {
+		putMeta, err = s.segments.Put(ctx, transformedReader, expectedSize, expiration, func() (storj.Path, []byte, error) {
 			encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
 			if err != nil {
 				return "", nil, err
@@ -222,7 +233,7 @@ func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher st
 
 			streamInfo, err := proto.Marshal(&pb.StreamInfo{
 				NumberOfSegments: currentSegment + 1,
-				SegmentsSize:     s.segmentSize,
+				SegmentsSize:     segmentSize,
 				LastSegmentSize:  sizeReader.Size(),
 				Metadata:         metadata,
 			})
@@ -442,6 +453,85 @@ func (s *streamStore) Delete(ctx context.Context, path storj.Path, pathCipher st
 	return s.segments.Delete(ctx, storj.JoinPaths("l", encPath))
 }
 
+// Undelete restores the segments of a deleted stream until the satellite
+// purged them. The last segment is restored first, as it holds the number of
+// segments, and is deleted again when restoring the others fails.
+func (s *streamStore) Undelete(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+	if err != nil {
+		return err
+	}
+	lastSegmentPath := storj.JoinPaths("l", encPath)
+	if err := s.segments.Undelete(ctx, lastSegmentPath); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errs.Combine(err, s.segments.Delete(ctx, lastSegmentPath))
+		}
+	}()
+
+	lastSegmentMeta, err := s.segments.Meta(ctx, lastSegmentPath)
+	if err != nil {
+		return err
+	}
+
+	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, path, s.rootKey)
+	if err != nil {
+		return err
+	}
+
+	stream := pb.StreamInfo{}
+	err = proto.Unmarshal(streamInfo, &stream)
+	if err != nil {
+		return err
+	}
+
+	for i := int64(0); i < stream.NumberOfSegments-1; i++ {
+		err = s.segments.Undelete(ctx, getSegmentPath(encPath, i))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SegmentPaths returns the encrypted paths of all the segments of the
+// stream, with the last one last
+func (s *streamStore) SegmentPaths(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (paths []storj.Path, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+	if err != nil {
+		return nil, err
+	}
+	lastSegmentMeta, err := s.segments.Meta(ctx, storj.JoinPaths("l", encPath))
+	if err != nil {
+		return nil, err
+	}
+
+	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, path, s.rootKey)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := pb.StreamInfo{}
+	err = proto.Unmarshal(streamInfo, &stream)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < int(stream.NumberOfSegments-1); i++ {
+		paths = append(paths, getSegmentPath(encPath, int64(i)))
+	}
+	return append(paths, storj.JoinPaths("l", encPath)), nil
+}
+
+// Close closes the store, which holds no resources of its own
+func (s *streamStore) Close() error { return nil }
+
 // ListItem is a single item in a listing
 type ListItem struct {
 	Path     storj.Path
@@ -510,6 +600,122 @@ func (s *streamStore) List(ctx context.Context, prefix, startAfter, endBefore st
 	return items, more, nil
 }
 
+// ListPending lists recursively the paths inside s0/ which don't have a
+// segment in l/, i.e. the streams which were partially uploaded but never
+// committed. Only the first segment of a pending stream is looked up, hence
+// the listed Meta contains its modification and expiration time, but no size.
+func (s *streamStore) ListPending(ctx context.Context, prefix, startAfter, endBefore storj.Path, pathCipher storj.Cipher, limit int) (items []ListItem, more bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if limit <= 0 || limit > storage.LookupLimit {
+		limit = storage.LookupLimit
+	}
+
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	encPrefix, err := EncryptAfterBucket(prefix, pathCipher, s.rootKey)
+	if err != nil {
+		return nil, false, err
+	}
+
+	prefixKey, err := encryption.DerivePathKey(prefix, s.rootKey, len(storj.SplitPath(prefix)))
+	if err != nil {
+		return nil, false, err
+	}
+
+	encStartAfter, err := s.encryptMarker(startAfter, pathCipher, prefixKey)
+	if err != nil {
+		return nil, false, err
+	}
+
+	encEndBefore, err := s.encryptMarker(endBefore, pathCipher, prefixKey)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// committed streams are skipped, so keep listing until the limit is
+	// reached or there are no more first segments
+	reverse := endBefore != ""
+	for len(items) < limit {
+		var firstSegments []segments.ListItem
+		firstSegments, more, err = s.segments.List(ctx, getSegmentPath(encPrefix, 0), encStartAfter, encEndBefore, true, limit-len(items), meta.Modified|meta.Expiration)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(firstSegments) == 0 {
+			break
+		}
+
+		var pending []ListItem
+		for _, item := range firstSegments {
+			_, err := s.segments.Meta(ctx, storj.JoinPaths("l", encPrefix, item.Path))
+			if err == nil {
+				continue
+			}
+			if !storage.ErrKeyNotFound.Has(err) {
+				return nil, false, err
+			}
+
+			path, err := s.decryptMarker(item.Path, pathCipher, prefixKey)
+			if err != nil {
+				return nil, false, err
+			}
+
+			pending = append(pending, ListItem{
+				Path: path,
+				Meta: Meta{
+					Modified:   item.Meta.Modified,
+					Expiration: item.Meta.Expiration,
+				},
+			})
+		}
+
+		if reverse {
+			items = append(pending, items...)
+			encEndBefore = firstSegments[0].Path
+		} else {
+			items = append(items, pending...)
+			encStartAfter = firstSegments[len(firstSegments)-1].Path
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return items, more, nil
+}
+
+// DeletePending deletes all the uploaded segments of a stream which was never
+// committed
+func (s *streamStore) DeletePending(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.segments.Meta(ctx, storj.JoinPaths("l", encPath))
+	if err == nil {
+		return errs.New("stream %q is committed", path)
+	}
+	if !storage.ErrKeyNotFound.Has(err) {
+		return err
+	}
+
+	for i := int64(0); ; i++ {
+		err = s.segments.Delete(ctx, getSegmentPath(encPath, i))
+		if storage.ErrKeyNotFound.Has(err) && i > 0 {
+			// there are no more uploaded segments
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
 // encryptMarker is a helper method for encrypting startAfter and endBefore markers
 func (s *streamStore) encryptMarker(marker storj.Path, pathCipher storj.Cipher, prefixKey *storj.Key) (storj.Path, error) {
 	if bytes.Equal(s.rootKey[:], prefixKey[:]) { // empty prefix
@@ -41,6 +41,9 @@ type Metainfo interface {
 	ModifyPendingObject(ctx context.Context, bucket string, path Path) (MutableObject, error)
 	// ListPendingObjects lists pending objects in bucket based on the ListOptions
 	ListPendingObjects(ctx context.Context, bucket string, options ListOptions) (ObjectList, error)
+
+	// PrefixUsage aggregates the sizes of the objects below prefix and of its directories
+	PrefixUsage(ctx context.Context, bucket string, prefix Path) (PrefixUsage, error)
 }
 
 // CreateObject has optional parameters that can be set
@@ -48,6 +51,9 @@ type CreateObject struct {
 	Metadata    map[string]string
 	ContentType string
 	Expires     time.Time
+	// SizeHint is the expected size of the object in bytes, 0 when unknown.
+	// It is used to choose the segment size of the upload.
+	SizeHint int64
 
 	RedundancyScheme
 	EncryptionScheme
@@ -108,6 +114,35 @@ type ObjectList struct {
 	Items []Object
 }
 
+// ObjectUsage is the aggregated size of objects
+type ObjectUsage struct {
+	Objects       int64
+	Segments      int64
+	EncryptedSize int64
+	// PlainSize is the size of the objects before encryption, including the
+	// padding of the last encryption block of every segment
+	PlainSize int64
+}
+
+// PrefixUsage is the usage of the objects below a prefix
+type PrefixUsage struct {
+	Bucket string
+	Prefix Path
+	Total  ObjectUsage
+
+	// Directories are the directories directly below Prefix, their paths are
+	// relative to Prefix and end in a slash
+	Directories []DirectoryUsage
+	// More is whether there were more directories than were aggregated
+	More bool
+}
+
+// DirectoryUsage is the usage of the objects in a directory
+type DirectoryUsage struct {
+	Path  Path
+	Usage ObjectUsage
+}
+
 // NextPage returns options for listing the next page
 func (opts ListOptions) NextPage(list ObjectList) ListOptions {
 	if !list.More || len(list.Items) == 0 {
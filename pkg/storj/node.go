@@ -4,6 +4,7 @@
 package storj
 
 import (
+	"encoding/json"
 	"math/bits"
 
 	"github.com/btcsuite/btcutil/base58"
@@ -113,6 +114,22 @@ func (id NodeID) Difficulty() (uint16, error) {
 	return 0, ErrNodeID.New("difficulty matches id hash length: %d; hash (hex): % x", idLen, id)
 }
 
+// VerifyDifficulty returns an error when the id has fewer than minimum
+// trailing zero bits
+func (id NodeID) VerifyDifficulty(minimum uint16) error {
+	if minimum == 0 {
+		return nil
+	}
+	difficulty, err := id.Difficulty()
+	if err != nil {
+		return err
+	}
+	if difficulty < minimum {
+		return ErrNodeID.New("difficulty of %s is %d, need at least %d", id, difficulty, minimum)
+	}
+	return nil
+}
+
 // Marshal serializes a node id
 func (id NodeID) Marshal() ([]byte, error) {
 	return id.Bytes(), nil
@@ -143,8 +160,12 @@ func (id NodeID) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON deserializes a json string (as bytes) to a node ID
 func (id *NodeID) UnmarshalJSON(data []byte) error {
+	var unquoted string
+	if err := json.Unmarshal(data, &unquoted); err != nil {
+		return ErrNodeID.Wrap(err)
+	}
 	var err error
-	*id, err = NodeIDFromString(string(data))
+	*id, err = NodeIDFromString(unquoted)
 	if err != nil {
 		return err
 	}
@@ -5,6 +5,7 @@ package storj_test
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -61,3 +62,27 @@ func TestNodeID_Difficulty(t *testing.T) {
 		assert.Equal(t, testcase.difficulty, difficulty)
 	}
 }
+
+func TestNodeID_VerifyDifficulty(t *testing.T) {
+	id := storj.NodeID{31: 0x10} // difficulty 4
+
+	assert.NoError(t, id.VerifyDifficulty(0))
+	assert.NoError(t, id.VerifyDifficulty(4))
+	assert.Error(t, id.VerifyDifficulty(5))
+
+	assert.NoError(t, storj.NodeID{}.VerifyDifficulty(0))
+	assert.Error(t, storj.NodeID{}.VerifyDifficulty(1))
+}
+
+func TestNodeID_JSON(t *testing.T) {
+	id := storj.NodeID{1, 2, 3, 31: 0x10}
+
+	data, err := json.Marshal(id)
+	assert.NoError(t, err)
+
+	var decoded storj.NodeID
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, id, decoded)
+
+	assert.Error(t, json.Unmarshal([]byte(`"invalid"`), &decoded))
+}
@@ -49,7 +49,8 @@ func NewUpload(ctx context.Context, stream storj.MutableStream, streams streams.
 			return utils.CombineErrors(err, reader.CloseWithError(err))
 		}
 
-		_, err = streams.Put(ctx, storj.JoinPaths(obj.Bucket.Name, obj.Path), obj.Bucket.PathCipher, reader, metadata, obj.Expires)
+		// the size of an object being created is its expected size, if known
+		_, err = streams.Put(ctx, storj.JoinPaths(obj.Bucket.Name, obj.Path), obj.Bucket.PathCipher, reader, obj.Size, metadata, obj.Expires)
 		if err != nil {
 			return utils.CombineErrors(err, reader.CloseWithError(err))
 		}
@@ -0,0 +1,143 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package transport
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/storj"
+)
+
+// Call is a call made on a connection of a client observing calls, or a
+// failed dial
+type Call struct {
+	// Node is the node called, it's zero for calls to addresses
+	Node storj.NodeID
+	// Address is the address of the node called
+	Address string
+	// Method is the full method called, it's empty for failed dials
+	Method   string
+	Start    time.Time
+	Duration time.Duration
+	Err      error
+}
+
+// ObserveCalls returns a client which calls observe with every call made on
+// the connections it dials and with every dial which failed. Streams are
+// observed when they end.
+func ObserveCalls(client Client, observe func(call Call)) Client {
+	return &callClient{client: client, observe: observe}
+}
+
+// callClient dials nodes observing the calls to them
+type callClient struct {
+	client  Client
+	observe func(call Call)
+}
+
+// DialNode dials the node, the calls on the connection are observed
+func (client *callClient) DialNode(ctx context.Context, node *pb.Node, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	call := Call{Node: node.Id, Address: node.GetAddress().GetAddress()}
+	start := time.Now()
+	conn, err := client.client.DialNode(ctx, node, append(opts, client.interceptors(call)...)...)
+	if err != nil {
+		call.Start, call.Duration, call.Err = start, time.Since(start), err
+		client.observe(call)
+	}
+	return conn, err
+}
+
+// DialAddress dials the address, the calls on the connection are observed
+func (client *callClient) DialAddress(ctx context.Context, address string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	call := Call{Address: address}
+	start := time.Now()
+	conn, err := client.client.DialAddress(ctx, address, append(opts, client.interceptors(call)...)...)
+	if err != nil {
+		call.Start, call.Duration, call.Err = start, time.Since(start), err
+		client.observe(call)
+	}
+	return conn, err
+}
+
+// Identity returns the identity of the client
+func (client *callClient) Identity() *provider.FullIdentity {
+	return client.client.Identity()
+}
+
+// interceptors returns the dial options observing the calls to the node of
+// call
+func (client *callClient) interceptors(call Call) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			call := call
+			call.Method, call.Start = method, time.Now()
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			call.Duration, call.Err = time.Since(call.Start), err
+			client.observe(call)
+			return err
+		}),
+		grpc.WithStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			call := call
+			call.Method, call.Start = method, time.Now()
+			stream, err := streamer(ctx, desc, cc, method, opts...)
+			if err != nil {
+				call.Duration, call.Err = time.Since(call.Start), err
+				client.observe(call)
+				return nil, err
+			}
+			return &callStream{ClientStream: stream, call: call, serverStreams: desc.ServerStreams, observe: client.observe}, nil
+		}),
+	}
+}
+
+// callStream observes a stream when it ends: when receiving or sending fails,
+// or when the response of a stream without server streaming was received
+type callStream struct {
+	grpc.ClientStream
+	call          Call
+	serverStreams bool
+	observe       func(call Call)
+	once          sync.Once
+}
+
+// SendMsg sends a message
+func (stream *callStream) SendMsg(m interface{}) error {
+	err := stream.ClientStream.SendMsg(m)
+	if err != nil && err != io.EOF {
+		// io.EOF is returned when the stream was ended by the server, its
+		// error is returned by RecvMsg
+		stream.end(err)
+	}
+	return err
+}
+
+// RecvMsg receives a message
+func (stream *callStream) RecvMsg(m interface{}) error {
+	err := stream.ClientStream.RecvMsg(m)
+	switch {
+	case err == io.EOF:
+		stream.end(nil)
+	case err != nil:
+		stream.end(err)
+	case !stream.serverStreams:
+		stream.end(nil)
+	}
+	return err
+}
+
+// end observes the stream once
+func (stream *callStream) end(err error) {
+	stream.once.Do(func() {
+		call := stream.call
+		call.Duration, call.Err = time.Since(call.Start), err
+		stream.observe(call)
+	})
+}
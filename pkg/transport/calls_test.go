@@ -0,0 +1,80 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package transport_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+)
+
+func TestObserveCalls(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	planet, err := testplanet.New(t, 0, 2, 0)
+	require.NoError(t, err)
+	defer ctx.Check(planet.Shutdown)
+
+	planet.Start(ctx)
+
+	var mu sync.Mutex
+	var calls []transport.Call
+	client := transport.ObserveCalls(transport.NewClient(planet.StorageNodes[0].Identity), func(call transport.Call) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, call)
+	})
+
+	target := planet.StorageNodes[1]
+	conn, err := client.DialNode(ctx, &pb.Node{
+		Id: target.ID(),
+		Address: &pb.NodeAddress{
+			Transport: pb.NodeTransport_TCP_TLS_GRPC,
+			Address:   target.Addr(),
+		},
+		Type: pb.NodeType_STORAGE,
+	})
+	require.NoError(t, err)
+	defer ctx.Check(conn.Close)
+
+	_, err = pb.NewNodesClient(conn).Ping(ctx, &pb.PingRequest{})
+	require.NoError(t, err)
+
+	timedCtx, cancel := context.WithTimeout(ctx, time.Second)
+	_, err = client.DialNode(timedCtx, &pb.Node{
+		Id: storj.NodeID{123},
+		Address: &pb.NodeAddress{
+			Transport: pb.NodeTransport_TCP_TLS_GRPC,
+			Address:   "127.0.0.1:100",
+		},
+		Type: pb.NodeType_STORAGE,
+	}, grpc.WithBlock())
+	cancel()
+	require.Error(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, calls, 2)
+
+	assert.Equal(t, target.ID(), calls[0].Node)
+	assert.Equal(t, target.Addr(), calls[0].Address)
+	assert.Equal(t, "/overlay.Nodes/Ping", calls[0].Method)
+	assert.NoError(t, calls[0].Err)
+
+	assert.Equal(t, storj.NodeID{123}, calls[1].Node)
+	assert.Empty(t, calls[1].Method)
+	assert.Error(t, calls[1].Err)
+}
@@ -0,0 +1,220 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package transport
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/storj"
+)
+
+// ContactsConfig configures how many connections the subsystems of a
+// satellite may open to nodes
+type ContactsConfig struct {
+	MaxConnections int `help:"maximum number of connections to nodes open at once, shared by all subsystems (0 is unlimited)" default:"1000"`
+	MaxPerNode     int `help:"maximum number of connections to a single node open at once (0 is unlimited)" default:"4"`
+}
+
+// Contacts is the outbound contact pool shared by the subsystems contacting
+// nodes. It caps the number of open connections in total and to every node,
+// and when connections are closed it hands out the freed slots to the
+// waiting subsystems in turn, so a burst of one subsystem doesn't starve the
+// others.
+type Contacts struct {
+	client Client
+	config ContactsConfig
+
+	mu      sync.Mutex
+	open    int
+	perNode map[storj.NodeID]int
+	waiting map[string][]*contactRequest
+	order   []string // subsystems in the order they are served
+	next    int
+}
+
+// contactRequest is a subsystem waiting to open a connection to a node
+type contactRequest struct {
+	id      storj.NodeID
+	granted chan struct{}
+}
+
+// NewContacts returns a contact pool dialing nodes with client
+func NewContacts(client Client, config ContactsConfig) *Contacts {
+	return &Contacts{
+		client:  client,
+		config:  config,
+		perNode: make(map[storj.NodeID]int),
+		waiting: make(map[string][]*contactRequest),
+	}
+}
+
+// Client returns the client the subsystem uses to dial nodes through the
+// pool. Connections count against the caps until they are closed.
+func (contacts *Contacts) Client(subsystem string) Client {
+	return &contactsClient{contacts: contacts, subsystem: subsystem}
+}
+
+// contactsClient dials nodes for a subsystem through the contact pool
+type contactsClient struct {
+	contacts  *Contacts
+	subsystem string
+}
+
+// DialNode waits until the caps allow another connection to the node and
+// dials it
+func (client *contactsClient) DialNode(ctx context.Context, node *pb.Node, opts ...grpc.DialOption) (conn *grpc.ClientConn, err error) {
+	defer mon.Task()(&ctx)(&err)
+	contacts := client.contacts
+
+	if err := contacts.acquire(ctx, client.subsystem, node.Id); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	conn, err = contacts.client.DialNode(ctx, node, opts...)
+	if err != nil {
+		contacts.release(node.Id)
+		return nil, err
+	}
+
+	go contacts.releaseOnClose(conn, node.Id)
+	return conn, nil
+}
+
+// DialAddress dials the address, connections to addresses aren't capped as
+// the node behind them isn't known
+func (client *contactsClient) DialAddress(ctx context.Context, address string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	return client.contacts.client.DialAddress(ctx, address, opts...)
+}
+
+// Identity returns the identity of the pool's client
+func (client *contactsClient) Identity() *provider.FullIdentity {
+	return client.contacts.client.Identity()
+}
+
+// acquire takes a slot for a connection to the node, waiting for it when
+// the caps are reached
+func (contacts *Contacts) acquire(ctx context.Context, subsystem string, id storj.NodeID) error {
+	contacts.mu.Lock()
+	if contacts.available(id) {
+		contacts.take(id)
+		contacts.mu.Unlock()
+		return nil
+	}
+
+	request := &contactRequest{id: id, granted: make(chan struct{})}
+	if _, ok := contacts.waiting[subsystem]; !ok {
+		contacts.order = append(contacts.order, subsystem)
+	}
+	contacts.waiting[subsystem] = append(contacts.waiting[subsystem], request)
+	contacts.mu.Unlock()
+
+	mon.Counter("contacts_waited").Inc(1)
+	select {
+	case <-request.granted:
+		return nil
+	case <-ctx.Done():
+	}
+
+	contacts.mu.Lock()
+	defer contacts.mu.Unlock()
+
+	select {
+	case <-request.granted:
+		// the slot was granted while giving up, pass it on
+		contacts.free(id)
+	default:
+		contacts.cancel(subsystem, request)
+	}
+	return ctx.Err()
+}
+
+// release frees the slot of a connection to the node
+func (contacts *Contacts) release(id storj.NodeID) {
+	contacts.mu.Lock()
+	defer contacts.mu.Unlock()
+
+	contacts.free(id)
+}
+
+// releaseOnClose frees the slot of the connection once it is closed
+func (contacts *Contacts) releaseOnClose(conn *grpc.ClientConn, id storj.NodeID) {
+	for state := conn.GetState(); state != connectivity.Shutdown; state = conn.GetState() {
+		conn.WaitForStateChange(context.Background(), state)
+	}
+	contacts.release(id)
+}
+
+// available returns whether another connection to the node can be opened,
+// must hold mu
+func (contacts *Contacts) available(id storj.NodeID) bool {
+	if contacts.config.MaxConnections > 0 && contacts.open >= contacts.config.MaxConnections {
+		return false
+	}
+	return contacts.config.MaxPerNode <= 0 || contacts.perNode[id] < contacts.config.MaxPerNode
+}
+
+// take counts a connection to the node, must hold mu
+func (contacts *Contacts) take(id storj.NodeID) {
+	contacts.open++
+	contacts.perNode[id]++
+}
+
+// free uncounts a connection to the node and grants the freed slot to the
+// waiting subsystems, must hold mu
+func (contacts *Contacts) free(id storj.NodeID) {
+	contacts.open--
+	contacts.perNode[id]--
+	if contacts.perNode[id] <= 0 {
+		delete(contacts.perNode, id)
+	}
+	contacts.grant()
+}
+
+// grant hands out the available slots to the waiting subsystems in turn,
+// every subsystem gets the oldest request which can be served, must hold mu
+func (contacts *Contacts) grant() {
+	for served := 0; served < len(contacts.order); {
+		subsystem := contacts.order[contacts.next]
+		contacts.next = (contacts.next + 1) % len(contacts.order)
+
+		request := contacts.first(subsystem)
+		if request == nil {
+			served++
+			continue
+		}
+		served = 0
+
+		contacts.take(request.id)
+		contacts.cancel(subsystem, request)
+		close(request.granted)
+	}
+}
+
+// first returns the oldest request of the subsystem which can be served,
+// must hold mu
+func (contacts *Contacts) first(subsystem string) *contactRequest {
+	for _, request := range contacts.waiting[subsystem] {
+		if contacts.available(request.id) {
+			return request
+		}
+	}
+	return nil
+}
+
+// cancel removes the request from the waiting requests, must hold mu
+func (contacts *Contacts) cancel(subsystem string, request *contactRequest) {
+	queue := contacts.waiting[subsystem]
+	for i, waiting := range queue {
+		if waiting == request {
+			contacts.waiting[subsystem] = append(queue[:i:i], queue[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package transport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestContacts(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	contacts := NewContacts(nil, ContactsConfig{MaxConnections: 3, MaxPerNode: 2})
+	a, b, c, d, e := storj.NodeID{1}, storj.NodeID{2}, storj.NodeID{3}, storj.NodeID{4}, storj.NodeID{5}
+
+	// acquire acquires a slot in the background, acquired receives the
+	// subsystem once it got it
+	acquired := make(chan string, 10)
+	acquire := func(subsystem string, id storj.NodeID) {
+		ctx.Go(func() error {
+			err := contacts.acquire(ctx, subsystem, id)
+			acquired <- subsystem
+			return err
+		})
+	}
+	// waiting waits until count requests are waiting
+	waiting := func(count int) {
+		for {
+			contacts.mu.Lock()
+			n := 0
+			for _, queue := range contacts.waiting {
+				n += len(queue)
+			}
+			contacts.mu.Unlock()
+			if n == count {
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	{ // connections to a node are capped
+		require.NoError(t, contacts.acquire(ctx, "audit", a))
+		require.NoError(t, contacts.acquire(ctx, "audit", a))
+
+		timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		assert.Error(t, contacts.acquire(timeout, "audit", a))
+		cancel()
+		waiting(0)
+
+		require.NoError(t, contacts.acquire(ctx, "audit", b))
+	}
+
+	{ // freed slots are handed out to the subsystems in turn
+		acquire("repair", c)
+		waiting(1)
+		acquire("repair", d)
+		waiting(2)
+		acquire("audit", e)
+		waiting(3)
+
+		contacts.release(a)
+		assert.Equal(t, "audit", <-acquired)
+		contacts.release(a)
+		assert.Equal(t, "repair", <-acquired)
+		contacts.release(b)
+		assert.Equal(t, "repair", <-acquired)
+	}
+
+	{ // requests for capped nodes don't block the others
+		contacts.release(d)
+		require.NoError(t, contacts.acquire(ctx, "repair", c))
+
+		acquire("repair", c)
+		waiting(1)
+		contacts.release(e)
+		acquire("audit", a)
+		assert.Equal(t, "audit", <-acquired)
+		waiting(1)
+
+		contacts.release(c)
+		assert.Equal(t, "repair", <-acquired)
+		waiting(0)
+	}
+}
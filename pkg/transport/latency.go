@@ -0,0 +1,119 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package transport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/storj"
+)
+
+// ObserveLatency returns a client which calls observe with the round trip of
+// every call to a node it dials: the time from the last request message to
+// the first response message, so that the round trip of a transfer doesn't
+// include sending or receiving its data.
+func ObserveLatency(client Client, observe func(id storj.NodeID, rtt time.Duration)) Client {
+	return &latencyClient{client: client, observe: observe}
+}
+
+// latencyClient dials nodes observing the round trips of calls to them
+type latencyClient struct {
+	client  Client
+	observe func(id storj.NodeID, rtt time.Duration)
+}
+
+// DialNode dials the node, the round trips of calls on the connection are
+// observed
+func (client *latencyClient) DialNode(ctx context.Context, node *pb.Node, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	id := node.Id
+	opts = append(opts,
+		grpc.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			start := time.Now()
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil {
+				client.observe(id, time.Since(start))
+			}
+			return err
+		}),
+		grpc.WithStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			start := time.Now()
+			stream, err := streamer(ctx, desc, cc, method, opts...)
+			if err != nil {
+				return nil, err
+			}
+			return &latencyStream{ClientStream: stream, id: id, observe: client.observe, sent: start}, nil
+		}),
+	)
+	return client.client.DialNode(ctx, node, opts...)
+}
+
+// DialAddress dials the address, the node behind it isn't known, so round
+// trips aren't observed
+func (client *latencyClient) DialAddress(ctx context.Context, address string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	return client.client.DialAddress(ctx, address, opts...)
+}
+
+// Identity returns the identity of the client
+func (client *latencyClient) Identity() *provider.FullIdentity {
+	return client.client.Identity()
+}
+
+// latencyStream observes the time from the last message sent to the first
+// message received on a stream
+type latencyStream struct {
+	grpc.ClientStream
+	id      storj.NodeID
+	observe func(id storj.NodeID, rtt time.Duration)
+
+	mu       sync.Mutex
+	sent     time.Time
+	received bool
+}
+
+// SendMsg sends a message
+func (stream *latencyStream) SendMsg(m interface{}) error {
+	stream.sending()
+	return stream.ClientStream.SendMsg(m)
+}
+
+// CloseSend closes the sending direction of the stream
+func (stream *latencyStream) CloseSend() error {
+	stream.sending()
+	return stream.ClientStream.CloseSend()
+}
+
+// RecvMsg receives a message, the first one is observed
+func (stream *latencyStream) RecvMsg(m interface{}) error {
+	err := stream.ClientStream.RecvMsg(m)
+	if err != nil {
+		return err
+	}
+
+	stream.mu.Lock()
+	first := !stream.received
+	stream.received = true
+	sent := stream.sent
+	stream.mu.Unlock()
+
+	if first {
+		stream.observe(stream.id, time.Since(sent))
+	}
+	return nil
+}
+
+// sending records when the last message was sent before the first one was
+// received
+func (stream *latencyStream) sending() {
+	stream.mu.Lock()
+	defer stream.mu.Unlock()
+	if !stream.received {
+		stream.sent = time.Now()
+	}
+}
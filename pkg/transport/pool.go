@@ -0,0 +1,241 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package transport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+// Pool keeps the connections to nodes open to reuse them, connections which
+// weren't used for the idle timeout are closed
+type Pool struct {
+	client    Client
+	idle      time.Duration
+	observers []Observer
+
+	mu     sync.Mutex
+	closed bool
+	conns  map[poolKey]*pooledConn
+}
+
+// poolKey identifies the connections of the pool, a node announcing a new
+// address gets a new connection
+type poolKey struct {
+	id      storj.NodeID
+	address string
+}
+
+// pooledConn is a connection of the pool which is shared by its users
+type pooledConn struct {
+	dialed chan struct{} // closed when dialing finished
+	conn   *grpc.ClientConn
+	err    error
+
+	users   int
+	idle    *time.Timer // closes the connection after it was idle, nil while in use
+	release uint64      // counts the releases, so that timers of earlier ones do nothing
+	broken  bool        // closed as soon as it isn't used anymore
+}
+
+// PooledConn is a connection to a node leased from the pool. It must be
+// closed when it isn't used anymore, which returns it to the pool.
+type PooledConn struct {
+	*grpc.ClientConn
+
+	pool   *Pool
+	key    poolKey
+	pooled *pooledConn
+	once   sync.Once
+}
+
+// NewPool returns a pool dialing the nodes with client, which closes
+// connections after they were idle for the idle timeout
+func NewPool(client Client, idle time.Duration, obs ...Observer) *Pool {
+	return &Pool{
+		client:    client,
+		idle:      idle,
+		observers: obs,
+		conns:     make(map[poolKey]*pooledConn),
+	}
+}
+
+// Dial returns a connection to the node, reusing a pooled one unless it
+// isn't healthy anymore
+func (pool *Pool) Dial(ctx context.Context, node *pb.Node) (_ *PooledConn, err error) {
+	defer mon.Task()(&ctx)(&err)
+	if node.Address == nil || node.Address.Address == "" {
+		return nil, Error.New("no address")
+	}
+	key := poolKey{id: node.Id, address: node.Address.Address}
+
+	for {
+		pooled, dialing, err := pool.acquire(key)
+		if err != nil {
+			return nil, err
+		}
+
+		if dialing {
+			conn, err := pool.client.DialNode(ctx, node)
+			pool.mu.Lock()
+			pooled.conn, pooled.err = conn, err
+			if err != nil {
+				pool.remove(key, pooled)
+			}
+			pool.mu.Unlock()
+			close(pooled.dialed)
+		} else {
+			select {
+			case <-pooled.dialed:
+			case <-ctx.Done():
+				return nil, errs.Combine(ctx.Err(), pool.release(key, pooled))
+			}
+		}
+
+		if pooled.err != nil {
+			alertFail(ctx, pool.observers, node, pooled.err)
+			return nil, Error.Wrap(pooled.err)
+		}
+
+		if !healthy(pooled.conn) {
+			mon.Counter("pooled_connections_unhealthy").Inc(1)
+			pool.discard(key, pooled)
+			continue
+		}
+
+		if !dialing {
+			mon.Counter("pooled_connections_reused").Inc(1)
+		}
+		alertSuccess(ctx, pool.observers, node)
+		return &PooledConn{
+			ClientConn: pooled.conn,
+			pool:       pool,
+			key:        key,
+			pooled:     pooled,
+		}, nil
+	}
+}
+
+// Close returns the connection to the pool
+func (conn *PooledConn) Close() (err error) {
+	conn.once.Do(func() {
+		err = conn.pool.release(conn.key, conn.pooled)
+	})
+	return err
+}
+
+// Close closes the connections and prevents new ones from being made,
+// connections still in use are closed when they are returned
+func (pool *Pool) Close() error {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	pool.closed = true
+	var errors []error
+	for key, pooled := range pool.conns {
+		pool.remove(key, pooled)
+		if pooled.users == 0 {
+			errors = append(errors, pooled.conn.Close())
+		}
+	}
+	return Error.Wrap(errs.Combine(errors...))
+}
+
+// acquire returns the pooled connection for key, dialing is true when the
+// caller has to dial it
+func (pool *Pool) acquire(key poolKey) (pooled *pooledConn, dialing bool, err error) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pool.closed {
+		return nil, false, Error.New("pool is closed")
+	}
+
+	pooled, ok := pool.conns[key]
+	if !ok {
+		pooled = &pooledConn{dialed: make(chan struct{})}
+		pool.conns[key] = pooled
+	}
+	pooled.users++
+	if pooled.idle != nil {
+		pooled.idle.Stop()
+		pooled.idle = nil
+	}
+	return pooled, !ok, nil
+}
+
+// release returns a connection to the pool, closing it when it is broken or
+// starting the idle timeout otherwise
+func (pool *Pool) release(key poolKey, pooled *pooledConn) error {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	pooled.users--
+	if pooled.users > 0 || pooled.err != nil {
+		return nil
+	}
+	if pooled.broken {
+		return Error.Wrap(pooled.conn.Close())
+	}
+
+	pooled.release++
+	release := pooled.release
+	pooled.idle = time.AfterFunc(pool.idle, func() {
+		pool.expire(key, pooled, release)
+	})
+	return nil
+}
+
+// expire closes the connection when it is still idle since the release
+func (pool *Pool) expire(key poolKey, pooled *pooledConn, release uint64) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pooled.release != release || pooled.idle == nil || pooled.users > 0 || pooled.broken {
+		return
+	}
+	pool.remove(key, pooled)
+	mon.Counter("pooled_connections_expired").Inc(1)
+	_ = pooled.conn.Close()
+}
+
+// discard removes an unhealthy connection from the pool, the connection is
+// closed when the last user returns it
+func (pool *Pool) discard(key poolKey, pooled *pooledConn) {
+	pool.mu.Lock()
+	pool.remove(key, pooled)
+	pool.mu.Unlock()
+
+	_ = pool.release(key, pooled)
+}
+
+// remove removes the pooled connection from the pool, must hold mu
+func (pool *Pool) remove(key poolKey, pooled *pooledConn) {
+	if pool.conns[key] == pooled {
+		delete(pool.conns, key)
+	}
+	pooled.broken = true
+	if pooled.idle != nil {
+		pooled.idle.Stop()
+		pooled.idle = nil
+	}
+}
+
+// healthy returns whether the connection can still be used
+func healthy(conn *grpc.ClientConn) bool {
+	switch conn.GetState() {
+	case connectivity.TransientFailure, connectivity.Shutdown:
+		return false
+	default:
+		return true
+	}
+}
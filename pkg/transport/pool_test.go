@@ -0,0 +1,102 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package transport_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/transport"
+)
+
+func TestPool(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	planet, err := testplanet.New(t, 0, 2, 0)
+	require.NoError(t, err)
+	defer ctx.Check(planet.Shutdown)
+
+	planet.Start(ctx)
+
+	idle := 100 * time.Millisecond
+	pool := transport.NewPool(transport.NewClient(planet.StorageNodes[0].Identity), idle)
+
+	target := &pb.Node{
+		Id: planet.StorageNodes[1].ID(),
+		Address: &pb.NodeAddress{
+			Transport: pb.NodeTransport_TCP_TLS_GRPC,
+			Address:   planet.StorageNodes[1].Addr(),
+		},
+		Type: pb.NodeType_STORAGE,
+	}
+
+	ping := func(conn *transport.PooledConn) {
+		_, err := pb.NewNodesClient(conn.ClientConn).Ping(ctx, &pb.PingRequest{}, grpc.FailFast(false))
+		require.NoError(t, err)
+	}
+
+	first, err := pool.Dial(ctx, target)
+	require.NoError(t, err)
+	ping(first)
+
+	{ // connections are shared while they are in use and reused afterwards
+		second, err := pool.Dial(ctx, target)
+		require.NoError(t, err)
+		assert.Equal(t, first.ClientConn, second.ClientConn)
+		assert.NoError(t, second.Close())
+		assert.NoError(t, second.Close(), "closing twice returns the connection once")
+
+		assert.NoError(t, first.Close())
+		third, err := pool.Dial(ctx, target)
+		require.NoError(t, err)
+		assert.Equal(t, first.ClientConn, third.ClientConn)
+		assert.NoError(t, third.Close())
+	}
+
+	{ // idle connections are closed and redialed
+		time.Sleep(3 * idle)
+		assert.Equal(t, connectivity.Shutdown, first.GetState())
+
+		redialed, err := pool.Dial(ctx, target)
+		require.NoError(t, err)
+		assert.NotEqual(t, first.ClientConn, redialed.ClientConn)
+		ping(redialed)
+		assert.NoError(t, redialed.Close())
+	}
+
+	{ // unhealthy connections aren't reused
+		unhealthy, err := pool.Dial(ctx, target)
+		require.NoError(t, err)
+		require.NoError(t, unhealthy.ClientConn.Close())
+		assert.NoError(t, unhealthy.Close())
+
+		healthy, err := pool.Dial(ctx, target)
+		require.NoError(t, err)
+		assert.NotEqual(t, unhealthy.ClientConn, healthy.ClientConn)
+		ping(healthy)
+		assert.NoError(t, healthy.Close())
+	}
+
+	{ // a closed pool closes its connections once they are returned
+		leased, err := pool.Dial(ctx, target)
+		require.NoError(t, err)
+		assert.NoError(t, pool.Close())
+		assert.NotEqual(t, connectivity.Shutdown, leased.GetState())
+		assert.NoError(t, leased.Close())
+		assert.Equal(t, connectivity.Shutdown, leased.GetState())
+
+		_, err = pool.Dial(ctx, target)
+		assert.Error(t, err)
+		assert.NoError(t, pool.Close())
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package transport
+
+import (
+	"crypto/tls"
+
+	"storj.io/storj/pkg/storj"
+)
+
+// sessionCacheSize is the number of TLS sessions kept for resumption
+const sessionCacheSize = 1024
+
+// nodeSessions stores the TLS sessions with one node in a cache shared with
+// the other nodes. Sessions are resumed without verifying the peer again,
+// so they are kept apart to never resume a session with another node at the
+// same address.
+type nodeSessions struct {
+	id    storj.NodeID
+	cache tls.ClientSessionCache
+}
+
+// Get returns the session with the node for the key
+func (sessions *nodeSessions) Get(sessionKey string) (*tls.ClientSessionState, bool) {
+	return sessions.cache.Get(sessions.id.String() + "/" + sessionKey)
+}
+
+// Put stores the session with the node for the key
+func (sessions *nodeSessions) Put(sessionKey string, cs *tls.ClientSessionState) {
+	sessions.cache.Put(sessions.id.String()+"/"+sessionKey, cs)
+}
@@ -5,6 +5,8 @@ package transport
 
 import (
 	"context"
+	"crypto/tls"
+	"net"
 	"time"
 
 	"github.com/zeebo/errs"
@@ -13,6 +15,7 @@ import (
 
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/relay"
 	"storj.io/storj/pkg/storj"
 )
 
@@ -42,6 +45,7 @@ type Client interface {
 type Transport struct {
 	identity  *provider.FullIdentity
 	observers []Observer
+	sessions  tls.ClientSessionCache
 }
 
 // NewClient returns a newly instantiated Transport Client
@@ -49,6 +53,7 @@ func NewClient(identity *provider.FullIdentity, obs ...Observer) Client {
 	return &Transport{
 		identity:  identity,
 		observers: obs,
+		sessions:  tls.NewLRUClientSessionCache(sessionCacheSize),
 	}
 }
 
@@ -62,8 +67,9 @@ func (transport *Transport) DialNode(ctx context.Context, node *pb.Node, opts ..
 		return nil, Error.New("no address")
 	}
 
-	// add ID of node we are wanting to connect to
-	dialOpt, err := transport.identity.DialOption(node.Id)
+	// add ID of node we are wanting to connect to, redials resume the TLS
+	// session with it
+	dialOpt, err := transport.identity.DialOptionWithSessions(node.Id, transport.sessionsWith(node.Id))
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -73,6 +79,17 @@ func (transport *Transport) DialNode(ctx context.Context, node *pb.Node, opts ..
 	ctx, cf := context.WithTimeout(ctx, timeout)
 	defer cf()
 
+	// nodes which can't be dialed directly are dialed through their relay
+	if relayAddress := node.GetAddress().Relay; relayAddress != "" {
+		id := node.Id
+		options = append(options, grpc.WithDialer(func(_ string, timeout time.Duration) (net.Conn, error) {
+			// grpc redials with this dialer, after DialNode returned
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			return relay.Dial(ctx, relayAddress, id)
+		}))
+	}
+
 	conn, err = grpc.DialContext(ctx, node.GetAddress().Address, options...)
 	if err != nil {
 		alertFail(ctx, transport.observers, node, err)
@@ -98,6 +115,15 @@ func (transport *Transport) DialAddress(ctx context.Context, address string, opt
 	return conn, Error.Wrap(err)
 }
 
+// sessionsWith returns the TLS sessions with the node, nil when its ID is
+// unknown
+func (transport *Transport) sessionsWith(id storj.NodeID) tls.ClientSessionCache {
+	if id.IsZero() {
+		return nil
+	}
+	return &nodeSessions{id: id, cache: transport.sessions}
+}
+
 // Identity is a getter for the transport's identity
 func (transport *Transport) Identity() *provider.FullIdentity {
 	return transport.identity
@@ -6,8 +6,6 @@ package utils
 import (
 	"bytes"
 	"encoding/gob"
-	"fmt"
-	"strings"
 	"time"
 )
 
@@ -22,19 +20,6 @@ func GetBytes(key interface{}) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-// SplitDBURL returns the driver and DSN portions of a URL
-func SplitDBURL(s string) (string, string, error) {
-	// consider https://github.com/xo/dburl if this ends up lacking
-	parts := strings.SplitN(s, "://", 2)
-	if len(parts) != 2 {
-		return "", "", fmt.Errorf("Could not parse DB URL %s", s)
-	}
-	if parts[0] == "postgres" {
-		parts[1] = s // postgres wants full URLS for its DSN
-	}
-	return parts[0], parts[1], nil
-}
-
 // CombineErrors combines multiple errors to a single error
 func CombineErrors(errs ...error) error {
 	var errlist ErrorGroup
@@ -0,0 +1,163 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package watchdog
+
+import (
+	"sync"
+	"time"
+)
+
+// Kind is the kind of background processing that is tracked
+type Kind string
+
+const (
+	// KindLoop is a loop which runs a cycle every interval
+	KindLoop = Kind("loop")
+	// KindQueue is a queue which is drained by a consumer
+	KindQueue = Kind("queue")
+)
+
+// Status is the progress of a loop or queue
+type Status struct {
+	Name string `json:"name"`
+	Kind Kind   `json:"kind"`
+
+	// LastProgress is when a cycle last succeeded or items were last drained
+	LastProgress time.Time `json:"lastProgress"`
+	// Count is the number of succeeded cycles or drained items
+	Count int64 `json:"count"`
+	// Rate is the number of succeeded cycles or drained items per minute
+	// measured between the last two watchdog checks
+	Rate float64 `json:"rate"`
+	// LastError is the error of the last failed cycle, if it failed
+	LastError string `json:"lastError,omitempty"`
+	// Standby is set while another process runs the loop or queue
+	Standby bool `json:"standby,omitempty"`
+
+	Stalled bool `json:"stalled"`
+}
+
+// tracker keeps the progress of a loop or queue
+type tracker struct {
+	name    string
+	kind    Kind
+	allowed time.Duration
+	now     func() time.Time
+
+	mu       sync.Mutex
+	progress time.Time
+	count    int64
+	lastErr  error
+	standby  bool
+
+	sampled      time.Time
+	sampledCount int64
+	rate         float64
+}
+
+// advance records n units of progress
+func (tracker *tracker) advance(n int64) {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	tracker.progress = tracker.now()
+	tracker.count += n
+	tracker.lastErr = nil
+}
+
+// fail records a failed cycle, which doesn't count as progress
+func (tracker *tracker) fail(err error) {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	tracker.lastErr = err
+}
+
+// setStandby records whether another process runs the loop or queue
+func (tracker *tracker) setStandby(standby bool) {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	if tracker.standby && !standby {
+		tracker.progress = tracker.now()
+	}
+	tracker.standby = standby
+}
+
+// sample updates the rate of progress since the previous sample
+func (tracker *tracker) sample() {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	now := tracker.now()
+	if elapsed := now.Sub(tracker.sampled); elapsed > 0 {
+		tracker.rate = float64(tracker.count-tracker.sampledCount) / elapsed.Minutes()
+	}
+	tracker.sampled, tracker.sampledCount = now, tracker.count
+}
+
+func (tracker *tracker) status() Status {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	status := Status{
+		Name:         tracker.name,
+		Kind:         tracker.kind,
+		LastProgress: tracker.progress,
+		Count:        tracker.count,
+		Rate:         tracker.rate,
+		Standby:      tracker.standby,
+		Stalled:      !tracker.standby && tracker.now().Sub(tracker.progress) > tracker.allowed,
+	}
+	if tracker.lastErr != nil {
+		status.LastError = tracker.lastErr.Error()
+	}
+	return status
+}
+
+// Loop reports the progress of a loop to the watchdog. A nil Loop ignores
+// all reports.
+type Loop struct{ tracker *tracker }
+
+// Cycle records a completed cycle of the loop. Failed cycles don't count as
+// progress, so a loop failing for too long is reported as stalled.
+func (loop *Loop) Cycle(err error) {
+	if loop == nil {
+		return
+	}
+	if err != nil {
+		loop.tracker.fail(err)
+		return
+	}
+	loop.tracker.advance(1)
+}
+
+// Queue reports the progress of a queue consumer to the watchdog. A nil Queue
+// ignores all reports.
+type Queue struct{ tracker *tracker }
+
+// Drained records that n items were taken off the queue and processed
+func (queue *Queue) Drained(n int) {
+	if queue == nil {
+		return
+	}
+	queue.tracker.advance(int64(n))
+}
+
+// Empty records that the consumer found the queue empty, which means it isn't
+// stuck either
+func (queue *Queue) Empty() {
+	if queue == nil {
+		return
+	}
+	queue.tracker.advance(0)
+}
+
+// Failed records that the consumer failed to take items off the queue
+func (queue *Queue) Failed(err error) {
+	if queue == nil {
+		return
+	}
+	queue.tracker.fail(err)
+}
@@ -0,0 +1,189 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package watchdog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the default error class for the watchdog
+	Error = errs.Class("watchdog error")
+)
+
+// Config contains configurable values for the watchdog
+type Config struct {
+	Interval time.Duration `help:"how frequently background loop progress is checked" default:"1m0s"`
+	MaxStall time.Duration `help:"how much longer than its interval a loop may go without completing a cycle, or a queue without draining, before it's reported as stalled" default:"1h0m0s"`
+}
+
+// Watchdog detects background loops that stopped completing cycles and
+// queues that stopped draining, so that gaps in background processing are
+// reported instead of going unnoticed.
+type Watchdog struct {
+	log    *zap.Logger
+	config Config
+	now    func() time.Time
+
+	mu       sync.Mutex
+	trackers map[string]*tracker
+}
+
+// New creates a watchdog without any loops or queues
+func New(log *zap.Logger, config Config) *Watchdog {
+	return &Watchdog{
+		log:      log,
+		config:   config,
+		now:      time.Now,
+		trackers: make(map[string]*tracker),
+	}
+}
+
+// Loop starts tracking a loop which is expected to complete a cycle every
+// interval
+func (watchdog *Watchdog) Loop(name string, interval time.Duration) *Loop {
+	return &Loop{watchdog.track(name, KindLoop, interval)}
+}
+
+// Queue starts tracking a queue which is expected to be drained by a consumer
+// checking it every interval
+func (watchdog *Watchdog) Queue(name string, interval time.Duration) *Queue {
+	return &Queue{watchdog.track(name, KindQueue, interval)}
+}
+
+func (watchdog *Watchdog) track(name string, kind Kind, interval time.Duration) *tracker {
+	watchdog.mu.Lock()
+	defer watchdog.mu.Unlock()
+
+	now := watchdog.now()
+	tracker := &tracker{
+		name:     name,
+		kind:     kind,
+		allowed:  interval + watchdog.config.MaxStall,
+		now:      watchdog.now,
+		progress: now,
+		sampled:  now,
+	}
+	watchdog.trackers[name] = tracker
+	return tracker
+}
+
+// Standby tells whether the loop or queue name stands by while another
+// process runs it. Loops and queues on standby are never stalled, their
+// progress is tracked anew when they stop standing by.
+func (watchdog *Watchdog) Standby(name string, standby bool) {
+	watchdog.mu.Lock()
+	tracker, ok := watchdog.trackers[name]
+	watchdog.mu.Unlock()
+
+	if ok {
+		tracker.setStandby(standby)
+	}
+}
+
+// Status returns the status of all loops and queues ordered by name
+func (watchdog *Watchdog) Status() []Status {
+	watchdog.mu.Lock()
+	trackers := make([]*tracker, 0, len(watchdog.trackers))
+	for _, tracker := range watchdog.trackers {
+		trackers = append(trackers, tracker)
+	}
+	watchdog.mu.Unlock()
+
+	statuses := make([]Status, 0, len(trackers))
+	for _, tracker := range trackers {
+		statuses = append(statuses, tracker.status())
+	}
+	sort.Slice(statuses, func(i, k int) bool { return statuses[i].Name < statuses[k].Name })
+	return statuses
+}
+
+// Check returns an error describing every stalled loop and queue
+func (watchdog *Watchdog) Check(ctx context.Context) error {
+	var group errs.Group
+	for _, status := range watchdog.Status() {
+		if status.Stalled {
+			group.Add(Error.New("%s %s stalled: no progress since %v", status.Kind, status.Name, status.LastProgress.Format(time.RFC3339)))
+		}
+	}
+	return group.Err()
+}
+
+// ServeHTTP serves the status of all loops and queues as JSON, responding with
+// 503 Service Unavailable when any of them is stalled
+func (watchdog *Watchdog) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	statuses := watchdog.Status()
+
+	code := http.StatusOK
+	for _, status := range statuses {
+		if status.Stalled {
+			code = http.StatusServiceUnavailable
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(statuses)
+}
+
+// Run checks the loops and queues every interval, logging when they stall
+// and recover, until the context is canceled
+func (watchdog *Watchdog) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ticker := time.NewTicker(watchdog.config.Interval)
+	defer ticker.Stop()
+
+	reported := make(map[string]bool)
+	for {
+		watchdog.check(reported)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// check samples drain rates and logs changes of the stalled state
+func (watchdog *Watchdog) check(reported map[string]bool) {
+	watchdog.mu.Lock()
+	for _, tracker := range watchdog.trackers {
+		tracker.sample()
+	}
+	watchdog.mu.Unlock()
+
+	stalled := 0
+	for _, status := range watchdog.Status() {
+		switch {
+		case status.Stalled:
+			stalled++
+			if !reported[status.Name] {
+				watchdog.log.Error("background processing stalled",
+					zap.String("kind", string(status.Kind)),
+					zap.String("name", status.Name),
+					zap.Time("last progress", status.LastProgress),
+					zap.String("last error", status.LastError))
+			}
+		case reported[status.Name]:
+			watchdog.log.Info("background processing recovered",
+				zap.String("kind", string(status.Kind)),
+				zap.String("name", status.Name))
+		}
+		reported[status.Name] = status.Stalled
+	}
+	mon.IntVal("stalled").Observe(int64(stalled))
+}
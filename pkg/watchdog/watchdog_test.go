@@ -0,0 +1,131 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package watchdog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWatchdog(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+	core, logs := observer.New(zapcore.InfoLevel)
+	watchdog := New(zap.New(core), Config{Interval: time.Minute, MaxStall: time.Hour})
+	watchdog.now = func() time.Time { return now }
+
+	checker := watchdog.Loop("checker", time.Hour)
+	repair := watchdog.Queue("repair", time.Minute)
+
+	reported := make(map[string]bool)
+	watchdog.check(reported)
+	require.NoError(t, watchdog.Check(ctx))
+
+	{ // failing cycles and an erroring queue aren't progress
+		now = now.Add(time.Hour)
+		checker.Cycle(errors.New("iteration failed"))
+		repair.Drained(3)
+		now = now.Add(30 * time.Minute)
+		watchdog.check(reported)
+
+		statuses := watchdog.Status()
+		require.Len(t, statuses, 2)
+		assert.Equal(t, "checker", statuses[0].Name)
+		assert.Equal(t, KindLoop, statuses[0].Kind)
+		assert.EqualValues(t, 0, statuses[0].Count)
+		assert.Equal(t, "iteration failed", statuses[0].LastError)
+		assert.False(t, statuses[0].Stalled)
+
+		assert.Equal(t, "repair", statuses[1].Name)
+		assert.EqualValues(t, 3, statuses[1].Count)
+		assert.InDelta(t, 3.0/90, statuses[1].Rate, 1e-9)
+		assert.False(t, statuses[1].Stalled)
+
+		now = now.Add(time.Hour)
+		repair.Failed(errors.New("database locked"))
+		watchdog.check(reported)
+
+		statuses = watchdog.Status()
+		assert.True(t, statuses[0].Stalled)
+		assert.True(t, statuses[1].Stalled)
+		assert.Equal(t, "database locked", statuses[1].LastError)
+		assert.EqualValues(t, 0, statuses[1].Rate)
+
+		err := watchdog.Check(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "loop checker stalled")
+		assert.Contains(t, err.Error(), "queue repair stalled")
+
+		recorder := httptest.NewRecorder()
+		watchdog.ServeHTTP(recorder, httptest.NewRequest("GET", "/health/watchdog", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+		stalled := logs.FilterMessage("background processing stalled").All()
+		assert.Len(t, stalled, 2)
+
+		// stalls are only logged once
+		watchdog.check(reported)
+		assert.Len(t, logs.FilterMessage("background processing stalled").All(), 2)
+	}
+
+	{ // progress recovers
+		checker.Cycle(nil)
+		repair.Empty()
+		watchdog.check(reported)
+
+		require.NoError(t, watchdog.Check(ctx))
+		assert.Len(t, logs.FilterMessage("background processing recovered").All(), 2)
+
+		recorder := httptest.NewRecorder()
+		watchdog.ServeHTTP(recorder, httptest.NewRequest("GET", "/health/watchdog", nil))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	}
+}
+
+func TestNilProgress(t *testing.T) {
+	var loop *Loop
+	var queue *Queue
+
+	loop.Cycle(nil)
+	loop.Cycle(errors.New("failed"))
+	queue.Drained(1)
+	queue.Empty()
+	queue.Failed(errors.New("failed"))
+}
+
+func TestStandby(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+	watchdog := New(zap.NewNop(), Config{Interval: time.Minute, MaxStall: time.Hour})
+	watchdog.now = func() time.Time { return now }
+
+	watchdog.Loop("gc", time.Hour)
+	watchdog.Standby("gc", true)
+	watchdog.Standby("unknown", true)
+
+	// loops run by another process don't stall
+	now = now.Add(24 * time.Hour)
+	require.NoError(t, watchdog.Check(ctx))
+	assert.True(t, watchdog.Status()[0].Standby)
+
+	// taking over starts tracking anew
+	watchdog.Standby("gc", false)
+	now = now.Add(time.Hour)
+	require.NoError(t, watchdog.Check(ctx))
+
+	now = now.Add(2 * time.Hour)
+	require.Error(t, watchdog.Check(ctx))
+}
@@ -7,8 +7,11 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"path/filepath"
+	"time"
 
+	"github.com/skyrings/skyring-common/tools/uuid"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -19,17 +22,25 @@ import (
 	"storj.io/storj/pkg/datarepair/checker"
 	"storj.io/storj/pkg/datarepair/irreparable"
 	"storj.io/storj/pkg/datarepair/queue"
+	"storj.io/storj/pkg/datarepair/rebalancer"
 	"storj.io/storj/pkg/datarepair/repairer"
 	"storj.io/storj/pkg/discovery"
+	"storj.io/storj/pkg/gc"
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/kademlia"
+	"storj.io/storj/pkg/lease"
 	"storj.io/storj/pkg/node"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/relay"
+	"storj.io/storj/pkg/retryqueue"
 	"storj.io/storj/pkg/server"
 	"storj.io/storj/pkg/statdb"
+	ecclient "storj.io/storj/pkg/storage/ec"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/pkg/watchdog"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/boltdb"
@@ -49,12 +60,18 @@ type DB interface {
 	StatDB() statdb.DB
 	// OverlayCache returns database for caching overlay information
 	OverlayCache() overlay.DB
+	// NodeEvents returns database for node lifecycle events
+	NodeEvents() overlay.EventsDB
 	// Accounting returns database for storing information about data use
 	Accounting() accounting.DB
 	// RepairQueue returns queue for segments that need repairing
 	RepairQueue() queue.RepairQueue
+	// RetryQueue returns database for durable queues retrying their items
+	RetryQueue() retryqueue.DB
 	// Irreparable returns database for failed repairs
 	Irreparable() irreparable.DB
+	// Leases returns database for the leases of singleton jobs shared by replicas
+	Leases() lease.DB
 	// Console returns database for satellite console
 	Console() console.DB
 }
@@ -66,6 +83,11 @@ type Config struct {
 	// TODO: switch to using server.Config when Identity has been removed from it
 	Database      string `help:"satellite database connection string" default:"sqlite3://$CONFDIR/master.db"`
 	PublicAddress string `help:"public address to listen on" default:":7777"`
+	HealthAddress string `help:"address to serve the HTTP /health endpoint on, empty disables it" default:""`
+
+	PeerIdentityCacheSize int `help:"number of verified peer certificate chains to remember (0 disables caching)" default:"1000"`
+
+	RequestLog server.RequestLogConfig
 
 	Kademlia  kademlia.Config
 	Overlay   overlay.Config
@@ -74,9 +96,20 @@ type Config struct {
 	PointerDB   pointerdb.Config
 	BwAgreement bwagreement.Config
 
-	Checker  checker.Config
-	Repairer repairer.Config
+	Checker    checker.Config
+	Repairer   repairer.Config
+	Rebalancer rebalancer.Config
 	// TODO: Audit    audit.Config
+
+	GarbageCollection gc.Config
+
+	Watchdog watchdog.Config
+
+	Leases lease.Config
+
+	Relay relay.Config
+
+	Contacts transport.ContactsConfig
 }
 
 // Peer is the satellite
@@ -88,31 +121,66 @@ type Peer struct {
 
 	// servers
 	Public struct {
+		Listener   net.Listener
+		Server     *server.Server
+		RequestLog *server.RequestLog
+		Pruner     *server.RequestLogPruner
+	}
+
+	Health struct {
+		Listener net.Listener
+		Server   *http.Server
+	}
+
+	Stats struct {
 		Listener net.Listener
-		Server   *server.Server
+		Server   *http.Server
 	}
 
 	// services and endpoints
+	Watchdog *watchdog.Watchdog
+
+	// Leases runs the singleton jobs in one of the replicas sharing the
+	// database, it's nil when the satellite runs without replicas
+	Leases *lease.Coordinator
+
+	// Contacts is shared by the subsystems contacting storage nodes
+	Contacts *transport.Contacts
+
 	Kademlia struct {
 		RoutingTable *kademlia.RoutingTable
 		Service      *kademlia.Kademlia
 		Endpoint     *node.Server
+		Refresher    *kademlia.Refresher
 	}
 
 	Overlay struct {
-		Service  *overlay.Cache
-		Endpoint *overlay.Server
+		Service     *overlay.Cache
+		NodeLists   *overlay.NodeLists
+		Vetting     *overlay.Vetting
+		Network     *overlay.Network
+		RateLimiter *overlay.RateLimiter
+		Latencies   *overlay.Latencies
+		Endpoint    *overlay.Server
 	}
 
 	Discovery struct {
-		Service *discovery.Discovery
+		Service   *discovery.Discovery
+		Partition *discovery.PartitionDetector
 	}
 
+	Relay *relay.Server
+
 	Metainfo struct {
 		Database   storage.KeyValueStore // TODO: move into pointerDB
 		Allocation *pointerdb.AllocationSigner
 		Service    *pointerdb.Service
 		Endpoint   *pointerdb.Server
+		Quota      *pointerdb.Quota
+		Purger     *pointerdb.Purger
+		Reaper     *pointerdb.Reaper
+		AuditLog   *pointerdb.AuditLog
+		Compactor  *pointerdb.AuditLogCompactor
 	}
 
 	Agreements struct {
@@ -120,13 +188,17 @@ type Peer struct {
 	}
 
 	Repair struct {
-		Checker  checker.Checker // TODO: convert to actual struct
-		Repairer *repairer.Service
+		Checker    checker.Checker // TODO: convert to actual struct
+		Repairer   *repairer.Service
+		Health     *checker.HealthEndpoint
+		Rebalancer *rebalancer.Rebalancer
 	}
 	Audit struct {
 		// TODO: Service *audit.Service
 	}
 
+	GarbageCollection *gc.Service
+
 	// TODO: add console
 }
 
@@ -146,19 +218,63 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config *Config) (*
 			return nil, errs.Combine(err, peer.Close())
 		}
 
-		publicConfig := server.Config{Address: peer.Public.Listener.Addr().String()}
+		publicConfig := server.Config{
+			Address:               peer.Public.Listener.Addr().String(),
+			PeerIdentityCacheSize: config.PeerIdentityCacheSize,
+		}
 		publicOptions, err := server.NewOptions(peer.Identity, publicConfig)
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
 		}
 
-		peer.Public.Server, err = server.NewServer(publicOptions, peer.Public.Listener, nil)
+		// node selection is rate limited before it reaches the overlay endpoint
+		peer.Overlay.RateLimiter = overlay.NewRateLimiter(config.Overlay.RateLimit)
+
+		// operator endpoints are only served to local callers
+		interceptor := server.CombineInterceptors(server.LocalOnly("/inspector."), peer.Overlay.RateLimiter.UnaryInterceptor())
+
+		// requests are logged with the outcome of the other interceptors
+		if config.RequestLog.URL != "" {
+			requestDB, err := pointerdb.NewStore(config.RequestLog.URL)
+			if err != nil {
+				return nil, errs.Combine(err, peer.Close())
+			}
+			peer.Public.RequestLog = server.NewRequestLog(requestDB, config.RequestLog.SampleRate)
+			interceptor = server.CombineInterceptors(peer.Public.RequestLog.UnaryInterceptor(), interceptor)
+		}
+
+		peer.Public.Server, err = server.NewServer(publicOptions, peer.Public.Listener, interceptor)
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
 		}
 	}
 
+	{ // setup watchdog
+		peer.Watchdog = watchdog.New(peer.Log.Named("watchdog"), config.Watchdog)
+	}
+
+	if config.Leases.Holder != "" { // setup coordination with the replicas sharing the database
+		peer.Leases = lease.NewCoordinator(peer.Log.Named("leases"), peer.DB.Leases(), config.Leases)
+		peer.Leases.SetStandby(peer.Watchdog.Standby)
+	}
+
+	if peer.Public.RequestLog != nil && config.RequestLog.Retention > 0 { // setup request log pruning
+		config := config.RequestLog
+		peer.Public.Pruner = server.NewRequestLogPruner(peer.Log.Named("requestlog"), peer.Public.RequestLog,
+			config.Retention, config.PruneInterval, peer.Watchdog.Loop("requestlog", config.PruneInterval))
+	}
+
+	{ // setup outbound contact pool, which records the round trips of transfers
+		region := config.Overlay.Node.Region
+		peer.Overlay.Latencies = overlay.NewLatencies()
+		client := transport.ObserveLatency(transport.NewClient(peer.Identity), func(id storj.NodeID, rtt time.Duration) {
+			peer.Overlay.Latencies.Observe(region, id, rtt)
+		})
+		peer.Contacts = transport.NewContacts(client, config.Contacts)
+	}
+
 	{ // setup kademlia
+		region := config.Overlay.Node.Region
 		config := config.Kademlia
 		// TODO: move this setup logic into kademlia package
 		if config.ExternalAddress == "" {
@@ -171,10 +287,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config *Config) (*
 			Address: &pb.NodeAddress{
 				Address: config.ExternalAddress,
 			},
-			Metadata: &pb.NodeMetadata{
-				Email:  config.Operator.Email,
-				Wallet: config.Operator.Wallet,
-			},
+			Metadata: config.Operator.Metadata(),
 		}
 
 		{ // setup routing table
@@ -194,34 +307,67 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config *Config) (*
 			}
 		}
 
+		bootstrapNodes, err := kademlia.GetIntroNodes(config.BootstrapAddr)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+
 		// TODO: reduce number of arguments
-		peer.Kademlia.Service, err = kademlia.NewWith(peer.Log.Named("kademlia"), self, nil, peer.Identity, config.Alpha, peer.Kademlia.RoutingTable)
+		peer.Kademlia.Service, err = kademlia.NewWith(peer.Log.Named("kademlia"), self, bootstrapNodes, peer.Identity, config.Alpha, peer.Kademlia.RoutingTable)
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
 		}
+		peer.Kademlia.Service.SetBootstrapBackoff(config.BootstrapBackoff)
+		peer.Kademlia.Service.SetLookupCache(config.LookupCacheSize, config.LookupCacheTTL)
+		peer.Kademlia.Service.SetAddressDiscovery(config.AddressQuorum)
+		peer.Kademlia.Service.OnPing(func(id storj.NodeID, rtt time.Duration) {
+			peer.Overlay.Latencies.Observe(region, id, rtt)
+		})
+		peer.Kademlia.RoutingTable.SetMinimumDifficulty(uint16(config.MinDifficulty))
+		peer.Kademlia.RoutingTable.SetBucketSize(config.BucketSize, config.ReplacementCache)
 
 		peer.Kademlia.Endpoint = node.NewServer(peer.Log.Named("kademlia:endpoint"), peer.Kademlia.Service)
 		pb.RegisterNodesServer(peer.Public.Server.GRPC(), peer.Kademlia.Endpoint)
+		pb.RegisterKadInspectorServer(peer.Public.Server.GRPC(), kademlia.NewInspector(peer.Kademlia.Service, peer.Identity))
+
+		peer.Kademlia.Refresher = kademlia.NewRefresher(peer.Log.Named("kademlia:refresh"), peer.Kademlia.Service, config.Refresh)
+	}
+
+	if config.Relay.Address != "" { // setup relay for nodes which can't be dialed directly
+		listener, err := net.Listen("tcp", config.Relay.Address)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+		peer.Relay = relay.NewServer(peer.Log.Named("relay"), listener)
 	}
 
 	{ // setup overlay
 		config := config.Overlay
-		peer.Overlay.Service = overlay.NewCache(peer.DB.OverlayCache(), peer.DB.StatDB())
+		peer.Overlay.Service = overlay.NewCache(peer.DB.OverlayCache(), peer.DB.StatDB(), config.Node)
 
-		ns := &pb.NodeStats{
-			UptimeCount:       config.Node.UptimeCount,
-			UptimeRatio:       config.Node.UptimeRatio,
-			AuditSuccessRatio: config.Node.AuditSuccessRatio,
-			AuditCount:        config.Node.AuditCount,
+		peer.Overlay.NodeLists, err = overlay.NewNodeLists(peer.Log.Named("overlay:lists"), config.Node.BlacklistFile, config.Node.WhitelistFile, config.Node.TagsFile)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
 		}
 
-		peer.Overlay.Endpoint = overlay.NewServer(peer.Log.Named("overlay:endpoint"), peer.Overlay.Service, ns)
+		loop := peer.Watchdog.Loop("overlay:vetting", config.Vetting.Interval)
+		peer.Overlay.Vetting = overlay.NewVetting(peer.Log.Named("overlay:vetting"), peer.Overlay.Service, peer.DB.NodeEvents(), config.Vetting, loop)
+
+		networkLoop := peer.Watchdog.Loop("overlay:network", config.Network.Interval)
+		peer.Overlay.Network = overlay.NewNetwork(peer.Log.Named("overlay:network"), peer.Overlay.Service, config.Network, networkLoop)
+
+		peer.Overlay.Endpoint = overlay.NewServer(peer.Log.Named("overlay:endpoint"), peer.Overlay.Service, config.Node, peer.Overlay.NodeLists, peer.Overlay.Vetting)
+		peer.Overlay.Endpoint.SetLatencies(peer.Overlay.Latencies)
+		peer.Overlay.Endpoint.SetSigner(peer.Identity)
 		pb.RegisterOverlayServer(peer.Public.Server.GRPC(), peer.Overlay.Endpoint)
 	}
 
 	{ // setup discovery
 		config := config.Discovery
-		peer.Discovery.Service = discovery.New(peer.Log.Named("discovery"), peer.Overlay.Service, peer.Kademlia.Service, peer.DB.StatDB(), config.RefreshInterval)
+		loop := peer.Watchdog.Loop("discovery:refresh", config.RefreshInterval)
+		peer.Discovery.Service = discovery.New(peer.Log.Named("discovery"), peer.Overlay.Service, peer.Kademlia.Service, peer.DB.StatDB(), config.RefreshInterval, loop)
+		peer.Kademlia.Service.AddObserver(peer.Discovery.Service)
+		peer.Discovery.Partition = discovery.NewPartitionDetector(peer.Log.Named("discovery:partition"), peer.Overlay.Service, peer.Kademlia.Service, peer.Kademlia.Refresher, config.Partition)
 	}
 
 	{ // setup metainfo
@@ -232,9 +378,67 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config *Config) (*
 
 		peer.Metainfo.Database = storelogger.New(peer.Log.Named("pdb"), db)
 		peer.Metainfo.Service = pointerdb.NewService(peer.Log.Named("pointerdb"), peer.Metainfo.Database)
+		peer.Metainfo.Service.SetCompression(config.PointerDB.CompressPointers)
+		// replicas don't see the pointers changed by each other
+		if peer.Leases == nil {
+			peer.Metainfo.Service.SetCache(config.PointerDB.PointerCacheSize)
+		} else if config.PointerDB.PointerCacheSize > 0 {
+			peer.Log.Warn("pointer cache disabled, it can't be kept consistent between replicas")
+		}
+		peer.Metainfo.Service.SetChecksums(config.PointerDB.ChecksumPointers)
+
+		windows, err := pointerdb.ParseUndeleteWindows(config.PointerDB.BucketUndeleteWindows)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+		peer.Metainfo.Service.SetUndeleteWindow(config.PointerDB.UndeleteWindow, windows)
+		peer.Metainfo.Service.SetExpirationIndex(pointerdb.IndexesExpiration(config.PointerDB.DatabaseURL))
+
+		if config.PointerDB.AuditLogURL != "" {
+			auditDB, err := pointerdb.NewStore(config.PointerDB.AuditLogURL)
+			if err != nil {
+				return nil, errs.Combine(err, peer.Close())
+			}
+			peer.Metainfo.AuditLog = pointerdb.NewAuditLog(auditDB)
+			peer.Metainfo.Service.SetAuditLog(peer.Metainfo.AuditLog)
+			peer.Metainfo.Compactor = pointerdb.NewAuditLogCompactor(peer.Log.Named("pointerdb:auditlog"),
+				peer.Metainfo.AuditLog, config.PointerDB.AuditLogCompactAfter, config.PointerDB.AuditLogRetention,
+				config.PointerDB.AuditLogCompactInterval,
+				peer.Watchdog.Loop("auditlog", config.PointerDB.AuditLogCompactInterval))
+		}
+
 		peer.Metainfo.Allocation = pointerdb.NewAllocationSigner(peer.Identity, config.PointerDB.BwExpiration)
 		peer.Metainfo.Endpoint = pointerdb.NewServer(peer.Log.Named("pointerdb:endpoint"), peer.Metainfo.Service, peer.Metainfo.Allocation, peer.Overlay.Service, config.PointerDB, peer.Identity)
 		pb.RegisterPointerDBServer(peer.Public.Server.GRPC(), peer.Metainfo.Endpoint)
+
+		if config.PointerDB.StorageQuota > 0 || config.PointerDB.ObjectQuota > 0 {
+			apiKeys := peer.DB.Console().APIKeys()
+			projects := func(ctx context.Context, apiKey []byte) (uuid.UUID, error) {
+				info, err := apiKeys.GetByKey(ctx, *console.APIKeyFromBytes(apiKey))
+				if err != nil {
+					return uuid.UUID{}, err
+				}
+				return info.ProjectID, nil
+			}
+			peer.Metainfo.Quota = pointerdb.NewQuota(peer.Log.Named("pointerdb:quota"),
+				peer.DB.Accounting(), projects,
+				config.PointerDB.StorageQuota, config.PointerDB.ObjectQuota)
+			peer.Metainfo.Endpoint.SetQuota(peer.Metainfo.Quota)
+		}
+
+		peer.Metainfo.Purger = pointerdb.NewPurger(peer.Log.Named("pointerdb:purger"),
+			peer.Metainfo.Service, peer.Overlay.Service,
+			ecclient.NewTransportClient(peer.Contacts.Client("purger"), 0), peer.Identity,
+			config.PointerDB.PurgeInterval,
+			peer.Watchdog.Loop("purger", config.PointerDB.PurgeInterval))
+		peer.Metainfo.Purger.SetDeletionQueue(retryqueue.New(peer.DB.RetryQueue(), pointerdb.DeletionQueueName, config.PointerDB.DeletionRetry), config.PointerDB.DeletionWorkers)
+
+		peer.Metainfo.Reaper = pointerdb.NewReaper(peer.Log.Named("pointerdb:reaper"),
+			peer.Metainfo.Service, config.PointerDB.ReapInterval,
+			peer.Watchdog.Loop("reaper", config.PointerDB.ReapInterval))
+		if peer.Metainfo.Quota != nil {
+			peer.Metainfo.Reaper.OnDelete(peer.Metainfo.Quota.ReleaseExpired)
+		}
 	}
 
 	{ // setup agreements
@@ -243,27 +447,83 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config *Config) (*
 	}
 
 	{ // setup datarepair
+		// pieces storage nodes reported as lost count as missing in the checker
+		lost := checker.NewLostPieces(config.Checker.LostPiecesExpiration)
+
+		// failed repairs are retried with a backoff
+		repairQueue := queue.NewRetryQueue(retryqueue.New(peer.DB.RetryQueue(), queue.RetryQueueName, config.Repairer.Retry))
+
 		// TODO: simplify argument list somehow
 		peer.Repair.Checker = checker.NewChecker(
 			peer.Metainfo.Service,
-			peer.DB.StatDB(), peer.DB.RepairQueue(),
-			peer.Overlay.Endpoint, peer.DB.Irreparable(),
-			0, peer.Log.Named("checker"),
-			config.Checker.Interval)
+			peer.DB.StatDB(), repairQueue,
+			peer.Overlay.Endpoint, peer.Overlay.Vetting, peer.DB.Irreparable(),
+			lost, 0, peer.Log.Named("checker"),
+			config.Checker.Interval,
+			peer.Watchdog.Loop("checker", config.Checker.Interval))
 
 		// TODO: close segment repairer, currently this leaks connections
-		segmentRepairer, err := config.Repairer.GetSegmentRepairer(context.TODO(), peer.Identity)
+		segmentRepairer, err := config.Repairer.GetSegmentRepairer(context.TODO(), peer.Identity, peer.Contacts.Client("repair"))
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+
+		budget, err := repairer.NewBudget(config.Repairer.Budget)
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
 		}
 
-		peer.Repair.Repairer = repairer.NewService(peer.DB.RepairQueue(), segmentRepairer, config.Repairer.Interval, config.Repairer.MaxRepair)
+		progress := peer.Watchdog.Queue("repair", config.Repairer.Interval)
+		peer.Repair.Repairer = repairer.NewService(repairQueue, segmentRepairer, config.Repairer.Interval, config.Repairer.MaxRepair, budget, progress)
+
+		peer.Repair.Health = checker.NewHealthEndpoint(peer.Log.Named("checker:health"), lost)
+		pb.RegisterPieceHealthServer(peer.Public.Server.GRPC(), peer.Repair.Health)
+
+		peer.Repair.Rebalancer = rebalancer.NewRebalancer(peer.Log.Named("rebalancer"),
+			peer.Metainfo.Service, peer.Overlay.Service, peer.Overlay.Vetting, peer.DB.NodeEvents(),
+			config.Rebalancer,
+			peer.Watchdog.Loop("rebalancer", config.Rebalancer.Interval))
+		pb.RegisterRebalancerInspectorServer(peer.Public.Server.GRPC(), rebalancer.NewInspector(peer.Repair.Rebalancer))
 	}
 
 	{ // setup audit
 		// TODO: audit needs many fixes
 	}
 
+	{ // setup garbage collection of the pieces of deleted segments
+		// a disabled collection makes no progress the watchdog could track
+		var loop *watchdog.Loop
+		if config.GarbageCollection.Interval > 0 {
+			loop = peer.Watchdog.Loop("gc", config.GarbageCollection.Interval)
+		}
+		peer.GarbageCollection = gc.NewService(peer.Log.Named("gc"),
+			peer.Metainfo.Service, peer.Overlay.Service, peer.Contacts.Client("gc"),
+			config.GarbageCollection, loop)
+	}
+
+	if config.HealthAddress != "" { // setup health endpoint
+		peer.Health.Listener, err = net.Listen("tcp", config.HealthAddress)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle("/health", overlay.HealthHandler(peer.Overlay.Service, peer.Watchdog.Check))
+		mux.Handle("/health/watchdog", peer.Watchdog)
+		peer.Health.Server = &http.Server{Handler: mux}
+	}
+
+	if config.Overlay.Network.Address != "" { // setup public stats endpoint
+		peer.Stats.Listener, err = net.Listen("tcp", config.Overlay.Network.Address)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle("/stats/network", peer.Overlay.Network)
+		peer.Stats.Server = &http.Server{Handler: mux}
+	}
+
 	return peer, nil
 }
 
@@ -284,20 +544,92 @@ func (peer *Peer) Run(ctx context.Context) error {
 		return ignoreCancel(peer.Kademlia.Service.Bootstrap(ctx))
 	})
 	group.Go(func() error {
-		return ignoreCancel(peer.Kademlia.Service.RunRefresh(ctx))
+		return ignoreCancel(peer.Kademlia.Service.VerifyRestored(ctx))
+	})
+	group.Go(func() error {
+		return ignoreCancel(peer.Kademlia.Refresher.Run(ctx))
+	})
+	group.Go(func() error {
+		return ignoreCancel(peer.Overlay.NodeLists.Run(ctx))
+	})
+	group.Go(func() error {
+		return ignoreCancel(peer.Overlay.Vetting.Run(ctx))
+	})
+	group.Go(func() error {
+		return ignoreCancel(peer.Overlay.Network.Run(ctx))
+	})
+	group.Go(func() error {
+		return ignoreCancel(peer.Watchdog.Run(ctx))
 	})
 	group.Go(func() error {
 		return ignoreCancel(peer.Discovery.Service.Run(ctx))
 	})
 	group.Go(func() error {
-		return ignoreCancel(peer.Repair.Checker.Run(ctx))
+		return ignoreCancel(peer.Discovery.Partition.Run(ctx))
+	})
+	group.Go(func() error {
+		return ignoreCancel(peer.Leases.Run(ctx, "purger", peer.Metainfo.Purger.Run))
+	})
+	group.Go(func() error {
+		return ignoreCancel(peer.Leases.Run(ctx, "reaper", peer.Metainfo.Reaper.Run))
+	})
+	if peer.Metainfo.Compactor != nil {
+		group.Go(func() error {
+			return ignoreCancel(peer.Leases.Run(ctx, "auditlog", peer.Metainfo.Compactor.Run))
+		})
+	}
+	if peer.Public.Pruner != nil {
+		group.Go(func() error {
+			return ignoreCancel(peer.Leases.Run(ctx, "requestlog", peer.Public.Pruner.Run))
+		})
+	}
+	group.Go(func() error {
+		return ignoreCancel(peer.Leases.Run(ctx, "checker", peer.Repair.Checker.Run))
 	})
 	group.Go(func() error {
 		return ignoreCancel(peer.Repair.Repairer.Run(ctx))
 	})
+	group.Go(func() error {
+		return ignoreCancel(peer.Leases.Run(ctx, "rebalancer", peer.Repair.Rebalancer.Run))
+	})
+	group.Go(func() error {
+		return ignoreCancel(peer.Leases.Run(ctx, "gc", peer.GarbageCollection.Run))
+	})
+	if peer.Relay != nil {
+		group.Go(func() error {
+			return ignoreCancel(peer.Relay.Run(ctx))
+		})
+	}
 	group.Go(func() error {
 		return ignoreCancel(peer.Public.Server.Run(ctx))
 	})
+	if peer.Health.Server != nil {
+		group.Go(func() error {
+			err := peer.Health.Server.Serve(peer.Health.Listener)
+			if err == http.ErrServerClosed {
+				return nil
+			}
+			return err
+		})
+		group.Go(func() error {
+			<-ctx.Done()
+			return peer.Health.Server.Close()
+		})
+	}
+
+	if peer.Stats.Server != nil {
+		group.Go(func() error {
+			err := peer.Stats.Server.Serve(peer.Stats.Listener)
+			if err == http.ErrServerClosed {
+				return nil
+			}
+			return err
+		})
+		group.Go(func() error {
+			<-ctx.Done()
+			return peer.Stats.Server.Close()
+		})
+	}
 
 	return group.Wait()
 }
@@ -326,6 +658,9 @@ func (peer *Peer) Close() error {
 	if peer.Metainfo.Database != nil {
 		errlist.Add(peer.Metainfo.Database.Close())
 	}
+	if peer.Metainfo.AuditLog != nil {
+		errlist.Add(peer.Metainfo.AuditLog.Close())
+	}
 
 	if peer.Discovery.Service != nil {
 		errlist.Add(peer.Discovery.Service.Close())
@@ -338,6 +673,10 @@ func (peer *Peer) Close() error {
 		errlist.Add(peer.Overlay.Service.Close())
 	}
 
+	if peer.Relay != nil {
+		errlist.Add(peer.Relay.Close())
+	}
+
 	// TODO: add kademlia.Endpoint for consistency
 	if peer.Kademlia.Service != nil {
 		errlist.Add(peer.Kademlia.Service.Close())
@@ -347,6 +686,16 @@ func (peer *Peer) Close() error {
 	}
 
 	// close servers
+	if peer.Health.Server != nil {
+		errlist.Add(peer.Health.Server.Close())
+	} else if peer.Health.Listener != nil {
+		errlist.Add(peer.Health.Listener.Close())
+	}
+	if peer.Stats.Server != nil {
+		errlist.Add(peer.Stats.Server.Close())
+	} else if peer.Stats.Listener != nil {
+		errlist.Add(peer.Stats.Listener.Close())
+	}
 	if peer.Public.Server != nil {
 		errlist.Add(peer.Public.Server.Close())
 	} else {
@@ -355,6 +704,9 @@ func (peer *Peer) Close() error {
 			errlist.Add(peer.Public.Listener.Close())
 		}
 	}
+	if peer.Public.RequestLog != nil {
+		errlist.Add(peer.Public.RequestLog.Close())
+	}
 	return errlist.Err()
 }
 
@@ -366,3 +718,19 @@ func (peer *Peer) Local() pb.Node { return peer.Kademlia.RoutingTable.Local() }
 
 // Addr returns the public address.
 func (peer *Peer) Addr() string { return peer.Public.Server.Addr().String() }
+
+// HealthAddr returns the address of the health endpoint, empty when it is disabled.
+func (peer *Peer) HealthAddr() string {
+	if peer.Health.Listener == nil {
+		return ""
+	}
+	return peer.Health.Listener.Addr().String()
+}
+
+// StatsAddr returns the address of the public stats endpoint, empty when it is disabled.
+func (peer *Peer) StatsAddr() string {
+	if peer.Stats.Listener == nil {
+		return ""
+	}
+	return peer.Stats.Listener.Addr().String()
+}
@@ -5,8 +5,11 @@ package satellitedb
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
+	"github.com/skyrings/skyring-common/tools/uuid"
+
 	"storj.io/storj/pkg/accounting"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/utils"
@@ -151,6 +154,75 @@ func (db *accountingDB) GetRawSince(ctx context.Context, latestRollup time.Time)
 	return out, Error.Wrap(err)
 }
 
+// CountRawBefore counts the raw tallies with an interval ending before the time
+func (db *accountingDB) CountRawBefore(ctx context.Context, before time.Time) (count int64, err error) {
+	err = db.db.QueryRowContext(ctx, db.db.Rebind(
+		`SELECT COUNT(*) FROM accounting_raws WHERE interval_end_time < ?`), before.UTC()).Scan(&count)
+	return count, Error.Wrap(err)
+}
+
+// DeleteRawBefore deletes the raw tallies with an interval ending before the time
+func (db *accountingDB) DeleteRawBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := db.db.ExecContext(ctx, db.db.Rebind(
+		`DELETE FROM accounting_raws WHERE interval_end_time < ?`), before.UTC())
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+	count, err := result.RowsAffected()
+	return count, Error.Wrap(err)
+}
+
+// GetRawBefore retrieves the raw tallies with an interval ending before the time
+func (db *accountingDB) GetRawBefore(ctx context.Context, before time.Time) (_ []*accounting.Raw, err error) {
+	rows, err := db.db.QueryContext(ctx, db.db.Rebind(
+		`SELECT id, node_id, interval_end_time, data_total, data_type, created_at
+		FROM accounting_raws WHERE interval_end_time < ? ORDER BY id`), before.UTC())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	var out []*accounting.Raw
+	for rows.Next() {
+		var raw accounting.Raw
+		var nodeID []byte
+		err := rows.Scan(&raw.ID, &nodeID, &raw.IntervalEndTime, &raw.DataTotal, &raw.DataType, &raw.CreatedAt)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		raw.NodeID, err = storj.NodeIDFromBytes(nodeID)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		out = append(out, &raw)
+	}
+	return out, Error.Wrap(rows.Err())
+}
+
+// ImportRaws inserts archived raw tallies, keeping their creation time
+func (db *accountingDB) ImportRaws(ctx context.Context, raws []*accounting.Raw) (err error) {
+	tx, err := db.db.Open(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+		} else {
+			err = utils.CombineErrors(err, tx.Rollback())
+		}
+	}()
+	insert := db.db.Rebind(`INSERT INTO accounting_raws
+		(node_id, interval_end_time, data_total, data_type, created_at) VALUES (?, ?, ?, ?, ?)`)
+	for _, raw := range raws {
+		_, err = tx.Tx.ExecContext(ctx, insert, raw.NodeID.Bytes(), raw.IntervalEndTime.UTC(), raw.DataTotal, raw.DataType, raw.CreatedAt.UTC())
+		if err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	return nil
+}
+
 // SaveRollup records raw tallies of at rest data to the database
 func (db *accountingDB) SaveRollup(ctx context.Context, latestRollup time.Time, stats accounting.RollupStats) error {
 	if len(stats) == 0 {
@@ -184,10 +256,90 @@ func (db *accountingDB) SaveRollup(ctx context.Context, latestRollup time.Time,
 		}
 	}
 	update := dbx.AccountingTimestamps_Update_Fields{Value: dbx.AccountingTimestamps_Value(latestRollup)}
-	_, err = tx.Update_AccountingTimestamps_By_Name(ctx, dbx.AccountingTimestamps_Name(accounting.LastRollup), update)
+	updated, err := tx.Update_AccountingTimestamps_By_Name(ctx, dbx.AccountingTimestamps_Name(accounting.LastRollup), update)
+	if err == nil && updated == nil {
+		// the first rollup
+		_, err = tx.Create_AccountingTimestamps(ctx, dbx.AccountingTimestamps_Name(accounting.LastRollup), dbx.AccountingTimestamps_Value(latestRollup))
+	}
 	return Error.Wrap(err)
 }
 
+// GetRollupsBefore retrieves the rollups starting before the time
+func (db *accountingDB) GetRollupsBefore(ctx context.Context, before time.Time) (_ []*accounting.Rollup, err error) {
+	rows, err := db.db.QueryContext(ctx, db.db.Rebind(
+		`SELECT id, node_id, start_time, put_total, get_total, get_audit_total, get_repair_total, put_repair_total, at_rest_total
+		FROM accounting_rollups WHERE start_time < ? ORDER BY id`), before.UTC())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	var out []*accounting.Rollup
+	for rows.Next() {
+		var rollup accounting.Rollup
+		var nodeID []byte
+		err := rows.Scan(&rollup.ID, &nodeID, &rollup.StartTime, &rollup.PutTotal, &rollup.GetTotal,
+			&rollup.GetAuditTotal, &rollup.GetRepairTotal, &rollup.PutRepairTotal, &rollup.AtRestTotal)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		rollup.NodeID, err = storj.NodeIDFromBytes(nodeID)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		out = append(out, &rollup)
+	}
+	return out, Error.Wrap(rows.Err())
+}
+
+// CountRollupsBefore counts the rollups starting before the time
+func (db *accountingDB) CountRollupsBefore(ctx context.Context, before time.Time) (count int64, err error) {
+	err = db.db.QueryRowContext(ctx, db.db.Rebind(
+		`SELECT COUNT(*) FROM accounting_rollups WHERE start_time < ?`), before.UTC()).Scan(&count)
+	return count, Error.Wrap(err)
+}
+
+// DeleteRollupsBefore deletes the rollups starting before the time
+func (db *accountingDB) DeleteRollupsBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := db.db.ExecContext(ctx, db.db.Rebind(
+		`DELETE FROM accounting_rollups WHERE start_time < ?`), before.UTC())
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+	count, err := result.RowsAffected()
+	return count, Error.Wrap(err)
+}
+
+// ImportRollups inserts archived rollups
+func (db *accountingDB) ImportRollups(ctx context.Context, rollups []*accounting.Rollup) (err error) {
+	tx, err := db.db.Open(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+		} else {
+			err = utils.CombineErrors(err, tx.Rollback())
+		}
+	}()
+	for _, ar := range rollups {
+		nID := dbx.AccountingRollup_NodeId(ar.NodeID.Bytes())
+		start := dbx.AccountingRollup_StartTime(ar.StartTime)
+		put := dbx.AccountingRollup_PutTotal(ar.PutTotal)
+		get := dbx.AccountingRollup_GetTotal(ar.GetTotal)
+		audit := dbx.AccountingRollup_GetAuditTotal(ar.GetAuditTotal)
+		getRepair := dbx.AccountingRollup_GetRepairTotal(ar.GetRepairTotal)
+		putRepair := dbx.AccountingRollup_PutRepairTotal(ar.PutRepairTotal)
+		atRest := dbx.AccountingRollup_AtRestTotal(ar.AtRestTotal)
+		_, err = tx.Create_AccountingRollup(ctx, nID, start, put, get, audit, getRepair, putRepair, atRest)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	return nil
+}
+
 // QueryPaymentInfo queries StatDB, Accounting Rollup on nodeID
 func (db *accountingDB) QueryPaymentInfo(ctx context.Context, start time.Time, end time.Time) ([]*accounting.CSVRow, error) {
 	s := dbx.AccountingRollup_StartTime(start)
@@ -245,3 +397,48 @@ func (db *accountingDB) TestPayments(ctx context.Context) error {
 
 	return nil
 }
+
+// GetProjectUsage returns the storage used by the project, zero when it didn't store anything yet
+func (db *accountingDB) GetProjectUsage(ctx context.Context, projectID uuid.UUID) (usage accounting.ProjectUsage, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = db.db.QueryRowContext(ctx, db.db.Rebind(
+		`SELECT stored_bytes, objects, updated_at FROM project_usages WHERE project_id = ?`),
+		projectID[:]).Scan(&usage.StoredBytes, &usage.Objects, &usage.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return accounting.ProjectUsage{}, nil
+	}
+	return usage, Error.Wrap(err)
+}
+
+// AddProjectUsage adds to the storage used by the project, negative values free storage
+func (db *accountingDB) AddProjectUsage(ctx context.Context, projectID uuid.UUID, storedBytes, objects int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// the usage is inserted when the update misses it, the update is retried
+	// when another insert won
+	for attempt := 0; ; attempt++ {
+		now := time.Now().UTC()
+		result, err := db.db.ExecContext(ctx, db.db.Rebind(
+			`UPDATE project_usages SET stored_bytes = stored_bytes + ?, objects = objects + ?, updated_at = ?
+			WHERE project_id = ?`),
+			storedBytes, objects, now, projectID[:])
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		updated, err := result.RowsAffected()
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		if updated > 0 {
+			return nil
+		}
+
+		_, err = db.db.ExecContext(ctx, db.db.Rebind(
+			`INSERT INTO project_usages (project_id, stored_bytes, objects, updated_at) VALUES (?, ?, ?, ?)`),
+			projectID[:], storedBytes, objects, now)
+		if err == nil || attempt > 0 {
+			return Error.Wrap(err)
+		}
+	}
+}
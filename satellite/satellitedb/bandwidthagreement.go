@@ -57,6 +57,21 @@ func (b *bandwidthagreement) GetAgreementsSince(ctx context.Context, since time.
 	return agreements, nil
 }
 
+func (b *bandwidthagreement) CountAgreementsBefore(ctx context.Context, before time.Time) (count int64, err error) {
+	err = b.db.QueryRowContext(ctx, b.db.Rebind(
+		`SELECT COUNT(*) FROM bwagreements WHERE created_at < ?`), before.UTC()).Scan(&count)
+	return count, err
+}
+
+func (b *bandwidthagreement) DeleteAgreementsBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := b.db.ExecContext(ctx, b.db.Rebind(
+		`DELETE FROM bwagreements WHERE created_at < ?`), before.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 func (b *bandwidthagreement) DeletePaidAndExpired(ctx context.Context) error {
 	// TODO: implement deletion of paid and expired BWAs
 	return Error.New("DeletePaidAndExpired not implemented")
@@ -6,14 +6,16 @@ package satellitedb
 import (
 	"github.com/zeebo/errs"
 
+	"storj.io/storj/internal/dburl"
 	"storj.io/storj/internal/migrate"
 	"storj.io/storj/pkg/accounting"
 	"storj.io/storj/pkg/bwagreement"
 	"storj.io/storj/pkg/datarepair/irreparable"
 	"storj.io/storj/pkg/datarepair/queue"
+	"storj.io/storj/pkg/lease"
 	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/retryqueue"
 	"storj.io/storj/pkg/statdb"
-	"storj.io/storj/pkg/utils"
 	"storj.io/storj/satellite"
 	"storj.io/storj/satellite/console"
 	dbx "storj.io/storj/satellite/satellitedb/dbx"
@@ -33,19 +35,19 @@ type DB struct {
 
 // New creates instance of database (supports: postgres, sqlite3)
 func New(databaseURL string) (satellite.DB, error) {
-	driver, source, err := utils.SplitDBURL(databaseURL)
+	dbURL, err := dburl.Parse(databaseURL)
 	if err != nil {
-		return nil, err
+		return nil, Error.Wrap(err)
 	}
 
-	db, err := dbx.Open(driver, source)
+	db, err := dbx.Open(dbURL.Driver, dbURL.Source)
 	if err != nil {
-		return nil, Error.New("failed opening database %q, %q: %v",
-			driver, source, err)
+		return nil, Error.New("failed opening database %s: %v", dbURL, err)
 	}
+	dbURL.ConfigureSQL(db.DB)
 
 	core := &DB{db: db}
-	if driver == "sqlite3" {
+	if dbURL.Driver == "sqlite3" {
 		return newLocked(core), nil
 	}
 	return core, nil
@@ -76,9 +78,24 @@ func (db *DB) OverlayCache() overlay.DB {
 	return &overlaycache{db: db.db}
 }
 
+// NodeEvents is a getter for node events repository
+func (db *DB) NodeEvents() overlay.EventsDB {
+	return &nodeEvents{db: db.db}
+}
+
+// Leases is a getter for the leases of singleton jobs repository
+func (db *DB) Leases() lease.DB {
+	return &leases{db: db.db}
+}
+
 // RepairQueue is a getter for RepairQueue repository
 func (db *DB) RepairQueue() queue.RepairQueue {
-	return &repairQueue{db: db.db}
+	return queue.NewRetryQueue(retryqueue.New(db.RetryQueue(), queue.RetryQueueName, retryqueue.DefaultConfig))
+}
+
+// RetryQueue is a getter for retry queues repository
+func (db *DB) RetryQueue() retryqueue.DB {
+	return &retryQueue{db: db.db}
 }
 
 // Accounting returns database for tracking bandwidth agreements over time
@@ -321,6 +321,20 @@ CREATE TABLE irreparabledbs (
 	repair_attempt_count bigint NOT NULL,
 	PRIMARY KEY ( segmentpath )
 );
+CREATE TABLE leases (
+	name text NOT NULL,
+	holder text NOT NULL,
+	expires timestamp with time zone NOT NULL,
+	PRIMARY KEY ( name )
+);
+CREATE TABLE node_events (
+	id bigserial NOT NULL,
+	node_id bytea NOT NULL,
+	event_type integer NOT NULL,
+	data text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE nodes (
 	id bytea NOT NULL,
 	audit_success_count bigint NOT NULL,
@@ -329,6 +343,10 @@ CREATE TABLE nodes (
 	uptime_success_count bigint NOT NULL,
 	total_uptime_count bigint NOT NULL,
 	uptime_ratio double precision NOT NULL,
+	audit_reputation_alpha double precision NOT NULL,
+	audit_reputation_beta double precision NOT NULL,
+	uptime_reputation_alpha double precision NOT NULL,
+	uptime_reputation_beta double precision NOT NULL,
 	created_at timestamp with time zone NOT NULL,
 	updated_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
@@ -340,6 +358,8 @@ CREATE TABLE overlay_cache_nodes (
 	protocol integer NOT NULL,
 	operator_email text NOT NULL,
 	operator_wallet text NOT NULL,
+	operator_wallet_features text NOT NULL,
+	tags bytea NOT NULL,
 	free_bandwidth bigint NOT NULL,
 	free_disk bigint NOT NULL,
 	latency_90 bigint NOT NULL,
@@ -349,6 +369,10 @@ CREATE TABLE overlay_cache_nodes (
 	audit_success_count bigint NOT NULL,
 	uptime_count bigint NOT NULL,
 	uptime_success_count bigint NOT NULL,
+	audit_reputation double precision NOT NULL,
+	uptime_reputation double precision NOT NULL,
+	last_contact_success timestamp with time zone NOT NULL,
+	last_contact_failure timestamp with time zone NOT NULL,
 	PRIMARY KEY ( node_id ),
 	UNIQUE ( node_id )
 );
@@ -360,6 +384,24 @@ CREATE TABLE projects (
 	created_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
 );
+CREATE TABLE project_usages (
+	project_id bytea NOT NULL,
+	stored_bytes bigint NOT NULL,
+	objects bigint NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( project_id )
+);
+CREATE TABLE retry_items (
+	id bigserial NOT NULL,
+	queue text NOT NULL,
+	payload bytea NOT NULL,
+	attempts integer NOT NULL,
+	next_attempt timestamp with time zone NOT NULL,
+	last_error text NOT NULL,
+	dead boolean NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE users (
 	id bytea NOT NULL,
 	first_name text NOT NULL,
@@ -503,6 +545,20 @@ CREATE TABLE irreparabledbs (
 	repair_attempt_count INTEGER NOT NULL,
 	PRIMARY KEY ( segmentpath )
 );
+CREATE TABLE leases (
+	name TEXT NOT NULL,
+	holder TEXT NOT NULL,
+	expires TIMESTAMP NOT NULL,
+	PRIMARY KEY ( name )
+);
+CREATE TABLE node_events (
+	id INTEGER NOT NULL,
+	node_id BLOB NOT NULL,
+	event_type INTEGER NOT NULL,
+	data TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE nodes (
 	id BLOB NOT NULL,
 	audit_success_count INTEGER NOT NULL,
@@ -511,6 +567,10 @@ CREATE TABLE nodes (
 	uptime_success_count INTEGER NOT NULL,
 	total_uptime_count INTEGER NOT NULL,
 	uptime_ratio REAL NOT NULL,
+	audit_reputation_alpha REAL NOT NULL,
+	audit_reputation_beta REAL NOT NULL,
+	uptime_reputation_alpha REAL NOT NULL,
+	uptime_reputation_beta REAL NOT NULL,
 	created_at TIMESTAMP NOT NULL,
 	updated_at TIMESTAMP NOT NULL,
 	PRIMARY KEY ( id )
@@ -522,6 +582,8 @@ CREATE TABLE overlay_cache_nodes (
 	protocol INTEGER NOT NULL,
 	operator_email TEXT NOT NULL,
 	operator_wallet TEXT NOT NULL,
+	operator_wallet_features TEXT NOT NULL,
+	tags BLOB NOT NULL,
 	free_bandwidth INTEGER NOT NULL,
 	free_disk INTEGER NOT NULL,
 	latency_90 INTEGER NOT NULL,
@@ -531,6 +593,10 @@ CREATE TABLE overlay_cache_nodes (
 	audit_success_count INTEGER NOT NULL,
 	uptime_count INTEGER NOT NULL,
 	uptime_success_count INTEGER NOT NULL,
+	audit_reputation REAL NOT NULL,
+	uptime_reputation REAL NOT NULL,
+	last_contact_success TIMESTAMP NOT NULL,
+	last_contact_failure TIMESTAMP NOT NULL,
 	PRIMARY KEY ( node_id ),
 	UNIQUE ( node_id )
 );
@@ -542,6 +608,24 @@ CREATE TABLE projects (
 	created_at TIMESTAMP NOT NULL,
 	PRIMARY KEY ( id )
 );
+CREATE TABLE project_usages (
+	project_id BLOB NOT NULL,
+	stored_bytes INTEGER NOT NULL,
+	objects INTEGER NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( project_id )
+);
+CREATE TABLE retry_items (
+	id INTEGER NOT NULL,
+	queue TEXT NOT NULL,
+	payload BLOB NOT NULL,
+	attempts INTEGER NOT NULL,
+	next_attempt TIMESTAMP NOT NULL,
+	last_error TEXT NOT NULL,
+	dead INTEGER NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE users (
 	id BLOB NOT NULL,
 	first_name TEXT NOT NULL,
@@ -1269,27 +1353,105 @@ func (f Irreparabledb_RepairAttemptCount_Field) value() interface{} {
 
 func (Irreparabledb_RepairAttemptCount_Field) _Column() string { return "repair_attempt_count" }
 
+type Lease struct {
+	Name    string
+	Holder  string
+	Expires time.Time
+}
+
+func (Lease) _Table() string { return "leases" }
+
+type Lease_Update_Fields struct {
+	Holder  Lease_Holder_Field
+	Expires Lease_Expires_Field
+}
+
+type Lease_Name_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func Lease_Name(v string) Lease_Name_Field {
+	return Lease_Name_Field{_set: true, _value: v}
+}
+
+func (f Lease_Name_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Lease_Name_Field) _Column() string { return "name" }
+
+type Lease_Holder_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func Lease_Holder(v string) Lease_Holder_Field {
+	return Lease_Holder_Field{_set: true, _value: v}
+}
+
+func (f Lease_Holder_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Lease_Holder_Field) _Column() string { return "holder" }
+
+type Lease_Expires_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func Lease_Expires(v time.Time) Lease_Expires_Field {
+	return Lease_Expires_Field{_set: true, _value: v}
+}
+
+func (f Lease_Expires_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Lease_Expires_Field) _Column() string { return "expires" }
+
 type Node struct {
-	Id                 []byte
-	AuditSuccessCount  int64
-	TotalAuditCount    int64
-	AuditSuccessRatio  float64
-	UptimeSuccessCount int64
-	TotalUptimeCount   int64
-	UptimeRatio        float64
-	CreatedAt          time.Time
-	UpdatedAt          time.Time
+	Id                    []byte
+	AuditSuccessCount     int64
+	TotalAuditCount       int64
+	AuditSuccessRatio     float64
+	UptimeSuccessCount    int64
+	TotalUptimeCount      int64
+	UptimeRatio           float64
+	AuditReputationAlpha  float64
+	AuditReputationBeta   float64
+	UptimeReputationAlpha float64
+	UptimeReputationBeta  float64
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
 }
 
 func (Node) _Table() string { return "nodes" }
 
 type Node_Update_Fields struct {
-	AuditSuccessCount  Node_AuditSuccessCount_Field
-	TotalAuditCount    Node_TotalAuditCount_Field
-	AuditSuccessRatio  Node_AuditSuccessRatio_Field
-	UptimeSuccessCount Node_UptimeSuccessCount_Field
-	TotalUptimeCount   Node_TotalUptimeCount_Field
-	UptimeRatio        Node_UptimeRatio_Field
+	AuditSuccessCount     Node_AuditSuccessCount_Field
+	TotalAuditCount       Node_TotalAuditCount_Field
+	AuditSuccessRatio     Node_AuditSuccessRatio_Field
+	UptimeSuccessCount    Node_UptimeSuccessCount_Field
+	TotalUptimeCount      Node_TotalUptimeCount_Field
+	UptimeRatio           Node_UptimeRatio_Field
+	AuditReputationAlpha  Node_AuditReputationAlpha_Field
+	AuditReputationBeta   Node_AuditReputationBeta_Field
+	UptimeReputationAlpha Node_UptimeReputationAlpha_Field
+	UptimeReputationBeta  Node_UptimeReputationBeta_Field
 }
 
 type Node_Id_Field struct {
@@ -1425,6 +1587,82 @@ func (f Node_UptimeRatio_Field) value() interface{} {
 
 func (Node_UptimeRatio_Field) _Column() string { return "uptime_ratio" }
 
+type Node_AuditReputationAlpha_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func Node_AuditReputationAlpha(v float64) Node_AuditReputationAlpha_Field {
+	return Node_AuditReputationAlpha_Field{_set: true, _value: v}
+}
+
+func (f Node_AuditReputationAlpha_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_AuditReputationAlpha_Field) _Column() string { return "audit_reputation_alpha" }
+
+type Node_AuditReputationBeta_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func Node_AuditReputationBeta(v float64) Node_AuditReputationBeta_Field {
+	return Node_AuditReputationBeta_Field{_set: true, _value: v}
+}
+
+func (f Node_AuditReputationBeta_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_AuditReputationBeta_Field) _Column() string { return "audit_reputation_beta" }
+
+type Node_UptimeReputationAlpha_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func Node_UptimeReputationAlpha(v float64) Node_UptimeReputationAlpha_Field {
+	return Node_UptimeReputationAlpha_Field{_set: true, _value: v}
+}
+
+func (f Node_UptimeReputationAlpha_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_UptimeReputationAlpha_Field) _Column() string { return "uptime_reputation_alpha" }
+
+type Node_UptimeReputationBeta_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func Node_UptimeReputationBeta(v float64) Node_UptimeReputationBeta_Field {
+	return Node_UptimeReputationBeta_Field{_set: true, _value: v}
+}
+
+func (f Node_UptimeReputationBeta_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_UptimeReputationBeta_Field) _Column() string { return "uptime_reputation_beta" }
+
 type Node_CreatedAt_Field struct {
 	_set   bool
 	_null  bool
@@ -1463,40 +1701,160 @@ func (f Node_UpdatedAt_Field) value() interface{} {
 
 func (Node_UpdatedAt_Field) _Column() string { return "updated_at" }
 
+type NodeEvent struct {
+	Id        int64
+	NodeId    []byte
+	EventType int
+	Data      string
+	CreatedAt time.Time
+}
+
+func (NodeEvent) _Table() string { return "node_events" }
+
+type NodeEvent_Update_Fields struct {
+}
+
+type NodeEvent_Id_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func NodeEvent_Id(v int64) NodeEvent_Id_Field {
+	return NodeEvent_Id_Field{_set: true, _value: v}
+}
+
+func (f NodeEvent_Id_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeEvent_Id_Field) _Column() string { return "id" }
+
+type NodeEvent_NodeId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func NodeEvent_NodeId(v []byte) NodeEvent_NodeId_Field {
+	return NodeEvent_NodeId_Field{_set: true, _value: v}
+}
+
+func (f NodeEvent_NodeId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeEvent_NodeId_Field) _Column() string { return "node_id" }
+
+type NodeEvent_EventType_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func NodeEvent_EventType(v int) NodeEvent_EventType_Field {
+	return NodeEvent_EventType_Field{_set: true, _value: v}
+}
+
+func (f NodeEvent_EventType_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeEvent_EventType_Field) _Column() string { return "event_type" }
+
+type NodeEvent_Data_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func NodeEvent_Data(v string) NodeEvent_Data_Field {
+	return NodeEvent_Data_Field{_set: true, _value: v}
+}
+
+func (f NodeEvent_Data_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeEvent_Data_Field) _Column() string { return "data" }
+
+type NodeEvent_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func NodeEvent_CreatedAt(v time.Time) NodeEvent_CreatedAt_Field {
+	return NodeEvent_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f NodeEvent_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeEvent_CreatedAt_Field) _Column() string { return "created_at" }
+
 type OverlayCacheNode struct {
-	NodeId             []byte
-	NodeType           int
-	Address            string
-	Protocol           int
-	OperatorEmail      string
-	OperatorWallet     string
-	FreeBandwidth      int64
-	FreeDisk           int64
-	Latency90          int64
-	AuditSuccessRatio  float64
-	AuditUptimeRatio   float64
-	AuditCount         int64
-	AuditSuccessCount  int64
-	UptimeCount        int64
-	UptimeSuccessCount int64
+	NodeId                 []byte
+	NodeType               int
+	Address                string
+	Protocol               int
+	OperatorEmail          string
+	OperatorWallet         string
+	OperatorWalletFeatures string
+	Tags                   []byte
+	FreeBandwidth          int64
+	FreeDisk               int64
+	Latency90              int64
+	AuditSuccessRatio      float64
+	AuditUptimeRatio       float64
+	AuditCount             int64
+	AuditSuccessCount      int64
+	UptimeCount            int64
+	UptimeSuccessCount     int64
+	AuditReputation        float64
+	UptimeReputation       float64
+	LastContactSuccess     time.Time
+	LastContactFailure     time.Time
 }
 
 func (OverlayCacheNode) _Table() string { return "overlay_cache_nodes" }
 
 type OverlayCacheNode_Update_Fields struct {
-	Address            OverlayCacheNode_Address_Field
-	Protocol           OverlayCacheNode_Protocol_Field
-	OperatorEmail      OverlayCacheNode_OperatorEmail_Field
-	OperatorWallet     OverlayCacheNode_OperatorWallet_Field
-	FreeBandwidth      OverlayCacheNode_FreeBandwidth_Field
-	FreeDisk           OverlayCacheNode_FreeDisk_Field
-	Latency90          OverlayCacheNode_Latency90_Field
-	AuditSuccessRatio  OverlayCacheNode_AuditSuccessRatio_Field
-	AuditUptimeRatio   OverlayCacheNode_AuditUptimeRatio_Field
-	AuditCount         OverlayCacheNode_AuditCount_Field
-	AuditSuccessCount  OverlayCacheNode_AuditSuccessCount_Field
-	UptimeCount        OverlayCacheNode_UptimeCount_Field
-	UptimeSuccessCount OverlayCacheNode_UptimeSuccessCount_Field
+	Address                OverlayCacheNode_Address_Field
+	Protocol               OverlayCacheNode_Protocol_Field
+	OperatorEmail          OverlayCacheNode_OperatorEmail_Field
+	OperatorWallet         OverlayCacheNode_OperatorWallet_Field
+	OperatorWalletFeatures OverlayCacheNode_OperatorWalletFeatures_Field
+	Tags                   OverlayCacheNode_Tags_Field
+	FreeBandwidth          OverlayCacheNode_FreeBandwidth_Field
+	FreeDisk               OverlayCacheNode_FreeDisk_Field
+	Latency90              OverlayCacheNode_Latency90_Field
+	AuditSuccessRatio      OverlayCacheNode_AuditSuccessRatio_Field
+	AuditUptimeRatio       OverlayCacheNode_AuditUptimeRatio_Field
+	AuditCount             OverlayCacheNode_AuditCount_Field
+	AuditSuccessCount      OverlayCacheNode_AuditSuccessCount_Field
+	UptimeCount            OverlayCacheNode_UptimeCount_Field
+	UptimeSuccessCount     OverlayCacheNode_UptimeSuccessCount_Field
+	AuditReputation        OverlayCacheNode_AuditReputation_Field
+	UptimeReputation       OverlayCacheNode_UptimeReputation_Field
+	LastContactSuccess     OverlayCacheNode_LastContactSuccess_Field
+	LastContactFailure     OverlayCacheNode_LastContactFailure_Field
 }
 
 type OverlayCacheNode_NodeId_Field struct {
@@ -1613,6 +1971,46 @@ func (f OverlayCacheNode_OperatorWallet_Field) value() interface{} {
 
 func (OverlayCacheNode_OperatorWallet_Field) _Column() string { return "operator_wallet" }
 
+type OverlayCacheNode_OperatorWalletFeatures_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func OverlayCacheNode_OperatorWalletFeatures(v string) OverlayCacheNode_OperatorWalletFeatures_Field {
+	return OverlayCacheNode_OperatorWalletFeatures_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_OperatorWalletFeatures_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_OperatorWalletFeatures_Field) _Column() string {
+	return "operator_wallet_features"
+}
+
+type OverlayCacheNode_Tags_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func OverlayCacheNode_Tags(v []byte) OverlayCacheNode_Tags_Field {
+	return OverlayCacheNode_Tags_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_Tags_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_Tags_Field) _Column() string { return "tags" }
+
 type OverlayCacheNode_FreeBandwidth_Field struct {
 	_set   bool
 	_null  bool
@@ -1657,242 +2055,580 @@ type OverlayCacheNode_Latency90_Field struct {
 	_value int64
 }
 
-func OverlayCacheNode_Latency90(v int64) OverlayCacheNode_Latency90_Field {
-	return OverlayCacheNode_Latency90_Field{_set: true, _value: v}
+func OverlayCacheNode_Latency90(v int64) OverlayCacheNode_Latency90_Field {
+	return OverlayCacheNode_Latency90_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_Latency90_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_Latency90_Field) _Column() string { return "latency_90" }
+
+type OverlayCacheNode_AuditSuccessRatio_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func OverlayCacheNode_AuditSuccessRatio(v float64) OverlayCacheNode_AuditSuccessRatio_Field {
+	return OverlayCacheNode_AuditSuccessRatio_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_AuditSuccessRatio_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_AuditSuccessRatio_Field) _Column() string { return "audit_success_ratio" }
+
+type OverlayCacheNode_AuditUptimeRatio_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func OverlayCacheNode_AuditUptimeRatio(v float64) OverlayCacheNode_AuditUptimeRatio_Field {
+	return OverlayCacheNode_AuditUptimeRatio_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_AuditUptimeRatio_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_AuditUptimeRatio_Field) _Column() string { return "audit_uptime_ratio" }
+
+type OverlayCacheNode_AuditCount_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func OverlayCacheNode_AuditCount(v int64) OverlayCacheNode_AuditCount_Field {
+	return OverlayCacheNode_AuditCount_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_AuditCount_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_AuditCount_Field) _Column() string { return "audit_count" }
+
+type OverlayCacheNode_AuditSuccessCount_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func OverlayCacheNode_AuditSuccessCount(v int64) OverlayCacheNode_AuditSuccessCount_Field {
+	return OverlayCacheNode_AuditSuccessCount_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_AuditSuccessCount_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_AuditSuccessCount_Field) _Column() string { return "audit_success_count" }
+
+type OverlayCacheNode_UptimeCount_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func OverlayCacheNode_UptimeCount(v int64) OverlayCacheNode_UptimeCount_Field {
+	return OverlayCacheNode_UptimeCount_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_UptimeCount_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_UptimeCount_Field) _Column() string { return "uptime_count" }
+
+type OverlayCacheNode_UptimeSuccessCount_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func OverlayCacheNode_UptimeSuccessCount(v int64) OverlayCacheNode_UptimeSuccessCount_Field {
+	return OverlayCacheNode_UptimeSuccessCount_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_UptimeSuccessCount_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_UptimeSuccessCount_Field) _Column() string { return "uptime_success_count" }
+
+type OverlayCacheNode_AuditReputation_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func OverlayCacheNode_AuditReputation(v float64) OverlayCacheNode_AuditReputation_Field {
+	return OverlayCacheNode_AuditReputation_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_AuditReputation_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_AuditReputation_Field) _Column() string { return "audit_reputation" }
+
+type OverlayCacheNode_UptimeReputation_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func OverlayCacheNode_UptimeReputation(v float64) OverlayCacheNode_UptimeReputation_Field {
+	return OverlayCacheNode_UptimeReputation_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_UptimeReputation_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_UptimeReputation_Field) _Column() string { return "uptime_reputation" }
+
+type OverlayCacheNode_LastContactSuccess_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func OverlayCacheNode_LastContactSuccess(v time.Time) OverlayCacheNode_LastContactSuccess_Field {
+	return OverlayCacheNode_LastContactSuccess_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_LastContactSuccess_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_LastContactSuccess_Field) _Column() string { return "last_contact_success" }
+
+type OverlayCacheNode_LastContactFailure_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func OverlayCacheNode_LastContactFailure(v time.Time) OverlayCacheNode_LastContactFailure_Field {
+	return OverlayCacheNode_LastContactFailure_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_LastContactFailure_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_LastContactFailure_Field) _Column() string { return "last_contact_failure" }
+
+type Project struct {
+	Id            []byte
+	Name          string
+	Description   string
+	TermsAccepted int
+	CreatedAt     time.Time
+}
+
+func (Project) _Table() string { return "projects" }
+
+type Project_Update_Fields struct {
+	Description   Project_Description_Field
+	TermsAccepted Project_TermsAccepted_Field
+}
+
+type Project_Id_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func Project_Id(v []byte) Project_Id_Field {
+	return Project_Id_Field{_set: true, _value: v}
+}
+
+func (f Project_Id_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Project_Id_Field) _Column() string { return "id" }
+
+type Project_Name_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func Project_Name(v string) Project_Name_Field {
+	return Project_Name_Field{_set: true, _value: v}
+}
+
+func (f Project_Name_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Project_Name_Field) _Column() string { return "name" }
+
+type Project_Description_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func Project_Description(v string) Project_Description_Field {
+	return Project_Description_Field{_set: true, _value: v}
+}
+
+func (f Project_Description_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Project_Description_Field) _Column() string { return "description" }
+
+type Project_TermsAccepted_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func Project_TermsAccepted(v int) Project_TermsAccepted_Field {
+	return Project_TermsAccepted_Field{_set: true, _value: v}
+}
+
+func (f Project_TermsAccepted_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Project_TermsAccepted_Field) _Column() string { return "terms_accepted" }
+
+type Project_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func Project_CreatedAt(v time.Time) Project_CreatedAt_Field {
+	return Project_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f Project_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Project_CreatedAt_Field) _Column() string { return "created_at" }
+
+type ProjectUsage struct {
+	ProjectId   []byte
+	StoredBytes int64
+	Objects     int64
+	UpdatedAt   time.Time
+}
+
+func (ProjectUsage) _Table() string { return "project_usages" }
+
+type ProjectUsage_Update_Fields struct {
+	StoredBytes ProjectUsage_StoredBytes_Field
+	Objects     ProjectUsage_Objects_Field
+}
+
+type ProjectUsage_ProjectId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ProjectUsage_ProjectId(v []byte) ProjectUsage_ProjectId_Field {
+	return ProjectUsage_ProjectId_Field{_set: true, _value: v}
 }
 
-func (f OverlayCacheNode_Latency90_Field) value() interface{} {
+func (f ProjectUsage_ProjectId_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (OverlayCacheNode_Latency90_Field) _Column() string { return "latency_90" }
+func (ProjectUsage_ProjectId_Field) _Column() string { return "project_id" }
 
-type OverlayCacheNode_AuditSuccessRatio_Field struct {
+type ProjectUsage_StoredBytes_Field struct {
 	_set   bool
 	_null  bool
-	_value float64
+	_value int64
 }
 
-func OverlayCacheNode_AuditSuccessRatio(v float64) OverlayCacheNode_AuditSuccessRatio_Field {
-	return OverlayCacheNode_AuditSuccessRatio_Field{_set: true, _value: v}
+func ProjectUsage_StoredBytes(v int64) ProjectUsage_StoredBytes_Field {
+	return ProjectUsage_StoredBytes_Field{_set: true, _value: v}
 }
 
-func (f OverlayCacheNode_AuditSuccessRatio_Field) value() interface{} {
+func (f ProjectUsage_StoredBytes_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (OverlayCacheNode_AuditSuccessRatio_Field) _Column() string { return "audit_success_ratio" }
+func (ProjectUsage_StoredBytes_Field) _Column() string { return "stored_bytes" }
 
-type OverlayCacheNode_AuditUptimeRatio_Field struct {
+type ProjectUsage_Objects_Field struct {
 	_set   bool
 	_null  bool
-	_value float64
+	_value int64
 }
 
-func OverlayCacheNode_AuditUptimeRatio(v float64) OverlayCacheNode_AuditUptimeRatio_Field {
-	return OverlayCacheNode_AuditUptimeRatio_Field{_set: true, _value: v}
+func ProjectUsage_Objects(v int64) ProjectUsage_Objects_Field {
+	return ProjectUsage_Objects_Field{_set: true, _value: v}
 }
 
-func (f OverlayCacheNode_AuditUptimeRatio_Field) value() interface{} {
+func (f ProjectUsage_Objects_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (OverlayCacheNode_AuditUptimeRatio_Field) _Column() string { return "audit_uptime_ratio" }
+func (ProjectUsage_Objects_Field) _Column() string { return "objects" }
 
-type OverlayCacheNode_AuditCount_Field struct {
+type ProjectUsage_UpdatedAt_Field struct {
 	_set   bool
 	_null  bool
-	_value int64
+	_value time.Time
 }
 
-func OverlayCacheNode_AuditCount(v int64) OverlayCacheNode_AuditCount_Field {
-	return OverlayCacheNode_AuditCount_Field{_set: true, _value: v}
+func ProjectUsage_UpdatedAt(v time.Time) ProjectUsage_UpdatedAt_Field {
+	return ProjectUsage_UpdatedAt_Field{_set: true, _value: v}
 }
 
-func (f OverlayCacheNode_AuditCount_Field) value() interface{} {
+func (f ProjectUsage_UpdatedAt_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (OverlayCacheNode_AuditCount_Field) _Column() string { return "audit_count" }
+func (ProjectUsage_UpdatedAt_Field) _Column() string { return "updated_at" }
 
-type OverlayCacheNode_AuditSuccessCount_Field struct {
+type RetryItem struct {
+	Id          int64
+	Queue       string
+	Payload     []byte
+	Attempts    int
+	NextAttempt time.Time
+	LastError   string
+	Dead        bool
+	CreatedAt   time.Time
+}
+
+func (RetryItem) _Table() string { return "retry_items" }
+
+type RetryItem_Update_Fields struct {
+	Attempts    RetryItem_Attempts_Field
+	NextAttempt RetryItem_NextAttempt_Field
+	LastError   RetryItem_LastError_Field
+	Dead        RetryItem_Dead_Field
+}
+
+type RetryItem_Id_Field struct {
 	_set   bool
 	_null  bool
 	_value int64
 }
 
-func OverlayCacheNode_AuditSuccessCount(v int64) OverlayCacheNode_AuditSuccessCount_Field {
-	return OverlayCacheNode_AuditSuccessCount_Field{_set: true, _value: v}
+func RetryItem_Id(v int64) RetryItem_Id_Field {
+	return RetryItem_Id_Field{_set: true, _value: v}
 }
 
-func (f OverlayCacheNode_AuditSuccessCount_Field) value() interface{} {
+func (f RetryItem_Id_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (OverlayCacheNode_AuditSuccessCount_Field) _Column() string { return "audit_success_count" }
+func (RetryItem_Id_Field) _Column() string { return "id" }
 
-type OverlayCacheNode_UptimeCount_Field struct {
+type RetryItem_Queue_Field struct {
 	_set   bool
 	_null  bool
-	_value int64
+	_value string
 }
 
-func OverlayCacheNode_UptimeCount(v int64) OverlayCacheNode_UptimeCount_Field {
-	return OverlayCacheNode_UptimeCount_Field{_set: true, _value: v}
+func RetryItem_Queue(v string) RetryItem_Queue_Field {
+	return RetryItem_Queue_Field{_set: true, _value: v}
 }
 
-func (f OverlayCacheNode_UptimeCount_Field) value() interface{} {
+func (f RetryItem_Queue_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (OverlayCacheNode_UptimeCount_Field) _Column() string { return "uptime_count" }
+func (RetryItem_Queue_Field) _Column() string { return "queue" }
 
-type OverlayCacheNode_UptimeSuccessCount_Field struct {
+type RetryItem_Payload_Field struct {
 	_set   bool
 	_null  bool
-	_value int64
+	_value []byte
 }
 
-func OverlayCacheNode_UptimeSuccessCount(v int64) OverlayCacheNode_UptimeSuccessCount_Field {
-	return OverlayCacheNode_UptimeSuccessCount_Field{_set: true, _value: v}
+func RetryItem_Payload(v []byte) RetryItem_Payload_Field {
+	return RetryItem_Payload_Field{_set: true, _value: v}
 }
 
-func (f OverlayCacheNode_UptimeSuccessCount_Field) value() interface{} {
+func (f RetryItem_Payload_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (OverlayCacheNode_UptimeSuccessCount_Field) _Column() string { return "uptime_success_count" }
-
-type Project struct {
-	Id            []byte
-	Name          string
-	Description   string
-	TermsAccepted int
-	CreatedAt     time.Time
-}
-
-func (Project) _Table() string { return "projects" }
-
-type Project_Update_Fields struct {
-	Description   Project_Description_Field
-	TermsAccepted Project_TermsAccepted_Field
-}
+func (RetryItem_Payload_Field) _Column() string { return "payload" }
 
-type Project_Id_Field struct {
+type RetryItem_Attempts_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value int
 }
 
-func Project_Id(v []byte) Project_Id_Field {
-	return Project_Id_Field{_set: true, _value: v}
+func RetryItem_Attempts(v int) RetryItem_Attempts_Field {
+	return RetryItem_Attempts_Field{_set: true, _value: v}
 }
 
-func (f Project_Id_Field) value() interface{} {
+func (f RetryItem_Attempts_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Project_Id_Field) _Column() string { return "id" }
+func (RetryItem_Attempts_Field) _Column() string { return "attempts" }
 
-type Project_Name_Field struct {
+type RetryItem_NextAttempt_Field struct {
 	_set   bool
 	_null  bool
-	_value string
+	_value time.Time
 }
 
-func Project_Name(v string) Project_Name_Field {
-	return Project_Name_Field{_set: true, _value: v}
+func RetryItem_NextAttempt(v time.Time) RetryItem_NextAttempt_Field {
+	return RetryItem_NextAttempt_Field{_set: true, _value: v}
 }
 
-func (f Project_Name_Field) value() interface{} {
+func (f RetryItem_NextAttempt_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Project_Name_Field) _Column() string { return "name" }
+func (RetryItem_NextAttempt_Field) _Column() string { return "next_attempt" }
 
-type Project_Description_Field struct {
+type RetryItem_LastError_Field struct {
 	_set   bool
 	_null  bool
 	_value string
 }
 
-func Project_Description(v string) Project_Description_Field {
-	return Project_Description_Field{_set: true, _value: v}
+func RetryItem_LastError(v string) RetryItem_LastError_Field {
+	return RetryItem_LastError_Field{_set: true, _value: v}
 }
 
-func (f Project_Description_Field) value() interface{} {
+func (f RetryItem_LastError_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Project_Description_Field) _Column() string { return "description" }
+func (RetryItem_LastError_Field) _Column() string { return "last_error" }
 
-type Project_TermsAccepted_Field struct {
+type RetryItem_Dead_Field struct {
 	_set   bool
 	_null  bool
-	_value int
+	_value bool
 }
 
-func Project_TermsAccepted(v int) Project_TermsAccepted_Field {
-	return Project_TermsAccepted_Field{_set: true, _value: v}
+func RetryItem_Dead(v bool) RetryItem_Dead_Field {
+	return RetryItem_Dead_Field{_set: true, _value: v}
 }
 
-func (f Project_TermsAccepted_Field) value() interface{} {
+func (f RetryItem_Dead_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Project_TermsAccepted_Field) _Column() string { return "terms_accepted" }
+func (RetryItem_Dead_Field) _Column() string { return "dead" }
 
-type Project_CreatedAt_Field struct {
+type RetryItem_CreatedAt_Field struct {
 	_set   bool
 	_null  bool
 	_value time.Time
 }
 
-func Project_CreatedAt(v time.Time) Project_CreatedAt_Field {
-	return Project_CreatedAt_Field{_set: true, _value: v}
+func RetryItem_CreatedAt(v time.Time) RetryItem_CreatedAt_Field {
+	return RetryItem_CreatedAt_Field{_set: true, _value: v}
 }
 
-func (f Project_CreatedAt_Field) value() interface{} {
+func (f RetryItem_CreatedAt_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Project_CreatedAt_Field) _Column() string { return "created_at" }
+func (RetryItem_CreatedAt_Field) _Column() string { return "created_at" }
 
 type User struct {
 	Id           []byte
@@ -2608,7 +3344,11 @@ func (obj *postgresImpl) Create_Node(ctx context.Context,
 	node_audit_success_ratio Node_AuditSuccessRatio_Field,
 	node_uptime_success_count Node_UptimeSuccessCount_Field,
 	node_total_uptime_count Node_TotalUptimeCount_Field,
-	node_uptime_ratio Node_UptimeRatio_Field) (
+	node_uptime_ratio Node_UptimeRatio_Field,
+	node_audit_reputation_alpha Node_AuditReputationAlpha_Field,
+	node_audit_reputation_beta Node_AuditReputationBeta_Field,
+	node_uptime_reputation_alpha Node_UptimeReputationAlpha_Field,
+	node_uptime_reputation_beta Node_UptimeReputationBeta_Field) (
 	node *Node, err error) {
 
 	__now := obj.db.Hooks.Now().UTC()
@@ -2619,16 +3359,20 @@ func (obj *postgresImpl) Create_Node(ctx context.Context,
 	__uptime_success_count_val := node_uptime_success_count.value()
 	__total_uptime_count_val := node_total_uptime_count.value()
 	__uptime_ratio_val := node_uptime_ratio.value()
+	__audit_reputation_alpha_val := node_audit_reputation_alpha.value()
+	__audit_reputation_beta_val := node_audit_reputation_beta.value()
+	__uptime_reputation_alpha_val := node_uptime_reputation_alpha.value()
+	__uptime_reputation_beta_val := node_uptime_reputation_beta.value()
 	__created_at_val := __now
 	__updated_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO nodes ( id, audit_success_count, total_audit_count, audit_success_ratio, uptime_success_count, total_uptime_count, uptime_ratio, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO nodes ( id, audit_success_count, total_audit_count, audit_success_ratio, uptime_success_count, total_uptime_count, uptime_ratio, audit_reputation_alpha, audit_reputation_beta, uptime_reputation_alpha, uptime_reputation_beta, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.audit_reputation_alpha, nodes.audit_reputation_beta, nodes.uptime_reputation_alpha, nodes.uptime_reputation_beta, nodes.created_at, nodes.updated_at")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __id_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __created_at_val, __updated_at_val)
+	obj.logStmt(__stmt, __id_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __audit_reputation_alpha_val, __audit_reputation_beta_val, __uptime_reputation_alpha_val, __uptime_reputation_beta_val, __created_at_val, __updated_at_val)
 
 	node = &Node{}
-	err = obj.driver.QueryRow(__stmt, __id_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __created_at_val, __updated_at_val).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt)
+	err = obj.driver.QueryRow(__stmt, __id_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __audit_reputation_alpha_val, __audit_reputation_beta_val, __uptime_reputation_alpha_val, __uptime_reputation_beta_val, __created_at_val, __updated_at_val).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.AuditReputationAlpha, &node.AuditReputationBeta, &node.UptimeReputationAlpha, &node.UptimeReputationBeta, &node.CreatedAt, &node.UpdatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -2643,6 +3387,8 @@ func (obj *postgresImpl) Create_OverlayCacheNode(ctx context.Context,
 	overlay_cache_node_protocol OverlayCacheNode_Protocol_Field,
 	overlay_cache_node_operator_email OverlayCacheNode_OperatorEmail_Field,
 	overlay_cache_node_operator_wallet OverlayCacheNode_OperatorWallet_Field,
+	overlay_cache_node_operator_wallet_features OverlayCacheNode_OperatorWalletFeatures_Field,
+	overlay_cache_node_tags OverlayCacheNode_Tags_Field,
 	overlay_cache_node_free_bandwidth OverlayCacheNode_FreeBandwidth_Field,
 	overlay_cache_node_free_disk OverlayCacheNode_FreeDisk_Field,
 	overlay_cache_node_latency_90 OverlayCacheNode_Latency90_Field,
@@ -2651,7 +3397,11 @@ func (obj *postgresImpl) Create_OverlayCacheNode(ctx context.Context,
 	overlay_cache_node_audit_count OverlayCacheNode_AuditCount_Field,
 	overlay_cache_node_audit_success_count OverlayCacheNode_AuditSuccessCount_Field,
 	overlay_cache_node_uptime_count OverlayCacheNode_UptimeCount_Field,
-	overlay_cache_node_uptime_success_count OverlayCacheNode_UptimeSuccessCount_Field) (
+	overlay_cache_node_uptime_success_count OverlayCacheNode_UptimeSuccessCount_Field,
+	overlay_cache_node_audit_reputation OverlayCacheNode_AuditReputation_Field,
+	overlay_cache_node_uptime_reputation OverlayCacheNode_UptimeReputation_Field,
+	overlay_cache_node_last_contact_success OverlayCacheNode_LastContactSuccess_Field,
+	overlay_cache_node_last_contact_failure OverlayCacheNode_LastContactFailure_Field) (
 	overlay_cache_node *OverlayCacheNode, err error) {
 	__node_id_val := overlay_cache_node_node_id.value()
 	__node_type_val := overlay_cache_node_node_type.value()
@@ -2659,6 +3409,8 @@ func (obj *postgresImpl) Create_OverlayCacheNode(ctx context.Context,
 	__protocol_val := overlay_cache_node_protocol.value()
 	__operator_email_val := overlay_cache_node_operator_email.value()
 	__operator_wallet_val := overlay_cache_node_operator_wallet.value()
+	__operator_wallet_features_val := overlay_cache_node_operator_wallet_features.value()
+	__tags_val := overlay_cache_node_tags.value()
 	__free_bandwidth_val := overlay_cache_node_free_bandwidth.value()
 	__free_disk_val := overlay_cache_node_free_disk.value()
 	__latency_90_val := overlay_cache_node_latency_90.value()
@@ -2668,14 +3420,18 @@ func (obj *postgresImpl) Create_OverlayCacheNode(ctx context.Context,
 	__audit_success_count_val := overlay_cache_node_audit_success_count.value()
 	__uptime_count_val := overlay_cache_node_uptime_count.value()
 	__uptime_success_count_val := overlay_cache_node_uptime_success_count.value()
+	__audit_reputation_val := overlay_cache_node_audit_reputation.value()
+	__uptime_reputation_val := overlay_cache_node_uptime_reputation.value()
+	__last_contact_success_val := overlay_cache_node_last_contact_success.value()
+	__last_contact_failure_val := overlay_cache_node_last_contact_failure.value()
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO overlay_cache_nodes ( node_id, node_type, address, protocol, operator_email, operator_wallet, free_bandwidth, free_disk, latency_90, audit_success_ratio, audit_uptime_ratio, audit_count, audit_success_count, uptime_count, uptime_success_count ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING overlay_cache_nodes.node_id, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.protocol, overlay_cache_nodes.operator_email, overlay_cache_nodes.operator_wallet, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.latency_90, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_uptime_ratio, overlay_cache_nodes.audit_count, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.uptime_success_count")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO overlay_cache_nodes ( node_id, node_type, address, protocol, operator_email, operator_wallet, operator_wallet_features, tags, free_bandwidth, free_disk, latency_90, audit_success_ratio, audit_uptime_ratio, audit_count, audit_success_count, uptime_count, uptime_success_count, audit_reputation, uptime_reputation, last_contact_success, last_contact_failure ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING overlay_cache_nodes.node_id, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.protocol, overlay_cache_nodes.operator_email, overlay_cache_nodes.operator_wallet, overlay_cache_nodes.operator_wallet_features, overlay_cache_nodes.tags, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.latency_90, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_uptime_ratio, overlay_cache_nodes.audit_count, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.uptime_success_count, overlay_cache_nodes.audit_reputation, overlay_cache_nodes.uptime_reputation, overlay_cache_nodes.last_contact_success, overlay_cache_nodes.last_contact_failure")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __node_id_val, __node_type_val, __address_val, __protocol_val, __operator_email_val, __operator_wallet_val, __free_bandwidth_val, __free_disk_val, __latency_90_val, __audit_success_ratio_val, __audit_uptime_ratio_val, __audit_count_val, __audit_success_count_val, __uptime_count_val, __uptime_success_count_val)
+	obj.logStmt(__stmt, __node_id_val, __node_type_val, __address_val, __protocol_val, __operator_email_val, __operator_wallet_val, __operator_wallet_features_val, __tags_val, __free_bandwidth_val, __free_disk_val, __latency_90_val, __audit_success_ratio_val, __audit_uptime_ratio_val, __audit_count_val, __audit_success_count_val, __uptime_count_val, __uptime_success_count_val, __audit_reputation_val, __uptime_reputation_val, __last_contact_success_val, __last_contact_failure_val)
 
 	overlay_cache_node = &OverlayCacheNode{}
-	err = obj.driver.QueryRow(__stmt, __node_id_val, __node_type_val, __address_val, __protocol_val, __operator_email_val, __operator_wallet_val, __free_bandwidth_val, __free_disk_val, __latency_90_val, __audit_success_ratio_val, __audit_uptime_ratio_val, __audit_count_val, __audit_success_count_val, __uptime_count_val, __uptime_success_count_val).Scan(&overlay_cache_node.NodeId, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.Protocol, &overlay_cache_node.OperatorEmail, &overlay_cache_node.OperatorWallet, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.Latency90, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditUptimeRatio, &overlay_cache_node.AuditCount, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.UptimeSuccessCount)
+	err = obj.driver.QueryRow(__stmt, __node_id_val, __node_type_val, __address_val, __protocol_val, __operator_email_val, __operator_wallet_val, __operator_wallet_features_val, __tags_val, __free_bandwidth_val, __free_disk_val, __latency_90_val, __audit_success_ratio_val, __audit_uptime_ratio_val, __audit_count_val, __audit_success_count_val, __uptime_count_val, __uptime_success_count_val, __audit_reputation_val, __uptime_reputation_val, __last_contact_success_val, __last_contact_failure_val).Scan(&overlay_cache_node.NodeId, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.Protocol, &overlay_cache_node.OperatorEmail, &overlay_cache_node.OperatorWallet, &overlay_cache_node.OperatorWalletFeatures, &overlay_cache_node.Tags, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.Latency90, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditUptimeRatio, &overlay_cache_node.AuditCount, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.UptimeSuccessCount, &overlay_cache_node.AuditReputation, &overlay_cache_node.UptimeReputation, &overlay_cache_node.LastContactSuccess, &overlay_cache_node.LastContactFailure)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -2683,6 +3439,32 @@ func (obj *postgresImpl) Create_OverlayCacheNode(ctx context.Context,
 
 }
 
+func (obj *postgresImpl) Create_NodeEvent(ctx context.Context,
+	node_event_node_id NodeEvent_NodeId_Field,
+	node_event_event_type NodeEvent_EventType_Field,
+	node_event_data NodeEvent_Data_Field) (
+	node_event *NodeEvent, err error) {
+
+	__now := obj.db.Hooks.Now().UTC()
+	__node_id_val := node_event_node_id.value()
+	__event_type_val := node_event_event_type.value()
+	__data_val := node_event_data.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO node_events ( node_id, event_type, data, created_at ) VALUES ( ?, ?, ?, ? ) RETURNING node_events.id, node_events.node_id, node_events.event_type, node_events.data, node_events.created_at")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __node_id_val, __event_type_val, __data_val, __created_at_val)
+
+	node_event = &NodeEvent{}
+	err = obj.driver.QueryRow(__stmt, __node_id_val, __event_type_val, __data_val, __created_at_val).Scan(&node_event.Id, &node_event.NodeId, &node_event.EventType, &node_event.Data, &node_event.CreatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return node_event, nil
+
+}
+
 func (obj *postgresImpl) Create_Injuredsegment(ctx context.Context,
 	injuredsegment_info Injuredsegment_Info_Field) (
 	injuredsegment *Injuredsegment, err error) {
@@ -3146,7 +3928,7 @@ func (obj *postgresImpl) Get_Node_By_Id(ctx context.Context,
 	node_id Node_Id_Field) (
 	node *Node, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at FROM nodes WHERE nodes.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.audit_reputation_alpha, nodes.audit_reputation_beta, nodes.uptime_reputation_alpha, nodes.uptime_reputation_beta, nodes.created_at, nodes.updated_at FROM nodes WHERE nodes.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, node_id.value())
@@ -3155,7 +3937,7 @@ func (obj *postgresImpl) Get_Node_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	node = &Node{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.AuditReputationAlpha, &node.AuditReputationBeta, &node.UptimeReputationAlpha, &node.UptimeReputationBeta, &node.CreatedAt, &node.UpdatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -3233,7 +4015,7 @@ func (obj *postgresImpl) Get_OverlayCacheNode_By_NodeId(ctx context.Context,
 	overlay_cache_node_node_id OverlayCacheNode_NodeId_Field) (
 	overlay_cache_node *OverlayCacheNode, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.node_id, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.protocol, overlay_cache_nodes.operator_email, overlay_cache_nodes.operator_wallet, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.latency_90, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_uptime_ratio, overlay_cache_nodes.audit_count, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.uptime_success_count FROM overlay_cache_nodes WHERE overlay_cache_nodes.node_id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.node_id, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.protocol, overlay_cache_nodes.operator_email, overlay_cache_nodes.operator_wallet, overlay_cache_nodes.operator_wallet_features, overlay_cache_nodes.tags, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.latency_90, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_uptime_ratio, overlay_cache_nodes.audit_count, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.uptime_success_count, overlay_cache_nodes.audit_reputation, overlay_cache_nodes.uptime_reputation, overlay_cache_nodes.last_contact_success, overlay_cache_nodes.last_contact_failure FROM overlay_cache_nodes WHERE overlay_cache_nodes.node_id = ?")
 
 	var __values []interface{}
 	__values = append(__values, overlay_cache_node_node_id.value())
@@ -3242,7 +4024,7 @@ func (obj *postgresImpl) Get_OverlayCacheNode_By_NodeId(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	overlay_cache_node = &OverlayCacheNode{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&overlay_cache_node.NodeId, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.Protocol, &overlay_cache_node.OperatorEmail, &overlay_cache_node.OperatorWallet, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.Latency90, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditUptimeRatio, &overlay_cache_node.AuditCount, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.UptimeSuccessCount)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&overlay_cache_node.NodeId, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.Protocol, &overlay_cache_node.OperatorEmail, &overlay_cache_node.OperatorWallet, &overlay_cache_node.OperatorWalletFeatures, &overlay_cache_node.Tags, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.Latency90, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditUptimeRatio, &overlay_cache_node.AuditCount, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.UptimeSuccessCount, &overlay_cache_node.AuditReputation, &overlay_cache_node.UptimeReputation, &overlay_cache_node.LastContactSuccess, &overlay_cache_node.LastContactFailure)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -3276,7 +4058,7 @@ func (obj *postgresImpl) Limited_OverlayCacheNode_By_NodeId_GreaterOrEqual(ctx c
 	limit int, offset int64) (
 	rows []*OverlayCacheNode, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.node_id, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.protocol, overlay_cache_nodes.operator_email, overlay_cache_nodes.operator_wallet, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.latency_90, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_uptime_ratio, overlay_cache_nodes.audit_count, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.uptime_success_count FROM overlay_cache_nodes WHERE overlay_cache_nodes.node_id >= ? LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.node_id, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.protocol, overlay_cache_nodes.operator_email, overlay_cache_nodes.operator_wallet, overlay_cache_nodes.operator_wallet_features, overlay_cache_nodes.tags, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.latency_90, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_uptime_ratio, overlay_cache_nodes.audit_count, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.uptime_success_count, overlay_cache_nodes.audit_reputation, overlay_cache_nodes.uptime_reputation, overlay_cache_nodes.last_contact_success, overlay_cache_nodes.last_contact_failure FROM overlay_cache_nodes WHERE overlay_cache_nodes.node_id >= ? LIMIT ? OFFSET ?")
 
 	var __values []interface{}
 	__values = append(__values, overlay_cache_node_node_id_greater_or_equal.value())
@@ -3294,7 +4076,7 @@ func (obj *postgresImpl) Limited_OverlayCacheNode_By_NodeId_GreaterOrEqual(ctx c
 
 	for __rows.Next() {
 		overlay_cache_node := &OverlayCacheNode{}
-		err = __rows.Scan(&overlay_cache_node.NodeId, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.Protocol, &overlay_cache_node.OperatorEmail, &overlay_cache_node.OperatorWallet, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.Latency90, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditUptimeRatio, &overlay_cache_node.AuditCount, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.UptimeSuccessCount)
+		err = __rows.Scan(&overlay_cache_node.NodeId, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.Protocol, &overlay_cache_node.OperatorEmail, &overlay_cache_node.OperatorWallet, &overlay_cache_node.OperatorWalletFeatures, &overlay_cache_node.Tags, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.Latency90, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditUptimeRatio, &overlay_cache_node.AuditCount, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.UptimeSuccessCount, &overlay_cache_node.AuditReputation, &overlay_cache_node.UptimeReputation, &overlay_cache_node.LastContactSuccess, &overlay_cache_node.LastContactFailure)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -3803,7 +4585,7 @@ func (obj *postgresImpl) Update_Node_By_Id(ctx context.Context,
 	node *Node, err error) {
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE nodes SET "), __sets, __sqlbundle_Literal(" WHERE nodes.id = ? RETURNING nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE nodes SET "), __sets, __sqlbundle_Literal(" WHERE nodes.id = ? RETURNING nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.audit_reputation_alpha, nodes.audit_reputation_beta, nodes.uptime_reputation_alpha, nodes.uptime_reputation_beta, nodes.created_at, nodes.updated_at")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
@@ -3839,6 +4621,26 @@ func (obj *postgresImpl) Update_Node_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_ratio = ?"))
 	}
 
+	if update.AuditReputationAlpha._set {
+		__values = append(__values, update.AuditReputationAlpha.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("audit_reputation_alpha = ?"))
+	}
+
+	if update.AuditReputationBeta._set {
+		__values = append(__values, update.AuditReputationBeta.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("audit_reputation_beta = ?"))
+	}
+
+	if update.UptimeReputationAlpha._set {
+		__values = append(__values, update.UptimeReputationAlpha.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_reputation_alpha = ?"))
+	}
+
+	if update.UptimeReputationBeta._set {
+		__values = append(__values, update.UptimeReputationBeta.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_reputation_beta = ?"))
+	}
+
 	__now := obj.db.Hooks.Now().UTC()
 
 	__values = append(__values, __now)
@@ -3853,7 +4655,7 @@ func (obj *postgresImpl) Update_Node_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	node = &Node{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.AuditReputationAlpha, &node.AuditReputationBeta, &node.UptimeReputationAlpha, &node.UptimeReputationBeta, &node.CreatedAt, &node.UpdatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -3869,7 +4671,7 @@ func (obj *postgresImpl) Update_OverlayCacheNode_By_NodeId(ctx context.Context,
 	overlay_cache_node *OverlayCacheNode, err error) {
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE overlay_cache_nodes SET "), __sets, __sqlbundle_Literal(" WHERE overlay_cache_nodes.node_id = ? RETURNING overlay_cache_nodes.node_id, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.protocol, overlay_cache_nodes.operator_email, overlay_cache_nodes.operator_wallet, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.latency_90, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_uptime_ratio, overlay_cache_nodes.audit_count, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.uptime_success_count")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE overlay_cache_nodes SET "), __sets, __sqlbundle_Literal(" WHERE overlay_cache_nodes.node_id = ? RETURNING overlay_cache_nodes.node_id, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.protocol, overlay_cache_nodes.operator_email, overlay_cache_nodes.operator_wallet, overlay_cache_nodes.operator_wallet_features, overlay_cache_nodes.tags, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.latency_90, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_uptime_ratio, overlay_cache_nodes.audit_count, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.uptime_success_count, overlay_cache_nodes.audit_reputation, overlay_cache_nodes.uptime_reputation, overlay_cache_nodes.last_contact_success, overlay_cache_nodes.last_contact_failure")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
@@ -3895,6 +4697,16 @@ func (obj *postgresImpl) Update_OverlayCacheNode_By_NodeId(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("operator_wallet = ?"))
 	}
 
+	if update.OperatorWalletFeatures._set {
+		__values = append(__values, update.OperatorWalletFeatures.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("operator_wallet_features = ?"))
+	}
+
+	if update.Tags._set {
+		__values = append(__values, update.Tags.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("tags = ?"))
+	}
+
 	if update.FreeBandwidth._set {
 		__values = append(__values, update.FreeBandwidth.value())
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("free_bandwidth = ?"))
@@ -3940,6 +4752,26 @@ func (obj *postgresImpl) Update_OverlayCacheNode_By_NodeId(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_success_count = ?"))
 	}
 
+	if update.AuditReputation._set {
+		__values = append(__values, update.AuditReputation.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("audit_reputation = ?"))
+	}
+
+	if update.UptimeReputation._set {
+		__values = append(__values, update.UptimeReputation.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_reputation = ?"))
+	}
+
+	if update.LastContactSuccess._set {
+		__values = append(__values, update.LastContactSuccess.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("last_contact_success = ?"))
+	}
+
+	if update.LastContactFailure._set {
+		__values = append(__values, update.LastContactFailure.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("last_contact_failure = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -3953,7 +4785,7 @@ func (obj *postgresImpl) Update_OverlayCacheNode_By_NodeId(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	overlay_cache_node = &OverlayCacheNode{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&overlay_cache_node.NodeId, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.Protocol, &overlay_cache_node.OperatorEmail, &overlay_cache_node.OperatorWallet, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.Latency90, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditUptimeRatio, &overlay_cache_node.AuditCount, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.UptimeSuccessCount)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&overlay_cache_node.NodeId, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.Protocol, &overlay_cache_node.OperatorEmail, &overlay_cache_node.OperatorWallet, &overlay_cache_node.OperatorWalletFeatures, &overlay_cache_node.Tags, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.Latency90, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditUptimeRatio, &overlay_cache_node.AuditCount, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.UptimeSuccessCount, &overlay_cache_node.AuditReputation, &overlay_cache_node.UptimeReputation, &overlay_cache_node.LastContactSuccess, &overlay_cache_node.LastContactFailure)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -4490,6 +5322,26 @@ func (obj *postgresImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM retry_items;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM project_usages;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -4520,6 +5372,26 @@ func (obj *postgresImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM node_events;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM leases;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -4749,7 +5621,11 @@ func (obj *sqlite3Impl) Create_Node(ctx context.Context,
 	node_audit_success_ratio Node_AuditSuccessRatio_Field,
 	node_uptime_success_count Node_UptimeSuccessCount_Field,
 	node_total_uptime_count Node_TotalUptimeCount_Field,
-	node_uptime_ratio Node_UptimeRatio_Field) (
+	node_uptime_ratio Node_UptimeRatio_Field,
+	node_audit_reputation_alpha Node_AuditReputationAlpha_Field,
+	node_audit_reputation_beta Node_AuditReputationBeta_Field,
+	node_uptime_reputation_alpha Node_UptimeReputationAlpha_Field,
+	node_uptime_reputation_beta Node_UptimeReputationBeta_Field) (
 	node *Node, err error) {
 
 	__now := obj.db.Hooks.Now().UTC()
@@ -4760,15 +5636,19 @@ func (obj *sqlite3Impl) Create_Node(ctx context.Context,
 	__uptime_success_count_val := node_uptime_success_count.value()
 	__total_uptime_count_val := node_total_uptime_count.value()
 	__uptime_ratio_val := node_uptime_ratio.value()
+	__audit_reputation_alpha_val := node_audit_reputation_alpha.value()
+	__audit_reputation_beta_val := node_audit_reputation_beta.value()
+	__uptime_reputation_alpha_val := node_uptime_reputation_alpha.value()
+	__uptime_reputation_beta_val := node_uptime_reputation_beta.value()
 	__created_at_val := __now
 	__updated_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO nodes ( id, audit_success_count, total_audit_count, audit_success_ratio, uptime_success_count, total_uptime_count, uptime_ratio, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ? )")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO nodes ( id, audit_success_count, total_audit_count, audit_success_ratio, uptime_success_count, total_uptime_count, uptime_ratio, audit_reputation_alpha, audit_reputation_beta, uptime_reputation_alpha, uptime_reputation_beta, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __id_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __created_at_val, __updated_at_val)
+	obj.logStmt(__stmt, __id_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __audit_reputation_alpha_val, __audit_reputation_beta_val, __uptime_reputation_alpha_val, __uptime_reputation_beta_val, __created_at_val, __updated_at_val)
 
-	__res, err := obj.driver.Exec(__stmt, __id_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __created_at_val, __updated_at_val)
+	__res, err := obj.driver.Exec(__stmt, __id_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __audit_reputation_alpha_val, __audit_reputation_beta_val, __uptime_reputation_alpha_val, __uptime_reputation_beta_val, __created_at_val, __updated_at_val)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -4787,6 +5667,8 @@ func (obj *sqlite3Impl) Create_OverlayCacheNode(ctx context.Context,
 	overlay_cache_node_protocol OverlayCacheNode_Protocol_Field,
 	overlay_cache_node_operator_email OverlayCacheNode_OperatorEmail_Field,
 	overlay_cache_node_operator_wallet OverlayCacheNode_OperatorWallet_Field,
+	overlay_cache_node_operator_wallet_features OverlayCacheNode_OperatorWalletFeatures_Field,
+	overlay_cache_node_tags OverlayCacheNode_Tags_Field,
 	overlay_cache_node_free_bandwidth OverlayCacheNode_FreeBandwidth_Field,
 	overlay_cache_node_free_disk OverlayCacheNode_FreeDisk_Field,
 	overlay_cache_node_latency_90 OverlayCacheNode_Latency90_Field,
@@ -4795,7 +5677,11 @@ func (obj *sqlite3Impl) Create_OverlayCacheNode(ctx context.Context,
 	overlay_cache_node_audit_count OverlayCacheNode_AuditCount_Field,
 	overlay_cache_node_audit_success_count OverlayCacheNode_AuditSuccessCount_Field,
 	overlay_cache_node_uptime_count OverlayCacheNode_UptimeCount_Field,
-	overlay_cache_node_uptime_success_count OverlayCacheNode_UptimeSuccessCount_Field) (
+	overlay_cache_node_uptime_success_count OverlayCacheNode_UptimeSuccessCount_Field,
+	overlay_cache_node_audit_reputation OverlayCacheNode_AuditReputation_Field,
+	overlay_cache_node_uptime_reputation OverlayCacheNode_UptimeReputation_Field,
+	overlay_cache_node_last_contact_success OverlayCacheNode_LastContactSuccess_Field,
+	overlay_cache_node_last_contact_failure OverlayCacheNode_LastContactFailure_Field) (
 	overlay_cache_node *OverlayCacheNode, err error) {
 	__node_id_val := overlay_cache_node_node_id.value()
 	__node_type_val := overlay_cache_node_node_type.value()
@@ -4803,6 +5689,8 @@ func (obj *sqlite3Impl) Create_OverlayCacheNode(ctx context.Context,
 	__protocol_val := overlay_cache_node_protocol.value()
 	__operator_email_val := overlay_cache_node_operator_email.value()
 	__operator_wallet_val := overlay_cache_node_operator_wallet.value()
+	__operator_wallet_features_val := overlay_cache_node_operator_wallet_features.value()
+	__tags_val := overlay_cache_node_tags.value()
 	__free_bandwidth_val := overlay_cache_node_free_bandwidth.value()
 	__free_disk_val := overlay_cache_node_free_disk.value()
 	__latency_90_val := overlay_cache_node_latency_90.value()
@@ -4812,13 +5700,17 @@ func (obj *sqlite3Impl) Create_OverlayCacheNode(ctx context.Context,
 	__audit_success_count_val := overlay_cache_node_audit_success_count.value()
 	__uptime_count_val := overlay_cache_node_uptime_count.value()
 	__uptime_success_count_val := overlay_cache_node_uptime_success_count.value()
+	__audit_reputation_val := overlay_cache_node_audit_reputation.value()
+	__uptime_reputation_val := overlay_cache_node_uptime_reputation.value()
+	__last_contact_success_val := overlay_cache_node_last_contact_success.value()
+	__last_contact_failure_val := overlay_cache_node_last_contact_failure.value()
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO overlay_cache_nodes ( node_id, node_type, address, protocol, operator_email, operator_wallet, free_bandwidth, free_disk, latency_90, audit_success_ratio, audit_uptime_ratio, audit_count, audit_success_count, uptime_count, uptime_success_count ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO overlay_cache_nodes ( node_id, node_type, address, protocol, operator_email, operator_wallet, operator_wallet_features, tags, free_bandwidth, free_disk, latency_90, audit_success_ratio, audit_uptime_ratio, audit_count, audit_success_count, uptime_count, uptime_success_count, audit_reputation, uptime_reputation, last_contact_success, last_contact_failure ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __node_id_val, __node_type_val, __address_val, __protocol_val, __operator_email_val, __operator_wallet_val, __free_bandwidth_val, __free_disk_val, __latency_90_val, __audit_success_ratio_val, __audit_uptime_ratio_val, __audit_count_val, __audit_success_count_val, __uptime_count_val, __uptime_success_count_val)
+	obj.logStmt(__stmt, __node_id_val, __node_type_val, __address_val, __protocol_val, __operator_email_val, __operator_wallet_val, __operator_wallet_features_val, __tags_val, __free_bandwidth_val, __free_disk_val, __latency_90_val, __audit_success_ratio_val, __audit_uptime_ratio_val, __audit_count_val, __audit_success_count_val, __uptime_count_val, __uptime_success_count_val, __audit_reputation_val, __uptime_reputation_val, __last_contact_success_val, __last_contact_failure_val)
 
-	__res, err := obj.driver.Exec(__stmt, __node_id_val, __node_type_val, __address_val, __protocol_val, __operator_email_val, __operator_wallet_val, __free_bandwidth_val, __free_disk_val, __latency_90_val, __audit_success_ratio_val, __audit_uptime_ratio_val, __audit_count_val, __audit_success_count_val, __uptime_count_val, __uptime_success_count_val)
+	__res, err := obj.driver.Exec(__stmt, __node_id_val, __node_type_val, __address_val, __protocol_val, __operator_email_val, __operator_wallet_val, __operator_wallet_features_val, __tags_val, __free_bandwidth_val, __free_disk_val, __latency_90_val, __audit_success_ratio_val, __audit_uptime_ratio_val, __audit_count_val, __audit_success_count_val, __uptime_count_val, __uptime_success_count_val, __audit_reputation_val, __uptime_reputation_val, __last_contact_success_val, __last_contact_failure_val)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -4830,6 +5722,35 @@ func (obj *sqlite3Impl) Create_OverlayCacheNode(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) Create_NodeEvent(ctx context.Context,
+	node_event_node_id NodeEvent_NodeId_Field,
+	node_event_event_type NodeEvent_EventType_Field,
+	node_event_data NodeEvent_Data_Field) (
+	node_event *NodeEvent, err error) {
+
+	__now := obj.db.Hooks.Now().UTC()
+	__node_id_val := node_event_node_id.value()
+	__event_type_val := node_event_event_type.value()
+	__data_val := node_event_data.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO node_events ( node_id, event_type, data, created_at ) VALUES ( ?, ?, ?, ? )")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __node_id_val, __event_type_val, __data_val, __created_at_val)
+
+	__res, err := obj.driver.Exec(__stmt, __node_id_val, __event_type_val, __data_val, __created_at_val)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	__pk, err := __res.LastInsertId()
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return obj.getLastNodeEvent(ctx, __pk)
+
+}
+
 func (obj *sqlite3Impl) Create_Injuredsegment(ctx context.Context,
 	injuredsegment_info Injuredsegment_Info_Field) (
 	injuredsegment *Injuredsegment, err error) {
@@ -5311,7 +6232,7 @@ func (obj *sqlite3Impl) Get_Node_By_Id(ctx context.Context,
 	node_id Node_Id_Field) (
 	node *Node, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at FROM nodes WHERE nodes.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.audit_reputation_alpha, nodes.audit_reputation_beta, nodes.uptime_reputation_alpha, nodes.uptime_reputation_beta, nodes.created_at, nodes.updated_at FROM nodes WHERE nodes.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, node_id.value())
@@ -5320,7 +6241,7 @@ func (obj *sqlite3Impl) Get_Node_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	node = &Node{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.AuditReputationAlpha, &node.AuditReputationBeta, &node.UptimeReputationAlpha, &node.UptimeReputationBeta, &node.CreatedAt, &node.UpdatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -5398,7 +6319,7 @@ func (obj *sqlite3Impl) Get_OverlayCacheNode_By_NodeId(ctx context.Context,
 	overlay_cache_node_node_id OverlayCacheNode_NodeId_Field) (
 	overlay_cache_node *OverlayCacheNode, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.node_id, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.protocol, overlay_cache_nodes.operator_email, overlay_cache_nodes.operator_wallet, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.latency_90, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_uptime_ratio, overlay_cache_nodes.audit_count, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.uptime_success_count FROM overlay_cache_nodes WHERE overlay_cache_nodes.node_id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.node_id, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.protocol, overlay_cache_nodes.operator_email, overlay_cache_nodes.operator_wallet, overlay_cache_nodes.operator_wallet_features, overlay_cache_nodes.tags, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.latency_90, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_uptime_ratio, overlay_cache_nodes.audit_count, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.uptime_success_count, overlay_cache_nodes.audit_reputation, overlay_cache_nodes.uptime_reputation, overlay_cache_nodes.last_contact_success, overlay_cache_nodes.last_contact_failure FROM overlay_cache_nodes WHERE overlay_cache_nodes.node_id = ?")
 
 	var __values []interface{}
 	__values = append(__values, overlay_cache_node_node_id.value())
@@ -5407,7 +6328,7 @@ func (obj *sqlite3Impl) Get_OverlayCacheNode_By_NodeId(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	overlay_cache_node = &OverlayCacheNode{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&overlay_cache_node.NodeId, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.Protocol, &overlay_cache_node.OperatorEmail, &overlay_cache_node.OperatorWallet, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.Latency90, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditUptimeRatio, &overlay_cache_node.AuditCount, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.UptimeSuccessCount)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&overlay_cache_node.NodeId, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.Protocol, &overlay_cache_node.OperatorEmail, &overlay_cache_node.OperatorWallet, &overlay_cache_node.OperatorWalletFeatures, &overlay_cache_node.Tags, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.Latency90, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditUptimeRatio, &overlay_cache_node.AuditCount, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.UptimeSuccessCount, &overlay_cache_node.AuditReputation, &overlay_cache_node.UptimeReputation, &overlay_cache_node.LastContactSuccess, &overlay_cache_node.LastContactFailure)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -5441,7 +6362,7 @@ func (obj *sqlite3Impl) Limited_OverlayCacheNode_By_NodeId_GreaterOrEqual(ctx co
 	limit int, offset int64) (
 	rows []*OverlayCacheNode, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.node_id, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.protocol, overlay_cache_nodes.operator_email, overlay_cache_nodes.operator_wallet, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.latency_90, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_uptime_ratio, overlay_cache_nodes.audit_count, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.uptime_success_count FROM overlay_cache_nodes WHERE overlay_cache_nodes.node_id >= ? LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.node_id, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.protocol, overlay_cache_nodes.operator_email, overlay_cache_nodes.operator_wallet, overlay_cache_nodes.operator_wallet_features, overlay_cache_nodes.tags, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.latency_90, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_uptime_ratio, overlay_cache_nodes.audit_count, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.uptime_success_count, overlay_cache_nodes.audit_reputation, overlay_cache_nodes.uptime_reputation, overlay_cache_nodes.last_contact_success, overlay_cache_nodes.last_contact_failure FROM overlay_cache_nodes WHERE overlay_cache_nodes.node_id >= ? LIMIT ? OFFSET ?")
 
 	var __values []interface{}
 	__values = append(__values, overlay_cache_node_node_id_greater_or_equal.value())
@@ -5459,7 +6380,7 @@ func (obj *sqlite3Impl) Limited_OverlayCacheNode_By_NodeId_GreaterOrEqual(ctx co
 
 	for __rows.Next() {
 		overlay_cache_node := &OverlayCacheNode{}
-		err = __rows.Scan(&overlay_cache_node.NodeId, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.Protocol, &overlay_cache_node.OperatorEmail, &overlay_cache_node.OperatorWallet, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.Latency90, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditUptimeRatio, &overlay_cache_node.AuditCount, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.UptimeSuccessCount)
+		err = __rows.Scan(&overlay_cache_node.NodeId, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.Protocol, &overlay_cache_node.OperatorEmail, &overlay_cache_node.OperatorWallet, &overlay_cache_node.OperatorWalletFeatures, &overlay_cache_node.Tags, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.Latency90, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditUptimeRatio, &overlay_cache_node.AuditCount, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.UptimeSuccessCount, &overlay_cache_node.AuditReputation, &overlay_cache_node.UptimeReputation, &overlay_cache_node.LastContactSuccess, &overlay_cache_node.LastContactFailure)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -6024,6 +6945,26 @@ func (obj *sqlite3Impl) Update_Node_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_ratio = ?"))
 	}
 
+	if update.AuditReputationAlpha._set {
+		__values = append(__values, update.AuditReputationAlpha.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("audit_reputation_alpha = ?"))
+	}
+
+	if update.AuditReputationBeta._set {
+		__values = append(__values, update.AuditReputationBeta.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("audit_reputation_beta = ?"))
+	}
+
+	if update.UptimeReputationAlpha._set {
+		__values = append(__values, update.UptimeReputationAlpha.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_reputation_alpha = ?"))
+	}
+
+	if update.UptimeReputationBeta._set {
+		__values = append(__values, update.UptimeReputationBeta.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_reputation_beta = ?"))
+	}
+
 	__now := obj.db.Hooks.Now().UTC()
 
 	__values = append(__values, __now)
@@ -6043,12 +6984,12 @@ func (obj *sqlite3Impl) Update_Node_By_Id(ctx context.Context,
 		return nil, obj.makeErr(err)
 	}
 
-	var __embed_stmt_get = __sqlbundle_Literal("SELECT nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at FROM nodes WHERE nodes.id = ?")
+	var __embed_stmt_get = __sqlbundle_Literal("SELECT nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.audit_reputation_alpha, nodes.audit_reputation_beta, nodes.uptime_reputation_alpha, nodes.uptime_reputation_beta, nodes.created_at, nodes.updated_at FROM nodes WHERE nodes.id = ?")
 
 	var __stmt_get = __sqlbundle_Render(obj.dialect, __embed_stmt_get)
 	obj.logStmt("(IMPLIED) "+__stmt_get, __args...)
 
-	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt)
+	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.AuditReputationAlpha, &node.AuditReputationBeta, &node.UptimeReputationAlpha, &node.UptimeReputationBeta, &node.CreatedAt, &node.UpdatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -6090,6 +7031,16 @@ func (obj *sqlite3Impl) Update_OverlayCacheNode_By_NodeId(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("operator_wallet = ?"))
 	}
 
+	if update.OperatorWalletFeatures._set {
+		__values = append(__values, update.OperatorWalletFeatures.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("operator_wallet_features = ?"))
+	}
+
+	if update.Tags._set {
+		__values = append(__values, update.Tags.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("tags = ?"))
+	}
+
 	if update.FreeBandwidth._set {
 		__values = append(__values, update.FreeBandwidth.value())
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("free_bandwidth = ?"))
@@ -6135,6 +7086,26 @@ func (obj *sqlite3Impl) Update_OverlayCacheNode_By_NodeId(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_success_count = ?"))
 	}
 
+	if update.AuditReputation._set {
+		__values = append(__values, update.AuditReputation.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("audit_reputation = ?"))
+	}
+
+	if update.UptimeReputation._set {
+		__values = append(__values, update.UptimeReputation.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_reputation = ?"))
+	}
+
+	if update.LastContactSuccess._set {
+		__values = append(__values, update.LastContactSuccess.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("last_contact_success = ?"))
+	}
+
+	if update.LastContactFailure._set {
+		__values = append(__values, update.LastContactFailure.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("last_contact_failure = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -6153,12 +7124,12 @@ func (obj *sqlite3Impl) Update_OverlayCacheNode_By_NodeId(ctx context.Context,
 		return nil, obj.makeErr(err)
 	}
 
-	var __embed_stmt_get = __sqlbundle_Literal("SELECT overlay_cache_nodes.node_id, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.protocol, overlay_cache_nodes.operator_email, overlay_cache_nodes.operator_wallet, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.latency_90, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_uptime_ratio, overlay_cache_nodes.audit_count, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.uptime_success_count FROM overlay_cache_nodes WHERE overlay_cache_nodes.node_id = ?")
+	var __embed_stmt_get = __sqlbundle_Literal("SELECT overlay_cache_nodes.node_id, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.protocol, overlay_cache_nodes.operator_email, overlay_cache_nodes.operator_wallet, overlay_cache_nodes.operator_wallet_features, overlay_cache_nodes.tags, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.latency_90, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_uptime_ratio, overlay_cache_nodes.audit_count, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.uptime_success_count, overlay_cache_nodes.audit_reputation, overlay_cache_nodes.uptime_reputation, overlay_cache_nodes.last_contact_success, overlay_cache_nodes.last_contact_failure FROM overlay_cache_nodes WHERE overlay_cache_nodes.node_id = ?")
 
 	var __stmt_get = __sqlbundle_Render(obj.dialect, __embed_stmt_get)
 	obj.logStmt("(IMPLIED) "+__stmt_get, __args...)
 
-	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&overlay_cache_node.NodeId, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.Protocol, &overlay_cache_node.OperatorEmail, &overlay_cache_node.OperatorWallet, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.Latency90, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditUptimeRatio, &overlay_cache_node.AuditCount, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.UptimeSuccessCount)
+	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&overlay_cache_node.NodeId, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.Protocol, &overlay_cache_node.OperatorEmail, &overlay_cache_node.OperatorWallet, &overlay_cache_node.OperatorWalletFeatures, &overlay_cache_node.Tags, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.Latency90, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditUptimeRatio, &overlay_cache_node.AuditCount, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.UptimeSuccessCount, &overlay_cache_node.AuditReputation, &overlay_cache_node.UptimeReputation, &overlay_cache_node.LastContactSuccess, &overlay_cache_node.LastContactFailure)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -6771,13 +7742,13 @@ func (obj *sqlite3Impl) getLastNode(ctx context.Context,
 	pk int64) (
 	node *Node, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at FROM nodes WHERE _rowid_ = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.audit_reputation_alpha, nodes.audit_reputation_beta, nodes.uptime_reputation_alpha, nodes.uptime_reputation_beta, nodes.created_at, nodes.updated_at FROM nodes WHERE _rowid_ = ?")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, pk)
 
 	node = &Node{}
-	err = obj.driver.QueryRow(__stmt, pk).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt)
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.AuditReputationAlpha, &node.AuditReputationBeta, &node.UptimeReputationAlpha, &node.UptimeReputationBeta, &node.CreatedAt, &node.UpdatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -6789,13 +7760,13 @@ func (obj *sqlite3Impl) getLastOverlayCacheNode(ctx context.Context,
 	pk int64) (
 	overlay_cache_node *OverlayCacheNode, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.node_id, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.protocol, overlay_cache_nodes.operator_email, overlay_cache_nodes.operator_wallet, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.latency_90, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_uptime_ratio, overlay_cache_nodes.audit_count, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.uptime_success_count FROM overlay_cache_nodes WHERE _rowid_ = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.node_id, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.protocol, overlay_cache_nodes.operator_email, overlay_cache_nodes.operator_wallet, overlay_cache_nodes.operator_wallet_features, overlay_cache_nodes.tags, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.latency_90, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_uptime_ratio, overlay_cache_nodes.audit_count, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.uptime_success_count, overlay_cache_nodes.audit_reputation, overlay_cache_nodes.uptime_reputation, overlay_cache_nodes.last_contact_success, overlay_cache_nodes.last_contact_failure FROM overlay_cache_nodes WHERE _rowid_ = ?")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, pk)
 
 	overlay_cache_node = &OverlayCacheNode{}
-	err = obj.driver.QueryRow(__stmt, pk).Scan(&overlay_cache_node.NodeId, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.Protocol, &overlay_cache_node.OperatorEmail, &overlay_cache_node.OperatorWallet, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.Latency90, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditUptimeRatio, &overlay_cache_node.AuditCount, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.UptimeSuccessCount)
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&overlay_cache_node.NodeId, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.Protocol, &overlay_cache_node.OperatorEmail, &overlay_cache_node.OperatorWallet, &overlay_cache_node.OperatorWalletFeatures, &overlay_cache_node.Tags, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.Latency90, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditUptimeRatio, &overlay_cache_node.AuditCount, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.UptimeSuccessCount, &overlay_cache_node.AuditReputation, &overlay_cache_node.UptimeReputation, &overlay_cache_node.LastContactSuccess, &overlay_cache_node.LastContactFailure)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -6803,6 +7774,24 @@ func (obj *sqlite3Impl) getLastOverlayCacheNode(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) getLastNodeEvent(ctx context.Context,
+	pk int64) (
+	node_event *NodeEvent, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT node_events.id, node_events.node_id, node_events.event_type, node_events.data, node_events.created_at FROM node_events WHERE _rowid_ = ?")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, pk)
+
+	node_event = &NodeEvent{}
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&node_event.Id, &node_event.NodeId, &node_event.EventType, &node_event.Data, &node_event.CreatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return node_event, nil
+
+}
+
 func (obj *sqlite3Impl) getLastInjuredsegment(ctx context.Context,
 	pk int64) (
 	injuredsegment *Injuredsegment, err error) {
@@ -6964,6 +7953,26 @@ func (obj *sqlite3Impl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM retry_items;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM project_usages;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -6994,6 +8003,26 @@ func (obj *sqlite3Impl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM node_events;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM leases;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -7340,13 +8369,30 @@ func (rx *Rx) Create_Node(ctx context.Context,
 	node_audit_success_ratio Node_AuditSuccessRatio_Field,
 	node_uptime_success_count Node_UptimeSuccessCount_Field,
 	node_total_uptime_count Node_TotalUptimeCount_Field,
-	node_uptime_ratio Node_UptimeRatio_Field) (
+	node_uptime_ratio Node_UptimeRatio_Field,
+	node_audit_reputation_alpha Node_AuditReputationAlpha_Field,
+	node_audit_reputation_beta Node_AuditReputationBeta_Field,
+	node_uptime_reputation_alpha Node_UptimeReputationAlpha_Field,
+	node_uptime_reputation_beta Node_UptimeReputationBeta_Field) (
 	node *Node, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Create_Node(ctx, node_id, node_audit_success_count, node_total_audit_count, node_audit_success_ratio, node_uptime_success_count, node_total_uptime_count, node_uptime_ratio)
+	return tx.Create_Node(ctx, node_id, node_audit_success_count, node_total_audit_count, node_audit_success_ratio, node_uptime_success_count, node_total_uptime_count, node_uptime_ratio, node_audit_reputation_alpha, node_audit_reputation_beta, node_uptime_reputation_alpha, node_uptime_reputation_beta)
+
+}
+
+func (rx *Rx) Create_NodeEvent(ctx context.Context,
+	node_event_node_id NodeEvent_NodeId_Field,
+	node_event_event_type NodeEvent_EventType_Field,
+	node_event_data NodeEvent_Data_Field) (
+	node_event *NodeEvent, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Create_NodeEvent(ctx, node_event_node_id, node_event_event_type, node_event_data)
 
 }
 
@@ -7357,6 +8403,8 @@ func (rx *Rx) Create_OverlayCacheNode(ctx context.Context,
 	overlay_cache_node_protocol OverlayCacheNode_Protocol_Field,
 	overlay_cache_node_operator_email OverlayCacheNode_OperatorEmail_Field,
 	overlay_cache_node_operator_wallet OverlayCacheNode_OperatorWallet_Field,
+	overlay_cache_node_operator_wallet_features OverlayCacheNode_OperatorWalletFeatures_Field,
+	overlay_cache_node_tags OverlayCacheNode_Tags_Field,
 	overlay_cache_node_free_bandwidth OverlayCacheNode_FreeBandwidth_Field,
 	overlay_cache_node_free_disk OverlayCacheNode_FreeDisk_Field,
 	overlay_cache_node_latency_90 OverlayCacheNode_Latency90_Field,
@@ -7365,13 +8413,17 @@ func (rx *Rx) Create_OverlayCacheNode(ctx context.Context,
 	overlay_cache_node_audit_count OverlayCacheNode_AuditCount_Field,
 	overlay_cache_node_audit_success_count OverlayCacheNode_AuditSuccessCount_Field,
 	overlay_cache_node_uptime_count OverlayCacheNode_UptimeCount_Field,
-	overlay_cache_node_uptime_success_count OverlayCacheNode_UptimeSuccessCount_Field) (
+	overlay_cache_node_uptime_success_count OverlayCacheNode_UptimeSuccessCount_Field,
+	overlay_cache_node_audit_reputation OverlayCacheNode_AuditReputation_Field,
+	overlay_cache_node_uptime_reputation OverlayCacheNode_UptimeReputation_Field,
+	overlay_cache_node_last_contact_success OverlayCacheNode_LastContactSuccess_Field,
+	overlay_cache_node_last_contact_failure OverlayCacheNode_LastContactFailure_Field) (
 	overlay_cache_node *OverlayCacheNode, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Create_OverlayCacheNode(ctx, overlay_cache_node_node_id, overlay_cache_node_node_type, overlay_cache_node_address, overlay_cache_node_protocol, overlay_cache_node_operator_email, overlay_cache_node_operator_wallet, overlay_cache_node_free_bandwidth, overlay_cache_node_free_disk, overlay_cache_node_latency_90, overlay_cache_node_audit_success_ratio, overlay_cache_node_audit_uptime_ratio, overlay_cache_node_audit_count, overlay_cache_node_audit_success_count, overlay_cache_node_uptime_count, overlay_cache_node_uptime_success_count)
+	return tx.Create_OverlayCacheNode(ctx, overlay_cache_node_node_id, overlay_cache_node_node_type, overlay_cache_node_address, overlay_cache_node_protocol, overlay_cache_node_operator_email, overlay_cache_node_operator_wallet, overlay_cache_node_operator_wallet_features, overlay_cache_node_tags, overlay_cache_node_free_bandwidth, overlay_cache_node_free_disk, overlay_cache_node_latency_90, overlay_cache_node_audit_success_ratio, overlay_cache_node_audit_uptime_ratio, overlay_cache_node_audit_count, overlay_cache_node_audit_success_count, overlay_cache_node_uptime_count, overlay_cache_node_uptime_success_count, overlay_cache_node_audit_reputation, overlay_cache_node_uptime_reputation, overlay_cache_node_last_contact_success, overlay_cache_node_last_contact_failure)
 
 }
 
@@ -7923,9 +8975,19 @@ type Methods interface {
 		node_audit_success_ratio Node_AuditSuccessRatio_Field,
 		node_uptime_success_count Node_UptimeSuccessCount_Field,
 		node_total_uptime_count Node_TotalUptimeCount_Field,
-		node_uptime_ratio Node_UptimeRatio_Field) (
+		node_uptime_ratio Node_UptimeRatio_Field,
+		node_audit_reputation_alpha Node_AuditReputationAlpha_Field,
+		node_audit_reputation_beta Node_AuditReputationBeta_Field,
+		node_uptime_reputation_alpha Node_UptimeReputationAlpha_Field,
+		node_uptime_reputation_beta Node_UptimeReputationBeta_Field) (
 		node *Node, err error)
 
+	Create_NodeEvent(ctx context.Context,
+		node_event_node_id NodeEvent_NodeId_Field,
+		node_event_event_type NodeEvent_EventType_Field,
+		node_event_data NodeEvent_Data_Field) (
+		node_event *NodeEvent, err error)
+
 	Create_OverlayCacheNode(ctx context.Context,
 		overlay_cache_node_node_id OverlayCacheNode_NodeId_Field,
 		overlay_cache_node_node_type OverlayCacheNode_NodeType_Field,
@@ -7933,6 +8995,8 @@ type Methods interface {
 		overlay_cache_node_protocol OverlayCacheNode_Protocol_Field,
 		overlay_cache_node_operator_email OverlayCacheNode_OperatorEmail_Field,
 		overlay_cache_node_operator_wallet OverlayCacheNode_OperatorWallet_Field,
+		overlay_cache_node_operator_wallet_features OverlayCacheNode_OperatorWalletFeatures_Field,
+		overlay_cache_node_tags OverlayCacheNode_Tags_Field,
 		overlay_cache_node_free_bandwidth OverlayCacheNode_FreeBandwidth_Field,
 		overlay_cache_node_free_disk OverlayCacheNode_FreeDisk_Field,
 		overlay_cache_node_latency_90 OverlayCacheNode_Latency90_Field,
@@ -7941,7 +9005,11 @@ type Methods interface {
 		overlay_cache_node_audit_count OverlayCacheNode_AuditCount_Field,
 		overlay_cache_node_audit_success_count OverlayCacheNode_AuditSuccessCount_Field,
 		overlay_cache_node_uptime_count OverlayCacheNode_UptimeCount_Field,
-		overlay_cache_node_uptime_success_count OverlayCacheNode_UptimeSuccessCount_Field) (
+		overlay_cache_node_uptime_success_count OverlayCacheNode_UptimeSuccessCount_Field,
+		overlay_cache_node_audit_reputation OverlayCacheNode_AuditReputation_Field,
+		overlay_cache_node_uptime_reputation OverlayCacheNode_UptimeReputation_Field,
+		overlay_cache_node_last_contact_success OverlayCacheNode_LastContactSuccess_Field,
+		overlay_cache_node_last_contact_failure OverlayCacheNode_LastContactFailure_Field) (
 		overlay_cache_node *OverlayCacheNode, err error)
 
 	Create_Project(ctx context.Context,
@@ -48,6 +48,20 @@ CREATE TABLE irreparabledbs (
 	repair_attempt_count bigint NOT NULL,
 	PRIMARY KEY ( segmentpath )
 );
+CREATE TABLE leases (
+	name text NOT NULL,
+	holder text NOT NULL,
+	expires timestamp with time zone NOT NULL,
+	PRIMARY KEY ( name )
+);
+CREATE TABLE node_events (
+	id bigserial NOT NULL,
+	node_id bytea NOT NULL,
+	event_type integer NOT NULL,
+	data text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE nodes (
 	id bytea NOT NULL,
 	audit_success_count bigint NOT NULL,
@@ -56,6 +70,10 @@ CREATE TABLE nodes (
 	uptime_success_count bigint NOT NULL,
 	total_uptime_count bigint NOT NULL,
 	uptime_ratio double precision NOT NULL,
+	audit_reputation_alpha double precision NOT NULL,
+	audit_reputation_beta double precision NOT NULL,
+	uptime_reputation_alpha double precision NOT NULL,
+	uptime_reputation_beta double precision NOT NULL,
 	created_at timestamp with time zone NOT NULL,
 	updated_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
@@ -67,6 +85,8 @@ CREATE TABLE overlay_cache_nodes (
 	protocol integer NOT NULL,
 	operator_email text NOT NULL,
 	operator_wallet text NOT NULL,
+	operator_wallet_features text NOT NULL,
+	tags bytea NOT NULL,
 	free_bandwidth bigint NOT NULL,
 	free_disk bigint NOT NULL,
 	latency_90 bigint NOT NULL,
@@ -76,6 +96,10 @@ CREATE TABLE overlay_cache_nodes (
 	audit_success_count bigint NOT NULL,
 	uptime_count bigint NOT NULL,
 	uptime_success_count bigint NOT NULL,
+	audit_reputation double precision NOT NULL,
+	uptime_reputation double precision NOT NULL,
+	last_contact_success timestamp with time zone NOT NULL,
+	last_contact_failure timestamp with time zone NOT NULL,
 	PRIMARY KEY ( node_id ),
 	UNIQUE ( node_id )
 );
@@ -87,6 +111,24 @@ CREATE TABLE projects (
 	created_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
 );
+CREATE TABLE project_usages (
+	project_id bytea NOT NULL,
+	stored_bytes bigint NOT NULL,
+	objects bigint NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( project_id )
+);
+CREATE TABLE retry_items (
+	id bigserial NOT NULL,
+	queue text NOT NULL,
+	payload bytea NOT NULL,
+	attempts integer NOT NULL,
+	next_attempt timestamp with time zone NOT NULL,
+	last_error text NOT NULL,
+	dead boolean NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE users (
 	id bytea NOT NULL,
 	first_name text NOT NULL,
@@ -48,6 +48,20 @@ CREATE TABLE irreparabledbs (
 	repair_attempt_count INTEGER NOT NULL,
 	PRIMARY KEY ( segmentpath )
 );
+CREATE TABLE leases (
+	name TEXT NOT NULL,
+	holder TEXT NOT NULL,
+	expires TIMESTAMP NOT NULL,
+	PRIMARY KEY ( name )
+);
+CREATE TABLE node_events (
+	id INTEGER NOT NULL,
+	node_id BLOB NOT NULL,
+	event_type INTEGER NOT NULL,
+	data TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE nodes (
 	id BLOB NOT NULL,
 	audit_success_count INTEGER NOT NULL,
@@ -56,6 +70,10 @@ CREATE TABLE nodes (
 	uptime_success_count INTEGER NOT NULL,
 	total_uptime_count INTEGER NOT NULL,
 	uptime_ratio REAL NOT NULL,
+	audit_reputation_alpha REAL NOT NULL,
+	audit_reputation_beta REAL NOT NULL,
+	uptime_reputation_alpha REAL NOT NULL,
+	uptime_reputation_beta REAL NOT NULL,
 	created_at TIMESTAMP NOT NULL,
 	updated_at TIMESTAMP NOT NULL,
 	PRIMARY KEY ( id )
@@ -67,6 +85,8 @@ CREATE TABLE overlay_cache_nodes (
 	protocol INTEGER NOT NULL,
 	operator_email TEXT NOT NULL,
 	operator_wallet TEXT NOT NULL,
+	operator_wallet_features TEXT NOT NULL,
+	tags BLOB NOT NULL,
 	free_bandwidth INTEGER NOT NULL,
 	free_disk INTEGER NOT NULL,
 	latency_90 INTEGER NOT NULL,
@@ -76,6 +96,10 @@ CREATE TABLE overlay_cache_nodes (
 	audit_success_count INTEGER NOT NULL,
 	uptime_count INTEGER NOT NULL,
 	uptime_success_count INTEGER NOT NULL,
+	audit_reputation REAL NOT NULL,
+	uptime_reputation REAL NOT NULL,
+	last_contact_success TIMESTAMP NOT NULL,
+	last_contact_failure TIMESTAMP NOT NULL,
 	PRIMARY KEY ( node_id ),
 	UNIQUE ( node_id )
 );
@@ -87,6 +111,24 @@ CREATE TABLE projects (
 	created_at TIMESTAMP NOT NULL,
 	PRIMARY KEY ( id )
 );
+CREATE TABLE project_usages (
+	project_id BLOB NOT NULL,
+	stored_bytes INTEGER NOT NULL,
+	objects INTEGER NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( project_id )
+);
+CREATE TABLE retry_items (
+	id INTEGER NOT NULL,
+	queue TEXT NOT NULL,
+	payload BLOB NOT NULL,
+	attempts INTEGER NOT NULL,
+	next_attempt TIMESTAMP NOT NULL,
+	last_error TEXT NOT NULL,
+	dead INTEGER NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE users (
 	id BLOB NOT NULL,
 	first_name TEXT NOT NULL,
@@ -0,0 +1,41 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/utils"
+	"storj.io/storj/satellite"
+)
+
+// overlayDualRead is a satellite database whose overlay cache is being
+// migrated to another database
+type overlayDualRead struct {
+	satellite.DB
+	next satellite.DB
+	log  *zap.Logger
+}
+
+// NewOverlayDualRead returns db with its overlay cache written to next as
+// well and read from both, logging where next diverges from db
+func NewOverlayDualRead(log *zap.Logger, db, next satellite.DB) satellite.DB {
+	return &overlayDualRead{DB: db, next: next, log: log}
+}
+
+// OverlayCache is a getter for overlay cache repository
+func (db *overlayDualRead) OverlayCache() overlay.DB {
+	return overlay.NewDualReadDB(db.log, db.DB.OverlayCache(), db.next.OverlayCache())
+}
+
+// CreateTables creates the tables of both databases
+func (db *overlayDualRead) CreateTables() error {
+	return utils.CombineErrors(db.DB.CreateTables(), db.next.CreateTables())
+}
+
+// Close closes both databases
+func (db *overlayDualRead) Close() error {
+	return utils.CombineErrors(db.DB.Close(), db.next.Close())
+}
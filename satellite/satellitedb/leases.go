@@ -0,0 +1,91 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"storj.io/storj/pkg/lease"
+	"storj.io/storj/pkg/utils"
+	dbx "storj.io/storj/satellite/satellitedb/dbx"
+)
+
+type leases struct {
+	db *dbx.DB
+}
+
+// Acquire takes the lease for holder until expires when it's free, expired
+// by now or already held by holder, and returns whether holder holds it
+func (leases *leases) Acquire(ctx context.Context, name, holder string, now, expires time.Time) (_ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// the condition is checked by the database, so only one replica takes
+	// over an expired lease
+	result, err := leases.db.ExecContext(ctx, leases.db.Rebind(
+		`UPDATE leases SET holder = ?, expires = ? WHERE name = ? AND (holder = ? OR expires < ?)`),
+		holder, expires.UTC(), name, holder, now.UTC())
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+	if updated == 1 {
+		return true, nil
+	}
+
+	_, err = leases.db.ExecContext(ctx, leases.db.Rebind(
+		`INSERT INTO leases (name, holder, expires) VALUES (?, ?, ?)`),
+		name, holder, expires.UTC())
+	if err == nil {
+		return true, nil
+	}
+
+	// the insert fails when another replica holds the lease
+	var current string
+	serr := leases.db.QueryRowContext(ctx, leases.db.Rebind(
+		`SELECT holder FROM leases WHERE name = ?`), name).Scan(&current)
+	if serr == sql.ErrNoRows {
+		return false, Error.Wrap(err)
+	}
+	if serr != nil {
+		return false, Error.Wrap(utils.CombineErrors(err, serr))
+	}
+	return current == holder, nil
+}
+
+// Release gives up the lease when holder holds it
+func (leases *leases) Release(ctx context.Context, name, holder string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = leases.db.ExecContext(ctx, leases.db.Rebind(
+		`DELETE FROM leases WHERE name = ? AND holder = ?`), name, holder)
+	return Error.Wrap(err)
+}
+
+// List returns all leases ordered by name
+func (leases *leases) List(ctx context.Context) (_ []*lease.Lease, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := leases.db.QueryContext(ctx, `SELECT name, holder, expires FROM leases ORDER BY name`)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() {
+		err = utils.CombineErrors(err, rows.Close())
+	}()
+
+	var list []*lease.Lease
+	for rows.Next() {
+		item := &lease.Lease{}
+		if err := rows.Scan(&item.Name, &item.Holder, &item.Expires); err != nil {
+			return nil, Error.Wrap(err)
+		}
+		list = append(list, item)
+	}
+	return list, Error.Wrap(rows.Err())
+}
@@ -16,8 +16,10 @@ import (
 	"storj.io/storj/pkg/bwagreement"
 	"storj.io/storj/pkg/datarepair/irreparable"
 	"storj.io/storj/pkg/datarepair/queue"
+	"storj.io/storj/pkg/lease"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/retryqueue"
 	"storj.io/storj/pkg/statdb"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/satellite"
@@ -48,6 +50,48 @@ type lockedAccounting struct {
 	db accounting.DB
 }
 
+// AddProjectUsage adds to the storage used by the project, negative values free storage
+func (m *lockedAccounting) AddProjectUsage(ctx context.Context, projectID uuid.UUID, storedBytes int64, objects int64) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.AddProjectUsage(ctx, projectID, storedBytes, objects)
+}
+
+// CountRawBefore counts the raw tallies with an interval ending before the time
+func (m *lockedAccounting) CountRawBefore(ctx context.Context, before time.Time) (int64, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.CountRawBefore(ctx, before)
+}
+
+// CountRollupsBefore counts the rollups starting before the time
+func (m *lockedAccounting) CountRollupsBefore(ctx context.Context, before time.Time) (int64, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.CountRollupsBefore(ctx, before)
+}
+
+// DeleteRawBefore deletes the raw tallies with an interval ending before the time
+func (m *lockedAccounting) DeleteRawBefore(ctx context.Context, before time.Time) (int64, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.DeleteRawBefore(ctx, before)
+}
+
+// DeleteRollupsBefore deletes the rollups starting before the time
+func (m *lockedAccounting) DeleteRollupsBefore(ctx context.Context, before time.Time) (int64, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.DeleteRollupsBefore(ctx, before)
+}
+
+// GetProjectUsage returns the storage used by the project, zero when it didn't store anything yet
+func (m *lockedAccounting) GetProjectUsage(ctx context.Context, projectID uuid.UUID) (accounting.ProjectUsage, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetProjectUsage(ctx, projectID)
+}
+
 // GetRaw retrieves all raw tallies
 func (m *lockedAccounting) GetRaw(ctx context.Context) ([]*accounting.Raw, error) {
 	m.Lock()
@@ -55,6 +99,13 @@ func (m *lockedAccounting) GetRaw(ctx context.Context) ([]*accounting.Raw, error
 	return m.db.GetRaw(ctx)
 }
 
+// GetRawBefore retrieves the raw tallies with an interval ending before the time
+func (m *lockedAccounting) GetRawBefore(ctx context.Context, before time.Time) ([]*accounting.Raw, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetRawBefore(ctx, before)
+}
+
 // GetRawSince r retrieves all raw tallies sinces
 func (m *lockedAccounting) GetRawSince(ctx context.Context, latestRollup time.Time) ([]*accounting.Raw, error) {
 	m.Lock()
@@ -62,6 +113,27 @@ func (m *lockedAccounting) GetRawSince(ctx context.Context, latestRollup time.Ti
 	return m.db.GetRawSince(ctx, latestRollup)
 }
 
+// GetRollupsBefore retrieves the rollups starting before the time
+func (m *lockedAccounting) GetRollupsBefore(ctx context.Context, before time.Time) ([]*accounting.Rollup, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetRollupsBefore(ctx, before)
+}
+
+// ImportRaws inserts archived raw tallies, keeping their creation time
+func (m *lockedAccounting) ImportRaws(ctx context.Context, raws []*accounting.Raw) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.ImportRaws(ctx, raws)
+}
+
+// ImportRollups inserts archived rollups
+func (m *lockedAccounting) ImportRollups(ctx context.Context, rollups []*accounting.Rollup) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.ImportRollups(ctx, rollups)
+}
+
 // LastRawTime records the latest last tallied time.
 func (m *lockedAccounting) LastRawTime(ctx context.Context, timestampType string) (time.Time, bool, error) {
 	m.Lock()
@@ -103,6 +175,13 @@ type lockedBandwidthAgreement struct {
 	db bwagreement.DB
 }
 
+// CountAgreementsBefore counts the bandwidth agreements created before specific time.
+func (m *lockedBandwidthAgreement) CountAgreementsBefore(ctx context.Context, a1 time.Time) (int64, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.CountAgreementsBefore(ctx, a1)
+}
+
 // CreateAgreement adds a new bandwidth agreement.
 func (m *lockedBandwidthAgreement) CreateAgreement(ctx context.Context, a1 string, a2 bwagreement.Agreement) error {
 	m.Lock()
@@ -110,6 +189,13 @@ func (m *lockedBandwidthAgreement) CreateAgreement(ctx context.Context, a1 strin
 	return m.db.CreateAgreement(ctx, a1, a2)
 }
 
+// DeleteAgreementsBefore deletes the bandwidth agreements created before specific time.
+func (m *lockedBandwidthAgreement) DeleteAgreementsBefore(ctx context.Context, a1 time.Time) (int64, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.DeleteAgreementsBefore(ctx, a1)
+}
+
 // GetAgreements gets all bandwidth agreements.
 func (m *lockedBandwidthAgreement) GetAgreements(ctx context.Context) ([]bwagreement.Agreement, error) {
 	m.Lock()
@@ -449,6 +535,69 @@ func (m *lockedIrreparable) IncrementRepairAttempts(ctx context.Context, segment
 	return m.db.IncrementRepairAttempts(ctx, segmentInfo)
 }
 
+// Leases returns database for the leases of singleton jobs shared by replicas
+func (m *locked) Leases() lease.DB {
+	m.Lock()
+	defer m.Unlock()
+	return &lockedLeases{m.Locker, m.db.Leases()}
+}
+
+// lockedLeases implements locking wrapper for lease.DB
+type lockedLeases struct {
+	sync.Locker
+	db lease.DB
+}
+
+// Acquire takes the lease for holder until expires when it's free,
+// expired by now or already held by holder, and returns whether holder
+// holds it
+func (m *lockedLeases) Acquire(ctx context.Context, name string, holder string, now time.Time, expires time.Time) (bool, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.Acquire(ctx, name, holder, now, expires)
+}
+
+// List returns all leases ordered by name
+func (m *lockedLeases) List(ctx context.Context) ([]*lease.Lease, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.List(ctx)
+}
+
+// Release gives up the lease when holder holds it
+func (m *lockedLeases) Release(ctx context.Context, name string, holder string) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.Release(ctx, name, holder)
+}
+
+// NodeEvents returns database for node lifecycle events
+func (m *locked) NodeEvents() overlay.EventsDB {
+	m.Lock()
+	defer m.Unlock()
+	return &lockedNodeEvents{m.Locker, m.db.NodeEvents()}
+}
+
+// lockedNodeEvents implements locking wrapper for overlay.EventsDB
+type lockedNodeEvents struct {
+	sync.Locker
+	db overlay.EventsDB
+}
+
+// List returns up to limit events with an id greater than cursor, for all nodes when nodeID is zero
+func (m *lockedNodeEvents) List(ctx context.Context, nodeID storj.NodeID, cursor int64, limit int) ([]*pb.NodeEvent, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.List(ctx, nodeID, cursor, limit)
+}
+
+// Record adds a new event for the node
+func (m *lockedNodeEvents) Record(ctx context.Context, nodeID storj.NodeID, eventType pb.NodeEventType, data string) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.Record(ctx, nodeID, eventType, data)
+}
+
 // OverlayCache returns database for caching overlay information
 func (m *locked) OverlayCache() overlay.DB {
 	m.Lock()
@@ -497,6 +646,20 @@ func (m *lockedOverlayCache) Update(ctx context.Context, value *pb.Node) error {
 	return m.db.Update(ctx, value)
 }
 
+// UpdateBatch updates information of multiple nodes at once
+func (m *lockedOverlayCache) UpdateBatch(ctx context.Context, values []*pb.Node) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.UpdateBatch(ctx, values)
+}
+
+// UpdateLastContact records when contacting the node last succeeded or failed
+func (m *lockedOverlayCache) UpdateLastContact(ctx context.Context, nodeID storj.NodeID, success bool, at time.Time) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.UpdateLastContact(ctx, nodeID, success, at)
+}
+
 //GetWalletAddress gets the node's wallet address
 func (m *lockedOverlayCache) GetWalletAddress(ctx context.Context, id storj.NodeID) (string, error) {
 	m.Lock()
@@ -538,6 +701,71 @@ func (m *lockedRepairQueue) Peekqueue(ctx context.Context, limit int) ([]pb.Inju
 	return m.db.Peekqueue(ctx, limit)
 }
 
+// RetryQueue returns database for durable queues retrying their items
+func (m *locked) RetryQueue() retryqueue.DB {
+	m.Lock()
+	defer m.Unlock()
+	return &lockedRetryQueue{m.Locker, m.db.RetryQueue()}
+}
+
+// lockedRetryQueue implements locking wrapper for retryqueue.DB
+type lockedRetryQueue struct {
+	sync.Locker
+	db retryqueue.DB
+}
+
+// Ack deletes the item
+func (m *lockedRetryQueue) Ack(ctx context.Context, id int64) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.Ack(ctx, id)
+}
+
+// Bury marks the item dead, recording why it failed
+func (m *lockedRetryQueue) Bury(ctx context.Context, id int64, lastError string) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.Bury(ctx, id, lastError)
+}
+
+// Count returns the number of live or dead items of the queue
+func (m *lockedRetryQueue) Count(ctx context.Context, queue string, dead bool) (int64, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.Count(ctx, queue, dead)
+}
+
+// Enqueue adds a payload to the queue, due at the given time
+func (m *lockedRetryQueue) Enqueue(ctx context.Context, queue string, payload []byte, at time.Time) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.Enqueue(ctx, queue, payload, at)
+}
+
+// Lease returns the item of the queue due first by now and postpones it
+// until the lease expires, counting the attempt. Nil is returned when no
+// item is due.
+func (m *lockedRetryQueue) Lease(ctx context.Context, queue string, now time.Time, expires time.Time) (*retryqueue.Item, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.Lease(ctx, queue, now, expires)
+}
+
+// List returns up to limit live or dead items of the queue, the item due
+// first comes first
+func (m *lockedRetryQueue) List(ctx context.Context, queue string, dead bool, limit int) ([]*retryqueue.Item, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.List(ctx, queue, dead, limit)
+}
+
+// Retry postpones the item to the given time, recording why it failed
+func (m *lockedRetryQueue) Retry(ctx context.Context, id int64, at time.Time, lastError string) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.Retry(ctx, id, at, lastError)
+}
+
 // StatDB returns database for storing node statistics
 func (m *locked) StatDB() statdb.DB {
 	m.Lock()
@@ -587,10 +815,10 @@ func (m *lockedStatDB) Update(ctx context.Context, request *statdb.UpdateRequest
 }
 
 // UpdateAuditSuccess updates a single storagenode's audit stats.
-func (m *lockedStatDB) UpdateAuditSuccess(ctx context.Context, nodeID storj.NodeID, auditSuccess bool) (stats *statdb.NodeStats, err error) {
+func (m *lockedStatDB) UpdateAuditSuccess(ctx context.Context, nodeID storj.NodeID, auditSuccess bool, halfLife time.Duration) (stats *statdb.NodeStats, err error) {
 	m.Lock()
 	defer m.Unlock()
-	return m.db.UpdateAuditSuccess(ctx, nodeID, auditSuccess)
+	return m.db.UpdateAuditSuccess(ctx, nodeID, auditSuccess, halfLife)
 }
 
 // UpdateBatch for updating multiple storage nodes' stats.
@@ -601,8 +829,8 @@ func (m *lockedStatDB) UpdateBatch(ctx context.Context, requests []*statdb.Updat
 }
 
 // UpdateUptime updates a single storagenode's uptime stats.
-func (m *lockedStatDB) UpdateUptime(ctx context.Context, nodeID storj.NodeID, isUp bool) (stats *statdb.NodeStats, err error) {
+func (m *lockedStatDB) UpdateUptime(ctx context.Context, nodeID storj.NodeID, isUp bool, halfLife time.Duration) (stats *statdb.NodeStats, err error) {
 	m.Lock()
 	defer m.Unlock()
-	return m.db.UpdateUptime(ctx, nodeID, isUp)
+	return m.db.UpdateUptime(ctx, nodeID, isUp, halfLife)
 }
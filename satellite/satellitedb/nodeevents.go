@@ -0,0 +1,84 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/utils"
+	dbx "storj.io/storj/satellite/satellitedb/dbx"
+)
+
+type nodeEvents struct {
+	db *dbx.DB
+}
+
+// Record adds a new event for the node
+func (events *nodeEvents) Record(ctx context.Context, nodeID storj.NodeID, eventType pb.NodeEventType, data string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return Error.Wrap(recordNodeEvent(ctx, events.db, nodeID, eventType, data))
+}
+
+// List returns up to limit events with an id greater than cursor, for all nodes when nodeID is zero
+func (events *nodeEvents) List(ctx context.Context, nodeID storj.NodeID, cursor int64, limit int) (_ []*pb.NodeEvent, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	query := `SELECT id, node_id, event_type, data, created_at FROM node_events WHERE id > ?`
+	args := []interface{}{cursor}
+	if !nodeID.IsZero() {
+		query += ` AND node_id = ?`
+		args = append(args, nodeID.Bytes())
+	}
+	query += ` ORDER BY id ASC LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := events.db.Query(events.db.Rebind(query), args...)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() {
+		err = utils.CombineErrors(err, rows.Close())
+	}()
+
+	var result []*pb.NodeEvent
+	for rows.Next() {
+		var (
+			event     pb.NodeEvent
+			id        []byte
+			eventType int
+			createdAt time.Time
+		)
+		if err := rows.Scan(&event.Id, &id, &eventType, &event.Data, &createdAt); err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		event.NodeId, err = storj.NodeIDFromBytes(id)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		event.Type = pb.NodeEventType(eventType)
+		event.CreatedAt, err = ptypes.TimestampProto(createdAt)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		result = append(result, &event)
+	}
+	return result, Error.Wrap(rows.Err())
+}
+
+// recordNodeEvent adds a new event using db, which can also be a transaction
+func recordNodeEvent(ctx context.Context, db dbx.Methods, nodeID storj.NodeID, eventType pb.NodeEventType, data string) error {
+	_, err := db.Create_NodeEvent(ctx,
+		dbx.NodeEvent_NodeId(nodeID.Bytes()),
+		dbx.NodeEvent_EventType(int(eventType)),
+		dbx.NodeEvent_Data(data),
+	)
+	return err
+}
@@ -6,7 +6,11 @@ package satellitedb
 import (
 	"context"
 	"database/sql"
+	"strings"
+	"time"
 
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/zeebo/errs"
 
 	"storj.io/storj/pkg/overlay"
@@ -91,8 +95,70 @@ func (cache *overlaycache) Update(ctx context.Context, info *pb.Node) (err error
 		return Error.Wrap(err)
 	}
 
+	if err := updateNode(ctx, tx, info); err != nil {
+		return Error.Wrap(errs.Combine(err, tx.Rollback()))
+	}
+
+	return Error.Wrap(tx.Commit())
+}
+
+// UpdateBatch updates information of multiple nodes inside a single
+// transaction, which also loads the reputation of values without one from
+// statdb and sets it on them
+func (cache *overlaycache) UpdateBatch(ctx context.Context, infos []*pb.Node) (err error) {
+	for _, info := range infos {
+		if info == nil || info.Id.IsZero() {
+			return overlay.ErrEmptyNode
+		}
+	}
+	if len(infos) == 0 {
+		return nil
+	}
+
+	tx, err := cache.db.Open(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	var missing storj.NodeIDList
+	for _, info := range infos {
+		if info.Reputation == nil {
+			missing = append(missing, info.Id)
+		}
+	}
+	if len(missing) > 0 {
+		stats, err := createEntriesIfNotExist(ctx, tx, missing)
+		if err != nil {
+			return Error.Wrap(errs.Combine(err, tx.Rollback()))
+		}
+		for _, info := range infos {
+			if info.Reputation == nil {
+				info.Reputation = overlay.Reputation(stats[info.Id])
+			}
+		}
+	}
+
+	for _, info := range infos {
+		if err := updateNode(ctx, tx, info); err != nil {
+			return Error.Wrap(errs.Combine(err, tx.Rollback()))
+		}
+	}
+
+	return Error.Wrap(tx.Commit())
+}
+
+// updateNode creates or updates the node information using tx
+func updateNode(ctx context.Context, tx *dbx.Tx, info *pb.Node) (err error) {
+	tags := []byte{}
+	if info.Tags != nil {
+		tags, err = proto.Marshal(info.Tags)
+		if err != nil {
+			return err
+		}
+	}
+
 	// TODO: use upsert
-	_, err = tx.Get_OverlayCacheNode_By_NodeId(ctx,
+	existing, err := tx.Get_OverlayCacheNode_By_NodeId(ctx,
 		dbx.OverlayCacheNode_NodeId(info.Id.Bytes()),
 	)
 
@@ -130,6 +196,8 @@ func (cache *overlaycache) Update(ctx context.Context, info *pb.Node) (err error
 
 			dbx.OverlayCacheNode_OperatorEmail(metadata.Email),
 			dbx.OverlayCacheNode_OperatorWallet(metadata.Wallet),
+			dbx.OverlayCacheNode_OperatorWalletFeatures(strings.Join(metadata.WalletFeatures, ",")),
+			dbx.OverlayCacheNode_Tags(tags),
 
 			dbx.OverlayCacheNode_FreeBandwidth(restrictions.FreeBandwidth),
 			dbx.OverlayCacheNode_FreeDisk(restrictions.FreeDisk),
@@ -142,45 +210,95 @@ func (cache *overlaycache) Update(ctx context.Context, info *pb.Node) (err error
 
 			dbx.OverlayCacheNode_UptimeCount(reputation.UptimeCount),
 			dbx.OverlayCacheNode_UptimeSuccessCount(reputation.UptimeSuccessCount),
+			dbx.OverlayCacheNode_AuditReputation(reputation.AuditReputation),
+			dbx.OverlayCacheNode_UptimeReputation(reputation.UptimeReputation),
+
+			// contacts are only recorded through UpdateLastContact
+			dbx.OverlayCacheNode_LastContactSuccess(time.Time{}),
+			dbx.OverlayCacheNode_LastContactFailure(time.Time{}),
 		)
 		if err != nil {
-			return Error.Wrap(errs.Combine(err, tx.Rollback()))
+			return err
 		}
-	} else {
-		update := dbx.OverlayCacheNode_Update_Fields{
-			// TODO: should we be able to update node type?
-			Address:  dbx.OverlayCacheNode_Address(address.Address),
-			Protocol: dbx.OverlayCacheNode_Protocol(int(address.Transport)),
+		return recordNodeEvent(ctx, tx, info.Id, pb.NodeEventType_FIRST_CONTACT, address.Address)
+	}
 
-			Latency90:          dbx.OverlayCacheNode_Latency90(info.Reputation.Latency_90),
-			AuditSuccessRatio:  dbx.OverlayCacheNode_AuditSuccessRatio(info.Reputation.AuditSuccessRatio),
-			AuditUptimeRatio:   dbx.OverlayCacheNode_AuditUptimeRatio(info.Reputation.UptimeRatio),
-			AuditCount:         dbx.OverlayCacheNode_AuditCount(info.Reputation.AuditCount),
-			AuditSuccessCount:  dbx.OverlayCacheNode_AuditSuccessCount(info.Reputation.AuditSuccessCount),
-			UptimeCount:        dbx.OverlayCacheNode_UptimeCount(info.Reputation.UptimeCount),
-			UptimeSuccessCount: dbx.OverlayCacheNode_UptimeSuccessCount(info.Reputation.UptimeSuccessCount),
+	if existing.Address != address.Address {
+		err = recordNodeEvent(ctx, tx, info.Id, pb.NodeEventType_ADDRESS_CHANGED, address.Address)
+		if err != nil {
+			return err
 		}
+	}
 
-		if info.Metadata != nil {
-			update.OperatorEmail = dbx.OverlayCacheNode_OperatorEmail(info.Metadata.Email)
-			update.OperatorWallet = dbx.OverlayCacheNode_OperatorWallet(info.Metadata.Wallet)
-		}
+	update := dbx.OverlayCacheNode_Update_Fields{
+		// TODO: should we be able to update node type?
+		Address:  dbx.OverlayCacheNode_Address(address.Address),
+		Protocol: dbx.OverlayCacheNode_Protocol(int(address.Transport)),
+
+		Latency90:          dbx.OverlayCacheNode_Latency90(info.Reputation.Latency_90),
+		AuditSuccessRatio:  dbx.OverlayCacheNode_AuditSuccessRatio(info.Reputation.AuditSuccessRatio),
+		AuditUptimeRatio:   dbx.OverlayCacheNode_AuditUptimeRatio(info.Reputation.UptimeRatio),
+		AuditCount:         dbx.OverlayCacheNode_AuditCount(info.Reputation.AuditCount),
+		AuditSuccessCount:  dbx.OverlayCacheNode_AuditSuccessCount(info.Reputation.AuditSuccessCount),
+		UptimeCount:        dbx.OverlayCacheNode_UptimeCount(info.Reputation.UptimeCount),
+		UptimeSuccessCount: dbx.OverlayCacheNode_UptimeSuccessCount(info.Reputation.UptimeSuccessCount),
+		AuditReputation:    dbx.OverlayCacheNode_AuditReputation(info.Reputation.AuditReputation),
+		UptimeReputation:   dbx.OverlayCacheNode_UptimeReputation(info.Reputation.UptimeReputation),
+	}
 
-		if info.Restrictions != nil {
-			update.FreeBandwidth = dbx.OverlayCacheNode_FreeBandwidth(restrictions.FreeBandwidth)
-			update.FreeDisk = dbx.OverlayCacheNode_FreeDisk(restrictions.FreeDisk)
-		}
+	if info.Metadata != nil {
+		update.OperatorEmail = dbx.OverlayCacheNode_OperatorEmail(info.Metadata.Email)
+		update.OperatorWallet = dbx.OverlayCacheNode_OperatorWallet(info.Metadata.Wallet)
+		update.OperatorWalletFeatures = dbx.OverlayCacheNode_OperatorWalletFeatures(strings.Join(info.Metadata.WalletFeatures, ","))
+	}
 
-		_, err := tx.Update_OverlayCacheNode_By_NodeId(ctx,
-			dbx.OverlayCacheNode_NodeId(info.Id.Bytes()),
-			update,
-		)
-		if err != nil {
-			return Error.Wrap(errs.Combine(err, tx.Rollback()))
+	if info.Tags != nil {
+		// tags relayed by other nodes may be older than the stored ones
+		var stored pb.SignedNodeTags
+		if err := proto.Unmarshal(existing.Tags, &stored); err != nil {
+			return err
+		}
+		if info.Tags.SignedAt >= stored.SignedAt {
+			update.Tags = dbx.OverlayCacheNode_Tags(tags)
 		}
 	}
 
-	return Error.Wrap(tx.Commit())
+	if info.Restrictions != nil {
+		update.FreeBandwidth = dbx.OverlayCacheNode_FreeBandwidth(restrictions.FreeBandwidth)
+		update.FreeDisk = dbx.OverlayCacheNode_FreeDisk(restrictions.FreeDisk)
+	}
+
+	_, err = tx.Update_OverlayCacheNode_By_NodeId(ctx,
+		dbx.OverlayCacheNode_NodeId(info.Id.Bytes()),
+		update,
+	)
+	return err
+}
+
+// UpdateLastContact records when contacting the node last succeeded or failed
+func (cache *overlaycache) UpdateLastContact(ctx context.Context, id storj.NodeID, success bool, at time.Time) error {
+	if id.IsZero() {
+		return overlay.ErrEmptyNode
+	}
+
+	update := dbx.OverlayCacheNode_Update_Fields{}
+	if success {
+		update.LastContactSuccess = dbx.OverlayCacheNode_LastContactSuccess(at.UTC())
+	} else {
+		update.LastContactFailure = dbx.OverlayCacheNode_LastContactFailure(at.UTC())
+	}
+
+	updated, err := cache.db.Update_OverlayCacheNode_By_NodeId(ctx,
+		dbx.OverlayCacheNode_NodeId(id.Bytes()),
+		update,
+	)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if updated == nil {
+		return overlay.ErrNodeNotFound
+	}
+	return nil
 }
 
 // Delete deletes node based on id
@@ -225,13 +343,38 @@ func convertOverlayNode(info *dbx.OverlayCacheNode) (*pb.Node, error) {
 			AuditSuccessCount:  info.AuditSuccessCount,
 			UptimeCount:        info.UptimeCount,
 			UptimeSuccessCount: info.UptimeSuccessCount,
+			AuditReputation:    info.AuditReputation,
+			UptimeReputation:   info.UptimeReputation,
 		},
 	}
 
+	if info.OperatorWalletFeatures != "" {
+		node.Metadata.WalletFeatures = strings.Split(info.OperatorWalletFeatures, ",")
+	}
+	if len(info.Tags) > 0 {
+		node.Tags = &pb.SignedNodeTags{}
+		if err := proto.Unmarshal(info.Tags, node.Tags); err != nil {
+			return nil, err
+		}
+	}
+
+	if !info.LastContactSuccess.IsZero() {
+		node.LastContactSuccess, err = ptypes.TimestampProto(info.LastContactSuccess)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !info.LastContactFailure.IsZero() {
+		node.LastContactFailure, err = ptypes.TimestampProto(info.LastContactFailure)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if node.Address.Address == "" {
 		node.Address = nil
 	}
-	if node.Metadata.Email == "" && node.Metadata.Wallet == "" {
+	if node.Metadata.Email == "" && node.Metadata.Wallet == "" && len(node.Metadata.WalletFeatures) == 0 {
 		node.Metadata = nil
 	}
 	if node.Restrictions.FreeBandwidth < 0 && node.Restrictions.FreeDisk < 0 {
@@ -0,0 +1,162 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"storj.io/storj/pkg/retryqueue"
+	"storj.io/storj/pkg/utils"
+	dbx "storj.io/storj/satellite/satellitedb/dbx"
+	"storj.io/storj/storage"
+)
+
+type retryQueue struct {
+	db *dbx.DB
+}
+
+const retryItemColumns = `id, queue, payload, attempts, next_attempt, last_error, dead, created_at`
+
+// Enqueue adds a payload to the queue, due at the given time
+func (queue *retryQueue) Enqueue(ctx context.Context, name string, payload []byte, at time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = queue.db.ExecContext(ctx, queue.db.Rebind(
+		`INSERT INTO retry_items (queue, payload, attempts, next_attempt, last_error, dead, created_at)
+		VALUES (?, ?, 0, ?, '', ?, ?)`),
+		name, payload, at.UTC(), false, time.Now().UTC())
+	return Error.Wrap(err)
+}
+
+// Lease returns the item of the queue due first by now and postpones it
+// until the lease expires, counting the attempt. Nil is returned when no item
+// is due.
+func (queue *retryQueue) Lease(ctx context.Context, name string, now, expires time.Time) (_ *retryqueue.Item, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for {
+		item, err := scanRetryItem(queue.db.QueryRowContext(ctx, queue.db.Rebind(
+			`SELECT `+retryItemColumns+` FROM retry_items
+			WHERE queue = ? AND dead = ? AND next_attempt <= ?
+			ORDER BY next_attempt, id LIMIT 1`),
+			name, false, now.UTC()))
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		// the attempts tell whether another worker leased the item meanwhile
+		result, err := queue.db.ExecContext(ctx, queue.db.Rebind(
+			`UPDATE retry_items SET attempts = attempts + 1, next_attempt = ? WHERE id = ? AND attempts = ?`),
+			expires.UTC(), item.ID, item.Attempts)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		updated, err := result.RowsAffected()
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		if updated == 1 {
+			item.Attempts++
+			item.NextAttempt = expires
+			return item, nil
+		}
+	}
+}
+
+// Ack deletes the item
+func (queue *retryQueue) Ack(ctx context.Context, id int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return queue.update(ctx, `DELETE FROM retry_items WHERE id = ?`, id)
+}
+
+// Retry postpones the item to the given time, recording why it failed
+func (queue *retryQueue) Retry(ctx context.Context, id int64, at time.Time, lastError string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return queue.update(ctx, `UPDATE retry_items SET next_attempt = ?, last_error = ? WHERE id = ?`, at.UTC(), lastError, id)
+}
+
+// Bury marks the item dead, recording why it failed
+func (queue *retryQueue) Bury(ctx context.Context, id int64, lastError string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return queue.update(ctx, `UPDATE retry_items SET dead = ?, last_error = ? WHERE id = ?`, true, lastError, id)
+}
+
+// update runs a statement changing the item with id, the last argument
+func (queue *retryQueue) update(ctx context.Context, query string, args ...interface{}) error {
+	result, err := queue.db.ExecContext(ctx, queue.db.Rebind(query), args...)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if updated == 0 {
+		return storage.ErrKeyNotFound.New("retry item %d", args[len(args)-1])
+	}
+	return nil
+}
+
+// List returns up to limit live or dead items of the queue, the item due
+// first comes first
+func (queue *retryQueue) List(ctx context.Context, name string, dead bool, limit int) (_ []*retryqueue.Item, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if limit <= 0 || limit > storage.LookupLimit {
+		limit = storage.LookupLimit
+	}
+
+	rows, err := queue.db.QueryContext(ctx, queue.db.Rebind(
+		`SELECT `+retryItemColumns+` FROM retry_items
+		WHERE queue = ? AND dead = ?
+		ORDER BY next_attempt, id LIMIT ?`),
+		name, dead, limit)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() {
+		err = utils.CombineErrors(err, rows.Close())
+	}()
+
+	var items []*retryqueue.Item
+	for rows.Next() {
+		item, err := scanRetryItem(rows)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		items = append(items, item)
+	}
+	return items, Error.Wrap(rows.Err())
+}
+
+// Count returns the number of live or dead items of the queue
+func (queue *retryQueue) Count(ctx context.Context, name string, dead bool) (count int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = queue.db.QueryRowContext(ctx, queue.db.Rebind(
+		`SELECT COUNT(*) FROM retry_items WHERE queue = ? AND dead = ?`),
+		name, dead).Scan(&count)
+	return count, Error.Wrap(err)
+}
+
+// rowScanner is a single row or the current row of rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanRetryItem scans the retryItemColumns of a row
+func scanRetryItem(row rowScanner) (*retryqueue.Item, error) {
+	item := &retryqueue.Item{}
+	err := row.Scan(&item.ID, &item.Queue, &item.Payload, &item.Attempts, &item.NextAttempt,
+		&item.LastError, &item.Dead, &item.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
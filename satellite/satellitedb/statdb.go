@@ -7,6 +7,7 @@ import (
 	"context"
 	"database/sql"
 	"strings"
+	"time"
 
 	"github.com/zeebo/errs"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
@@ -37,14 +38,28 @@ func getNodeStats(nodeID storj.NodeID, dbNode *dbx.Node) *statdb.NodeStats {
 		UptimeRatio:        dbNode.UptimeRatio,
 		UptimeSuccessCount: dbNode.UptimeSuccessCount,
 		UptimeCount:        dbNode.TotalUptimeCount,
+		AuditReputation:    auditReputation(dbNode).Score(),
+		UptimeReputation:   uptimeReputation(dbNode).Score(),
 	}
 	return nodeStats
 }
 
+func auditReputation(dbNode *dbx.Node) statdb.Reputation {
+	return statdb.Reputation{Alpha: dbNode.AuditReputationAlpha, Beta: dbNode.AuditReputationBeta}
+}
+
+func uptimeReputation(dbNode *dbx.Node) statdb.Reputation {
+	return statdb.Reputation{Alpha: dbNode.UptimeReputationAlpha, Beta: dbNode.UptimeReputationBeta}
+}
+
 // Create a db entry for the provided storagenode
 func (s *statDB) Create(ctx context.Context, nodeID storj.NodeID, startingStats *statdb.NodeStats) (stats *statdb.NodeStats, err error) {
 	defer mon.Task()(&ctx)(&err)
+	return createNodeStats(ctx, s.db, nodeID, startingStats)
+}
 
+// createNodeStats creates the db entry for the provided storagenode using methods
+func createNodeStats(ctx context.Context, methods dbx.Methods, nodeID storj.NodeID, startingStats *statdb.NodeStats) (stats *statdb.NodeStats, err error) {
 	var (
 		totalAuditCount    int64
 		auditSuccessCount  int64
@@ -70,7 +85,10 @@ func (s *statDB) Create(ctx context.Context, nodeID storj.NodeID, startingStats
 		}
 	}
 
-	dbNode, err := s.db.Create_Node(
+	auditRep := statdb.NewReputation(auditSuccessCount, totalAuditCount)
+	uptimeRep := statdb.NewReputation(uptimeSuccessCount, totalUptimeCount)
+
+	dbNode, err := methods.Create_Node(
 		ctx,
 		dbx.Node_Id(nodeID.Bytes()),
 		dbx.Node_AuditSuccessCount(auditSuccessCount),
@@ -79,6 +97,10 @@ func (s *statDB) Create(ctx context.Context, nodeID storj.NodeID, startingStats
 		dbx.Node_UptimeSuccessCount(uptimeSuccessCount),
 		dbx.Node_TotalUptimeCount(totalUptimeCount),
 		dbx.Node_UptimeRatio(uptimeRatio),
+		dbx.Node_AuditReputationAlpha(auditRep.Alpha),
+		dbx.Node_AuditReputationBeta(auditRep.Beta),
+		dbx.Node_UptimeReputationAlpha(uptimeRep.Alpha),
+		dbx.Node_UptimeReputationBeta(uptimeRep.Beta),
 	)
 	if err != nil {
 		return nil, Error.Wrap(err)
@@ -107,6 +129,7 @@ func (s *statDB) FindInvalidNodes(ctx context.Context, nodeIDs storj.NodeIDList,
 
 	var invalidIds storj.NodeIDList
 
+	// reputations are compared against the decayed success ratios, see statdb.Reputation
 	maxAuditSuccess := maxStats.AuditSuccessRatio
 	maxUptime := maxStats.UptimeRatio
 
@@ -150,8 +173,8 @@ func (s *statDB) findInvalidNodesQuery(nodeIds storj.NodeIDList, auditSuccess, u
 		AND nodes.total_audit_count > 0
 		AND nodes.total_uptime_count > 0
 		AND (
-			nodes.audit_success_ratio < ?
-			OR nodes.uptime_ratio < ?
+			nodes.audit_reputation_alpha < ? * (nodes.audit_reputation_alpha + nodes.audit_reputation_beta)
+			OR nodes.uptime_reputation_alpha < ? * (nodes.uptime_reputation_alpha + nodes.uptime_reputation_beta)
 		)`), args...)
 
 	return rows, err
@@ -191,13 +214,21 @@ func (s *statDB) Update(ctx context.Context, updateReq *statdb.UpdateRequest) (s
 		totalUptimeCount,
 	)
 
+	elapsed := time.Since(dbNode.UpdatedAt)
+	auditRep := auditReputation(dbNode).Update(updateReq.AuditSuccess, elapsed, updateReq.HalfLife)
+	uptimeRep := uptimeReputation(dbNode).Update(updateReq.IsUp, elapsed, updateReq.HalfLife)
+
 	updateFields := dbx.Node_Update_Fields{
-		AuditSuccessCount:  dbx.Node_AuditSuccessCount(auditSuccessCount),
-		TotalAuditCount:    dbx.Node_TotalAuditCount(totalAuditCount),
-		AuditSuccessRatio:  dbx.Node_AuditSuccessRatio(auditSuccessRatio),
-		UptimeSuccessCount: dbx.Node_UptimeSuccessCount(uptimeSuccessCount),
-		TotalUptimeCount:   dbx.Node_TotalUptimeCount(totalUptimeCount),
-		UptimeRatio:        dbx.Node_UptimeRatio(uptimeRatio),
+		AuditSuccessCount:     dbx.Node_AuditSuccessCount(auditSuccessCount),
+		TotalAuditCount:       dbx.Node_TotalAuditCount(totalAuditCount),
+		AuditSuccessRatio:     dbx.Node_AuditSuccessRatio(auditSuccessRatio),
+		UptimeSuccessCount:    dbx.Node_UptimeSuccessCount(uptimeSuccessCount),
+		TotalUptimeCount:      dbx.Node_TotalUptimeCount(totalUptimeCount),
+		UptimeRatio:           dbx.Node_UptimeRatio(uptimeRatio),
+		AuditReputationAlpha:  dbx.Node_AuditReputationAlpha(auditRep.Alpha),
+		AuditReputationBeta:   dbx.Node_AuditReputationBeta(auditRep.Beta),
+		UptimeReputationAlpha: dbx.Node_UptimeReputationAlpha(uptimeRep.Alpha),
+		UptimeReputationBeta:  dbx.Node_UptimeReputationBeta(uptimeRep.Beta),
 	}
 
 	updateFields.UptimeSuccessCount = dbx.Node_UptimeSuccessCount(uptimeSuccessCount)
@@ -214,7 +245,7 @@ func (s *statDB) Update(ctx context.Context, updateReq *statdb.UpdateRequest) (s
 }
 
 // UpdateUptime updates a single storagenode's uptime stats in the db
-func (s *statDB) UpdateUptime(ctx context.Context, nodeID storj.NodeID, isUp bool) (stats *statdb.NodeStats, err error) {
+func (s *statDB) UpdateUptime(ctx context.Context, nodeID storj.NodeID, isUp bool, halfLife time.Duration) (stats *statdb.NodeStats, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	tx, err := s.db.Open(ctx)
@@ -242,6 +273,15 @@ func (s *statDB) UpdateUptime(ctx context.Context, nodeID storj.NodeID, isUp boo
 	updateFields.TotalUptimeCount = dbx.Node_TotalUptimeCount(totalUptimeCount)
 	updateFields.UptimeRatio = dbx.Node_UptimeRatio(uptimeRatio)
 
+	// decay the audit reputation as well, so both share the same update time
+	elapsed := time.Since(dbNode.UpdatedAt)
+	auditRep := auditReputation(dbNode).Decay(elapsed, halfLife)
+	uptimeRep := uptimeReputation(dbNode).Update(isUp, elapsed, halfLife)
+	updateFields.AuditReputationAlpha = dbx.Node_AuditReputationAlpha(auditRep.Alpha)
+	updateFields.AuditReputationBeta = dbx.Node_AuditReputationBeta(auditRep.Beta)
+	updateFields.UptimeReputationAlpha = dbx.Node_UptimeReputationAlpha(uptimeRep.Alpha)
+	updateFields.UptimeReputationBeta = dbx.Node_UptimeReputationBeta(uptimeRep.Beta)
+
 	dbNode, err = tx.Update_Node_By_Id(ctx, dbx.Node_Id(nodeID.Bytes()), updateFields)
 	if err != nil {
 		return nil, Error.Wrap(utils.CombineErrors(err, tx.Rollback()))
@@ -252,7 +292,7 @@ func (s *statDB) UpdateUptime(ctx context.Context, nodeID storj.NodeID, isUp boo
 }
 
 // UpdateAuditSuccess updates a single storagenode's uptime stats in the db
-func (s *statDB) UpdateAuditSuccess(ctx context.Context, nodeID storj.NodeID, auditSuccess bool) (stats *statdb.NodeStats, err error) {
+func (s *statDB) UpdateAuditSuccess(ctx context.Context, nodeID storj.NodeID, auditSuccess bool, halfLife time.Duration) (stats *statdb.NodeStats, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	tx, err := s.db.Open(ctx)
@@ -280,6 +320,15 @@ func (s *statDB) UpdateAuditSuccess(ctx context.Context, nodeID storj.NodeID, au
 	updateFields.TotalAuditCount = dbx.Node_TotalAuditCount(totalAuditCount)
 	updateFields.AuditSuccessRatio = dbx.Node_AuditSuccessRatio(auditRatio)
 
+	// decay the uptime reputation as well, so both share the same update time
+	elapsed := time.Since(dbNode.UpdatedAt)
+	auditRep := auditReputation(dbNode).Update(auditSuccess, elapsed, halfLife)
+	uptimeRep := uptimeReputation(dbNode).Decay(elapsed, halfLife)
+	updateFields.AuditReputationAlpha = dbx.Node_AuditReputationAlpha(auditRep.Alpha)
+	updateFields.AuditReputationBeta = dbx.Node_AuditReputationBeta(auditRep.Beta)
+	updateFields.UptimeReputationAlpha = dbx.Node_UptimeReputationAlpha(uptimeRep.Alpha)
+	updateFields.UptimeReputationBeta = dbx.Node_UptimeReputationBeta(uptimeRep.Beta)
+
 	dbNode, err = tx.Update_Node_By_Id(ctx, dbx.Node_Id(nodeID.Bytes()), updateFields)
 	if err != nil {
 		return nil, Error.Wrap(utils.CombineErrors(err, tx.Rollback()))
@@ -333,6 +382,78 @@ func (s *statDB) CreateEntryIfNotExists(ctx context.Context, nodeID storj.NodeID
 	return getStats, nil
 }
 
+// statsQueryBatch is the number of nodes whose stats are queried at once,
+// staying below the limit of query parameters of sqlite
+const statsQueryBatch = 500
+
+// createEntriesIfNotExist returns the stats of the nodes using tx, creating
+// the entries of nodes which don't have one yet
+func createEntriesIfNotExist(ctx context.Context, tx *dbx.Tx, nodeIDs storj.NodeIDList) (_ map[storj.NodeID]*statdb.NodeStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	stats := make(map[storj.NodeID]*statdb.NodeStats, len(nodeIDs))
+	for start := 0; start < len(nodeIDs); start += statsQueryBatch {
+		end := start + statsQueryBatch
+		if end > len(nodeIDs) {
+			end = len(nodeIDs)
+		}
+		if err := getNodeStatsBatch(ctx, tx, nodeIDs[start:end], stats); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, nodeID := range nodeIDs {
+		if _, ok := stats[nodeID]; ok {
+			continue
+		}
+		stats[nodeID], err = createNodeStats(ctx, tx, nodeID, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stats, nil
+}
+
+// getNodeStatsBatch adds the stats of the existing entries of nodeIDs to stats
+func getNodeStatsBatch(ctx context.Context, tx *dbx.Tx, nodeIDs storj.NodeIDList, stats map[storj.NodeID]*statdb.NodeStats) (err error) {
+	args := make([]interface{}, len(nodeIDs))
+	for i, id := range nodeIDs {
+		args[i] = id.Bytes()
+	}
+
+	rows, err := tx.Tx.QueryContext(ctx, tx.Rebind(`SELECT nodes.id,
+		nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio,
+		nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio,
+		nodes.audit_reputation_alpha, nodes.audit_reputation_beta,
+		nodes.uptime_reputation_alpha, nodes.uptime_reputation_beta
+		FROM nodes
+		WHERE nodes.id IN (?`+strings.Repeat(", ?", len(nodeIDs)-1)+`)`), args...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = utils.CombineErrors(err, rows.Close())
+	}()
+
+	for rows.Next() {
+		node := &dbx.Node{}
+		err = rows.Scan(&node.Id,
+			&node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio,
+			&node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio,
+			&node.AuditReputationAlpha, &node.AuditReputationBeta,
+			&node.UptimeReputationAlpha, &node.UptimeReputationBeta)
+		if err != nil {
+			return err
+		}
+		id, err := storj.NodeIDFromBytes(node.Id)
+		if err != nil {
+			return err
+		}
+		stats[id] = getNodeStats(id, node)
+	}
+	return rows.Err()
+}
+
 func updateRatioVars(newStatus bool, successCount, totalCount int64) (int64, int64, float64) {
 	totalCount++
 	if newStatus {
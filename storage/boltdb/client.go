@@ -29,12 +29,33 @@ const (
 	defaultTimeout = 1 * time.Second
 )
 
+// Options are the tuning options of a BoltDB
+type Options struct {
+	// Timeout is how long opening waits for the file lock, defaults to a second
+	Timeout time.Duration
+	// NoSync disables syncing after every commit
+	NoSync bool
+	// NoGrowSync disables syncing when the file grows
+	NoGrowSync bool
+}
+
 // New instantiates a new BoltDB client given db file path, and a bucket name
 func New(path, bucket string) (*Client, error) {
-	db, err := bolt.Open(path, fileMode, &bolt.Options{Timeout: defaultTimeout})
+	return NewWithOptions(path, bucket, Options{})
+}
+
+// NewWithOptions instantiates a new BoltDB client given db file path, a
+// bucket name and tuning options
+func NewWithOptions(path, bucket string, options Options) (*Client, error) {
+	if options.Timeout == 0 {
+		options.Timeout = defaultTimeout
+	}
+
+	db, err := bolt.Open(path, fileMode, &bolt.Options{Timeout: options.Timeout, NoGrowSync: options.NoGrowSync})
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
+	db.NoSync = options.NoSync
 
 	err = Error.Wrap(db.Update(func(tx *bolt.Tx) error {
 		_, err = tx.CreateBucketIfNotExists([]byte(bucket))
@@ -149,6 +170,47 @@ func (client *Client) Delete(key storage.Key) error {
 	})
 }
 
+// CompareAndSwap atomically replaces the value of key with newValue when it is
+// oldValue, a nil oldValue expects key to be missing and a nil newValue
+// deletes key
+func (client *Client) CompareAndSwap(key storage.Key, oldValue, newValue storage.Value) error {
+	if key.IsZero() {
+		return storage.ErrEmptyKey.New("")
+	}
+
+	return client.update(func(bucket *bolt.Bucket) error {
+		data := bucket.Get([]byte(key))
+		if (data == nil) != (oldValue == nil) || !bytes.Equal(data, oldValue) {
+			return storage.ErrValueChanged.New("%s", key)
+		}
+		if newValue == nil {
+			return bucket.Delete(key)
+		}
+		return bucket.Put(key, newValue)
+	})
+}
+
+// ApplyBatch applies all puts and deletes of batch in a single transaction
+func (client *Client) ApplyBatch(batch storage.Batch) error {
+	if err := batch.Validate(); err != nil {
+		return err
+	}
+
+	return client.update(func(bucket *bolt.Bucket) error {
+		for _, key := range batch.Deletes {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		for _, item := range batch.Puts {
+			if err := bucket.Put(item.Key, item.Value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // List returns either a list of keys for which boltdb has values or an error.
 func (client *Client) List(first storage.Key, limit int) (storage.Keys, error) {
 	rv, err := storage.ListKeys(client, first, limit)
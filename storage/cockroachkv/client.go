@@ -0,0 +1,365 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cockroachkv
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/storage"
+)
+
+const (
+	defaultBatchSize = 1000
+	defaultBucket    = ""
+
+	// maxRetries is how often statements and transactions are retried after
+	// serialization failures
+	maxRetries = 10
+)
+
+// schema sets up the table of the store. Unlike postgreskv it doesn't define
+// functions, as cockroach doesn't support them; directories are collapsed
+// by the iterator instead.
+const schema = `
+	CREATE TABLE IF NOT EXISTS pathdata (
+		bucket BYTES NOT NULL,
+		fullpath BYTES NOT NULL CHECK (fullpath <> b''),
+		metadata BYTES NOT NULL,
+		PRIMARY KEY (bucket, fullpath)
+	)
+`
+
+// Client is the entrypoint into a cockroachkv data store. Cockroach speaks
+// the postgres wire protocol, so it's accessed with the postgres driver.
+type Client struct {
+	URL string
+	db  *sql.DB
+}
+
+// New instantiates a new cockroachkv client given a postgres:// URL of the
+// cockroach database
+func New(dbURL string) (*Client, error) {
+	db, err := sql.Open("postgres", dbURL)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	client := &Client{URL: dbURL, db: db}
+	if err := client.retry(func() error {
+		_, err := db.Exec(schema)
+		return err
+	}); err != nil {
+		return nil, Error.Wrap(errs.Combine(err, db.Close()))
+	}
+	return client, nil
+}
+
+// DB returns the underlying database, e.g. to configure its connection pool
+func (client *Client) DB() *sql.DB {
+	return client.db
+}
+
+// isRetryable returns whether err is a serialization failure, after which
+// cockroach expects the transaction to be retried
+func isRetryable(err error) bool {
+	pqErr, ok := errs.Unwrap(err).(*pq.Error)
+	return ok && pqErr.Code == "40001"
+}
+
+// retry runs the single statement of fn until it doesn't fail with a
+// serialization failure. Cockroach retries most implicit transactions by
+// itself, but not the ones whose results were already sent to the client.
+func (client *Client) retry(fn func() error) (err error) {
+	for i := 0; i < maxRetries; i++ {
+		err = fn()
+		if !isRetryable(err) {
+			return err
+		}
+		mon.Event("cockroachkv_retry")
+	}
+	return Error.New("giving up after %d retries: %v", maxRetries, err)
+}
+
+// withTx runs fn in a transaction. On serialization failures fn is retried
+// within the same transaction using the cockroach_restart savepoint, which
+// keeps the priority of the transaction so it eventually wins conflicts.
+func (client *Client) withTx(fn func(tx *sql.Tx) error) (err error) {
+	tx, err := client.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errs.Combine(err, tx.Rollback())
+		}
+	}()
+
+	if _, err := tx.Exec("SAVEPOINT cockroach_restart"); err != nil {
+		return err
+	}
+	for i := 0; i < maxRetries; i++ {
+		err = fn(tx)
+		if err == nil {
+			_, err = tx.Exec("RELEASE SAVEPOINT cockroach_restart")
+			if err == nil {
+				return tx.Commit()
+			}
+		}
+		if !isRetryable(err) {
+			return err
+		}
+
+		mon.Event("cockroachkv_retry")
+		if _, err := tx.Exec("ROLLBACK TO SAVEPOINT cockroach_restart"); err != nil {
+			return err
+		}
+	}
+	return Error.New("giving up after %d retries: %v", maxRetries, err)
+}
+
+// Put sets the value for the provided key.
+func (client *Client) Put(key storage.Key, value storage.Value) error {
+	return client.PutPath(storage.Key(defaultBucket), key, value)
+}
+
+// PutPath sets the value for the provided key (in the given bucket).
+func (client *Client) PutPath(bucket, key storage.Key, value storage.Value) error {
+	if key.IsZero() {
+		return storage.ErrEmptyKey.New("")
+	}
+	q := "UPSERT INTO pathdata (bucket, fullpath, metadata) VALUES ($1, $2, $3)"
+	return client.retry(func() error {
+		_, err := client.db.Exec(q, []byte(bucket), []byte(key), []byte(value))
+		return err
+	})
+}
+
+// Get looks up the provided key and returns its value (or an error).
+func (client *Client) Get(key storage.Key) (storage.Value, error) {
+	return client.GetPath(storage.Key(defaultBucket), key)
+}
+
+// GetPath looks up the provided key (in the given bucket) and returns its value (or an error).
+func (client *Client) GetPath(bucket, key storage.Key) (storage.Value, error) {
+	if key.IsZero() {
+		return nil, storage.ErrEmptyKey.New("")
+	}
+
+	q := "SELECT metadata FROM pathdata WHERE bucket = $1 AND fullpath = $2"
+	var value []byte
+	err := client.retry(func() error {
+		return client.db.QueryRow(q, []byte(bucket), []byte(key)).Scan(&value)
+	})
+	if err == sql.ErrNoRows {
+		return nil, storage.ErrKeyNotFound.New("%s", key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// GetAll finds all values for the provided keys (up to storage.LookupLimit).
+// If more keys are provided than the maximum, an error will be returned.
+func (client *Client) GetAll(keys storage.Keys) (storage.Values, error) {
+	return client.GetAllPath(storage.Key(defaultBucket), keys)
+}
+
+// GetAllPath finds all values for the provided keys (up to storage.LookupLimit)
+// in the given bucket. if more keys are provided than the maximum, an error
+// will be returned.
+func (client *Client) GetAllPath(bucket storage.Key, keys storage.Keys) (storage.Values, error) {
+	if len(keys) > storage.LookupLimit {
+		return nil, storage.ErrLimitExceeded
+	}
+	values := make(storage.Values, len(keys))
+	if len(keys) == 0 {
+		return values, nil
+	}
+
+	in, args := inList(2, keys)
+	q := "SELECT fullpath, metadata FROM pathdata WHERE bucket = $1 AND fullpath IN (" + in + ")"
+	found := make(map[string][]byte, len(keys))
+	err := client.retry(func() (err error) {
+		rows, err := client.db.Query(q, append([]interface{}{[]byte(bucket)}, args...)...)
+		if err != nil {
+			return err
+		}
+		defer func() { err = errs.Combine(err, rows.Close()) }()
+
+		for rows.Next() {
+			var key, value []byte
+			if err := rows.Scan(&key, &value); err != nil {
+				return err
+			}
+			found[string(key)] = value
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	for i, key := range keys {
+		if value, ok := found[string(key)]; ok {
+			values[i] = storage.Value(value)
+		}
+	}
+	return values, nil
+}
+
+// inList returns the placeholders of keys starting at $first and the keys
+// as arguments, as cockroach doesn't parse the bytea arrays of lib/pq
+func inList(first int, keys storage.Keys) (string, []interface{}) {
+	placeholders := make([]string, len(keys))
+	args := make([]interface{}, len(keys))
+	for i, key := range keys {
+		placeholders[i] = fmt.Sprintf("$%d", first+i)
+		args[i] = []byte(key)
+	}
+	return strings.Join(placeholders, ", "), args
+}
+
+// Delete deletes the given key and its associated value.
+func (client *Client) Delete(key storage.Key) error {
+	return client.DeletePath(storage.Key(defaultBucket), key)
+}
+
+// DeletePath deletes the given key (in the given bucket) and its associated value.
+func (client *Client) DeletePath(bucket, key storage.Key) error {
+	if key.IsZero() {
+		return storage.ErrEmptyKey.New("")
+	}
+
+	q := "DELETE FROM pathdata WHERE bucket = $1 AND fullpath = $2"
+	var result sql.Result
+	err := client.retry(func() (err error) {
+		result, err = client.db.Exec(q, []byte(bucket), []byte(key))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	numRows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if numRows == 0 {
+		return storage.ErrKeyNotFound.New("%s", key)
+	}
+	return nil
+}
+
+// CompareAndSwap atomically replaces the value of key with newValue when it is
+// oldValue, a nil oldValue expects key to be missing and a nil newValue
+// deletes key
+func (client *Client) CompareAndSwap(key storage.Key, oldValue, newValue storage.Value) error {
+	return client.CompareAndSwapPath(storage.Key(defaultBucket), key, oldValue, newValue)
+}
+
+// CompareAndSwapPath is CompareAndSwap for the key in the given bucket
+func (client *Client) CompareAndSwapPath(bucket, key storage.Key, oldValue, newValue storage.Value) error {
+	if key.IsZero() {
+		return storage.ErrEmptyKey.New("")
+	}
+
+	var q string
+	args := []interface{}{[]byte(bucket), []byte(key)}
+	switch {
+	case oldValue == nil && newValue == nil:
+		_, err := client.GetPath(bucket, key)
+		if err == nil {
+			return storage.ErrValueChanged.New("%s", key)
+		}
+		if storage.ErrKeyNotFound.Has(err) {
+			return nil
+		}
+		return err
+	case oldValue == nil:
+		q = `
+			INSERT INTO pathdata (bucket, fullpath, metadata) VALUES ($1, $2, $3)
+				ON CONFLICT (bucket, fullpath) DO NOTHING
+		`
+		args = append(args, []byte(newValue))
+	case newValue == nil:
+		q = "DELETE FROM pathdata WHERE bucket = $1 AND fullpath = $2 AND metadata = $3"
+		args = append(args, []byte(oldValue))
+	default:
+		q = "UPDATE pathdata SET metadata = $4 WHERE bucket = $1 AND fullpath = $2 AND metadata = $3"
+		args = append(args, []byte(oldValue), []byte(newValue))
+	}
+
+	var result sql.Result
+	err := client.retry(func() (err error) {
+		result, err = client.db.Exec(q, args...)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	numRows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if numRows == 0 {
+		return storage.ErrValueChanged.New("%s", key)
+	}
+	return nil
+}
+
+// ApplyBatch applies all puts and deletes of batch in a single transaction
+func (client *Client) ApplyBatch(batch storage.Batch) error {
+	return client.ApplyBatchPath(storage.Key(defaultBucket), batch)
+}
+
+// ApplyBatchPath is ApplyBatch for the keys in the given bucket
+func (client *Client) ApplyBatchPath(bucket storage.Key, batch storage.Batch) error {
+	if err := batch.Validate(); err != nil {
+		return err
+	}
+
+	return client.withTx(func(tx *sql.Tx) error {
+		if len(batch.Deletes) > 0 {
+			in, args := inList(2, batch.Deletes)
+			q := "DELETE FROM pathdata WHERE bucket = $1 AND fullpath IN (" + in + ")"
+			if _, err := tx.Exec(q, append([]interface{}{[]byte(bucket)}, args...)...); err != nil {
+				return err
+			}
+		}
+		q := "UPSERT INTO pathdata (bucket, fullpath, metadata) VALUES ($1, $2, $3)"
+		for _, item := range batch.Puts {
+			if _, err := tx.Exec(q, []byte(bucket), []byte(item.Key), []byte(item.Value)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// List returns either a list of known keys, in order, or an error.
+func (client *Client) List(first storage.Key, limit int) (storage.Keys, error) {
+	return storage.ListKeys(client, first, limit)
+}
+
+// ReverseList returns either a list of known keys, in reverse order, or an error.
+// Starts from first and iterates backwards
+func (client *Client) ReverseList(first storage.Key, limit int) (storage.Keys, error) {
+	return storage.ReverseListKeys(client, first, limit)
+}
+
+// Iterate iterates over items based on opts
+func (client *Client) Iterate(opts storage.IterateOptions, fn func(storage.Iterator) error) error {
+	it := newIterator(client, storage.Key(defaultBucket), opts, defaultBatchSize)
+	return errs.Combine(fn(it), it.err)
+}
+
+// Close closes the client
+func (client *Client) Close() error {
+	return client.db.Close()
+}
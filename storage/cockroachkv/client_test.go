@@ -0,0 +1,56 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cockroachkv
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/storage/storelogger"
+	"storj.io/storj/storage/testsuite"
+)
+
+const (
+	// this connstring is expected to work with a local insecure cockroach node
+	defaultCockroachConn = "postgres://root@localhost:26257/teststorj?sslmode=disable"
+)
+
+var (
+	testCockroach = flag.String("cockroach-test-db", os.Getenv("STORJ_COCKROACH_TEST"), "CockroachDB test database connection string")
+)
+
+func newTestCockroach(t testing.TB) (store *Client, cleanup func()) {
+	if *testCockroach == "" {
+		t.Skipf("cockroach flag missing, example:\n-cockroach-test-db=%s", defaultCockroachConn)
+	}
+
+	db, err := New(*testCockroach)
+	if err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	return db, func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("failed to close db: %v", err)
+		}
+	}
+}
+
+func TestSuite(t *testing.T) {
+	store, cleanup := newTestCockroach(t)
+	defer cleanup()
+
+	zap := zaptest.NewLogger(t)
+	testsuite.RunTests(t, storelogger.New(zap, store))
+}
+
+func BenchmarkSuite(b *testing.B) {
+	store, cleanup := newTestCockroach(b)
+	defer cleanup()
+
+	testsuite.RunBenchmarks(b, store)
+}
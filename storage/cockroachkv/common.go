@@ -0,0 +1,15 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cockroachkv
+
+import (
+	"github.com/zeebo/errs"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+)
+
+var (
+	// Error is the default cockroachkv errs class
+	Error = errs.Class("cockroachkv error")
+	mon   = monkit.Package()
+)
@@ -0,0 +1,145 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cockroachkv
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/storage"
+)
+
+// iterator iterates over the keys of a bucket in batches. Without Recurse
+// the keys of a directory are collapsed into the directory, and the next
+// batch is queried past the directory, so directories with many keys are
+// skipped without reading them.
+type iterator struct {
+	client    *Client
+	bucket    storage.Key
+	opts      storage.IterateOptions
+	batchSize int
+
+	// lower is the inclusive lower bound and upper the exclusive upper bound
+	// of the keys left to iterate, a nil upper is unbounded
+	lower storage.Key
+	upper storage.Key
+
+	items storage.Items
+	done  bool
+	skip  storage.Key // the directory whose keys are skipped
+	err   error
+}
+
+func newIterator(client *Client, bucket storage.Key, opts storage.IterateOptions, batchSize int) *iterator {
+	it := &iterator{
+		client:    client,
+		bucket:    bucket,
+		opts:      opts,
+		batchSize: batchSize,
+		lower:     storage.CloneKey(opts.Prefix),
+	}
+	if !opts.Prefix.IsZero() {
+		it.upper = storage.AfterPrefix(opts.Prefix)
+	}
+	if !opts.First.IsZero() {
+		if !opts.Reverse {
+			if it.lower.Less(opts.First) {
+				it.lower = storage.CloneKey(opts.First)
+			}
+		} else {
+			if after := storage.NextKey(opts.First); it.upper == nil || after.Less(it.upper) {
+				it.upper = after
+			}
+		}
+	}
+	return it
+}
+
+// Next fills in info for the next item in an ongoing listing.
+func (it *iterator) Next(item *storage.ListItem) bool {
+	for {
+		if len(it.items) == 0 {
+			if it.done || it.err != nil {
+				return false
+			}
+			if it.err = it.fetch(); it.err != nil {
+				return false
+			}
+			continue
+		}
+
+		next := it.items[0]
+		it.items = it.items[1:]
+		if it.skip != nil && bytes.HasPrefix(next.Key, it.skip) {
+			continue
+		}
+
+		if !it.opts.Reverse {
+			it.lower = storage.NextKey(next.Key)
+		} else {
+			it.upper = next.Key
+		}
+
+		if !it.opts.Recurse {
+			if p := bytes.IndexByte(next.Key[len(it.opts.Prefix):], storage.Delimiter); p >= 0 {
+				dir := next.Key[:len(it.opts.Prefix)+p+1]
+				it.skip = dir
+				if !it.opts.Reverse {
+					it.lower = storage.AfterPrefix(dir)
+				} else {
+					it.upper = dir
+				}
+				*item = storage.ListItem{Key: dir, IsPrefix: true}
+				return true
+			}
+		}
+
+		*item = next
+		return true
+	}
+}
+
+// fetch queries the next batch of keys within the bounds
+func (it *iterator) fetch() error {
+	q := "SELECT fullpath, metadata FROM pathdata WHERE bucket = $1 AND fullpath >= $2"
+	args := []interface{}{[]byte(it.bucket), append([]byte{}, it.lower...)}
+	if it.upper != nil {
+		q += " AND fullpath < $3"
+		args = append(args, []byte(it.upper))
+	}
+	if !it.opts.Reverse {
+		q += " ORDER BY fullpath"
+	} else {
+		q += " ORDER BY fullpath DESC"
+	}
+	q += fmt.Sprintf(" LIMIT %d", it.batchSize)
+
+	var items storage.Items
+	err := it.client.retry(func() (err error) {
+		items = items[:0]
+		rows, err := it.client.db.Query(q, args...)
+		if err != nil {
+			return err
+		}
+		defer func() { err = errs.Combine(err, rows.Close()) }()
+
+		for rows.Next() {
+			var key, value []byte
+			if err := rows.Scan(&key, &value); err != nil {
+				return err
+			}
+			items = append(items, storage.ListItem{Key: key, Value: value})
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	it.items = items
+	it.done = len(items) < it.batchSize
+	return nil
+}
@@ -19,6 +19,10 @@ var ErrKeyNotFound = errs.Class("key not found")
 // ErrEmptyKey is returned when an empty key is used in Put
 var ErrEmptyKey = errs.Class("empty key")
 
+// ErrValueChanged is returned when the current value of the key doesn't
+// match the expected value in CompareAndSwap
+var ErrValueChanged = errs.Class("value changed")
+
 // ErrEmptyQueue is returned when attempting to Dequeue from an empty queue
 var ErrEmptyQueue = errs.Class("empty queue")
 
@@ -60,6 +64,12 @@ type KeyValueStore interface {
 	GetAll(Keys) (Values, error)
 	// Delete deletes key and the value
 	Delete(Key) error
+	// CompareAndSwap atomically replaces the value of key with newValue when
+	// it is oldValue, otherwise it fails with ErrValueChanged. A nil oldValue
+	// expects key to be missing, a nil newValue deletes key.
+	CompareAndSwap(key Key, oldValue, newValue Value) error
+	// ApplyBatch applies all puts and deletes of batch atomically
+	ApplyBatch(batch Batch) error
 	// List lists all keys starting from start and upto limit items
 	List(start Key, limit int) (Keys, error)
 	// ReverseList lists all keys in revers order
@@ -82,6 +92,32 @@ type Queue interface {
 	Close() error
 }
 
+// Batch is a set of changes applied atomically, the deletes are applied
+// before the puts and deleting missing keys isn't an error. A batch is
+// limited to LookupLimit changes.
+type Batch struct {
+	Puts    Items
+	Deletes Keys
+}
+
+// Validate checks the keys and the size of the batch
+func (batch Batch) Validate() error {
+	if len(batch.Puts)+len(batch.Deletes) > LookupLimit {
+		return ErrLimitExceeded
+	}
+	for _, item := range batch.Puts {
+		if item.Key.IsZero() {
+			return ErrEmptyKey.New("")
+		}
+	}
+	for _, key := range batch.Deletes {
+		if key.IsZero() {
+			return ErrEmptyKey.New("")
+		}
+	}
+	return nil
+}
+
 // IterateOptions contains options for iterator
 type IterateOptions struct {
 	// Prefix ensure
@@ -10,6 +10,7 @@ import (
 // ListOptions are items that are optional for the LIST method
 type ListOptions struct {
 	Prefix       Key
+	NamePrefix   Key // NamePrefix limits the listing to keys starting with Prefix+NamePrefix, keys stay relative to Prefix
 	StartAfter   Key // StartAfter is relative to Prefix
 	EndBefore    Key // EndBefore is relative to Prefix
 	Recursive    bool
@@ -90,8 +91,10 @@ func ListV2(store KeyValueStore, opts ListOptions) (result Items, more bool, err
 	if reverse && !opts.EndBefore.IsZero() {
 		firstFull = joinKey(opts.Prefix, opts.EndBefore)
 	}
+	// iterating over Prefix+NamePrefix keeps the listing a range scan, while
+	// delimiters after the name prefix still group the keys
 	err = store.Iterate(IterateOptions{
-		Prefix:  opts.Prefix,
+		Prefix:  joinKey(opts.Prefix, opts.NamePrefix),
 		First:   firstFull,
 		Reverse: reverse,
 		Recurse: opts.Recursive,
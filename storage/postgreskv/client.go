@@ -41,6 +41,11 @@ func New(dbURL string) (*Client, error) {
 	}, nil
 }
 
+// DB returns the underlying database, e.g. to configure its connection pool
+func (client *Client) DB() *sql.DB {
+	return client.pgConn
+}
+
 // Put sets the value for the provided key.
 func (client *Client) Put(key storage.Key, value storage.Value) error {
 	return client.PutPath(storage.Key(defaultBucket), key, value)
@@ -110,6 +115,103 @@ func (client *Client) DeletePath(bucket, key storage.Key) error {
 	return nil
 }
 
+// CompareAndSwap atomically replaces the value of key with newValue when it is
+// oldValue, a nil oldValue expects key to be missing and a nil newValue
+// deletes key
+func (client *Client) CompareAndSwap(key storage.Key, oldValue, newValue storage.Value) error {
+	return client.CompareAndSwapPath(storage.Key(defaultBucket), key, oldValue, newValue)
+}
+
+// CompareAndSwapPath is CompareAndSwap for the key in the given bucket
+func (client *Client) CompareAndSwapPath(bucket, key storage.Key, oldValue, newValue storage.Value) error {
+	if key.IsZero() {
+		return storage.ErrEmptyKey.New("")
+	}
+
+	var q string
+	args := []interface{}{[]byte(bucket), []byte(key)}
+	switch {
+	case oldValue == nil && newValue == nil:
+		_, err := client.GetPath(bucket, key)
+		if err == nil {
+			return storage.ErrValueChanged.New("%s", key)
+		}
+		if storage.ErrKeyNotFound.Has(err) {
+			return nil
+		}
+		return err
+	case oldValue == nil:
+		q = `
+			INSERT INTO pathdata (bucket, fullpath, metadata)
+				VALUES ($1::BYTEA, $2::BYTEA, $3::BYTEA)
+				ON CONFLICT (bucket, fullpath) DO NOTHING
+		`
+		args = append(args, []byte(newValue))
+	case newValue == nil:
+		q = "DELETE FROM pathdata WHERE bucket = $1::BYTEA AND fullpath = $2::BYTEA AND metadata = $3::BYTEA"
+		args = append(args, []byte(oldValue))
+	default:
+		q = `
+			UPDATE pathdata SET metadata = $4::BYTEA
+				WHERE bucket = $1::BYTEA AND fullpath = $2::BYTEA AND metadata = $3::BYTEA
+		`
+		args = append(args, []byte(oldValue), []byte(newValue))
+	}
+
+	result, err := client.pgConn.Exec(q, args...)
+	if err != nil {
+		return err
+	}
+	numRows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if numRows == 0 {
+		return storage.ErrValueChanged.New("%s", key)
+	}
+	return nil
+}
+
+// ApplyBatch applies all puts and deletes of batch in a single transaction
+func (client *Client) ApplyBatch(batch storage.Batch) error {
+	return client.ApplyBatchPath(storage.Key(defaultBucket), batch)
+}
+
+// ApplyBatchPath is ApplyBatch for the keys in the given bucket
+func (client *Client) ApplyBatchPath(bucket storage.Key, batch storage.Batch) (err error) {
+	if err := batch.Validate(); err != nil {
+		return err
+	}
+
+	tx, err := client.pgConn.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errs.Combine(err, tx.Rollback())
+		}
+	}()
+
+	if len(batch.Deletes) > 0 {
+		q := "DELETE FROM pathdata WHERE bucket = $1::BYTEA AND fullpath = ANY($2::BYTEA[])"
+		if _, err := tx.Exec(q, []byte(bucket), pq.ByteaArray(batch.Deletes.ByteSlices())); err != nil {
+			return err
+		}
+	}
+	q := `
+		INSERT INTO pathdata (bucket, fullpath, metadata)
+			VALUES ($1::BYTEA, $2::BYTEA, $3::BYTEA)
+			ON CONFLICT (bucket, fullpath) DO UPDATE SET metadata = EXCLUDED.metadata
+	`
+	for _, item := range batch.Puts {
+		if _, err := tx.Exec(q, []byte(bucket), []byte(item.Key), []byte(item.Value)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
 // List returns either a list of known keys, in order, or an error.
 func (client *Client) List(first storage.Key, limit int) (storage.Keys, error) {
 	return storage.ListKeys(client, first, limit)
@@ -99,6 +99,80 @@ func (client *Client) Put(key storage.Key, value storage.Value) error {
 	return nil
 }
 
+// compareAndSwapScript swaps the value of KEYS[1] atomically, ARGV holds
+// whether a value is expected, the expected value, whether the key is
+// deleted, the new value, the ttl in milliseconds and the database, which
+// is selected explicitly as not every server runs scripts in the database
+// of the connection
+var compareAndSwapScript = redis.NewScript(`
+	redis.call("SELECT", ARGV[6])
+	local value = redis.call("GET", KEYS[1])
+	if ARGV[1] == "1" then
+		if value ~= ARGV[2] then return 0 end
+	elseif value then
+		return 0
+	end
+
+	if ARGV[3] == "1" then
+		redis.call("DEL", KEYS[1])
+	elseif tonumber(ARGV[5]) > 0 then
+		redis.call("SET", KEYS[1], ARGV[4], "PX", ARGV[5])
+	else
+		redis.call("SET", KEYS[1], ARGV[4])
+	end
+	return 1
+`)
+
+// CompareAndSwap atomically replaces the value of key with newValue when it is
+// oldValue, a nil oldValue expects key to be missing and a nil newValue
+// deletes key
+func (client *Client) CompareAndSwap(key storage.Key, oldValue, newValue storage.Value) error {
+	if key.IsZero() {
+		return storage.ErrEmptyKey.New("")
+	}
+
+	flag := func(b bool) string {
+		if b {
+			return "1"
+		}
+		return "0"
+	}
+
+	swapped, err := compareAndSwapScript.Run(client.db, []string{key.String()},
+		flag(oldValue != nil), []byte(oldValue),
+		flag(newValue == nil), []byte(newValue),
+		int64(client.TTL/time.Millisecond), client.db.Options().DB,
+	).Int64()
+	if err != nil {
+		return Error.New("compare and swap error: %v", err)
+	}
+	if swapped == 0 {
+		return storage.ErrValueChanged.New("%s", key)
+	}
+	return nil
+}
+
+// ApplyBatch applies all puts and deletes of batch in a MULTI/EXEC transaction
+func (client *Client) ApplyBatch(batch storage.Batch) error {
+	if err := batch.Validate(); err != nil {
+		return err
+	}
+
+	_, err := client.db.TxPipelined(func(pipe redis.Pipeliner) error {
+		for _, key := range batch.Deletes {
+			pipe.Del(key.String())
+		}
+		for _, item := range batch.Puts {
+			pipe.Set(item.Key.String(), []byte(item.Value), client.TTL)
+		}
+		return nil
+	})
+	if err != nil {
+		return Error.New("batch error: %v", err)
+	}
+	return nil
+}
+
 // List returns either a list of keys for which boltdb has values or an error.
 func (client *Client) List(first storage.Key, limit int) (storage.Keys, error) {
 	return storage.ListKeys(client, first, limit)
@@ -51,6 +51,20 @@ func (store *Logger) Delete(key storage.Key) error {
 	return store.store.Delete(key)
 }
 
+// CompareAndSwap atomically replaces the value of key with newValue when it is oldValue
+func (store *Logger) CompareAndSwap(key storage.Key, oldValue, newValue storage.Value) error {
+	store.log.Debug("CompareAndSwap", zap.String("key", string(key)),
+		zap.Int("old value length", len(oldValue)), zap.Int("new value length", len(newValue)),
+		zap.Binary("truncated old value", truncate(oldValue)), zap.Binary("truncated new value", truncate(newValue)))
+	return store.store.CompareAndSwap(key, oldValue, newValue)
+}
+
+// ApplyBatch applies all puts and deletes of batch atomically
+func (store *Logger) ApplyBatch(batch storage.Batch) error {
+	store.log.Debug("ApplyBatch", zap.Any("puts", batch.Puts.GetKeys().Strings()), zap.Any("deletes", batch.Deletes.Strings()))
+	return store.store.ApplyBatch(batch)
+}
+
 // List lists all keys starting from first and upto limit items
 func (store *Logger) List(first storage.Key, limit int) (storage.Keys, error) {
 	keys, err := store.store.List(first, limit)
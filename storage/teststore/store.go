@@ -28,6 +28,8 @@ type Client struct {
 		GetAll      int
 		ReverseList int
 		Delete      int
+		CAS         int
+		ApplyBatch  int
 		Close       int
 		Iterate     int
 	}
@@ -166,6 +168,83 @@ func (store *Client) Delete(key storage.Key) error {
 	return nil
 }
 
+// CompareAndSwap atomically replaces the value of key with newValue when it is
+// oldValue, a nil oldValue expects key to be missing and a nil newValue
+// deletes key
+func (store *Client) CompareAndSwap(key storage.Key, oldValue, newValue storage.Value) error {
+	defer store.locked()()
+
+	store.version++
+	store.CallCount.CAS++
+
+	if store.forcedError() {
+		return errInternal
+	}
+
+	if key.IsZero() {
+		return storage.ErrEmptyKey.New("")
+	}
+
+	keyIndex, found := store.indexOf(key)
+	if found != (oldValue != nil) || (found && !bytes.Equal(store.Items[keyIndex].Value, oldValue)) {
+		return storage.ErrValueChanged.New("%s", key)
+	}
+
+	switch {
+	case newValue == nil && found:
+		copy(store.Items[keyIndex:], store.Items[keyIndex+1:])
+		store.Items = store.Items[:len(store.Items)-1]
+	case newValue == nil:
+	case found:
+		store.Items[keyIndex].Value = storage.CloneValue(newValue)
+	default:
+		store.Items = append(store.Items, storage.ListItem{})
+		copy(store.Items[keyIndex+1:], store.Items[keyIndex:])
+		store.Items[keyIndex] = storage.ListItem{
+			Key:   storage.CloneKey(key),
+			Value: storage.CloneValue(newValue),
+		}
+	}
+	return nil
+}
+
+// ApplyBatch applies all puts and deletes of batch atomically
+func (store *Client) ApplyBatch(batch storage.Batch) error {
+	defer store.locked()()
+
+	store.version++
+	store.CallCount.ApplyBatch++
+
+	if store.forcedError() {
+		return errInternal
+	}
+
+	if err := batch.Validate(); err != nil {
+		return err
+	}
+
+	for _, key := range batch.Deletes {
+		if keyIndex, found := store.indexOf(key); found {
+			copy(store.Items[keyIndex:], store.Items[keyIndex+1:])
+			store.Items = store.Items[:len(store.Items)-1]
+		}
+	}
+	for _, item := range batch.Puts {
+		keyIndex, found := store.indexOf(item.Key)
+		if found {
+			store.Items[keyIndex].Value = storage.CloneValue(item.Value)
+			continue
+		}
+		store.Items = append(store.Items, storage.ListItem{})
+		copy(store.Items[keyIndex+1:], store.Items[keyIndex:])
+		store.Items[keyIndex] = storage.ListItem{
+			Key:   storage.CloneKey(item.Key),
+			Value: storage.CloneValue(item.Value),
+		}
+	}
+	return nil
+}
+
 // List lists all keys starting from start and upto limit items
 func (store *Client) List(first storage.Key, limit int) (storage.Keys, error) {
 	store.mu.Lock()
@@ -354,6 +433,11 @@ func (cursor *cursor) positionLast() {
 	store := cursor.store
 	cursor.version = store.version
 	cursor.nextIndex = len(store.Items) - 1
+	if cursor.nextIndex < 0 {
+		// the store is empty
+		cursor.lastKey = nil
+		return
+	}
 	cursor.lastKey = storage.NextKey(store.Items[cursor.nextIndex].Key)
 }
 
@@ -0,0 +1,70 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testsuite
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"storj.io/storj/storage"
+)
+
+func testApplyBatch(t *testing.T, store storage.KeyValueStore) {
+	items := storage.Items{
+		newItem("batch/a", "a", false),
+		newItem("batch/b", "b", false),
+		newItem("batch/c", "c", false),
+	}
+	rand.Shuffle(len(items), items.Swap)
+	defer cleanupItems(store, items)
+
+	if err := store.ApplyBatch(storage.Batch{Puts: items}); err != nil {
+		t.Fatalf("failed to put batch: %v", err)
+	}
+	for _, item := range items {
+		value, err := store.Get(item.Key)
+		if err != nil || string(value) != string(item.Value) {
+			t.Fatalf("invalid value for %q: got %q, %v", item.Key, value, err)
+		}
+	}
+
+	err := store.ApplyBatch(storage.Batch{
+		Puts:    storage.Items{newItem("batch/a", "z", false)},
+		Deletes: storage.Keys{storage.Key("batch/a"), storage.Key("batch/b"), storage.Key("batch/missing")},
+	})
+	if err != nil {
+		t.Fatalf("failed to apply batch: %v", err)
+	}
+
+	value, err := store.Get(storage.Key("batch/a"))
+	if err != nil || string(value) != "z" {
+		t.Fatalf("expected the put to follow the delete, got %q, %v", value, err)
+	}
+	if _, err := store.Get(storage.Key("batch/b")); !storage.ErrKeyNotFound.Has(err) {
+		t.Fatalf("expected batch/b to be deleted, got %v", err)
+	}
+	if _, err := store.Get(storage.Key("batch/missing")); !storage.ErrKeyNotFound.Has(err) {
+		t.Fatalf("expected batch/missing to stay missing, got %v", err)
+	}
+
+	err = store.ApplyBatch(storage.Batch{
+		Puts: storage.Items{newItem("batch/c", "x", false), newItem("", "empty", false)},
+	})
+	if !storage.ErrEmptyKey.Has(err) {
+		t.Fatalf("expected empty key error, got %v", err)
+	}
+	value, err = store.Get(storage.Key("batch/c"))
+	if err != nil || string(value) != "c" {
+		t.Fatalf("expected a failed batch to change nothing, got %q, %v", value, err)
+	}
+
+	var tooMany storage.Keys
+	for i := 0; i <= storage.LookupLimit; i++ {
+		tooMany = append(tooMany, storage.Key("batch/"+strconv.Itoa(i)))
+	}
+	if err := store.ApplyBatch(storage.Batch{Deletes: tooMany}); err != storage.ErrLimitExceeded {
+		t.Fatalf("expected limit exceeded error, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testsuite
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"storj.io/storj/storage"
+)
+
+func testCompareAndSwap(t *testing.T, store storage.KeyValueStore) {
+	key := storage.Key("cas/key")
+	defer func() { _ = store.Delete(key) }()
+
+	expectValue := func(expected storage.Value) {
+		t.Helper()
+		value, err := store.Get(key)
+		if expected == nil {
+			if !storage.ErrKeyNotFound.Has(err) {
+				t.Fatalf("expected %q to be missing, got %v, %v", key, value, err)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("failed to get %q: %v", key, err)
+		}
+		if !bytes.Equal(value, expected) {
+			t.Fatalf("invalid value for %q = %v: got %v", key, expected, value)
+		}
+	}
+
+	expectChanged := func(err error) {
+		t.Helper()
+		if !storage.ErrValueChanged.Has(err) {
+			t.Fatalf("expected value changed error, got %v", err)
+		}
+	}
+
+	if err := store.CompareAndSwap(key, nil, storage.Value("a")); err != nil {
+		t.Fatalf("failed to create %q: %v", key, err)
+	}
+	expectValue(storage.Value("a"))
+
+	expectChanged(store.CompareAndSwap(key, nil, storage.Value("b")))
+	expectChanged(store.CompareAndSwap(key, storage.Value("b"), storage.Value("c")))
+	expectValue(storage.Value("a"))
+
+	if err := store.CompareAndSwap(key, storage.Value("a"), storage.Value("b")); err != nil {
+		t.Fatalf("failed to swap %q: %v", key, err)
+	}
+	expectValue(storage.Value("b"))
+
+	expectChanged(store.CompareAndSwap(key, storage.Value("a"), nil))
+	if err := store.CompareAndSwap(key, storage.Value("b"), nil); err != nil {
+		t.Fatalf("failed to delete %q: %v", key, err)
+	}
+	expectValue(nil)
+
+	expectChanged(store.CompareAndSwap(key, storage.Value("b"), storage.Value("c")))
+	expectValue(nil)
+
+	if err := store.CompareAndSwap(storage.Key{}, nil, storage.Value("a")); err == nil {
+		t.Fatal("swapping empty key should fail")
+	}
+
+	// only one of the concurrent swaps from the same value succeeds
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = store.CompareAndSwap(key, nil, storage.Value{byte(i)})
+		}(i)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case !storage.ErrValueChanged.Has(err):
+			t.Fatalf("concurrent swap failed: %v", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Fatalf("%d concurrent swaps succeeded, expected 1", succeeded)
+	}
+}
@@ -165,6 +165,74 @@ func testListV2(t *testing.T, store storage.KeyValueStore) {
 				newItem("song3.mp3", "", false),
 			},
 		},
+		{"name prefix",
+			storage.ListOptions{
+				Prefix:     storage.Key("music/"),
+				NamePrefix: storage.Key("a-"),
+			},
+			false, storage.Items{
+				newItem("a-song1.mp3", "", false),
+				newItem("a-song2.mp3", "", false),
+			},
+		},
+		{"name prefix groups after the name",
+			storage.ListOptions{
+				Prefix:     storage.Key("music/"),
+				NamePrefix: storage.Key("my"),
+			},
+			false, storage.Items{
+				newItem("my-album/", "", true),
+			},
+		},
+		{"name prefix without prefix recursive",
+			storage.ListOptions{
+				Recursive:  true,
+				NamePrefix: storage.Key("music/my"),
+			},
+			false, storage.Items{
+				newItem("music/my-album/song3.mp3", "", false),
+				newItem("music/my-album/song4.mp3", "", false),
+			},
+		},
+		{"name prefix start after",
+			storage.ListOptions{
+				Prefix:     storage.Key("music/"),
+				NamePrefix: storage.Key("a-"),
+				StartAfter: storage.Key("a-song1.mp3"),
+			},
+			false, storage.Items{
+				newItem("a-song2.mp3", "", false),
+			},
+		},
+		{"name prefix start before the name",
+			storage.ListOptions{
+				Prefix:     storage.Key("music/"),
+				NamePrefix: storage.Key("my"),
+				StartAfter: storage.Key("a-song1.mp3"),
+			},
+			false, storage.Items{
+				newItem("my-album/", "", true),
+			},
+		},
+		{"name prefix end before 1",
+			storage.ListOptions{
+				Prefix:     storage.Key("music/"),
+				NamePrefix: storage.Key("a-"),
+				EndBefore:  storage.Key("z-song5.mp3"),
+				Limit:      1,
+			},
+			true, storage.Items{
+				newItem("a-song2.mp3", "", false),
+			},
+		},
+		{"name prefix end before the name",
+			storage.ListOptions{
+				Prefix:     storage.Key("music/"),
+				NamePrefix: storage.Key("my"),
+				EndBefore:  storage.Key("a-song2.mp3"),
+			},
+			false, storage.Items{},
+		},
 	}
 
 	for _, test := range tests {
@@ -6,6 +6,7 @@ package storagenode
 import (
 	"context"
 	"net"
+	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
@@ -15,10 +16,12 @@ import (
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/kademlia"
 	"storj.io/storj/pkg/node"
+	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	pstore "storj.io/storj/pkg/piecestore"
 	"storj.io/storj/pkg/piecestore/psserver"
 	"storj.io/storj/pkg/piecestore/psserver/psdb"
+	"storj.io/storj/pkg/relay"
 	"storj.io/storj/pkg/server"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/storage"
@@ -39,6 +42,7 @@ type Config struct {
 	// TODO: switch to using server.Config when Identity has been removed from it
 	PublicAddress string `help:"public address to listen on" default:":7777"`
 	Kademlia      kademlia.Config
+	Relay         relay.NodeConfig
 	Storage       psserver.Config
 }
 
@@ -64,8 +68,15 @@ type Peer struct {
 	RoutingTable     *kademlia.RoutingTable
 	Kademlia         *kademlia.Kademlia
 	KademliaEndpoint *node.Server
+	KademliaRefresh  *kademlia.Refresher
+	Relay            *relay.Service
 
 	Piecestore *psserver.Server // TODO: separate into endpoint and service
+
+	Capacity *psserver.RefreshService
+	Scrubber *psserver.Scrubber
+	Trust    *psserver.Trust
+	Retainer *psserver.Retainer
 }
 
 // New creates a new Storage Node.
@@ -90,7 +101,8 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config Config) (*P
 			return nil, errs.Combine(err, peer.Close())
 		}
 
-		peer.Public.Server, err = server.NewServer(publicOptions, peer.Public.Listener, nil)
+		// operator endpoints are only served to local callers
+		peer.Public.Server, err = server.NewServer(publicOptions, peer.Public.Listener, server.LocalOnly("/inspector."))
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
 		}
@@ -109,10 +121,18 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config Config) (*P
 			Address: &pb.NodeAddress{
 				Address: config.ExternalAddress,
 			},
-			Metadata: &pb.NodeMetadata{
-				Email:  config.Operator.Email,
-				Wallet: config.Operator.Wallet,
-			},
+			Metadata: config.Operator.Metadata(),
+		}
+
+		if config.Tags != "" {
+			tags, err := overlay.ParseTags(config.Tags)
+			if err != nil {
+				return nil, errs.Combine(err, peer.Close())
+			}
+			self.Tags, err = overlay.SignTags(peer.Identity, tags, time.Now())
+			if err != nil {
+				return nil, errs.Combine(err, peer.Close())
+			}
 		}
 
 		kdb, ndb := peer.DB.RoutingTable()
@@ -121,23 +141,65 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config Config) (*P
 			return nil, errs.Combine(err, peer.Close())
 		}
 
+		bootstrapNodes, err := kademlia.GetIntroNodes(config.BootstrapAddr)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+
 		// TODO: reduce number of arguments
-		peer.Kademlia, err = kademlia.NewWith(peer.Log.Named("kademlia"), self, nil, peer.Identity, config.Alpha, peer.RoutingTable)
+		peer.Kademlia, err = kademlia.NewWith(peer.Log.Named("kademlia"), self, bootstrapNodes, peer.Identity, config.Alpha, peer.RoutingTable)
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
 		}
+		peer.Kademlia.SetBootstrapBackoff(config.BootstrapBackoff)
+		peer.Kademlia.SetLookupCache(config.LookupCacheSize, config.LookupCacheTTL)
+		peer.Kademlia.SetAddressDiscovery(config.AddressQuorum)
+		peer.RoutingTable.SetMinimumDifficulty(uint16(config.MinDifficulty))
+		peer.RoutingTable.SetBucketSize(config.BucketSize, config.ReplacementCache)
 
 		peer.KademliaEndpoint = node.NewServer(peer.Log.Named("kademlia:endpoint"), peer.Kademlia)
 		pb.RegisterNodesServer(peer.Public.Server.GRPC(), peer.KademliaEndpoint)
+		pb.RegisterKadInspectorServer(peer.Public.Server.GRPC(), kademlia.NewInspector(peer.Kademlia, peer.Identity))
+
+		peer.KademliaRefresh = kademlia.NewRefresher(peer.Log.Named("kademlia:refresh"), peer.Kademlia, config.Refresh)
+	}
+
+	{ // setup relaying for nodes behind NAT
+		peer.Relay = relay.NewService(peer.Log.Named("relay"), config.Relay, peer.RoutingTable, peer.Public.Server.GRPC())
 	}
 
 	{ // setup piecestore
 		// TODO: move this setup logic into psstore package
 		config := config.Storage
 
+		// serve frequently retrieved pieces from memory
+		peer.DB.Storage().SetCache(config.Cache.NewCache())
+
 		// TODO: psserver shouldn't need the private key
 		peer.Piecestore = psserver.New(peer.Log.Named("piecestore"), peer.DB.Storage(), peer.DB.PSDB(), config, peer.Identity.Key)
 		pb.RegisterPieceStoreRoutesServer(peer.Public.Server.GRPC(), peer.Piecestore)
+
+		// advertise free disk and bandwidth through kademlia self info
+		peer.Capacity = psserver.NewRefreshService(peer.Log.Named("piecestore:capacity"), config.KBucketRefreshInterval, peer.RoutingTable, peer.Piecestore)
+
+		// verify stored pieces and report corrupted ones to their satellites
+		reporter := psserver.NewCorruptionReporter(peer.Identity, peer.Kademlia)
+		peer.Scrubber = psserver.NewScrubber(peer.Log.Named("piecestore:scrubber"), peer.DB.Storage(), peer.DB.PSDB(), config.Scrub, reporter)
+		peer.Piecestore.Scrubber = peer.Scrubber
+
+		// accept uploads only from trusted satellites
+		peer.Trust, err = psserver.NewTrust(peer.Log.Named("piecestore:trust"), config.Trust)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+		peer.Piecestore.Trust = peer.Trust
+
+		// garbage collect pieces which satellites don't retain anymore
+		peer.Retainer, err = psserver.NewRetainer(peer.Log.Named("piecestore:retain"), peer.DB.Storage(), peer.DB.PSDB(), peer.Identity, config.Retain)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+		peer.Piecestore.Retainer = peer.Retainer
 	}
 
 	return peer, nil
@@ -158,12 +220,42 @@ func (peer *Peer) Run(ctx context.Context) error {
 		return err
 	})
 	group.Go(func() error {
-		err := peer.Kademlia.RunRefresh(ctx)
+		err := peer.Kademlia.VerifyRestored(ctx)
+		if err == context.Canceled {
+			err = nil
+		}
+		return err
+	})
+	group.Go(func() error {
+		err := peer.KademliaRefresh.Run(ctx)
 		if err == context.Canceled || err == grpc.ErrServerStopped {
 			err = nil
 		}
 		return err
 	})
+	group.Go(func() error {
+		err := peer.Relay.Run(ctx)
+		if err == context.Canceled {
+			err = nil
+		}
+		return err
+	})
+	group.Go(func() error {
+		peer.Capacity.Run(ctx)
+		return nil
+	})
+	group.Go(func() error {
+		return peer.Scrubber.Run(ctx)
+	})
+	group.Go(func() error {
+		return peer.Trust.Run(ctx)
+	})
+	group.Go(func() error {
+		return peer.Retainer.Run(ctx)
+	})
+	group.Go(func() error {
+		return peer.Piecestore.Partials.Run(ctx)
+	})
 	group.Go(func() error {
 		err := peer.Public.Server.Run(ctx)
 		if err == context.Canceled || err == grpc.ErrServerStopped {
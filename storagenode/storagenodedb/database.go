@@ -5,12 +5,15 @@ package storagenodedb
 
 import (
 	"context"
+	"path/filepath"
 
 	"github.com/zeebo/errs"
 
+	"storj.io/storj/pkg/kademlia"
 	pstore "storj.io/storj/pkg/piecestore"
 	"storj.io/storj/pkg/piecestore/psserver/psdb"
 	"storj.io/storj/storage"
+	"storj.io/storj/storage/boltdb"
 	"storj.io/storj/storage/teststore"
 	"storj.io/storj/storagenode"
 )
@@ -24,6 +27,30 @@ type DB struct {
 	kdb, ndb storage.KeyValueStore
 }
 
+// New creates a new database for storagenode which keeps its data in
+// storageDir, the kademlia routing table survives restarts
+func New(storageDir string) (*DB, error) {
+	storage := pstore.NewStorage(storageDir)
+
+	// TODO: Open shouldn't need context argument
+	psdb, err := psdb.Open(context.TODO(), storage, filepath.Join(storageDir, "piecestore.db"))
+	if err != nil {
+		return nil, err
+	}
+
+	dbs, err := boltdb.NewShared(filepath.Join(storageDir, "kademlia.db"), kademlia.KademliaBucket, kademlia.NodeBucket)
+	if err != nil {
+		return nil, errs.Combine(err, psdb.Close())
+	}
+
+	return &DB{
+		storage: storage,
+		psdb:    psdb,
+		kdb:     dbs[0],
+		ndb:     dbs[1],
+	}, nil
+}
+
 // NewInMemory creates new inmemory database for storagenode
 // TODO: still stores data on disk
 func NewInMemory(storageDir string) (*DB, error) {